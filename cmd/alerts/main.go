@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Alerts displays and changes the alert state of a dashboard, so users can snooze or acknowledge known issues.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer/notify"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	summaryPath gcs.Path
+	creds       string
+	confirm     bool
+	dashboard   string
+	tab         string
+	snooze      time.Duration
+	unsnooze    bool
+	ack         string
+	unack       bool
+	logs        logging.Options
+}
+
+func (o *options) changes() bool {
+	return o.snooze != 0 || o.unsnooze || o.ack != "" || o.unack
+}
+
+func (o *options) validate() error {
+	if o.summaryPath.String() == "" {
+		return errors.New("empty --summary-path")
+	}
+	if o.dashboard == "" {
+		return errors.New("empty --dashboard")
+	}
+	if o.changes() && o.tab == "" {
+		return errors.New("changing alert state requires --tab")
+	}
+	if o.snooze < 0 {
+		return errors.New("negative --snooze")
+	}
+	if o.snooze > 0 && o.unsnooze {
+		return errors.New("--snooze and --unsnooze are mutually exclusive")
+	}
+	if o.ack != "" && o.unack {
+		return errors.New("--ack and --unack are mutually exclusive")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.summaryPath, "summary-path", "gs://path/to/summaries, containing the alert state of each dashboard")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
+	flag.StringVar(&o.dashboard, "dashboard", "", "Display or change the alert state of this dashboard")
+	flag.StringVar(&o.tab, "tab", "", "Change the alert state of this tab")
+	flag.DurationVar(&o.snooze, "snooze", 0, "Silence notifications about --tab for this long if set")
+	flag.BoolVar(&o.unsnooze, "unsnooze", false, "Resume notifications about --tab if set")
+	flag.StringVar(&o.ack, "ack", "", "Acknowledge the current --tab alert as this user if set")
+	flag.BoolVar(&o.unack, "unack", false, "Remove any acknowledgement of the current --tab alert if set")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+
+	statePath, err := gcs.NewPath("gs://" + path.Join(opt.summaryPath.Bucket(), opt.summaryPath.Object(), notify.StatePath(opt.dashboard)))
+	if err != nil {
+		logrus.Fatalf("Bad alert state path: %v", err)
+	}
+	log := logrus.WithField("path", statePath)
+	state, gen, err := notify.ReadState(ctx, client, *statePath)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to read alert state")
+	}
+
+	switch {
+	case opt.snooze > 0:
+		err = notify.Snooze(state, opt.tab, time.Now().Add(opt.snooze))
+	case opt.unsnooze:
+		err = notify.Snooze(state, opt.tab, time.Time{})
+	}
+	if err != nil {
+		log.WithError(err).Fatal("Failed to snooze")
+	}
+	switch {
+	case opt.ack != "":
+		err = notify.Acknowledge(state, opt.tab, opt.ack)
+	case opt.unack:
+		err = notify.Acknowledge(state, opt.tab, "")
+	}
+	if err != nil {
+		log.WithError(err).Fatal("Failed to acknowledge")
+	}
+
+	fmt.Fprintln(os.Stdout, proto.MarshalTextString(state))
+	if !opt.changes() {
+		return
+	}
+	if !opt.confirm {
+		log.Info("--confirm=false (DRY-RUN): will not write to gcs")
+		return
+	}
+	if err := notify.WriteState(ctx, client, *statePath, state, gen); err != nil {
+		log.WithError(err).Fatal("Failed to write alert state")
+	}
+	log.Info("Updated alert state")
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/util/debug"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
+)
+
+type options struct {
+	config            gcs.Path // gs://path/to/config/proto
+	scopes            api.Scopes
+	reloadConfig      time.Duration
+	creds             string
+	gridPathPrefix    string
+	summaryPathPrefix string
+	triggerPrefix     string
+	watchInterval     time.Duration
+	indexInterval     time.Duration
+	cacheSize         int
+	grpcPort          int
+	httpPort          int
+	oidcIssuer        string
+	oidcAudience      string
+	accessRules       string
+	rateLimit         float64
+	rateBurst         int
+	trustedProxies    int
+	metrics           metrics.Options
+	otlpEndpoint      string
+	debugAddress      string
+	logs              logging.Options
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.grpcPort <= 0 {
+		return fmt.Errorf("--grpc-port must be positive, got %d", o.grpcPort)
+	}
+	if o.watchInterval <= 0 {
+		return fmt.Errorf("--watch-interval must be positive, got %s", o.watchInterval)
+	}
+	if o.indexInterval < 0 {
+		return fmt.Errorf("--index-interval must not be negative, got %s", o.indexInterval)
+	}
+	if o.cacheSize < 0 {
+		return fmt.Errorf("--cache-size must not be negative, got %d", o.cacheSize)
+	}
+	if o.httpPort < 0 {
+		return fmt.Errorf("--http-port must not be negative, got %d", o.httpPort)
+	}
+	if (o.oidcIssuer == "") != (o.oidcAudience == "") {
+		return errors.New("--oidc-issuer and --oidc-audience must be set together")
+	}
+	if o.accessRules != "" && o.oidcIssuer == "" {
+		return errors.New("--access-rules requires --oidc-issuer")
+	}
+	if o.triggerPrefix != "" && o.oidcIssuer == "" {
+		return errors.New("--trigger-prefix requires --oidc-issuer")
+	}
+	if o.rateLimit < 0 {
+		return fmt.Errorf("--rate-limit must not be negative, got %f", o.rateLimit)
+	}
+	if o.rateLimit > 0 && o.rateBurst < 1 {
+		return fmt.Errorf("--rate-burst must be positive, got %d", o.rateBurst)
+	}
+	if o.trustedProxies < 0 {
+		return fmt.Errorf("--trusted-proxies must not be negative, got %d", o.trustedProxies)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.Var(&o.scopes, "scope", "Also serve the config at gs://path/to/config.pb as this scope, selected by the /scopes/NAME/ path prefix or testgrid-scope gRPC header (repeatable NAME=gs://path/to/config.pb)")
+	flag.DurationVar(&o.reloadConfig, "config-reload", time.Minute, "Check the config for changes at most this often while serving (never if zero)")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
+	flag.StringVar(&o.summaryPathPrefix, "summary-path", "", "Read summaries under this GCS path.")
+	flag.StringVar(&o.triggerPrefix, "trigger-prefix", "", "Let users trigger updates and summaries by writing under this GCS path (disabled if empty)")
+	flag.DurationVar(&o.watchInterval, "watch-interval", 10*time.Second, "Check watched dashboards for changes this often")
+	flag.DurationVar(&o.indexInterval, "index-interval", 10*time.Minute, "Refresh the index of test names SearchTests reads this often (never index if zero)")
+	flag.IntVar(&o.cacheSize, "cache-size", 100, "Keep this many of the most recently read grids and summaries decoded in memory (none if zero)")
+	flag.IntVar(&o.grpcPort, "grpc-port", 9090, "Serve the gRPC API on this port")
+	flag.IntVar(&o.httpPort, "http-port", 8080, "Serve the JSON API on this port (never if zero)")
+	flag.StringVar(&o.oidcIssuer, "oidc-issuer", "", "Authenticate users with ID tokens from this OpenID Connect issuer URL")
+	flag.StringVar(&o.oidcAudience, "oidc-audience", "", "Require ID tokens issued for this audience")
+	flag.StringVar(&o.accessRules, "access-rules", "", "/path/to/rules.yaml restricting dashboard groups to some users")
+	flag.Float64Var(&o.rateLimit, "rate-limit", 0, "Allow each user or client address this many requests per second (unlimited if zero)")
+	flag.IntVar(&o.rateBurst, "rate-burst", 20, "Allow bursts of up to this many requests above --rate-limit")
+	flag.IntVar(&o.trustedProxies, "trusted-proxies", 0, "Identify anonymous clients by their X-Forwarded-For address, when behind this many proxies")
+	o.metrics.AddFlags(flag.CommandLine)
+	flag.StringVar(&o.otlpEndpoint, "otlp-endpoint", "", "Export OpenTelemetry traces to the OTLP gRPC collector at this host:port, if set")
+	flag.StringVar(&o.debugAddress, "debug-address", "", "Serve pprof profiles at /debug/pprof/ and expvar variables at /debug/vars on this address, such as localhost:6060, if set")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := debug.Serve(opt.debugAddress); err != nil {
+		logrus.Fatalf("Failed to serve --debug-address: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shutdown, err := tracing.Setup(ctx, "api", opt.otlpEndpoint)
+	if err != nil {
+		logrus.Fatalf("Failed to export --otlp-endpoint traces: %v", err)
+	}
+	defer shutdown()
+
+	stopMetrics, err := opt.metrics.Setup(ctx)
+	if err != nil {
+		logrus.Fatalf("Failed to report metrics: %v", err)
+	}
+	defer stopMetrics()
+
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+
+	var rules []api.AccessRule
+	if opt.accessRules != "" {
+		rules, err = api.LoadAccessRules(opt.accessRules)
+		if err != nil {
+			logrus.Fatalf("Failed to load --access-rules=%s: %v", opt.accessRules, err)
+		}
+	}
+	newService := func(configPath gcs.Path) apipb.TestGridDataServer {
+		server, err := api.NewServer(ctx, client, configPath, opt.reloadConfig, opt.gridPathPrefix, opt.summaryPathPrefix, opt.triggerPrefix, opt.watchInterval, opt.cacheSize)
+		if err != nil {
+			logrus.WithField("config", configPath).Fatalf("Failed to create server: %v", err)
+		}
+		if opt.indexInterval > 0 {
+			go server.IndexTests(ctx, opt.indexInterval)
+		}
+		if opt.accessRules != "" {
+			return api.Authorize(server, rules)
+		}
+		return server
+	}
+	svc := newService(opt.config)
+	scoped := make(map[string]apipb.TestGridDataServer, len(opt.scopes))
+	for name, configPath := range opt.scopes {
+		scoped[name] = newService(configPath)
+	}
+	// Label the tab metrics of each scope, leaving the label empty for the default config.
+	registerTabs := func(scope string, s apipb.TestGridDataServer) {
+		prometheus.WrapRegistererWith(prometheus.Labels{"scope": scope}, prometheus.DefaultRegisterer).MustRegister(api.NewTabCollector(s))
+	}
+	registerTabs("", svc)
+	for name, s := range scoped {
+		registerTabs(name, s)
+	}
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	var authenticator *api.Authenticator
+	if opt.oidcIssuer != "" {
+		authenticator, err = api.NewAuthenticator(ctx, http.DefaultClient, opt.oidcIssuer, opt.oidcAudience)
+		if err != nil {
+			logrus.Fatalf("Failed to create authenticator: %v", err)
+		}
+		unary = append(unary, authenticator.UnaryInterceptor())
+		stream = append(stream, authenticator.StreamInterceptor())
+	}
+	var limiter *api.RateLimiter
+	if opt.rateLimit > 0 {
+		limiter, err = api.NewRateLimiter(opt.rateLimit, opt.rateBurst, opt.trustedProxies)
+		if err != nil {
+			logrus.Fatalf("Failed to create rate limiter: %v", err)
+		}
+		// Limit after authenticating, to identify users by their claims.
+		unary = append(unary, limiter.UnaryInterceptor())
+		stream = append(stream, limiter.StreamInterceptor())
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", opt.grpcPort))
+	if err != nil {
+		logrus.Fatalf("Failed to listen on --grpc-port=%d: %v", opt.grpcPort, err)
+	}
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	apipb.RegisterTestGridDataServer(grpcServer, api.Scoped(svc, scoped))
+
+	if opt.httpPort > 0 {
+		handlers := make(map[string]http.Handler, len(scoped))
+		for name, s := range scoped {
+			handlers[name] = newMux(s)
+		}
+		mux := http.NewServeMux()
+		mux.Handle(metrics.Path, promhttp.Handler())
+		mux.Handle("/", api.ScopedHandler(newMux(svc), handlers))
+		var handler http.Handler = mux
+		if limiter != nil {
+			handler = limiter.Middleware(handler)
+		}
+		if authenticator != nil {
+			handler = authenticator.Middleware(handler)
+		}
+		go func() {
+			logrus.WithField("port", opt.httpPort).Info("Serving JSON API")
+			if err := http.ListenAndServe(fmt.Sprintf(":%d", opt.httpPort), handler); err != nil {
+				logrus.Fatalf("Failed to serve JSON API: %v", err)
+			}
+		}()
+	}
+	logrus.WithField("port", opt.grpcPort).Info("Serving gRPC API")
+	if err := grpcServer.Serve(lis); err != nil {
+		logrus.Fatalf("Failed to serve gRPC API: %v", err)
+	}
+}
+
+// newMux serves the JSON API and badges of the server.
+func newMux(svc apipb.TestGridDataServer) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(api.PathPrefix+"/", api.Handler(svc))
+	mux.Handle(api.BadgePrefix, api.BadgeHandler(svc))
+	return mux
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api/client"
+)
+
+type options struct {
+	openAPI    string
+	goClient   string
+	typeScript string
+}
+
+func (o *options) validate() error {
+	if o.openAPI == "" && o.goClient == "" && o.typeScript == "" {
+		return errors.New("set at least one of --openapi, --go-client or --typescript")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.openAPI, "openapi", "", "Write the OpenAPI document to this path if set")
+	flag.StringVar(&o.goClient, "go-client", "", "Write the methods of the Go client to this path if set")
+	flag.StringVar(&o.typeScript, "typescript", "", "Write the TypeScript client to this path if set")
+	flag.Parse()
+	return o
+}
+
+func main() {
+	log := logrus.WithField("component", "api-gen")
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		log.WithError(err).Fatal("Invalid flags")
+	}
+
+	for _, out := range []struct {
+		flag     string
+		path     string
+		generate func() ([]byte, error)
+	}{
+		{"--openapi", opt.openAPI, api.OpenAPI},
+		{"--go-client", opt.goClient, client.GoMethods},
+		{"--typescript", opt.typeScript, client.TypeScript},
+	} {
+		if out.path == "" {
+			continue
+		}
+		log := log.WithField(out.flag, out.path)
+		buf, err := out.generate()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to generate")
+		}
+		if err := ioutil.WriteFile(out.path, buf, 0644); err != nil {
+			log.WithError(err).Fatal("Failed to write")
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Backfill rebuilds the grid of a test group from its historical builds, such as after losing its state.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+// dateFormat is the format of --start and --end, in addition to RFC 3339.
+const dateFormat = "2006-01-02"
+
+type options struct {
+	config           gcs.Path
+	creds            string
+	confirm          bool
+	group            string
+	gridPrefix       string
+	progressPrefix   string
+	start            string
+	end              string
+	batch            int
+	pause            time.Duration
+	buildConcurrency int
+	buildTimeout     time.Duration
+	kmsKeys          gcs.KMSKeys
+	logs             logging.Options
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.group == "" {
+		return errors.New("empty --test-group")
+	}
+	if o.start == "" {
+		return errors.New("empty --start")
+	}
+	if o.batch < 1 {
+		return fmt.Errorf("--batch must be positive, got %d", o.batch)
+	}
+	if o.buildConcurrency < 1 {
+		return fmt.Errorf("--build-concurrency must be positive, got %d", o.buildConcurrency)
+	}
+	if o.gridPrefix == o.progressPrefix {
+		return errors.New("--progress-prefix must differ from --grid-prefix")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload progress and the grid if set")
+	flag.StringVar(&o.group, "test-group", "", "Rebuild the grid of this test group")
+	flag.StringVar(&o.gridPrefix, "grid-prefix", "grid", "Join this with the group name to create the GCS suffix of the grid")
+	flag.StringVar(&o.progressPrefix, "progress-prefix", "backfill", "Join this with the group name to create the GCS suffix of the progress, resuming from it when present")
+	flag.StringVar(&o.start, "start", "", "Include builds started at or after this YYYY-MM-DD date or RFC 3339 time")
+	flag.StringVar(&o.end, "end", "", "Include builds started before this YYYY-MM-DD date or RFC 3339 time (now if empty)")
+	flag.IntVar(&o.batch, "batch", 20, "Read this many builds between saving progress")
+	flag.DurationVar(&o.pause, "pause", 10*time.Second, "Wait this long between batches to limit the load on the bucket")
+	flag.IntVar(&o.buildConcurrency, "build-concurrency", 4, "Concurrently read this many builds of each batch")
+	flag.DurationVar(&o.buildTimeout, "build-timeout", 3*time.Minute, "Maximum time to wait to read each build")
+	flag.Var(&o.kmsKeys, "kms-key", "Encrypt objects written to a bucket with a Cloud KMS key when set as bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k (repeatable)")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+// parseTime parses a YYYY-MM-DD date in UTC or an RFC 3339 time.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(dateFormat, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	start, err := parseTime(opt.start)
+	if err != nil {
+		logrus.Fatalf("Invalid --start: %v", err)
+	}
+	end := time.Now()
+	if opt.end != "" {
+		if end, err = parseTime(opt.end); err != nil {
+			logrus.Fatalf("Invalid --end: %v", err)
+		}
+	}
+	if !opt.confirm {
+		logrus.Warning("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to create storage client: %v", err)
+	}
+	defer storageClient.Close()
+	client := gcs.NewClientWithKeys(storageClient, opt.kmsKeys)
+
+	log := logrus.WithFields(logrus.Fields{
+		"group": opt.group,
+		"start": start,
+		"end":   end,
+	})
+	log.Info("Backfilling")
+	if err := updater.Backfill(ctx, client, opt.config, opt.gridPrefix, opt.progressPrefix, opt.group, start, end, opt.batch, opt.pause, opt.buildTimeout, opt.buildConcurrency, opt.confirm); err != nil {
+		log.WithError(err).Fatal("Failed to backfill")
+	}
+	log.Info("Backfilled")
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Bucket migrate copies configs, grids and summaries to a new bucket or prefix, for planned bucket moves.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/migrate"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	from        gcs.Path
+	to          gcs.Path
+	config      string
+	prefixes    string
+	concurrency int
+	creds       string
+	confirm     bool
+	kmsKeys     gcs.KMSKeys
+	logs        logging.Options
+}
+
+func (o *options) validate() error {
+	switch {
+	case o.from.String() == "":
+		return errors.New("empty --from")
+	case o.to.String() == "":
+		return errors.New("empty --to")
+	case o.from.String() == o.to.String():
+		return errors.New("--from and --to must differ")
+	case o.concurrency < 1:
+		return fmt.Errorf("--concurrency must be positive, got %d", o.concurrency)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.from, "from", "Copy objects under this gs://bucket/prefix")
+	flag.Var(&o.to, "to", "Copy objects to this gs://bucket/prefix")
+	flag.StringVar(&o.config, "config", "config", "Copy the config proto at this path under --from, rewriting paths under --from (skip if empty)")
+	flag.StringVar(&o.prefixes, "prefixes", "grid,tabs,summary", "Comma-separated paths under --from to copy")
+	flag.IntVar(&o.concurrency, "concurrency", 10, "Copy this many objects in parallel")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Copy objects if set")
+	flag.Var(&o.kmsKeys, "kms-key", "Encrypt objects written to a bucket with a Cloud KMS key when set as bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k (repeatable)")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Warning("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to create storage client")
+	}
+	client := gcs.NewClientWithKeys(storageClient, opt.kmsKeys)
+
+	log := logrus.WithFields(logrus.Fields{"from": opt.from, "to": opt.to})
+	var prefixes []string
+	for _, p := range strings.Split(opt.prefixes, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	copied, err := migrate.Objects(ctx, log, client, opt.from, opt.to, prefixes, opt.concurrency, opt.confirm)
+	log = log.WithField("objects", copied)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to copy objects")
+	}
+	log.Info("Copied objects")
+
+	// Copy the config last, so it only points at objects that already exist.
+	if opt.config == "" {
+		return
+	}
+	rewrote, err := migrate.Config(ctx, client, opt.from, opt.to, opt.config, opt.confirm)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to copy config")
+	}
+	log.WithField("rewrote", rewrote).Info("Copied config")
+}
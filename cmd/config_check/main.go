@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Config check validates and lints configs for CI, exiting with a distinct code for errors and warnings.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/config/lint"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+// Exit codes when a finding is an error, or else a warning.
+//
+// Invalid flags exit 1.
+const (
+	exitErrors   = 2
+	exitWarnings = 3
+)
+
+// readRule names findings for configs that cannot be read.
+const readRule = "read"
+
+type options struct {
+	defaults string
+	creds    string
+	format   string
+	disable  string
+	paths    []string
+	logs     logging.Options
+}
+
+func (o *options) validate() error {
+	if len(o.paths) == 0 {
+		return errors.New("specify at least one YAML or proto path")
+	}
+	switch o.format {
+	case "text", "json", "github":
+	default:
+		return fmt.Errorf("--format must be text, json or github, got %q", o.format)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.defaults, "defaults", "", "Path to the default.yaml applied to YAML paths")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.format, "format", "text", "Print findings as text, json or github workflow commands")
+	flag.StringVar(&o.disable, "disable", "", "Comma-separated lint rules to skip")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	o.paths = flag.Args()
+	return o
+}
+
+// finding is a lint finding along with where the config defines its entity.
+type finding struct {
+	lint.Finding
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+func (f finding) String() string {
+	switch {
+	case f.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", f.File, f.Line, f.Finding)
+	case f.File != "":
+		return fmt.Sprintf("%s: %s", f.File, f.Finding)
+	}
+	return f.Finding.String()
+}
+
+// githubLevels maps severities to GitHub workflow command annotations.
+var githubLevels = map[lint.Severity]string{
+	lint.Error:   "error",
+	lint.Warning: "warning",
+	lint.Info:    "notice",
+}
+
+// github returns the finding as a GitHub workflow command, which annotates the line in pull requests.
+func (f finding) github() string {
+	props := []string{"title=" + escapeProperty(f.Rule)}
+	if f.File != "" {
+		props = append(props, "file="+escapeProperty(f.File))
+	}
+	if f.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", f.Line))
+	}
+	msg := fmt.Sprintf("%s %s: %s", f.Entity, f.Name, f.Message)
+	return fmt.Sprintf("::%s %s::%s", githubLevels[f.Severity], strings.Join(props, ","), escapeData(msg))
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// check validates and lints the config, locating findings with locs when set or else at file.
+func check(cfg *configpb.Configuration, rules []lint.Rule, file string, locs map[string]map[string]yamlcfg.Location) []finding {
+	findings := append(lint.Validation(cfg), lint.Lint(cfg, rules...)...)
+	out := make([]finding, 0, len(findings))
+	for _, f := range findings {
+		loc, ok := locs[f.Entity][f.Name]
+		if !ok {
+			loc.Path = file
+		}
+		out = append(out, finding{Finding: f, File: loc.Path, Line: loc.Line})
+	}
+	return out
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	rules := lint.Without(lint.Rules(), strings.Split(opt.disable, ",")...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var storageClient *storage.Client
+	newClient := func() (*storage.Client, error) {
+		if storageClient != nil {
+			return storageClient, nil
+		}
+		var err error
+		storageClient, err = gcs.ClientWithCreds(ctx, opt.creds)
+		return storageClient, err
+	}
+
+	var findings []finding
+	var yamls []string
+	for _, path := range opt.paths {
+		if !config.IsProto(path) {
+			yamls = append(yamls, path)
+			continue
+		}
+		cfg, err := yamlcfg.ReadPaths(ctx, []string{path}, "", newClient)
+		if err != nil {
+			findings = append(findings, readFinding(path, err))
+			continue
+		}
+		findings = append(findings, check(cfg, rules, path, nil)...)
+	}
+	if len(yamls) > 0 {
+		file := strings.Join(yamls, ",")
+		cfg, err := yamlcfg.ReadPaths(ctx, yamls, opt.defaults, newClient)
+		if err != nil {
+			findings = append(findings, readFinding(file, err))
+		} else {
+			locs, err := yamlcfg.Locate(yamls)
+			if err != nil {
+				logrus.WithError(err).Warning("Failed to locate entities")
+			}
+			findings = append(findings, check(cfg, rules, file, locs)...)
+		}
+	}
+
+	if err := write(os.Stdout, opt.format, findings); err != nil {
+		logrus.WithError(err).Fatal("Failed to write findings")
+	}
+	os.Exit(exitCode(findings))
+}
+
+// readFinding returns an error finding for a config at path that cannot be read.
+func readFinding(path string, err error) finding {
+	return finding{
+		Finding: lint.Finding{
+			Rule:     readRule,
+			Severity: lint.Error,
+			Entity:   "Configuration",
+			Name:     path,
+			Message:  err.Error(),
+		},
+		File: path,
+	}
+}
+
+// write prints the findings in the format.
+func write(w io.Writer, format string, findings []finding) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if findings == nil {
+			findings = []finding{}
+		}
+		return enc.Encode(findings)
+	case "github":
+		for _, f := range findings {
+			if _, err := fmt.Fprintln(w, f.github()); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, f := range findings {
+			if _, err := fmt.Fprintln(w, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// exitCode returns exitErrors when any finding is an error, exitWarnings for a warning, or else zero.
+func exitCode(findings []finding) int {
+	code := 0
+	for _, f := range findings {
+		switch f.Severity {
+		case lint.Error:
+			return exitErrors
+		case lint.Warning:
+			code = exitWarnings
+		}
+	}
+	return code
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Config convert converts YAML config files to the config proto, or a config proto back to YAML.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	config   string
+	defaults string
+	creds    string
+	comments string
+	output   string
+	text     bool
+	yamls    []string
+	logs     logging.Options
+}
+
+func (o *options) validate() error {
+	if (o.config == "") == (len(o.yamls) == 0) {
+		return errors.New("specify either --config or YAML paths")
+	}
+	if o.config != "" && o.text {
+		return errors.New("--text only applies to YAML paths")
+	}
+	if o.config == "" && o.comments != "" {
+		return errors.New("--comments only applies to --config")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.config, "config", "", "Convert this local or gs://path/to/config.pb to YAML instead of converting YAML paths to a proto")
+	flag.StringVar(&o.defaults, "defaults", "", "Path to the default.yaml applied to YAML paths")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.comments, "comments", "", "Keep the comments of these comma-separated YAML files when converting --config")
+	flag.StringVar(&o.output, "output", "", "Write to this path instead of stdout")
+	flag.BoolVar(&o.text, "text", false, "Write the text format of the proto instead of the wire format")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	o.yamls = flag.Args()
+	return o
+}
+
+// toYAML reads the config proto and returns it as YAML, with the comments of each file in comments.
+func toYAML(ctx context.Context, opt options) ([]byte, error) {
+	var client *storage.Client
+	if strings.HasPrefix(opt.config, "gs://") {
+		var err error
+		if client, err = gcs.ClientWithCreds(ctx, opt.creds); err != nil {
+			return nil, fmt.Errorf("storage client: %w", err)
+		}
+	}
+	cfg, err := config.Read(opt.config, ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	out, err := yamlcfg.MarshalYAML(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	if opt.comments == "" {
+		return out, nil
+	}
+	for _, path := range strings.Split(opt.comments, ",") {
+		original, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read comments: %w", err)
+		}
+		if out, err = yamlcfg.KeepComments(out, original); err != nil {
+			return nil, fmt.Errorf("keep comments of %s: %w", path, err)
+		}
+	}
+	return out, nil
+}
+
+// toProto reads the YAML paths and returns the config they configure as a proto.
+func toProto(opt options) ([]byte, error) {
+	cfg, err := yamlcfg.ReadConfig(opt.yamls, opt.defaults)
+	if err != nil {
+		return nil, fmt.Errorf("read yaml: %w", err)
+	}
+	if !opt.text {
+		return config.MarshalBytes(&cfg)
+	}
+	var buf bytes.Buffer
+	if err := config.MarshalText(&cfg, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var out []byte
+	var err error
+	if opt.config != "" {
+		out, err = toYAML(ctx, opt)
+	} else {
+		out, err = toProto(opt)
+	}
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to convert config")
+	}
+	if opt.output == "" {
+		if _, err := os.Stdout.Write(out); err != nil {
+			logrus.WithError(err).Fatal("Failed to write config")
+		}
+		return
+	}
+	if err := ioutil.WriteFile(opt.output, out, 0644); err != nil {
+		logrus.WithError(err).WithField("--output", opt.output).Fatal("Failed to write config")
+	}
+}
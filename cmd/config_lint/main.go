@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config/lint"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	config   string
+	defaults string
+	creds    string
+	format   string
+	failOn   string
+	disable  string
+	list     bool
+	yamls    []string
+	logs     logging.Options
+}
+
+func (o *options) validate() error {
+	if o.list {
+		return nil
+	}
+	if (o.config == "") == (len(o.yamls) == 0) {
+		return errors.New("specify either --config or YAML paths")
+	}
+	if o.format != "text" && o.format != "json" {
+		return fmt.Errorf("--format must be text or json, got %q", o.format)
+	}
+	if _, err := lint.ParseSeverity(o.failOn); err != nil {
+		return fmt.Errorf("--fail-on: %w", err)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.config, "config", "", "Lint this local or gs://path/to/config.pb instead of YAML paths")
+	flag.StringVar(&o.defaults, "defaults", "", "Path to the default.yaml applied to YAML paths")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.format, "format", "text", "Print findings as text or json")
+	flag.StringVar(&o.failOn, "fail-on", string(lint.Error), "Exit non-zero when a finding is at least this severe (info, warning, error)")
+	flag.StringVar(&o.disable, "disable", "", "Comma-separated rules to skip")
+	flag.BoolVar(&o.list, "list-rules", false, "List the rules and exit")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	o.yamls = flag.Args()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	rules := lint.Without(lint.Rules(), strings.Split(opt.disable, ",")...)
+	if opt.list {
+		for _, r := range rules {
+			fmt.Printf("%s (%s): %s\n", r.Name, r.Severity, r.Description)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	paths := opt.yamls
+	if opt.config != "" {
+		paths = []string{opt.config}
+	}
+	newClient := func() (*storage.Client, error) {
+		return gcs.ClientWithCreds(ctx, opt.creds)
+	}
+	cfg, err := yamlcfg.ReadPaths(ctx, paths, opt.defaults, newClient)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to read config")
+	}
+
+	findings := lint.Lint(cfg, rules...)
+	if opt.format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if findings == nil {
+			findings = []lint.Finding{}
+		}
+		if err := enc.Encode(findings); err != nil {
+			logrus.WithError(err).Fatal("Failed to write findings")
+		}
+	} else {
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+	}
+
+	failOn, _ := lint.ParseSeverity(opt.failOn)
+	for _, f := range findings {
+		if f.Severity.AtLeast(failOn) {
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// diffMain prints the changes between two configs: config_merger diff [flags] OLD NEW
+func diffMain(args []string) {
+	log := logrus.WithField("component", "config-merger-diff")
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	creds := fs.String("gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	format := fs.String("format", "text", "Print changes as text or json")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: config_merger diff [flags] OLD NEW")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("--format must be text or json, got %q", *format)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var client *storage.Client
+	if strings.HasPrefix(fs.Arg(0), "gs://") || strings.HasPrefix(fs.Arg(1), "gs://") {
+		var err error
+		if client, err = gcs.ClientWithCreds(ctx, *creds); err != nil {
+			log.WithError(err).Fatal("Can't make storage client")
+		}
+	}
+	oldCfg, err := config.Read(fs.Arg(0), ctx, client)
+	if err != nil {
+		log.WithError(err).WithField("old", fs.Arg(0)).Fatal("Can't read old config")
+	}
+	newCfg, err := config.Read(fs.Arg(1), ctx, client)
+	if err != nil {
+		log.WithError(err).WithField("new", fs.Arg(1)).Fatal("Can't read new config")
+	}
+
+	changes := config.Diff(oldCfg, newCfg)
+	if *format == "json" {
+		if changes == nil {
+			changes = []config.Change{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(changes); err != nil {
+			log.WithError(err).Fatal("Can't write changes")
+		}
+		return
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+}
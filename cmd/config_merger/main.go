@@ -20,20 +20,34 @@ import (
 	"context"
 	"flag"
 	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/GoogleCloudPlatform/testgrid/pkg/merger"
+	"github.com/GoogleCloudPlatform/testgrid/util/debug"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/health"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 
 	"github.com/sirupsen/logrus"
 )
 
 type options struct {
-	listPath     string
-	creds        string
-	confirm      bool
-	wait         time.Duration
-	skipValidate bool
+	listPath       string
+	creds          string
+	confirm        bool
+	wait           time.Duration
+	skipValidate   bool
+	mirror         gcs.Path
+	kmsKeys        gcs.KMSKeys
+	metrics        metrics.Options
+	otlpEndpoint   string
+	debugAddress   string
+	healthAddress  string
+	healthMultiple float64
+	logs           logging.Options
 }
 
 func (o *options) validate(log logrus.FieldLogger) {
@@ -55,15 +69,30 @@ func gatherOptions() options {
 	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
 	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time ahs passed since the last loop. (Run only once if zero)")
 	flag.BoolVar(&o.skipValidate, "allow-invalid-configs", false, "Allows merging of configs that don't validate. Usually skips invalid configs")
+	flag.Var(&o.mirror, "mirror", "Asynchronously mirror writes under gs://bucket/prefix if set")
+	flag.Var(&o.kmsKeys, "kms-key", "Encrypt objects written to a bucket with a Cloud KMS key when set as bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k (repeatable)")
+	o.metrics.AddFlags(flag.CommandLine)
+	flag.StringVar(&o.otlpEndpoint, "otlp-endpoint", "", "Export OpenTelemetry traces to the OTLP gRPC collector at this host:port, if set")
+	flag.StringVar(&o.debugAddress, "debug-address", "", "Serve pprof profiles at /debug/pprof/ and expvar variables at /debug/vars on this address, such as localhost:6060, if set")
+	flag.StringVar(&o.healthAddress, "health-address", "", "Serve liveness at /healthz and readiness at /readyz on this address, such as :8081, if set")
+	flag.Float64Var(&o.healthMultiple, "health-wait-multiple", 3, "Report unhealthy when no cycle succeeds within this many --wait periods (never if zero)")
+	o.logs.AddFlags(flag.CommandLine)
 	flag.Parse()
 	return o
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		diffMain(os.Args[2:])
+		return
+	}
 	log := logrus.WithField("component", "config-merger")
 
 	opt := gatherOptions()
 	opt.validate(log)
+	if err := opt.logs.Configure(); err != nil {
+		log.WithError(err).Fatal("Invalid flags")
+	}
 	file, err := ioutil.ReadFile(opt.listPath)
 	if err != nil {
 		log.WithField("--config-list", opt.listPath).WithError(err).Fatalf("Can't find --config-list")
@@ -74,19 +103,55 @@ func main() {
 		log.WithField("--config-list", opt.listPath).WithError(err).Fatal("Can't parse --config-list")
 	}
 
+	if err := debug.Serve(opt.debugAddress); err != nil {
+		log.WithError(err).Fatal("Can't serve --debug-address")
+	}
+	checker := health.NewChecker(time.Duration(opt.healthMultiple * float64(opt.wait)))
+	if err := health.Serve(opt.healthAddress, checker); err != nil {
+		log.WithError(err).Fatal("Can't serve --health-address")
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+
+	shutdown, err := tracing.Setup(ctx, "config-merger", opt.otlpEndpoint)
+	if err != nil {
+		log.WithError(err).Fatal("Can't export --otlp-endpoint traces")
+	}
+	defer shutdown()
+
+	stopMetrics, err := opt.metrics.Setup(ctx)
+	if err != nil {
+		log.WithError(err).Fatal("Can't report metrics")
+	}
+	defer stopMetrics()
+
 	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
 	if err != nil {
 		log.WithError(err).Fatalf("Can't make storage client")
 	}
 
-	client := gcs.NewClient(storageClient)
+	var client gcs.ConditionalClient = gcs.NewPathClient(gcs.NewClientWithKeys(storageClient, opt.kmsKeys))
+	var mirror gcs.MirrorClient
+	if opt.mirror.String() != "" {
+		mirror = gcs.NewMirrorClient(client, client, gcs.MirrorPath(opt.mirror), 1, time.Minute)
+		client = mirror
+	}
 
 	updateOnce := func(ctx context.Context) error {
+		start := time.Now()
 		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 		defer cancel()
-		return merger.MergeAndUpdate(ctx, client, list, opt.skipValidate, opt.confirm)
+		ctx, span := tracing.Start(ctx, "config_merger.cycle")
+		err := merger.MergeAndUpdate(ctx, client, list, opt.skipValidate, opt.confirm)
+		if mirror != nil {
+			mirror.Wait()
+			log.WithField("mirror", mirror.Stats()).Info("Mirrored writes")
+		}
+		tracing.End(span, err)
+		metrics.Cycle("config-merger", start, err)
+		checker.Cycle(err)
+		return err
 	}
 
 	if err := updateOnce(ctx); err != nil {
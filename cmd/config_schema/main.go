@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+)
+
+type options struct {
+	output string
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.output, "output", "", "Write the schema to this path instead of stdout")
+	flag.Parse()
+	return o
+}
+
+func main() {
+	log := logrus.WithField("component", "config-schema")
+	opt := gatherOptions()
+
+	schema, err := yamlcfg.JSONSchema()
+	if err != nil {
+		log.WithError(err).Fatal("Failed to generate schema")
+	}
+	if opt.output == "" {
+		if _, err := os.Stdout.Write(schema); err != nil {
+			log.WithError(err).Fatal("Failed to write schema")
+		}
+		return
+	}
+	if err := ioutil.WriteFile(opt.output, schema, 0644); err != nil {
+		log.WithError(err).WithField("--output", opt.output).Fatal("Failed to write schema")
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/configurator"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	prowJobs           string
+	yamls              string
+	defaults           string
+	rules              string
+	bucket             string
+	output             string
+	creds              string
+	confirm            bool
+	daysOfResults      int
+	numColumnsRecent   int
+	numFailuresToAlert int
+	logs               logging.Options
+}
+
+func (o *options) validate() error {
+	if o.prowJobs == "" {
+		return errors.New("--prow-jobs required")
+	}
+	if o.output == "" {
+		return errors.New("--output required")
+	}
+	if o.bucket == "" {
+		return errors.New("--bucket required")
+	}
+	if !o.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not write the shard")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	defaults := configurator.DefaultOptions()
+	flag.StringVar(&o.prowJobs, "prow-jobs", "", "Comma-separated files or directories of Prow job configs")
+	flag.StringVar(&o.yamls, "yaml", "", "Comma-separated YAML configs to update, if any")
+	flag.StringVar(&o.defaults, "defaults", "", "Path to the default.yaml applied to --yaml configs")
+	flag.StringVar(&o.rules, "rules", "", "YAML list of {match: regex, dashboards: [...]} rules for jobs without testgrid-dashboards")
+	flag.StringVar(&o.bucket, "bucket", "", "Bucket holding the results of jobs that do not set decoration_config.gcs_configuration.bucket")
+	flag.StringVar(&o.output, "output", "", "Write the generated shard to this local or gs://path")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Write the shard if set")
+	flag.IntVar(&o.daysOfResults, "days-of-results", int(defaults.DaysOfResults), "Days of results for jobs without the testgrid-days-of-results annotation")
+	flag.IntVar(&o.numColumnsRecent, "num-columns-recent", int(defaults.NumColumnsRecent), "Recent columns for jobs without the testgrid-num-columns-recent annotation")
+	flag.IntVar(&o.numFailuresToAlert, "num-failures-to-alert", int(defaults.NumFailuresToAlert), "Consecutive failures to alert after for jobs without the testgrid-num-failures-to-alert annotation")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func readRules(path string) ([]configurator.Rule, error) {
+	if path == "" {
+		return nil, nil
+	}
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []configurator.Rule
+	if err := yaml.Unmarshal(buf, &rules); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return rules, nil
+}
+
+func write(ctx context.Context, path, creds string, buf []byte) error {
+	if !strings.HasPrefix(path, "gs://") {
+		return ioutil.WriteFile(path, buf, 0644)
+	}
+	gcsPath, err := gcs.NewPath(path)
+	if err != nil {
+		return fmt.Errorf("bad path: %w", err)
+	}
+	client, err := gcs.ClientWithCreds(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("storage client: %w", err)
+	}
+	return gcs.NewClient(client).Upload(ctx, *gcsPath, buf, gcs.DefaultAcl, "no-cache")
+}
+
+func main() {
+	log := logrus.WithField("component", "configurator")
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+
+	jobs, err := configurator.ReadJobs(split(opt.prowJobs)...)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to read Prow jobs")
+	}
+	rules, err := readRules(opt.rules)
+	if err != nil {
+		log.WithError(err).WithField("--rules", opt.rules).Fatal("Failed to read rules")
+	}
+	var base *configpb.Configuration
+	if paths := split(opt.yamls); len(paths) > 0 {
+		cfg, err := yamlcfg.ReadConfig(paths, opt.defaults)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to read --yaml configs")
+		}
+		base = &cfg
+	}
+
+	genOpts := configurator.Options{
+		Bucket:             opt.bucket,
+		Rules:              rules,
+		DaysOfResults:      int32(opt.daysOfResults),
+		NumColumnsRecent:   int32(opt.numColumnsRecent),
+		NumFailuresToAlert: int32(opt.numFailuresToAlert),
+	}
+	cfg, err := configurator.Generate(base, jobs, genOpts)
+	if err != nil {
+		log.WithError(err).Warning("Skipped some jobs")
+	}
+	if cfg == nil {
+		log.Fatal("Failed to generate config")
+	}
+	buf, err := config.MarshalBytes(cfg)
+	if err != nil {
+		log.WithError(err).Fatal("Generated an invalid config")
+	}
+	log = log.WithFields(logrus.Fields{
+		"test-groups": len(cfg.TestGroups),
+		"dashboards":  len(cfg.Dashboards),
+	})
+	if !opt.confirm {
+		log.Info("Generated config")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := write(ctx, opt.output, opt.creds, buf); err != nil {
+		log.WithError(err).WithField("--output", opt.output).Fatal("Failed to write shard")
+	}
+	log.WithField("--output", opt.output).Info("Wrote shard")
+}
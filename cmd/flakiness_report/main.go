@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Flakiness report prints the flakiest tests of one or more grids, for teams without the API deployed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/statedump"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+// Formats of the --format flag.
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+type options struct {
+	paths  []gcs.Path
+	creds  string
+	days   int
+	top    int
+	all    bool
+	format string
+	logs   logging.Options
+}
+
+func (o *options) validate() error {
+	if len(o.paths) == 0 {
+		return errors.New("specify at least one gs://path/to/grid")
+	}
+	if o.days < 1 {
+		return fmt.Errorf("--days must be positive, got %d", o.days)
+	}
+	if o.top < 0 {
+		return fmt.Errorf("negative --top: %d", o.top)
+	}
+	switch o.format {
+	case formatTable, formatCSV, formatJSON:
+	default:
+		return fmt.Errorf("unknown --format %q, want table, csv or json", o.format)
+	}
+	return nil
+}
+
+func gatherOptions() (options, error) {
+	var o options
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.IntVar(&o.days, "days", 7, "Consider the columns of this many days")
+	flag.IntVar(&o.top, "top", 20, "List this many of the flakiest tests (all if zero)")
+	flag.BoolVar(&o.all, "all", false, "Include tests that never failed or flaked")
+	flag.StringVar(&o.format, "format", formatTable, "Print tests as a table, csv or json")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	for _, arg := range flag.Args() {
+		p, err := gcs.NewPath(arg)
+		if err != nil {
+			return o, fmt.Errorf("bad grid path %q: %w", arg, err)
+		}
+		o.paths = append(o.paths, *p)
+	}
+	return o, nil
+}
+
+func main() {
+	opt, err := gatherOptions()
+	if err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to create storage client")
+	}
+	client := gcs.NewClient(storageClient)
+
+	now := time.Now()
+	var tests []statedump.RunStats
+	for _, path := range opt.paths {
+		grid, _, err := statedump.Read(ctx, client, path)
+		if err != nil {
+			logrus.WithError(err).WithField("path", path).Fatal("Failed to read grid")
+		}
+		for _, tf := range statedump.Flakiness(grid, now, opt.days) {
+			if !opt.all && tf.FlakeRate == 0 && tf.Failures == 0 && tf.Flakes == 0 {
+				continue
+			}
+			if len(opt.paths) > 1 {
+				tf.Grid = path.String()
+			}
+			tests = append(tests, tf)
+		}
+	}
+	statedump.SortFlakiness(tests)
+	if opt.top > 0 && len(tests) > opt.top {
+		tests = tests[:opt.top]
+	}
+
+	switch opt.format {
+	case formatCSV:
+		err = statedump.WriteFlakinessCSV(os.Stdout, tests)
+	case formatJSON:
+		if tests == nil {
+			tests = []statedump.RunStats{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(tests)
+	default:
+		err = statedump.WriteFlakinessTable(os.Stdout, tests)
+	}
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to print report")
+	}
+}
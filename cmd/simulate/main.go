@@ -0,0 +1,229 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Simulate prints the grid and summary the updater and summarizer would produce for a test group, without writing anything.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/statedump"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+// Formats of the --format flag.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type options struct {
+	paths            []string
+	defaults         string
+	group            string
+	results          string
+	creds            string
+	format           string
+	maxColumns       int
+	buildConcurrency int
+	buildTimeout     time.Duration
+	logs             logging.Options
+}
+
+func (o *options) validate() error {
+	if len(o.paths) == 0 {
+		return errors.New("specify at least one YAML or proto config path")
+	}
+	switch o.format {
+	case formatTable, formatJSON:
+	default:
+		return fmt.Errorf("unknown --format %q, want table or json", o.format)
+	}
+	if o.maxColumns < 1 {
+		return fmt.Errorf("--max-columns must be positive, got %d", o.maxColumns)
+	}
+	if o.buildConcurrency < 1 {
+		return fmt.Errorf("--build-concurrency must be positive, got %d", o.buildConcurrency)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.defaults, "defaults", "", "Path to the default.yaml applied to YAML paths")
+	flag.StringVar(&o.group, "test-group", "", "Simulate this test group (optional when the config has only one)")
+	flag.StringVar(&o.results, "results", "", "Read results from this local directory or gs://bucket/prefix instead of the group's gcs_prefix")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.format, "format", formatTable, "Print the grid and summary as a table or json")
+	flag.IntVar(&o.maxColumns, "max-columns", 50, "Read at most this many of the newest builds")
+	flag.IntVar(&o.buildConcurrency, "build-concurrency", 4, "Read this many builds in parallel")
+	flag.DurationVar(&o.buildTimeout, "build-timeout", 3*time.Minute, "Maximum time to wait to read each build")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	o.paths = flag.Args()
+	return o
+}
+
+// findGroup returns the named group, or the only group when name is empty.
+func findGroup(cfg *configpb.Configuration, name string) (*configpb.TestGroup, error) {
+	if name == "" {
+		if n := len(cfg.TestGroups); n != 1 {
+			return nil, fmt.Errorf("config has %d test groups, set --test-group", n)
+		}
+		return cfg.TestGroups[0], nil
+	}
+	if tg := config.FindTestGroup(name, cfg); tg != nil {
+		return tg, nil
+	}
+	return nil, fmt.Errorf("test group %q not found", name)
+}
+
+// groupDashboards returns each dashboard showing the group, with only the tabs of the group.
+//
+// Returns a dashboard with a single default tab when none do.
+func groupDashboards(cfg *configpb.Configuration, group string) []*configpb.Dashboard {
+	var out []*configpb.Dashboard
+	for _, dash := range cfg.Dashboards {
+		var tabs []*configpb.DashboardTab
+		for _, tab := range dash.DashboardTab {
+			if tab.TestGroupName == group {
+				tabs = append(tabs, tab)
+			}
+		}
+		if len(tabs) == 0 {
+			continue
+		}
+		d := proto.Clone(dash).(*configpb.Dashboard)
+		d.DashboardTab = tabs
+		out = append(out, d)
+	}
+	if len(out) == 0 {
+		out = append(out, &configpb.Dashboard{
+			Name: "simulate",
+			DashboardTab: []*configpb.DashboardTab{
+				{Name: group, TestGroupName: group},
+			},
+		})
+	}
+	return out
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var storageClient *storage.Client
+	newClient := func() (*storage.Client, error) {
+		if storageClient != nil {
+			return storageClient, nil
+		}
+		var err error
+		storageClient, err = gcs.ClientWithCreds(ctx, opt.creds)
+		return storageClient, err
+	}
+
+	cfg, err := yamlcfg.ReadPaths(ctx, opt.paths, opt.defaults, newClient)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to read config")
+	}
+	tg, err := findGroup(cfg, opt.group)
+	if err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	log := logrus.WithField("group", tg.Name)
+
+	var client gcs.Downloader
+	if opt.results != "" {
+		tg.AdditionalGcsPrefixes = nil
+	}
+	switch {
+	case opt.results != "" && !strings.HasPrefix(opt.results, "gs://"):
+		// Treat the directory as a bucket, under the local client rooted at its parent.
+		dir, err := filepath.Abs(opt.results)
+		if err != nil {
+			logrus.Fatalf("Invalid flags: %v", err)
+		}
+		client = gcs.NewLocalClient(filepath.Dir(dir))
+		tg.GcsPrefix = filepath.Base(dir)
+	default:
+		if opt.results != "" {
+			tg.GcsPrefix = strings.TrimPrefix(opt.results, "gs://")
+		}
+		sc, err := newClient()
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create storage client")
+		}
+		client = gcs.NewClient(sc)
+	}
+	log = log.WithField("results", tg.GcsPrefix)
+
+	grid, err := updater.SimulateGroup(ctx, log, client, tg, opt.maxColumns, opt.buildConcurrency, opt.buildTimeout)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to simulate grid")
+	}
+
+	if opt.format == formatJSON {
+		err = statedump.WriteJSON(os.Stdout, grid)
+	} else {
+		err = statedump.WriteTable(os.Stdout, grid)
+	}
+	if err != nil {
+		log.WithError(err).Fatal("Failed to print grid")
+	}
+
+	now := time.Now()
+	for _, dash := range groupDashboards(cfg, tg.Name) {
+		sum, err := summarizer.SummarizeGrid(ctx, dash, tg, grid)
+		if err != nil {
+			log.WithError(err).WithField("dashboard", dash.Name).Error("Failed to summarize")
+			continue
+		}
+		if opt.format == formatJSON {
+			err = statedump.WriteJSON(os.Stdout, sum)
+		} else {
+			fmt.Printf("\nDashboard %s:\n", dash.Name)
+			err = statedump.WriteSummary(os.Stdout, sum, now, true)
+		}
+		if err != nil {
+			log.WithError(err).Fatal("Failed to print summary")
+		}
+	}
+}
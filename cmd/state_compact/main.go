@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// State compact rewrites the grid of a test group without old columns or long messages to shrink it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	config     gcs.Path
+	creds      string
+	confirm    bool
+	group      string
+	gridPrefix string
+	maxAge     time.Duration
+	maxMessage int
+	kmsKeys    gcs.KMSKeys
+	logs       logging.Options
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.group == "" {
+		return errors.New("empty --test-group")
+	}
+	if o.maxAge <= 0 {
+		return fmt.Errorf("--max-age must be positive, got %s", o.maxAge)
+	}
+	if o.maxMessage < 0 {
+		return fmt.Errorf("--max-message must not be negative, got %d", o.maxMessage)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload the compacted grid if set")
+	flag.StringVar(&o.group, "test-group", "", "Compact the grid of this test group")
+	flag.StringVar(&o.gridPrefix, "grid-prefix", "grid", "Join this with the group name to create the GCS suffix of the grid")
+	flag.DurationVar(&o.maxAge, "max-age", 0, "Drop columns started longer ago than this, such as 168h")
+	flag.IntVar(&o.maxMessage, "max-message", 0, "Truncate messages longer than this many bytes (never if zero)")
+	flag.Var(&o.kmsKeys, "kms-key", "Encrypt objects written to a bucket with a Cloud KMS key when set as bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k (repeatable)")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Warning("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to create storage client: %v", err)
+	}
+	defer storageClient.Close()
+	client := gcs.NewClientWithKeys(storageClient, opt.kmsKeys)
+
+	log := logrus.WithField("group", opt.group)
+	before, after, err := updater.Compact(ctx, client, opt.config, opt.gridPrefix, opt.group, time.Now().Add(-opt.maxAge), opt.maxMessage, opt.confirm)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to compact grid")
+	}
+	var percent float64
+	if before > 0 {
+		percent = 100 * float64(before-after) / float64(before)
+	}
+	fmt.Printf("%s: %d bytes -> %d bytes, saved %d bytes (%.1f%%)\n", opt.group, before, after, before-after, percent)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// State dump prints a grid state object as a table, JSON or stats, to debug what the updater wrote.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/statedump"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+// Formats of the --format flag.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatStats = "stats"
+)
+
+type options struct {
+	path         gcs.Path
+	creds        string
+	format       string
+	rowRegex     string
+	columnOffset int
+	columnLimit  int
+	top          int
+	logs         logging.Options
+}
+
+func (o *options) validate() error {
+	if o.path.String() == "" {
+		return errors.New("empty --path")
+	}
+	switch o.format {
+	case formatTable, formatJSON, formatStats:
+	default:
+		return fmt.Errorf("unknown --format %q, want table, json or stats", o.format)
+	}
+	if o.columnOffset < 0 || o.columnLimit < 0 {
+		return errors.New("negative --column-offset or --column-limit")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.path, "path", "gs://path/to/grid/state, such as gs://bucket/grid/my-group")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.format, "format", formatTable, "Print the grid as a table, json or stats")
+	flag.StringVar(&o.rowRegex, "row-regex", "", "Only include rows whose name matches this regex if set")
+	flag.IntVar(&o.columnOffset, "column-offset", 0, "Skip this many of the newest columns")
+	flag.IntVar(&o.columnLimit, "column-limit", 0, "Include at most this many columns after --column-offset (all if zero)")
+	flag.IntVar(&o.top, "top", 10, "List this many of the largest rows in stats")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+
+	log := logrus.WithField("path", opt.path)
+	grid, compressed, err := statedump.Read(ctx, client, opt.path)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to read grid")
+	}
+	req := apipb.GetTabStateRequest{
+		RowRegex:     opt.rowRegex,
+		ColumnOffset: int32(opt.columnOffset),
+		ColumnLimit:  int32(opt.columnLimit),
+	}
+	if _, err := api.FilterGrid(grid, &req); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	switch opt.format {
+	case formatJSON:
+		err = statedump.WriteJSON(os.Stdout, grid)
+	case formatStats:
+		err = statedump.WriteStats(os.Stdout, statedump.GridStats(grid, compressed, opt.top))
+	default:
+		err = statedump.WriteTable(os.Stdout, grid)
+	}
+	if err != nil {
+		log.WithError(err).Fatal("Failed to print grid")
+	}
+}
@@ -20,18 +20,28 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"io/ioutil"
 	"runtime"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/GoogleCloudPlatform/testgrid/util/debug"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/health"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 
 	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer/notify"
 )
 
 type options struct {
 	config            gcs.Path // gcs://path/to/config/proto
+	reloadConfig      time.Duration
 	creds             string
 	confirm           bool
 	dashboard         string
@@ -39,12 +49,44 @@ type options struct {
 	wait              time.Duration
 	gridPathPrefix    string
 	summaryPathPrefix string
+	triggerPrefix     string
+	mirror            gcs.Path
+	signTTL           time.Duration
+	kmsKeys           gcs.KMSKeys
+	slackWebhooks     string
+	pagerDutyKeys     string
+	webhooks          string
+	channels          bool
+	smtpServer        string
+	smtpUsername      string
+	smtpPasswordFile  string
+	emailFrom         string
+	githubTokenFile   string
+	githubURL         string
+	issueTemplate     string
+	digestEvery       time.Duration
+	digestDays        int
+	digestTop         int
+	groupSummaries    bool
+	groupSummaryTop   int
+	metrics           metrics.Options
+	otlpEndpoint      string
+	debugAddress      string
+	healthAddress     string
+	healthMultiple    float64
+	logs              logging.Options
 }
 
 func (o *options) validate() error {
 	if o.config.String() == "" {
 		return errors.New("empty --config")
 	}
+	if o.signTTL > 0 && o.creds == "" {
+		return errors.New("--sign-artifacts requires --gcp-service-account")
+	}
+	if o.smtpServer != "" && o.emailFrom == "" {
+		return errors.New("--smtp-server requires --email-from")
+	}
 	if o.concurrency == 0 {
 		o.concurrency = 4 * runtime.NumCPU()
 	}
@@ -54,6 +96,7 @@ func (o *options) validate() error {
 func gatherOptions() options {
 	var o options
 	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.DurationVar(&o.reloadConfig, "config-reload", time.Minute, "Check the config for changes this often during an update, replanning the remaining dashboards (never if zero)")
 	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
 	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
 	flag.StringVar(&o.dashboard, "dashboard", "", "Only update named dashboard if set")
@@ -61,6 +104,32 @@ func gatherOptions() options {
 	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
 	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
 	flag.StringVar(&o.summaryPathPrefix, "summary-path", "", "Write summaries under this GCS path.")
+	flag.StringVar(&o.triggerPrefix, "trigger-prefix", "", "Summarize dashboards triggered under this GCS path first (never if empty)")
+	flag.DurationVar(&o.signTTL, "sign-artifacts", 0, "Embed signed links to the GCS artifacts linked by failing results, such as their log property, valid for this long if non-zero")
+	flag.StringVar(&o.slackWebhooks, "slack-webhooks", "", "Post notifications to slack using the channel: webhook-url mapping in this /path/to/webhooks.yaml if set")
+	flag.StringVar(&o.pagerDutyKeys, "pagerduty-routing-keys", "", "Manage pagerduty incidents using the service: routing-key mapping in this /path/to/keys.yaml if set")
+	flag.StringVar(&o.webhooks, "webhooks", "", "Send templated alert payloads using the name: {url, template} mapping in this /path/to/webhooks.yaml if set")
+	flag.BoolVar(&o.channels, "notification-channels", false, "Send alerts to the notification_channels each dashboard references, mailing email channels through --smtp-server")
+	flag.StringVar(&o.smtpServer, "smtp-server", "", "Email alerts through this host:port SMTP server if set")
+	flag.StringVar(&o.smtpUsername, "smtp-username", "", "Authenticate to --smtp-server as this user if set")
+	flag.StringVar(&o.smtpPasswordFile, "smtp-password-file", "", "/path/to/file containing the --smtp-username password")
+	flag.StringVar(&o.emailFrom, "email-from", "", "Send alert emails from this address")
+	flag.StringVar(&o.githubTokenFile, "github-token-file", "", "File GitHub issues about sustained failures using the token in this /path/to/token if set")
+	flag.StringVar(&o.githubURL, "github-url", notify.GitHubAPI, "File GitHub issues through this API endpoint")
+	flag.StringVar(&o.issueTemplate, "github-issue-template", "", "Render GitHub issue bodies with the Go template in this /path/to/template if set")
+	flag.DurationVar(&o.digestEvery, "flaky-digest-every", 0, "Rank the flakiest tests of each dashboard group this often if non-zero, such as 168h for weekly")
+	flag.IntVar(&o.digestDays, "flaky-digest-days", 7, "Rank flaky tests over this many days")
+	flag.IntVar(&o.digestTop, "flaky-digest-top", 20, "Include this many of the flakiest tests in each digest (all if zero)")
+	flag.BoolVar(&o.groupSummaries, "group-summaries", false, "Roll up the summaries of each dashboard group after summarizing dashboards if set")
+	flag.IntVar(&o.groupSummaryTop, "group-summary-top", 10, "List this many of the least healthy tabs in each group summary (all if zero)")
+	flag.Var(&o.mirror, "mirror", "Asynchronously mirror writes under gs://bucket/prefix if set")
+	flag.Var(&o.kmsKeys, "kms-key", "Encrypt objects written to a bucket with a Cloud KMS key when set as bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k (repeatable)")
+	o.metrics.AddFlags(flag.CommandLine)
+	flag.StringVar(&o.otlpEndpoint, "otlp-endpoint", "", "Export OpenTelemetry traces to the OTLP gRPC collector at this host:port, if set")
+	flag.StringVar(&o.debugAddress, "debug-address", "", "Serve pprof profiles at /debug/pprof/ and expvar variables at /debug/vars on this address, such as localhost:6060, if set")
+	flag.StringVar(&o.healthAddress, "health-address", "", "Serve liveness at /healthz and readiness at /readyz on this address, such as :8081, if set")
+	flag.Float64Var(&o.healthMultiple, "health-wait-multiple", 3, "Report unhealthy when no cycle succeeds within this many --wait periods (never if zero)")
+	o.logs.AddFlags(flag.CommandLine)
 	flag.Parse()
 	return o
 }
@@ -71,21 +140,138 @@ func main() {
 	if err := opt.validate(); err != nil {
 		logrus.Fatalf("Invalid flags: %v", err)
 	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
 	if !opt.confirm {
 		logrus.Info("--confirm=false (DRY-RUN): will not write to gcs")
 	}
+	if err := debug.Serve(opt.debugAddress); err != nil {
+		logrus.Fatalf("Failed to serve --debug-address: %v", err)
+	}
+	checker := health.NewChecker(time.Duration(opt.healthMultiple * float64(opt.wait)))
+	if err := health.Serve(opt.healthAddress, checker); err != nil {
+		logrus.Fatalf("Failed to serve --health-address: %v", err)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	client, err := gcs.ClientWithCreds(ctx, opt.creds)
+
+	shutdown, err := tracing.Setup(ctx, "summarizer", opt.otlpEndpoint)
+	if err != nil {
+		logrus.Fatalf("Failed to export --otlp-endpoint traces: %v", err)
+	}
+	defer shutdown()
+
+	stopMetrics, err := opt.metrics.Setup(ctx)
+	if err != nil {
+		logrus.Fatalf("Failed to report metrics: %v", err)
+	}
+	defer stopMetrics()
+
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
 	if err != nil {
 		logrus.Fatalf("Failed to read storage client: %v", err)
 	}
+	var client gcs.ConditionalClient = gcs.NewPathClient(gcs.NewClientWithKeys(storageClient, opt.kmsKeys))
+	var mirror gcs.MirrorClient
+	if opt.mirror.String() != "" {
+		mirror = gcs.NewMirrorClient(client, client, gcs.MirrorPath(opt.mirror), opt.concurrency, time.Minute)
+		client = mirror
+	}
+
+	var signer gcs.Signer
+	if opt.signTTL > 0 {
+		if signer, err = gcs.SignerFromCreds(opt.creds, opt.signTTL); err != nil {
+			logrus.Fatalf("Failed to create url signer: %v", err)
+		}
+	}
+
+	var notifiers []notify.Notifier
+	if opt.slackWebhooks != "" {
+		webhooks, err := notify.LoadSecrets(opt.slackWebhooks)
+		if err != nil {
+			logrus.Fatalf("Failed to load --slack-webhooks: %v", err)
+		}
+		notifiers = append(notifiers, notify.Slack{Webhooks: webhooks})
+	}
+	if opt.pagerDutyKeys != "" {
+		keys, err := notify.LoadSecrets(opt.pagerDutyKeys)
+		if err != nil {
+			logrus.Fatalf("Failed to load --pagerduty-routing-keys: %v", err)
+		}
+		notifiers = append(notifiers, notify.PagerDuty{RoutingKeys: keys})
+	}
+	if opt.webhooks != "" {
+		targets, err := notify.LoadWebhooks(opt.webhooks)
+		if err != nil {
+			logrus.Fatalf("Failed to load --webhooks: %v", err)
+		}
+		notifiers = append(notifiers, notify.Webhook{Targets: targets})
+	}
+	var email notify.Email
+	if opt.smtpServer != "" {
+		var password string
+		if opt.smtpPasswordFile != "" {
+			buf, err := ioutil.ReadFile(opt.smtpPasswordFile)
+			if err != nil {
+				logrus.Fatalf("Failed to read --smtp-password-file: %v", err)
+			}
+			password = strings.TrimSpace(string(buf))
+		}
+		send, err := notify.SMTPSender(opt.smtpServer, opt.smtpUsername, password)
+		if err != nil {
+			logrus.Fatalf("Bad --smtp-server: %v", err)
+		}
+		email = notify.Email{From: opt.emailFrom, Send: send}
+		notifiers = append(notifiers, email)
+	}
+	if opt.channels {
+		notifiers = append(notifiers, notify.Channels{Email: email})
+	}
+	var notifier notify.Notifier
+	if len(notifiers) > 0 {
+		notifier = notify.Multi(notifiers)
+	}
+	var tracker notify.Tracker
+	if opt.githubTokenFile != "" {
+		buf, err := ioutil.ReadFile(opt.githubTokenFile)
+		if err != nil {
+			logrus.Fatalf("Failed to read --github-token-file: %v", err)
+		}
+		gh := notify.GitHub{Token: strings.TrimSpace(string(buf)), URL: opt.githubURL}
+		if opt.issueTemplate != "" {
+			if gh.Template, err = template.ParseFiles(opt.issueTemplate); err != nil {
+				logrus.Fatalf("Bad --github-issue-template: %v", err)
+			}
+		}
+		tracker = gh
+	}
 
 	updateOnce := func(ctx context.Context) error {
+		start := time.Now()
 		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 		defer cancel()
-		return summarizer.Update(ctx, client, opt.config, opt.concurrency, opt.dashboard, opt.gridPathPrefix, opt.summaryPathPrefix, opt.confirm)
+		ctx, span := tracing.Start(ctx, "summarizer.cycle")
+		err := summarizer.Update(ctx, client, opt.config, opt.reloadConfig, opt.concurrency, opt.dashboard, opt.gridPathPrefix, opt.summaryPathPrefix, opt.triggerPrefix, signer, notifier, tracker, opt.confirm)
+		if opt.digestEvery > 0 {
+			if derr := summarizer.UpdateDigests(ctx, client, opt.config, "", opt.gridPathPrefix, opt.summaryPathPrefix, opt.digestDays, opt.digestTop, opt.digestEvery, notifier, opt.confirm); derr != nil {
+				logrus.WithError(derr).Error("Failed to update flaky test digests")
+			}
+		}
+		if opt.groupSummaries {
+			if gerr := summarizer.UpdateGroupSummaries(ctx, client, opt.config, "", opt.summaryPathPrefix, opt.groupSummaryTop, opt.confirm); gerr != nil {
+				logrus.WithError(gerr).Error("Failed to update dashboard group summaries")
+			}
+		}
+		if mirror != nil {
+			mirror.Wait()
+			logrus.WithField("mirror", mirror.Stats()).Info("Mirrored writes")
+		}
+		tracing.End(span, err)
+		metrics.Cycle("summarizer", start, err)
+		checker.Cycle(err)
+		return err
 	}
 
 	if err := updateOnce(ctx); err != nil {
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Summary dump prints the tab statuses, alerts and update times of a dashboard summary, optionally as they change.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/statedump"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+)
+
+type options struct {
+	path          gcs.Path
+	config        gcs.Path
+	dashboard     string
+	summaryPrefix string
+	creds         string
+	format        string
+	failing       bool
+	watch         time.Duration
+	logs          logging.Options
+}
+
+func (o *options) validate() error {
+	if (o.path.String() == "") == (o.config.String() == "") {
+		return errors.New("specify either --path or --config")
+	}
+	if o.config.String() != "" && o.dashboard == "" {
+		return errors.New("--config requires --dashboard")
+	}
+	if o.format != "table" && o.format != "json" {
+		return fmt.Errorf("--format must be table or json, got %q", o.format)
+	}
+	if o.watch < 0 {
+		return fmt.Errorf("--watch must not be negative, got %s", o.watch)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.path, "path", "gs://path/to/summary-dashboard")
+	flag.Var(&o.config, "config", "gs://path/to/config.pb, to read the summary of --dashboard instead of --path")
+	flag.StringVar(&o.dashboard, "dashboard", "", "Read the summary of this dashboard under --config")
+	flag.StringVar(&o.summaryPrefix, "summary-prefix", "summary", "Join this with the summary name to create the GCS suffix")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.format, "format", "table", "Print the summary as a table or json")
+	flag.BoolVar(&o.failing, "failing", false, "Also list the failing tests of each tab in the table")
+	flag.DurationVar(&o.watch, "watch", 0, "Reread and print the summary this often (only once if zero)")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+// summaryPath returns --path, or else the path of the summary of --dashboard under --config.
+func summaryPath(opt options) (*gcs.Path, error) {
+	if opt.path.String() != "" {
+		return &opt.path, nil
+	}
+	return opt.config.ResolveReference(&url.URL{Path: path.Join(opt.summaryPrefix, summarizer.SummaryPath(opt.dashboard))})
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	sumPath, err := summaryPath(opt)
+	if err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to create storage client: %v", err)
+	}
+	defer storageClient.Close()
+	client := gcs.NewClient(storageClient)
+
+	log := logrus.WithField("path", sumPath)
+	for {
+		err := dump(ctx, os.Stdout, client, *sumPath, opt)
+		if opt.watch == 0 {
+			if err != nil {
+				log.WithError(err).Fatal("Failed to print summary")
+			}
+			return
+		}
+		if err != nil {
+			log.WithError(err).Error("Failed to print summary")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(opt.watch):
+		}
+	}
+}
+
+// dump reads the summary at path and prints it, clearing the screen first when watching.
+func dump(ctx context.Context, w io.Writer, client gcs.Opener, path gcs.Path, opt options) error {
+	sum, err := summarizer.ReadSummary(ctx, client, path)
+	if err != nil {
+		return err
+	}
+	if sum == nil {
+		return fmt.Errorf("%s not found", path)
+	}
+	if opt.format == "json" {
+		return statedump.WriteJSON(w, sum)
+	}
+	now := time.Now()
+	if opt.watch > 0 {
+		fmt.Fprintf(w, "\033[H\033[2J%s at %s (every %s)\n\n", path, now.Format(time.RFC3339), opt.watch)
+	}
+	return statedump.WriteSummary(w, sum, now, opt.failing)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
+	"github.com/GoogleCloudPlatform/testgrid/util/debug"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/health"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
+)
+
+type options struct {
+	config         gcs.Path // gs://path/to/config/proto
+	creds          string
+	confirm        bool
+	dashboard      string
+	concurrency    int
+	wait           time.Duration
+	gridPathPrefix string
+	tabsPathPrefix string
+
+	githubTokenFile string
+	githubURL       string
+	jiraUser        string
+	jiraTokenFile   string
+	metrics         metrics.Options
+	otlpEndpoint    string
+	debugAddress    string
+	healthAddress   string
+	healthMultiple  float64
+	logs            logging.Options
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.tabsPathPrefix == "" {
+		return errors.New("empty --tabs-path")
+	}
+	if o.concurrency == 0 {
+		o.concurrency = 4 * runtime.NumCPU()
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
+	flag.StringVar(&o.dashboard, "dashboard", "", "Only update the tabs of the named dashboard if set")
+	flag.IntVar(&o.concurrency, "concurrency", 0, "Manually define the number of test groups to concurrently read if non-zero")
+	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
+	flag.StringVar(&o.tabsPathPrefix, "tabs-path", "tabs", "Write tab states under this GCS path.")
+	flag.StringVar(&o.githubTokenFile, "github-token-file", "", "Search GitHub issues using the token in this /path/to/token if set")
+	flag.StringVar(&o.githubURL, "github-url", tabulator.GitHubAPI, "Search GitHub issues through this API endpoint")
+	flag.StringVar(&o.jiraUser, "jira-user", "", "Search Jira issues as this user if set")
+	flag.StringVar(&o.jiraTokenFile, "jira-token-file", "", "Search Jira issues using the API token in this /path/to/token if set")
+	o.metrics.AddFlags(flag.CommandLine)
+	flag.StringVar(&o.otlpEndpoint, "otlp-endpoint", "", "Export OpenTelemetry traces to the OTLP gRPC collector at this host:port, if set")
+	flag.StringVar(&o.debugAddress, "debug-address", "", "Serve pprof profiles at /debug/pprof/ and expvar variables at /debug/vars on this address, such as localhost:6060, if set")
+	flag.StringVar(&o.healthAddress, "health-address", "", "Serve liveness at /healthz and readiness at /readyz on this address, such as :8081, if set")
+	flag.Float64Var(&o.healthMultiple, "health-wait-multiple", 3, "Report unhealthy when no cycle succeeds within this many --wait periods (never if zero)")
+	o.logs.AddFlags(flag.CommandLine)
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+	if err := debug.Serve(opt.debugAddress); err != nil {
+		logrus.Fatalf("Failed to serve --debug-address: %v", err)
+	}
+	checker := health.NewChecker(time.Duration(opt.healthMultiple * float64(opt.wait)))
+	if err := health.Serve(opt.healthAddress, checker); err != nil {
+		logrus.Fatalf("Failed to serve --health-address: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shutdown, err := tracing.Setup(ctx, "tabulator", opt.otlpEndpoint)
+	if err != nil {
+		logrus.Fatalf("Failed to export --otlp-endpoint traces: %v", err)
+	}
+	defer shutdown()
+
+	stopMetrics, err := opt.metrics.Setup(ctx)
+	if err != nil {
+		logrus.Fatalf("Failed to report metrics: %v", err)
+	}
+	defer stopMetrics()
+
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+
+	issues := tabulator.IssueTrackers{
+		GitHub: tabulator.GitHubIssues{Token: readToken("--github-token-file", opt.githubTokenFile), URL: opt.githubURL},
+		Jira:   tabulator.JiraIssues{User: opt.jiraUser, Token: readToken("--jira-token-file", opt.jiraTokenFile)},
+	}
+
+	updateOnce := func(ctx context.Context) error {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+		ctx, span := tracing.Start(ctx, "tabulator.cycle")
+		err := tabulator.Update(ctx, client, opt.config, opt.concurrency, opt.dashboard, opt.gridPathPrefix, opt.tabsPathPrefix, issues, opt.confirm)
+		tracing.End(span, err)
+		metrics.Cycle("tabulator", start, err)
+		checker.Cycle(err)
+		return err
+	}
+
+	if err := updateOnce(ctx); err != nil {
+		logrus.WithError(err).Error("Failed update")
+	}
+	if opt.wait == 0 {
+		return
+	}
+	timer := time.NewTimer(opt.wait)
+	defer timer.Stop()
+	for range timer.C {
+		timer.Reset(opt.wait)
+		if err := updateOnce(ctx); err != nil {
+			logrus.WithError(err).Error("Failed update")
+		}
+		logrus.WithField("wait", opt.wait).Info("Sleeping")
+	}
+}
+
+// readToken returns the trimmed contents of the file at path, if set, read for the named flag.
+func readToken(name, path string) string {
+	if path == "" {
+		return ""
+	}
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		logrus.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return strings.TrimSpace(string(buf))
+}
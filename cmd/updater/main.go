@@ -21,31 +21,73 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/resultstore"
+	"github.com/GoogleCloudPlatform/testgrid/util/debug"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/health"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/api/option"
 )
 
 // options configures the updater
 type options struct {
 	config           gcs.Path // gs://path/to/config/proto
+	reloadConfig     time.Duration
 	creds            string
 	confirm          bool
+	dryRun           bool
 	debug            bool
 	trace            bool
-	group            string
+	groups           groupNames
 	groupConcurrency int
 	buildConcurrency int
 	wait             time.Duration
 	groupTimeout     time.Duration
+	gridCacheBytes   int64
 	buildTimeout     time.Duration
 	gridPrefix       string
+	triggerPrefix    string
 	jsonLogs         bool
+	mirror           gcs.Path
+	kmsKeys          gcs.KMSKeys
+	bigQuery         bool
+	resultStore      bool
+	cloudBuild       bool
+	azureDevOps      bool
+	azureTokenFile   string
+	azureURL         string
+	metrics          metrics.Options
+	otlpEndpoint     string
+	debugAddress     string
+	healthAddress    string
+	healthMultiple   float64
+	logs             logging.Options
+}
+
+// groupNames implements flag.Value, accepting repeated group names.
+type groupNames []string
+
+func (g *groupNames) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *groupNames) Set(v string) error {
+	if v == "" {
+		return errors.New("empty group name")
+	}
+	*g = append(*g, v)
+	return nil
 }
 
 // validate ensures sane options
@@ -53,6 +95,18 @@ func (o *options) validate() error {
 	if o.config.String() == "" {
 		return errors.New("empty --config")
 	}
+	switch {
+	case o.trace:
+		o.logs.Level = logrus.TraceLevel.String()
+	case o.debug:
+		o.logs.Level = logrus.DebugLevel.String()
+	}
+	if o.jsonLogs {
+		o.logs.Format = logging.FormatJSON
+	}
+	if o.dryRun && o.confirm {
+		return errors.New("--dry-run and --confirm are mutually exclusive")
+	}
 	if o.config.Bucket() == "k8s-testgrid" && o.gridPrefix == "" && o.confirm {
 		return fmt.Errorf("--config=%s: cannot write grid state to gs://k8s-testgrid", o.config)
 	}
@@ -73,18 +127,36 @@ func (o *options) validate() error {
 func gatherFlagOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 	fs.Var(&o.config, "config", "gs://path/to/config.pb")
+	fs.DurationVar(&o.reloadConfig, "config-reload", time.Minute, "Check the config for changes this often during an update, replanning the remaining groups (never if zero)")
 	fs.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
 	fs.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
-	fs.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
-	fs.BoolVar(&o.trace, "trace", false, "Log trace and debug lines if set")
-	fs.StringVar(&o.group, "test-group", "", "Only update named group if set")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "Write nothing, not even update locks, logging the size of each grid and whether it changed instead, if set")
+	fs.BoolVar(&o.debug, "debug", false, "Log debug lines if set (deprecated: use --log-level=debug)")
+	fs.BoolVar(&o.trace, "trace", false, "Log trace and debug lines if set (deprecated: use --log-level=trace)")
+	fs.Var(&o.groups, "test-group", "Only update named group if set (repeatable)")
 	fs.IntVar(&o.groupConcurrency, "group-concurrency", 0, "Manually define the number of groups to concurrently update if non-zero")
 	fs.IntVar(&o.buildConcurrency, "build-concurrency", 0, "Manually define the number of builds to concurrently read if non-zero")
 	fs.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
 	fs.DurationVar(&o.groupTimeout, "group-timeout", 10*time.Minute, "Maximum time to wait for each group to update")
 	fs.DurationVar(&o.buildTimeout, "build-timeout", 3*time.Minute, "Maximum time to wait to read each build")
+	fs.Int64Var(&o.gridCacheBytes, "grid-cache-bytes", 0, "Keep up to this many bytes of recently written grids in memory, skipping their download next cycle when unchanged (never if zero)")
 	fs.StringVar(&o.gridPrefix, "grid-prefix", "grid", "Join this with the grid name to create the GCS suffix")
-	fs.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+	fs.StringVar(&o.triggerPrefix, "trigger-prefix", "", "Update groups triggered under this GCS path first, triggering summaries of their dashboards (never if empty)")
+	fs.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set (deprecated: use --log-format=json)")
+	fs.Var(&o.mirror, "mirror", "Asynchronously mirror writes under gs://bucket/prefix if set")
+	fs.Var(&o.kmsKeys, "kms-key", "Encrypt objects written to a bucket with a Cloud KMS key when set as bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k (repeatable)")
+	fs.BoolVar(&o.bigQuery, "bigquery", false, "Read groups with a bigquery_config result source from BigQuery if set")
+	fs.BoolVar(&o.resultStore, "resultstore", false, "Read groups with a resultstore_config result source from ResultStore if set")
+	fs.BoolVar(&o.cloudBuild, "cloud-build", false, "Read groups with a cloud_build_config result source from Cloud Build if set")
+	fs.BoolVar(&o.azureDevOps, "azure-devops", false, "Read groups with an azure_devops_config result source from Azure DevOps if set")
+	fs.StringVar(&o.azureTokenFile, "azure-devops-token-file", "", "Read Azure DevOps test runs using the personal access token in this /path/to/token if set")
+	fs.StringVar(&o.azureURL, "azure-devops-url", updater.AzureDevOpsAPI, "Read Azure DevOps test runs through this API endpoint")
+	o.metrics.AddFlags(fs)
+	fs.StringVar(&o.otlpEndpoint, "otlp-endpoint", "", "Export OpenTelemetry traces to the OTLP gRPC collector at this host:port, if set")
+	fs.StringVar(&o.debugAddress, "debug-address", "", "Serve pprof profiles at /debug/pprof/ and expvar variables at /debug/vars on this address, such as localhost:6060, if set")
+	fs.StringVar(&o.healthAddress, "health-address", "", "Serve liveness at /healthz and readiness at /readyz on this address, such as :8081, if set")
+	fs.Float64Var(&o.healthMultiple, "health-wait-multiple", 3, "Report unhealthy when no cycle succeeds within this many --wait periods (never if zero)")
+	o.logs.AddFlags(fs)
 	fs.Parse(args)
 	return o
 }
@@ -99,59 +171,149 @@ func main() {
 	if err := opt.validate(); err != nil {
 		logrus.Fatalf("Invalid flags: %v", err)
 	}
-	if !opt.confirm {
+	if opt.dryRun {
+		logrus.Warning("--dry-run: will not write anything to gcs")
+	} else if !opt.confirm {
 		logrus.Warning("--confirm=false (DRY-RUN): will not write to gcs")
 	}
-	switch {
-	case opt.trace:
-		logrus.SetLevel(logrus.TraceLevel)
-	case opt.debug:
-		logrus.SetLevel(logrus.DebugLevel)
+	if err := opt.logs.Configure(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
 	}
+	logrus.SetReportCaller(true)
 
-	if opt.jsonLogs {
-		logrus.SetFormatter(&logrus.JSONFormatter{})
+	if err := debug.Serve(opt.debugAddress); err != nil {
+		logrus.Fatalf("Failed to serve --debug-address: %v", err)
+	}
+	checker := health.NewChecker(time.Duration(opt.healthMultiple * float64(opt.wait)))
+	if err := health.Serve(opt.healthAddress, checker); err != nil {
+		logrus.Fatalf("Failed to serve --health-address: %v", err)
 	}
-	logrus.SetReportCaller(true)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	shutdown, err := tracing.Setup(ctx, "updater", opt.otlpEndpoint)
+	if err != nil {
+		logrus.Fatalf("Failed to export --otlp-endpoint traces: %v", err)
+	}
+	defer shutdown()
+
+	stopMetrics, err := opt.metrics.Setup(ctx)
+	if err != nil {
+		logrus.Fatalf("Failed to report metrics: %v", err)
+	}
+	defer stopMetrics()
+
 	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
 	if err != nil {
 		logrus.Fatalf("Failed to create storage client: %v", err)
 	}
 	defer storageClient.Close()
 
-	client := gcs.NewClient(storageClient)
+	var client gcs.ConditionalClient = gcs.NewPathClient(gcs.NewClientWithKeys(storageClient, opt.kmsKeys))
+	if opt.dryRun {
+		client = gcs.NewDryRunClient(client)
+	}
+	var mirror gcs.MirrorClient
+	if opt.mirror.String() != "" {
+		mirror = gcs.NewMirrorClient(client, client, gcs.MirrorPath(opt.mirror), opt.groupConcurrency, opt.groupTimeout)
+		client = mirror
+	}
 
 	logrus.WithFields(logrus.Fields{
 		"group": opt.groupConcurrency,
 		"build": opt.buildConcurrency,
 	}).Info("Configured concurrency")
 
-	groupUpdater := updater.GCS(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, opt.confirm)
+	cache := updater.NewGridCache(opt.gridCacheBytes)
+	groupUpdater := updater.CachedGCS(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, opt.confirm, cache)
+	var opts []option.ClientOption
+	if opt.creds != "" {
+		opts = append(opts, option.WithCredentialsFile(opt.creds))
+	}
+	if opt.bigQuery {
+		querier, err := updater.NewBigQueryClient(ctx, opts...)
+		if err != nil {
+			logrus.Fatalf("Failed to create bigquery client: %v", err)
+		}
+		groupUpdater = updater.BigQuery(opt.groupTimeout, querier, opt.confirm, cache, groupUpdater)
+	}
+	if opt.resultStore {
+		conn, err := resultstore.Connect(ctx, opt.creds)
+		if err != nil {
+			logrus.Fatalf("Failed to connect to resultstore: %v", err)
+		}
+		defer conn.Close()
+		searcher := updater.NewResultStoreClient(resultstore.NewClient(conn))
+		groupUpdater = updater.ResultStore(opt.groupTimeout, searcher, opt.confirm, cache, groupUpdater)
+	}
+	if opt.cloudBuild {
+		lister, err := updater.NewCloudBuildClient(ctx, opts...)
+		if err != nil {
+			logrus.Fatalf("Failed to create cloud build client: %v", err)
+		}
+		groupUpdater = updater.CloudBuild(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, lister, opt.confirm, cache, groupUpdater)
+	}
+	if opt.azureDevOps {
+		ado := updater.AzureDevOpsClient{URL: opt.azureURL}
+		if opt.azureTokenFile != "" {
+			buf, err := ioutil.ReadFile(opt.azureTokenFile)
+			if err != nil {
+				logrus.Fatalf("Failed to read --azure-devops-token-file: %v", err)
+			}
+			ado.Token = strings.TrimSpace(string(buf))
+		}
+		groupUpdater = updater.AzureDevOps(opt.groupTimeout, ado, opt.confirm, cache, groupUpdater)
+	}
 	updateOnce := func() {
 		start := time.Now()
-		if err := updater.Update(ctx, client, opt.config, opt.gridPrefix, opt.groupConcurrency, opt.group, groupUpdater); err != nil {
+		ctx, span := tracing.Start(ctx, "updater.cycle")
+		err := updater.Update(ctx, client, opt.config, opt.reloadConfig, opt.gridPrefix, opt.triggerPrefix, opt.groupConcurrency, opt.groups, groupUpdater)
+		if err != nil {
 			logrus.WithError(err).Error("Could not update")
 		}
+		tracing.End(span, err)
+		metrics.Cycle("updater", start, err)
+		checker.Cycle(err)
+		if mirror != nil {
+			mirror.Wait()
+			logrus.WithField("mirror", mirror.Stats()).Info("Mirrored writes")
+		}
 		logrus.Infof("Update completed in %s", time.Since(start))
 	}
 
+	// sleep returns at until, or once a group is triggered after since when updating every group.
+	sleep := func(since, until time.Time) {
+		sleepCtx, cancel := context.WithDeadline(ctx, until)
+		defer cancel()
+		if opt.triggerPrefix == "" || len(opt.groups) > 0 {
+			<-sleepCtx.Done()
+			return
+		}
+		err := updater.WaitForTriggers(sleepCtx, client, opt.config, opt.gridPrefix, opt.triggerPrefix, since)
+		switch {
+		case err == nil:
+			logrus.Info("Groups triggered, updating early")
+		case !errors.Is(err, context.DeadlineExceeded):
+			logrus.WithError(err).Warning("Failed to wait for triggers")
+			<-sleepCtx.Done()
+		}
+	}
+
+	since := time.Now()
 	updateOnce()
 	if opt.wait == 0 {
 		return
 	}
-	timer := time.NewTimer(opt.wait)
-	defer timer.Stop()
-	for range timer.C {
-		until := time.Now().Add(opt.wait).Round(time.Second)
-		timer.Reset(opt.wait)
+	until := time.Now().Add(opt.wait)
+	for {
+		sleep(since, until)
+		since = time.Now()
+		until = since.Add(opt.wait)
 		updateOnce()
 		logrus.WithFields(logrus.Fields{
 			"wait":  opt.wait,
-			"until": until,
+			"until": until.Round(time.Second),
 		}).Info("Sleeping...")
 	}
 }
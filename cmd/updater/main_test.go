@@ -24,7 +24,10 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
 )
 
 func newPathOrDie(s string) *gcs.Path {
@@ -110,6 +113,59 @@ func TestGatherFlagOptions(t *testing.T) {
 				o.confirm = true
 			},
 		},
+		{
+			name: "deprecated log flags set the log options",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--debug",
+				"--json-logs",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.debug = true
+				o.jsonLogs = true
+				o.logs.Level = "debug"
+				o.logs.Format = logging.FormatJSON
+			},
+		},
+		{
+			name: "log flags",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--log-level=warning",
+				"--log-format=json",
+				"--log-sample=10",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.logs.Level = "warning"
+				o.logs.Format = logging.FormatJSON
+				o.logs.Sample = 10
+			},
+		},
+		{
+			name: "repeated test groups",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--test-group=foo",
+				"--test-group=bar",
+				"--dry-run",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.groups = groupNames{"foo", "bar"}
+				o.dryRun = true
+			},
+		},
+		{
+			name: "reject --dry-run --confirm",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--dry-run",
+				"--confirm",
+			},
+			err: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -120,10 +176,22 @@ func TestGatherFlagOptions(t *testing.T) {
 			}
 			expected := options{
 				buildTimeout:     3 * time.Minute,
+				reloadConfig:     time.Minute,
 				buildConcurrency: ceil,
 				groupConcurrency: runtime.NumCPU(),
 				groupTimeout:     10 * time.Minute,
 				gridPrefix:       "grid",
+				healthMultiple:   3,
+				azureURL:         updater.AzureDevOpsAPI,
+				metrics: metrics.Options{
+					Reporter:     metrics.ReporterPrometheus,
+					SLOTarget:    0.99,
+					SLOFreshness: 30 * time.Minute,
+				},
+				logs: logging.Options{
+					Format: logging.FormatText,
+					Level:  "info",
+				},
 			}
 			if tc.expected != nil {
 				tc.expected(&expected)
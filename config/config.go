@@ -22,7 +22,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/golang/protobuf/proto"
 
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	evalpb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 	multierror "github.com/hashicorp/go-multierror"
 )
@@ -74,25 +78,38 @@ func (e ConfigError) Error() string {
 	return fmt.Sprintf("configuration error for (%s) %s: %s", e.Entity, e.Name, e.Message)
 }
 
-// normalize lowercases, and removes all non-alphanumeric characters from a string.
-func normalize(s string) string {
-	regex := regexp.MustCompile("[^a-zA-Z0-9]+")
-	s = regex.ReplaceAllString(s, "")
+var nonAlphanumeric = regexp.MustCompile("[^a-zA-Z0-9]+")
+
+// Normalize lowercases, and removes all non-alphanumeric characters from a string.
+func Normalize(s string) string {
+	s = nonAlphanumeric.ReplaceAllString(s, "")
 	s = strings.ToLower(s)
 	return s
 }
 
+// Duplicates maps the index of each name that matches an earlier name after normalizing
+// to the index of the first such name.
+func Duplicates(names []string) map[int]int {
+	dups := map[int]int{}
+	first := map[string]int{}
+	for i, name := range names {
+		s := Normalize(name)
+		if j, ok := first[s]; ok {
+			dups[i] = j
+		} else {
+			first[s] = i
+		}
+	}
+	return dups
+}
+
 // validateUnique checks that a list has no duplicate normalized entries.
 func validateUnique(items []string, entity string) error {
 	var mErr error
-	set := map[string]bool{}
-	for _, item := range items {
-		s := normalize(item)
-		_, ok := set[s]
-		if ok {
-			mErr = multierror.Append(mErr, DuplicateNameError{s, entity})
-		} else {
-			set[s] = true
+	dups := Duplicates(items)
+	for i, item := range items {
+		if _, ok := dups[i]; ok {
+			mErr = multierror.Append(mErr, DuplicateNameError{Normalize(item), entity})
 		}
 	}
 	return mErr
@@ -150,6 +167,18 @@ func validateAllUnique(c *configpb.Configuration) error {
 		mErr = multierror.Append(mErr, err)
 	}
 
+	var channelNames []string
+	for _, ch := range c.GetNotificationChannels() {
+		if Normalize(ch.Name) == "" {
+			mErr = multierror.Append(mErr, &ConfigError{ch.Name, "NotificationChannel", "normalized name can't be empty"})
+		}
+		channelNames = append(channelNames, ch.Name)
+	}
+	// Notification Channel names must be unique within Notification Channels.
+	if err := validateUnique(channelNames, "NotificationChannel"); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
 	// Names must also be unique within DashboardGroups AND Dashbaords.
 	if err := validateUnique(append(dashNames, dgNames...), "Dashboard/DashboardGroup"); err != nil {
 		mErr = multierror.Append(mErr, err)
@@ -164,26 +193,13 @@ func validateReferencesExist(c *configpb.Configuration) error {
 		return multierror.Append(mErr, errors.New("got an empty config.Configuration"))
 	}
 
-	tgNames := map[string]bool{}
-	for _, tg := range c.GetTestGroups() {
-		tgNames[tg.GetName()] = true
-	}
-	tgInTabs := map[string]bool{}
-	for _, dash := range c.GetDashboards() {
-		for _, tab := range dash.DashboardTab {
-			tabTg := tab.TestGroupName
-			tgInTabs[tabTg] = true
-			// Verify that each Test Group referenced by a Dashboard Tab exists.
-			if _, ok := tgNames[tabTg]; !ok {
-				mErr = multierror.Append(mErr, MissingEntityError{tabTg, "TestGroup"})
-			}
-		}
+	// Verify that each Test Group referenced by a Dashboard Tab exists.
+	for _, ref := range MissingTestGroups(c) {
+		mErr = multierror.Append(mErr, MissingEntityError{ref.Tab.TestGroupName, "TestGroup"})
 	}
 	// Likewise, each Test Group must be referenced by a Dashboard Tab, so each Test Group gets displayed.
-	for tgName := range tgNames {
-		if _, ok := tgInTabs[tgName]; !ok {
-			mErr = multierror.Append(mErr, ConfigError{tgName, "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab."})
-		}
+	for _, tg := range UnusedTestGroups(c) {
+		mErr = multierror.Append(mErr, ConfigError{tg.Name, "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab."})
 	}
 
 	dashNames := map[string]bool{}
@@ -204,13 +220,66 @@ func validateReferencesExist(c *configpb.Configuration) error {
 			}
 		}
 	}
+
+	channelNames := map[string]bool{}
+	for _, ch := range c.GetNotificationChannels() {
+		channelNames[ch.Name] = true
+	}
+	for _, dash := range c.GetDashboards() {
+		// The Notification Channels each Dashboard references must exist.
+		for _, name := range dash.GetNotificationOptions().GetChannels() {
+			if !channelNames[name] {
+				mErr = multierror.Append(mErr, MissingEntityError{name, "NotificationChannel"})
+			}
+		}
+	}
 	return mErr
 }
 
+// TabRef is a dashboard tab along with the name of its dashboard.
+type TabRef struct {
+	Dashboard string
+	Tab       *configpb.DashboardTab
+}
+
+// MissingTestGroups returns each dashboard tab that references a test group the config does not define.
+func MissingTestGroups(c *configpb.Configuration) []TabRef {
+	tgNames := map[string]bool{}
+	for _, tg := range c.GetTestGroups() {
+		tgNames[tg.GetName()] = true
+	}
+	var out []TabRef
+	for _, dash := range c.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			if !tgNames[tab.TestGroupName] {
+				out = append(out, TabRef{dash.Name, tab})
+			}
+		}
+	}
+	return out
+}
+
+// UnusedTestGroups returns each test group that no dashboard tab references.
+func UnusedTestGroups(c *configpb.Configuration) []*configpb.TestGroup {
+	tgInTabs := map[string]bool{}
+	for _, dash := range c.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			tgInTabs[tab.TestGroupName] = true
+		}
+	}
+	var out []*configpb.TestGroup
+	for _, tg := range c.GetTestGroups() {
+		if !tgInTabs[tg.GetName()] {
+			out = append(out, tg)
+		}
+	}
+	return out
+}
+
 // validateName validates an entity name is non-empty and contains no prefix that overlaps with a
 // TestGrid file prefix, post-normalization.
 func validateName(s string) error {
-	name := normalize(s)
+	name := Normalize(s)
 	if name == "" {
 		return errors.New("normalized name can't be empty")
 	}
@@ -249,9 +318,47 @@ func validateTestGroup(tg *configpb.TestGroup) error {
 		return multierror.Append(mErr, errors.New("got an empty TestGroup"))
 	}
 	// Check that required fields are a non-zero-value.
-	if tg.GetGcsPrefix() == "" {
+	bq := tg.GetResultSource().GetBigqueryConfig()
+	rs := tg.GetResultSource().GetResultstoreConfig()
+	cb := tg.GetResultSource().GetCloudBuildConfig()
+	ado := tg.GetResultSource().GetAzureDevopsConfig()
+	if tg.GetGcsPrefix() == "" && bq == nil && rs == nil && cb == nil && ado == nil {
 		mErr = multierror.Append(mErr, errors.New("gcs_prefix can't be empty"))
 	}
+	prefixes := map[string]bool{tg.GetGcsPrefix(): true}
+	for _, prefix := range tg.GetAdditionalGcsPrefixes() {
+		switch {
+		case prefix == "":
+			mErr = multierror.Append(mErr, errors.New("additional_gcs_prefixes can't contain an empty prefix"))
+		case prefixes[prefix]:
+			mErr = multierror.Append(mErr, fmt.Errorf("additional_gcs_prefixes repeats %q", prefix))
+		}
+		prefixes[prefix] = true
+	}
+	for i, link := range tg.GetArtifactLinks() {
+		if link.GetArtifact() == "" || link.GetPath() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("artifact_links[%d] needs an artifact and a path", i))
+			continue
+		}
+		if _, err := path.Match(link.GetArtifact(), ""); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("artifact_links[%d].artifact %q: %w", i, link.GetArtifact(), err))
+		}
+	}
+	if ps := tg.GetResultSource().GetPubsubConfig(); ps != nil && (ps.GetProject() == "" || ps.GetSubscription() == "") {
+		mErr = multierror.Append(mErr, errors.New("result_source.pubsub_config needs a project and a subscription"))
+	}
+	if bq != nil && (bq.GetProject() == "" || bq.GetQuery() == "") {
+		mErr = multierror.Append(mErr, errors.New("result_source.bigquery_config needs a project and a query"))
+	}
+	if rs != nil && (rs.GetProject() == "" || rs.GetQuery() == "") {
+		mErr = multierror.Append(mErr, errors.New("result_source.resultstore_config needs a project and a query"))
+	}
+	if cb != nil && (cb.GetProject() == "" || cb.GetTriggerId() == "") {
+		mErr = multierror.Append(mErr, errors.New("result_source.cloud_build_config needs a project and a trigger_id"))
+	}
+	if ado != nil && (ado.GetOrganization() == "" || ado.GetProject() == "") {
+		mErr = multierror.Append(mErr, errors.New("result_source.azure_devops_config needs an organization and a project"))
+	}
 	if tg.GetDaysOfResults() <= 0 {
 		mErr = multierror.Append(mErr, errors.New("days_of_results should be positive"))
 	}
@@ -307,6 +414,10 @@ func validateTestGroup(tg *configpb.TestGroup) error {
 		}
 	}
 
+	if err := validateRuleSet(tg.GetCustomEvaluatorRuleSet()); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
 	fallbackConfigSettingSet := tg.GetFallbackGrouping() == configpb.TestGroup_FALLBACK_GROUPING_CONFIGURATION_VALUE
 	fallbackConfigValueSet := tg.GetFallbackGroupingConfigurationValue() != ""
 	if fallbackConfigSettingSet != fallbackConfigValueSet {
@@ -361,6 +472,64 @@ func validateTestGroup(tg *configpb.TestGroup) error {
 	return mErr
 }
 
+// resultFields are the test_result_field values the updater can evaluate.
+var resultFields = map[string]bool{
+	"name":      true,
+	"classname": true,
+	"time":      true,
+	"message":   true,
+	"status":    true,
+}
+
+// validateRuleSet ensures the updater can evaluate every comparison of every rule.
+func validateRuleSet(rs *evalpb.RuleSet) error {
+	var mErr error
+	for i, rule := range rs.GetRules() {
+		if len(rule.GetTestResultComparisons()) == 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("custom_evaluator_rule_set.rules[%d] needs test_result_comparisons", i))
+		}
+		for j, cmp := range rule.GetTestResultComparisons() {
+			where := fmt.Sprintf("custom_evaluator_rule_set.rules[%d].test_result_comparisons[%d]", i, j)
+			switch info := cmp.GetTestResultInfo().(type) {
+			case nil:
+				mErr = multierror.Append(mErr, fmt.Errorf("%s needs a property_key, test_result_field or test_result_error_field", where))
+			case *evalpb.TestResultComparison_PropertyKey:
+				if info.PropertyKey == "" {
+					mErr = multierror.Append(mErr, fmt.Errorf("%s property_key can't be empty", where))
+				}
+			case *evalpb.TestResultComparison_TestResultField:
+				if !resultFields[info.TestResultField] {
+					mErr = multierror.Append(mErr, fmt.Errorf("%s unsupported test_result_field %q", where, info.TestResultField))
+				}
+			case *evalpb.TestResultComparison_TestResultErrorField:
+				if info.TestResultErrorField != "message" {
+					mErr = multierror.Append(mErr, fmt.Errorf("%s unsupported test_result_error_field %q", where, info.TestResultErrorField))
+				}
+			}
+			c := cmp.GetComparison()
+			switch c.GetComparisonValue().(type) {
+			case nil:
+				mErr = multierror.Append(mErr, fmt.Errorf("%s needs a string_value or numerical_value", where))
+			case *evalpb.Comparison_StringValue:
+				switch c.GetOp() {
+				case evalpb.Comparison_OP_LT, evalpb.Comparison_OP_LE, evalpb.Comparison_OP_GT, evalpb.Comparison_OP_GE:
+					mErr = multierror.Append(mErr, fmt.Errorf("%s %s needs a numerical_value", where, c.GetOp()))
+				case evalpb.Comparison_OP_REGEX:
+					if _, err := regexp.Compile(c.GetStringValue()); err != nil {
+						mErr = multierror.Append(mErr, fmt.Errorf("%s regex doesn't compile: %v", where, err))
+					}
+				}
+			case *evalpb.Comparison_NumericalValue:
+				switch c.GetOp() {
+				case evalpb.Comparison_OP_REGEX, evalpb.Comparison_OP_STARTS_WITH, evalpb.Comparison_OP_CONTAINS:
+					mErr = multierror.Append(mErr, fmt.Errorf("%s %s needs a string_value", where, c.GetOp()))
+				}
+			}
+		}
+	}
+	return mErr
+}
+
 func validateDashboardTab(dt *configpb.DashboardTab) error {
 	var mErr error
 	if dt == nil {
@@ -401,6 +570,76 @@ func validateDashboardTab(dt *configpb.DashboardTab) error {
 		}
 	}
 
+	// Issue trackers must identify exactly one place to search.
+	if it := dt.GetIssueTracker(); it != nil {
+		switch {
+		case it.GithubRepo != "" && it.JiraProject != "":
+			mErr = multierror.Append(mErr, errors.New("issue_tracker can't set both github_repo and jira_project"))
+		case it.GithubRepo == "" && it.JiraProject == "":
+			mErr = multierror.Append(mErr, errors.New("issue_tracker requires github_repo or jira_project"))
+		case it.JiraProject != "" && it.JiraUrl == "":
+			mErr = multierror.Append(mErr, errors.New("issue_tracker requires jira_url with jira_project"))
+		}
+	}
+
+	// Objectives need a target that allows some failures.
+	if slo := dt.GetSloOptions(); slo != nil {
+		if target := slo.GetTargetPassRate(); target <= 0 || target >= 100 {
+			mErr = multierror.Append(mErr, fmt.Errorf("slo_options target_pass_rate must be between 0 and 100, got %v", target))
+		}
+		if slo.GetShortWindowHours() < 0 || slo.GetLongWindowHours() < 0 || slo.GetBurnRateThreshold() < 0 {
+			mErr = multierror.Append(mErr, errors.New("slo_options windows and burn_rate_threshold can't be negative"))
+		}
+	}
+
+	return mErr
+}
+
+func validateNotificationChannel(ch *configpb.NotificationChannel) error {
+	var mErr error
+	var kinds int
+	if slack := ch.GetSlack(); slack != nil {
+		kinds++
+		if slack.WebhookUrl == "" {
+			mErr = multierror.Append(mErr, errors.New("slack webhook_url can't be empty"))
+		}
+	}
+	if email := ch.GetEmail(); email != nil {
+		kinds++
+		if err := validateEmails(email.ToAddresses); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	if webhook := ch.GetWebhook(); webhook != nil {
+		kinds++
+		if webhook.Url == "" || webhook.Template == "" {
+			mErr = multierror.Append(mErr, errors.New("webhook url and template can't be empty"))
+		}
+	}
+	if kinds != 1 {
+		mErr = multierror.Append(mErr, fmt.Errorf("must set exactly one of slack, email or webhook, got %d", kinds))
+	}
+	return mErr
+}
+
+// validateOwnership checks that owners are non-empty and the contact is an email address or absolute URL.
+func validateOwnership(o *configpb.Ownership) error {
+	var mErr error
+	for _, owner := range o.GetOwners() {
+		if strings.TrimSpace(owner) == "" {
+			mErr = multierror.Append(mErr, errors.New("owners can't be empty"))
+		}
+	}
+	contact := o.GetContact()
+	if contact == "" {
+		return mErr
+	}
+	if u, err := url.Parse(contact); err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "") {
+		return mErr
+	}
+	if err := validateEmails(contact); err != nil || strings.Contains(contact, ",") {
+		mErr = multierror.Append(mErr, fmt.Errorf("contact %q must be an email address or absolute URL", contact))
+	}
 	return mErr
 }
 
@@ -410,7 +649,6 @@ func validateEntityConfigs(c *configpb.Configuration) error {
 		return multierror.Append(mErr, errors.New("got an empty config.Configuration"))
 	}
 
-	// At the moment, don't need to further validate Dashboards or DashboardGroups.
 	for _, tg := range c.GetTestGroups() {
 		if err := validateTestGroup(tg); err != nil {
 			mErr = multierror.Append(mErr, &ConfigError{tg.GetName(), "TestGroup", err.Error()})
@@ -418,6 +656,9 @@ func validateEntityConfigs(c *configpb.Configuration) error {
 	}
 
 	for _, d := range c.GetDashboards() {
+		if err := validateOwnership(d.Ownership); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{d.GetName(), "Dashboard", err.Error()})
+		}
 		for _, dt := range d.DashboardTab {
 			if err := validateDashboardTab(dt); err != nil {
 				mErr = multierror.Append(mErr, &ConfigError{dt.GetName(), "DashboardTab", err.Error()})
@@ -425,6 +666,18 @@ func validateEntityConfigs(c *configpb.Configuration) error {
 		}
 	}
 
+	for _, dg := range c.GetDashboardGroups() {
+		if err := validateOwnership(dg.Ownership); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{dg.GetName(), "DashboardGroup", err.Error()})
+		}
+	}
+
+	for _, ch := range c.GetNotificationChannels() {
+		if err := validateNotificationChannel(ch); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{ch.GetName(), "NotificationChannel", err.Error()})
+		}
+	}
+
 	return mErr
 }
 
@@ -501,16 +754,27 @@ func MarshalBytes(c *configpb.Configuration) ([]byte, error) {
 }
 
 // ReadGCS opens the config at path and unmarshals it into a Configuration proto.
+//
+// Reads the split configuration under path when there is no object at path.
 func ReadGCS(ctx context.Context, opener gcs.Opener, path gcs.Path) (*configpb.Configuration, error) {
 	r, err := opener.Open(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		cfg, splitErr := readSplitGCS(ctx, opener, path)
+		if !errors.Is(splitErr, storage.ErrObjectNotExist) {
+			return cfg, splitErr
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config: %v", err)
+		return nil, fmt.Errorf("failed to open config: %w", err)
 	}
 	return Unmarshal(r)
 }
 
-// ReadPath reads the config from the specified local file path.
+// ReadPath reads the config from the specified local file path, or split configuration directory.
 func ReadPath(path string) (*configpb.Configuration, error) {
+	if isDir(path) {
+		return readSplitPath(path)
+	}
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("open: %v", err)
@@ -532,6 +796,11 @@ func Read(path string, ctx context.Context, client *storage.Client) (*configpb.C
 	return ReadPath(path)
 }
 
+// IsProto returns true for gs:// paths and .pb files, which hold a config proto rather than YAML.
+func IsProto(path string) bool {
+	return strings.HasPrefix(path, "gs://") || filepath.Ext(path) == ".pb"
+}
+
 // FindTestGroup returns the configpb.TestGroup proto for a given TestGroup name.
 func FindTestGroup(name string, cfg *configpb.Configuration) *configpb.TestGroup {
 	if cfg == nil {
@@ -557,3 +826,49 @@ func FindDashboard(name string, cfg *configpb.Configuration) *configpb.Dashboard
 	}
 	return nil
 }
+
+// FindDashboardGroup returns the configpb.DashboardGroup proto for a given DashboardGroup name.
+func FindDashboardGroup(name string, cfg *configpb.Configuration) *configpb.DashboardGroup {
+	if cfg == nil {
+		return nil
+	}
+	for _, dg := range cfg.GetDashboardGroups() {
+		if dg.Name == name {
+			return dg
+		}
+	}
+	return nil
+}
+
+// FindNotificationChannel returns the configpb.NotificationChannel proto for a given channel name.
+func FindNotificationChannel(name string, cfg *configpb.Configuration) *configpb.NotificationChannel {
+	if cfg == nil {
+		return nil
+	}
+	for _, ch := range cfg.GetNotificationChannels() {
+		if ch.Name == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+// FindOwnership returns the ownership of the named dashboard, or else that of its dashboard group.
+//
+// Returns nil when neither sets one.
+func FindOwnership(dashboard string, cfg *configpb.Configuration) *configpb.Ownership {
+	if o := FindDashboard(dashboard, cfg).GetOwnership(); o != nil {
+		return o
+	}
+	for _, dg := range cfg.GetDashboardGroups() {
+		if dg.Ownership == nil {
+			continue
+		}
+		for _, name := range dg.DashboardNames {
+			if name == dashboard {
+				return dg.Ownership
+			}
+		}
+	}
+	return nil
+}
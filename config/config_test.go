@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	evalpb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
@@ -46,7 +48,7 @@ func TestNormalize(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.input, func(t *testing.T) {
-			got := normalize(test.input)
+			got := Normalize(test.input)
 			if got != test.expected {
 				t.Fatalf("got %s, want %s", got, test.expected)
 			}
@@ -54,6 +56,39 @@ func TestNormalize(t *testing.T) {
 	}
 }
 
+func TestDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected map[int]int
+	}{
+		{
+			name:     "No names",
+			expected: map[int]int{},
+		},
+		{
+			name:     "Unique names",
+			input:    []string{"test_group_1", "test_group_2"},
+			expected: map[int]int{},
+		},
+		{
+			name:  "Duplicates map to the first name",
+			input: []string{"test_group_1", "test_group_2", "TEST GROUP 1", "testgroup1"},
+			expected: map[int]int{
+				2: 0,
+				3: 0,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Duplicates(test.input); !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
 func TestValidateUnique(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -337,6 +372,28 @@ func TestValidateReferencesExist(t *testing.T) {
 				ConfigError{"dash_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group."},
 			},
 		},
+		{
+			name: "Dashboards must reference existing Notification Channels",
+			input: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash_1",
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							Channels: []string{"channel_1", "channel_2"},
+						},
+					},
+				},
+				NotificationChannels: []*configpb.NotificationChannel{
+					{
+						Name:  "channel_1",
+						Slack: &configpb.SlackChannel{WebhookUrl: "https://hooks.example.com"},
+					},
+				},
+			},
+			expectedErrs: []error{
+				MissingEntityError{"channel_2", "NotificationChannel"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -363,6 +420,35 @@ func TestValidateReferencesExist(t *testing.T) {
 	}
 }
 
+func TestMissingTestGroups(t *testing.T) {
+	present := &configpb.DashboardTab{Name: "present", TestGroupName: "group"}
+	missing := &configpb.DashboardTab{Name: "missing", TestGroupName: "other"}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}},
+		Dashboards: []*configpb.Dashboard{
+			{Name: "dash", DashboardTab: []*configpb.DashboardTab{present, missing}},
+		},
+	}
+	expected := []TabRef{{"dash", missing}}
+	if got := MissingTestGroups(cfg); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("Expected %v, but got: %v", expected, got)
+	}
+}
+
+func TestUnusedTestGroups(t *testing.T) {
+	unused := &configpb.TestGroup{Name: "unused"}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}, unused},
+		Dashboards: []*configpb.Dashboard{
+			{Name: "dash", DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}}},
+		},
+	}
+	expected := []*configpb.TestGroup{unused}
+	if got := UnusedTestGroups(cfg); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("Expected %v, but got: %v", expected, got)
+	}
+}
+
 func TestUpdate_validateNames(t *testing.T) {
 	tests := []struct {
 		input string
@@ -449,6 +535,414 @@ func TestValidateTestGroup(t *testing.T) {
 				NumColumnsRecent: 1,
 			},
 		},
+		{
+			name: "Additional gcs prefixes pass",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:                  "test_group",
+				DaysOfResults:         1,
+				GcsPrefix:             "new-bucket/logs/job",
+				AdditionalGcsPrefixes: []string{"old-bucket/logs/job"},
+				NumColumnsRecent:      1,
+			},
+		},
+		{
+			name: "Additional gcs prefixes must not be empty",
+			testGroup: &configpb.TestGroup{
+				Name:                  "test_group",
+				DaysOfResults:         1,
+				GcsPrefix:             "new-bucket/logs/job",
+				AdditionalGcsPrefixes: []string{""},
+				NumColumnsRecent:      1,
+			},
+		},
+		{
+			name: "Additional gcs prefixes must not repeat",
+			testGroup: &configpb.TestGroup{
+				Name:                  "test_group",
+				DaysOfResults:         1,
+				GcsPrefix:             "new-bucket/logs/job",
+				AdditionalGcsPrefixes: []string{"new-bucket/logs/job"},
+				NumColumnsRecent:      1,
+			},
+		},
+		{
+			name: "Artifact links pass",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				ArtifactLinks: []*configpb.TestGroup_ArtifactLink{
+					{Artifact: "artifacts/junit*.xml", Path: "build-log.txt"},
+				},
+			},
+		},
+		{
+			name: "Artifact links need a path",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				ArtifactLinks: []*configpb.TestGroup_ArtifactLink{
+					{Artifact: "artifacts/junit*.xml"},
+				},
+			},
+		},
+		{
+			name: "Artifact links need a valid glob",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				ArtifactLinks: []*configpb.TestGroup_ArtifactLink{
+					{Artifact: "artifacts/junit[.xml", Path: "build-log.txt"},
+				},
+			},
+		},
+		{
+			name: "Pub/Sub config passes",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					PubsubConfig: &configpb.PubSubConfig{
+						Project:      "my-project",
+						Subscription: "testgrid-results",
+						Filter:       `attributes.eventType = "OBJECT_FINALIZE"`,
+					},
+				},
+			},
+		},
+		{
+			name: "Pub/Sub config needs a subscription",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					PubsubConfig: &configpb.PubSubConfig{
+						Project: "my-project",
+					},
+				},
+			},
+		},
+		{
+			name: "BigQuery config passes without a gcs prefix",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_BigqueryConfig{
+						BigqueryConfig: &configpb.BigQueryConfig{
+							Project: "my-project",
+							Query:   "SELECT build, started, test_name, status FROM results",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BigQuery config needs a query",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_BigqueryConfig{
+						BigqueryConfig: &configpb.BigQueryConfig{
+							Project: "my-project",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ResultStore config passes without a gcs prefix",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultstoreConfig{
+						ResultstoreConfig: &configpb.ResultStoreConfig{
+							Project: "my-project",
+							Query:   `invocation_attributes.labels:"my-job"`,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ResultStore config needs a project",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultstoreConfig{
+						ResultstoreConfig: &configpb.ResultStoreConfig{
+							Query: `invocation_attributes.labels:"my-job"`,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Cloud Build config passes without a gcs prefix",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_CloudBuildConfig{
+						CloudBuildConfig: &configpb.CloudBuildConfig{
+							Project:   "my-project",
+							TriggerId: "my-trigger",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Cloud Build config needs a trigger",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_CloudBuildConfig{
+						CloudBuildConfig: &configpb.CloudBuildConfig{
+							Project: "my-project",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Azure DevOps config passes without a gcs prefix",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_AzureDevopsConfig{
+						AzureDevopsConfig: &configpb.AzureDevOpsConfig{
+							Organization: "my-org",
+							Project:      "my-project",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Azure DevOps config needs an organization",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_AzureDevopsConfig{
+						AzureDevopsConfig: &configpb.AzureDevOpsConfig{
+							Project: "my-project",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator rules pass",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							TestResultComparisons: []*evalpb.TestResultComparison{
+								{
+									TestResultInfo: &evalpb.TestResultComparison_PropertyKey{PropertyKey: "infra"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_EQ,
+										ComparisonValue: &evalpb.Comparison_StringValue{StringValue: "true"},
+									},
+								},
+								{
+									TestResultInfo: &evalpb.TestResultComparison_TestResultField{TestResultField: "time"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_GE,
+										ComparisonValue: &evalpb.Comparison_NumericalValue{NumericalValue: 60},
+									},
+								},
+								{
+									TestResultInfo: &evalpb.TestResultComparison_TestResultErrorField{TestResultErrorField: "message"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_REGEX,
+										ComparisonValue: &evalpb.Comparison_StringValue{StringValue: `quota|refused`},
+									},
+								},
+							},
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator rules need comparisons",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator comparisons need a value",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							TestResultComparisons: []*evalpb.TestResultComparison{
+								{
+									TestResultInfo: &evalpb.TestResultComparison_PropertyKey{PropertyKey: "infra"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_EQ,
+										ComparisonValue: nil,
+									},
+								},
+							},
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator comparisons need a supported field",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							TestResultComparisons: []*evalpb.TestResultComparison{
+								{
+									TestResultInfo: &evalpb.TestResultComparison_TestResultField{TestResultField: "duration"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_GE,
+										ComparisonValue: &evalpb.Comparison_NumericalValue{NumericalValue: 60},
+									},
+								},
+							},
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator comparisons need a supported error field",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							TestResultComparisons: []*evalpb.TestResultComparison{
+								{
+									TestResultInfo: &evalpb.TestResultComparison_TestResultErrorField{TestResultErrorField: "type"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_EQ,
+										ComparisonValue: &evalpb.Comparison_StringValue{StringValue: "infra"},
+									},
+								},
+							},
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator regex must compile",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							TestResultComparisons: []*evalpb.TestResultComparison{
+								{
+									TestResultInfo: &evalpb.TestResultComparison_PropertyKey{PropertyKey: "infra"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_REGEX,
+										ComparisonValue: &evalpb.Comparison_StringValue{StringValue: "("},
+									},
+								},
+							},
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Custom evaluator ordering needs a number",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "bucket/logs/job",
+				NumColumnsRecent: 1,
+				CustomEvaluatorRuleSet: &evalpb.RuleSet{
+					Rules: []*evalpb.Rule{
+						{
+							TestResultComparisons: []*evalpb.TestResultComparison{
+								{
+									TestResultInfo: &evalpb.TestResultComparison_TestResultField{TestResultField: "name"},
+									Comparison: &evalpb.Comparison{
+										Op:              evalpb.Comparison_OP_LT,
+										ComparisonValue: &evalpb.Comparison_StringValue{StringValue: "m"},
+									},
+								},
+							},
+							ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Must have num_columns_recent",
 			testGroup: &configpb.TestGroup{
@@ -834,6 +1328,86 @@ func TestValidateDashboardTab(t *testing.T) {
 				TabularNamesRegex: ".*",
 			},
 		},
+		{
+			name: "Issue trackers may search GitHub",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				IssueTracker:  &configpb.IssueTrackerOptions{GithubRepo: "o/r"},
+			},
+			pass: true,
+		},
+		{
+			name: "Issue trackers may search Jira",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				IssueTracker: &configpb.IssueTrackerOptions{
+					JiraProject: "PROJ",
+					JiraUrl:     "https://jira.example.com",
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "Issue trackers must search somewhere",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				IssueTracker:  &configpb.IssueTrackerOptions{IncludeClosed: true},
+			},
+		},
+		{
+			name: "Issue trackers must not search both GitHub and Jira",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				IssueTracker: &configpb.IssueTrackerOptions{
+					GithubRepo:  "o/r",
+					JiraProject: "PROJ",
+					JiraUrl:     "https://jira.example.com",
+				},
+			},
+		},
+		{
+			name: "Jira issue trackers require a URL",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				IssueTracker:  &configpb.IssueTrackerOptions{JiraProject: "PROJ"},
+			},
+		},
+		{
+			name: "SLOs pass",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				SloOptions: &configpb.SLOOptions{
+					TargetPassRate:   99,
+					ShortWindowHours: 6,
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "SLOs must allow failures",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				SloOptions:    &configpb.SLOOptions{TargetPassRate: 100},
+			},
+		},
+		{
+			name: "SLO windows must not be negative",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				SloOptions: &configpb.SLOOptions{
+					TargetPassRate:  99,
+					LongWindowHours: -1,
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -846,6 +1420,81 @@ func TestValidateDashboardTab(t *testing.T) {
 	}
 }
 
+func TestValidateNotificationChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel *configpb.NotificationChannel
+		pass    bool
+	}{
+		{
+			name: "Slack channels work",
+			channel: &configpb.NotificationChannel{
+				Name:  "slack",
+				Slack: &configpb.SlackChannel{WebhookUrl: "https://hooks.example.com"},
+			},
+			pass: true,
+		},
+		{
+			name: "Email channels work",
+			channel: &configpb.NotificationChannel{
+				Name:  "email",
+				Email: &configpb.EmailChannel{ToAddresses: "a@example.com,b@example.com"},
+			},
+			pass: true,
+		},
+		{
+			name: "Webhook channels work",
+			channel: &configpb.NotificationChannel{
+				Name:    "webhook",
+				Webhook: &configpb.WebhookChannel{Url: "https://example.com", Template: `{"tab": {{json .Tab}}}`},
+			},
+			pass: true,
+		},
+		{
+			name:    "Channels must set a kind",
+			channel: &configpb.NotificationChannel{Name: "empty"},
+		},
+		{
+			name: "Channels must set only one kind",
+			channel: &configpb.NotificationChannel{
+				Name:  "both",
+				Slack: &configpb.SlackChannel{WebhookUrl: "https://hooks.example.com"},
+				Email: &configpb.EmailChannel{ToAddresses: "a@example.com"},
+			},
+		},
+		{
+			name: "Slack channels must specify a webhook URL",
+			channel: &configpb.NotificationChannel{
+				Name:  "slack",
+				Slack: &configpb.SlackChannel{},
+			},
+		},
+		{
+			name: "Email channels must specify valid addresses",
+			channel: &configpb.NotificationChannel{
+				Name:  "email",
+				Email: &configpb.EmailChannel{ToAddresses: "nope"},
+			},
+		},
+		{
+			name: "Webhook channels must specify a template",
+			channel: &configpb.NotificationChannel{
+				Name:    "webhook",
+				Webhook: &configpb.WebhookChannel{Url: "https://example.com"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateNotificationChannel(test.channel)
+			pass := err == nil
+			if pass != test.pass {
+				t.Fatalf("Invalid notification channel config: %v", err)
+			}
+		})
+	}
+}
+
 func TestUpdate_Validate(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1088,3 +1737,118 @@ func TestUpdate_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOwnership(t *testing.T) {
+	tests := []struct {
+		name      string
+		ownership *configpb.Ownership
+		pass      bool
+	}{
+		{
+			name: "no ownership works",
+			pass: true,
+		},
+		{
+			name: "email contact works",
+			ownership: &configpb.Ownership{
+				Owners:  []string{"alice", "bob"},
+				Team:    "sig-testing",
+				Contact: "sig-testing@example.com",
+			},
+			pass: true,
+		},
+		{
+			name:      "URL contact works",
+			ownership: &configpb.Ownership{Contact: "https://chat.example.com/channels/sig-testing"},
+			pass:      true,
+		},
+		{
+			name:      "mailto contact works",
+			ownership: &configpb.Ownership{Contact: "mailto:sig-testing@example.com"},
+			pass:      true,
+		},
+		{
+			name:      "owners must not be empty",
+			ownership: &configpb.Ownership{Owners: []string{"alice", " "}},
+		},
+		{
+			name:      "contact must be an email or URL",
+			ownership: &configpb.Ownership{Contact: "#sig-testing"},
+		},
+		{
+			name:      "contact must be a single email",
+			ownership: &configpb.Ownership{Contact: "a@example.com,b@example.com"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateOwnership(test.ownership)
+			pass := err == nil
+			if pass != test.pass {
+				t.Fatalf("Invalid ownership config: %v", err)
+			}
+		})
+	}
+}
+
+func TestFindOwnership(t *testing.T) {
+	dashOwner := &configpb.Ownership{Team: "dash-team"}
+	groupOwner := &configpb.Ownership{Team: "group-team"}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{Name: "owned", Ownership: dashOwner},
+			{Name: "grouped"},
+			{Name: "orphaned"},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "unowned-group", DashboardNames: []string{"orphaned"}},
+			{Name: "group", DashboardNames: []string{"owned", "grouped"}, Ownership: groupOwner},
+		},
+	}
+	tests := []struct {
+		dashboard string
+		expected  *configpb.Ownership
+	}{
+		{dashboard: "owned", expected: dashOwner},
+		{dashboard: "grouped", expected: groupOwner},
+		{dashboard: "orphaned"},
+		{dashboard: "missing"},
+	}
+	for _, test := range tests {
+		t.Run(test.dashboard, func(t *testing.T) {
+			if actual := FindOwnership(test.dashboard, cfg); actual != test.expected {
+				t.Errorf("FindOwnership(%q) got %v, want %v", test.dashboard, actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestIsProto(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			input:    "gs://bucket/config",
+			expected: true,
+		},
+		{
+			input:    "path/to/config.pb",
+			expected: true,
+		},
+		{
+			input: "path/to/config.yaml",
+		},
+		{
+			input: "path/to/configs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			if got := IsProto(test.input); got != test.expected {
+				t.Fatalf("got %t, want %t", got, test.expected)
+			}
+		})
+	}
+}
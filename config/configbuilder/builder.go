@@ -0,0 +1,260 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configbuilder constructs TestGrid configurations in Go.
+//
+//	cfg, err := configbuilder.New().
+//		TestGroups(configbuilder.TestGroup("ci-foo", "bucket/logs/ci-foo").DaysOfResults(7).NumColumnsRecent(5)).
+//		Dashboards(configbuilder.Dashboard("foo").Tab(configbuilder.Tab("ci", "ci-foo").AlertEmails("team@example.com"))).
+//		DashboardGroups(configbuilder.DashboardGroup("teams", "foo")).
+//		Build()
+package configbuilder
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Builder constructs a configuration.
+type Builder struct {
+	cfg configpb.Configuration
+}
+
+// New returns a builder for an empty configuration.
+func New() *Builder {
+	return &Builder{}
+}
+
+// TestGroups adds the test groups to the configuration.
+func (b *Builder) TestGroups(groups ...*TestGroupBuilder) *Builder {
+	for _, g := range groups {
+		b.cfg.TestGroups = append(b.cfg.TestGroups, g.Proto())
+	}
+	return b
+}
+
+// Dashboards adds the dashboards to the configuration.
+func (b *Builder) Dashboards(dashboards ...*DashboardBuilder) *Builder {
+	for _, d := range dashboards {
+		b.cfg.Dashboards = append(b.cfg.Dashboards, d.Proto())
+	}
+	return b
+}
+
+// DashboardGroups adds the dashboard groups to the configuration.
+func (b *Builder) DashboardGroups(groups ...*DashboardGroupBuilder) *Builder {
+	for _, g := range groups {
+		b.cfg.DashboardGroups = append(b.cfg.DashboardGroups, g.Proto())
+	}
+	return b
+}
+
+// Build returns a copy of the configuration, after applying tab defaults, or an error when it is invalid.
+func (b *Builder) Build() (*configpb.Configuration, error) {
+	cfg := proto.Clone(&b.cfg).(*configpb.Configuration)
+	config.ApplyTabDefaults(cfg)
+	if err := config.Validate(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// TestGroupBuilder constructs a test group.
+type TestGroupBuilder struct {
+	tg configpb.TestGroup
+}
+
+// TestGroup returns a builder for a test group reading results under gcsPrefix.
+func TestGroup(name, gcsPrefix string) *TestGroupBuilder {
+	return &TestGroupBuilder{
+		tg: configpb.TestGroup{
+			Name:      name,
+			GcsPrefix: gcsPrefix,
+		},
+	}
+}
+
+// DaysOfResults sets the number of days of results to keep.
+func (b *TestGroupBuilder) DaysOfResults(days int32) *TestGroupBuilder {
+	b.tg.DaysOfResults = days
+	return b
+}
+
+// NumColumnsRecent sets the number of columns considered recent.
+func (b *TestGroupBuilder) NumColumnsRecent(n int32) *TestGroupBuilder {
+	b.tg.NumColumnsRecent = n
+	return b
+}
+
+// With calls f to set any other fields of the test group.
+func (b *TestGroupBuilder) With(f func(*configpb.TestGroup)) *TestGroupBuilder {
+	f(&b.tg)
+	return b
+}
+
+// Proto returns a copy of the test group.
+func (b *TestGroupBuilder) Proto() *configpb.TestGroup {
+	return proto.Clone(&b.tg).(*configpb.TestGroup)
+}
+
+// TabBuilder constructs a dashboard tab.
+type TabBuilder struct {
+	tab configpb.DashboardTab
+}
+
+// Tab returns a builder for a tab displaying the test group.
+func Tab(name, testGroup string) *TabBuilder {
+	return &TabBuilder{
+		tab: configpb.DashboardTab{
+			Name:          name,
+			TestGroupName: testGroup,
+		},
+	}
+}
+
+// TabDefaults returns a builder for the tab_defaults of a dashboard or dashboard group.
+func TabDefaults() *TabBuilder {
+	return &TabBuilder{}
+}
+
+// Description sets the description of the tab.
+func (b *TabBuilder) Description(desc string) *TabBuilder {
+	b.tab.Description = desc
+	return b
+}
+
+// BaseOptions sets the base_options of the tab, such as include-filter-by-regex=foo.
+func (b *TabBuilder) BaseOptions(opts string) *TabBuilder {
+	b.tab.BaseOptions = opts
+	return b
+}
+
+// NumColumnsRecent sets the number of columns considered recent.
+func (b *TabBuilder) NumColumnsRecent(n int32) *TabBuilder {
+	b.tab.NumColumnsRecent = n
+	return b
+}
+
+func (b *TabBuilder) alertOptions() *configpb.DashboardTabAlertOptions {
+	if b.tab.AlertOptions == nil {
+		b.tab.AlertOptions = &configpb.DashboardTabAlertOptions{}
+	}
+	return b.tab.AlertOptions
+}
+
+// AlertEmails sends alerts for the tab to the addresses.
+func (b *TabBuilder) AlertEmails(addresses ...string) *TabBuilder {
+	b.alertOptions().AlertMailToAddresses = strings.Join(addresses, ",")
+	return b
+}
+
+// NumFailuresToAlert alerts after this many consecutive failures.
+func (b *TabBuilder) NumFailuresToAlert(n int32) *TabBuilder {
+	b.alertOptions().NumFailuresToAlert = n
+	return b
+}
+
+// With calls f to set any other fields of the tab.
+func (b *TabBuilder) With(f func(*configpb.DashboardTab)) *TabBuilder {
+	f(&b.tab)
+	return b
+}
+
+// Proto returns a copy of the tab.
+func (b *TabBuilder) Proto() *configpb.DashboardTab {
+	return proto.Clone(&b.tab).(*configpb.DashboardTab)
+}
+
+// DashboardBuilder constructs a dashboard.
+type DashboardBuilder struct {
+	dash configpb.Dashboard
+}
+
+// Dashboard returns a builder for a dashboard.
+func Dashboard(name string) *DashboardBuilder {
+	return &DashboardBuilder{
+		dash: configpb.Dashboard{Name: name},
+	}
+}
+
+// Tab adds the tabs to the dashboard.
+func (b *DashboardBuilder) Tab(tabs ...*TabBuilder) *DashboardBuilder {
+	for _, t := range tabs {
+		b.dash.DashboardTab = append(b.dash.DashboardTab, t.Proto())
+	}
+	return b
+}
+
+// TabDefaults sets the fields each tab of the dashboard inherits.
+func (b *DashboardBuilder) TabDefaults(defaults *TabBuilder) *DashboardBuilder {
+	b.dash.TabDefaults = defaults.Proto()
+	return b
+}
+
+// Notifications adds notifications to the dashboard.
+func (b *DashboardBuilder) Notifications(summaries ...string) *DashboardBuilder {
+	for _, s := range summaries {
+		b.dash.Notifications = append(b.dash.Notifications, &configpb.Notification{Summary: s})
+	}
+	return b
+}
+
+// With calls f to set any other fields of the dashboard.
+func (b *DashboardBuilder) With(f func(*configpb.Dashboard)) *DashboardBuilder {
+	f(&b.dash)
+	return b
+}
+
+// Proto returns a copy of the dashboard.
+func (b *DashboardBuilder) Proto() *configpb.Dashboard {
+	return proto.Clone(&b.dash).(*configpb.Dashboard)
+}
+
+// DashboardGroupBuilder constructs a dashboard group.
+type DashboardGroupBuilder struct {
+	group configpb.DashboardGroup
+}
+
+// DashboardGroup returns a builder for a group of the dashboards.
+func DashboardGroup(name string, dashboards ...string) *DashboardGroupBuilder {
+	return &DashboardGroupBuilder{
+		group: configpb.DashboardGroup{
+			Name:           name,
+			DashboardNames: dashboards,
+		},
+	}
+}
+
+// TabDefaults sets the fields each tab of the group's dashboards inherits.
+func (b *DashboardGroupBuilder) TabDefaults(defaults *TabBuilder) *DashboardGroupBuilder {
+	b.group.TabDefaults = defaults.Proto()
+	return b
+}
+
+// With calls f to set any other fields of the dashboard group.
+func (b *DashboardGroupBuilder) With(f func(*configpb.DashboardGroup)) *DashboardGroupBuilder {
+	f(&b.group)
+	return b
+}
+
+// Proto returns a copy of the dashboard group.
+func (b *DashboardGroupBuilder) Proto() *configpb.DashboardGroup {
+	return proto.Clone(&b.group).(*configpb.DashboardGroup)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configbuilder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestBuild(t *testing.T) {
+	group := func(name string) *TestGroupBuilder {
+		return TestGroup(name, "bucket/logs/"+name).DaysOfResults(7).NumColumnsRecent(5)
+	}
+	cases := []struct {
+		name     string
+		builder  *Builder
+		expected *configpb.Configuration
+		err      bool
+	}{
+		{
+			name:    "empty config is invalid",
+			builder: New(),
+			err:     true,
+		},
+		{
+			name: "basically works",
+			builder: New().
+				TestGroups(group("ci-foo")).
+				Dashboards(Dashboard("foo").Tab(Tab("ci", "ci-foo").Description("hello"))).
+				DashboardGroups(DashboardGroup("teams", "foo")),
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:             "ci-foo",
+						GcsPrefix:        "bucket/logs/ci-foo",
+						DaysOfResults:    7,
+						NumColumnsRecent: 5,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "foo",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "ci",
+								TestGroupName: "ci-foo",
+								Description:   "hello",
+							},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{
+						Name:           "teams",
+						DashboardNames: []string{"foo"},
+					},
+				},
+			},
+		},
+		{
+			name: "alerts and tab defaults",
+			builder: New().
+				TestGroups(group("ci-foo"), group("ci-bar")).
+				Dashboards(
+					Dashboard("foo").
+						TabDefaults(TabDefaults().NumColumnsRecent(3).AlertEmails("team@example.com")).
+						Tab(
+							Tab("ci", "ci-foo").NumFailuresToAlert(2),
+							Tab("bar", "ci-bar").
+								AlertEmails("a@example.com", "b@example.com").
+								With(func(tab *configpb.DashboardTab) {
+									tab.CodeSearchPath = "github.com/foo/bar"
+								}),
+						),
+				),
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:             "ci-foo",
+						GcsPrefix:        "bucket/logs/ci-foo",
+						DaysOfResults:    7,
+						NumColumnsRecent: 5,
+					},
+					{
+						Name:             "ci-bar",
+						GcsPrefix:        "bucket/logs/ci-bar",
+						DaysOfResults:    7,
+						NumColumnsRecent: 5,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "foo",
+						TabDefaults: &configpb.DashboardTab{
+							NumColumnsRecent: 3,
+							AlertOptions: &configpb.DashboardTabAlertOptions{
+								AlertMailToAddresses: "team@example.com",
+							},
+						},
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "ci",
+								TestGroupName:    "ci-foo",
+								NumColumnsRecent: 3,
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert:   2,
+									AlertMailToAddresses: "team@example.com",
+								},
+							},
+							{
+								Name:             "bar",
+								TestGroupName:    "ci-bar",
+								NumColumnsRecent: 3,
+								CodeSearchPath:   "github.com/foo/bar",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									AlertMailToAddresses: "a@example.com,b@example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reject missing test group",
+			builder: New().
+				TestGroups(group("ci-foo")).
+				Dashboards(Dashboard("foo").Tab(Tab("ci", "ci-foo"), Tab("bar", "missing"))),
+			err: true,
+		},
+		{
+			name: "reject bad emails",
+			builder: New().
+				TestGroups(group("ci-foo")).
+				Dashboards(Dashboard("foo").Tab(Tab("ci", "ci-foo").AlertEmails("nope"))),
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.builder.Build()
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Build() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Build() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+					t.Errorf("Build() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestProtoCopies(t *testing.T) {
+	tab := Tab("ci", "ci-foo")
+	dash := Dashboard("foo").Tab(tab)
+	first := dash.Proto()
+	tab.Description("changed")
+	first.DashboardTab[0].Name = "renamed"
+	expected := &configpb.Dashboard{
+		Name: "foo",
+		DashboardTab: []*configpb.DashboardTab{
+			{
+				Name:          "ci",
+				TestGroupName: "ci-foo",
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, dash.Proto(), protocmp.Transform()); diff != "" {
+		t.Errorf("Proto() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
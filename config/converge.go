@@ -32,7 +32,7 @@ var insert void
 
 // Converge merges together the Configurations given in the map set.
 // If there are duplicate entries, the string key may be added as a prefix to
-// maintain uniqueness for Dashboards, DashboardGroups, and TestGroups.
+// maintain uniqueness for Dashboards, DashboardGroups, TestGroups and NotificationChannels.
 // The config at key "" will not be modified.
 //
 // The output protobuf will pass config.Validate if all its inputs pass config.Validate
@@ -53,11 +53,13 @@ func Converge(shards map[string]*configpb.Configuration) (*configpb.Configuratio
 	// Dashboards and Dashboard Groups can't share names with each other
 	DashboardsAndGroups := make(map[string]void)
 	TestGroups := make(map[string]void)
+	Channels := make(map[string]void)
 
 	for _, key := range keys {
 		cfg := shards[key]
 		NewDashboardsAndGroups := make(map[string]void)
 		NewTestGroups := make(map[string]void)
+		NewChannels := make(map[string]void)
 		for _, testgroup := range cfg.TestGroups {
 			NewTestGroups[testgroup.Name] = insert
 		}
@@ -67,9 +69,13 @@ func Converge(shards map[string]*configpb.Configuration) (*configpb.Configuratio
 		for _, dashboardGroup := range cfg.DashboardGroups {
 			NewDashboardsAndGroups[dashboardGroup.Name] = insert
 		}
+		for _, channel := range cfg.NotificationChannels {
+			NewChannels[channel.Name] = insert
+		}
 
 		dashboardRenames := negotiateConversions(key, DashboardsAndGroups, NewDashboardsAndGroups)
 		testGroupRenames := negotiateConversions(key, TestGroups, NewTestGroups)
+		channelRenames := negotiateConversions(key, Channels, NewChannels)
 
 		for olddash, newdash := range dashboardRenames {
 			RenameDashboardOrGroup(olddash, newdash, cfg)
@@ -79,6 +85,10 @@ func Converge(shards map[string]*configpb.Configuration) (*configpb.Configuratio
 			RenameTestGroup(oldtest, newtest, cfg)
 		}
 
+		for oldchannel, newchannel := range channelRenames {
+			RenameNotificationChannel(oldchannel, newchannel, cfg)
+		}
+
 		// Merge protos and cached sets
 		proto.Merge(&result, cfg)
 
@@ -91,6 +101,9 @@ func Converge(shards map[string]*configpb.Configuration) (*configpb.Configuratio
 		for _, test := range cfg.TestGroups {
 			TestGroups[test.Name] = insert
 		}
+		for _, channel := range cfg.NotificationChannels {
+			Channels[channel.Name] = insert
+		}
 	}
 
 	return &result, nil
@@ -184,3 +197,22 @@ func RenameDashboardGroup(original, new string, cfg *configpb.Configuration) *co
 
 	return cfg
 }
+
+// RenameNotificationChannel renames all references to NotificationChannel 'original' to 'new'.
+// Does not verify if the new name is already taken.
+func RenameNotificationChannel(original, new string, cfg *configpb.Configuration) *configpb.Configuration {
+	for _, channel := range cfg.NotificationChannels {
+		if channel.Name == original {
+			channel.Name = new
+		}
+	}
+	for _, dashboard := range cfg.Dashboards {
+		channels := dashboard.GetNotificationOptions().GetChannels()
+		for i, channel := range channels {
+			if channel == original {
+				channels[i] = new
+			}
+		}
+	}
+	return cfg
+}
@@ -499,6 +499,75 @@ func TestRenameDashboardGroup(t *testing.T) {
 	}
 }
 
+func TestRenameNotificationChannel(t *testing.T) {
+	cases := []struct {
+		name     string
+		old      string
+		new      string
+		input    *configpb.Configuration
+		expected *configpb.Configuration
+	}{
+		{
+			name: "Old string isn't a Notification Channel; do nothing",
+			old:  "foo",
+			new:  "bar",
+			input: &configpb.Configuration{
+				NotificationChannels: []*configpb.NotificationChannel{
+					{Name: "foo-foo"},
+				},
+			},
+			expected: &configpb.Configuration{
+				NotificationChannels: []*configpb.NotificationChannel{
+					{Name: "foo-foo"},
+				},
+			},
+		},
+		{
+			name: "Renames Notification Channel and its references",
+			old:  "foo",
+			new:  "bar",
+			input: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							Channels: []string{"foo", "other"},
+						},
+					},
+					{Name: "quiet"},
+				},
+				NotificationChannels: []*configpb.NotificationChannel{
+					{Name: "foo"},
+				},
+			},
+			expected: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							Channels: []string{"bar", "other"},
+						},
+					},
+					{Name: "quiet"},
+				},
+				NotificationChannels: []*configpb.NotificationChannel{
+					{Name: "bar"},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range cases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := RenameNotificationChannel(testcase.old, testcase.new, testcase.input)
+
+			if !proto.Equal(testcase.expected, result) {
+				t.Errorf("Expected %v, but got %v", testcase.expected, result)
+			}
+		})
+	}
+}
+
 func TestNegotiateConversions(t *testing.T) {
 	cases := []struct {
 		Name     string
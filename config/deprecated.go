@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Deprecation lists where a config sets a field marked as deprecated in config.proto.
+type Deprecation struct {
+	// Field is the full name of the field, such as TestGroup.num_failures_to_alert.
+	Field string `json:"field"`
+	// Count is the number of times the config sets the field.
+	Count int `json:"count"`
+	// Locations are the paths to each of these fields, such as test_groups[foo].num_failures_to_alert.
+	Locations []string `json:"locations"`
+}
+
+func (d Deprecation) String() string {
+	return fmt.Sprintf("%s is deprecated, set %d times: %s", d.Field, d.Count, strings.Join(d.Locations, ", "))
+}
+
+// Deprecations returns the deprecated fields the config sets, sorted by field.
+//
+// Mark a field as deprecated with the [deprecated = true] option in config.proto.
+func Deprecations(c *configpb.Configuration) []Deprecation {
+	found := map[string]*Deprecation{}
+	findDeprecations(proto.MessageReflect(c), "", found)
+	out := make([]Deprecation, 0, len(found))
+	for _, d := range found {
+		out = append(out, *d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Field < out[j].Field
+	})
+	return out
+}
+
+func findDeprecations(msg protoreflect.Message, prefix string, found map[string]*Deprecation) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		where := prefix + string(fd.Name())
+		if deprecated(fd) {
+			name := string(fd.FullName())
+			d, ok := found[name]
+			if !ok {
+				d = &Deprecation{Field: name}
+				found[name] = d
+			}
+			d.Count++
+			d.Locations = append(d.Locations, where)
+		}
+		switch {
+		case fd.IsMap() || fd.Message() == nil:
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				elem := list.Get(i).Message()
+				findDeprecations(elem, fmt.Sprintf("%s[%s].", where, elementKey(elem, i)), found)
+			}
+		default:
+			findDeprecations(v.Message(), where+".", found)
+		}
+		return true
+	})
+}
+
+// elementKey identifies a list element by its name when set, or else its index.
+func elementKey(msg protoreflect.Message, i int) string {
+	if fd := msg.Descriptor().Fields().ByName("name"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		if name := msg.Get(fd).String(); name != "" {
+			return name
+		}
+	}
+	return fmt.Sprint(i)
+}
+
+// deprecated returns true when the field sets the deprecated option.
+func deprecated(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDeprecated()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestDeprecations(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      *configpb.Configuration
+		expected []Deprecation
+	}{
+		{
+			name:     "basically works",
+			cfg:      &configpb.Configuration{},
+			expected: []Deprecation{},
+		},
+		{
+			name: "ignore current fields",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "foo", GcsPrefix: "bucket/foo", DaysOfResults: 7},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name: "tab",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert: 3,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []Deprecation{},
+		},
+		{
+			name: "count deprecated fields",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "foo", NumFailuresToAlert: 3, AlertMailToAddresses: "foo@example.com"},
+					{Name: "bar"},
+					{NumFailuresToAlert: 1},
+				},
+			},
+			expected: []Deprecation{
+				{
+					Field:     "TestGroup.alert_mail_to_addresses",
+					Count:     1,
+					Locations: []string{"test_groups[foo].alert_mail_to_addresses"},
+				},
+				{
+					Field: "TestGroup.num_failures_to_alert",
+					Count: 2,
+					Locations: []string{
+						"test_groups[foo].num_failures_to_alert",
+						"test_groups[2].num_failures_to_alert",
+					},
+				},
+			},
+		},
+		{
+			name: "repeated fields",
+			cfg: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						TabDefaults: &configpb.DashboardTab{
+							Name: "defaults",
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{
+						Name: "group",
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                       "foo",
+						CustomResultEvaluatorRules: []string{"rule"},
+					},
+				},
+			},
+			expected: []Deprecation{
+				{
+					Field:     "TestGroup.custom_result_evaluator_rules",
+					Count:     1,
+					Locations: []string{"test_groups[foo].custom_result_evaluator_rules"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Deprecations(tc.cfg)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Deprecations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
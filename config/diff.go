@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ChangeKind describes how an entity changed.
+type ChangeKind string
+
+// Kinds of changes.
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+)
+
+var changeSymbols = map[ChangeKind]string{
+	Added:    "+",
+	Removed:  "-",
+	Modified: "~",
+}
+
+// Change describes an entity that differs between two configurations.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Entity is the kind of entity, such as TestGroup or DashboardTab.
+	Entity string `json:"entity"`
+	// Name identifies the entity, such as dashboard/tab for a DashboardTab.
+	Name string `json:"name"`
+	// Fields lists the modified fields.
+	Fields []string `json:"fields,omitempty"`
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %s", changeSymbols[c.Kind], c.Entity, c.Name)
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s
+}
+
+// Diff returns the test groups, dashboards, tabs, dashboard groups and notification channels that differ between old and new.
+//
+// Lists changes to test groups first, then dashboards, tabs, dashboard groups and channels, each sorted by name.
+// Changes to the tabs of a dashboard do not modify the dashboard itself.
+func Diff(old, new *configpb.Configuration) []Change {
+	var changes []Change
+	changes = append(changes, diffEntities("TestGroup", testGroups(old), testGroups(new))...)
+	changes = append(changes, diffEntities("Dashboard", dashboards(old), dashboards(new), "dashboard_tab")...)
+	changes = append(changes, diffEntities("DashboardTab", tabs(old), tabs(new))...)
+	changes = append(changes, diffEntities("DashboardGroup", dashboardGroups(old), dashboardGroups(new))...)
+	changes = append(changes, diffEntities("NotificationChannel", notificationChannels(old), notificationChannels(new))...)
+	return changes
+}
+
+func testGroups(c *configpb.Configuration) map[string]proto.Message {
+	out := map[string]proto.Message{}
+	for _, tg := range c.GetTestGroups() {
+		out[tg.Name] = tg
+	}
+	return out
+}
+
+func dashboards(c *configpb.Configuration) map[string]proto.Message {
+	out := map[string]proto.Message{}
+	for _, d := range c.GetDashboards() {
+		out[d.Name] = d
+	}
+	return out
+}
+
+func tabs(c *configpb.Configuration) map[string]proto.Message {
+	out := map[string]proto.Message{}
+	for _, d := range c.GetDashboards() {
+		for _, tab := range d.DashboardTab {
+			out[d.Name+"/"+tab.Name] = tab
+		}
+	}
+	return out
+}
+
+func dashboardGroups(c *configpb.Configuration) map[string]proto.Message {
+	out := map[string]proto.Message{}
+	for _, dg := range c.GetDashboardGroups() {
+		out[dg.Name] = dg
+	}
+	return out
+}
+
+func notificationChannels(c *configpb.Configuration) map[string]proto.Message {
+	out := map[string]proto.Message{}
+	for _, ch := range c.GetNotificationChannels() {
+		out[ch.Name] = ch
+	}
+	return out
+}
+
+func diffEntities(entity string, old, new map[string]proto.Message, ignore ...string) []Change {
+	names := map[string]bool{}
+	for n := range old {
+		names[n] = true
+	}
+	for n := range new {
+		names[n] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for n := range names {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, name := range sorted {
+		o, n := old[name], new[name]
+		switch {
+		case o == nil:
+			changes = append(changes, Change{Kind: Added, Entity: entity, Name: name})
+		case n == nil:
+			changes = append(changes, Change{Kind: Removed, Entity: entity, Name: name})
+		default:
+			if fields := changedFields(o, n, ignore...); len(fields) > 0 {
+				changes = append(changes, Change{Kind: Modified, Entity: entity, Name: name, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// changedFields returns the names of the fields that differ between the messages, in field number order.
+func changedFields(old, new proto.Message, ignore ...string) []string {
+	skip := map[protoreflect.Name]bool{}
+	for _, name := range ignore {
+		skip[protoreflect.Name(name)] = true
+	}
+	o, n := proto.MessageReflect(old), proto.MessageReflect(new)
+	fields := o.Descriptor().Fields()
+	var out []string
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if skip[fd.Name()] {
+			continue
+		}
+		if !fieldEqual(fd, o, n) {
+			out = append(out, string(fd.Name()))
+		}
+	}
+	return out
+}
+
+func fieldEqual(fd protoreflect.FieldDescriptor, a, b protoreflect.Message) bool {
+	if a.Has(fd) != b.Has(fd) {
+		return false
+	}
+	if !a.Has(fd) {
+		return true
+	}
+	// Compare single fields by comparing messages containing only that field.
+	x, y := a.New(), b.New()
+	x.Set(fd, a.Get(fd))
+	y.Set(fd, b.Get(fd))
+	return proto.Equal(proto.MessageV1(x.Interface()), proto.MessageV1(y.Interface()))
+}
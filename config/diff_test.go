@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		old      *configpb.Configuration
+		new      *configpb.Configuration
+		expected []Change
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "no changes",
+			old: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", DaysOfResults: 1}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "tg"}},
+					},
+				},
+			},
+			new: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", DaysOfResults: 1}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "tg"}},
+					},
+				},
+			},
+		},
+		{
+			name: "added and removed",
+			old: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "old-tg"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "old-dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab"}},
+					},
+				},
+			},
+			new: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "new-tg"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "new-dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab"}},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: "group"}},
+			},
+			expected: []Change{
+				{Kind: Added, Entity: "TestGroup", Name: "new-tg"},
+				{Kind: Removed, Entity: "TestGroup", Name: "old-tg"},
+				{Kind: Added, Entity: "Dashboard", Name: "new-dash"},
+				{Kind: Removed, Entity: "Dashboard", Name: "old-dash"},
+				{Kind: Added, Entity: "DashboardTab", Name: "new-dash/tab"},
+				{Kind: Removed, Entity: "DashboardTab", Name: "old-dash/tab"},
+				{Kind: Added, Entity: "DashboardGroup", Name: "group"},
+			},
+		},
+		{
+			name: "modified fields",
+			old: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", DaysOfResults: 1, GcsPrefix: "bucket/a"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name: "tab",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert: 1,
+								},
+							},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{Name: "group", DashboardNames: []string{"dash"}},
+				},
+			},
+			new: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", DaysOfResults: 2, GcsPrefix: "bucket/a"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:                "dash",
+						DownplayFailingTabs: true,
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:        "tab",
+								BaseOptions: "width=10",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert: 2,
+								},
+							},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{Name: "group", DashboardNames: []string{"dash", "other"}},
+				},
+			},
+			expected: []Change{
+				{Kind: Modified, Entity: "TestGroup", Name: "tg", Fields: []string{"days_of_results"}},
+				{Kind: Modified, Entity: "Dashboard", Name: "dash", Fields: []string{"downplay_failing_tabs"}},
+				{Kind: Modified, Entity: "DashboardTab", Name: "dash/tab", Fields: []string{"base_options", "alert_options"}},
+				{Kind: Modified, Entity: "DashboardGroup", Name: "group", Fields: []string{"dashboard_names"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Diff(tc.old, tc.new)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Diff() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	cases := []struct {
+		change   Change
+		expected string
+	}{
+		{
+			change:   Change{Kind: Added, Entity: "TestGroup", Name: "tg"},
+			expected: "+ TestGroup tg",
+		},
+		{
+			change:   Change{Kind: Removed, Entity: "Dashboard", Name: "dash"},
+			expected: "- Dashboard dash",
+		},
+		{
+			change:   Change{Kind: Modified, Entity: "DashboardTab", Name: "dash/tab", Fields: []string{"base_options", "alert_options"}},
+			expected: "~ DashboardTab dash/tab: base_options, alert_options",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := tc.change.String(); actual != tc.expected {
+				t.Errorf("String() got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	multierror "github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// envMessages lists the messages whose string fields ExpandEnv substitutes.
+var envMessages = messageNames(
+	&configpb.LinkTemplate{},
+	&configpb.LinkOptionsTemplate{},
+	&configpb.Notification{},
+	&configpb.DashboardNotificationOptions{},
+	&configpb.GitHubIssueOptions{},
+	&configpb.DashboardGroupNotificationOptions{},
+	&configpb.DashboardTabAlertOptions{},
+	&configpb.DashboardTabFlakinessAlertOptions{},
+	&configpb.NotificationChannel{},
+)
+
+// envFields lists the fields outside of envMessages that ExpandEnv substitutes.
+var envFields = fieldNames(&configpb.TestGroup{}, "gcs_prefix", "additional_gcs_prefixes")
+
+// envSkip lists the fields of envMessages that ExpandEnv leaves as is.
+var envSkip = merge(
+	fieldNames(&configpb.LinkOptionsTemplate{}, "key"),
+	fieldNames(&configpb.NotificationChannel{}, "name"),
+)
+
+func merge(sets ...map[protoreflect.FullName]bool) map[protoreflect.FullName]bool {
+	out := map[protoreflect.FullName]bool{}
+	for _, set := range sets {
+		for name := range set {
+			out[name] = true
+		}
+	}
+	return out
+}
+
+func messageNames(msgs ...proto.Message) map[protoreflect.FullName]bool {
+	out := map[protoreflect.FullName]bool{}
+	for _, m := range msgs {
+		out[proto.MessageReflect(m).Descriptor().FullName()] = true
+	}
+	return out
+}
+
+func fieldNames(msg proto.Message, names ...protoreflect.Name) map[protoreflect.FullName]bool {
+	out := map[protoreflect.FullName]bool{}
+	fields := proto.MessageReflect(msg).Descriptor().Fields()
+	for _, name := range names {
+		out[fields.ByName(name).FullName()] = true
+	}
+	return out
+}
+
+// ExpandEnv substitutes variables in the bucket paths, link templates and notification settings of the config.
+//
+// See Expand for the syntax. Returns an error listing every required variable that is unset.
+// Expands messages shared by several parts of the config, such as tabs, only once.
+func ExpandEnv(c *configpb.Configuration, lookup func(string) (string, bool)) error {
+	return expandEnv(proto.MessageReflect(c), "", false, lookup, map[proto.Message]bool{})
+}
+
+func expandEnv(msg protoreflect.Message, prefix string, all bool, lookup func(string) (string, bool), seen map[proto.Message]bool) error {
+	m := proto.MessageV1(msg.Interface())
+	if seen[m] {
+		return nil
+	}
+	seen[m] = true
+	all = all || envMessages[msg.Descriptor().FullName()]
+	var mErr error
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		where := prefix + string(fd.Name())
+		expand := (all || envFields[fd.FullName()]) && !envSkip[fd.FullName()]
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				where := fmt.Sprintf("%s[%d]", where, i)
+				switch {
+				case fd.Message() != nil:
+					if err := expandEnv(list.Get(i).Message(), where+".", all, lookup, seen); err != nil {
+						mErr = multierror.Append(mErr, err)
+					}
+				case expand && fd.Kind() == protoreflect.StringKind:
+					s, err := Expand(list.Get(i).String(), lookup)
+					if err != nil {
+						mErr = multierror.Append(mErr, fmt.Errorf("%s: %w", where, err))
+						continue
+					}
+					list.Set(i, protoreflect.ValueOfString(s))
+				}
+			}
+		case fd.Message() != nil:
+			if err := expandEnv(v.Message(), where+".", all, lookup, seen); err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+		case expand && fd.Kind() == protoreflect.StringKind:
+			s, err := Expand(v.String(), lookup)
+			if err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s: %w", where, err))
+				break
+			}
+			msg.Set(fd, protoreflect.ValueOfString(s))
+		}
+		return true
+	})
+	return mErr
+}
+
+// Expand substitutes the variables in s with their values according to lookup.
+//
+//	${VAR}            the value of VAR, or empty when unset
+//	${VAR:-default}   the value of VAR, or default when unset or empty
+//	${VAR:?message}   the value of VAR, or an error with message when unset or empty
+//	$${               a literal ${
+//
+// Leaves any other $ as is.
+func Expand(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var out strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			out.WriteString(s)
+			return out.String(), nil
+		}
+		if i > 0 && s[i-1] == '$' {
+			out.WriteString(s[:i-1] + "${")
+			s = s[i+2:]
+			continue
+		}
+		out.WriteString(s[:i])
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in %q", s[i:])
+		}
+		val, err := expandVar(s[i+2:i+end], lookup)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(val)
+		s = s[i+end+1:]
+	}
+}
+
+// expandVar returns the value of a NAME, NAME:-default or NAME:?message expression.
+func expandVar(expr string, lookup func(string) (string, bool)) (string, error) {
+	name, op, arg := expr, "", ""
+	if i := strings.Index(expr, ":"); i >= 0 && i+1 < len(expr) && (expr[i+1] == '-' || expr[i+1] == '?') {
+		name, op, arg = expr[:i], expr[i:i+2], expr[i+2:]
+	}
+	if name == "" {
+		return "", fmt.Errorf("empty variable name in ${%s}", expr)
+	}
+	val, _ := lookup(name)
+	if val != "" || op == "" {
+		return val, nil
+	}
+	if op == ":-" {
+		return arg, nil
+	}
+	if arg == "" {
+		arg = "required"
+	}
+	return "", errors.New(name + ": " + arg)
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func fakeEnv(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+}
+
+func TestExpand(t *testing.T) {
+	env := map[string]string{
+		"BUCKET": "my-bucket",
+		"EMPTY":  "",
+	}
+	cases := []struct {
+		name     string
+		in       string
+		expected string
+		err      bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "no variables",
+			in:       "gs://bucket/$path",
+			expected: "gs://bucket/$path",
+		},
+		{
+			name:     "substitute",
+			in:       "${BUCKET}/logs/${BUCKET}",
+			expected: "my-bucket/logs/my-bucket",
+		},
+		{
+			name:     "unset is empty",
+			in:       "a${MISSING}b",
+			expected: "ab",
+		},
+		{
+			name:     "default when unset",
+			in:       "${MISSING:-fallback}/logs",
+			expected: "fallback/logs",
+		},
+		{
+			name:     "default when empty",
+			in:       "${EMPTY:-fallback}",
+			expected: "fallback",
+		},
+		{
+			name:     "default unused when set",
+			in:       "${BUCKET:-fallback}",
+			expected: "my-bucket",
+		},
+		{
+			name:     "required when set",
+			in:       "${BUCKET:?set the bucket}",
+			expected: "my-bucket",
+		},
+		{
+			name: "required when unset",
+			in:   "${MISSING:?set the bucket}",
+			err:  true,
+		},
+		{
+			name: "required when empty",
+			in:   "${EMPTY:?}",
+			err:  true,
+		},
+		{
+			name:     "escape",
+			in:       "$${BUCKET} ${BUCKET}",
+			expected: "${BUCKET} my-bucket",
+		},
+		{
+			name: "unterminated",
+			in:   "${BUCKET",
+			err:  true,
+		},
+		{
+			name: "empty name",
+			in:   "${:-default}",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Expand(tc.in, fakeEnv(env))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Expand(%q) got unexpected error: %v", tc.in, err)
+				}
+			case tc.err:
+				t.Errorf("Expand(%q) failed to return an error, got %q", tc.in, actual)
+			case actual != tc.expected:
+				t.Errorf("Expand(%q) got %q, want %q", tc.in, actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{
+		"BUCKET": "my-bucket",
+		"TEAM":   "team@example.com",
+		"HOST":   "example.com",
+	}
+	shared := &configpb.DashboardTabAlertOptions{AlertMailToAddresses: "${TEAM},$${TEAM}"}
+	cases := []struct {
+		name     string
+		cfg      *configpb.Configuration
+		expected *configpb.Configuration
+		err      bool
+	}{
+		{
+			name:     "basically works",
+			cfg:      &configpb.Configuration{},
+			expected: &configpb.Configuration{},
+		},
+		{
+			name: "expand supported fields",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                  "${BUCKET}",
+						GcsPrefix:             "${BUCKET}/logs/job",
+						AdditionalGcsPrefixes: []string{"old-${BUCKET}/logs/job"},
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						Notifications: []*configpb.Notification{
+							{Summary: "moved to ${HOST}", ContextLink: "https://${HOST}"},
+						},
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							SlackChannels: []string{"${SLACK:-#testgrid}"},
+						},
+						FileBugTemplate: &configpb.LinkTemplate{
+							Url: "https://${HOST}/new",
+							Options: []*configpb.LinkOptionsTemplate{
+								{Key: "${KEY}", Value: "<test-name> on ${HOST}"},
+							},
+						},
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "${TAB}",
+								TestGroupName: "${BUCKET}",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									AlertMailToAddresses: "${TEAM}",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                  "${BUCKET}",
+						GcsPrefix:             "my-bucket/logs/job",
+						AdditionalGcsPrefixes: []string{"old-my-bucket/logs/job"},
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						Notifications: []*configpb.Notification{
+							{Summary: "moved to example.com", ContextLink: "https://example.com"},
+						},
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							SlackChannels: []string{"#testgrid"},
+						},
+						FileBugTemplate: &configpb.LinkTemplate{
+							Url: "https://example.com/new",
+							Options: []*configpb.LinkOptionsTemplate{
+								{Key: "${KEY}", Value: "<test-name> on example.com"},
+							},
+						},
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "${TAB}",
+								TestGroupName: "${BUCKET}",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									AlertMailToAddresses: "team@example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "expand shared messages once",
+			cfg: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "first", AlertOptions: shared},
+							{Name: "second", AlertOptions: shared},
+						},
+					},
+				},
+			},
+			expected: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name: "first",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									AlertMailToAddresses: "team@example.com,${TEAM}",
+								},
+							},
+							{
+								Name: "second",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									AlertMailToAddresses: "team@example.com,${TEAM}",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing required variable",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:      "group",
+						GcsPrefix: "${MISSING:?set the bucket}/logs",
+					},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ExpandEnv(tc.cfg, fakeEnv(env))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ExpandEnv() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("ExpandEnv() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, tc.cfg, protocmp.Transform()); diff != "" {
+					t.Errorf("ExpandEnv() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
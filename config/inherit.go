@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ApplyTabDefaults fills in the fields of each dashboard tab from the tab_defaults
+// of its dashboard, then from those of each dashboard group containing the dashboard.
+//
+// Fields set on the tab take precedence, where zero values count as unset.
+// Nested messages, such as alert_options, inherit field by field;
+// lists and maps are inherited only when the tab leaves them empty.
+func ApplyTabDefaults(c *configpb.Configuration) {
+	groupDefaults := map[string][]*configpb.DashboardTab{}
+	for _, group := range c.GetDashboardGroups() {
+		if group.TabDefaults == nil {
+			continue
+		}
+		for _, name := range group.DashboardNames {
+			groupDefaults[name] = append(groupDefaults[name], group.TabDefaults)
+		}
+	}
+	for _, dash := range c.GetDashboards() {
+		var defaults []*configpb.DashboardTab
+		if dash.TabDefaults != nil {
+			defaults = append(defaults, dash.TabDefaults)
+		}
+		defaults = append(defaults, groupDefaults[dash.Name]...)
+		for _, tab := range dash.DashboardTab {
+			for _, d := range defaults {
+				// Clone so tabs do not share the default's messages and lists.
+				inherit(proto.MessageReflect(tab), proto.MessageReflect(proto.Clone(d)))
+			}
+		}
+	}
+}
+
+// inherit sets each field of msg that is unset in msg but set in defaults.
+func inherit(msg, defaults protoreflect.Message) {
+	defaults.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case !msg.Has(fd):
+			msg.Set(fd, v)
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			inherit(msg.Mutable(fd).Message(), v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestApplyTabDefaults(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      *configpb.Configuration
+		expected []*configpb.DashboardTab
+	}{
+		{
+			name: "basically works",
+			cfg: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab"}},
+					},
+				},
+			},
+			expected: []*configpb.DashboardTab{{Name: "tab"}},
+		},
+		{
+			name: "inherit dashboard defaults",
+			cfg: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						TabDefaults: &configpb.DashboardTab{
+							NumColumnsRecent: 10,
+							ResultsText:      "results",
+							AlertOptions: &configpb.DashboardTabAlertOptions{
+								NumFailuresToAlert:   3,
+								AlertMailToAddresses: "team@example.com",
+							},
+						},
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "plain"},
+							{
+								Name:             "custom",
+								NumColumnsRecent: 5,
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert: 1,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []*configpb.DashboardTab{
+				{
+					Name:             "plain",
+					NumColumnsRecent: 10,
+					ResultsText:      "results",
+					AlertOptions: &configpb.DashboardTabAlertOptions{
+						NumFailuresToAlert:   3,
+						AlertMailToAddresses: "team@example.com",
+					},
+				},
+				{
+					Name:             "custom",
+					NumColumnsRecent: 5,
+					ResultsText:      "results",
+					AlertOptions: &configpb.DashboardTabAlertOptions{
+						NumFailuresToAlert:   1,
+						AlertMailToAddresses: "team@example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "dashboard defaults take precedence over group defaults",
+			cfg: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						TabDefaults: &configpb.DashboardTab{
+							NumColumnsRecent: 10,
+						},
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:        "tab",
+								BaseOptions: "width=10",
+							},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{
+						Name:           "group",
+						DashboardNames: []string{"dash"},
+						TabDefaults: &configpb.DashboardTab{
+							NumColumnsRecent: 3,
+							BaseOptions:      "width=5",
+							ResultsText:      "group results",
+						},
+					},
+				},
+			},
+			expected: []*configpb.DashboardTab{
+				{
+					Name:             "tab",
+					NumColumnsRecent: 10,
+					BaseOptions:      "width=10",
+					ResultsText:      "group results",
+				},
+			},
+		},
+		{
+			name: "inherit lists when empty",
+			cfg: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						TabDefaults: &configpb.DashboardTab{
+							FileBugTemplate: &configpb.LinkTemplate{
+								Url: "https://bugs",
+								Options: []*configpb.LinkOptionsTemplate{
+									{Key: "title", Value: "<test-name>"},
+								},
+							},
+						},
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab"},
+							{
+								Name: "override",
+								FileBugTemplate: &configpb.LinkTemplate{
+									Options: []*configpb.LinkOptionsTemplate{
+										{Key: "body", Value: "<test-name>"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []*configpb.DashboardTab{
+				{
+					Name: "tab",
+					FileBugTemplate: &configpb.LinkTemplate{
+						Url: "https://bugs",
+						Options: []*configpb.LinkOptionsTemplate{
+							{Key: "title", Value: "<test-name>"},
+						},
+					},
+				},
+				{
+					Name: "override",
+					FileBugTemplate: &configpb.LinkTemplate{
+						Url: "https://bugs",
+						Options: []*configpb.LinkOptionsTemplate{
+							{Key: "body", Value: "<test-name>"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ApplyTabDefaults(tc.cfg)
+			actual := tc.cfg.Dashboards[0].DashboardTab
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("ApplyTabDefaults() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+var (
+	linkField  = regexp.MustCompile(`<([a-z0-9_-]+)>`)
+	linkEncode = regexp.MustCompile(`<encode:((?:<[a-z0-9_-]+>|[^<>])*)>`)
+)
+
+// ExpandLink substitutes the fields into the template's url, appending any options as query parameters.
+//
+// Replaces <encode:...> with the URL encoded expansion of its contents. Leaves unknown fields as is.
+func ExpandLink(tmpl *configpb.LinkTemplate, fields map[string]string) string {
+	if tmpl.GetUrl() == "" {
+		return ""
+	}
+	substitute := func(s string) string {
+		return linkField.ReplaceAllStringFunc(s, func(field string) string {
+			if v, ok := fields[field[1:len(field)-1]]; ok {
+				return v
+			}
+			return field
+		})
+	}
+	expand := func(s string) string {
+		s = linkEncode.ReplaceAllStringFunc(s, func(match string) string {
+			return encodeURIComponent(substitute(linkEncode.FindStringSubmatch(match)[1]))
+		})
+		return substitute(s)
+	}
+	link := expand(tmpl.Url)
+	if len(tmpl.Options) == 0 {
+		return link
+	}
+	vals := url.Values{}
+	for _, opt := range tmpl.Options {
+		vals.Add(opt.Key, expand(opt.Value))
+	}
+	sep := "?"
+	if strings.Contains(link, "?") {
+		sep = "&"
+	}
+	return link + sep + vals.Encode()
+}
+
+// encodeURIComponent escapes the string like the javascript function of the same name.
+func encodeURIComponent(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// sampleLinkFields are typical values of the fields documented for link templates.
+var sampleLinkFields = map[string]string{
+	"environment":      "my-tab",
+	"test-status":      "Failed",
+	"test-id":          "1234",
+	"test-name":        "//pkg:test",
+	"display-name":     "test",
+	"gcs_prefix":       "my-bucket/logs/my-job",
+	"results-explorer": "https://testgrid.example.com/my-dashboard#my-tab",
+	"cs-path":          "src/pkg",
+	"failure-message":  "expected true, got false",
+	"build-id":         "1234",
+	"changelist":       "1234",
+	"start-cl":         "1233",
+	"end-cl":           "1234",
+}
+
+// ValidateLinkTemplate expands the template with sample values, returning an error unless the result is a well-formed URL.
+//
+// The URL must be absolute, with a host when it links to a website.
+// Fields without a sample value, such as <custom-0>, expand to their name.
+func ValidateLinkTemplate(tmpl *configpb.LinkTemplate) error {
+	if tmpl.GetUrl() == "" {
+		if len(tmpl.GetOptions()) > 0 {
+			return errors.New("options without a url")
+		}
+		return nil
+	}
+	fields := map[string]string{}
+	sample := func(s string) {
+		for _, mat := range linkField.FindAllStringSubmatch(s, -1) {
+			name := mat[1]
+			if v, ok := sampleLinkFields[name]; ok {
+				fields[name] = v
+			} else {
+				fields[name] = name
+			}
+		}
+	}
+	sample(tmpl.Url)
+	for _, opt := range tmpl.Options {
+		sample(opt.Value)
+	}
+	link := ExpandLink(tmpl, fields)
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("expands to a malformed url: %w", err)
+	}
+	switch {
+	case u.Scheme == "":
+		return fmt.Errorf("expands to %q, which is not an absolute url", link)
+	case (u.Scheme == "http" || u.Scheme == "https") && u.Host == "":
+		return fmt.Errorf("expands to %q, which has no host", link)
+	}
+	return nil
+}
+
+// ValidateLinkTemplates validates every link template of each dashboard and its tabs.
+func ValidateLinkTemplates(c *configpb.Configuration) error {
+	var mErr error
+	for _, dash := range c.GetDashboards() {
+		if err := ValidateLinkTemplate(dash.FileBugTemplate); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{dash.Name, "Dashboard", "file_bug_template " + err.Error()})
+		}
+		for _, tab := range dash.DashboardTab {
+			for _, t := range []struct {
+				field string
+				tmpl  *configpb.LinkTemplate
+			}{
+				{"open_test_template", tab.OpenTestTemplate},
+				{"file_bug_template", tab.FileBugTemplate},
+				{"attach_bug_template", tab.AttachBugTemplate},
+				{"results_url_template", tab.ResultsUrlTemplate},
+				{"code_search_url_template", tab.CodeSearchUrlTemplate},
+				{"open_bug_template", tab.OpenBugTemplate},
+				{"context_menu_template", tab.ContextMenuTemplate},
+			} {
+				if err := ValidateLinkTemplate(t.tmpl); err != nil {
+					mErr = multierror.Append(mErr, &ConfigError{dash.Name + "/" + tab.Name, "DashboardTab", t.field + " " + err.Error()})
+				}
+			}
+		}
+	}
+	return mErr
+}
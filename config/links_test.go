@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestExpandLink(t *testing.T) {
+	fields := map[string]string{
+		"test-name":       "//foo:bar test",
+		"failure-message": "expected <nil>",
+	}
+	cases := []struct {
+		name     string
+		tmpl     *configpb.LinkTemplate
+		expected string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "substitute fields",
+			tmpl:     &configpb.LinkTemplate{Url: "https://bugs/<test-name>/<unknown>"},
+			expected: "https://bugs///foo:bar test/<unknown>",
+		},
+		{
+			name:     "encode fields",
+			tmpl:     &configpb.LinkTemplate{Url: "https://bugs/new?title=<encode:<test-name> failed: <failure-message>>"},
+			expected: "https://bugs/new?title=%2F%2Ffoo%3Abar%20test%20failed%3A%20expected%20%3Cnil%3E",
+		},
+		{
+			name: "append options",
+			tmpl: &configpb.LinkTemplate{
+				Url: "https://bugs/new?component=1",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "<test-name> failed"},
+				},
+			},
+			expected: "https://bugs/new?component=1&title=%2F%2Ffoo%3Abar+test+failed",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := ExpandLink(tc.tmpl, fields); actual != tc.expected {
+				t.Errorf("ExpandLink() got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestValidateLinkTemplate(t *testing.T) {
+	cases := []struct {
+		name string
+		tmpl *configpb.LinkTemplate
+		err  bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "open test template",
+			tmpl: &configpb.LinkTemplate{Url: "https://prow.example.com/view/gcs/<gcs_prefix>/<changelist>"},
+		},
+		{
+			name: "code search template",
+			tmpl: &configpb.LinkTemplate{Url: "https://github.com/org/repo/compare/<start-custom-0>...<end-custom-0>"},
+		},
+		{
+			name: "options",
+			tmpl: &configpb.LinkTemplate{
+				Url: "https://github.com/org/repo/issues/new",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "<test-name> is failing in <environment>"},
+				},
+			},
+		},
+		{
+			name: "field provides host",
+			tmpl: &configpb.LinkTemplate{Url: "https://<gcs_prefix>"},
+		},
+		{
+			name: "other schemes",
+			tmpl: &configpb.LinkTemplate{Url: "mailto:team@example.com?subject=<encode:<test-name>>"},
+		},
+		{
+			name: "relative",
+			tmpl: &configpb.LinkTemplate{Url: "results/<test-id>"},
+			err:  true,
+		},
+		{
+			name: "missing host",
+			tmpl: &configpb.LinkTemplate{Url: "https:///<test-id>"},
+			err:  true,
+		},
+		{
+			name: "malformed",
+			tmpl: &configpb.LinkTemplate{Url: "https://example.com/%zz/<test-id>"},
+			err:  true,
+		},
+		{
+			name: "options without url",
+			tmpl: &configpb.LinkTemplate{
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "<test-name>"},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLinkTemplate(tc.tmpl)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ValidateLinkTemplate() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("ValidateLinkTemplate() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestValidateLinkTemplates(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name:            "dash",
+				FileBugTemplate: &configpb.LinkTemplate{Url: "bugs"},
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:               "tab",
+						OpenTestTemplate:   &configpb.LinkTemplate{Url: "https://prow.example.com/view/<gcs_prefix>"},
+						ResultsUrlTemplate: &configpb.LinkTemplate{Url: "job-history/<gcs_prefix>"},
+					},
+				},
+			},
+		},
+	}
+	err := ValidateLinkTemplates(cfg)
+	if err == nil {
+		t.Fatal("ValidateLinkTemplates() failed to return an error")
+	}
+	expected := []string{
+		`configuration error for (Dashboard) dash: file_bug_template expands to "bugs", which is not an absolute url`,
+		`configuration error for (DashboardTab) dash/tab: results_url_template expands to "job-history/my-bucket/logs/my-job", which is not an absolute url`,
+	}
+	errs := err.(*multierror.Error).Errors
+	if len(errs) != len(expected) {
+		t.Fatalf("ValidateLinkTemplates() got %d errors, want %d: %v", len(errs), len(expected), err)
+	}
+	for i, e := range errs {
+		if e.Error() != expected[i] {
+			t.Errorf("ValidateLinkTemplates() got error %q, want %q", e, expected[i])
+		}
+	}
+}
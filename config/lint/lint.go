@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint checks a configuration for semantic problems that proto validation misses.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Severity describes how serious a finding is.
+type Severity string
+
+// Severities from least to most severe.
+const (
+	Info    Severity = "info"
+	Warning Severity = "warning"
+	Error   Severity = "error"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case Error:
+		return 2
+	case Warning:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast returns true when s is at least as severe as min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.rank() >= min.rank()
+}
+
+// ParseSeverity returns the named severity.
+func ParseSeverity(name string) (Severity, error) {
+	switch s := Severity(name); s {
+	case Info, Warning, Error:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown severity %q, want one of %s, %s or %s", name, Info, Warning, Error)
+}
+
+// Finding describes a problem with an entity of the configuration.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Entity is the kind of entity, such as TestGroup or DashboardTab.
+	Entity string `json:"entity"`
+	// Name identifies the entity, such as dashboard/tab for a DashboardTab.
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s %s: %s (%s)", f.Severity, f.Entity, f.Name, f.Message, f.Rule)
+}
+
+// Rule checks the configuration for one kind of problem.
+type Rule struct {
+	Name        string
+	Description string
+	// Severity of findings that do not set their own.
+	Severity Severity
+	Check    func(*configpb.Configuration) []Finding
+}
+
+// Lint checks the configuration with each rule, returning the findings sorted by severity, entity and name.
+func Lint(cfg *configpb.Configuration, rules ...Rule) []Finding {
+	var out []Finding
+	for _, rule := range rules {
+		for _, f := range rule.Check(cfg) {
+			f.Rule = rule.Name
+			if f.Severity == "" {
+				f.Severity = rule.Severity
+			}
+			out = append(out, f)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if ra, rb := a.Severity.rank(), b.Severity.rank(); ra != rb {
+			return ra > rb
+		}
+		if a.Entity != b.Entity {
+			return a.Entity < b.Entity
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+// Without returns the rules except those with the named rules.
+func Without(rules []Rule, names ...string) []Rule {
+	skip := map[string]bool{}
+	for _, n := range names {
+		skip[n] = true
+	}
+	var out []Rule
+	for _, r := range rules {
+		if !skip[r.Name] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestLint(t *testing.T) {
+	findings := func(fs ...Finding) func(*configpb.Configuration) []Finding {
+		return func(*configpb.Configuration) []Finding {
+			return fs
+		}
+	}
+	cases := []struct {
+		name     string
+		rules    []Rule
+		expected []Finding
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "sort findings",
+			rules: []Rule{
+				{
+					Name:     "warn",
+					Severity: Warning,
+					Check: findings(
+						Finding{Entity: "TestGroup", Name: "b"},
+						Finding{Entity: "TestGroup", Name: "a"},
+					),
+				},
+				{
+					Name:     "err",
+					Severity: Error,
+					Check: findings(
+						Finding{Entity: "TestGroup", Name: "c"},
+						Finding{Entity: "Dashboard", Name: "d"},
+					),
+				},
+			},
+			expected: []Finding{
+				{Rule: "err", Severity: Error, Entity: "Dashboard", Name: "d"},
+				{Rule: "err", Severity: Error, Entity: "TestGroup", Name: "c"},
+				{Rule: "warn", Severity: Warning, Entity: "TestGroup", Name: "a"},
+				{Rule: "warn", Severity: Warning, Entity: "TestGroup", Name: "b"},
+			},
+		},
+		{
+			name: "finding severity overrides rule",
+			rules: []Rule{
+				{
+					Name:     "mixed",
+					Severity: Warning,
+					Check: findings(
+						Finding{Entity: "TestGroup", Name: "a"},
+						Finding{Entity: "TestGroup", Name: "b", Severity: Info},
+					),
+				},
+			},
+			expected: []Finding{
+				{Rule: "mixed", Severity: Warning, Entity: "TestGroup", Name: "a"},
+				{Rule: "mixed", Severity: Info, Entity: "TestGroup", Name: "b"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Lint(&configpb.Configuration{}, tc.rules...)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Lint() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	cases := []struct {
+		name     string
+		severity Severity
+		min      Severity
+		expected bool
+	}{
+		{
+			name:     "same",
+			severity: Warning,
+			min:      Warning,
+			expected: true,
+		},
+		{
+			name:     "more severe",
+			severity: Error,
+			min:      Info,
+			expected: true,
+		},
+		{
+			name:     "less severe",
+			severity: Warning,
+			min:      Error,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.severity.AtLeast(tc.min); actual != tc.expected {
+				t.Errorf("%s.AtLeast(%s) got %t, want %t", tc.severity, tc.min, actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected Severity
+		err      bool
+	}{
+		{
+			name:     "error",
+			expected: Error,
+		},
+		{
+			name:     "info",
+			expected: Info,
+		},
+		{
+			name: "fatal",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseSeverity(tc.name)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ParseSeverity() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("ParseSeverity() failed to return an error")
+			case actual != tc.expected:
+				t.Errorf("ParseSeverity() got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWithout(t *testing.T) {
+	rules := []Rule{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	var actual []string
+	for _, r := range Without(rules, "b", "missing") {
+		actual = append(actual, r.Name)
+	}
+	if diff := cmp.Diff([]string{"a", "c"}, actual); diff != "" {
+		t.Errorf("Without() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
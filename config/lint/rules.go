@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Rules returns the default rules.
+func Rules() []Rule {
+	return []Rule{
+		{
+			Name:        "missing-test-group",
+			Description: "Dashboard tabs must display a test group that exists",
+			Severity:    Error,
+			Check:       missingTestGroups,
+		},
+		{
+			Name:        "unused-test-group",
+			Description: "Test groups should be displayed by at least one dashboard tab",
+			Severity:    Warning,
+			Check:       unusedTestGroups,
+		},
+		{
+			Name:        "invalid-regex",
+			Description: "Regular expressions in base_options and grouping_regex must compile",
+			Severity:    Error,
+			Check:       invalidRegexes,
+		},
+		{
+			Name:        "invalid-email",
+			Description: "Alert and report recipients must be comma-separated email addresses",
+			Severity:    Error,
+			Check:       invalidEmails,
+		},
+		{
+			Name:        "duplicate-name",
+			Description: "Names must be unique after normalizing",
+			Severity:    Error,
+			Check:       duplicateNames,
+		},
+		{
+			Name:        "invalid-link-template",
+			Description: "Link templates must expand to well-formed absolute URLs",
+			Severity:    Error,
+			Check:       invalidLinkTemplates,
+		},
+		{
+			Name:        "unowned-dashboard",
+			Description: "Dashboards should set an ownership, or belong to a dashboard group that does",
+			Severity:    Info,
+			Check:       unownedDashboards,
+		},
+		{
+			Name:        "deprecated-field",
+			Description: "Fields marked as deprecated in config.proto should be replaced",
+			Severity:    Warning,
+			Check:       deprecatedFields,
+		},
+	}
+}
+
+func invalidLinkTemplates(cfg *configpb.Configuration) []Finding {
+	var mErr *multierror.Error
+	if !errors.As(config.ValidateLinkTemplates(cfg), &mErr) {
+		return nil
+	}
+	var out []Finding
+	for _, err := range mErr.Errors {
+		var cErr *config.ConfigError
+		if !errors.As(err, &cErr) {
+			continue
+		}
+		out = append(out, Finding{
+			Entity:  cErr.Entity,
+			Name:    cErr.Name,
+			Message: cErr.Message,
+		})
+	}
+	return out
+}
+
+func unownedDashboards(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	for _, dash := range cfg.GetDashboards() {
+		o := config.FindOwnership(dash.Name, cfg)
+		if len(o.GetOwners()) > 0 || o.GetTeam() != "" || o.GetContact() != "" {
+			continue
+		}
+		out = append(out, Finding{
+			Entity:  "Dashboard",
+			Name:    dash.Name,
+			Message: "no owners, team or contact",
+		})
+	}
+	return out
+}
+
+func deprecatedFields(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	for _, d := range config.Deprecations(cfg) {
+		out = append(out, Finding{
+			Entity:  "Field",
+			Name:    d.Field,
+			Message: fmt.Sprintf("deprecated, set %d times: %s", d.Count, strings.Join(d.Locations, ", ")),
+		})
+	}
+	return out
+}
+
+func tabName(dash *configpb.Dashboard, tab *configpb.DashboardTab) string {
+	return dash.Name + "/" + tab.Name
+}
+
+func missingTestGroups(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	for _, ref := range config.MissingTestGroups(cfg) {
+		out = append(out, Finding{
+			Entity:  "DashboardTab",
+			Name:    ref.Dashboard + "/" + ref.Tab.Name,
+			Message: fmt.Sprintf("test group %q does not exist", ref.Tab.TestGroupName),
+		})
+	}
+	return out
+}
+
+func unusedTestGroups(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	for _, tg := range config.UnusedTestGroups(cfg) {
+		out = append(out, Finding{
+			Entity:  "TestGroup",
+			Name:    tg.Name,
+			Message: "not displayed by any dashboard tab",
+		})
+	}
+	return out
+}
+
+func invalidRegexes(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	for _, dash := range cfg.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			bad := func(msg string, args ...interface{}) {
+				out = append(out, Finding{
+					Entity:  "DashboardTab",
+					Name:    tabName(dash, tab),
+					Message: fmt.Sprintf(msg, args...),
+				})
+			}
+			vals, err := url.ParseQuery(tab.BaseOptions)
+			if err != nil {
+				bad("bad base_options %q: %v", tab.BaseOptions, err)
+			}
+			keys := make([]string, 0, len(vals))
+			for k := range vals {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if !strings.Contains(k, "regex") {
+					continue
+				}
+				for _, v := range vals[k] {
+					if _, err := regexp.Compile(v); err != nil {
+						bad("bad base_options %s=%s: %v", k, v, err)
+					}
+				}
+			}
+			if re := tab.GetHealthAnalysisOptions().GetGroupingRegex(); re != "" {
+				if _, err := regexp.Compile(re); err != nil {
+					bad("bad health_analysis_options.grouping_regex %q: %v", re, err)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// badAddresses returns the entries of the comma-separated list that are not plain email addresses.
+func badAddresses(addresses string) []string {
+	var bad []string
+	for _, a := range strings.Split(addresses, ",") {
+		a = strings.TrimSpace(a)
+		if addr, err := mail.ParseAddress(a); err != nil || addr.Address != a {
+			bad = append(bad, a)
+		}
+	}
+	return bad
+}
+
+func invalidEmails(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	check := func(entity, name, field, addresses string) {
+		if addresses == "" {
+			return
+		}
+		if bad := badAddresses(addresses); len(bad) > 0 {
+			out = append(out, Finding{
+				Entity:  entity,
+				Name:    name,
+				Message: fmt.Sprintf("bad %s addresses: %q", field, bad),
+			})
+		}
+	}
+	for _, tg := range cfg.GetTestGroups() {
+		check("TestGroup", tg.Name, "alert_mail_to_addresses", tg.AlertMailToAddresses)
+	}
+	for _, dash := range cfg.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			name := tabName(dash, tab)
+			check("DashboardTab", name, "alert_options.alert_mail_to_addresses", tab.GetAlertOptions().GetAlertMailToAddresses())
+			check("DashboardTab", name, "flakiness_alert_options.alert_mail_to_addresses", tab.GetFlakinessAlertOptions().GetAlertMailToAddresses())
+			check("DashboardTab", name, "health_analysis_options.email_recipients", tab.GetHealthAnalysisOptions().GetEmailRecipients())
+		}
+	}
+	return out
+}
+
+type entityName struct {
+	entity string
+	name   string
+}
+
+// duplicates reports each entity whose normalized name matches that of an earlier one.
+func duplicates(entities []entityName, display func(entityName) string) []Finding {
+	names := make([]string, len(entities))
+	for i, e := range entities {
+		names[i] = e.name
+	}
+	dups := config.Duplicates(names)
+	var out []Finding
+	for i, e := range entities {
+		j, ok := dups[i]
+		if !ok {
+			continue
+		}
+		out = append(out, Finding{
+			Entity:  e.entity,
+			Name:    display(e),
+			Message: fmt.Sprintf("name duplicates %q after normalizing", names[j]),
+		})
+	}
+	return out
+}
+
+func duplicateNames(cfg *configpb.Configuration) []Finding {
+	name := func(e entityName) string { return e.name }
+	var groups []entityName
+	for _, tg := range cfg.GetTestGroups() {
+		groups = append(groups, entityName{"TestGroup", tg.Name})
+	}
+	out := duplicates(groups, name)
+
+	// Dashboards and dashboard groups share a namespace.
+	var dashes []entityName
+	for _, dash := range cfg.GetDashboards() {
+		dashes = append(dashes, entityName{"Dashboard", dash.Name})
+		var tabs []entityName
+		for _, tab := range dash.DashboardTab {
+			tabs = append(tabs, entityName{"DashboardTab", tab.Name})
+		}
+		out = append(out, duplicates(tabs, func(e entityName) string { return dash.Name + "/" + e.name })...)
+	}
+	for _, dg := range cfg.GetDashboardGroups() {
+		dashes = append(dashes, entityName{"DashboardGroup", dg.Name})
+	}
+	return append(out, duplicates(dashes, name)...)
+}
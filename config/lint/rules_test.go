@@ -0,0 +1,317 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      *configpb.Configuration
+		expected []Finding
+	}{
+		{
+			name: "basically works",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab",
+								TestGroupName: "group",
+								BaseOptions:   "include-filter-by-regex=^foo&width=10",
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									AlertMailToAddresses: "a@example.com, b@example.com",
+								},
+							},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{
+						Name:           "dash-group",
+						DashboardNames: []string{"dash"},
+					},
+				},
+			},
+		},
+		{
+			name: "missing and unused test groups",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "unused"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", TestGroupName: "missing"},
+						},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     "missing-test-group",
+					Severity: Error,
+					Entity:   "DashboardTab",
+					Name:     "dash/tab",
+					Message:  `test group "missing" does not exist`,
+				},
+				{
+					Rule:     "unused-test-group",
+					Severity: Warning,
+					Entity:   "TestGroup",
+					Name:     "unused",
+					Message:  "not displayed by any dashboard tab",
+				},
+			},
+		},
+		{
+			name: "invalid regexes",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab",
+								TestGroupName: "group",
+								BaseOptions:   "exclude-filter-by-regex=(&group-by-regex-mask=ok",
+								HealthAnalysisOptions: &configpb.HealthAnalysisOptions{
+									GroupingRegex: "[",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     "invalid-regex",
+					Severity: Error,
+					Entity:   "DashboardTab",
+					Name:     "dash/tab",
+					Message:  "bad base_options exclude-filter-by-regex=(: error parsing regexp: missing closing ): `(`",
+				},
+				{
+					Rule:     "invalid-regex",
+					Severity: Error,
+					Entity:   "DashboardTab",
+					Name:     "dash/tab",
+					Message:  "bad health_analysis_options.grouping_regex \"[\": error parsing regexp: missing closing ]: `[`",
+				},
+			},
+		},
+		{
+			name: "invalid emails",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                 "group",
+						AlertMailToAddresses: "ok@example.com,nope",
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab",
+								TestGroupName: "group",
+								FlakinessAlertOptions: &configpb.DashboardTabFlakinessAlertOptions{
+									AlertMailToAddresses: "Team <team@example.com>",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     "invalid-email",
+					Severity: Error,
+					Entity:   "DashboardTab",
+					Name:     "dash/tab",
+					Message:  `bad flakiness_alert_options.alert_mail_to_addresses addresses: ["Team <team@example.com>"]`,
+				},
+				{
+					Rule:     "invalid-email",
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "group",
+					Message:  `bad alert_mail_to_addresses addresses: ["nope"]`,
+				},
+				{
+					Rule:     "deprecated-field",
+					Severity: Warning,
+					Entity:   "Field",
+					Name:     "TestGroup.alert_mail_to_addresses",
+					Message:  "deprecated, set 1 times: test_groups[group].alert_mail_to_addresses",
+				},
+			},
+		},
+		{
+			name: "duplicate names",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group"}, {Name: "Group"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "some-tab", TestGroupName: "group"},
+							{Name: "some tab", TestGroupName: "Group"},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: "DASH"}},
+			},
+			expected: []Finding{
+				{
+					Rule:     "duplicate-name",
+					Severity: Error,
+					Entity:   "DashboardGroup",
+					Name:     "DASH",
+					Message:  `name duplicates "dash" after normalizing`,
+				},
+				{
+					Rule:     "duplicate-name",
+					Severity: Error,
+					Entity:   "DashboardTab",
+					Name:     "dash/some tab",
+					Message:  `name duplicates "some-tab" after normalizing`,
+				},
+				{
+					Rule:     "duplicate-name",
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "Group",
+					Message:  `name duplicates "group" after normalizing`,
+				},
+			},
+		},
+		{
+			name: "invalid link templates",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:               "tab",
+								TestGroupName:      "group",
+								OpenTestTemplate:   &configpb.LinkTemplate{Url: "https://prow.example.com/view/gcs/<gcs_prefix>/<changelist>"},
+								ResultsUrlTemplate: &configpb.LinkTemplate{Url: "job-history/<gcs_prefix>"},
+							},
+						},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     "invalid-link-template",
+					Severity: Error,
+					Entity:   "DashboardTab",
+					Name:     "dash/tab",
+					Message:  `results_url_template expands to "job-history/my-bucket/logs/my-job", which is not an absolute url`,
+				},
+			},
+		},
+		{
+			name: "deprecated fields",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "group", NumFailuresToAlert: 3},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", TestGroupName: "group"},
+						},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     "deprecated-field",
+					Severity: Warning,
+					Entity:   "Field",
+					Name:     "TestGroup.num_failures_to_alert",
+					Message:  "deprecated, set 1 times: test_groups[group].num_failures_to_alert",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Few of these configs bother with ownership, see TestUnownedDashboards.
+			actual := Lint(tc.cfg, Without(Rules(), "unowned-dashboard")...)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Lint() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnownedDashboards(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{Name: "owned", Ownership: &configpb.Ownership{Owners: []string{"alice"}}},
+			{Name: "grouped"},
+			{Name: "orphaned"},
+			{Name: "empty", Ownership: &configpb.Ownership{}},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "group", DashboardNames: []string{"grouped"}, Ownership: &configpb.Ownership{Team: "team"}},
+		},
+	}
+	expected := []Finding{
+		{
+			Rule:     "unowned-dashboard",
+			Severity: Info,
+			Entity:   "Dashboard",
+			Name:     "empty",
+			Message:  "no owners, team or contact",
+		},
+		{
+			Rule:     "unowned-dashboard",
+			Severity: Info,
+			Entity:   "Dashboard",
+			Name:     "orphaned",
+			Message:  "no owners, team or contact",
+		},
+	}
+	var rules []Rule
+	for _, r := range Rules() {
+		if r.Name == "unowned-dashboard" {
+			rules = append(rules, r)
+		}
+	}
+	if diff := cmp.Diff(expected, Lint(cfg, rules...)); diff != "" {
+		t.Errorf("Lint() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
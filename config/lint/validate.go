@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"errors"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ValidateRule names the findings of Validation.
+const ValidateRule = "validate"
+
+// Validation returns an error finding for each problem config.Validate reports.
+func Validation(cfg *configpb.Configuration) []Finding {
+	var out []Finding
+	for _, err := range flatten(config.Validate(cfg)) {
+		f := Finding{
+			Rule:     ValidateRule,
+			Severity: Error,
+			Entity:   "Configuration",
+			Message:  err.Error(),
+		}
+		if cfgErr := configError(err); cfgErr != nil {
+			f.Entity, f.Name = cfgErr.Entity, cfgErr.Name
+			for _, msg := range messages(cfgErr.Message) {
+				f.Message = msg
+				out = append(out, f)
+			}
+			continue
+		}
+		var dupErr config.DuplicateNameError
+		var missingErr config.MissingEntityError
+		var fieldErr config.MissingFieldError
+		switch {
+		case errors.As(err, &dupErr):
+			f.Entity, f.Name = dupErr.Entity, dupErr.Name
+			f.Message = "duplicate name after normalizing"
+		case errors.As(err, &missingErr):
+			f.Entity, f.Name = missingErr.Entity, missingErr.Name
+			f.Message = "referenced but does not exist"
+		case errors.As(err, &fieldErr):
+			f.Name = fieldErr.Field
+			f.Message = "missing or unset"
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// configError returns the ConfigError that err wraps by value or pointer, or nil.
+func configError(err error) *config.ConfigError {
+	var ptr *config.ConfigError
+	if errors.As(err, &ptr) {
+		return ptr
+	}
+	var val config.ConfigError
+	if errors.As(err, &val) {
+		return &val
+	}
+	return nil
+}
+
+// flatten returns the errors of nested multierrors.
+func flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var mErr *multierror.Error
+	if !errors.As(err, &mErr) {
+		return []error{err}
+	}
+	var out []error
+	for _, e := range mErr.Errors {
+		out = append(out, flatten(e)...)
+	}
+	return out
+}
+
+// messages splits the text of a multierror into its messages.
+func messages(msg string) []string {
+	var out []string
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(line, "\t* ") {
+			out = append(out, strings.TrimPrefix(line, "\t* "))
+		}
+	}
+	if len(out) == 0 {
+		return []string{msg}
+	}
+	return out
+}
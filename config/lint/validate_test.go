@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestValidation(t *testing.T) {
+	group := func(name string) *configpb.TestGroup {
+		return &configpb.TestGroup{
+			Name:             name,
+			GcsPrefix:        "bucket/" + name,
+			DaysOfResults:    1,
+			NumColumnsRecent: 1,
+		}
+	}
+	tab := func(name, group string) *configpb.DashboardTab {
+		return &configpb.DashboardTab{Name: name, TestGroupName: group}
+	}
+	cases := []struct {
+		name     string
+		cfg      *configpb.Configuration
+		expected []Finding
+	}{
+		{
+			name: "basically works",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{group("group")},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{tab("tab", "group")},
+					},
+				},
+			},
+		},
+		{
+			name: "missing field",
+			cfg:  &configpb.Configuration{},
+			expected: []Finding{
+				{
+					Rule:     ValidateRule,
+					Severity: Error,
+					Entity:   "Configuration",
+					Name:     "TestGroups",
+					Message:  "missing or unset",
+				},
+			},
+		},
+		{
+			name: "split entity errors",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{tab("tab", "group")},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     ValidateRule,
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "group",
+					Message:  "gcs_prefix can't be empty",
+				},
+				{
+					Rule:     ValidateRule,
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "group",
+					Message:  "days_of_results should be positive",
+				},
+				{
+					Rule:     ValidateRule,
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "group",
+					Message:  "num_columns_recent should be positive",
+				},
+			},
+		},
+		{
+			name: "duplicate and missing entities",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{group("group"), group("Group")},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							tab("tab", "group"),
+							tab("other", "Group"),
+							tab("missing", "gone"),
+						},
+					},
+				},
+			},
+			expected: []Finding{
+				{
+					Rule:     ValidateRule,
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "group",
+					Message:  "duplicate name after normalizing",
+				},
+				{
+					Rule:     ValidateRule,
+					Severity: Error,
+					Entity:   "TestGroup",
+					Name:     "gone",
+					Message:  "referenced but does not exist",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Validation(tc.cfg)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Validation() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
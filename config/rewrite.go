@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// RewritePaths moves every path under from in the config to the same path under to.
+//
+// Matches both gs://bucket/prefix urls and bare bucket/prefix values, such as gcs_prefix.
+// Returns the number of values it changed.
+func RewritePaths(c *configpb.Configuration, from, to gcs.Path) int {
+	old, new := pathRoot(from), pathRoot(to)
+	return rewriteStrings(proto.MessageReflect(c), func(s string) (string, bool) {
+		scheme := ""
+		if strings.HasPrefix(s, "gs://") {
+			scheme, s = "gs://", strings.TrimPrefix(s, "gs://")
+		}
+		if s != old && !strings.HasPrefix(s, old+"/") {
+			return "", false
+		}
+		return scheme + new + strings.TrimPrefix(s, old), true
+	})
+}
+
+// pathRoot returns the path as bucket/prefix, without any trailing slash.
+func pathRoot(p gcs.Path) string {
+	return strings.TrimSuffix(p.Bucket()+"/"+p.Object(), "/")
+}
+
+// rewriteStrings replaces each string in msg and its nested messages that replace changes.
+func rewriteStrings(msg protoreflect.Message, replace func(string) (string, bool)) int {
+	var n int
+	rewrite := func(fd protoreflect.FieldDescriptor, v protoreflect.Value, set func(protoreflect.Value)) {
+		switch {
+		case fd.Message() != nil:
+			n += rewriteStrings(v.Message(), replace)
+		case fd.Kind() == protoreflect.StringKind:
+			if s, ok := replace(v.String()); ok {
+				set(protoreflect.ValueOfString(s))
+				n++
+			}
+		}
+	}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				i := i
+				rewrite(fd, list.Get(i), func(v protoreflect.Value) { list.Set(i, v) })
+			}
+		case fd.IsMap():
+			m := msg.Mutable(fd).Map()
+			m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				rewrite(fd.MapValue(), v, func(v protoreflect.Value) { m.Set(k, v) })
+				return true
+			})
+		case fd.Message() != nil:
+			rewrite(fd, msg.Mutable(fd), nil)
+		default:
+			rewrite(fd, v, func(v protoreflect.Value) { msg.Set(fd, v) })
+		}
+		return true
+	})
+	return n
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestRewritePaths(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("NewPath(%q): %v", s, err)
+		}
+		return *p
+	}
+	cases := []struct {
+		name     string
+		from     string
+		to       string
+		cfg      *configpb.Configuration
+		expected *configpb.Configuration
+		changed  int
+	}{
+		{
+			name: "basically works",
+			from: "gs://old/prefix",
+			to:   "gs://new/other",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "moved", GcsPrefix: "old/prefix/logs/moved"},
+					{Name: "root", GcsPrefix: "old/prefix"},
+					{Name: "similar", GcsPrefix: "old/prefixed/logs"},
+					{Name: "elsewhere", GcsPrefix: "other/prefix/logs"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "tab",
+								OpenTestTemplate: &configpb.LinkTemplate{Url: "gs://old/prefix/logs/<build>"},
+							},
+						},
+					},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "moved", GcsPrefix: "new/other/logs/moved"},
+					{Name: "root", GcsPrefix: "new/other"},
+					{Name: "similar", GcsPrefix: "old/prefixed/logs"},
+					{Name: "elsewhere", GcsPrefix: "other/prefix/logs"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "tab",
+								OpenTestTemplate: &configpb.LinkTemplate{Url: "gs://new/other/logs/<build>"},
+							},
+						},
+					},
+				},
+			},
+			changed: 3,
+		},
+		{
+			name: "whole bucket",
+			from: "gs://old/",
+			to:   "gs://new",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group", GcsPrefix: "old/logs"}},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group", GcsPrefix: "new/logs"}},
+			},
+			changed: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := RewritePaths(tc.cfg, mustPath(tc.from), mustPath(tc.to))
+			if diff := cmp.Diff(tc.expected, tc.cfg, protocmp.Transform()); diff != "" {
+				t.Errorf("RewritePaths() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if changed != tc.changed {
+				t.Errorf("RewritePaths() changed %d values, want %d", changed, tc.changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// IndexName is the name of the index object of a split configuration under its path.
+const IndexName = "index"
+
+// ungroupedShard holds the dashboards and test groups outside of any dashboard group, along with every notification channel.
+//
+// Normalized group names never start with an underscore.
+const ungroupedShard = "_ungrouped"
+
+// Split returns a configuration for each dashboard group, keyed by normalized group name.
+//
+// Each shard holds the group, its dashboards and their test groups.
+// Dashboards outside of any group, unreferenced test groups and notification channels share another shard.
+func Split(c *configpb.Configuration) map[string]*configpb.Configuration {
+	groups := map[string]*configpb.TestGroup{}
+	for _, tg := range c.GetTestGroups() {
+		groups[tg.Name] = tg
+	}
+	dashes := map[string]*configpb.Dashboard{}
+	for _, d := range c.GetDashboards() {
+		dashes[d.Name] = d
+	}
+
+	shards := map[string]*configpb.Configuration{}
+	placedDashes := map[string]bool{}
+	placedGroups := map[string]bool{}
+	addDashboard := func(shard *configpb.Configuration, d *configpb.Dashboard) {
+		placedDashes[d.Name] = true
+		shard.Dashboards = append(shard.Dashboards, d)
+		seen := map[string]bool{}
+		for _, tab := range d.DashboardTab {
+			tg, ok := groups[tab.TestGroupName]
+			if !ok || seen[tg.Name] {
+				continue
+			}
+			seen[tg.Name] = true
+			placedGroups[tg.Name] = true
+			shard.TestGroups = append(shard.TestGroups, tg)
+		}
+	}
+	for _, dg := range c.GetDashboardGroups() {
+		shard := &configpb.Configuration{DashboardGroups: []*configpb.DashboardGroup{dg}}
+		for _, name := range dg.DashboardNames {
+			if d, ok := dashes[name]; ok && !placedDashes[name] {
+				addDashboard(shard, d)
+			}
+		}
+		shards[Normalize(dg.Name)] = shard
+	}
+	rest := &configpb.Configuration{}
+	for _, d := range c.GetDashboards() {
+		if !placedDashes[d.Name] {
+			addDashboard(rest, d)
+		}
+	}
+	for _, tg := range c.GetTestGroups() {
+		if !placedGroups[tg.Name] {
+			placedGroups[tg.Name] = true
+			rest.TestGroups = append(rest.TestGroups, tg)
+		}
+	}
+	rest.NotificationChannels = c.GetNotificationChannels()
+	if len(rest.Dashboards) > 0 || len(rest.TestGroups) > 0 || len(rest.NotificationChannels) > 0 {
+		shards[ungroupedShard] = rest
+	}
+	return shards
+}
+
+// join combines the shards into a single configuration, keeping the first test group, dashboard or channel of each name.
+func join(shards []*configpb.Configuration) *configpb.Configuration {
+	var out configpb.Configuration
+	groups := map[string]bool{}
+	dashes := map[string]bool{}
+	channels := map[string]bool{}
+	for _, shard := range shards {
+		for _, tg := range shard.TestGroups {
+			if !groups[tg.Name] {
+				groups[tg.Name] = true
+				out.TestGroups = append(out.TestGroups, tg)
+			}
+		}
+		for _, d := range shard.Dashboards {
+			if !dashes[d.Name] {
+				dashes[d.Name] = true
+				out.Dashboards = append(out.Dashboards, d)
+			}
+		}
+		out.DashboardGroups = append(out.DashboardGroups, shard.DashboardGroups...)
+		for _, ch := range shard.NotificationChannels {
+			if !channels[ch.Name] {
+				channels[ch.Name] = true
+				out.NotificationChannels = append(out.NotificationChannels, ch)
+			}
+		}
+	}
+	return &out
+}
+
+// WriteSplit writes each shard of the configuration under path, followed by an index listing them.
+//
+// Names shards after a hash of their contents, so readers see either the previous
+// or the new set of shards. Does not delete shards of previous indexes.
+func WriteSplit(ctx context.Context, client gcs.Uploader, path gcs.Path, c *configpb.Configuration) error {
+	shards := Split(c)
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var index configpb.ConfigurationIndex
+	for _, name := range names {
+		buf, err := proto.Marshal(shards[name])
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
+		rel := fmt.Sprintf("groups/%s-%x", name, sha256.Sum256(buf))
+		shardPath, err := splitPath(path, rel)
+		if err != nil {
+			return err
+		}
+		if err := client.Upload(ctx, *shardPath, buf, gcs.DefaultAcl, "no-cache"); err != nil {
+			return fmt.Errorf("upload %s: %w", shardPath, err)
+		}
+		index.Shards = append(index.Shards, rel)
+	}
+
+	buf, err := proto.Marshal(&index)
+	if err != nil {
+		return fmt.Errorf("marshal index: %w", err)
+	}
+	indexPath, err := splitPath(path, IndexName)
+	if err != nil {
+		return err
+	}
+	if err := client.Upload(ctx, *indexPath, buf, gcs.DefaultAcl, "no-cache"); err != nil {
+		return fmt.Errorf("upload %s: %w", indexPath, err)
+	}
+	return nil
+}
+
+// splitPath returns the path of the object named rel under the split configuration at configPath.
+func splitPath(configPath gcs.Path, rel string) (*gcs.Path, error) {
+	p, err := configPath.ResolveReference(&url.URL{Path: path.Base(configPath.Object()) + "/" + rel})
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", rel, err)
+	}
+	return p, nil
+}
+
+// readSplitGCS reads the index under path and each of its shards in parallel.
+func readSplitGCS(ctx context.Context, opener gcs.Opener, path gcs.Path) (*configpb.Configuration, error) {
+	indexPath, err := splitPath(path, IndexName)
+	if err != nil {
+		return nil, err
+	}
+	var index configpb.ConfigurationIndex
+	if err := readProtoGCS(ctx, opener, *indexPath, &index); err != nil {
+		return nil, fmt.Errorf("read index: %w", err)
+	}
+	shards := make([]*configpb.Configuration, len(index.Shards))
+	errs := make([]error, len(index.Shards))
+	var wg sync.WaitGroup
+	for i, rel := range index.Shards {
+		wg.Add(1)
+		go func(i int, rel string) {
+			defer wg.Done()
+			p, err := indexPath.ResolveReference(&url.URL{Path: rel})
+			if err != nil {
+				errs[i] = fmt.Errorf("resolve %s: %w", rel, err)
+				return
+			}
+			var shard configpb.Configuration
+			if err := readProtoGCS(ctx, opener, *p, &shard); err != nil {
+				errs[i] = fmt.Errorf("read %s: %w", rel, err)
+				return
+			}
+			shards[i] = &shard
+		}(i, rel)
+	}
+	wg.Wait()
+	var mErr error
+	for _, err := range errs {
+		if err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	if mErr != nil {
+		return nil, mErr
+	}
+	return join(shards), nil
+}
+
+func readProtoGCS(ctx context.Context, opener gcs.Opener, path gcs.Path, msg proto.Message) error {
+	r, err := opener.Open(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(buf, msg)
+}
+
+// readSplitPath reads the index in the local directory and each of its shards.
+func readSplitPath(dir string) (*configpb.Configuration, error) {
+	var index configpb.ConfigurationIndex
+	if err := readProtoPath(filepath.Join(dir, IndexName), &index); err != nil {
+		return nil, fmt.Errorf("read index: %w", err)
+	}
+	var shards []*configpb.Configuration
+	for _, rel := range index.Shards {
+		var shard configpb.Configuration
+		if err := readProtoPath(filepath.Join(dir, filepath.FromSlash(rel)), &shard); err != nil {
+			return nil, fmt.Errorf("read %s: %w", rel, err)
+		}
+		shards = append(shards, &shard)
+	}
+	return join(shards), nil
+}
+
+func readProtoPath(path string, msg proto.Message) error {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(buf, msg)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
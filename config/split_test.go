@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type fakeObjects map[string][]byte
+
+func (fo fakeObjects) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	buf, ok := fo[path.String()]
+	if !ok {
+		return nil, fmt.Errorf("wrap not exist: %w", storage.ErrObjectNotExist)
+	}
+	return ioutil.NopCloser(bytes.NewReader(buf)), nil
+}
+
+func (fo fakeObjects) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, _ string) error {
+	fo[path.String()] = buf
+	return nil
+}
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("bad path %q: %v", s, err)
+	}
+	return *p
+}
+
+func splitConfig() *configpb.Configuration {
+	return &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "tg-a"},
+			{Name: "tg-b"},
+			{Name: "tg-shared"},
+			{Name: "tg-unused"},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash-a",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "a", TestGroupName: "tg-a"},
+					{Name: "shared", TestGroupName: "tg-shared"},
+				},
+			},
+			{
+				Name: "dash-b",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "b", TestGroupName: "tg-b"},
+					{Name: "shared", TestGroupName: "tg-shared"},
+				},
+			},
+			{Name: "dash-alone"},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "Group A", DashboardNames: []string{"dash-a"}},
+			{Name: "Group B", DashboardNames: []string{"dash-b"}},
+		},
+		NotificationChannels: []*configpb.NotificationChannel{
+			{Name: "mail", Email: &configpb.EmailChannel{ToAddresses: "team@example.com"}},
+		},
+	}
+}
+
+func TestSplit(t *testing.T) {
+	cfg := splitConfig()
+	expected := map[string]*configpb.Configuration{
+		"groupa": {
+			TestGroups:      []*configpb.TestGroup{cfg.TestGroups[0], cfg.TestGroups[2]},
+			Dashboards:      []*configpb.Dashboard{cfg.Dashboards[0]},
+			DashboardGroups: []*configpb.DashboardGroup{cfg.DashboardGroups[0]},
+		},
+		"groupb": {
+			TestGroups:      []*configpb.TestGroup{cfg.TestGroups[1], cfg.TestGroups[2]},
+			Dashboards:      []*configpb.Dashboard{cfg.Dashboards[1]},
+			DashboardGroups: []*configpb.DashboardGroup{cfg.DashboardGroups[1]},
+		},
+		ungroupedShard: {
+			TestGroups:           []*configpb.TestGroup{cfg.TestGroups[3]},
+			Dashboards:           []*configpb.Dashboard{cfg.Dashboards[2]},
+			NotificationChannels: cfg.NotificationChannels,
+		},
+	}
+	if diff := cmp.Diff(expected, Split(cfg), protocmp.Transform()); diff != "" {
+		t.Errorf("Split() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func sortedConfig(c *configpb.Configuration) *configpb.Configuration {
+	c = proto.Clone(c).(*configpb.Configuration)
+	sort.Slice(c.TestGroups, func(i, j int) bool { return c.TestGroups[i].Name < c.TestGroups[j].Name })
+	sort.Slice(c.Dashboards, func(i, j int) bool { return c.Dashboards[i].Name < c.Dashboards[j].Name })
+	sort.Slice(c.DashboardGroups, func(i, j int) bool { return c.DashboardGroups[i].Name < c.DashboardGroups[j].Name })
+	return c
+}
+
+func TestReadGCS(t *testing.T) {
+	monolith, err := proto.Marshal(splitConfig())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	cases := []struct {
+		name    string
+		objects fakeObjects
+		split   bool
+		err     bool
+	}{
+		{
+			name:    "single object",
+			objects: fakeObjects{"gs://bucket/config": monolith},
+		},
+		{
+			name:    "split objects",
+			objects: fakeObjects{},
+			split:   true,
+		},
+		{
+			name:    "missing config",
+			objects: fakeObjects{},
+			err:     true,
+		},
+		{
+			name:    "missing shard",
+			objects: fakeObjects{"gs://bucket/config/index": []byte("\x0a\x04nope")},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := mustPath(t, "gs://bucket/config")
+			if tc.split {
+				if err := WriteSplit(ctx, tc.objects, path, splitConfig()); err != nil {
+					t.Fatalf("WriteSplit() got unexpected error: %v", err)
+				}
+				if _, ok := tc.objects["gs://bucket/config/index"]; !ok {
+					t.Fatalf("WriteSplit() did not write an index: %v", tc.objects)
+				}
+			}
+			actual, err := ReadGCS(ctx, tc.objects, path)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ReadGCS() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("ReadGCS() failed to return an error")
+			}
+			if diff := cmp.Diff(sortedConfig(splitConfig()), sortedConfig(actual), protocmp.Transform()); diff != "" {
+				t.Errorf("ReadGCS() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadGCSNotExist(t *testing.T) {
+	_, err := ReadGCS(context.Background(), fakeObjects{}, mustPath(t, "gs://bucket/config"))
+	if !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("ReadGCS() got %v, want ErrObjectNotExist", err)
+	}
+}
+
+func TestReadPathSplit(t *testing.T) {
+	objects := fakeObjects{}
+	if err := WriteSplit(context.Background(), objects, mustPath(t, "gs://bucket/config"), splitConfig()); err != nil {
+		t.Fatalf("WriteSplit() got unexpected error: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "split")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for name, buf := range objects {
+		path := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(name, "gs://bucket/")))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	actual, err := ReadPath(filepath.Join(dir, "config"))
+	if err != nil {
+		t.Fatalf("ReadPath() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(sortedConfig(splitConfig()), sortedConfig(actual), protocmp.Transform()); diff != "" {
+		t.Errorf("ReadPath() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type watchClient interface {
+	gcs.Opener
+	gcs.Stater
+}
+
+// Watcher reloads a config whenever the generation of its object changes.
+//
+// Watches the index of a split configuration. Not safe for concurrent use.
+type Watcher struct {
+	client     watchClient
+	path       gcs.Path
+	interval   time.Duration
+	lastCheck  time.Time
+	generation int64
+	cfg        *configpb.Configuration
+}
+
+// NewWatcher reads the config at path, checking for changes at most once per interval.
+//
+// Never checks for changes when interval is zero.
+func NewWatcher(ctx context.Context, client watchClient, path gcs.Path, interval time.Duration) (*Watcher, error) {
+	w := Watcher{
+		client:   client,
+		path:     path,
+		interval: interval,
+	}
+	var gen int64
+	if interval > 0 {
+		var err error
+		if gen, err = w.stat(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.read(ctx, gen); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Config returns the most recently read config.
+func (w *Watcher) Config() *configpb.Configuration {
+	return w.cfg
+}
+
+// Generation returns the generation of the most recently read config, or zero when the watcher never checks it.
+func (w *Watcher) Generation() int64 {
+	return w.generation
+}
+
+// Reload reads the config again if its generation changed, returning true when it did.
+//
+// Does nothing until the interval has passed since the last check.
+// Keeps the previous config when it cannot read the new one.
+func (w *Watcher) Reload(ctx context.Context) (bool, error) {
+	if w.interval == 0 || time.Since(w.lastCheck) < w.interval {
+		return false, nil
+	}
+	gen, err := w.stat(ctx)
+	if err != nil {
+		return false, err
+	}
+	if gen == w.generation {
+		return false, nil
+	}
+	if err := w.read(ctx, gen); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (w *Watcher) read(ctx context.Context, gen int64) error {
+	cfg, err := ReadGCS(ctx, w.client, w.path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	w.cfg = cfg
+	w.generation = gen
+	return nil
+}
+
+// stat returns the generation of the config object, or the index of a split config.
+func (w *Watcher) stat(ctx context.Context) (int64, error) {
+	w.lastCheck = time.Now()
+	attrs, err := w.client.Stat(ctx, w.path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		indexPath, pathErr := splitPath(w.path, IndexName)
+		if pathErr != nil {
+			return 0, pathErr
+		}
+		attrs, err = w.client.Stat(ctx, *indexPath)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("stat config: %w", err)
+	}
+	return attrs.Generation, nil
+}
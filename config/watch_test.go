@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type fakeGenerations struct {
+	fakeObjects
+	generations map[string]int64
+}
+
+func (fg fakeGenerations) Stat(_ context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	gen, ok := fg.generations[path.String()]
+	if !ok {
+		return nil, fmt.Errorf("wrap not exist: %w", storage.ErrObjectNotExist)
+	}
+	return &storage.ObjectAttrs{Generation: gen}, nil
+}
+
+func (fg fakeGenerations) put(t *testing.T, path string, gen int64, cfg proto.Message) {
+	buf, err := proto.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	fg.fakeObjects[path] = buf
+	fg.generations[path] = gen
+}
+
+func TestWatcher(t *testing.T) {
+	first := &configpb.Configuration{TestGroups: []*configpb.TestGroup{{Name: "first"}}}
+	second := &configpb.Configuration{TestGroups: []*configpb.TestGroup{{Name: "second"}}}
+	cases := []struct {
+		name     string
+		interval time.Duration
+		split    bool
+		change   bool
+		expected *configpb.Configuration
+		changed  bool
+		gen      int64
+	}{
+		{
+			name:     "unchanged",
+			expected: first,
+			gen:      1,
+		},
+		{
+			name:     "reload changes",
+			change:   true,
+			expected: second,
+			changed:  true,
+			gen:      2,
+		},
+		{
+			name:     "reload split changes",
+			split:    true,
+			change:   true,
+			expected: second,
+			changed:  true,
+			gen:      2,
+		},
+		{
+			name:     "wait for interval",
+			interval: time.Hour,
+			change:   true,
+			expected: first,
+			gen:      1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := mustPath(t, "gs://bucket/config")
+			client := fakeGenerations{fakeObjects{}, map[string]int64{}}
+			write := func(gen int64, cfg *configpb.Configuration) {
+				if !tc.split {
+					client.put(t, path.String(), gen, cfg)
+					return
+				}
+				if err := WriteSplit(ctx, client, path, cfg); err != nil {
+					t.Fatalf("WriteSplit() got unexpected error: %v", err)
+				}
+				client.generations["gs://bucket/config/index"] = gen
+			}
+			write(1, first)
+			interval := tc.interval
+			if interval == 0 {
+				interval = time.Nanosecond
+			}
+			w, err := NewWatcher(ctx, client, path, interval)
+			if err != nil {
+				t.Fatalf("NewWatcher() got unexpected error: %v", err)
+			}
+			if tc.change {
+				write(2, second)
+			}
+			changed, err := w.Reload(ctx)
+			if err != nil {
+				t.Fatalf("Reload() got unexpected error: %v", err)
+			}
+			if changed != tc.changed {
+				t.Errorf("Reload() got changed %t, want %t", changed, tc.changed)
+			}
+			if diff := cmp.Diff(tc.expected, w.Config(), protocmp.Transform()); diff != "" {
+				t.Errorf("Config() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if gen := w.Generation(); gen != tc.gen {
+				t.Errorf("Generation() got %d, want %d", gen, tc.gen)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeepComments returns the YAML in out with the comments of the matching nodes in original.
+//
+// Matches map entries by key and list items by their name, or else by position.
+// Keeps any comments out already has and drops those of nodes out no longer contains.
+func KeepComments(out, original []byte) ([]byte, error) {
+	var dst, src yaml.Node
+	if err := yaml.Unmarshal(out, &dst); err != nil {
+		return nil, fmt.Errorf("parse output: %w", err)
+	}
+	if err := yaml.Unmarshal(original, &src); err != nil {
+		return nil, fmt.Errorf("parse original: %w", err)
+	}
+	copyComments(&dst, &src)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&dst); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("close: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// copyComments copies the comments of src and its children to any of the matching nodes in dst without one.
+func copyComments(dst, src *yaml.Node) {
+	if src == nil {
+		return
+	}
+	if dst.HeadComment == "" {
+		dst.HeadComment = src.HeadComment
+	}
+	if dst.LineComment == "" {
+		dst.LineComment = src.LineComment
+	}
+	if dst.FootComment == "" {
+		dst.FootComment = src.FootComment
+	}
+	if dst.Kind != src.Kind {
+		return
+	}
+	switch dst.Kind {
+	case yaml.DocumentNode:
+		if len(dst.Content) > 0 && len(src.Content) > 0 {
+			copyComments(dst.Content[0], src.Content[0])
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			key, val := mappingEntry(src, dst.Content[i].Value)
+			copyComments(dst.Content[i], key)
+			copyComments(dst.Content[i+1], val)
+		}
+	case yaml.SequenceNode:
+		for i, item := range dst.Content {
+			copyComments(item, sequenceItem(src, item, i))
+		}
+	}
+}
+
+// mappingEntry returns the key and value nodes of the key in the mapping, or nil.
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// sequenceItem returns the item of the sequence with the same name as item, or else at the same position.
+//
+// Returns nil when the item has a name no item of the sequence has.
+func sequenceItem(sequence, item *yaml.Node, i int) *yaml.Node {
+	if item.Kind == yaml.MappingNode {
+		if _, name := mappingEntry(item, "name"); name != nil {
+			for _, other := range sequence.Content {
+				if other.Kind != yaml.MappingNode {
+					continue
+				}
+				if _, otherName := mappingEntry(other, "name"); otherName != nil && otherName.Value == name.Value {
+					return other
+				}
+			}
+			return nil
+		}
+	}
+	if i < len(sequence.Content) {
+		return sequence.Content[i]
+	}
+	return nil
+}
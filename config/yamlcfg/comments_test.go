@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKeepComments(t *testing.T) {
+	cases := []struct {
+		name     string
+		out      string
+		original string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "basically works",
+			out:      "test_groups:\n- name: foo\n",
+			expected: "test_groups:\n- name: foo\n",
+		},
+		{
+			name:     "keep comments",
+			out:      "test_groups:\n- days_of_results: 3\n  name: foo\n",
+			original: "# groups\ntest_groups:\n- name: foo # the foo group\n  # days\n  days_of_results: 3\n",
+			expected: "# groups\ntest_groups:\n- # days\n  days_of_results: 3\n  name: foo # the foo group\n",
+		},
+		{
+			name:     "match items by name",
+			out:      "test_groups:\n- name: bar\n- name: foo\n",
+			original: "test_groups:\n- name: foo # foo\n- name: bar # bar\n",
+			expected: "test_groups:\n- name: bar # bar\n- name: foo # foo\n",
+		},
+		{
+			name:     "drop comments of removed nodes",
+			out:      "test_groups:\n- name: foo\n",
+			original: "test_groups:\n- name: foo # foo\n- name: bar # bar\ndashboards: [] # gone\n",
+			expected: "test_groups:\n- name: foo # foo\n",
+		},
+		{
+			name:     "match other items by position",
+			out:      "dashboard_names:\n- foo\n- bar\n",
+			original: "dashboard_names:\n- foo # first\n",
+			expected: "dashboard_names:\n- foo # first\n- bar\n",
+		},
+		{
+			name:     "skip renamed items",
+			out:      "test_groups:\n- name: bar\n",
+			original: "test_groups:\n- name: foo # foo\n",
+			expected: "test_groups:\n- name: bar\n",
+		},
+		{
+			name:     "bad original",
+			out:      "test_groups: []\n",
+			original: "test_groups: [",
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := KeepComments([]byte(tc.out), []byte(tc.original))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("KeepComments() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("KeepComments() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, string(actual)); diff != "" {
+					t.Errorf("KeepComments() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// includeLine matches lines such as `key: !include file.yaml`, `- !include file.yaml` or `!include file.yaml`.
+var includeLine = regexp.MustCompile(`^(\s*(?:- )*)([^\s#'"-][^#'"]*:\s+)?!include\s+(\S+)\s*$`)
+
+// ExpandIncludes reads the YAML file, replacing each `!include path` with the contents of that file.
+//
+// Paths are relative to the including file. The included contents replace the
+// include in place, so anchors defined by earlier includes are available to
+// the rest of the file:
+//
+//	key: !include map.yaml    # nests the contents under key
+//	- !include item.yaml      # adds a list item, or every item of a list
+//	!include top.yaml         # merges the contents into the enclosing map or list
+//
+// Returns the expanded contents and the absolute path of every included file.
+func ExpandIncludes(path string) ([]byte, []string, error) {
+	var included []string
+	buf, err := expandIncludes(path, map[string]bool{}, &included)
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf, included, nil
+}
+
+func expandIncludes(path string, stack map[string]bool, included *[]string) ([]byte, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if stack[abs] {
+		return nil, fmt.Errorf("include cycle at %s", path)
+	}
+	stack[abs] = true
+	defer delete(stack, abs)
+
+	buf, err := ioutil.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(buf, []byte("!include")) {
+		return buf, nil
+	}
+	var out bytes.Buffer
+	for i, line := range strings.Split(string(buf), "\n") {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		mat := includeLine.FindStringSubmatch(line)
+		if mat == nil {
+			out.WriteString(line)
+			continue
+		}
+		lead, key, target := mat[1], mat[2], mat[3]
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(abs), target)
+		}
+		content, err := expandIncludes(target, stack, included)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: include %s: %w", path, i+1, mat[3], err)
+		}
+		*included = append(*included, target)
+		lines := contentLines(content)
+		switch {
+		case key != "":
+			out.WriteString(lead + strings.TrimRight(key, " \t") + "\n")
+			indent := spaces(lead) + "  "
+			writeIndented(&out, indent, indent, lines)
+		case strings.HasSuffix(lead, "- ") && isSequence(lines):
+			// Splice the items into the enclosing list.
+			lead = strings.TrimSuffix(lead, "- ")
+			writeIndented(&out, lead, spaces(lead), lines)
+		default:
+			writeIndented(&out, lead, spaces(lead), lines)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// contentLines returns the lines of the content, without any trailing empty lines or document markers.
+func contentLines(content []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if line == "---" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// isSequence returns true when the first significant line starts a list item.
+func isSequence(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		return trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+	}
+	return false
+}
+
+// spaces returns as many spaces as the length of the lead.
+func spaces(lead string) string {
+	return strings.Repeat(" ", len(lead))
+}
+
+// writeIndented writes the lines, prefixing the first with first and the rest with rest.
+func writeIndented(out *bytes.Buffer, first, rest string, lines []string) {
+	for i, line := range lines {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		switch {
+		case i == 0:
+			out.WriteString(first + line)
+		case line == "":
+		default:
+			out.WriteString(rest + line)
+		}
+	}
+}
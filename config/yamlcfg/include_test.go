@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+}
+
+func TestExpandIncludes(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		expected string
+		included []string
+		err      bool
+	}{
+		{
+			name: "basically works",
+			files: map[string]string{
+				"main.yaml": "dashboards:\n- name: foo\n",
+			},
+			expected: "dashboards:\n- name: foo\n",
+		},
+		{
+			name: "nest under key",
+			files: map[string]string{
+				"main.yaml":     "dashboards:\n- name: foo\n  dashboard_tab: !include tabs/foo.yaml\n",
+				"tabs/foo.yaml": "- name: bar\n- name: baz\n",
+			},
+			expected: "dashboards:\n- name: foo\n  dashboard_tab:\n    - name: bar\n    - name: baz\n",
+			included: []string{"tabs/foo.yaml"},
+		},
+		{
+			name: "add list items",
+			files: map[string]string{
+				"main.yaml": "dashboards:\n- !include foo.yaml\n- !include more.yaml\n",
+				"foo.yaml":  "name: foo\ndefault_tab: bar\n",
+				"more.yaml": "- name: bar\n- name: baz\n",
+			},
+			expected: "dashboards:\n- name: foo\n  default_tab: bar\n- name: bar\n- name: baz\n",
+			included: []string{"foo.yaml", "more.yaml"},
+		},
+		{
+			name: "merge at top level",
+			files: map[string]string{
+				"main.yaml":    "!include anchors.yaml\ndashboards:\n- name: foo\n",
+				"anchors.yaml": "x-tab: &tab\n  num_columns_recent: 3\n",
+			},
+			expected: "x-tab: &tab\n  num_columns_recent: 3\ndashboards:\n- name: foo\n",
+			included: []string{"anchors.yaml"},
+		},
+		{
+			name: "nested includes are relative",
+			files: map[string]string{
+				"main.yaml":  "test_groups: !include sub/a.yaml\n",
+				"sub/a.yaml": "- !include b.yaml\n",
+				"sub/b.yaml": "name: b\n",
+			},
+			expected: "test_groups:\n  - name: b\n",
+			included: []string{"sub/b.yaml", "sub/a.yaml"},
+		},
+		{
+			name: "reject cycles",
+			files: map[string]string{
+				"main.yaml": "foo: !include a.yaml\n",
+				"a.yaml":    "bar: !include main.yaml\n",
+			},
+			err: true,
+		},
+		{
+			name: "reject missing files",
+			files: map[string]string{
+				"main.yaml": "foo: !include missing.yaml\n",
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "includes")
+			if err != nil {
+				t.Fatalf("temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			writeFiles(t, dir, tc.files)
+
+			buf, included, err := ExpandIncludes(filepath.Join(dir, "main.yaml"))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ExpandIncludes() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("ExpandIncludes() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, string(buf)); diff != "" {
+				t.Errorf("ExpandIncludes() got unexpected diff (-want +got):\n%s", diff)
+			}
+			var expected []string
+			for _, inc := range tc.included {
+				expected = append(expected, filepath.Join(dir, inc))
+			}
+			if diff := cmp.Diff(expected, included); diff != "" {
+				t.Errorf("ExpandIncludes() got unexpected included diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadConfigIncludes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "includes")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"main.yaml":           "!include common/anchors.yaml\ndashboards:\n- name: foo\n  dashboard_tab: !include common/tabs.yaml\n",
+		"common/anchors.yaml": "x-tab: &tab\n  test_group_name: group\n  num_columns_recent: 3\n",
+		"common/tabs.yaml":    "- <<: *tab\n  name: bar\n- <<: *tab\n  name: baz\n  num_columns_recent: 5\n",
+	})
+
+	actual, err := ReadConfig([]string{dir}, "")
+	if err != nil {
+		t.Fatalf("ReadConfig() got unexpected error: %v", err)
+	}
+	expected := &config.Configuration{
+		Dashboards: []*config.Dashboard{
+			{
+				Name: "foo",
+				DashboardTab: []*config.DashboardTab{
+					{Name: "bar", TestGroupName: "group", NumColumnsRecent: 3},
+					{Name: "baz", TestGroupName: "group", NumColumnsRecent: 5},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, &actual, protocmp.Transform()); diff != "" {
+		t.Errorf("ReadConfig() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
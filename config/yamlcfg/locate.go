@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Location is the line of a YAML file that names an entity.
+type Location struct {
+	Path string
+	Line int
+}
+
+// entityLists are the top-level keys of the lists of each kind of entity.
+var entityLists = []struct {
+	kind string
+	key  string
+}{
+	{"TestGroup", "test_groups"},
+	{"Dashboard", "dashboards"},
+	{"DashboardGroup", "dashboard_groups"},
+	{"NotificationChannel", "notification_channels"},
+}
+
+// Locate returns where the YAML files under paths name each entity, keyed by kind and then name.
+//
+// Keys dashboard tabs both as dashboard/tab and as tab, keeping the first location of each.
+// Skips files that do not parse, as well as entities in files included by another file.
+func Locate(paths []string) (map[string]map[string]Location, error) {
+	out := map[string]map[string]Location{}
+	add := func(kind, name string, loc Location) {
+		if out[kind] == nil {
+			out[kind] = map[string]Location{}
+		}
+		if _, ok := out[kind][name]; !ok {
+			out[kind][name] = loc
+		}
+	}
+	err := SeekYAMLFiles(paths, func(path string, info os.FileInfo) error {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(buf, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return nil
+		}
+		for _, list := range entityLists {
+			for _, item := range sequence(doc.Content[0], list.key) {
+				name := nameNode(item)
+				if name == nil {
+					continue
+				}
+				add(list.kind, name.Value, Location{path, name.Line})
+				if list.kind != "Dashboard" {
+					continue
+				}
+				for _, tab := range sequence(item, "dashboard_tab") {
+					if tabName := nameNode(tab); tabName != nil {
+						loc := Location{path, tabName.Line}
+						add("DashboardTab", name.Value+"/"+tabName.Value, loc)
+						add("DashboardTab", tabName.Value, loc)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// sequence returns the items of the list at key in the mapping, if any.
+func sequence(mapping *yaml.Node, key string) []*yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	_, val := mappingEntry(mapping, key)
+	if val == nil || val.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return val.Content
+}
+
+// nameNode returns the value of the name key of the mapping, or nil.
+func nameNode(mapping *yaml.Node) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	_, name := mappingEntry(mapping, "name")
+	return name
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLocate(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		expected map[string]map[string]Location
+	}{
+		{
+			name: "basically works",
+			files: map[string]string{
+				"a.yaml": "test_groups:\n- name: foo\n  gcs_prefix: bucket/foo\n- name: bar\n",
+			},
+			expected: map[string]map[string]Location{
+				"TestGroup": {
+					"foo": {"a.yaml", 2},
+					"bar": {"a.yaml", 4},
+				},
+			},
+		},
+		{
+			name: "dashboards and tabs",
+			files: map[string]string{
+				"a.yaml": "dashboards:\n- name: dash\n  dashboard_tab:\n  - name: tab\n    test_group_name: foo\n",
+				"b.yaml": "dashboard_groups:\n- name: group\n  dashboard_names: [dash]\nnotification_channels:\n- name: chan\n",
+			},
+			expected: map[string]map[string]Location{
+				"Dashboard": {
+					"dash": {"a.yaml", 2},
+				},
+				"DashboardTab": {
+					"dash/tab": {"a.yaml", 4},
+					"tab":      {"a.yaml", 4},
+				},
+				"DashboardGroup": {
+					"group": {"b.yaml", 2},
+				},
+				"NotificationChannel": {
+					"chan": {"b.yaml", 5},
+				},
+			},
+		},
+		{
+			name: "keep the first location",
+			files: map[string]string{
+				"a.yaml": "test_groups:\n- name: foo\n",
+				"b.yaml": "test_groups:\n\n- name: foo\n",
+			},
+			expected: map[string]map[string]Location{
+				"TestGroup": {
+					"foo": {"a.yaml", 2},
+				},
+			},
+		},
+		{
+			name: "skip unparsable and included files",
+			files: map[string]string{
+				"a.yaml":   "test_groups: [",
+				"tab.yaml": "- name: tab\n",
+			},
+			expected: map[string]map[string]Location{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "locate")
+			if err != nil {
+				t.Fatalf("tempdir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			writeFiles(t, dir, tc.files)
+			actual, err := Locate([]string{dir})
+			if err != nil {
+				t.Fatalf("Locate() got unexpected error: %v", err)
+			}
+			for _, names := range tc.expected {
+				for name, loc := range names {
+					loc.Path = filepath.Join(dir, loc.Path)
+					names[name] = loc
+				}
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Locate() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	cfgutil "github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ReadPaths reads a single config proto path, or else combines YAML paths into one config.
+//
+// The client is only created when the path refers to GCS.
+func ReadPaths(ctx context.Context, paths []string, defaultpath string, client func() (*storage.Client, error)) (*config.Configuration, error) {
+	if len(paths) == 1 && cfgutil.IsProto(paths[0]) {
+		var sc *storage.Client
+		if strings.HasPrefix(paths[0], "gs://") {
+			var err error
+			if sc, err = client(); err != nil {
+				return nil, err
+			}
+		}
+		return cfgutil.Read(paths[0], ctx, sc)
+	}
+	cfg, err := ReadConfig(paths, defaultpath)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
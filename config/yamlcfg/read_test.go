@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestReadPaths(t *testing.T) {
+	pbCfg := &config.Configuration{
+		TestGroups: []*config.TestGroup{{Name: "proto"}},
+	}
+	buf, err := proto.Marshal(pbCfg)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		files   map[string]string
+		paths   []string
+		want    *config.Configuration
+		wantErr bool
+	}{
+		{
+			name:  "reads a config proto",
+			files: map[string]string{"config.pb": string(buf)},
+			paths: []string{"config.pb"},
+			want:  pbCfg,
+		},
+		{
+			name: "combines YAML paths",
+			files: map[string]string{
+				"a.yaml": "test_groups:\n- name: a\n  gcs_prefix: bucket/a\n",
+				"b.yaml": "test_groups:\n- name: b\n  gcs_prefix: bucket/b\n",
+			},
+			paths: []string{"a.yaml", "b.yaml"},
+			want: &config.Configuration{
+				TestGroups: []*config.TestGroup{
+					{Name: "a", GcsPrefix: "bucket/a"},
+					{Name: "b", GcsPrefix: "bucket/b"},
+				},
+			},
+		},
+		{
+			name:    "missing config proto",
+			paths:   []string{"missing.pb"},
+			wantErr: true,
+		},
+		{
+			name:    "gs:// paths need a client",
+			paths:   []string{"gs://bucket/config"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "read-paths")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			for name, content := range tc.files {
+				if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+			var paths []string
+			for _, p := range tc.paths {
+				if strings.HasPrefix(p, "gs://") {
+					paths = append(paths, p)
+					continue
+				}
+				paths = append(paths, filepath.Join(dir, p))
+			}
+			client := func() (*storage.Client, error) {
+				return nil, errors.New("no client")
+			}
+			got, err := ReadPaths(context.Background(), paths, "", client)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("ReadPaths() got unexpected error: %v", err)
+				}
+			case tc.wantErr:
+				t.Errorf("ReadPaths() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("ReadPaths() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// SchemaURL identifies the draft of JSON Schema produced by JSONSchema.
+const SchemaURL = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns a JSON Schema for the YAML files read by ReadConfig.
+//
+// Properties use the proto field names and enums their numeric values.
+// Omits oneof fields, which these files cannot set.
+func JSONSchema() ([]byte, error) {
+	root := proto.MessageReflect(&config.Configuration{}).Descriptor()
+	defs := map[string]interface{}{}
+	messageSchema(root, defs)
+	schema := map[string]interface{}{
+		"$schema":     SchemaURL,
+		"title":       "TestGrid configuration",
+		"$ref":        ref(root),
+		"definitions": defs,
+	}
+	buf, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	return append(buf, '\n'), nil
+}
+
+func ref(md protoreflect.MessageDescriptor) string {
+	return "#/definitions/" + string(md.FullName())
+}
+
+// messageSchema adds the definition of the message and the messages it references to defs.
+func messageSchema(md protoreflect.MessageDescriptor, defs map[string]interface{}) {
+	name := string(md.FullName())
+	if _, ok := defs[name]; ok {
+		return
+	}
+	props := map[string]interface{}{}
+	def := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	defs[name] = def
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.ContainingOneof() != nil {
+			continue
+		}
+		props[string(fd.Name())] = fieldSchema(fd, defs)
+	}
+}
+
+// fieldSchema returns the schema of the field, such as an array of its elements when repeated.
+func fieldSchema(fd protoreflect.FieldDescriptor, defs map[string]interface{}) map[string]interface{} {
+	switch {
+	case fd.IsMap():
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": valueSchema(fd.MapValue(), defs),
+		}
+	case fd.IsList():
+		return map[string]interface{}{
+			"type":  "array",
+			"items": valueSchema(fd, defs),
+		}
+	}
+	return valueSchema(fd, defs)
+}
+
+// valueSchema returns the schema of a single value of the field.
+func valueSchema(fd protoreflect.FieldDescriptor, defs map[string]interface{}) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		messageSchema(fd.Message(), defs)
+		return map[string]interface{}{"$ref": ref(fd.Message())}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		nums := make([]int32, 0, values.Len())
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			v := values.Get(i)
+			nums = append(nums, int32(v.Number()))
+			names = append(names, fmt.Sprintf("%d=%s", v.Number(), v.Name()))
+		}
+		return map[string]interface{}{
+			"type":        "integer",
+			"enum":        nums,
+			"description": fmt.Sprintf("%s: %s", fd.Enum().FullName(), strings.Join(names, ", ")),
+		}
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number"}
+	}
+	return map[string]interface{}{"type": "integer"}
+}
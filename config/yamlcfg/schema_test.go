@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONSchema(t *testing.T) {
+	buf, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() got unexpected error: %v", err)
+	}
+	var schema struct {
+		Schema      string `json:"$schema"`
+		Ref         string `json:"$ref"`
+		Definitions map[string]struct {
+			Type       string                            `json:"type"`
+			Properties map[string]map[string]interface{} `json:"properties"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(buf, &schema); err != nil {
+		t.Fatalf("JSONSchema() returned invalid JSON: %v", err)
+	}
+	if schema.Schema != SchemaURL {
+		t.Errorf("JSONSchema() got $schema %q, want %q", schema.Schema, SchemaURL)
+	}
+	if want := "#/definitions/Configuration"; schema.Ref != want {
+		t.Errorf("JSONSchema() got $ref %q, want %q", schema.Ref, want)
+	}
+
+	cases := []struct {
+		name       string
+		definition string
+		property   string
+		expected   map[string]interface{}
+	}{
+		{
+			name:       "repeated message",
+			definition: "Configuration",
+			property:   "dashboards",
+			expected: map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/definitions/Dashboard"},
+			},
+		},
+		{
+			name:       "nested message",
+			definition: "DashboardTab",
+			property:   "alert_options",
+			expected:   map[string]interface{}{"$ref": "#/definitions/DashboardTabAlertOptions"},
+		},
+		{
+			name:       "string",
+			definition: "DashboardTab",
+			property:   "test_group_name",
+			expected:   map[string]interface{}{"type": "string"},
+		},
+		{
+			name:       "integer",
+			definition: "DashboardTab",
+			property:   "num_columns_recent",
+			expected:   map[string]interface{}{"type": "integer"},
+		},
+		{
+			name:       "number",
+			definition: "DashboardTab",
+			property:   "infra_failure_threshold",
+			expected:   map[string]interface{}{"type": "number"},
+		},
+		{
+			name:       "enum",
+			definition: "TestGroup",
+			property:   "tests_name_policy",
+			expected: map[string]interface{}{
+				"type":        "integer",
+				"enum":        []interface{}{0.0, 1.0, 2.0, 3.0},
+				"description": "TestGroup.TestsName: 0=TESTS_NAME_UNSPECIFIED, 1=TESTS_NAME_IGNORE, 2=TESTS_NAME_REPLACE, 3=TESTS_NAME_APPEND",
+			},
+		},
+		{
+			name:       "omit oneof",
+			definition: "TestGroup.TestAnnotation",
+			property:   "property_name",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			def, ok := schema.Definitions[tc.definition]
+			if !ok {
+				t.Fatalf("JSONSchema() missing definition %q", tc.definition)
+			}
+			if def.Type != "object" {
+				t.Errorf("JSONSchema() got %s type %q, want object", tc.definition, def.Type)
+			}
+			actual := def.Properties[tc.property]
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("JSONSchema() got unexpected %s.%s diff (-want +got):\n%s", tc.definition, tc.property, diff)
+			}
+		})
+	}
+}
+
+func TestShippedSchema(t *testing.T) {
+	expected, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() got unexpected error: %v", err)
+	}
+	actual, err := ioutil.ReadFile("config.schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read shipped schema: %v", err)
+	}
+	if diff := cmp.Diff(string(expected), string(actual)); diff != "" {
+		t.Errorf("config.schema.json is stale, run go run ./cmd/config_schema --output=config/yamlcfg/config.schema.json (-want +got):\n%s", diff)
+	}
+}
@@ -23,9 +23,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/golang/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
 	cfgutil "github.com/GoogleCloudPlatform/testgrid/config"
 	"github.com/GoogleCloudPlatform/testgrid/pb/config"
-	"sigs.k8s.io/yaml"
 )
 
 // getDefaults take all paths found through seeking, returns list of dirs with defaults
@@ -82,7 +84,7 @@ func pathDefault(path string, defaultFiles map[string]DefaultConfiguration, defa
 }
 
 // Takes multiple source paths of the following form:
-//   If path is a local file, then the file will be parsed as YAML
+//   If path is a local file, then the file will be parsed as YAML, expanding any includes.
 //   If path is a directory, then all files and directories within it will be parsed.
 //     If this directory has a default(s).yaml file, apply it to all configured entities,
 // 		 after applying defaults from defaultPath.
@@ -111,25 +113,65 @@ func ReadConfig(paths []string, defaultpath string) (config.Configuration, error
 		return result, err
 	}
 
-	// Gather configuration from each YAML file, applying the config's default.yaml if
-	// one exists in its directory, or the overall default otherwise.
+	// Read each YAML file, expanding any includes.
+	// Files included by another file are only part of that file's config.
+	type yamlFile struct {
+		path string
+		data []byte
+	}
+	var files []yamlFile
+	included := map[string]bool{}
 	err = SeekYAMLFiles(paths, func(path string, info os.FileInfo) error {
-		// Read YAML file and Update config
-		b, err := ioutil.ReadFile(path)
+		b, inc, err := ExpandIncludes(path)
 		if err != nil {
 			return fmt.Errorf("failed to read %s: %v", path, err)
 		}
-		localDefaults := pathDefault(path, defaultFiles, defaults)
-		if err = Update(&result, b, &localDefaults); err != nil {
-			return fmt.Errorf("failed to merge %s into config: %v", path, err)
+		for _, p := range inc {
+			included[p] = true
 		}
+		files = append(files, yamlFile{path, b})
 		return nil
 	})
 	if err != nil {
 		return result, fmt.Errorf("SeekYAMLFiles(%v), gathering config: %v", paths, err)
 	}
 
-	return result, err
+	// Gather configuration from each YAML file, applying the config's default.yaml if
+	// one exists in its directory, or the overall default otherwise.
+	// Tabs inherit from tab_defaults before default.yaml.
+	type tabDefault struct {
+		tab      *config.DashboardTab
+		defaults *config.DashboardTab
+	}
+	var tabDefaults []tabDefault
+	for _, f := range files {
+		if abs, err := filepath.Abs(f.path); err == nil && included[abs] {
+			continue
+		}
+		localDefaults := pathDefault(f.path, defaultFiles, defaults)
+		groupDefaults := DefaultConfiguration{DefaultTestGroup: localDefaults.DefaultTestGroup}
+		n := len(result.Dashboards)
+		if err = Update(&result, f.data, &groupDefaults); err != nil {
+			return result, fmt.Errorf("failed to merge %s into config: %v", f.path, err)
+		}
+		if localDefaults.DefaultDashboardTab == nil {
+			continue
+		}
+		for _, dashboard := range result.Dashboards[n:] {
+			for _, tab := range dashboard.DashboardTab {
+				tabDefaults = append(tabDefaults, tabDefault{tab, localDefaults.DefaultDashboardTab})
+			}
+		}
+	}
+
+	cfgutil.ApplyTabDefaults(&result)
+	for _, td := range tabDefaults {
+		ReconcileDashboardTab(td.tab, td.defaults)
+	}
+	if err := cfgutil.ExpandEnv(&result, os.LookupEnv); err != nil {
+		return result, fmt.Errorf("failed to expand variables: %v", err)
+	}
+	return result, nil
 }
 
 // Update reads the config in yamlData and updates the config in c.
@@ -146,14 +188,14 @@ func Update(cfg *config.Configuration, yamlData []byte, reconcile *DefaultConfig
 	}
 
 	for _, testgroup := range newConfig.TestGroups {
-		if reconcile != nil {
+		if reconcile != nil && reconcile.DefaultTestGroup != nil {
 			ReconcileTestGroup(testgroup, reconcile.DefaultTestGroup)
 		}
 		cfg.TestGroups = append(cfg.TestGroups, testgroup)
 	}
 
 	for _, dashboard := range newConfig.Dashboards {
-		if reconcile != nil {
+		if reconcile != nil && reconcile.DefaultDashboardTab != nil {
 			for _, dashboardtab := range dashboard.DashboardTab {
 				ReconcileDashboardTab(dashboardtab, reconcile.DefaultDashboardTab)
 			}
@@ -165,6 +207,8 @@ func Update(cfg *config.Configuration, yamlData []byte, reconcile *DefaultConfig
 		cfg.DashboardGroups = append(cfg.DashboardGroups, dashboardGroup)
 	}
 
+	cfg.NotificationChannels = append(cfg.NotificationChannels, newConfig.NotificationChannels...)
+
 	return nil
 }
 
@@ -218,6 +262,10 @@ func ReconcileTestGroup(currentTestGroup *config.TestGroup, defaultTestGroup *co
 		currentTestGroup.IgnoreSkip = defaultTestGroup.IgnoreSkip
 	}
 
+	if currentTestGroup.IgnorePass == false {
+		currentTestGroup.IgnorePass = defaultTestGroup.IgnorePass
+	}
+
 	if currentTestGroup.ColumnHeader == nil {
 		currentTestGroup.ColumnHeader = defaultTestGroup.ColumnHeader
 	}
@@ -245,6 +293,8 @@ func ReconcileTestGroup(currentTestGroup *config.TestGroup, defaultTestGroup *co
 }
 
 // ReconcileDashboardTab sets unfilled currentTab fields to the corresponding defaultTab value, if present
+//
+// Copies messages so tabs never share them with defaultTab.
 func ReconcileDashboardTab(currentTab *config.DashboardTab, defaultTab *config.DashboardTab) {
 	if currentTab.BugComponent == 0 {
 		currentTab.BugComponent = defaultTab.BugComponent
@@ -259,15 +309,15 @@ func ReconcileDashboardTab(currentTab *config.DashboardTab, defaultTab *config.D
 	}
 
 	if currentTab.OpenTestTemplate == nil {
-		currentTab.OpenTestTemplate = defaultTab.OpenTestTemplate
+		currentTab.OpenTestTemplate = proto.Clone(defaultTab.OpenTestTemplate).(*config.LinkTemplate)
 	}
 
 	if currentTab.FileBugTemplate == nil {
-		currentTab.FileBugTemplate = defaultTab.FileBugTemplate
+		currentTab.FileBugTemplate = proto.Clone(defaultTab.FileBugTemplate).(*config.LinkTemplate)
 	}
 
 	if currentTab.AttachBugTemplate == nil {
-		currentTab.AttachBugTemplate = defaultTab.AttachBugTemplate
+		currentTab.AttachBugTemplate = proto.Clone(defaultTab.AttachBugTemplate).(*config.LinkTemplate)
 	}
 
 	if currentTab.ResultsText == "" {
@@ -275,19 +325,19 @@ func ReconcileDashboardTab(currentTab *config.DashboardTab, defaultTab *config.D
 	}
 
 	if currentTab.ResultsUrlTemplate == nil {
-		currentTab.ResultsUrlTemplate = defaultTab.ResultsUrlTemplate
+		currentTab.ResultsUrlTemplate = proto.Clone(defaultTab.ResultsUrlTemplate).(*config.LinkTemplate)
 	}
 
 	if currentTab.CodeSearchUrlTemplate == nil {
-		currentTab.CodeSearchUrlTemplate = defaultTab.CodeSearchUrlTemplate
+		currentTab.CodeSearchUrlTemplate = proto.Clone(defaultTab.CodeSearchUrlTemplate).(*config.LinkTemplate)
 	}
 
 	if currentTab.AlertOptions == nil {
-		currentTab.AlertOptions = defaultTab.AlertOptions
+		currentTab.AlertOptions = proto.Clone(defaultTab.AlertOptions).(*config.DashboardTabAlertOptions)
 	}
 
 	if currentTab.OpenBugTemplate == nil {
-		currentTab.OpenBugTemplate = defaultTab.OpenBugTemplate
+		currentTab.OpenBugTemplate = proto.Clone(defaultTab.OpenBugTemplate).(*config.LinkTemplate)
 	}
 }
 
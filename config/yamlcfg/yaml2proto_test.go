@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -619,6 +620,7 @@ func Test_ReadConfig(t *testing.T) {
 	tests := []struct {
 		name          string
 		files         map[string]string
+		defaults      string
 		useDir        bool
 		expected      config.Configuration
 		expectFailure bool
@@ -648,6 +650,116 @@ func Test_ReadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Reads notification channels",
+			files: map[string]string{
+				"1*.yaml": "notification_channels:\n- name: mail\n  email:\n    to_addresses: a@example.com\n",
+			},
+			expected: config.Configuration{
+				NotificationChannels: []*config.NotificationChannel{
+					{Name: "mail", Email: &config.EmailChannel{ToAddresses: "a@example.com"}},
+				},
+			},
+		},
+		{
+			name: "Expands variables",
+			files: map[string]string{
+				"1*.yaml": "test_groups:\n- name: Foo\n  gcs_prefix: ${TESTGRID_TEST_UNSET_BUCKET:-bucket}/logs/foo\n",
+			},
+			expected: config.Configuration{
+				TestGroups: []*config.TestGroup{
+					{Name: "Foo", GcsPrefix: "bucket/logs/foo"},
+				},
+			},
+		},
+		{
+			name: "Missing required variable: fails",
+			files: map[string]string{
+				"1*.yaml": "test_groups:\n- name: Foo\n  gcs_prefix: ${TESTGRID_TEST_UNSET_BUCKET:?required}/logs/foo\n",
+			},
+			expectFailure: true,
+		},
+		{
+			name: "Tab defaults take precedence over default.yaml",
+			files: map[string]string{
+				"1*.yaml": `dashboards:
+- name: d1
+  tab_defaults:
+    num_columns_recent: 10
+    alert_options:
+      alert_mail_to_addresses: d1@example.com
+  dashboard_tab:
+  - name: t1
+- name: d2
+  dashboard_tab:
+  - name: t2
+`,
+			},
+			defaults: `default_test_group:
+  days_of_results: 1
+default_dashboard_tab:
+  num_columns_recent: 5
+  alert_options:
+    alert_mail_to_addresses: default@example.com
+`,
+			expected: config.Configuration{
+				Dashboards: []*config.Dashboard{
+					{
+						Name: "d1",
+						TabDefaults: &config.DashboardTab{
+							NumColumnsRecent: 10,
+							AlertOptions:     &config.DashboardTabAlertOptions{AlertMailToAddresses: "d1@example.com"},
+						},
+						DashboardTab: []*config.DashboardTab{
+							{
+								Name:             "t1",
+								NumColumnsRecent: 10,
+								AlertOptions:     &config.DashboardTabAlertOptions{AlertMailToAddresses: "d1@example.com"},
+							},
+						},
+					},
+					{
+						Name: "d2",
+						DashboardTab: []*config.DashboardTab{
+							{
+								Name:             "t2",
+								NumColumnsRecent: 5,
+								AlertOptions:     &config.DashboardTabAlertOptions{AlertMailToAddresses: "default@example.com"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Expands default.yaml messages once per tab",
+			files: map[string]string{
+				"1*.yaml": "dashboards:\n- name: Foo\n  dashboard_tab:\n  - name: a\n  - name: b\n",
+			},
+			defaults: `default_test_group:
+  days_of_results: 1
+default_dashboard_tab:
+  alert_options:
+    alert_mail_to_addresses: $${TESTGRID_TEST_UNSET_TEAM}
+`,
+			expected: config.Configuration{
+				Dashboards: []*config.Dashboard{
+					{
+						Name: "Foo",
+						DashboardTab: []*config.DashboardTab{
+							{
+								Name:         "a",
+								AlertOptions: &config.DashboardTabAlertOptions{AlertMailToAddresses: "${TESTGRID_TEST_UNSET_TEAM}"},
+							},
+							{
+								Name:         "b",
+								AlertOptions: &config.DashboardTabAlertOptions{AlertMailToAddresses: "${TESTGRID_TEST_UNSET_TEAM}"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Invalid YAML: fails",
 			files: map[string]string{
@@ -692,12 +804,20 @@ func Test_ReadConfig(t *testing.T) {
 				}
 			}
 
+			var defaultPath string
+			if test.defaults != "" {
+				defaultPath = filepath.Join(directory, "defaults")
+				if err := ioutil.WriteFile(defaultPath, []byte(test.defaults), 0644); err != nil {
+					t.Fatalf("Error in writing defaults: %v", err)
+				}
+			}
+
 			var result config.Configuration
 			var readErr error
 			if test.useDir {
-				result, readErr = ReadConfig([]string{directory}, "")
+				result, readErr = ReadConfig([]string{directory}, defaultPath)
 			} else {
-				result, readErr = ReadConfig(inputs, "")
+				result, readErr = ReadConfig(inputs, defaultPath)
 			}
 
 			if test.expectFailure && readErr == nil {
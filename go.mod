@@ -3,18 +3,24 @@ module github.com/GoogleCloudPlatform/testgrid
 require (
 	cloud.google.com/go/storage v1.10.1-0.20200805182106-fcd132957b02
 	github.com/client9/misspell v0.3.4
+	github.com/coreos/go-oidc/v3 v3.2.0
 	github.com/fvbommel/sortorder v1.0.1
 	github.com/golang/protobuf v1.4.2
-	github.com/google/go-cmp v0.5.1
+	github.com/google/go-cmp v0.5.2
 	github.com/google/uuid v1.1.1
 	github.com/hashicorp/go-multierror v1.0.0
+	github.com/prometheus/client_golang v1.7.1
 	github.com/sirupsen/logrus v1.6.0
-	github.com/stretchr/testify v1.5.1
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/otel v0.13.0
+	go.opentelemetry.io/otel/exporters/otlp v0.13.0
+	go.opentelemetry.io/otel/sdk v0.13.0
 	google.golang.org/api v0.30.0
 	google.golang.org/genproto v0.0.0-20200804151602-45615f50871c
-	google.golang.org/grpc v1.31.0
+	google.golang.org/grpc v1.32.0
 	google.golang.org/protobuf v1.25.0
-	gopkg.in/yaml.v2 v2.2.4
+	gopkg.in/yaml.v2 v2.2.5
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 	sigs.k8s.io/yaml v1.1.0
 )
 
@@ -44,10 +44,11 @@ var (
 		statuspb.TestStatus_BLOCKED:           9,
 		statuspb.TestStatus_FLAKY:             10,
 		statuspb.TestStatus_TOOL_FAIL:         11,
-		statuspb.TestStatus_TIMED_OUT:         12,
-		statuspb.TestStatus_CATEGORIZED_FAIL:  13,
-		statuspb.TestStatus_BUILD_FAIL:        14,
-		statuspb.TestStatus_FAIL:              15,
+		statuspb.TestStatus_ABORTED:           12,
+		statuspb.TestStatus_TIMED_OUT:         13,
+		statuspb.TestStatus_CATEGORIZED_FAIL:  14,
+		statuspb.TestStatus_BUILD_FAIL:        15,
+		statuspb.TestStatus_FAIL:              16,
 	}
 )
 
@@ -61,6 +62,11 @@ func gte(rowResult, compareTo statuspb.TestStatus) bool {
 	return statusSeverity[rowResult] >= statusSeverity[compareTo]
 }
 
+// Worse returns true if rowResult is more severe than compareTo, such as FAIL compared to PASS.
+func Worse(rowResult, compareTo statuspb.TestStatus) bool {
+	return statusSeverity[rowResult] > statusSeverity[compareTo]
+}
+
 // IsPassingResult returns true if the test status is any passing status,
 // including PASS_WITH_SKIPS, BUILD_PASSED, and more.
 func IsPassingResult(rowResult statuspb.TestStatus) bool {
@@ -68,7 +74,7 @@ func IsPassingResult(rowResult statuspb.TestStatus) bool {
 }
 
 // IsFailingResult returns true if the test status is any failing status,
-// including CATEGORIZED_FAILURE, BUILD_FAIL, and more.
+// including CATEGORIZED_FAILURE, BUILD_FAIL, ABORTED, TIMED_OUT and more.
 func IsFailingResult(rowResult statuspb.TestStatus) bool {
 	return gte(rowResult, statuspb.TestStatus_TOOL_FAIL) && lte(rowResult, statuspb.TestStatus_FAIL)
 }
@@ -105,6 +105,10 @@ func TestIsFailingResult(t *testing.T) {
 			status:   statuspb.TestStatus_TOOL_FAIL,
 			expected: true,
 		},
+		{
+			status:   statuspb.TestStatus_ABORTED,
+			expected: true,
+		},
 		{
 			status:   statuspb.TestStatus_TIMED_OUT,
 			expected: true,
@@ -132,6 +136,52 @@ func TestIsFailingResult(t *testing.T) {
 	}
 }
 
+func TestWorse(t *testing.T) {
+	cases := []struct {
+		name      string
+		rowResult statuspb.TestStatus
+		compareTo statuspb.TestStatus
+		expected  bool
+	}{
+		{
+			name:      "fail is worse than pass",
+			rowResult: statuspb.TestStatus_FAIL,
+			compareTo: statuspb.TestStatus_PASS,
+			expected:  true,
+		},
+		{
+			name:      "pass is not worse than fail",
+			rowResult: statuspb.TestStatus_PASS,
+			compareTo: statuspb.TestStatus_FAIL,
+		},
+		{
+			name:      "flaky is worse than pass",
+			rowResult: statuspb.TestStatus_FLAKY,
+			compareTo: statuspb.TestStatus_PASS,
+			expected:  true,
+		},
+		{
+			name:      "pass is worse than no result",
+			rowResult: statuspb.TestStatus_PASS,
+			compareTo: statuspb.TestStatus_NO_RESULT,
+			expected:  true,
+		},
+		{
+			name:      "equal results",
+			rowResult: statuspb.TestStatus_FAIL,
+			compareTo: statuspb.TestStatus_FAIL,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := Worse(tc.rowResult, tc.compareTo); actual != tc.expected {
+				t.Errorf("Worse(%v, %v) got %t, want %t", tc.rowResult, tc.compareTo, actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestCoalesce(t *testing.T) {
 	cases := []struct {
 		status        statuspb.TestStatus
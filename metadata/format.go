@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jobs write started.json and finished.json in several formats, such as:
+//   {"timestamp": 1600000000}
+//   {"timestamp": "1600000000"}
+//   {"timestamp": 1600000000000}
+//   {"timestamp": "2020-09-13T12:26:40Z"}
+// The UnmarshalJSON methods below accept each of these, along with a string
+// "passed" and the legacy "result" of finished.json, ignoring unknown fields.
+
+// UnmarshalJSON parses started.json, coercing the timestamp to epoch seconds.
+func (s *Started) UnmarshalJSON(buf []byte) error {
+	type plain Started
+	var raw struct {
+		plain
+		Timestamp json.RawMessage `json:"timestamp"`
+	}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+	ts, err := parseTimestamp(raw.Timestamp)
+	if err != nil {
+		return fmt.Errorf("started.json timestamp: %w", err)
+	}
+	*s = Started(raw.plain)
+	if ts != nil {
+		s.Timestamp = *ts
+	}
+	return nil
+}
+
+// UnmarshalJSON parses finished.json, coercing the timestamp to epoch seconds and passed to a bool.
+func (f *Finished) UnmarshalJSON(buf []byte) error {
+	type plain Finished
+	var raw struct {
+		plain
+		Timestamp json.RawMessage `json:"timestamp"`
+		Passed    json.RawMessage `json:"passed"`
+	}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+	ts, err := parseTimestamp(raw.Timestamp)
+	if err != nil {
+		return fmt.Errorf("finished.json timestamp: %w", err)
+	}
+	passed, err := parseBool(raw.Passed)
+	if err != nil {
+		return fmt.Errorf("finished.json passed: %w", err)
+	}
+	*f = Finished(raw.plain)
+	f.Timestamp = ts
+	f.Passed = passed
+	return nil
+}
+
+// Results of finished.json for jobs stopped before they could pass or fail.
+const (
+	ResultAborted  = "ABORTED"
+	ResultTimedOut = "TIMED_OUT"
+)
+
+// Stopped returns ResultAborted or ResultTimedOut when the result of the job says it was, or else an empty string.
+//
+// Also accepts lower case results, and TIMEOUT for a timed out job.
+func (f Finished) Stopped() string {
+	switch r := strings.ToUpper(f.Result); r {
+	case ResultAborted, ResultTimedOut:
+		return r
+	case "TIMEOUT":
+		return ResultTimedOut
+	}
+	return ""
+}
+
+// Succeeded returns whether the job passed, and false when finished.json does not say.
+//
+// Uses the legacy result when passed is missing.
+func (f Finished) Succeeded() (bool, bool) {
+	switch {
+	case f.Passed != nil:
+		return *f.Passed, true
+	case f.Result != "":
+		return f.Result == "SUCCESS", true
+	}
+	return false, false
+}
+
+// msThreshold is the first epoch second in 2286, beyond which timestamps are in milliseconds.
+const msThreshold = 10000000000
+
+// parseTimestamp returns the epoch seconds of a JSON number or string, or nil when null or missing.
+func parseTimestamp(raw json.RawMessage) (*int64, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+	var val string
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &val); err != nil {
+			return nil, err
+		}
+		val = strings.TrimSpace(val)
+		if val == "" {
+			return nil, nil
+		}
+		if t, err := time.Parse(time.RFC3339, val); err == nil {
+			sec := t.Unix()
+			return &sec, nil
+		}
+	} else {
+		val = string(raw)
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither epoch seconds nor RFC 3339", raw)
+	}
+	sec := int64(f)
+	if sec > msThreshold {
+		sec /= 1000
+	}
+	return &sec, nil
+}
+
+// parseBool returns the value of a JSON bool or string, or nil when null or missing.
+func parseBool(raw json.RawMessage) (*bool, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+	val := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &val); err != nil {
+			return nil, err
+		}
+		if val == "" {
+			return nil, nil
+		}
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a bool", raw)
+	}
+	return &b, nil
+}
+
+func isNull(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || bytes.Equal(raw, []byte("null"))
+}
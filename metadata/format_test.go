@@ -0,0 +1,267 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStartedUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		buf      string
+		expected *Started
+	}{
+		{
+			name:     "basically works",
+			buf:      `{}`,
+			expected: &Started{},
+		},
+		{
+			name:     "number",
+			buf:      `{"timestamp": 1600000000, "node": "machine"}`,
+			expected: &Started{Timestamp: 1600000000, Node: "machine"},
+		},
+		{
+			name:     "float",
+			buf:      `{"timestamp": 1600000000.5}`,
+			expected: &Started{Timestamp: 1600000000},
+		},
+		{
+			name:     "string",
+			buf:      `{"timestamp": "1600000000"}`,
+			expected: &Started{Timestamp: 1600000000},
+		},
+		{
+			name:     "milliseconds",
+			buf:      `{"timestamp": 1600000000123}`,
+			expected: &Started{Timestamp: 1600000000},
+		},
+		{
+			name:     "rfc 3339",
+			buf:      `{"timestamp": "2020-09-13T12:26:40Z"}`,
+			expected: &Started{Timestamp: 1600000000},
+		},
+		{
+			name:     "null",
+			buf:      `{"timestamp": null}`,
+			expected: &Started{},
+		},
+		{
+			name: "unknown fields",
+			buf:  `{"timestamp": 1, "surprise": {"nested": [1, 2]}, "repos": {"a": "b"}}`,
+			expected: &Started{
+				Timestamp: 1,
+				Repos:     map[string]string{"a": "b"},
+			},
+		},
+		{
+			name: "bad timestamp",
+			buf:  `{"timestamp": "soon"}`,
+		},
+		{
+			name: "malformed",
+			buf:  `{"timestamp": `,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual Started
+			err := json.Unmarshal([]byte(tc.buf), &actual)
+			switch {
+			case err != nil:
+				if tc.expected != nil {
+					t.Errorf("Unmarshal() got unexpected error: %v", err)
+				}
+			case tc.expected == nil:
+				t.Error("Unmarshal() failed to return an error")
+			case !reflect.DeepEqual(&actual, tc.expected):
+				t.Errorf("Unmarshal() got %#v, want %#v", actual, *tc.expected)
+			}
+		})
+	}
+}
+
+func TestFinishedUnmarshalJSON(t *testing.T) {
+	pint := func(v int64) *int64 {
+		return &v
+	}
+	pbool := func(v bool) *bool {
+		return &v
+	}
+	cases := []struct {
+		name     string
+		buf      string
+		expected *Finished
+	}{
+		{
+			name:     "basically works",
+			buf:      `{}`,
+			expected: &Finished{},
+		},
+		{
+			name: "current format",
+			buf:  `{"timestamp": 1600000000, "passed": true, "metadata": {"version": "v1"}}`,
+			expected: &Finished{
+				Timestamp: pint(1600000000),
+				Passed:    pbool(true),
+				Metadata:  Metadata{"version": "v1"},
+			},
+		},
+		{
+			name: "string values",
+			buf:  `{"timestamp": "1600000000", "passed": "false"}`,
+			expected: &Finished{
+				Timestamp: pint(1600000000),
+				Passed:    pbool(false),
+			},
+		},
+		{
+			name: "legacy result",
+			buf:  `{"timestamp": 1600000000, "result": "SUCCESS", "job-version": "v2"}`,
+			expected: &Finished{
+				Timestamp:            pint(1600000000),
+				Result:               "SUCCESS",
+				DeprecatedJobVersion: "v2",
+			},
+		},
+		{
+			name:     "null passed",
+			buf:      `{"passed": null}`,
+			expected: &Finished{},
+		},
+		{
+			name: "bad passed",
+			buf:  `{"passed": "maybe"}`,
+		},
+		{
+			name: "bad timestamp",
+			buf:  `{"timestamp": true}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual Finished
+			err := json.Unmarshal([]byte(tc.buf), &actual)
+			switch {
+			case err != nil:
+				if tc.expected != nil {
+					t.Errorf("Unmarshal() got unexpected error: %v", err)
+				}
+			case tc.expected == nil:
+				t.Error("Unmarshal() failed to return an error")
+			case !reflect.DeepEqual(&actual, tc.expected):
+				t.Errorf("Unmarshal() got %#v, want %#v", actual, *tc.expected)
+			}
+		})
+	}
+}
+
+func TestSucceeded(t *testing.T) {
+	yes := true
+	no := false
+	cases := []struct {
+		name     string
+		finished Finished
+		passed   bool
+		ok       bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "passed",
+			finished: Finished{Passed: &yes},
+			passed:   true,
+			ok:       true,
+		},
+		{
+			name:     "passed wins over result",
+			finished: Finished{Passed: &no, Result: "SUCCESS"},
+			ok:       true,
+		},
+		{
+			name:     "legacy success",
+			finished: Finished{Result: "SUCCESS"},
+			passed:   true,
+			ok:       true,
+		},
+		{
+			name:     "legacy failure",
+			finished: Finished{Result: "FAILURE"},
+			ok:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			passed, ok := tc.finished.Succeeded()
+			if passed != tc.passed || ok != tc.ok {
+				t.Errorf("Succeeded() got %t, %t, want %t, %t", passed, ok, tc.passed, tc.ok)
+			}
+		})
+	}
+}
+
+func TestStopped(t *testing.T) {
+	no := false
+	cases := []struct {
+		name     string
+		finished Finished
+		expected string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "failure",
+			finished: Finished{Passed: &no, Result: "FAILURE"},
+		},
+		{
+			name:     "aborted",
+			finished: Finished{Passed: &no, Result: "ABORTED"},
+			expected: ResultAborted,
+		},
+		{
+			name:     "lower case aborted",
+			finished: Finished{Result: "aborted"},
+			expected: ResultAborted,
+		},
+		{
+			name:     "timed out",
+			finished: Finished{Passed: &no, Result: "TIMED_OUT"},
+			expected: ResultTimedOut,
+		},
+		{
+			name:     "timeout",
+			finished: Finished{Passed: &no, Result: "timeout"},
+			expected: ResultTimedOut,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.finished.Stopped(); actual != tc.expected {
+				t.Errorf("Stopped() got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package metadata
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -78,7 +79,7 @@ type Finished struct {
 
 // Metadata holds the finished.json values in the metadata key.
 //
-// Metadata values can either be string or string map of strings
+// Metadata values can either be string or string map of strings.
 //
 // TODO(fejta): figure out which of these we want and document them
 // Special values: infra-commit, repos, repo, repo-commit, links, others
@@ -119,12 +120,19 @@ func (m Metadata) Keys() []string {
 	return ka
 }
 
-// Strings returns the submap of values in the map that are strings.
+// Strings returns the submap of values in the map that are strings, numbers or bools.
+//
+// Formats numbers and bools as strings, such as 1.5 and true.
 func (m Metadata) Strings() map[string]string {
 	bm := map[string]string{}
 	for k, v := range m {
-		if s, ok := v.(string); ok {
-			bm[k] = s
+		switch t := v.(type) {
+		case string:
+			bm[k] = t
+		case float64:
+			bm[k] = strconv.FormatFloat(t, 'f', -1, 64)
+		case bool:
+			bm[k] = strconv.FormatBool(t)
 		}
 		// TODO(fejta): handle sub items
 	}
@@ -280,3 +280,41 @@ func TestSetVersion(t *testing.T) {
 	}
 
 }
+
+func TestStrings(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       Metadata
+		expected map[string]string
+	}{
+		{
+			name:     "basically works",
+			expected: map[string]string{},
+		},
+		{
+			name: "format numbers and bools",
+			in: Metadata{
+				"version": "v1",
+				"count":   float64(3),
+				"ratio":   1.5,
+				"canary":  true,
+				"object":  map[string]interface{}{"ignored": "yes"},
+				"list":    []interface{}{"ignored"},
+			},
+			expected: map[string]string{
+				"version": "v1",
+				"count":   "3",
+				"ratio":   "1.5",
+				"canary":  "true",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.in.Strings(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Strings() got %v, want %v", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Other dialects of test reports, converted into Suites.
+//
+// Their results use the fully qualified name of each test, such as
+// Namespace.Class.Method, so the converted suites are unnamed.
+
+// reportFailure holds the <failure/> of xUnit.net and NUnit results.
+type reportFailure struct {
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stack-trace"`
+}
+
+func (f reportFailure) String() string {
+	return joinLines(f.Message, f.StackTrace)
+}
+
+// joinLines joins the non-empty strings with newlines.
+func joinLines(strs ...string) string {
+	var lines []string
+	for _, s := range strs {
+		if s = strings.TrimSpace(s); s != "" {
+			lines = append(lines, s)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func properties(props []Property) *Properties {
+	if len(props) == 0 {
+		return nil
+	}
+	return &Properties{PropertyList: props}
+}
+
+// xunitAssembly holds an xUnit.net v2 <assembly/>, usually inside <assemblies/>.
+type xunitAssembly struct {
+	Time        float64 `xml:"time,attr"`
+	Collections []struct {
+		Tests []xunitTest `xml:"test"`
+	} `xml:"collection"`
+}
+
+type xunitTest struct {
+	Name    string         `xml:"name,attr"`
+	Type    string         `xml:"type,attr"`
+	Time    float64        `xml:"time,attr"`
+	Result  string         `xml:"result,attr"` // Pass, Fail, Skip or NotRun
+	Failure *reportFailure `xml:"failure"`
+	Reason  *string        `xml:"reason"`
+	Output  *string        `xml:"output"`
+	Traits  []Property     `xml:"traits>trait"`
+}
+
+func (a xunitAssembly) suite() Suite {
+	suite := Suite{Time: a.Time}
+	for _, c := range a.Collections {
+		for _, t := range c.Tests {
+			r := Result{
+				Name:       t.Name,
+				ClassName:  t.Type,
+				Time:       t.Time,
+				Output:     t.Output,
+				Properties: properties(t.Traits),
+			}
+			switch t.Result {
+			case "Fail":
+				var msg string
+				if t.Failure != nil {
+					msg = t.Failure.String()
+				}
+				r.Failure = &msg
+				suite.Failures++
+			case "Skip", "NotRun":
+				var reason string
+				if t.Reason != nil {
+					reason = strings.TrimSpace(*t.Reason)
+				}
+				r.Skipped = &reason
+			}
+			suite.Results = append(suite.Results, r)
+		}
+	}
+	suite.Tests = len(suite.Results)
+	return suite
+}
+
+// nunitSuite holds an NUnit 3 <test-suite/>, whose assemblies are inside <test-run/>.
+type nunitSuite struct {
+	Duration float64      `xml:"duration,attr"`
+	Suites   []nunitSuite `xml:"test-suite"`
+	Cases    []nunitCase  `xml:"test-case"`
+}
+
+type nunitCase struct {
+	Name      string         `xml:"name,attr"`
+	FullName  string         `xml:"fullname,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Duration  float64        `xml:"duration,attr"`
+	Result    string         `xml:"result,attr"` // Passed, Failed, Skipped, Inconclusive or Warning
+	Label     string         `xml:"label,attr"`  // Such as Error, Cancelled or Ignored
+	Failure   *reportFailure `xml:"failure"`
+	Reason    *struct {
+		Message string `xml:"message"`
+	} `xml:"reason"`
+	Output     *string    `xml:"output"`
+	Properties []Property `xml:"properties>property"`
+}
+
+// suite returns the cases of the suite and every suite nested inside it.
+func (s nunitSuite) suite() Suite {
+	suite := Suite{Time: s.Duration}
+	s.addCases(&suite)
+	suite.Tests = len(suite.Results)
+	return suite
+}
+
+func (s nunitSuite) addCases(suite *Suite) {
+	for _, inner := range s.Suites {
+		inner.addCases(suite)
+	}
+	for _, c := range s.Cases {
+		name := c.FullName
+		if name == "" {
+			name = c.Name
+		}
+		r := Result{
+			Name:       name,
+			ClassName:  c.ClassName,
+			Time:       c.Duration,
+			Output:     c.Output,
+			Properties: properties(c.Properties),
+		}
+		switch c.Result {
+		case "Failed":
+			var msg string
+			if c.Failure != nil {
+				msg = c.Failure.String()
+			}
+			r.Failure = &msg
+			if c.Label == "Cancelled" {
+				r.Status = "aborted"
+			}
+			suite.Failures++
+		case "Skipped", "Inconclusive":
+			var reason string
+			if c.Reason != nil {
+				reason = strings.TrimSpace(c.Reason.Message)
+			}
+			r.Skipped = &reason
+		}
+		suite.Results = append(suite.Results, r)
+	}
+}
+
+// testngSuite holds a TestNG <suite/>, inside <testng-results/>.
+type testngSuite struct {
+	DurationMillis float64 `xml:"duration-ms,attr"`
+	Tests          []struct {
+		Classes []struct {
+			Name    string         `xml:"name,attr"`
+			Methods []testngMethod `xml:"test-method"`
+		} `xml:"class"`
+	} `xml:"test"`
+}
+
+type testngMethod struct {
+	Name           string  `xml:"name,attr"`
+	Status         string  `xml:"status,attr"` // PASS, FAIL or SKIP
+	IsConfig       bool    `xml:"is-config,attr"`
+	DurationMillis float64 `xml:"duration-ms,attr"`
+	Exception      *struct {
+		Message    string `xml:"message"`
+		StackTrace string `xml:"full-stacktrace"`
+	} `xml:"exception"`
+	Output []string `xml:"reporter-output>line"`
+}
+
+// suite returns the test methods of each class, along with any configuration methods that did not pass.
+func (s testngSuite) suite() Suite {
+	suite := Suite{Time: s.DurationMillis / 1000}
+	for _, t := range s.Tests {
+		for _, c := range t.Classes {
+			for _, m := range c.Methods {
+				if m.IsConfig && m.Status == "PASS" {
+					continue // such as @BeforeMethod
+				}
+				r := Result{
+					Name:      c.Name + "." + m.Name,
+					ClassName: c.Name,
+					Time:      m.DurationMillis / 1000,
+				}
+				if out := joinLines(m.Output...); out != "" {
+					r.Output = &out
+				}
+				var msg string
+				if m.Exception != nil {
+					msg = joinLines(m.Exception.Message, m.Exception.StackTrace)
+				}
+				switch m.Status {
+				case "FAIL":
+					r.Failure = &msg
+					suite.Failures++
+				case "SKIP":
+					r.Skipped = &msg
+				}
+				suite.Results = append(suite.Results, r)
+			}
+		}
+	}
+	suite.Tests = len(suite.Results)
+	return suite
+}
+
+// decodeDialect converts the xUnit.net, NUnit or TestNG report starting at start, returning false for other elements.
+func decodeDialect(d *xml.Decoder, start xml.StartElement, suites *Suites) (bool, error) {
+	switch start.Name.Local {
+	case "assemblies":
+		var report struct {
+			Assemblies []xunitAssembly `xml:"assembly"`
+		}
+		if err := d.DecodeElement(&report, &start); err != nil {
+			return true, err
+		}
+		for _, a := range report.Assemblies {
+			suites.Suites = append(suites.Suites, a.suite())
+		}
+	case "assembly":
+		var a xunitAssembly
+		if err := d.DecodeElement(&a, &start); err != nil {
+			return true, err
+		}
+		suites.Suites = append(suites.Suites, a.suite())
+	case "test-run":
+		var report struct {
+			Suites []nunitSuite `xml:"test-suite"`
+		}
+		if err := d.DecodeElement(&report, &start); err != nil {
+			return true, err
+		}
+		for _, s := range report.Suites {
+			suites.Suites = append(suites.Suites, s.suite())
+		}
+	case "testng-results":
+		var report struct {
+			Suites []testngSuite `xml:"suite"`
+		}
+		if err := d.DecodeElement(&report, &start); err != nil {
+			return true, err
+		}
+		for _, s := range report.Suites {
+			suites.Suites = append(suites.Suites, s.suite())
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
@@ -15,7 +15,7 @@ limitations under the License.
 */
 
 // Package junit describes the test-infra definition of "junit", and provides
-// utilities to parse it.
+// utilities to parse it, along with xUnit.net, NUnit 3 and TestNG reports.
 package junit
 
 import (
@@ -40,7 +40,13 @@ func (s *suiteOrSuites) UnmarshalXML(d *xml.Decoder, start xml.StartElement) err
 		d.DecodeElement(&suite, &start)
 		s.suites.Suites = append(s.suites.Suites, suite)
 	default:
-		return fmt.Errorf("bad element name: %q", start.Name)
+		ok, err := decodeDialect(d, start, &s.suites)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("bad element name: %q", start.Name)
+		}
 	}
 	s.suites.Truncate(10000)
 	return nil
@@ -96,14 +102,18 @@ type Properties struct {
 
 // Result holds <testcase/> results
 type Result struct {
-	Name       string      `xml:"name,attr"`
-	Time       float64     `xml:"time,attr"`
-	ClassName  string      `xml:"classname,attr"`
+	Name      string  `xml:"name,attr"`
+	Time      float64 `xml:"time,attr"`
+	ClassName string  `xml:"classname,attr"`
+	// Status optionally says how the testcase ended, such as aborted or timed_out.
+	Status     string      `xml:"status,attr,omitempty"`
 	Failure    *string     `xml:"failure,omitempty"`
 	Output     *string     `xml:"system-out,omitempty"`
 	Error      *string     `xml:"system-err,omitempty"`
 	Skipped    *string     `xml:"skipped,omitempty"`
 	Properties *Properties `xml:"properties,omitempty"`
+	// Methods holds the <testcase/> results nested in this one, such as its steps.
+	Methods []Result `xml:"testcase,omitempty"`
 }
 
 // SetProperty adds the specified property to the Result or replaces the
@@ -175,6 +185,9 @@ func (r Result) Truncate(max int) {
 	for _, s := range []*string{r.Failure, r.Skipped, r.Error, r.Output} {
 		truncatePointer(s, max)
 	}
+	for _, m := range r.Methods {
+		m.Truncate(max)
+	}
 }
 
 func unmarshalXML(reader io.Reader, i interface{}) error {
@@ -100,6 +100,9 @@ func TestMessage(t *testing.T) {
 }
 
 func TestParse(t *testing.T) {
+	pstr := func(s string) *string {
+		return &s
+	}
 	cases := []struct {
 		name     string
 		buf      []byte
@@ -127,6 +130,48 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse nested test methods",
+			buf: []byte(`
+                        <testsuite>
+                            <testcase name="case">
+                                <testcase name="first" time="1" />
+                                <testcase name="second">
+                                    <failure>boom</failure>
+                                </testcase>
+                            </testcase>
+                        </testsuite>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Results: []Result{
+							{
+								Name: "case",
+								Methods: []Result{
+									{Name: "first", Time: 1},
+									{Name: "second", Failure: pstr("boom")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "parse testcase status",
+			buf:  []byte(`<testsuite><testcase name="slow" status="timed_out"><failure>killed</failure></testcase></testsuite>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Results: []Result{
+							{Name: "slow", Status: "timed_out", Failure: pstr("killed")},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "parse testsuites correctly",
 			buf: []byte(`
@@ -167,6 +212,133 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse xunit.net assemblies",
+			buf: []byte(`
+                        <assemblies>
+                            <assembly name="Tests.dll" time="1.5">
+                                <collection name="Test collection for Ns.Class">
+                                    <test name="Ns.Class.Pass" type="Ns.Class" time="0.5" result="Pass">
+                                        <traits><trait name="Category" value="fast" /></traits>
+                                    </test>
+                                    <test name="Ns.Class.Fail" type="Ns.Class" time="1" result="Fail">
+                                        <failure><message>boom</message><stack-trace>at Fail()</stack-trace></failure>
+                                        <output>hello</output>
+                                    </test>
+                                    <test name="Ns.Class.Skip" type="Ns.Class" result="Skip">
+                                        <reason>later</reason>
+                                    </test>
+                                </collection>
+                            </assembly>
+                        </assemblies>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						Time:     1.5,
+						Failures: 1,
+						Tests:    3,
+						Results: []Result{
+							{
+								Name:      "Ns.Class.Pass",
+								ClassName: "Ns.Class",
+								Time:      0.5,
+								Properties: &Properties{
+									PropertyList: []Property{{Name: "Category", Value: "fast"}},
+								},
+							},
+							{
+								Name:      "Ns.Class.Fail",
+								ClassName: "Ns.Class",
+								Time:      1,
+								Failure:   pstr("boom\nat Fail()"),
+								Output:    pstr("hello"),
+							},
+							{
+								Name:      "Ns.Class.Skip",
+								ClassName: "Ns.Class",
+								Skipped:   pstr("later"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "parse nunit test runs",
+			buf: []byte(`
+                        <test-run>
+                            <test-suite type="Assembly" name="Tests.dll" duration="2">
+                                <test-suite type="TestFixture" name="Class">
+                                    <test-case name="Pass" fullname="Ns.Class.Pass" classname="Ns.Class" duration="0.25" result="Passed" />
+                                    <test-case name="Fail" fullname="Ns.Class.Fail" classname="Ns.Class" result="Failed">
+                                        <failure><message>boom</message></failure>
+                                    </test-case>
+                                    <test-case name="Cancel" fullname="Ns.Class.Cancel" classname="Ns.Class" result="Failed" label="Cancelled" />
+                                    <test-case name="Ignore" fullname="Ns.Class.Ignore" classname="Ns.Class" result="Skipped" label="Ignored">
+                                        <reason><message>later</message></reason>
+                                    </test-case>
+                                </test-suite>
+                                <test-case name="Top" result="Passed" />
+                            </test-suite>
+                        </test-run>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						Time:     2,
+						Failures: 2,
+						Tests:    5,
+						Results: []Result{
+							{Name: "Ns.Class.Pass", ClassName: "Ns.Class", Time: 0.25},
+							{Name: "Ns.Class.Fail", ClassName: "Ns.Class", Failure: pstr("boom")},
+							{Name: "Ns.Class.Cancel", ClassName: "Ns.Class", Status: "aborted", Failure: pstr("")},
+							{Name: "Ns.Class.Ignore", ClassName: "Ns.Class", Skipped: pstr("later")},
+							{Name: "Top"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "parse testng results",
+			buf: []byte(`
+                        <testng-results>
+                            <suite name="Suite" duration-ms="3000">
+                                <test name="Test">
+                                    <class name="pkg.ClassTest">
+                                        <test-method status="PASS" name="setUp" is-config="true" duration-ms="1" />
+                                        <test-method status="PASS" name="passes" duration-ms="1500">
+                                            <reporter-output><line>hello</line><line>world</line></reporter-output>
+                                        </test-method>
+                                        <test-method status="FAIL" name="fails" duration-ms="500">
+                                            <exception class="java.lang.AssertionError">
+                                                <message>boom</message>
+                                                <full-stacktrace>at fails()</full-stacktrace>
+                                            </exception>
+                                        </test-method>
+                                        <test-method status="SKIP" name="skips" />
+                                    </class>
+                                </test>
+                            </suite>
+                        </testng-results>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						Time:     3,
+						Failures: 1,
+						Tests:    3,
+						Results: []Result{
+							{Name: "pkg.ClassTest.passes", ClassName: "pkg.ClassTest", Time: 1.5, Output: pstr("hello\nworld")},
+							{Name: "pkg.ClassTest.fails", ClassName: "pkg.ClassTest", Time: 0.5, Failure: pstr("boom\nat fails()")},
+							{Name: "pkg.ClassTest.skips", ClassName: "pkg.ClassTest", Skipped: pstr("")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reject unknown reports",
+			buf:  []byte(`<report><test name="hi" /></report>`),
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"strconv"
+)
+
+// Pull describes a pull request checked out by Prow.
+type Pull struct {
+	Number int    `json:"number"`
+	Author string `json:"author,omitempty"`
+	SHA    string `json:"sha,omitempty"`
+}
+
+// Refs describes a repo checked out by Prow, along with any pulls merged into it.
+type Refs struct {
+	Org     string `json:"org"`
+	Repo    string `json:"repo"`
+	BaseRef string `json:"base_ref,omitempty"`
+	BaseSHA string `json:"base_sha,omitempty"`
+	Pulls   []Pull `json:"pulls,omitempty"`
+}
+
+// CloneRecord holds an entry of the clone-records.json clonerefs writes.
+type CloneRecord struct {
+	Refs     Refs   `json:"refs"`
+	Failed   bool   `json:"failed,omitempty"`
+	FinalSHA string `json:"final_sha,omitempty"`
+}
+
+// PodInfo holds the parts of the podinfo.json Prow writes that describe what the pod tested.
+type PodInfo struct {
+	Pod struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels,omitempty"`
+		} `json:"metadata"`
+		Spec struct {
+			Containers []Container `json:"containers,omitempty"`
+		} `json:"spec"`
+	} `json:"pod"`
+}
+
+// Container holds the environment of a container in the pod.
+type Container struct {
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// EnvVar is an environment variable of a container.
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// Keys of the metadata ProwMetadata returns.
+const (
+	ProwRepo       = "repo"
+	ProwBaseRef    = "base-ref"
+	ProwBaseSHA    = "base-sha"
+	ProwPull       = "pull"
+	ProwPullSHA    = "pull-sha"
+	ProwPullAuthor = "pull-author"
+)
+
+// ProwMetadata describes the primary repo and pull request a Prow job tested.
+//
+// Prefers the first clone record, falling back to the labels and environment of the pod.
+// Omits unknown values.
+func ProwMetadata(records []CloneRecord, pod *PodInfo) map[string]string {
+	out := map[string]string{}
+	set := func(key, val string) {
+		if _, ok := out[key]; ok || val == "" {
+			return
+		}
+		out[key] = val
+	}
+	if len(records) > 0 {
+		refs := records[0].Refs
+		if refs.Org != "" && refs.Repo != "" {
+			set(ProwRepo, refs.Org+"/"+refs.Repo)
+		}
+		set(ProwBaseRef, refs.BaseRef)
+		set(ProwBaseSHA, refs.BaseSHA)
+		if len(refs.Pulls) > 0 {
+			pull := refs.Pulls[0]
+			if pull.Number > 0 {
+				set(ProwPull, strconv.Itoa(pull.Number))
+			}
+			set(ProwPullSHA, pull.SHA)
+			set(ProwPullAuthor, pull.Author)
+		}
+	}
+	if pod != nil {
+		labels := pod.Pod.Metadata.Labels
+		env := map[string]string{}
+		for _, c := range pod.Pod.Spec.Containers {
+			for _, e := range c.Env {
+				env[e.Name] = e.Value
+			}
+		}
+		org, repo := firstFilled("", labels["prow.k8s.io/refs.org"], env["REPO_OWNER"]), firstFilled("", labels["prow.k8s.io/refs.repo"], env["REPO_NAME"])
+		if org != "" && repo != "" {
+			set(ProwRepo, org+"/"+repo)
+		}
+		set(ProwBaseRef, firstFilled("", labels["prow.k8s.io/refs.base_ref"], env["PULL_BASE_REF"]))
+		set(ProwBaseSHA, env["PULL_BASE_SHA"])
+		set(ProwPull, firstFilled("", labels["prow.k8s.io/refs.pull"], env["PULL_NUMBER"]))
+		set(ProwPullSHA, env["PULL_PULL_SHA"])
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
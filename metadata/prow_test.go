@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProwMetadata(t *testing.T) {
+	pod := func(labels map[string]string, env ...string) *PodInfo {
+		var p PodInfo
+		p.Pod.Metadata.Labels = labels
+		var c Container
+		for i := 0; i+1 < len(env); i += 2 {
+			c.Env = append(c.Env, EnvVar{Name: env[i], Value: env[i+1]})
+		}
+		p.Pod.Spec.Containers = []Container{c}
+		return &p
+	}
+	cases := []struct {
+		name     string
+		records  []CloneRecord
+		pod      *PodInfo
+		expected map[string]string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "periodic clone record",
+			records: []CloneRecord{
+				{
+					Refs: Refs{
+						Org:     "kubernetes",
+						Repo:    "kubernetes",
+						BaseRef: "master",
+						BaseSHA: "abc",
+					},
+				},
+			},
+			expected: map[string]string{
+				ProwRepo:    "kubernetes/kubernetes",
+				ProwBaseRef: "master",
+				ProwBaseSHA: "abc",
+			},
+		},
+		{
+			name: "presubmit uses the first pull of the first record",
+			records: []CloneRecord{
+				{
+					Refs: Refs{
+						Org:     "kubernetes",
+						Repo:    "test-infra",
+						BaseRef: "master",
+						Pulls: []Pull{
+							{Number: 123, Author: "someone", SHA: "def"},
+							{Number: 456, SHA: "ignored"},
+						},
+					},
+				},
+				{
+					Refs: Refs{Org: "other", Repo: "repo"},
+				},
+			},
+			expected: map[string]string{
+				ProwRepo:       "kubernetes/test-infra",
+				ProwBaseRef:    "master",
+				ProwPull:       "123",
+				ProwPullSHA:    "def",
+				ProwPullAuthor: "someone",
+			},
+		},
+		{
+			name: "pod fills in missing values",
+			records: []CloneRecord{
+				{
+					Refs: Refs{Org: "kubernetes", Repo: "test-infra"},
+				},
+			},
+			pod: pod(map[string]string{
+				"prow.k8s.io/refs.org":  "ignored",
+				"prow.k8s.io/refs.repo": "ignored",
+				"prow.k8s.io/refs.pull": "123",
+			}, "PULL_PULL_SHA", "def", "PULL_BASE_SHA", "abc"),
+			expected: map[string]string{
+				ProwRepo:    "kubernetes/test-infra",
+				ProwBaseSHA: "abc",
+				ProwPull:    "123",
+				ProwPullSHA: "def",
+			},
+		},
+		{
+			name: "pod env without labels",
+			pod:  pod(nil, "REPO_OWNER", "kubernetes", "REPO_NAME", "test-infra", "PULL_NUMBER", "7", "PULL_BASE_REF", "main"),
+			expected: map[string]string{
+				ProwRepo:    "kubernetes/test-infra",
+				ProwBaseRef: "main",
+				ProwPull:    "7",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := ProwMetadata(tc.records, tc.pod)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("ProwMetadata() got %v, want %v", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,2553 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: api.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	config "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	state "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summary "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	test_status "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The kind of state that changed.
+type DashboardEvent_Kind int32
+
+const (
+	DashboardEvent_UNKNOWN DashboardEvent_Kind = 0
+	// The summarizer wrote a new summary of the tab.
+	DashboardEvent_TAB_SUMMARY DashboardEvent_Kind = 1
+	// The updater wrote a new grid for the test group backing the tab.
+	DashboardEvent_GRID DashboardEvent_Kind = 2
+)
+
+var DashboardEvent_Kind_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "TAB_SUMMARY",
+	2: "GRID",
+}
+
+var DashboardEvent_Kind_value = map[string]int32{
+	"UNKNOWN":     0,
+	"TAB_SUMMARY": 1,
+	"GRID":        2,
+}
+
+func (x DashboardEvent_Kind) String() string {
+	return proto.EnumName(DashboardEvent_Kind_name, int32(x))
+}
+
+func (DashboardEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11, 0}
+}
+
+// How the test behaved over the columns of a range.
+type TestComparison_Outcome int32
+
+const (
+	// The test has no passing or failing results in the range.
+	TestComparison_ABSENT TestComparison_Outcome = 0
+	// The test only passed.
+	TestComparison_PASSING TestComparison_Outcome = 1
+	// The test both passed and failed, or flaked.
+	TestComparison_FLAKY TestComparison_Outcome = 2
+	// The test only failed.
+	TestComparison_FAILING TestComparison_Outcome = 3
+)
+
+var TestComparison_Outcome_name = map[int32]string{
+	0: "ABSENT",
+	1: "PASSING",
+	2: "FLAKY",
+	3: "FAILING",
+}
+
+var TestComparison_Outcome_value = map[string]int32{
+	"ABSENT":  0,
+	"PASSING": 1,
+	"FLAKY":   2,
+	"FAILING": 3,
+}
+
+func (x TestComparison_Outcome) String() string {
+	return proto.EnumName(TestComparison_Outcome_name, int32(x))
+}
+
+func (TestComparison_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14, 0}
+}
+
+// How the outcome of the test changed.
+type TestComparison_Change int32
+
+const (
+	TestComparison_UNKNOWN TestComparison_Change = 0
+	// The test fails in the target, but did not fail in the base.
+	TestComparison_NEWLY_FAILING TestComparison_Change = 1
+	// The test behaves worse in the target without newly failing, such as
+	// flaking where it passed before, or failing where it flaked before.
+	TestComparison_REGRESSED TestComparison_Change = 2
+	// The test passes in the target, but failed or flaked in the base.
+	TestComparison_FIXED TestComparison_Change = 3
+)
+
+var TestComparison_Change_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "NEWLY_FAILING",
+	2: "REGRESSED",
+	3: "FIXED",
+}
+
+var TestComparison_Change_value = map[string]int32{
+	"UNKNOWN":       0,
+	"NEWLY_FAILING": 1,
+	"REGRESSED":     2,
+	"FIXED":         3,
+}
+
+func (x TestComparison_Change) String() string {
+	return proto.EnumName(TestComparison_Change_name, int32(x))
+}
+
+func (TestComparison_Change) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14, 1}
+}
+
+// A request to list the dashboards of the configuration.
+type ListDashboardsRequest struct {
+	// Only list the dashboards in this dashboard group if set.
+	DashboardGroup       string   `protobuf:"bytes,1,opt,name=dashboard_group,json=dashboardGroup,proto3" json:"dashboard_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDashboardsRequest) Reset()         { *m = ListDashboardsRequest{} }
+func (m *ListDashboardsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDashboardsRequest) ProtoMessage()    {}
+func (*ListDashboardsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
+
+func (m *ListDashboardsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDashboardsRequest.Unmarshal(m, b)
+}
+func (m *ListDashboardsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDashboardsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDashboardsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDashboardsRequest.Merge(m, src)
+}
+func (m *ListDashboardsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDashboardsRequest.Size(m)
+}
+func (m *ListDashboardsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDashboardsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDashboardsRequest proto.InternalMessageInfo
+
+func (m *ListDashboardsRequest) GetDashboardGroup() string {
+	if m != nil {
+		return m.DashboardGroup
+	}
+	return ""
+}
+
+// A dashboard, without its tabs.
+type DashboardResource struct {
+	// The name of the dashboard.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The dashboard groups containing this dashboard.
+	DashboardGroups []string `protobuf:"bytes,2,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
+	// The owners of this dashboard, or else those of its dashboard group.
+	Ownership *config.Ownership `protobuf:"bytes,3,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	// The names of the tabs of this dashboard.
+	TabNames             []string `protobuf:"bytes,4,rep,name=tab_names,json=tabNames,proto3" json:"tab_names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardResource) Reset()         { *m = DashboardResource{} }
+func (m *DashboardResource) String() string { return proto.CompactTextString(m) }
+func (*DashboardResource) ProtoMessage()    {}
+func (*DashboardResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+
+func (m *DashboardResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardResource.Unmarshal(m, b)
+}
+func (m *DashboardResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardResource.Marshal(b, m, deterministic)
+}
+func (m *DashboardResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardResource.Merge(m, src)
+}
+func (m *DashboardResource) XXX_Size() int {
+	return xxx_messageInfo_DashboardResource.Size(m)
+}
+func (m *DashboardResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardResource proto.InternalMessageInfo
+
+func (m *DashboardResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DashboardResource) GetDashboardGroups() []string {
+	if m != nil {
+		return m.DashboardGroups
+	}
+	return nil
+}
+
+func (m *DashboardResource) GetOwnership() *config.Ownership {
+	if m != nil {
+		return m.Ownership
+	}
+	return nil
+}
+
+func (m *DashboardResource) GetTabNames() []string {
+	if m != nil {
+		return m.TabNames
+	}
+	return nil
+}
+
+// The dashboards of the configuration, sorted by name.
+type ListDashboardsResponse struct {
+	Dashboards           []*DashboardResource `protobuf:"bytes,1,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListDashboardsResponse) Reset()         { *m = ListDashboardsResponse{} }
+func (m *ListDashboardsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDashboardsResponse) ProtoMessage()    {}
+func (*ListDashboardsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+
+func (m *ListDashboardsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDashboardsResponse.Unmarshal(m, b)
+}
+func (m *ListDashboardsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDashboardsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDashboardsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDashboardsResponse.Merge(m, src)
+}
+func (m *ListDashboardsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDashboardsResponse.Size(m)
+}
+func (m *ListDashboardsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDashboardsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDashboardsResponse proto.InternalMessageInfo
+
+func (m *ListDashboardsResponse) GetDashboards() []*DashboardResource {
+	if m != nil {
+		return m.Dashboards
+	}
+	return nil
+}
+
+// A request to list the tabs of a dashboard.
+type ListTabsRequest struct {
+	// The name of the dashboard.
+	Dashboard            string   `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTabsRequest) Reset()         { *m = ListTabsRequest{} }
+func (m *ListTabsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTabsRequest) ProtoMessage()    {}
+func (*ListTabsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+}
+
+func (m *ListTabsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTabsRequest.Unmarshal(m, b)
+}
+func (m *ListTabsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTabsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTabsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTabsRequest.Merge(m, src)
+}
+func (m *ListTabsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTabsRequest.Size(m)
+}
+func (m *ListTabsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTabsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTabsRequest proto.InternalMessageInfo
+
+func (m *ListTabsRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+// A dashboard tab.
+type TabResource struct {
+	// The name of the tab.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The name of the test group backing this tab.
+	TestGroupName string `protobuf:"bytes,2,opt,name=test_group_name,json=testGroupName,proto3" json:"test_group_name,omitempty"`
+	// The description of the tab.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Link to the runbook or other documentation of the tab.
+	DocumentationUrl     string   `protobuf:"bytes,4,opt,name=documentation_url,json=documentationUrl,proto3" json:"documentation_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TabResource) Reset()         { *m = TabResource{} }
+func (m *TabResource) String() string { return proto.CompactTextString(m) }
+func (*TabResource) ProtoMessage()    {}
+func (*TabResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+}
+
+func (m *TabResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TabResource.Unmarshal(m, b)
+}
+func (m *TabResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TabResource.Marshal(b, m, deterministic)
+}
+func (m *TabResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TabResource.Merge(m, src)
+}
+func (m *TabResource) XXX_Size() int {
+	return xxx_messageInfo_TabResource.Size(m)
+}
+func (m *TabResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_TabResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TabResource proto.InternalMessageInfo
+
+func (m *TabResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TabResource) GetTestGroupName() string {
+	if m != nil {
+		return m.TestGroupName
+	}
+	return ""
+}
+
+func (m *TabResource) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *TabResource) GetDocumentationUrl() string {
+	if m != nil {
+		return m.DocumentationUrl
+	}
+	return ""
+}
+
+// The tabs of a dashboard, in the order the dashboard displays them.
+type ListTabsResponse struct {
+	Tabs                 []*TabResource `protobuf:"bytes,1,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListTabsResponse) Reset()         { *m = ListTabsResponse{} }
+func (m *ListTabsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTabsResponse) ProtoMessage()    {}
+func (*ListTabsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+}
+
+func (m *ListTabsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTabsResponse.Unmarshal(m, b)
+}
+func (m *ListTabsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTabsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTabsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTabsResponse.Merge(m, src)
+}
+func (m *ListTabsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTabsResponse.Size(m)
+}
+func (m *ListTabsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTabsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTabsResponse proto.InternalMessageInfo
+
+func (m *ListTabsResponse) GetTabs() []*TabResource {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+// A request for a page of the grid of a dashboard tab.
+type GetTabStateRequest struct {
+	// The name of the dashboard.
+	Dashboard string `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	// The name of the tab.
+	Tab string `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	// Skip this many of the most recent columns.
+	ColumnOffset int32 `protobuf:"varint,3,opt,name=column_offset,json=columnOffset,proto3" json:"column_offset,omitempty"`
+	// Return at most this many columns, or all remaining columns when zero.
+	ColumnLimit int32 `protobuf:"varint,4,opt,name=column_limit,json=columnLimit,proto3" json:"column_limit,omitempty"`
+	// Skip this many rows.
+	RowOffset int32 `protobuf:"varint,5,opt,name=row_offset,json=rowOffset,proto3" json:"row_offset,omitempty"`
+	// Return at most this many rows, or all remaining rows when zero.
+	RowLimit int32 `protobuf:"varint,6,opt,name=row_limit,json=rowLimit,proto3" json:"row_limit,omitempty"`
+	// Only return rows whose name matches this regular expression if set.
+	RowRegex string `protobuf:"bytes,7,opt,name=row_regex,json=rowRegex,proto3" json:"row_regex,omitempty"`
+	// Only return rows with a result of one of these statuses in the returned
+	// columns if set.
+	Status []test_status.TestStatus `protobuf:"varint,8,rep,packed,name=status,proto3,enum=TestStatus" json:"status,omitempty"`
+	// Only return these fields of the response if set, such as grid.columns for
+	// only the column headers, or grid.rows.name and grid.rows.results. Paths use
+	// proto field names and descend into the elements of repeated messages.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,9,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetTabStateRequest) Reset()         { *m = GetTabStateRequest{} }
+func (m *GetTabStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTabStateRequest) ProtoMessage()    {}
+func (*GetTabStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+}
+
+func (m *GetTabStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTabStateRequest.Unmarshal(m, b)
+}
+func (m *GetTabStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTabStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTabStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTabStateRequest.Merge(m, src)
+}
+func (m *GetTabStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTabStateRequest.Size(m)
+}
+func (m *GetTabStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTabStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTabStateRequest proto.InternalMessageInfo
+
+func (m *GetTabStateRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *GetTabStateRequest) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *GetTabStateRequest) GetColumnOffset() int32 {
+	if m != nil {
+		return m.ColumnOffset
+	}
+	return 0
+}
+
+func (m *GetTabStateRequest) GetColumnLimit() int32 {
+	if m != nil {
+		return m.ColumnLimit
+	}
+	return 0
+}
+
+func (m *GetTabStateRequest) GetRowOffset() int32 {
+	if m != nil {
+		return m.RowOffset
+	}
+	return 0
+}
+
+func (m *GetTabStateRequest) GetRowLimit() int32 {
+	if m != nil {
+		return m.RowLimit
+	}
+	return 0
+}
+
+func (m *GetTabStateRequest) GetRowRegex() string {
+	if m != nil {
+		return m.RowRegex
+	}
+	return ""
+}
+
+func (m *GetTabStateRequest) GetStatus() []test_status.TestStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetTabStateRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+// A page of the grid of a dashboard tab.
+type GetTabStateResponse struct {
+	// The requested columns and rows of the grid.
+	// Omits failure clusters unless the page includes every column.
+	Grid *state.Grid `protobuf:"bytes,1,opt,name=grid,proto3" json:"grid,omitempty"`
+	// The number of columns in the full grid.
+	TotalColumns int32 `protobuf:"varint,2,opt,name=total_columns,json=totalColumns,proto3" json:"total_columns,omitempty"`
+	// The number of rows matching the filters, before paging through them.
+	TotalRows            int32    `protobuf:"varint,3,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTabStateResponse) Reset()         { *m = GetTabStateResponse{} }
+func (m *GetTabStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTabStateResponse) ProtoMessage()    {}
+func (*GetTabStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+}
+
+func (m *GetTabStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTabStateResponse.Unmarshal(m, b)
+}
+func (m *GetTabStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTabStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTabStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTabStateResponse.Merge(m, src)
+}
+func (m *GetTabStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTabStateResponse.Size(m)
+}
+func (m *GetTabStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTabStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTabStateResponse proto.InternalMessageInfo
+
+func (m *GetTabStateResponse) GetGrid() *state.Grid {
+	if m != nil {
+		return m.Grid
+	}
+	return nil
+}
+
+func (m *GetTabStateResponse) GetTotalColumns() int32 {
+	if m != nil {
+		return m.TotalColumns
+	}
+	return 0
+}
+
+func (m *GetTabStateResponse) GetTotalRows() int32 {
+	if m != nil {
+		return m.TotalRows
+	}
+	return 0
+}
+
+// A request for the summary of a dashboard.
+type GetSummaryRequest struct {
+	// The name of the dashboard.
+	Dashboard            string   `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSummaryRequest) Reset()         { *m = GetSummaryRequest{} }
+func (m *GetSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSummaryRequest) ProtoMessage()    {}
+func (*GetSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+}
+
+func (m *GetSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSummaryRequest.Unmarshal(m, b)
+}
+func (m *GetSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSummaryRequest.Merge(m, src)
+}
+func (m *GetSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSummaryRequest.Size(m)
+}
+func (m *GetSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSummaryRequest proto.InternalMessageInfo
+
+func (m *GetSummaryRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+// The summary of a dashboard.
+type GetSummaryResponse struct {
+	Summary              *summary.DashboardSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetSummaryResponse) Reset()         { *m = GetSummaryResponse{} }
+func (m *GetSummaryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSummaryResponse) ProtoMessage()    {}
+func (*GetSummaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+}
+
+func (m *GetSummaryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSummaryResponse.Unmarshal(m, b)
+}
+func (m *GetSummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSummaryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSummaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSummaryResponse.Merge(m, src)
+}
+func (m *GetSummaryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSummaryResponse.Size(m)
+}
+func (m *GetSummaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSummaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSummaryResponse proto.InternalMessageInfo
+
+func (m *GetSummaryResponse) GetSummary() *summary.DashboardSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+// A request to watch a dashboard for changes.
+type WatchDashboardRequest struct {
+	// The name of the dashboard.
+	Dashboard            string   `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchDashboardRequest) Reset()         { *m = WatchDashboardRequest{} }
+func (m *WatchDashboardRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchDashboardRequest) ProtoMessage()    {}
+func (*WatchDashboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+
+func (m *WatchDashboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchDashboardRequest.Unmarshal(m, b)
+}
+func (m *WatchDashboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchDashboardRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchDashboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchDashboardRequest.Merge(m, src)
+}
+func (m *WatchDashboardRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchDashboardRequest.Size(m)
+}
+func (m *WatchDashboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchDashboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchDashboardRequest proto.InternalMessageInfo
+
+func (m *WatchDashboardRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+// A change to the state of a dashboard tab.
+type DashboardEvent struct {
+	Kind DashboardEvent_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=DashboardEvent_Kind" json:"kind,omitempty"`
+	// The name of the dashboard.
+	Dashboard string `protobuf:"bytes,2,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	// The name of the tab.
+	Tab string `protobuf:"bytes,3,opt,name=tab,proto3" json:"tab,omitempty"`
+	// The generation of the object the change wrote.
+	Generation int64 `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`
+	// The new summary of the tab, for TAB_SUMMARY events.
+	TabSummary           *summary.DashboardTabSummary `protobuf:"bytes,5,opt,name=tab_summary,json=tabSummary,proto3" json:"tab_summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *DashboardEvent) Reset()         { *m = DashboardEvent{} }
+func (m *DashboardEvent) String() string { return proto.CompactTextString(m) }
+func (*DashboardEvent) ProtoMessage()    {}
+func (*DashboardEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+
+func (m *DashboardEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardEvent.Unmarshal(m, b)
+}
+func (m *DashboardEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardEvent.Marshal(b, m, deterministic)
+}
+func (m *DashboardEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardEvent.Merge(m, src)
+}
+func (m *DashboardEvent) XXX_Size() int {
+	return xxx_messageInfo_DashboardEvent.Size(m)
+}
+func (m *DashboardEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardEvent proto.InternalMessageInfo
+
+func (m *DashboardEvent) GetKind() DashboardEvent_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return DashboardEvent_UNKNOWN
+}
+
+func (m *DashboardEvent) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *DashboardEvent) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *DashboardEvent) GetGeneration() int64 {
+	if m != nil {
+		return m.Generation
+	}
+	return 0
+}
+
+func (m *DashboardEvent) GetTabSummary() *summary.DashboardTabSummary {
+	if m != nil {
+		return m.TabSummary
+	}
+	return nil
+}
+
+// The columns of a dashboard tab started within a time range.
+type TabRange struct {
+	// The name of the dashboard.
+	Dashboard string `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	// The name of the tab.
+	Tab string `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	// Only include columns started at or after this time if set.
+	Start *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	// Only include columns started before this time if set.
+	End                  *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TabRange) Reset()         { *m = TabRange{} }
+func (m *TabRange) String() string { return proto.CompactTextString(m) }
+func (*TabRange) ProtoMessage()    {}
+func (*TabRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+
+func (m *TabRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TabRange.Unmarshal(m, b)
+}
+func (m *TabRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TabRange.Marshal(b, m, deterministic)
+}
+func (m *TabRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TabRange.Merge(m, src)
+}
+func (m *TabRange) XXX_Size() int {
+	return xxx_messageInfo_TabRange.Size(m)
+}
+func (m *TabRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_TabRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TabRange proto.InternalMessageInfo
+
+func (m *TabRange) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *TabRange) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *TabRange) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *TabRange) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+// A request to compare the results of tests in two tabs, or in the same tab
+// over two time ranges.
+type CompareTabsRequest struct {
+	// The results to compare against, such as those of the previous release.
+	Base *TabRange `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// The results to compare, such as those of the new release branch.
+	Target               *TabRange `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CompareTabsRequest) Reset()         { *m = CompareTabsRequest{} }
+func (m *CompareTabsRequest) String() string { return proto.CompactTextString(m) }
+func (*CompareTabsRequest) ProtoMessage()    {}
+func (*CompareTabsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+}
+
+func (m *CompareTabsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompareTabsRequest.Unmarshal(m, b)
+}
+func (m *CompareTabsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompareTabsRequest.Marshal(b, m, deterministic)
+}
+func (m *CompareTabsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareTabsRequest.Merge(m, src)
+}
+func (m *CompareTabsRequest) XXX_Size() int {
+	return xxx_messageInfo_CompareTabsRequest.Size(m)
+}
+func (m *CompareTabsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareTabsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompareTabsRequest proto.InternalMessageInfo
+
+func (m *CompareTabsRequest) GetBase() *TabRange {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *CompareTabsRequest) GetTarget() *TabRange {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// A test whose outcome differs between the base and target ranges.
+type TestComparison struct {
+	// The name of the test row.
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Change        TestComparison_Change  `protobuf:"varint,2,opt,name=change,proto3,enum=TestComparison_Change" json:"change,omitempty"`
+	BaseOutcome   TestComparison_Outcome `protobuf:"varint,3,opt,name=base_outcome,json=baseOutcome,proto3,enum=TestComparison_Outcome" json:"base_outcome,omitempty"`
+	TargetOutcome TestComparison_Outcome `protobuf:"varint,4,opt,name=target_outcome,json=targetOutcome,proto3,enum=TestComparison_Outcome" json:"target_outcome,omitempty"`
+	// The message of the most recent failure in the target range, if any.
+	FailureMessage       string   `protobuf:"bytes,5,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestComparison) Reset()         { *m = TestComparison{} }
+func (m *TestComparison) String() string { return proto.CompactTextString(m) }
+func (*TestComparison) ProtoMessage()    {}
+func (*TestComparison) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+
+func (m *TestComparison) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestComparison.Unmarshal(m, b)
+}
+func (m *TestComparison) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestComparison.Marshal(b, m, deterministic)
+}
+func (m *TestComparison) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestComparison.Merge(m, src)
+}
+func (m *TestComparison) XXX_Size() int {
+	return xxx_messageInfo_TestComparison.Size(m)
+}
+func (m *TestComparison) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestComparison.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestComparison proto.InternalMessageInfo
+
+func (m *TestComparison) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TestComparison) GetChange() TestComparison_Change {
+	if m != nil {
+		return m.Change
+	}
+	return TestComparison_UNKNOWN
+}
+
+func (m *TestComparison) GetBaseOutcome() TestComparison_Outcome {
+	if m != nil {
+		return m.BaseOutcome
+	}
+	return TestComparison_ABSENT
+}
+
+func (m *TestComparison) GetTargetOutcome() TestComparison_Outcome {
+	if m != nil {
+		return m.TargetOutcome
+	}
+	return TestComparison_ABSENT
+}
+
+func (m *TestComparison) GetFailureMessage() string {
+	if m != nil {
+		return m.FailureMessage
+	}
+	return ""
+}
+
+// The tests whose outcomes changed.
+type CompareTabsResponse struct {
+	// Sorted by change, newly failing tests first, then by name.
+	Tests []*TestComparison `protobuf:"bytes,1,rep,name=tests,proto3" json:"tests,omitempty"`
+	// The number of columns in the base range.
+	BaseColumns int32 `protobuf:"varint,2,opt,name=base_columns,json=baseColumns,proto3" json:"base_columns,omitempty"`
+	// The number of columns in the target range.
+	TargetColumns        int32    `protobuf:"varint,3,opt,name=target_columns,json=targetColumns,proto3" json:"target_columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompareTabsResponse) Reset()         { *m = CompareTabsResponse{} }
+func (m *CompareTabsResponse) String() string { return proto.CompactTextString(m) }
+func (*CompareTabsResponse) ProtoMessage()    {}
+func (*CompareTabsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+
+func (m *CompareTabsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompareTabsResponse.Unmarshal(m, b)
+}
+func (m *CompareTabsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompareTabsResponse.Marshal(b, m, deterministic)
+}
+func (m *CompareTabsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareTabsResponse.Merge(m, src)
+}
+func (m *CompareTabsResponse) XXX_Size() int {
+	return xxx_messageInfo_CompareTabsResponse.Size(m)
+}
+func (m *CompareTabsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareTabsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompareTabsResponse proto.InternalMessageInfo
+
+func (m *CompareTabsResponse) GetTests() []*TestComparison {
+	if m != nil {
+		return m.Tests
+	}
+	return nil
+}
+
+func (m *CompareTabsResponse) GetBaseColumns() int32 {
+	if m != nil {
+		return m.BaseColumns
+	}
+	return 0
+}
+
+func (m *CompareTabsResponse) GetTargetColumns() int32 {
+	if m != nil {
+		return m.TargetColumns
+	}
+	return 0
+}
+
+// A request to update the test group of a tab as soon as possible.
+type TriggerUpdateRequest struct {
+	Dashboard            string   `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	Tab                  string   `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerUpdateRequest) Reset()         { *m = TriggerUpdateRequest{} }
+func (m *TriggerUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*TriggerUpdateRequest) ProtoMessage()    {}
+func (*TriggerUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+
+func (m *TriggerUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerUpdateRequest.Unmarshal(m, b)
+}
+func (m *TriggerUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerUpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *TriggerUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerUpdateRequest.Merge(m, src)
+}
+func (m *TriggerUpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_TriggerUpdateRequest.Size(m)
+}
+func (m *TriggerUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerUpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerUpdateRequest proto.InternalMessageInfo
+
+func (m *TriggerUpdateRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *TriggerUpdateRequest) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+// The updater will update the group, then trigger summaries of its dashboards.
+type TriggerUpdateResponse struct {
+	// The name of the test group that will update.
+	TestGroupName        string   `protobuf:"bytes,1,opt,name=test_group_name,json=testGroupName,proto3" json:"test_group_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerUpdateResponse) Reset()         { *m = TriggerUpdateResponse{} }
+func (m *TriggerUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*TriggerUpdateResponse) ProtoMessage()    {}
+func (*TriggerUpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+
+func (m *TriggerUpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerUpdateResponse.Unmarshal(m, b)
+}
+func (m *TriggerUpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerUpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *TriggerUpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerUpdateResponse.Merge(m, src)
+}
+func (m *TriggerUpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_TriggerUpdateResponse.Size(m)
+}
+func (m *TriggerUpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerUpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerUpdateResponse proto.InternalMessageInfo
+
+func (m *TriggerUpdateResponse) GetTestGroupName() string {
+	if m != nil {
+		return m.TestGroupName
+	}
+	return ""
+}
+
+// A request to summarize a dashboard as soon as possible.
+type TriggerSummaryRequest struct {
+	Dashboard            string   `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerSummaryRequest) Reset()         { *m = TriggerSummaryRequest{} }
+func (m *TriggerSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*TriggerSummaryRequest) ProtoMessage()    {}
+func (*TriggerSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+
+func (m *TriggerSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSummaryRequest.Unmarshal(m, b)
+}
+func (m *TriggerSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *TriggerSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSummaryRequest.Merge(m, src)
+}
+func (m *TriggerSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_TriggerSummaryRequest.Size(m)
+}
+func (m *TriggerSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSummaryRequest proto.InternalMessageInfo
+
+func (m *TriggerSummaryRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+type TriggerSummaryResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerSummaryResponse) Reset()         { *m = TriggerSummaryResponse{} }
+func (m *TriggerSummaryResponse) String() string { return proto.CompactTextString(m) }
+func (*TriggerSummaryResponse) ProtoMessage()    {}
+func (*TriggerSummaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+
+func (m *TriggerSummaryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSummaryResponse.Unmarshal(m, b)
+}
+func (m *TriggerSummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSummaryResponse.Marshal(b, m, deterministic)
+}
+func (m *TriggerSummaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSummaryResponse.Merge(m, src)
+}
+func (m *TriggerSummaryResponse) XXX_Size() int {
+	return xxx_messageInfo_TriggerSummaryResponse.Size(m)
+}
+func (m *TriggerSummaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSummaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSummaryResponse proto.InternalMessageInfo
+
+// A request to list the failing tests of every dashboard.
+type ListFailingTestsRequest struct {
+	// Only list tests whose name matches this regular expression if set.
+	TestRegex string `protobuf:"bytes,1,opt,name=test_regex,json=testRegex,proto3" json:"test_regex,omitempty"`
+	// Only search the dashboards in this dashboard group if set.
+	DashboardGroup       string   `protobuf:"bytes,2,opt,name=dashboard_group,json=dashboardGroup,proto3" json:"dashboard_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFailingTestsRequest) Reset()         { *m = ListFailingTestsRequest{} }
+func (m *ListFailingTestsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFailingTestsRequest) ProtoMessage()    {}
+func (*ListFailingTestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+
+func (m *ListFailingTestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFailingTestsRequest.Unmarshal(m, b)
+}
+func (m *ListFailingTestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFailingTestsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFailingTestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFailingTestsRequest.Merge(m, src)
+}
+func (m *ListFailingTestsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFailingTestsRequest.Size(m)
+}
+func (m *ListFailingTestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFailingTestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFailingTestsRequest proto.InternalMessageInfo
+
+func (m *ListFailingTestsRequest) GetTestRegex() string {
+	if m != nil {
+		return m.TestRegex
+	}
+	return ""
+}
+
+func (m *ListFailingTestsRequest) GetDashboardGroup() string {
+	if m != nil {
+		return m.DashboardGroup
+	}
+	return ""
+}
+
+// A test failing in one or more dashboard tabs.
+type FailingTest struct {
+	// The name of the test.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The tabs in which the test fails, sorted by dashboard then tab.
+	Tabs                 []*FailingTest_Tab `protobuf:"bytes,2,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *FailingTest) Reset()         { *m = FailingTest{} }
+func (m *FailingTest) String() string { return proto.CompactTextString(m) }
+func (*FailingTest) ProtoMessage()    {}
+func (*FailingTest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+}
+
+func (m *FailingTest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailingTest.Unmarshal(m, b)
+}
+func (m *FailingTest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailingTest.Marshal(b, m, deterministic)
+}
+func (m *FailingTest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailingTest.Merge(m, src)
+}
+func (m *FailingTest) XXX_Size() int {
+	return xxx_messageInfo_FailingTest.Size(m)
+}
+func (m *FailingTest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailingTest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailingTest proto.InternalMessageInfo
+
+func (m *FailingTest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FailingTest) GetTabs() []*FailingTest_Tab {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+// A dashboard tab in which the test fails.
+type FailingTest_Tab struct {
+	// The name of the dashboard.
+	Dashboard string `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	// The name of the tab.
+	Tab string `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	// The failure from the latest summary of the tab.
+	Failure              *summary.FailingTestSummary `protobuf:"bytes,3,opt,name=failure,proto3" json:"failure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *FailingTest_Tab) Reset()         { *m = FailingTest_Tab{} }
+func (m *FailingTest_Tab) String() string { return proto.CompactTextString(m) }
+func (*FailingTest_Tab) ProtoMessage()    {}
+func (*FailingTest_Tab) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21, 0}
+}
+
+func (m *FailingTest_Tab) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailingTest_Tab.Unmarshal(m, b)
+}
+func (m *FailingTest_Tab) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailingTest_Tab.Marshal(b, m, deterministic)
+}
+func (m *FailingTest_Tab) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailingTest_Tab.Merge(m, src)
+}
+func (m *FailingTest_Tab) XXX_Size() int {
+	return xxx_messageInfo_FailingTest_Tab.Size(m)
+}
+func (m *FailingTest_Tab) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailingTest_Tab.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailingTest_Tab proto.InternalMessageInfo
+
+func (m *FailingTest_Tab) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *FailingTest_Tab) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *FailingTest_Tab) GetFailure() *summary.FailingTestSummary {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+// The tests failing in the latest summaries of the dashboards.
+type ListFailingTestsResponse struct {
+	// Sorted by the number of tabs, most widespread failures first, then by name.
+	Tests                []*FailingTest `protobuf:"bytes,1,rep,name=tests,proto3" json:"tests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListFailingTestsResponse) Reset()         { *m = ListFailingTestsResponse{} }
+func (m *ListFailingTestsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFailingTestsResponse) ProtoMessage()    {}
+func (*ListFailingTestsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+}
+
+func (m *ListFailingTestsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFailingTestsResponse.Unmarshal(m, b)
+}
+func (m *ListFailingTestsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFailingTestsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFailingTestsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFailingTestsResponse.Merge(m, src)
+}
+func (m *ListFailingTestsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFailingTestsResponse.Size(m)
+}
+func (m *ListFailingTestsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFailingTestsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFailingTestsResponse proto.InternalMessageInfo
+
+func (m *ListFailingTestsResponse) GetTests() []*FailingTest {
+	if m != nil {
+		return m.Tests
+	}
+	return nil
+}
+
+// A request to find the tabs containing tests by name.
+type SearchTestsRequest struct {
+	// Find tests whose name starts with this query, ignoring case.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Find tests whose name contains the query anywhere instead.
+	Substring bool `protobuf:"varint,2,opt,name=substring,proto3" json:"substring,omitempty"`
+	// Return at most this many tests, or 100 when zero.
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchTestsRequest) Reset()         { *m = SearchTestsRequest{} }
+func (m *SearchTestsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchTestsRequest) ProtoMessage()    {}
+func (*SearchTestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *SearchTestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchTestsRequest.Unmarshal(m, b)
+}
+func (m *SearchTestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchTestsRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchTestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchTestsRequest.Merge(m, src)
+}
+func (m *SearchTestsRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchTestsRequest.Size(m)
+}
+func (m *SearchTestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchTestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchTestsRequest proto.InternalMessageInfo
+
+func (m *SearchTestsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchTestsRequest) GetSubstring() bool {
+	if m != nil {
+		return m.Substring
+	}
+	return false
+}
+
+func (m *SearchTestsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// A test and the tabs whose grids contain a row for it.
+type TestMatch struct {
+	// The name of the test row.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Sorted by dashboard then tab.
+	Tabs                 []*TestMatch_Tab `protobuf:"bytes,2,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *TestMatch) Reset()         { *m = TestMatch{} }
+func (m *TestMatch) String() string { return proto.CompactTextString(m) }
+func (*TestMatch) ProtoMessage()    {}
+func (*TestMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *TestMatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestMatch.Unmarshal(m, b)
+}
+func (m *TestMatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestMatch.Marshal(b, m, deterministic)
+}
+func (m *TestMatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestMatch.Merge(m, src)
+}
+func (m *TestMatch) XXX_Size() int {
+	return xxx_messageInfo_TestMatch.Size(m)
+}
+func (m *TestMatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestMatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestMatch proto.InternalMessageInfo
+
+func (m *TestMatch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TestMatch) GetTabs() []*TestMatch_Tab {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+// A dashboard tab containing the test.
+type TestMatch_Tab struct {
+	// The name of the dashboard.
+	Dashboard string `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	// The name of the tab.
+	Tab                  string   `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestMatch_Tab) Reset()         { *m = TestMatch_Tab{} }
+func (m *TestMatch_Tab) String() string { return proto.CompactTextString(m) }
+func (*TestMatch_Tab) ProtoMessage()    {}
+func (*TestMatch_Tab) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24, 0}
+}
+
+func (m *TestMatch_Tab) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestMatch_Tab.Unmarshal(m, b)
+}
+func (m *TestMatch_Tab) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestMatch_Tab.Marshal(b, m, deterministic)
+}
+func (m *TestMatch_Tab) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestMatch_Tab.Merge(m, src)
+}
+func (m *TestMatch_Tab) XXX_Size() int {
+	return xxx_messageInfo_TestMatch_Tab.Size(m)
+}
+func (m *TestMatch_Tab) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestMatch_Tab.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestMatch_Tab proto.InternalMessageInfo
+
+func (m *TestMatch_Tab) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *TestMatch_Tab) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+// The tests matching the query in the latest index of test names.
+type SearchTestsResponse struct {
+	// Sorted by name, ignoring case.
+	Tests []*TestMatch `protobuf:"bytes,1,rep,name=tests,proto3" json:"tests,omitempty"`
+	// True when more tests match than the limit.
+	Truncated bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// When the server finished indexing the grids.
+	Indexed              *timestamp.Timestamp `protobuf:"bytes,3,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SearchTestsResponse) Reset()         { *m = SearchTestsResponse{} }
+func (m *SearchTestsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchTestsResponse) ProtoMessage()    {}
+func (*SearchTestsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *SearchTestsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchTestsResponse.Unmarshal(m, b)
+}
+func (m *SearchTestsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchTestsResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchTestsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchTestsResponse.Merge(m, src)
+}
+func (m *SearchTestsResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchTestsResponse.Size(m)
+}
+func (m *SearchTestsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchTestsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchTestsResponse proto.InternalMessageInfo
+
+func (m *SearchTestsResponse) GetTests() []*TestMatch {
+	if m != nil {
+		return m.Tests
+	}
+	return nil
+}
+
+func (m *SearchTestsResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *SearchTestsResponse) GetIndexed() *timestamp.Timestamp {
+	if m != nil {
+		return m.Indexed
+	}
+	return nil
+}
+
+// A request for the recent results of a test in every tab containing it.
+type GetTestHistoryRequest struct {
+	// The exact name of the test row.
+	Test string `protobuf:"bytes,1,opt,name=test,proto3" json:"test,omitempty"`
+	// Return at most this many results of each tab, or 50 when zero.
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTestHistoryRequest) Reset()         { *m = GetTestHistoryRequest{} }
+func (m *GetTestHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTestHistoryRequest) ProtoMessage()    {}
+func (*GetTestHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *GetTestHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTestHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetTestHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTestHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTestHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTestHistoryRequest.Merge(m, src)
+}
+func (m *GetTestHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTestHistoryRequest.Size(m)
+}
+func (m *GetTestHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTestHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTestHistoryRequest proto.InternalMessageInfo
+
+func (m *GetTestHistoryRequest) GetTest() string {
+	if m != nil {
+		return m.Test
+	}
+	return ""
+}
+
+func (m *GetTestHistoryRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// The result of a test in a column of a tab.
+type TestResult struct {
+	// The build of the column.
+	Build string `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	// When the column started.
+	Started *timestamp.Timestamp   `protobuf:"bytes,2,opt,name=started,proto3" json:"started,omitempty"`
+	Status  test_status.TestStatus `protobuf:"varint,3,opt,name=status,proto3,enum=TestStatus" json:"status,omitempty"`
+	Message string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Link to the result, expanded from the open_test_template of the tab.
+	Link                 string   `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestResult) Reset()         { *m = TestResult{} }
+func (m *TestResult) String() string { return proto.CompactTextString(m) }
+func (*TestResult) ProtoMessage()    {}
+func (*TestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+
+func (m *TestResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestResult.Unmarshal(m, b)
+}
+func (m *TestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestResult.Marshal(b, m, deterministic)
+}
+func (m *TestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestResult.Merge(m, src)
+}
+func (m *TestResult) XXX_Size() int {
+	return xxx_messageInfo_TestResult.Size(m)
+}
+func (m *TestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestResult proto.InternalMessageInfo
+
+func (m *TestResult) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *TestResult) GetStarted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *TestResult) GetStatus() test_status.TestStatus {
+	if m != nil {
+		return m.Status
+	}
+	return test_status.TestStatus_NO_RESULT
+}
+
+func (m *TestResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *TestResult) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+// The recent results of a test in a tab.
+type TestHistory struct {
+	// The name of the dashboard.
+	Dashboard string `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	// The name of the tab.
+	Tab string `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	// The most recent results, newest first, omitting columns without a result.
+	Results []*TestResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	// The most recent passing result in the grid, even when older than the
+	// results above. Unset when the test never passed in the tab.
+	LastPass             *TestResult `protobuf:"bytes,4,opt,name=last_pass,json=lastPass,proto3" json:"last_pass,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TestHistory) Reset()         { *m = TestHistory{} }
+func (m *TestHistory) String() string { return proto.CompactTextString(m) }
+func (*TestHistory) ProtoMessage()    {}
+func (*TestHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+
+func (m *TestHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestHistory.Unmarshal(m, b)
+}
+func (m *TestHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestHistory.Marshal(b, m, deterministic)
+}
+func (m *TestHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestHistory.Merge(m, src)
+}
+func (m *TestHistory) XXX_Size() int {
+	return xxx_messageInfo_TestHistory.Size(m)
+}
+func (m *TestHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestHistory proto.InternalMessageInfo
+
+func (m *TestHistory) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *TestHistory) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *TestHistory) GetResults() []*TestResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *TestHistory) GetLastPass() *TestResult {
+	if m != nil {
+		return m.LastPass
+	}
+	return nil
+}
+
+// The history of the test in each tab containing it.
+type GetTestHistoryResponse struct {
+	// Sorted by dashboard then tab.
+	Tabs []*TestHistory `protobuf:"bytes,1,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	// The tab with the most recent passing result, along with only that result.
+	// Unset when the test never passed in any of the tabs.
+	LastPass             *TestHistory `protobuf:"bytes,2,opt,name=last_pass,json=lastPass,proto3" json:"last_pass,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetTestHistoryResponse) Reset()         { *m = GetTestHistoryResponse{} }
+func (m *GetTestHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTestHistoryResponse) ProtoMessage()    {}
+func (*GetTestHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+}
+
+func (m *GetTestHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTestHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetTestHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTestHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTestHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTestHistoryResponse.Merge(m, src)
+}
+func (m *GetTestHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTestHistoryResponse.Size(m)
+}
+func (m *GetTestHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTestHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTestHistoryResponse proto.InternalMessageInfo
+
+func (m *GetTestHistoryResponse) GetTabs() []*TestHistory {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+func (m *GetTestHistoryResponse) GetLastPass() *TestHistory {
+	if m != nil {
+		return m.LastPass
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("DashboardEvent_Kind", DashboardEvent_Kind_name, DashboardEvent_Kind_value)
+	proto.RegisterEnum("TestComparison_Outcome", TestComparison_Outcome_name, TestComparison_Outcome_value)
+	proto.RegisterEnum("TestComparison_Change", TestComparison_Change_name, TestComparison_Change_value)
+	proto.RegisterType((*ListDashboardsRequest)(nil), "ListDashboardsRequest")
+	proto.RegisterType((*DashboardResource)(nil), "DashboardResource")
+	proto.RegisterType((*ListDashboardsResponse)(nil), "ListDashboardsResponse")
+	proto.RegisterType((*ListTabsRequest)(nil), "ListTabsRequest")
+	proto.RegisterType((*TabResource)(nil), "TabResource")
+	proto.RegisterType((*ListTabsResponse)(nil), "ListTabsResponse")
+	proto.RegisterType((*GetTabStateRequest)(nil), "GetTabStateRequest")
+	proto.RegisterType((*GetTabStateResponse)(nil), "GetTabStateResponse")
+	proto.RegisterType((*GetSummaryRequest)(nil), "GetSummaryRequest")
+	proto.RegisterType((*GetSummaryResponse)(nil), "GetSummaryResponse")
+	proto.RegisterType((*WatchDashboardRequest)(nil), "WatchDashboardRequest")
+	proto.RegisterType((*DashboardEvent)(nil), "DashboardEvent")
+	proto.RegisterType((*TabRange)(nil), "TabRange")
+	proto.RegisterType((*CompareTabsRequest)(nil), "CompareTabsRequest")
+	proto.RegisterType((*TestComparison)(nil), "TestComparison")
+	proto.RegisterType((*CompareTabsResponse)(nil), "CompareTabsResponse")
+	proto.RegisterType((*TriggerUpdateRequest)(nil), "TriggerUpdateRequest")
+	proto.RegisterType((*TriggerUpdateResponse)(nil), "TriggerUpdateResponse")
+	proto.RegisterType((*TriggerSummaryRequest)(nil), "TriggerSummaryRequest")
+	proto.RegisterType((*TriggerSummaryResponse)(nil), "TriggerSummaryResponse")
+	proto.RegisterType((*ListFailingTestsRequest)(nil), "ListFailingTestsRequest")
+	proto.RegisterType((*FailingTest)(nil), "FailingTest")
+	proto.RegisterType((*FailingTest_Tab)(nil), "FailingTest.Tab")
+	proto.RegisterType((*ListFailingTestsResponse)(nil), "ListFailingTestsResponse")
+	proto.RegisterType((*SearchTestsRequest)(nil), "SearchTestsRequest")
+	proto.RegisterType((*TestMatch)(nil), "TestMatch")
+	proto.RegisterType((*TestMatch_Tab)(nil), "TestMatch.Tab")
+	proto.RegisterType((*SearchTestsResponse)(nil), "SearchTestsResponse")
+	proto.RegisterType((*GetTestHistoryRequest)(nil), "GetTestHistoryRequest")
+	proto.RegisterType((*TestResult)(nil), "TestResult")
+	proto.RegisterType((*TestHistory)(nil), "TestHistory")
+	proto.RegisterType((*GetTestHistoryResponse)(nil), "GetTestHistoryResponse")
+}
+
+func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 1685 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xdd, 0x72, 0xe3, 0xb6,
+	0x15, 0x16, 0xf5, 0x63, 0x89, 0x47, 0xb6, 0x24, 0x43, 0xb6, 0x96, 0x51, 0x92, 0x56, 0x41, 0xba,
+	0x8d, 0x33, 0x69, 0xe1, 0x44, 0xcd, 0x4e, 0x66, 0x76, 0xa6, 0x69, 0xb4, 0xeb, 0x9f, 0x7a, 0xd6,
+	0xf6, 0x66, 0x28, 0x6d, 0xb7, 0x7b, 0x53, 0x0d, 0x28, 0xc1, 0x32, 0x67, 0x25, 0x52, 0x21, 0xc0,
+	0x3a, 0xed, 0x03, 0xf4, 0xa6, 0x33, 0xbd, 0x6e, 0xfb, 0x0a, 0x9d, 0x3e, 0x45, 0xaf, 0xfa, 0x38,
+	0xbd, 0xec, 0x5d, 0x06, 0x3f, 0x94, 0x48, 0x99, 0x4e, 0xec, 0xdc, 0x48, 0xc4, 0xc1, 0x87, 0x83,
+	0x73, 0x3e, 0xe0, 0xfc, 0x00, 0x6c, 0xba, 0xf4, 0xc9, 0x32, 0x0a, 0x45, 0xd8, 0xed, 0xcd, 0xc2,
+	0x70, 0x36, 0x67, 0x87, 0x6a, 0xe4, 0xc5, 0x57, 0x87, 0x57, 0x3e, 0x9b, 0x4f, 0xc7, 0x0b, 0xca,
+	0xdf, 0x1a, 0xc4, 0x4f, 0x37, 0x11, 0xc2, 0x5f, 0x30, 0x2e, 0xe8, 0x62, 0x69, 0x00, 0x9d, 0xa5,
+	0x77, 0x38, 0x09, 0x83, 0x2b, 0x7f, 0x66, 0xfe, 0x8c, 0x7c, 0x6f, 0xe9, 0x1d, 0x72, 0x41, 0x05,
+	0xd3, 0xbf, 0x46, 0xea, 0x48, 0x69, 0xbc, 0x58, 0xd0, 0xe8, 0x4f, 0xc9, 0x7f, 0x62, 0xca, 0xd2,
+	0x3b, 0x14, 0x8c, 0x8b, 0xb1, 0x84, 0xc7, 0x3c, 0xfd, 0xad, 0x11, 0xf8, 0x2b, 0xd8, 0x3f, 0xf7,
+	0xb9, 0x38, 0xa2, 0xfc, 0xda, 0x0b, 0x69, 0x34, 0xe5, 0x2e, 0xfb, 0x26, 0x66, 0x5c, 0xa0, 0x8f,
+	0xa0, 0x39, 0x4d, 0x84, 0xe3, 0x59, 0x14, 0xc6, 0x4b, 0xc7, 0xea, 0x59, 0x07, 0xb6, 0xdb, 0x58,
+	0x89, 0x4f, 0xa5, 0x14, 0xff, 0xc3, 0x82, 0xdd, 0xd5, 0x72, 0x97, 0xf1, 0x30, 0x8e, 0x26, 0x0c,
+	0x21, 0x28, 0x07, 0x74, 0xc1, 0xcc, 0x1a, 0xf5, 0x8d, 0x3e, 0x86, 0xd6, 0x86, 0x4a, 0xee, 0x14,
+	0x7b, 0xa5, 0x03, 0xdb, 0x6d, 0x66, 0x75, 0x72, 0x74, 0x00, 0x76, 0x78, 0x13, 0xb0, 0x88, 0x5f,
+	0xfb, 0x4b, 0xa7, 0xd4, 0xb3, 0x0e, 0xea, 0x7d, 0x20, 0x2f, 0x13, 0x89, 0xbb, 0x9e, 0x44, 0xef,
+	0x82, 0x2d, 0xa8, 0x37, 0x96, 0x1b, 0x70, 0xa7, 0xac, 0xb4, 0xd5, 0x04, 0xf5, 0x2e, 0xe5, 0x18,
+	0x9f, 0x43, 0x67, 0xd3, 0x3b, 0xbe, 0x0c, 0x03, 0xce, 0x50, 0x1f, 0x60, 0xb5, 0x27, 0x77, 0xac,
+	0x5e, 0xe9, 0xa0, 0xde, 0x47, 0xe4, 0x96, 0x1f, 0x6e, 0x0a, 0x85, 0x0f, 0xa1, 0x29, 0xb5, 0x8d,
+	0xa8, 0xb7, 0x62, 0xe9, 0x3d, 0xb0, 0x57, 0x00, 0xe3, 0xeb, 0x5a, 0x80, 0xff, 0x6e, 0x41, 0x7d,
+	0x44, 0xbd, 0xef, 0x25, 0xe5, 0xe7, 0xd0, 0x54, 0xa7, 0xa2, 0xf8, 0x50, 0x6e, 0x38, 0x45, 0x35,
+	0xbd, 0x23, 0xc5, 0x8a, 0x0e, 0xe9, 0x0b, 0xea, 0x41, 0x7d, 0xca, 0xf8, 0x24, 0xf2, 0x97, 0xc2,
+	0x0f, 0x03, 0xc5, 0x89, 0xed, 0xa6, 0x45, 0xe8, 0x13, 0xd8, 0x9d, 0x86, 0x93, 0x78, 0xc1, 0x02,
+	0x41, 0xa5, 0x60, 0x1c, 0x47, 0x73, 0xa7, 0xac, 0x70, 0xad, 0xcc, 0xc4, 0xab, 0x68, 0x8e, 0x3f,
+	0x87, 0xd6, 0xda, 0x17, 0xc3, 0x49, 0x0f, 0xca, 0x82, 0x7a, 0x09, 0x1b, 0xdb, 0x24, 0x65, 0xba,
+	0xab, 0x66, 0xf0, 0x7f, 0x8a, 0x80, 0x4e, 0x99, 0x5c, 0x35, 0x94, 0xf7, 0xef, 0x5e, 0x2c, 0xa0,
+	0x16, 0x94, 0x04, 0xf5, 0x8c, 0x57, 0xf2, 0x13, 0x7d, 0x08, 0x3b, 0x93, 0x70, 0x1e, 0x2f, 0x82,
+	0x71, 0x78, 0x75, 0xc5, 0x99, 0x50, 0xde, 0x54, 0xdc, 0x6d, 0x2d, 0x7c, 0xa9, 0x64, 0xe8, 0x03,
+	0x30, 0xe3, 0xf1, 0xdc, 0x5f, 0xf8, 0x42, 0x79, 0x52, 0x71, 0xeb, 0x5a, 0x76, 0x2e, 0x45, 0xe8,
+	0x7d, 0x80, 0x28, 0xbc, 0x49, 0x94, 0x54, 0x14, 0xc0, 0x8e, 0xc2, 0x1b, 0xa3, 0xe1, 0x5d, 0x90,
+	0x03, 0xb3, 0x7c, 0x4b, 0xcd, 0xd6, 0xa2, 0xf0, 0x46, 0xaf, 0x35, 0x93, 0x11, 0x9b, 0xb1, 0x6f,
+	0x9d, 0xaa, 0xb2, 0x4d, 0x4e, 0xba, 0x72, 0x8c, 0x3e, 0x84, 0x2d, 0x1d, 0x25, 0x4e, 0xad, 0x57,
+	0x3a, 0x68, 0xf4, 0xeb, 0x64, 0xc4, 0xb8, 0x18, 0x2a, 0x91, 0x6b, 0xa6, 0xd0, 0x17, 0x60, 0x47,
+	0x8c, 0xea, 0xc0, 0x76, 0x6c, 0x75, 0x47, 0xbb, 0x44, 0x47, 0x36, 0x49, 0x22, 0x9b, 0x9c, 0xc8,
+	0xd8, 0xbf, 0xa0, 0xfc, 0xad, 0x5b, 0x93, 0x60, 0xf9, 0x85, 0x05, 0xb4, 0x33, 0x24, 0x1a, 0xfa,
+	0xdf, 0x81, 0xf2, 0x2c, 0xf2, 0x35, 0x81, 0xf5, 0x7e, 0x85, 0x9c, 0x46, 0xfe, 0xd4, 0x55, 0x22,
+	0x49, 0x98, 0x08, 0x05, 0x9d, 0x8f, 0xb5, 0xf7, 0x5c, 0x91, 0x59, 0x71, 0xb7, 0x95, 0xf0, 0xb9,
+	0x96, 0x49, 0x36, 0x34, 0x28, 0x0a, 0x6f, 0xb8, 0xa1, 0xd4, 0x56, 0x12, 0x37, 0xbc, 0xe1, 0xf8,
+	0x33, 0xd8, 0x3d, 0x65, 0x62, 0xa8, 0xf3, 0xc3, 0xfd, 0xee, 0xef, 0x00, 0x50, 0x7a, 0x89, 0xb1,
+	0xf3, 0x13, 0xa8, 0x9a, 0x2c, 0x63, 0x4c, 0xdd, 0x5d, 0xc7, 0x4d, 0x82, 0x4d, 0x10, 0xf8, 0x09,
+	0xec, 0xbf, 0xa6, 0x62, 0x72, 0x9d, 0x8a, 0xac, 0xfb, 0xec, 0xfc, 0x3f, 0x0b, 0x1a, 0xab, 0x25,
+	0xc7, 0x7f, 0x64, 0x81, 0x40, 0x07, 0x50, 0x7e, 0xeb, 0x07, 0x1a, 0xdb, 0xe8, 0xef, 0x91, 0xec,
+	0x34, 0x79, 0xe1, 0x07, 0x53, 0x57, 0x21, 0xb2, 0xaa, 0x8b, 0x77, 0x5c, 0xc7, 0xd2, 0xfa, 0x3a,
+	0xfe, 0x04, 0x60, 0xc6, 0x02, 0x16, 0xa9, 0xe0, 0x50, 0xf7, 0xac, 0xe4, 0xa6, 0x24, 0xe8, 0x09,
+	0xd4, 0x65, 0x8a, 0x49, 0x9c, 0xae, 0x28, 0xa7, 0x53, 0x06, 0xc8, 0x93, 0x34, 0x7e, 0x83, 0x58,
+	0x7d, 0x63, 0x02, 0x65, 0x69, 0x14, 0xaa, 0x43, 0xf5, 0xd5, 0xe5, 0x8b, 0xcb, 0x97, 0xaf, 0x2f,
+	0x5b, 0x05, 0xd4, 0x84, 0xfa, 0x68, 0xf0, 0x6c, 0x3c, 0x7c, 0x75, 0x71, 0x31, 0x70, 0xdf, 0xb4,
+	0x2c, 0x54, 0x83, 0xf2, 0xa9, 0x7b, 0x76, 0xd4, 0x2a, 0xe2, 0x7f, 0x5a, 0x50, 0x93, 0x21, 0x47,
+	0x83, 0x19, 0x7b, 0x70, 0x48, 0x7d, 0x0a, 0x15, 0x2e, 0x68, 0x24, 0x9c, 0xd2, 0x1d, 0x17, 0x71,
+	0x94, 0x94, 0x18, 0x57, 0x03, 0xd1, 0x2f, 0xa0, 0xc4, 0x82, 0xa9, 0x53, 0xfe, 0x41, 0xbc, 0x84,
+	0xe1, 0xdf, 0x01, 0x7a, 0x1e, 0x2e, 0x96, 0x34, 0x62, 0xe9, 0xf4, 0xf7, 0x3e, 0x94, 0x3d, 0xca,
+	0x99, 0xb9, 0x07, 0x36, 0x49, 0xcc, 0x77, 0x95, 0x18, 0x7d, 0x00, 0x5b, 0x82, 0x46, 0x33, 0x26,
+	0x9c, 0xe2, 0x26, 0xc0, 0x4c, 0xe0, 0xff, 0x17, 0xa1, 0x21, 0x63, 0x4b, 0x2b, 0xf7, 0x79, 0x18,
+	0xe4, 0x66, 0x49, 0x02, 0x5b, 0x93, 0x6b, 0xb9, 0x50, 0x69, 0x6a, 0xf4, 0x3b, 0x24, 0xbb, 0x88,
+	0x3c, 0xbf, 0xd6, 0x6a, 0x35, 0x0a, 0x3d, 0x85, 0x6d, 0x69, 0xc1, 0x38, 0x8c, 0xc5, 0x24, 0x5c,
+	0x30, 0xc5, 0x4a, 0xa3, 0xff, 0x68, 0x73, 0xd5, 0x4b, 0x3d, 0xed, 0xd6, 0x25, 0xd8, 0x0c, 0xd0,
+	0x97, 0xd0, 0xd0, 0xc6, 0xad, 0x56, 0x97, 0xbf, 0x7f, 0xf5, 0x8e, 0x86, 0x27, 0xeb, 0x3f, 0x82,
+	0xe6, 0x15, 0xf5, 0xe7, 0x71, 0xc4, 0xc6, 0x0b, 0xc6, 0x39, 0x9d, 0x31, 0x75, 0x65, 0x6c, 0xb7,
+	0x61, 0xc4, 0x17, 0x5a, 0x8a, 0x9f, 0x42, 0x35, 0x59, 0x03, 0xb0, 0x35, 0x78, 0x36, 0x3c, 0xbe,
+	0x1c, 0xb5, 0x0a, 0xf2, 0xbe, 0x7c, 0x3d, 0x18, 0x0e, 0xcf, 0x2e, 0x4f, 0x5b, 0x16, 0xb2, 0xa1,
+	0x72, 0x72, 0x3e, 0x78, 0xf1, 0xa6, 0x55, 0x94, 0xf2, 0x93, 0xc1, 0xd9, 0xb9, 0x94, 0x97, 0xf0,
+	0x33, 0xd8, 0xd2, 0x2e, 0x67, 0xaf, 0xd7, 0x2e, 0xec, 0x5c, 0x1e, 0xbf, 0x3e, 0x7f, 0x33, 0x4e,
+	0x90, 0x16, 0xda, 0x01, 0xdb, 0x3d, 0x3e, 0x75, 0x8f, 0x87, 0xc3, 0xe3, 0xa3, 0x56, 0x51, 0x29,
+	0x3c, 0xfb, 0xfd, 0xf1, 0x51, 0xab, 0x84, 0xff, 0x62, 0x41, 0x3b, 0x73, 0xa8, 0x26, 0xc0, 0x1f,
+	0x43, 0x45, 0x30, 0x2e, 0x92, 0x42, 0xd0, 0xdc, 0xf0, 0xdb, 0xd5, 0xb3, 0x32, 0x41, 0x2b, 0x8e,
+	0xb3, 0x39, 0x49, 0x51, 0x99, 0xa4, 0xa4, 0xc7, 0x2b, 0x2a, 0x13, 0x90, 0x4e, 0x4b, 0x86, 0x31,
+	0x03, 0xc3, 0x27, 0xb0, 0x37, 0x8a, 0xfc, 0xd9, 0x8c, 0x45, 0xaf, 0x96, 0xd3, 0x1f, 0x5f, 0x57,
+	0xf0, 0x6f, 0x60, 0x7f, 0x43, 0x8f, 0xf1, 0x28, 0xa7, 0xc8, 0x5a, 0x39, 0x45, 0x16, 0x3f, 0x59,
+	0x29, 0x78, 0x50, 0x9e, 0x74, 0xa0, 0xb3, 0xb9, 0x4c, 0x6f, 0x8c, 0x29, 0x3c, 0x92, 0x65, 0xf6,
+	0x84, 0xfa, 0x73, 0x3f, 0x98, 0x49, 0x1e, 0x53, 0xb1, 0x03, 0xca, 0x26, 0x5d, 0x81, 0x8c, 0x4e,
+	0x29, 0xd1, 0x25, 0x28, 0xa7, 0xff, 0x2a, 0xe6, 0xf6, 0x5f, 0xff, 0xb6, 0xa0, 0x9e, 0xd2, 0x9f,
+	0x1b, 0x3e, 0x3f, 0x33, 0x95, 0xbd, 0xa8, 0x0e, 0xb4, 0x45, 0x52, 0x78, 0x15, 0x92, 0x6a, 0xb6,
+	0x3b, 0x85, 0xd2, 0x88, 0x7a, 0x0f, 0x4e, 0x3d, 0xbf, 0x84, 0xaa, 0xb9, 0xd8, 0x26, 0xf9, 0xb4,
+	0xd3, 0xfa, 0x57, 0x15, 0xc1, 0x60, 0xf0, 0x97, 0xe0, 0xdc, 0xa6, 0xc4, 0x9c, 0x13, 0xce, 0xde,
+	0xbc, 0xed, 0xb4, 0x22, 0x73, 0xed, 0xf0, 0x1f, 0x00, 0x0d, 0x19, 0x8d, 0x26, 0xd7, 0x19, 0x36,
+	0xf7, 0xa0, 0xf2, 0x4d, 0xcc, 0x4c, 0x49, 0xb2, 0x5d, 0x3d, 0x90, 0xae, 0xf0, 0xd8, 0xe3, 0x22,
+	0xf2, 0x83, 0x99, 0x32, 0xb9, 0xe6, 0xae, 0x05, 0x72, 0x8d, 0xee, 0x0d, 0xf4, 0xa5, 0xd4, 0x03,
+	0xfc, 0x67, 0xb0, 0xa5, 0xe6, 0x0b, 0x59, 0xb5, 0x72, 0xc9, 0xc4, 0x19, 0x32, 0x1b, 0x64, 0x85,
+	0x4e, 0x51, 0xf9, 0xe4, 0x47, 0x51, 0x89, 0xff, 0x6a, 0x41, 0x3b, 0xe3, 0xdc, 0xaa, 0x33, 0xcb,
+	0xf0, 0x02, 0xeb, 0x3d, 0x93, 0x60, 0x7c, 0x0f, 0x6c, 0x11, 0xc5, 0xc1, 0x84, 0x0a, 0x36, 0x4d,
+	0x3c, 0x5d, 0x09, 0xd0, 0xe7, 0x50, 0xf5, 0x83, 0x29, 0xfb, 0x96, 0x4d, 0xef, 0x51, 0x1f, 0x12,
+	0x28, 0x1e, 0xc0, 0xbe, 0xec, 0x53, 0x18, 0x17, 0xbf, 0xf5, 0xb9, 0x08, 0xd7, 0xd1, 0x80, 0xa0,
+	0x2c, 0x77, 0x4d, 0x58, 0x11, 0xe6, 0x00, 0x34, 0x99, 0xc5, 0x34, 0x99, 0xff, 0xb2, 0x00, 0xd4,
+	0xe1, 0x31, 0x1e, 0xcf, 0x15, 0xc8, 0x8b, 0xfd, 0x79, 0xc2, 0x85, 0x1e, 0x48, 0xeb, 0x54, 0x49,
+	0x32, 0x96, 0xff, 0x80, 0x75, 0x06, 0x9a, 0xea, 0xd1, 0x74, 0x72, 0xcf, 0xed, 0xd1, 0x1c, 0xa8,
+	0x26, 0x39, 0x58, 0x77, 0xc2, 0xc9, 0x50, 0xfa, 0x30, 0xf7, 0x83, 0xb7, 0x26, 0x35, 0xab, 0x6f,
+	0xfc, 0x37, 0xd9, 0xaf, 0xaf, 0xdd, 0x7d, 0x70, 0x24, 0x3c, 0x86, 0x6a, 0xa4, 0x1c, 0x95, 0x36,
+	0xc9, 0x83, 0xaa, 0x93, 0xb5, 0xf3, 0x6e, 0x32, 0x27, 0x1f, 0x37, 0x73, 0xca, 0xc5, 0x78, 0x49,
+	0x39, 0x37, 0xf5, 0x37, 0x03, 0xac, 0xc9, 0xd9, 0xaf, 0x29, 0xe7, 0x98, 0x41, 0x67, 0xf3, 0x04,
+	0xee, 0xea, 0xd5, 0x53, 0x18, 0x35, 0x83, 0x3e, 0x4e, 0xef, 0xa2, 0x79, 0xcd, 0xc2, 0x56, 0xdb,
+	0xf4, 0xff, 0x5b, 0x81, 0xed, 0x91, 0x4a, 0x84, 0xfe, 0xf4, 0x88, 0x0a, 0x8a, 0x9e, 0x43, 0x23,
+	0xfb, 0x6e, 0x42, 0x1d, 0x92, 0xfb, 0x4c, 0xec, 0x3e, 0x22, 0xf9, 0x0f, 0x2c, 0x5c, 0x40, 0x9f,
+	0x41, 0x2d, 0x79, 0x62, 0xa0, 0x16, 0xd9, 0x78, 0x39, 0x75, 0x77, 0xc9, 0xe6, 0xfb, 0x03, 0x17,
+	0xd0, 0x53, 0xa8, 0xa7, 0x3a, 0x63, 0xd4, 0x26, 0xb7, 0x1f, 0x1b, 0xdd, 0x3d, 0x92, 0xd3, 0x3c,
+	0xe3, 0x02, 0xfa, 0x02, 0x60, 0xdd, 0xac, 0x22, 0x44, 0x6e, 0x35, 0xbb, 0xdd, 0x36, 0xb9, 0xdd,
+	0xcd, 0xe2, 0x02, 0xfa, 0x35, 0x34, 0xb2, 0x2d, 0x2a, 0xea, 0x90, 0xdc, 0x9e, 0xb5, 0xdb, 0xdc,
+	0x68, 0x3a, 0x71, 0xe1, 0x53, 0x4b, 0xda, 0x9c, 0x2a, 0xa2, 0xa8, 0x4d, 0x6e, 0xf7, 0x49, 0xdd,
+	0x3d, 0x92, 0x53, 0x67, 0x71, 0x01, 0x7d, 0x05, 0x3b, 0x99, 0x82, 0x85, 0xf6, 0x49, 0x5e, 0x21,
+	0xec, 0x76, 0x48, 0x6e, 0x5d, 0xc3, 0x05, 0x79, 0x52, 0xd9, 0xd2, 0x83, 0x3a, 0x24, 0x2b, 0x58,
+	0x9f, 0xd4, 0x1d, 0x35, 0xaa, 0x80, 0xce, 0xf4, 0x63, 0x30, 0x9d, 0x92, 0x91, 0x43, 0xee, 0x28,
+	0x5c, 0xdd, 0x77, 0xc8, 0x5d, 0xf9, 0x5b, 0x9f, 0x60, 0x2a, 0x81, 0xa1, 0x36, 0xb9, 0x9d, 0xab,
+	0xbb, 0x7b, 0x24, 0x27, 0xc7, 0x69, 0x5f, 0xb2, 0xb7, 0x1d, 0x75, 0x48, 0x6e, 0x02, 0xea, 0x3e,
+	0x22, 0xf9, 0x61, 0x81, 0x0b, 0xde, 0x96, 0xca, 0x19, 0xbf, 0xfa, 0x6e, 0x00, 0x3a, 0xb5, 0x60,
+	0x18, 0x91, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TestGridDataClient is the client API for TestGridData service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TestGridDataClient interface {
+	// Lists the dashboards of the configuration.
+	ListDashboards(ctx context.Context, in *ListDashboardsRequest, opts ...grpc.CallOption) (*ListDashboardsResponse, error)
+	// Lists the tabs of a dashboard.
+	ListTabs(ctx context.Context, in *ListTabsRequest, opts ...grpc.CallOption) (*ListTabsResponse, error)
+	// Returns a page of the grid backing a dashboard tab.
+	GetTabState(ctx context.Context, in *GetTabStateRequest, opts ...grpc.CallOption) (*GetTabStateResponse, error)
+	// Returns the latest summary of a dashboard.
+	GetSummary(ctx context.Context, in *GetSummaryRequest, opts ...grpc.CallOption) (*GetSummaryResponse, error)
+	// Streams an event whenever the summary or grid of a tab of the dashboard
+	// changes, until the client cancels the stream.
+	WatchDashboard(ctx context.Context, in *WatchDashboardRequest, opts ...grpc.CallOption) (TestGridData_WatchDashboardClient, error)
+	// Lists the tests that newly fail, regressed or were fixed between two tabs
+	// or time ranges.
+	CompareTabs(ctx context.Context, in *CompareTabsRequest, opts ...grpc.CallOption) (*CompareTabsResponse, error)
+	// Asks the updater to update the test group of a tab before the rest of
+	// its cycle. Requires an authenticated user.
+	TriggerUpdate(ctx context.Context, in *TriggerUpdateRequest, opts ...grpc.CallOption) (*TriggerUpdateResponse, error)
+	// Asks the summarizer to summarize a dashboard before the rest of its
+	// cycle. Requires an authenticated user.
+	TriggerSummary(ctx context.Context, in *TriggerSummaryRequest, opts ...grpc.CallOption) (*TriggerSummaryResponse, error)
+	// Lists the tests failing in the latest summaries of every dashboard, along
+	// with the tabs in which they fail.
+	ListFailingTests(ctx context.Context, in *ListFailingTestsRequest, opts ...grpc.CallOption) (*ListFailingTestsResponse, error)
+	// Finds the tabs containing tests whose name matches a prefix or substring,
+	// from an index of every grid the server refreshes periodically.
+	SearchTests(ctx context.Context, in *SearchTestsRequest, opts ...grpc.CallOption) (*SearchTestsResponse, error)
+	// Returns the recent results of a test in every tab containing it, along
+	// with when it last passed anywhere, using the index of SearchTests to
+	// find the tabs.
+	GetTestHistory(ctx context.Context, in *GetTestHistoryRequest, opts ...grpc.CallOption) (*GetTestHistoryResponse, error)
+}
+
+type testGridDataClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTestGridDataClient(cc grpc.ClientConnInterface) TestGridDataClient {
+	return &testGridDataClient{cc}
+}
+
+func (c *testGridDataClient) ListDashboards(ctx context.Context, in *ListDashboardsRequest, opts ...grpc.CallOption) (*ListDashboardsResponse, error) {
+	out := new(ListDashboardsResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/ListDashboards", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) ListTabs(ctx context.Context, in *ListTabsRequest, opts ...grpc.CallOption) (*ListTabsResponse, error) {
+	out := new(ListTabsResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/ListTabs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) GetTabState(ctx context.Context, in *GetTabStateRequest, opts ...grpc.CallOption) (*GetTabStateResponse, error) {
+	out := new(GetTabStateResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/GetTabState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) GetSummary(ctx context.Context, in *GetSummaryRequest, opts ...grpc.CallOption) (*GetSummaryResponse, error) {
+	out := new(GetSummaryResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/GetSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) WatchDashboard(ctx context.Context, in *WatchDashboardRequest, opts ...grpc.CallOption) (TestGridData_WatchDashboardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TestGridData_serviceDesc.Streams[0], "/TestGridData/WatchDashboard", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testGridDataWatchDashboardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TestGridData_WatchDashboardClient interface {
+	Recv() (*DashboardEvent, error)
+	grpc.ClientStream
+}
+
+type testGridDataWatchDashboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *testGridDataWatchDashboardClient) Recv() (*DashboardEvent, error) {
+	m := new(DashboardEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *testGridDataClient) CompareTabs(ctx context.Context, in *CompareTabsRequest, opts ...grpc.CallOption) (*CompareTabsResponse, error) {
+	out := new(CompareTabsResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/CompareTabs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) TriggerUpdate(ctx context.Context, in *TriggerUpdateRequest, opts ...grpc.CallOption) (*TriggerUpdateResponse, error) {
+	out := new(TriggerUpdateResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/TriggerUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) TriggerSummary(ctx context.Context, in *TriggerSummaryRequest, opts ...grpc.CallOption) (*TriggerSummaryResponse, error) {
+	out := new(TriggerSummaryResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/TriggerSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) ListFailingTests(ctx context.Context, in *ListFailingTestsRequest, opts ...grpc.CallOption) (*ListFailingTestsResponse, error) {
+	out := new(ListFailingTestsResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/ListFailingTests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) SearchTests(ctx context.Context, in *SearchTestsRequest, opts ...grpc.CallOption) (*SearchTestsResponse, error) {
+	out := new(SearchTestsResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/SearchTests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testGridDataClient) GetTestHistory(ctx context.Context, in *GetTestHistoryRequest, opts ...grpc.CallOption) (*GetTestHistoryResponse, error) {
+	out := new(GetTestHistoryResponse)
+	err := c.cc.Invoke(ctx, "/TestGridData/GetTestHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestGridDataServer is the server API for TestGridData service.
+type TestGridDataServer interface {
+	// Lists the dashboards of the configuration.
+	ListDashboards(context.Context, *ListDashboardsRequest) (*ListDashboardsResponse, error)
+	// Lists the tabs of a dashboard.
+	ListTabs(context.Context, *ListTabsRequest) (*ListTabsResponse, error)
+	// Returns a page of the grid backing a dashboard tab.
+	GetTabState(context.Context, *GetTabStateRequest) (*GetTabStateResponse, error)
+	// Returns the latest summary of a dashboard.
+	GetSummary(context.Context, *GetSummaryRequest) (*GetSummaryResponse, error)
+	// Streams an event whenever the summary or grid of a tab of the dashboard
+	// changes, until the client cancels the stream.
+	WatchDashboard(*WatchDashboardRequest, TestGridData_WatchDashboardServer) error
+	// Lists the tests that newly fail, regressed or were fixed between two tabs
+	// or time ranges.
+	CompareTabs(context.Context, *CompareTabsRequest) (*CompareTabsResponse, error)
+	// Asks the updater to update the test group of a tab before the rest of
+	// its cycle. Requires an authenticated user.
+	TriggerUpdate(context.Context, *TriggerUpdateRequest) (*TriggerUpdateResponse, error)
+	// Asks the summarizer to summarize a dashboard before the rest of its
+	// cycle. Requires an authenticated user.
+	TriggerSummary(context.Context, *TriggerSummaryRequest) (*TriggerSummaryResponse, error)
+	// Lists the tests failing in the latest summaries of every dashboard, along
+	// with the tabs in which they fail.
+	ListFailingTests(context.Context, *ListFailingTestsRequest) (*ListFailingTestsResponse, error)
+	// Finds the tabs containing tests whose name matches a prefix or substring,
+	// from an index of every grid the server refreshes periodically.
+	SearchTests(context.Context, *SearchTestsRequest) (*SearchTestsResponse, error)
+	// Returns the recent results of a test in every tab containing it, along
+	// with when it last passed anywhere, using the index of SearchTests to
+	// find the tabs.
+	GetTestHistory(context.Context, *GetTestHistoryRequest) (*GetTestHistoryResponse, error)
+}
+
+// UnimplementedTestGridDataServer can be embedded to have forward compatible implementations.
+type UnimplementedTestGridDataServer struct {
+}
+
+func (*UnimplementedTestGridDataServer) ListDashboards(ctx context.Context, req *ListDashboardsRequest) (*ListDashboardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDashboards not implemented")
+}
+func (*UnimplementedTestGridDataServer) ListTabs(ctx context.Context, req *ListTabsRequest) (*ListTabsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTabs not implemented")
+}
+func (*UnimplementedTestGridDataServer) GetTabState(ctx context.Context, req *GetTabStateRequest) (*GetTabStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTabState not implemented")
+}
+func (*UnimplementedTestGridDataServer) GetSummary(ctx context.Context, req *GetSummaryRequest) (*GetSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSummary not implemented")
+}
+func (*UnimplementedTestGridDataServer) WatchDashboard(req *WatchDashboardRequest, srv TestGridData_WatchDashboardServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDashboard not implemented")
+}
+func (*UnimplementedTestGridDataServer) CompareTabs(ctx context.Context, req *CompareTabsRequest) (*CompareTabsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareTabs not implemented")
+}
+func (*UnimplementedTestGridDataServer) TriggerUpdate(ctx context.Context, req *TriggerUpdateRequest) (*TriggerUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerUpdate not implemented")
+}
+func (*UnimplementedTestGridDataServer) TriggerSummary(ctx context.Context, req *TriggerSummaryRequest) (*TriggerSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerSummary not implemented")
+}
+func (*UnimplementedTestGridDataServer) ListFailingTests(ctx context.Context, req *ListFailingTestsRequest) (*ListFailingTestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFailingTests not implemented")
+}
+func (*UnimplementedTestGridDataServer) SearchTests(ctx context.Context, req *SearchTestsRequest) (*SearchTestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTests not implemented")
+}
+func (*UnimplementedTestGridDataServer) GetTestHistory(ctx context.Context, req *GetTestHistoryRequest) (*GetTestHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTestHistory not implemented")
+}
+
+func RegisterTestGridDataServer(s *grpc.Server, srv TestGridDataServer) {
+	s.RegisterService(&_TestGridData_serviceDesc, srv)
+}
+
+func _TestGridData_ListDashboards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDashboardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).ListDashboards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/ListDashboards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).ListDashboards(ctx, req.(*ListDashboardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_ListTabs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTabsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).ListTabs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/ListTabs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).ListTabs(ctx, req.(*ListTabsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_GetTabState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTabStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).GetTabState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/GetTabState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).GetTabState(ctx, req.(*GetTabStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_GetSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).GetSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/GetSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).GetSummary(ctx, req.(*GetSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_WatchDashboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDashboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TestGridDataServer).WatchDashboard(m, &testGridDataWatchDashboardServer{stream})
+}
+
+type TestGridData_WatchDashboardServer interface {
+	Send(*DashboardEvent) error
+	grpc.ServerStream
+}
+
+type testGridDataWatchDashboardServer struct {
+	grpc.ServerStream
+}
+
+func (x *testGridDataWatchDashboardServer) Send(m *DashboardEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TestGridData_CompareTabs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareTabsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).CompareTabs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/CompareTabs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).CompareTabs(ctx, req.(*CompareTabsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_TriggerUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).TriggerUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/TriggerUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).TriggerUpdate(ctx, req.(*TriggerUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_TriggerSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).TriggerSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/TriggerSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).TriggerSummary(ctx, req.(*TriggerSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_ListFailingTests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFailingTestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).ListFailingTests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/ListFailingTests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).ListFailingTests(ctx, req.(*ListFailingTestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_SearchTests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).SearchTests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/SearchTests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).SearchTests(ctx, req.(*SearchTestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestGridData_GetTestHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTestHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestGridDataServer).GetTestHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TestGridData/GetTestHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestGridDataServer).GetTestHistory(ctx, req.(*GetTestHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TestGridData_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "TestGridData",
+	HandlerType: (*TestGridDataServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDashboards",
+			Handler:    _TestGridData_ListDashboards_Handler,
+		},
+		{
+			MethodName: "ListTabs",
+			Handler:    _TestGridData_ListTabs_Handler,
+		},
+		{
+			MethodName: "GetTabState",
+			Handler:    _TestGridData_GetTabState_Handler,
+		},
+		{
+			MethodName: "GetSummary",
+			Handler:    _TestGridData_GetSummary_Handler,
+		},
+		{
+			MethodName: "CompareTabs",
+			Handler:    _TestGridData_CompareTabs_Handler,
+		},
+		{
+			MethodName: "TriggerUpdate",
+			Handler:    _TestGridData_TriggerUpdate_Handler,
+		},
+		{
+			MethodName: "TriggerSummary",
+			Handler:    _TestGridData_TriggerSummary_Handler,
+		},
+		{
+			MethodName: "ListFailingTests",
+			Handler:    _TestGridData_ListFailingTests_Handler,
+		},
+		{
+			MethodName: "SearchTests",
+			Handler:    _TestGridData_SearchTests_Handler,
+		},
+		{
+			MethodName: "GetTestHistory",
+			Handler:    _TestGridData_GetTestHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDashboard",
+			Handler:       _TestGridData_WatchDashboard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
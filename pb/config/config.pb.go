@@ -182,6 +182,69 @@ func (TestGroup_Environment) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4}
 }
 
+// How to show builds that share a build ID, such as retries that upload
+// to another prefix.
+type TestGroup_DuplicateBuilds int32
+
+const (
+	// Show each build as its own column.
+	TestGroup_DUPLICATE_BUILDS_SEPARATE TestGroup_DuplicateBuilds = 0
+	// Show only the build that started last.
+	TestGroup_DUPLICATE_BUILDS_LATEST TestGroup_DuplicateBuilds = 1
+	// Combine the results of every build into one column, preferring
+	// the results of builds that started later.
+	TestGroup_DUPLICATE_BUILDS_UNION TestGroup_DuplicateBuilds = 2
+)
+
+var TestGroup_DuplicateBuilds_name = map[int32]string{
+	0: "DUPLICATE_BUILDS_SEPARATE",
+	1: "DUPLICATE_BUILDS_LATEST",
+	2: "DUPLICATE_BUILDS_UNION",
+}
+
+var TestGroup_DuplicateBuilds_value = map[string]int32{
+	"DUPLICATE_BUILDS_SEPARATE": 0,
+	"DUPLICATE_BUILDS_LATEST":   1,
+	"DUPLICATE_BUILDS_UNION":    2,
+}
+
+func (x TestGroup_DuplicateBuilds) String() string {
+	return proto.EnumName(TestGroup_DuplicateBuilds_name, int32(x))
+}
+
+func (TestGroup_DuplicateBuilds) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 5}
+}
+
+// Which columns older than a week to keep within days_of_results.
+type TestGroup_ColumnRetention int32
+
+const (
+	// Keep every column.
+	TestGroup_COLUMN_RETENTION_ALL TestGroup_ColumnRetention = 0
+	// Keep every column of the last week, the latest column of each day
+	// for the last 90 days, and the latest column of each week before that.
+	TestGroup_COLUMN_RETENTION_EXPONENTIAL TestGroup_ColumnRetention = 1
+)
+
+var TestGroup_ColumnRetention_name = map[int32]string{
+	0: "COLUMN_RETENTION_ALL",
+	1: "COLUMN_RETENTION_EXPONENTIAL",
+}
+
+var TestGroup_ColumnRetention_value = map[string]int32{
+	"COLUMN_RETENTION_ALL":         0,
+	"COLUMN_RETENTION_EXPONENTIAL": 1,
+}
+
+func (x TestGroup_ColumnRetention) String() string {
+	return proto.EnumName(TestGroup_ColumnRetention_name, int32(x))
+}
+
+func (TestGroup_ColumnRetention) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 6}
+}
+
 // Scale of issue priority, used to indicate importance of issue.
 type AutoBugOptions_Priority int32
 
@@ -219,7 +282,7 @@ func (x AutoBugOptions_Priority) String() string {
 }
 
 func (AutoBugOptions_Priority) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{5, 0}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{10, 0}
 }
 
 // Specifies the test name, and its source
@@ -495,6 +558,8 @@ type TestGroup struct {
 	PrimaryGrouping TestGroup_PrimaryGrouping `protobuf:"varint,29,opt,name=primary_grouping,json=primaryGrouping,proto3,enum=TestGroup_PrimaryGrouping" json:"primary_grouping,omitempty"`
 	// Whether to collect pass-fail data for test methods. Additional test cases
 	// will be added for each test method in a target.
+	// The updater reads methods from <testcase/> elements nested in a test case,
+	// naming each row as the test case followed by a dot and the method.
 	EnableTestMethods bool `protobuf:"varint,30,opt,name=enable_test_methods,json=enableTestMethods,proto3" json:"enable_test_methods,omitempty"`
 	// Test annotations to look for. Adds custom short text overlays to results.
 	TestAnnotations []*TestGroup_TestAnnotation `protobuf:"bytes,31,rep,name=test_annotations,json=testAnnotations,proto3" json:"test_annotations,omitempty"`
@@ -573,6 +638,8 @@ type TestGroup struct {
 	// for a test with the same name are encountered.
 	IgnoreOldResults bool `protobuf:"varint,53,opt,name=ignore_old_results,json=ignoreOldResults,proto3" json:"ignore_old_results,omitempty"`
 	// If True, ignore the 'pass with skips' status (show as a blank cell).
+	// The updater omits rows with only skipped results from the grid, counting
+	// their cells in the ignored_skips of each column.
 	IgnoreSkip bool `protobuf:"varint,54,opt,name=ignore_skip,json=ignoreSkip,proto3" json:"ignore_skip,omitempty"`
 	// A string containing go/strftime formatting specifiers that overrides the
 	// commit with the date formatted according to this string. This is useful
@@ -580,7 +647,38 @@ type TestGroup struct {
 	CommitOverrideStrftime string `protobuf:"bytes,55,opt,name=commit_override_strftime,json=commitOverrideStrftime,proto3" json:"commit_override_strftime,omitempty"`
 	// Specify a property that will be read into state in the user_property field.
 	// These can be substituted into LinkTemplates.
-	UserProperty         string   `protobuf:"bytes,56,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	UserProperty string `protobuf:"bytes,56,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// Additional paths to test results, merged with gcs_prefix into one grid.
+	// Columns from every path interleave by start time, such as for a job
+	// that moved to another bucket partway through its history.
+	AdditionalGcsPrefixes []string `protobuf:"bytes,57,rep,name=additional_gcs_prefixes,json=additionalGcsPrefixes,proto3" json:"additional_gcs_prefixes,omitempty"`
+	// Links added to the properties of failing results,
+	// such as the log written beside their junit artifacts.
+	ArtifactLinks []*TestGroup_ArtifactLink `protobuf:"bytes,58,rep,name=artifact_links,json=artifactLinks,proto3" json:"artifact_links,omitempty"`
+	// If true, omit rows with only passing results from the grid, counting their
+	// cells in the ignored_passes of each column. Always keeps the Overall row.
+	IgnorePass      bool                      `protobuf:"varint,59,opt,name=ignore_pass,json=ignorePass,proto3" json:"ignore_pass,omitempty"`
+	DuplicateBuilds TestGroup_DuplicateBuilds `protobuf:"varint,60,opt,name=duplicate_builds,json=duplicateBuilds,proto3,enum=TestGroup_DuplicateBuilds" json:"duplicate_builds,omitempty"`
+	ColumnRetention TestGroup_ColumnRetention `protobuf:"varint,61,opt,name=column_retention,json=columnRetention,proto3,enum=TestGroup_ColumnRetention" json:"column_retention,omitempty"`
+	// Most bytes of cell messages to keep in the grid, 64 MiB by default.
+	// Replaces the messages of older cells beyond this with a marker.
+	MaxMessageBytes int64 `protobuf:"varint,62,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty"`
+	// Most distinct metric names to keep in the grid, 1000 by default.
+	// Drops the values of other metrics, preferring metrics of newer columns.
+	MaxMetricNames int32 `protobuf:"varint,63,opt,name=max_metric_names,json=maxMetricNames,proto3" json:"max_metric_names,omitempty"`
+	// If true, keep testcases marked <skipped/> without a reason as
+	// PASS_WITH_SKIPS cells, which are otherwise dropped.
+	// Skip reasons are always kept, as the message of the cell.
+	KeepSkipped bool `protobuf:"varint,64,opt,name=keep_skipped,json=keepSkipped,proto3" json:"keep_skipped,omitempty"`
+	// If positive, reread builds missing from the numbering of the columns
+	// started within this many hours, such as builds listed late because of
+	// delayed uploads. Otherwise builds older than the newest column are never
+	// read once it is listed.
+	ListingGapHours int32 `protobuf:"varint,65,opt,name=listing_gap_hours,json=listingGapHours,proto3" json:"listing_gap_hours,omitempty"`
+	// If positive, the minutes to spend on each update of this group, instead
+	// of the default of the updater. Keeps the columns read in time and reads
+	// the rest in later updates.
+	UpdateTimeoutMinutes int32    `protobuf:"varint,66,opt,name=update_timeout_minutes,json=updateTimeoutMinutes,proto3" json:"update_timeout_minutes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1013,6 +1111,76 @@ func (m *TestGroup) GetUserProperty() string {
 	return ""
 }
 
+func (m *TestGroup) GetAdditionalGcsPrefixes() []string {
+	if m != nil {
+		return m.AdditionalGcsPrefixes
+	}
+	return nil
+}
+
+func (m *TestGroup) GetArtifactLinks() []*TestGroup_ArtifactLink {
+	if m != nil {
+		return m.ArtifactLinks
+	}
+	return nil
+}
+
+func (m *TestGroup) GetIgnorePass() bool {
+	if m != nil {
+		return m.IgnorePass
+	}
+	return false
+}
+
+func (m *TestGroup) GetDuplicateBuilds() TestGroup_DuplicateBuilds {
+	if m != nil {
+		return m.DuplicateBuilds
+	}
+	return TestGroup_DUPLICATE_BUILDS_SEPARATE
+}
+
+func (m *TestGroup) GetColumnRetention() TestGroup_ColumnRetention {
+	if m != nil {
+		return m.ColumnRetention
+	}
+	return TestGroup_COLUMN_RETENTION_ALL
+}
+
+func (m *TestGroup) GetMaxMessageBytes() int64 {
+	if m != nil {
+		return m.MaxMessageBytes
+	}
+	return 0
+}
+
+func (m *TestGroup) GetMaxMetricNames() int32 {
+	if m != nil {
+		return m.MaxMetricNames
+	}
+	return 0
+}
+
+func (m *TestGroup) GetKeepSkipped() bool {
+	if m != nil {
+		return m.KeepSkipped
+	}
+	return false
+}
+
+func (m *TestGroup) GetListingGapHours() int32 {
+	if m != nil {
+		return m.ListingGapHours
+	}
+	return 0
+}
+
+func (m *TestGroup) GetUpdateTimeoutMinutes() int32 {
+	if m != nil {
+		return m.UpdateTimeoutMinutes
+	}
+	return 0
+}
+
 // Custom column headers for defining extra column-heading rows from values in
 // the test result.
 type TestGroup_ColumnHeader struct {
@@ -1196,10 +1364,16 @@ func (m *TestGroup_KeyValue) GetValue() string {
 type TestGroup_ResultSource struct {
 	// Types that are valid to be assigned to ResultSourceConfig:
 	//	*TestGroup_ResultSource_JunitConfig
-	ResultSourceConfig   isTestGroup_ResultSource_ResultSourceConfig `protobuf_oneof:"result_source_config"`
-	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
-	XXX_unrecognized     []byte                                      `json:"-"`
-	XXX_sizecache        int32                                       `json:"-"`
+	//	*TestGroup_ResultSource_BigqueryConfig
+	//	*TestGroup_ResultSource_ResultstoreConfig
+	//	*TestGroup_ResultSource_CloudBuildConfig
+	//	*TestGroup_ResultSource_AzureDevopsConfig
+	ResultSourceConfig isTestGroup_ResultSource_ResultSourceConfig `protobuf_oneof:"result_source_config"`
+	// Notifications of new results, for updating the group as they arrive.
+	PubsubConfig         *PubSubConfig `protobuf:"bytes,4,opt,name=pubsub_config,json=pubsubConfig,proto3" json:"pubsub_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *TestGroup_ResultSource) Reset()         { *m = TestGroup_ResultSource{} }
@@ -1235,8 +1409,32 @@ type TestGroup_ResultSource_JunitConfig struct {
 	JunitConfig *JUnitConfig `protobuf:"bytes,2,opt,name=junit_config,json=junitConfig,proto3,oneof"`
 }
 
+type TestGroup_ResultSource_BigqueryConfig struct {
+	BigqueryConfig *BigQueryConfig `protobuf:"bytes,5,opt,name=bigquery_config,json=bigqueryConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_ResultstoreConfig struct {
+	ResultstoreConfig *ResultStoreConfig `protobuf:"bytes,6,opt,name=resultstore_config,json=resultstoreConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_CloudBuildConfig struct {
+	CloudBuildConfig *CloudBuildConfig `protobuf:"bytes,7,opt,name=cloud_build_config,json=cloudBuildConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_AzureDevopsConfig struct {
+	AzureDevopsConfig *AzureDevOpsConfig `protobuf:"bytes,8,opt,name=azure_devops_config,json=azureDevopsConfig,proto3,oneof"`
+}
+
 func (*TestGroup_ResultSource_JunitConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
 
+func (*TestGroup_ResultSource_BigqueryConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_ResultstoreConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_CloudBuildConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_AzureDevopsConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
 func (m *TestGroup_ResultSource) GetResultSourceConfig() isTestGroup_ResultSource_ResultSourceConfig {
 	if m != nil {
 		return m.ResultSourceConfig
@@ -1251,11 +1449,120 @@ func (m *TestGroup_ResultSource) GetJunitConfig() *JUnitConfig {
 	return nil
 }
 
+func (m *TestGroup_ResultSource) GetBigqueryConfig() *BigQueryConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_BigqueryConfig); ok {
+		return x.BigqueryConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetResultstoreConfig() *ResultStoreConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_ResultstoreConfig); ok {
+		return x.ResultstoreConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetCloudBuildConfig() *CloudBuildConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_CloudBuildConfig); ok {
+		return x.CloudBuildConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetAzureDevopsConfig() *AzureDevOpsConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_AzureDevopsConfig); ok {
+		return x.AzureDevopsConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetPubsubConfig() *PubSubConfig {
+	if m != nil {
+		return m.PubsubConfig
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*TestGroup_ResultSource) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*TestGroup_ResultSource_JunitConfig)(nil),
+		(*TestGroup_ResultSource_BigqueryConfig)(nil),
+		(*TestGroup_ResultSource_ResultstoreConfig)(nil),
+		(*TestGroup_ResultSource_CloudBuildConfig)(nil),
+		(*TestGroup_ResultSource_AzureDevopsConfig)(nil),
+	}
+}
+
+// Links each result to a file next to the artifact that reported it.
+type TestGroup_ArtifactLink struct {
+	// Glob matching artifacts relative to the build, such as artifacts/junit*.xml
+	Artifact string `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	// Path of the linked file relative to the artifact's directory,
+	// such as build-log.txt or ../build-log.txt
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Result property holding the link, defaults to log.
+	Property string `protobuf:"bytes,3,opt,name=property,proto3" json:"property,omitempty"`
+	// Prepended to the bucket and object of the file to form the link,
+	// defaults to https://storage.cloud.google.com/
+	UrlPrefix            string   `protobuf:"bytes,4,opt,name=url_prefix,json=urlPrefix,proto3" json:"url_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_ArtifactLink) Reset()         { *m = TestGroup_ArtifactLink{} }
+func (m *TestGroup_ArtifactLink) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ArtifactLink) ProtoMessage()    {}
+func (*TestGroup_ArtifactLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4}
+}
+
+func (m *TestGroup_ArtifactLink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ArtifactLink.Unmarshal(m, b)
+}
+func (m *TestGroup_ArtifactLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ArtifactLink.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ArtifactLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ArtifactLink.Merge(m, src)
+}
+func (m *TestGroup_ArtifactLink) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ArtifactLink.Size(m)
+}
+func (m *TestGroup_ArtifactLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ArtifactLink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ArtifactLink proto.InternalMessageInfo
+
+func (m *TestGroup_ArtifactLink) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
+	}
+	return ""
+}
+
+func (m *TestGroup_ArtifactLink) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *TestGroup_ArtifactLink) GetProperty() string {
+	if m != nil {
+		return m.Property
+	}
+	return ""
+}
+
+func (m *TestGroup_ArtifactLink) GetUrlPrefix() string {
+	if m != nil {
+		return m.UrlPrefix
 	}
+	return ""
 }
 
 type JUnitConfig struct {
@@ -1289,150 +1596,435 @@ func (m *JUnitConfig) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_JUnitConfig proto.InternalMessageInfo
 
-// Default metadata to apply when opening bugs.
-type TestMetadataOptions struct {
-	// Apply the following metadata if this regex matches a test's name.
-	TestNameRegex string `protobuf:"bytes,1,opt,name=test_name_regex,json=testNameRegex,proto3" json:"test_name_regex,omitempty"`
-	// Default bug component to open a bug in.
-	BugComponent int32 `protobuf:"varint,2,opt,name=bug_component,json=bugComponent,proto3" json:"bug_component,omitempty"`
-	// Default owner to assign a bug to.
-	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
-	// List of default users to CC a bug to.
-	Cc []string `protobuf:"bytes,4,rep,name=cc,proto3" json:"cc,omitempty"`
-	// Apply following metadata if this regex matches a test’s failure message.
-	MessageRegex         string   `protobuf:"bytes,5,opt,name=message_regex,json=messageRegex,proto3" json:"message_regex,omitempty"`
+// A BigQuery query returning a row for each result of a test in a build.
+type BigQueryConfig struct {
+	// Project to run the query in, such as my-project.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// Standard SQL query returning build (STRING), started (TIMESTAMP),
+	// test_name (STRING) and status (STRING) columns, along with optional
+	// duration (FLOAT64 seconds) and message (STRING) columns.
+	//
+	// Status is the name of a TestStatus or one of PASSED, FAILED, SKIPPED,
+	// ERROR or TIMEOUT, ignoring case. May use the @since (TIMESTAMP) parameter
+	// to read only builds started since then and the @group (STRING) parameter
+	// to read the results of this group.
+	Query                string   `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestMetadataOptions) Reset()         { *m = TestMetadataOptions{} }
-func (m *TestMetadataOptions) String() string { return proto.CompactTextString(m) }
-func (*TestMetadataOptions) ProtoMessage()    {}
-func (*TestMetadataOptions) Descriptor() ([]byte, []int) {
+func (m *BigQueryConfig) Reset()         { *m = BigQueryConfig{} }
+func (m *BigQueryConfig) String() string { return proto.CompactTextString(m) }
+func (*BigQueryConfig) ProtoMessage()    {}
+func (*BigQueryConfig) Descriptor() ([]byte, []int) {
 	return fileDescriptor_3eaf2c85e69e9ea4, []int{4}
 }
 
-func (m *TestMetadataOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestMetadataOptions.Unmarshal(m, b)
+func (m *BigQueryConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigQueryConfig.Unmarshal(m, b)
 }
-func (m *TestMetadataOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestMetadataOptions.Marshal(b, m, deterministic)
+func (m *BigQueryConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigQueryConfig.Marshal(b, m, deterministic)
 }
-func (m *TestMetadataOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestMetadataOptions.Merge(m, src)
+func (m *BigQueryConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigQueryConfig.Merge(m, src)
 }
-func (m *TestMetadataOptions) XXX_Size() int {
-	return xxx_messageInfo_TestMetadataOptions.Size(m)
+func (m *BigQueryConfig) XXX_Size() int {
+	return xxx_messageInfo_BigQueryConfig.Size(m)
 }
-func (m *TestMetadataOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestMetadataOptions.DiscardUnknown(m)
+func (m *BigQueryConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigQueryConfig.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TestMetadataOptions proto.InternalMessageInfo
+var xxx_messageInfo_BigQueryConfig proto.InternalMessageInfo
 
-func (m *TestMetadataOptions) GetTestNameRegex() string {
+func (m *BigQueryConfig) GetProject() string {
 	if m != nil {
-		return m.TestNameRegex
+		return m.Project
 	}
 	return ""
 }
 
-func (m *TestMetadataOptions) GetBugComponent() int32 {
+func (m *BigQueryConfig) GetQuery() string {
 	if m != nil {
-		return m.BugComponent
+		return m.Query
 	}
-	return 0
+	return ""
 }
 
-func (m *TestMetadataOptions) GetOwner() string {
-	if m != nil {
-		return m.Owner
-	}
-	return ""
+// A Cloud Build trigger whose builds are the columns of the group.
+//
+// Reads junit results from the artifacts each build uploads. Column headers
+// may use the substitutions of the build, such as COMMIT_SHA, along with tags
+// for its comma-separated tags.
+type CloudBuildConfig struct {
+	// Project owning the trigger, such as my-project.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// ID of the trigger.
+	TriggerId            string   `protobuf:"bytes,2,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestMetadataOptions) GetCc() []string {
+func (m *CloudBuildConfig) Reset()         { *m = CloudBuildConfig{} }
+func (m *CloudBuildConfig) String() string { return proto.CompactTextString(m) }
+func (*CloudBuildConfig) ProtoMessage()    {}
+func (*CloudBuildConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{5}
+}
+
+func (m *CloudBuildConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudBuildConfig.Unmarshal(m, b)
+}
+func (m *CloudBuildConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudBuildConfig.Marshal(b, m, deterministic)
+}
+func (m *CloudBuildConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudBuildConfig.Merge(m, src)
+}
+func (m *CloudBuildConfig) XXX_Size() int {
+	return xxx_messageInfo_CloudBuildConfig.Size(m)
+}
+func (m *CloudBuildConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudBuildConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudBuildConfig proto.InternalMessageInfo
+
+func (m *CloudBuildConfig) GetProject() string {
 	if m != nil {
-		return m.Cc
+		return m.Project
 	}
-	return nil
+	return ""
 }
 
-func (m *TestMetadataOptions) GetMessageRegex() string {
+func (m *CloudBuildConfig) GetTriggerId() string {
 	if m != nil {
-		return m.MessageRegex
+		return m.TriggerId
 	}
 	return ""
 }
 
-type AutoBugOptions struct {
-	// [BETA] When specified, file bugs to this component, using the beta AutoBug.
-	// If you do not want to opt into the beta, specify `bug_component` in your
-	// TestGroup instead.
-	// TODO(b/154866134): Rename to autobug_component once we've migrated.
-	BetaAutobugComponent int32 `protobuf:"varint,10,opt,name=beta_autobug_component,json=betaAutobugComponent,proto3" json:"beta_autobug_component,omitempty"`
-	// Whether to auto-close auto-filed bugs.
-	AutoClose bool `protobuf:"varint,1,opt,name=auto_close,json=autoClose,proto3" json:"auto_close,omitempty"`
-	// A list of hotlist ids attached to auto-filed bugs.
-	HotlistIds []int64 `protobuf:"varint,2,rep,packed,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
-	// The priority of the auto-filed bug. If provided, this will overwrite the
-	// priority in the component default template
-	Priority AutoBugOptions_Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=AutoBugOptions_Priority" json:"priority,omitempty"`
-	// A list of hotlist id sources
-	// Corresponds with the list hotlist_ids (#2)
-	HotlistIdsFromSource []*HotlistIdFromSource `protobuf:"bytes,4,rep,name=hotlist_ids_from_source,json=hotlistIdsFromSource,proto3" json:"hotlist_ids_from_source,omitempty"`
-	// If True, files separate bugs for each failing target, instead of one bug
-	// for each set of targets failing at the same run.
-	FileIndividual bool `protobuf:"varint,5,opt,name=file_individual,json=fileIndividual,proto3" json:"file_individual,omitempty"`
-	// If True; keep only one automantic bug per target, regardless of the number of
-	// separate failures a target gets. This also requires `auto_close` and
-	// `file_individual` to be True.
-	// Consider setting `num_passes_to_disable_alert` instead if you're tracking
-	// flaky tests.
-	SingletonAutobug bool `protobuf:"varint,6,opt,name=singleton_autobug,json=singletonAutobug,proto3" json:"singleton_autobug,omitempty"`
-	// If provided: only raise one bug if the number of failures for a single
-	// query by testgrid for a single failure group exceeds this value. Requires
-	// 'file_individual' to be True.
-	MaxAllowedIndividualBugs int32 `protobuf:"varint,7,opt,name=max_allowed_individual_bugs,json=maxAllowedIndividualBugs,proto3" json:"max_allowed_individual_bugs,omitempty"`
-	// If True; file issues for the 'Overall' target, even if otherwise invalid.
-	FileOverall bool `protobuf:"varint,8,opt,name=file_overall,json=fileOverall,proto3" json:"file_overall,omitempty"`
-	// If provided: supplements `max_allowed_individual_bugs` field to raise a
-	// single bug if the number of failures for a single query by testgrid exceeds
-	// the max_allowed_individual_bugs` value, regardless of `TEST_METADATA`
-	// configurations. This is useful for filing fewer suspected environmental
-	// failure bugs and routing them to a specific location (i.e. an oncall).
-	// Requires 'file_individual' to be true and `max_allowed_individual_bugs` to
-	// not be empty.
-	DefaultTestMetadata *AutoBugOptions_DefaultTestMetadata `protobuf:"bytes,9,opt,name=default_test_metadata,json=defaultTestMetadata,proto3" json:"default_test_metadata,omitempty"`
-	// [BETA] If True, query the test metadata API to get issue-routing metadata.
-	// Enables routing issues using structured test failures.
-	AdvancedTestMetadata bool     `protobuf:"varint,11,opt,name=advanced_test_metadata,json=advancedTestMetadata,proto3" json:"advanced_test_metadata,omitempty"`
+// An Azure DevOps project whose test runs are the results of the group.
+//
+// Each build is a column, with a row for each result of its test runs.
+type AzureDevOpsConfig struct {
+	// Organization owning the project, as in dev.azure.com/my-org.
+	Organization string `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	// Project of the pipelines.
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	// Read the runs of these pipeline definitions, or of every pipeline if empty.
+	BuildDefinitionIds   []int32  `protobuf:"varint,3,rep,packed,name=build_definition_ids,json=buildDefinitionIds,proto3" json:"build_definition_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AutoBugOptions) Reset()         { *m = AutoBugOptions{} }
-func (m *AutoBugOptions) String() string { return proto.CompactTextString(m) }
-func (*AutoBugOptions) ProtoMessage()    {}
-func (*AutoBugOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{5}
+func (m *AzureDevOpsConfig) Reset()         { *m = AzureDevOpsConfig{} }
+func (m *AzureDevOpsConfig) String() string { return proto.CompactTextString(m) }
+func (*AzureDevOpsConfig) ProtoMessage()    {}
+func (*AzureDevOpsConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{6}
 }
 
-func (m *AutoBugOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AutoBugOptions.Unmarshal(m, b)
+func (m *AzureDevOpsConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AzureDevOpsConfig.Unmarshal(m, b)
 }
-func (m *AutoBugOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AutoBugOptions.Marshal(b, m, deterministic)
+func (m *AzureDevOpsConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AzureDevOpsConfig.Marshal(b, m, deterministic)
 }
-func (m *AutoBugOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AutoBugOptions.Merge(m, src)
+func (m *AzureDevOpsConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AzureDevOpsConfig.Merge(m, src)
 }
-func (m *AutoBugOptions) XXX_Size() int {
-	return xxx_messageInfo_AutoBugOptions.Size(m)
+func (m *AzureDevOpsConfig) XXX_Size() int {
+	return xxx_messageInfo_AzureDevOpsConfig.Size(m)
 }
-func (m *AutoBugOptions) XXX_DiscardUnknown() {
+func (m *AzureDevOpsConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_AzureDevOpsConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AzureDevOpsConfig proto.InternalMessageInfo
+
+func (m *AzureDevOpsConfig) GetOrganization() string {
+	if m != nil {
+		return m.Organization
+	}
+	return ""
+}
+
+func (m *AzureDevOpsConfig) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *AzureDevOpsConfig) GetBuildDefinitionIds() []int32 {
+	if m != nil {
+		return m.BuildDefinitionIds
+	}
+	return nil
+}
+
+// A ResultStore search returning an invocation for each column of the group.
+//
+// Each target of an invocation is a row of its column.
+type ResultStoreConfig struct {
+	// Project owning the invocations, such as my-project.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// SearchInvocations query selecting the invocations of the group, such as
+	// invocation_attributes.labels:"my-job".
+	Query                string   `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResultStoreConfig) Reset()         { *m = ResultStoreConfig{} }
+func (m *ResultStoreConfig) String() string { return proto.CompactTextString(m) }
+func (*ResultStoreConfig) ProtoMessage()    {}
+func (*ResultStoreConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{7}
+}
+
+func (m *ResultStoreConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResultStoreConfig.Unmarshal(m, b)
+}
+func (m *ResultStoreConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResultStoreConfig.Marshal(b, m, deterministic)
+}
+func (m *ResultStoreConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResultStoreConfig.Merge(m, src)
+}
+func (m *ResultStoreConfig) XXX_Size() int {
+	return xxx_messageInfo_ResultStoreConfig.Size(m)
+}
+func (m *ResultStoreConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResultStoreConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResultStoreConfig proto.InternalMessageInfo
+
+func (m *ResultStoreConfig) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ResultStoreConfig) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+// A Pub/Sub subscription receiving notifications of new results.
+type PubSubConfig struct {
+	// Project owning the subscription, such as my-project.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// Name of the subscription within the project, such as testgrid-results.
+	Subscription string `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	// Only handle messages matching this filter, using the syntax of
+	// subscription filters, such as attributes.eventType = "OBJECT_FINALIZE".
+	Filter               string   `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PubSubConfig) Reset()         { *m = PubSubConfig{} }
+func (m *PubSubConfig) String() string { return proto.CompactTextString(m) }
+func (*PubSubConfig) ProtoMessage()    {}
+func (*PubSubConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{8}
+}
+
+func (m *PubSubConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PubSubConfig.Unmarshal(m, b)
+}
+func (m *PubSubConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PubSubConfig.Marshal(b, m, deterministic)
+}
+func (m *PubSubConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubSubConfig.Merge(m, src)
+}
+func (m *PubSubConfig) XXX_Size() int {
+	return xxx_messageInfo_PubSubConfig.Size(m)
+}
+func (m *PubSubConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubSubConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubSubConfig proto.InternalMessageInfo
+
+func (m *PubSubConfig) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *PubSubConfig) GetSubscription() string {
+	if m != nil {
+		return m.Subscription
+	}
+	return ""
+}
+
+func (m *PubSubConfig) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+// Default metadata to apply when opening bugs.
+type TestMetadataOptions struct {
+	// Apply the following metadata if this regex matches a test's name.
+	TestNameRegex string `protobuf:"bytes,1,opt,name=test_name_regex,json=testNameRegex,proto3" json:"test_name_regex,omitempty"`
+	// Default bug component to open a bug in.
+	BugComponent int32 `protobuf:"varint,2,opt,name=bug_component,json=bugComponent,proto3" json:"bug_component,omitempty"`
+	// Default owner to assign a bug to.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// List of default users to CC a bug to.
+	Cc []string `protobuf:"bytes,4,rep,name=cc,proto3" json:"cc,omitempty"`
+	// Apply following metadata if this regex matches a test’s failure message.
+	MessageRegex         string   `protobuf:"bytes,5,opt,name=message_regex,json=messageRegex,proto3" json:"message_regex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestMetadataOptions) Reset()         { *m = TestMetadataOptions{} }
+func (m *TestMetadataOptions) String() string { return proto.CompactTextString(m) }
+func (*TestMetadataOptions) ProtoMessage()    {}
+func (*TestMetadataOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{9}
+}
+
+func (m *TestMetadataOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestMetadataOptions.Unmarshal(m, b)
+}
+func (m *TestMetadataOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestMetadataOptions.Marshal(b, m, deterministic)
+}
+func (m *TestMetadataOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestMetadataOptions.Merge(m, src)
+}
+func (m *TestMetadataOptions) XXX_Size() int {
+	return xxx_messageInfo_TestMetadataOptions.Size(m)
+}
+func (m *TestMetadataOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestMetadataOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestMetadataOptions proto.InternalMessageInfo
+
+func (m *TestMetadataOptions) GetTestNameRegex() string {
+	if m != nil {
+		return m.TestNameRegex
+	}
+	return ""
+}
+
+func (m *TestMetadataOptions) GetBugComponent() int32 {
+	if m != nil {
+		return m.BugComponent
+	}
+	return 0
+}
+
+func (m *TestMetadataOptions) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *TestMetadataOptions) GetCc() []string {
+	if m != nil {
+		return m.Cc
+	}
+	return nil
+}
+
+func (m *TestMetadataOptions) GetMessageRegex() string {
+	if m != nil {
+		return m.MessageRegex
+	}
+	return ""
+}
+
+type AutoBugOptions struct {
+	// [BETA] When specified, file bugs to this component, using the beta AutoBug.
+	// If you do not want to opt into the beta, specify `bug_component` in your
+	// TestGroup instead.
+	// TODO(b/154866134): Rename to autobug_component once we've migrated.
+	BetaAutobugComponent int32 `protobuf:"varint,10,opt,name=beta_autobug_component,json=betaAutobugComponent,proto3" json:"beta_autobug_component,omitempty"`
+	// Whether to auto-close auto-filed bugs.
+	AutoClose bool `protobuf:"varint,1,opt,name=auto_close,json=autoClose,proto3" json:"auto_close,omitempty"`
+	// A list of hotlist ids attached to auto-filed bugs.
+	HotlistIds []int64 `protobuf:"varint,2,rep,packed,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	// The priority of the auto-filed bug. If provided, this will overwrite the
+	// priority in the component default template
+	Priority AutoBugOptions_Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=AutoBugOptions_Priority" json:"priority,omitempty"`
+	// A list of hotlist id sources
+	// Corresponds with the list hotlist_ids (#2)
+	HotlistIdsFromSource []*HotlistIdFromSource `protobuf:"bytes,4,rep,name=hotlist_ids_from_source,json=hotlistIdsFromSource,proto3" json:"hotlist_ids_from_source,omitempty"`
+	// If True, files separate bugs for each failing target, instead of one bug
+	// for each set of targets failing at the same run.
+	FileIndividual bool `protobuf:"varint,5,opt,name=file_individual,json=fileIndividual,proto3" json:"file_individual,omitempty"`
+	// If True; keep only one automantic bug per target, regardless of the number of
+	// separate failures a target gets. This also requires `auto_close` and
+	// `file_individual` to be True.
+	// Consider setting `num_passes_to_disable_alert` instead if you're tracking
+	// flaky tests.
+	SingletonAutobug bool `protobuf:"varint,6,opt,name=singleton_autobug,json=singletonAutobug,proto3" json:"singleton_autobug,omitempty"`
+	// If provided: only raise one bug if the number of failures for a single
+	// query by testgrid for a single failure group exceeds this value. Requires
+	// 'file_individual' to be True.
+	MaxAllowedIndividualBugs int32 `protobuf:"varint,7,opt,name=max_allowed_individual_bugs,json=maxAllowedIndividualBugs,proto3" json:"max_allowed_individual_bugs,omitempty"`
+	// If True; file issues for the 'Overall' target, even if otherwise invalid.
+	FileOverall bool `protobuf:"varint,8,opt,name=file_overall,json=fileOverall,proto3" json:"file_overall,omitempty"`
+	// If provided: supplements `max_allowed_individual_bugs` field to raise a
+	// single bug if the number of failures for a single query by testgrid exceeds
+	// the max_allowed_individual_bugs` value, regardless of `TEST_METADATA`
+	// configurations. This is useful for filing fewer suspected environmental
+	// failure bugs and routing them to a specific location (i.e. an oncall).
+	// Requires 'file_individual' to be true and `max_allowed_individual_bugs` to
+	// not be empty.
+	DefaultTestMetadata *AutoBugOptions_DefaultTestMetadata `protobuf:"bytes,9,opt,name=default_test_metadata,json=defaultTestMetadata,proto3" json:"default_test_metadata,omitempty"`
+	// [BETA] If True, query the test metadata API to get issue-routing metadata.
+	// Enables routing issues using structured test failures.
+	AdvancedTestMetadata bool     `protobuf:"varint,11,opt,name=advanced_test_metadata,json=advancedTestMetadata,proto3" json:"advanced_test_metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AutoBugOptions) Reset()         { *m = AutoBugOptions{} }
+func (m *AutoBugOptions) String() string { return proto.CompactTextString(m) }
+func (*AutoBugOptions) ProtoMessage()    {}
+func (*AutoBugOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{10}
+}
+
+func (m *AutoBugOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AutoBugOptions.Unmarshal(m, b)
+}
+func (m *AutoBugOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AutoBugOptions.Marshal(b, m, deterministic)
+}
+func (m *AutoBugOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AutoBugOptions.Merge(m, src)
+}
+func (m *AutoBugOptions) XXX_Size() int {
+	return xxx_messageInfo_AutoBugOptions.Size(m)
+}
+func (m *AutoBugOptions) XXX_DiscardUnknown() {
 	xxx_messageInfo_AutoBugOptions.DiscardUnknown(m)
 }
 
@@ -1528,7 +2120,7 @@ func (m *AutoBugOptions_DefaultTestMetadata) Reset()         { *m = AutoBugOptio
 func (m *AutoBugOptions_DefaultTestMetadata) String() string { return proto.CompactTextString(m) }
 func (*AutoBugOptions_DefaultTestMetadata) ProtoMessage()    {}
 func (*AutoBugOptions_DefaultTestMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{5, 0}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{10, 0}
 }
 
 func (m *AutoBugOptions_DefaultTestMetadata) XXX_Unmarshal(b []byte) error {
@@ -1584,7 +2176,7 @@ func (m *HotlistIdFromSource) Reset()         { *m = HotlistIdFromSource{} }
 func (m *HotlistIdFromSource) String() string { return proto.CompactTextString(m) }
 func (*HotlistIdFromSource) ProtoMessage()    {}
 func (*HotlistIdFromSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{6}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{11}
 }
 
 func (m *HotlistIdFromSource) XXX_Unmarshal(b []byte) error {
@@ -1668,17 +2260,28 @@ type Dashboard struct {
 	HighlightFailingTabs bool `protobuf:"varint,6,opt,name=highlight_failing_tabs,json=highlightFailingTabs,proto3" json:"highlight_failing_tabs,omitempty"` // Deprecated: Do not use.
 	// Controls whether to apply special highlighting to result header columns for
 	// the current day.
-	HighlightToday       bool     `protobuf:"varint,7,opt,name=highlight_today,json=highlightToday,proto3" json:"highlight_today,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	HighlightToday bool `protobuf:"varint,7,opt,name=highlight_today,json=highlightToday,proto3" json:"highlight_today,omitempty"`
+	// Where to send notifications when tabs on this dashboard alert.
+	NotificationOptions *DashboardNotificationOptions `protobuf:"bytes,9,opt,name=notification_options,json=notificationOptions,proto3" json:"notification_options,omitempty"`
+	// The URL template to visit when filing a bug, for tabs on this dashboard
+	// without their own file_bug_template.
+	FileBugTemplate *LinkTemplate `protobuf:"bytes,10,opt,name=file_bug_template,json=fileBugTemplate,proto3" json:"file_bug_template,omitempty"`
+	// Fields inherited by each tab on this dashboard unless the tab sets them.
+	// Takes precedence over the tab_defaults of the dashboard's groups.
+	TabDefaults *DashboardTab `protobuf:"bytes,11,opt,name=tab_defaults,json=tabDefaults,proto3" json:"tab_defaults,omitempty"`
+	// Who is responsible for the dashboard, defaulting to the ownership of its
+	// dashboard group.
+	Ownership            *Ownership `protobuf:"bytes,12,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *Dashboard) Reset()         { *m = Dashboard{} }
 func (m *Dashboard) String() string { return proto.CompactTextString(m) }
 func (*Dashboard) ProtoMessage()    {}
 func (*Dashboard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{7}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{12}
 }
 
 func (m *Dashboard) XXX_Unmarshal(b []byte) error {
@@ -1749,6 +2352,222 @@ func (m *Dashboard) GetHighlightToday() bool {
 	return false
 }
 
+func (m *Dashboard) GetNotificationOptions() *DashboardNotificationOptions {
+	if m != nil {
+		return m.NotificationOptions
+	}
+	return nil
+}
+
+func (m *Dashboard) GetFileBugTemplate() *LinkTemplate {
+	if m != nil {
+		return m.FileBugTemplate
+	}
+	return nil
+}
+
+func (m *Dashboard) GetTabDefaults() *DashboardTab {
+	if m != nil {
+		return m.TabDefaults
+	}
+	return nil
+}
+
+func (m *Dashboard) GetOwnership() *Ownership {
+	if m != nil {
+		return m.Ownership
+	}
+	return nil
+}
+
+// Who is responsible for a dashboard or dashboard group.
+type Ownership struct {
+	// Usernames of the individual owners, such as GitHub handles.
+	Owners []string `protobuf:"bytes,1,rep,name=owners,proto3" json:"owners,omitempty"`
+	// The team responsible.
+	Team string `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	// How to reach the owners: an email address or a URL, such as a chat channel.
+	Contact              string   `protobuf:"bytes,3,opt,name=contact,proto3" json:"contact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ownership) Reset()         { *m = Ownership{} }
+func (m *Ownership) String() string { return proto.CompactTextString(m) }
+func (*Ownership) ProtoMessage()    {}
+func (*Ownership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{13}
+}
+
+func (m *Ownership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ownership.Unmarshal(m, b)
+}
+func (m *Ownership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ownership.Marshal(b, m, deterministic)
+}
+func (m *Ownership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ownership.Merge(m, src)
+}
+func (m *Ownership) XXX_Size() int {
+	return xxx_messageInfo_Ownership.Size(m)
+}
+func (m *Ownership) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ownership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ownership proto.InternalMessageInfo
+
+func (m *Ownership) GetOwners() []string {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *Ownership) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *Ownership) GetContact() string {
+	if m != nil {
+		return m.Contact
+	}
+	return ""
+}
+
+// Configuration options for sending notifications about a dashboard.
+type DashboardNotificationOptions struct {
+	// Slack channels to post to when a tab starts or stops alerting.
+	SlackChannels []string `protobuf:"bytes,1,rep,name=slack_channels,json=slackChannels,proto3" json:"slack_channels,omitempty"`
+	// Named webhooks to send a templated payload to for each alert event.
+	Webhooks []string `protobuf:"bytes,2,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	// File GitHub issues about tests which fail consecutively if set.
+	GithubIssues *GitHubIssueOptions `protobuf:"bytes,3,opt,name=github_issues,json=githubIssues,proto3" json:"github_issues,omitempty"`
+	// Names of the notification_channels to send each alert event to.
+	Channels             []string `protobuf:"bytes,4,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardNotificationOptions) Reset()         { *m = DashboardNotificationOptions{} }
+func (m *DashboardNotificationOptions) String() string { return proto.CompactTextString(m) }
+func (*DashboardNotificationOptions) ProtoMessage()    {}
+func (*DashboardNotificationOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{14}
+}
+
+func (m *DashboardNotificationOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardNotificationOptions.Unmarshal(m, b)
+}
+func (m *DashboardNotificationOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardNotificationOptions.Marshal(b, m, deterministic)
+}
+func (m *DashboardNotificationOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardNotificationOptions.Merge(m, src)
+}
+func (m *DashboardNotificationOptions) XXX_Size() int {
+	return xxx_messageInfo_DashboardNotificationOptions.Size(m)
+}
+func (m *DashboardNotificationOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardNotificationOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardNotificationOptions proto.InternalMessageInfo
+
+func (m *DashboardNotificationOptions) GetSlackChannels() []string {
+	if m != nil {
+		return m.SlackChannels
+	}
+	return nil
+}
+
+func (m *DashboardNotificationOptions) GetWebhooks() []string {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+func (m *DashboardNotificationOptions) GetGithubIssues() *GitHubIssueOptions {
+	if m != nil {
+		return m.GithubIssues
+	}
+	return nil
+}
+
+func (m *DashboardNotificationOptions) GetChannels() []string {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+// Configuration options for filing GitHub issues about sustained test failures.
+type GitHubIssueOptions struct {
+	// Repository to file issues in, as owner/name.
+	Repo string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// Open an issue once a test fails this many consecutive runs, defaulting to 3.
+	// Comments on any open issue with the same title instead of filing another.
+	// Closes the issue once the test passes.
+	ConsecutiveFailures int32 `protobuf:"varint,2,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// Labels to add to each issue filed.
+	Labels               []string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GitHubIssueOptions) Reset()         { *m = GitHubIssueOptions{} }
+func (m *GitHubIssueOptions) String() string { return proto.CompactTextString(m) }
+func (*GitHubIssueOptions) ProtoMessage()    {}
+func (*GitHubIssueOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{15}
+}
+
+func (m *GitHubIssueOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GitHubIssueOptions.Unmarshal(m, b)
+}
+func (m *GitHubIssueOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GitHubIssueOptions.Marshal(b, m, deterministic)
+}
+func (m *GitHubIssueOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GitHubIssueOptions.Merge(m, src)
+}
+func (m *GitHubIssueOptions) XXX_Size() int {
+	return xxx_messageInfo_GitHubIssueOptions.Size(m)
+}
+func (m *GitHubIssueOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_GitHubIssueOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GitHubIssueOptions proto.InternalMessageInfo
+
+func (m *GitHubIssueOptions) GetRepo() string {
+	if m != nil {
+		return m.Repo
+	}
+	return ""
+}
+
+func (m *GitHubIssueOptions) GetConsecutiveFailures() int32 {
+	if m != nil {
+		return m.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (m *GitHubIssueOptions) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type LinkTemplate struct {
 	// The URL template.
 	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -1765,7 +2584,7 @@ func (m *LinkTemplate) Reset()         { *m = LinkTemplate{} }
 func (m *LinkTemplate) String() string { return proto.CompactTextString(m) }
 func (*LinkTemplate) ProtoMessage()    {}
 func (*LinkTemplate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{8}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{16}
 }
 
 func (m *LinkTemplate) XXX_Unmarshal(b []byte) error {
@@ -1822,7 +2641,7 @@ func (m *LinkOptionsTemplate) Reset()         { *m = LinkOptionsTemplate{} }
 func (m *LinkOptionsTemplate) String() string { return proto.CompactTextString(m) }
 func (*LinkOptionsTemplate) ProtoMessage()    {}
 func (*LinkOptionsTemplate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{9}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{17}
 }
 
 func (m *LinkOptionsTemplate) XXX_Unmarshal(b []byte) error {
@@ -1926,16 +2745,34 @@ type DashboardTab struct {
 	BetaAutobugOptions *AutoBugOptions `protobuf:"bytes,22,opt,name=beta_autobug_options,json=betaAutobugOptions,proto3" json:"beta_autobug_options,omitempty"`
 	// Options for the configuration of the flakiness analysis tool, on a per tab basis
 	HealthAnalysisOptions *HealthAnalysisOptions `protobuf:"bytes,23,opt,name=health_analysis_options,json=healthAnalysisOptions,proto3" json:"health_analysis_options,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{}               `json:"-"`
-	XXX_unrecognized      []byte                 `json:"-"`
-	XXX_sizecache         int32                  `json:"-"`
+	// When specified, treat a column as an infrastructure failure when the ratio
+	// of failed or errored rows to rows with results exceeds <threshold>.
+	// Excludes such columns from flakiness analysis and alerts.
+	InfraFailureThreshold float32 `protobuf:"fixed32,25,opt,name=infra_failure_threshold,json=infraFailureThreshold,proto3" json:"infra_failure_threshold,omitempty"`
+	// Associate failing tests with the issues that mention them in this tracker.
+	IssueTracker *IssueTrackerOptions `protobuf:"bytes,26,opt,name=issue_tracker,json=issueTracker,proto3" json:"issue_tracker,omitempty"`
+	// Service level objective for the pass rate of the tab.
+	SloOptions *SLOOptions `protobuf:"bytes,27,opt,name=slo_options,json=sloOptions,proto3" json:"slo_options,omitempty"`
+	// When specified, also treat a column as an infrastructure failure when
+	// any of its results has a non-empty value for this property, such as one
+	// set by the job when its cluster failed to start.
+	InfraFailureProperty string `protobuf:"bytes,28,opt,name=infra_failure_property,json=infraFailureProperty,proto3" json:"infra_failure_property,omitempty"`
+	// Number of recent columns over which the summary reports the pass
+	// percentage of the tab, 50 by default.
+	RecentHealthColumns int32 `protobuf:"varint,29,opt,name=recent_health_columns,json=recentHealthColumns,proto3" json:"recent_health_columns,omitempty"`
+	// Link to the runbook or other documentation of the tab, for those
+	// investigating its failures.
+	DocumentationUrl     string   `protobuf:"bytes,30,opt,name=documentation_url,json=documentationUrl,proto3" json:"documentation_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DashboardTab) Reset()         { *m = DashboardTab{} }
 func (m *DashboardTab) String() string { return proto.CompactTextString(m) }
 func (*DashboardTab) ProtoMessage()    {}
 func (*DashboardTab) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{10}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{18}
 }
 
 func (m *DashboardTab) XXX_Unmarshal(b []byte) error {
@@ -2061,67 +2898,247 @@ func (m *DashboardTab) GetAlertOptions() *DashboardTabAlertOptions {
 	return nil
 }
 
-func (m *DashboardTab) GetFlakinessAlertOptions() *DashboardTabFlakinessAlertOptions {
+func (m *DashboardTab) GetFlakinessAlertOptions() *DashboardTabFlakinessAlertOptions {
+	if m != nil {
+		return m.FlakinessAlertOptions
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetAboutDashboardUrl() string {
+	if m != nil {
+		return m.AboutDashboardUrl
+	}
+	return ""
+}
+
+func (m *DashboardTab) GetOpenBugTemplate() *LinkTemplate {
+	if m != nil {
+		return m.OpenBugTemplate
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetAutoFileBugs() bool {
+	if m != nil {
+		return m.AutoFileBugs
+	}
+	return false
+}
+
+func (m *DashboardTab) GetDisplayLocalTime() bool {
+	if m != nil {
+		return m.DisplayLocalTime
+	}
+	return false
+}
+
+func (m *DashboardTab) GetContextMenuTemplate() *LinkTemplate {
+	if m != nil {
+		return m.ContextMenuTemplate
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetBrokenColumnThreshold() float32 {
+	if m != nil {
+		return m.BrokenColumnThreshold
+	}
+	return 0
+}
+
+func (m *DashboardTab) GetBetaAutobugOptions() *AutoBugOptions {
+	if m != nil {
+		return m.BetaAutobugOptions
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetHealthAnalysisOptions() *HealthAnalysisOptions {
+	if m != nil {
+		return m.HealthAnalysisOptions
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetInfraFailureThreshold() float32 {
+	if m != nil {
+		return m.InfraFailureThreshold
+	}
+	return 0
+}
+
+func (m *DashboardTab) GetIssueTracker() *IssueTrackerOptions {
+	if m != nil {
+		return m.IssueTracker
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetSloOptions() *SLOOptions {
+	if m != nil {
+		return m.SloOptions
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetInfraFailureProperty() string {
+	if m != nil {
+		return m.InfraFailureProperty
+	}
+	return ""
+}
+
+func (m *DashboardTab) GetRecentHealthColumns() int32 {
+	if m != nil {
+		return m.RecentHealthColumns
+	}
+	return 0
+}
+
+func (m *DashboardTab) GetDocumentationUrl() string {
+	if m != nil {
+		return m.DocumentationUrl
+	}
+	return ""
+}
+
+// A service level objective for the pass rate of a tab, excluding infra failures.
+// Burn rates compare the failure rate of a window with the failures the target allows.
+type SLOOptions struct {
+	// Percentage of results expected to pass, such as 99. Must be below 100.
+	TargetPassRate float32 `protobuf:"fixed32,1,opt,name=target_pass_rate,json=targetPassRate,proto3" json:"target_pass_rate,omitempty"`
+	// Hours in the short and long windows, defaulting to 24 and 168.
+	ShortWindowHours int32 `protobuf:"varint,2,opt,name=short_window_hours,json=shortWindowHours,proto3" json:"short_window_hours,omitempty"`
+	LongWindowHours  int32 `protobuf:"varint,3,opt,name=long_window_hours,json=longWindowHours,proto3" json:"long_window_hours,omitempty"`
+	// Flag the tab as violating its objective once both windows burn at least
+	// this many times faster than the target allows, defaulting to 1.
+	BurnRateThreshold    float32  `protobuf:"fixed32,4,opt,name=burn_rate_threshold,json=burnRateThreshold,proto3" json:"burn_rate_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SLOOptions) Reset()         { *m = SLOOptions{} }
+func (m *SLOOptions) String() string { return proto.CompactTextString(m) }
+func (*SLOOptions) ProtoMessage()    {}
+func (*SLOOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{19}
+}
+
+func (m *SLOOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SLOOptions.Unmarshal(m, b)
+}
+func (m *SLOOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SLOOptions.Marshal(b, m, deterministic)
+}
+func (m *SLOOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SLOOptions.Merge(m, src)
+}
+func (m *SLOOptions) XXX_Size() int {
+	return xxx_messageInfo_SLOOptions.Size(m)
+}
+func (m *SLOOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_SLOOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SLOOptions proto.InternalMessageInfo
+
+func (m *SLOOptions) GetTargetPassRate() float32 {
 	if m != nil {
-		return m.FlakinessAlertOptions
+		return m.TargetPassRate
 	}
-	return nil
+	return 0
 }
 
-func (m *DashboardTab) GetAboutDashboardUrl() string {
+func (m *SLOOptions) GetShortWindowHours() int32 {
 	if m != nil {
-		return m.AboutDashboardUrl
+		return m.ShortWindowHours
 	}
-	return ""
+	return 0
 }
 
-func (m *DashboardTab) GetOpenBugTemplate() *LinkTemplate {
+func (m *SLOOptions) GetLongWindowHours() int32 {
 	if m != nil {
-		return m.OpenBugTemplate
+		return m.LongWindowHours
 	}
-	return nil
+	return 0
 }
 
-func (m *DashboardTab) GetAutoFileBugs() bool {
+func (m *SLOOptions) GetBurnRateThreshold() float32 {
 	if m != nil {
-		return m.AutoFileBugs
+		return m.BurnRateThreshold
 	}
-	return false
+	return 0
 }
 
-func (m *DashboardTab) GetDisplayLocalTime() bool {
-	if m != nil {
-		return m.DisplayLocalTime
-	}
-	return false
+// Configuration options for finding the issues associated with failing tests.
+// Set either the GitHub repo or the Jira project.
+type IssueTrackerOptions struct {
+	// GitHub repository to search, as owner/name.
+	GithubRepo string `protobuf:"bytes,1,opt,name=github_repo,json=githubRepo,proto3" json:"github_repo,omitempty"`
+	// Key of the Jira project to search, such as PROJ.
+	JiraProject string `protobuf:"bytes,2,opt,name=jira_project,json=jiraProject,proto3" json:"jira_project,omitempty"`
+	// Base URL of the Jira server, such as https://example.atlassian.net.
+	JiraUrl string `protobuf:"bytes,3,opt,name=jira_url,json=jiraUrl,proto3" json:"jira_url,omitempty"`
+	// Also associate closed issues if set.
+	IncludeClosed        bool     `protobuf:"varint,4,opt,name=include_closed,json=includeClosed,proto3" json:"include_closed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DashboardTab) GetContextMenuTemplate() *LinkTemplate {
+func (m *IssueTrackerOptions) Reset()         { *m = IssueTrackerOptions{} }
+func (m *IssueTrackerOptions) String() string { return proto.CompactTextString(m) }
+func (*IssueTrackerOptions) ProtoMessage()    {}
+func (*IssueTrackerOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{20}
+}
+
+func (m *IssueTrackerOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTrackerOptions.Unmarshal(m, b)
+}
+func (m *IssueTrackerOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTrackerOptions.Marshal(b, m, deterministic)
+}
+func (m *IssueTrackerOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTrackerOptions.Merge(m, src)
+}
+func (m *IssueTrackerOptions) XXX_Size() int {
+	return xxx_messageInfo_IssueTrackerOptions.Size(m)
+}
+func (m *IssueTrackerOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTrackerOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTrackerOptions proto.InternalMessageInfo
+
+func (m *IssueTrackerOptions) GetGithubRepo() string {
 	if m != nil {
-		return m.ContextMenuTemplate
+		return m.GithubRepo
 	}
-	return nil
+	return ""
 }
 
-func (m *DashboardTab) GetBrokenColumnThreshold() float32 {
+func (m *IssueTrackerOptions) GetJiraProject() string {
 	if m != nil {
-		return m.BrokenColumnThreshold
+		return m.JiraProject
 	}
-	return 0
+	return ""
 }
 
-func (m *DashboardTab) GetBetaAutobugOptions() *AutoBugOptions {
+func (m *IssueTrackerOptions) GetJiraUrl() string {
 	if m != nil {
-		return m.BetaAutobugOptions
+		return m.JiraUrl
 	}
-	return nil
+	return ""
 }
 
-func (m *DashboardTab) GetHealthAnalysisOptions() *HealthAnalysisOptions {
+func (m *IssueTrackerOptions) GetIncludeClosed() bool {
 	if m != nil {
-		return m.HealthAnalysisOptions
+		return m.IncludeClosed
 	}
-	return nil
+	return false
 }
 
 // Configuration options for dashboard tab alerts.
@@ -2131,11 +3148,15 @@ type DashboardTabAlertOptions struct {
 	// alerts are raised.
 	AlertStaleResultsHours int32 `protobuf:"varint,1,opt,name=alert_stale_results_hours,json=alertStaleResultsHours,proto3" json:"alert_stale_results_hours,omitempty"`
 	// The number of consecutive test result failures to see before alerting of
-	// a consistent failure. If zero, no alerts are raised.
+	// a consistent failure. If zero, uses the test group's num_failures_to_alert,
+	// raising no alerts when that is also zero.
+	// When set, the summarizer alerts on this tab using its own thresholds
+	// instead of those of the test group.
 	NumFailuresToAlert int32 `protobuf:"varint,2,opt,name=num_failures_to_alert,json=numFailuresToAlert,proto3" json:"num_failures_to_alert,omitempty"`
 	// The comma-separated addresses to send mail.
 	AlertMailToAddresses string `protobuf:"bytes,3,opt,name=alert_mail_to_addresses,json=alertMailToAddresses,proto3" json:"alert_mail_to_addresses,omitempty"`
 	// The number of consecutive test passes to close the alert.
+	// Overrides the test group's num_passes_to_disable_alert when set.
 	NumPassesToDisableAlert int32 `protobuf:"varint,4,opt,name=num_passes_to_disable_alert,json=numPassesToDisableAlert,proto3" json:"num_passes_to_disable_alert,omitempty"`
 	// Custom subject for alert mails.
 	Subject string `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
@@ -2148,17 +3169,25 @@ type DashboardTabAlertOptions struct {
 	// TestGrid does not pester about staleness
 	WaitMinutesBetweenEmails int32 `protobuf:"varint,8,opt,name=wait_minutes_between_emails,json=waitMinutesBetweenEmails,proto3" json:"wait_minutes_between_emails,omitempty"`
 	// A custom message
-	AlertMailFailureMessage string   `protobuf:"bytes,9,opt,name=alert_mail_failure_message,json=alertMailFailureMessage,proto3" json:"alert_mail_failure_message,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
-	XXX_unrecognized        []byte   `json:"-"`
-	XXX_sizecache           int32    `json:"-"`
+	AlertMailFailureMessage string `protobuf:"bytes,9,opt,name=alert_mail_failure_message,json=alertMailFailureMessage,proto3" json:"alert_mail_failure_message,omitempty"`
+	// Only alert on tests with at least this many results in the tab.
+	MinRunsToAlert int32 `protobuf:"varint,10,opt,name=min_runs_to_alert,json=minRunsToAlert,proto3" json:"min_runs_to_alert,omitempty"`
+	// If true, TIMED_OUT results neither open nor close alerts on this tab,
+	// as though the test did not run. Otherwise they count as failures.
+	IgnoreTimedOut bool `protobuf:"varint,11,opt,name=ignore_timed_out,json=ignoreTimedOut,proto3" json:"ignore_timed_out,omitempty"`
+	// If true, ABORTED results neither open nor close alerts on this tab.
+	// Otherwise they count as failures.
+	IgnoreAborted        bool     `protobuf:"varint,12,opt,name=ignore_aborted,json=ignoreAborted,proto3" json:"ignore_aborted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DashboardTabAlertOptions) Reset()         { *m = DashboardTabAlertOptions{} }
 func (m *DashboardTabAlertOptions) String() string { return proto.CompactTextString(m) }
 func (*DashboardTabAlertOptions) ProtoMessage()    {}
 func (*DashboardTabAlertOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{11}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{21}
 }
 
 func (m *DashboardTabAlertOptions) XXX_Unmarshal(b []byte) error {
@@ -2242,6 +3271,27 @@ func (m *DashboardTabAlertOptions) GetAlertMailFailureMessage() string {
 	return ""
 }
 
+func (m *DashboardTabAlertOptions) GetMinRunsToAlert() int32 {
+	if m != nil {
+		return m.MinRunsToAlert
+	}
+	return 0
+}
+
+func (m *DashboardTabAlertOptions) GetIgnoreTimedOut() bool {
+	if m != nil {
+		return m.IgnoreTimedOut
+	}
+	return false
+}
+
+func (m *DashboardTabAlertOptions) GetIgnoreAborted() bool {
+	if m != nil {
+		return m.IgnoreAborted
+	}
+	return false
+}
+
 // Configuration options for dashboard tab flakiness alerts.
 type DashboardTabFlakinessAlertOptions struct {
 	// The minimum amount of flakiness needed to trigger a flakiness alert.
@@ -2266,7 +3316,7 @@ func (m *DashboardTabFlakinessAlertOptions) Reset()         { *m = DashboardTabF
 func (m *DashboardTabFlakinessAlertOptions) String() string { return proto.CompactTextString(m) }
 func (*DashboardTabFlakinessAlertOptions) ProtoMessage()    {}
 func (*DashboardTabFlakinessAlertOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{12}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{22}
 }
 
 func (m *DashboardTabFlakinessAlertOptions) XXX_Unmarshal(b []byte) error {
@@ -2328,17 +3378,24 @@ type DashboardGroup struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// A list of names specifying dashboards to show links to in a separate tabbed
 	// bar at the top of the page for each of the given dashboards.
-	DashboardNames       []string `protobuf:"bytes,2,rep,name=dashboard_names,json=dashboardNames,proto3" json:"dashboard_names,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	DashboardNames []string `protobuf:"bytes,2,rep,name=dashboard_names,json=dashboardNames,proto3" json:"dashboard_names,omitempty"`
+	// Where to send notifications when tabs on these dashboards alert.
+	NotificationOptions *DashboardGroupNotificationOptions `protobuf:"bytes,3,opt,name=notification_options,json=notificationOptions,proto3" json:"notification_options,omitempty"`
+	// Fields inherited by each tab on these dashboards unless the tab or its
+	// dashboard's tab_defaults set them.
+	TabDefaults *DashboardTab `protobuf:"bytes,4,opt,name=tab_defaults,json=tabDefaults,proto3" json:"tab_defaults,omitempty"`
+	// Who is responsible for these dashboards, unless they set their own.
+	Ownership            *Ownership `protobuf:"bytes,5,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *DashboardGroup) Reset()         { *m = DashboardGroup{} }
 func (m *DashboardGroup) String() string { return proto.CompactTextString(m) }
 func (*DashboardGroup) ProtoMessage()    {}
 func (*DashboardGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{13}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{23}
 }
 
 func (m *DashboardGroup) XXX_Unmarshal(b []byte) error {
@@ -2373,6 +3430,69 @@ func (m *DashboardGroup) GetDashboardNames() []string {
 	return nil
 }
 
+func (m *DashboardGroup) GetNotificationOptions() *DashboardGroupNotificationOptions {
+	if m != nil {
+		return m.NotificationOptions
+	}
+	return nil
+}
+
+func (m *DashboardGroup) GetTabDefaults() *DashboardTab {
+	if m != nil {
+		return m.TabDefaults
+	}
+	return nil
+}
+
+func (m *DashboardGroup) GetOwnership() *Ownership {
+	if m != nil {
+		return m.Ownership
+	}
+	return nil
+}
+
+// Configuration options for sending notifications about a dashboard group.
+type DashboardGroupNotificationOptions struct {
+	// PagerDuty services to open, acknowledge and resolve incidents on as tabs
+	// start alerting, get linked issues and recover.
+	PagerdutyServices    []string `protobuf:"bytes,1,rep,name=pagerduty_services,json=pagerdutyServices,proto3" json:"pagerduty_services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardGroupNotificationOptions) Reset()         { *m = DashboardGroupNotificationOptions{} }
+func (m *DashboardGroupNotificationOptions) String() string { return proto.CompactTextString(m) }
+func (*DashboardGroupNotificationOptions) ProtoMessage()    {}
+func (*DashboardGroupNotificationOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{24}
+}
+
+func (m *DashboardGroupNotificationOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardGroupNotificationOptions.Unmarshal(m, b)
+}
+func (m *DashboardGroupNotificationOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardGroupNotificationOptions.Marshal(b, m, deterministic)
+}
+func (m *DashboardGroupNotificationOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardGroupNotificationOptions.Merge(m, src)
+}
+func (m *DashboardGroupNotificationOptions) XXX_Size() int {
+	return xxx_messageInfo_DashboardGroupNotificationOptions.Size(m)
+}
+func (m *DashboardGroupNotificationOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardGroupNotificationOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardGroupNotificationOptions proto.InternalMessageInfo
+
+func (m *DashboardGroupNotificationOptions) GetPagerdutyServices() []string {
+	if m != nil {
+		return m.PagerdutyServices
+	}
+	return nil
+}
+
 // A service configuration consisting of multiple test groups and dashboards.
 type Configuration struct {
 	// A list of groups of tests to gather.
@@ -2380,17 +3500,19 @@ type Configuration struct {
 	// A list of all of the dashboards for a server.
 	Dashboards []*Dashboard `protobuf:"bytes,2,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
 	// A list of all the dashboard groups for a server.
-	DashboardGroups      []*DashboardGroup `protobuf:"bytes,3,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DashboardGroups []*DashboardGroup `protobuf:"bytes,3,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
+	// Destinations for notifications, which dashboards reference by name.
+	NotificationChannels []*NotificationChannel `protobuf:"bytes,4,rep,name=notification_channels,json=notificationChannels,proto3" json:"notification_channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *Configuration) Reset()         { *m = Configuration{} }
 func (m *Configuration) String() string { return proto.CompactTextString(m) }
 func (*Configuration) ProtoMessage()    {}
 func (*Configuration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{14}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{25}
 }
 
 func (m *Configuration) XXX_Unmarshal(b []byte) error {
@@ -2432,6 +3554,255 @@ func (m *Configuration) GetDashboardGroups() []*DashboardGroup {
 	return nil
 }
 
+func (m *Configuration) GetNotificationChannels() []*NotificationChannel {
+	if m != nil {
+		return m.NotificationChannels
+	}
+	return nil
+}
+
+// A named destination for notifications, setting exactly one of slack, email
+// or webhook.
+type NotificationChannel struct {
+	// The name dashboards use to reference the channel.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Post a message describing the events to a slack channel.
+	Slack *SlackChannel `protobuf:"bytes,2,opt,name=slack,proto3" json:"slack,omitempty"`
+	// Mail the alerts to a list of recipients.
+	Email *EmailChannel `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// Send a templated payload per event to a webhook.
+	Webhook              *WebhookChannel `protobuf:"bytes,4,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NotificationChannel) Reset()         { *m = NotificationChannel{} }
+func (m *NotificationChannel) String() string { return proto.CompactTextString(m) }
+func (*NotificationChannel) ProtoMessage()    {}
+func (*NotificationChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{26}
+}
+
+func (m *NotificationChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationChannel.Unmarshal(m, b)
+}
+func (m *NotificationChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationChannel.Marshal(b, m, deterministic)
+}
+func (m *NotificationChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationChannel.Merge(m, src)
+}
+func (m *NotificationChannel) XXX_Size() int {
+	return xxx_messageInfo_NotificationChannel.Size(m)
+}
+func (m *NotificationChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationChannel proto.InternalMessageInfo
+
+func (m *NotificationChannel) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NotificationChannel) GetSlack() *SlackChannel {
+	if m != nil {
+		return m.Slack
+	}
+	return nil
+}
+
+func (m *NotificationChannel) GetEmail() *EmailChannel {
+	if m != nil {
+		return m.Email
+	}
+	return nil
+}
+
+func (m *NotificationChannel) GetWebhook() *WebhookChannel {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+// A slack channel, posted to through its incoming webhook.
+type SlackChannel struct {
+	// The incoming webhook URL of the channel.
+	WebhookUrl           string   `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlackChannel) Reset()         { *m = SlackChannel{} }
+func (m *SlackChannel) String() string { return proto.CompactTextString(m) }
+func (*SlackChannel) ProtoMessage()    {}
+func (*SlackChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{27}
+}
+
+func (m *SlackChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlackChannel.Unmarshal(m, b)
+}
+func (m *SlackChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlackChannel.Marshal(b, m, deterministic)
+}
+func (m *SlackChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlackChannel.Merge(m, src)
+}
+func (m *SlackChannel) XXX_Size() int {
+	return xxx_messageInfo_SlackChannel.Size(m)
+}
+func (m *SlackChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlackChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlackChannel proto.InternalMessageInfo
+
+func (m *SlackChannel) GetWebhookUrl() string {
+	if m != nil {
+		return m.WebhookUrl
+	}
+	return ""
+}
+
+// Recipients of alert mails.
+type EmailChannel struct {
+	// The comma-separated addresses to send mail.
+	ToAddresses          string   `protobuf:"bytes,1,opt,name=to_addresses,json=toAddresses,proto3" json:"to_addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmailChannel) Reset()         { *m = EmailChannel{} }
+func (m *EmailChannel) String() string { return proto.CompactTextString(m) }
+func (*EmailChannel) ProtoMessage()    {}
+func (*EmailChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{28}
+}
+
+func (m *EmailChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmailChannel.Unmarshal(m, b)
+}
+func (m *EmailChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmailChannel.Marshal(b, m, deterministic)
+}
+func (m *EmailChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmailChannel.Merge(m, src)
+}
+func (m *EmailChannel) XXX_Size() int {
+	return xxx_messageInfo_EmailChannel.Size(m)
+}
+func (m *EmailChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmailChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmailChannel proto.InternalMessageInfo
+
+func (m *EmailChannel) GetToAddresses() string {
+	if m != nil {
+		return m.ToAddresses
+	}
+	return ""
+}
+
+// A webhook receiving a JSON payload per event.
+type WebhookChannel struct {
+	// The URL to post each payload to.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// A Go template rendering an event into the JSON payload.
+	Template             string   `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookChannel) Reset()         { *m = WebhookChannel{} }
+func (m *WebhookChannel) String() string { return proto.CompactTextString(m) }
+func (*WebhookChannel) ProtoMessage()    {}
+func (*WebhookChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{29}
+}
+
+func (m *WebhookChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookChannel.Unmarshal(m, b)
+}
+func (m *WebhookChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookChannel.Marshal(b, m, deterministic)
+}
+func (m *WebhookChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookChannel.Merge(m, src)
+}
+func (m *WebhookChannel) XXX_Size() int {
+	return xxx_messageInfo_WebhookChannel.Size(m)
+}
+func (m *WebhookChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookChannel proto.InternalMessageInfo
+
+func (m *WebhookChannel) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WebhookChannel) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+// Lists the objects of a configuration split into one Configuration per dashboard group.
+type ConfigurationIndex struct {
+	// Paths of each shard, relative to the index.
+	Shards               []string `protobuf:"bytes,1,rep,name=shards,proto3" json:"shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigurationIndex) Reset()         { *m = ConfigurationIndex{} }
+func (m *ConfigurationIndex) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationIndex) ProtoMessage()    {}
+func (*ConfigurationIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{30}
+}
+
+func (m *ConfigurationIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigurationIndex.Unmarshal(m, b)
+}
+func (m *ConfigurationIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigurationIndex.Marshal(b, m, deterministic)
+}
+func (m *ConfigurationIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationIndex.Merge(m, src)
+}
+func (m *ConfigurationIndex) XXX_Size() int {
+	return xxx_messageInfo_ConfigurationIndex.Size(m)
+}
+func (m *ConfigurationIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationIndex proto.InternalMessageInfo
+
+func (m *ConfigurationIndex) GetShards() []string {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
 // A grouping of configuration options for the flakiness analysis tool.
 // Later configuration options could include the ability to choose different kinds of
 // flakiness and choosing if and who to email a copy of the flakiness report.
@@ -2454,7 +3825,18 @@ type HealthAnalysisOptions struct {
 	// The regex will match " - env" in the above test name and give a group of:
 	// //path/to/test  <- Group Name
 	//     - env       <- Group Member
-	GroupingRegex        string   `protobuf:"bytes,5,opt,name=grouping_regex,json=groupingRegex,proto3" json:"grouping_regex,omitempty"`
+	GroupingRegex string `protobuf:"bytes,5,opt,name=grouping_regex,json=groupingRegex,proto3" json:"grouping_regex,omitempty"`
+	// Number of days in each window to compute per-test flake rates over, e.g. [1, 7, 30].
+	// The first window annotates the rows of the tab summary.
+	// Flake rates are computed independently of enable; empty disables them.
+	FlakeRateWindows []int32 `protobuf:"varint,6,rep,packed,name=flake_rate_windows,json=flakeRateWindows,proto3" json:"flake_rate_windows,omitempty"`
+	// Compare the pass rate of the last N days against this longer window to
+	// find degrading tabs, defaulting to 7 and 30 days.
+	TrendShortDays int32 `protobuf:"varint,7,opt,name=trend_short_days,json=trendShortDays,proto3" json:"trend_short_days,omitempty"`
+	TrendLongDays  int32 `protobuf:"varint,8,opt,name=trend_long_days,json=trendLongDays,proto3" json:"trend_long_days,omitempty"`
+	// Flag the tab as degrading once its short window pass rate drops this many
+	// percentage points below the long window, defaulting to 5.
+	DegradingThreshold   float32  `protobuf:"fixed32,9,opt,name=degrading_threshold,json=degradingThreshold,proto3" json:"degrading_threshold,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2464,7 +3846,7 @@ func (m *HealthAnalysisOptions) Reset()         { *m = HealthAnalysisOptions{} }
 func (m *HealthAnalysisOptions) String() string { return proto.CompactTextString(m) }
 func (*HealthAnalysisOptions) ProtoMessage()    {}
 func (*HealthAnalysisOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{15}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{31}
 }
 
 func (m *HealthAnalysisOptions) XXX_Unmarshal(b []byte) error {
@@ -2520,6 +3902,34 @@ func (m *HealthAnalysisOptions) GetGroupingRegex() string {
 	return ""
 }
 
+func (m *HealthAnalysisOptions) GetFlakeRateWindows() []int32 {
+	if m != nil {
+		return m.FlakeRateWindows
+	}
+	return nil
+}
+
+func (m *HealthAnalysisOptions) GetTrendShortDays() int32 {
+	if m != nil {
+		return m.TrendShortDays
+	}
+	return 0
+}
+
+func (m *HealthAnalysisOptions) GetTrendLongDays() int32 {
+	if m != nil {
+		return m.TrendLongDays
+	}
+	return 0
+}
+
+func (m *HealthAnalysisOptions) GetDegradingThreshold() float32 {
+	if m != nil {
+		return m.DegradingThreshold
+	}
+	return 0
+}
+
 // The DefaultConfiguration Proto is deprecated, and will be deleted after Nov 1, 2019
 // For defaulting behavior, use the yamlcfg library instead.
 type DefaultConfiguration struct {
@@ -2536,7 +3946,7 @@ func (m *DefaultConfiguration) Reset()         { *m = DefaultConfiguration{} }
 func (m *DefaultConfiguration) String() string { return proto.CompactTextString(m) }
 func (*DefaultConfiguration) ProtoMessage()    {}
 func (*DefaultConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{16}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{32}
 }
 
 func (m *DefaultConfiguration) XXX_Unmarshal(b []byte) error {
@@ -2579,6 +3989,8 @@ func init() {
 	proto.RegisterEnum("TestGroup_ColumnSortBy", TestGroup_ColumnSortBy_name, TestGroup_ColumnSortBy_value)
 	proto.RegisterEnum("TestGroup_PrimaryGrouping", TestGroup_PrimaryGrouping_name, TestGroup_PrimaryGrouping_value)
 	proto.RegisterEnum("TestGroup_Environment", TestGroup_Environment_name, TestGroup_Environment_value)
+	proto.RegisterEnum("TestGroup_DuplicateBuilds", TestGroup_DuplicateBuilds_name, TestGroup_DuplicateBuilds_value)
+	proto.RegisterEnum("TestGroup_ColumnRetention", TestGroup_ColumnRetention_name, TestGroup_ColumnRetention_value)
 	proto.RegisterEnum("AutoBugOptions_Priority", AutoBugOptions_Priority_name, AutoBugOptions_Priority_value)
 	proto.RegisterType((*TestNameConfig)(nil), "TestNameConfig")
 	proto.RegisterType((*TestNameConfig_NameElement)(nil), "TestNameConfig.NameElement")
@@ -2588,19 +4000,36 @@ func init() {
 	proto.RegisterType((*TestGroup_TestAnnotation)(nil), "TestGroup.TestAnnotation")
 	proto.RegisterType((*TestGroup_KeyValue)(nil), "TestGroup.KeyValue")
 	proto.RegisterType((*TestGroup_ResultSource)(nil), "TestGroup.ResultSource")
+	proto.RegisterType((*TestGroup_ArtifactLink)(nil), "TestGroup.ArtifactLink")
 	proto.RegisterType((*JUnitConfig)(nil), "JUnitConfig")
+	proto.RegisterType((*BigQueryConfig)(nil), "BigQueryConfig")
+	proto.RegisterType((*CloudBuildConfig)(nil), "CloudBuildConfig")
+	proto.RegisterType((*AzureDevOpsConfig)(nil), "AzureDevOpsConfig")
+	proto.RegisterType((*ResultStoreConfig)(nil), "ResultStoreConfig")
+	proto.RegisterType((*PubSubConfig)(nil), "PubSubConfig")
 	proto.RegisterType((*TestMetadataOptions)(nil), "TestMetadataOptions")
 	proto.RegisterType((*AutoBugOptions)(nil), "AutoBugOptions")
 	proto.RegisterType((*AutoBugOptions_DefaultTestMetadata)(nil), "AutoBugOptions.DefaultTestMetadata")
 	proto.RegisterType((*HotlistIdFromSource)(nil), "HotlistIdFromSource")
 	proto.RegisterType((*Dashboard)(nil), "Dashboard")
+	proto.RegisterType((*Ownership)(nil), "Ownership")
+	proto.RegisterType((*DashboardNotificationOptions)(nil), "DashboardNotificationOptions")
+	proto.RegisterType((*GitHubIssueOptions)(nil), "GitHubIssueOptions")
 	proto.RegisterType((*LinkTemplate)(nil), "LinkTemplate")
 	proto.RegisterType((*LinkOptionsTemplate)(nil), "LinkOptionsTemplate")
 	proto.RegisterType((*DashboardTab)(nil), "DashboardTab")
+	proto.RegisterType((*SLOOptions)(nil), "SLOOptions")
+	proto.RegisterType((*IssueTrackerOptions)(nil), "IssueTrackerOptions")
 	proto.RegisterType((*DashboardTabAlertOptions)(nil), "DashboardTabAlertOptions")
 	proto.RegisterType((*DashboardTabFlakinessAlertOptions)(nil), "DashboardTabFlakinessAlertOptions")
 	proto.RegisterType((*DashboardGroup)(nil), "DashboardGroup")
+	proto.RegisterType((*DashboardGroupNotificationOptions)(nil), "DashboardGroupNotificationOptions")
 	proto.RegisterType((*Configuration)(nil), "Configuration")
+	proto.RegisterType((*NotificationChannel)(nil), "NotificationChannel")
+	proto.RegisterType((*SlackChannel)(nil), "SlackChannel")
+	proto.RegisterType((*EmailChannel)(nil), "EmailChannel")
+	proto.RegisterType((*WebhookChannel)(nil), "WebhookChannel")
+	proto.RegisterType((*ConfigurationIndex)(nil), "ConfigurationIndex")
 	proto.RegisterType((*HealthAnalysisOptions)(nil), "HealthAnalysisOptions")
 	proto.RegisterType((*DefaultConfiguration)(nil), "DefaultConfiguration")
 }
@@ -2608,224 +4037,314 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptor_3eaf2c85e69e9ea4) }
 
 var fileDescriptor_3eaf2c85e69e9ea4 = []byte{
-	// 3489 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x5a, 0xdd, 0x73, 0x1b, 0x47,
-	0x72, 0x37, 0xc0, 0x0f, 0x81, 0x4d, 0x00, 0x5c, 0x0e, 0x40, 0x70, 0x45, 0x5a, 0x11, 0x05, 0x9d,
-	0xce, 0xb4, 0x7d, 0xa1, 0x2d, 0xca, 0xbe, 0x58, 0x39, 0x2b, 0x67, 0x90, 0x04, 0x45, 0x5a, 0xfc,
-	0xc0, 0x2d, 0xc0, 0x4b, 0xf9, 0x5e, 0x36, 0x83, 0xdd, 0x01, 0xb0, 0xc7, 0xfd, 0x40, 0x76, 0x66,
-	0x25, 0xf1, 0x2d, 0x8f, 0xf9, 0x1f, 0x92, 0xca, 0x53, 0x2a, 0x6f, 0xf7, 0x6f, 0xe4, 0x35, 0x55,
-	0xa9, 0xca, 0xff, 0x93, 0x9a, 0x9e, 0xd9, 0xc5, 0x2e, 0x01, 0xc9, 0x4e, 0xe5, 0x89, 0xd8, 0xfe,
-	0x9a, 0x99, 0x9e, 0x9e, 0xdf, 0x74, 0xf7, 0x10, 0xaa, 0x4e, 0x14, 0x8e, 0xbc, 0xf1, 0xc1, 0x34,
-	0x8e, 0x44, 0xb4, 0xf3, 0xc5, 0x74, 0xf8, 0x95, 0x93, 0x70, 0x11, 0x05, 0x36, 0x7b, 0x4b, 0xfd,
-	0x84, 0x8a, 0x28, 0x9e, 0x23, 0x28, 0xd9, 0xf6, 0xbf, 0x96, 0xa1, 0x3e, 0x60, 0x5c, 0x5c, 0xd1,
-	0x80, 0x1d, 0xa3, 0x11, 0xf2, 0x03, 0xd4, 0x42, 0x1a, 0x30, 0x9b, 0xf9, 0x2c, 0x60, 0xa1, 0xe0,
-	0x66, 0x69, 0x6f, 0x69, 0x7f, 0xfd, 0x70, 0xf7, 0xa0, 0x28, 0x77, 0x20, 0x7f, 0x76, 0x95, 0x8c,
-	0x55, 0x0d, 0x67, 0x1f, 0x9c, 0x3c, 0x86, 0x75, 0xb4, 0x30, 0x8a, 0xe2, 0x80, 0x0a, 0xb3, 0xbc,
-	0x57, 0xda, 0x5f, 0xb3, 0x40, 0x92, 0x4e, 0x91, 0xb2, 0xf3, 0x1f, 0x25, 0x58, 0xcf, 0xa9, 0x93,
-	0x16, 0xac, 0xfa, 0x74, 0xc8, 0x7c, 0x39, 0x96, 0x94, 0xd5, 0x5f, 0xe4, 0x29, 0xd4, 0x04, 0x8d,
-	0xc7, 0x4c, 0xd8, 0x6a, 0x81, 0xda, 0x54, 0x55, 0x11, 0xf5, 0x7c, 0x9f, 0x40, 0x75, 0x98, 0x78,
-	0xbe, 0x6b, 0x2b, 0xaa, 0xb9, 0xb4, 0x57, 0xda, 0xaf, 0x58, 0xeb, 0x48, 0x1b, 0x20, 0x89, 0x10,
-	0x58, 0x16, 0x74, 0xcc, 0xcd, 0x65, 0x54, 0xc7, 0xdf, 0x68, 0x9b, 0x71, 0x61, 0x4f, 0xe3, 0x68,
-	0xca, 0x62, 0x71, 0x67, 0xae, 0x68, 0xdb, 0x8c, 0x8b, 0x9e, 0xa6, 0xb5, 0xdf, 0x40, 0xf5, 0x2a,
-	0x12, 0xde, 0xc8, 0x73, 0xa8, 0xf0, 0xa2, 0x90, 0x98, 0xf0, 0x80, 0x27, 0x41, 0x40, 0xe3, 0x3b,
-	0x3d, 0xd3, 0xf4, 0x53, 0xce, 0xc2, 0x89, 0x42, 0xc1, 0xde, 0x0b, 0xdb, 0xf7, 0xc2, 0x5b, 0x3d,
-	0xd3, 0x75, 0x4d, 0xbb, 0xf0, 0xc2, 0xdb, 0xf6, 0xbf, 0x3d, 0x86, 0x35, 0xe9, 0xc3, 0xd7, 0x71,
-	0x94, 0x4c, 0xe5, 0x9c, 0xa4, 0x47, 0xb4, 0x1d, 0xfc, 0x4d, 0x1e, 0x01, 0x8c, 0x1d, 0x6e, 0x4f,
-	0x63, 0x36, 0xf2, 0xde, 0x6b, 0x13, 0x6b, 0x63, 0x87, 0xf7, 0x90, 0x40, 0x7e, 0x0d, 0x1b, 0x2e,
-	0xbd, 0xe3, 0x76, 0x34, 0xb2, 0x63, 0xc6, 0x13, 0x5f, 0x70, 0x5c, 0xec, 0x8a, 0x55, 0x93, 0xe4,
-	0xeb, 0x91, 0xa5, 0x88, 0xe4, 0x19, 0xd4, 0xbd, 0x71, 0x18, 0xc5, 0xcc, 0x9e, 0xb2, 0xd0, 0xf5,
-	0xc2, 0x31, 0x2e, 0xbc, 0x62, 0xd5, 0x14, 0xb5, 0xa7, 0x88, 0x72, 0xca, 0x5a, 0x4c, 0xfa, 0x4a,
-	0xa0, 0x03, 0x2a, 0xd6, 0xba, 0xa2, 0x1d, 0x49, 0x12, 0xf9, 0x01, 0x36, 0xa5, 0x3f, 0xb8, 0x8d,
-	0xfb, 0x39, 0x8d, 0x7c, 0xcf, 0xb9, 0x33, 0x57, 0xf7, 0x4a, 0xfb, 0xf5, 0xc3, 0xe6, 0x41, 0xb6,
-	0x16, 0xfc, 0xc5, 0xe5, 0x86, 0x5a, 0x1b, 0x22, 0xfd, 0xd9, 0x43, 0x61, 0xf2, 0x1d, 0xb4, 0xc6,
-	0x54, 0x4c, 0x58, 0x6c, 0xe7, 0xbd, 0xed, 0x31, 0x6e, 0x3e, 0x90, 0xc3, 0x1d, 0x95, 0xcd, 0x92,
-	0xd5, 0x54, 0x12, 0x83, 0x99, 0xe7, 0x3d, 0xc6, 0xc9, 0x21, 0x6c, 0xe9, 0xe9, 0xa1, 0x26, 0x4f,
-	0x86, 0x5c, 0xc4, 0x72, 0x31, 0x95, 0xbd, 0xa5, 0xfd, 0x35, 0xab, 0xa1, 0x98, 0x52, 0xa9, 0x9f,
-	0xb2, 0xc8, 0xf7, 0x50, 0x73, 0x22, 0x3f, 0x09, 0x42, 0x7b, 0xc2, 0xa8, 0xcb, 0x62, 0x73, 0x0d,
-	0x63, 0x77, 0x3b, 0x37, 0xd7, 0x63, 0xe4, 0x9f, 0x21, 0xdb, 0xaa, 0x3a, 0xb9, 0x2f, 0x72, 0x06,
-	0x9b, 0x23, 0xea, 0xfb, 0x43, 0xea, 0xdc, 0xda, 0x63, 0x29, 0x2c, 0x47, 0x03, 0x5c, 0xed, 0x6e,
-	0xce, 0xc2, 0xa9, 0x96, 0x79, 0xad, 0x45, 0x2c, 0x63, 0x74, 0x8f, 0x42, 0x5e, 0xc1, 0x43, 0xea,
-	0xb3, 0x58, 0xd8, 0x5c, 0x50, 0x9f, 0xa5, 0xbb, 0x65, 0x4f, 0xa2, 0x24, 0xe6, 0xe6, 0xba, 0xdc,
-	0x33, 0x5c, 0x78, 0x0b, 0x85, 0xfa, 0x52, 0x46, 0xef, 0xdd, 0x99, 0x94, 0x20, 0xdf, 0xc2, 0x56,
-	0x98, 0x04, 0xf6, 0x88, 0x7a, 0x7e, 0x12, 0x33, 0x6e, 0x8b, 0xc8, 0x46, 0x49, 0xb3, 0x9a, 0xa9,
-	0x92, 0x30, 0x09, 0x4e, 0x35, 0x7f, 0x10, 0x75, 0x24, 0x57, 0x86, 0xf4, 0x30, 0x19, 0xdb, 0x4e,
-	0x14, 0x4c, 0xa3, 0x90, 0x85, 0xc2, 0xac, 0x61, 0x74, 0x54, 0x87, 0xc9, 0xf8, 0x38, 0xa5, 0x91,
-	0x7d, 0x30, 0x9c, 0xc8, 0x65, 0x36, 0x67, 0x34, 0x76, 0x26, 0xf6, 0x94, 0x8a, 0x89, 0x59, 0xc7,
-	0x48, 0xab, 0x4b, 0x7a, 0x1f, 0xc9, 0x3d, 0x2a, 0x26, 0xe4, 0x37, 0x20, 0x07, 0xb1, 0x95, 0x8b,
-	0xb8, 0x1d, 0x33, 0x47, 0xda, 0xdc, 0x40, 0x9b, 0x46, 0x98, 0x04, 0xca, 0x93, 0xdc, 0x42, 0x3a,
-	0xf9, 0x02, 0x36, 0x13, 0xae, 0xf7, 0x2a, 0x60, 0x82, 0xba, 0x54, 0x50, 0xd3, 0xc0, 0x90, 0xda,
-	0x48, 0x38, 0xee, 0xd3, 0xa5, 0x26, 0x93, 0x97, 0xb0, 0xad, 0xdc, 0x13, 0x50, 0xcf, 0xc7, 0xd5,
-	0xb9, 0x6e, 0xcc, 0x38, 0x67, 0xdc, 0xdc, 0x94, 0x53, 0x51, 0x51, 0x81, 0x22, 0x97, 0xd4, 0xf3,
-	0x07, 0x51, 0x27, 0xe5, 0x93, 0xaf, 0x81, 0xe4, 0x54, 0x79, 0x32, 0xfc, 0x33, 0x73, 0x84, 0x49,
-	0x32, 0x2d, 0x23, 0xd3, 0xea, 0x2b, 0x1e, 0xf9, 0x3d, 0xec, 0xe4, 0x34, 0xb4, 0x4f, 0xed, 0x80,
-	0x71, 0x4e, 0xc7, 0xcc, 0x6c, 0x64, 0x9a, 0xdb, 0x99, 0xa6, 0xf6, 0xeb, 0xa5, 0x12, 0x21, 0x2f,
-	0xa0, 0x99, 0x33, 0xe0, 0x32, 0xe9, 0xe3, 0x24, 0xf6, 0xcd, 0x66, 0xa6, 0xba, 0x99, 0xa9, 0x9e,
-	0x48, 0xee, 0x4d, 0xec, 0x93, 0x0b, 0x78, 0x12, 0x78, 0xa1, 0xcd, 0x7c, 0x3a, 0xe5, 0xcc, 0xb5,
-	0x03, 0x2f, 0x4c, 0x04, 0xe3, 0xf6, 0x90, 0x89, 0x77, 0x8c, 0x85, 0x68, 0x8a, 0x9b, 0x5b, 0xd9,
-	0x76, 0x3e, 0x0a, 0xbc, 0xb0, 0xab, 0x64, 0x2f, 0x95, 0xe8, 0x91, 0x92, 0x94, 0x46, 0x39, 0xf9,
-	0x09, 0xf6, 0xa5, 0x73, 0x15, 0x0a, 0x26, 0x31, 0x82, 0x91, 0x2d, 0xa1, 0x9c, 0x71, 0x9b, 0x72,
-	0x15, 0x1c, 0xf6, 0x94, 0xc6, 0x34, 0xe0, 0x66, 0x2b, 0x3b, 0x57, 0x4f, 0x13, 0xae, 0xc1, 0x5a,
-	0xab, 0xfc, 0x11, 0x35, 0x3a, 0x1c, 0xc3, 0xa5, 0x87, 0xe2, 0xe4, 0x00, 0x1a, 0x2c, 0xa4, 0x43,
-	0x9f, 0xd9, 0x23, 0x9f, 0xde, 0xde, 0xc9, 0x88, 0x15, 0x09, 0x37, 0xb7, 0x71, 0xe7, 0x36, 0x15,
-	0xeb, 0x54, 0x72, 0xfa, 0xc8, 0x90, 0xc7, 0x52, 0x4e, 0xe5, 0x36, 0x19, 0xb2, 0x38, 0x64, 0x72,
-	0x4d, 0x8e, 0xef, 0xc9, 0xc0, 0x30, 0x51, 0xa3, 0x91, 0x70, 0xf6, 0x26, 0xe3, 0x1d, 0x23, 0x4b,
-	0x5e, 0x08, 0x1e, 0xb7, 0xd9, 0x7b, 0xc1, 0xe2, 0x90, 0xfa, 0xe6, 0x43, 0x94, 0x04, 0x8f, 0x77,
-	0x35, 0x85, 0xbc, 0x04, 0x03, 0x03, 0x07, 0x61, 0x46, 0x63, 0xfd, 0xce, 0x5e, 0x69, 0x7f, 0xfd,
-	0x70, 0xe3, 0xde, 0xb5, 0x63, 0xd5, 0x45, 0xf1, 0xba, 0x7a, 0x01, 0xb5, 0x30, 0x07, 0xd1, 0xdc,
-	0xdc, 0xc5, 0x23, 0x5f, 0x3b, 0xc8, 0x03, 0xb7, 0x55, 0x94, 0x21, 0xaf, 0xa0, 0xae, 0x71, 0x82,
-	0x47, 0xb1, 0xb0, 0x87, 0x77, 0xe6, 0xa7, 0x78, 0xcc, 0xe7, 0x81, 0xa2, 0x1f, 0xc5, 0xe2, 0xe8,
-	0x2e, 0x05, 0x0a, 0xf5, 0x45, 0xba, 0x60, 0x4c, 0x63, 0x4f, 0xe2, 0xfe, 0x0c, 0x27, 0x1e, 0xa1,
-	0x81, 0x9d, 0x9c, 0x81, 0x9e, 0x12, 0xc9, 0x60, 0x62, 0x63, 0x5a, 0x24, 0xe4, 0x5c, 0x9f, 0x9e,
-	0x9a, 0x49, 0xe4, 0x72, 0xf3, 0xaf, 0xf2, 0xae, 0xd7, 0xe7, 0x46, 0x32, 0xc8, 0x89, 0xf6, 0x12,
-	0x0d, 0xc3, 0x48, 0xe8, 0xd5, 0x3e, 0xc6, 0xd5, 0x3e, 0xbc, 0x07, 0xc6, 0x9d, 0x4c, 0x42, 0x21,
-	0xf2, 0xec, 0x9b, 0x93, 0xef, 0xe0, 0x61, 0x40, 0xdf, 0x17, 0x86, 0xb4, 0xa7, 0x1a, 0x9f, 0xcd,
-	0x3d, 0x3c, 0xdd, 0x5b, 0x01, 0x7d, 0x9f, 0x1b, 0xb8, 0xa7, 0xb0, 0x99, 0x74, 0xe0, 0x91, 0x13,
-	0x05, 0x81, 0x27, 0xec, 0xe8, 0x2d, 0x8b, 0x63, 0xcf, 0x65, 0x36, 0x5e, 0xd4, 0x12, 0x44, 0xe4,
-	0x46, 0x9a, 0x4f, 0x10, 0x47, 0x76, 0x94, 0xd0, 0xb5, 0x96, 0xb9, 0x90, 0x22, 0x3d, 0x25, 0x41,
-	0xce, 0x60, 0xab, 0x80, 0x10, 0x76, 0x34, 0x55, 0xeb, 0x68, 0xe3, 0x3a, 0xd4, 0xa5, 0x92, 0xe2,
-	0xc4, 0xb5, 0xe2, 0x59, 0x0d, 0x31, 0x4f, 0x94, 0x38, 0x86, 0x96, 0x04, 0x1d, 0x67, 0xe3, 0x3f,
-	0x55, 0x38, 0x26, 0xe9, 0x03, 0x3a, 0x4e, 0xc7, 0x7c, 0x09, 0x06, 0x4d, 0x44, 0x64, 0xcb, 0x73,
-	0x9b, 0x0e, 0xf7, 0x2b, 0x1d, 0x5c, 0x9d, 0x44, 0x44, 0x47, 0xc9, 0x38, 0x1d, 0xa9, 0x4e, 0x0b,
-	0xdf, 0xe4, 0x05, 0xb4, 0x32, 0x5f, 0xc5, 0x49, 0x28, 0xbc, 0x80, 0x69, 0x10, 0x7f, 0x86, 0x8e,
-	0x6a, 0x68, 0x47, 0x59, 0x8a, 0xa7, 0xd0, 0xfb, 0x7b, 0xd8, 0x95, 0xb8, 0x39, 0xa5, 0x12, 0xb0,
-	0x24, 0xba, 0xb9, 0x1e, 0xc7, 0x5d, 0x56, 0x18, 0xfe, 0x6b, 0xd4, 0xdc, 0x0e, 0x93, 0xa0, 0x87,
-	0x12, 0x83, 0xe8, 0x44, 0xf1, 0x15, 0x88, 0x7f, 0x09, 0x44, 0x26, 0x10, 0x72, 0xb6, 0xdc, 0x1e,
-	0xea, 0x00, 0x33, 0x3f, 0x53, 0x40, 0x2a, 0x39, 0x47, 0xc9, 0x98, 0x1f, 0xa9, 0x20, 0x22, 0xe7,
-	0xd0, 0x64, 0xe1, 0x5b, 0x2f, 0x8e, 0x42, 0x99, 0x47, 0xd9, 0x5e, 0xc8, 0x05, 0x0d, 0x1d, 0x66,
-	0xee, 0x63, 0x30, 0xb6, 0x72, 0x51, 0xd1, 0x9d, 0x89, 0x59, 0x8d, 0x9c, 0xce, 0xb9, 0x56, 0x21,
-	0xe7, 0xd0, 0xca, 0x85, 0x44, 0xfe, 0xa2, 0xfe, 0x1c, 0xb7, 0xa6, 0x91, 0x33, 0xf6, 0x86, 0xdd,
-	0x21, 0x94, 0x58, 0x4d, 0x91, 0x45, 0x49, 0xee, 0xe6, 0x7e, 0x0c, 0xeb, 0xfa, 0xce, 0x97, 0x8b,
-	0x30, 0xbf, 0x50, 0xc7, 0x5d, 0x91, 0xe4, 0xec, 0xe5, 0x5d, 0xc1, 0x27, 0xf2, 0xe0, 0x61, 0xbe,
-	0x14, 0x30, 0x11, 0x7b, 0x8e, 0xf9, 0x25, 0x6e, 0xde, 0x06, 0x32, 0x06, 0xec, 0xbd, 0x34, 0x1b,
-	0x7b, 0x0e, 0xb9, 0x84, 0xa7, 0xf7, 0x83, 0x6e, 0x01, 0x0c, 0x9a, 0xbf, 0x41, 0xed, 0xbd, 0x62,
-	0xe8, 0xcd, 0x83, 0x9f, 0x8c, 0xfe, 0x82, 0x7b, 0x0b, 0x27, 0xef, 0xaf, 0x71, 0xa6, 0x5b, 0x33,
-	0x2f, 0xe7, 0x4f, 0xdf, 0xb7, 0xb0, 0x9d, 0x77, 0x50, 0x40, 0x85, 0x33, 0xb1, 0x63, 0x36, 0x66,
-	0xef, 0xcd, 0x03, 0x1c, 0x3c, 0xe7, 0x8c, 0x4b, 0xc9, 0xb4, 0x24, 0x8f, 0x3c, 0x57, 0x78, 0x39,
-	0x4a, 0x7c, 0x3f, 0x55, 0x95, 0x28, 0xc7, 0xcd, 0xaf, 0x70, 0x30, 0x92, 0x70, 0x76, 0x9a, 0xf8,
-	0xbe, 0xd2, 0x93, 0xb8, 0xc6, 0x49, 0x17, 0x1e, 0xe9, 0x74, 0x5d, 0x25, 0x0e, 0xb3, 0xac, 0xdd,
-	0x8e, 0x13, 0x9f, 0x71, 0xf3, 0x6b, 0x99, 0x01, 0x21, 0xc4, 0xef, 0x28, 0x41, 0x95, 0x3d, 0x74,
-	0x53, 0x31, 0x4b, 0x4a, 0x91, 0x3f, 0xc0, 0xb3, 0xb9, 0x74, 0x66, 0xa1, 0xef, 0x9e, 0xe3, 0xf4,
-	0xdb, 0xf7, 0xb3, 0x98, 0x05, 0xde, 0xfb, 0x1e, 0x6a, 0x7a, 0x4a, 0x3c, 0x4a, 0x62, 0x87, 0x99,
-	0x87, 0x78, 0x8e, 0xf2, 0xb0, 0xa9, 0xa6, 0xd2, 0x47, 0xb6, 0x55, 0x8d, 0x73, 0x5f, 0xe4, 0x18,
-	0x1e, 0xde, 0x2f, 0x43, 0x70, 0x41, 0x36, 0x67, 0xc2, 0x7c, 0x81, 0x96, 0x2a, 0x07, 0x72, 0xee,
-	0x7d, 0x26, 0xac, 0x96, 0x12, 0x2d, 0xac, 0xa9, 0xcf, 0x84, 0xdc, 0x86, 0x98, 0x51, 0x17, 0xef,
-	0x29, 0x66, 0x8f, 0xe2, 0x28, 0xb0, 0xb9, 0x88, 0x62, 0x79, 0x97, 0x7f, 0x83, 0x1e, 0x6d, 0x4a,
-	0xb6, 0xbc, 0xac, 0xd8, 0x69, 0x1c, 0x05, 0x7d, 0xc5, 0x93, 0xc9, 0x8c, 0xce, 0x26, 0x23, 0xdf,
-	0xcd, 0xd2, 0xe7, 0x6f, 0x51, 0xc3, 0x50, 0x9c, 0x6b, 0xdf, 0x4d, 0x33, 0x68, 0x79, 0x61, 0x29,
-	0x69, 0x7e, 0xeb, 0x4d, 0xcd, 0xdf, 0xea, 0x0b, 0x0b, 0x49, 0xfd, 0x5b, 0x6f, 0x4a, 0xbe, 0x03,
-	0xf3, 0x7e, 0x54, 0x72, 0x11, 0x8f, 0x24, 0x08, 0x98, 0x7f, 0x83, 0xee, 0x6c, 0x15, 0x43, 0xb1,
-	0xaf, 0xb9, 0x32, 0x49, 0x4b, 0x38, 0x8b, 0x67, 0x75, 0xc7, 0x77, 0xaa, 0xee, 0x90, 0xc4, 0xb4,
-	0xee, 0xd8, 0xf9, 0x47, 0xa8, 0xe6, 0xf3, 0x54, 0xd2, 0x84, 0x15, 0x44, 0x5a, 0x5d, 0x2d, 0xa8,
-	0x0f, 0xb2, 0x03, 0x95, 0xcc, 0x8a, 0x2a, 0x16, 0xb2, 0x6f, 0xf2, 0x15, 0x34, 0x16, 0x6d, 0xf5,
-	0x12, 0x8a, 0x11, 0x67, 0x6e, 0x6b, 0x77, 0xb8, 0x2a, 0x04, 0x67, 0x37, 0x85, 0xac, 0x46, 0x66,
-	0xa7, 0x54, 0x8f, 0xbc, 0x96, 0x1d, 0x4f, 0xf2, 0x0c, 0x6a, 0xe9, 0x68, 0x18, 0xd1, 0x6a, 0x0a,
-	0x67, 0x9f, 0x58, 0xd5, 0x94, 0x2c, 0xa3, 0xf9, 0x68, 0x17, 0x1e, 0x16, 0xce, 0x3a, 0xe6, 0x54,
-	0x3a, 0x7c, 0x76, 0x0e, 0xa1, 0x92, 0x62, 0x09, 0x31, 0x60, 0xe9, 0x96, 0xa5, 0x75, 0x95, 0xfc,
-	0x29, 0x57, 0xad, 0x66, 0xad, 0x16, 0xa7, 0x3e, 0x76, 0x18, 0x54, 0xf3, 0x31, 0x46, 0x9e, 0x43,
-	0xf5, 0xcf, 0x49, 0xe8, 0x15, 0x6a, 0xc4, 0xf5, 0xc3, 0xea, 0xc1, 0x8f, 0x37, 0xa1, 0xa7, 0x6b,
-	0xc4, 0xb3, 0x4f, 0xac, 0x75, 0x94, 0x51, 0x9f, 0x47, 0x2d, 0x68, 0x16, 0xc2, 0x58, 0xab, 0xfe,
-	0xb8, 0x5c, 0x29, 0x19, 0xe5, 0x1f, 0x97, 0x2b, 0x4b, 0xc6, 0x72, 0x3b, 0x50, 0xc5, 0x1a, 0xd6,
-	0x32, 0x64, 0x07, 0x5a, 0x83, 0x6e, 0x7f, 0xd0, 0xb7, 0xaf, 0x3a, 0x97, 0x5d, 0xfb, 0xe6, 0xaa,
-	0xdf, 0xeb, 0x1e, 0x9f, 0x9f, 0x9e, 0x77, 0x4f, 0x8c, 0x4f, 0xc8, 0x16, 0x6c, 0xe6, 0x78, 0xe7,
-	0xaf, 0xaf, 0xae, 0xad, 0xae, 0x51, 0x22, 0x2d, 0x20, 0x39, 0xb2, 0xd5, 0xed, 0x5d, 0x74, 0x8e,
-	0xbb, 0x46, 0xf9, 0x9e, 0x78, 0xa7, 0xd7, 0xeb, 0x5e, 0x9d, 0x18, 0x4b, 0xed, 0xff, 0x2a, 0x81,
-	0x71, 0xbf, 0xb0, 0x90, 0xc3, 0x9e, 0x76, 0x2e, 0x2e, 0x8e, 0x3a, 0xc7, 0x6f, 0xec, 0xd7, 0xd6,
-	0xf5, 0x4d, 0xef, 0xfc, 0xea, 0xb5, 0x7d, 0x75, 0x7d, 0xd5, 0x35, 0x3e, 0x59, 0xcc, 0x3b, 0xe9,
-	0x0c, 0xe4, 0xd8, 0x9f, 0x82, 0x39, 0xcf, 0xbb, 0xe8, 0x1c, 0x75, 0x2f, 0xfa, 0x46, 0x99, 0x98,
-	0xd0, 0x9c, 0xe7, 0x9e, 0x9f, 0x18, 0x4b, 0x64, 0x0f, 0x3e, 0x9d, 0xe7, 0x1c, 0x5f, 0x5f, 0x5e,
-	0x9e, 0x0f, 0xec, 0xab, 0x9b, 0x4b, 0x63, 0x99, 0x7c, 0x0e, 0xcf, 0x16, 0x49, 0x5c, 0x9d, 0x9e,
-	0xbf, 0xbe, 0xb1, 0x3a, 0x83, 0xf3, 0xeb, 0x2b, 0xfb, 0x8f, 0x9d, 0x8b, 0x9b, 0xae, 0xb1, 0xd2,
-	0xfe, 0x21, 0x8d, 0x61, 0x9d, 0x34, 0x35, 0xc1, 0x38, 0xbe, 0xbe, 0xb8, 0xb9, 0xbc, 0xb2, 0xfb,
-	0xd7, 0xd6, 0x40, 0x4d, 0x15, 0x97, 0x91, 0xa7, 0xe6, 0x06, 0x2b, 0xb5, 0x2f, 0x61, 0xe3, 0x5e,
-	0x0e, 0x45, 0x1e, 0xc2, 0x56, 0xcf, 0x3a, 0xbf, 0xec, 0x58, 0x3f, 0xcd, 0x39, 0xe4, 0x31, 0xec,
-	0xce, 0xb1, 0x0a, 0xe6, 0x1e, 0xc3, 0x7a, 0xee, 0x16, 0x24, 0x15, 0x58, 0xee, 0x59, 0xd7, 0x72,
-	0x07, 0x57, 0xa1, 0xfc, 0x87, 0x8e, 0x51, 0x6a, 0xd7, 0x60, 0x3d, 0x17, 0x34, 0xed, 0xbf, 0x94,
-	0xa0, 0xb1, 0x20, 0x1d, 0x91, 0x65, 0xf8, 0x2c, 0x59, 0x55, 0x17, 0x80, 0x0a, 0xda, 0x5a, 0x9a,
-	0x9a, 0x2a, 0xe4, 0x9f, 0x2b, 0xc7, 0xca, 0x0b, 0xca, 0xb1, 0x26, 0xac, 0x44, 0xef, 0x42, 0x16,
-	0xeb, 0x93, 0xa9, 0x3e, 0x48, 0x1d, 0xca, 0x8e, 0x63, 0x2e, 0x63, 0xa1, 0x5b, 0x76, 0x1c, 0x69,
-	0x2a, 0x3d, 0x39, 0x6a, 0x40, 0xdd, 0xac, 0xd0, 0x44, 0x1c, 0xaf, 0xfd, 0x4f, 0xab, 0x50, 0x2f,
-	0xe6, 0x33, 0xe4, 0x1b, 0x68, 0x0d, 0x99, 0xa0, 0xb6, 0x4c, 0x6b, 0x8a, 0x73, 0x01, 0x9c, 0x4b,
-	0x53, 0x72, 0x3b, 0x8a, 0x39, 0x9b, 0xd3, 0x23, 0x00, 0x4c, 0x98, 0x1c, 0x3f, 0xe2, 0xaa, 0x41,
-	0x51, 0xb1, 0xd6, 0x24, 0xe5, 0x58, 0x12, 0x24, 0x38, 0x4e, 0x22, 0xe1, 0x7b, 0x5c, 0xd8, 0x9e,
-	0xcb, 0xcd, 0xf2, 0xde, 0xd2, 0xfe, 0x92, 0x05, 0x9a, 0x74, 0xee, 0xca, 0x51, 0x2b, 0xd3, 0xd8,
-	0x8b, 0x62, 0x4f, 0xdc, 0xe1, 0xb2, 0xea, 0x87, 0xe6, 0xbd, 0x44, 0x4b, 0xe6, 0xc6, 0xc8, 0xb7,
-	0x32, 0x49, 0xf2, 0x06, 0xb6, 0x73, 0x66, 0x35, 0xb2, 0xab, 0x5b, 0x66, 0x59, 0x27, 0x87, 0x67,
-	0xe9, 0x18, 0x88, 0xec, 0xea, 0x8a, 0x69, 0xce, 0x06, 0x9e, 0x51, 0xc9, 0x67, 0xb0, 0x31, 0xf2,
-	0x7c, 0x66, 0x7b, 0xa1, 0xeb, 0xbd, 0xf5, 0xdc, 0x84, 0xfa, 0xba, 0xbd, 0x51, 0x97, 0xe4, 0xf3,
-	0x8c, 0x4a, 0xbe, 0x84, 0x4d, 0xee, 0x85, 0x63, 0x9f, 0x89, 0x28, 0x4c, 0xdd, 0x84, 0x1d, 0x8e,
-	0x8a, 0x65, 0x64, 0x0c, 0xed, 0x21, 0xf2, 0x0a, 0x76, 0x65, 0x3a, 0x48, 0x7d, 0x3f, 0x7a, 0xc7,
-	0xdc, 0x9c, 0x71, 0x95, 0xe8, 0x3c, 0x40, 0x9f, 0x9a, 0x01, 0x7d, 0xdf, 0x51, 0x12, 0xb3, 0x71,
-	0x30, 0xed, 0x79, 0x02, 0x55, 0x9c, 0x94, 0xbc, 0x32, 0xa8, 0xef, 0x9b, 0x15, 0xd5, 0x70, 0x91,
-	0xb4, 0x6b, 0x45, 0x22, 0x7f, 0x0f, 0x5b, 0x2e, 0x1b, 0x51, 0x09, 0x4d, 0xc5, 0x4a, 0x7a, 0x0d,
-	0x51, 0xed, 0xe9, 0x7d, 0x3f, 0x9e, 0x28, 0xe1, 0x7c, 0x98, 0x5a, 0x0d, 0x77, 0x9e, 0x28, 0x23,
-	0x81, 0xba, 0x6f, 0x65, 0xa6, 0xe7, 0xde, 0xb3, 0xbc, 0xae, 0x6e, 0xcd, 0x94, 0x9b, 0xd7, 0xda,
-	0xf9, 0x07, 0x68, 0x2c, 0x18, 0x61, 0x3e, 0xb2, 0x4b, 0x1f, 0x8b, 0xec, 0xf2, 0x7c, 0x64, 0xab,
-	0x60, 0x2f, 0x3b, 0x4e, 0xfb, 0x02, 0x2a, 0x69, 0x2c, 0x48, 0x60, 0xea, 0x59, 0xe7, 0xd7, 0xd6,
-	0xf9, 0xe0, 0xa7, 0x7b, 0x18, 0xbb, 0x0a, 0xe5, 0xde, 0xd7, 0x46, 0x09, 0xff, 0x3e, 0x37, 0xca,
-	0xf8, 0xf7, 0xd0, 0x58, 0xc2, 0xbf, 0x2f, 0x8c, 0x65, 0xfc, 0xfb, 0x8d, 0xb1, 0xd2, 0xfe, 0x13,
-	0x34, 0x16, 0xc4, 0x08, 0x69, 0xa5, 0x17, 0x89, 0x9c, 0xe7, 0xd2, 0xd9, 0x27, 0xfa, 0x2a, 0x91,
-	0x74, 0x75, 0xad, 0xa6, 0x57, 0x97, 0xfa, 0x3c, 0x6a, 0xc0, 0xe6, 0x2c, 0x14, 0x75, 0x10, 0xb6,
-	0xff, 0xb3, 0x0c, 0x6b, 0x27, 0x94, 0x4f, 0x86, 0x11, 0x8d, 0x5d, 0x72, 0x08, 0x35, 0x37, 0xfd,
-	0xb0, 0x05, 0x1d, 0xea, 0x2e, 0x69, 0xed, 0x20, 0x13, 0x19, 0xd0, 0xa1, 0x55, 0x75, 0x73, 0x5f,
-	0x59, 0xcb, 0xaf, 0x9c, 0x6b, 0xf9, 0xcd, 0x95, 0xaf, 0x4b, 0xbf, 0xa0, 0x7c, 0x7d, 0x0c, 0xeb,
-	0x59, 0x94, 0xd0, 0xa1, 0x06, 0x03, 0x48, 0xb7, 0x9d, 0x0e, 0x65, 0x91, 0xee, 0x46, 0xef, 0xc2,
-	0xa9, 0x4f, 0xef, 0xb0, 0xe3, 0x21, 0x33, 0x3f, 0x41, 0x87, 0x5c, 0x87, 0x5c, 0x23, 0x65, 0x9e,
-	0x2a, 0xde, 0x80, 0x0e, 0x65, 0x5d, 0xd8, 0x9a, 0x78, 0xe3, 0x89, 0xef, 0x8d, 0x27, 0xa2, 0xa8,
-	0xb4, 0x3a, 0xeb, 0xd4, 0x65, 0x12, 0x79, 0xcd, 0xcf, 0x60, 0x63, 0xa6, 0x29, 0x22, 0x97, 0xde,
-	0xa9, 0xe6, 0x9e, 0x55, 0xcf, 0xc8, 0x03, 0x49, 0xfd, 0x71, 0xb9, 0xb2, 0x6c, 0xac, 0xb4, 0x5d,
-	0xa8, 0x5e, 0x78, 0xe1, 0xed, 0x80, 0x05, 0x53, 0x9f, 0x0a, 0xbc, 0xf8, 0x93, 0x38, 0x4d, 0x6d,
-	0xe4, 0x4f, 0x72, 0x00, 0x0f, 0xd2, 0x42, 0xad, 0xac, 0x8f, 0xbe, 0xd4, 0xd0, 0x41, 0x9f, 0x2a,
-	0x5a, 0xa9, 0x50, 0xe6, 0xd8, 0xa5, 0x99, 0x63, 0xdb, 0xaf, 0xa0, 0xb1, 0x40, 0xe7, 0x97, 0x66,
-	0x19, 0xed, 0x7f, 0x06, 0xa8, 0x9e, 0x2c, 0xda, 0xbc, 0x7c, 0xbf, 0x36, 0xbd, 0x09, 0x30, 0xbb,
-	0xce, 0x25, 0x41, 0xea, 0x26, 0xc0, 0x4b, 0x0b, 0xd3, 0x87, 0xb9, 0xf3, 0xb2, 0xf4, 0x0b, 0x1b,
-	0x73, 0xcb, 0xff, 0x87, 0xc6, 0xdc, 0xca, 0x07, 0x1a, 0x73, 0x4f, 0xa0, 0x3a, 0xa4, 0x9c, 0x65,
-	0xa5, 0xef, 0xaa, 0xea, 0x4c, 0x4b, 0x5a, 0x7a, 0x4d, 0xfc, 0x0e, 0x48, 0x34, 0x65, 0xa1, 0x02,
-	0x06, 0xa1, 0x5d, 0x85, 0x7b, 0x28, 0x23, 0x31, 0xbf, 0x59, 0x96, 0x21, 0x05, 0x25, 0x18, 0x64,
-	0x1e, 0x7d, 0x09, 0x9b, 0x88, 0x6a, 0x72, 0x85, 0x99, 0x6e, 0x65, 0x91, 0x2e, 0x42, 0xf2, 0x51,
-	0x32, 0xce, 0x54, 0x5f, 0x41, 0x83, 0x0a, 0x41, 0x9d, 0x49, 0x51, 0x79, 0x6d, 0x91, 0xf2, 0xa6,
-	0x92, 0xcc, 0xab, 0x3f, 0x81, 0x6a, 0xda, 0x59, 0xc5, 0x14, 0x15, 0xd4, 0xca, 0x34, 0x0d, 0x93,
-	0xd4, 0xdf, 0xa7, 0x99, 0x1e, 0xb7, 0x93, 0xd8, 0x9f, 0x0d, 0xb1, 0xbe, 0x68, 0x08, 0xa2, 0x45,
-	0x6f, 0x62, 0x3f, 0x1b, 0xe3, 0x54, 0x26, 0xfa, 0xb3, 0x5d, 0x29, 0x18, 0xa9, 0x2e, 0x32, 0xb2,
-	0x35, 0xdb, 0xac, 0xbc, 0x9d, 0x3d, 0x79, 0x64, 0xb9, 0x13, 0x7b, 0xe8, 0x72, 0xec, 0xcc, 0xae,
-	0x59, 0x79, 0x12, 0x39, 0x80, 0x86, 0xa0, 0xc3, 0xc4, 0xa7, 0xb1, 0x2a, 0x10, 0xf5, 0x4d, 0xaf,
-	0x7a, 0xb3, 0x9b, 0x9a, 0x85, 0x05, 0xa2, 0x4a, 0x2f, 0xfe, 0x0e, 0x6a, 0xaa, 0xef, 0x97, 0x6e,
-	0xec, 0x06, 0x4e, 0xe7, 0x61, 0x01, 0x81, 0xb0, 0xa7, 0x90, 0x76, 0x37, 0xaa, 0x34, 0xf7, 0x45,
-	0xfe, 0x04, 0xdb, 0x23, 0x9f, 0xde, 0x7a, 0x21, 0xe3, 0x69, 0x07, 0x31, 0xb5, 0x64, 0xa2, 0xa5,
-	0x76, 0xc1, 0xd2, 0x69, 0x2a, 0x5b, 0x30, 0xb9, 0x35, 0x5a, 0x44, 0x96, 0x6b, 0xa1, 0xc3, 0x28,
-	0x11, 0xf6, 0x0c, 0x23, 0xe5, 0x11, 0x37, 0xd4, 0x5a, 0x90, 0x95, 0xd9, 0xbe, 0x89, 0x7d, 0x19,
-	0x43, 0x18, 0x80, 0x85, 0x30, 0xd8, 0x5c, 0x18, 0x43, 0x52, 0x2e, 0x1f, 0x04, 0xbf, 0x02, 0x6c,
-	0xda, 0xd8, 0x69, 0x0c, 0x72, 0x6c, 0x06, 0x57, 0xac, 0xaa, 0xa4, 0x9e, 0xaa, 0x80, 0xe3, 0xf2,
-	0xc8, 0xb8, 0x1e, 0x47, 0x3c, 0xf4, 0x23, 0x87, 0xfa, 0x36, 0x56, 0x6a, 0x0d, 0x75, 0xcf, 0x6b,
-	0xce, 0x85, 0x64, 0x0c, 0x64, 0x8d, 0xd6, 0x81, 0xad, 0xf4, 0x31, 0x27, 0x60, 0x61, 0x32, 0x9b,
-	0x52, 0x73, 0xd1, 0x94, 0x1a, 0x5a, 0xf6, 0x92, 0x85, 0x49, 0x36, 0xad, 0xdf, 0xc2, 0xf6, 0x30,
-	0x8e, 0x6e, 0x59, 0xa8, 0x8f, 0xa9, 0x2d, 0x26, 0x31, 0xe3, 0x93, 0xc8, 0x77, 0xb1, 0xeb, 0x5b,
-	0xb6, 0xb6, 0x14, 0x5b, 0x9d, 0xd5, 0x41, 0xca, 0x24, 0x1d, 0x68, 0x16, 0x32, 0xb6, 0x74, 0x4b,
-	0x5a, 0x8b, 0x1b, 0x56, 0x24, 0x97, 0xc0, 0xa5, 0xce, 0xbf, 0x82, 0xed, 0x09, 0xa3, 0xbe, 0x98,
-	0xd8, 0x34, 0xa4, 0xfe, 0x1d, 0xf7, 0x78, 0x66, 0x65, 0x1b, 0xad, 0xb4, 0x0e, 0xce, 0x90, 0xdf,
-	0xd1, 0xec, 0x6c, 0x33, 0x27, 0x8b, 0xc8, 0xed, 0xff, 0x59, 0x02, 0xf3, 0x43, 0x31, 0x45, 0x5e,
-	0x7e, 0xec, 0xa5, 0x43, 0xa5, 0x05, 0x1f, 0x7a, 0xe5, 0x78, 0xfe, 0xa1, 0x57, 0x0e, 0x95, 0x27,
-	0x2f, 0x7a, 0xe1, 0xf8, 0xf6, 0xc3, 0x0f, 0x07, 0x0a, 0xfb, 0x17, 0x3f, 0x1a, 0xfc, 0x4c, 0x47,
-	0x6e, 0xf9, 0xe3, 0x1d, 0x39, 0x7c, 0xf4, 0x53, 0xef, 0x0c, 0x2b, 0xe9, 0xa3, 0x9f, 0x7a, 0x5a,
-	0xd8, 0x85, 0xb5, 0xd9, 0x73, 0x80, 0xc2, 0xd5, 0x8a, 0x9b, 0xbe, 0x00, 0x3c, 0x85, 0x9a, 0x62,
-	0xa6, 0x4f, 0x0d, 0x0f, 0x54, 0xce, 0x8e, 0xc4, 0xf4, 0x6d, 0xe1, 0x15, 0xec, 0xbe, 0xa3, 0x9e,
-	0x98, 0x7b, 0x1f, 0x60, 0xea, 0x81, 0xa0, 0xa2, 0x32, 0x4a, 0x29, 0x52, 0x7c, 0x16, 0xe8, 0x22,
-	0x9f, 0xfc, 0xee, 0xa3, 0x6f, 0x1b, 0x6b, 0x38, 0xe0, 0x87, 0xde, 0x35, 0xda, 0x7f, 0x29, 0xc3,
-	0x93, 0x9f, 0x3d, 0xe1, 0x72, 0x88, 0xc0, 0x0b, 0xbd, 0x40, 0xee, 0x54, 0x06, 0x17, 0xd9, 0x56,
-	0x95, 0x30, 0x96, 0xb7, 0xb5, 0x44, 0x66, 0xe1, 0x17, 0xec, 0x57, 0xf9, 0x23, 0xfb, 0x95, 0xf3,
-	0xf8, 0x52, 0xd1, 0xe3, 0x3f, 0xe3, 0xaf, 0xe5, 0xff, 0x97, 0xbf, 0x56, 0x3e, 0xee, 0xaf, 0x4b,
-	0xa8, 0x67, 0xee, 0xfa, 0xf0, 0x1b, 0xee, 0x67, 0xb0, 0x31, 0x03, 0x3d, 0xd5, 0xe9, 0x2b, 0x63,
-	0x1d, 0x57, 0xcf, 0xc8, 0x08, 0xe2, 0xed, 0x7f, 0x2f, 0x41, 0xad, 0xd0, 0x62, 0x23, 0x5f, 0xc2,
-	0xfa, 0x2c, 0x9d, 0x48, 0xdf, 0xdd, 0x61, 0xd6, 0x5b, 0xb3, 0x20, 0x4b, 0x2b, 0x38, 0xf9, 0x02,
-	0x20, 0x33, 0x98, 0xa6, 0x49, 0x30, 0x43, 0x6c, 0x2b, 0xc7, 0x25, 0x7f, 0x0b, 0xc6, 0x6c, 0x4e,
-	0xda, 0xba, 0xca, 0x33, 0x37, 0x0e, 0x8a, 0x4b, 0xb2, 0x66, 0x93, 0x57, 0xe3, 0xb4, 0xff, 0xbb,
-	0x04, 0x5b, 0x0b, 0xe1, 0x82, 0xb4, 0x60, 0x55, 0xbd, 0x51, 0xe8, 0x12, 0x51, 0x7f, 0xc9, 0x44,
-	0x26, 0x7d, 0xa6, 0x4e, 0x01, 0x48, 0x1f, 0xe9, 0xba, 0x7a, 0xa7, 0x4e, 0x0d, 0x91, 0x67, 0x50,
-	0x67, 0xea, 0x1d, 0xcf, 0x99, 0x30, 0x37, 0xf1, 0xd3, 0x0c, 0xae, 0x86, 0xd4, 0xbe, 0x26, 0x92,
-	0xcf, 0xc1, 0x50, 0x62, 0x31, 0x73, 0xbc, 0xa9, 0x87, 0xff, 0x94, 0xa0, 0x32, 0xa3, 0x0d, 0xa4,
-	0x5b, 0x19, 0x59, 0x5a, 0xcc, 0x5a, 0x9d, 0xf9, 0x4a, 0xb9, 0x96, 0x52, 0x55, 0xa9, 0xfc, 0x2f,
-	0x25, 0x68, 0xea, 0xc2, 0xa6, 0xb8, 0x05, 0xdf, 0x03, 0x29, 0xd4, 0x5f, 0xaa, 0xfb, 0x5e, 0x42,
-	0xd8, 0xcc, 0xed, 0x84, 0x7a, 0x6a, 0xcc, 0xd5, 0x59, 0x2a, 0x1e, 0xba, 0xb3, 0xea, 0xad, 0x58,
-	0x1c, 0x94, 0xf5, 0xbd, 0x91, 0x3f, 0x6e, 0x68, 0x23, 0xad, 0xd5, 0xf2, 0x8c, 0xe1, 0x2a, 0xfe,
-	0x6f, 0xc6, 0x8b, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x56, 0x3a, 0xe8, 0x44, 0xd7, 0x21, 0x00,
-	0x00,
+	// 4932 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3b, 0x5d, 0x73, 0xdb, 0x46,
+	0x92, 0x26, 0x29, 0xd9, 0x54, 0x93, 0x94, 0xa8, 0xa1, 0x3e, 0x60, 0x39, 0x5e, 0xcb, 0xf4, 0x66,
+	0xa3, 0x6c, 0xbc, 0x4a, 0x2c, 0x6f, 0x72, 0x71, 0x12, 0x27, 0xa1, 0x24, 0xca, 0x66, 0xac, 0x0f,
+	0x06, 0xa4, 0x36, 0x97, 0x7d, 0xc1, 0x0d, 0x81, 0x11, 0x89, 0x08, 0x04, 0xb8, 0x18, 0xc0, 0xb6,
+	0xf6, 0x69, 0xab, 0xf6, 0x2f, 0xdc, 0xdb, 0x5d, 0xdd, 0xcb, 0x55, 0xdd, 0xdb, 0xd6, 0x3d, 0xde,
+	0xeb, 0xfe, 0x83, 0xab, 0xfb, 0x25, 0xf7, 0x17, 0xae, 0xba, 0x67, 0x00, 0x02, 0x22, 0xed, 0x38,
+	0x75, 0x4f, 0xc4, 0x74, 0xf7, 0x7c, 0xf5, 0xf4, 0xf4, 0xe7, 0x10, 0xaa, 0x76, 0xe0, 0x5f, 0xb8,
+	0xc3, 0xdd, 0x49, 0x18, 0x44, 0xc1, 0xd6, 0x6f, 0x27, 0x83, 0x8f, 0xed, 0x58, 0x46, 0xc1, 0xd8,
+	0x12, 0x2f, 0xb9, 0x17, 0xf3, 0x28, 0x08, 0x67, 0x00, 0x8a, 0xb6, 0xf9, 0xaf, 0x45, 0x58, 0xee,
+	0x0b, 0x19, 0x9d, 0xf2, 0xb1, 0x38, 0xa0, 0x41, 0xd8, 0xb7, 0x50, 0xf3, 0xf9, 0x58, 0x58, 0xc2,
+	0x13, 0x63, 0xe1, 0x47, 0xd2, 0x28, 0x6c, 0x97, 0x76, 0x2a, 0x7b, 0x77, 0x76, 0xf3, 0x74, 0xbb,
+	0xf8, 0xd9, 0x56, 0x34, 0x66, 0xd5, 0x9f, 0x36, 0x24, 0xbb, 0x07, 0x15, 0x1a, 0xe1, 0x22, 0x08,
+	0xc7, 0x3c, 0x32, 0x8a, 0xdb, 0x85, 0x9d, 0x25, 0x13, 0x10, 0x74, 0x44, 0x90, 0xad, 0xff, 0x28,
+	0x40, 0x25, 0xd3, 0x9d, 0x6d, 0xc0, 0x4d, 0x8f, 0x0f, 0x84, 0x87, 0x73, 0x21, 0xad, 0x6e, 0xb1,
+	0x07, 0x50, 0x8b, 0x78, 0x38, 0x14, 0x91, 0xa5, 0x36, 0xa8, 0x87, 0xaa, 0x2a, 0xa0, 0x5e, 0xef,
+	0x7d, 0xa8, 0x0e, 0x62, 0xd7, 0x73, 0x2c, 0x05, 0x35, 0x4a, 0xdb, 0x85, 0x9d, 0xb2, 0x59, 0x21,
+	0x58, 0x9f, 0x40, 0x8c, 0xc1, 0x42, 0xc4, 0x87, 0xd2, 0x58, 0xa0, 0xee, 0xf4, 0x4d, 0x63, 0x0b,
+	0x19, 0x59, 0x93, 0x30, 0x98, 0x88, 0x30, 0xba, 0x32, 0x16, 0xf5, 0xd8, 0x42, 0x46, 0x5d, 0x0d,
+	0x6b, 0xbe, 0x80, 0xea, 0x69, 0x10, 0xb9, 0x17, 0xae, 0xcd, 0x23, 0x37, 0xf0, 0x99, 0x01, 0xb7,
+	0x64, 0x3c, 0x1e, 0xf3, 0xf0, 0x4a, 0xaf, 0x34, 0x69, 0xe2, 0x2a, 0xec, 0xc0, 0x8f, 0xc4, 0xeb,
+	0xc8, 0xf2, 0x5c, 0xff, 0x52, 0xaf, 0xb4, 0xa2, 0x61, 0xc7, 0xae, 0x7f, 0xd9, 0xfc, 0xe7, 0x1d,
+	0x58, 0x42, 0x1e, 0x3e, 0x0b, 0x83, 0x78, 0x82, 0x6b, 0x42, 0x8e, 0xe8, 0x71, 0xe8, 0x9b, 0xdd,
+	0x05, 0x18, 0xda, 0xd2, 0x9a, 0x84, 0xe2, 0xc2, 0x7d, 0xad, 0x87, 0x58, 0x1a, 0xda, 0xb2, 0x4b,
+	0x00, 0xf6, 0x1b, 0x58, 0x71, 0xf8, 0x95, 0xb4, 0x82, 0x0b, 0x2b, 0x14, 0x32, 0xf6, 0x22, 0x49,
+	0x9b, 0x5d, 0x34, 0x6b, 0x08, 0x3e, 0xbb, 0x30, 0x15, 0x90, 0xbd, 0x0f, 0xcb, 0xee, 0xd0, 0x0f,
+	0x42, 0x61, 0x4d, 0x84, 0xef, 0xb8, 0xfe, 0x90, 0x36, 0x5e, 0x36, 0x6b, 0x0a, 0xda, 0x55, 0x40,
+	0x5c, 0xb2, 0x26, 0x43, 0x5e, 0x45, 0xc4, 0x80, 0xb2, 0x59, 0x51, 0xb0, 0x7d, 0x04, 0xb1, 0x6f,
+	0x61, 0x15, 0xf9, 0x21, 0x2d, 0x3a, 0xcf, 0x49, 0xe0, 0xb9, 0xf6, 0x95, 0x71, 0x73, 0xbb, 0xb0,
+	0xb3, 0xbc, 0xb7, 0xb6, 0x9b, 0xee, 0x85, 0xbe, 0x24, 0x1e, 0xa8, 0xb9, 0x12, 0x25, 0x9f, 0x5d,
+	0x22, 0x66, 0x9f, 0xc3, 0xc6, 0x90, 0x47, 0x23, 0x11, 0x5a, 0x59, 0x6e, 0xbb, 0x42, 0x1a, 0xb7,
+	0x70, 0xba, 0xfd, 0xa2, 0x51, 0x30, 0xd7, 0x14, 0x45, 0x7f, 0xca, 0x79, 0x57, 0x48, 0xb6, 0x07,
+	0xeb, 0x7a, 0x79, 0xd4, 0x53, 0xc6, 0x03, 0x19, 0x85, 0xb8, 0x99, 0xf2, 0x76, 0x69, 0x67, 0xc9,
+	0x6c, 0x28, 0x24, 0x76, 0xea, 0x25, 0x28, 0xf6, 0x15, 0xd4, 0xec, 0xc0, 0x8b, 0xc7, 0xbe, 0x35,
+	0x12, 0xdc, 0x11, 0xa1, 0xb1, 0x44, 0xb2, 0xbb, 0x99, 0x59, 0xeb, 0x01, 0xe1, 0x9f, 0x13, 0xda,
+	0xac, 0xda, 0x99, 0x16, 0x7b, 0x0e, 0xab, 0x17, 0xdc, 0xf3, 0x06, 0xdc, 0xbe, 0xb4, 0x86, 0x48,
+	0x8c, 0xb3, 0x01, 0xed, 0xf6, 0x4e, 0x66, 0x84, 0x23, 0x4d, 0xf3, 0x4c, 0x93, 0x98, 0xf5, 0x8b,
+	0x6b, 0x10, 0xf6, 0x14, 0x6e, 0x73, 0x4f, 0x84, 0x91, 0x25, 0x23, 0xee, 0x89, 0xe4, 0xb4, 0xac,
+	0x51, 0x10, 0x87, 0xd2, 0xa8, 0xe0, 0x99, 0xd1, 0xc6, 0x37, 0x88, 0xa8, 0x87, 0x34, 0xfa, 0xec,
+	0x9e, 0x23, 0x05, 0xfb, 0x14, 0xd6, 0xfd, 0x78, 0x6c, 0x5d, 0x70, 0xd7, 0x8b, 0x43, 0x21, 0xad,
+	0x28, 0xb0, 0x88, 0xd2, 0xa8, 0xa6, 0x5d, 0x99, 0x1f, 0x8f, 0x8f, 0x34, 0xbe, 0x1f, 0xb4, 0x10,
+	0x8b, 0x22, 0x3d, 0x88, 0x87, 0x96, 0x1d, 0x8c, 0x27, 0x81, 0x2f, 0xfc, 0xc8, 0xa8, 0x91, 0x74,
+	0x54, 0x07, 0xf1, 0xf0, 0x20, 0x81, 0xb1, 0x1d, 0xa8, 0xdb, 0x81, 0x23, 0x2c, 0x29, 0x78, 0x68,
+	0x8f, 0xac, 0x09, 0x8f, 0x46, 0xc6, 0x32, 0x49, 0xda, 0x32, 0xc2, 0x7b, 0x04, 0xee, 0xf2, 0x68,
+	0xc4, 0x1e, 0x02, 0x4e, 0x62, 0x29, 0x16, 0x49, 0x2b, 0x14, 0x36, 0x8e, 0xb9, 0x42, 0x63, 0xd6,
+	0xfd, 0x78, 0xac, 0x38, 0x29, 0x4d, 0x82, 0xb3, 0xdf, 0xc2, 0x6a, 0x2c, 0xf5, 0x59, 0x8d, 0x45,
+	0xc4, 0x1d, 0x1e, 0x71, 0xa3, 0x4e, 0x22, 0xb5, 0x12, 0x4b, 0x3a, 0xa7, 0x13, 0x0d, 0x66, 0x4f,
+	0x60, 0x53, 0xb1, 0x67, 0xcc, 0x5d, 0x8f, 0x76, 0xe7, 0x38, 0xa1, 0x90, 0x52, 0x48, 0x63, 0x15,
+	0x97, 0xa2, 0xa4, 0x82, 0x48, 0x4e, 0xb8, 0xeb, 0xf5, 0x83, 0x56, 0x82, 0x67, 0x9f, 0x00, 0xcb,
+	0x74, 0x95, 0xf1, 0xe0, 0x27, 0x61, 0x47, 0x06, 0x4b, 0x7b, 0xd5, 0xd3, 0x5e, 0x3d, 0x85, 0x63,
+	0xdf, 0xc0, 0x56, 0xa6, 0x87, 0xe6, 0xa9, 0x35, 0x16, 0x52, 0xf2, 0xa1, 0x30, 0x1a, 0x69, 0xcf,
+	0xcd, 0xb4, 0xa7, 0xe6, 0xeb, 0x89, 0x22, 0x61, 0x8f, 0x61, 0x2d, 0x33, 0x80, 0x23, 0x90, 0xc7,
+	0x71, 0xe8, 0x19, 0x6b, 0x69, 0xd7, 0xd5, 0xb4, 0xeb, 0x21, 0x62, 0xcf, 0x43, 0x8f, 0x1d, 0xc3,
+	0xfd, 0xb1, 0xeb, 0x5b, 0xc2, 0xe3, 0x13, 0x29, 0x1c, 0x6b, 0xec, 0xfa, 0x71, 0x24, 0xa4, 0x35,
+	0x10, 0xd1, 0x2b, 0x21, 0x7c, 0x1a, 0x4a, 0x1a, 0xeb, 0xe9, 0x71, 0xde, 0x1d, 0xbb, 0x7e, 0x5b,
+	0xd1, 0x9e, 0x28, 0xd2, 0x7d, 0x45, 0x89, 0x83, 0x4a, 0xf6, 0x23, 0xec, 0x20, 0x73, 0x95, 0x16,
+	0x8c, 0x43, 0x52, 0x46, 0x16, 0xaa, 0x72, 0x21, 0x2d, 0x2e, 0x95, 0x70, 0x58, 0x13, 0x1e, 0xf2,
+	0xb1, 0x34, 0x36, 0xd2, 0x7b, 0xf5, 0x20, 0x96, 0xe2, 0x20, 0xdb, 0xe5, 0x0f, 0xd4, 0xa3, 0x25,
+	0x49, 0x5c, 0xba, 0x44, 0xce, 0x76, 0xa1, 0x21, 0x7c, 0x3e, 0xf0, 0x84, 0x75, 0xe1, 0xf1, 0xcb,
+	0x2b, 0x94, 0xd8, 0x28, 0x96, 0xc6, 0x26, 0x9d, 0xdc, 0xaa, 0x42, 0x1d, 0x21, 0xa6, 0x47, 0x08,
+	0xbc, 0x96, 0xb8, 0x94, 0xcb, 0x78, 0x20, 0x42, 0x5f, 0xe0, 0x9e, 0x6c, 0xcf, 0x45, 0xc1, 0x30,
+	0xa8, 0x47, 0x23, 0x96, 0xe2, 0x45, 0x8a, 0x3b, 0x20, 0x14, 0x1a, 0x04, 0x57, 0x5a, 0xe2, 0x75,
+	0x24, 0x42, 0x9f, 0x7b, 0xc6, 0x6d, 0xa2, 0x04, 0x57, 0xb6, 0x35, 0x84, 0x3d, 0x81, 0x3a, 0x09,
+	0x0e, 0xa9, 0x19, 0xad, 0xeb, 0xb7, 0xb6, 0x0b, 0x3b, 0x95, 0xbd, 0x95, 0x6b, 0x66, 0xc7, 0x5c,
+	0x8e, 0x72, 0x6d, 0xf6, 0x18, 0x6a, 0x7e, 0x46, 0x45, 0x4b, 0xe3, 0x0e, 0x5d, 0xf9, 0xda, 0x6e,
+	0x56, 0x71, 0x9b, 0x79, 0x1a, 0xf6, 0x14, 0x96, 0xb5, 0x9e, 0x90, 0x41, 0x18, 0x59, 0x83, 0x2b,
+	0xe3, 0x3d, 0xba, 0xe6, 0xb3, 0x8a, 0xa2, 0x17, 0x84, 0xd1, 0xfe, 0x55, 0xa2, 0x28, 0x54, 0x8b,
+	0xb5, 0xa1, 0x3e, 0x09, 0x5d, 0xd4, 0xfb, 0x53, 0x3d, 0x71, 0x97, 0x06, 0xd8, 0xca, 0x0c, 0xd0,
+	0x55, 0x24, 0xa9, 0x9a, 0x58, 0x99, 0xe4, 0x01, 0x19, 0xd6, 0x27, 0xb7, 0x66, 0x14, 0x38, 0xd2,
+	0xf8, 0x55, 0x96, 0xf5, 0xfa, 0xde, 0x20, 0x82, 0x1d, 0x6a, 0x2e, 0x71, 0xdf, 0x0f, 0x22, 0xbd,
+	0xdb, 0x7b, 0xb4, 0xdb, 0xdb, 0xd7, 0x94, 0x71, 0x2b, 0xa5, 0x50, 0x1a, 0x79, 0xda, 0x96, 0xec,
+	0x73, 0xb8, 0x3d, 0xe6, 0xaf, 0x73, 0x53, 0x5a, 0x13, 0xad, 0x9f, 0x8d, 0x6d, 0xba, 0xdd, 0xeb,
+	0x63, 0xfe, 0x3a, 0x33, 0x71, 0x57, 0xe9, 0x66, 0xd6, 0x82, 0xbb, 0x76, 0x30, 0x1e, 0xbb, 0x91,
+	0x15, 0xbc, 0x14, 0x61, 0xe8, 0x3a, 0xc2, 0x22, 0x43, 0x8d, 0x4a, 0x04, 0x0f, 0xd2, 0xb8, 0x4f,
+	0x7a, 0x64, 0x4b, 0x11, 0x9d, 0x69, 0x9a, 0x63, 0x24, 0xe9, 0x2a, 0x0a, 0xf6, 0x1c, 0xd6, 0x73,
+	0x1a, 0xc2, 0x0a, 0x26, 0x6a, 0x1f, 0x4d, 0xda, 0xc7, 0xda, 0x6e, 0x56, 0x4f, 0x9c, 0x29, 0x9c,
+	0xd9, 0x88, 0x66, 0x81, 0xa8, 0xc7, 0x68, 0xa4, 0x88, 0x0f, 0xd3, 0xf9, 0x1f, 0x28, 0x3d, 0x86,
+	0xf0, 0x3e, 0x1f, 0x26, 0x73, 0x3e, 0x81, 0x3a, 0x8f, 0xa3, 0xc0, 0xc2, 0x7b, 0x9b, 0x4c, 0xf7,
+	0x6b, 0x2d, 0x5c, 0xad, 0x38, 0x0a, 0xf6, 0xe3, 0x61, 0x32, 0xd3, 0x32, 0xcf, 0xb5, 0xd9, 0x63,
+	0xd8, 0x48, 0x79, 0x15, 0xc6, 0x7e, 0xe4, 0x8e, 0x85, 0x56, 0xe2, 0xef, 0x13, 0xa3, 0x1a, 0x9a,
+	0x51, 0xa6, 0xc2, 0x29, 0xed, 0xfd, 0x15, 0xdc, 0x41, 0xbd, 0x39, 0xe1, 0x52, 0x2a, 0xdd, 0xed,
+	0xb8, 0x92, 0x4e, 0x59, 0xe9, 0xf0, 0xdf, 0x50, 0xcf, 0x4d, 0x3f, 0x1e, 0x77, 0x89, 0xa2, 0x1f,
+	0x1c, 0x2a, 0xbc, 0x52, 0xe2, 0x1f, 0x01, 0x43, 0x07, 0x02, 0x57, 0x2b, 0xad, 0x81, 0x16, 0x30,
+	0xe3, 0x03, 0xa5, 0x48, 0x11, 0xb3, 0x1f, 0x0f, 0xe5, 0xbe, 0x12, 0x22, 0xd6, 0x81, 0x35, 0xe1,
+	0xbf, 0x74, 0xc3, 0xc0, 0x47, 0x3f, 0xca, 0x72, 0x7d, 0x19, 0x71, 0xdf, 0x16, 0xc6, 0x0e, 0x09,
+	0xe3, 0x46, 0x46, 0x2a, 0xda, 0x53, 0x32, 0xb3, 0x91, 0xe9, 0xd3, 0xd1, 0x5d, 0x58, 0x07, 0x36,
+	0x32, 0x22, 0x91, 0x35, 0xd4, 0x1f, 0xd2, 0xd1, 0x34, 0x32, 0x83, 0xbd, 0x10, 0x57, 0xa4, 0x4a,
+	0xcc, 0xb5, 0x28, 0x95, 0x92, 0x8c, 0xe5, 0xbe, 0x07, 0x15, 0x6d, 0xf3, 0x71, 0x13, 0xc6, 0x6f,
+	0xd5, 0x75, 0x57, 0x20, 0x5c, 0x3d, 0xda, 0x0a, 0x39, 0xc2, 0x8b, 0x47, 0xfe, 0xd2, 0x58, 0x44,
+	0xa1, 0x6b, 0x1b, 0x1f, 0xd1, 0xe1, 0xad, 0x10, 0xa2, 0x2f, 0x5e, 0xe3, 0xb0, 0xa1, 0x6b, 0xb3,
+	0x13, 0x78, 0x70, 0x5d, 0xe8, 0xe6, 0xa8, 0x41, 0xe3, 0x21, 0xf5, 0xde, 0xce, 0x8b, 0xde, 0xac,
+	0xf2, 0x43, 0xe9, 0xcf, 0xb1, 0x37, 0x77, 0xf3, 0x7e, 0x47, 0x2b, 0x5d, 0x9f, 0x72, 0x39, 0x7b,
+	0xfb, 0x3e, 0x85, 0xcd, 0x2c, 0x83, 0xc6, 0x3c, 0xb2, 0x47, 0x56, 0x28, 0x86, 0xe2, 0xb5, 0xb1,
+	0x4b, 0x93, 0x67, 0x98, 0x71, 0x82, 0x48, 0x13, 0x71, 0xec, 0x91, 0xd2, 0x97, 0x17, 0xb1, 0xe7,
+	0x25, 0x5d, 0x51, 0xcb, 0x49, 0xe3, 0x63, 0x9a, 0x8c, 0xc5, 0x52, 0x1c, 0xc5, 0x9e, 0xa7, 0xfa,
+	0xa1, 0x5e, 0x93, 0xac, 0x0d, 0x77, 0xb5, 0xbb, 0xae, 0x1c, 0x87, 0xa9, 0xd7, 0x6e, 0x85, 0xb1,
+	0x27, 0xa4, 0xf1, 0x09, 0x7a, 0x40, 0xa4, 0xe2, 0xb7, 0x14, 0xa1, 0xf2, 0x1e, 0xda, 0x09, 0x99,
+	0x89, 0x54, 0xec, 0x7b, 0x78, 0x7f, 0xc6, 0x9d, 0x99, 0xcb, 0xbb, 0x47, 0xb4, 0xfc, 0xe6, 0x75,
+	0x2f, 0x66, 0x0e, 0xf7, 0xbe, 0x82, 0x9a, 0x5e, 0x92, 0x0c, 0xe2, 0xd0, 0x16, 0xc6, 0x1e, 0xdd,
+	0xa3, 0xac, 0xda, 0x54, 0x4b, 0xe9, 0x11, 0xda, 0xac, 0x86, 0x99, 0x16, 0x3b, 0x80, 0xdb, 0xd7,
+	0xc3, 0x10, 0xda, 0x90, 0x25, 0x45, 0x64, 0x3c, 0xa6, 0x91, 0xca, 0xbb, 0xb8, 0xf6, 0x9e, 0x88,
+	0xcc, 0x0d, 0x45, 0x9a, 0xdb, 0x53, 0x4f, 0x44, 0x78, 0x0c, 0xa1, 0xe0, 0x0e, 0xd9, 0x29, 0x61,
+	0x5d, 0x84, 0xc1, 0xd8, 0x92, 0x51, 0x10, 0xa2, 0x2d, 0xff, 0x3d, 0x71, 0x74, 0x0d, 0xd1, 0x68,
+	0xac, 0xc4, 0x51, 0x18, 0x8c, 0x7b, 0x0a, 0x87, 0xce, 0x8c, 0xf6, 0x26, 0x03, 0xcf, 0x49, 0xdd,
+	0xe7, 0x4f, 0xa9, 0x47, 0x5d, 0x61, 0xce, 0x3c, 0x27, 0xf1, 0xa0, 0xd1, 0x60, 0x29, 0x6a, 0x79,
+	0xe9, 0x4e, 0x8c, 0xcf, 0xb4, 0xc1, 0x22, 0x50, 0xef, 0xd2, 0x9d, 0xb0, 0xcf, 0xc1, 0xb8, 0x2e,
+	0x95, 0x32, 0x0a, 0x2f, 0x50, 0x09, 0x18, 0xff, 0x40, 0xec, 0xdc, 0xc8, 0x8b, 0x62, 0x4f, 0x63,
+	0xd1, 0x49, 0x8b, 0xa5, 0x08, 0xa7, 0x71, 0xc7, 0xe7, 0x2a, 0xee, 0x40, 0x60, 0x12, 0x77, 0xb0,
+	0xcf, 0x60, 0x93, 0x3b, 0x8e, 0x8b, 0x8c, 0xe7, 0x9e, 0x35, 0x8d, 0x09, 0x84, 0x34, 0x9e, 0x90,
+	0xf7, 0xbb, 0x3e, 0x45, 0x3f, 0x4b, 0xe2, 0x03, 0x21, 0xd9, 0xd7, 0xb0, 0xcc, 0xc3, 0xc8, 0xbd,
+	0xe0, 0xb6, 0x0a, 0x43, 0xa4, 0xf1, 0xc5, 0x8c, 0x03, 0xdc, 0xd2, 0x04, 0x18, 0x93, 0x98, 0x35,
+	0x9e, 0x69, 0x65, 0xf7, 0x8d, 0xda, 0xcb, 0xf8, 0x32, 0xbb, 0x6f, 0xd4, 0x56, 0x68, 0xf9, 0x9c,
+	0x78, 0xe2, 0xa1, 0x21, 0x55, 0x61, 0x83, 0x23, 0x8d, 0xaf, 0x66, 0x2c, 0xdf, 0x61, 0x42, 0xb2,
+	0x4f, 0x14, 0xe6, 0x8a, 0x93, 0x07, 0xe0, 0x30, 0xda, 0xfe, 0x86, 0x22, 0x12, 0x3e, 0x6e, 0xc4,
+	0x78, 0x3a, 0x33, 0x8c, 0xb2, 0xc0, 0x66, 0x42, 0x61, 0xae, 0xd8, 0x79, 0x00, 0xea, 0x11, 0x54,
+	0xcf, 0xda, 0x97, 0xb3, 0x06, 0x57, 0x91, 0x90, 0xc6, 0xd7, 0xdb, 0x85, 0x9d, 0x92, 0xb9, 0x32,
+	0xe6, 0xaf, 0xb5, 0x03, 0xb7, 0x8f, 0x60, 0xb4, 0x17, 0x8a, 0x16, 0xb5, 0x8a, 0xbe, 0x82, 0xdf,
+	0x90, 0x2a, 0x5e, 0x26, 0x52, 0x04, 0xab, 0xeb, 0x77, 0x1f, 0xaa, 0x97, 0x42, 0x4c, 0xe8, 0xe8,
+	0x27, 0xc2, 0x31, 0xbe, 0x55, 0x71, 0x11, 0xc2, 0x7a, 0x0a, 0x84, 0x13, 0x7b, 0xae, 0x8c, 0xf0,
+	0x42, 0x0d, 0xf9, 0x44, 0x9b, 0x84, 0x16, 0x8d, 0xb6, 0xa2, 0x11, 0xcf, 0xf8, 0x44, 0x99, 0x83,
+	0xdf, 0xc3, 0x46, 0x3c, 0x71, 0x90, 0x5f, 0x78, 0xfe, 0x41, 0x1c, 0x25, 0xce, 0xa0, 0xb1, 0x4f,
+	0x1d, 0xd6, 0x14, 0xb6, 0xaf, 0x90, 0xda, 0xfb, 0xdb, 0xfa, 0x13, 0x54, 0xb3, 0x91, 0x0a, 0x5b,
+	0x83, 0x45, 0xb2, 0xb5, 0x3a, 0x5e, 0x54, 0x0d, 0xb6, 0x05, 0xe5, 0x54, 0x8e, 0x54, 0xb8, 0x98,
+	0xb6, 0xd9, 0xc7, 0xd0, 0x98, 0x77, 0xd9, 0x4b, 0x44, 0xc6, 0xec, 0x99, 0xcb, 0xbd, 0x25, 0x55,
+	0x2a, 0x60, 0xea, 0x2b, 0x60, 0x3c, 0x3a, 0xd5, 0xd3, 0x7a, 0xe6, 0xa5, 0x54, 0x41, 0xb3, 0xf7,
+	0xa1, 0x96, 0xcc, 0x46, 0x0c, 0x55, 0x4b, 0x78, 0x7e, 0xc3, 0xac, 0x26, 0x60, 0x64, 0xe8, 0xfe,
+	0x1d, 0xb8, 0x9d, 0xd3, 0xf6, 0xea, 0xb0, 0x94, 0x02, 0xd9, 0xda, 0x83, 0x72, 0x62, 0x4d, 0x58,
+	0x1d, 0x4a, 0x97, 0x22, 0x89, 0xac, 0xf1, 0x13, 0x77, 0xad, 0x56, 0xad, 0x36, 0xa7, 0x1a, 0x5b,
+	0xff, 0x5e, 0x82, 0x6a, 0x56, 0xcd, 0xb0, 0x47, 0x50, 0xfd, 0x29, 0xf6, 0xdd, 0x5c, 0x9a, 0xa0,
+	0xb2, 0x57, 0xdd, 0xfd, 0xee, 0xdc, 0x77, 0x75, 0x9a, 0xe0, 0xf9, 0x0d, 0xb3, 0xf2, 0x53, 0x9c,
+	0x36, 0xd9, 0x17, 0xb0, 0x32, 0x70, 0x87, 0x7f, 0x8a, 0x45, 0x78, 0x95, 0xf4, 0x5a, 0xd4, 0x3e,
+	0xc1, 0xbe, 0x3b, 0xfc, 0x1e, 0xe1, 0x69, 0xc7, 0xe5, 0x84, 0x52, 0xf7, 0x3d, 0x00, 0xa6, 0x15,
+	0x48, 0x84, 0x57, 0x45, 0x77, 0xbf, 0x49, 0xdd, 0x59, 0xa2, 0x00, 0x11, 0x95, 0x8e, 0xb0, 0x9a,
+	0xa1, 0xd7, 0x83, 0xb4, 0x80, 0xd9, 0x5e, 0x10, 0x3b, 0xea, 0x16, 0x25, 0x83, 0xdc, 0xa2, 0x41,
+	0x56, 0x77, 0x0f, 0x10, 0x45, 0x97, 0x25, 0x1d, 0xa3, 0x6e, 0x5f, 0x83, 0xb1, 0x43, 0x68, 0xf0,
+	0x3f, 0x63, 0x30, 0xe3, 0x88, 0x97, 0xc1, 0x44, 0x26, 0x63, 0x94, 0xf5, 0x42, 0x5a, 0x88, 0x3b,
+	0x14, 0x2f, 0xcf, 0x26, 0x72, 0xba, 0x10, 0xae, 0x81, 0x41, 0x02, 0x64, 0x7b, 0x50, 0x9b, 0xc4,
+	0x03, 0x19, 0x0f, 0x92, 0xfe, 0x0b, 0xd4, 0xbf, 0xb6, 0xdb, 0x8d, 0x07, 0xbd, 0x78, 0xa0, 0xa8,
+	0xcc, 0xaa, 0xa2, 0x51, 0xad, 0xfd, 0x0d, 0x58, 0xcb, 0xd9, 0x01, 0xdd, 0xf5, 0xbb, 0x85, 0x72,
+	0xa1, 0x5e, 0xfc, 0x6e, 0xa1, 0x5c, 0xaa, 0x2f, 0x6c, 0x5d, 0x41, 0x35, 0xab, 0x6a, 0x50, 0x56,
+	0x13, 0x65, 0xa3, 0x8f, 0x38, 0x6d, 0x63, 0x32, 0x84, 0x02, 0x51, 0x75, 0xcc, 0xf4, 0x9d, 0x93,
+	0xed, 0xd2, 0x35, 0xd9, 0xbe, 0x0b, 0x10, 0x87, 0x5e, 0x92, 0x28, 0x51, 0x69, 0x9d, 0xa5, 0x38,
+	0xf4, 0x94, 0x22, 0x6c, 0x8e, 0x55, 0xa2, 0x85, 0xf2, 0x10, 0x6c, 0x0b, 0x36, 0xfa, 0xed, 0x5e,
+	0xbf, 0x67, 0x9d, 0xb6, 0x4e, 0xda, 0xd6, 0xf9, 0x69, 0xaf, 0xdb, 0x3e, 0xe8, 0x1c, 0x75, 0xda,
+	0x87, 0xf5, 0x1b, 0x6c, 0x1d, 0x56, 0x33, 0xb8, 0xce, 0xb3, 0xd3, 0x33, 0xb3, 0x5d, 0x2f, 0xb0,
+	0x0d, 0x60, 0x19, 0xb0, 0xd9, 0xee, 0x1e, 0xb7, 0x0e, 0xda, 0xf5, 0xe2, 0x35, 0xf2, 0x56, 0xb7,
+	0xdb, 0x3e, 0x3d, 0xac, 0x97, 0x9a, 0xff, 0x5d, 0x80, 0xfa, 0xf5, 0xa4, 0x00, 0x4e, 0x7b, 0xd4,
+	0x3a, 0x3e, 0xde, 0x6f, 0x1d, 0xbc, 0xb0, 0x9e, 0x99, 0x67, 0xe7, 0xdd, 0xce, 0xe9, 0x33, 0xeb,
+	0xf4, 0xec, 0xb4, 0x5d, 0xbf, 0x31, 0x1f, 0x77, 0xd8, 0xea, 0xe3, 0xdc, 0xef, 0x81, 0x31, 0x8b,
+	0x3b, 0x6e, 0xed, 0xb7, 0x8f, 0x7b, 0xf5, 0x22, 0x33, 0x60, 0x6d, 0x16, 0xdb, 0x39, 0xac, 0x97,
+	0xd8, 0x36, 0xbc, 0x37, 0x8b, 0x39, 0x38, 0x3b, 0x39, 0xe9, 0xf4, 0xad, 0xd3, 0xf3, 0x93, 0xfa,
+	0x02, 0xfb, 0x10, 0xde, 0x9f, 0x47, 0x71, 0x7a, 0xd4, 0x79, 0x76, 0x6e, 0xb6, 0xfa, 0x9d, 0xb3,
+	0x53, 0xeb, 0x0f, 0xad, 0xe3, 0xf3, 0x76, 0x7d, 0xb1, 0xf9, 0x6d, 0xa2, 0x7d, 0x74, 0xc0, 0xb3,
+	0x06, 0xf5, 0x83, 0xb3, 0xe3, 0xf3, 0x93, 0x53, 0xab, 0x77, 0x66, 0xf6, 0xd5, 0x52, 0x69, 0x1b,
+	0x59, 0x68, 0x66, 0xb2, 0x42, 0xf3, 0x04, 0x56, 0xae, 0xc5, 0x3f, 0xec, 0x36, 0xac, 0x77, 0xcd,
+	0xce, 0x49, 0xcb, 0xfc, 0x71, 0x86, 0x21, 0xf7, 0xe0, 0xce, 0x0c, 0x2a, 0x37, 0xdc, 0x3d, 0xa8,
+	0x64, 0x3c, 0x58, 0x56, 0x86, 0x85, 0xae, 0x79, 0x86, 0x27, 0x78, 0x13, 0x8a, 0xdf, 0xb7, 0xea,
+	0x85, 0xa6, 0x0b, 0x2b, 0xd7, 0xac, 0x0e, 0xbb, 0x0b, 0xb7, 0x0f, 0xcf, 0xbb, 0xc7, 0x9d, 0x83,
+	0x56, 0xbf, 0x6d, 0xed, 0x9f, 0x77, 0x8e, 0x0f, 0x7b, 0x56, 0xaf, 0xdd, 0x6d, 0x99, 0x6a, 0xf5,
+	0x77, 0x60, 0x73, 0x06, 0x7d, 0xdc, 0xc2, 0xf3, 0xad, 0x17, 0x70, 0x6b, 0x33, 0xc8, 0xf3, 0xd3,
+	0xce, 0xd9, 0x69, 0xbd, 0x88, 0x5b, 0xbb, 0x66, 0x99, 0xf0, 0x58, 0x34, 0x27, 0xcc, 0x76, 0xbf,
+	0x7d, 0x4a, 0xbc, 0x6c, 0x1d, 0x1f, 0xd7, 0x6f, 0xe0, 0xb1, 0xcc, 0x60, 0xda, 0xff, 0xd8, 0x3d,
+	0x3b, 0xc5, 0xef, 0xd6, 0x71, 0xbd, 0xd0, 0xac, 0x41, 0x25, 0xa3, 0xa7, 0x9a, 0xdf, 0xc2, 0x72,
+	0x5e, 0x01, 0x61, 0xd2, 0x71, 0x12, 0x06, 0x3f, 0x89, 0xf4, 0xde, 0x24, 0x4d, 0x54, 0x8f, 0xa4,
+	0x97, 0x12, 0xf5, 0x48, 0x8d, 0xe6, 0x0b, 0xa8, 0x5f, 0x57, 0x1f, 0x6f, 0x19, 0xe3, 0x2e, 0x40,
+	0x14, 0xba, 0xc3, 0xa1, 0x08, 0x2d, 0xd7, 0x49, 0x72, 0x8e, 0x1a, 0xd2, 0x71, 0x9a, 0x7f, 0x2d,
+	0xc0, 0xea, 0x8c, 0x22, 0x61, 0x4d, 0xa8, 0x06, 0xe1, 0x90, 0xfb, 0xee, 0x9f, 0xc9, 0x50, 0xe8,
+	0x31, 0x73, 0xb0, 0xec, 0x94, 0xc5, 0xfc, 0x94, 0x9f, 0xc0, 0x9a, 0x52, 0x7a, 0x8e, 0xb8, 0x70,
+	0x7d, 0x72, 0x63, 0x2c, 0xd7, 0xc1, 0x64, 0x66, 0x69, 0x67, 0xd1, 0x64, 0x84, 0x3b, 0x4c, 0x51,
+	0x1d, 0x47, 0x36, 0x0f, 0x60, 0x75, 0x46, 0xad, 0xfe, 0x62, 0xbe, 0x38, 0x50, 0xcd, 0xaa, 0xb4,
+	0xb7, 0xf4, 0x6f, 0x42, 0x15, 0xd3, 0x8d, 0x76, 0xe8, 0x52, 0x1c, 0x98, 0xa4, 0x9d, 0xb3, 0x30,
+	0xcc, 0x59, 0x5f, 0xb8, 0x5e, 0x24, 0x42, 0xad, 0x9c, 0x74, 0xab, 0xf9, 0xb7, 0x02, 0x34, 0xe6,
+	0x04, 0xb1, 0x98, 0xbc, 0x9d, 0xa6, 0x38, 0x54, 0xd8, 0xa0, 0x66, 0xad, 0x25, 0x09, 0x0d, 0x15,
+	0x2f, 0xcc, 0x24, 0xf1, 0x8a, 0x73, 0x92, 0x78, 0x6b, 0xb0, 0x18, 0xbc, 0xf2, 0xd3, 0xb9, 0x55,
+	0x83, 0x2d, 0x43, 0xd1, 0xb6, 0x8d, 0x05, 0x72, 0x10, 0x8b, 0xb6, 0x8d, 0x43, 0x25, 0xd6, 0x56,
+	0x4d, 0xa8, 0x53, 0xdc, 0x1a, 0x48, 0xf3, 0x35, 0xff, 0x72, 0x13, 0x96, 0xf3, 0x51, 0x30, 0x7a,
+	0x2c, 0x03, 0x11, 0x71, 0x8b, 0xc7, 0x51, 0x90, 0x5f, 0x0b, 0x28, 0x8f, 0x05, 0xb1, 0x2d, 0x85,
+	0x9c, 0xae, 0xe9, 0x2e, 0x00, 0x76, 0xb0, 0x6c, 0x2f, 0x90, 0x2a, 0xad, 0x5d, 0x36, 0x97, 0x10,
+	0x72, 0x80, 0x00, 0x74, 0x2d, 0x47, 0x41, 0x84, 0xce, 0x11, 0x9d, 0x75, 0x71, 0xbb, 0xb4, 0x53,
+	0x32, 0x41, 0x83, 0x3a, 0x0e, 0xce, 0x5a, 0x9e, 0x84, 0x6e, 0x10, 0xba, 0x5a, 0xdf, 0x2f, 0xef,
+	0x19, 0xd7, 0xc2, 0xf3, 0xdd, 0xae, 0xc6, 0x9b, 0x29, 0x25, 0x7b, 0x01, 0x9b, 0x99, 0x61, 0x75,
+	0x3c, 0xa0, 0x62, 0x93, 0x05, 0x9d, 0x52, 0x78, 0x9e, 0xcc, 0x41, 0xf1, 0x00, 0xe1, 0xcc, 0xb5,
+	0xe9, 0xc4, 0x53, 0x28, 0xfb, 0x00, 0x56, 0x2e, 0x5c, 0x4f, 0x58, 0xae, 0xef, 0xb8, 0x2f, 0x5d,
+	0x27, 0xe6, 0x9e, 0x4e, 0x8a, 0x2f, 0x23, 0xb8, 0x93, 0x42, 0xd9, 0x47, 0xb0, 0x2a, 0x5d, 0x7f,
+	0xe8, 0x89, 0x28, 0xf0, 0x13, 0x36, 0x91, 0x03, 0x50, 0x36, 0xeb, 0x29, 0x42, 0x73, 0x88, 0x3d,
+	0x85, 0x3b, 0xe8, 0x79, 0x72, 0xcf, 0x0b, 0x5e, 0x09, 0x27, 0x33, 0xb8, 0x0a, 0x8f, 0x6f, 0x11,
+	0x4f, 0x8d, 0x31, 0x7f, 0xdd, 0x52, 0x14, 0xd3, 0x79, 0x28, 0x58, 0xbe, 0x0f, 0x55, 0x5a, 0x14,
+	0x06, 0x1a, 0xdc, 0xf3, 0xc8, 0xbc, 0x97, 0xcd, 0x0a, 0xc2, 0xce, 0x14, 0x88, 0xfd, 0x00, 0xeb,
+	0x8e, 0xb8, 0xe0, 0x68, 0x8f, 0xf3, 0xf9, 0xd7, 0x25, 0x32, 0xe5, 0x0f, 0xae, 0xf3, 0xf1, 0x50,
+	0x11, 0x67, 0xc5, 0xd4, 0x6c, 0x38, 0xb3, 0x40, 0x94, 0x04, 0xee, 0xbc, 0xc4, 0xfc, 0x80, 0x73,
+	0x6d, 0xe4, 0x8a, 0x8a, 0xb5, 0x12, 0x6c, 0xb6, 0xd7, 0xd6, 0x3f, 0x41, 0x63, 0xce, 0x0c, 0xb3,
+	0x92, 0x5d, 0x78, 0x9b, 0x64, 0x17, 0x67, 0x25, 0x5b, 0x09, 0x7b, 0xd1, 0xb6, 0x9b, 0xc7, 0x50,
+	0x4e, 0x64, 0x01, 0x75, 0x6f, 0xd7, 0xec, 0x9c, 0x99, 0x9d, 0xfe, 0x8f, 0xd7, 0xac, 0xfb, 0x4d,
+	0x28, 0x76, 0x3f, 0xa9, 0x17, 0xe8, 0xf7, 0x51, 0xbd, 0x48, 0xbf, 0x7b, 0xf5, 0x12, 0xfd, 0x3e,
+	0xae, 0x2f, 0xd0, 0xef, 0xef, 0xeb, 0x8b, 0xcd, 0x3f, 0x42, 0x63, 0x8e, 0x8c, 0xb0, 0x8d, 0xc4,
+	0xf9, 0xc4, 0x75, 0x96, 0x9e, 0xdf, 0xd0, 0xee, 0x27, 0xc2, 0x95, 0x2b, 0x9e, 0xb8, 0xbb, 0xaa,
+	0xb9, 0xdf, 0x80, 0xd5, 0xa9, 0x28, 0x6a, 0x21, 0x6c, 0xfe, 0xe7, 0x02, 0x2c, 0x1d, 0x72, 0x39,
+	0x1a, 0x04, 0x3c, 0x74, 0xd0, 0xd7, 0x72, 0x92, 0x86, 0x15, 0xf1, 0x81, 0xae, 0xad, 0xd5, 0x76,
+	0x53, 0x92, 0x3e, 0x1f, 0x98, 0x55, 0x27, 0xd3, 0x4a, 0x0b, 0x45, 0xc5, 0x4c, 0xa1, 0x68, 0x26,
+	0xe9, 0x59, 0x7a, 0x87, 0xa4, 0xe7, 0x3d, 0xa8, 0xa4, 0x52, 0xc2, 0x07, 0x5a, 0x19, 0x40, 0x72,
+	0xec, 0x7c, 0x80, 0xa9, 0x5d, 0x27, 0x78, 0xe5, 0x4f, 0x3c, 0x7e, 0x45, 0x79, 0x72, 0x0c, 0x6f,
+	0x22, 0x3e, 0x90, 0x5a, 0xe4, 0x1a, 0x09, 0xf2, 0x48, 0xe1, 0xfa, 0x7c, 0x80, 0xd9, 0xc4, 0x8d,
+	0x91, 0x3b, 0x1c, 0x79, 0xee, 0x70, 0x14, 0xe5, 0x3b, 0xdd, 0x9c, 0xd6, 0x77, 0x52, 0x8a, 0x6c,
+	0xcf, 0x0f, 0x60, 0x65, 0xda, 0x33, 0x0a, 0x1c, 0x7e, 0xa5, 0x4a, 0x42, 0xe6, 0x72, 0x0a, 0xee,
+	0x23, 0x94, 0x75, 0x61, 0x2d, 0xbb, 0x91, 0x34, 0x87, 0xa7, 0x84, 0xfb, 0xee, 0x94, 0x77, 0xd9,
+	0xcd, 0xa7, 0xb9, 0x43, 0x7f, 0x16, 0xc8, 0x9e, 0xc0, 0x2a, 0x5d, 0x29, 0x14, 0xc7, 0x48, 0x8c,
+	0x27, 0x1e, 0x8f, 0x04, 0xe9, 0x36, 0x64, 0x21, 0x3a, 0xab, 0x7d, 0x0d, 0x34, 0x49, 0x1f, 0xec,
+	0xc7, 0xc3, 0x04, 0xc0, 0x3e, 0x81, 0x6a, 0xc4, 0x07, 0x96, 0xe6, 0x9a, 0x2a, 0xe6, 0xcc, 0x1c,
+	0x60, 0x25, 0xe2, 0x03, 0x7d, 0x03, 0x30, 0xf0, 0x5c, 0x22, 0x21, 0x96, 0x23, 0x77, 0x42, 0x05,
+	0x9c, 0xca, 0x1e, 0xec, 0x9e, 0x25, 0x10, 0x73, 0x8a, 0xfc, 0x6e, 0xa1, 0xbc, 0x50, 0x5f, 0x6c,
+	0x7e, 0x0f, 0x4b, 0x29, 0x16, 0xad, 0x8c, 0xc2, 0x93, 0xa4, 0x2c, 0x99, 0xba, 0x45, 0x15, 0x4d,
+	0xc1, 0xc7, 0x89, 0x50, 0xe0, 0x37, 0xda, 0x33, 0x2c, 0x37, 0xa2, 0x7f, 0xad, 0x6e, 0x4a, 0xd2,
+	0x6c, 0xfe, 0x57, 0x01, 0xde, 0x7b, 0x1b, 0x97, 0xb0, 0x62, 0x28, 0x3d, 0xcc, 0x13, 0xd9, 0x23,
+	0xee, 0xfb, 0xc2, 0x4b, 0xa6, 0xab, 0x11, 0xf4, 0x40, 0x03, 0xd1, 0x25, 0x7f, 0x25, 0x06, 0xa3,
+	0x20, 0xb8, 0x54, 0x0a, 0x7c, 0xc9, 0x4c, 0xdb, 0xec, 0x73, 0xa8, 0x0d, 0xdd, 0x68, 0x14, 0x0f,
+	0x2c, 0x57, 0xca, 0x58, 0xa8, 0xd2, 0x24, 0xa6, 0x0d, 0x9f, 0xb9, 0xd1, 0xf3, 0x78, 0xd0, 0x41,
+	0x60, 0x72, 0x28, 0x55, 0x45, 0x49, 0x30, 0x1a, 0x35, 0x9d, 0x56, 0x19, 0xaf, 0xb4, 0xdd, 0x94,
+	0xc0, 0x66, 0xfb, 0xe3, 0xee, 0x43, 0x31, 0x09, 0x92, 0xda, 0x29, 0x7e, 0xb3, 0x47, 0xb0, 0x66,
+	0x07, 0xbe, 0x14, 0x76, 0x1c, 0xb9, 0x2f, 0x45, 0x5a, 0x3b, 0xd3, 0xe6, 0xb3, 0x91, 0xc1, 0x25,
+	0x65, 0xb3, 0x4c, 0xd9, 0xb9, 0xa4, 0x98, 0xab, 0x5a, 0xe8, 0x28, 0x64, 0x85, 0x00, 0xe3, 0x52,
+	0xac, 0xf7, 0xe8, 0xb8, 0x34, 0x0e, 0x3d, 0xb6, 0x0b, 0xb7, 0x12, 0x29, 0x2c, 0x6a, 0x2b, 0x83,
+	0x3d, 0xf4, 0xfa, 0x52, 0xe9, 0xb9, 0x15, 0x4c, 0x17, 0x4c, 0x77, 0xb8, 0x34, 0xbd, 0xc3, 0xcd,
+	0xa7, 0xd0, 0x98, 0xd3, 0xe7, 0x5d, 0x83, 0xe0, 0xe6, 0xdf, 0xab, 0x50, 0x3d, 0x9c, 0xa7, 0x27,
+	0xb2, 0x05, 0xe5, 0xc4, 0xe9, 0xa0, 0xf4, 0x5f, 0x26, 0x46, 0x57, 0x4e, 0x07, 0x79, 0xe6, 0x14,
+	0x23, 0xcd, 0xa8, 0xe6, 0xd2, 0x3b, 0x56, 0x0e, 0x17, 0x7e, 0x41, 0xe5, 0x70, 0xf1, 0x0d, 0x95,
+	0x43, 0x2c, 0xe0, 0x73, 0x29, 0xd2, 0x7b, 0x7d, 0x53, 0x95, 0xce, 0x11, 0x96, 0x1c, 0xf8, 0x97,
+	0xc0, 0x82, 0x89, 0xf0, 0x95, 0x0d, 0x4a, 0x6f, 0xec, 0xad, 0x79, 0x37, 0xb6, 0x8e, 0x84, 0x68,
+	0x77, 0x52, 0x8e, 0xce, 0xbd, 0xed, 0xe5, 0x77, 0xba, 0xed, 0x4f, 0xa1, 0xc1, 0xa3, 0x88, 0xdb,
+	0xa3, 0x7c, 0xe7, 0xa5, 0x79, 0x9d, 0x57, 0x15, 0x65, 0xb6, 0xfb, 0x7d, 0xa8, 0x26, 0xa5, 0x5f,
+	0xca, 0xa0, 0x80, 0xda, 0x99, 0x86, 0x51, 0x0e, 0xe5, 0x9b, 0x24, 0x92, 0x96, 0x58, 0x53, 0x9c,
+	0x4e, 0x51, 0x99, 0x37, 0x45, 0x92, 0x76, 0x38, 0x0f, 0xbd, 0x74, 0x8e, 0x23, 0x30, 0xb2, 0xa7,
+	0x92, 0x1b, 0xa4, 0x3a, 0x6f, 0x90, 0xf5, 0xe9, 0x61, 0x65, 0xc7, 0xd9, 0x46, 0xeb, 0x30, 0x75,
+	0x79, 0x6b, 0x6a, 0xa9, 0x19, 0x10, 0x96, 0xab, 0x22, 0x3e, 0x88, 0x3d, 0x1e, 0xaa, 0xf4, 0x99,
+	0x76, 0x2a, 0x55, 0xf1, 0x78, 0x55, 0xa3, 0x28, 0x85, 0xa6, 0x3c, 0xd9, 0xaf, 0xa1, 0xa6, 0x0a,
+	0x93, 0xc9, 0xc1, 0xae, 0xd0, 0x72, 0x6e, 0xe7, 0x74, 0x25, 0x15, 0x3d, 0x52, 0xbd, 0xc0, 0x33,
+	0x2d, 0xf6, 0x47, 0xd8, 0xc4, 0x92, 0xa4, 0xeb, 0x0b, 0x29, 0xad, 0xfc, 0x48, 0x06, 0x8d, 0xd4,
+	0xcc, 0x8d, 0x74, 0x94, 0xd0, 0xe6, 0x86, 0x5c, 0xbf, 0x98, 0x07, 0xc6, 0xbd, 0xf0, 0x41, 0x10,
+	0x47, 0xd6, 0xd4, 0x1c, 0xe3, 0x15, 0xaf, 0xab, 0xbd, 0x10, 0x2a, 0x1d, 0x1b, 0xcb, 0xb9, 0x4f,
+	0x60, 0x95, 0x04, 0x30, 0x27, 0x06, 0xab, 0x73, 0x65, 0x08, 0xe9, 0xb2, 0x42, 0xf0, 0x6b, 0xa0,
+	0xaa, 0x92, 0x95, 0xc8, 0xa0, 0xa4, 0x6a, 0x75, 0xd9, 0xac, 0x22, 0xf4, 0x48, 0x09, 0x9c, 0xc4,
+	0x2b, 0xe3, 0xb8, 0x92, 0x4c, 0xaf, 0x17, 0xd8, 0xdc, 0xa3, 0x64, 0x21, 0x55, 0xa7, 0xcb, 0x66,
+	0x5d, 0x63, 0x8e, 0x11, 0x81, 0x79, 0x42, 0xd6, 0x82, 0xf5, 0xe4, 0xb5, 0xc9, 0x58, 0xf8, 0xf1,
+	0x74, 0x49, 0x6b, 0xf3, 0x96, 0xd4, 0xd0, 0xb4, 0x27, 0xc2, 0x8f, 0xd3, 0x65, 0x7d, 0x06, 0x9b,
+	0x83, 0x30, 0xb8, 0x14, 0xbe, 0xbe, 0xa6, 0x56, 0x34, 0x0a, 0x85, 0x1c, 0x05, 0x9e, 0x43, 0x65,
+	0xe9, 0xa2, 0xb9, 0xae, 0xd0, 0xea, 0xae, 0xf6, 0x13, 0x24, 0x6b, 0xc1, 0x5a, 0x2e, 0x38, 0x48,
+	0x8e, 0x64, 0x63, 0x7e, 0x45, 0x8d, 0x65, 0x62, 0x85, 0x84, 0xf9, 0xa7, 0xb0, 0x39, 0x12, 0xdc,
+	0x8b, 0x46, 0x16, 0xf7, 0xb9, 0x77, 0x25, 0x5d, 0x99, 0x8e, 0xb2, 0x49, 0xa3, 0x6c, 0xec, 0x3e,
+	0x27, 0x7c, 0x4b, 0xa3, 0xd3, 0xc3, 0x1c, 0xcd, 0x03, 0xe3, 0x56, 0x5c, 0xff, 0x22, 0xe4, 0x69,
+	0x71, 0x7f, 0xba, 0x95, 0xdb, 0x6a, 0x2b, 0x84, 0xd6, 0x7a, 0x7f, 0xba, 0x95, 0x27, 0x50, 0x23,
+	0x5b, 0x65, 0x45, 0x21, 0xb7, 0x2f, 0x45, 0xa8, 0x4b, 0xce, 0x6b, 0xbb, 0x64, 0x6c, 0xfa, 0x0a,
+	0x98, 0xca, 0xa6, 0x9b, 0x01, 0xb2, 0x87, 0x50, 0x91, 0x5e, 0x90, 0x2e, 0xfb, 0x0e, 0x75, 0xac,
+	0xec, 0xf6, 0x8e, 0xcf, 0x12, 0x7a, 0x90, 0x5e, 0x90, 0x09, 0xa8, 0xf2, 0x0b, 0x4c, 0x13, 0x5b,
+	0xef, 0xa9, 0xca, 0x51, 0x76, 0x7d, 0x69, 0x11, 0x60, 0x0f, 0xd6, 0x95, 0xe6, 0xb4, 0x34, 0xb7,
+	0xb4, 0x3e, 0xa5, 0x52, 0xf3, 0xa2, 0xd9, 0x50, 0x48, 0xc5, 0x29, 0xad, 0x51, 0x31, 0x30, 0x71,
+	0x02, 0x3b, 0xc6, 0x24, 0x89, 0x72, 0x96, 0x50, 0xaa, 0x7f, 0x45, 0x93, 0xd4, 0x73, 0x88, 0xf3,
+	0xd0, 0x6b, 0xfe, 0xbd, 0x00, 0x30, 0x5d, 0x31, 0x55, 0x54, 0xd5, 0x6b, 0xab, 0x09, 0x97, 0xd2,
+	0x0a, 0x79, 0xa4, 0x8c, 0x49, 0xd1, 0x5c, 0x56, 0x70, 0xac, 0x00, 0x98, 0x28, 0x3b, 0x0f, 0x81,
+	0xa9, 0x8c, 0xee, 0x2b, 0xd7, 0x77, 0x82, 0x57, 0x3a, 0xff, 0xad, 0x2c, 0x6d, 0x9d, 0x30, 0x3f,
+	0x10, 0x42, 0x25, 0xc0, 0x31, 0x59, 0x1e, 0xf8, 0xc3, 0x3c, 0x71, 0x49, 0x27, 0xcb, 0x03, 0x7f,
+	0x98, 0xa5, 0xdd, 0x85, 0xc6, 0x20, 0x0e, 0x7d, 0x9a, 0x3c, 0x73, 0x8c, 0x0b, 0xb4, 0x8c, 0x55,
+	0x44, 0xe1, 0x02, 0xd2, 0x23, 0x6c, 0xfe, 0x4b, 0x01, 0x1a, 0x73, 0x4e, 0x8b, 0x4a, 0x90, 0xca,
+	0x1b, 0xc9, 0x38, 0x0a, 0xa0, 0x40, 0x26, 0xba, 0x0b, 0xf7, 0xa1, 0xfa, 0x93, 0x1b, 0x72, 0x2b,
+	0x9f, 0xa2, 0xa8, 0x20, 0xac, 0xab, 0x40, 0xec, 0x36, 0x94, 0x89, 0x04, 0x59, 0xa8, 0x1d, 0x2a,
+	0x6c, 0xa3, 0x3a, 0xc0, 0x17, 0x56, 0xbe, 0xed, 0xc5, 0x58, 0x8c, 0xf4, 0x02, 0x29, 0x9c, 0xf4,
+	0x85, 0x95, 0x82, 0x52, 0xc8, 0xeb, 0x34, 0xff, 0x67, 0x01, 0x8c, 0x37, 0x29, 0x3b, 0xf6, 0xe4,
+	0x6d, 0x6f, 0x84, 0x54, 0x68, 0xf4, 0xa6, 0xf7, 0x41, 0x8f, 0xde, 0xf4, 0x3e, 0x48, 0x1d, 0xc1,
+	0xbc, 0xb7, 0x41, 0x9f, 0xbe, 0xf9, 0xc9, 0x8d, 0xda, 0xdb, 0xfc, 0xe7, 0x36, 0x3f, 0x53, 0xcb,
+	0x5e, 0x78, 0x7b, 0x2d, 0x9b, 0x9e, 0xcb, 0xa9, 0x17, 0x3a, 0x8b, 0xc9, 0x73, 0x39, 0x6a, 0xb2,
+	0x3b, 0xb0, 0x34, 0x7d, 0x48, 0xa3, 0x0c, 0x7e, 0xd9, 0x49, 0xde, 0xce, 0x3c, 0x80, 0x9a, 0x42,
+	0x26, 0x8f, 0x74, 0x6e, 0xa9, 0xbc, 0x05, 0x01, 0x93, 0x57, 0x39, 0x4f, 0xe1, 0xce, 0x2b, 0xee,
+	0x46, 0x33, 0x2f, 0x6b, 0x84, 0x7a, 0x5a, 0x53, 0x56, 0x51, 0x35, 0x92, 0xe4, 0x1f, 0xd4, 0xb4,
+	0x09, 0xcf, 0xbe, 0x7c, 0xeb, 0xab, 0xa0, 0x25, 0x9a, 0xf0, 0x8d, 0x2f, 0x82, 0x3e, 0x84, 0x55,
+	0x7c, 0xdc, 0x13, 0xc6, 0x7e, 0x86, 0xf7, 0xa0, 0x8b, 0x49, 0xae, 0x6f, 0xc6, 0x7e, 0xca, 0xf7,
+	0x1d, 0xa8, 0x27, 0xaf, 0xd8, 0xdc, 0xb1, 0x70, 0xac, 0x20, 0x8e, 0x74, 0xec, 0xac, 0xdf, 0xe8,
+	0xa1, 0x3e, 0x77, 0xce, 0xe2, 0x28, 0xf3, 0x6a, 0x8f, 0x0f, 0x82, 0x30, 0x12, 0x8e, 0x51, 0xd5,
+	0x32, 0x45, 0xd0, 0x96, 0x02, 0x36, 0xff, 0x56, 0x84, 0xfb, 0x3f, 0x6b, 0xf6, 0x70, 0x7b, 0x63,
+	0xd7, 0x77, 0xc7, 0x28, 0x25, 0x09, 0xc1, 0x74, 0xa9, 0xea, 0x56, 0x6f, 0x6a, 0x8a, 0x74, 0x84,
+	0x77, 0x90, 0x95, 0xe2, 0x5b, 0x64, 0x25, 0x73, 0xda, 0xa5, 0xfc, 0x69, 0xff, 0xcc, 0x59, 0x2d,
+	0xfc, 0xbf, 0xce, 0x6a, 0xf1, 0xad, 0x67, 0xd5, 0xfc, 0x4b, 0x11, 0x96, 0x53, 0x7e, 0xbd, 0xf9,
+	0xe9, 0xe5, 0x07, 0xf8, 0xb6, 0x52, 0x53, 0xe9, 0xea, 0xa0, 0x8a, 0x70, 0x96, 0x53, 0xb0, 0xaa,
+	0x0e, 0x9e, 0xbf, 0x21, 0x1a, 0x2d, 0x5d, 0x77, 0x49, 0x94, 0x77, 0xfd, 0xae, 0x21, 0xe9, 0xf5,
+	0xb8, 0x72, 0xe1, 0x97, 0xc5, 0x95, 0x8b, 0x6f, 0x89, 0x2b, 0x9b, 0x26, 0xdc, 0xff, 0xd9, 0x55,
+	0xb1, 0xdf, 0x01, 0x9b, 0xf0, 0xa1, 0x08, 0x9d, 0x38, 0xba, 0xb2, 0xa4, 0x08, 0x5f, 0xba, 0xb6,
+	0x48, 0xc2, 0xc0, 0xd5, 0x14, 0xd3, 0xd3, 0x88, 0xe6, 0xff, 0x16, 0xa0, 0x96, 0x7b, 0x20, 0xc0,
+	0x3e, 0x82, 0xca, 0x34, 0xd6, 0x48, 0x5e, 0x0d, 0xc3, 0xb4, 0x9c, 0x6b, 0x42, 0x1a, 0x73, 0xa0,
+	0x4d, 0x80, 0x94, 0xaf, 0x49, 0x0c, 0x05, 0xd3, 0xcd, 0x9a, 0x19, 0x2c, 0xfb, 0x02, 0xea, 0x69,
+	0x2b, 0x19, 0x5d, 0xe5, 0x3b, 0x56, 0xae, 0x71, 0xdb, 0x5c, 0x71, 0x72, 0x6d, 0xc9, 0x3a, 0xb0,
+	0x9e, 0x3b, 0xad, 0x5c, 0xa0, 0x89, 0xa6, 0x3e, 0xcb, 0x0a, 0x1d, 0xe7, 0x9a, 0x6b, 0xfe, 0x2c,
+	0x50, 0x36, 0xff, 0xad, 0x00, 0x8d, 0x39, 0xd4, 0x73, 0xa5, 0xe9, 0x01, 0x2c, 0x52, 0xe4, 0xac,
+	0x2b, 0x91, 0xb5, 0xdd, 0x5e, 0x26, 0x8e, 0x36, 0x15, 0x0e, 0x89, 0xe8, 0x02, 0x68, 0xd1, 0xa9,
+	0xed, 0x92, 0xb8, 0xa7, 0x44, 0x84, 0x63, 0x1f, 0xc2, 0x2d, 0x1d, 0x62, 0x6b, 0x91, 0x58, 0xd9,
+	0xfd, 0x41, 0xb5, 0x13, 0xc2, 0x04, 0xdf, 0xfc, 0x18, 0xaa, 0xd9, 0x69, 0xd0, 0x06, 0x6a, 0x94,
+	0x35, 0x0d, 0x5f, 0x41, 0x83, 0xd0, 0xfe, 0x3f, 0x82, 0x6a, 0x76, 0x4a, 0xb4, 0x89, 0xb9, 0xcb,
+	0xae, 0x7a, 0x54, 0xa2, 0xe9, 0x1d, 0x6f, 0x7e, 0x0d, 0xcb, 0xf9, 0xe9, 0xe7, 0x04, 0xc7, 0x5b,
+	0x50, 0x4e, 0xfd, 0x51, 0x5d, 0x94, 0x4e, 0xda, 0xcd, 0x87, 0xc0, 0x72, 0x52, 0xd3, 0xf1, 0x1d,
+	0xf1, 0x1a, 0x03, 0x71, 0x39, 0x22, 0x49, 0xd0, 0x59, 0x0e, 0xd5, 0x6a, 0xfe, 0xb5, 0x04, 0xeb,
+	0x73, 0x3d, 0x41, 0xec, 0xa1, 0xde, 0xc7, 0xe9, 0x44, 0xb3, 0x6e, 0xa1, 0xba, 0x4d, 0x9e, 0x48,
+	0x27, 0xbe, 0xa5, 0x36, 0x8a, 0xcb, 0xea, 0x8d, 0x74, 0x32, 0x10, 0xaa, 0x5b, 0xa1, 0xde, 0x90,
+	0xda, 0x23, 0xe1, 0xc4, 0x5e, 0x12, 0x9c, 0xd7, 0x08, 0xda, 0xd3, 0x40, 0xf6, 0x21, 0xd4, 0x15,
+	0x59, 0x28, 0x6c, 0x77, 0xe2, 0xd2, 0x83, 0x78, 0x15, 0xf4, 0xae, 0x10, 0xdc, 0x4c, 0xc1, 0x38,
+	0x62, 0xfa, 0xcc, 0x26, 0x9b, 0x6f, 0xaf, 0x25, 0x50, 0x15, 0x16, 0x3d, 0x04, 0x86, 0x2a, 0x59,
+	0x28, 0x1f, 0x47, 0x39, 0x45, 0x18, 0xf4, 0x62, 0xed, 0xa3, 0x4e, 0x18, 0x93, 0x47, 0x42, 0x39,
+	0x45, 0xca, 0x29, 0x0b, 0x85, 0xef, 0x58, 0xca, 0xe1, 0xc2, 0x4d, 0xe8, 0x8c, 0xf1, 0x32, 0xc1,
+	0x7b, 0x08, 0x3e, 0xe4, 0x57, 0xaa, 0xc0, 0x40, 0x94, 0xe4, 0x6c, 0x11, 0xa1, 0x32, 0x82, 0x35,
+	0x02, 0x1f, 0x07, 0xfe, 0x90, 0xe8, 0x3e, 0x86, 0x86, 0x23, 0x86, 0x21, 0xc7, 0x37, 0xe0, 0x19,
+	0x17, 0x6b, 0x89, 0x6c, 0x02, 0x4b, 0x51, 0x39, 0x1f, 0x6b, 0x4d, 0x6b, 0x9d, 0xfc, 0x8d, 0xff,
+	0x0a, 0x58, 0x2e, 0xed, 0x4c, 0xfb, 0xa4, 0x03, 0xc9, 0x5d, 0x7c, 0xf5, 0x2e, 0x37, 0x93, 0x5e,
+	0x26, 0x28, 0x6b, 0x4f, 0x93, 0xd6, 0xf9, 0x9c, 0x68, 0x71, 0x8e, 0xea, 0xa3, 0x31, 0x92, 0x14,
+	0x75, 0x16, 0x31, 0xb8, 0x49, 0x7f, 0x64, 0x78, 0xfc, 0x7f, 0x03, 0x00, 0x56, 0x81, 0xa1, 0x35,
+	0x04, 0x31, 0x00, 0x00,
 }
@@ -400,34 +400,34 @@ func init() {
 func init() { proto.RegisterFile("custom_evaluator.proto", fileDescriptor_14164f833d03200a) }
 
 var fileDescriptor_14164f833d03200a = []byte{
-	// 461 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xdd, 0x6e, 0xd3, 0x30,
-	0x18, 0x9d, 0xfb, 0xb3, 0xb5, 0x5f, 0xa6, 0xd4, 0x58, 0x1d, 0x54, 0x70, 0x13, 0x05, 0x84, 0x8a,
-	0x40, 0x41, 0x2a, 0x48, 0x5c, 0x8f, 0x29, 0xac, 0xd3, 0x20, 0x09, 0x4e, 0x60, 0xdc, 0x59, 0x59,
-	0xe7, 0xa1, 0x88, 0xb4, 0x8e, 0x6c, 0x07, 0xa9, 0xcf, 0xc0, 0x05, 0xaf, 0xc1, 0x7b, 0xf1, 0x22,
-	0xc8, 0xf9, 0x59, 0x82, 0xb4, 0xbb, 0xe3, 0x73, 0xbe, 0x63, 0x9f, 0x63, 0x1b, 0x1e, 0x6e, 0x4a,
-	0xa5, 0xc5, 0x96, 0xf1, 0x9f, 0x69, 0x5e, 0xa6, 0x5a, 0x48, 0xaf, 0x90, 0x42, 0x8b, 0xc7, 0x4e,
-	0x71, 0xfd, 0x5a, 0x73, 0xa5, 0x99, 0xd2, 0xa9, 0x2e, 0x55, 0x1f, 0xd7, 0x13, 0xee, 0x73, 0x38,
-	0xa2, 0x65, 0xce, 0x63, 0xae, 0xc9, 0x13, 0x18, 0xcb, 0x32, 0xe7, 0x6a, 0x81, 0x9c, 0xe1, 0xd2,
-	0x5a, 0x8d, 0x3d, 0x23, 0xd0, 0x9a, 0x73, 0x7f, 0x21, 0x18, 0x99, 0x35, 0xf9, 0x04, 0x8f, 0xaa,
-	0x5d, 0x24, 0x57, 0x65, 0xae, 0xd9, 0x46, 0x6c, 0x8b, 0x54, 0x66, 0x4a, 0xec, 0x5a, 0xdf, 0x89,
-	0x97, 0x70, 0xa5, 0x69, 0x25, 0x9f, 0xdd, 0xa9, 0xf4, 0x44, 0xdf, 0xc3, 0x2a, 0xf2, 0x16, 0x66,
-	0x66, 0x8b, 0x52, 0xf3, 0x9b, 0x26, 0xd8, 0x62, 0xe8, 0xa0, 0xa5, 0xbd, 0xb2, 0xaa, 0x6d, 0xe2,
-	0x8a, 0xa2, 0x76, 0x3b, 0x53, 0xaf, 0xdd, 0xbf, 0x08, 0xe6, 0xf7, 0x9d, 0x42, 0x5e, 0x02, 0x74,
-	0x89, 0x16, 0xc8, 0x41, 0x4b, 0x6b, 0x65, 0x79, 0xbd, 0x18, 0x3d, 0x99, 0x3c, 0x85, 0xe3, 0x42,
-	0x8a, 0x82, 0x4b, 0xbd, 0x67, 0x3f, 0xf8, 0x7e, 0x31, 0x70, 0xd0, 0x72, 0xba, 0x3e, 0xa0, 0x56,
-	0xcb, 0x5e, 0xf2, 0x3d, 0x79, 0x05, 0x0f, 0xfa, 0x7d, 0x6f, 0x33, 0x9e, 0xdf, 0x54, 0x11, 0xcd,
-	0xe4, 0xac, 0x2b, 0xf5, 0xc1, 0x08, 0xe4, 0xdd, 0xff, 0xb7, 0xc3, 0xa5, 0x14, 0xb2, 0xf1, 0x8c,
-	0x1a, 0xcf, 0xbc, 0xf3, 0xf8, 0x46, 0xae, 0x8c, 0xef, 0x09, 0xe0, 0xbe, 0x31, 0xdb, 0xdd, 0x0a,
-	0xf7, 0xcf, 0x00, 0xa0, 0xd7, 0xed, 0x19, 0x0c, 0x44, 0x51, 0x75, 0xb2, 0x57, 0xf3, 0x5e, 0x27,
-	0x2f, 0x2c, 0xb8, 0x34, 0x8f, 0x4e, 0x07, 0xa2, 0x30, 0xa5, 0x94, 0x96, 0xd9, 0xee, 0x3b, 0x33,
-	0x7f, 0x81, 0x77, 0xa5, 0x6a, 0xf6, 0xab, 0x21, 0xc9, 0x0b, 0x98, 0xed, 0xca, 0x2d, 0x97, 0xd9,
-	0x26, 0xcd, 0x9b, 0x39, 0x53, 0x09, 0xad, 0x0f, 0xa8, 0x7d, 0x27, 0x54, 0xa3, 0xee, 0x6f, 0x04,
-	0x93, 0xf6, 0x00, 0x62, 0x03, 0x84, 0x11, 0xfb, 0x12, 0x5c, 0x06, 0xe1, 0x55, 0x80, 0x0f, 0xc8,
-	0x14, 0xc6, 0x61, 0xc4, 0xfc, 0xcf, 0x18, 0x35, 0x30, 0xf0, 0xf1, 0xa0, 0x81, 0x1f, 0x13, 0x3c,
-	0x6c, 0xa1, 0x8f, 0x47, 0x0d, 0x3c, 0x4f, 0xf0, 0xb8, 0x85, 0x3e, 0x3e, 0x24, 0xc7, 0x30, 0x09,
-	0x23, 0x46, 0xfd, 0x73, 0xff, 0x1b, 0x3e, 0x22, 0x04, 0xec, 0x30, 0x62, 0x71, 0x72, 0x4a, 0x93,
-	0x98, 0x5d, 0x5d, 0x24, 0x6b, 0x3c, 0x21, 0x33, 0xb0, 0xc2, 0x88, 0x9d, 0x85, 0x41, 0x72, 0x7a,
-	0x11, 0xc4, 0x78, 0x6a, 0xae, 0xaa, 0x7b, 0xc4, 0x3a, 0xfd, 0xf5, 0x61, 0xf5, 0x9b, 0xdf, 0xfc,
-	0x0b, 0x00, 0x00, 0xff, 0xff, 0x06, 0x22, 0x01, 0xaf, 0x09, 0x03, 0x00, 0x00,
+	// 457 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xdb, 0x6e, 0xd3, 0x30,
+	0x18, 0x9e, 0x7b, 0xd8, 0xda, 0x3f, 0x53, 0x6a, 0xac, 0x0e, 0x2a, 0xb8, 0x89, 0x02, 0x42, 0x45,
+	0xa0, 0x20, 0x15, 0x24, 0xae, 0xc7, 0x14, 0xd6, 0x69, 0x90, 0x04, 0x27, 0x30, 0xee, 0xac, 0xac,
+	0xf3, 0x50, 0x44, 0x5a, 0x47, 0xb6, 0x83, 0xd4, 0x67, 0xe0, 0x82, 0xd7, 0xe0, 0xbd, 0x78, 0x11,
+	0xe4, 0x1c, 0x96, 0x20, 0xed, 0xee, 0xf3, 0x77, 0xb0, 0xff, 0xcf, 0x36, 0x3c, 0xdc, 0x94, 0x4a,
+	0x8b, 0x2d, 0xe3, 0x3f, 0xd3, 0xbc, 0x4c, 0xb5, 0x90, 0x5e, 0x21, 0x85, 0x16, 0x8f, 0x9d, 0xe2,
+	0xfa, 0xb5, 0xe6, 0x4a, 0x33, 0xa5, 0x53, 0x5d, 0xaa, 0x3e, 0xae, 0x1d, 0xee, 0x73, 0x38, 0xa2,
+	0x65, 0xce, 0x63, 0xae, 0xc9, 0x13, 0x18, 0xcb, 0x32, 0xe7, 0x6a, 0x81, 0x9c, 0xe1, 0xd2, 0x5a,
+	0x8d, 0x3d, 0x23, 0xd0, 0x9a, 0x73, 0x7f, 0x21, 0x18, 0x99, 0x35, 0xf9, 0x04, 0x8f, 0xaa, 0x5d,
+	0x24, 0x57, 0x65, 0xae, 0xd9, 0x46, 0x6c, 0x8b, 0x54, 0x66, 0x4a, 0xec, 0xda, 0xdc, 0x89, 0x97,
+	0x70, 0xa5, 0x69, 0x25, 0x9f, 0xdd, 0xa9, 0xf4, 0x44, 0xdf, 0xc3, 0x2a, 0xf2, 0x16, 0x66, 0x66,
+	0x8b, 0x52, 0xf3, 0x9b, 0x66, 0xb0, 0xc5, 0xd0, 0x41, 0x4b, 0x7b, 0x65, 0x55, 0xdb, 0xc4, 0x15,
+	0x45, 0xed, 0xd6, 0x53, 0xaf, 0xdd, 0xbf, 0x08, 0xe6, 0xf7, 0x9d, 0x42, 0x5e, 0x02, 0x74, 0x13,
+	0x2d, 0x90, 0x83, 0x96, 0xd6, 0xca, 0xf2, 0x7a, 0x63, 0xf4, 0x64, 0xf2, 0x14, 0x8e, 0x0b, 0x29,
+	0x0a, 0x2e, 0xf5, 0x9e, 0xfd, 0xe0, 0xfb, 0xc5, 0xc0, 0x41, 0xcb, 0xe9, 0xfa, 0x80, 0x5a, 0x2d,
+	0x7b, 0xc9, 0xf7, 0xe4, 0x15, 0x3c, 0xe8, 0xf7, 0xbd, 0xcd, 0x78, 0x7e, 0xb3, 0x18, 0x36, 0xce,
+	0x59, 0x57, 0xea, 0x83, 0x11, 0xc8, 0xbb, 0xff, 0x6f, 0x87, 0x4b, 0x29, 0x64, 0x93, 0x19, 0x35,
+	0x99, 0x79, 0x97, 0xf1, 0x8d, 0x5c, 0x05, 0xdf, 0x13, 0xc0, 0xfd, 0x60, 0xb6, 0xbb, 0x15, 0xee,
+	0x9f, 0x01, 0x40, 0xaf, 0xdb, 0x33, 0x18, 0x88, 0xa2, 0xea, 0x64, 0xaf, 0xe6, 0xbd, 0x4e, 0x5e,
+	0x58, 0x70, 0x69, 0x1e, 0x9d, 0x0e, 0x44, 0x61, 0x4a, 0x29, 0x2d, 0xb3, 0xdd, 0x77, 0x66, 0xfe,
+	0x02, 0xef, 0x4a, 0xd5, 0xec, 0x57, 0x43, 0x92, 0x17, 0x30, 0xdb, 0x95, 0x5b, 0x2e, 0xb3, 0x4d,
+	0x9a, 0x37, 0x3e, 0x53, 0x09, 0xad, 0x0f, 0xa8, 0x7d, 0x27, 0x54, 0x56, 0xf7, 0x37, 0x82, 0x49,
+	0x7b, 0x00, 0xb1, 0x01, 0xc2, 0x88, 0x7d, 0x09, 0x2e, 0x83, 0xf0, 0x2a, 0xc0, 0x07, 0x64, 0x0a,
+	0xe3, 0x30, 0x62, 0xfe, 0x67, 0x8c, 0x1a, 0x18, 0xf8, 0x78, 0xd0, 0xc0, 0x8f, 0x09, 0x1e, 0xb6,
+	0xd0, 0xc7, 0xa3, 0x06, 0x9e, 0x27, 0x78, 0xdc, 0x42, 0x1f, 0x1f, 0x92, 0x63, 0x98, 0x84, 0x11,
+	0xa3, 0xfe, 0xb9, 0xff, 0x0d, 0x1f, 0x11, 0x02, 0x76, 0x18, 0xb1, 0x38, 0x39, 0xa5, 0x49, 0xcc,
+	0xae, 0x2e, 0x92, 0x35, 0x9e, 0x90, 0x19, 0x58, 0x61, 0xc4, 0xce, 0xc2, 0x20, 0x39, 0xbd, 0x08,
+	0x62, 0x3c, 0x35, 0x57, 0xd5, 0x3d, 0x62, 0x3d, 0xfd, 0xf5, 0x61, 0xf5, 0x9b, 0xdf, 0xfc, 0x1b,
+	0x00, 0x06, 0x22, 0x01, 0xaf, 0x09, 0x03, 0x00, 0x00,
 }
@@ -440,7 +440,11 @@ type Column struct {
 	// Additional custom headers like commit, image used, etc.
 	Extra []string `protobuf:"bytes,4,rep,name=extra,proto3" json:"extra,omitempty"`
 	// Custom hotlist ids.
-	HotlistIds           string   `protobuf:"bytes,5,opt,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	HotlistIds string `protobuf:"bytes,5,opt,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	// Number of cells omitted from rows dropped by the ignore_pass and
+	// ignore_skip options of the test group.
+	IgnoredPasses        int32    `protobuf:"varint,6,opt,name=ignored_passes,json=ignoredPasses,proto3" json:"ignored_passes,omitempty"`
+	IgnoredSkips         int32    `protobuf:"varint,7,opt,name=ignored_skips,json=ignoredSkips,proto3" json:"ignored_skips,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -506,6 +510,20 @@ func (m *Column) GetHotlistIds() string {
 	return ""
 }
 
+func (m *Column) GetIgnoredPasses() int32 {
+	if m != nil {
+		return m.IgnoredPasses
+	}
+	return 0
+}
+
+func (m *Column) GetIgnoredSkips() int32 {
+	if m != nil {
+		return m.IgnoredSkips
+	}
+	return 0
+}
+
 // TestGrid rows (also known as TestRow)
 type Row struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -535,10 +553,16 @@ type Row struct {
 	// An alert for the failure if there's a recent failure for this test case.
 	AlertInfo *AlertInfo `protobuf:"bytes,11,opt,name=alert_info,json=alertInfo,proto3" json:"alert_info,omitempty"`
 	// Values of a user-defined property found in test results for this row.
-	UserProperty         []string `protobuf:"bytes,12,rep,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UserProperty []string `protobuf:"bytes,12,rep,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// Properties of each result, such as a link to its log.
+	// Parallel to messages when any result of the row has properties.
+	Properties []*Property `protobuf:"bytes,13,rep,name=properties,proto3" json:"properties,omitempty"`
+	// The consecutive failures of the row since it last passed or flaked,
+	// whether or not they alert. Unset when its latest result passed.
+	FailureStreak        *AlertInfo `protobuf:"bytes,14,opt,name=failure_streak,json=failureStreak,proto3" json:"failure_streak,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *Row) Reset()         { *m = Row{} }
@@ -643,6 +667,60 @@ func (m *Row) GetUserProperty() []string {
 	return nil
 }
 
+func (m *Row) GetProperties() []*Property {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Row) GetFailureStreak() *AlertInfo {
+	if m != nil {
+		return m.FailureStreak
+	}
+	return nil
+}
+
+// Named values of a result.
+type Property struct {
+	Property             map[string]string `protobuf:"bytes,1,rep,name=property,proto3" json:"property,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Property) Reset()         { *m = Property{} }
+func (m *Property) String() string { return proto.CompactTextString(m) }
+func (*Property) ProtoMessage()    {}
+func (*Property) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{7}
+}
+
+func (m *Property) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Property.Unmarshal(m, b)
+}
+func (m *Property) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Property.Marshal(b, m, deterministic)
+}
+func (m *Property) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Property.Merge(m, src)
+}
+func (m *Property) XXX_Size() int {
+	return xxx_messageInfo_Property.Size(m)
+}
+func (m *Property) XXX_DiscardUnknown() {
+	xxx_messageInfo_Property.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Property proto.InternalMessageInfo
+
+func (m *Property) GetProperty() map[string]string {
+	if m != nil {
+		return m.Property
+	}
+	return nil
+}
+
 // A single table of test results backing a dashboard tab.
 type Grid struct {
 	// A cycle of test results, not including the results. In the TestGrid client,
@@ -664,17 +742,20 @@ type Grid struct {
 	// Clusters of failures for a TestResultTable instance.
 	Cluster []*Cluster `protobuf:"bytes,10,rep,name=cluster,proto3" json:"cluster,omitempty"`
 	// Most recent timestamp that clusters have processed.
-	MostRecentClusterTimestamp float64  `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	MostRecentClusterTimestamp float64 `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
+	// The width of each cell in pixels, from the width in the base_options of
+	// the tab. Only set in the state of tabs.
+	CellWidth            int32    `protobuf:"varint,12,opt,name=cell_width,json=cellWidth,proto3" json:"cell_width,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Grid) Reset()         { *m = Grid{} }
 func (m *Grid) String() string { return proto.CompactTextString(m) }
 func (*Grid) ProtoMessage()    {}
 func (*Grid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{7}
+	return fileDescriptor_a888679467bb7853, []int{8}
 }
 
 func (m *Grid) XXX_Unmarshal(b []byte) error {
@@ -758,6 +839,13 @@ func (m *Grid) GetMostRecentClusterTimestamp() float64 {
 	return 0
 }
 
+func (m *Grid) GetCellWidth() int32 {
+	if m != nil {
+		return m.CellWidth
+	}
+	return 0
+}
+
 // A cluster of failures grouped by test status and message for a test results
 // table.
 type Cluster struct {
@@ -776,7 +864,7 @@ func (m *Cluster) Reset()         { *m = Cluster{} }
 func (m *Cluster) String() string { return proto.CompactTextString(m) }
 func (*Cluster) ProtoMessage()    {}
 func (*Cluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{8}
+	return fileDescriptor_a888679467bb7853, []int{9}
 }
 
 func (m *Cluster) XXX_Unmarshal(b []byte) error {
@@ -833,7 +921,7 @@ func (m *ClusterRow) Reset()         { *m = ClusterRow{} }
 func (m *ClusterRow) String() string { return proto.CompactTextString(m) }
 func (*ClusterRow) ProtoMessage()    {}
 func (*ClusterRow) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{9}
+	return fileDescriptor_a888679467bb7853, []int{10}
 }
 
 func (m *ClusterRow) XXX_Unmarshal(b []byte) error {
@@ -877,6 +965,8 @@ func init() {
 	proto.RegisterType((*TestMetadata)(nil), "TestMetadata")
 	proto.RegisterType((*Column)(nil), "Column")
 	proto.RegisterType((*Row)(nil), "Row")
+	proto.RegisterType((*Property)(nil), "Property")
+	proto.RegisterMapType((map[string]string)(nil), "Property.PropertyEntry")
 	proto.RegisterType((*Grid)(nil), "Grid")
 	proto.RegisterType((*Cluster)(nil), "Cluster")
 	proto.RegisterType((*ClusterRow)(nil), "ClusterRow")
@@ -885,73 +975,80 @@ func init() {
 func init() { proto.RegisterFile("state.proto", fileDescriptor_a888679467bb7853) }
 
 var fileDescriptor_a888679467bb7853 = []byte{
-	// 1077 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xdf, 0x8f, 0xdb, 0xc4,
-	0x13, 0x97, 0xf3, 0xdb, 0xe3, 0xe4, 0x2e, 0xdd, 0x6f, 0xbf, 0x95, 0x09, 0xaa, 0x9a, 0xba, 0x08,
-	0x02, 0x02, 0x9f, 0x14, 0x1e, 0x40, 0x15, 0x3c, 0x94, 0xa3, 0x54, 0x39, 0x71, 0x55, 0xb5, 0xbd,
-	0x3e, 0x5b, 0x8e, 0xbd, 0x97, 0x5a, 0x75, 0xbc, 0xd6, 0xee, 0x9a, 0x5c, 0x9e, 0xf9, 0x1b, 0x90,
-	0xe0, 0x3f, 0xe1, 0xcf, 0x43, 0x33, 0xbb, 0x4e, 0x72, 0x15, 0x12, 0x4f, 0xf1, 0x7c, 0x66, 0x76,
-	0x66, 0x76, 0x7e, 0x7c, 0x36, 0x10, 0x68, 0x93, 0x1a, 0x11, 0xd7, 0x4a, 0x1a, 0x39, 0x7b, 0xb2,
-	0x91, 0x72, 0x53, 0x8a, 0x0b, 0x92, 0xd6, 0xcd, 0xed, 0x85, 0x29, 0xb6, 0x42, 0x9b, 0x74, 0x5b,
-	0x3b, 0x83, 0x47, 0xf5, 0xfa, 0x22, 0x93, 0xd5, 0x6d, 0xb1, 0x71, 0x3f, 0x16, 0x8f, 0x5e, 0xc3,
-	0xe0, 0x5a, 0x18, 0x55, 0x64, 0x8c, 0x41, 0xaf, 0x4a, 0xb7, 0x22, 0xf4, 0xe6, 0xde, 0xc2, 0xe7,
-	0xf4, 0xcd, 0x42, 0x18, 0x16, 0x55, 0x5e, 0x64, 0x42, 0x87, 0x9d, 0x79, 0x77, 0xd1, 0xe7, 0xad,
-	0xc8, 0x1e, 0xc1, 0xe0, 0xb7, 0xb4, 0x6c, 0x84, 0x0e, 0xbb, 0xf3, 0xee, 0xc2, 0xe3, 0x4e, 0x8a,
-	0xde, 0xc1, 0xf9, 0xbb, 0x3a, 0x4f, 0x8d, 0x78, 0xf3, 0x3e, 0xd5, 0xe2, 0xe7, 0xd4, 0xa4, 0xec,
-	0x31, 0x40, 0x8d, 0x42, 0x72, 0xe2, 0xde, 0x27, 0xe4, 0x35, 0xc6, 0x78, 0x06, 0x13, 0xab, 0xd6,
-	0x22, 0x93, 0x55, 0x8e, 0x91, 0xbc, 0x85, 0xc7, 0xc7, 0x04, 0xbe, 0xb5, 0x58, 0x74, 0x05, 0x60,
-	0xdd, 0xae, 0xaa, 0x5b, 0xc9, 0x7e, 0x80, 0x07, 0x0d, 0x49, 0x89, 0x3d, 0x99, 0xa7, 0x26, 0x0d,
-	0xbd, 0x79, 0x77, 0x11, 0x2c, 0xa7, 0xf1, 0x47, 0xe1, 0xf9, 0x79, 0x73, 0x1f, 0x88, 0xfe, 0xec,
-	0x83, 0xff, 0xa2, 0x14, 0xca, 0x90, 0xaf, 0xc7, 0x00, 0xb7, 0x69, 0x51, 0x26, 0x99, 0x6c, 0x2a,
-	0x43, 0xd9, 0xf5, 0xb9, 0x8f, 0xc8, 0x25, 0x02, 0x2c, 0x82, 0x09, 0xa9, 0xd7, 0x4d, 0x51, 0xe6,
-	0x49, 0x91, 0x53, 0x76, 0x3e, 0x0f, 0x10, 0xfc, 0x09, 0xb1, 0x55, 0xce, 0xbe, 0x03, 0x3a, 0x90,
-	0x60, 0xcd, 0xc3, 0xee, 0xdc, 0x5b, 0x04, 0xcb, 0x59, 0x6c, 0x1b, 0x12, 0xb7, 0x0d, 0x89, 0x6f,
-	0xda, 0x86, 0xf0, 0x11, 0x1a, 0xa3, 0xc8, 0xe6, 0x30, 0xb6, 0x07, 0x85, 0x36, 0xe8, 0xbb, 0x47,
-	0xbe, 0x29, 0x9f, 0x1b, 0xa1, 0xcd, 0x2a, 0xc7, 0xf0, 0x75, 0xaa, 0xf5, 0x31, 0x7c, 0xdf, 0x86,
-	0x47, 0xf0, 0x24, 0x3c, 0xd9, 0x50, 0xf8, 0xc1, 0x7f, 0x87, 0x47, 0x63, 0x0a, 0xff, 0x05, 0x9c,
-	0x63, 0xa8, 0x46, 0x89, 0x64, 0x2b, 0xb4, 0x4e, 0x37, 0x22, 0x1c, 0x92, 0xfb, 0x33, 0x07, 0x5f,
-	0x5b, 0x14, 0x6b, 0x64, 0x13, 0x28, 0x8b, 0xea, 0x43, 0x38, 0xb2, 0x1d, 0x24, 0xe4, 0xd7, 0xa2,
-	0xfa, 0xc0, 0x3e, 0x87, 0xf3, 0xa3, 0x3a, 0x31, 0xe2, 0xce, 0x84, 0x3e, 0xd9, 0x4c, 0x0e, 0x36,
-	0x37, 0xe2, 0xce, 0xb0, 0xcf, 0xe0, 0xcc, 0xda, 0x35, 0xaa, 0xb4, 0x66, 0x40, 0x66, 0x63, 0x42,
-	0xdf, 0xa9, 0x92, 0xac, 0x2e, 0xe0, 0x61, 0x99, 0x52, 0x45, 0xee, 0x17, 0x3e, 0x20, 0xdb, 0x07,
-	0x56, 0xf7, 0xcb, 0x49, 0xf9, 0xbf, 0x81, 0xff, 0x9d, 0x1e, 0x68, 0x8b, 0x79, 0x46, 0xf6, 0xd3,
-	0xa3, 0xbd, 0x2b, 0xe9, 0x73, 0x80, 0x5a, 0xc9, 0x5a, 0x28, 0x53, 0x08, 0x1d, 0x8e, 0x69, 0x6a,
-	0x66, 0xf1, 0x61, 0x20, 0xe2, 0x37, 0x07, 0xe5, 0xcb, 0xca, 0xa8, 0x3d, 0x3f, 0xb1, 0x66, 0x4f,
-	0x20, 0x78, 0x2f, 0x4d, 0x59, 0x50, 0x04, 0x1d, 0x4e, 0xe6, 0x5d, 0xec, 0x97, 0x83, 0x56, 0xb9,
-	0x9e, 0xfd, 0x08, 0xe7, 0x1f, 0x9d, 0x67, 0x53, 0xe8, 0x7e, 0x10, 0x7b, 0x37, 0xf7, 0xf8, 0xc9,
-	0x1e, 0x42, 0x9f, 0xb6, 0xc5, 0xcd, 0x92, 0x15, 0x9e, 0x77, 0xbe, 0xf7, 0xa2, 0x3f, 0x3c, 0x18,
-	0x63, 0x9a, 0xd7, 0xc2, 0xa4, 0x38, 0xd4, 0xec, 0x53, 0xf0, 0xe9, 0x3e, 0x27, 0xab, 0x33, 0x42,
-	0xa0, 0xdd, 0x9c, 0x75, 0xb3, 0x49, 0x32, 0xb9, 0xad, 0x65, 0x25, 0x2a, 0x43, 0xfe, 0xfa, 0x58,
-	0xce, 0xcd, 0x65, 0x8b, 0x61, 0x30, 0xb9, 0xab, 0x84, 0xa2, 0xc1, 0xf4, 0xb9, 0x15, 0xd8, 0x19,
-	0x74, 0xb2, 0x2c, 0xec, 0x51, 0xfe, 0x9d, 0x2c, 0xc3, 0x0e, 0x0b, 0xa5, 0xa4, 0x4a, 0xcc, 0xbe,
-	0x16, 0x6e, 0xc8, 0x7c, 0x42, 0x6e, 0xf6, 0xb5, 0x88, 0x7e, 0xf7, 0x60, 0x70, 0x29, 0xcb, 0x66,
-	0x5b, 0xa1, 0x3f, 0x6a, 0x89, 0xcb, 0xc6, 0x0a, 0x07, 0xf2, 0xe8, 0xdc, 0x27, 0x0f, 0x6d, 0x52,
-	0x65, 0x44, 0x4e, 0xb1, 0x3d, 0xde, 0x8a, 0xe8, 0x43, 0xdc, 0x19, 0x95, 0xba, 0x04, 0xac, 0xf0,
-	0x71, 0x71, 0x6d, 0x12, 0x27, 0xc5, 0x8d, 0xfe, 0xee, 0x40, 0x97, 0xcb, 0xdd, 0xbf, 0x32, 0xd5,
-	0x19, 0x74, 0x0e, 0xcb, 0xd9, 0x29, 0x72, 0x0c, 0xae, 0x84, 0x6e, 0x4a, 0x63, 0x09, 0xaa, 0xcf,
-	0x5b, 0x91, 0x7d, 0x02, 0xa3, 0x4c, 0x94, 0x25, 0xc5, 0xb0, 0xf1, 0x87, 0x28, 0xaf, 0x72, 0xcd,
-	0x66, 0x30, 0x72, 0x8b, 0x80, 0xe1, 0x51, 0x75, 0x90, 0x91, 0xf0, 0xb6, 0x44, 0x94, 0xe1, 0x90,
-	0x34, 0x4e, 0x62, 0x4f, 0x61, 0x68, 0xbf, 0x74, 0x38, 0xa2, 0x59, 0x1a, 0xc6, 0x96, 0x50, 0x79,
-	0x8b, 0xe3, 0x75, 0x8b, 0x4c, 0x56, 0x3a, 0xf4, 0xed, 0x75, 0x49, 0x60, 0xff, 0x87, 0x01, 0x76,
-	0xaf, 0xc8, 0x43, 0xb0, 0xf0, 0xba, 0xd9, 0xac, 0x72, 0xf6, 0x25, 0x40, 0x8a, 0xb3, 0x98, 0x14,
-	0xd5, 0xad, 0xa4, 0xa1, 0x0f, 0x96, 0x70, 0x1c, 0x4f, 0xee, 0xa7, 0x07, 0xea, 0x7a, 0x06, 0x93,
-	0x46, 0x0b, 0x95, 0xb8, 0x01, 0xdd, 0xd3, 0x30, 0xfb, 0x7c, 0x8c, 0xa0, 0x9b, 0xc2, 0xfd, 0x55,
-	0x6f, 0x34, 0x98, 0x0e, 0xa3, 0xbf, 0xba, 0xd0, 0x7b, 0xa5, 0x8a, 0x1c, 0xd3, 0xcd, 0xa8, 0x91,
-	0xda, 0x11, 0xe6, 0x30, 0xb6, 0x8d, 0xe5, 0x2d, 0xce, 0x42, 0xe8, 0x29, 0xb9, 0xb3, 0x8c, 0x1f,
-	0x2c, 0x7b, 0x31, 0x97, 0x3b, 0x4e, 0x88, 0x5d, 0x4d, 0x6d, 0x12, 0x9b, 0xe0, 0xf6, 0x1e, 0xe7,
-	0x79, 0xb8, 0x9a, 0xda, 0x50, 0xa2, 0xd7, 0x2d, 0xc1, 0x45, 0x30, 0xb0, 0xaf, 0x0d, 0x51, 0x1b,
-	0x5e, 0x04, 0xa7, 0xfb, 0x95, 0x92, 0x4d, 0xcd, 0x9d, 0x86, 0x7d, 0x05, 0x74, 0x90, 0x3c, 0x25,
-	0x96, 0xab, 0x73, 0xa2, 0x31, 0x8f, 0x9f, 0xa3, 0x02, 0x1d, 0x59, 0x4e, 0xcf, 0xd9, 0xd7, 0x10,
-	0x38, 0xe2, 0xa7, 0xea, 0xd8, 0x82, 0x07, 0xf1, 0xf1, 0x69, 0xe0, 0xd0, 0x1c, 0x9f, 0x89, 0x25,
-	0x4c, 0x68, 0x79, 0xb6, 0x6e, 0x9b, 0xa8, 0xfe, 0xc1, 0x72, 0x12, 0x9f, 0xae, 0x18, 0x1f, 0x9b,
-	0xd3, 0x85, 0x8b, 0x60, 0x98, 0x95, 0x8d, 0x36, 0x42, 0x51, 0x5b, 0x82, 0xe5, 0x28, 0xbe, 0xb4,
-	0x32, 0x6f, 0x15, 0xec, 0x05, 0x3c, 0xde, 0x4a, 0x6d, 0x12, 0x25, 0x32, 0x51, 0x99, 0xc4, 0xc1,
-	0xc9, 0xe1, 0xc9, 0xa5, 0xae, 0x79, 0x7c, 0x86, 0x46, 0x9c, 0x6c, 0x9c, 0x8b, 0x03, 0x09, 0x5f,
-	0xf5, 0x46, 0xfd, 0xe9, 0xe0, 0xaa, 0x37, 0x1a, 0x4e, 0x47, 0x91, 0x82, 0xa1, 0xd3, 0xe3, 0x0a,
-	0x50, 0xc6, 0xf8, 0xb4, 0x37, 0xda, 0xbd, 0x46, 0x80, 0xd0, 0x5b, 0x42, 0x70, 0xac, 0x5b, 0xaa,
-	0xb6, 0xb3, 0xde, 0x8a, 0x58, 0x9a, 0x36, 0x11, 0x25, 0x77, 0x34, 0xf4, 0x58, 0x9a, 0x36, 0x79,
-	0xb9, 0xe3, 0x90, 0x1d, 0xbe, 0xa3, 0x97, 0x00, 0x47, 0x0d, 0x7b, 0x0a, 0xe3, 0xbc, 0xd0, 0x75,
-	0x99, 0xee, 0x4f, 0x89, 0x26, 0x70, 0x18, 0x71, 0x0d, 0xce, 0x70, 0x95, 0x8b, 0x3b, 0xf7, 0x3f,
-	0xc0, 0x0a, 0xeb, 0x01, 0xbd, 0x2f, 0xdf, 0xfe, 0x13, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x81, 0x5f,
-	0xf8, 0x8c, 0x08, 0x00, 0x00,
+	// 1191 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x6d, 0x6f, 0xdc, 0xc4,
+	0x13, 0x97, 0xef, 0xd1, 0x1e, 0xdf, 0x5d, 0xd2, 0xfd, 0xf7, 0x5f, 0xcc, 0xa1, 0xaa, 0x57, 0xf3,
+	0x14, 0x10, 0x38, 0xe2, 0xfa, 0x02, 0x54, 0xe0, 0x45, 0x09, 0xa5, 0x4a, 0x44, 0xaa, 0x6a, 0x93,
+	0x8a, 0x97, 0x96, 0x63, 0x6f, 0xae, 0x56, 0x7c, 0xb6, 0xb5, 0xbb, 0xe6, 0x72, 0x12, 0x5f, 0x03,
+	0xc4, 0x47, 0xe1, 0x5b, 0xf0, 0x95, 0xd0, 0xcc, 0xae, 0x9d, 0xbb, 0x82, 0x84, 0x78, 0x75, 0x3b,
+	0xbf, 0x99, 0x9d, 0x19, 0xcf, 0xfe, 0x66, 0xe6, 0xc0, 0x57, 0x3a, 0xd1, 0x22, 0xaa, 0x65, 0xa5,
+	0xab, 0xf9, 0xa3, 0x55, 0x55, 0xad, 0x0a, 0x71, 0x4c, 0xd2, 0x55, 0x73, 0x7d, 0xac, 0xf3, 0xb5,
+	0x50, 0x3a, 0x59, 0xd7, 0xd6, 0xe0, 0x41, 0x7d, 0x75, 0x9c, 0x56, 0xe5, 0x75, 0xbe, 0xb2, 0x3f,
+	0x06, 0x0f, 0x5f, 0xc2, 0xe8, 0x5c, 0x68, 0x99, 0xa7, 0x8c, 0xc1, 0xa0, 0x4c, 0xd6, 0x22, 0x70,
+	0x16, 0xce, 0x91, 0xc7, 0xe9, 0xcc, 0x02, 0x18, 0xe7, 0x65, 0x96, 0xa7, 0x42, 0x05, 0xbd, 0x45,
+	0xff, 0x68, 0xc8, 0x5b, 0x91, 0x3d, 0x80, 0xd1, 0xcf, 0x49, 0xd1, 0x08, 0x15, 0xf4, 0x17, 0xfd,
+	0x23, 0x87, 0x5b, 0x29, 0x7c, 0x0d, 0x07, 0xaf, 0xeb, 0x2c, 0xd1, 0xe2, 0xd5, 0x9b, 0x44, 0x89,
+	0xef, 0x13, 0x9d, 0xb0, 0x87, 0x00, 0x35, 0x0a, 0xf1, 0x8e, 0x7b, 0x8f, 0x90, 0x97, 0x18, 0xe3,
+	0x7d, 0x98, 0x1a, 0xb5, 0x12, 0x69, 0x55, 0x66, 0x18, 0xc9, 0x39, 0x72, 0xf8, 0x84, 0xc0, 0x0b,
+	0x83, 0x85, 0x67, 0x00, 0xc6, 0xed, 0x69, 0x79, 0x5d, 0xb1, 0x6f, 0xe0, 0x5e, 0x43, 0x52, 0x6c,
+	0x6e, 0x66, 0x89, 0x4e, 0x02, 0x67, 0xd1, 0x3f, 0xf2, 0x97, 0x87, 0xd1, 0x5b, 0xe1, 0xf9, 0x41,
+	0xb3, 0x0f, 0x84, 0xbf, 0x0f, 0xc1, 0x7b, 0x56, 0x08, 0xa9, 0xc9, 0xd7, 0x43, 0x80, 0xeb, 0x24,
+	0x2f, 0xe2, 0xb4, 0x6a, 0x4a, 0x4d, 0xd9, 0x0d, 0xb9, 0x87, 0xc8, 0x09, 0x02, 0x2c, 0x84, 0x29,
+	0xa9, 0xaf, 0x9a, 0xbc, 0xc8, 0xe2, 0x3c, 0xa3, 0xec, 0x3c, 0xee, 0x23, 0xf8, 0x1d, 0x62, 0xa7,
+	0x19, 0xfb, 0x12, 0xe8, 0x42, 0x8c, 0x35, 0x0f, 0xfa, 0x0b, 0xe7, 0xc8, 0x5f, 0xce, 0x23, 0xf3,
+	0x20, 0x51, 0xfb, 0x20, 0xd1, 0x65, 0xfb, 0x20, 0xdc, 0x45, 0x63, 0x14, 0xd9, 0x02, 0x26, 0xe6,
+	0xa2, 0x50, 0x1a, 0x7d, 0x0f, 0xc8, 0x37, 0xe5, 0x73, 0x29, 0x94, 0x3e, 0xcd, 0x30, 0x7c, 0x9d,
+	0x28, 0x75, 0x17, 0x7e, 0x68, 0xc2, 0x23, 0xb8, 0x13, 0x9e, 0x6c, 0x28, 0xfc, 0xe8, 0xdf, 0xc3,
+	0xa3, 0x31, 0x85, 0xff, 0x18, 0x0e, 0x30, 0x54, 0x23, 0x45, 0xbc, 0x16, 0x4a, 0x25, 0x2b, 0x11,
+	0x8c, 0xc9, 0xfd, 0xcc, 0xc2, 0xe7, 0x06, 0xc5, 0x1a, 0x99, 0x04, 0x8a, 0xbc, 0xbc, 0x09, 0x5c,
+	0xf3, 0x82, 0x84, 0xfc, 0x98, 0x97, 0x37, 0xec, 0x23, 0x38, 0xb8, 0x53, 0xc7, 0x5a, 0xdc, 0xea,
+	0xc0, 0x23, 0x9b, 0x69, 0x67, 0x73, 0x29, 0x6e, 0x35, 0xfb, 0x00, 0x66, 0xc6, 0xae, 0x91, 0x85,
+	0x31, 0x03, 0x32, 0x9b, 0x10, 0xfa, 0x5a, 0x16, 0x64, 0x75, 0x0c, 0xf7, 0x8b, 0x84, 0x2a, 0xb2,
+	0x5f, 0x78, 0x9f, 0x6c, 0xef, 0x19, 0xdd, 0x0f, 0x3b, 0xe5, 0xff, 0x1c, 0xfe, 0xb7, 0x7b, 0xa1,
+	0x2d, 0xe6, 0x8c, 0xec, 0x0f, 0xef, 0xec, 0x6d, 0x49, 0x9f, 0x02, 0xd4, 0xb2, 0xaa, 0x85, 0xd4,
+	0xb9, 0x50, 0xc1, 0x84, 0x58, 0x33, 0x8f, 0x3a, 0x42, 0x44, 0xaf, 0x3a, 0xe5, 0xf3, 0x52, 0xcb,
+	0x2d, 0xdf, 0xb1, 0x66, 0x8f, 0xc0, 0x7f, 0x53, 0xe9, 0x22, 0xa7, 0x08, 0x2a, 0x98, 0x2e, 0xfa,
+	0xf8, 0x5e, 0x16, 0x3a, 0xcd, 0xd4, 0xfc, 0x5b, 0x38, 0x78, 0xeb, 0x3e, 0x3b, 0x84, 0xfe, 0x8d,
+	0xd8, 0x5a, 0xde, 0xe3, 0x91, 0xdd, 0x87, 0x21, 0x75, 0x8b, 0xe5, 0x92, 0x11, 0x9e, 0xf6, 0xbe,
+	0x72, 0xc2, 0x5f, 0x1d, 0x98, 0x60, 0x9a, 0xe7, 0x42, 0x27, 0x48, 0x6a, 0xf6, 0x1e, 0x78, 0xf4,
+	0x3d, 0x3b, 0xad, 0xe3, 0x22, 0xd0, 0x76, 0xce, 0x55, 0xb3, 0x8a, 0xd3, 0x6a, 0x5d, 0x57, 0xa5,
+	0x28, 0x35, 0xf9, 0x1b, 0x62, 0x39, 0x57, 0x27, 0x2d, 0x86, 0xc1, 0xaa, 0x4d, 0x29, 0x24, 0x11,
+	0xd3, 0xe3, 0x46, 0x60, 0x33, 0xe8, 0xa5, 0x69, 0x30, 0xa0, 0xfc, 0x7b, 0x69, 0x8a, 0x2f, 0x2c,
+	0xa4, 0xac, 0x64, 0xac, 0xb7, 0xb5, 0xb0, 0x24, 0xf3, 0x08, 0xb9, 0xdc, 0xd6, 0x22, 0xfc, 0xd3,
+	0x81, 0xd1, 0x49, 0x55, 0x34, 0xeb, 0x12, 0xfd, 0xd1, 0x93, 0xd8, 0x6c, 0x8c, 0xd0, 0x0d, 0x8f,
+	0xde, 0xfe, 0xf0, 0x50, 0x3a, 0x91, 0x5a, 0x64, 0x14, 0xdb, 0xe1, 0xad, 0x88, 0x3e, 0xc4, 0xad,
+	0x96, 0x89, 0x4d, 0xc0, 0x08, 0x6f, 0x17, 0xd7, 0x24, 0xb1, 0x53, 0x5c, 0xf6, 0x21, 0xcc, 0xf2,
+	0x55, 0x59, 0x49, 0x91, 0xc5, 0xc8, 0x61, 0xa1, 0x88, 0xed, 0x43, 0x3e, 0xb5, 0xe8, 0x2b, 0x02,
+	0xb1, 0x2c, 0xad, 0x99, 0xba, 0xc9, 0x6b, 0x45, 0xa4, 0x1e, 0xf2, 0x89, 0x05, 0x2f, 0x10, 0x0b,
+	0x7f, 0xeb, 0x43, 0x9f, 0x57, 0x9b, 0x7f, 0x9c, 0x7a, 0x33, 0xe8, 0x75, 0x8d, 0xde, 0xcb, 0x33,
+	0xfc, 0x10, 0x29, 0x54, 0x53, 0x68, 0x33, 0xec, 0x86, 0xbc, 0x15, 0xd9, 0xbb, 0xe0, 0xa6, 0xa2,
+	0x28, 0x28, 0x5f, 0xf3, 0x2d, 0x63, 0x94, 0x31, 0xd9, 0x39, 0xb8, 0xb6, 0xa9, 0xf0, 0x53, 0x50,
+	0xd5, 0xc9, 0x38, 0x3c, 0xd7, 0x34, 0x74, 0x83, 0x31, 0x69, 0xac, 0xc4, 0x1e, 0xc3, 0xd8, 0x9c,
+	0x54, 0xe0, 0x12, 0x2f, 0xc7, 0x91, 0x19, 0xce, 0xbc, 0xc5, 0xb1, 0x74, 0x79, 0x5a, 0x95, 0x2a,
+	0xf0, 0x4c, 0xe9, 0x48, 0x60, 0xff, 0x87, 0x11, 0x32, 0x21, 0xcf, 0x02, 0x30, 0xf0, 0x55, 0xb3,
+	0x3a, 0xcd, 0xd8, 0x27, 0x00, 0x09, 0xf2, 0x3a, 0xce, 0xcb, 0xeb, 0x8a, 0x1a, 0xc8, 0x5f, 0xc2,
+	0x1d, 0xd5, 0xb9, 0x97, 0xb4, 0x47, 0x2c, 0x5a, 0xa3, 0x84, 0x8c, 0x2d, 0xd9, 0xb7, 0xd4, 0x18,
+	0x1e, 0x9f, 0x20, 0x68, 0x19, 0xbd, 0x45, 0x7f, 0x3b, 0xad, 0x33, 0xa5, 0x14, 0xbd, 0xb6, 0x61,
+	0xf6, 0x3b, 0xe5, 0x0b, 0x68, 0x87, 0x48, 0xac, 0xb4, 0x14, 0xc9, 0x4d, 0x30, 0xfb, 0x5b, 0xf8,
+	0xa9, 0xb5, 0xb8, 0x20, 0x83, 0xb3, 0x81, 0x3b, 0x3a, 0x1c, 0x87, 0xbf, 0x80, 0xdb, 0xc5, 0x7b,
+	0x02, 0x6e, 0x97, 0x8f, 0x19, 0xef, 0xef, 0x74, 0xd1, 0xba, 0x83, 0xe9, 0xd2, 0xce, 0x70, 0xfe,
+	0x35, 0x4c, 0xf7, 0x54, 0xff, 0xa9, 0x01, 0xff, 0xe8, 0xc3, 0xe0, 0x85, 0xcc, 0x33, 0x7c, 0x8a,
+	0x94, 0x08, 0xaf, 0x6c, 0xe4, 0x71, 0x64, 0x1a, 0x80, 0xb7, 0x38, 0x0b, 0x60, 0x20, 0xab, 0x8d,
+	0xd9, 0x8c, 0xfe, 0x72, 0x10, 0xf1, 0x6a, 0xc3, 0x09, 0x31, 0x23, 0x4c, 0xe9, 0xd8, 0x14, 0x7f,
+	0xbd, 0xb7, 0x1b, 0x1c, 0x1c, 0x61, 0x4a, 0x53, 0x15, 0xce, 0xdb, 0x45, 0x10, 0xc2, 0xc8, 0x6c,
+	0xe5, 0x60, 0x60, 0xab, 0x84, 0x53, 0xe0, 0x85, 0xac, 0x9a, 0x9a, 0x5b, 0x0d, 0xfb, 0x14, 0xe8,
+	0x22, 0x79, 0x8a, 0xcd, 0x4e, 0xcb, 0xa8, 0x01, 0x1c, 0x7e, 0x80, 0x0a, 0x74, 0x64, 0x76, 0x5f,
+	0xc6, 0x3e, 0x03, 0xdf, 0x2e, 0x48, 0x7a, 0x79, 0x43, 0x26, 0x3f, 0xba, 0x5b, 0xa1, 0x1c, 0x9a,
+	0xee, 0xcc, 0x96, 0x30, 0xa5, 0x21, 0xb3, 0xb6, 0x53, 0x87, 0xb8, 0xe5, 0x2f, 0xa7, 0xd1, 0xee,
+	0x28, 0xe2, 0x13, 0xbd, 0x23, 0xb1, 0x10, 0xc6, 0x69, 0xd1, 0x28, 0x2d, 0x24, 0x51, 0xce, 0x5f,
+	0xba, 0xd1, 0x89, 0x91, 0x79, 0xab, 0x60, 0xcf, 0xe0, 0xe1, 0xba, 0x52, 0x3a, 0x96, 0x22, 0x15,
+	0xa5, 0x8e, 0x2d, 0x1c, 0x77, 0x7f, 0x4d, 0x88, 0x91, 0x0e, 0x9f, 0xa3, 0x11, 0x27, 0x1b, 0xeb,
+	0xa2, 0x5b, 0x56, 0x38, 0x97, 0xa8, 0xc1, 0x36, 0x79, 0xa6, 0xdf, 0x04, 0x13, 0xb3, 0x9d, 0x11,
+	0xf9, 0x09, 0x81, 0xb3, 0x81, 0x3b, 0x3c, 0x1c, 0x9d, 0x0d, 0xdc, 0xf1, 0xa1, 0x1b, 0x4a, 0x18,
+	0xdb, 0xeb, 0x38, 0x49, 0xe8, 0x83, 0x94, 0x4e, 0x74, 0xa3, 0xec, 0x52, 0x07, 0x84, 0x2e, 0x08,
+	0xc1, 0x8e, 0x6e, 0x37, 0x9e, 0xa1, 0x40, 0x2b, 0x62, 0xe5, 0xda, 0x3c, 0x65, 0xb5, 0x09, 0xfa,
+	0xb6, 0x72, 0xed, 0xb7, 0x55, 0x1b, 0x0e, 0x69, 0x77, 0x0e, 0x9f, 0x03, 0xdc, 0x69, 0xd8, 0x63,
+	0x98, 0x64, 0xb9, 0xaa, 0x8b, 0x64, 0xbb, 0x3b, 0xaf, 0x7d, 0x8b, 0xd1, 0xc8, 0xc6, 0xf6, 0x2d,
+	0x33, 0x71, 0x6b, 0xff, 0x4e, 0x19, 0xe1, 0x6a, 0x44, 0x6b, 0xfa, 0xc9, 0x5f, 0x03, 0x00, 0x20,
+	0x39, 0x61, 0x07, 0xd3, 0x09, 0x00, 0x00,
 }
@@ -105,7 +105,40 @@ func (x DashboardTabSummary_TabStatus) String() string {
 }
 
 func (DashboardTabSummary_TabStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{4, 0}
+	return fileDescriptor_f7168d0e3f3f5589, []int{12, 0}
+}
+
+type HealthTrend_Direction int32
+
+const (
+	// Either window lacks results.
+	HealthTrend_UNKNOWN   HealthTrend_Direction = 0
+	HealthTrend_STABLE    HealthTrend_Direction = 1
+	HealthTrend_IMPROVING HealthTrend_Direction = 2
+	// The tab is getting less healthy, even if it is not alerting yet.
+	HealthTrend_DEGRADING HealthTrend_Direction = 3
+)
+
+var HealthTrend_Direction_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "STABLE",
+	2: "IMPROVING",
+	3: "DEGRADING",
+}
+
+var HealthTrend_Direction_value = map[string]int32{
+	"UNKNOWN":   0,
+	"STABLE":    1,
+	"IMPROVING": 2,
+	"DEGRADING": 3,
+}
+
+func (x HealthTrend_Direction) String() string {
+	return proto.EnumName(HealthTrend_Direction_name, int32(x))
+}
+
+func (HealthTrend_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{15, 0}
 }
 
 // Summary of a failing test.
@@ -144,7 +177,14 @@ type FailingTestSummary struct {
 	// Maps (property name):(property value) for arbitrary alert properties.
 	Properties map[string]string `protobuf:"bytes,15,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// A list of IDs for issue hotlists related to this failure.
-	HotlistIds           []string `protobuf:"bytes,16,rep,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	HotlistIds []string `protobuf:"bytes,16,rep,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	// A time-limited signed URL to the GCS artifact linked by the latest failure,
+	// such as its log property.
+	LatestFailArtifactUrl string `protobuf:"bytes,18,opt,name=latest_fail_artifact_url,json=latestFailArtifactUrl,proto3" json:"latest_fail_artifact_url,omitempty"`
+	// GitHub issue automatically filed about the failure.
+	IssueUrl string `protobuf:"bytes,19,opt,name=issue_url,json=issueUrl,proto3" json:"issue_url,omitempty"`
+	// Link to file a bug about the failure, expanded from the tab's file_bug_template.
+	FileBugUrl           string   `protobuf:"bytes,20,opt,name=file_bug_url,json=fileBugUrl,proto3" json:"file_bug_url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -294,6 +334,27 @@ func (m *FailingTestSummary) GetHotlistIds() []string {
 	return nil
 }
 
+func (m *FailingTestSummary) GetLatestFailArtifactUrl() string {
+	if m != nil {
+		return m.LatestFailArtifactUrl
+	}
+	return ""
+}
+
+func (m *FailingTestSummary) GetIssueUrl() string {
+	if m != nil {
+		return m.IssueUrl
+	}
+	return ""
+}
+
+func (m *FailingTestSummary) GetFileBugUrl() string {
+	if m != nil {
+		return m.FileBugUrl
+	}
+	return ""
+}
+
 // Metrics about a specific test, i.e. passes, fails, total runs, etc.
 // Next ID: 12
 type TestInfo struct {
@@ -514,328 +575,1692 @@ func (m *HealthinessInfo) GetPreviousFlakiness() []float32 {
 	return nil
 }
 
-// Information about alerts that have been sent
-type AlertingData struct {
-	// Seconds since epoch at which an email was last sent
-	LastEmailTime        *timestamp.Timestamp `protobuf:"bytes,1,opt,name=last_email_time,json=lastEmailTime,proto3" json:"last_email_time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+// Flaky runs of a test over a window of time.
+type TestFlakeRate struct {
+	// Display name of the test.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Runs considered, excluding infra failures and runs of consecutive failures.
+	Runs int32 `protobuf:"varint,2,opt,name=runs,proto3" json:"runs,omitempty"`
+	// Runs which failed and then passed after a retry.
+	PassedAfterRetry int32 `protobuf:"varint,3,opt,name=passed_after_retry,json=passedAfterRetry,proto3" json:"passed_after_retry,omitempty"`
+	// Failures surrounded by passing runs.
+	IntermittentFailures int32 `protobuf:"varint,4,opt,name=intermittent_failures,json=intermittentFailures,proto3" json:"intermittent_failures,omitempty"`
+	// Flaky runs out of 100 considered runs.
+	FlakeRate            float32  `protobuf:"fixed32,5,opt,name=flake_rate,json=flakeRate,proto3" json:"flake_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AlertingData) Reset()         { *m = AlertingData{} }
-func (m *AlertingData) String() string { return proto.CompactTextString(m) }
-func (*AlertingData) ProtoMessage()    {}
-func (*AlertingData) Descriptor() ([]byte, []int) {
+func (m *TestFlakeRate) Reset()         { *m = TestFlakeRate{} }
+func (m *TestFlakeRate) String() string { return proto.CompactTextString(m) }
+func (*TestFlakeRate) ProtoMessage()    {}
+func (*TestFlakeRate) Descriptor() ([]byte, []int) {
 	return fileDescriptor_f7168d0e3f3f5589, []int{3}
 }
 
-func (m *AlertingData) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AlertingData.Unmarshal(m, b)
+func (m *TestFlakeRate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestFlakeRate.Unmarshal(m, b)
 }
-func (m *AlertingData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AlertingData.Marshal(b, m, deterministic)
+func (m *TestFlakeRate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestFlakeRate.Marshal(b, m, deterministic)
 }
-func (m *AlertingData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AlertingData.Merge(m, src)
+func (m *TestFlakeRate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestFlakeRate.Merge(m, src)
 }
-func (m *AlertingData) XXX_Size() int {
-	return xxx_messageInfo_AlertingData.Size(m)
+func (m *TestFlakeRate) XXX_Size() int {
+	return xxx_messageInfo_TestFlakeRate.Size(m)
 }
-func (m *AlertingData) XXX_DiscardUnknown() {
-	xxx_messageInfo_AlertingData.DiscardUnknown(m)
+func (m *TestFlakeRate) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestFlakeRate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AlertingData proto.InternalMessageInfo
+var xxx_messageInfo_TestFlakeRate proto.InternalMessageInfo
 
-func (m *AlertingData) GetLastEmailTime() *timestamp.Timestamp {
+func (m *TestFlakeRate) GetDisplayName() string {
 	if m != nil {
-		return m.LastEmailTime
+		return m.DisplayName
 	}
-	return nil
+	return ""
 }
 
-// Summary of a dashboard tab.
-type DashboardTabSummary struct {
-	// The name of the dashboard.
-	DashboardName string `protobuf:"bytes,1,opt,name=dashboard_name,json=dashboardName,proto3" json:"dashboard_name,omitempty"`
-	// The name of the dashboard tab.
-	DashboardTabName string `protobuf:"bytes,2,opt,name=dashboard_tab_name,json=dashboardTabName,proto3" json:"dashboard_tab_name,omitempty"`
-	// Any top-level alert on this dashboard tab.
-	Alert string `protobuf:"bytes,3,opt,name=alert,proto3" json:"alert,omitempty"`
-	// List of failing test summary information.
-	FailingTestSummaries []*FailingTestSummary `protobuf:"bytes,4,rep,name=failing_test_summaries,json=failingTestSummaries,proto3" json:"failing_test_summaries,omitempty"`
-	// Seconds since epoch at which the test group was last updated.
-	LastUpdateTimestamp float64 `protobuf:"fixed64,5,opt,name=last_update_timestamp,json=lastUpdateTimestamp,proto3" json:"last_update_timestamp,omitempty"`
-	// A summary of the status of this dashboard tab.
-	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	// The overall status for this dashboard tab.
-	OverallStatus DashboardTabSummary_TabStatus `protobuf:"varint,7,opt,name=overall_status,json=overallStatus,proto3,enum=DashboardTabSummary_TabStatus" json:"overall_status,omitempty"`
-	// The ID for the latest passing build.
-	LatestGreen string `protobuf:"bytes,8,opt,name=latest_green,json=latestGreen,proto3" json:"latest_green,omitempty"`
-	// Seconds since epoch at which tests last ran.
-	LastRunTimestamp float64 `protobuf:"fixed64,9,opt,name=last_run_timestamp,json=lastRunTimestamp,proto3" json:"last_run_timestamp,omitempty"`
-	// String indicating the URL for linking to a bug.
-	BugUrl string `protobuf:"bytes,10,opt,name=bug_url,json=bugUrl,proto3" json:"bug_url,omitempty"`
-	// Metrics for the recent healthiness of a tab
-	Healthiness *HealthinessInfo `protobuf:"bytes,12,opt,name=healthiness,proto3" json:"healthiness,omitempty"`
-	// All the issue IDs linked to this tab.
-	LinkedIssues []string `protobuf:"bytes,13,rep,name=linked_issues,json=linkedIssues,proto3" json:"linked_issues,omitempty"`
-	// Metrics about alerts sent with respect to this summary
-	// Maintained by alerter; does not need to be populated by summarizer
-	AlertingData         *AlertingData `protobuf:"bytes,14,opt,name=alerting_data,json=alertingData,proto3" json:"alerting_data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *TestFlakeRate) GetRuns() int32 {
+	if m != nil {
+		return m.Runs
+	}
+	return 0
 }
 
-func (m *DashboardTabSummary) Reset()         { *m = DashboardTabSummary{} }
-func (m *DashboardTabSummary) String() string { return proto.CompactTextString(m) }
-func (*DashboardTabSummary) ProtoMessage()    {}
-func (*DashboardTabSummary) Descriptor() ([]byte, []int) {
+func (m *TestFlakeRate) GetPassedAfterRetry() int32 {
+	if m != nil {
+		return m.PassedAfterRetry
+	}
+	return 0
+}
+
+func (m *TestFlakeRate) GetIntermittentFailures() int32 {
+	if m != nil {
+		return m.IntermittentFailures
+	}
+	return 0
+}
+
+func (m *TestFlakeRate) GetFlakeRate() float32 {
+	if m != nil {
+		return m.FlakeRate
+	}
+	return 0
+}
+
+// Flake rates of the tests in a tab over a window of time.
+type FlakeRateWindow struct {
+	// Number of days in the window.
+	Days  int32                `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	Start *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Tests with at least one considered run during the window.
+	Tests                []*TestFlakeRate `protobuf:"bytes,4,rep,name=tests,proto3" json:"tests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FlakeRateWindow) Reset()         { *m = FlakeRateWindow{} }
+func (m *FlakeRateWindow) String() string { return proto.CompactTextString(m) }
+func (*FlakeRateWindow) ProtoMessage()    {}
+func (*FlakeRateWindow) Descriptor() ([]byte, []int) {
 	return fileDescriptor_f7168d0e3f3f5589, []int{4}
 }
 
-func (m *DashboardTabSummary) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DashboardTabSummary.Unmarshal(m, b)
+func (m *FlakeRateWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlakeRateWindow.Unmarshal(m, b)
 }
-func (m *DashboardTabSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DashboardTabSummary.Marshal(b, m, deterministic)
+func (m *FlakeRateWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlakeRateWindow.Marshal(b, m, deterministic)
 }
-func (m *DashboardTabSummary) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DashboardTabSummary.Merge(m, src)
+func (m *FlakeRateWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlakeRateWindow.Merge(m, src)
 }
-func (m *DashboardTabSummary) XXX_Size() int {
-	return xxx_messageInfo_DashboardTabSummary.Size(m)
+func (m *FlakeRateWindow) XXX_Size() int {
+	return xxx_messageInfo_FlakeRateWindow.Size(m)
 }
-func (m *DashboardTabSummary) XXX_DiscardUnknown() {
-	xxx_messageInfo_DashboardTabSummary.DiscardUnknown(m)
+func (m *FlakeRateWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlakeRateWindow.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DashboardTabSummary proto.InternalMessageInfo
+var xxx_messageInfo_FlakeRateWindow proto.InternalMessageInfo
 
-func (m *DashboardTabSummary) GetDashboardName() string {
+func (m *FlakeRateWindow) GetDays() int32 {
 	if m != nil {
-		return m.DashboardName
+		return m.Days
 	}
-	return ""
+	return 0
 }
 
-func (m *DashboardTabSummary) GetDashboardTabName() string {
+func (m *FlakeRateWindow) GetStart() *timestamp.Timestamp {
 	if m != nil {
-		return m.DashboardTabName
+		return m.Start
 	}
-	return ""
+	return nil
 }
 
-func (m *DashboardTabSummary) GetAlert() string {
+func (m *FlakeRateWindow) GetEnd() *timestamp.Timestamp {
 	if m != nil {
-		return m.Alert
+		return m.End
 	}
-	return ""
+	return nil
 }
 
-func (m *DashboardTabSummary) GetFailingTestSummaries() []*FailingTestSummary {
+func (m *FlakeRateWindow) GetTests() []*TestFlakeRate {
 	if m != nil {
-		return m.FailingTestSummaries
+		return m.Tests
 	}
 	return nil
 }
 
-func (m *DashboardTabSummary) GetLastUpdateTimestamp() float64 {
+// Flake rates of a dashboard tab over each configured window.
+// Stored in GCS as "flakiness-<normalized dashboard name>-<normalized tab name>".
+type FlakinessReport struct {
+	DashboardName        string             `protobuf:"bytes,1,opt,name=dashboard_name,json=dashboardName,proto3" json:"dashboard_name,omitempty"`
+	DashboardTabName     string             `protobuf:"bytes,2,opt,name=dashboard_tab_name,json=dashboardTabName,proto3" json:"dashboard_tab_name,omitempty"`
+	Windows              []*FlakeRateWindow `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *FlakinessReport) Reset()         { *m = FlakinessReport{} }
+func (m *FlakinessReport) String() string { return proto.CompactTextString(m) }
+func (*FlakinessReport) ProtoMessage()    {}
+func (*FlakinessReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{5}
+}
+
+func (m *FlakinessReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlakinessReport.Unmarshal(m, b)
+}
+func (m *FlakinessReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlakinessReport.Marshal(b, m, deterministic)
+}
+func (m *FlakinessReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlakinessReport.Merge(m, src)
+}
+func (m *FlakinessReport) XXX_Size() int {
+	return xxx_messageInfo_FlakinessReport.Size(m)
+}
+func (m *FlakinessReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlakinessReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlakinessReport proto.InternalMessageInfo
+
+func (m *FlakinessReport) GetDashboardName() string {
 	if m != nil {
-		return m.LastUpdateTimestamp
+		return m.DashboardName
 	}
-	return 0
+	return ""
 }
 
-func (m *DashboardTabSummary) GetStatus() string {
+func (m *FlakinessReport) GetDashboardTabName() string {
 	if m != nil {
-		return m.Status
+		return m.DashboardTabName
 	}
 	return ""
 }
 
-func (m *DashboardTabSummary) GetOverallStatus() DashboardTabSummary_TabStatus {
+func (m *FlakinessReport) GetWindows() []*FlakeRateWindow {
 	if m != nil {
-		return m.OverallStatus
+		return m.Windows
 	}
-	return DashboardTabSummary_NOT_SET
+	return nil
 }
 
-func (m *DashboardTabSummary) GetLatestGreen() string {
+// A test in a flaky test digest.
+type FlakyTest struct {
+	DashboardName        string         `protobuf:"bytes,1,opt,name=dashboard_name,json=dashboardName,proto3" json:"dashboard_name,omitempty"`
+	DashboardTabName     string         `protobuf:"bytes,2,opt,name=dashboard_tab_name,json=dashboardTabName,proto3" json:"dashboard_tab_name,omitempty"`
+	FlakeRate            *TestFlakeRate `protobuf:"bytes,3,opt,name=flake_rate,json=flakeRate,proto3" json:"flake_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FlakyTest) Reset()         { *m = FlakyTest{} }
+func (m *FlakyTest) String() string { return proto.CompactTextString(m) }
+func (*FlakyTest) ProtoMessage()    {}
+func (*FlakyTest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{6}
+}
+
+func (m *FlakyTest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlakyTest.Unmarshal(m, b)
+}
+func (m *FlakyTest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlakyTest.Marshal(b, m, deterministic)
+}
+func (m *FlakyTest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlakyTest.Merge(m, src)
+}
+func (m *FlakyTest) XXX_Size() int {
+	return xxx_messageInfo_FlakyTest.Size(m)
+}
+func (m *FlakyTest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlakyTest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlakyTest proto.InternalMessageInfo
+
+func (m *FlakyTest) GetDashboardName() string {
 	if m != nil {
-		return m.LatestGreen
+		return m.DashboardName
 	}
 	return ""
 }
 
-func (m *DashboardTabSummary) GetLastRunTimestamp() float64 {
+func (m *FlakyTest) GetDashboardTabName() string {
 	if m != nil {
-		return m.LastRunTimestamp
+		return m.DashboardTabName
 	}
-	return 0
+	return ""
 }
 
-func (m *DashboardTabSummary) GetBugUrl() string {
+func (m *FlakyTest) GetFlakeRate() *TestFlakeRate {
 	if m != nil {
-		return m.BugUrl
+		return m.FlakeRate
+	}
+	return nil
+}
+
+// The flakiest tests of a dashboard group over a window of days.
+type FlakyTestDigest struct {
+	DashboardGroupName string `protobuf:"bytes,1,opt,name=dashboard_group_name,json=dashboardGroupName,proto3" json:"dashboard_group_name,omitempty"`
+	// Number of days in the window.
+	Days  int32                `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	Start *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	// Flaky tests, from the highest flake rate to the lowest.
+	Tests                []*FlakyTest `protobuf:"bytes,5,rep,name=tests,proto3" json:"tests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *FlakyTestDigest) Reset()         { *m = FlakyTestDigest{} }
+func (m *FlakyTestDigest) String() string { return proto.CompactTextString(m) }
+func (*FlakyTestDigest) ProtoMessage()    {}
+func (*FlakyTestDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{7}
+}
+
+func (m *FlakyTestDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlakyTestDigest.Unmarshal(m, b)
+}
+func (m *FlakyTestDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlakyTestDigest.Marshal(b, m, deterministic)
+}
+func (m *FlakyTestDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlakyTestDigest.Merge(m, src)
+}
+func (m *FlakyTestDigest) XXX_Size() int {
+	return xxx_messageInfo_FlakyTestDigest.Size(m)
+}
+func (m *FlakyTestDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlakyTestDigest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlakyTestDigest proto.InternalMessageInfo
+
+func (m *FlakyTestDigest) GetDashboardGroupName() string {
+	if m != nil {
+		return m.DashboardGroupName
 	}
 	return ""
 }
 
-func (m *DashboardTabSummary) GetHealthiness() *HealthinessInfo {
+func (m *FlakyTestDigest) GetDays() int32 {
 	if m != nil {
-		return m.Healthiness
+		return m.Days
+	}
+	return 0
+}
+
+func (m *FlakyTestDigest) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
 	}
 	return nil
 }
 
-func (m *DashboardTabSummary) GetLinkedIssues() []string {
+func (m *FlakyTestDigest) GetEnd() *timestamp.Timestamp {
 	if m != nil {
-		return m.LinkedIssues
+		return m.End
 	}
 	return nil
 }
 
-func (m *DashboardTabSummary) GetAlertingData() *AlertingData {
+func (m *FlakyTestDigest) GetTests() []*FlakyTest {
 	if m != nil {
-		return m.AlertingData
+		return m.Tests
 	}
 	return nil
 }
 
-// Summary state of a dashboard.
-type DashboardSummary struct {
-	// Summary of a dashboard tab; see config.proto.
-	TabSummaries         []*DashboardTabSummary `protobuf:"bytes,1,rep,name=tab_summaries,json=tabSummaries,proto3" json:"tab_summaries,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+// Notification state of a dashboard tab.
+type AlertState struct {
+	DashboardTabName string `protobuf:"bytes,1,opt,name=dashboard_tab_name,json=dashboardTabName,proto3" json:"dashboard_tab_name,omitempty"`
+	// When the current alert first fired, unset while the tab is not alerting.
+	FirstFired *timestamp.Timestamp `protobuf:"bytes,2,opt,name=first_fired,json=firstFired,proto3" json:"first_fired,omitempty"`
+	// When a notification about the current alert was last sent.
+	LastFired *timestamp.Timestamp `protobuf:"bytes,3,opt,name=last_fired,json=lastFired,proto3" json:"last_fired,omitempty"`
+	// Who acknowledged the current alert, silencing further alerts until it recovers.
+	AcknowledgedBy string `protobuf:"bytes,4,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	// Silence all notifications about the tab until this time.
+	SnoozedUntil *timestamp.Timestamp `protobuf:"bytes,5,opt,name=snoozed_until,json=snoozedUntil,proto3" json:"snoozed_until,omitempty"`
+	// Open GitHub issues filed about failing tests of the tab.
+	Issues               []*TestIssue `protobuf:"bytes,6,rep,name=issues,proto3" json:"issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *DashboardSummary) Reset()         { *m = DashboardSummary{} }
-func (m *DashboardSummary) String() string { return proto.CompactTextString(m) }
-func (*DashboardSummary) ProtoMessage()    {}
-func (*DashboardSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{5}
+func (m *AlertState) Reset()         { *m = AlertState{} }
+func (m *AlertState) String() string { return proto.CompactTextString(m) }
+func (*AlertState) ProtoMessage()    {}
+func (*AlertState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{8}
 }
 
-func (m *DashboardSummary) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DashboardSummary.Unmarshal(m, b)
+func (m *AlertState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertState.Unmarshal(m, b)
 }
-func (m *DashboardSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DashboardSummary.Marshal(b, m, deterministic)
+func (m *AlertState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertState.Marshal(b, m, deterministic)
 }
-func (m *DashboardSummary) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DashboardSummary.Merge(m, src)
+func (m *AlertState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertState.Merge(m, src)
 }
-func (m *DashboardSummary) XXX_Size() int {
-	return xxx_messageInfo_DashboardSummary.Size(m)
+func (m *AlertState) XXX_Size() int {
+	return xxx_messageInfo_AlertState.Size(m)
 }
-func (m *DashboardSummary) XXX_DiscardUnknown() {
-	xxx_messageInfo_DashboardSummary.DiscardUnknown(m)
+func (m *AlertState) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertState.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DashboardSummary proto.InternalMessageInfo
+var xxx_messageInfo_AlertState proto.InternalMessageInfo
 
-func (m *DashboardSummary) GetTabSummaries() []*DashboardTabSummary {
+func (m *AlertState) GetDashboardTabName() string {
 	if m != nil {
-		return m.TabSummaries
+		return m.DashboardTabName
+	}
+	return ""
+}
+
+func (m *AlertState) GetFirstFired() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstFired
+	}
+	return nil
+}
+
+func (m *AlertState) GetLastFired() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastFired
 	}
 	return nil
 }
 
+func (m *AlertState) GetAcknowledgedBy() string {
+	if m != nil {
+		return m.AcknowledgedBy
+	}
+	return ""
+}
+
+func (m *AlertState) GetSnoozedUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.SnoozedUntil
+	}
+	return nil
+}
+
+func (m *AlertState) GetIssues() []*TestIssue {
+	if m != nil {
+		return m.Issues
+	}
+	return nil
+}
+
+// A GitHub issue filed about a failing test.
+type TestIssue struct {
+	// Display name of the test.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Repository of the issue, as owner/name.
+	Repo                 string   `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	Number               int64    `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Url                  string   `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestIssue) Reset()         { *m = TestIssue{} }
+func (m *TestIssue) String() string { return proto.CompactTextString(m) }
+func (*TestIssue) ProtoMessage()    {}
+func (*TestIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{9}
+}
+
+func (m *TestIssue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestIssue.Unmarshal(m, b)
+}
+func (m *TestIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestIssue.Marshal(b, m, deterministic)
+}
+func (m *TestIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestIssue.Merge(m, src)
+}
+func (m *TestIssue) XXX_Size() int {
+	return xxx_messageInfo_TestIssue.Size(m)
+}
+func (m *TestIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestIssue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestIssue proto.InternalMessageInfo
+
+func (m *TestIssue) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *TestIssue) GetRepo() string {
+	if m != nil {
+		return m.Repo
+	}
+	return ""
+}
+
+func (m *TestIssue) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *TestIssue) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+// Notification state of each tab of a dashboard.
+// Stored in GCS as "alerts-<normalized dashboard name>".
+type DashboardAlertState struct {
+	Tabs                 []*AlertState `protobuf:"bytes,1,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DashboardAlertState) Reset()         { *m = DashboardAlertState{} }
+func (m *DashboardAlertState) String() string { return proto.CompactTextString(m) }
+func (*DashboardAlertState) ProtoMessage()    {}
+func (*DashboardAlertState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{10}
+}
+
+func (m *DashboardAlertState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardAlertState.Unmarshal(m, b)
+}
+func (m *DashboardAlertState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardAlertState.Marshal(b, m, deterministic)
+}
+func (m *DashboardAlertState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardAlertState.Merge(m, src)
+}
+func (m *DashboardAlertState) XXX_Size() int {
+	return xxx_messageInfo_DashboardAlertState.Size(m)
+}
+func (m *DashboardAlertState) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardAlertState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardAlertState proto.InternalMessageInfo
+
+func (m *DashboardAlertState) GetTabs() []*AlertState {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+// Information about alerts that have been sent
+type AlertingData struct {
+	// Seconds since epoch at which an email was last sent
+	LastEmailTime        *timestamp.Timestamp `protobuf:"bytes,1,opt,name=last_email_time,json=lastEmailTime,proto3" json:"last_email_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AlertingData) Reset()         { *m = AlertingData{} }
+func (m *AlertingData) String() string { return proto.CompactTextString(m) }
+func (*AlertingData) ProtoMessage()    {}
+func (*AlertingData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{11}
+}
+
+func (m *AlertingData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertingData.Unmarshal(m, b)
+}
+func (m *AlertingData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertingData.Marshal(b, m, deterministic)
+}
+func (m *AlertingData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertingData.Merge(m, src)
+}
+func (m *AlertingData) XXX_Size() int {
+	return xxx_messageInfo_AlertingData.Size(m)
+}
+func (m *AlertingData) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertingData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertingData proto.InternalMessageInfo
+
+func (m *AlertingData) GetLastEmailTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastEmailTime
+	}
+	return nil
+}
+
+// Summary of a dashboard tab.
+type DashboardTabSummary struct {
+	// The name of the dashboard.
+	DashboardName string `protobuf:"bytes,1,opt,name=dashboard_name,json=dashboardName,proto3" json:"dashboard_name,omitempty"`
+	// The name of the dashboard tab.
+	DashboardTabName string `protobuf:"bytes,2,opt,name=dashboard_tab_name,json=dashboardTabName,proto3" json:"dashboard_tab_name,omitempty"`
+	// Any top-level alert on this dashboard tab.
+	Alert string `protobuf:"bytes,3,opt,name=alert,proto3" json:"alert,omitempty"`
+	// List of failing test summary information.
+	FailingTestSummaries []*FailingTestSummary `protobuf:"bytes,4,rep,name=failing_test_summaries,json=failingTestSummaries,proto3" json:"failing_test_summaries,omitempty"`
+	// Seconds since epoch at which the test group was last updated.
+	LastUpdateTimestamp float64 `protobuf:"fixed64,5,opt,name=last_update_timestamp,json=lastUpdateTimestamp,proto3" json:"last_update_timestamp,omitempty"`
+	// A summary of the status of this dashboard tab.
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// The overall status for this dashboard tab.
+	OverallStatus DashboardTabSummary_TabStatus `protobuf:"varint,7,opt,name=overall_status,json=overallStatus,proto3,enum=DashboardTabSummary_TabStatus" json:"overall_status,omitempty"`
+	// The ID for the latest passing build.
+	LatestGreen string `protobuf:"bytes,8,opt,name=latest_green,json=latestGreen,proto3" json:"latest_green,omitempty"`
+	// Seconds since epoch at which tests last ran.
+	LastRunTimestamp float64 `protobuf:"fixed64,9,opt,name=last_run_timestamp,json=lastRunTimestamp,proto3" json:"last_run_timestamp,omitempty"`
+	// String indicating the URL for linking to a bug.
+	BugUrl string `protobuf:"bytes,10,opt,name=bug_url,json=bugUrl,proto3" json:"bug_url,omitempty"`
+	// Metrics for the recent healthiness of a tab
+	Healthiness *HealthinessInfo `protobuf:"bytes,12,opt,name=healthiness,proto3" json:"healthiness,omitempty"`
+	// All the issue IDs linked to this tab.
+	LinkedIssues []string `protobuf:"bytes,13,rep,name=linked_issues,json=linkedIssues,proto3" json:"linked_issues,omitempty"`
+	// Metrics about alerts sent with respect to this summary
+	// Maintained by alerter; does not need to be populated by summarizer
+	AlertingData *AlertingData `protobuf:"bytes,14,opt,name=alerting_data,json=alertingData,proto3" json:"alerting_data,omitempty"`
+	// Flake rate of each flaky row over the first configured flake rate window,
+	// for annotating rows. Keyed by the row's display name.
+	FlakeRates map[string]float32 `protobuf:"bytes,15,rep,name=flake_rates,json=flakeRates,proto3" json:"flake_rates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+	// Groups of recent failures with similar messages, from the most tests to the least.
+	FailureClusters []*FailureCluster `protobuf:"bytes,16,rep,name=failure_clusters,json=failureClusters,proto3" json:"failure_clusters,omitempty"`
+	// Change in pass rate between a short and long window, when analyzing healthiness.
+	HealthTrend *HealthTrend `protobuf:"bytes,17,opt,name=health_trend,json=healthTrend,proto3" json:"health_trend,omitempty"`
+	// Generation of the test group state this summarizes.
+	GridGeneration int64 `protobuf:"varint,18,opt,name=grid_generation,json=gridGeneration,proto3" json:"grid_generation,omitempty"`
+	// Fingerprint of the tab and test group configuration, set when the summary
+	// only depends on the grid and configuration. The summarizer reuses such
+	// summaries until either changes.
+	ConfigFingerprint string `protobuf:"bytes,19,opt,name=config_fingerprint,json=configFingerprint,proto3" json:"config_fingerprint,omitempty"`
+	// Builds of recent columns detected as infrastructure failures, which
+	// flakiness analysis and alerts ignore.
+	InfraFailureBuilds []string `protobuf:"bytes,20,rep,name=infra_failure_builds,json=infraFailureBuilds,proto3" json:"infra_failure_builds,omitempty"`
+	// Recent columns with passing or failing results, and those where every
+	// result passed.
+	CompletedColumns int32 `protobuf:"varint,21,opt,name=completed_columns,json=completedColumns,proto3" json:"completed_columns,omitempty"`
+	PassingColumns   int32 `protobuf:"varint,22,opt,name=passing_columns,json=passingColumns,proto3" json:"passing_columns,omitempty"`
+	// Passing or failing results in recent columns, and those that passed.
+	FilledCells  int32 `protobuf:"varint,23,opt,name=filled_cells,json=filledCells,proto3" json:"filled_cells,omitempty"`
+	PassingCells int32 `protobuf:"varint,24,opt,name=passing_cells,json=passingCells,proto3" json:"passing_cells,omitempty"`
+	// Burn rates of the tab's service level objective, when configured.
+	Slo *SLOStatus `protobuf:"bytes,25,opt,name=slo,proto3" json:"slo,omitempty"`
+	// Pass percentage of the tab's recent_health_columns, excluding infra failures.
+	RecentHealth *RecentHealth `protobuf:"bytes,26,opt,name=recent_health,json=recentHealth,proto3" json:"recent_health,omitempty"`
+	// The description and documentation_url of the tab's configuration.
+	Description          string   `protobuf:"bytes,27,opt,name=description,proto3" json:"description,omitempty"`
+	DocumentationUrl     string   `protobuf:"bytes,28,opt,name=documentation_url,json=documentationUrl,proto3" json:"documentation_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardTabSummary) Reset()         { *m = DashboardTabSummary{} }
+func (m *DashboardTabSummary) String() string { return proto.CompactTextString(m) }
+func (*DashboardTabSummary) ProtoMessage()    {}
+func (*DashboardTabSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{12}
+}
+
+func (m *DashboardTabSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardTabSummary.Unmarshal(m, b)
+}
+func (m *DashboardTabSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardTabSummary.Marshal(b, m, deterministic)
+}
+func (m *DashboardTabSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardTabSummary.Merge(m, src)
+}
+func (m *DashboardTabSummary) XXX_Size() int {
+	return xxx_messageInfo_DashboardTabSummary.Size(m)
+}
+func (m *DashboardTabSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardTabSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardTabSummary proto.InternalMessageInfo
+
+func (m *DashboardTabSummary) GetDashboardName() string {
+	if m != nil {
+		return m.DashboardName
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetDashboardTabName() string {
+	if m != nil {
+		return m.DashboardTabName
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetAlert() string {
+	if m != nil {
+		return m.Alert
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetFailingTestSummaries() []*FailingTestSummary {
+	if m != nil {
+		return m.FailingTestSummaries
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetLastUpdateTimestamp() float64 {
+	if m != nil {
+		return m.LastUpdateTimestamp
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetOverallStatus() DashboardTabSummary_TabStatus {
+	if m != nil {
+		return m.OverallStatus
+	}
+	return DashboardTabSummary_NOT_SET
+}
+
+func (m *DashboardTabSummary) GetLatestGreen() string {
+	if m != nil {
+		return m.LatestGreen
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetLastRunTimestamp() float64 {
+	if m != nil {
+		return m.LastRunTimestamp
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetBugUrl() string {
+	if m != nil {
+		return m.BugUrl
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetHealthiness() *HealthinessInfo {
+	if m != nil {
+		return m.Healthiness
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetLinkedIssues() []string {
+	if m != nil {
+		return m.LinkedIssues
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetAlertingData() *AlertingData {
+	if m != nil {
+		return m.AlertingData
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetFlakeRates() map[string]float32 {
+	if m != nil {
+		return m.FlakeRates
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetFailureClusters() []*FailureCluster {
+	if m != nil {
+		return m.FailureClusters
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetHealthTrend() *HealthTrend {
+	if m != nil {
+		return m.HealthTrend
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetGridGeneration() int64 {
+	if m != nil {
+		return m.GridGeneration
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetConfigFingerprint() string {
+	if m != nil {
+		return m.ConfigFingerprint
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetInfraFailureBuilds() []string {
+	if m != nil {
+		return m.InfraFailureBuilds
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetCompletedColumns() int32 {
+	if m != nil {
+		return m.CompletedColumns
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetPassingColumns() int32 {
+	if m != nil {
+		return m.PassingColumns
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetFilledCells() int32 {
+	if m != nil {
+		return m.FilledCells
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetPassingCells() int32 {
+	if m != nil {
+		return m.PassingCells
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetSlo() *SLOStatus {
+	if m != nil {
+		return m.Slo
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetRecentHealth() *RecentHealth {
+	if m != nil {
+		return m.RecentHealth
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetDocumentationUrl() string {
+	if m != nil {
+		return m.DocumentationUrl
+	}
+	return ""
+}
+
+// How many of the recent columns of a tab passed.
+type RecentHealth struct {
+	// Number of recent columns considered, whether or not they have results.
+	Window int32 `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	// Columns with passing or failing results, and those where every result passed.
+	CompletedColumns int32 `protobuf:"varint,2,opt,name=completed_columns,json=completedColumns,proto3" json:"completed_columns,omitempty"`
+	PassingColumns   int32 `protobuf:"varint,3,opt,name=passing_columns,json=passingColumns,proto3" json:"passing_columns,omitempty"`
+	// Passing columns out of 100 completed columns.
+	PassPercent          float32  `protobuf:"fixed32,4,opt,name=pass_percent,json=passPercent,proto3" json:"pass_percent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecentHealth) Reset()         { *m = RecentHealth{} }
+func (m *RecentHealth) String() string { return proto.CompactTextString(m) }
+func (*RecentHealth) ProtoMessage()    {}
+func (*RecentHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{13}
+}
+
+func (m *RecentHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecentHealth.Unmarshal(m, b)
+}
+func (m *RecentHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecentHealth.Marshal(b, m, deterministic)
+}
+func (m *RecentHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecentHealth.Merge(m, src)
+}
+func (m *RecentHealth) XXX_Size() int {
+	return xxx_messageInfo_RecentHealth.Size(m)
+}
+func (m *RecentHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecentHealth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecentHealth proto.InternalMessageInfo
+
+func (m *RecentHealth) GetWindow() int32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+func (m *RecentHealth) GetCompletedColumns() int32 {
+	if m != nil {
+		return m.CompletedColumns
+	}
+	return 0
+}
+
+func (m *RecentHealth) GetPassingColumns() int32 {
+	if m != nil {
+		return m.PassingColumns
+	}
+	return 0
+}
+
+func (m *RecentHealth) GetPassPercent() float32 {
+	if m != nil {
+		return m.PassPercent
+	}
+	return 0
+}
+
+// How quickly a tab spends the failures allowed by its target pass rate.
+type SLOStatus struct {
+	TargetPassRate   float32 `protobuf:"fixed32,1,opt,name=target_pass_rate,json=targetPassRate,proto3" json:"target_pass_rate,omitempty"`
+	ShortWindowHours int32   `protobuf:"varint,2,opt,name=short_window_hours,json=shortWindowHours,proto3" json:"short_window_hours,omitempty"`
+	LongWindowHours  int32   `protobuf:"varint,3,opt,name=long_window_hours,json=longWindowHours,proto3" json:"long_window_hours,omitempty"`
+	// Passing cells out of 100 results, excluding infra failures.
+	ShortPassRate float32 `protobuf:"fixed32,4,opt,name=short_pass_rate,json=shortPassRate,proto3" json:"short_pass_rate,omitempty"`
+	LongPassRate  float32 `protobuf:"fixed32,5,opt,name=long_pass_rate,json=longPassRate,proto3" json:"long_pass_rate,omitempty"`
+	// Failure rate over the failures the target allows, where 1 spends exactly the budget.
+	// Zero when the window lacks results.
+	ShortBurnRate float32 `protobuf:"fixed32,6,opt,name=short_burn_rate,json=shortBurnRate,proto3" json:"short_burn_rate,omitempty"`
+	LongBurnRate  float32 `protobuf:"fixed32,7,opt,name=long_burn_rate,json=longBurnRate,proto3" json:"long_burn_rate,omitempty"`
+	// Both windows burn at or above the threshold.
+	Violating            bool     `protobuf:"varint,8,opt,name=violating,proto3" json:"violating,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SLOStatus) Reset()         { *m = SLOStatus{} }
+func (m *SLOStatus) String() string { return proto.CompactTextString(m) }
+func (*SLOStatus) ProtoMessage()    {}
+func (*SLOStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{14}
+}
+
+func (m *SLOStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SLOStatus.Unmarshal(m, b)
+}
+func (m *SLOStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SLOStatus.Marshal(b, m, deterministic)
+}
+func (m *SLOStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SLOStatus.Merge(m, src)
+}
+func (m *SLOStatus) XXX_Size() int {
+	return xxx_messageInfo_SLOStatus.Size(m)
+}
+func (m *SLOStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SLOStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SLOStatus proto.InternalMessageInfo
+
+func (m *SLOStatus) GetTargetPassRate() float32 {
+	if m != nil {
+		return m.TargetPassRate
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetShortWindowHours() int32 {
+	if m != nil {
+		return m.ShortWindowHours
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetLongWindowHours() int32 {
+	if m != nil {
+		return m.LongWindowHours
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetShortPassRate() float32 {
+	if m != nil {
+		return m.ShortPassRate
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetLongPassRate() float32 {
+	if m != nil {
+		return m.LongPassRate
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetShortBurnRate() float32 {
+	if m != nil {
+		return m.ShortBurnRate
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetLongBurnRate() float32 {
+	if m != nil {
+		return m.LongBurnRate
+	}
+	return 0
+}
+
+func (m *SLOStatus) GetViolating() bool {
+	if m != nil {
+		return m.Violating
+	}
+	return false
+}
+
+// Compares the recent pass rate of a tab against a longer window.
+type HealthTrend struct {
+	ShortDays int32 `protobuf:"varint,1,opt,name=short_days,json=shortDays,proto3" json:"short_days,omitempty"`
+	LongDays  int32 `protobuf:"varint,2,opt,name=long_days,json=longDays,proto3" json:"long_days,omitempty"`
+	// Passing cells out of 100 results, excluding infra failures.
+	ShortPassRate float32 `protobuf:"fixed32,3,opt,name=short_pass_rate,json=shortPassRate,proto3" json:"short_pass_rate,omitempty"`
+	LongPassRate  float32 `protobuf:"fixed32,4,opt,name=long_pass_rate,json=longPassRate,proto3" json:"long_pass_rate,omitempty"`
+	// Short minus long pass rate.
+	Delta                float32               `protobuf:"fixed32,5,opt,name=delta,proto3" json:"delta,omitempty"`
+	Direction            HealthTrend_Direction `protobuf:"varint,6,opt,name=direction,proto3,enum=HealthTrend_Direction" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *HealthTrend) Reset()         { *m = HealthTrend{} }
+func (m *HealthTrend) String() string { return proto.CompactTextString(m) }
+func (*HealthTrend) ProtoMessage()    {}
+func (*HealthTrend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{15}
+}
+
+func (m *HealthTrend) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthTrend.Unmarshal(m, b)
+}
+func (m *HealthTrend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthTrend.Marshal(b, m, deterministic)
+}
+func (m *HealthTrend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthTrend.Merge(m, src)
+}
+func (m *HealthTrend) XXX_Size() int {
+	return xxx_messageInfo_HealthTrend.Size(m)
+}
+func (m *HealthTrend) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthTrend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthTrend proto.InternalMessageInfo
+
+func (m *HealthTrend) GetShortDays() int32 {
+	if m != nil {
+		return m.ShortDays
+	}
+	return 0
+}
+
+func (m *HealthTrend) GetLongDays() int32 {
+	if m != nil {
+		return m.LongDays
+	}
+	return 0
+}
+
+func (m *HealthTrend) GetShortPassRate() float32 {
+	if m != nil {
+		return m.ShortPassRate
+	}
+	return 0
+}
+
+func (m *HealthTrend) GetLongPassRate() float32 {
+	if m != nil {
+		return m.LongPassRate
+	}
+	return 0
+}
+
+func (m *HealthTrend) GetDelta() float32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *HealthTrend) GetDirection() HealthTrend_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return HealthTrend_UNKNOWN
+}
+
+// Recent failures of several tests with similar failure messages.
+type FailureCluster struct {
+	// Message of the first failure in the cluster.
+	FailureMessage string `protobuf:"bytes,1,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	// Display names of the tests failing with the message.
+	Tests []string `protobuf:"bytes,2,rep,name=tests,proto3" json:"tests,omitempty"`
+	// Number of failing cells in the cluster.
+	Failures             int32    `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailureCluster) Reset()         { *m = FailureCluster{} }
+func (m *FailureCluster) String() string { return proto.CompactTextString(m) }
+func (*FailureCluster) ProtoMessage()    {}
+func (*FailureCluster) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{16}
+}
+
+func (m *FailureCluster) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailureCluster.Unmarshal(m, b)
+}
+func (m *FailureCluster) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailureCluster.Marshal(b, m, deterministic)
+}
+func (m *FailureCluster) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailureCluster.Merge(m, src)
+}
+func (m *FailureCluster) XXX_Size() int {
+	return xxx_messageInfo_FailureCluster.Size(m)
+}
+func (m *FailureCluster) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailureCluster.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailureCluster proto.InternalMessageInfo
+
+func (m *FailureCluster) GetFailureMessage() string {
+	if m != nil {
+		return m.FailureMessage
+	}
+	return ""
+}
+
+func (m *FailureCluster) GetTests() []string {
+	if m != nil {
+		return m.Tests
+	}
+	return nil
+}
+
+func (m *FailureCluster) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+// Summary state of a dashboard.
+type DashboardSummary struct {
+	// Summary of a dashboard tab; see config.proto.
+	TabSummaries []*DashboardTabSummary `protobuf:"bytes,1,rep,name=tab_summaries,json=tabSummaries,proto3" json:"tab_summaries,omitempty"`
+	// Who is responsible for the dashboard; see Ownership in config.proto.
+	Ownership            *DashboardOwnership `protobuf:"bytes,2,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DashboardSummary) Reset()         { *m = DashboardSummary{} }
+func (m *DashboardSummary) String() string { return proto.CompactTextString(m) }
+func (*DashboardSummary) ProtoMessage()    {}
+func (*DashboardSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{17}
+}
+
+func (m *DashboardSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardSummary.Unmarshal(m, b)
+}
+func (m *DashboardSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardSummary.Marshal(b, m, deterministic)
+}
+func (m *DashboardSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardSummary.Merge(m, src)
+}
+func (m *DashboardSummary) XXX_Size() int {
+	return xxx_messageInfo_DashboardSummary.Size(m)
+}
+func (m *DashboardSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardSummary proto.InternalMessageInfo
+
+func (m *DashboardSummary) GetTabSummaries() []*DashboardTabSummary {
+	if m != nil {
+		return m.TabSummaries
+	}
+	return nil
+}
+
+func (m *DashboardSummary) GetOwnership() *DashboardOwnership {
+	if m != nil {
+		return m.Ownership
+	}
+	return nil
+}
+
+// The owners, team and contact of a dashboard.
+type DashboardOwnership struct {
+	Owners               []string `protobuf:"bytes,1,rep,name=owners,proto3" json:"owners,omitempty"`
+	Team                 string   `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	Contact              string   `protobuf:"bytes,3,opt,name=contact,proto3" json:"contact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardOwnership) Reset()         { *m = DashboardOwnership{} }
+func (m *DashboardOwnership) String() string { return proto.CompactTextString(m) }
+func (*DashboardOwnership) ProtoMessage()    {}
+func (*DashboardOwnership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{18}
+}
+
+func (m *DashboardOwnership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardOwnership.Unmarshal(m, b)
+}
+func (m *DashboardOwnership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardOwnership.Marshal(b, m, deterministic)
+}
+func (m *DashboardOwnership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardOwnership.Merge(m, src)
+}
+func (m *DashboardOwnership) XXX_Size() int {
+	return xxx_messageInfo_DashboardOwnership.Size(m)
+}
+func (m *DashboardOwnership) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardOwnership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardOwnership proto.InternalMessageInfo
+
+func (m *DashboardOwnership) GetOwners() []string {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *DashboardOwnership) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *DashboardOwnership) GetContact() string {
+	if m != nil {
+		return m.Contact
+	}
+	return ""
+}
+
+// Roll-up of the tab summaries of every dashboard in a dashboard group.
+// Stored in GCS as "group-<normalized dashboard group name>".
+type DashboardGroupSummary struct {
+	DashboardGroupName string `protobuf:"bytes,1,opt,name=dashboard_group_name,json=dashboardGroupName,proto3" json:"dashboard_group_name,omitempty"`
+	// Seconds since epoch at which the roll-up was computed.
+	LastUpdateTimestamp float64 `protobuf:"fixed64,2,opt,name=last_update_timestamp,json=lastUpdateTimestamp,proto3" json:"last_update_timestamp,omitempty"`
+	// Number of tabs in the group, and those passing or flaky.
+	TotalTabs   int32 `protobuf:"varint,3,opt,name=total_tabs,json=totalTabs,proto3" json:"total_tabs,omitempty"`
+	PassingTabs int32 `protobuf:"varint,4,opt,name=passing_tabs,json=passingTabs,proto3" json:"passing_tabs,omitempty"`
+	// Number of tabs with each status, keyed by the TabStatus name.
+	TabStatuses map[string]int32 `protobuf:"bytes,5,rep,name=tab_statuses,json=tabStatuses,proto3" json:"tab_statuses,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Roll-up of each dashboard, in the order of the group.
+	Dashboards []*DashboardRollup `protobuf:"bytes,6,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
+	// The least healthy tabs of the group, worst first.
+	WorstTabs            []*TabRollup `protobuf:"bytes,7,rep,name=worst_tabs,json=worstTabs,proto3" json:"worst_tabs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DashboardGroupSummary) Reset()         { *m = DashboardGroupSummary{} }
+func (m *DashboardGroupSummary) String() string { return proto.CompactTextString(m) }
+func (*DashboardGroupSummary) ProtoMessage()    {}
+func (*DashboardGroupSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{19}
+}
+
+func (m *DashboardGroupSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardGroupSummary.Unmarshal(m, b)
+}
+func (m *DashboardGroupSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardGroupSummary.Marshal(b, m, deterministic)
+}
+func (m *DashboardGroupSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardGroupSummary.Merge(m, src)
+}
+func (m *DashboardGroupSummary) XXX_Size() int {
+	return xxx_messageInfo_DashboardGroupSummary.Size(m)
+}
+func (m *DashboardGroupSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardGroupSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardGroupSummary proto.InternalMessageInfo
+
+func (m *DashboardGroupSummary) GetDashboardGroupName() string {
+	if m != nil {
+		return m.DashboardGroupName
+	}
+	return ""
+}
+
+func (m *DashboardGroupSummary) GetLastUpdateTimestamp() float64 {
+	if m != nil {
+		return m.LastUpdateTimestamp
+	}
+	return 0
+}
+
+func (m *DashboardGroupSummary) GetTotalTabs() int32 {
+	if m != nil {
+		return m.TotalTabs
+	}
+	return 0
+}
+
+func (m *DashboardGroupSummary) GetPassingTabs() int32 {
+	if m != nil {
+		return m.PassingTabs
+	}
+	return 0
+}
+
+func (m *DashboardGroupSummary) GetTabStatuses() map[string]int32 {
+	if m != nil {
+		return m.TabStatuses
+	}
+	return nil
+}
+
+func (m *DashboardGroupSummary) GetDashboards() []*DashboardRollup {
+	if m != nil {
+		return m.Dashboards
+	}
+	return nil
+}
+
+func (m *DashboardGroupSummary) GetWorstTabs() []*TabRollup {
+	if m != nil {
+		return m.WorstTabs
+	}
+	return nil
+}
+
+// Counts the tabs of a dashboard.
+type DashboardRollup struct {
+	DashboardName string `protobuf:"bytes,1,opt,name=dashboard_name,json=dashboardName,proto3" json:"dashboard_name,omitempty"`
+	TotalTabs     int32  `protobuf:"varint,2,opt,name=total_tabs,json=totalTabs,proto3" json:"total_tabs,omitempty"`
+	PassingTabs   int32  `protobuf:"varint,3,opt,name=passing_tabs,json=passingTabs,proto3" json:"passing_tabs,omitempty"`
+	// The worst status of any tab.
+	OverallStatus        DashboardTabSummary_TabStatus `protobuf:"varint,4,opt,name=overall_status,json=overallStatus,proto3,enum=DashboardTabSummary_TabStatus" json:"overall_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *DashboardRollup) Reset()         { *m = DashboardRollup{} }
+func (m *DashboardRollup) String() string { return proto.CompactTextString(m) }
+func (*DashboardRollup) ProtoMessage()    {}
+func (*DashboardRollup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{20}
+}
+
+func (m *DashboardRollup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardRollup.Unmarshal(m, b)
+}
+func (m *DashboardRollup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardRollup.Marshal(b, m, deterministic)
+}
+func (m *DashboardRollup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardRollup.Merge(m, src)
+}
+func (m *DashboardRollup) XXX_Size() int {
+	return xxx_messageInfo_DashboardRollup.Size(m)
+}
+func (m *DashboardRollup) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardRollup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardRollup proto.InternalMessageInfo
+
+func (m *DashboardRollup) GetDashboardName() string {
+	if m != nil {
+		return m.DashboardName
+	}
+	return ""
+}
+
+func (m *DashboardRollup) GetTotalTabs() int32 {
+	if m != nil {
+		return m.TotalTabs
+	}
+	return 0
+}
+
+func (m *DashboardRollup) GetPassingTabs() int32 {
+	if m != nil {
+		return m.PassingTabs
+	}
+	return 0
+}
+
+func (m *DashboardRollup) GetOverallStatus() DashboardTabSummary_TabStatus {
+	if m != nil {
+		return m.OverallStatus
+	}
+	return DashboardTabSummary_NOT_SET
+}
+
+// Identifies an unhealthy tab and why.
+type TabRollup struct {
+	DashboardName    string                        `protobuf:"bytes,1,opt,name=dashboard_name,json=dashboardName,proto3" json:"dashboard_name,omitempty"`
+	DashboardTabName string                        `protobuf:"bytes,2,opt,name=dashboard_tab_name,json=dashboardTabName,proto3" json:"dashboard_tab_name,omitempty"`
+	OverallStatus    DashboardTabSummary_TabStatus `protobuf:"varint,3,opt,name=overall_status,json=overallStatus,proto3,enum=DashboardTabSummary_TabStatus" json:"overall_status,omitempty"`
+	// The status message of the tab summary.
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// Number of tests failing in the tab.
+	FailingTests int32 `protobuf:"varint,5,opt,name=failing_tests,json=failingTests,proto3" json:"failing_tests,omitempty"`
+	// Seconds since epoch at which tests last ran.
+	LastRunTimestamp     float64  `protobuf:"fixed64,6,opt,name=last_run_timestamp,json=lastRunTimestamp,proto3" json:"last_run_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TabRollup) Reset()         { *m = TabRollup{} }
+func (m *TabRollup) String() string { return proto.CompactTextString(m) }
+func (*TabRollup) ProtoMessage()    {}
+func (*TabRollup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{21}
+}
+
+func (m *TabRollup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TabRollup.Unmarshal(m, b)
+}
+func (m *TabRollup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TabRollup.Marshal(b, m, deterministic)
+}
+func (m *TabRollup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TabRollup.Merge(m, src)
+}
+func (m *TabRollup) XXX_Size() int {
+	return xxx_messageInfo_TabRollup.Size(m)
+}
+func (m *TabRollup) XXX_DiscardUnknown() {
+	xxx_messageInfo_TabRollup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TabRollup proto.InternalMessageInfo
+
+func (m *TabRollup) GetDashboardName() string {
+	if m != nil {
+		return m.DashboardName
+	}
+	return ""
+}
+
+func (m *TabRollup) GetDashboardTabName() string {
+	if m != nil {
+		return m.DashboardTabName
+	}
+	return ""
+}
+
+func (m *TabRollup) GetOverallStatus() DashboardTabSummary_TabStatus {
+	if m != nil {
+		return m.OverallStatus
+	}
+	return DashboardTabSummary_NOT_SET
+}
+
+func (m *TabRollup) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TabRollup) GetFailingTests() int32 {
+	if m != nil {
+		return m.FailingTests
+	}
+	return 0
+}
+
+func (m *TabRollup) GetLastRunTimestamp() float64 {
+	if m != nil {
+		return m.LastRunTimestamp
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("TestInfo_Trend", TestInfo_Trend_name, TestInfo_Trend_value)
 	proto.RegisterEnum("DashboardTabSummary_TabStatus", DashboardTabSummary_TabStatus_name, DashboardTabSummary_TabStatus_value)
+	proto.RegisterEnum("HealthTrend_Direction", HealthTrend_Direction_name, HealthTrend_Direction_value)
 	proto.RegisterType((*FailingTestSummary)(nil), "FailingTestSummary")
 	proto.RegisterMapType((map[string]string)(nil), "FailingTestSummary.PropertiesEntry")
 	proto.RegisterType((*TestInfo)(nil), "TestInfo")
 	proto.RegisterMapType((map[string]int32)(nil), "TestInfo.InfraFailuresEntry")
 	proto.RegisterType((*HealthinessInfo)(nil), "HealthinessInfo")
+	proto.RegisterType((*TestFlakeRate)(nil), "TestFlakeRate")
+	proto.RegisterType((*FlakeRateWindow)(nil), "FlakeRateWindow")
+	proto.RegisterType((*FlakinessReport)(nil), "FlakinessReport")
+	proto.RegisterType((*FlakyTest)(nil), "FlakyTest")
+	proto.RegisterType((*FlakyTestDigest)(nil), "FlakyTestDigest")
+	proto.RegisterType((*AlertState)(nil), "AlertState")
+	proto.RegisterType((*TestIssue)(nil), "TestIssue")
+	proto.RegisterType((*DashboardAlertState)(nil), "DashboardAlertState")
 	proto.RegisterType((*AlertingData)(nil), "AlertingData")
 	proto.RegisterType((*DashboardTabSummary)(nil), "DashboardTabSummary")
+	proto.RegisterMapType((map[string]float32)(nil), "DashboardTabSummary.FlakeRatesEntry")
+	proto.RegisterType((*RecentHealth)(nil), "RecentHealth")
+	proto.RegisterType((*SLOStatus)(nil), "SLOStatus")
+	proto.RegisterType((*HealthTrend)(nil), "HealthTrend")
+	proto.RegisterType((*FailureCluster)(nil), "FailureCluster")
 	proto.RegisterType((*DashboardSummary)(nil), "DashboardSummary")
+	proto.RegisterType((*DashboardOwnership)(nil), "DashboardOwnership")
+	proto.RegisterType((*DashboardGroupSummary)(nil), "DashboardGroupSummary")
+	proto.RegisterMapType((map[string]int32)(nil), "DashboardGroupSummary.TabStatusesEntry")
+	proto.RegisterType((*DashboardRollup)(nil), "DashboardRollup")
+	proto.RegisterType((*TabRollup)(nil), "TabRollup")
 }
 
 func init() { proto.RegisterFile("summary.proto", fileDescriptor_f7168d0e3f3f5589) }
 
 var fileDescriptor_f7168d0e3f3f5589 = []byte{
-	// 1187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xff, 0x6e, 0xdb, 0x54,
-	0x14, 0x5e, 0x9a, 0x38, 0xad, 0x4f, 0xe2, 0xc4, 0xbd, 0x2b, 0xc3, 0x94, 0xc1, 0x4a, 0xc6, 0xa0,
-	0x82, 0xe1, 0x42, 0x10, 0x12, 0x20, 0x21, 0x91, 0x76, 0xc9, 0x96, 0xad, 0x4b, 0x27, 0x37, 0xd5,
-	0x84, 0xf8, 0xc3, 0xba, 0x99, 0x6f, 0x12, 0xab, 0x8e, 0x1d, 0xf9, 0x5e, 0x97, 0xf5, 0x51, 0x78,
-	0x13, 0x9e, 0x0a, 0x5e, 0x01, 0x9d, 0x73, 0x9d, 0xd8, 0xcb, 0x0a, 0xdb, 0x7f, 0xf6, 0x77, 0xbe,
-	0xef, 0xdc, 0x7b, 0xcf, 0x4f, 0xb0, 0x64, 0xb6, 0x58, 0xf0, 0xf4, 0xda, 0x5d, 0xa6, 0x89, 0x4a,
-	0xf6, 0xef, 0xcd, 0x92, 0x64, 0x16, 0x89, 0x23, 0xfa, 0x9b, 0x64, 0xd3, 0x23, 0x15, 0x2e, 0x84,
-	0x54, 0x7c, 0xb1, 0xd4, 0x84, 0xce, 0x3f, 0x06, 0xb0, 0x01, 0x0f, 0xa3, 0x30, 0x9e, 0x8d, 0x85,
-	0x54, 0xe7, 0x5a, 0xcd, 0x3e, 0x83, 0x66, 0x10, 0xca, 0x65, 0xc4, 0xaf, 0xfd, 0x98, 0x2f, 0x84,
-	0x53, 0x39, 0xa8, 0x1c, 0x9a, 0x5e, 0x23, 0xc7, 0x46, 0x7c, 0x21, 0xd8, 0xc7, 0x60, 0x2a, 0x21,
-	0x95, 0xb6, 0x6f, 0x91, 0x7d, 0x07, 0x01, 0x32, 0x76, 0xc0, 0x9a, 0xf2, 0x30, 0xf2, 0x27, 0x59,
-	0x18, 0x05, 0x7e, 0x18, 0x38, 0x55, 0xed, 0x00, 0xc1, 0x63, 0xc4, 0x86, 0x01, 0x7b, 0x00, 0x2d,
-	0xe2, 0xac, 0xaf, 0xe4, 0xd4, 0x0e, 0x2a, 0x87, 0x15, 0x8f, 0x94, 0xe3, 0x15, 0x88, 0xae, 0x96,
-	0x5c, 0xca, 0xc2, 0x95, 0xa1, 0x5d, 0x21, 0x58, 0x72, 0x45, 0x9c, 0xc2, 0x55, 0x5d, 0xbb, 0x42,
-	0xb4, 0x70, 0xf5, 0x09, 0x00, 0x9d, 0xf8, 0x2a, 0xc9, 0x62, 0xe5, 0x6c, 0x1f, 0x54, 0x0e, 0x0d,
-	0xcf, 0x44, 0xe4, 0x04, 0x01, 0x34, 0xeb, 0x43, 0xa2, 0x30, 0xbe, 0x74, 0x76, 0xe8, 0x18, 0x93,
-	0x90, 0xd3, 0x30, 0xbe, 0x64, 0x5f, 0x40, 0xbb, 0x30, 0xfb, 0x4a, 0xbc, 0x56, 0x8e, 0x49, 0x1c,
-	0x6b, 0xcd, 0x19, 0x8b, 0xd7, 0x8a, 0x7d, 0x0e, 0x2d, 0xcd, 0xcb, 0xd2, 0x48, 0xd3, 0x80, 0x68,
-	0x4d, 0x42, 0x2f, 0xd2, 0x88, 0x58, 0x5f, 0x42, 0x1b, 0x4f, 0xce, 0x52, 0xe1, 0x2f, 0x84, 0x94,
-	0x7c, 0x26, 0x9c, 0x06, 0xd1, 0x5a, 0x39, 0xfc, 0x5c, 0xa3, 0xec, 0x1e, 0x34, 0xf0, 0x40, 0x11,
-	0xf8, 0x93, 0x6c, 0x26, 0x9d, 0xe6, 0x41, 0xf5, 0xd0, 0xf4, 0x40, 0x43, 0xc7, 0xd9, 0x4c, 0xe2,
-	0x79, 0x3a, 0x8e, 0x98, 0x0d, 0xba, 0xba, 0xa5, 0xcf, 0xa3, 0x38, 0x0a, 0xa9, 0xe8, 0xf6, 0xdf,
-	0xc1, 0x07, 0x11, 0x27, 0xca, 0x06, 0x79, 0x97, 0xc8, 0x4c, 0x1b, 0x07, 0x65, 0xc9, 0x11, 0xec,
-	0x95, 0x25, 0xeb, 0x04, 0xb4, 0x48, 0xb1, 0x5b, 0x28, 0x56, 0x69, 0x38, 0x01, 0x58, 0xa6, 0xc9,
-	0x52, 0xa4, 0x2a, 0x14, 0xd2, 0x69, 0x1f, 0x54, 0x0f, 0x1b, 0xdd, 0xfb, 0xee, 0xdb, 0xe5, 0xe5,
-	0xbe, 0x58, 0xb3, 0xfa, 0xb1, 0x4a, 0xaf, 0xbd, 0x92, 0x0c, 0xdf, 0x3b, 0x4f, 0x54, 0x14, 0x4a,
-	0xe5, 0x87, 0x81, 0x74, 0x6c, 0xfd, 0xde, 0x1c, 0x1a, 0x06, 0x72, 0xff, 0x17, 0x68, 0x6f, 0xe8,
-	0x99, 0x0d, 0xd5, 0x4b, 0x71, 0x9d, 0x57, 0x29, 0x7e, 0xb2, 0x3d, 0x30, 0xae, 0x78, 0x94, 0xad,
-	0x2a, 0x53, 0xff, 0xfc, 0xbc, 0xf5, 0x63, 0xa5, 0xf3, 0xa7, 0x01, 0x3b, 0x78, 0x97, 0x61, 0x3c,
-	0x4d, 0xde, 0xa7, 0xce, 0x8f, 0x60, 0x4f, 0x25, 0x8a, 0x47, 0x7e, 0x9c, 0xc4, 0x7e, 0x18, 0x4f,
-	0x53, 0xee, 0xa7, 0x59, 0x2c, 0xc9, 0xb1, 0xe1, 0xed, 0x92, 0x6d, 0x94, 0xc4, 0x43, 0xb4, 0x78,
-	0x59, 0x2c, 0x31, 0xd2, 0x58, 0x76, 0x22, 0xd8, 0x54, 0x54, 0x49, 0xc1, 0xb4, 0x71, 0x53, 0x82,
-	0x21, 0x7e, 0x5b, 0x52, 0xd3, 0x12, 0x6d, 0x7c, 0x43, 0xf2, 0x15, 0xec, 0xe6, 0x92, 0x12, 0xdd,
-	0x20, 0x7a, 0x5b, 0x1b, 0xde, 0x70, 0xaf, 0x9f, 0x80, 0x24, 0xff, 0x8f, 0x50, 0xcd, 0xb5, 0x88,
-	0xba, 0xc4, 0xf0, 0x18, 0x19, 0x91, 0xf9, 0x32, 0x54, 0x73, 0x92, 0x61, 0x2f, 0x24, 0x6a, 0x2e,
-	0x52, 0xed, 0x37, 0x6f, 0x15, 0x42, 0xc8, 0xe3, 0x5d, 0x30, 0xa7, 0x11, 0xbf, 0x0c, 0x63, 0x21,
-	0x25, 0x75, 0xca, 0x96, 0x57, 0x00, 0xec, 0x1b, 0x60, 0xcb, 0x54, 0x5c, 0x85, 0x49, 0x26, 0xfd,
-	0x82, 0x06, 0x07, 0xd5, 0xc3, 0x2d, 0x6f, 0x77, 0x65, 0x19, 0xac, 0xe9, 0x4f, 0xe1, 0xa3, 0x57,
-	0x73, 0x1e, 0xcf, 0x84, 0x3f, 0x4d, 0x93, 0x85, 0x1f, 0x71, 0x4c, 0x7d, 0xac, 0x44, 0x7a, 0xc5,
-	0x23, 0x6a, 0xb1, 0x56, 0xb7, 0xed, 0xae, 0x52, 0xe6, 0x8e, 0x53, 0x11, 0x07, 0xde, 0x1d, 0xad,
-	0x18, 0xa4, 0xc9, 0xe2, 0x94, 0xa3, 0x45, 0xd3, 0xd9, 0x09, 0xb4, 0x74, 0x3c, 0xf2, 0x2e, 0x92,
-	0x4e, 0x83, 0xca, 0xf0, 0x6e, 0xe1, 0x80, 0x1e, 0x38, 0xc8, 0xcd, 0xba, 0xfe, 0xac, 0xb0, 0x8c,
-	0xed, 0xff, 0x0a, 0xec, 0x6d, 0xd2, 0xbb, 0x8a, 0xcc, 0x28, 0x17, 0xd9, 0x0f, 0x60, 0xd0, 0x3d,
-	0x59, 0x03, 0xb6, 0x2f, 0x46, 0xcf, 0x46, 0x67, 0x2f, 0x47, 0xf6, 0x2d, 0x66, 0x81, 0x39, 0x3a,
-	0xf3, 0x4f, 0x9e, 0xf4, 0x46, 0x8f, 0xfb, 0x76, 0x85, 0xd5, 0x61, 0xeb, 0xe2, 0x85, 0xbd, 0xc5,
-	0x76, 0xa0, 0xf6, 0x08, 0x09, 0xd5, 0xce, 0xdf, 0x15, 0x68, 0x3f, 0x11, 0x3c, 0x52, 0x73, 0x8a,
-	0x0c, 0x95, 0xe8, 0xb7, 0x60, 0x48, 0xc5, 0x53, 0x45, 0x07, 0x37, 0xba, 0xfb, 0xae, 0x1e, 0xe9,
-	0xee, 0x6a, 0xa4, 0xbb, 0xeb, 0xf9, 0xe6, 0x69, 0x22, 0x7b, 0x08, 0x55, 0x11, 0x07, 0x74, 0xa9,
-	0xff, 0xe7, 0x23, 0x8d, 0xdd, 0x03, 0x03, 0xfb, 0x18, 0xcb, 0x13, 0x03, 0x65, 0xae, 0x03, 0xe5,
-	0x69, 0x9c, 0x7d, 0x0d, 0xbb, 0xfc, 0x4a, 0xa4, 0x1c, 0xf3, 0xb3, 0x4e, 0x66, 0x8d, 0x72, 0x6e,
-	0xe7, 0x86, 0xc1, 0x3b, 0x52, 0x6f, 0xfc, 0x47, 0xea, 0x3b, 0x1e, 0x34, 0x7b, 0x11, 0x76, 0x72,
-	0x3c, 0x7b, 0xc4, 0x15, 0x67, 0xc7, 0xd0, 0xa6, 0xf4, 0x8b, 0xc5, 0x6a, 0x33, 0xbc, 0xc7, 0xb3,
-	0x2d, 0x94, 0xf4, 0x17, 0xf9, 0xd6, 0xe8, 0xfc, 0x65, 0xc0, 0xed, 0x47, 0x5c, 0xce, 0x27, 0x09,
-	0x4f, 0x83, 0x31, 0x9f, 0xac, 0x76, 0xda, 0x03, 0x68, 0x05, 0x2b, 0xb8, 0xdc, 0xed, 0xd6, 0x1a,
-	0xa5, 0x7e, 0x7f, 0x08, 0xac, 0xa0, 0x29, 0x3e, 0x29, 0x2f, 0x38, 0x3b, 0x28, 0xf9, 0x25, 0xf6,
-	0x1e, 0x18, 0x1c, 0x1f, 0x90, 0x2f, 0x38, 0xfd, 0xc3, 0x86, 0x70, 0x67, 0xaa, 0xa7, 0x9e, 0x1e,
-	0xb4, 0x7a, 0x29, 0xe3, 0x50, 0xac, 0x51, 0x90, 0x6f, 0xdf, 0x30, 0x14, 0xbd, 0xbd, 0xe9, 0x26,
-	0x86, 0xe3, 0xb0, 0x8b, 0x73, 0x5b, 0x2a, 0x3f, 0x5b, 0x06, 0x5c, 0x89, 0xd2, 0x86, 0x33, 0x68,
-	0xc3, 0xdd, 0x46, 0xe3, 0x05, 0xd9, 0x8a, 0x3d, 0x77, 0x07, 0xea, 0x52, 0x71, 0x95, 0x49, 0x6a,
-	0x70, 0xd3, 0xcb, 0xff, 0x58, 0x1f, 0x5a, 0x09, 0x26, 0x2c, 0x8a, 0xfc, 0xdc, 0xbe, 0x4d, 0xdd,
-	0xf5, 0xa9, 0x7b, 0x43, 0xbc, 0x5c, 0xfc, 0x24, 0x96, 0x67, 0xe5, 0x2a, 0xfd, 0x8b, 0x43, 0x33,
-	0xdf, 0x0b, 0xb3, 0x54, 0x88, 0x38, 0xdf, 0x94, 0x0d, 0x8d, 0x3d, 0x46, 0x08, 0x83, 0x48, 0xb7,
-	0x4e, 0xb3, 0xb8, 0x74, 0x65, 0x93, 0xae, 0x6c, 0xa3, 0xc5, 0xcb, 0xe2, 0xe2, 0xbe, 0x1f, 0xc2,
-	0xf6, 0x24, 0x9b, 0xe1, 0xbe, 0xcc, 0x57, 0x65, 0x7d, 0x92, 0xcd, 0x2e, 0xd2, 0x88, 0x75, 0xa1,
-	0x31, 0x2f, 0xda, 0xc1, 0x69, 0x52, 0x29, 0xd8, 0xee, 0x46, 0x8b, 0x78, 0x65, 0x12, 0xbb, 0x0f,
-	0x56, 0xbe, 0x2f, 0x43, 0x29, 0x33, 0x21, 0x1d, 0x8b, 0x36, 0x48, 0x53, 0x83, 0x43, 0xc2, 0x58,
-	0x17, 0x2c, 0x9e, 0xd7, 0x9d, 0x1f, 0x70, 0xc5, 0x69, 0xa7, 0x35, 0xba, 0x96, 0x5b, 0xae, 0x46,
-	0xaf, 0xc9, 0x4b, 0x7f, 0x9d, 0xdf, 0xc1, 0x5c, 0x87, 0x04, 0xfb, 0x7a, 0x74, 0x36, 0xf6, 0xcf,
-	0xfb, 0x63, 0xfb, 0x56, 0xb9, 0xc9, 0x2b, 0xd8, 0xcd, 0x2f, 0x7a, 0xe7, 0xe7, 0xba, 0xaf, 0x07,
-	0xbd, 0xe1, 0xa9, 0x5d, 0x65, 0x26, 0x18, 0x83, 0xd3, 0xde, 0xb3, 0xdf, 0xec, 0x1a, 0x7e, 0x9e,
-	0x8f, 0x7b, 0xa7, 0x7d, 0xdb, 0x60, 0x00, 0xf5, 0x63, 0xef, 0xec, 0x59, 0x7f, 0x64, 0xd7, 0x9f,
-	0xd6, 0x76, 0x1a, 0x76, 0xb3, 0xf3, 0x1c, 0xec, 0x75, 0x26, 0x56, 0x65, 0xfb, 0x13, 0x58, 0x58,
-	0x85, 0x45, 0x09, 0x55, 0xa8, 0x84, 0xf6, 0x6e, 0xca, 0x99, 0xd7, 0x54, 0xab, 0xef, 0x50, 0xc8,
-	0x49, 0x9d, 0x9a, 0xe5, 0xfb, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x30, 0x43, 0x83, 0xdd, 0x15,
-	0x0a, 0x00, 0x00,
+	// 2466 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcb, 0x72, 0x23, 0xb7,
+	0xd5, 0x36, 0xaf, 0x52, 0x1f, 0x5e, 0x85, 0xe1, 0x8c, 0xdb, 0xf2, 0xf8, 0x1f, 0x99, 0x9e, 0xdf,
+	0x56, 0x1c, 0x9b, 0x63, 0xcb, 0xb9, 0xd9, 0xa9, 0x5c, 0x74, 0x1d, 0xcb, 0x96, 0xa5, 0xa9, 0x96,
+	0x94, 0xa9, 0x24, 0x8b, 0x2e, 0x90, 0x0d, 0x92, 0x5d, 0xd3, 0xec, 0x66, 0xa1, 0xd1, 0x33, 0x56,
+	0x56, 0x79, 0x83, 0x54, 0x79, 0x97, 0x55, 0x96, 0x49, 0x55, 0x5e, 0x22, 0xd9, 0x25, 0x8f, 0x90,
+	0x27, 0xc8, 0x2e, 0xab, 0x3c, 0x40, 0xea, 0x1c, 0xa0, 0x2f, 0x94, 0xa8, 0x92, 0xe4, 0x2a, 0xef,
+	0x1a, 0xdf, 0xf9, 0x00, 0x1c, 0x1c, 0x1c, 0x00, 0xdf, 0x21, 0xa1, 0x15, 0x27, 0xb3, 0x19, 0x97,
+	0x17, 0x83, 0xb9, 0x8c, 0x54, 0xb4, 0xfe, 0x68, 0x12, 0x45, 0x93, 0x40, 0x3c, 0xa1, 0xd6, 0x30,
+	0x19, 0x3f, 0x51, 0xfe, 0x4c, 0xc4, 0x8a, 0xcf, 0xe6, 0x9a, 0xd0, 0xff, 0x77, 0x1d, 0xd8, 0x01,
+	0xf7, 0x03, 0x3f, 0x9c, 0x9c, 0x89, 0x58, 0x9d, 0xea, 0xde, 0xec, 0x6d, 0x68, 0x7a, 0x7e, 0x3c,
+	0x0f, 0xf8, 0x85, 0x1b, 0xf2, 0x99, 0xb0, 0x4b, 0x1b, 0xa5, 0x4d, 0xcb, 0x69, 0x18, 0xec, 0x98,
+	0xcf, 0x04, 0x7b, 0x13, 0x2c, 0x25, 0x62, 0xa5, 0xed, 0x65, 0xb2, 0xaf, 0x22, 0x40, 0xc6, 0x3e,
+	0xb4, 0xc6, 0xdc, 0x0f, 0xdc, 0x61, 0xe2, 0x07, 0x9e, 0xeb, 0x7b, 0x76, 0x45, 0x0f, 0x80, 0xe0,
+	0x0e, 0x62, 0x87, 0x1e, 0xfb, 0x7f, 0x68, 0x13, 0x27, 0x73, 0xc9, 0xae, 0x6e, 0x94, 0x36, 0x4b,
+	0x0e, 0xf5, 0x3c, 0x4b, 0x41, 0x1c, 0x6a, 0xce, 0xe3, 0x38, 0x1f, 0xaa, 0xa6, 0x87, 0x42, 0xb0,
+	0x30, 0x14, 0x71, 0xf2, 0xa1, 0xea, 0x7a, 0x28, 0x44, 0xf3, 0xa1, 0xde, 0x02, 0xa0, 0x19, 0x47,
+	0x51, 0x12, 0x2a, 0x7b, 0x65, 0xa3, 0xb4, 0x59, 0x73, 0x2c, 0x44, 0x76, 0x11, 0x40, 0xb3, 0x9e,
+	0x24, 0xf0, 0xc3, 0x17, 0xf6, 0x2a, 0x4d, 0x63, 0x11, 0x72, 0xe4, 0x87, 0x2f, 0xd8, 0xbb, 0xd0,
+	0xc9, 0xcd, 0xae, 0x12, 0x5f, 0x2b, 0xdb, 0x22, 0x4e, 0x2b, 0xe3, 0x9c, 0x89, 0xaf, 0x15, 0x7b,
+	0x0c, 0x6d, 0xcd, 0x4b, 0x64, 0xa0, 0x69, 0x40, 0xb4, 0x26, 0xa1, 0xe7, 0x32, 0x20, 0xd6, 0x7b,
+	0xd0, 0xc1, 0x99, 0x13, 0x29, 0xdc, 0x99, 0x88, 0x63, 0x3e, 0x11, 0x76, 0x83, 0x68, 0x6d, 0x03,
+	0x7f, 0xa5, 0x51, 0xf6, 0x08, 0x1a, 0x38, 0xa1, 0xf0, 0xdc, 0x61, 0x32, 0x89, 0xed, 0xe6, 0x46,
+	0x65, 0xd3, 0x72, 0x40, 0x43, 0x3b, 0xc9, 0x24, 0xc6, 0xf9, 0x74, 0x1c, 0x71, 0x37, 0xc8, 0xf5,
+	0x96, 0x9e, 0x8f, 0xe2, 0x28, 0x62, 0x45, 0xde, 0x7f, 0x0c, 0xf7, 0x03, 0x4e, 0x94, 0x4b, 0xe4,
+	0x35, 0x22, 0x33, 0x6d, 0x3c, 0x28, 0x76, 0x79, 0x02, 0xbd, 0x62, 0x97, 0x6c, 0x03, 0xda, 0xd4,
+	0x63, 0x2d, 0xef, 0x91, 0x6e, 0xc3, 0x2e, 0xc0, 0x5c, 0x46, 0x73, 0x21, 0x95, 0x2f, 0x62, 0xbb,
+	0xb3, 0x51, 0xd9, 0x6c, 0x6c, 0xbd, 0x33, 0xb8, 0x9a, 0x5e, 0x83, 0x67, 0x19, 0x6b, 0x3f, 0x54,
+	0xf2, 0xc2, 0x29, 0x74, 0xc3, 0xf5, 0x4e, 0x23, 0x15, 0xf8, 0xb1, 0x72, 0x7d, 0x2f, 0xb6, 0xbb,
+	0x7a, 0xbd, 0x06, 0x3a, 0xf4, 0x62, 0xf6, 0x63, 0xb0, 0x8b, 0x6e, 0x71, 0xa9, 0xfc, 0x31, 0x1f,
+	0x29, 0x0c, 0xb7, 0xcd, 0xc8, 0xb5, 0xfb, 0xb9, 0x6b, 0xdb, 0xc6, 0x7a, 0x2e, 0x03, 0xcc, 0x58,
+	0x3f, 0x8e, 0x13, 0x41, 0xcc, 0x7b, 0x3a, 0x63, 0x09, 0x40, 0xe3, 0x06, 0x34, 0xc7, 0x7e, 0x20,
+	0x30, 0xc8, 0x64, 0xef, 0x91, 0x1d, 0x10, 0xdb, 0x49, 0x26, 0xe7, 0x32, 0x58, 0xff, 0x19, 0x74,
+	0x2e, 0xf9, 0xcd, 0xba, 0x50, 0x79, 0x21, 0x2e, 0xcc, 0xe9, 0xc0, 0x4f, 0xd6, 0x83, 0xda, 0x4b,
+	0x1e, 0x24, 0xe9, 0x89, 0xd0, 0x8d, 0xcf, 0xca, 0x3f, 0x29, 0xf5, 0xff, 0x58, 0x83, 0x55, 0x8c,
+	0xc1, 0x61, 0x38, 0x8e, 0x6e, 0x73, 0xbe, 0x9e, 0x40, 0x4f, 0x45, 0x8a, 0x07, 0x6e, 0x18, 0x85,
+	0xae, 0x1f, 0x8e, 0x25, 0x77, 0x65, 0x12, 0xc6, 0x34, 0x70, 0xcd, 0x59, 0x23, 0xdb, 0x71, 0x14,
+	0x1e, 0xa2, 0xc5, 0x49, 0xc2, 0x18, 0x77, 0x18, 0xd3, 0x5d, 0x78, 0x97, 0x7b, 0x54, 0xa8, 0x07,
+	0xd3, 0xc6, 0xcb, 0x5d, 0x30, 0x86, 0x57, 0xbb, 0x54, 0x75, 0x17, 0x6d, 0x5c, 0xe8, 0xf2, 0x3e,
+	0xac, 0x99, 0x2e, 0x05, 0x7a, 0x8d, 0xe8, 0x1d, 0x6d, 0x58, 0x18, 0x5e, 0x2f, 0x01, 0x49, 0xee,
+	0x2b, 0x5f, 0x4d, 0x75, 0x27, 0x3a, 0x9d, 0x35, 0x87, 0x91, 0x11, 0x99, 0xcf, 0x7d, 0x35, 0xa5,
+	0x6e, 0x78, 0x06, 0x23, 0x35, 0x15, 0x52, 0x8f, 0x6b, 0x8e, 0x28, 0x21, 0x34, 0xe2, 0x43, 0xb0,
+	0xc6, 0x01, 0x7f, 0xe1, 0x87, 0x22, 0x8e, 0xe9, 0x84, 0x96, 0x9d, 0x1c, 0x60, 0x1f, 0x02, 0x9b,
+	0x4b, 0xf1, 0xd2, 0x8f, 0x92, 0xd8, 0xcd, 0x69, 0xb0, 0x51, 0xd9, 0x2c, 0x3b, 0x6b, 0xa9, 0xe5,
+	0x20, 0xa3, 0x7f, 0x01, 0x6f, 0x8c, 0xa6, 0x3c, 0x9c, 0x08, 0x77, 0x2c, 0xa3, 0x99, 0x1b, 0x70,
+	0x4c, 0xb9, 0x50, 0x09, 0xf9, 0x92, 0x07, 0x74, 0xb4, 0xdb, 0x5b, 0x9d, 0x41, 0xba, 0x65, 0x83,
+	0x33, 0x29, 0x42, 0xcf, 0x79, 0xa0, 0x7b, 0x1c, 0xc8, 0x68, 0x76, 0xc4, 0xd1, 0xa2, 0xe9, 0x6c,
+	0x17, 0xda, 0x3a, 0x1e, 0xe6, 0xf4, 0xc6, 0x76, 0x83, 0xd2, 0xff, 0x61, 0x3e, 0x00, 0x2d, 0xf0,
+	0xc0, 0x98, 0x75, 0xde, 0xb7, 0xfc, 0x22, 0xb6, 0xfe, 0x4b, 0x60, 0x57, 0x49, 0x37, 0x25, 0x59,
+	0xad, 0x98, 0x64, 0x3f, 0x84, 0x1a, 0xf9, 0xc9, 0x1a, 0xb0, 0x72, 0x7e, 0xfc, 0xe5, 0xf1, 0xc9,
+	0xf3, 0xe3, 0xee, 0x6b, 0xac, 0x05, 0xd6, 0xf1, 0x89, 0xbb, 0xfb, 0xf9, 0xf6, 0xf1, 0xd3, 0xfd,
+	0x6e, 0x89, 0xd5, 0xa1, 0x7c, 0xfe, 0xac, 0x5b, 0x66, 0xab, 0x50, 0xdd, 0x43, 0x42, 0xa5, 0xff,
+	0x9f, 0x12, 0x74, 0x3e, 0x17, 0x3c, 0x50, 0x53, 0x8a, 0x0c, 0xa5, 0xe8, 0x47, 0x50, 0x8b, 0x15,
+	0x97, 0x8a, 0x26, 0x6e, 0x6c, 0xad, 0x0f, 0xf4, 0x53, 0x32, 0x48, 0x9f, 0x92, 0x41, 0x76, 0xaf,
+	0x3a, 0x9a, 0xc8, 0x3e, 0x80, 0x8a, 0x08, 0x3d, 0xbb, 0x7c, 0x23, 0x1f, 0x69, 0xec, 0x11, 0xd4,
+	0xf0, 0x90, 0x62, 0x7a, 0x62, 0xa0, 0xac, 0x2c, 0x50, 0x8e, 0xc6, 0xd9, 0xf7, 0x61, 0x8d, 0xbf,
+	0x14, 0x92, 0xe3, 0xfe, 0x64, 0x9b, 0x59, 0xa5, 0x3d, 0xef, 0x1a, 0xc3, 0xc1, 0x0d, 0x5b, 0x5f,
+	0xbb, 0x66, 0xeb, 0xfb, 0xff, 0x28, 0x41, 0x0b, 0xe7, 0x43, 0x44, 0x38, 0x5c, 0x89, 0xdb, 0x9c,
+	0x48, 0x06, 0xd5, 0xc2, 0x09, 0xa4, 0x6f, 0xf6, 0x01, 0x98, 0x73, 0xe5, 0xf2, 0xb1, 0xc2, 0xb4,
+	0x15, 0x4a, 0x5e, 0x98, 0x13, 0xd7, 0xd5, 0x96, 0x6d, 0x34, 0x38, 0x88, 0xb3, 0x4f, 0xe0, 0x3e,
+	0x25, 0xd8, 0xcc, 0x57, 0x4a, 0x84, 0x2a, 0x4f, 0x16, 0x7d, 0xde, 0x7a, 0x45, 0x63, 0x9a, 0x04,
+	0xf4, 0x6a, 0xa1, 0x9b, 0xae, 0xe4, 0x4a, 0xd8, 0xb5, 0x3c, 0xe9, 0xc9, 0xf1, 0xfe, 0x5f, 0x4b,
+	0xd0, 0xc9, 0x96, 0xf1, 0xdc, 0x0f, 0xbd, 0xe8, 0x15, 0x7a, 0xea, 0xf1, 0x8b, 0x98, 0x16, 0x51,
+	0x73, 0xe8, 0x3b, 0xdf, 0xcf, 0xf2, 0x1d, 0xf7, 0xb3, 0x72, 0xbb, 0xfd, 0x7c, 0x9c, 0xee, 0x67,
+	0x95, 0xf6, 0xb3, 0x3d, 0x58, 0x88, 0xaf, 0xd9, 0xd4, 0xfe, 0x37, 0xc6, 0x5b, 0xda, 0x06, 0x47,
+	0xcc, 0x23, 0xa9, 0xf0, 0xf5, 0xf6, 0x78, 0x3c, 0x1d, 0x46, 0x5c, 0x7a, 0xc5, 0xe0, 0xb7, 0x32,
+	0x94, 0xc2, 0xff, 0x01, 0xb0, 0x9c, 0xa6, 0xf8, 0xb0, 0xa8, 0x3c, 0xba, 0x99, 0xe5, 0x8c, 0x0f,
+	0x89, 0xfd, 0x3e, 0xac, 0xbc, 0xa2, 0x60, 0xa4, 0x09, 0xd6, 0x1d, 0x5c, 0x8a, 0x92, 0x93, 0x12,
+	0xfa, 0x7f, 0x28, 0x81, 0x85, 0xc6, 0x0b, 0x74, 0xf9, 0xbb, 0x71, 0xe7, 0xc3, 0x85, 0x4d, 0xd4,
+	0x21, 0xbd, 0x1c, 0xa2, 0xc2, 0xa6, 0xfe, 0xcb, 0x84, 0x89, 0x3c, 0xda, 0xf3, 0x27, 0xe8, 0xd7,
+	0x47, 0xd0, 0xcb, 0x27, 0x9c, 0xc8, 0x28, 0x99, 0x17, 0xbd, 0xcb, 0x9d, 0x79, 0x8a, 0xa6, 0x34,
+	0x61, 0x29, 0x0d, 0xca, 0xcb, 0xd2, 0xa0, 0x72, 0xc7, 0x34, 0xa8, 0xde, 0x2e, 0x0d, 0x36, 0xd2,
+	0x34, 0xa8, 0x51, 0xd4, 0x61, 0x90, 0x2d, 0x23, 0x4d, 0x81, 0xbf, 0x95, 0x01, 0xb6, 0x03, 0x21,
+	0xd5, 0xa9, 0xe2, 0xea, 0xba, 0x38, 0x96, 0xae, 0x89, 0xe3, 0x4f, 0xa1, 0x31, 0xf6, 0x25, 0xbe,
+	0xfd, 0xbe, 0x14, 0xb7, 0xb9, 0x6b, 0x80, 0xe8, 0x07, 0xc8, 0x66, 0x9f, 0x02, 0x04, 0x3c, 0xeb,
+	0x7b, 0x73, 0x00, 0xac, 0x80, 0xa7, 0x5d, 0xdf, 0x83, 0x0e, 0x1f, 0xbd, 0x08, 0xa3, 0x57, 0x81,
+	0xf0, 0x26, 0xa8, 0xc5, 0x2e, 0x28, 0x20, 0x96, 0xd3, 0x2e, 0xc2, 0x3b, 0x17, 0xec, 0x17, 0xd0,
+	0x8a, 0xc3, 0x28, 0xfa, 0x9d, 0xf0, 0xdc, 0x24, 0x54, 0x7e, 0x60, 0xd7, 0x6e, 0x9c, 0xa6, 0x69,
+	0x3a, 0x9c, 0x23, 0x9f, 0xf5, 0xa1, 0x4e, 0xa2, 0x24, 0xb6, 0xeb, 0x26, 0x82, 0x74, 0x31, 0x22,
+	0xe4, 0x18, 0x4b, 0x3f, 0x00, 0x2b, 0x03, 0x6f, 0x7b, 0x73, 0x89, 0x79, 0x64, 0xb2, 0x93, 0xbe,
+	0xd9, 0x03, 0xa8, 0x87, 0xc9, 0x6c, 0x28, 0x24, 0x05, 0xa2, 0xe2, 0x98, 0x16, 0x3e, 0x37, 0xa8,
+	0x7f, 0xf4, 0xea, 0xf0, 0xb3, 0xff, 0x23, 0xb8, 0xb7, 0x97, 0xee, 0x43, 0x61, 0xe3, 0x1e, 0x41,
+	0x55, 0xf1, 0x21, 0x5e, 0x32, 0xe8, 0x66, 0x63, 0x90, 0x9b, 0x1c, 0x32, 0xf4, 0x1d, 0x68, 0x12,
+	0xe6, 0x87, 0x93, 0x3d, 0xae, 0x38, 0xdb, 0x81, 0x0e, 0x85, 0x5f, 0xcc, 0x52, 0xd9, 0x7f, 0x8b,
+	0xb7, 0xa5, 0x85, 0x5d, 0xf6, 0x67, 0xa6, 0x24, 0xe8, 0xff, 0x17, 0x0a, 0xce, 0x9c, 0xf1, 0x61,
+	0x5a, 0xb0, 0x7c, 0x27, 0x87, 0xb6, 0x07, 0x35, 0x8e, 0x0b, 0x30, 0xd5, 0x8b, 0x6e, 0xb0, 0x43,
+	0x78, 0x30, 0xd6, 0x92, 0x56, 0xab, 0x68, 0x5d, 0x71, 0xf9, 0x22, 0xbd, 0xf9, 0xee, 0x2d, 0x51,
+	0xbc, 0x4e, 0x6f, 0x7c, 0x19, 0x43, 0xad, 0xbb, 0x85, 0xa2, 0x3c, 0x56, 0x6e, 0x32, 0xf7, 0xb8,
+	0x12, 0x85, 0xf2, 0xa5, 0x46, 0xe5, 0xcb, 0x3d, 0x34, 0x9e, 0x93, 0x2d, 0x2f, 0x62, 0x1e, 0x40,
+	0x3d, 0x56, 0x5c, 0x25, 0x31, 0xa9, 0x28, 0xcb, 0x31, 0x2d, 0xb6, 0x0f, 0xed, 0x08, 0x5f, 0xc5,
+	0x20, 0x70, 0x8d, 0x7d, 0x85, 0x24, 0xcc, 0xff, 0x0d, 0x96, 0xc4, 0x6b, 0x80, 0x9f, 0xc4, 0x72,
+	0x5a, 0xa6, 0x97, 0x6e, 0x62, 0x36, 0x19, 0x75, 0x3d, 0x91, 0x42, 0x84, 0xa6, 0x0c, 0x6a, 0x68,
+	0xec, 0x29, 0x42, 0x18, 0x44, 0xf2, 0x5a, 0x26, 0x61, 0xc1, 0x65, 0x8b, 0x5c, 0xee, 0xa2, 0xc5,
+	0x49, 0xc2, 0xdc, 0xdf, 0xd7, 0x61, 0x25, 0xd5, 0xd4, 0xba, 0x0e, 0xaa, 0x0f, 0x49, 0x4f, 0xb3,
+	0x2d, 0x68, 0x4c, 0x73, 0xcd, 0x61, 0x37, 0x29, 0x15, 0xba, 0x83, 0x4b, 0x3a, 0xc4, 0x29, 0x92,
+	0xd8, 0x3b, 0xd0, 0x32, 0xc5, 0x90, 0x39, 0x23, 0x2d, 0x2a, 0x0f, 0x9a, 0x1a, 0xa4, 0xf3, 0x80,
+	0x51, 0x6d, 0x71, 0x93, 0x77, 0xae, 0xc7, 0x15, 0xa7, 0x82, 0xa5, 0xb1, 0xd5, 0x1a, 0x14, 0xb3,
+	0xd1, 0x69, 0xf2, 0x42, 0x8b, 0xed, 0x43, 0x23, 0xbf, 0x9f, 0xd3, 0xda, 0xe5, 0xf1, 0xd2, 0xd0,
+	0x65, 0x17, 0x76, 0x5a, 0xbc, 0x64, 0xd7, 0x76, 0xcc, 0x3e, 0x83, 0x6e, 0x5a, 0xd5, 0x8d, 0x82,
+	0x24, 0x56, 0x42, 0xea, 0x0a, 0xa6, 0xb1, 0xd5, 0x19, 0x98, 0x07, 0x7d, 0x57, 0xe3, 0x4e, 0x67,
+	0xbc, 0xd0, 0x8e, 0xd9, 0x13, 0x68, 0xea, 0xa5, 0xba, 0x0a, 0x25, 0x1c, 0x15, 0x66, 0x8d, 0xad,
+	0xa6, 0x09, 0x88, 0x96, 0x9f, 0x8d, 0x69, 0xde, 0xc0, 0x3b, 0x69, 0x22, 0x7d, 0xcf, 0x9d, 0x88,
+	0x50, 0x48, 0xae, 0xfc, 0x28, 0xa4, 0xfa, 0xa7, 0xe2, 0xb4, 0x11, 0x7e, 0x9a, 0xa1, 0x28, 0x8e,
+	0x46, 0x51, 0x38, 0xf6, 0x27, 0xee, 0xd8, 0x0f, 0x27, 0x42, 0xce, 0xa5, 0x1f, 0x2a, 0x53, 0x01,
+	0xad, 0x69, 0xcb, 0x41, 0x6e, 0xc0, 0x87, 0x66, 0x41, 0xcb, 0xea, 0xca, 0x2f, 0xb6, 0x7b, 0x14,
+	0x6b, 0x56, 0xd4, 0xac, 0x54, 0xf9, 0x91, 0x54, 0x1b, 0x45, 0xb3, 0x79, 0x20, 0x94, 0xf0, 0xdc,
+	0x51, 0x14, 0x24, 0xb3, 0x30, 0xb6, 0xef, 0x6b, 0x11, 0x94, 0x19, 0x76, 0x35, 0x8e, 0x6e, 0xa3,
+	0x30, 0xc2, 0xdd, 0x49, 0xa9, 0x0f, 0x88, 0xda, 0x36, 0x70, 0x4a, 0x7c, 0x9b, 0x4a, 0x32, 0x2c,
+	0x35, 0x46, 0x22, 0x08, 0x62, 0xfb, 0x75, 0x62, 0x35, 0x34, 0xb6, 0x8b, 0x10, 0xe6, 0x43, 0x36,
+	0x16, 0x71, 0x6c, 0xe2, 0x34, 0xd3, 0x91, 0x88, 0xf4, 0x10, 0x2a, 0x71, 0x10, 0xd9, 0x6f, 0x50,
+	0x3c, 0x61, 0x70, 0x7a, 0x74, 0x62, 0x52, 0x1f, 0x61, 0xcc, 0x16, 0x29, 0x46, 0xa8, 0xc6, 0x74,
+	0x6c, 0xed, 0x75, 0x93, 0x2d, 0x0e, 0xa1, 0x3a, 0xfa, 0x4e, 0x53, 0x16, 0x5a, 0x6c, 0x03, 0x1a,
+	0x9e, 0x88, 0x47, 0xd2, 0x9f, 0x53, 0xd4, 0xdf, 0x34, 0x37, 0x6e, 0x0e, 0x61, 0x44, 0xbc, 0x68,
+	0x94, 0xcc, 0x44, 0xa8, 0x68, 0x0f, 0x28, 0xff, 0x1f, 0x9a, 0x7b, 0xa6, 0x68, 0x30, 0x95, 0xe5,
+	0xa5, 0xa4, 0xba, 0x49, 0xf4, 0x97, 0x8b, 0xa2, 0xff, 0xb7, 0x60, 0x65, 0xc7, 0x19, 0x85, 0xff,
+	0xf1, 0xc9, 0x99, 0x7b, 0xba, 0x7f, 0xd6, 0x7d, 0xad, 0x58, 0x05, 0x94, 0x50, 0xee, 0x3f, 0xdb,
+	0x3e, 0x3d, 0xd5, 0xc2, 0xff, 0x60, 0xfb, 0xf0, 0xa8, 0x5b, 0x61, 0x16, 0xd4, 0x0e, 0x8e, 0xb6,
+	0xbf, 0xfc, 0x75, 0xb7, 0x8a, 0x9f, 0xa7, 0x67, 0xdb, 0x47, 0xfb, 0xdd, 0x1a, 0x03, 0xa8, 0xef,
+	0x38, 0x27, 0x5f, 0xee, 0x1f, 0x77, 0xeb, 0x5f, 0x54, 0x57, 0x1b, 0xdd, 0x66, 0xff, 0x4f, 0x25,
+	0x68, 0x16, 0xe3, 0x81, 0xb7, 0x90, 0x56, 0x4f, 0x46, 0x63, 0x9a, 0xd6, 0xf2, 0x4c, 0x28, 0xdf,
+	0x3e, 0x13, 0x2a, 0xd7, 0x65, 0x02, 0x22, 0xee, 0x5c, 0x48, 0xf4, 0xc1, 0x54, 0x01, 0xf4, 0x13,
+	0xd0, 0x33, 0x0d, 0xf5, 0xff, 0x59, 0x06, 0x2b, 0xdb, 0x59, 0xb6, 0x09, 0x5d, 0xc5, 0xe5, 0x44,
+	0x28, 0x97, 0xfa, 0x91, 0xe8, 0x2a, 0x51, 0xa7, 0xb6, 0xc6, 0x9f, 0xf1, 0x38, 0x76, 0x8c, 0xfc,
+	0x88, 0xa7, 0x91, 0x54, 0xae, 0x5e, 0x80, 0x3b, 0x8d, 0x12, 0x99, 0x79, 0x4c, 0x16, 0xad, 0x16,
+	0x3f, 0x47, 0x1c, 0xab, 0xdf, 0x20, 0x0a, 0x27, 0x8b, 0x64, 0xed, 0x73, 0x07, 0x0d, 0x45, 0xee,
+	0xbb, 0xd0, 0xd1, 0x23, 0xe7, 0x2e, 0x68, 0xbf, 0x5b, 0x04, 0x67, 0x1e, 0x3c, 0x86, 0x36, 0x8d,
+	0x99, 0xd3, 0xb4, 0xc6, 0x6f, 0x22, 0x9a, 0xb1, 0xb2, 0xd1, 0x86, 0x89, 0x0c, 0x35, 0xad, 0x5e,
+	0x18, 0x6d, 0x27, 0x91, 0xe1, 0xc2, 0x68, 0x39, 0x6d, 0x25, 0x1f, 0x2d, 0x63, 0x3d, 0x04, 0xeb,
+	0xa5, 0x1f, 0x05, 0x1c, 0xef, 0x3f, 0xba, 0xe2, 0x57, 0x9d, 0x1c, 0xe8, 0xff, 0xb9, 0x0c, 0x8d,
+	0xc2, 0xad, 0x83, 0x15, 0x88, 0x9e, 0xbb, 0x50, 0x54, 0x58, 0x84, 0xec, 0xa1, 0xa4, 0x7c, 0x13,
+	0x2c, 0x9a, 0xb2, 0xa0, 0x35, 0x57, 0x11, 0x20, 0xe3, 0x92, 0x28, 0x54, 0x6e, 0x17, 0x85, 0xea,
+	0x92, 0x28, 0xf4, 0xa0, 0xe6, 0x89, 0x40, 0x71, 0x13, 0x22, 0xdd, 0x60, 0x3f, 0x00, 0xcb, 0xf3,
+	0xa5, 0x18, 0xd1, 0x61, 0xac, 0xd3, 0xab, 0xf7, 0xa0, 0x78, 0x6d, 0x0e, 0xf6, 0x52, 0xab, 0x93,
+	0x13, 0xfb, 0x3b, 0x60, 0x65, 0xf8, 0x62, 0xbd, 0x0c, 0x50, 0x3f, 0x3d, 0xdb, 0xde, 0x39, 0xc2,
+	0x62, 0xb9, 0x05, 0xd6, 0xe1, 0x57, 0xcf, 0x9c, 0x93, 0x5f, 0x1d, 0x1e, 0x3f, 0xed, 0x96, 0xb1,
+	0xb9, 0xb7, 0xff, 0xd4, 0xd9, 0xde, 0xc3, 0x66, 0xa5, 0xff, 0x02, 0xda, 0x8b, 0xd7, 0xfa, 0xb2,
+	0xdf, 0xf5, 0x4a, 0x4b, 0x7f, 0xd7, 0xeb, 0xa5, 0x42, 0xb9, 0x4c, 0xd7, 0xaa, 0x6e, 0xb0, 0x75,
+	0x58, 0xcd, 0x8a, 0x42, 0x9d, 0x57, 0x59, 0xbb, 0xff, 0xfb, 0x12, 0x74, 0xb3, 0x07, 0x29, 0x15,
+	0x3e, 0x9f, 0x42, 0x0b, 0x75, 0x4c, 0x2e, 0x42, 0xb4, 0x1c, 0xeb, 0x2d, 0x7b, 0xba, 0x9c, 0xa6,
+	0xe2, 0xc3, 0x5c, 0x7d, 0x7c, 0x0c, 0x56, 0xf4, 0x2a, 0x14, 0x32, 0x9e, 0xfa, 0x73, 0xa3, 0xa4,
+	0xef, 0xe5, 0xdd, 0x4e, 0x52, 0x93, 0x93, 0xb3, 0xfa, 0xbf, 0x01, 0x76, 0x95, 0x80, 0x97, 0x81,
+	0xa6, 0xd0, 0xe4, 0x96, 0x63, 0x5a, 0x28, 0x3b, 0x95, 0xe0, 0xb3, 0x54, 0x76, 0xe2, 0x37, 0xb3,
+	0x61, 0x65, 0x14, 0x85, 0x8a, 0x8f, 0x52, 0x55, 0x95, 0x36, 0xfb, 0x7f, 0xa9, 0xc0, 0xfd, 0xbd,
+	0x85, 0x22, 0x26, 0x5d, 0xe3, 0xdd, 0x2b, 0x9f, 0x6b, 0x85, 0x55, 0xf9, 0x7a, 0x61, 0xf5, 0x16,
+	0x80, 0xfe, 0xb5, 0x8a, 0x54, 0xad, 0x0e, 0xbe, 0x45, 0xc8, 0x19, 0x1f, 0x66, 0x77, 0x10, 0xc9,
+	0x3e, 0x24, 0xe8, 0x92, 0xbd, 0x61, 0x30, 0xa2, 0x7c, 0x01, 0x4d, 0xda, 0x0b, 0xba, 0x83, 0x44,
+	0x5a, 0x02, 0xbd, 0x37, 0x58, 0xba, 0xaa, 0x5c, 0x82, 0xa5, 0x42, 0xa2, 0xa1, 0x72, 0x84, 0x7d,
+	0x04, 0x90, 0xad, 0x2b, 0x2d, 0x05, 0xba, 0xf9, 0x48, 0x4e, 0x14, 0x04, 0xc9, 0xdc, 0x29, 0x70,
+	0xd8, 0xf7, 0x00, 0x5e, 0x45, 0x58, 0x1a, 0x91, 0x7b, 0x2b, 0x69, 0xf1, 0xc0, 0x87, 0x86, 0x6b,
+	0x91, 0x15, 0x1d, 0x5d, 0xff, 0x39, 0x74, 0x2f, 0xcf, 0x7e, 0xa7, 0x9f, 0x99, 0xfe, 0x5e, 0x82,
+	0xce, 0x25, 0x57, 0x6e, 0xab, 0xc0, 0x17, 0xa3, 0x5c, 0xbe, 0x29, 0xca, 0x95, 0xab, 0x51, 0xbe,
+	0x2a, 0x74, 0xab, 0xdf, 0x42, 0xe8, 0xf6, 0xbf, 0x29, 0xd3, 0xb3, 0x79, 0x37, 0xef, 0xef, 0x56,
+	0x3f, 0x5c, 0xf5, 0xb4, 0xf2, 0x6d, 0x24, 0x79, 0xae, 0xf8, 0xab, 0x0b, 0x8a, 0xff, 0x1d, 0xfd,
+	0x27, 0x4b, 0x5a, 0x88, 0xa4, 0x3f, 0xc3, 0x36, 0x0b, 0xa5, 0x46, 0x7c, 0x8d, 0x58, 0xaf, 0x2f,
+	0x17, 0xeb, 0xc3, 0x3a, 0x55, 0x60, 0x9f, 0xfc, 0x6f, 0x00, 0x78, 0x11, 0x88, 0xff, 0x47, 0x1a,
+	0x00, 0x00,
 }
@@ -58,6 +58,8 @@ const (
 	TestStatus_FLAKY             TestStatus = 13
 	TestStatus_TOOL_FAIL         TestStatus = 14
 	TestStatus_BUILD_PASSED      TestStatus = 15
+	// The run was stopped before it finished, such as by a newer run.
+	TestStatus_ABORTED TestStatus = 16
 )
 
 var TestStatus_name = map[int32]string{
@@ -77,6 +79,7 @@ var TestStatus_name = map[int32]string{
 	13: "FLAKY",
 	14: "TOOL_FAIL",
 	15: "BUILD_PASSED",
+	16: "ABORTED",
 }
 
 var TestStatus_value = map[string]int32{
@@ -96,6 +99,7 @@ var TestStatus_value = map[string]int32{
 	"FLAKY":             13,
 	"TOOL_FAIL":         14,
 	"BUILD_PASSED":      15,
+	"ABORTED":           16,
 }
 
 func (x TestStatus) String() string {
@@ -113,20 +117,21 @@ func init() {
 func init() { proto.RegisterFile("test_status.proto", fileDescriptor_3f9a6ab41bff9dae) }
 
 var fileDescriptor_3f9a6ab41bff9dae = []byte{
-	// 238 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x4d, 0x4e, 0x03, 0x31,
-	0x0c, 0x85, 0xa1, 0xb4, 0xd3, 0x8e, 0xfb, 0xe7, 0x1a, 0xb8, 0x04, 0x0b, 0x36, 0x9c, 0x20, 0x33,
-	0x93, 0x96, 0x68, 0x42, 0x5c, 0xe5, 0x47, 0x15, 0x6c, 0x22, 0x90, 0xba, 0x2e, 0x62, 0xc2, 0x11,
-	0xb8, 0x37, 0x4a, 0x8a, 0x44, 0x77, 0xcf, 0xcf, 0xcf, 0x4f, 0x9f, 0x0c, 0x9b, 0x74, 0x1c, 0x52,
+	// 241 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x4d, 0x4e, 0xc3, 0x30,
+	0x10, 0x85, 0xa1, 0xb4, 0x69, 0x33, 0xfd, 0x9b, 0x0e, 0x70, 0x09, 0x16, 0x6c, 0x38, 0x81, 0x93,
+	0xb8, 0xc5, 0x8a, 0xf1, 0x54, 0xfe, 0x51, 0x05, 0x1b, 0x0b, 0xa4, 0xae, 0x8b, 0x88, 0xb9, 0x08,
+	0x27, 0x46, 0x76, 0x91, 0xe8, 0xee, 0xf9, 0xf9, 0xcd, 0x9b, 0x4f, 0x03, 0x9b, 0x74, 0x1c, 0x52,
 	0x1c, 0xd2, 0x7b, 0xfa, 0x1e, 0x1e, 0x3f, 0xbf, 0x4e, 0xe9, 0xf4, 0xf0, 0x33, 0x02, 0xf0, 0xc7,
 	0x21, 0xb9, 0x62, 0xd2, 0x12, 0x6a, 0xc3, 0xd1, 0x4a, 0x17, 0xb4, 0xc7, 0x2b, 0x9a, 0xc1, 0x78,
 	0x2f, 0x9c, 0xc3, 0x6b, 0xba, 0x03, 0xcc, 0x2a, 0x1e, 0x94, 0x7f, 0x8e, 0xd2, 0x5a, 0xb6, 0x0e,
 	0x47, 0x74, 0x0b, 0xeb, 0x7f, 0xd7, 0xf5, 0x6a, 0xef, 0xf0, 0x86, 0xe6, 0x30, 0xb5, 0xc1, 0x18,
 	0x65, 0x76, 0x38, 0xa6, 0x7b, 0xd8, 0xb4, 0xc2, 0xcb, 0x1d, 0x5b, 0xf5, 0x26, 0xbb, 0x28, 0x1a,
 	0xb6, 0x1e, 0x27, 0x39, 0x13, 0x4c, 0x6f, 0xf8, 0x60, 0xb0, 0x22, 0x80, 0xaa, 0x15, 0xa6, 0x95,
-	0x1a, 0xa7, 0x79, 0xd1, 0x68, 0x6e, 0x7b, 0xd9, 0xe1, 0x2c, 0xd3, 0x78, 0xf5, 0x22, 0xbb, 0xc8,
-	0xc1, 0x63, 0x9d, 0x19, 0x2e, 0xbb, 0xb6, 0x42, 0x69, 0x04, 0x5a, 0x01, 0x34, 0x41, 0xe9, 0xbf,
-	0x79, 0x9e, 0x99, 0x8b, 0x5a, 0x50, 0x0d, 0x93, 0xad, 0x16, 0xfd, 0x2b, 0x2e, 0x4b, 0x13, 0xb3,
-	0x3e, 0x67, 0x56, 0x84, 0xb0, 0x38, 0xdf, 0x64, 0x7a, 0xd9, 0xe1, 0xfa, 0xa3, 0x2a, 0xef, 0x78,
-	0xfa, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x4a, 0xb6, 0x9b, 0x15, 0x23, 0x01, 0x00, 0x00,
+	0x1a, 0xa7, 0xf9, 0xa3, 0xd1, 0xdc, 0xf6, 0xb2, 0xc3, 0x59, 0xa6, 0xf1, 0xea, 0x45, 0x76, 0x91,
+	0x83, 0xc7, 0x3a, 0x33, 0x5c, 0x76, 0x6d, 0x85, 0xd2, 0x08, 0xb4, 0x02, 0x68, 0x82, 0xd2, 0x7f,
+	0xef, 0x79, 0x66, 0x2e, 0x6a, 0x41, 0x35, 0x4c, 0xb6, 0x5a, 0xf4, 0xaf, 0xb8, 0x2c, 0x4d, 0xcc,
+	0xfa, 0x9c, 0x59, 0x11, 0xc2, 0xe2, 0x3c, 0x93, 0xe9, 0x65, 0x87, 0xeb, 0xbc, 0xb7, 0xb0, 0xc9,
+	0x0e, 0xf1, 0xa3, 0x2a, 0xb7, 0x79, 0xfa, 0x1d, 0x00, 0x95, 0xfd, 0xdb, 0xfc, 0x30, 0x01, 0x00,
+	0x00,
 }
@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alerts determines which rows of a grid are alerting.
+package alerts
+
+import (
+	"context"
+	"math"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// AlertRows configures the alert for every row that has one.
+//
+// Results with an ignored status neither open nor close alerts.
+func AlertRows(cols []*statepb.Column, rows []*statepb.Row, openFailures, closePasses int, ignored ...statuspb.TestStatus) {
+	for _, r := range rows {
+		r.AlertInfo = alertRow(cols, r, openFailures, closePasses, ignored...)
+	}
+}
+
+// FailureStreaks records the consecutive failures of every row, regardless of alerting.
+func FailureStreaks(cols []*statepb.Column, rows []*statepb.Row) {
+	for _, r := range rows {
+		r.FailureStreak = alertRow(cols, r, 1, 1)
+	}
+}
+
+// alertRow returns an AlertInfo proto if there have been failuresToOpen consecutive failures more recently than passesToClose.
+//
+// The alert describes the latest failure, including its properties.
+func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesToClose int, ignored ...statuspb.TestStatus) *statepb.AlertInfo {
+	if failuresToOpen == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var failures int
+	var totalFailures int32
+	var passes int
+	var compressedIdx int
+	ch := result.Iter(ctx, row.Results)
+	var lastFail *statepb.Column
+	var latestPass *statepb.Column
+	var failIdx, failCol int
+	// find the first number of consecutive passesToClose (no alert)
+	// or else failuresToOpen (alert).
+	for i, col := range cols {
+		// TODO(fejta): ignore old running
+		rawRes := <-ch
+		if isIgnored(rawRes, ignored) {
+			compressedIdx++
+			continue
+		}
+		res := result.Coalesce(rawRes, result.IgnoreRunning)
+		if res == statuspb.TestStatus_NO_RESULT {
+			if rawRes == statuspb.TestStatus_RUNNING {
+				compressedIdx++
+			}
+			continue
+		}
+		if res == statuspb.TestStatus_PASS {
+			passes++
+			if failures >= failuresToOpen {
+				latestPass = col // most recent pass before outage
+				break
+			}
+			if passes >= passesToClose {
+				return nil // there is no outage
+			}
+			failures = 0
+		}
+		if res == statuspb.TestStatus_FAIL {
+			passes = 0
+			failures++
+			totalFailures++
+			if failures == 1 { // note most recent failure for this outage
+				failIdx = compressedIdx
+				failCol = i
+			}
+			lastFail = col
+		}
+		if res == statuspb.TestStatus_FLAKY {
+			passes = 0
+			if failures >= failuresToOpen {
+				break // cannot definitively say which commit is at fault
+			}
+			failures = 0
+		}
+		compressedIdx++
+	}
+	if failures < failuresToOpen {
+		return nil
+	}
+	msg := row.Messages[failIdx]
+	// Cell IDs include empty cells.
+	id := row.CellIds[failCol]
+	info := alertInfo(totalFailures, msg, id, lastFail, latestPass)
+	if failIdx < len(row.Properties) {
+		// Such as the link to the log of the failure.
+		info.Properties = row.Properties[failIdx].GetProperty()
+	}
+	return info
+}
+
+// isIgnored returns true when the status is one of the ignored ones.
+func isIgnored(status statuspb.TestStatus, ignored []statuspb.TestStatus) bool {
+	for _, s := range ignored {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// alertInfo returns an alert proto with the configured fields
+func alertInfo(failures int32, msg, cellID string, fail, pass *statepb.Column) *statepb.AlertInfo {
+	return &statepb.AlertInfo{
+		FailCount:      failures,
+		FailBuildId:    buildID(fail),
+		FailTime:       stamp(fail),
+		FailTestId:     cellID,
+		FailureMessage: msg,
+		PassTime:       stamp(pass),
+		PassBuildId:    buildID(pass),
+	}
+}
+
+// buildID extracts the ID from the first extra row or else the Build field.
+func buildID(col *statepb.Column) string {
+	if col == nil {
+		return ""
+	}
+	if len(col.Extra) > 0 {
+		return col.Extra[0]
+	}
+	return col.Build
+}
+
+const billion = 1e9
+
+// stamp converts seconds into a timestamp proto
+func stamp(col *statepb.Column) *timestamp.Timestamp {
+	if col == nil {
+		return nil
+	}
+	seconds := col.Started
+	floor := math.Floor(seconds)
+	remain := seconds - floor
+	return &timestamp.Timestamp{
+		Seconds: int64(floor),
+		Nanos:   int32(remain * billion),
+	}
+}
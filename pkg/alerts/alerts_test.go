@@ -0,0 +1,411 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestAlertRow(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	cases := []struct {
+		name      string
+		row       statepb.Row
+		failOpen  int
+		passClose int
+		ignored   []statuspb.TestStatus
+		expected  *statepb.AlertInfo
+	}{
+		{
+			name: "never alert by default",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 6,
+				},
+			},
+		},
+		{
+			name: "passes do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 6,
+				},
+			},
+			failOpen:  1,
+			passClose: 3,
+		},
+		{
+			name: "flakes do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 6,
+				},
+			},
+			failOpen: 1,
+		},
+		{
+			name: "intermittent failures do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+			},
+			failOpen: 3,
+		},
+		{
+			name: "new failures alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 3,
+				},
+				Messages: []string{"hello", "no", "no again", "very wrong"},
+				CellIds:  []string{"yes", "no", "no again", "very wrong"},
+			},
+			failOpen: 3,
+			expected: alertInfo(3, "hello", "yes", columns[2], columns[3]),
+		},
+		{
+			name: "alerts include the properties of the latest failure",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				Messages: []string{"hello", "again", ""},
+				CellIds:  []string{"yes", "no", "pass"},
+				Properties: []*statepb.Property{
+					{Property: map[string]string{"log": "gs://bucket/logs/2/build-log.txt"}},
+					{Property: map[string]string{"log": "gs://bucket/logs/1/build-log.txt"}},
+					{},
+				},
+			},
+			failOpen: 2,
+			expected: func() *statepb.AlertInfo {
+				info := alertInfo(2, "hello", "yes", columns[1], columns[2])
+				info.Properties = map[string]string{"log": "gs://bucket/logs/2/build-log.txt"}
+				return info
+			}(),
+		},
+		{
+			name: "too few passes do not close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"nope", "no", "yay", "very wrong"},
+				CellIds:  []string{"wrong", "no", "yep", "very wrong"},
+			},
+			failOpen:  1,
+			passClose: 3,
+			expected:  alertInfo(4, "yay", "yep", columns[5], nil),
+		},
+		{
+			name: "flakes do not close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 2,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"nope", "no", "yay", "very wrong"},
+				CellIds:  []string{"wrong", "no", "yep", "very wrong"},
+			},
+			failOpen: 1,
+			expected: alertInfo(4, "yay", "yep", columns[5], nil),
+		},
+		{
+			name: "count failures after flaky passes",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+				Messages: []string{"nope", "no", "buu", "wrong", "this one"},
+				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job"},
+			},
+			failOpen:  2,
+			passClose: 2,
+			expected:  alertInfo(4, "this one", "good job", columns[5], nil),
+		},
+		{
+			name: "close alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
+				},
+			},
+			failOpen: 1,
+		},
+		{
+			name: "track through empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"yay", "no", "buu", "wrong", "nono"},
+				CellIds:  []string{"yay-cell", "no", "buzz", "wrong2", "nada"},
+			},
+			failOpen:  5,
+			passClose: 2,
+			expected:  alertInfo(5, "yay", "yay-cell", columns[5], nil),
+		},
+		{
+			name: "track passes through empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 3,
+				},
+			},
+			failOpen:  1,
+			passClose: 2,
+		},
+		{
+			name: "cell IDs include empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+				Messages: []string{"first", "second"},
+				CellIds:  []string{"", "first-cell", "second-cell"},
+			},
+			failOpen: 1,
+			expected: alertInfo(2, "first", "first-cell", columns[2], nil),
+		},
+		{
+			name: "running cells advance compressed index",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_RUNNING), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
+				},
+				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
+				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
+			},
+			failOpen: 1,
+			expected: alertInfo(5, "fail1-expected", "yep", columns[5], nil),
+		},
+		{
+			name: "timeouts and aborts count as failures",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_TIMED_OUT), 1,
+					int32(statuspb.TestStatus_ABORTED), 1,
+					int32(statuspb.TestStatus_PASS), 4,
+				},
+				Messages: []string{"timeout0", "abort1", "pass2", "pass3", "pass4", "pass5"},
+				CellIds:  []string{"cell0", "cell1", "cell2", "cell3", "cell4", "cell5"},
+			},
+			failOpen:  2,
+			passClose: 1,
+			expected:  alertInfo(2, "timeout0", "cell0", columns[1], columns[2]),
+		},
+		{
+			name: "ignored statuses neither open nor close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_TIMED_OUT), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_ABORTED), 1,
+					int32(statuspb.TestStatus_PASS), 2,
+				},
+				Messages: []string{"timeout0", "fail1-expected", "fail2", "abort3", "pass4", "pass5"},
+				CellIds:  []string{"cell0", "yep", "cell2", "cell3", "cell4", "cell5"},
+			},
+			failOpen:  2,
+			passClose: 1,
+			ignored:   []statuspb.TestStatus{statuspb.TestStatus_TIMED_OUT, statuspb.TestStatus_ABORTED},
+			expected:  alertInfo(2, "fail1-expected", "yep", columns[2], columns[4]),
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := alertRow(columns, &tc.row, tc.failOpen, tc.passClose, tc.ignored...); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s alert %s != expected %s", tc.name, actual, tc.expected)
+		}
+	}
+}
+
+func TestFailureStreaks(t *testing.T) {
+	cols := []*statepb.Column{
+		{Build: "4", Started: 4000},
+		{Build: "3", Started: 3000},
+		{Build: "2", Started: 2000},
+		{Build: "1", Started: 1000},
+	}
+	const (
+		pass  = int32(statuspb.TestStatus_PASS)
+		fail  = int32(statuspb.TestStatus_FAIL)
+		flaky = int32(statuspb.TestStatus_FLAKY)
+		empty = int32(statuspb.TestStatus_NO_RESULT)
+	)
+	cases := []struct {
+		name     string
+		row      statepb.Row
+		expected *statepb.AlertInfo
+	}{
+		{
+			name: "passing rows have no streak",
+			row: statepb.Row{
+				Results:  []int32{pass, 1, fail, 3},
+				CellIds:  []string{"", "", "", ""},
+				Messages: []string{"", "", "", ""},
+			},
+		},
+		{
+			name: "count failures since the last pass",
+			row: statepb.Row{
+				Results:  []int32{fail, 1, empty, 1, fail, 1, pass, 1},
+				CellIds:  []string{"four", "", "two", ""},
+				Messages: []string{"newest", "oldest", ""},
+			},
+			expected: alertInfo(2, "newest", "four", cols[2], cols[3]),
+		},
+		{
+			name: "no pass",
+			row: statepb.Row{
+				Results:  []int32{fail, 4},
+				CellIds:  []string{"four", "", "", ""},
+				Messages: []string{"latest", "", "", ""},
+			},
+			expected: alertInfo(4, "latest", "four", cols[3], nil),
+		},
+		{
+			name: "flakes end the streak",
+			row: statepb.Row{
+				Results:  []int32{fail, 1, flaky, 1, fail, 2},
+				CellIds:  []string{"four", "", "", ""},
+				Messages: []string{"flake", "", "", ""},
+			},
+			expected: alertInfo(1, "flake", "four", cols[0], nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := &tc.row
+			FailureStreaks(cols, []*statepb.Row{row})
+			if diff := cmp.Diff(tc.expected, row.FailureStreak, protocmp.Transform()); diff != "" {
+				t.Errorf("FailureStreaks() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildID(t *testing.T) {
+	cases := []struct {
+		name     string
+		build    string
+		extra    string
+		expected string
+	}{
+		{
+			name: "return empty by default",
+		},
+		{
+			name:     "favor extra if it exists",
+			build:    "wrong",
+			extra:    "right",
+			expected: "right",
+		},
+		{
+			name:     "build if no extra",
+			build:    "yes",
+			expected: "yes",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			col := statepb.Column{
+				Build: tc.build,
+			}
+			if tc.extra != "" {
+				col.Extra = append(col.Extra, tc.extra)
+			}
+			if actual := buildID(&col); actual != tc.expected {
+				t.Errorf("%q != expected %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStamp(t *testing.T) {
+	cases := []struct {
+		name     string
+		col      *statepb.Column
+		expected *timestamp.Timestamp
+	}{
+		{
+			name: "0 returns nil",
+		},
+		{
+			name: "no nanos",
+			col: &statepb.Column{
+				Started: 2,
+			},
+			expected: &timestamp.Timestamp{
+				Seconds: 2,
+				Nanos:   0,
+			},
+		},
+		{
+			name: "has nanos",
+			col: &statepb.Column{
+				Started: 1.1,
+			},
+			expected: &timestamp.Timestamp{
+				Seconds: 1,
+				Nanos:   1e8,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := stamp(tc.col); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("stamp %s != expected stamp %s", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/yaml"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+)
+
+// AccessRule only serves the dashboards of its dashboard groups to the users it allows.
+type AccessRule struct {
+	DashboardGroups []string `json:"dashboard_groups"`
+	// Emails allows users with these verified emails.
+	Emails []string `json:"emails,omitempty"`
+	// Domains allows users with verified emails in these domains.
+	Domains []string `json:"domains,omitempty"`
+	// Groups allows users whose groups claim includes any of these groups.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// LoadAccessRules reads the YAML list of rules at path.
+func LoadAccessRules(path string) ([]AccessRule, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var rules []AccessRule
+	if err := yaml.UnmarshalStrict(buf, &rules); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	for i, r := range rules {
+		if len(r.DashboardGroups) == 0 {
+			return nil, fmt.Errorf("rule %d: dashboard_groups required", i)
+		}
+	}
+	return rules, nil
+}
+
+// allows returns true when the rule allows the user with the claims.
+func (r AccessRule) allows(claims *Claims) bool {
+	if claims == nil {
+		return false
+	}
+	if email := strings.ToLower(claims.Email); email != "" && claims.EmailVerified != nil && *claims.EmailVerified {
+		for _, e := range r.Emails {
+			if strings.ToLower(e) == email {
+				return true
+			}
+		}
+		for _, d := range r.Domains {
+			if strings.HasSuffix(email, "@"+strings.ToLower(d)) {
+				return true
+			}
+		}
+	}
+	for _, want := range r.Groups {
+		for _, g := range claims.Groups {
+			if g == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorize returns a server only serving dashboards to the users allowed by the rules.
+//
+// Dashboards outside the dashboard groups of every rule are public. Otherwise
+// users need the permission of every rule covering a group containing the
+// dashboard. Add claims to the context of each call with an Authenticator.
+func Authorize(server apipb.TestGridDataServer, rules []AccessRule) apipb.TestGridDataServer {
+	byGroup := map[string][]AccessRule{}
+	for _, r := range rules {
+		for _, g := range r.DashboardGroups {
+			byGroup[g] = append(byGroup[g], r)
+		}
+	}
+	return &authorizedServer{server: server, rules: byGroup}
+}
+
+type authorizedServer struct {
+	server apipb.TestGridDataServer
+	rules  map[string][]AccessRule
+}
+
+// allowed returns true when the user may see a dashboard in the groups.
+func (as *authorizedServer) allowed(claims *Claims, groups []string) bool {
+	for _, g := range groups {
+		for _, r := range as.rules[g] {
+			if !r.allows(claims) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// check returns an error unless the user of the call may see the dashboard.
+//
+// Leaves it to the server to report dashboards that do not exist.
+func (as *authorizedServer) check(ctx context.Context, dashboard string) error {
+	// Discard the headers of this internal call.
+	resp, err := as.server.ListDashboards(grpc.NewContextWithServerTransportStream(ctx, &headerStream{}), &apipb.ListDashboardsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, dash := range resp.Dashboards {
+		if dash.Name != dashboard {
+			continue
+		}
+		claims := ClaimsFrom(ctx)
+		switch {
+		case as.allowed(claims, dash.DashboardGroups):
+			return nil
+		case claims == nil:
+			return status.Errorf(codes.Unauthenticated, "dashboard %q requires authentication", dashboard)
+		default:
+			return status.Errorf(codes.PermissionDenied, "%s may not access dashboard %q", userName(claims), dashboard)
+		}
+	}
+	return nil
+}
+
+func userName(claims *Claims) string {
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.Subject
+}
+
+// ListDashboards lists the dashboards the user may see.
+func (as *authorizedServer) ListDashboards(ctx context.Context, req *apipb.ListDashboardsRequest) (*apipb.ListDashboardsResponse, error) {
+	resp, err := as.server.ListDashboards(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	claims := ClaimsFrom(ctx)
+	dashboards := resp.Dashboards[:0]
+	for _, dash := range resp.Dashboards {
+		if as.allowed(claims, dash.DashboardGroups) {
+			dashboards = append(dashboards, dash)
+		}
+	}
+	resp.Dashboards = dashboards
+	return resp, nil
+}
+
+func (as *authorizedServer) ListTabs(ctx context.Context, req *apipb.ListTabsRequest) (*apipb.ListTabsResponse, error) {
+	if err := as.check(ctx, req.GetDashboard()); err != nil {
+		return nil, err
+	}
+	return as.server.ListTabs(ctx, req)
+}
+
+func (as *authorizedServer) GetTabState(ctx context.Context, req *apipb.GetTabStateRequest) (*apipb.GetTabStateResponse, error) {
+	if err := as.check(ctx, req.GetDashboard()); err != nil {
+		return nil, err
+	}
+	return as.server.GetTabState(ctx, req)
+}
+
+func (as *authorizedServer) GetSummary(ctx context.Context, req *apipb.GetSummaryRequest) (*apipb.GetSummaryResponse, error) {
+	if err := as.check(ctx, req.GetDashboard()); err != nil {
+		return nil, err
+	}
+	return as.server.GetSummary(ctx, req)
+}
+
+func (as *authorizedServer) WatchDashboard(req *apipb.WatchDashboardRequest, stream apipb.TestGridData_WatchDashboardServer) error {
+	if err := as.check(stream.Context(), req.GetDashboard()); err != nil {
+		return err
+	}
+	return as.server.WatchDashboard(req, stream)
+}
+
+func (as *authorizedServer) CompareTabs(ctx context.Context, req *apipb.CompareTabsRequest) (*apipb.CompareTabsResponse, error) {
+	for _, r := range []*apipb.TabRange{req.GetBase(), req.GetTarget()} {
+		if err := as.check(ctx, r.GetDashboard()); err != nil {
+			return nil, err
+		}
+	}
+	return as.server.CompareTabs(ctx, req)
+}
+
+func (as *authorizedServer) TriggerUpdate(ctx context.Context, req *apipb.TriggerUpdateRequest) (*apipb.TriggerUpdateResponse, error) {
+	if err := as.check(ctx, req.GetDashboard()); err != nil {
+		return nil, err
+	}
+	return as.server.TriggerUpdate(ctx, req)
+}
+
+func (as *authorizedServer) TriggerSummary(ctx context.Context, req *apipb.TriggerSummaryRequest) (*apipb.TriggerSummaryResponse, error) {
+	if err := as.check(ctx, req.GetDashboard()); err != nil {
+		return nil, err
+	}
+	return as.server.TriggerSummary(ctx, req)
+}
+
+// ListFailingTests lists the failing tests of the dashboards the user may see.
+func (as *authorizedServer) ListFailingTests(ctx context.Context, req *apipb.ListFailingTestsRequest) (*apipb.ListFailingTestsResponse, error) {
+	resp, err := as.server.ListFailingTests(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := as.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tests := resp.Tests[:0]
+	for _, test := range resp.Tests {
+		tabs := test.Tabs[:0]
+		for _, tab := range test.Tabs {
+			if visible[tab.Dashboard] {
+				tabs = append(tabs, tab)
+			}
+		}
+		if test.Tabs = tabs; len(tabs) > 0 {
+			tests = append(tests, test)
+		}
+	}
+	resp.Tests = tests
+	sortFailingTests(resp.Tests)
+	return resp, nil
+}
+
+// SearchTests finds the tests in the tabs of the dashboards the user may see.
+//
+// Returns fewer tests than the limit when it filters out every tab of some.
+func (as *authorizedServer) SearchTests(ctx context.Context, req *apipb.SearchTestsRequest) (*apipb.SearchTestsResponse, error) {
+	resp, err := as.server.SearchTests(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := as.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tests := resp.Tests[:0]
+	for _, test := range resp.Tests {
+		tabs := test.Tabs[:0]
+		for _, tab := range test.Tabs {
+			if visible[tab.Dashboard] {
+				tabs = append(tabs, tab)
+			}
+		}
+		if test.Tabs = tabs; len(tabs) > 0 {
+			tests = append(tests, test)
+		}
+	}
+	resp.Tests = tests
+	return resp, nil
+}
+
+// GetTestHistory returns the history of the test in the tabs of the dashboards the user may see.
+//
+// Picks the last pass from only these tabs.
+func (as *authorizedServer) GetTestHistory(ctx context.Context, req *apipb.GetTestHistoryRequest) (*apipb.GetTestHistoryResponse, error) {
+	resp, err := as.server.GetTestHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := as.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tabs := resp.Tabs[:0]
+	for _, tab := range resp.Tabs {
+		if visible[tab.Dashboard] {
+			tabs = append(tabs, tab)
+		}
+	}
+	resp.Tabs = tabs
+	resp.LastPass = lastPass(tabs)
+	return resp, nil
+}
+
+// visible returns the set of dashboards the user of the call may see.
+func (as *authorizedServer) visible(ctx context.Context) (map[string]bool, error) {
+	// Discard the headers of this internal call.
+	dashes, err := as.ListDashboards(grpc.NewContextWithServerTransportStream(ctx, &headerStream{}), &apipb.ListDashboardsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[string]bool, len(dashes.Dashboards))
+	for _, dash := range dashes.Dashboards {
+		visible[dash.Name] = true
+	}
+	return visible, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestLoadAccessRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected []AccessRule
+		err      bool
+	}{
+		{
+			name: "basic",
+			content: `
+- dashboard_groups: [private]
+  emails: [alice@example.com]
+  domains: [example.org]
+- dashboard_groups: [internal, secret]
+  groups: [team]
+`,
+			expected: []AccessRule{
+				{
+					DashboardGroups: []string{"private"},
+					Emails:          []string{"alice@example.com"},
+					Domains:         []string{"example.org"},
+				},
+				{
+					DashboardGroups: []string{"internal", "secret"},
+					Groups:          []string{"team"},
+				},
+			},
+		},
+		{
+			name:    "missing dashboard groups",
+			content: "- emails: [alice@example.com]\n",
+			err:     true,
+		},
+		{
+			name:    "unknown field",
+			content: "- dashboard_groups: [private]\n  users: [alice]\n",
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := ioutil.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			got, err := LoadAccessRules(path)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("LoadAccessRules() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("LoadAccessRules() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, got); diff != "" {
+					t.Errorf("LoadAccessRules() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	yes, no := true, false
+	rule := AccessRule{
+		DashboardGroups: []string{"private"},
+		Emails:          []string{"Alice@example.com"},
+		Domains:         []string{"example.org"},
+		Groups:          []string{"team"},
+	}
+	cases := []struct {
+		name     string
+		claims   *Claims
+		expected bool
+	}{
+		{
+			name: "anonymous",
+		},
+		{
+			name:     "email",
+			claims:   &Claims{Email: "alice@EXAMPLE.com", EmailVerified: &yes},
+			expected: true,
+		},
+		{
+			name:   "unverified email",
+			claims: &Claims{Email: "alice@example.com", EmailVerified: &no},
+		},
+		{
+			name:   "email without verification claim",
+			claims: &Claims{Email: "alice@example.com"},
+		},
+		{
+			name:     "domain",
+			claims:   &Claims{Email: "bob@example.org", EmailVerified: &yes},
+			expected: true,
+		},
+		{
+			name:   "domain without verification claim",
+			claims: &Claims{Email: "bob@example.org"},
+		},
+		{
+			name:   "subdomain",
+			claims: &Claims{Email: "bob@evil-example.org", EmailVerified: &yes},
+		},
+		{
+			name:     "group",
+			claims:   &Claims{Email: "carol@elsewhere.com", EmailVerified: &no, Groups: []string{"other", "team"}},
+			expected: true,
+		},
+		{
+			name:   "other user",
+			claims: &Claims{Email: "mallory@elsewhere.com", Groups: []string{"other"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rule.allows(tc.claims); got != tc.expected {
+				t.Errorf("allows() got %t, want %t", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	objects := fakeObjects{}
+	for _, dash := range []string{"first", "second"} {
+		objects.put(t, "gs://bucket/summary/summary-"+dash, &summarypb.DashboardSummary{
+			TabSummaries: []*summarypb.DashboardTabSummary{
+				{
+					DashboardName:        dash,
+					DashboardTabName:     "tab",
+					FailingTestSummaries: []*summarypb.FailingTestSummary{{TestName: "shared"}},
+				},
+			},
+		})
+	}
+	server := Authorize(testServer(t, objects), []AccessRule{
+		{DashboardGroups: []string{"dashboard-group"}, Domains: []string{"example.com"}},
+	})
+	cases := []struct {
+		name       string
+		claims     *Claims
+		dashboards []string
+		code       codes.Code
+	}{
+		{
+			name:       "anonymous",
+			dashboards: []string{"second"},
+			code:       codes.Unauthenticated,
+		},
+		{
+			name:       "allowed",
+			claims:     &Claims{Email: "alice@example.com", EmailVerified: &[]bool{true}[0]},
+			dashboards: []string{"first", "second"},
+		},
+		{
+			name:       "denied",
+			claims:     &Claims{Email: "mallory@elsewhere.com"},
+			dashboards: []string{"second"},
+			code:       codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.claims != nil {
+				ctx = WithClaims(ctx, tc.claims)
+			}
+			resp, err := server.ListDashboards(ctx, &apipb.ListDashboardsRequest{})
+			if err != nil {
+				t.Fatalf("ListDashboards() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, dash := range resp.Dashboards {
+				got = append(got, dash.Name)
+			}
+			if diff := cmp.Diff(tc.dashboards, got); diff != "" {
+				t.Errorf("ListDashboards() got unexpected diff (-want +got):\n%s", diff)
+			}
+
+			_, err = server.ListTabs(ctx, &apipb.ListTabsRequest{Dashboard: "first"})
+			if got := status.Code(err); got != tc.code {
+				t.Errorf("ListTabs(first) got %v, want %v", err, tc.code)
+			}
+			if _, err := server.ListTabs(ctx, &apipb.ListTabsRequest{Dashboard: "second"}); err != nil {
+				t.Errorf("ListTabs(second) got unexpected error: %v", err)
+			}
+			_, err = server.ListTabs(ctx, &apipb.ListTabsRequest{Dashboard: "missing"})
+			if got := status.Code(err); got != codes.NotFound {
+				t.Errorf("ListTabs(missing) got %v, want NotFound", err)
+			}
+			_, err = server.GetSummary(ctx, &apipb.GetSummaryRequest{Dashboard: "first"})
+			if got := status.Code(err); tc.code != codes.OK && got != tc.code {
+				t.Errorf("GetSummary(first) got %v, want %v", err, tc.code)
+			}
+			failing, err := server.ListFailingTests(ctx, &apipb.ListFailingTestsRequest{})
+			if err != nil {
+				t.Fatalf("ListFailingTests() got unexpected error: %v", err)
+			}
+			got = nil
+			for _, test := range failing.Tests {
+				for _, tab := range test.Tabs {
+					got = append(got, tab.Dashboard)
+				}
+			}
+			if diff := cmp.Diff(tc.dashboards, got); diff != "" {
+				t.Errorf("ListFailingTests() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Claims identify the user presenting a verified ID token.
+type Claims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified *bool    `json:"email_verified"`
+	Groups        []string `json:"groups"`
+}
+
+type claimsKey struct{}
+
+// WithClaims returns a context holding the claims of the user making the request.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFrom returns the claims of the user making the request, or nil for anonymous requests.
+func ClaimsFrom(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*Claims)
+	return claims
+}
+
+// Authenticator verifies the ID tokens an OIDC issuer signs for an audience.
+type Authenticator struct {
+	verifier *oidc.IDTokenVerifier
+}
+
+// clockSkew tolerates this much difference between the clocks of the issuer and the server.
+const clockSkew = time.Minute
+
+// NewAuthenticator discovers the issuer, returning an authenticator of its tokens for the audience.
+//
+// Fetches the keys of the issuer with the client, again whenever a token names an unknown key.
+func NewAuthenticator(ctx context.Context, client *http.Client, issuer, audience string) (*Authenticator, error) {
+	if issuer == "" || audience == "" {
+		return nil, errors.New("empty issuer or audience")
+	}
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, client), issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover: %w", err)
+	}
+	verifier := provider.Verifier(&oidc.Config{
+		ClientID:             audience,
+		SupportedSigningAlgs: []string{oidc.RS256, oidc.ES256},
+		// Accept tokens that expired within the skew.
+		Now: func() time.Time { return time.Now().Add(-clockSkew) },
+	})
+	return &Authenticator{verifier: verifier}, nil
+}
+
+// Verify returns the claims of the token once it checks the token is an unexpired one the issuer signed for the audience.
+func (a *Authenticator) Verify(ctx context.Context, token string) (*Claims, error) {
+	idToken, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	var claims Claims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	return &claims, nil
+}
+
+// bearer returns the token of an authorization header such as "Bearer <token>", or else an empty string.
+func bearer(header string) string {
+	const prefix = "bearer "
+	if len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+		return strings.TrimSpace(header[len(prefix):])
+	}
+	return ""
+}
+
+// Middleware adds the claims of the bearer token of each request to its context.
+//
+// Rejects requests with invalid tokens, while passing along anonymous requests without one.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Authorization")
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := bearer(header)
+		if token == "" {
+			http.Error(w, "authorization must be a bearer token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := a.Verify(r.Context(), token)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid token: %v", err), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+	})
+}
+
+// authenticate adds the claims of the bearer token in the metadata of the call, if any, to the context.
+func (a *Authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return ctx, nil
+	}
+	token := bearer(vals[0])
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	claims, err := a.Verify(ctx, token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	return WithClaims(ctx, claims), nil
+}
+
+// UnaryInterceptor adds the claims of the bearer token of each call to its context, like Middleware.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor adds the claims of the bearer token of each stream to its context, like Middleware.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, contextStream{ss, ctx})
+	}
+}
+
+// contextStream replaces the context of a stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (cs contextStream) Context() context.Context {
+	return cs.ctx
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeIssuer serves the discovery document and keys of an OIDC issuer, signing tokens with them.
+type fakeIssuer struct {
+	server *httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+	fi := fakeIssuer{rsaKey: rsaKey, ecKey: ecKey}
+	enc := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   fi.server.URL,
+			"jwks_uri": fi.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa",
+					"use": "sig",
+					"n":   enc(rsaKey.N.Bytes()),
+					"e":   enc(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					"kty": "EC",
+					"kid": "ec",
+					"crv": "P-256",
+					"x":   enc(ecKey.X.FillBytes(make([]byte, 32))),
+					"y":   enc(ecKey.Y.FillBytes(make([]byte, 32))),
+				},
+				{
+					"kty": "oct",
+					"kid": "symmetric",
+					"k":   enc([]byte("secret")),
+				},
+			},
+		})
+	})
+	fi.server = httptest.NewServer(mux)
+	t.Cleanup(fi.server.Close)
+	return &fi
+}
+
+// token signs the claims with the algorithm, using the key ID unless overridden.
+func (fi *fakeIssuer) token(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	enc := func(v interface{}) string {
+		buf, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(buf)
+	}
+	signed := enc(map[string]string{"alg": alg, "kid": kid}) + "." + enc(claims)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	switch alg {
+	case "RS256":
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, fi.rsaKey, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, fi.ecKey, digest[:])
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (fi *fakeIssuer) claims(mutate func(map[string]interface{})) map[string]interface{} {
+	claims := map[string]interface{}{
+		"iss":   fi.server.URL,
+		"aud":   "testgrid",
+		"sub":   "123",
+		"email": "alice@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	if mutate != nil {
+		mutate(claims)
+	}
+	return claims
+}
+
+func TestVerify(t *testing.T) {
+	fi := newFakeIssuer(t)
+	cases := []struct {
+		name     string
+		token    func(t *testing.T) string
+		expected *Claims
+	}{
+		{
+			name: "rs256",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(nil))
+			},
+			expected: &Claims{Issuer: fi.server.URL, Subject: "123", Email: "alice@example.com"},
+		},
+		{
+			name: "es256",
+			token: func(t *testing.T) string {
+				return fi.token(t, "ES256", "ec", fi.claims(func(c map[string]interface{}) {
+					c["groups"] = []string{"team"}
+					c["email_verified"] = true
+				}))
+			},
+			expected: &Claims{Issuer: fi.server.URL, Subject: "123", Email: "alice@example.com", EmailVerified: &[]bool{true}[0], Groups: []string{"team"}},
+		},
+		{
+			name: "audience list",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["aud"] = []string{"other", "testgrid"}
+				}))
+			},
+			expected: &Claims{Issuer: fi.server.URL, Subject: "123", Email: "alice@example.com"},
+		},
+		{
+			name: "wrong audience",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["aud"] = "other"
+				}))
+			},
+		},
+		{
+			name: "wrong issuer",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["iss"] = "https://evil.example.com"
+				}))
+			},
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["exp"] = time.Now().Add(-time.Hour).Unix()
+				}))
+			},
+		},
+		{
+			name: "expired within the clock skew",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["exp"] = time.Now().Add(-clockSkew / 2).Unix()
+				}))
+			},
+			expected: &Claims{Issuer: fi.server.URL, Subject: "123", Email: "alice@example.com"},
+		},
+		{
+			name: "missing expiry",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					delete(c, "exp")
+				}))
+			},
+		},
+		{
+			name: "not yet valid",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["nbf"] = time.Now().Add(time.Hour).Unix()
+				}))
+			},
+		},
+		{
+			name: "algorithm mismatch",
+			token: func(t *testing.T) string {
+				return fi.token(t, "ES256", "rsa", fi.claims(nil))
+			},
+		},
+		{
+			name: "unsigned",
+			token: func(t *testing.T) string {
+				return fi.token(t, "none", "rsa", fi.claims(nil))
+			},
+		},
+		{
+			name: "unknown key",
+			token: func(t *testing.T) string {
+				return fi.token(t, "RS256", "nope", fi.claims(nil))
+			},
+		},
+		{
+			name: "tampered",
+			token: func(t *testing.T) string {
+				good := fi.token(t, "RS256", "rsa", fi.claims(nil))
+				evil := fi.token(t, "RS256", "rsa", fi.claims(func(c map[string]interface{}) {
+					c["email"] = "mallory@example.com"
+				}))
+				return evil[:len(evil)-10] + good[len(good)-10:]
+			},
+		},
+		{
+			name: "malformed",
+			token: func(*testing.T) string {
+				return "not-a-token"
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			a, err := NewAuthenticator(ctx, fi.server.Client(), fi.server.URL, "testgrid")
+			if err != nil {
+				t.Fatalf("NewAuthenticator() got unexpected error: %v", err)
+			}
+			got, err := a.Verify(ctx, tc.token(t))
+			switch {
+			case err != nil:
+				if tc.expected != nil {
+					t.Errorf("Verify() got unexpected error: %v", err)
+				}
+			case tc.expected == nil:
+				t.Errorf("Verify() failed to return an error, got %#v", got)
+			default:
+				if diff := cmp.Diff(tc.expected, got); diff != "" {
+					t.Errorf("Verify() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	fi := newFakeIssuer(t)
+	ctx := context.Background()
+	if _, err := NewAuthenticator(ctx, fi.server.Client(), fi.server.URL+"/other", "testgrid"); err == nil {
+		t.Error("NewAuthenticator() with an undiscoverable issuer failed to return an error")
+	}
+	if _, err := NewAuthenticator(ctx, fi.server.Client(), fi.server.URL, ""); err == nil {
+		t.Error("NewAuthenticator() without an audience failed to return an error")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	fi := newFakeIssuer(t)
+	a, err := NewAuthenticator(context.Background(), fi.server.Client(), fi.server.URL, "testgrid")
+	if err != nil {
+		t.Fatalf("NewAuthenticator() got unexpected error: %v", err)
+	}
+	cases := []struct {
+		name   string
+		header func(t *testing.T) string
+		code   int
+		email  string
+	}{
+		{
+			name:   "anonymous",
+			header: func(*testing.T) string { return "" },
+			code:   http.StatusOK,
+		},
+		{
+			name: "valid token",
+			header: func(t *testing.T) string {
+				return "Bearer " + fi.token(t, "RS256", "rsa", fi.claims(nil))
+			},
+			code:  http.StatusOK,
+			email: "alice@example.com",
+		},
+		{
+			name:   "invalid token",
+			header: func(*testing.T) string { return "Bearer not-a-token" },
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "not a bearer token",
+			header: func(*testing.T) string { return "Basic YWxpY2U6c2VjcmV0" },
+			code:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var email string
+			handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if claims := ClaimsFrom(r.Context()); claims != nil {
+					email = claims.Email
+				}
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if h := tc.header(t); h != "" {
+				req.Header.Set("Authorization", h)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.code {
+				t.Errorf("ServeHTTP() got code %d, want %d", rec.Code, tc.code)
+			}
+			if email != tc.email {
+				t.Errorf("ServeHTTP() got claims of %q, want %q", email, tc.email)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	fi := newFakeIssuer(t)
+	a, err := NewAuthenticator(context.Background(), fi.server.Client(), fi.server.URL, "testgrid")
+	if err != nil {
+		t.Fatalf("NewAuthenticator() got unexpected error: %v", err)
+	}
+	interceptor := a.UnaryInterceptor()
+	call := func(md metadata.MD) (string, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			if claims := ClaimsFrom(ctx); claims != nil {
+				return claims.Email, nil
+			}
+			return "", nil
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.(string), nil
+	}
+
+	if email, err := call(metadata.MD{}); err != nil || email != "" {
+		t.Errorf("anonymous call got %q, %v, want no claims", email, err)
+	}
+	token := fi.token(t, "RS256", "rsa", fi.claims(nil))
+	if email, err := call(metadata.Pairs("authorization", "Bearer "+token)); err != nil || email != "alice@example.com" {
+		t.Errorf("authenticated call got %q, %v, want alice@example.com", email, err)
+	}
+	if _, err := call(metadata.Pairs("authorization", "Bearer nope")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("invalid token got %v, want Unauthenticated", err)
+	}
+}
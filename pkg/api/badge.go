@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// BadgePrefix prefixes the path of the badges served by BadgeHandler.
+const BadgePrefix = "/badge/"
+
+// badgeCacheSeconds is how long clients may cache a badge without revalidating it.
+const badgeCacheSeconds = 60
+
+type badgeStyle struct {
+	message string
+	color   string
+}
+
+var badgeStyles = map[summarypb.DashboardTabSummary_TabStatus]badgeStyle{
+	summarypb.DashboardTabSummary_PASS:   {"passing", "#4c1"},
+	summarypb.DashboardTabSummary_FAIL:   {"failing", "#e05d44"},
+	summarypb.DashboardTabSummary_FLAKY:  {"flaky", "#dfb317"},
+	summarypb.DashboardTabSummary_STALE:  {"stale", "#9f9f9f"},
+	summarypb.DashboardTabSummary_BROKEN: {"broken", "#8b0000"},
+}
+
+var unknownBadge = badgeStyle{"unknown", "#9f9f9f"}
+
+// BadgeHandler serves an SVG badge with the status of a dashboard tab in its latest summary:
+//
+//	GET /badge/{dashboard}/{tab}.svg?label=...
+//
+// Labels the badge with the name of the tab unless label is set.
+func BadgeHandler(server apipb.TestGridDataServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		dashboard, tab, err := badgePath(r.URL.EscapedPath())
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatus(err))
+			return
+		}
+		var headers headerStream
+		ctx := grpc.NewContextWithServerTransportStream(r.Context(), &headers)
+		resp, err := server.GetSummary(ctx, &apipb.GetSummaryRequest{Dashboard: dashboard})
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatus(err))
+			return
+		}
+		var sum *summarypb.DashboardTabSummary
+		for _, ts := range resp.GetSummary().GetTabSummaries() {
+			if ts.DashboardTabName == tab {
+				sum = ts
+				break
+			}
+		}
+		if sum == nil {
+			http.Error(w, fmt.Sprintf("no summary for tab %q of dashboard %q", tab, dashboard), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", badgeCacheSeconds))
+		if notModified(w, r, &headers) {
+			return
+		}
+		label := r.URL.Query().Get("label")
+		if label == "" {
+			label = tab
+		}
+		style, ok := badgeStyles[sum.OverallStatus]
+		if !ok {
+			style = unknownBadge
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		fmt.Fprint(w, badge(label, style))
+	})
+}
+
+// badgePath returns the unescaped dashboard and tab of a badge path such as /badge/{dashboard}/{tab}.svg.
+func badgePath(p string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(p, BadgePrefix), "/")
+	if !strings.HasPrefix(p, BadgePrefix) || len(parts) != 2 || !strings.HasSuffix(parts[1], ".svg") {
+		return "", "", status.Errorf(codes.NotFound, "unknown badge %s, want %s{dashboard}/{tab}.svg", p, BadgePrefix)
+	}
+	parts[1] = strings.TrimSuffix(parts[1], ".svg")
+	for i, part := range parts {
+		s, err := url.PathUnescape(part)
+		if err != nil {
+			return "", "", status.Errorf(codes.InvalidArgument, "bad path: %v", err)
+		}
+		parts[i] = s
+	}
+	return parts[0], parts[1], nil
+}
+
+// textWidth estimates the width of the text in pixels, at the 11px font of the badge.
+func textWidth(text string) int {
+	return 7*len([]rune(text)) + 10
+}
+
+// badge renders a flat badge with the label on the left and the colored message on the right.
+func badge(label string, style badgeStyle) string {
+	lw, mw := textWidth(label), textWidth(style.message)
+	var el, em strings.Builder
+	xml.EscapeText(&el, []byte(label))
+	xml.EscapeText(&em, []byte(style.message))
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+<title>%[4]s: %[5]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text><text x="%[7]d" y="14">%[4]s</text>
+<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text><text x="%[8]d" y="14">%[5]s</text>
+</g>
+</svg>
+`, lw+mw, lw, mw, el.String(), em.String(), style.color, lw/2, lw+mw/2)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestBadgeHandler(t *testing.T) {
+	objects := fakeObjects{}
+	objects.put(t, "gs://bucket/summary/summary-second", &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{DashboardName: "second", DashboardTabName: "tab", OverallStatus: summarypb.DashboardTabSummary_FAIL},
+			{DashboardName: "second", DashboardTabName: "missing"},
+		},
+	})
+	handler := BadgeHandler(testServer(t, objects))
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		code     int
+		contains []string
+	}{
+		{
+			name:     "basic",
+			path:     "/badge/second/tab.svg",
+			code:     http.StatusOK,
+			contains: []string{">tab<", ">failing<", "#e05d44"},
+		},
+		{
+			name:     "label",
+			path:     "/badge/sec%6Fnd/tab.svg?label=e2e%20%3Ctests%3E",
+			code:     http.StatusOK,
+			contains: []string{">e2e &lt;tests&gt;<", ">failing<"},
+		},
+		{
+			name:     "unknown status",
+			path:     "/badge/second/missing.svg",
+			code:     http.StatusOK,
+			contains: []string{">unknown<"},
+		},
+		{
+			name: "missing tab",
+			path: "/badge/second/nope.svg",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "missing summary",
+			path: "/badge/first/tab.svg",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "not svg",
+			path: "/badge/second/tab.png",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "too many parts",
+			path: "/badge/second/tab/extra.svg",
+			code: http.StatusNotFound,
+		},
+		{
+			name:   "post",
+			method: http.MethodPost,
+			path:   "/badge/second/tab.svg",
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Fatalf("ServeHTTP() got code %d, want %d: %s", rec.Code, tc.code, rec.Body.String())
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != "image/svg+xml" {
+				t.Errorf("ServeHTTP() got Content-Type %q, want image/svg+xml", got)
+			}
+			body := rec.Body.String()
+			for _, want := range tc.contains {
+				if !strings.Contains(body, want) {
+					t.Errorf("ServeHTTP() got badge missing %q:\n%s", want, body)
+				}
+			}
+			dec := xml.NewDecoder(strings.NewReader(body))
+			for {
+				_, err := dec.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ServeHTTP() got invalid SVG: %v\n%s", err, body)
+				}
+			}
+
+			req := httptest.NewRequest(method, tc.path, nil)
+			req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("ServeHTTP() with the etag got code %d, want %d", rec.Code, http.StatusNotModified)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// objectCache holds the most recently used messages decoded from objects, by path and generation.
+type objectCache struct {
+	lock  sync.Mutex
+	size  int
+	order *list.List // of *cacheEntry, most recently used first
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	path       string
+	generation int64
+	msg        proto.Message
+}
+
+// newObjectCache returns a cache holding up to size messages, or none when size is zero.
+func newObjectCache(size int) *objectCache {
+	return &objectCache{
+		size:  size,
+		order: list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+// get returns the cached message of the generation of the object, or nil.
+func (c *objectCache) get(path string, generation int64) proto.Message {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	el, ok := c.items[path]
+	if !ok {
+		return nil
+	}
+	entry := el.Value.(*cacheEntry)
+	if entry.generation != generation {
+		return nil
+	}
+	c.order.MoveToFront(el)
+	return entry.msg
+}
+
+// put caches the message of the generation of the object, evicting the least recently used one when full.
+func (c *objectCache) put(path string, generation int64, msg proto.Message) {
+	if c.size <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if el, ok := c.items[path]; ok {
+		el.Value = &cacheEntry{path, generation, msg}
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[path] = c.order.PushFront(&cacheEntry{path, generation, msg})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).path)
+	}
+}
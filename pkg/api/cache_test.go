@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestObjectCache(t *testing.T) {
+	grid := func(build string) *statepb.Grid {
+		return &statepb.Grid{Columns: []*statepb.Column{{Build: build}}}
+	}
+	type put struct {
+		path string
+		gen  int64
+		msg  proto.Message
+	}
+	cases := []struct {
+		name     string
+		size     int
+		puts     []put
+		path     string
+		gen      int64
+		expected proto.Message
+	}{
+		{
+			name: "empty",
+			size: 2,
+			path: "a",
+			gen:  1,
+		},
+		{
+			name:     "hit",
+			size:     2,
+			puts:     []put{{"a", 1, grid("a1")}},
+			path:     "a",
+			gen:      1,
+			expected: grid("a1"),
+		},
+		{
+			name: "old generation",
+			size: 2,
+			puts: []put{{"a", 1, grid("a1")}},
+			path: "a",
+			gen:  2,
+		},
+		{
+			name:     "replace generation",
+			size:     2,
+			puts:     []put{{"a", 1, grid("a1")}, {"a", 2, grid("a2")}},
+			path:     "a",
+			gen:      2,
+			expected: grid("a2"),
+		},
+		{
+			name: "evict least recently used",
+			size: 2,
+			puts: []put{{"a", 1, grid("a")}, {"b", 1, grid("b")}, {"c", 1, grid("c")}},
+			path: "a",
+			gen:  1,
+		},
+		{
+			name:     "keep recently used",
+			size:     2,
+			puts:     []put{{"a", 1, grid("a")}, {"b", 1, grid("b")}, {"c", 1, grid("c")}},
+			path:     "c",
+			gen:      1,
+			expected: grid("c"),
+		},
+		{
+			name: "disabled",
+			puts: []put{{"a", 1, grid("a1")}},
+			path: "a",
+			gen:  1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newObjectCache(tc.size)
+			for _, p := range tc.puts {
+				c.put(p.path, p.gen, p.msg)
+			}
+			got := c.get(tc.path, tc.gen)
+			if !proto.Equal(got, tc.expected) {
+				t.Errorf("get(%q, %d) got %v, want %v", tc.path, tc.gen, got, tc.expected)
+			}
+		})
+	}
+}
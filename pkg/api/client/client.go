@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client calls the JSON API of a TestGrid API server.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+)
+
+// maxEvent bounds the size of a server-sent event, which may hold a tab summary.
+const maxEvent = 16 << 20
+
+// Client calls the methods of a server through its JSON API.
+type Client struct {
+	base   string
+	client *http.Client
+}
+
+// New returns a client of the server at baseURL, such as https://testgrid.example.com,
+// or https://testgrid.example.com/scopes/internal to call a scope.
+//
+// Sends requests with http.DefaultClient when httpClient is nil. Use a client
+// whose transport adds an Authorization header to authenticate.
+func New(baseURL string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", baseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("want an http or https URL, got %q", baseURL)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		base:   strings.TrimRight(u.String(), "/") + api.PathPrefix,
+		client: httpClient,
+	}, nil
+}
+
+// Error is the status code and message of a failed call.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// endpoint returns the endpoint of the method.
+func endpoint(method string) (*api.Endpoint, error) {
+	for i, e := range api.Endpoints {
+		if e.Method == method {
+			return &api.Endpoints[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
+
+// request returns an HTTP request calling the method with the fields of req.
+func (c *Client) request(ctx context.Context, method string, req proto.Message) (*http.Request, error) {
+	e, err := endpoint(method)
+	if err != nil {
+		return nil, err
+	}
+	msg := proto.MessageReflect(req)
+	p := e.Path
+	for _, param := range e.PathParams {
+		vals, err := values(msg, param)
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) == 0 || vals[0] == "" {
+			return nil, fmt.Errorf("%s: missing %s", method, param.Field)
+		}
+		p = strings.Replace(p, "{"+param.Name+"}", url.PathEscape(vals[0]), 1)
+	}
+	query := url.Values{}
+	for _, param := range e.Query {
+		vals, err := values(msg, param)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vals {
+			query.Add(param.Name, v)
+		}
+	}
+	u := c.base + p
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return http.NewRequestWithContext(ctx, e.HTTPMethod, u, nil)
+}
+
+// values returns the formatted values of the field of the parameter, or none when unset.
+func values(msg protoreflect.Message, param api.Param) ([]string, error) {
+	fds, err := param.Fields(msg.Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	for _, fd := range fds[:len(fds)-1] {
+		if !msg.Has(fd) {
+			return nil, nil
+		}
+		msg = msg.Get(fd).Message()
+	}
+	fd := fds[len(fds)-1]
+	if !msg.Has(fd) {
+		return nil, nil
+	}
+	v := msg.Get(fd)
+	if !fd.IsList() {
+		return []string{formatValue(fd, v)}, nil
+	}
+	list := v.List()
+	out := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		out = append(out, formatValue(fd, list.Get(i)))
+	}
+	return out, nil
+}
+
+// formatValue returns the value of the field as the server parses it from a parameter.
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.MessageKind:
+		// Only timestamps and field masks are parameters.
+		msg := v.Message()
+		fields := msg.Descriptor().Fields()
+		if msg.Descriptor().FullName() == fieldMaskName {
+			list := msg.Get(fields.ByName("paths")).List()
+			paths := make([]string, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				paths = append(paths, list.Get(i).String())
+			}
+			return strings.Join(paths, ",")
+		}
+		secs := msg.Get(fields.ByName("seconds")).Int()
+		nanos := msg.Get(fields.ByName("nanos")).Int()
+		return time.Unix(secs, nanos).UTC().Format(time.RFC3339Nano)
+	}
+	return v.String()
+}
+
+// call calls the method with req, unmarshaling the response into resp.
+func (c *Client) call(ctx context.Context, method string, req, resp proto.Message) error {
+	r, err := c.do(ctx, method, req, "application/json")
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := u.Unmarshal(r.Body, resp); err != nil {
+		return fmt.Errorf("%s: unmarshal: %w", method, err)
+	}
+	return nil
+}
+
+// stream calls the method with req, handling the data of each event until the stream ends or handle fails.
+func (c *Client) stream(ctx context.Context, method string, req proto.Message, handle func([]byte) error) error {
+	r, err := c.do(ctx, method, req, "text/event-stream")
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(nil, maxEvent)
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			if err := handle(data.Bytes()); err != nil {
+				return err
+			}
+			data.Reset()
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: read: %w", method, err)
+	}
+	return nil
+}
+
+// do sends the request calling the method, returning the response when it succeeds.
+func (c *Client) do(ctx context.Context, method string, req proto.Message, accept string) (*http.Response, error) {
+	hreq, err := c.request(ctx, method, req)
+	if err != nil {
+		return nil, err
+	}
+	hreq.Header.Set("Accept", accept)
+	r, err := c.client.Do(hreq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	if r.StatusCode == http.StatusOK {
+		return r, nil
+	}
+	defer r.Body.Close()
+	buf, _ := ioutil.ReadAll(io.LimitReader(r.Body, 1<<10))
+	return nil, &Error{StatusCode: r.StatusCode, Message: strings.TrimSpace(string(buf))}
+}
+
+// unmarshal parses the JSON of a message from the data of an event.
+func unmarshal(data []byte, msg proto.Message) error {
+	u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	return u.Unmarshal(bytes.NewReader(data), msg)
+}
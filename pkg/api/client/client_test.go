@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+)
+
+// fakeServer records the request of each call, answering with a response naming the method.
+type fakeServer struct {
+	apipb.UnimplementedTestGridDataServer
+	got proto.Message
+}
+
+func (fs *fakeServer) ListDashboards(_ context.Context, req *apipb.ListDashboardsRequest) (*apipb.ListDashboardsResponse, error) {
+	fs.got = req
+	return &apipb.ListDashboardsResponse{Dashboards: []*apipb.DashboardResource{{Name: "ListDashboards"}}}, nil
+}
+
+func (fs *fakeServer) GetTabState(_ context.Context, req *apipb.GetTabStateRequest) (*apipb.GetTabStateResponse, error) {
+	fs.got = req
+	return &apipb.GetTabStateResponse{TotalRows: 3}, nil
+}
+
+func (fs *fakeServer) CompareTabs(_ context.Context, req *apipb.CompareTabsRequest) (*apipb.CompareTabsResponse, error) {
+	fs.got = req
+	return &apipb.CompareTabsResponse{BaseColumns: 2}, nil
+}
+
+func (fs *fakeServer) GetSummary(_ context.Context, req *apipb.GetSummaryRequest) (*apipb.GetSummaryResponse, error) {
+	fs.got = req
+	return nil, status.Error(codes.NotFound, "nope")
+}
+
+func (fs *fakeServer) TriggerSummary(_ context.Context, req *apipb.TriggerSummaryRequest) (*apipb.TriggerSummaryResponse, error) {
+	fs.got = req
+	return &apipb.TriggerSummaryResponse{}, nil
+}
+
+func (fs *fakeServer) WatchDashboard(req *apipb.WatchDashboardRequest, stream apipb.TestGridData_WatchDashboardServer) error {
+	fs.got = req
+	for _, gen := range []int64{1, 2} {
+		if err := stream.Send(&apipb.DashboardEvent{Kind: apipb.DashboardEvent_GRID, Dashboard: req.Dashboard, Generation: gen}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fs *fakeServer) ListTabs(_ context.Context, req *apipb.ListTabsRequest) (*apipb.ListTabsResponse, error) {
+	// Events check the dashboard exists.
+	return &apipb.ListTabsResponse{}, nil
+}
+
+func testClient(t *testing.T) (*Client, *fakeServer) {
+	var fs fakeServer
+	server := httptest.NewServer(api.Handler(&fs))
+	t.Cleanup(server.Close)
+	c, err := New(server.URL+"/", server.Client())
+	if err != nil {
+		t.Fatalf("New() got unexpected error: %v", err)
+	}
+	return c, &fs
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name     string
+		url      string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "basic",
+			url:      "https://testgrid.example.com",
+			expected: "https://testgrid.example.com/api/v1",
+		},
+		{
+			name:     "scope",
+			url:      "http://localhost:8080/scopes/internal/",
+			expected: "http://localhost:8080/scopes/internal/api/v1",
+		},
+		{
+			name: "relative",
+			url:  "/api",
+			err:  true,
+		},
+		{
+			name: "not http",
+			url:  "gs://bucket/config",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(tc.url, nil)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("New() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("New() failed to return an error, got %s", c.base)
+			case c.base != tc.expected:
+				t.Errorf("New() got base %s, want %s", c.base, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCalls(t *testing.T) {
+	cases := []struct {
+		name     string
+		call     func(context.Context, *Client) (proto.Message, error)
+		req      proto.Message
+		expected proto.Message
+		code     int
+	}{
+		{
+			name: "query",
+			req:  &apipb.ListDashboardsRequest{DashboardGroup: "a group"},
+			call: func(ctx context.Context, c *Client) (proto.Message, error) {
+				return c.ListDashboards(ctx, &apipb.ListDashboardsRequest{DashboardGroup: "a group"})
+			},
+			expected: &apipb.ListDashboardsResponse{Dashboards: []*apipb.DashboardResource{{Name: "ListDashboards"}}},
+		},
+		{
+			name: "escaped path and repeated query",
+			req: &apipb.GetTabStateRequest{
+				Dashboard:   "sig/testing",
+				Tab:         "unit?",
+				ColumnLimit: 10,
+				RowRegex:    "^Test",
+				Status:      []statuspb.TestStatus{statuspb.TestStatus_FAIL, statuspb.TestStatus_FLAKY},
+				ReadMask:    &field_mask.FieldMask{Paths: []string{"grid.rows.name", "total_rows"}},
+			},
+			call: func(ctx context.Context, c *Client) (proto.Message, error) {
+				return c.GetTabState(ctx, &apipb.GetTabStateRequest{
+					Dashboard:   "sig/testing",
+					Tab:         "unit?",
+					ColumnLimit: 10,
+					RowRegex:    "^Test",
+					Status:      []statuspb.TestStatus{statuspb.TestStatus_FAIL, statuspb.TestStatus_FLAKY},
+					ReadMask:    &field_mask.FieldMask{Paths: []string{"grid.rows.name", "total_rows"}},
+				})
+			},
+			expected: &apipb.GetTabStateResponse{TotalRows: 3},
+		},
+		{
+			name: "nested fields and timestamps",
+			req: &apipb.CompareTabsRequest{
+				Base:   &apipb.TabRange{Dashboard: "dash", Tab: "old", Start: &timestamp.Timestamp{Seconds: 1000, Nanos: 5}},
+				Target: &apipb.TabRange{Dashboard: "dash", Tab: "new", End: &timestamp.Timestamp{Seconds: 2000}},
+			},
+			call: func(ctx context.Context, c *Client) (proto.Message, error) {
+				return c.CompareTabs(ctx, &apipb.CompareTabsRequest{
+					Base:   &apipb.TabRange{Tab: "old", Start: &timestamp.Timestamp{Seconds: 1000, Nanos: 5}},
+					Target: &apipb.TabRange{Dashboard: "dash", Tab: "new", End: &timestamp.Timestamp{Seconds: 2000}},
+				})
+			},
+			expected: &apipb.CompareTabsResponse{BaseColumns: 2},
+		},
+		{
+			name: "post",
+			req:  &apipb.TriggerSummaryRequest{Dashboard: "dash"},
+			call: func(ctx context.Context, c *Client) (proto.Message, error) {
+				return c.TriggerSummary(ctx, &apipb.TriggerSummaryRequest{Dashboard: "dash"})
+			},
+			expected: &apipb.TriggerSummaryResponse{},
+		},
+		{
+			name: "error",
+			req:  &apipb.GetSummaryRequest{Dashboard: "dash"},
+			call: func(ctx context.Context, c *Client) (proto.Message, error) {
+				return c.GetSummary(ctx, &apipb.GetSummaryRequest{Dashboard: "dash"})
+			},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, fs := testClient(t)
+			got, err := tc.call(context.Background(), c)
+			var apiErr *Error
+			switch {
+			case errors.As(err, &apiErr):
+				if apiErr.StatusCode != tc.code {
+					t.Errorf("call got status %d, want %d: %v", apiErr.StatusCode, tc.code, err)
+				}
+			case err != nil:
+				t.Fatalf("call got unexpected error: %v", err)
+			case tc.code != 0:
+				t.Errorf("call failed to return a %d error, got %v", tc.code, got)
+			default:
+				if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+					t.Errorf("call got unexpected response diff (-want +got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.req, fs.got, protocmp.Transform()); diff != "" {
+				t.Errorf("server got unexpected request diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMissingPathParam(t *testing.T) {
+	c, fs := testClient(t)
+	if _, err := c.ListTabs(context.Background(), &apipb.ListTabsRequest{}); err == nil {
+		t.Error("ListTabs() failed to return an error")
+	}
+	if fs.got != nil {
+		t.Errorf("ListTabs() unexpectedly called the server with %v", fs.got)
+	}
+}
+
+func TestWatchDashboard(t *testing.T) {
+	c, _ := testClient(t)
+	var got []*apipb.DashboardEvent
+	err := c.WatchDashboard(context.Background(), &apipb.WatchDashboardRequest{Dashboard: "dash"}, func(event *apipb.DashboardEvent) error {
+		got = append(got, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WatchDashboard() got unexpected error: %v", err)
+	}
+	expected := []*apipb.DashboardEvent{
+		{Kind: apipb.DashboardEvent_GRID, Dashboard: "dash", Generation: 1},
+		{Kind: apipb.DashboardEvent_GRID, Dashboard: "dash", Generation: 2},
+	}
+	if diff := cmp.Diff(expected, got, protocmp.Transform()); diff != "" {
+		t.Errorf("WatchDashboard() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
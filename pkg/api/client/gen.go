@@ -0,0 +1,307 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+)
+
+// generated heads every file the generators write.
+const generated = "Code generated by go run ./cmd/api_gen. DO NOT EDIT."
+
+// GoMethods returns the Go source of a Client method for each of the api.Endpoints.
+func GoMethods() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// %s\n\npackage client\n\n", generated)
+	buf.WriteString("import (\n\t\"context\"\n\n\tapipb \"github.com/GoogleCloudPlatform/testgrid/pb/api\"\n)\n")
+	for _, e := range api.Endpoints {
+		req := proto.MessageReflect(e.Request).Descriptor().Name()
+		resp := proto.MessageReflect(e.Response).Descriptor().Name()
+		fmt.Fprintf(&buf, "\n// %s %s\n", e.Method, lowerFirst(e.Summary))
+		if e.Stream {
+			fmt.Fprintf(&buf, "//\n// Handles each event until the stream ends, handle fails or the context is done.\n")
+			fmt.Fprintf(&buf, "func (c *Client) %s(ctx context.Context, req *apipb.%s, handle func(*apipb.%s) error) error {\n", e.Method, req, resp)
+			fmt.Fprintf(&buf, "\treturn c.stream(ctx, %q, req, func(data []byte) error {\n", e.Method)
+			fmt.Fprintf(&buf, "\t\tvar event apipb.%s\n", resp)
+			fmt.Fprintf(&buf, "\t\tif err := unmarshal(data, &event); err != nil {\n\t\t\treturn err\n\t\t}\n")
+			fmt.Fprintf(&buf, "\t\treturn handle(&event)\n\t})\n}\n")
+			continue
+		}
+		fmt.Fprintf(&buf, "func (c *Client) %s(ctx context.Context, req *apipb.%s) (*apipb.%s, error) {\n", e.Method, req, resp)
+		fmt.Fprintf(&buf, "\tvar resp apipb.%s\n", resp)
+		fmt.Fprintf(&buf, "\tif err := c.call(ctx, %q, req, &resp); err != nil {\n\t\treturn nil, err\n\t}\n", e.Method)
+		fmt.Fprintf(&buf, "\treturn &resp, nil\n}\n")
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format: %w", err)
+	}
+	return out, nil
+}
+
+// TypeScript returns the TypeScript source of a client calling the api.Endpoints,
+// along with the types of their requests and responses.
+//
+// Types follow the JSON mapping of the protos, as api.OpenAPI describes.
+func TypeScript() ([]byte, error) {
+	messages := map[string]protoreflect.MessageDescriptor{}
+	enums := map[string]protoreflect.EnumDescriptor{}
+	for _, e := range api.Endpoints {
+		for _, msg := range []proto.Message{e.Request, e.Response} {
+			collect(proto.MessageReflect(msg).Descriptor(), messages, enums)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// %s\n", generated)
+	enumNames := map[string]bool{}
+	for name := range enums {
+		enumNames[name] = true
+	}
+	messageNames := map[string]bool{}
+	for name := range messages {
+		messageNames[name] = true
+	}
+	for _, name := range sortedKeys(enumNames) {
+		values := enums[name].Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, fmt.Sprintf("%q", values.Get(i).Name()))
+		}
+		fmt.Fprintf(&buf, "\nexport type %s = %s;\n", tsName(name), strings.Join(names, " | "))
+	}
+	for _, name := range sortedKeys(messageNames) {
+		fmt.Fprintf(&buf, "\nexport interface %s {\n", tsName(name))
+		fields := messages[name].Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			fmt.Fprintf(&buf, "  %s?: %s;\n", fd.Name(), tsFieldType(fd))
+		}
+		buf.WriteString("}\n")
+	}
+
+	buf.WriteString(tsPrelude)
+	for _, e := range api.Endpoints {
+		if err := tsMethod(&buf, e); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Method, err)
+		}
+	}
+	buf.WriteString(tsHelpers)
+	return buf.Bytes(), nil
+}
+
+const tsPrelude = `
+/** The status and message of a failed call. */
+export class TestGridError extends Error {
+  status: number;
+
+  constructor(status: number, message: string) {
+    super(` + "`${status}: ${message}`" + `);
+    this.status = status;
+  }
+}
+
+/** Calls the methods of a TestGrid API server through its JSON API. */
+export class TestGridClient {
+  private readonly base: string;
+  private readonly init: RequestInit;
+
+  /**
+   * Calls the server at baseUrl, such as https://testgrid.example.com, or
+   * https://testgrid.example.com/scopes/internal to call a scope.
+   *
+   * Merges init into each fetch, such as to add an Authorization header.
+   */
+  constructor(baseUrl: string, init: RequestInit = {}) {
+    this.base = baseUrl.replace(/\/+$/, "") + "` + api.PathPrefix + `";
+    this.init = init;
+  }
+`
+
+const tsHelpers = `
+  private async call<T>(method: string, path: string, query: URLSearchParams): Promise<T> {
+    const resp = await fetch(this.url(path, query), {...this.init, method});
+    if (!resp.ok) {
+      throw new TestGridError(resp.status, (await resp.text()).trim());
+    }
+    return (await resp.json()) as T;
+  }
+
+  private url(path: string, query: URLSearchParams): string {
+    const q = query.toString();
+    return this.base + path + (q ? "?" + q : "");
+  }
+}
+
+function segment(value: string | undefined): string {
+  if (!value) {
+    throw new Error("missing path parameter");
+  }
+  return encodeURIComponent(value);
+}
+
+function add(query: URLSearchParams, name: string, value: unknown): void {
+  if (value === undefined || value === null || value === "") {
+    return;
+  }
+  for (const v of Array.isArray(value) ? value : [value]) {
+    query.append(name, String(v));
+  }
+}
+`
+
+// tsMethod writes the client method calling the endpoint.
+func tsMethod(buf *bytes.Buffer, e api.Endpoint) error {
+	reqDesc := proto.MessageReflect(e.Request).Descriptor()
+	respDesc := proto.MessageReflect(e.Response).Descriptor()
+	req, resp := tsName(string(reqDesc.FullName())), tsName(string(respDesc.FullName()))
+	path := e.Path
+	for _, p := range e.PathParams {
+		if _, err := p.Fields(reqDesc); err != nil {
+			return err
+		}
+		path = strings.Replace(path, "{"+p.Name+"}", "${segment(req."+tsAccess(p.Field)+")}", 1)
+	}
+	arg := "req: " + req
+	if len(e.PathParams) == 0 {
+		arg += " = {}"
+	}
+	fmt.Fprintf(buf, "\n  /** %s */\n", e.Summary)
+	if e.Stream {
+		kind := respDesc.Fields().ByName("kind")
+		if kind == nil || kind.Enum() == nil {
+			return fmt.Errorf("%s has no kind enum naming its events", respDesc.FullName())
+		}
+		var kinds []string
+		values := kind.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			kinds = append(kinds, fmt.Sprintf("%q", values.Get(i).Name()))
+		}
+		fmt.Fprintf(buf, "  %s(%s, onEvent: (event: %s) => void): EventSource {\n", lowerFirst(e.Method), arg, resp)
+		fmt.Fprintf(buf, "    const source = new EventSource(this.url(`%s`, new URLSearchParams()));\n", path)
+		fmt.Fprintf(buf, "    for (const kind of [%s]) {\n", strings.Join(kinds, ", "))
+		fmt.Fprintf(buf, "      source.addEventListener(kind, (event) => onEvent(JSON.parse((event as MessageEvent).data) as %s));\n", resp)
+		fmt.Fprintf(buf, "    }\n    return source;\n  }\n")
+		return nil
+	}
+	fmt.Fprintf(buf, "  async %s(%s): Promise<%s> {\n", lowerFirst(e.Method), arg, resp)
+	fmt.Fprintf(buf, "    const query = new URLSearchParams();\n")
+	for _, p := range e.Query {
+		if _, err := p.Fields(reqDesc); err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "    add(query, %q, req.%s);\n", p.Name, tsAccess(p.Field))
+	}
+	fmt.Fprintf(buf, "    return this.call(%q, `%s`, query);\n  }\n", e.HTTPMethod, path)
+	return nil
+}
+
+// collect adds the message and the messages and enums it references.
+func collect(md protoreflect.MessageDescriptor, messages map[string]protoreflect.MessageDescriptor, enums map[string]protoreflect.EnumDescriptor) {
+	name := string(md.FullName())
+	if _, ok := messages[name]; ok || name == timestampName || name == fieldMaskName {
+		return
+	}
+	messages[name] = md
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		switch {
+		case fd.Message() != nil:
+			collect(fd.Message(), messages, enums)
+		case fd.Enum() != nil:
+			enums[string(fd.Enum().FullName())] = fd.Enum()
+		}
+	}
+}
+
+// Full names of the well-known messages which JSON maps to strings.
+const (
+	timestampName = "google.protobuf.Timestamp"
+	fieldMaskName = "google.protobuf.FieldMask"
+)
+
+func tsFieldType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return "Record<string, " + tsValueType(fd.MapValue()) + ">"
+	case fd.IsList():
+		t := tsValueType(fd)
+		if strings.Contains(t, " ") {
+			t = "(" + t + ")"
+		}
+		return t + "[]"
+	}
+	return tsValueType(fd)
+}
+
+func tsValueType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if name := fd.Message().FullName(); name == timestampName || name == fieldMaskName {
+			return "string"
+		}
+		return tsName(string(fd.Message().FullName()))
+	case protoreflect.EnumKind:
+		return tsName(string(fd.Enum().FullName()))
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.StringKind, protoreflect.BytesKind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "string"
+	}
+	return "number"
+}
+
+// tsName returns the TypeScript name of a proto type, joining nested names with underscores.
+func tsName(fullName string) string {
+	return strings.ReplaceAll(fullName, ".", "_")
+}
+
+// tsAccess returns the optional chain reading the field path.
+func tsAccess(field string) string {
+	return strings.ReplaceAll(field, ".", "?.")
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+func sortedKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
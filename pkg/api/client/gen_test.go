@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestShippedClients(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		generate func() ([]byte, error)
+		flag     string
+	}{
+		{
+			name:     "go",
+			path:     "methods.go",
+			generate: GoMethods,
+			flag:     "--go-client=pkg/api/client/methods.go",
+		},
+		{
+			name:     "typescript",
+			path:     "testgrid.ts",
+			generate: TypeScript,
+			flag:     "--typescript=pkg/api/client/testgrid.ts",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, err := tc.generate()
+			if err != nil {
+				t.Fatalf("generate got unexpected error: %v", err)
+			}
+			actual, err := ioutil.ReadFile(tc.path)
+			if err != nil {
+				t.Fatalf("Failed to read shipped client: %v", err)
+			}
+			if diff := cmp.Diff(string(expected), string(actual)); diff != "" {
+				t.Errorf("%s is stale, run go run ./cmd/api_gen %s (-want +got):\n%s", tc.path, tc.flag, diff)
+			}
+		})
+	}
+}
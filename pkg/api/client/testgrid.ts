@@ -0,0 +1,782 @@
+// Code generated by go run ./cmd/api_gen. DO NOT EDIT.
+
+export type AutoBugOptions_Priority = "PRIORITY_UNSPECIFIED" | "P0" | "P1" | "P2" | "P3" | "P4";
+
+export type Comparison_Operator = "OP_UNKNOWN" | "OP_EQ" | "OP_NE" | "OP_LT" | "OP_LE" | "OP_GT" | "OP_GE" | "OP_REGEX" | "OP_STARTS_WITH" | "OP_CONTAINS";
+
+export type DashboardEvent_Kind = "UNKNOWN" | "TAB_SUMMARY" | "GRID";
+
+export type DashboardTabSummary_TabStatus = "NOT_SET" | "UNKNOWN" | "PASS" | "FAIL" | "FLAKY" | "STALE" | "BROKEN";
+
+export type HealthTrend_Direction = "UNKNOWN" | "STABLE" | "IMPROVING" | "DEGRADING";
+
+export type TestComparison_Change = "UNKNOWN" | "NEWLY_FAILING" | "REGRESSED" | "FIXED";
+
+export type TestComparison_Outcome = "ABSENT" | "PASSING" | "FLAKY" | "FAILING";
+
+export type TestGroup_ColumnRetention = "COLUMN_RETENTION_ALL" | "COLUMN_RETENTION_EXPONENTIAL";
+
+export type TestGroup_ColumnSortBy = "COLUMN_SORT_DATE" | "COLUMN_SORT_COMMIT_NUM";
+
+export type TestGroup_DuplicateBuilds = "DUPLICATE_BUILDS_SEPARATE" | "DUPLICATE_BUILDS_LATEST" | "DUPLICATE_BUILDS_UNION";
+
+export type TestGroup_Environment = "PROD" | "QA";
+
+export type TestGroup_FallbackGrouping = "FALLBACK_GROUPING_NONE" | "FALLBACK_GROUPING_DATE" | "FALLBACK_GROUPING_LABELS" | "FALLBACK_GROUPING_ID" | "FALLBACK_GROUPING_COMMIT_NUM" | "FALLBACK_GROUPING_CONFIGURATION_VALUE";
+
+export type TestGroup_PrimaryGrouping = "PRIMARY_GROUPING_NONE" | "PRIMARY_GROUPING_COMMIT_NUM";
+
+export type TestGroup_TestsName = "TESTS_NAME_UNSPECIFIED" | "TESTS_NAME_IGNORE" | "TESTS_NAME_REPLACE" | "TESTS_NAME_APPEND";
+
+export type TestInfo_Trend = "UNKNOWN" | "NO_CHANGE" | "UP" | "DOWN";
+
+export type TestStatus = "NO_RESULT" | "PASS" | "PASS_WITH_ERRORS" | "PASS_WITH_SKIPS" | "RUNNING" | "CATEGORIZED_ABORT" | "UNKNOWN" | "CANCEL" | "BLOCKED" | "TIMED_OUT" | "CATEGORIZED_FAIL" | "BUILD_FAIL" | "FAIL" | "FLAKY" | "TOOL_FAIL" | "BUILD_PASSED" | "ABORTED";
+
+export interface AlertInfo {
+  fail_count?: number;
+  fail_build_id?: string;
+  fail_time?: string;
+  fail_test_id?: string;
+  pass_build_id?: string;
+  pass_time?: string;
+  failure_message?: string;
+  build_link?: string;
+  build_link_text?: string;
+  build_url_text?: string;
+  latest_fail_build_id?: string;
+  latest_fail_test_id?: string;
+  properties?: Record<string, string>;
+  hotlist_ids?: string[];
+}
+
+export interface AlertingData {
+  last_email_time?: string;
+}
+
+export interface AutoBugOptions {
+  beta_autobug_component?: number;
+  auto_close?: boolean;
+  hotlist_ids?: string[];
+  priority?: AutoBugOptions_Priority;
+  hotlist_ids_from_source?: HotlistIdFromSource[];
+  file_individual?: boolean;
+  singleton_autobug?: boolean;
+  max_allowed_individual_bugs?: number;
+  file_overall?: boolean;
+  default_test_metadata?: AutoBugOptions_DefaultTestMetadata;
+  advanced_test_metadata?: boolean;
+}
+
+export interface AutoBugOptions_DefaultTestMetadata {
+  bug_component?: number;
+  owner?: string;
+  cc?: string;
+}
+
+export interface AzureDevOpsConfig {
+  organization?: string;
+  project?: string;
+  build_definition_ids?: number[];
+}
+
+export interface BigQueryConfig {
+  project?: string;
+  query?: string;
+}
+
+export interface CloudBuildConfig {
+  project?: string;
+  trigger_id?: string;
+}
+
+export interface Cluster {
+  test_status?: number;
+  message?: string;
+  cluster_row?: ClusterRow[];
+}
+
+export interface ClusterRow {
+  display_name?: string;
+  index?: number[];
+}
+
+export interface Column {
+  build?: string;
+  name?: string;
+  started?: number;
+  extra?: string[];
+  hotlist_ids?: string;
+  ignored_passes?: number;
+  ignored_skips?: number;
+}
+
+export interface CompareTabsRequest {
+  base?: TabRange;
+  target?: TabRange;
+}
+
+export interface CompareTabsResponse {
+  tests?: TestComparison[];
+  base_columns?: number;
+  target_columns?: number;
+}
+
+export interface Comparison {
+  op?: Comparison_Operator;
+  string_value?: string;
+  numerical_value?: number;
+}
+
+export interface DashboardEvent {
+  kind?: DashboardEvent_Kind;
+  dashboard?: string;
+  tab?: string;
+  generation?: string;
+  tab_summary?: DashboardTabSummary;
+}
+
+export interface DashboardOwnership {
+  owners?: string[];
+  team?: string;
+  contact?: string;
+}
+
+export interface DashboardResource {
+  name?: string;
+  dashboard_groups?: string[];
+  ownership?: Ownership;
+  tab_names?: string[];
+}
+
+export interface DashboardSummary {
+  tab_summaries?: DashboardTabSummary[];
+  ownership?: DashboardOwnership;
+}
+
+export interface DashboardTabSummary {
+  dashboard_name?: string;
+  dashboard_tab_name?: string;
+  alert?: string;
+  failing_test_summaries?: FailingTestSummary[];
+  last_update_timestamp?: number;
+  status?: string;
+  overall_status?: DashboardTabSummary_TabStatus;
+  latest_green?: string;
+  last_run_timestamp?: number;
+  bug_url?: string;
+  healthiness?: HealthinessInfo;
+  linked_issues?: string[];
+  alerting_data?: AlertingData;
+  flake_rates?: Record<string, number>;
+  failure_clusters?: FailureCluster[];
+  health_trend?: HealthTrend;
+  grid_generation?: string;
+  config_fingerprint?: string;
+  infra_failure_builds?: string[];
+  completed_columns?: number;
+  passing_columns?: number;
+  filled_cells?: number;
+  passing_cells?: number;
+  slo?: SLOStatus;
+  recent_health?: RecentHealth;
+  description?: string;
+  documentation_url?: string;
+}
+
+export interface FailingTest {
+  name?: string;
+  tabs?: FailingTest_Tab[];
+}
+
+export interface FailingTest_Tab {
+  dashboard?: string;
+  tab?: string;
+  failure?: FailingTestSummary;
+}
+
+export interface FailingTestSummary {
+  display_name?: string;
+  test_name?: string;
+  fail_build_id?: string;
+  fail_timestamp?: number;
+  pass_build_id?: string;
+  pass_timestamp?: number;
+  fail_count?: number;
+  build_link?: string;
+  build_link_text?: string;
+  build_url_text?: string;
+  failure_message?: string;
+  linked_bugs?: string[];
+  fail_test_link?: string;
+  latest_fail_test_link?: string;
+  latest_fail_build_id?: string;
+  properties?: Record<string, string>;
+  hotlist_ids?: string[];
+  latest_fail_artifact_url?: string;
+  issue_url?: string;
+  file_bug_url?: string;
+}
+
+export interface FailureCluster {
+  failure_message?: string;
+  tests?: string[];
+  failures?: number;
+}
+
+export interface GetSummaryRequest {
+  dashboard?: string;
+}
+
+export interface GetSummaryResponse {
+  summary?: DashboardSummary;
+}
+
+export interface GetTabStateRequest {
+  dashboard?: string;
+  tab?: string;
+  column_offset?: number;
+  column_limit?: number;
+  row_offset?: number;
+  row_limit?: number;
+  row_regex?: string;
+  status?: TestStatus[];
+  read_mask?: string;
+}
+
+export interface GetTabStateResponse {
+  grid?: Grid;
+  total_columns?: number;
+  total_rows?: number;
+}
+
+export interface GetTestHistoryRequest {
+  test?: string;
+  limit?: number;
+}
+
+export interface GetTestHistoryResponse {
+  tabs?: TestHistory[];
+  last_pass?: TestHistory;
+}
+
+export interface Grid {
+  columns?: Column[];
+  rows?: Row[];
+  last_alert_mail_time?: number;
+  config?: TestGroup;
+  last_time_updated?: number;
+  update_info?: UpdateInfo[];
+  test_metadata?: TestMetadata[];
+  cluster?: Cluster[];
+  most_recent_cluster_timestamp?: number;
+  cell_width?: number;
+}
+
+export interface HealthTrend {
+  short_days?: number;
+  long_days?: number;
+  short_pass_rate?: number;
+  long_pass_rate?: number;
+  delta?: number;
+  direction?: HealthTrend_Direction;
+}
+
+export interface HealthinessInfo {
+  start?: string;
+  end?: string;
+  tests?: TestInfo[];
+  average_flakiness?: number;
+  previous_flakiness?: number[];
+}
+
+export interface HotlistIdFromSource {
+  value?: string;
+  label?: string;
+}
+
+export interface JUnitConfig {
+}
+
+export interface ListDashboardsRequest {
+  dashboard_group?: string;
+}
+
+export interface ListDashboardsResponse {
+  dashboards?: DashboardResource[];
+}
+
+export interface ListFailingTestsRequest {
+  test_regex?: string;
+  dashboard_group?: string;
+}
+
+export interface ListFailingTestsResponse {
+  tests?: FailingTest[];
+}
+
+export interface ListTabsRequest {
+  dashboard?: string;
+}
+
+export interface ListTabsResponse {
+  tabs?: TabResource[];
+}
+
+export interface Metric {
+  name?: string;
+  indices?: number[];
+  values?: number[];
+}
+
+export interface Notification {
+  summary?: string;
+  context_link?: string;
+}
+
+export interface Ownership {
+  owners?: string[];
+  team?: string;
+  contact?: string;
+}
+
+export interface Property {
+  property?: Record<string, string>;
+}
+
+export interface PubSubConfig {
+  project?: string;
+  subscription?: string;
+  filter?: string;
+}
+
+export interface RecentHealth {
+  window?: number;
+  completed_columns?: number;
+  passing_columns?: number;
+  pass_percent?: number;
+}
+
+export interface ResultStoreConfig {
+  project?: string;
+  query?: string;
+}
+
+export interface Row {
+  name?: string;
+  id?: string;
+  results?: number[];
+  cell_ids?: string[];
+  messages?: string[];
+  metric?: string[];
+  metrics?: Metric[];
+  icons?: string[];
+  bug_id?: string[];
+  alert_info?: AlertInfo;
+  user_property?: string[];
+  properties?: Property[];
+  failure_streak?: AlertInfo;
+}
+
+export interface Rule {
+  test_result_comparisons?: TestResultComparison[];
+  computed_status?: TestStatus;
+}
+
+export interface RuleSet {
+  rules?: Rule[];
+}
+
+export interface SLOStatus {
+  target_pass_rate?: number;
+  short_window_hours?: number;
+  long_window_hours?: number;
+  short_pass_rate?: number;
+  long_pass_rate?: number;
+  short_burn_rate?: number;
+  long_burn_rate?: number;
+  violating?: boolean;
+}
+
+export interface SearchTestsRequest {
+  query?: string;
+  substring?: boolean;
+  limit?: number;
+}
+
+export interface SearchTestsResponse {
+  tests?: TestMatch[];
+  truncated?: boolean;
+  indexed?: string;
+}
+
+export interface TabRange {
+  dashboard?: string;
+  tab?: string;
+  start?: string;
+  end?: string;
+}
+
+export interface TabResource {
+  name?: string;
+  test_group_name?: string;
+  description?: string;
+  documentation_url?: string;
+}
+
+export interface TestComparison {
+  name?: string;
+  change?: TestComparison_Change;
+  base_outcome?: TestComparison_Outcome;
+  target_outcome?: TestComparison_Outcome;
+  failure_message?: string;
+}
+
+export interface TestGroup {
+  name?: string;
+  gcs_prefix?: string;
+  days_of_results?: number;
+  ignore_pending?: boolean;
+  ignore_built?: boolean;
+  tests_name_policy?: TestGroup_TestsName;
+  gather_test_properties?: boolean;
+  ignore_test_substring?: string[];
+  column_header?: TestGroup_ColumnHeader[];
+  fallback_grouping?: TestGroup_FallbackGrouping;
+  alert_stale_results_hours?: number;
+  num_failures_to_alert?: number;
+  bug_component?: number;
+  code_search_path?: string;
+  num_columns_recent?: number;
+  use_test_metadata?: boolean;
+  alert_mail_to_addresses?: string;
+  alert_mail_subject?: string;
+  alert_mail_failure_message?: string;
+  alert_mail_debug_url?: string;
+  min_elapsed_minutes_between_mails?: number;
+  use_configuration_values_as_alert_params?: boolean;
+  enable_flaky_status?: boolean;
+  use_kubernetes_client?: boolean;
+  is_external?: boolean;
+  test_name_config?: TestNameConfig;
+  notifications?: Notification[];
+  column_sort_by?: TestGroup_ColumnSortBy;
+  primary_grouping?: TestGroup_PrimaryGrouping;
+  enable_test_methods?: boolean;
+  test_annotations?: TestGroup_TestAnnotation[];
+  max_test_methods_per_test?: number;
+  commit_override_label_pattern?: string;
+  test_metadata_options?: TestMetadataOptions[];
+  test_tag_pattern?: string;
+  auto_bug_options?: AutoBugOptions;
+  max_test_runtime_hours?: number;
+  num_passes_to_disable_alert?: number;
+  link_bugs_by_group?: boolean;
+  environment_instance?: TestGroup_Environment;
+  test_method_properties?: TestGroup_KeyValue[];
+  gather_bugs?: boolean;
+  short_text_metric?: string;
+  commit_override_configuration_value?: string;
+  link_bugs_by_test_methods?: boolean;
+  test_method_match_regex?: string;
+  use_full_method_names?: boolean;
+  custom_result_evaluator_rules?: string[];
+  fallback_grouping_configuration_value?: string;
+  result_source?: TestGroup_ResultSource;
+  custom_evaluator_rule_set?: RuleSet;
+  read_state_from_storage?: boolean;
+  ignore_old_results?: boolean;
+  ignore_skip?: boolean;
+  commit_override_strftime?: string;
+  user_property?: string;
+  additional_gcs_prefixes?: string[];
+  artifact_links?: TestGroup_ArtifactLink[];
+  ignore_pass?: boolean;
+  duplicate_builds?: TestGroup_DuplicateBuilds;
+  column_retention?: TestGroup_ColumnRetention;
+  max_message_bytes?: string;
+  max_metric_names?: number;
+  keep_skipped?: boolean;
+  listing_gap_hours?: number;
+  update_timeout_minutes?: number;
+}
+
+export interface TestGroup_ArtifactLink {
+  artifact?: string;
+  path?: string;
+  property?: string;
+  url_prefix?: string;
+}
+
+export interface TestGroup_ColumnHeader {
+  label?: string;
+  property?: string;
+  configuration_value?: string;
+}
+
+export interface TestGroup_KeyValue {
+  key?: string;
+  value?: string;
+}
+
+export interface TestGroup_ResultSource {
+  junit_config?: JUnitConfig;
+  bigquery_config?: BigQueryConfig;
+  resultstore_config?: ResultStoreConfig;
+  cloud_build_config?: CloudBuildConfig;
+  azure_devops_config?: AzureDevOpsConfig;
+  pubsub_config?: PubSubConfig;
+}
+
+export interface TestGroup_TestAnnotation {
+  short_text?: string;
+  property_name?: string;
+}
+
+export interface TestHistory {
+  dashboard?: string;
+  tab?: string;
+  results?: TestResult[];
+  last_pass?: TestResult;
+}
+
+export interface TestInfo {
+  display_name?: string;
+  total_non_infra_runs?: number;
+  passed_non_infra_runs?: number;
+  failed_non_infra_runs?: number;
+  failed_infra_runs?: number;
+  total_runs_with_infra?: number;
+  other_runs?: number;
+  flakiness?: number;
+  previous_flakiness?: number[];
+  change_from_last_interval?: TestInfo_Trend;
+  infra_failures?: Record<string, number>;
+}
+
+export interface TestMatch {
+  name?: string;
+  tabs?: TestMatch_Tab[];
+}
+
+export interface TestMatch_Tab {
+  dashboard?: string;
+  tab?: string;
+}
+
+export interface TestMetadata {
+  test_name?: string;
+  bug_component?: number;
+  owner?: string;
+  cc?: string[];
+  error_type?: string;
+}
+
+export interface TestMetadataOptions {
+  test_name_regex?: string;
+  bug_component?: number;
+  owner?: string;
+  cc?: string[];
+  message_regex?: string;
+}
+
+export interface TestNameConfig {
+  name_elements?: TestNameConfig_NameElement[];
+  name_format?: string;
+}
+
+export interface TestNameConfig_NameElement {
+  labels?: string;
+  target_config?: string;
+  build_target?: boolean;
+  tags?: string;
+  test_property?: string;
+}
+
+export interface TestResult {
+  build?: string;
+  started?: string;
+  status?: TestStatus;
+  message?: string;
+  link?: string;
+}
+
+export interface TestResultComparison {
+  comparison?: Comparison;
+  property_key?: string;
+  test_result_field?: string;
+  test_result_error_field?: string;
+}
+
+export interface TriggerSummaryRequest {
+  dashboard?: string;
+}
+
+export interface TriggerSummaryResponse {
+}
+
+export interface TriggerUpdateRequest {
+  dashboard?: string;
+  tab?: string;
+}
+
+export interface TriggerUpdateResponse {
+  test_group_name?: string;
+}
+
+export interface UpdateInfo {
+  update_phase_data?: UpdatePhaseData[];
+}
+
+export interface UpdatePhaseData {
+  phase_name?: string;
+  phase_seconds?: number;
+}
+
+export interface WatchDashboardRequest {
+  dashboard?: string;
+}
+
+/** The status and message of a failed call. */
+export class TestGridError extends Error {
+  status: number;
+
+  constructor(status: number, message: string) {
+    super(`${status}: ${message}`);
+    this.status = status;
+  }
+}
+
+/** Calls the methods of a TestGrid API server through its JSON API. */
+export class TestGridClient {
+  private readonly base: string;
+  private readonly init: RequestInit;
+
+  /**
+   * Calls the server at baseUrl, such as https://testgrid.example.com, or
+   * https://testgrid.example.com/scopes/internal to call a scope.
+   *
+   * Merges init into each fetch, such as to add an Authorization header.
+   */
+  constructor(baseUrl: string, init: RequestInit = {}) {
+    this.base = baseUrl.replace(/\/+$/, "") + "/api/v1";
+    this.init = init;
+  }
+
+  /** Lists the dashboards of the configuration. */
+  async listDashboards(req: ListDashboardsRequest = {}): Promise<ListDashboardsResponse> {
+    const query = new URLSearchParams();
+    add(query, "dashboard_group", req.dashboard_group);
+    return this.call("GET", `/dashboards`, query);
+  }
+
+  /** Lists the tabs of a dashboard. */
+  async listTabs(req: ListTabsRequest): Promise<ListTabsResponse> {
+    const query = new URLSearchParams();
+    return this.call("GET", `/dashboards/${segment(req.dashboard)}/tabs`, query);
+  }
+
+  /** Returns a page of the grid backing a dashboard tab. */
+  async getTabState(req: GetTabStateRequest): Promise<GetTabStateResponse> {
+    const query = new URLSearchParams();
+    add(query, "column_offset", req.column_offset);
+    add(query, "column_limit", req.column_limit);
+    add(query, "row_offset", req.row_offset);
+    add(query, "row_limit", req.row_limit);
+    add(query, "row_regex", req.row_regex);
+    add(query, "status", req.status);
+    add(query, "fields", req.read_mask);
+    return this.call("GET", `/dashboards/${segment(req.dashboard)}/tabs/${segment(req.tab)}`, query);
+  }
+
+  /** Returns the latest summary of a dashboard. */
+  async getSummary(req: GetSummaryRequest): Promise<GetSummaryResponse> {
+    const query = new URLSearchParams();
+    return this.call("GET", `/dashboards/${segment(req.dashboard)}/summary`, query);
+  }
+
+  /** Streams an event whenever the summary or grid of a tab of the dashboard changes. */
+  watchDashboard(req: WatchDashboardRequest, onEvent: (event: DashboardEvent) => void): EventSource {
+    const source = new EventSource(this.url(`/dashboards/${segment(req.dashboard)}/events`, new URLSearchParams()));
+    for (const kind of ["UNKNOWN", "TAB_SUMMARY", "GRID"]) {
+      source.addEventListener(kind, (event) => onEvent(JSON.parse((event as MessageEvent).data) as DashboardEvent));
+    }
+    return source;
+  }
+
+  /** Lists the tests that newly fail, regressed or were fixed between two tabs or time ranges. */
+  async compareTabs(req: CompareTabsRequest): Promise<CompareTabsResponse> {
+    const query = new URLSearchParams();
+    add(query, "start", req.target?.start);
+    add(query, "end", req.target?.end);
+    add(query, "base_dashboard", req.base?.dashboard);
+    add(query, "base_tab", req.base?.tab);
+    add(query, "base_start", req.base?.start);
+    add(query, "base_end", req.base?.end);
+    return this.call("GET", `/dashboards/${segment(req.target?.dashboard)}/tabs/${segment(req.target?.tab)}/compare`, query);
+  }
+
+  /** Asks the updater to update the test group of a tab before the rest of its cycle. */
+  async triggerUpdate(req: TriggerUpdateRequest): Promise<TriggerUpdateResponse> {
+    const query = new URLSearchParams();
+    return this.call("POST", `/dashboards/${segment(req.dashboard)}/tabs/${segment(req.tab)}/update`, query);
+  }
+
+  /** Asks the summarizer to summarize a dashboard before the rest of its cycle. */
+  async triggerSummary(req: TriggerSummaryRequest): Promise<TriggerSummaryResponse> {
+    const query = new URLSearchParams();
+    return this.call("POST", `/dashboards/${segment(req.dashboard)}/summarize`, query);
+  }
+
+  /** Lists the tests failing in the latest summaries of every dashboard. */
+  async listFailingTests(req: ListFailingTestsRequest = {}): Promise<ListFailingTestsResponse> {
+    const query = new URLSearchParams();
+    add(query, "test_regex", req.test_regex);
+    add(query, "dashboard_group", req.dashboard_group);
+    return this.call("GET", `/failing_tests`, query);
+  }
+
+  /** Finds the tabs containing tests whose name matches a prefix or substring. */
+  async searchTests(req: SearchTestsRequest = {}): Promise<SearchTestsResponse> {
+    const query = new URLSearchParams();
+    add(query, "query", req.query);
+    add(query, "substring", req.substring);
+    add(query, "limit", req.limit);
+    return this.call("GET", `/tests`, query);
+  }
+
+  /** Returns the recent results of a test in every tab containing it, and when it last passed anywhere. */
+  async getTestHistory(req: GetTestHistoryRequest): Promise<GetTestHistoryResponse> {
+    const query = new URLSearchParams();
+    add(query, "limit", req.limit);
+    return this.call("GET", `/tests/${segment(req.test)}/history`, query);
+  }
+
+  private async call<T>(method: string, path: string, query: URLSearchParams): Promise<T> {
+    const resp = await fetch(this.url(path, query), {...this.init, method});
+    if (!resp.ok) {
+      throw new TestGridError(resp.status, (await resp.text()).trim());
+    }
+    return (await resp.json()) as T;
+  }
+
+  private url(path: string, query: URLSearchParams): string {
+    const q = query.toString();
+    return this.base + path + (q ? "?" + q : "");
+  }
+}
+
+function segment(value: string | undefined): string {
+  if (!value) {
+    throw new Error("missing path parameter");
+  }
+  return encodeURIComponent(value);
+}
+
+function add(query: URLSearchParams, name: string, value: unknown): void {
+  if (value === undefined || value === null || value === "") {
+    return;
+  }
+  for (const v of Array.isArray(value) ? value : [value]) {
+    query.append(name, String(v));
+  }
+}
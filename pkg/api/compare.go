@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// CompareTabs lists the tests whose outcome changed between the base and target ranges.
+func (s *Server) CompareTabs(ctx context.Context, req *apipb.CompareTabsRequest) (*apipb.CompareTabsResponse, error) {
+	if req.GetBase() == nil || req.GetTarget() == nil {
+		return nil, status.Error(codes.InvalidArgument, "base and target required")
+	}
+	for _, r := range []*apipb.TabRange{req.Base, req.Target} {
+		if r.End != nil && millis(r.Start) >= millis(r.End) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s/%s: start must be before end", r.Dashboard, r.Tab)
+		}
+	}
+	cfg, version := s.config(ctx)
+	base, baseGen, err := s.tabGrid(ctx, cfg, req.Base.Dashboard, req.Base.Tab)
+	if err != nil {
+		return nil, err
+	}
+	target, targetGen, err := s.tabGrid(ctx, cfg, req.Target.Dashboard, req.Target.Tab)
+	if err != nil {
+		return nil, err
+	}
+	resp := compareGrids(base, target, req.Base, req.Target)
+	setETag(ctx, version, baseGen, targetGen)
+	return resp, nil
+}
+
+// millis returns the time in milliseconds, matching the started time of grid columns.
+func millis(t *timestamp.Timestamp) float64 {
+	return float64(t.GetSeconds())*1000 + float64(t.GetNanos())/1e6
+}
+
+// inRange returns which columns of the grid started within the range.
+func inRange(cols []*statepb.Column, r *apipb.TabRange) ([]bool, int) {
+	start, end := millis(r.Start), millis(r.End)
+	include := make([]bool, len(cols))
+	var n int
+	for i, col := range cols {
+		if col.Started < start || r.End != nil && col.Started >= end {
+			continue
+		}
+		include[i] = true
+		n++
+	}
+	return include, n
+}
+
+// compareGrids compares the outcome of each test in the target range of the target grid with the base range of the base grid.
+//
+// Matches tests by row name, ignoring those missing from the target.
+func compareGrids(base, target *statepb.Grid, baseRange, targetRange *apipb.TabRange) *apipb.CompareTabsResponse {
+	baseCols, baseN := inRange(base.Columns, baseRange)
+	targetCols, targetN := inRange(target.Columns, targetRange)
+	baseOutcomes := map[string]apipb.TestComparison_Outcome{}
+	for _, row := range base.Rows {
+		baseOutcomes[row.Name], _ = rowOutcome(row, baseCols)
+	}
+	resp := apipb.CompareTabsResponse{
+		BaseColumns:   int32(baseN),
+		TargetColumns: int32(targetN),
+	}
+	for _, row := range target.Rows {
+		after, msg := rowOutcome(row, targetCols)
+		before := baseOutcomes[row.Name]
+		change := outcomeChange(before, after)
+		if change == apipb.TestComparison_UNKNOWN {
+			continue
+		}
+		resp.Tests = append(resp.Tests, &apipb.TestComparison{
+			Name:           row.Name,
+			Change:         change,
+			BaseOutcome:    before,
+			TargetOutcome:  after,
+			FailureMessage: msg,
+		})
+	}
+	sort.SliceStable(resp.Tests, func(i, j int) bool {
+		a, b := resp.Tests[i], resp.Tests[j]
+		if a.Change != b.Change {
+			return a.Change < b.Change
+		}
+		return a.Name < b.Name
+	})
+	return &resp
+}
+
+// rowOutcome returns the outcome of the row over the included columns, along with the message of its most recent failure.
+func rowOutcome(row *statepb.Row, include []bool) (apipb.TestComparison_Outcome, string) {
+	var passed, failed, flaked bool
+	var msg string
+	var col, filled int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		res, n := statuspb.TestStatus(row.Results[i]), int(row.Results[i+1])
+		for ; n > 0; n-- {
+			if col < len(include) && include[col] {
+				switch {
+				case res == statuspb.TestStatus_FLAKY:
+					flaked = true
+				case result.IsPassingResult(res):
+					passed = true
+				case result.IsFailingResult(res):
+					// Columns are sorted from newest to oldest.
+					if !failed && filled < len(row.Messages) {
+						msg = row.Messages[filled]
+					}
+					failed = true
+				}
+			}
+			if res != statuspb.TestStatus_NO_RESULT {
+				filled++ // Messages skip empty cells.
+			}
+			col++
+		}
+	}
+	switch {
+	case flaked || passed && failed:
+		return apipb.TestComparison_FLAKY, msg
+	case failed:
+		return apipb.TestComparison_FAILING, msg
+	case passed:
+		return apipb.TestComparison_PASSING, ""
+	}
+	return apipb.TestComparison_ABSENT, ""
+}
+
+// outcomeChange returns how the outcome changed from before to after, or UNKNOWN when it did not get better or worse.
+func outcomeChange(before, after apipb.TestComparison_Outcome) apipb.TestComparison_Change {
+	switch {
+	case after == apipb.TestComparison_FAILING && before < apipb.TestComparison_FLAKY:
+		return apipb.TestComparison_NEWLY_FAILING
+	case after > before && after >= apipb.TestComparison_FLAKY:
+		return apipb.TestComparison_REGRESSED
+	case after == apipb.TestComparison_PASSING && before >= apipb.TestComparison_FLAKY:
+		return apipb.TestComparison_FIXED
+	}
+	return apipb.TestComparison_UNKNOWN
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestRowOutcome(t *testing.T) {
+	cases := []struct {
+		name     string
+		row      *statepb.Row
+		include  []bool
+		expected apipb.TestComparison_Outcome
+		msg      string
+	}{
+		{
+			name:    "empty",
+			row:     &statepb.Row{Results: []int32{0, 2}},
+			include: []bool{true, true},
+		},
+		{
+			name:     "passing",
+			row:      &statepb.Row{Results: []int32{1, 1, 3, 1}},
+			include:  []bool{true, true},
+			expected: apipb.TestComparison_PASSING,
+		},
+		{
+			name:     "failing",
+			row:      &statepb.Row{Results: []int32{0, 1, 12, 1, 9, 1}, Messages: []string{"newest", "oldest"}},
+			include:  []bool{true, true, true},
+			expected: apipb.TestComparison_FAILING,
+			msg:      "newest",
+		},
+		{
+			name:     "passed and failed",
+			row:      &statepb.Row{Results: []int32{1, 1, 12, 1}, Messages: []string{"", "boom"}},
+			include:  []bool{true, true},
+			expected: apipb.TestComparison_FLAKY,
+			msg:      "boom",
+		},
+		{
+			name:     "flaked",
+			row:      &statepb.Row{Results: []int32{13, 1, 1, 1}},
+			include:  []bool{true, true},
+			expected: apipb.TestComparison_FLAKY,
+		},
+		{
+			name:     "ignore excluded columns",
+			row:      &statepb.Row{Results: []int32{12, 1, 0, 1, 1, 1}, Messages: []string{"boom", ""}},
+			include:  []bool{false, true, true},
+			expected: apipb.TestComparison_PASSING,
+		},
+		{
+			name:    "ignore other results",
+			row:     &statepb.Row{Results: []int32{4, 1, 7, 1}},
+			include: []bool{true, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, msg := rowOutcome(tc.row, tc.include)
+			if got != tc.expected || msg != tc.msg {
+				t.Errorf("rowOutcome() got %s, %q, want %s, %q", got, msg, tc.expected, tc.msg)
+			}
+		})
+	}
+}
+
+func TestOutcomeChange(t *testing.T) {
+	const (
+		absent  = apipb.TestComparison_ABSENT
+		passing = apipb.TestComparison_PASSING
+		flaky   = apipb.TestComparison_FLAKY
+		failing = apipb.TestComparison_FAILING
+	)
+	cases := []struct {
+		before   apipb.TestComparison_Outcome
+		after    apipb.TestComparison_Outcome
+		expected apipb.TestComparison_Change
+	}{
+		{absent, absent, apipb.TestComparison_UNKNOWN},
+		{absent, passing, apipb.TestComparison_UNKNOWN},
+		{absent, flaky, apipb.TestComparison_REGRESSED},
+		{absent, failing, apipb.TestComparison_NEWLY_FAILING},
+		{passing, absent, apipb.TestComparison_UNKNOWN},
+		{passing, passing, apipb.TestComparison_UNKNOWN},
+		{passing, flaky, apipb.TestComparison_REGRESSED},
+		{passing, failing, apipb.TestComparison_NEWLY_FAILING},
+		{flaky, absent, apipb.TestComparison_UNKNOWN},
+		{flaky, passing, apipb.TestComparison_FIXED},
+		{flaky, flaky, apipb.TestComparison_UNKNOWN},
+		{flaky, failing, apipb.TestComparison_REGRESSED},
+		{failing, absent, apipb.TestComparison_UNKNOWN},
+		{failing, passing, apipb.TestComparison_FIXED},
+		{failing, flaky, apipb.TestComparison_UNKNOWN},
+		{failing, failing, apipb.TestComparison_UNKNOWN},
+	}
+
+	for _, tc := range cases {
+		if got := outcomeChange(tc.before, tc.after); got != tc.expected {
+			t.Errorf("outcomeChange(%s, %s) got %s, want %s", tc.before, tc.after, got, tc.expected)
+		}
+	}
+}
+
+func TestCompareTabs(t *testing.T) {
+	objects := fakeObjects{}
+	// Columns started at 4, 3, 2 and 1 seconds, comparing the first two with the last two.
+	objects.putGrid(t, "gs://bucket/grid/group", &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "4", Started: 4000}, {Build: "3", Started: 3000}, {Build: "2", Started: 2000}, {Build: "1", Started: 1000}},
+		Rows: []*statepb.Row{
+			{Name: "broken", Results: []int32{12, 2, 1, 2}, Messages: []string{"boom", "bang", "", ""}},
+			{Name: "fixed", Results: []int32{1, 2, 12, 2}, Messages: []string{"", "", "boom", "bang"}},
+			{Name: "flaky", Results: []int32{13, 1, 1, 3}},
+			{Name: "new", Results: []int32{12, 1, 0, 3}, Messages: []string{"boom"}},
+			{Name: "same", Results: []int32{1, 4}},
+		},
+	})
+	s := testServer(t, objects)
+	ts := func(seconds int64) *timestamp.Timestamp {
+		return &timestamp.Timestamp{Seconds: seconds}
+	}
+
+	cases := []struct {
+		name     string
+		req      *apipb.CompareTabsRequest
+		expected *apipb.CompareTabsResponse
+		code     codes.Code
+	}{
+		{
+			name: "basic",
+			req: &apipb.CompareTabsRequest{
+				Base:   &apipb.TabRange{Dashboard: "second", Tab: "tab", End: ts(3)},
+				Target: &apipb.TabRange{Dashboard: "second", Tab: "tab", Start: ts(3)},
+			},
+			expected: &apipb.CompareTabsResponse{
+				Tests: []*apipb.TestComparison{
+					{
+						Name:           "broken",
+						Change:         apipb.TestComparison_NEWLY_FAILING,
+						BaseOutcome:    apipb.TestComparison_PASSING,
+						TargetOutcome:  apipb.TestComparison_FAILING,
+						FailureMessage: "boom",
+					},
+					{
+						Name:           "new",
+						Change:         apipb.TestComparison_NEWLY_FAILING,
+						BaseOutcome:    apipb.TestComparison_ABSENT,
+						TargetOutcome:  apipb.TestComparison_FAILING,
+						FailureMessage: "boom",
+					},
+					{
+						Name:          "flaky",
+						Change:        apipb.TestComparison_REGRESSED,
+						BaseOutcome:   apipb.TestComparison_PASSING,
+						TargetOutcome: apipb.TestComparison_FLAKY,
+					},
+					{
+						Name:          "fixed",
+						Change:        apipb.TestComparison_FIXED,
+						BaseOutcome:   apipb.TestComparison_FAILING,
+						TargetOutcome: apipb.TestComparison_PASSING,
+					},
+				},
+				BaseColumns:   2,
+				TargetColumns: 2,
+			},
+		},
+		{
+			name: "same range",
+			req: &apipb.CompareTabsRequest{
+				Base:   &apipb.TabRange{Dashboard: "second", Tab: "tab"},
+				Target: &apipb.TabRange{Dashboard: "second", Tab: "tab"},
+			},
+			expected: &apipb.CompareTabsResponse{
+				BaseColumns:   4,
+				TargetColumns: 4,
+			},
+		},
+		{
+			name: "missing base",
+			req: &apipb.CompareTabsRequest{
+				Target: &apipb.TabRange{Dashboard: "second", Tab: "tab"},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "empty range",
+			req: &apipb.CompareTabsRequest{
+				Base:   &apipb.TabRange{Dashboard: "second", Tab: "tab", Start: ts(3), End: ts(3)},
+				Target: &apipb.TabRange{Dashboard: "second", Tab: "tab"},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "missing tab state",
+			req: &apipb.CompareTabsRequest{
+				Base:   &apipb.TabRange{Dashboard: "second", Tab: "tab"},
+				Target: &apipb.TabRange{Dashboard: "second", Tab: "missing"},
+			},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.CompareTabs(context.Background(), tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("CompareTabs() got error %v, want code %s", err, tc.code)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("CompareTabs() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+)
+
+// Endpoint describes how Handler serves a method of the TestGridData service.
+type Endpoint struct {
+	// Method is the name of the gRPC method, such as ListTabs.
+	Method string
+	// Summary describes the method.
+	Summary string
+	// HTTPMethod is the HTTP method of requests, such as GET.
+	HTTPMethod string
+	// Path follows PathPrefix, naming path parameters in braces, such as /dashboards/{dashboard}/tabs.
+	Path string
+	// PathParams fill the fields of the request from the parameters in the path.
+	PathParams []Param
+	// Query fills the fields of the request from query parameters.
+	Query []Param
+	// Request and Response are empty messages of the types the method accepts and returns.
+	Request, Response proto.Message
+	// Stream is true when the endpoint streams each response as a server-sent event named by its kind.
+	Stream bool
+}
+
+// Param maps a path or query parameter to a field of the request.
+type Param struct {
+	// Name of the parameter.
+	Name string
+	// Field is the dot-separated path of proto field names from the request to the field, such as base.tab.
+	Field string
+}
+
+// Endpoints lists every endpoint Handler serves, in the order of the service methods.
+var Endpoints = []Endpoint{
+	{
+		Method:     "ListDashboards",
+		Summary:    "Lists the dashboards of the configuration.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/dashboards",
+		Query:      []Param{{"dashboard_group", "dashboard_group"}},
+		Request:    &apipb.ListDashboardsRequest{},
+		Response:   &apipb.ListDashboardsResponse{},
+	},
+	{
+		Method:     "ListTabs",
+		Summary:    "Lists the tabs of a dashboard.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/dashboards/{dashboard}/tabs",
+		PathParams: []Param{{"dashboard", "dashboard"}},
+		Request:    &apipb.ListTabsRequest{},
+		Response:   &apipb.ListTabsResponse{},
+	},
+	{
+		Method:     "GetTabState",
+		Summary:    "Returns a page of the grid backing a dashboard tab.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/dashboards/{dashboard}/tabs/{tab}",
+		PathParams: []Param{{"dashboard", "dashboard"}, {"tab", "tab"}},
+		Query: []Param{
+			{"column_offset", "column_offset"},
+			{"column_limit", "column_limit"},
+			{"row_offset", "row_offset"},
+			{"row_limit", "row_limit"},
+			{"row_regex", "row_regex"},
+			{"status", "status"},
+			{"fields", "read_mask"},
+		},
+		Request:  &apipb.GetTabStateRequest{},
+		Response: &apipb.GetTabStateResponse{},
+	},
+	{
+		Method:     "GetSummary",
+		Summary:    "Returns the latest summary of a dashboard.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/dashboards/{dashboard}/summary",
+		PathParams: []Param{{"dashboard", "dashboard"}},
+		Request:    &apipb.GetSummaryRequest{},
+		Response:   &apipb.GetSummaryResponse{},
+	},
+	{
+		Method:     "WatchDashboard",
+		Summary:    "Streams an event whenever the summary or grid of a tab of the dashboard changes.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/dashboards/{dashboard}/events",
+		PathParams: []Param{{"dashboard", "dashboard"}},
+		Request:    &apipb.WatchDashboardRequest{},
+		Response:   &apipb.DashboardEvent{},
+		Stream:     true,
+	},
+	{
+		Method:     "CompareTabs",
+		Summary:    "Lists the tests that newly fail, regressed or were fixed between two tabs or time ranges.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/dashboards/{dashboard}/tabs/{tab}/compare",
+		PathParams: []Param{{"dashboard", "target.dashboard"}, {"tab", "target.tab"}},
+		Query: []Param{
+			{"start", "target.start"},
+			{"end", "target.end"},
+			{"base_dashboard", "base.dashboard"},
+			{"base_tab", "base.tab"},
+			{"base_start", "base.start"},
+			{"base_end", "base.end"},
+		},
+		Request:  &apipb.CompareTabsRequest{},
+		Response: &apipb.CompareTabsResponse{},
+	},
+	{
+		Method:     "TriggerUpdate",
+		Summary:    "Asks the updater to update the test group of a tab before the rest of its cycle.",
+		HTTPMethod: http.MethodPost,
+		Path:       "/dashboards/{dashboard}/tabs/{tab}/update",
+		PathParams: []Param{{"dashboard", "dashboard"}, {"tab", "tab"}},
+		Request:    &apipb.TriggerUpdateRequest{},
+		Response:   &apipb.TriggerUpdateResponse{},
+	},
+	{
+		Method:     "TriggerSummary",
+		Summary:    "Asks the summarizer to summarize a dashboard before the rest of its cycle.",
+		HTTPMethod: http.MethodPost,
+		Path:       "/dashboards/{dashboard}/summarize",
+		PathParams: []Param{{"dashboard", "dashboard"}},
+		Request:    &apipb.TriggerSummaryRequest{},
+		Response:   &apipb.TriggerSummaryResponse{},
+	},
+	{
+		Method:     "ListFailingTests",
+		Summary:    "Lists the tests failing in the latest summaries of every dashboard.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/failing_tests",
+		Query:      []Param{{"test_regex", "test_regex"}, {"dashboard_group", "dashboard_group"}},
+		Request:    &apipb.ListFailingTestsRequest{},
+		Response:   &apipb.ListFailingTestsResponse{},
+	},
+	{
+		Method:     "SearchTests",
+		Summary:    "Finds the tabs containing tests whose name matches a prefix or substring.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/tests",
+		Query:      []Param{{"query", "query"}, {"substring", "substring"}, {"limit", "limit"}},
+		Request:    &apipb.SearchTestsRequest{},
+		Response:   &apipb.SearchTestsResponse{},
+	},
+	{
+		Method:     "GetTestHistory",
+		Summary:    "Returns the recent results of a test in every tab containing it, and when it last passed anywhere.",
+		HTTPMethod: http.MethodGet,
+		Path:       "/tests/{test}/history",
+		PathParams: []Param{{"test", "test"}},
+		Query:      []Param{{"limit", "limit"}},
+		Request:    &apipb.GetTestHistoryRequest{},
+		Response:   &apipb.GetTestHistoryResponse{},
+	},
+}
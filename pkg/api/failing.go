@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+)
+
+// ListFailingTests lists the tests failing in the latest summary of each dashboard, along with the tabs in which they fail.
+//
+// Skips dashboards without a summary.
+func (s *Server) ListFailingTests(ctx context.Context, req *apipb.ListFailingTestsRequest) (*apipb.ListFailingTestsResponse, error) {
+	re, err := regexp.Compile(req.GetTestRegex())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad test_regex: %v", err)
+	}
+	cfg, version := s.config(ctx)
+	only, err := groupDashboards(cfg, req.GetDashboardGroup())
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []int64{version}
+	tests := map[string]*apipb.FailingTest{}
+	for _, dash := range cfg.GetDashboards() {
+		if only != nil && !only[dash.Name] {
+			continue
+		}
+		sum, gen, err := s.dashboardSummary(ctx, dash.Name)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, gen)
+		for _, tab := range sum.GetTabSummaries() {
+			for _, f := range tab.FailingTestSummaries {
+				if !re.MatchString(f.TestName) {
+					continue
+				}
+				test, ok := tests[f.TestName]
+				if !ok {
+					test = &apipb.FailingTest{Name: f.TestName}
+					tests[f.TestName] = test
+				}
+				test.Tabs = append(test.Tabs, &apipb.FailingTest_Tab{
+					Dashboard: dash.Name,
+					Tab:       tab.DashboardTabName,
+					Failure:   f,
+				})
+			}
+		}
+	}
+
+	var resp apipb.ListFailingTestsResponse
+	for _, test := range tests {
+		sort.SliceStable(test.Tabs, func(i, j int) bool {
+			a, b := test.Tabs[i], test.Tabs[j]
+			if a.Dashboard != b.Dashboard {
+				return a.Dashboard < b.Dashboard
+			}
+			return a.Tab < b.Tab
+		})
+		resp.Tests = append(resp.Tests, test)
+	}
+	sortFailingTests(resp.Tests)
+	setETag(ctx, versions...)
+	return &resp, nil
+}
+
+// sortFailingTests sorts the tests failing in the most tabs first, then by name.
+func sortFailingTests(tests []*apipb.FailingTest) {
+	sort.Slice(tests, func(i, j int) bool {
+		a, b := tests[i], tests[j]
+		if len(a.Tabs) != len(b.Tabs) {
+			return len(a.Tabs) > len(b.Tabs)
+		}
+		return a.Name < b.Name
+	})
+}
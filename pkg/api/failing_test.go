@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestListFailingTests(t *testing.T) {
+	widespread := &summarypb.FailingTestSummary{TestName: "//pkg:widespread", FailCount: 3}
+	isolated := &summarypb.FailingTestSummary{TestName: "//pkg:isolated", FailCount: 1}
+	other := &summarypb.FailingTestSummary{TestName: "//other:test", FailCount: 2}
+	first := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardName:        "first",
+				DashboardTabName:     "unit",
+				FailingTestSummaries: []*summarypb.FailingTestSummary{widespread, other},
+			},
+		},
+	}
+	second := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardName:        "second",
+				DashboardTabName:     "tab",
+				FailingTestSummaries: []*summarypb.FailingTestSummary{isolated, widespread},
+			},
+			{
+				DashboardName:        "second",
+				DashboardTabName:     "missing",
+				FailingTestSummaries: []*summarypb.FailingTestSummary{widespread},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		req      *apipb.ListFailingTestsRequest
+		expected *apipb.ListFailingTestsResponse
+		code     codes.Code
+	}{
+		{
+			name: "all tests",
+			req:  &apipb.ListFailingTestsRequest{},
+			expected: &apipb.ListFailingTestsResponse{
+				Tests: []*apipb.FailingTest{
+					{
+						Name: "//pkg:widespread",
+						Tabs: []*apipb.FailingTest_Tab{
+							{Dashboard: "first", Tab: "unit", Failure: widespread},
+							{Dashboard: "second", Tab: "missing", Failure: widespread},
+							{Dashboard: "second", Tab: "tab", Failure: widespread},
+						},
+					},
+					{
+						Name: "//other:test",
+						Tabs: []*apipb.FailingTest_Tab{{Dashboard: "first", Tab: "unit", Failure: other}},
+					},
+					{
+						Name: "//pkg:isolated",
+						Tabs: []*apipb.FailingTest_Tab{{Dashboard: "second", Tab: "tab", Failure: isolated}},
+					},
+				},
+			},
+		},
+		{
+			name: "matching tests",
+			req:  &apipb.ListFailingTestsRequest{TestRegex: "^//pkg:"},
+			expected: &apipb.ListFailingTestsResponse{
+				Tests: []*apipb.FailingTest{
+					{
+						Name: "//pkg:widespread",
+						Tabs: []*apipb.FailingTest_Tab{
+							{Dashboard: "first", Tab: "unit", Failure: widespread},
+							{Dashboard: "second", Tab: "missing", Failure: widespread},
+							{Dashboard: "second", Tab: "tab", Failure: widespread},
+						},
+					},
+					{
+						Name: "//pkg:isolated",
+						Tabs: []*apipb.FailingTest_Tab{{Dashboard: "second", Tab: "tab", Failure: isolated}},
+					},
+				},
+			},
+		},
+		{
+			name: "dashboard group",
+			req:  &apipb.ListFailingTestsRequest{TestRegex: "widespread", DashboardGroup: "dashboard-group"},
+			expected: &apipb.ListFailingTestsResponse{
+				Tests: []*apipb.FailingTest{
+					{
+						Name: "//pkg:widespread",
+						Tabs: []*apipb.FailingTest_Tab{{Dashboard: "first", Tab: "unit", Failure: widespread}},
+					},
+				},
+			},
+		},
+		{
+			name:     "no matches",
+			req:      &apipb.ListFailingTestsRequest{TestRegex: "nope"},
+			expected: &apipb.ListFailingTestsResponse{},
+		},
+		{
+			name: "bad regex",
+			req:  &apipb.ListFailingTestsRequest{TestRegex: "("},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "missing group",
+			req:  &apipb.ListFailingTestsRequest{DashboardGroup: "nope"},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := fakeObjects{}
+			objects.put(t, "gs://bucket/summary/summary-first", first)
+			objects.put(t, "gs://bucket/summary/summary-second", second)
+			s := testServer(t, objects)
+			got, err := s.ListFailingTests(context.Background(), tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("ListFailingTests() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ListFailingTests() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
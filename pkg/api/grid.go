@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// window returns the start and end of the items from offset, keeping at most limit items unless limit is zero.
+func window(n, offset, limit int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if limit > 0 && offset+limit < n {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+// FilterGrid reduces the grid to the requested page of columns and rows matching the request.
+//
+// Drops failure clusters when removing columns, as their indices refer to the full grid.
+// Returns the number of matching rows before paging through them.
+func FilterGrid(grid *statepb.Grid, req *apipb.GetTabStateRequest) (int, error) {
+	if re := req.GetRowRegex(); re != "" {
+		rowRegex, err := regexp.Compile(re)
+		if err != nil {
+			return 0, fmt.Errorf("bad row_regex: %w", err)
+		}
+		var rows []*statepb.Row
+		for _, row := range grid.Rows {
+			if rowRegex.MatchString(row.Name) {
+				rows = append(rows, row)
+			}
+		}
+		grid.Rows = rows
+	}
+
+	start, end := window(len(grid.Columns), int(req.GetColumnOffset()), int(req.GetColumnLimit()))
+	if start > 0 || end < len(grid.Columns) {
+		grid.Columns = grid.Columns[start:end]
+		grid.Cluster = nil
+		for _, row := range grid.Rows {
+			sliceRow(row, start, end)
+		}
+	}
+
+	if statuses := req.GetStatus(); len(statuses) > 0 {
+		var rows []*statepb.Row
+		for _, row := range grid.Rows {
+			if hasStatus(row, statuses) {
+				rows = append(rows, row)
+			}
+		}
+		grid.Rows = rows
+	}
+
+	total := len(grid.Rows)
+	start, end = window(total, int(req.GetRowOffset()), int(req.GetRowLimit()))
+	grid.Rows = grid.Rows[start:end]
+	return total, nil
+}
+
+// hasStatus returns true when any result of the row has one of the statuses.
+func hasStatus(row *statepb.Row, statuses []statuspb.TestStatus) bool {
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		if row.Results[i+1] == 0 {
+			continue
+		}
+		for _, s := range statuses {
+			if row.Results[i] == int32(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sliceRow reduces the row to the cells of the columns from start to end.
+func sliceRow(row *statepb.Row, start, end int) {
+	var results []int32
+	var col, skipped, filled int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		val, n := row.Results[i], int(row.Results[i+1])
+		lo, hi := col, col+n
+		col = hi
+		kept := overlap(lo, hi, start, end)
+		if val != int32(statuspb.TestStatus_NO_RESULT) {
+			// Icons and messages skip empty cells.
+			skipped += overlap(lo, hi, 0, start)
+			filled += kept
+		}
+		if kept > 0 {
+			results = append(results, val, int32(kept))
+		}
+	}
+	row.Results = results
+	row.CellIds = sliceStrings(row.CellIds, start, end)
+	row.UserProperty = sliceStrings(row.UserProperty, start, end)
+	row.Messages = sliceStrings(row.Messages, skipped, skipped+filled)
+	row.Icons = sliceStrings(row.Icons, skipped, skipped+filled)
+	row.Properties = sliceProperties(row.Properties, skipped, skipped+filled)
+	for _, m := range row.Metrics {
+		sliceMetric(m, start, end)
+	}
+}
+
+// sliceMetric reduces the sparse values of the metric to those of the columns from start to end.
+func sliceMetric(metric *statepb.Metric, start, end int) {
+	var indices []int32
+	var values []float64
+	var valueIdx int
+	for i := 0; i+1 < len(metric.Indices); i += 2 {
+		first, n := int(metric.Indices[i]), int(metric.Indices[i+1])
+		lo, hi := first, first+n
+		if lo < start {
+			lo = start
+		}
+		if hi > end {
+			hi = end
+		}
+		if lo < hi {
+			indices = append(indices, int32(lo-start), int32(hi-lo))
+			values = append(values, metric.Values[valueIdx+lo-first:valueIdx+hi-first]...)
+		}
+		valueIdx += n
+	}
+	metric.Indices = indices
+	metric.Values = values
+}
+
+// overlap returns how many items the range from lo to hi shares with the range from start to end.
+func overlap(lo, hi, start, end int) int {
+	if lo < start {
+		lo = start
+	}
+	if hi > end {
+		hi = end
+	}
+	if lo >= hi {
+		return 0
+	}
+	return hi - lo
+}
+
+func sliceStrings(items []string, start, end int) []string {
+	if end > len(items) {
+		end = len(items)
+	}
+	if start >= end {
+		return nil
+	}
+	return items[start:end]
+}
+
+func sliceProperties(items []*statepb.Property, start, end int) []*statepb.Property {
+	if end > len(items) {
+		end = len(items)
+	}
+	if start >= end {
+		return nil
+	}
+	return items[start:end]
+}
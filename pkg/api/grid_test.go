@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestFilterGrid(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := func() *statepb.Grid {
+		return &statepb.Grid{
+			Columns: []*statepb.Column{{Build: "4"}, {Build: "3"}, {Build: "2"}, {Build: "1"}},
+			Rows: []*statepb.Row{
+				{
+					Name:     "a",
+					Results:  []int32{pass, 1, empty, 2, fail, 1},
+					CellIds:  []string{"a4", "a3", "a2", "a1"},
+					Messages: []string{"pass", "fail"},
+					Icons:    []string{"P", "F"},
+					Properties: []*statepb.Property{
+						{},
+						{Property: map[string]string{"log": "a1"}},
+					},
+					Metric: []string{"elapsed"},
+					Metrics: []*statepb.Metric{
+						{
+							Name:    "elapsed",
+							Indices: []int32{0, 1, 3, 1},
+							Values:  []float64{4, 1},
+						},
+					},
+				},
+				{
+					Name:     "b",
+					Results:  []int32{fail, 4},
+					CellIds:  []string{"b4", "b3", "b2", "b1"},
+					Messages: []string{"4", "3", "2", "1"},
+					Icons:    []string{"F4", "F3", "F2", "F1"},
+				},
+			},
+			Cluster: []*statepb.Cluster{{Message: "cluster"}},
+		}
+	}
+	cases := []struct {
+		name     string
+		req      *apipb.GetTabStateRequest
+		expected func() *statepb.Grid
+		total    int
+		err      bool
+	}{
+		{
+			name:     "full grid",
+			req:      &apipb.GetTabStateRequest{},
+			expected: grid,
+			total:    2,
+		},
+		{
+			name:  "limit rows",
+			req:   &apipb.GetTabStateRequest{RowLimit: 1},
+			total: 2,
+			expected: func() *statepb.Grid {
+				g := grid()
+				g.Rows = g.Rows[:1]
+				return g
+			},
+		},
+		{
+			name:  "offset past rows",
+			req:   &apipb.GetTabStateRequest{RowOffset: 3},
+			total: 2,
+			expected: func() *statepb.Grid {
+				g := grid()
+				g.Rows = g.Rows[:0]
+				return g
+			},
+		},
+		{
+			name:  "limit columns",
+			req:   &apipb.GetTabStateRequest{ColumnLimit: 1},
+			total: 2,
+			expected: func() *statepb.Grid {
+				return &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "4"}},
+					Rows: []*statepb.Row{
+						{
+							Name:       "a",
+							Results:    []int32{pass, 1},
+							CellIds:    []string{"a4"},
+							Messages:   []string{"pass"},
+							Icons:      []string{"P"},
+							Properties: []*statepb.Property{{}},
+							Metric:     []string{"elapsed"},
+							Metrics: []*statepb.Metric{
+								{
+									Name:    "elapsed",
+									Indices: []int32{0, 1},
+									Values:  []float64{4},
+								},
+							},
+						},
+						{
+							Name:     "b",
+							Results:  []int32{fail, 1},
+							CellIds:  []string{"b4"},
+							Messages: []string{"4"},
+							Icons:    []string{"F4"},
+						},
+					},
+				}
+			},
+		},
+		{
+			name:  "middle columns",
+			req:   &apipb.GetTabStateRequest{ColumnOffset: 1, ColumnLimit: 2, RowOffset: 1},
+			total: 2,
+			expected: func() *statepb.Grid {
+				return &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}},
+					Rows: []*statepb.Row{
+						{
+							Name:     "b",
+							Results:  []int32{fail, 2},
+							CellIds:  []string{"b3", "b2"},
+							Messages: []string{"3", "2"},
+							Icons:    []string{"F3", "F2"},
+						},
+					},
+				}
+			},
+		},
+		{
+			name:  "skip empty cells",
+			req:   &apipb.GetTabStateRequest{ColumnOffset: 2, RowLimit: 1},
+			total: 2,
+			expected: func() *statepb.Grid {
+				return &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+					Rows: []*statepb.Row{
+						{
+							Name:     "a",
+							Results:  []int32{empty, 1, fail, 1},
+							CellIds:  []string{"a2", "a1"},
+							Messages: []string{"fail"},
+							Icons:    []string{"F"},
+							Properties: []*statepb.Property{
+								{Property: map[string]string{"log": "a1"}},
+							},
+							Metric: []string{"elapsed"},
+							Metrics: []*statepb.Metric{
+								{
+									Name:    "elapsed",
+									Indices: []int32{1, 1},
+									Values:  []float64{1},
+								},
+							},
+						},
+					},
+				}
+			},
+		},
+		{
+			name:  "row regex",
+			req:   &apipb.GetTabStateRequest{RowRegex: "^b"},
+			total: 1,
+			expected: func() *statepb.Grid {
+				g := grid()
+				g.Rows = g.Rows[1:]
+				return g
+			},
+		},
+		{
+			name: "bad row regex",
+			req:  &apipb.GetTabStateRequest{RowRegex: "("},
+			err:  true,
+		},
+		{
+			name:  "status in columns",
+			req:   &apipb.GetTabStateRequest{Status: []statuspb.TestStatus{statuspb.TestStatus_PASS}},
+			total: 1,
+			expected: func() *statepb.Grid {
+				g := grid()
+				g.Rows = g.Rows[:1]
+				return g
+			},
+		},
+		{
+			name:  "status outside columns",
+			req:   &apipb.GetTabStateRequest{ColumnOffset: 1, ColumnLimit: 2, Status: []statuspb.TestStatus{statuspb.TestStatus_PASS}},
+			total: 0,
+			expected: func() *statepb.Grid {
+				return &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}},
+				}
+			},
+		},
+		{
+			name:  "page filtered rows",
+			req:   &apipb.GetTabStateRequest{RowOffset: 1, Status: []statuspb.TestStatus{statuspb.TestStatus_FAIL}},
+			total: 2,
+			expected: func() *statepb.Grid {
+				g := grid()
+				g.Rows = g.Rows[1:]
+				return g
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := grid()
+			total, err := FilterGrid(got, tc.req)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("FilterGrid() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("FilterGrid() failed to return an error")
+			}
+			if total != tc.total {
+				t.Errorf("FilterGrid() got total %d, want %d", total, tc.total)
+			}
+			if diff := cmp.Diff(tc.expected(), got, protocmp.Transform()); diff != "" {
+				t.Errorf("FilterGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
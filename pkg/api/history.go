@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// defaultHistoryLimit is the number of results of each tab GetTestHistory returns when the request sets no limit.
+const defaultHistoryLimit = 50
+
+// tabs returns the tabs containing the test with exactly this name.
+func (ti *testIndex) tabs(name string) []*apipb.TestMatch_Tab {
+	key := strings.ToLower(name)
+	for i := sort.SearchStrings(ti.keys, key); i < len(ti.keys) && ti.keys[i] == key; i++ {
+		if ti.tests[i].Name == name {
+			return ti.tests[i].Tabs
+		}
+	}
+	return nil
+}
+
+// GetTestHistory returns the recent results of the test in each tab the index says contains it.
+//
+// Skips tabs removed from the config or without a grid since the index was built.
+func (s *Server) GetTestHistory(ctx context.Context, req *apipb.GetTestHistoryRequest) (*apipb.GetTestHistoryResponse, error) {
+	limit := int(req.GetLimit())
+	switch {
+	case req.GetTest() == "":
+		return nil, status.Error(codes.InvalidArgument, "test required")
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	case limit == 0:
+		limit = defaultHistoryLimit
+	}
+	s.indexLock.RLock()
+	index := s.index
+	s.indexLock.RUnlock()
+	if index == nil {
+		return nil, status.Error(codes.Unavailable, "test index is not ready")
+	}
+
+	cfg, version := s.config(ctx)
+	versions := []int64{version}
+	var resp apipb.GetTestHistoryResponse
+	for _, t := range index.tabs(req.Test) {
+		dash := config.FindDashboard(t.Dashboard, cfg)
+		if dash == nil {
+			continue
+		}
+		tab, err := findTab(dash, t.Tab)
+		if err != nil {
+			continue
+		}
+		grid, gen, err := s.tabGrid(ctx, cfg, dash.Name, tab.Name)
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, gen)
+		group := config.FindTestGroup(tab.TestGroupName, cfg)
+		for _, row := range grid.Rows {
+			if row.Name != req.Test {
+				continue
+			}
+			hist := testHistory(grid.Columns, row, limit, func(build string) string {
+				return config.ExpandLink(tab.OpenTestTemplate, map[string]string{
+					"environment": tab.Name,
+					"test-name":   row.Name,
+					"gcs_prefix":  group.GetGcsPrefix(),
+					"build-id":    build,
+					"changelist":  build,
+				})
+			})
+			hist.Dashboard, hist.Tab = dash.Name, tab.Name
+			resp.Tabs = append(resp.Tabs, hist)
+			break
+		}
+	}
+
+	resp.LastPass = lastPass(resp.Tabs)
+	setETag(ctx, versions...)
+	return &resp, nil
+}
+
+// lastPass returns the tab with the most recent pass, along with only that pass, or nil when none passed.
+func lastPass(tabs []*apipb.TestHistory) *apipb.TestHistory {
+	var out *apipb.TestHistory
+	for _, hist := range tabs {
+		if hist.LastPass == nil {
+			continue
+		}
+		if out == nil || millis(hist.LastPass.Started) > millis(out.LastPass.Started) {
+			out = &apipb.TestHistory{
+				Dashboard: hist.Dashboard,
+				Tab:       hist.Tab,
+				LastPass:  hist.LastPass,
+			}
+		}
+	}
+	return out
+}
+
+// testHistory returns up to limit of the most recent results of the row, along with its most recent pass.
+//
+// Links each result to the url link returns for its build.
+func testHistory(cols []*statepb.Column, row *statepb.Row, limit int, link func(string) string) *apipb.TestHistory {
+	var out apipb.TestHistory
+	var col, filled int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		res, n := statuspb.TestStatus(row.Results[i]), int(row.Results[i+1])
+		for ; n > 0; n, col = n-1, col+1 {
+			if res == statuspb.TestStatus_NO_RESULT || col >= len(cols) {
+				continue
+			}
+			r := &apipb.TestResult{
+				Build:   cols[col].Build,
+				Started: started(cols[col]),
+				Status:  res,
+				Link:    link(cols[col].Build),
+			}
+			if filled < len(row.Messages) {
+				r.Message = row.Messages[filled]
+			}
+			filled++ // Messages skip empty cells.
+			if len(out.Results) < limit {
+				out.Results = append(out.Results, r)
+			}
+			if out.LastPass == nil && result.IsPassingResult(res) {
+				out.LastPass = r
+			}
+			if len(out.Results) == limit && out.LastPass != nil {
+				return &out
+			}
+		}
+	}
+	return &out
+}
+
+// started returns when the column started, which the grid stores in milliseconds.
+func started(col *statepb.Column) *timestamp.Timestamp {
+	ms := int64(col.Started)
+	return &timestamp.Timestamp{Seconds: ms / 1000, Nanos: int32(ms%1000) * 1e6}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestGetTestHistory(t *testing.T) {
+	objects := fakeObjects{}
+	objects.putGrid(t, "gs://bucket/grid/group", &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "3", Started: 3000},
+			{Build: "2", Started: 2000},
+			{Build: "1", Started: 1000},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name: "//pkg:TestFoo",
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				Messages: []string{"boom", ""},
+			},
+		},
+	})
+	s := testServer(t, objects)
+	ctx := context.Background()
+	if _, err := s.GetTestHistory(ctx, &apipb.GetTestHistoryRequest{Test: "//pkg:TestFoo"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("GetTestHistory() before indexing got %v, want Unavailable", err)
+	}
+	if err := s.refreshIndex(ctx); err != nil {
+		t.Fatalf("refreshIndex() got unexpected error: %v", err)
+	}
+
+	fail := &apipb.TestResult{
+		Build:   "3",
+		Started: &timestamp.Timestamp{Seconds: 3},
+		Status:  statuspb.TestStatus_FAIL,
+		Message: "boom",
+	}
+	pass := &apipb.TestResult{
+		Build:   "1",
+		Started: &timestamp.Timestamp{Seconds: 1},
+		Status:  statuspb.TestStatus_PASS,
+	}
+	cases := []struct {
+		name     string
+		req      *apipb.GetTestHistoryRequest
+		expected *apipb.GetTestHistoryResponse
+		code     codes.Code
+	}{
+		{
+			name: "basically works",
+			req:  &apipb.GetTestHistoryRequest{Test: "//pkg:TestFoo"},
+			expected: &apipb.GetTestHistoryResponse{
+				Tabs: []*apipb.TestHistory{
+					{
+						Dashboard: "second",
+						Tab:       "tab",
+						Results:   []*apipb.TestResult{fail, pass},
+						LastPass:  pass,
+					},
+				},
+				LastPass: &apipb.TestHistory{
+					Dashboard: "second",
+					Tab:       "tab",
+					LastPass:  pass,
+				},
+			},
+		},
+		{
+			name: "limit results but not the last pass",
+			req:  &apipb.GetTestHistoryRequest{Test: "//pkg:TestFoo", Limit: 1},
+			expected: &apipb.GetTestHistoryResponse{
+				Tabs: []*apipb.TestHistory{
+					{
+						Dashboard: "second",
+						Tab:       "tab",
+						Results:   []*apipb.TestResult{fail},
+						LastPass:  pass,
+					},
+				},
+				LastPass: &apipb.TestHistory{
+					Dashboard: "second",
+					Tab:       "tab",
+					LastPass:  pass,
+				},
+			},
+		},
+		{
+			name:     "match the exact name",
+			req:      &apipb.GetTestHistoryRequest{Test: "//pkg:testfoo"},
+			expected: &apipb.GetTestHistoryResponse{},
+		},
+		{
+			name: "test required",
+			req:  &apipb.GetTestHistoryRequest{},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "negative limit",
+			req:  &apipb.GetTestHistoryRequest{Test: "//pkg:TestFoo", Limit: -1},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.GetTestHistory(ctx, tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("GetTestHistory() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("GetTestHistory() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTestHistory(t *testing.T) {
+	cols := []*statepb.Column{
+		{Build: "4", Started: 4000},
+		{Build: "3", Started: 3500},
+		{Build: "2", Started: 2000},
+		{Build: "1", Started: 1000},
+	}
+	link := func(build string) string {
+		return "https://prow/" + build
+	}
+	result := func(build string, started *timestamp.Timestamp, s statuspb.TestStatus, msg string) *apipb.TestResult {
+		return &apipb.TestResult{
+			Build:   build,
+			Started: started,
+			Status:  s,
+			Message: msg,
+			Link:    link(build),
+		}
+	}
+	cases := []struct {
+		name     string
+		row      *statepb.Row
+		limit    int
+		expected *apipb.TestHistory
+	}{
+		{
+			name:     "empty row",
+			row:      &statepb.Row{},
+			limit:    10,
+			expected: &apipb.TestHistory{},
+		},
+		{
+			name: "messages skip empty cells",
+			row: &statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_PASS_WITH_SKIPS), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+				},
+				Messages: []string{"flake", "skip"},
+			},
+			limit: 10,
+			expected: &apipb.TestHistory{
+				Results: []*apipb.TestResult{
+					result("3", &timestamp.Timestamp{Seconds: 3, Nanos: 5e8}, statuspb.TestStatus_FLAKY, "flake"),
+					result("2", &timestamp.Timestamp{Seconds: 2}, statuspb.TestStatus_PASS_WITH_SKIPS, "skip"),
+				},
+				LastPass: result("2", &timestamp.Timestamp{Seconds: 2}, statuspb.TestStatus_PASS_WITH_SKIPS, "skip"),
+			},
+		},
+		{
+			name: "find an old pass beyond the limit",
+			row: &statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				Messages: []string{"a", "b", "c", "ok"},
+			},
+			limit: 1,
+			expected: &apipb.TestHistory{
+				Results: []*apipb.TestResult{
+					result("4", &timestamp.Timestamp{Seconds: 4}, statuspb.TestStatus_FAIL, "a"),
+				},
+				LastPass: result("1", &timestamp.Timestamp{Seconds: 1}, statuspb.TestStatus_PASS, "ok"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := testHistory(cols, tc.row, tc.limit, link)
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("testHistory() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
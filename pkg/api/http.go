@@ -0,0 +1,418 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// PathPrefix prefixes the path of every endpoint served by Handler.
+const PathPrefix = "/api/v1"
+
+// Handler serves the methods of the server as JSON over HTTP:
+//
+//	GET /api/v1/dashboards?dashboard_group=...
+//	GET /api/v1/dashboards/{dashboard}/tabs
+//	GET /api/v1/dashboards/{dashboard}/tabs/{tab}?column_offset=&column_limit=&row_offset=&row_limit=&row_regex=&status=&fields=
+//	GET /api/v1/dashboards/{dashboard}/tabs/{tab}/compare?start=&end=&base_dashboard=&base_tab=&base_start=&base_end=
+//	GET /api/v1/dashboards/{dashboard}/summary
+//	GET /api/v1/dashboards/{dashboard}/events
+//	GET /api/v1/failing_tests?test_regex=&dashboard_group=
+//	GET /api/v1/tests?query=&substring=&limit=
+//	GET /api/v1/tests/{test}/history?limit=
+//	POST /api/v1/dashboards/{dashboard}/tabs/{tab}/update
+//	POST /api/v1/dashboards/{dashboard}/summarize
+//
+// Repeat status to match rows with any of several statuses, such as status=FAIL&status=FLAKY.
+// Compare bounds the ranges with RFC 3339 times, comparing against the same tab unless base_dashboard or base_tab is set.
+// Fields use their proto names.
+//
+// The events endpoint streams server-sent events, named by the kind of each event.
+// The POST endpoints trigger an update of the tab's test group or a summary of the dashboard.
+//
+// Responses include the etag the server sends, answering requests whose
+// If-None-Match header lists it with 304 Not Modified.
+func Handler(server apipb.TestGridDataServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "only GET and POST are supported", http.StatusMethodNotAllowed)
+			return
+		}
+		parts, err := pathParts(r.URL)
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatus(err))
+			return
+		}
+		if (r.Method == http.MethodPost) != postPath(parts) {
+			http.Error(w, r.Method+" is not supported by "+r.URL.Path, http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Method == http.MethodGet && len(parts) == 3 && parts[0] == "dashboards" && parts[2] == "events" {
+			serveEvents(server, parts[1], w, r)
+			return
+		}
+		var headers headerStream
+		ctx := grpc.NewContextWithServerTransportStream(r.Context(), &headers)
+		var resp proto.Message
+		if r.Method == http.MethodPost {
+			resp, err = postRoute(ctx, server, parts)
+		} else {
+			resp, err = route(ctx, server, parts, r.URL.Query())
+		}
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatus(err))
+			return
+		}
+		if notModified(w, r, &headers) {
+			return
+		}
+		m := jsonpb.Marshaler{OrigName: true}
+		w.Header().Set("Content-Type", "application/json")
+		if err := m.Marshal(w, resp); err != nil {
+			http.Error(w, fmt.Sprintf("marshal: %v", err), http.StatusInternalServerError)
+		}
+	})
+}
+
+// route calls the method of the server matching the path parts and query of the request.
+func route(ctx context.Context, server apipb.TestGridDataServer, parts []string, query url.Values) (proto.Message, error) {
+	switch {
+	case len(parts) == 1 && parts[0] == "dashboards":
+		return server.ListDashboards(ctx, &apipb.ListDashboardsRequest{
+			DashboardGroup: query.Get("dashboard_group"),
+		})
+	case len(parts) == 3 && parts[0] == "dashboards" && parts[2] == "tabs":
+		return server.ListTabs(ctx, &apipb.ListTabsRequest{Dashboard: parts[1]})
+	case len(parts) == 4 && parts[0] == "dashboards" && parts[2] == "tabs":
+		req, err := tabStateRequest(parts[1], parts[3], query)
+		if err != nil {
+			return nil, err
+		}
+		return server.GetTabState(ctx, req)
+	case len(parts) == 5 && parts[0] == "dashboards" && parts[2] == "tabs" && parts[4] == "compare":
+		req, err := compareRequest(parts[1], parts[3], query)
+		if err != nil {
+			return nil, err
+		}
+		return server.CompareTabs(ctx, req)
+	case len(parts) == 3 && parts[0] == "dashboards" && parts[2] == "summary":
+		return server.GetSummary(ctx, &apipb.GetSummaryRequest{Dashboard: parts[1]})
+	case len(parts) == 1 && parts[0] == "failing_tests":
+		return server.ListFailingTests(ctx, &apipb.ListFailingTestsRequest{
+			TestRegex:      query.Get("test_regex"),
+			DashboardGroup: query.Get("dashboard_group"),
+		})
+	case len(parts) == 1 && parts[0] == "tests":
+		req, err := searchRequest(query)
+		if err != nil {
+			return nil, err
+		}
+		return server.SearchTests(ctx, req)
+	case len(parts) == 3 && parts[0] == "tests" && parts[2] == "history":
+		req := apipb.GetTestHistoryRequest{Test: parts[1]}
+		if v := query.Get("limit"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "bad limit: %v", err)
+			}
+			req.Limit = int32(n)
+		}
+		return server.GetTestHistory(ctx, &req)
+	}
+	return nil, status.Errorf(codes.NotFound, "unknown path %s", strings.Join(parts, "/"))
+}
+
+// postPath returns true when the path parts name an endpoint that only accepts POST requests.
+func postPath(parts []string) bool {
+	switch {
+	case len(parts) == 5 && parts[0] == "dashboards" && parts[2] == "tabs" && parts[4] == "update":
+		return true
+	case len(parts) == 3 && parts[0] == "dashboards" && parts[2] == "summarize":
+		return true
+	}
+	return false
+}
+
+// postRoute calls the method of the server matching the path parts of a POST request.
+func postRoute(ctx context.Context, server apipb.TestGridDataServer, parts []string) (proto.Message, error) {
+	switch {
+	case len(parts) == 5 && parts[0] == "dashboards" && parts[2] == "tabs" && parts[4] == "update":
+		return server.TriggerUpdate(ctx, &apipb.TriggerUpdateRequest{Dashboard: parts[1], Tab: parts[3]})
+	case len(parts) == 3 && parts[0] == "dashboards" && parts[2] == "summarize":
+		return server.TriggerSummary(ctx, &apipb.TriggerSummaryRequest{Dashboard: parts[1]})
+	}
+	return nil, status.Errorf(codes.NotFound, "unknown path %s", strings.Join(parts, "/"))
+}
+
+// headerStream collects the headers a call sets.
+type headerStream struct {
+	lock sync.Mutex
+	md   metadata.MD
+}
+
+var _ grpc.ServerTransportStream = &headerStream{}
+
+func (hs *headerStream) Method() string {
+	return ""
+}
+
+func (hs *headerStream) SetHeader(md metadata.MD) error {
+	hs.lock.Lock()
+	defer hs.lock.Unlock()
+	hs.md = metadata.Join(hs.md, md)
+	return nil
+}
+
+func (hs *headerStream) SendHeader(md metadata.MD) error {
+	return hs.SetHeader(md)
+}
+
+func (hs *headerStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+func (hs *headerStream) get(key string) string {
+	hs.lock.Lock()
+	defer hs.lock.Unlock()
+	if vals := hs.md.Get(key); len(vals) > 0 {
+		return vals[len(vals)-1]
+	}
+	return ""
+}
+
+// notModified sends the etag the call set, answering 304 Not Modified and returning true when the request lists it.
+func notModified(w http.ResponseWriter, r *http.Request, headers *headerStream) bool {
+	etag := headers.get(ETagHeader)
+	if etag == "" {
+		return false
+	}
+	w.Header().Set("ETag", etag)
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// etagMatch returns true when the If-None-Match header lists the etag or is *.
+//
+// Compares weakly, ignoring any W/ prefix.
+func etagMatch(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// serveEvents streams the events of the dashboard as server-sent events until the client disconnects.
+func serveEvents(server apipb.TestGridDataServer, dashboard string, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	// Check the dashboard exists before committing to a successful response.
+	if _, err := server.ListTabs(r.Context(), &apipb.ListTabsRequest{Dashboard: dashboard}); err != nil {
+		http.Error(w, status.Convert(err).Message(), httpStatus(err))
+		return
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	stream := eventStream{ctx: r.Context(), w: w, flusher: flusher}
+	if err := server.WatchDashboard(&apipb.WatchDashboardRequest{Dashboard: dashboard}, &stream); err != nil {
+		logrus.WithError(err).WithField("dashboard", dashboard).Warning("Stopped streaming events")
+	}
+}
+
+// eventStream writes the events of a WatchDashboard call as server-sent events.
+type eventStream struct {
+	grpc.ServerStream // Only Context and Send are implemented.
+	ctx               context.Context
+	w                 http.ResponseWriter
+	flusher           http.Flusher
+}
+
+func (es *eventStream) Context() context.Context {
+	return es.ctx
+}
+
+func (es *eventStream) Send(event *apipb.DashboardEvent) error {
+	m := jsonpb.Marshaler{OrigName: true}
+	data, err := m.MarshalToString(event)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if _, err := fmt.Fprintf(es.w, "event: %s\ndata: %s\n\n", event.Kind, data); err != nil {
+		return err
+	}
+	es.flusher.Flush()
+	return nil
+}
+
+// pathParts returns the unescaped segments of the path after the PathPrefix.
+func pathParts(u *url.URL) ([]string, error) {
+	p := u.EscapedPath()
+	if !strings.HasPrefix(p, PathPrefix+"/") {
+		return nil, status.Errorf(codes.NotFound, "unknown path %s", u.Path)
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(p, PathPrefix), "/"), "/")
+	for i, part := range parts {
+		s, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad path: %v", err)
+		}
+		parts[i] = s
+	}
+	return parts, nil
+}
+
+// tabStateRequest returns a request for the tab from the query parameters.
+func tabStateRequest(dashboard, tab string, query url.Values) (*apipb.GetTabStateRequest, error) {
+	req := apipb.GetTabStateRequest{
+		Dashboard: dashboard,
+		Tab:       tab,
+		RowRegex:  query.Get("row_regex"),
+	}
+	for name, field := range map[string]*int32{
+		"column_offset": &req.ColumnOffset,
+		"column_limit":  &req.ColumnLimit,
+		"row_offset":    &req.RowOffset,
+		"row_limit":     &req.RowLimit,
+	} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad %s: %v", name, err)
+		}
+		*field = int32(n)
+	}
+	for _, s := range query["status"] {
+		val, ok := statuspb.TestStatus_value[strings.ToUpper(s)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", s)
+		}
+		req.Status = append(req.Status, statuspb.TestStatus(val))
+	}
+	for _, f := range query["fields"] {
+		if req.ReadMask == nil {
+			req.ReadMask = &field_mask.FieldMask{}
+		}
+		req.ReadMask.Paths = append(req.ReadMask.Paths, strings.Split(f, ",")...)
+	}
+	return &req, nil
+}
+
+// searchRequest returns a request searching for tests from the query parameters.
+func searchRequest(query url.Values) (*apipb.SearchTestsRequest, error) {
+	req := apipb.SearchTestsRequest{Query: query.Get("query")}
+	if v := query.Get("substring"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad substring: %v", err)
+		}
+		req.Substring = b
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad limit: %v", err)
+		}
+		req.Limit = int32(n)
+	}
+	return &req, nil
+}
+
+// compareRequest returns a request comparing the tab with the base from the query parameters.
+func compareRequest(dashboard, tab string, query url.Values) (*apipb.CompareTabsRequest, error) {
+	base := apipb.TabRange{
+		Dashboard: query.Get("base_dashboard"),
+		Tab:       query.Get("base_tab"),
+	}
+	if base.Dashboard == "" {
+		base.Dashboard = dashboard
+	}
+	if base.Tab == "" {
+		base.Tab = tab
+	}
+	target := apipb.TabRange{Dashboard: dashboard, Tab: tab}
+	for name, field := range map[string]**timestamp.Timestamp{
+		"start":      &target.Start,
+		"end":        &target.End,
+		"base_start": &base.Start,
+		"base_end":   &base.End,
+	} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad %s: %v", name, err)
+		}
+		*field = &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+	}
+	return &apipb.CompareTabsRequest{Base: &base, Target: &target}, nil
+}
+
+// httpStatus returns the HTTP status code matching the code of the error.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	}
+	return http.StatusInternalServerError
+}
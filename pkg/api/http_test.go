@@ -0,0 +1,330 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestHandler(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "a", Results: []int32{1, 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+			{Name: "b", Results: []int32{12, 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+		},
+	}
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{{DashboardName: "second", DashboardTabName: "tab"}},
+	}
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		code     int
+		expected proto.Message
+	}{
+		{
+			name: "list dashboards",
+			path: "/api/v1/dashboards?dashboard_group=dashboard-group",
+			code: http.StatusOK,
+			expected: &apipb.ListDashboardsResponse{
+				Dashboards: []*apipb.DashboardResource{
+					{
+						Name:            "first",
+						DashboardGroups: []string{"dashboard-group"},
+						Ownership:       &configpb.Ownership{Owners: []string{"alice"}},
+					},
+				},
+			},
+		},
+		{
+			name: "list tabs",
+			path: "/api/v1/dashboards/second/tabs",
+			code: http.StatusOK,
+			expected: &apipb.ListTabsResponse{
+				Tabs: []*apipb.TabResource{
+					{Name: "tab", TestGroupName: "group", Description: "hello", DocumentationUrl: "https://docs/tab"},
+					{Name: "missing", TestGroupName: "missing"},
+				},
+			},
+		},
+		{
+			name: "filter tab state",
+			path: "/api/v1/dashboards/second/tabs/tab?column_limit=1&row_regex=.&status=fail",
+			code: http.StatusOK,
+			expected: &apipb.GetTabStateResponse{
+				Grid: &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "2"}},
+					Rows: []*statepb.Row{
+						{Name: "b", Results: []int32{12, 1}, CellIds: []string{""}, Messages: []string{""}, Icons: []string{""}},
+					},
+				},
+				TotalColumns: 2,
+				TotalRows:    1,
+			},
+		},
+		{
+			name: "tab state fields",
+			path: "/api/v1/dashboards/second/tabs/tab?fields=grid.rows.name,grid.rows.results&fields=total_rows&row_regex=^b$",
+			code: http.StatusOK,
+			expected: &apipb.GetTabStateResponse{
+				Grid: &statepb.Grid{
+					Rows: []*statepb.Row{{Name: "b", Results: []int32{12, 2}}},
+				},
+				TotalRows: 1,
+			},
+		},
+		{
+			name: "bad fields",
+			path: "/api/v1/dashboards/second/tabs/tab?fields=grid.rows.name.nope",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "escaped names",
+			path: "/api/v1/dashboards/sec%6Fnd/tabs/t%61b",
+			code: http.StatusOK,
+			expected: &apipb.GetTabStateResponse{
+				Grid:         grid,
+				TotalColumns: 2,
+				TotalRows:    2,
+			},
+		},
+		{
+			name: "compare",
+			path: "/api/v1/dashboards/second/tabs/tab/compare?base_start=1970-01-01T00:00:01Z",
+			code: http.StatusOK,
+			expected: &apipb.CompareTabsResponse{
+				Tests: []*apipb.TestComparison{
+					{
+						Name:          "b",
+						Change:        apipb.TestComparison_NEWLY_FAILING,
+						TargetOutcome: apipb.TestComparison_FAILING,
+					},
+				},
+				TargetColumns: 2,
+			},
+		},
+		{
+			name: "bad compare time",
+			path: "/api/v1/dashboards/second/tabs/tab/compare?start=yesterday",
+			code: http.StatusBadRequest,
+		},
+		{
+			name:     "summary",
+			path:     "/api/v1/dashboards/second/summary",
+			code:     http.StatusOK,
+			expected: &apipb.GetSummaryResponse{Summary: sum},
+		},
+		{
+			name:     "failing tests",
+			path:     "/api/v1/failing_tests?test_regex=^a$",
+			code:     http.StatusOK,
+			expected: &apipb.ListFailingTestsResponse{},
+		},
+		{
+			name: "bad test regex",
+			path: "/api/v1/failing_tests?test_regex=(",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad search limit",
+			path: "/api/v1/tests?query=a&limit=some",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad history limit",
+			path: "/api/v1/tests/%2F%2Fpkg:TestFoo/history?limit=some",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad limit",
+			path: "/api/v1/dashboards/second/tabs/tab?row_limit=many",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad status",
+			path: "/api/v1/dashboards/second/tabs/tab?status=meh",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "missing dashboard",
+			path: "/api/v1/dashboards/nope/summary",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "events of missing dashboard",
+			path: "/api/v1/dashboards/nope/events",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "unknown path",
+			path: "/api/v1/nope",
+			code: http.StatusNotFound,
+		},
+		{
+			name:   "post",
+			method: http.MethodPost,
+			path:   "/api/v1/dashboards",
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "anonymous update",
+			method: http.MethodPost,
+			path:   "/api/v1/dashboards/second/tabs/tab/update",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "anonymous summarize",
+			method: http.MethodPost,
+			path:   "/api/v1/dashboards/second/summarize",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name: "get update",
+			path: "/api/v1/dashboards/second/tabs/tab/update",
+			code: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := fakeObjects{}
+			objects.putGrid(t, "gs://bucket/grid/group", grid)
+			objects.put(t, "gs://bucket/summary/summary-second", sum)
+			handler := Handler(testServer(t, objects))
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Fatalf("ServeHTTP() got code %d, want %d: %s", rec.Code, tc.code, rec.Body)
+			}
+			if tc.expected == nil {
+				return
+			}
+			got := proto.Clone(tc.expected)
+			got.Reset()
+			if err := jsonpb.Unmarshal(rec.Body, got); err != nil {
+				t.Fatalf("unmarshal %s: %v", rec.Body, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandlerETags(t *testing.T) {
+	objects := fakeObjects{}
+	objects.put(t, "gs://bucket/summary/summary-second", &summarypb.DashboardSummary{})
+	handler := Handler(testServer(t, objects))
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboards/second/summary", nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("ServeHTTP() got code %d and etag %q, want 200 and an etag", first.Code, etag)
+	}
+	if rec := get(etag); rec.Code != http.StatusNotModified || rec.Body.Len() > 0 {
+		t.Errorf("ServeHTTP(If-None-Match: %s) got code %d and body %q, want an empty 304", etag, rec.Code, rec.Body)
+	}
+
+	objects.put(t, "gs://bucket/summary/summary-second", &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{{DashboardTabName: "tab"}},
+	})
+	changed := get(etag)
+	if changed.Code != http.StatusOK {
+		t.Errorf("ServeHTTP(If-None-Match: %s) after a change got code %d, want 200", etag, changed.Code)
+	}
+	if got := changed.Header().Get("ETag"); got == etag {
+		t.Errorf("ServeHTTP() after a change got the same etag %s", got)
+	}
+}
+
+func TestETagMatch(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		etag   string
+		want   bool
+	}{
+		{
+			name: "empty",
+			etag: `"a"`,
+		},
+		{
+			name:   "match",
+			header: `"a"`,
+			etag:   `"a"`,
+			want:   true,
+		},
+		{
+			name:   "mismatch",
+			header: `"b"`,
+			etag:   `"a"`,
+		},
+		{
+			name:   "list",
+			header: `"b", "a"`,
+			etag:   `"a"`,
+			want:   true,
+		},
+		{
+			name:   "weak",
+			header: `W/"a"`,
+			etag:   `"a"`,
+			want:   true,
+		},
+		{
+			name:   "any",
+			header: `*`,
+			etag:   `"a"`,
+			want:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := etagMatch(tc.header, tc.etag); got != tc.want {
+				t.Errorf("etagMatch(%q, %q) got %t, want %t", tc.header, tc.etag, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldMask holds the fields to keep of a message, keeping all of a field when its subtree is nil.
+type fieldMask map[protoreflect.Name]fieldMask
+
+// newFieldMask returns the mask of the paths of fields of messages described by md.
+//
+// Paths use proto field names, descending into the elements of repeated and map fields.
+func newFieldMask(md protoreflect.MessageDescriptor, paths []string) (fieldMask, error) {
+	mask := fieldMask{}
+	for _, p := range paths {
+		if err := mask.add(md, strings.Split(p, ".")); err != nil {
+			return nil, fmt.Errorf("bad field mask path %q: %w", p, err)
+		}
+	}
+	return mask, nil
+}
+
+func (fm fieldMask) add(md protoreflect.MessageDescriptor, names []string) error {
+	name := protoreflect.Name(names[0])
+	fd := md.Fields().ByName(name)
+	if fd == nil {
+		return fmt.Errorf("%s has no field %q", md.FullName(), name)
+	}
+	if len(names) == 1 {
+		fm[name] = nil
+		return nil
+	}
+	sub, ok := fm[name]
+	if ok && sub == nil {
+		return nil // Already keeping the whole field.
+	}
+	elem := fd.Message()
+	if fd.IsMap() {
+		elem = fd.MapValue().Message()
+	}
+	if elem == nil {
+		return fmt.Errorf("%s is not a message", fd.FullName())
+	}
+	if sub == nil {
+		sub = fieldMask{}
+		fm[name] = sub
+	}
+	return sub.add(elem, names[1:])
+}
+
+// apply clears the fields of the message outside the mask.
+func (fm fieldMask) apply(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := fm[fd.Name()]
+		switch {
+		case !ok:
+			msg.Clear(fd)
+		case sub == nil:
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				sub.apply(list.Get(i).Message())
+			}
+		case fd.IsMap():
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				sub.apply(mv.Message())
+				return true
+			})
+		default:
+			sub.apply(v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestFieldMask(t *testing.T) {
+	resp := &apipb.GetTabStateResponse{
+		Grid: &statepb.Grid{
+			Columns: []*statepb.Column{{Build: "2", Started: 2000}, {Build: "1", Started: 1000}},
+			Rows: []*statepb.Row{
+				{Name: "a", Id: "a", Results: []int32{1, 2}, Messages: []string{"", "hi"}},
+				{Name: "b", Id: "b", Results: []int32{12, 2}, Messages: []string{"", ""}},
+			},
+		},
+		TotalColumns: 2,
+		TotalRows:    2,
+	}
+	cases := []struct {
+		name     string
+		paths    []string
+		expected *apipb.GetTabStateResponse
+		err      bool
+	}{
+		{
+			name:  "top-level fields",
+			paths: []string{"total_columns", "total_rows"},
+			expected: &apipb.GetTabStateResponse{
+				TotalColumns: 2,
+				TotalRows:    2,
+			},
+		},
+		{
+			name:  "whole field",
+			paths: []string{"grid.columns"},
+			expected: &apipb.GetTabStateResponse{
+				Grid: &statepb.Grid{Columns: resp.Grid.Columns},
+			},
+		},
+		{
+			name:  "repeated elements",
+			paths: []string{"grid.columns.build", "grid.rows.name", "grid.rows.results"},
+			expected: &apipb.GetTabStateResponse{
+				Grid: &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+					Rows: []*statepb.Row{
+						{Name: "a", Results: []int32{1, 2}},
+						{Name: "b", Results: []int32{12, 2}},
+					},
+				},
+			},
+		},
+		{
+			name:  "parent includes children",
+			paths: []string{"grid.rows.name", "grid", "grid.columns.build"},
+			expected: &apipb.GetTabStateResponse{
+				Grid: resp.Grid,
+			},
+		},
+		{
+			name:  "unknown field",
+			paths: []string{"grid.nope"},
+			err:   true,
+		},
+		{
+			name:  "descend into scalar",
+			paths: []string{"total_rows.value"},
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := proto.Clone(resp).(*apipb.GetTabStateResponse)
+			mask, err := newFieldMask(proto.MessageReflect(msg).Descriptor(), tc.paths)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("newFieldMask() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("newFieldMask() failed to return an error")
+			}
+			mask.apply(proto.MessageReflect(msg))
+			if diff := cmp.Diff(tc.expected, msg, protocmp.Transform()); diff != "" {
+				t.Errorf("apply() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+var (
+	tabLabels = []string{"dashboard", "tab"}
+
+	passRateDesc   = prometheus.NewDesc("testgrid_tab_pass_rate", "Fraction of the results in recent columns of the tab that passed.", tabLabels, nil)
+	failingDesc    = prometheus.NewDesc("testgrid_tab_failing_tests", "Tests of the tab alerting for consecutive failures.", tabLabels, nil)
+	tabStatusDesc  = prometheus.NewDesc("testgrid_tab_status", "Overall status of the tab, 1 for the current status and 0 for the others.", append(tabLabels, "status"), nil)
+	staleDesc      = prometheus.NewDesc("testgrid_tab_stale", "Whether the tab is stale, lacking recent results or updates.", tabLabels, nil)
+	updateAgeDesc  = prometheus.NewDesc("testgrid_tab_last_update_age_seconds", "Seconds since the updater last updated the test group of the tab.", tabLabels, nil)
+	runAgeDesc     = prometheus.NewDesc("testgrid_tab_last_run_age_seconds", "Seconds since the most recent run of the tab started.", tabLabels, nil)
+	readErrorsDesc = prometheus.NewDesc("testgrid_summary_read_errors", "Dashboards whose summary the last scrape failed to read.", nil, nil)
+)
+
+// tabCollector collects the health of each dashboard tab in the latest summaries of the server.
+type tabCollector struct {
+	server apipb.TestGridDataServer
+	now    func() time.Time
+}
+
+// NewTabCollector returns a Prometheus collector of the health of each dashboard tab in its latest summary.
+//
+// Reads the summary of every dashboard the server lists on each scrape.
+func NewTabCollector(server apipb.TestGridDataServer) prometheus.Collector {
+	return &tabCollector{server: server, now: time.Now}
+}
+
+// Describe sends the descriptors of every tab metric.
+func (c *tabCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{passRateDesc, failingDesc, tabStatusDesc, staleDesc, updateAgeDesc, runAgeDesc, readErrorsDesc} {
+		ch <- desc
+	}
+}
+
+// Collect reads the summary of each dashboard and sends the metrics of its tabs.
+func (c *tabCollector) Collect(ch chan<- prometheus.Metric) {
+	// Discard the headers of the calls.
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &headerStream{})
+	resp, err := c.server.ListDashboards(ctx, &apipb.ListDashboardsRequest{})
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(readErrorsDesc, err)
+		return
+	}
+	var sums []*summarypb.DashboardSummary
+	var errs int
+	for _, dash := range resp.Dashboards {
+		sum, err := c.server.GetSummary(ctx, &apipb.GetSummaryRequest{Dashboard: dash.Name})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			continue
+		case err != nil:
+			logrus.WithError(err).WithField("dashboard", dash.Name).Warning("Failed to read summary for metrics")
+			errs++
+			continue
+		}
+		sums = append(sums, sum.Summary)
+	}
+	collectTabs(ch, sums, c.now())
+	ch <- prometheus.MustNewConstMetric(readErrorsDesc, prometheus.GaugeValue, float64(errs))
+}
+
+// collectTabs sends gauges describing the health of each tab of the summaries at now.
+func collectTabs(ch chan<- prometheus.Metric, sums []*summarypb.DashboardSummary, now time.Time) {
+	gauge := func(desc *prometheus.Desc, value float64, labels ...string) {
+		m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+		if err != nil {
+			m = prometheus.NewInvalidMetric(desc, err)
+		}
+		ch <- m
+	}
+
+	var statuses []string
+	for val, name := range summarypb.DashboardTabSummary_TabStatus_name {
+		if val != int32(summarypb.DashboardTabSummary_NOT_SET) {
+			statuses = append(statuses, name)
+		}
+	}
+	sort.Strings(statuses)
+
+	for _, sum := range sums {
+		for _, tab := range sum.GetTabSummaries() {
+			dash, name := tab.DashboardName, tab.DashboardTabName
+			if tab.FilledCells > 0 {
+				gauge(passRateDesc, float64(tab.PassingCells)/float64(tab.FilledCells), dash, name)
+			}
+			gauge(failingDesc, float64(len(tab.FailingTestSummaries)), dash, name)
+			current := tab.OverallStatus
+			if current == summarypb.DashboardTabSummary_NOT_SET {
+				current = summarypb.DashboardTabSummary_UNKNOWN
+			}
+			for _, s := range statuses {
+				var v float64
+				if s == current.String() {
+					v = 1
+				}
+				gauge(tabStatusDesc, v, dash, name, s)
+			}
+			var isStale float64
+			if current == summarypb.DashboardTabSummary_STALE {
+				isStale = 1
+			}
+			gauge(staleDesc, isStale, dash, name)
+			if ts := tab.LastUpdateTimestamp; ts > 0 {
+				gauge(updateAgeDesc, age(now, ts), dash, name)
+			}
+			if ts := tab.LastRunTimestamp; ts > 0 {
+				gauge(runAgeDesc, age(now, ts), dash, name)
+			}
+		}
+	}
+}
+
+// age returns the seconds from the seconds since the epoch until now.
+func age(now time.Time, seconds float64) float64 {
+	return float64(now.UnixNano())/1e9 - seconds
+}
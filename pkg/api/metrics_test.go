@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestTabCollector(t *testing.T) {
+	objects := fakeObjects{}
+	objects.put(t, "gs://bucket/summary/summary-second", &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardName:        "second",
+				DashboardTabName:     "failing",
+				OverallStatus:        summarypb.DashboardTabSummary_FAIL,
+				FailingTestSummaries: []*summarypb.FailingTestSummary{{}, {}},
+				FilledCells:          4,
+				PassingCells:         1,
+				LastUpdateTimestamp:  900,
+				LastRunTimestamp:     400,
+			},
+			{
+				DashboardName:    "second",
+				DashboardTabName: `"stale"\tab`,
+				OverallStatus:    summarypb.DashboardTabSummary_STALE,
+			},
+		},
+	})
+	c := &tabCollector{
+		server: testServer(t, objects),
+		now:    func() time.Time { return time.Unix(1000, 0) },
+	}
+	const staleTab = `tab="\"stale\"\\tab"`
+	expected := `# HELP testgrid_tab_pass_rate Fraction of the results in recent columns of the tab that passed.
+# TYPE testgrid_tab_pass_rate gauge
+testgrid_tab_pass_rate{dashboard="second",tab="failing"} 0.25
+# HELP testgrid_tab_failing_tests Tests of the tab alerting for consecutive failures.
+# TYPE testgrid_tab_failing_tests gauge
+testgrid_tab_failing_tests{dashboard="second",tab="failing"} 2
+testgrid_tab_failing_tests{dashboard="second",` + staleTab + `} 0
+# HELP testgrid_tab_status Overall status of the tab, 1 for the current status and 0 for the others.
+# TYPE testgrid_tab_status gauge
+testgrid_tab_status{dashboard="second",status="BROKEN",tab="failing"} 0
+testgrid_tab_status{dashboard="second",status="FAIL",tab="failing"} 1
+testgrid_tab_status{dashboard="second",status="FLAKY",tab="failing"} 0
+testgrid_tab_status{dashboard="second",status="PASS",tab="failing"} 0
+testgrid_tab_status{dashboard="second",status="STALE",tab="failing"} 0
+testgrid_tab_status{dashboard="second",status="UNKNOWN",tab="failing"} 0
+testgrid_tab_status{dashboard="second",status="BROKEN",` + staleTab + `} 0
+testgrid_tab_status{dashboard="second",status="FAIL",` + staleTab + `} 0
+testgrid_tab_status{dashboard="second",status="FLAKY",` + staleTab + `} 0
+testgrid_tab_status{dashboard="second",status="PASS",` + staleTab + `} 0
+testgrid_tab_status{dashboard="second",status="STALE",` + staleTab + `} 1
+testgrid_tab_status{dashboard="second",status="UNKNOWN",` + staleTab + `} 0
+# HELP testgrid_tab_stale Whether the tab is stale, lacking recent results or updates.
+# TYPE testgrid_tab_stale gauge
+testgrid_tab_stale{dashboard="second",tab="failing"} 0
+testgrid_tab_stale{dashboard="second",` + staleTab + `} 1
+# HELP testgrid_tab_last_update_age_seconds Seconds since the updater last updated the test group of the tab.
+# TYPE testgrid_tab_last_update_age_seconds gauge
+testgrid_tab_last_update_age_seconds{dashboard="second",tab="failing"} 100
+# HELP testgrid_tab_last_run_age_seconds Seconds since the most recent run of the tab started.
+# TYPE testgrid_tab_last_run_age_seconds gauge
+testgrid_tab_last_run_age_seconds{dashboard="second",tab="failing"} 600
+# HELP testgrid_summary_read_errors Dashboards whose summary the last scrape failed to read.
+# TYPE testgrid_summary_read_errors gauge
+testgrid_summary_read_errors 0
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected)); err != nil {
+		t.Errorf("Collect() got unexpected metrics: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// timestampName is the full name of the well-known timestamp message, which JSON maps to an RFC 3339 string.
+const timestampName = "google.protobuf.Timestamp"
+
+// fieldMaskName is the full name of the well-known field mask message, which JSON maps to a comma-separated string.
+const fieldMaskName = "google.protobuf.FieldMask"
+
+// OpenAPI returns an OpenAPI 3 document describing the Endpoints.
+//
+// Schemas follow the JSON mapping of the protos using their proto field names,
+// where 64-bit integers are strings, enums are their names and timestamps are
+// RFC 3339 strings and field masks comma-separated strings.
+func OpenAPI() ([]byte, error) {
+	schemas := map[string]interface{}{}
+	paths := map[string]map[string]interface{}{}
+	for _, e := range Endpoints {
+		req := proto.MessageReflect(e.Request).Descriptor()
+		var params []interface{}
+		for _, p := range e.PathParams {
+			fds, err := p.Fields(req)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", e.Method, err)
+			}
+			params = append(params, map[string]interface{}{
+				"name":     p.Name,
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(fds[len(fds)-1], schemas),
+			})
+		}
+		for _, p := range e.Query {
+			fds, err := p.Fields(req)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", e.Method, err)
+			}
+			params = append(params, map[string]interface{}{
+				"name":   p.Name,
+				"in":     "query",
+				"schema": fieldSchema(fds[len(fds)-1], schemas),
+			})
+		}
+		contentType := "application/json"
+		if e.Stream {
+			contentType = "text/event-stream"
+		}
+		op := map[string]interface{}{
+			"operationId": e.Method,
+			"summary":     e.Summary,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"content": map[string]interface{}{
+						contentType: map[string]interface{}{
+							"schema": messageRef(proto.MessageReflect(e.Response).Descriptor(), schemas),
+						},
+					},
+				},
+				"default": map[string]interface{}{
+					"description": "The error message.",
+					"content": map[string]interface{}{
+						"text/plain": map[string]interface{}{
+							"schema": map[string]interface{}{"type": "string"},
+						},
+					},
+				},
+			},
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if paths[e.Path] == nil {
+			paths[e.Path] = map[string]interface{}{}
+		}
+		paths[e.Path][strings.ToLower(e.HTTPMethod)] = op
+	}
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "TestGrid API",
+			"version": "v1",
+		},
+		"servers":    []interface{}{map[string]interface{}{"url": PathPrefix}},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+	buf, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	return append(buf, '\n'), nil
+}
+
+// Fields returns the descriptors of the fields along the path from the request message.
+func (p Param) Fields(md protoreflect.MessageDescriptor) ([]protoreflect.FieldDescriptor, error) {
+	var out []protoreflect.FieldDescriptor
+	for i, name := range strings.Split(p.Field, ".") {
+		if i > 0 {
+			md = out[i-1].Message()
+			if md == nil {
+				return nil, fmt.Errorf("%s: %s is not a message", p.Name, out[i-1].Name())
+			}
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("%s: %s has no field %q", p.Name, md.FullName(), name)
+		}
+		out = append(out, fd)
+	}
+	return out, nil
+}
+
+// messageRef adds the schema of the message and the messages it references to schemas, returning a reference to it.
+func messageRef(md protoreflect.MessageDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	if md.FullName() == timestampName {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if md.FullName() == fieldMaskName {
+		return map[string]interface{}{"type": "string"}
+	}
+	name := string(md.FullName())
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+	props := map[string]interface{}{}
+	schemas[name] = map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		props[string(fd.Name())] = fieldSchema(fd, schemas)
+	}
+	return ref
+}
+
+// fieldSchema returns the schema of the field, such as an array of its elements when repeated.
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	switch {
+	case fd.IsMap():
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": valueSchema(fd.MapValue(), schemas),
+		}
+	case fd.IsList():
+		return map[string]interface{}{
+			"type":  "array",
+			"items": valueSchema(fd, schemas),
+		}
+	}
+	return valueSchema(fd, schemas)
+}
+
+// valueSchema returns the schema of a single value of the field.
+func valueSchema(fd protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageRef(fd.Message(), schemas)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	}
+	return map[string]interface{}{"type": "integer", "format": "int32"}
+}
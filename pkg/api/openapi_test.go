@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+)
+
+func TestEndpoints(t *testing.T) {
+	service := proto.MessageReflect(&apipb.ListTabsRequest{}).Descriptor().ParentFile().Services().ByName("TestGridData")
+	methods := service.Methods()
+	if n := methods.Len(); n != len(Endpoints) {
+		t.Errorf("got %d endpoints for %d methods", len(Endpoints), n)
+	}
+	for i, e := range Endpoints {
+		t.Run(e.Method, func(t *testing.T) {
+			if i < methods.Len() && string(methods.Get(i).Name()) != e.Method {
+				t.Errorf("endpoint %d serves %s, want %s", i, e.Method, methods.Get(i).Name())
+			}
+			md := methods.ByName(protoreflect.Name(e.Method))
+			if md == nil {
+				t.Fatalf("no %s method", e.Method)
+			}
+			req := proto.MessageReflect(e.Request).Descriptor()
+			if md.Input() != req || md.Output() != proto.MessageReflect(e.Response).Descriptor() {
+				t.Errorf("%s types do not match %s(%s) returns (%s)", e.Method, md.Name(), md.Input().FullName(), md.Output().FullName())
+			}
+			if md.IsStreamingServer() != e.Stream {
+				t.Errorf("%s got stream %t, want %t", e.Method, e.Stream, md.IsStreamingServer())
+			}
+			// Every leaf field of the request should be settable through a parameter.
+			covered := map[string]bool{}
+			for _, p := range e.PathParams {
+				if !strings.Contains(e.Path, "{"+p.Name+"}") {
+					t.Errorf("path %s lacks parameter %s", e.Path, p.Name)
+				}
+				covered[p.Field] = true
+			}
+			for _, p := range e.Query {
+				covered[p.Field] = true
+			}
+			for _, p := range append(append([]Param(nil), e.PathParams...), e.Query...) {
+				if _, err := p.Fields(req); err != nil {
+					t.Errorf("bad parameter: %v", err)
+				}
+			}
+			for _, field := range leafFields(req, "") {
+				if !covered[field] {
+					t.Errorf("no parameter sets %s", field)
+				}
+			}
+		})
+	}
+}
+
+// leafFields returns the paths of the fields of the message, descending into singular messages other than timestamps.
+func leafFields(md protoreflect.MessageDescriptor, prefix string) []string {
+	var out []string
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := prefix + string(fd.Name())
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && fd.Message().FullName() != timestampName && fd.Message().FullName() != fieldMaskName {
+			out = append(out, leafFields(fd.Message(), name+".")...)
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+func TestOpenAPI(t *testing.T) {
+	buf, err := OpenAPI()
+	if err != nil {
+		t.Fatalf("OpenAPI() got unexpected error: %v", err)
+	}
+	type schema struct {
+		Type       string             `json:"type"`
+		Format     string             `json:"format"`
+		Ref        string             `json:"$ref"`
+		Enum       []string           `json:"enum"`
+		Items      *schema            `json:"items"`
+		Properties map[string]*schema `json:"properties"`
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Parameters  []struct {
+				Name     string  `json:"name"`
+				In       string  `json:"in"`
+				Required bool    `json:"required"`
+				Schema   *schema `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]*schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(buf, &doc); err != nil {
+		t.Fatalf("OpenAPI() returned invalid JSON: %v", err)
+	}
+	for _, e := range Endpoints {
+		op, ok := doc.Paths[e.Path][strings.ToLower(e.HTTPMethod)]
+		if !ok {
+			t.Errorf("OpenAPI() missing %s %s", e.HTTPMethod, e.Path)
+			continue
+		}
+		if op.OperationID != e.Method {
+			t.Errorf("OpenAPI() got %s %s operation %q, want %q", e.HTTPMethod, e.Path, op.OperationID, e.Method)
+		}
+		if n := len(e.PathParams) + len(e.Query); len(op.Parameters) != n {
+			t.Errorf("OpenAPI() got %d %s parameters, want %d", len(op.Parameters), e.Method, n)
+		}
+	}
+
+	cases := []struct {
+		name     string
+		schema   *schema
+		expected *schema
+	}{
+		{
+			name:     "int64 strings",
+			schema:   doc.Components.Schemas["DashboardEvent"].Properties["generation"],
+			expected: &schema{Type: "string", Format: "int64"},
+		},
+		{
+			name:     "enum names",
+			schema:   doc.Components.Schemas["DashboardEvent"].Properties["kind"],
+			expected: &schema{Type: "string", Enum: []string{"UNKNOWN", "TAB_SUMMARY", "GRID"}},
+		},
+		{
+			name:     "timestamps",
+			schema:   doc.Components.Schemas["AlertInfo"].Properties["fail_time"],
+			expected: &schema{Type: "string", Format: "date-time"},
+		},
+		{
+			name:     "repeated messages",
+			schema:   doc.Components.Schemas["ListTabsResponse"].Properties["tabs"],
+			expected: &schema{Type: "array", Items: &schema{Ref: "#/components/schemas/TabResource"}},
+		},
+		{
+			name:     "query parameters",
+			schema:   doc.Paths["/dashboards/{dashboard}/tabs/{tab}/compare"]["get"].Parameters[2].Schema,
+			expected: &schema{Type: "string", Format: "date-time"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, tc.schema); diff != "" {
+				t.Errorf("OpenAPI() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShippedOpenAPI(t *testing.T) {
+	expected, err := OpenAPI()
+	if err != nil {
+		t.Fatalf("OpenAPI() got unexpected error: %v", err)
+	}
+	actual, err := ioutil.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatalf("Failed to read shipped document: %v", err)
+	}
+	if diff := cmp.Diff(string(expected), string(actual)); diff != "" {
+		t.Errorf("openapi.json is stale, run go run ./cmd/api_gen --openapi=pkg/api/openapi.json (-want +got):\n%s", diff)
+	}
+}
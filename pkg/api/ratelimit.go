@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter limits the rate of requests from each client with a token bucket.
+//
+// Identifies authenticated users by their claims and anonymous clients by their address.
+type RateLimiter struct {
+	rate           float64 // tokens per second
+	burst          float64
+	trustedProxies int
+	now            func() time.Time
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// sweepInterval is how often the limiter forgets clients whose buckets refilled.
+const sweepInterval = time.Minute
+
+// NewRateLimiter allows each client rate requests per second, with bursts of up to burst requests.
+//
+// When behind trustedProxies proxies, each appending the address of its
+// client to the X-Forwarded-For header, identifies anonymous clients by the
+// address that many entries from its end rather than the peer address.
+func NewRateLimiter(rate float64, burst, trustedProxies int) (*RateLimiter, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %f", rate)
+	}
+	if burst < 1 {
+		return nil, fmt.Errorf("burst must be positive, got %d", burst)
+	}
+	if trustedProxies < 0 {
+		return nil, fmt.Errorf("trusted proxies must not be negative, got %d", trustedProxies)
+	}
+	return &RateLimiter{
+		rate:           rate,
+		burst:          float64(burst),
+		trustedProxies: trustedProxies,
+		now:            time.Now,
+		buckets:        map[string]*bucket{},
+	}, nil
+}
+
+// allow takes a token from the bucket of the client, returning how long to wait when it is empty.
+func (rl *RateLimiter) allow(client string) (bool, time.Duration) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	now := rl.now()
+	if now.Sub(rl.swept) >= sweepInterval {
+		for key, b := range rl.buckets {
+			if rl.refill(b, now) >= rl.burst {
+				delete(rl.buckets, key)
+			}
+		}
+		rl.swept = now
+	}
+	b, ok := rl.buckets[client]
+	if !ok {
+		b = &bucket{tokens: rl.burst, last: now}
+		rl.buckets[client] = b
+	}
+	b.tokens = rl.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// refill returns the tokens in the bucket at now.
+func (rl *RateLimiter) refill(b *bucket, now time.Time) float64 {
+	return math.Min(rl.burst, b.tokens+now.Sub(b.last).Seconds()*rl.rate)
+}
+
+// client identifies the user with the claims, or else the client at the peer address and forwarded addresses.
+func (rl *RateLimiter) client(claims *Claims, addr string, forwarded []string) string {
+	if claims != nil {
+		return "user:" + claims.Issuer + "/" + claims.Subject
+	}
+	if rl.trustedProxies > 0 {
+		var hops []string
+		for _, f := range forwarded {
+			for _, hop := range strings.Split(f, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		if n := len(hops) - rl.trustedProxies; n >= 0 && hops[n] != "" {
+			return "ip:" + hops[n]
+		}
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return "ip:" + addr
+}
+
+// Middleware rejects requests exceeding the rate of their client with 429 Too Many Requests.
+//
+// Wrap it with Authenticator.Middleware to limit authenticated users by identity.
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := rl.client(ClaimsFrom(r.Context()), r.RemoteAddr, r.Header.Values("X-Forwarded-For"))
+		if ok, wait := rl.allow(client); !ok {
+			w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// check returns a ResourceExhausted error when the call exceeds the rate of its client.
+func (rl *RateLimiter) check(ctx context.Context) error {
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	client := rl.client(ClaimsFrom(ctx), addr, md.Get("x-forwarded-for"))
+	if ok, wait := rl.allow(client); !ok {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", wait.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// UnaryInterceptor rejects calls exceeding the rate of their client, like Middleware.
+//
+// Chain it after the Authenticator interceptors to limit authenticated users by identity.
+func (rl *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rl.check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects streams exceeding the rate of their client, like Middleware.
+func (rl *RateLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rl.check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	cases := []struct {
+		name           string
+		rate           float64
+		burst          int
+		trustedProxies int
+		err            bool
+	}{
+		{
+			name:  "basic",
+			rate:  1,
+			burst: 1,
+		},
+		{
+			name:  "zero rate",
+			burst: 1,
+			err:   true,
+		},
+		{
+			name: "zero burst",
+			rate: 1,
+			err:  true,
+		},
+		{
+			name:           "negative proxies",
+			rate:           1,
+			burst:          1,
+			trustedProxies: -1,
+			err:            true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRateLimiter(tc.rate, tc.burst, tc.trustedProxies)
+			if got := err != nil; got != tc.err {
+				t.Errorf("NewRateLimiter() got error %v, want error %t", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAllow(t *testing.T) {
+	rl, err := NewRateLimiter(2, 3, 0)
+	if err != nil {
+		t.Fatalf("NewRateLimiter() got unexpected error: %v", err)
+	}
+	now := time.Unix(1000, 0)
+	rl.now = func() time.Time { return now }
+
+	type call struct {
+		advance time.Duration
+		client  string
+		allowed bool
+		wait    time.Duration
+	}
+	calls := []call{
+		{client: "a", allowed: true},
+		{client: "a", allowed: true},
+		{client: "a", allowed: true},
+		{client: "a", wait: 500 * time.Millisecond},
+		{client: "b", allowed: true},
+		{advance: 250 * time.Millisecond, client: "a", wait: 250 * time.Millisecond},
+		{advance: 250 * time.Millisecond, client: "a", allowed: true},
+		{client: "a", wait: 500 * time.Millisecond},
+		{advance: time.Hour, client: "a", allowed: true},
+		{client: "a", allowed: true},
+		{client: "a", allowed: true},
+		{client: "a", wait: 500 * time.Millisecond},
+	}
+	for i, c := range calls {
+		now = now.Add(c.advance)
+		allowed, wait := rl.allow(c.client)
+		if allowed != c.allowed || wait != c.wait {
+			t.Errorf("%d: allow(%q) got %t, %s, want %t, %s", i, c.client, allowed, wait, c.allowed, c.wait)
+		}
+	}
+	if _, ok := rl.buckets["b"]; ok {
+		t.Error("allow() failed to forget the refilled bucket of b")
+	}
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	cases := []struct {
+		name           string
+		trustedProxies int
+		claims         *Claims
+		addr           string
+		forwarded      []string
+		expected       string
+	}{
+		{
+			name:     "peer address",
+			addr:     "10.0.0.1:1234",
+			expected: "ip:10.0.0.1",
+		},
+		{
+			name:      "ignore untrusted forwarded addresses",
+			addr:      "10.0.0.1:1234",
+			forwarded: []string{"1.2.3.4"},
+			expected:  "ip:10.0.0.1",
+		},
+		{
+			name:     "user",
+			claims:   &Claims{Issuer: "https://issuer", Subject: "123"},
+			addr:     "10.0.0.1:1234",
+			expected: "user:https://issuer/123",
+		},
+		{
+			name:           "forwarded",
+			trustedProxies: 2,
+			addr:           "10.0.0.1:1234",
+			forwarded:      []string{"6.6.6.6, 1.2.3.4", "35.0.0.1"},
+			expected:       "ip:1.2.3.4",
+		},
+		{
+			name:           "too few forwarded addresses",
+			trustedProxies: 2,
+			addr:           "10.0.0.1:1234",
+			forwarded:      []string{"1.2.3.4"},
+			expected:       "ip:10.0.0.1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rl, err := NewRateLimiter(1, 1, tc.trustedProxies)
+			if err != nil {
+				t.Fatalf("NewRateLimiter() got unexpected error: %v", err)
+			}
+			if got := rl.client(tc.claims, tc.addr, tc.forwarded); got != tc.expected {
+				t.Errorf("client() got %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	rl, err := NewRateLimiter(1, 1, 0)
+	if err != nil {
+		t.Fatalf("NewRateLimiter() got unexpected error: %v", err)
+	}
+	handler := rl.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	serve := func(addr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := serve("10.0.0.1:1"); rec.Code != http.StatusOK {
+		t.Errorf("first request got code %d, want %d", rec.Code, http.StatusOK)
+	}
+	rec := serve("10.0.0.1:2")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("second request got code %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("second request got Retry-After %q, want 1", got)
+	}
+	if rec := serve("10.0.0.2:1"); rec.Code != http.StatusOK {
+		t.Errorf("request from another client got code %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRateLimiterUnaryInterceptor(t *testing.T) {
+	rl, err := NewRateLimiter(1, 1, 1)
+	if err != nil {
+		t.Fatalf("NewRateLimiter() got unexpected error: %v", err)
+	}
+	interceptor := rl.UnaryInterceptor()
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1}})
+	call := func(ctx context.Context) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	if err := call(ctx); err != nil {
+		t.Errorf("first call got unexpected error: %v", err)
+	}
+	if err := call(ctx); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("second call got %v, want ResourceExhausted", err)
+	}
+	forwarded := metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "1.2.3.4"))
+	if err := call(forwarded); err != nil {
+		t.Errorf("call from a forwarded client got unexpected error: %v", err)
+	}
+}
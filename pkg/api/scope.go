@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// ScopePrefix prefixes the paths ScopedHandler serves from a named scope, as in /scopes/{scope}/api/v1/dashboards.
+const ScopePrefix = "/scopes/"
+
+// ScopeHeader is the gRPC metadata key selecting the scope of a call to a Scoped server.
+const ScopeHeader = "testgrid-scope"
+
+// scopeName matches valid scope names, which need no escaping in paths.
+var scopeName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Scopes maps the name of each scope to the path of its config.
+//
+// Implements flag.Value, accepting repeated name=gs://bucket/config values.
+type Scopes map[string]gcs.Path
+
+// String returns the comma-separated name=path pairs.
+func (s Scopes) String() string {
+	parts := make([]string, 0, len(s))
+	for name, path := range s {
+		parts = append(parts, name+"="+path.String())
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// Set adds a name=path pair.
+func (s *Scopes) Set(v string) error {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return fmt.Errorf("want name=gs://bucket/config, got %q", v)
+	}
+	name := parts[0]
+	if !scopeName.MatchString(name) {
+		return fmt.Errorf("bad scope name %q, want letters, digits, dots, underscores or dashes", name)
+	}
+	if _, ok := (*s)[name]; ok {
+		return fmt.Errorf("duplicate scope %q", name)
+	}
+	path, err := gcs.NewPath(parts[1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if *s == nil {
+		*s = Scopes{}
+	}
+	(*s)[name] = *path
+	return nil
+}
+
+// Scoped returns a server routing each call to the server of the scope its ScopeHeader selects,
+// or else to the default server.
+func Scoped(def apipb.TestGridDataServer, scopes map[string]apipb.TestGridDataServer) apipb.TestGridDataServer {
+	return &scopedServer{def: def, scopes: scopes}
+}
+
+type scopedServer struct {
+	def    apipb.TestGridDataServer
+	scopes map[string]apipb.TestGridDataServer
+}
+
+// pick returns the server of the scope the call selects.
+func (ss *scopedServer) pick(ctx context.Context) (apipb.TestGridDataServer, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(ScopeHeader)
+	if len(vals) == 0 || vals[0] == "" {
+		return ss.def, nil
+	}
+	server, ok := ss.scopes[vals[0]]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "scope %q not found", vals[0])
+	}
+	return server, nil
+}
+
+func (ss *scopedServer) ListDashboards(ctx context.Context, req *apipb.ListDashboardsRequest) (*apipb.ListDashboardsResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListDashboards(ctx, req)
+}
+
+func (ss *scopedServer) ListTabs(ctx context.Context, req *apipb.ListTabsRequest) (*apipb.ListTabsResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListTabs(ctx, req)
+}
+
+func (ss *scopedServer) GetTabState(ctx context.Context, req *apipb.GetTabStateRequest) (*apipb.GetTabStateResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.GetTabState(ctx, req)
+}
+
+func (ss *scopedServer) GetSummary(ctx context.Context, req *apipb.GetSummaryRequest) (*apipb.GetSummaryResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.GetSummary(ctx, req)
+}
+
+func (ss *scopedServer) WatchDashboard(req *apipb.WatchDashboardRequest, stream apipb.TestGridData_WatchDashboardServer) error {
+	server, err := ss.pick(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.WatchDashboard(req, stream)
+}
+
+func (ss *scopedServer) CompareTabs(ctx context.Context, req *apipb.CompareTabsRequest) (*apipb.CompareTabsResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.CompareTabs(ctx, req)
+}
+
+func (ss *scopedServer) TriggerUpdate(ctx context.Context, req *apipb.TriggerUpdateRequest) (*apipb.TriggerUpdateResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.TriggerUpdate(ctx, req)
+}
+
+func (ss *scopedServer) TriggerSummary(ctx context.Context, req *apipb.TriggerSummaryRequest) (*apipb.TriggerSummaryResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.TriggerSummary(ctx, req)
+}
+
+func (ss *scopedServer) ListFailingTests(ctx context.Context, req *apipb.ListFailingTestsRequest) (*apipb.ListFailingTestsResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListFailingTests(ctx, req)
+}
+
+func (ss *scopedServer) SearchTests(ctx context.Context, req *apipb.SearchTestsRequest) (*apipb.SearchTestsResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SearchTests(ctx, req)
+}
+
+func (ss *scopedServer) GetTestHistory(ctx context.Context, req *apipb.GetTestHistoryRequest) (*apipb.GetTestHistoryResponse, error) {
+	server, err := ss.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.GetTestHistory(ctx, req)
+}
+
+// ScopedHandler serves requests under ScopePrefix with the handler of the scope they name,
+// stripping the prefix and name from the path, and other requests with the default handler.
+func ScopedHandler(def http.Handler, scopes map[string]http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, ScopePrefix) {
+			def.ServeHTTP(w, r)
+			return
+		}
+		name := strings.SplitN(strings.TrimPrefix(r.URL.Path, ScopePrefix), "/", 2)[0]
+		h, ok := scopes[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("scope %q not found", name), http.StatusNotFound)
+			return
+		}
+		http.StripPrefix(ScopePrefix+name, h).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+)
+
+func TestScopesSet(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "basic",
+			values:   []string{"public=gs://public/config", "internal=gs://internal/config"},
+			expected: "internal=gs://internal/config,public=gs://public/config",
+		},
+		{
+			name:   "missing path",
+			values: []string{"public"},
+			err:    true,
+		},
+		{
+			name:   "bad name",
+			values: []string{"a/b=gs://bucket/config"},
+			err:    true,
+		},
+		{
+			name:   "bad path",
+			values: []string{"public=/local/config"},
+			err:    true,
+		},
+		{
+			name:   "duplicate",
+			values: []string{"public=gs://public/config", "public=gs://other/config"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var scopes Scopes
+			var err error
+			for _, v := range tc.values {
+				if err = scopes.Set(v); err != nil {
+					break
+				}
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Set() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("Set() failed to return an error, got %s", scopes)
+			case scopes.String() != tc.expected:
+				t.Errorf("Set() got %s, want %s", scopes, tc.expected)
+			}
+		})
+	}
+}
+
+// namedServer lists a single dashboard named after the server.
+type namedServer struct {
+	apipb.TestGridDataServer
+	name string
+}
+
+func (ns namedServer) ListDashboards(context.Context, *apipb.ListDashboardsRequest) (*apipb.ListDashboardsResponse, error) {
+	return &apipb.ListDashboardsResponse{Dashboards: []*apipb.DashboardResource{{Name: ns.name}}}, nil
+}
+
+func TestScoped(t *testing.T) {
+	server := Scoped(namedServer{name: "default"}, map[string]apipb.TestGridDataServer{
+		"internal": namedServer{name: "internal"},
+	})
+	cases := []struct {
+		name     string
+		md       metadata.MD
+		expected string
+		code     codes.Code
+	}{
+		{
+			name:     "default",
+			expected: "default",
+		},
+		{
+			name:     "scope",
+			md:       metadata.Pairs(ScopeHeader, "internal"),
+			expected: "internal",
+		},
+		{
+			name: "unknown scope",
+			md:   metadata.Pairs(ScopeHeader, "nope"),
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			resp, err := server.ListDashboards(ctx, &apipb.ListDashboardsRequest{})
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("ListDashboards() got code %s, want %s: %v", code, tc.code, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := resp.Dashboards[0].Name; got != tc.expected {
+				t.Errorf("ListDashboards() got server %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestScopedHandler(t *testing.T) {
+	pathHandler := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s %s", name, r.URL.EscapedPath())
+		})
+	}
+	handler := ScopedHandler(pathHandler("default"), map[string]http.Handler{
+		"internal": pathHandler("internal"),
+	})
+	cases := []struct {
+		name     string
+		path     string
+		code     int
+		expected string
+	}{
+		{
+			name:     "default",
+			path:     "/api/v1/dashboards",
+			code:     http.StatusOK,
+			expected: "default /api/v1/dashboards",
+		},
+		{
+			name:     "scope",
+			path:     "/scopes/internal/api/v1/dashboards/sig%2Ftesting/tabs",
+			code:     http.StatusOK,
+			expected: "internal /api/v1/dashboards/sig%2Ftesting/tabs",
+		},
+		{
+			name: "unknown scope",
+			path: "/scopes/nope/api/v1/dashboards",
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Fatalf("ServeHTTP() got code %d, want %d: %s", rec.Code, tc.code, rec.Body)
+			}
+			if tc.expected == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, rec.Body.String()); diff != "" {
+				t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
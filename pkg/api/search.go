@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+)
+
+// defaultSearchLimit is the number of tests SearchTests returns when the request sets no limit.
+const defaultSearchLimit = 100
+
+// testIndex holds the tabs containing each test, sorted by the lowercase name of the test.
+type testIndex struct {
+	built time.Time
+	keys  []string
+	tests []*apipb.TestMatch
+}
+
+// search returns up to limit tests whose lowercase name starts with, or contains, the lowercase query.
+func (ti *testIndex) search(query string, substring bool, limit int) ([]*apipb.TestMatch, bool) {
+	query = strings.ToLower(query)
+	var out []*apipb.TestMatch
+	start := 0
+	if !substring {
+		start = sort.SearchStrings(ti.keys, query)
+	}
+	for i := start; i < len(ti.keys); i++ {
+		key := ti.keys[i]
+		if !substring && !strings.HasPrefix(key, query) {
+			break
+		}
+		if substring && !strings.Contains(key, query) {
+			continue
+		}
+		if len(out) == limit {
+			return out, true
+		}
+		// Copy so callers may filter the tabs.
+		out = append(out, proto.Clone(ti.tests[i]).(*apipb.TestMatch))
+	}
+	return out, false
+}
+
+// IndexTests indexes the names of the rows of every tab, then refreshes the index once per interval until the context is done.
+//
+// SearchTests is unavailable until the first index is built.
+func (s *Server) IndexTests(ctx context.Context, interval time.Duration) {
+	for {
+		start := time.Now()
+		if err := s.refreshIndex(ctx); err != nil {
+			logrus.WithError(err).Warning("Incomplete test index")
+		}
+		logrus.WithField("duration", time.Since(start)).Info("Indexed tests")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// refreshIndex replaces the index with the rows of the current grid of every tab.
+//
+// Skips groups without a grid, and those it fails to read, returning an error listing the latter.
+func (s *Server) refreshIndex(ctx context.Context) error {
+	cfg, _ := s.config(ctx)
+	groupTabs := map[string][]*apipb.TestMatch_Tab{}
+	for _, dash := range cfg.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			groupTabs[tab.TestGroupName] = append(groupTabs[tab.TestGroupName], &apipb.TestMatch_Tab{
+				Dashboard: dash.Name,
+				Tab:       tab.Name,
+			})
+		}
+	}
+
+	tests := map[string]*apipb.TestMatch{}
+	var failed []string
+	for group, tabs := range groupTabs {
+		gridPath, err := s.configPath.ResolveReference(&url.URL{Path: path.Join(s.gridPathPrefix, group)})
+		if err != nil {
+			failed = append(failed, group)
+			continue
+		}
+		grid, err := readGrid(ctx, s.client, *gridPath)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			continue
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("path", gridPath).Warning("Failed to index grid")
+			failed = append(failed, group)
+			continue
+		}
+		for _, row := range grid.Rows {
+			test, ok := tests[row.Name]
+			if !ok {
+				test = &apipb.TestMatch{Name: row.Name}
+				tests[row.Name] = test
+			}
+			test.Tabs = append(test.Tabs, tabs...)
+		}
+	}
+
+	index := testIndex{built: time.Now()}
+	for _, test := range tests {
+		sort.Slice(test.Tabs, func(i, j int) bool {
+			a, b := test.Tabs[i], test.Tabs[j]
+			if a.Dashboard != b.Dashboard {
+				return a.Dashboard < b.Dashboard
+			}
+			return a.Tab < b.Tab
+		})
+		index.tests = append(index.tests, test)
+	}
+	sort.Slice(index.tests, func(i, j int) bool {
+		a, b := strings.ToLower(index.tests[i].Name), strings.ToLower(index.tests[j].Name)
+		if a != b {
+			return a < b
+		}
+		return index.tests[i].Name < index.tests[j].Name
+	})
+	index.keys = make([]string, 0, len(index.tests))
+	for _, test := range index.tests {
+		index.keys = append(index.keys, strings.ToLower(test.Name))
+	}
+
+	s.indexLock.Lock()
+	s.index = &index
+	s.indexLock.Unlock()
+
+	if n := len(failed); n > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("failed to index %d groups: %s", n, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// SearchTests finds the tabs containing tests whose name starts with or contains the query.
+func (s *Server) SearchTests(ctx context.Context, req *apipb.SearchTestsRequest) (*apipb.SearchTestsResponse, error) {
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	case limit == 0:
+		limit = defaultSearchLimit
+	}
+	s.indexLock.RLock()
+	index := s.index
+	s.indexLock.RUnlock()
+	if index == nil {
+		return nil, status.Error(codes.Unavailable, "test index is not ready")
+	}
+	tests, truncated := index.search(req.GetQuery(), req.GetSubstring(), limit)
+	setETag(ctx, index.built.UnixNano())
+	return &apipb.SearchTestsResponse{
+		Tests:     tests,
+		Truncated: truncated,
+		Indexed:   &timestamp.Timestamp{Seconds: index.built.Unix(), Nanos: int32(index.built.Nanosecond())},
+	}, nil
+}
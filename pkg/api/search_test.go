@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestSearchTests(t *testing.T) {
+	objects := fakeObjects{}
+	objects.putGrid(t, "gs://bucket/grid/group", &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "//pkg:TestFoo"},
+			{Name: "//pkg:TestBar"},
+			{Name: "//other:testfoo"},
+		},
+	})
+	objects["gs://bucket/grid/missing"] = []byte("garbage")
+	s := testServer(t, objects)
+	ctx := context.Background()
+	if _, err := s.SearchTests(ctx, &apipb.SearchTestsRequest{Query: "//"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("SearchTests() before indexing got %v, want Unavailable", err)
+	}
+	if err := s.refreshIndex(ctx); err == nil {
+		t.Error("refreshIndex() failed to report the unreadable grid")
+	}
+
+	tabs := []*apipb.TestMatch_Tab{{Dashboard: "second", Tab: "tab"}}
+	cases := []struct {
+		name     string
+		req      *apipb.SearchTestsRequest
+		expected *apipb.SearchTestsResponse
+		code     codes.Code
+	}{
+		{
+			name: "prefix",
+			req:  &apipb.SearchTestsRequest{Query: "//pkg:"},
+			expected: &apipb.SearchTestsResponse{
+				Tests: []*apipb.TestMatch{
+					{Name: "//pkg:TestBar", Tabs: tabs},
+					{Name: "//pkg:TestFoo", Tabs: tabs},
+				},
+			},
+		},
+		{
+			name: "ignore case",
+			req:  &apipb.SearchTestsRequest{Query: "//PKG:testf"},
+			expected: &apipb.SearchTestsResponse{
+				Tests: []*apipb.TestMatch{{Name: "//pkg:TestFoo", Tabs: tabs}},
+			},
+		},
+		{
+			name: "substring",
+			req:  &apipb.SearchTestsRequest{Query: "Foo", Substring: true},
+			expected: &apipb.SearchTestsResponse{
+				Tests: []*apipb.TestMatch{
+					{Name: "//other:testfoo", Tabs: tabs},
+					{Name: "//pkg:TestFoo", Tabs: tabs},
+				},
+			},
+		},
+		{
+			name: "limit",
+			req:  &apipb.SearchTestsRequest{Query: "//", Limit: 1},
+			expected: &apipb.SearchTestsResponse{
+				Tests:     []*apipb.TestMatch{{Name: "//other:testfoo", Tabs: tabs}},
+				Truncated: true,
+			},
+		},
+		{
+			name: "exactly the limit",
+			req:  &apipb.SearchTestsRequest{Query: "//pkg:", Limit: 2},
+			expected: &apipb.SearchTestsResponse{
+				Tests: []*apipb.TestMatch{
+					{Name: "//pkg:TestBar", Tabs: tabs},
+					{Name: "//pkg:TestFoo", Tabs: tabs},
+				},
+			},
+		},
+		{
+			name:     "no matches",
+			req:      &apipb.SearchTestsRequest{Query: "pkg"},
+			expected: &apipb.SearchTestsResponse{},
+		},
+		{
+			name: "negative limit",
+			req:  &apipb.SearchTestsRequest{Query: "//", Limit: -1},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.SearchTests(ctx, tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("SearchTests() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if got != nil {
+				if got.Indexed == nil {
+					t.Error("SearchTests() failed to set when it indexed the tests")
+				}
+				got.Indexed = nil
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("SearchTests() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
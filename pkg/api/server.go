@@ -0,0 +1,371 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api serves the configuration and state of TestGrid over gRPC.
+package api
+
+import (
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// ETagHeader is the header metadata of each response holding its etag.
+const ETagHeader = "etag"
+
+// Client reads the config and state objects, and writes triggers.
+type Client interface {
+	gcs.Opener
+	gcs.Stater
+	gcs.Uploader
+}
+
+// Server implements the TestGridData service, reading state from GCS.
+type Server struct {
+	client            Client
+	configPath        gcs.Path
+	gridPathPrefix    string
+	summaryPathPrefix string
+	triggerPrefix     string
+	watchInterval     time.Duration
+	objects           *objectCache
+	started           int64
+
+	lock    sync.Mutex
+	watcher *config.Watcher
+
+	indexLock sync.RWMutex
+	index     *testIndex
+}
+
+var _ apipb.TestGridDataServer = &Server{}
+
+// NewServer reads the config at configPath, checking for changes at most once per reloadConfig.
+//
+// Reads grids under gridPathPrefix and summaries under summaryPathPrefix, both relative to configPath.
+// Writes triggers under triggerPrefix, or refuses to trigger updates when empty.
+// Watched dashboards check for changes once per watchInterval.
+// Caches up to cacheSize of the most recently read grids and summaries.
+func NewServer(ctx context.Context, client Client, configPath gcs.Path, reloadConfig time.Duration, gridPathPrefix, summaryPathPrefix, triggerPrefix string, watchInterval time.Duration, cacheSize int) (*Server, error) {
+	if watchInterval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive, got %s", watchInterval)
+	}
+	watcher, err := config.NewWatcher(ctx, client, configPath, reloadConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		client:            client,
+		configPath:        configPath,
+		gridPathPrefix:    gridPathPrefix,
+		summaryPathPrefix: summaryPathPrefix,
+		triggerPrefix:     triggerPrefix,
+		watchInterval:     watchInterval,
+		objects:           newObjectCache(cacheSize),
+		started:           time.Now().UnixNano(),
+		watcher:           watcher,
+	}, nil
+}
+
+// config returns the current config and its version, reloading it when it changed.
+//
+// The version is the generation of the config, or when never reloading, the time the server started.
+func (s *Server) config(ctx context.Context) (*configpb.Configuration, int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, err := s.watcher.Reload(ctx); err != nil {
+		logrus.WithError(err).Warning("Failed to reload config, serving the previous one")
+	}
+	version := s.watcher.Generation()
+	if version == 0 {
+		version = s.started
+	}
+	return s.watcher.Config(), version
+}
+
+// setETag sends an etag derived from the versions of the objects backing the response.
+func setETag(ctx context.Context, versions ...int64) {
+	parts := make([]string, 0, len(versions))
+	for _, v := range versions {
+		parts = append(parts, strconv.FormatInt(v, 16))
+	}
+	// Fails outside of a call, which has nowhere to send headers.
+	grpc.SetHeader(ctx, metadata.Pairs(ETagHeader, `"`+strings.Join(parts, "-")+`"`))
+}
+
+// ListDashboards lists the dashboards of the config, sorted by name.
+func (s *Server) ListDashboards(ctx context.Context, req *apipb.ListDashboardsRequest) (*apipb.ListDashboardsResponse, error) {
+	cfg, version := s.config(ctx)
+	only, err := groupDashboards(cfg, req.GetDashboardGroup())
+	if err != nil {
+		return nil, err
+	}
+	groups := map[string][]string{}
+	for _, group := range cfg.GetDashboardGroups() {
+		for _, dash := range group.DashboardNames {
+			groups[dash] = append(groups[dash], group.Name)
+		}
+	}
+	var resp apipb.ListDashboardsResponse
+	for _, dash := range cfg.GetDashboards() {
+		if only != nil && !only[dash.Name] {
+			continue
+		}
+		res := apipb.DashboardResource{
+			Name:            dash.Name,
+			DashboardGroups: groups[dash.Name],
+			Ownership:       config.FindOwnership(dash.Name, cfg),
+		}
+		for _, tab := range dash.DashboardTab {
+			res.TabNames = append(res.TabNames, tab.Name)
+		}
+		resp.Dashboards = append(resp.Dashboards, &res)
+	}
+	sort.SliceStable(resp.Dashboards, func(i, j int) bool {
+		return resp.Dashboards[i].Name < resp.Dashboards[j].Name
+	})
+	setETag(ctx, version)
+	return &resp, nil
+}
+
+// groupDashboards returns the set of dashboards in the named dashboard group, or nil for every dashboard when the name is empty.
+func groupDashboards(cfg *configpb.Configuration, name string) (map[string]bool, error) {
+	if name == "" {
+		return nil, nil
+	}
+	group := config.FindDashboardGroup(name, cfg)
+	if group == nil {
+		return nil, status.Errorf(codes.NotFound, "dashboard group %q not found", name)
+	}
+	only := map[string]bool{}
+	for _, dash := range group.DashboardNames {
+		only[dash] = true
+	}
+	return only, nil
+}
+
+// ListTabs lists the tabs of a dashboard.
+func (s *Server) ListTabs(ctx context.Context, req *apipb.ListTabsRequest) (*apipb.ListTabsResponse, error) {
+	cfg, version := s.config(ctx)
+	dash, err := findDashboard(cfg, req.GetDashboard())
+	if err != nil {
+		return nil, err
+	}
+	var resp apipb.ListTabsResponse
+	for _, tab := range dash.DashboardTab {
+		resp.Tabs = append(resp.Tabs, &apipb.TabResource{
+			Name:             tab.Name,
+			TestGroupName:    tab.TestGroupName,
+			Description:      tab.Description,
+			DocumentationUrl: tab.DocumentationUrl,
+		})
+	}
+	setETag(ctx, version)
+	return &resp, nil
+}
+
+// GetTabState returns the requested page of the grid of the test group backing a tab, filtering its rows.
+func (s *Server) GetTabState(ctx context.Context, req *apipb.GetTabStateRequest) (*apipb.GetTabStateResponse, error) {
+	if req.GetColumnOffset() < 0 || req.GetColumnLimit() < 0 || req.GetRowOffset() < 0 || req.GetRowLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offsets and limits must not be negative")
+	}
+	var mask fieldMask
+	if paths := req.GetReadMask().GetPaths(); len(paths) > 0 {
+		var err error
+		if mask, err = newFieldMask(proto.MessageReflect(&apipb.GetTabStateResponse{}).Descriptor(), paths); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	cfg, version := s.config(ctx)
+	grid, gen, err := s.tabGrid(ctx, cfg, req.GetDashboard(), req.GetTab())
+	if err != nil {
+		return nil, err
+	}
+	cols := len(grid.Columns)
+	rows, err := FilterGrid(grid, req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	setETag(ctx, version, gen)
+	resp := apipb.GetTabStateResponse{
+		Grid:         grid,
+		TotalColumns: int32(cols),
+		TotalRows:    int32(rows),
+	}
+	if mask != nil {
+		mask.apply(proto.MessageReflect(&resp))
+	}
+	return &resp, nil
+}
+
+// GetSummary returns the latest summary of a dashboard.
+func (s *Server) GetSummary(ctx context.Context, req *apipb.GetSummaryRequest) (*apipb.GetSummaryResponse, error) {
+	cfg, version := s.config(ctx)
+	dash, err := findDashboard(cfg, req.GetDashboard())
+	if err != nil {
+		return nil, err
+	}
+	sum, gen, err := s.dashboardSummary(ctx, dash.Name)
+	if err != nil {
+		return nil, err
+	}
+	if sum == nil {
+		return nil, status.Errorf(codes.NotFound, "no summary for dashboard %q", dash.Name)
+	}
+	setETag(ctx, version, gen)
+	return &apipb.GetSummaryResponse{Summary: sum}, nil
+}
+
+// dashboardSummary returns a copy of the latest summary of the dashboard along with its generation, or nil if it has none.
+func (s *Server) dashboardSummary(ctx context.Context, dashboard string) (*summarypb.DashboardSummary, int64, error) {
+	summaryPath, err := s.configPath.ResolveReference(&url.URL{Path: path.Join(s.summaryPathPrefix, summarizer.SummaryPath(dashboard))})
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "resolve summary path: %v", err)
+	}
+	sum, gen, err := s.summary(ctx, *summaryPath)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "read %s: %v", summaryPath, err)
+	}
+	return sum, gen, nil
+}
+
+// tabGrid returns a copy of the grid of the test group backing the tab of the dashboard, along with its generation.
+func (s *Server) tabGrid(ctx context.Context, cfg *configpb.Configuration, dashboard, tabName string) (*statepb.Grid, int64, error) {
+	dash, err := findDashboard(cfg, dashboard)
+	if err != nil {
+		return nil, 0, err
+	}
+	tab, err := findTab(dash, tabName)
+	if err != nil {
+		return nil, 0, err
+	}
+	gridPath, err := s.configPath.ResolveReference(&url.URL{Path: path.Join(s.gridPathPrefix, tab.TestGroupName)})
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "resolve grid path: %v", err)
+	}
+	grid, gen, err := s.grid(ctx, *gridPath)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "read %s: %v", gridPath, err)
+	}
+	if grid == nil {
+		return nil, 0, status.Errorf(codes.NotFound, "no state for tab %q", tab.Name)
+	}
+	return grid, gen, nil
+}
+
+// grid returns a copy of the grid at path along with its generation, or nil if it does not exist.
+//
+// Only reads and decodes the object when its current generation is not cached.
+func (s *Server) grid(ctx context.Context, path gcs.Path) (*statepb.Grid, int64, error) {
+	gen, err := generation(ctx, s.client, path)
+	if err != nil || gen == 0 {
+		return nil, 0, err
+	}
+	if cached := s.objects.get(path.String(), gen); cached != nil {
+		return proto.Clone(cached).(*statepb.Grid), gen, nil
+	}
+	grid, err := readGrid(ctx, s.client, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	s.objects.put(path.String(), gen, proto.Clone(grid))
+	return grid, gen, nil
+}
+
+// summary returns a copy of the summary at path along with its generation, or nil if it does not exist.
+//
+// Only reads and decodes the object when its current generation is not cached.
+func (s *Server) summary(ctx context.Context, path gcs.Path) (*summarypb.DashboardSummary, int64, error) {
+	gen, err := generation(ctx, s.client, path)
+	if err != nil || gen == 0 {
+		return nil, 0, err
+	}
+	if cached := s.objects.get(path.String(), gen); cached != nil {
+		return proto.Clone(cached).(*summarypb.DashboardSummary), gen, nil
+	}
+	sum, err := summarizer.ReadSummary(ctx, s.client, path)
+	if err != nil || sum == nil {
+		return nil, 0, err
+	}
+	s.objects.put(path.String(), gen, proto.Clone(sum))
+	return sum, gen, nil
+}
+
+func findDashboard(cfg *configpb.Configuration, name string) (*configpb.Dashboard, error) {
+	dash := config.FindDashboard(name, cfg)
+	if dash == nil {
+		return nil, status.Errorf(codes.NotFound, "dashboard %q not found", name)
+	}
+	return dash, nil
+}
+
+func findTab(dash *configpb.Dashboard, name string) (*configpb.DashboardTab, error) {
+	for _, tab := range dash.DashboardTab {
+		if tab.Name == name {
+			return tab, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "tab %q not found in dashboard %q", name, dash.Name)
+}
+
+func readGrid(ctx context.Context, opener gcs.Opener, path gcs.Path) (*statepb.Grid, error) {
+	r, err := opener.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open zlib: %w", err)
+	}
+	buf, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	var grid statepb.Grid
+	if err := proto.Unmarshal(buf, &grid); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return &grid, nil
+}
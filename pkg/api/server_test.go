@@ -0,0 +1,438 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type fakeObjects map[string][]byte
+
+func (fo fakeObjects) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	buf, ok := fo[path.String()]
+	if !ok {
+		return nil, fmt.Errorf("wrap not exist: %w", storage.ErrObjectNotExist)
+	}
+	return ioutil.NopCloser(bytes.NewReader(buf)), nil
+}
+
+func (fo fakeObjects) Stat(_ context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	if _, ok := fo[path.String()]; !ok {
+		return nil, fmt.Errorf("wrap not exist: %w", storage.ErrObjectNotExist)
+	}
+	return &storage.ObjectAttrs{Generation: fo.generation(path.String())}, nil
+}
+
+func (fo fakeObjects) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, _ string) error {
+	fo[path.String()] = buf
+	return nil
+}
+
+// generation changes along with the contents of the object.
+func (fo fakeObjects) generation(path string) int64 {
+	return int64(crc32.ChecksumIEEE(fo[path])) + 1
+}
+
+func (fo fakeObjects) put(t *testing.T, path string, msg proto.Message) {
+	buf, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	fo[path] = buf
+}
+
+func (fo fakeObjects) putGrid(t *testing.T, path string, grid *statepb.Grid) {
+	buf, err := proto.Marshal(grid)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	if _, err := zw.Write(buf); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	fo[path] = zbuf.Bytes()
+}
+
+func testServer(t *testing.T, objects fakeObjects) *Server {
+	return testClientServer(t, objects, objects)
+}
+
+// testClientServer returns a server reading the objects through the client.
+func testClientServer(t *testing.T, objects fakeObjects, client Client) *Server {
+	objects.put(t, "gs://bucket/config", &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}, {Name: "missing"}},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "second",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tab", TestGroupName: "group", Description: "hello", DocumentationUrl: "https://docs/tab"},
+					{Name: "missing", TestGroupName: "missing"},
+				},
+				Ownership: &configpb.Ownership{Owners: []string{"bob"}},
+			},
+			{
+				Name: "first",
+			},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{
+				Name:           "dashboard-group",
+				DashboardNames: []string{"first"},
+				Ownership:      &configpb.Ownership{Owners: []string{"alice"}},
+			},
+		},
+	})
+	path, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("bad path: %v", err)
+	}
+	s, err := NewServer(context.Background(), client, *path, 0, "grid", "summary", "triggers", time.Millisecond, 10)
+	if err != nil {
+		t.Fatalf("NewServer() got unexpected error: %v", err)
+	}
+	return s
+}
+
+func TestListDashboards(t *testing.T) {
+	cases := []struct {
+		name     string
+		req      *apipb.ListDashboardsRequest
+		expected *apipb.ListDashboardsResponse
+		code     codes.Code
+	}{
+		{
+			name: "all dashboards",
+			req:  &apipb.ListDashboardsRequest{},
+			expected: &apipb.ListDashboardsResponse{
+				Dashboards: []*apipb.DashboardResource{
+					{
+						Name:            "first",
+						DashboardGroups: []string{"dashboard-group"},
+						Ownership:       &configpb.Ownership{Owners: []string{"alice"}},
+					},
+					{
+						Name:      "second",
+						Ownership: &configpb.Ownership{Owners: []string{"bob"}},
+						TabNames:  []string{"tab", "missing"},
+					},
+				},
+			},
+		},
+		{
+			name: "dashboard group",
+			req:  &apipb.ListDashboardsRequest{DashboardGroup: "dashboard-group"},
+			expected: &apipb.ListDashboardsResponse{
+				Dashboards: []*apipb.DashboardResource{
+					{
+						Name:            "first",
+						DashboardGroups: []string{"dashboard-group"},
+						Ownership:       &configpb.Ownership{Owners: []string{"alice"}},
+					},
+				},
+			},
+		},
+		{
+			name: "missing dashboard group",
+			req:  &apipb.ListDashboardsRequest{DashboardGroup: "nope"},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testServer(t, fakeObjects{})
+			got, err := s.ListDashboards(context.Background(), tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("ListDashboards() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ListDashboards() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListTabs(t *testing.T) {
+	cases := []struct {
+		name     string
+		req      *apipb.ListTabsRequest
+		expected *apipb.ListTabsResponse
+		code     codes.Code
+	}{
+		{
+			name: "tabs",
+			req:  &apipb.ListTabsRequest{Dashboard: "second"},
+			expected: &apipb.ListTabsResponse{
+				Tabs: []*apipb.TabResource{
+					{Name: "tab", TestGroupName: "group", Description: "hello", DocumentationUrl: "https://docs/tab"},
+					{Name: "missing", TestGroupName: "missing"},
+				},
+			},
+		},
+		{
+			name:     "no tabs",
+			req:      &apipb.ListTabsRequest{Dashboard: "first"},
+			expected: &apipb.ListTabsResponse{},
+		},
+		{
+			name: "missing dashboard",
+			req:  &apipb.ListTabsRequest{Dashboard: "nope"},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testServer(t, fakeObjects{})
+			got, err := s.ListTabs(context.Background(), tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("ListTabs() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ListTabs() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTabState(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "a", Results: []int32{1, 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+			{Name: "b", Results: []int32{1, 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+		},
+	}
+	cases := []struct {
+		name     string
+		req      *apipb.GetTabStateRequest
+		expected *apipb.GetTabStateResponse
+		code     codes.Code
+	}{
+		{
+			name: "full grid",
+			req:  &apipb.GetTabStateRequest{Dashboard: "second", Tab: "tab"},
+			expected: &apipb.GetTabStateResponse{
+				Grid:         grid,
+				TotalColumns: 2,
+				TotalRows:    2,
+			},
+		},
+		{
+			name: "page",
+			req:  &apipb.GetTabStateRequest{Dashboard: "second", Tab: "tab", ColumnLimit: 1, RowOffset: 1},
+			expected: &apipb.GetTabStateResponse{
+				Grid: &statepb.Grid{
+					Columns: []*statepb.Column{{Build: "2"}},
+					Rows: []*statepb.Row{
+						{Name: "b", Results: []int32{1, 1}, CellIds: []string{""}, Messages: []string{""}, Icons: []string{""}},
+					},
+				},
+				TotalColumns: 2,
+				TotalRows:    2,
+			},
+		},
+		{
+			name: "column headers",
+			req: &apipb.GetTabStateRequest{
+				Dashboard: "second",
+				Tab:       "tab",
+				ReadMask:  &field_mask.FieldMask{Paths: []string{"grid.columns", "total_rows"}},
+			},
+			expected: &apipb.GetTabStateResponse{
+				Grid:      &statepb.Grid{Columns: grid.Columns},
+				TotalRows: 2,
+			},
+		},
+		{
+			name: "row results",
+			req: &apipb.GetTabStateRequest{
+				Dashboard: "second",
+				Tab:       "tab",
+				RowRegex:  "^a$",
+				ReadMask:  &field_mask.FieldMask{Paths: []string{"grid.rows.name", "grid.rows.results"}},
+			},
+			expected: &apipb.GetTabStateResponse{
+				Grid: &statepb.Grid{
+					Rows: []*statepb.Row{{Name: "a", Results: []int32{1, 2}}},
+				},
+			},
+		},
+		{
+			name: "bad mask",
+			req: &apipb.GetTabStateRequest{
+				Dashboard: "second",
+				Tab:       "tab",
+				ReadMask:  &field_mask.FieldMask{Paths: []string{"grid.nope"}},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "negative offset",
+			req:  &apipb.GetTabStateRequest{Dashboard: "second", Tab: "tab", RowOffset: -1},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "missing dashboard",
+			req:  &apipb.GetTabStateRequest{Dashboard: "nope", Tab: "tab"},
+			code: codes.NotFound,
+		},
+		{
+			name: "missing tab",
+			req:  &apipb.GetTabStateRequest{Dashboard: "second", Tab: "nope"},
+			code: codes.NotFound,
+		},
+		{
+			name: "missing state",
+			req:  &apipb.GetTabStateRequest{Dashboard: "second", Tab: "missing"},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := fakeObjects{}
+			objects.putGrid(t, "gs://bucket/grid/group", grid)
+			s := testServer(t, objects)
+			got, err := s.GetTabState(context.Background(), tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("GetTabState() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("GetTabState() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{{DashboardName: "second", DashboardTabName: "tab"}},
+	}
+	cases := []struct {
+		name     string
+		req      *apipb.GetSummaryRequest
+		expected *apipb.GetSummaryResponse
+		code     codes.Code
+	}{
+		{
+			name:     "summary",
+			req:      &apipb.GetSummaryRequest{Dashboard: "second"},
+			expected: &apipb.GetSummaryResponse{Summary: sum},
+		},
+		{
+			name: "missing summary",
+			req:  &apipb.GetSummaryRequest{Dashboard: "first"},
+			code: codes.NotFound,
+		},
+		{
+			name: "missing dashboard",
+			req:  &apipb.GetSummaryRequest{Dashboard: "nope"},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := fakeObjects{}
+			objects.put(t, "gs://bucket/summary/summary-second", sum)
+			s := testServer(t, objects)
+			got, err := s.GetSummary(context.Background(), tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("GetSummary() got code %v, want %v: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("GetSummary() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type countingObjects struct {
+	fakeObjects
+	opens map[string]int
+}
+
+func (co countingObjects) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, error) {
+	co.opens[path.String()]++
+	return co.fakeObjects.Open(ctx, path)
+}
+
+func TestGetTabStateCache(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "a", Results: []int32{1, 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+		},
+	}
+	const gridPath = "gs://bucket/grid/group"
+	objects := countingObjects{fakeObjects{}, map[string]int{}}
+	objects.putGrid(t, gridPath, grid)
+	s := testClientServer(t, objects.fakeObjects, objects)
+	ctx := context.Background()
+	get := func(req *apipb.GetTabStateRequest) *statepb.Grid {
+		resp, err := s.GetTabState(ctx, req)
+		if err != nil {
+			t.Fatalf("GetTabState() got unexpected error: %v", err)
+		}
+		return resp.Grid
+	}
+
+	get(&apipb.GetTabStateRequest{Dashboard: "second", Tab: "tab", ColumnLimit: 1})
+	if diff := cmp.Diff(grid, get(&apipb.GetTabStateRequest{Dashboard: "second", Tab: "tab"}), protocmp.Transform()); diff != "" {
+		t.Errorf("GetTabState() got unexpected diff after paging the cached grid (-want +got):\n%s", diff)
+	}
+	if n := objects.opens[gridPath]; n != 1 {
+		t.Errorf("GetTabState() read the unchanged grid %d times, want once", n)
+	}
+
+	grid.Columns = grid.Columns[:1]
+	grid.Rows[0].Results = []int32{1, 1}
+	objects.putGrid(t, gridPath, grid)
+	if diff := cmp.Diff(grid.Columns, get(&apipb.GetTabStateRequest{Dashboard: "second", Tab: "tab"}).Columns, protocmp.Transform()); diff != "" {
+		t.Errorf("GetTabState() got unexpected diff after changing the grid (-want +got):\n%s", diff)
+	}
+	if n := objects.opens[gridPath]; n != 2 {
+		t.Errorf("GetTabState() read the changed grid %d times, want twice", n)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/trigger"
+)
+
+// TriggerUpdate asks the updater to update the test group of the tab.
+func (s *Server) TriggerUpdate(ctx context.Context, req *apipb.TriggerUpdateRequest) (*apipb.TriggerUpdateResponse, error) {
+	requester, err := s.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _ := s.config(ctx)
+	dash, err := findDashboard(cfg, req.GetDashboard())
+	if err != nil {
+		return nil, err
+	}
+	tab, err := findTab(dash, req.GetTab())
+	if err != nil {
+		return nil, err
+	}
+	if err := trigger.Write(ctx, s.client, s.configPath, s.triggerPrefix, trigger.Update, tab.TestGroupName, requester); err != nil {
+		return nil, status.Errorf(codes.Internal, "trigger update of %q: %v", tab.TestGroupName, err)
+	}
+	return &apipb.TriggerUpdateResponse{TestGroupName: tab.TestGroupName}, nil
+}
+
+// TriggerSummary asks the summarizer to summarize the dashboard.
+func (s *Server) TriggerSummary(ctx context.Context, req *apipb.TriggerSummaryRequest) (*apipb.TriggerSummaryResponse, error) {
+	requester, err := s.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _ := s.config(ctx)
+	dash, err := findDashboard(cfg, req.GetDashboard())
+	if err != nil {
+		return nil, err
+	}
+	if err := trigger.Write(ctx, s.client, s.configPath, s.triggerPrefix, trigger.Summary, dash.Name, requester); err != nil {
+		return nil, status.Errorf(codes.Internal, "trigger summary of %q: %v", dash.Name, err)
+	}
+	return &apipb.TriggerSummaryResponse{}, nil
+}
+
+// requester returns the name of the authenticated user, once the server may write triggers.
+func (s *Server) requester(ctx context.Context) (string, error) {
+	if s.triggerPrefix == "" {
+		return "", status.Error(codes.Unimplemented, "triggers are disabled")
+	}
+	claims := ClaimsFrom(ctx)
+	if claims == nil {
+		return "", status.Error(codes.Unauthenticated, "triggers require an authenticated user")
+	}
+	return userName(claims), nil
+}
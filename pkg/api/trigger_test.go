@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/trigger"
+)
+
+func TestTriggerUpdate(t *testing.T) {
+	alice := &Claims{Subject: "123", Email: "alice@example.com"}
+	cases := []struct {
+		name     string
+		disabled bool
+		claims   *Claims
+		req      *apipb.TriggerUpdateRequest
+		expected *apipb.TriggerUpdateResponse
+		code     codes.Code
+		path     string
+	}{
+		{
+			name:     "basic",
+			claims:   alice,
+			req:      &apipb.TriggerUpdateRequest{Dashboard: "second", Tab: "tab"},
+			expected: &apipb.TriggerUpdateResponse{TestGroupName: "group"},
+			path:     "gs://bucket/triggers/update/group",
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			claims:   alice,
+			req:      &apipb.TriggerUpdateRequest{Dashboard: "second", Tab: "tab"},
+			code:     codes.Unimplemented,
+		},
+		{
+			name: "anonymous",
+			req:  &apipb.TriggerUpdateRequest{Dashboard: "second", Tab: "tab"},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:   "missing dashboard",
+			claims: alice,
+			req:    &apipb.TriggerUpdateRequest{Dashboard: "nope", Tab: "tab"},
+			code:   codes.NotFound,
+		},
+		{
+			name:   "missing tab",
+			claims: alice,
+			req:    &apipb.TriggerUpdateRequest{Dashboard: "second", Tab: "nope"},
+			code:   codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := fakeObjects{}
+			s := testServer(t, objects)
+			if tc.disabled {
+				s.triggerPrefix = ""
+			}
+			ctx := context.Background()
+			if tc.claims != nil {
+				ctx = WithClaims(ctx, tc.claims)
+			}
+			got, err := s.TriggerUpdate(ctx, tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("TriggerUpdate() got code %s, want %s: %v", code, tc.code, err)
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("TriggerUpdate() got unexpected diff (-want +got):\n%s", diff)
+			}
+			checkTrigger(t, objects, tc.path, "alice@example.com")
+		})
+	}
+}
+
+func TestTriggerSummary(t *testing.T) {
+	bob := &Claims{Subject: "456"}
+	cases := []struct {
+		name     string
+		disabled bool
+		claims   *Claims
+		req      *apipb.TriggerSummaryRequest
+		code     codes.Code
+		path     string
+	}{
+		{
+			name:   "basic",
+			claims: bob,
+			req:    &apipb.TriggerSummaryRequest{Dashboard: "first"},
+			path:   "gs://bucket/triggers/summary/first",
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			claims:   bob,
+			req:      &apipb.TriggerSummaryRequest{Dashboard: "first"},
+			code:     codes.Unimplemented,
+		},
+		{
+			name: "anonymous",
+			req:  &apipb.TriggerSummaryRequest{Dashboard: "first"},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:   "missing dashboard",
+			claims: bob,
+			req:    &apipb.TriggerSummaryRequest{Dashboard: "nope"},
+			code:   codes.NotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := fakeObjects{}
+			s := testServer(t, objects)
+			if tc.disabled {
+				s.triggerPrefix = ""
+			}
+			ctx := context.Background()
+			if tc.claims != nil {
+				ctx = WithClaims(ctx, tc.claims)
+			}
+			_, err := s.TriggerSummary(ctx, tc.req)
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("TriggerSummary() got code %s, want %s: %v", code, tc.code, err)
+			}
+			checkTrigger(t, objects, tc.path, "456")
+		})
+	}
+}
+
+// checkTrigger fails unless the objects hold a trigger by the requester at the path, or no triggers when path is empty.
+func checkTrigger(t *testing.T, objects fakeObjects, path, requester string) {
+	t.Helper()
+	if path == "" {
+		if len(objects) > 1 {
+			t.Errorf("wrote unexpected objects: %v", objects)
+		}
+		return
+	}
+	buf, ok := objects[path]
+	if !ok {
+		t.Fatalf("failed to write %s, got %v", path, objects)
+	}
+	var got trigger.Trigger
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("wrote an invalid trigger %q: %v", buf, err)
+	}
+	if got.Requester != requester {
+		t.Errorf("wrote requester %q, want %q", got.Requester, requester)
+	}
+	if time.Since(got.Time) > time.Minute {
+		t.Errorf("wrote stale time %s", got.Time)
+	}
+}
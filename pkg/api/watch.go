@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// WatchDashboard streams an event whenever the summary or grid of a tab changes.
+//
+// Checks the generations of these objects once per watch interval,
+// starting with those that exist when the stream starts.
+func (s *Server) WatchDashboard(req *apipb.WatchDashboardRequest, stream apipb.TestGridData_WatchDashboardServer) error {
+	ctx := stream.Context()
+	cfg, _ := s.config(ctx)
+	if _, err := findDashboard(cfg, req.GetDashboard()); err != nil {
+		return err
+	}
+	log := logrus.WithField("dashboard", req.GetDashboard())
+	var w dashboardWatch
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+	for {
+		cfg, _ := s.config(ctx)
+		dash, err := findDashboard(cfg, req.GetDashboard())
+		if err != nil {
+			return err
+		}
+		events, err := s.poll(ctx, dash, &w)
+		if err != nil {
+			log.WithError(err).Warning("Failed to check for changes")
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// dashboardWatch holds the state of a dashboard as of the last poll.
+type dashboardWatch struct {
+	started    bool
+	summaryGen int64
+	summaries  map[string]*summarypb.DashboardTabSummary
+	grids      map[string]int64
+}
+
+// poll returns the events of the tabs whose summary or grid changed since the last poll.
+//
+// Records the initial state without any events during the first poll.
+func (s *Server) poll(ctx context.Context, dash *configpb.Dashboard, w *dashboardWatch) ([]*apipb.DashboardEvent, error) {
+	var events []*apipb.DashboardEvent
+	first := !w.started
+	w.started = true
+
+	summaryPath, err := s.configPath.ResolveReference(&url.URL{Path: path.Join(s.summaryPathPrefix, summarizer.SummaryPath(dash.Name))})
+	if err != nil {
+		return nil, err
+	}
+	gen, err := generation(ctx, s.client, *summaryPath)
+	if err != nil {
+		return nil, err
+	}
+	if gen != w.summaryGen {
+		sum, err := summarizer.ReadSummary(ctx, s.client, *summaryPath)
+		if err != nil {
+			return nil, err
+		}
+		summaries := map[string]*summarypb.DashboardTabSummary{}
+		for _, tab := range sum.GetTabSummaries() {
+			summaries[tab.DashboardTabName] = tab
+			if first || proto.Equal(tab, w.summaries[tab.DashboardTabName]) {
+				continue
+			}
+			events = append(events, &apipb.DashboardEvent{
+				Kind:       apipb.DashboardEvent_TAB_SUMMARY,
+				Dashboard:  dash.Name,
+				Tab:        tab.DashboardTabName,
+				Generation: gen,
+				TabSummary: tab,
+			})
+		}
+		w.summaryGen = gen
+		w.summaries = summaries
+	}
+
+	groups := map[string]int64{}
+	grids := map[string]int64{}
+	for _, tab := range dash.DashboardTab {
+		gen, ok := groups[tab.TestGroupName]
+		if !ok {
+			gridPath, err := s.configPath.ResolveReference(&url.URL{Path: path.Join(s.gridPathPrefix, tab.TestGroupName)})
+			if err != nil {
+				return events, err
+			}
+			if gen, err = generation(ctx, s.client, *gridPath); err != nil {
+				return events, err
+			}
+			groups[tab.TestGroupName] = gen
+		}
+		grids[tab.Name] = gen
+		if first || gen == 0 || gen == w.grids[tab.Name] {
+			continue
+		}
+		events = append(events, &apipb.DashboardEvent{
+			Kind:       apipb.DashboardEvent_GRID,
+			Dashboard:  dash.Name,
+			Tab:        tab.Name,
+			Generation: gen,
+		})
+	}
+	w.grids = grids
+	return events, nil
+}
+
+// generation returns the generation of the object, or zero when it does not exist.
+func generation(ctx context.Context, stater gcs.Stater, path gcs.Path) (int64, error) {
+	attrs, err := stater.Stat(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return attrs.Generation, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apipb "github.com/GoogleCloudPlatform/testgrid/pb/api"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestPoll(t *testing.T) {
+	tabSummary := func(name string, status summarypb.DashboardTabSummary_TabStatus) *summarypb.DashboardTabSummary {
+		return &summarypb.DashboardTabSummary{DashboardName: "second", DashboardTabName: name, OverallStatus: status}
+	}
+	before := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			tabSummary("tab", summarypb.DashboardTabSummary_PASS),
+			tabSummary("missing", summarypb.DashboardTabSummary_STALE),
+		},
+	}
+	grid := &statepb.Grid{Columns: []*statepb.Column{{Build: "1"}}}
+	newGrid := &statepb.Grid{Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}}}
+	cases := []struct {
+		name     string
+		change   func(t *testing.T, objects fakeObjects)
+		expected func(objects fakeObjects) []*apipb.DashboardEvent
+	}{
+		{
+			name:   "unchanged",
+			change: func(*testing.T, fakeObjects) {},
+		},
+		{
+			name: "rewrite same summary",
+			change: func(t *testing.T, objects fakeObjects) {
+				objects.put(t, "gs://bucket/summary/summary-second", &summarypb.DashboardSummary{
+					TabSummaries: before.TabSummaries,
+					Ownership:    &summarypb.DashboardOwnership{Team: "new"},
+				})
+			},
+		},
+		{
+			name: "change tab summary",
+			change: func(t *testing.T, objects fakeObjects) {
+				objects.put(t, "gs://bucket/summary/summary-second", &summarypb.DashboardSummary{
+					TabSummaries: []*summarypb.DashboardTabSummary{
+						tabSummary("tab", summarypb.DashboardTabSummary_FAIL),
+						tabSummary("missing", summarypb.DashboardTabSummary_STALE),
+					},
+				})
+			},
+			expected: func(objects fakeObjects) []*apipb.DashboardEvent {
+				return []*apipb.DashboardEvent{
+					{
+						Kind:       apipb.DashboardEvent_TAB_SUMMARY,
+						Dashboard:  "second",
+						Tab:        "tab",
+						Generation: objects.generation("gs://bucket/summary/summary-second"),
+						TabSummary: tabSummary("tab", summarypb.DashboardTabSummary_FAIL),
+					},
+				}
+			},
+		},
+		{
+			name: "change grid",
+			change: func(t *testing.T, objects fakeObjects) {
+				objects.putGrid(t, "gs://bucket/grid/group", newGrid)
+			},
+			expected: func(objects fakeObjects) []*apipb.DashboardEvent {
+				return []*apipb.DashboardEvent{
+					{
+						Kind:       apipb.DashboardEvent_GRID,
+						Dashboard:  "second",
+						Tab:        "tab",
+						Generation: objects.generation("gs://bucket/grid/group"),
+					},
+				}
+			},
+		},
+		{
+			name: "create grid",
+			change: func(t *testing.T, objects fakeObjects) {
+				objects.putGrid(t, "gs://bucket/grid/missing", newGrid)
+			},
+			expected: func(objects fakeObjects) []*apipb.DashboardEvent {
+				return []*apipb.DashboardEvent{
+					{
+						Kind:       apipb.DashboardEvent_GRID,
+						Dashboard:  "second",
+						Tab:        "missing",
+						Generation: objects.generation("gs://bucket/grid/missing"),
+					},
+				}
+			},
+		},
+		{
+			name: "delete grid",
+			change: func(t *testing.T, objects fakeObjects) {
+				delete(objects, "gs://bucket/grid/group")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			objects := fakeObjects{}
+			objects.put(t, "gs://bucket/summary/summary-second", before)
+			objects.putGrid(t, "gs://bucket/grid/group", grid)
+			s := testServer(t, objects)
+			cfg, _ := s.config(ctx)
+			dash := cfg.Dashboards[0]
+			var w dashboardWatch
+			events, err := s.poll(ctx, dash, &w)
+			if err != nil {
+				t.Fatalf("first poll() got unexpected error: %v", err)
+			}
+			if len(events) > 0 {
+				t.Fatalf("first poll() got unexpected events: %v", events)
+			}
+			tc.change(t, objects)
+			got, err := s.poll(ctx, dash, &w)
+			if err != nil {
+				t.Fatalf("poll() got unexpected error: %v", err)
+			}
+			var want []*apipb.DashboardEvent
+			if tc.expected != nil {
+				want = tc.expected(objects)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("poll() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	rec := httptest.NewRecorder()
+	stream := eventStream{ctx: context.Background(), w: rec, flusher: rec}
+	err := stream.Send(&apipb.DashboardEvent{
+		Kind:       apipb.DashboardEvent_GRID,
+		Dashboard:  "dash",
+		Tab:        "tab",
+		Generation: 7,
+	})
+	if err != nil {
+		t.Fatalf("Send() got unexpected error: %v", err)
+	}
+	want := "event: GRID\ndata: {\"kind\":\"GRID\",\"dashboard\":\"dash\",\"tab\":\"tab\",\"generation\":\"7\"}\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("Send() wrote %q, want %q", got, want)
+	}
+	if !rec.Flushed {
+		t.Error("Send() failed to flush")
+	}
+}
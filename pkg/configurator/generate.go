@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configurator generates test groups and dashboard tabs from Prow jobs.
+package configurator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Annotations of Prow jobs that control their test groups and tabs.
+const (
+	DashboardsAnnotation             = "testgrid-dashboards"
+	TabNameAnnotation                = "testgrid-tab-name"
+	AlertEmailAnnotation             = "testgrid-alert-email"
+	DescriptionAnnotation            = "description"
+	NumColumnsRecentAnnotation       = "testgrid-num-columns-recent"
+	NumFailuresToAlertAnnotation     = "testgrid-num-failures-to-alert"
+	AlertStaleResultsHoursAnnotation = "testgrid-alert-stale-results-hours"
+	DaysOfResultsAnnotation          = "testgrid-days-of-results"
+	CreateTestGroupAnnotation        = "testgrid-create-test-group"
+)
+
+// Rule adds jobs to dashboards by name, when the job does not list its own dashboards.
+type Rule struct {
+	// Match is a regular expression for the job name.
+	Match      string   `json:"match"`
+	Dashboards []string `json:"dashboards"`
+}
+
+// Options control the test groups and tabs generated for jobs.
+type Options struct {
+	// Bucket holding the results of jobs that do not set their own.
+	Bucket string
+	// Rules choosing the dashboards of jobs without a testgrid-dashboards annotation.
+	Rules []Rule
+	// Defaults for jobs without the corresponding annotation.
+	DaysOfResults      int32
+	NumColumnsRecent   int32
+	NumFailuresToAlert int32
+}
+
+// DefaultOptions returns the defaults documented for Prow job annotations.
+func DefaultOptions() Options {
+	return Options{
+		DaysOfResults:      15,
+		NumColumnsRecent:   10,
+		NumFailuresToAlert: 3,
+	}
+}
+
+type rule struct {
+	match      *regexp.Regexp
+	dashboards []string
+}
+
+// Generate adds a test group and dashboard tabs for each job to a copy of the base configuration.
+//
+// Keeps existing test groups and tabs of the same name, so generating from its own
+// output is a no-op. Creates dashboards that do not exist yet.
+// Skips jobs with invalid annotations, returning an error describing them along with the result.
+func Generate(base *configpb.Configuration, jobs *JobConfig, opts Options) (*configpb.Configuration, error) {
+	cfg := &configpb.Configuration{}
+	if base != nil {
+		cfg = proto.Clone(base).(*configpb.Configuration)
+	}
+	var rules []rule
+	for _, r := range opts.Rules {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			return nil, fmt.Errorf("bad rule %q: %w", r.Match, err)
+		}
+		rules = append(rules, rule{re, r.Dashboards})
+	}
+
+	var mErr error
+	add := func(job Job, presubmit bool) {
+		if err := addJob(cfg, job, presubmit, rules, opts); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s: %w", job.Name, err))
+		}
+	}
+	for _, job := range jobs.Periodics {
+		add(job, false)
+	}
+	for _, repo := range sortedKeys(jobs.Postsubmits) {
+		for _, job := range jobs.Postsubmits[repo] {
+			add(job, false)
+		}
+	}
+	for _, repo := range sortedKeys(jobs.Presubmits) {
+		for _, job := range jobs.Presubmits[repo] {
+			add(job, true)
+		}
+	}
+	return cfg, mErr
+}
+
+func sortedKeys(m map[string][]Job) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dashboards returns the dashboards listed by the job, or else those of every rule matching its name.
+func dashboards(job Job, rules []rule) []string {
+	var out []string
+	for _, d := range strings.Split(job.Annotations[DashboardsAnnotation], ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			out = append(out, d)
+		}
+	}
+	if len(out) > 0 {
+		return out
+	}
+	for _, r := range rules {
+		if r.match.MatchString(job.Name) {
+			out = append(out, r.dashboards...)
+		}
+	}
+	return out
+}
+
+// intAnnotation returns the value of the annotation, or else the default.
+func intAnnotation(job Job, name string, def int32) (int32, error) {
+	v, ok := job.Annotations[name]
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("bad %s: %w", name, err)
+	}
+	return int32(n), nil
+}
+
+func gcsPrefix(job Job, presubmit bool, bucket string) string {
+	if b := job.bucket(); b != "" {
+		bucket = b
+	}
+	bucket = strings.TrimSuffix(strings.TrimPrefix(bucket, "gs://"), "/")
+	if presubmit {
+		return bucket + "/pr-logs/directory/" + job.Name
+	}
+	return bucket + "/logs/" + job.Name
+}
+
+func addJob(cfg *configpb.Configuration, job Job, presubmit bool, rules []rule, opts Options) error {
+	dashes := dashboards(job, rules)
+	if len(dashes) == 0 && job.Annotations[CreateTestGroupAnnotation] != "true" {
+		return nil
+	}
+	days, err := intAnnotation(job, DaysOfResultsAnnotation, opts.DaysOfResults)
+	if err != nil {
+		return err
+	}
+	recent, err := intAnnotation(job, NumColumnsRecentAnnotation, opts.NumColumnsRecent)
+	if err != nil {
+		return err
+	}
+	failures, err := intAnnotation(job, NumFailuresToAlertAnnotation, opts.NumFailuresToAlert)
+	if err != nil {
+		return err
+	}
+	stale, err := intAnnotation(job, AlertStaleResultsHoursAnnotation, 0)
+	if err != nil {
+		return err
+	}
+
+	if config.FindTestGroup(job.Name, cfg) == nil {
+		cfg.TestGroups = append(cfg.TestGroups, &configpb.TestGroup{
+			Name:             job.Name,
+			GcsPrefix:        gcsPrefix(job, presubmit, opts.Bucket),
+			DaysOfResults:    days,
+			NumColumnsRecent: recent,
+		})
+	}
+
+	tabName := job.Annotations[TabNameAnnotation]
+	if tabName == "" {
+		tabName = job.Name
+	}
+	description := job.Annotations[DescriptionAnnotation]
+	if description == "" {
+		description = job.Name
+	}
+	for i, name := range dashes {
+		dash := config.FindDashboard(name, cfg)
+		if dash == nil {
+			dash = &configpb.Dashboard{Name: name}
+			cfg.Dashboards = append(cfg.Dashboards, dash)
+		}
+		if hasTab(dash, tabName) {
+			continue
+		}
+		tab := &configpb.DashboardTab{
+			Name:             tabName,
+			TestGroupName:    job.Name,
+			Description:      description,
+			NumColumnsRecent: recent,
+			AlertOptions: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert:     failures,
+				AlertStaleResultsHours: stale,
+			},
+		}
+		if i == 0 {
+			// Only alert on the first dashboard.
+			tab.AlertOptions.AlertMailToAddresses = job.Annotations[AlertEmailAnnotation]
+		}
+		dash.DashboardTab = append(dash.DashboardTab, tab)
+	}
+	return nil
+}
+
+func hasTab(dash *configpb.Dashboard, name string) bool {
+	for _, tab := range dash.DashboardTab {
+		if tab.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configurator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestGenerate(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Bucket = "gs://results/"
+	cases := []struct {
+		name     string
+		base     *configpb.Configuration
+		jobs     JobConfig
+		rules    []Rule
+		expected *configpb.Configuration
+		err      bool
+	}{
+		{
+			name:     "basically works",
+			expected: &configpb.Configuration{},
+		},
+		{
+			name: "skip jobs without dashboards",
+			jobs: JobConfig{
+				Periodics: []Job{{Name: "ignored"}},
+			},
+			expected: &configpb.Configuration{},
+		},
+		{
+			name: "annotated jobs",
+			jobs: JobConfig{
+				Periodics: []Job{
+					{
+						Name: "ci-foo",
+						Annotations: map[string]string{
+							DashboardsAnnotation:             "first, second",
+							TabNameAnnotation:                "foo",
+							AlertEmailAnnotation:             "team@example.com",
+							DescriptionAnnotation:            "Foo periodically",
+							NumFailuresToAlertAnnotation:     "1",
+							AlertStaleResultsHoursAnnotation: "12",
+						},
+					},
+				},
+				Presubmits: map[string][]Job{
+					"org/repo": {
+						{
+							Name: "pull-foo",
+							Annotations: map[string]string{
+								CreateTestGroupAnnotation: "true",
+								DaysOfResultsAnnotation:   "30",
+							},
+						},
+					},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:             "ci-foo",
+						GcsPrefix:        "results/logs/ci-foo",
+						DaysOfResults:    15,
+						NumColumnsRecent: 10,
+					},
+					{
+						Name:             "pull-foo",
+						GcsPrefix:        "results/pr-logs/directory/pull-foo",
+						DaysOfResults:    30,
+						NumColumnsRecent: 10,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "first",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "foo",
+								TestGroupName:    "ci-foo",
+								Description:      "Foo periodically",
+								NumColumnsRecent: 10,
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert:     1,
+									AlertStaleResultsHours: 12,
+									AlertMailToAddresses:   "team@example.com",
+								},
+							},
+						},
+					},
+					{
+						Name: "second",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "foo",
+								TestGroupName:    "ci-foo",
+								Description:      "Foo periodically",
+								NumColumnsRecent: 10,
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert:     1,
+									AlertStaleResultsHours: 12,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "naming rules and job buckets",
+			jobs: JobConfig{
+				Postsubmits: map[string][]Job{
+					"org/repo": {
+						{
+							Name: "post-repo-build",
+							DecorationConfig: &DecorationConfig{
+								GCSConfiguration: &GCSConfiguration{Bucket: "other"},
+							},
+						},
+					},
+				},
+			},
+			rules: []Rule{
+				{Match: "^post-repo-", Dashboards: []string{"repo-postsubmits"}},
+				{Match: "^pull-", Dashboards: []string{"repo-presubmits"}},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:             "post-repo-build",
+						GcsPrefix:        "other/logs/post-repo-build",
+						DaysOfResults:    15,
+						NumColumnsRecent: 10,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "repo-postsubmits",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "post-repo-build",
+								TestGroupName:    "post-repo-build",
+								Description:      "post-repo-build",
+								NumColumnsRecent: 10,
+								AlertOptions: &configpb.DashboardTabAlertOptions{
+									NumFailuresToAlert: 3,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "keep existing groups and tabs",
+			base: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "ci-foo", GcsPrefix: "hand/written"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "ci-foo", TestGroupName: "ci-foo"},
+						},
+					},
+				},
+			},
+			jobs: JobConfig{
+				Periodics: []Job{
+					{
+						Name:        "ci-foo",
+						Annotations: map[string]string{DashboardsAnnotation: "dash"},
+					},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "ci-foo", GcsPrefix: "hand/written"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "ci-foo", TestGroupName: "ci-foo"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "skip bad annotations",
+			jobs: JobConfig{
+				Periodics: []Job{
+					{
+						Name: "ci-bad",
+						Annotations: map[string]string{
+							DashboardsAnnotation:       "dash",
+							NumColumnsRecentAnnotation: "many",
+						},
+					},
+				},
+			},
+			expected: &configpb.Configuration{},
+			err:      true,
+		},
+		{
+			name: "reject bad rules",
+			rules: []Rule{
+				{Match: "(", Dashboards: []string{"dash"}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := opts
+			opts.Rules = tc.rules
+			actual, err := Generate(tc.base, &tc.jobs, opts)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Generate() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Generate() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("Generate() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateIdempotent(t *testing.T) {
+	jobs := &JobConfig{
+		Periodics: []Job{
+			{
+				Name:        "ci-foo",
+				Annotations: map[string]string{DashboardsAnnotation: "dash"},
+			},
+		},
+	}
+	first, err := Generate(nil, jobs, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Generate() got unexpected error: %v", err)
+	}
+	second, err := Generate(first, jobs, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Generate() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(first, second, protocmp.Transform()); diff != "" {
+		t.Errorf("Generate() changed its own output (-first +second):\n%s", diff)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configurator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+)
+
+// Job is the subset of a Prow job used to generate its test group.
+type Job struct {
+	Name             string            `json:"name"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
+	DecorationConfig *DecorationConfig `json:"decoration_config,omitempty"`
+}
+
+// DecorationConfig is the subset of a Prow job's decoration_config describing where it uploads results.
+type DecorationConfig struct {
+	GCSConfiguration *GCSConfiguration `json:"gcs_configuration,omitempty"`
+}
+
+// GCSConfiguration describes where a Prow job uploads results.
+type GCSConfiguration struct {
+	Bucket string `json:"bucket,omitempty"`
+}
+
+func (j Job) bucket() string {
+	if dc := j.DecorationConfig; dc != nil && dc.GCSConfiguration != nil {
+		return dc.GCSConfiguration.Bucket
+	}
+	return ""
+}
+
+// JobConfig is the subset of a Prow job config used to generate test groups.
+type JobConfig struct {
+	Periodics   []Job            `json:"periodics,omitempty"`
+	Presubmits  map[string][]Job `json:"presubmits,omitempty"`
+	Postsubmits map[string][]Job `json:"postsubmits,omitempty"`
+}
+
+func (jc *JobConfig) merge(other JobConfig) {
+	jc.Periodics = append(jc.Periodics, other.Periodics...)
+	if jc.Presubmits == nil {
+		jc.Presubmits = map[string][]Job{}
+	}
+	for repo, jobs := range other.Presubmits {
+		jc.Presubmits[repo] = append(jc.Presubmits[repo], jobs...)
+	}
+	if jc.Postsubmits == nil {
+		jc.Postsubmits = map[string][]Job{}
+	}
+	for repo, jobs := range other.Postsubmits {
+		jc.Postsubmits[repo] = append(jc.Postsubmits[repo], jobs...)
+	}
+}
+
+// ReadJobs reads the Prow jobs in the YAML files under each path.
+func ReadJobs(paths ...string) (*JobConfig, error) {
+	var jc JobConfig
+	err := yamlcfg.SeekYAMLFiles(paths, func(path string, _ os.FileInfo) error {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		var cfg JobConfig
+		if err := yaml.Unmarshal(buf, &cfg); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		jc.merge(cfg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &jc, nil
+}
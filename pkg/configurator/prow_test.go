@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configurator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadJobs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configurator")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"periodics.yaml": `
+periodics:
+- name: ci-foo
+  interval: 1h
+  annotations:
+    testgrid-dashboards: foo
+  decoration_config:
+    gcs_configuration:
+      bucket: my-bucket
+`,
+		"org/repo/presubmits.yaml": `
+presubmits:
+  org/repo:
+  - name: pull-repo-test
+    always_run: true
+postsubmits:
+  org/repo:
+  - name: post-repo-push
+`,
+		"README.md": "not a config",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	actual, err := ReadJobs(dir)
+	if err != nil {
+		t.Fatalf("ReadJobs() got unexpected error: %v", err)
+	}
+	expected := &JobConfig{
+		Periodics: []Job{
+			{
+				Name:        "ci-foo",
+				Annotations: map[string]string{DashboardsAnnotation: "foo"},
+				DecorationConfig: &DecorationConfig{
+					GCSConfiguration: &GCSConfiguration{Bucket: "my-bucket"},
+				},
+			},
+		},
+		Presubmits: map[string][]Job{
+			"org/repo": {{Name: "pull-repo-test"}},
+		},
+		Postsubmits: map[string][]Job{
+			"org/repo": {{Name: "post-repo-push"}},
+		},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("ReadJobs() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
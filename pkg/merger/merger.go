@@ -21,12 +21,16 @@ import (
 	"errors"
 	"fmt"
 
+	"cloud.google.com/go/storage"
+
 	"github.com/GoogleCloudPlatform/testgrid/config"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/label"
 	"gopkg.in/yaml.v2"
 )
 
@@ -36,6 +40,8 @@ type MergeList struct {
 	Target  string    `json:"Target"`
 	Path    *gcs.Path `json:"-"`
 	Sources []Source  `json:"Sources"`
+	// Split writes the result as one object per dashboard group plus an index.
+	Split bool `json:"Split,omitempty"`
 }
 
 // Source represents a configuration source in cloud storage
@@ -101,7 +107,9 @@ func MergeAndUpdate(ctx context.Context, client mergeClient, list MergeList, ski
 		if source.Path == nil {
 			return fmt.Errorf("path at %q is nil", source.Name)
 		}
-		cfg, err := config.ReadGCS(ctx, client, *source.Path)
+		sctx, span := tracing.Start(ctx, "merger.read_config", label.String("source", source.Name))
+		cfg, err := config.ReadGCS(sctx, client, *source.Path)
+		tracing.End(span, err)
 		if err != nil {
 			return fmt.Errorf("can't read config %q at %s: %w", source.Name, source.Path, err)
 		}
@@ -115,6 +123,7 @@ func MergeAndUpdate(ctx context.Context, client mergeClient, list MergeList, ski
 				continue
 			}
 		}
+		warnDeprecations(source, cfg)
 		shards[source.Name] = cfg
 	}
 
@@ -129,7 +138,14 @@ func MergeAndUpdate(ctx context.Context, client mergeClient, list MergeList, ski
 	}
 
 	if !confirm {
-		fmt.Println(result)
+		printDiff(ctx, client, *list.Path, result)
+		return nil
+	}
+
+	if list.Split {
+		if err := config.WriteSplit(ctx, client, *list.Path, result); err != nil {
+			return fmt.Errorf("can't write split config to %s: %w", list.Path, err)
+		}
 		return nil
 	}
 
@@ -144,3 +160,33 @@ func MergeAndUpdate(ctx context.Context, client mergeClient, list MergeList, ski
 
 	return nil
 }
+
+// warnDeprecations logs a warning for each deprecated field the source config sets.
+func warnDeprecations(source Source, cfg *configpb.Configuration) {
+	for _, d := range config.Deprecations(cfg) {
+		logrus.WithFields(logrus.Fields{
+			"component":   "config-merger",
+			"config-path": source.Location,
+			"contact":     source.Contact,
+			"field":       d.Field,
+			"count":       d.Count,
+			"locations":   d.Locations,
+		}).Warningf("config %q sets a deprecated field", source.Name)
+	}
+}
+
+// printDiff prints how the result differs from the current config at path.
+func printDiff(ctx context.Context, client gcs.Opener, path gcs.Path, result *configpb.Configuration) {
+	current, err := config.ReadGCS(ctx, client, path)
+	if err != nil {
+		if !errors.Is(err, storage.ErrObjectNotExist) {
+			logrus.WithError(err).WithField("path", path.String()).Warning("Can't read current config; diffing against an empty config")
+		}
+		current = &configpb.Configuration{}
+	}
+	changes := config.Diff(current, result)
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+	fmt.Printf("%d changes to %s\n", len(changes), path)
+}
@@ -168,6 +168,7 @@ func Test_MergeAndUpdate(t *testing.T) {
 		uploadInjectedError error
 		skipValidate        bool
 		confirm             bool
+		split               bool
 		expectError         bool
 		expectUpload        bool
 	}{
@@ -228,6 +229,25 @@ func Test_MergeAndUpdate(t *testing.T) {
 			confirm:             true,
 			expectError:         true,
 		},
+		{
+			name: "Split upload; succeeds",
+			paths: map[string]*gcs.Path{
+				"first": newPathOrDie("gs://valid/config"),
+			},
+			confirm:      true,
+			split:        true,
+			expectUpload: true,
+		},
+		{
+			name: "Split upload fails; fails",
+			paths: map[string]*gcs.Path{
+				"first": newPathOrDie("gs://valid/config"),
+			},
+			uploadInjectedError: errors.New("upload error"),
+			confirm:             true,
+			split:               true,
+			expectError:         true,
+		},
 		{
 			name: "no-confirm; succeeds with no upload",
 			paths: map[string]*gcs.Path{
@@ -291,6 +311,7 @@ func Test_MergeAndUpdate(t *testing.T) {
 				Target:  "gs://result/config",
 				Path:    newPathOrDie("gs://result/config"),
 				Sources: nil,
+				Split:   tc.split,
 			}
 
 			for name, path := range tc.paths {
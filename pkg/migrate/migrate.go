@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migrate copies configs, grids and summaries from one bucket or prefix to another.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Join returns the path of the object at rel under root.
+func Join(root gcs.Path, rel string) (*gcs.Path, error) {
+	return gcs.NewPath(fmt.Sprintf("gs://%s/%s", root.Bucket(), path.Join(root.Object(), rel)))
+}
+
+// Config copies the config at name under from to the same name under to.
+//
+// Rewrites paths under from to the same paths under to, only writing when write is set.
+// Returns the number of values rewritten.
+func Config(ctx context.Context, client gcs.Client, from, to gcs.Path, name string, write bool) (int, error) {
+	src, err := Join(from, name)
+	if err != nil {
+		return 0, fmt.Errorf("source: %w", err)
+	}
+	dst, err := Join(to, name)
+	if err != nil {
+		return 0, fmt.Errorf("destination: %w", err)
+	}
+	cfg, err := config.ReadGCS(ctx, client, *src)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", src, err)
+	}
+	n := config.RewritePaths(cfg, from, to)
+	if !write {
+		return n, nil
+	}
+	buf, err := proto.Marshal(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("marshal: %w", err)
+	}
+	if err := client.Upload(ctx, *dst, buf, gcs.DefaultAcl, "no-cache"); err != nil {
+		return 0, fmt.Errorf("upload %s: %w", dst, err)
+	}
+	return n, nil
+}
+
+// Objects copies every object under each prefix of from to the same path under to.
+//
+// Copies up to concurrency objects at once, only copying when write is set.
+// Returns the number of objects copied (or that would be), along with any error.
+func Objects(ctx context.Context, log logrus.FieldLogger, client gcs.Client, from, to gcs.Path, prefixes []string, concurrency int, write bool) (int64, error) {
+	if concurrency < 1 {
+		return 0, fmt.Errorf("concurrency must be positive, got %d", concurrency)
+	}
+	root := from.Object()
+	if root != "" && !strings.HasSuffix(root, "/") {
+		root += "/"
+	}
+
+	type job struct {
+		src gcs.Path
+		dst gcs.Path
+	}
+	jobs := make(chan job)
+	var copied, failed int64
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				log := log.WithField("from", j.src).WithField("to", j.dst)
+				if !write {
+					log.Debug("Skipping copy")
+					atomic.AddInt64(&copied, 1)
+					continue
+				}
+				if err := client.Copy(ctx, j.src, j.dst); err != nil {
+					log.WithError(err).Error("Failed to copy")
+					atomic.AddInt64(&failed, 1)
+					continue
+				}
+				log.Debug("Copied")
+				atomic.AddInt64(&copied, 1)
+			}
+		}()
+	}
+
+	err := func() error {
+		defer close(jobs)
+		for _, prefix := range prefixes {
+			dir, err := Join(from, prefix)
+			if err != nil {
+				return fmt.Errorf("%s: %w", prefix, err)
+			}
+			it := client.Objects(ctx, *dir, "", "")
+			for {
+				attrs, err := it.Next()
+				if errors.Is(err, iterator.Done) {
+					break
+				}
+				if err != nil {
+					return fmt.Errorf("list %s: %w", dir, err)
+				}
+				src, err := gcs.NewPath(fmt.Sprintf("gs://%s/%s", from.Bucket(), attrs.Name))
+				if err != nil {
+					return fmt.Errorf("source: %w", err)
+				}
+				dst, err := Join(to, strings.TrimPrefix(attrs.Name, root))
+				if err != nil {
+					return fmt.Errorf("destination: %w", err)
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case jobs <- job{*src, *dst}:
+				}
+			}
+		}
+		return nil
+	}()
+	wg.Wait()
+	if err != nil {
+		return copied, err
+	}
+	if failed > 0 {
+		return copied, fmt.Errorf("failed to copy %d objects", failed)
+	}
+	return copied, nil
+}
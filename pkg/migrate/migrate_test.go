@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("NewPath(%q): %v", s, err)
+	}
+	return *p
+}
+
+// files returns the slash-separated paths of the files under dir.
+func files(t *testing.T, dir string) []string {
+	t.Helper()
+	var out []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		out = append(out, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk(%s): %v", dir, err)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestObjects(t *testing.T) {
+	cases := []struct {
+		name     string
+		from     string
+		to       string
+		prefixes []string
+		write    bool
+		copied   int64
+		expected []string
+	}{
+		{
+			name:     "basically works",
+			from:     "gs://old/prefix",
+			to:       "gs://new/dest",
+			prefixes: []string{"grid", "summary"},
+			write:    true,
+			copied:   3,
+			expected: []string{
+				"dest/grid/a",
+				"dest/grid/nested/b",
+				"dest/summary/summary-dash",
+			},
+		},
+		{
+			name:     "bucket root",
+			from:     "gs://old",
+			to:       "gs://new/dest",
+			prefixes: []string{"prefix/tabs"},
+			write:    true,
+			copied:   1,
+			expected: []string{"dest/prefix/tabs/dash/tab"},
+		},
+		{
+			name:     "dry run",
+			from:     "gs://old/prefix",
+			to:       "gs://new/dest",
+			prefixes: []string{"grid", "missing"},
+			copied:   2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "migrate")
+			if err != nil {
+				t.Fatalf("TempDir: %v", err)
+			}
+			defer os.RemoveAll(root)
+			ctx := context.Background()
+			client := gcs.NewLocalClient(root)
+			for _, name := range []string{
+				"gs://old/prefix/grid/a",
+				"gs://old/prefix/grid/nested/b",
+				"gs://old/prefix/summary/summary-dash",
+				"gs://old/prefix/tabs/dash/tab",
+				"gs://old/prefix/unrelated",
+			} {
+				if err := client.Upload(ctx, mustPath(t, name), []byte(name), false, ""); err != nil {
+					t.Fatalf("Upload(%s): %v", name, err)
+				}
+			}
+
+			copied, err := Objects(ctx, logrus.WithField("test", tc.name), client, mustPath(t, tc.from), mustPath(t, tc.to), tc.prefixes, 2, tc.write)
+			if err != nil {
+				t.Fatalf("Objects() got unexpected error: %v", err)
+			}
+			if copied != tc.copied {
+				t.Errorf("Objects() copied %d, want %d", copied, tc.copied)
+			}
+			if diff := cmp.Diff(tc.expected, files(t, filepath.Join(root, "new"))); diff != "" {
+				t.Errorf("Objects() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	root, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	ctx := context.Background()
+	client := gcs.NewLocalClient(root)
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "moved", GcsPrefix: "old/prefix/logs/moved"},
+			{Name: "kept", GcsPrefix: "results/logs/kept"},
+		},
+	}
+	buf, err := proto.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	if err := client.Upload(ctx, mustPath(t, "gs://old/prefix/config"), buf, false, ""); err != nil {
+		t.Fatalf("Upload(): %v", err)
+	}
+
+	n, err := Config(ctx, client, mustPath(t, "gs://old/prefix"), mustPath(t, "gs://new/dest"), "config", false)
+	if err != nil {
+		t.Fatalf("Config(dry run) got unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Config(dry run) rewrote %d values, want 1", n)
+	}
+	if got := files(t, filepath.Join(root, "new")); len(got) > 0 {
+		t.Errorf("Config(dry run) wrote %v", got)
+	}
+
+	if _, err := Config(ctx, client, mustPath(t, "gs://old/prefix"), mustPath(t, "gs://new/dest"), "config", true); err != nil {
+		t.Fatalf("Config() got unexpected error: %v", err)
+	}
+	got, err := config.ReadGCS(ctx, client, mustPath(t, "gs://new/dest/config"))
+	if err != nil {
+		t.Fatalf("ReadGCS(): %v", err)
+	}
+	expected := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "moved", GcsPrefix: "new/dest/logs/moved"},
+			{Name: "kept", GcsPrefix: "results/logs/kept"},
+		},
+	}
+	if diff := cmp.Diff(expected, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Config() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statedump
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+)
+
+// RunStats describes how often a test passed, failed and flaked over a window.
+type RunStats struct {
+	// Grid optionally identifies the grid of the test, when reporting on several.
+	Grid     string `json:"grid,omitempty"`
+	Test     string `json:"test"`
+	Runs     int    `json:"runs"`
+	Passes   int    `json:"passes"`
+	Failures int    `json:"failures"`
+	Flakes   int    `json:"flakes"`
+	// PassRate is the percent of runs that passed.
+	PassRate float64 `json:"pass_rate"`
+	// FlakeRate is the flaky percent of runs, as computed by the summarizer.
+	FlakeRate float32 `json:"flake_rate"`
+	// Streak counts the consecutive latest runs with the StreakResult.
+	Streak       int    `json:"streak"`
+	StreakResult string `json:"streak_result,omitempty"`
+	// LongestFailures is the most consecutive failing runs.
+	LongestFailures int `json:"longest_failures"`
+}
+
+// Flakiness describes each test that ran in the grid over the days ending at now, flakiest first.
+func Flakiness(grid *statepb.Grid, now time.Time, days int) []RunStats {
+	window := summarizer.CalculateFlakeRates(grid, now, []int32{int32(days)})[0]
+	rates := make(map[string]float32, len(window.Tests))
+	for _, test := range window.Tests {
+		rates[test.DisplayName] = test.FlakeRate
+	}
+	start := float64(window.Start.GetSeconds()) * 1000
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var out []RunStats
+	for _, row := range grid.Rows {
+		rate, ok := rates[row.Name]
+		if !ok {
+			continue
+		}
+		tf := RunStats{Test: row.Name, FlakeRate: rate}
+		var failures int
+		streaking := true
+		var i int
+		for r := range result.Iter(ctx, row.Results) {
+			if i >= len(grid.Columns) || grid.Columns[i].Started < start {
+				break
+			}
+			i++
+			r = result.Coalesce(r, result.IgnoreRunning)
+			switch r {
+			case statuspb.TestStatus_NO_RESULT:
+				continue
+			case statuspb.TestStatus_PASS:
+				tf.Passes++
+				failures = 0
+			case statuspb.TestStatus_FLAKY:
+				tf.Flakes++
+				failures = 0
+			default:
+				tf.Failures++
+				failures++
+				if failures > tf.LongestFailures {
+					tf.LongestFailures = failures
+				}
+			}
+			tf.Runs++
+			switch {
+			case !streaking:
+			case tf.StreakResult == "" || tf.StreakResult == r.String():
+				tf.StreakResult = r.String()
+				tf.Streak++
+			default:
+				streaking = false
+			}
+		}
+		if tf.Runs == 0 {
+			continue
+		}
+		tf.PassRate = 100 * float64(tf.Passes) / float64(tf.Runs)
+		out = append(out, tf)
+	}
+	SortFlakiness(out)
+	return out
+}
+
+// SortFlakiness orders the tests by flake rate, then by how often they fail.
+func SortFlakiness(tests []RunStats) {
+	sort.SliceStable(tests, func(i, j int) bool {
+		a, b := tests[i], tests[j]
+		switch {
+		case a.FlakeRate != b.FlakeRate:
+			return a.FlakeRate > b.FlakeRate
+		case a.PassRate != b.PassRate:
+			return a.PassRate < b.PassRate
+		}
+		return a.Runs > b.Runs
+	})
+}
+
+// flakinessHeader names the columns of flakiness tables.
+var flakinessHeader = []string{"GRID", "TEST", "FLAKE RATE", "PASS RATE", "RUNS", "FAILURES", "FLAKES", "STREAK", "LONGEST FAILURES"}
+
+func flakinessRecord(tf RunStats) []string {
+	return []string{
+		tf.Grid,
+		tf.Test,
+		fmt.Sprintf("%.1f%%", tf.FlakeRate),
+		fmt.Sprintf("%.1f%%", tf.PassRate),
+		strconv.Itoa(tf.Runs),
+		strconv.Itoa(tf.Failures),
+		strconv.Itoa(tf.Flakes),
+		fmt.Sprintf("%d %s", tf.Streak, tf.StreakResult),
+		strconv.Itoa(tf.LongestFailures),
+	}
+}
+
+// WriteFlakinessTable writes a line for each test, omitting the grid column when tests lack one.
+func WriteFlakinessTable(w io.Writer, tests []RunStats) error {
+	skip := 1
+	for _, tf := range tests {
+		if tf.Grid != "" {
+			skip = 0
+			break
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	write := func(record []string) {
+		for i, s := range record[skip:] {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, s)
+		}
+		fmt.Fprintln(tw)
+	}
+	write(flakinessHeader)
+	for _, tf := range tests {
+		write(flakinessRecord(tf))
+	}
+	return tw.Flush()
+}
+
+// WriteFlakinessCSV writes a header and then a record for each test, naming columns like the JSON fields.
+func WriteFlakinessCSV(w io.Writer, tests []RunStats) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"grid", "test", "runs", "passes", "failures", "flakes", "pass_rate", "flake_rate", "streak", "streak_result", "longest_failures"})
+	for _, tf := range tests {
+		cw.Write([]string{
+			tf.Grid,
+			tf.Test,
+			strconv.Itoa(tf.Runs),
+			strconv.Itoa(tf.Passes),
+			strconv.Itoa(tf.Failures),
+			strconv.Itoa(tf.Flakes),
+			strconv.FormatFloat(tf.PassRate, 'f', 2, 64),
+			strconv.FormatFloat(float64(tf.FlakeRate), 'f', 2, 32),
+			strconv.Itoa(tf.Streak),
+			tf.StreakResult,
+			strconv.Itoa(tf.LongestFailures),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statedump
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestFlakiness(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) float64 {
+		return float64(now.Add(-time.Duration(h)*time.Hour).Unix() * 1000)
+	}
+	const (
+		pass = int32(statuspb.TestStatus_PASS)
+		fail = int32(statuspb.TestStatus_FAIL)
+		none = int32(statuspb.TestStatus_NO_RESULT)
+	)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "5", Started: hoursAgo(1)},
+			{Build: "4", Started: hoursAgo(2)},
+			{Build: "3", Started: hoursAgo(3)},
+			{Build: "2", Started: hoursAgo(4)},
+			{Build: "1", Started: hoursAgo(5)},
+			{Build: "old", Started: hoursAgo(24 * 30)},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:     "good",
+				Results:  []int32{pass, 5, fail, 1},
+				Messages: []string{"", "", "", "", "", "old"},
+			},
+			{
+				Name:     "broken",
+				Results:  []int32{fail, 3, pass, 2, fail, 1},
+				Messages: []string{"no such file", "no such file", "no such file", "", "", "old"},
+			},
+			{
+				Name:     "flaky",
+				Results:  []int32{pass, 1, fail, 1, pass, 1, fail, 1, pass, 2},
+				Messages: []string{"", "timed out", "", "timed out", "", ""},
+			},
+			{
+				Name:    "never",
+				Results: []int32{none, 6},
+			},
+		},
+	}
+
+	got := Flakiness(grid, now, 7)
+	want := []RunStats{
+		{
+			Test:            "flaky",
+			Runs:            5,
+			Passes:          3,
+			Failures:        2,
+			PassRate:        60,
+			FlakeRate:       40,
+			Streak:          1,
+			StreakResult:    "PASS",
+			LongestFailures: 1,
+		},
+		{
+			Test:            "broken",
+			Runs:            5,
+			Passes:          2,
+			Failures:        3,
+			PassRate:        40,
+			Streak:          3,
+			StreakResult:    "FAIL",
+			LongestFailures: 3,
+		},
+		{
+			Test:         "good",
+			Runs:         5,
+			Passes:       5,
+			PassRate:     100,
+			Streak:       5,
+			StreakResult: "PASS",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Flakiness() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteFlakinessTable(&buf, got[1:2]); err != nil {
+		t.Fatalf("WriteFlakinessTable() got unexpected error: %v", err)
+	}
+	wantTable := "TEST    FLAKE RATE  PASS RATE  RUNS  FAILURES  FLAKES  STREAK  LONGEST FAILURES\n" +
+		"broken  0.0%        40.0%      5     3         0       3 FAIL  3\n"
+	if diff := cmp.Diff(wantTable, buf.String()); diff != "" {
+		t.Errorf("WriteFlakinessTable() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	buf.Reset()
+	got[1].Grid = "grid/broken"
+	if err := WriteFlakinessCSV(&buf, got[1:2]); err != nil {
+		t.Fatalf("WriteFlakinessCSV() got unexpected error: %v", err)
+	}
+	wantCSV := "grid,test,runs,passes,failures,flakes,pass_rate,flake_rate,streak,streak_result,longest_failures\n" +
+		"grid/broken,broken,5,2,3,0,40.00,0.00,3,FAIL,3\n"
+	if diff := cmp.Diff(wantCSV, buf.String()); diff != "" {
+		t.Errorf("WriteFlakinessCSV() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statedump reads grid state and summary objects, describing them as tables, JSON or statistics.
+package statedump
+
+import (
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Read downloads and parses the grid at path, also returning its compressed size in bytes.
+func Read(ctx context.Context, opener gcs.Opener, path gcs.Path) (*statepb.Grid, int, error) {
+	r, err := opener.Open(ctx, path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	var counted countingReader
+	counted.r = r
+	zr, err := zlib.NewReader(&counted)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open zlib: %w", err)
+	}
+	buf, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decompress: %w", err)
+	}
+	var grid statepb.Grid
+	if err := proto.Unmarshal(buf, &grid); err != nil {
+		return nil, 0, fmt.Errorf("parse: %w", err)
+	}
+	return &grid, counted.n, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// RowSize is the serialized size of a row.
+type RowSize struct {
+	Name  string
+	Bytes int
+}
+
+// Stats describe the size and contents of a grid.
+type Stats struct {
+	Rows            int
+	Columns         int
+	Bytes           int
+	CompressedBytes int
+	Statuses        map[statuspb.TestStatus]int
+	Messages        int
+	Metrics         int
+	LargestRows     []RowSize
+}
+
+// GridStats counts the rows, columns and cells of each status in the grid, along with the largest rows.
+//
+// Keeps at most top of the largest rows.
+func GridStats(grid *statepb.Grid, compressed, top int) Stats {
+	stats := Stats{
+		Rows:            len(grid.Rows),
+		Columns:         len(grid.Columns),
+		Bytes:           proto.Size(grid),
+		CompressedBytes: compressed,
+		Statuses:        map[statuspb.TestStatus]int{},
+	}
+	for _, row := range grid.Rows {
+		for i := 0; i+1 < len(row.Results); i += 2 {
+			stats.Statuses[statuspb.TestStatus(row.Results[i])] += int(row.Results[i+1])
+		}
+		for _, m := range row.Messages {
+			if m != "" {
+				stats.Messages++
+			}
+		}
+		stats.Metrics += len(row.Metrics)
+		stats.LargestRows = append(stats.LargestRows, RowSize{Name: row.Name, Bytes: proto.Size(row)})
+	}
+	sort.SliceStable(stats.LargestRows, func(i, j int) bool {
+		return stats.LargestRows[i].Bytes > stats.LargestRows[j].Bytes
+	})
+	if len(stats.LargestRows) > top {
+		stats.LargestRows = stats.LargestRows[:top]
+	}
+	return stats
+}
+
+// WriteStats writes the stats as lines of text, listing statuses from most to least common.
+func WriteStats(w io.Writer, stats Stats) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "rows:\t%d\n", stats.Rows)
+	fmt.Fprintf(tw, "columns:\t%d\n", stats.Columns)
+	fmt.Fprintf(tw, "bytes:\t%d\n", stats.Bytes)
+	fmt.Fprintf(tw, "compressed bytes:\t%d\n", stats.CompressedBytes)
+	fmt.Fprintf(tw, "messages:\t%d\n", stats.Messages)
+	fmt.Fprintf(tw, "metrics:\t%d\n", stats.Metrics)
+	var cells int
+	statuses := make([]statuspb.TestStatus, 0, len(stats.Statuses))
+	for s, n := range stats.Statuses {
+		statuses = append(statuses, s)
+		cells += n
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		a, b := stats.Statuses[statuses[i]], stats.Statuses[statuses[j]]
+		if a != b {
+			return a > b
+		}
+		return statuses[i] < statuses[j]
+	})
+	fmt.Fprintf(tw, "cells:\t%d\n", cells)
+	for _, s := range statuses {
+		n := stats.Statuses[s]
+		fmt.Fprintf(tw, "  %s:\t%d\t(%.1f%%)\n", s, n, 100*float64(n)/float64(cells))
+	}
+	if len(stats.LargestRows) > 0 {
+		fmt.Fprintln(tw, "largest rows:")
+	}
+	for _, r := range stats.LargestRows {
+		fmt.Fprintf(tw, "  %s:\t%d\n", r.Name, r.Bytes)
+	}
+	return tw.Flush()
+}
+
+// icons abbreviate each status in a table.
+var icons = map[statuspb.TestStatus]string{
+	statuspb.TestStatus_NO_RESULT:         " ",
+	statuspb.TestStatus_PASS:              ".",
+	statuspb.TestStatus_PASS_WITH_ERRORS:  ".",
+	statuspb.TestStatus_PASS_WITH_SKIPS:   "s",
+	statuspb.TestStatus_RUNNING:           "R",
+	statuspb.TestStatus_CATEGORIZED_ABORT: "A",
+	statuspb.TestStatus_UNKNOWN:           "?",
+	statuspb.TestStatus_CANCEL:            "C",
+	statuspb.TestStatus_BLOCKED:           "K",
+	statuspb.TestStatus_TIMED_OUT:         "T",
+	statuspb.TestStatus_CATEGORIZED_FAIL:  "F",
+	statuspb.TestStatus_BUILD_FAIL:        "B",
+	statuspb.TestStatus_FAIL:              "F",
+	statuspb.TestStatus_FLAKY:             "~",
+	statuspb.TestStatus_TOOL_FAIL:         "X",
+	statuspb.TestStatus_BUILD_PASSED:      ".",
+	statuspb.TestStatus_ABORTED:           "a",
+}
+
+// WriteTable writes the build of each column, then a line for each row with a character for the status of each column.
+func WriteTable(w io.Writer, grid *statepb.Grid) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	fmt.Fprint(tw, "name")
+	for _, col := range grid.Columns {
+		fmt.Fprintf(tw, "\t%s", col.Build)
+	}
+	fmt.Fprintln(tw)
+	for _, row := range grid.Rows {
+		fmt.Fprint(tw, row.Name)
+		for i := 0; i+1 < len(row.Results); i += 2 {
+			icon, ok := icons[statuspb.TestStatus(row.Results[i])]
+			if !ok {
+				icon = "?"
+			}
+			for n := int32(0); n < row.Results[i+1]; n++ {
+				fmt.Fprintf(tw, "\t%s", icon)
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the grid or summary as indented JSON, using the field names of the proto.
+func WriteJSON(w io.Writer, msg proto.Message) error {
+	m := jsonpb.Marshaler{OrigName: true, Indent: "  "}
+	if err := m.Marshal(w, msg); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statedump
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type fakeOpener map[string][]byte
+
+func (fo fakeOpener) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	buf, ok := fo[path.String()]
+	if !ok {
+		return nil, storage.ErrObjectNotExist
+	}
+	return ioutil.NopCloser(bytes.NewReader(buf)), nil
+}
+
+func compress(t *testing.T, grid *statepb.Grid) []byte {
+	buf, err := proto.Marshal(grid)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	zw.Write(buf)
+	zw.Close()
+	return zbuf.Bytes()
+}
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("bad path %q: %v", s, err)
+	}
+	return *p
+}
+
+func TestRead(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows:    []*statepb.Row{{Name: "a", Results: []int32{1, 1}}},
+	}
+	cases := []struct {
+		name    string
+		objects fakeOpener
+		want    *statepb.Grid
+		err     bool
+	}{
+		{
+			name:    "read",
+			objects: fakeOpener{"gs://bucket/grid/group": compress(t, grid)},
+			want:    grid,
+		},
+		{
+			name:    "missing",
+			objects: fakeOpener{},
+			err:     true,
+		},
+		{
+			name:    "not compressed",
+			objects: fakeOpener{"gs://bucket/grid/group": []byte("hello")},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, size, err := Read(context.Background(), tc.objects, mustPath(t, "gs://bucket/grid/group"))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Read() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Read() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("Read() got unexpected diff (-want +got):\n%s", diff)
+				}
+				if want := len(tc.objects["gs://bucket/grid/group"]); size != want {
+					t.Errorf("Read() got size %d, want %d", size, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGridStats(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name:     "small",
+				Results:  []int32{int32(statuspb.TestStatus_PASS), 3},
+				Messages: []string{"", "", ""},
+			},
+			{
+				Name:     "large",
+				Results:  []int32{int32(statuspb.TestStatus_FAIL), 2, int32(statuspb.TestStatus_NO_RESULT), 1},
+				Messages: []string{"this failed", "this failed too"},
+				Metrics:  []*statepb.Metric{{Name: "elapsed"}},
+			},
+		},
+	}
+	got := GridStats(grid, 10, 1)
+	want := Stats{
+		Rows:            2,
+		Columns:         3,
+		Bytes:           proto.Size(grid),
+		CompressedBytes: 10,
+		Statuses: map[statuspb.TestStatus]int{
+			statuspb.TestStatus_PASS:      3,
+			statuspb.TestStatus_FAIL:      2,
+			statuspb.TestStatus_NO_RESULT: 1,
+		},
+		Messages:    2,
+		Metrics:     1,
+		LargestRows: []RowSize{{Name: "large", Bytes: proto.Size(grid.Rows[1])}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GridStats() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	stats := Stats{
+		Rows:            2,
+		Columns:         2,
+		Bytes:           100,
+		CompressedBytes: 20,
+		Statuses: map[statuspb.TestStatus]int{
+			statuspb.TestStatus_PASS: 3,
+			statuspb.TestStatus_FAIL: 1,
+		},
+		LargestRows: []RowSize{{Name: "a", Bytes: 60}, {Name: "b", Bytes: 40}},
+	}
+	var buf bytes.Buffer
+	if err := WriteStats(&buf, stats); err != nil {
+		t.Fatalf("WriteStats() got unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"rows:              2",
+		"columns:           2",
+		"bytes:             100",
+		"compressed bytes:  20",
+		"messages:          0",
+		"metrics:           0",
+		"cells:             4",
+		"  PASS:            3  (75.0%)",
+		"  FAIL:            1  (25.0%)",
+		"largest rows:",
+		"  a:  60",
+		"  b:  40",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteStats() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "30"}, {Build: "20"}, {Build: "10"}},
+		Rows: []*statepb.Row{
+			{Name: "a", Results: []int32{int32(statuspb.TestStatus_PASS), 3}},
+			{Name: "longer", Results: []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_FLAKY), 1, int32(statuspb.TestStatus_NO_RESULT), 1}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, grid); err != nil {
+		t.Fatalf("WriteTable() got unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"name   30 20 10",
+		"a      .  .  .",
+		"longer F  ~   ",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteTable() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows:    []*statepb.Row{{Name: "a", Results: []int32{1, 1}}},
+	}
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, grid); err != nil {
+		t.Fatalf("WriteJSON() got unexpected error: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, `"build": "1"`) || !strings.HasSuffix(got, "}\n") {
+		t.Errorf("WriteJSON() got %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statedump
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// WriteSummary writes a line with the status, update times and alert of each tab in the summary.
+//
+// Then lists the failing tests of each tab when failing is set.
+func WriteSummary(w io.Writer, sum *summarypb.DashboardSummary, now time.Time, failing bool) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "TAB\tSTATUS\tUPDATED\tLAST RUN\tLATEST GREEN\tFAILING\tALERT")
+	for _, tab := range sum.TabSummaries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			tab.DashboardTabName,
+			tab.OverallStatus,
+			ago(now, tab.LastUpdateTimestamp),
+			ago(now, tab.LastRunTimestamp),
+			tab.LatestGreen,
+			len(tab.FailingTestSummaries),
+			firstLine(tab.Alert),
+		)
+	}
+	if !failing {
+		return tw.Flush()
+	}
+	for _, tab := range sum.TabSummaries {
+		if len(tab.FailingTestSummaries) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s failing tests:\n", tab.DashboardTabName)
+		for _, f := range tab.FailingTestSummaries {
+			name := f.DisplayName
+			if name == "" {
+				name = f.TestName
+			}
+			fmt.Fprintf(tw, "  %s\t%d failures\tsince %s\t%s\n", name, f.FailCount, f.FailBuildId, firstLine(f.FailureMessage))
+		}
+	}
+	return tw.Flush()
+}
+
+// ago describes how long before now the seconds since the epoch are, or never when zero.
+func ago(now time.Time, seconds float64) string {
+	if seconds == 0 {
+		return "never"
+	}
+	when := time.Unix(0, int64(seconds*float64(time.Second)))
+	return now.Sub(when).Round(time.Second).String() + " ago"
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statedump
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestWriteSummary(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName:    "green",
+				OverallStatus:       summarypb.DashboardTabSummary_PASS,
+				LastUpdateTimestamp: 940,
+				LastRunTimestamp:    700,
+				LatestGreen:         "123",
+			},
+			{
+				DashboardTabName:    "red",
+				OverallStatus:       summarypb.DashboardTabSummary_FAIL,
+				LastUpdateTimestamp: 990,
+				Alert:               "2 tests failing\nmore details",
+				FailingTestSummaries: []*summarypb.FailingTestSummary{
+					{
+						TestName:       "test",
+						DisplayName:    "pretty test",
+						FailCount:      3,
+						FailBuildId:    "120",
+						FailureMessage: "boom\nstack",
+					},
+					{
+						TestName:  "other",
+						FailCount: 1,
+					},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		failing  bool
+		expected []string
+	}{
+		{
+			name: "basically works",
+			expected: []string{
+				"TAB    STATUS  UPDATED   LAST RUN  LATEST GREEN  FAILING  ALERT",
+				"green  PASS    1m0s ago  5m0s ago  123           0        ",
+				"red    FAIL    10s ago   never                   2        2 tests failing",
+				"",
+			},
+		},
+		{
+			name:    "failing tests",
+			failing: true,
+			expected: []string{
+				"TAB    STATUS  UPDATED   LAST RUN  LATEST GREEN  FAILING  ALERT",
+				"green  PASS    1m0s ago  5m0s ago  123           0        ",
+				"red    FAIL    10s ago   never                   2        2 tests failing",
+				"",
+				"red failing tests:",
+				"  pretty test  3 failures  since 120  boom",
+				"  other        1 failures  since      ",
+				"",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteSummary(&buf, sum, now, tc.failing); err != nil {
+				t.Fatalf("WriteSummary() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(strings.Join(tc.expected, "\n"), buf.String()); diff != "" {
+				t.Errorf("WriteSummary() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzers
+
+import (
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// FlakeRate counts the runs of a test that passed after a retry or failed intermittently.
+//
+// Runs of ignoreFailuresInARow or more consecutive failures are consistent failures
+// rather than flakes, so they are not considered.
+// statuses should have already filtered to the correct time horizon and removed infra failures.
+func FlakeRate(name string, statuses []StatusCategory) *summarypb.TestFlakeRate {
+	rate := summarypb.TestFlakeRate{DisplayName: name}
+	for i := 0; i < len(statuses); i++ {
+		if cf := consecutiveFailures(statuses, i); cf >= ignoreFailuresInARow {
+			i += cf - 1
+			continue
+		}
+		rate.Runs++
+		switch statuses[i] {
+		case StatusFlaky:
+			rate.PassedAfterRetry++
+		case StatusFail:
+			rate.IntermittentFailures++
+		}
+	}
+	if rate.Runs > 0 {
+		rate.FlakeRate = 100 * float32(rate.PassedAfterRetry+rate.IntermittentFailures) / float32(rate.Runs)
+	}
+	return &rate
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestFlakeRate(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []StatusCategory
+		expected *summarypb.TestFlakeRate
+	}{
+		{
+			name:     "no runs",
+			expected: &summarypb.TestFlakeRate{DisplayName: "test"},
+		},
+		{
+			name:     "all passing",
+			statuses: []StatusCategory{StatusPass, StatusPass, StatusPass, StatusPass},
+			expected: &summarypb.TestFlakeRate{DisplayName: "test", Runs: 4},
+		},
+		{
+			name:     "passed after retry",
+			statuses: []StatusCategory{StatusPass, StatusFlaky, StatusPass, StatusFlaky},
+			expected: &summarypb.TestFlakeRate{
+				DisplayName:      "test",
+				Runs:             4,
+				PassedAfterRetry: 2,
+				FlakeRate:        50,
+			},
+		},
+		{
+			name:     "intermittent failures",
+			statuses: []StatusCategory{StatusFail, StatusPass, StatusFail, StatusFail, StatusPass},
+			expected: &summarypb.TestFlakeRate{
+				DisplayName:          "test",
+				Runs:                 5,
+				IntermittentFailures: 3,
+				FlakeRate:            60,
+			},
+		},
+		{
+			name:     "ignore consistent failures",
+			statuses: []StatusCategory{StatusPass, StatusFail, StatusFail, StatusFail, StatusFlaky, StatusFail},
+			expected: &summarypb.TestFlakeRate{
+				DisplayName:          "test",
+				Runs:                 3,
+				PassedAfterRetry:     1,
+				IntermittentFailures: 1,
+				FlakeRate:            200.0 / 3,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := FlakeRate("test", tc.statuses)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("FlakeRate() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// clusterSimilarity is the minimum similarity of the messages of failures in the same cluster.
+const clusterSimilarity = 0.75
+
+var (
+	messageToken = regexp.MustCompile(`[a-z0-9]+`)
+	// Tokens with digits are usually ids, timestamps, ports, etc which vary between failures.
+	variableToken = regexp.MustCompile(`[0-9]`)
+)
+
+type cluster struct {
+	tokens map[string]bool
+	tests  map[string]bool
+	sum    *summarypb.FailureCluster
+}
+
+// normalizeMessage returns the set of tokens in the message, ignoring case and variable tokens.
+func normalizeMessage(msg string) map[string]bool {
+	tokens := map[string]bool{}
+	for _, tok := range messageToken.FindAllString(strings.ToLower(msg), -1) {
+		if variableToken.MatchString(tok) {
+			continue
+		}
+		tokens[tok] = true
+	}
+	return tokens
+}
+
+// similarity returns the Jaccard index of the token sets.
+func similarity(a, b map[string]bool) float64 {
+	var both int
+	for tok := range a {
+		if b[tok] {
+			both++
+		}
+	}
+	union := len(a) + len(b) - both
+	if union == 0 {
+		return 0
+	}
+	return float64(both) / float64(union)
+}
+
+// failureClusters groups the recent failing cells of the rows by the similarity of their messages.
+//
+// Each failure joins the most similar existing cluster, if similar enough.
+// Only returns clusters which span multiple tests.
+func failureClusters(rows []*statepb.Row, recent int) []*summarypb.FailureCluster {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var clusters []*cluster
+	for _, row := range rows {
+		var col, msg int
+		for rr := range result.Iter(ctx, row.Results) {
+			if col >= recent {
+				break
+			}
+			col++
+			if rr == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			idx := msg
+			msg++
+			if coalesceResult(rr, result.IgnoreRunning) != statuspb.TestStatus_FAIL || idx >= len(row.Messages) {
+				continue
+			}
+			tokens := normalizeMessage(row.Messages[idx])
+			if len(tokens) == 0 {
+				continue
+			}
+			var best *cluster
+			bestScore := clusterSimilarity
+			for _, c := range clusters {
+				if score := similarity(c.tokens, tokens); score >= bestScore {
+					best, bestScore = c, score
+				}
+			}
+			if best == nil {
+				best = &cluster{
+					tokens: tokens,
+					tests:  map[string]bool{},
+					sum:    &summarypb.FailureCluster{FailureMessage: row.Messages[idx]},
+				}
+				clusters = append(clusters, best)
+			}
+			best.sum.Failures++
+			if !best.tests[row.Name] {
+				best.tests[row.Name] = true
+				best.sum.Tests = append(best.sum.Tests, row.Name)
+			}
+		}
+	}
+
+	var out []*summarypb.FailureCluster
+	for _, c := range clusters {
+		if len(c.sum.Tests) > 1 {
+			out = append(out, c.sum)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if len(out[i].Tests) != len(out[j].Tests) {
+			return len(out[i].Tests) > len(out[j].Tests)
+		}
+		return out[i].Failures > out[j].Failures
+	})
+	return out
+}
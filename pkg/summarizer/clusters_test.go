@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		msg      string
+		expected map[string]bool
+	}{
+		{
+			name:     "basically works",
+			expected: map[string]bool{},
+		},
+		{
+			name: "ignore case, punctuation and variable tokens",
+			msg:  "Dial tcp 10.0.0.1:8080: Connection refused (attempt #3, id=abc123)",
+			expected: map[string]bool{
+				"dial":       true,
+				"tcp":        true,
+				"connection": true,
+				"refused":    true,
+				"attempt":    true,
+				"id":         true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, normalizeMessage(tc.msg)); diff != "" {
+				t.Errorf("normalizeMessage() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	set := func(tokens ...string) map[string]bool {
+		out := map[string]bool{}
+		for _, tok := range tokens {
+			out[tok] = true
+		}
+		return out
+	}
+	cases := []struct {
+		name     string
+		a        map[string]bool
+		b        map[string]bool
+		expected float64
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "identical",
+			a:        set("a", "b"),
+			b:        set("a", "b"),
+			expected: 1,
+		},
+		{
+			name:     "disjoint",
+			a:        set("a"),
+			b:        set("b"),
+			expected: 0,
+		},
+		{
+			name:     "overlap",
+			a:        set("a", "b", "c"),
+			b:        set("b", "c", "d"),
+			expected: 0.5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := similarity(tc.a, tc.b); got != tc.expected {
+				t.Errorf("similarity() got %f, want %f", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFailureClusters(t *testing.T) {
+	cases := []struct {
+		name     string
+		rows     []*statepb.Row
+		recent   int
+		expected []*summarypb.FailureCluster
+	}{
+		{
+			name:   "basically works",
+			recent: 5,
+		},
+		{
+			name:   "cluster similar failures across tests",
+			recent: 5,
+			rows: []*statepb.Row{
+				{
+					Name: "foo",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 1,
+						int32(statuspb.TestStatus_NO_RESULT), 1,
+						int32(statuspb.TestStatus_FAIL), 1,
+					},
+					Messages: []string{
+						"dial tcp 10.0.0.1:443: connection refused",
+						"dial tcp 10.0.0.2:443: connection refused",
+					},
+				},
+				{
+					Name: "bar",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 1,
+						int32(statuspb.TestStatus_PASS), 1,
+					},
+					Messages: []string{
+						"Dial TCP 10.0.0.3:443: connection refused",
+						"",
+					},
+				},
+				{
+					Name: "lonely",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 2,
+					},
+					Messages: []string{
+						"expected 1, got 2",
+						"expected 1, got 3",
+					},
+				},
+			},
+			expected: []*summarypb.FailureCluster{
+				{
+					FailureMessage: "dial tcp 10.0.0.1:443: connection refused",
+					Tests:          []string{"foo", "bar"},
+					Failures:       3,
+				},
+			},
+		},
+		{
+			name:   "only consider recent failures",
+			recent: 1,
+			rows: []*statepb.Row{
+				{
+					Name: "foo",
+					Results: []int32{
+						int32(statuspb.TestStatus_PASS), 1,
+						int32(statuspb.TestStatus_FAIL), 1,
+					},
+					Messages: []string{"", "timed out"},
+				},
+				{
+					Name: "bar",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 1,
+					},
+					Messages: []string{"timed out"},
+				},
+			},
+		},
+		{
+			name:   "order by tests affected",
+			recent: 5,
+			rows: []*statepb.Row{
+				{
+					Name:     "a",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1},
+					Messages: []string{"timed out"},
+				},
+				{
+					Name:     "b",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1},
+					Messages: []string{"timed out"},
+				},
+				{
+					Name:     "c",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1},
+					Messages: []string{"out of memory"},
+				},
+				{
+					Name:     "d",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1},
+					Messages: []string{"out of memory"},
+				},
+				{
+					Name:     "e",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1},
+					Messages: []string{"out of memory"},
+				},
+			},
+			expected: []*summarypb.FailureCluster{
+				{
+					FailureMessage: "out of memory",
+					Tests:          []string{"c", "d", "e"},
+					Failures:       3,
+				},
+				{
+					FailureMessage: "timed out",
+					Tests:          []string{"a", "b"},
+					Failures:       2,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := failureClusters(tc.rows, tc.recent)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("failureClusters() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
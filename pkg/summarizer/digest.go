@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer/notify"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// UpdateDigests ranks the flakiest tests of each dashboard group over the last days.
+//
+// Setting dashboardGroup limits the update to this group.
+// Keeps the top tests of each group, or all flaky tests when top is zero.
+// Skips groups whose previous digest ended less than every ago.
+// Will write each digest under summaryPathPrefix when confirm is set, sending it to the notifier if set.
+func UpdateDigests(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, dashboardGroup, gridPathPrefix, summaryPathPrefix string, days, top int, every time.Duration, notifier notify.Notifier, confirm bool) error {
+	if days < 1 {
+		return fmt.Errorf("days must be positive, got: %d", days)
+	}
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("Failed to read config: %w", err)
+	}
+	finder := gcsGroupFinder(client, cfg, configPath, gridPathPrefix)
+	now := time.Now()
+
+	var errs []string
+	for _, group := range cfg.DashboardGroups {
+		if dashboardGroup != "" && dashboardGroup != group.Name {
+			continue
+		}
+		log := logrus.WithField("dashboard-group", group.Name)
+		digestPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, digestPath(group.Name))})
+		if err != nil {
+			log.WithError(err).Error("Cannot resolve digest path")
+			errs = append(errs, group.Name)
+			continue
+		}
+		previous, err := readDigest(ctx, client, *digestPath)
+		if err != nil {
+			log.WithError(err).Warning("Cannot read previous digest")
+		} else if end := previous.GetEnd().GetSeconds(); end > 0 && now.Sub(time.Unix(end, 0)) < every {
+			log.WithField("end", time.Unix(end, 0)).Info("Skipping recent digest")
+			continue
+		}
+		d, err := digest(ctx, cfg, group, finder, now, days, top)
+		if err != nil {
+			log.WithError(err).Warning("Incomplete digest")
+		}
+		log.WithField("tests", len(d.Tests)).Info("Ranked flaky tests")
+		if !confirm {
+			continue
+		}
+		if err := writeDigest(ctx, client, *digestPath, d); err != nil {
+			log.WithError(err).Error("Cannot write digest")
+			errs = append(errs, group.Name)
+			continue
+		}
+		if notifier == nil {
+			continue
+		}
+		if events := notify.DigestEvents(d); len(events) > 0 {
+			if err := notifier.Notify(ctx, cfg, events); err != nil {
+				log.WithError(err).Warning("Failed to send digest")
+			}
+		}
+	}
+	if n := len(errs); n > 0 {
+		return fmt.Errorf("failed to update %d digests: %v", n, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// digest ranks the flaky tests of every tab in the group over the days ending at now.
+//
+// Skips tabs it cannot read, returning an error listing them along with the digest of the others.
+func digest(ctx context.Context, cfg *configpb.Configuration, group *configpb.DashboardGroup, finder groupFinder, now time.Time, days, top int) (*summarypb.FlakyTestDigest, error) {
+	out := summarypb.FlakyTestDigest{
+		DashboardGroupName: group.Name,
+		Days:               int32(days),
+		Start:              &timestamp.Timestamp{Seconds: int64(goBackDays(days, now))},
+		End:                &timestamp.Timestamp{Seconds: int64(goBackDays(0, now))},
+	}
+	var mErr error
+	for _, name := range group.DashboardNames {
+		dash := config.FindDashboard(name, cfg)
+		if dash == nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s: dashboard not found", name))
+			continue
+		}
+		for _, tab := range dash.DashboardTab {
+			_, reader, err := finder(tab.TestGroupName)
+			if err == nil && reader == nil {
+				err = fmt.Errorf("not found: %q", tab.TestGroupName)
+			}
+			if err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s/%s: find group: %w", dash.Name, tab.Name, err))
+				continue
+			}
+			grid, _, _, err := readGrid(ctx, reader)
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				continue
+			}
+			if err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s/%s: load %s: %w", dash.Name, tab.Name, tab.TestGroupName, err))
+				continue
+			}
+			grid = withoutColumns(grid, infraColumns(grid, tab.InfraFailureThreshold, tab.InfraFailureProperty))
+			window := CalculateFlakeRates(grid, now, []int32{int32(days)})[0]
+			for _, test := range window.Tests {
+				if test.FlakeRate == 0 {
+					continue
+				}
+				out.Tests = append(out.Tests, &summarypb.FlakyTest{
+					DashboardName:    dash.Name,
+					DashboardTabName: tab.Name,
+					FlakeRate:        test,
+				})
+			}
+		}
+	}
+	sort.SliceStable(out.Tests, func(i, j int) bool {
+		a, b := out.Tests[i].FlakeRate, out.Tests[j].FlakeRate
+		if a.FlakeRate != b.FlakeRate {
+			return a.FlakeRate > b.FlakeRate
+		}
+		return a.Runs > b.Runs
+	})
+	if top > 0 && len(out.Tests) > top {
+		out.Tests = out.Tests[:top]
+	}
+	return &out, mErr
+}
+
+func digestPath(group string) string {
+	return "flaky-" + config.Normalize(group)
+}
+
+func readDigest(ctx context.Context, client gcs.Opener, path gcs.Path) (*summarypb.FlakyTestDigest, error) {
+	r, err := client.Open(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var digest summarypb.FlakyTestDigest
+	if err := proto.Unmarshal(buf, &digest); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return &digest, nil
+}
+
+func writeDigest(ctx context.Context, client gcs.Uploader, path gcs.Path, digest *summarypb.FlakyTestDigest) error {
+	buf, err := proto.Marshal(digest)
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	return client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestDigest(t *testing.T) {
+	const day = 24 * 60 * 60
+	now := time.Unix(10*day, 0)
+	cols := []*statepb.Column{
+		{Started: (10*day - 100) * 1000},
+		{Started: (10*day - 200) * 1000},
+		{Started: (10*day - 300) * 1000},
+		{Started: (10*day - 400) * 1000},
+	}
+	row := func(name string, results ...statuspb.TestStatus) *statepb.Row {
+		r := statepb.Row{Name: name}
+		for _, res := range results {
+			r.Results = append(r.Results, int32(res), 1)
+			r.Messages = append(r.Messages, "")
+		}
+		return &r
+	}
+	const (
+		pass  = statuspb.TestStatus_PASS
+		fail  = statuspb.TestStatus_FAIL
+		flaky = statuspb.TestStatus_FLAKY
+	)
+	grids := map[string]*statepb.Grid{
+		"some-group": {
+			Columns: cols,
+			Rows: []*statepb.Row{
+				row("sometimes", pass, fail, pass, pass),
+				row("stable", pass, pass, pass, pass),
+			},
+		},
+		"often-group": {
+			Columns: cols,
+			Rows: []*statepb.Row{
+				row("often", flaky, pass, flaky, pass),
+			},
+		},
+	}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "some", TestGroupName: "some-group"},
+					{Name: "missing", TestGroupName: "missing-group"},
+				},
+			},
+			{
+				Name: "other",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "often", TestGroupName: "often-group"},
+				},
+			},
+		},
+	}
+	finder := func(name string) (*configpb.TestGroup, gridReader, error) {
+		reader := func(context.Context) (io.ReadCloser, time.Time, int64, error) {
+			grid, ok := grids[name]
+			if !ok {
+				return nil, time.Time{}, 0, storage.ErrObjectNotExist
+			}
+			return ioutil.NopCloser(bytes.NewBuffer(compress(gridBuf(grid)))), time.Time{}, 0, nil
+		}
+		return &configpb.TestGroup{Name: name}, reader, nil
+	}
+	often := &summarypb.FlakyTest{
+		DashboardName:    "other",
+		DashboardTabName: "often",
+		FlakeRate: &summarypb.TestFlakeRate{
+			DisplayName:      "often",
+			Runs:             4,
+			PassedAfterRetry: 2,
+			FlakeRate:        50,
+		},
+	}
+	sometimes := &summarypb.FlakyTest{
+		DashboardName:    "dash",
+		DashboardTabName: "some",
+		FlakeRate: &summarypb.TestFlakeRate{
+			DisplayName:          "sometimes",
+			Runs:                 4,
+			IntermittentFailures: 1,
+			FlakeRate:            25,
+		},
+	}
+
+	cases := []struct {
+		name       string
+		dashboards []string
+		top        int
+		expected   []*summarypb.FlakyTest
+		err        bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:       "rank flakiest tests",
+			dashboards: []string{"dash", "other"},
+			expected:   []*summarypb.FlakyTest{often, sometimes},
+		},
+		{
+			name:       "keep top tests",
+			dashboards: []string{"dash", "other"},
+			top:        1,
+			expected:   []*summarypb.FlakyTest{often},
+		},
+		{
+			name:       "report unknown dashboards",
+			dashboards: []string{"unknown", "dash"},
+			expected:   []*summarypb.FlakyTest{sometimes},
+			err:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.DashboardGroup{Name: "group", DashboardNames: tc.dashboards}
+			actual, err := digest(context.Background(), cfg, group, finder, now, 7, tc.top)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("digest() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("digest() failed to return an error")
+			}
+			expected := &summarypb.FlakyTestDigest{
+				DashboardGroupName: "group",
+				Days:               7,
+				Start:              &timestamp.Timestamp{Seconds: 3 * day},
+				End:                &timestamp.Timestamp{Seconds: 10 * day},
+				Tests:              tc.expected,
+			}
+			if diff := cmp.Diff(expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("digest() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
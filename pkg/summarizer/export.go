@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// ExportVersion identifies the schema of ExportedSummary.
+//
+// Fields may be added without changing the version, but never renamed or removed.
+const ExportVersion = 1
+
+// ExportedSummary is the stable JSON rendition of a dashboard summary.
+//
+// Stored in GCS as "summary-<normalized dashboard name>.json", next to the proto.
+type ExportedSummary struct {
+	Version   int                `json:"version"`
+	Dashboard string             `json:"dashboard"`
+	Ownership *ExportedOwnership `json:"ownership,omitempty"`
+	Tabs      []ExportedTab      `json:"tabs"`
+}
+
+// ExportedOwnership describes who is responsible for the dashboard.
+type ExportedOwnership struct {
+	Owners  []string `json:"owners,omitempty"`
+	Team    string   `json:"team,omitempty"`
+	Contact string   `json:"contact,omitempty"`
+}
+
+// ExportedTab describes the health of a dashboard tab.
+type ExportedTab struct {
+	Name string `json:"name"`
+	// Description and DocumentationURL come from the tab's configuration.
+	Description      string `json:"description,omitempty"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+	// Status is one of UNKNOWN, PASS, FAIL, FLAKY, STALE or BROKEN.
+	Status string `json:"status"`
+	// Healthy is true for PASS and FLAKY tabs.
+	Healthy bool `json:"healthy"`
+	// Message summarizes the recent results, such as how many columns passed.
+	Message     string     `json:"message"`
+	Alert       string     `json:"alert,omitempty"`
+	LastUpdate  *time.Time `json:"last_update,omitempty"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	LatestGreen string     `json:"latest_green,omitempty"`
+	// RecentHealth reports how many recent columns passed, if any have results.
+	RecentHealth *ExportedHealth `json:"recent_health,omitempty"`
+	// Failures lists the alerting tests.
+	Failures     []ExportedFailure `json:"failures"`
+	LinkedIssues []string          `json:"linked_issues,omitempty"`
+}
+
+// ExportedHealth describes how many of the recent columns of a tab passed.
+type ExportedHealth struct {
+	// Window is the number of recent columns considered.
+	Window           int32   `json:"window"`
+	CompletedColumns int32   `json:"completed_columns"`
+	PassingColumns   int32   `json:"passing_columns"`
+	PassPercent      float32 `json:"pass_percent"`
+}
+
+// ExportedFailure describes an alerting test.
+type ExportedFailure struct {
+	Test           string `json:"test"`
+	DisplayName    string `json:"display_name"`
+	FailCount      int32  `json:"fail_count"`
+	FirstFailBuild string `json:"first_fail_build,omitempty"`
+	LastPassBuild  string `json:"last_pass_build,omitempty"`
+	Message        string `json:"message,omitempty"`
+	IssueURL       string `json:"issue_url,omitempty"`
+	FileBugURL     string `json:"file_bug_url,omitempty"`
+}
+
+// Export renders the summary into its stable JSON representation.
+func Export(dashboard string, sum *summarypb.DashboardSummary) ExportedSummary {
+	out := ExportedSummary{
+		Version:   ExportVersion,
+		Dashboard: dashboard,
+		Tabs:      []ExportedTab{},
+	}
+	if o := sum.GetOwnership(); o != nil {
+		out.Ownership = &ExportedOwnership{
+			Owners:  o.Owners,
+			Team:    o.Team,
+			Contact: o.Contact,
+		}
+	}
+	for _, tab := range sum.GetTabSummaries() {
+		status := tab.OverallStatus
+		if status == summarypb.DashboardTabSummary_NOT_SET {
+			status = summarypb.DashboardTabSummary_UNKNOWN
+		}
+		et := ExportedTab{
+			Name:             tab.DashboardTabName,
+			Description:      tab.Description,
+			DocumentationURL: tab.DocumentationUrl,
+			Status:           status.String(),
+			Healthy:          status == summarypb.DashboardTabSummary_PASS || status == summarypb.DashboardTabSummary_FLAKY,
+			Message:          tab.Status,
+			Alert:            tab.Alert,
+			LastUpdate:       exportTime(tab.LastUpdateTimestamp),
+			LastRun:          exportTime(tab.LastRunTimestamp),
+			LatestGreen:      tab.LatestGreen,
+			Failures:         []ExportedFailure{},
+			LinkedIssues:     tab.LinkedIssues,
+		}
+		if h := tab.RecentHealth; h != nil {
+			et.RecentHealth = &ExportedHealth{
+				Window:           h.Window,
+				CompletedColumns: h.CompletedColumns,
+				PassingColumns:   h.PassingColumns,
+				PassPercent:      h.PassPercent,
+			}
+		}
+		for _, f := range tab.FailingTestSummaries {
+			et.Failures = append(et.Failures, ExportedFailure{
+				Test:           f.TestName,
+				DisplayName:    f.DisplayName,
+				FailCount:      f.FailCount,
+				FirstFailBuild: f.FailBuildId,
+				LastPassBuild:  f.PassBuildId,
+				Message:        f.FailureMessage,
+				IssueURL:       f.IssueUrl,
+				FileBugURL:     f.FileBugUrl,
+			})
+		}
+		out.Tabs = append(out.Tabs, et)
+	}
+	return out
+}
+
+func exportTime(seconds float64) *time.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	whole, frac := math.Modf(seconds)
+	when := time.Unix(int64(whole), int64(frac*1e9)).UTC()
+	return &when
+}
+
+func exportPath(name string) string {
+	return SummaryPath(name) + ".json"
+}
+
+func writeExportedSummary(ctx context.Context, client gcs.Uploader, path gcs.Path, dashboard string, sum *summarypb.DashboardSummary) error {
+	buf, err := json.MarshalIndent(Export(dashboard, sum), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	return client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
+}
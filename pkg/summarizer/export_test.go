@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestExport(t *testing.T) {
+	cases := []struct {
+		name     string
+		sum      *summarypb.DashboardSummary
+		expected string
+	}{
+		{
+			name:     "basically works",
+			expected: `{"version":1,"dashboard":"dash","tabs":[]}`,
+		},
+		{
+			name: "render tabs",
+			sum: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{
+						DashboardTabName:    "failing",
+						Description:         "foo tests",
+						DocumentationUrl:    "https://docs/foo",
+						OverallStatus:       summarypb.DashboardTabSummary_FAIL,
+						Status:              "1 of 2 recent columns passed",
+						LastUpdateTimestamp: 1600000000.5,
+						LastRunTimestamp:    1599999000,
+						LatestGreen:         "42",
+						FailingTestSummaries: []*summarypb.FailingTestSummary{
+							{
+								TestName:       "//foo:test",
+								DisplayName:    "foo",
+								FailCount:      3,
+								FailBuildId:    "43",
+								PassBuildId:    "42",
+								FailureMessage: "boom",
+								IssueUrl:       "https://github.com/o/r/issues/1",
+							},
+						},
+						LinkedIssues: []string{"123"},
+						RecentHealth: &summarypb.RecentHealth{
+							Window:           50,
+							CompletedColumns: 2,
+							PassingColumns:   1,
+							PassPercent:      50,
+						},
+					},
+					{
+						DashboardTabName: "unsummarized",
+						Alert:            "failed to summarize tab",
+					},
+				},
+			},
+			expected: `{"version":1,"dashboard":"dash","tabs":[` +
+				`{"name":"failing","description":"foo tests","documentation_url":"https://docs/foo","status":"FAIL","healthy":false,"message":"1 of 2 recent columns passed",` +
+				`"last_update":"2020-09-13T12:26:40.5Z","last_run":"2020-09-13T12:10:00Z","latest_green":"42",` +
+				`"recent_health":{"window":50,"completed_columns":2,"passing_columns":1,"pass_percent":50},` +
+				`"failures":[{"test":"//foo:test","display_name":"foo","fail_count":3,"first_fail_build":"43",` +
+				`"last_pass_build":"42","message":"boom","issue_url":"https://github.com/o/r/issues/1"}],"linked_issues":["123"]},` +
+				`{"name":"unsummarized","status":"UNKNOWN","healthy":false,"message":"","alert":"failed to summarize tab","failures":[]}]}`,
+		},
+		{
+			name: "render ownership",
+			sum: &summarypb.DashboardSummary{
+				Ownership: &summarypb.DashboardOwnership{
+					Owners:  []string{"alice"},
+					Contact: "team@example.com",
+				},
+			},
+			expected: `{"version":1,"dashboard":"dash","ownership":{"owners":["alice"],"contact":"team@example.com"},"tabs":[]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf, err := json.Marshal(Export("dash", tc.sum))
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, string(buf)); diff != "" {
+				t.Errorf("Export() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,10 @@ package summarizer
 import (
 	"context"
 	"regexp"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
 
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
@@ -54,6 +58,48 @@ func CalculateHealthiness(grid *statepb.Grid, startTime int, endTime int, tab st
 	return analyzer.GetFlakiness(gridMetrics, minRuns, startTime, endTime, tab)
 }
 
+// CalculateFlakeRates computes the flake rate of each test over each window of days ending at now.
+func CalculateFlakeRates(grid *statepb.Grid, now time.Time, windows []int32) []*summarypb.FlakeRateWindow {
+	end := goBackDays(0, now)
+	out := make([]*summarypb.FlakeRateWindow, 0, len(windows))
+	for _, days := range windows {
+		start := goBackDays(int(days), now)
+		_, statuses := parseGrid(grid, start, end)
+		names := make([]string, 0, len(statuses))
+		for name := range statuses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		window := summarypb.FlakeRateWindow{
+			Days:  days,
+			Start: &timestamp.Timestamp{Seconds: int64(start)},
+			End:   &timestamp.Timestamp{Seconds: int64(end)},
+		}
+		for _, name := range names {
+			if rate := analyzers.FlakeRate(name, statuses[name]); rate.Runs > 0 {
+				window.Tests = append(window.Tests, rate)
+			}
+		}
+		out = append(out, &window)
+	}
+	return out
+}
+
+// rowFlakeRates returns the flake rate of each row that flaked during the window.
+func rowFlakeRates(window *summarypb.FlakeRateWindow) map[string]float32 {
+	var out map[string]float32
+	for _, test := range window.GetTests() {
+		if test.FlakeRate == 0 {
+			continue
+		}
+		if out == nil {
+			out = map[string]float32{}
+		}
+		out[test.DisplayName] = test.FlakeRate
+	}
+	return out
+}
+
 // CalculateTrend populates the ChangeFromLastInterval fields of each TestInfo by comparing
 // the current flakiness to the flakiness calculated for the last interval. Interval length
 // is a config value that is 7 days by default. The Trend enum defaults to UNKNOWN, so there
@@ -19,6 +19,7 @@ package summarizer
 import (
 	"context"
 	"testing"
+	"time"
 
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
@@ -26,8 +27,10 @@ import (
 	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer/analyzers"
 	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer/common"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestCalculateTrend(t *testing.T) {
@@ -642,3 +645,138 @@ func TestFailingColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateFlakeRates(t *testing.T) {
+	const day = 24 * 60 * 60
+	now := time.Unix(10*day, 0)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Started: (10*day - 100) * 1000},
+			{Started: (10*day - 200) * 1000},
+			{Started: (5 * day) * 1000},
+			{Started: (5 * day) * 1000},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name: "flaky",
+				Results: []int32{
+					statuspb.TestStatus_value["FLAKY"], 1,
+					statuspb.TestStatus_value["PASS"], 1,
+					statuspb.TestStatus_value["FAIL"], 1,
+					statuspb.TestStatus_value["PASS"], 1,
+				},
+				Messages: []string{"", "", "", ""},
+			},
+			{
+				Name: "stable",
+				Results: []int32{
+					statuspb.TestStatus_value["PASS"], 4,
+				},
+				Messages: []string{"", "", "", ""},
+			},
+			{
+				Name: "old",
+				Results: []int32{
+					statuspb.TestStatus_value["NO_RESULT"], 2,
+					statuspb.TestStatus_value["PASS"], 2,
+				},
+				Messages: []string{"", ""},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		windows  []int32
+		expected []*summarypb.FlakeRateWindow
+	}{
+		{
+			name:     "basically works",
+			expected: []*summarypb.FlakeRateWindow{},
+		},
+		{
+			name:    "multiple windows",
+			windows: []int32{1, 7},
+			expected: []*summarypb.FlakeRateWindow{
+				{
+					Days:  1,
+					Start: &timestamp.Timestamp{Seconds: 9 * day},
+					End:   &timestamp.Timestamp{Seconds: 10 * day},
+					Tests: []*summarypb.TestFlakeRate{
+						{
+							DisplayName:      "flaky",
+							Runs:             2,
+							PassedAfterRetry: 1,
+							FlakeRate:        50,
+						},
+						{
+							DisplayName: "stable",
+							Runs:        2,
+						},
+					},
+				},
+				{
+					Days:  7,
+					Start: &timestamp.Timestamp{Seconds: 3 * day},
+					End:   &timestamp.Timestamp{Seconds: 10 * day},
+					Tests: []*summarypb.TestFlakeRate{
+						{
+							DisplayName:          "flaky",
+							Runs:                 4,
+							PassedAfterRetry:     1,
+							IntermittentFailures: 1,
+							FlakeRate:            50,
+						},
+						{
+							DisplayName: "old",
+							Runs:        2,
+						},
+						{
+							DisplayName: "stable",
+							Runs:        4,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := CalculateFlakeRates(grid, now, tc.windows)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("CalculateFlakeRates() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRowFlakeRates(t *testing.T) {
+	cases := []struct {
+		name     string
+		window   *summarypb.FlakeRateWindow
+		expected map[string]float32
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "annotate flaky rows",
+			window: &summarypb.FlakeRateWindow{
+				Tests: []*summarypb.TestFlakeRate{
+					{DisplayName: "flaky", Runs: 4, IntermittentFailures: 1, FlakeRate: 25},
+					{DisplayName: "stable", Runs: 4},
+				},
+			},
+			expected: map[string]float32{"flaky": 25},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, rowFlakeRates(tc.window)); diff != "" {
+				t.Errorf("rowFlakeRates() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
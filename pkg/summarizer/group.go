@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// UpdateGroupSummaries rolls up the dashboard summaries under summaryPathPrefix into a summary of each dashboard group.
+//
+// Setting dashboardGroup limits the update to this group.
+// Lists the top least healthy tabs of each group, or all of them when top is zero.
+// Will write each roll-up next to the summaries when confirm is set.
+func UpdateGroupSummaries(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, dashboardGroup, summaryPathPrefix string, top int, confirm bool) error {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("Failed to read config: %w", err)
+	}
+	resolve := func(name string) (*gcs.Path, error) {
+		return configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, name)})
+	}
+	now := time.Now()
+
+	var errs []string
+	for _, group := range cfg.DashboardGroups {
+		if dashboardGroup != "" && dashboardGroup != group.Name {
+			continue
+		}
+		log := logrus.WithField("dashboard-group", group.Name)
+		sums := map[string]*summarypb.DashboardSummary{}
+		for _, name := range group.DashboardNames {
+			summaryPath, err := resolve(SummaryPath(name))
+			if err != nil {
+				log.WithError(err).WithField("dashboard", name).Warning("Cannot resolve summary path")
+				continue
+			}
+			sum, err := ReadSummary(ctx, client, *summaryPath)
+			if err != nil {
+				log.WithError(err).WithField("dashboard", name).Warning("Cannot read summary")
+				continue
+			}
+			if sum != nil {
+				sums[name] = sum
+			}
+		}
+		rollup, err := rollUpGroup(group, sums, now, top)
+		if err != nil {
+			log.WithError(err).Warning("Incomplete group summary")
+		}
+		log.WithFields(logrus.Fields{
+			"tabs":    rollup.TotalTabs,
+			"passing": rollup.PassingTabs,
+		}).Info("Rolled up dashboard group")
+		if !confirm {
+			continue
+		}
+		groupPath, err := resolve(GroupSummaryPath(group.Name))
+		if err != nil {
+			log.WithError(err).Error("Cannot resolve group summary path")
+			errs = append(errs, group.Name)
+			continue
+		}
+		if err := writeGroupSummary(ctx, client, *groupPath, rollup); err != nil {
+			log.WithError(err).Error("Cannot write group summary")
+			errs = append(errs, group.Name)
+		}
+	}
+	if n := len(errs); n > 0 {
+		return fmt.Errorf("failed to update %d group summaries: %v", n, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// GroupSummaryPath returns the name of the dashboard group's roll-up under the summary path prefix.
+func GroupSummaryPath(name string) string {
+	return "group-" + config.Normalize(name)
+}
+
+// tabSeverity ranks tab statuses from the least to the most severe.
+var tabSeverity = map[summarypb.DashboardTabSummary_TabStatus]int{
+	summarypb.DashboardTabSummary_NOT_SET: 0,
+	summarypb.DashboardTabSummary_PASS:    1,
+	summarypb.DashboardTabSummary_FLAKY:   2,
+	summarypb.DashboardTabSummary_UNKNOWN: 3,
+	summarypb.DashboardTabSummary_STALE:   4,
+	summarypb.DashboardTabSummary_FAIL:    5,
+	summarypb.DashboardTabSummary_BROKEN:  6,
+}
+
+func passingTab(status summarypb.DashboardTabSummary_TabStatus) bool {
+	return status == summarypb.DashboardTabSummary_PASS || status == summarypb.DashboardTabSummary_FLAKY
+}
+
+// rollUpGroup aggregates the summary of each dashboard in the group, keeping the top least healthy tabs.
+//
+// Returns an error listing the dashboards without a summary along with the roll-up of the others.
+func rollUpGroup(group *configpb.DashboardGroup, sums map[string]*summarypb.DashboardSummary, now time.Time, top int) (*summarypb.DashboardGroupSummary, error) {
+	out := summarypb.DashboardGroupSummary{
+		DashboardGroupName:  group.Name,
+		LastUpdateTimestamp: float64(now.Unix()),
+		TabStatuses:         map[string]int32{},
+	}
+	var mErr error
+	for _, name := range group.DashboardNames {
+		dash := summarypb.DashboardRollup{
+			DashboardName: name,
+		}
+		sum, ok := sums[name]
+		if !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s: summary not found", name))
+			dash.OverallStatus = summarypb.DashboardTabSummary_UNKNOWN
+		}
+		for _, tab := range sum.GetTabSummaries() {
+			status := tab.OverallStatus
+			dash.TotalTabs++
+			out.TabStatuses[status.String()]++
+			if tabSeverity[status] > tabSeverity[dash.OverallStatus] {
+				dash.OverallStatus = status
+			}
+			if passingTab(status) {
+				dash.PassingTabs++
+				continue
+			}
+			out.WorstTabs = append(out.WorstTabs, &summarypb.TabRollup{
+				DashboardName:    name,
+				DashboardTabName: tab.DashboardTabName,
+				OverallStatus:    status,
+				Status:           tab.Status,
+				FailingTests:     int32(len(tab.FailingTestSummaries)),
+				LastRunTimestamp: tab.LastRunTimestamp,
+			})
+		}
+		out.TotalTabs += dash.TotalTabs
+		out.PassingTabs += dash.PassingTabs
+		out.Dashboards = append(out.Dashboards, &dash)
+	}
+	sort.SliceStable(out.WorstTabs, func(i, j int) bool {
+		a, b := out.WorstTabs[i], out.WorstTabs[j]
+		if sa, sb := tabSeverity[a.OverallStatus], tabSeverity[b.OverallStatus]; sa != sb {
+			return sa > sb
+		}
+		return a.FailingTests > b.FailingTests
+	})
+	if top > 0 && len(out.WorstTabs) > top {
+		out.WorstTabs = out.WorstTabs[:top]
+	}
+	return &out, mErr
+}
+
+func writeGroupSummary(ctx context.Context, client gcs.Uploader, path gcs.Path, sum *summarypb.DashboardGroupSummary) error {
+	buf, err := proto.Marshal(sum)
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	return client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestRollUpGroup(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tab := func(name string, status summarypb.DashboardTabSummary_TabStatus, failures int) *summarypb.DashboardTabSummary {
+		ts := summarypb.DashboardTabSummary{
+			DashboardTabName: name,
+			OverallStatus:    status,
+			Status:           name + " status",
+			LastRunTimestamp: 900,
+		}
+		for i := 0; i < failures; i++ {
+			ts.FailingTestSummaries = append(ts.FailingTestSummaries, &summarypb.FailingTestSummary{})
+		}
+		return &ts
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.DashboardGroup
+		sums     map[string]*summarypb.DashboardSummary
+		top      int
+		expected *summarypb.DashboardGroupSummary
+		err      bool
+	}{
+		{
+			name:  "basically works",
+			group: &configpb.DashboardGroup{Name: "group"},
+			expected: &summarypb.DashboardGroupSummary{
+				DashboardGroupName:  "group",
+				LastUpdateTimestamp: 1000,
+			},
+		},
+		{
+			name: "count tabs and rank the worst",
+			group: &configpb.DashboardGroup{
+				Name:           "group",
+				DashboardNames: []string{"healthy", "sick"},
+			},
+			sums: map[string]*summarypb.DashboardSummary{
+				"healthy": {
+					TabSummaries: []*summarypb.DashboardTabSummary{
+						tab("pass", summarypb.DashboardTabSummary_PASS, 0),
+						tab("flaky", summarypb.DashboardTabSummary_FLAKY, 0),
+					},
+				},
+				"sick": {
+					TabSummaries: []*summarypb.DashboardTabSummary{
+						tab("stale", summarypb.DashboardTabSummary_STALE, 0),
+						tab("some-fail", summarypb.DashboardTabSummary_FAIL, 1),
+						tab("broken", summarypb.DashboardTabSummary_BROKEN, 2),
+						tab("many-fail", summarypb.DashboardTabSummary_FAIL, 3),
+					},
+				},
+			},
+			expected: &summarypb.DashboardGroupSummary{
+				DashboardGroupName:  "group",
+				LastUpdateTimestamp: 1000,
+				TotalTabs:           6,
+				PassingTabs:         2,
+				TabStatuses: map[string]int32{
+					"PASS":   1,
+					"FLAKY":  1,
+					"STALE":  1,
+					"FAIL":   2,
+					"BROKEN": 1,
+				},
+				Dashboards: []*summarypb.DashboardRollup{
+					{
+						DashboardName: "healthy",
+						TotalTabs:     2,
+						PassingTabs:   2,
+						OverallStatus: summarypb.DashboardTabSummary_FLAKY,
+					},
+					{
+						DashboardName: "sick",
+						TotalTabs:     4,
+						OverallStatus: summarypb.DashboardTabSummary_BROKEN,
+					},
+				},
+				WorstTabs: []*summarypb.TabRollup{
+					{
+						DashboardName:    "sick",
+						DashboardTabName: "broken",
+						OverallStatus:    summarypb.DashboardTabSummary_BROKEN,
+						Status:           "broken status",
+						FailingTests:     2,
+						LastRunTimestamp: 900,
+					},
+					{
+						DashboardName:    "sick",
+						DashboardTabName: "many-fail",
+						OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+						Status:           "many-fail status",
+						FailingTests:     3,
+						LastRunTimestamp: 900,
+					},
+					{
+						DashboardName:    "sick",
+						DashboardTabName: "some-fail",
+						OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+						Status:           "some-fail status",
+						FailingTests:     1,
+						LastRunTimestamp: 900,
+					},
+					{
+						DashboardName:    "sick",
+						DashboardTabName: "stale",
+						OverallStatus:    summarypb.DashboardTabSummary_STALE,
+						Status:           "stale status",
+						LastRunTimestamp: 900,
+					},
+				},
+			},
+		},
+		{
+			name: "keep the top tabs",
+			group: &configpb.DashboardGroup{
+				Name:           "group",
+				DashboardNames: []string{"sick"},
+			},
+			sums: map[string]*summarypb.DashboardSummary{
+				"sick": {
+					TabSummaries: []*summarypb.DashboardTabSummary{
+						tab("fail", summarypb.DashboardTabSummary_FAIL, 1),
+						tab("broken", summarypb.DashboardTabSummary_BROKEN, 0),
+					},
+				},
+			},
+			top: 1,
+			expected: &summarypb.DashboardGroupSummary{
+				DashboardGroupName:  "group",
+				LastUpdateTimestamp: 1000,
+				TotalTabs:           2,
+				TabStatuses: map[string]int32{
+					"FAIL":   1,
+					"BROKEN": 1,
+				},
+				Dashboards: []*summarypb.DashboardRollup{
+					{
+						DashboardName: "sick",
+						TotalTabs:     2,
+						OverallStatus: summarypb.DashboardTabSummary_BROKEN,
+					},
+				},
+				WorstTabs: []*summarypb.TabRollup{
+					{
+						DashboardName:    "sick",
+						DashboardTabName: "broken",
+						OverallStatus:    summarypb.DashboardTabSummary_BROKEN,
+						Status:           "broken status",
+						LastRunTimestamp: 900,
+					},
+				},
+			},
+		},
+		{
+			name: "missing summaries are unknown",
+			group: &configpb.DashboardGroup{
+				Name:           "group",
+				DashboardNames: []string{"missing", "healthy"},
+			},
+			sums: map[string]*summarypb.DashboardSummary{
+				"healthy": {
+					TabSummaries: []*summarypb.DashboardTabSummary{
+						tab("pass", summarypb.DashboardTabSummary_PASS, 0),
+					},
+				},
+			},
+			expected: &summarypb.DashboardGroupSummary{
+				DashboardGroupName:  "group",
+				LastUpdateTimestamp: 1000,
+				TotalTabs:           1,
+				PassingTabs:         1,
+				TabStatuses: map[string]int32{
+					"PASS": 1,
+				},
+				Dashboards: []*summarypb.DashboardRollup{
+					{
+						DashboardName: "missing",
+						OverallStatus: summarypb.DashboardTabSummary_UNKNOWN,
+					},
+					{
+						DashboardName: "healthy",
+						TotalTabs:     1,
+						PassingTabs:   1,
+						OverallStatus: summarypb.DashboardTabSummary_PASS,
+					},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := rollUpGroup(tc.group, tc.sums, now, tc.top)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("rollUpGroup() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("rollUpGroup() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("rollUpGroup() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupSummaryPath(t *testing.T) {
+	if got, want := GroupSummaryPath("SIG Node: Release"), "group-signoderelease"; got != want {
+		t.Errorf("GroupSummaryPath() got %q, want %q", got, want)
+	}
+}
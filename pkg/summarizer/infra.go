@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/alerts"
+)
+
+// infraColumns returns whether each column is an infrastructure failure.
+//
+// A column is an infrastructure failure when the ratio of its failing rows
+// to rows with results exceeds the threshold, which requires at least two rows with results,
+// or when any of its results has a non-empty value for the property.
+func infraColumns(grid *statepb.Grid, threshold float32, property string) []bool {
+	out := make([]bool, len(grid.Columns))
+	if property != "" {
+		propertyColumns(grid, property, out)
+	}
+	if threshold <= 0 {
+		return out
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := results(ctx, grid.Rows)
+	for i := range grid.Columns {
+		var failures, total int
+		for _, ch := range results {
+			res := coalesceResult(<-ch, result.IgnoreRunning)
+			switch {
+			case res == statuspb.TestStatus_NO_RESULT:
+				continue
+			case res == statuspb.TestStatus_FAIL:
+				failures++
+			}
+			total++
+		}
+		out[i] = out[i] || total > 1 && float32(failures)/float32(total) > threshold
+	}
+	return out
+}
+
+// propertyColumns marks the columns with a result that has a non-empty value for the property.
+func propertyColumns(grid *statepb.Grid, property string, out []bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, row := range grid.Rows {
+		if len(row.Properties) == 0 {
+			continue
+		}
+		// Properties are parallel to the messages of results other than NO_RESULT.
+		var col, filled int
+		for res := range resultIter(ctx, row.Results) {
+			if col >= len(out) || filled >= len(row.Properties) {
+				break
+			}
+			if res != statuspb.TestStatus_NO_RESULT {
+				if row.Properties[filled].GetProperty()[property] != "" {
+					out[col] = true
+				}
+				filled++
+			}
+			col++
+		}
+	}
+}
+
+// infraBuilds returns the builds of the recent columns marked as infrastructure failures.
+func infraBuilds(cols []*statepb.Column, infra []bool, recent int) []string {
+	var builds []string
+	for i, col := range cols {
+		if i >= recent {
+			break
+		}
+		if infra[i] {
+			builds = append(builds, col.Build)
+		}
+	}
+	return builds
+}
+
+// withoutColumns returns a copy of the grid without the dropped columns.
+//
+// Rows of the copy share the order of the grid's rows, with failure streaks of the remaining columns.
+// Returns the grid itself when nothing is dropped.
+func withoutColumns(grid *statepb.Grid, drop []bool) *statepb.Grid {
+	var dropped bool
+	for _, d := range drop {
+		dropped = dropped || d
+	}
+	if !dropped {
+		return grid
+	}
+	out := statepb.Grid{
+		Columns: make([]*statepb.Column, 0, len(grid.Columns)),
+		Config:  grid.Config,
+	}
+	for i, col := range grid.Columns {
+		if !drop[i] {
+			out.Columns = append(out.Columns, col)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, row := range grid.Rows {
+		r := statepb.Row{
+			Name:   row.Name,
+			Id:     row.Id,
+			BugId:  row.BugId,
+			Metric: row.Metric,
+		}
+		values := make([]map[int]float64, len(row.Metrics))
+		for m, metric := range row.Metrics {
+			r.Metrics = append(r.Metrics, &statepb.Metric{Name: metric.Name})
+			values[m] = metricValues(metric)
+		}
+		ch := resultIter(ctx, row.Results)
+		var col int32
+		var idx int
+		for i := range grid.Columns {
+			res, ok := <-ch
+			if !ok {
+				break
+			}
+			filled := res != statuspb.TestStatus_NO_RESULT
+			if !drop[i] {
+				appendResult(&r, res)
+				// Cell IDs and user properties include empty cells.
+				if i < len(row.CellIds) {
+					r.CellIds = append(r.CellIds, row.CellIds[i])
+				}
+				if i < len(row.UserProperty) {
+					r.UserProperty = append(r.UserProperty, row.UserProperty[i])
+				}
+				if filled {
+					if idx < len(row.Messages) {
+						r.Messages = append(r.Messages, row.Messages[idx])
+					}
+					if idx < len(row.Icons) {
+						r.Icons = append(r.Icons, row.Icons[idx])
+					}
+					if idx < len(row.Properties) {
+						r.Properties = append(r.Properties, row.Properties[idx])
+					}
+				}
+				for m, vals := range values {
+					if v, ok := vals[i]; ok {
+						appendValue(r.Metrics[m], col, v)
+					}
+				}
+				col++
+			}
+			if filled {
+				idx++
+			}
+		}
+		out.Rows = append(out.Rows, &r)
+	}
+	if hasFailureStreaks(grid.Rows) {
+		alerts.FailureStreaks(out.Columns, out.Rows)
+	}
+	return &out
+}
+
+// metricValues returns the sparse values of the metric by column.
+func metricValues(metric *statepb.Metric) map[int]float64 {
+	out := map[int]float64{}
+	var v int
+	for i := 0; i+1 < len(metric.Indices); i += 2 {
+		first, count := int(metric.Indices[i]), int(metric.Indices[i+1])
+		for j := first; j < first+count && v < len(metric.Values); j++ {
+			out[j] = metric.Values[v]
+			v++
+		}
+	}
+	return out
+}
+
+// appendValue adds the value of the column to the sparse values of the metric.
+//
+// Columns must be monotonically increasing.
+func appendValue(metric *statepb.Metric, col int32, value float64) {
+	if l := len(metric.Indices); l > 0 && metric.Indices[l-2]+metric.Indices[l-1] == col {
+		metric.Indices[l-1]++
+	} else {
+		metric.Indices = append(metric.Indices, col, 1)
+	}
+	metric.Values = append(metric.Values, value)
+}
+
+// appendResult adds the result to the run-length encoded results of the row.
+func appendResult(row *statepb.Row, res statuspb.TestStatus) {
+	if n := len(row.Results); n > 1 && row.Results[n-2] == int32(res) {
+		row.Results[n-1]++
+		return
+	}
+	row.Results = append(row.Results, int32(res), 1)
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestInfraColumns(t *testing.T) {
+	cases := []struct {
+		name      string
+		rows      []*statepb.Row
+		threshold float32
+		property  string
+		expected  []bool
+	}{
+		{
+			name:     "basically works",
+			expected: []bool{false, false, false},
+		},
+		{
+			name: "disabled without threshold",
+			rows: []*statepb.Row{
+				{Name: "a", Results: []int32{int32(statuspb.TestStatus_FAIL), 3}},
+				{Name: "b", Results: []int32{int32(statuspb.TestStatus_FAIL), 3}},
+			},
+			expected: []bool{false, false, false},
+		},
+		{
+			name: "mark mostly failing columns",
+			rows: []*statepb.Row{
+				{
+					Name: "a",
+					Results: []int32{
+						int32(statuspb.TestStatus_BUILD_FAIL), 1,
+						int32(statuspb.TestStatus_PASS), 2,
+					},
+				},
+				{
+					Name: "b",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 2,
+						int32(statuspb.TestStatus_PASS), 1,
+					},
+				},
+				{
+					Name: "c",
+					Results: []int32{
+						int32(statuspb.TestStatus_TIMED_OUT), 1,
+						int32(statuspb.TestStatus_NO_RESULT), 1,
+						int32(statuspb.TestStatus_PASS), 1,
+					},
+				},
+			},
+			threshold: 0.6,
+			expected:  []bool{true, false, false},
+		},
+		{
+			name: "require multiple results",
+			rows: []*statepb.Row{
+				{Name: "a", Results: []int32{int32(statuspb.TestStatus_FAIL), 3}},
+				{Name: "b", Results: []int32{int32(statuspb.TestStatus_NO_RESULT), 3}},
+			},
+			threshold: 0.5,
+			expected:  []bool{false, false, false},
+		},
+		{
+			name: "mark columns with the property",
+			rows: []*statepb.Row{
+				{
+					Name: "a",
+					Results: []int32{
+						int32(statuspb.TestStatus_PASS), 1,
+						int32(statuspb.TestStatus_NO_RESULT), 1,
+						int32(statuspb.TestStatus_FAIL), 1,
+					},
+					Messages: []string{"", ""},
+					Properties: []*statepb.Property{
+						{},
+						{Property: map[string]string{"infra": "cluster failed to start"}},
+					},
+				},
+				{
+					Name: "b",
+					Results: []int32{
+						int32(statuspb.TestStatus_PASS), 3,
+					},
+					Messages: []string{"", "", ""},
+					Properties: []*statepb.Property{
+						{Property: map[string]string{"infra": "", "other": "yes"}},
+					},
+				},
+			},
+			property: "infra",
+			expected: []bool{false, false, true},
+		},
+		{
+			name: "combine property and threshold",
+			rows: []*statepb.Row{
+				{
+					Name:     "a",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_PASS), 2},
+					Messages: []string{"", "", ""},
+					Properties: []*statepb.Property{
+						{},
+						{},
+						{Property: map[string]string{"infra": "true"}},
+					},
+				},
+				{Name: "b", Results: []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_PASS), 2}},
+			},
+			threshold: 0.5,
+			property:  "infra",
+			expected:  []bool{true, false, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+				Rows:    tc.rows,
+			}
+			actual := infraColumns(grid, tc.threshold, tc.property)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("infraColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInfraBuilds(t *testing.T) {
+	cols := []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}}
+	cases := []struct {
+		name     string
+		infra    []bool
+		recent   int
+		expected []string
+	}{
+		{
+			name:   "basically works",
+			infra:  []bool{false, false, false},
+			recent: 3,
+		},
+		{
+			name:     "only recent columns",
+			infra:    []bool{true, false, true},
+			recent:   2,
+			expected: []string{"3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := infraBuilds(cols, tc.infra, tc.recent)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("infraBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithoutColumns(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name: "row",
+				Id:   "id",
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				CellIds:  []string{"c3", "c2", "c1"},
+				Messages: []string{"m3", "m2", "m1"},
+				Icons:    []string{"i3", "i2", "i1"},
+				Properties: []*statepb.Property{
+					{Property: map[string]string{"log": "p3"}},
+					{Property: map[string]string{"log": "p2"}},
+					{Property: map[string]string{"log": "p1"}},
+				},
+				BugId:  []string{"bug"},
+				Metric: []string{"elapsed"},
+				Metrics: []*statepb.Metric{
+					{
+						Name:    "elapsed",
+						Indices: []int32{0, 3},
+						Values:  []float64{3, 2, 1},
+					},
+				},
+			},
+			{
+				Name: "sparse",
+				Results: []int32{
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+				},
+				CellIds:      []string{"", "c2", "c1"},
+				UserProperty: []string{"", "u2", "u1"},
+				Messages:     []string{"m2", "m1"},
+				Properties: []*statepb.Property{
+					{},
+					{Property: map[string]string{"log": "p1"}},
+				},
+				Metrics: []*statepb.Metric{
+					{
+						Name:    "elapsed",
+						Indices: []int32{1, 1},
+						Values:  []float64{2},
+					},
+					{
+						Name:    "flakes",
+						Indices: []int32{2, 1},
+						Values:  []float64{7},
+					},
+				},
+				FailureStreak: &statepb.AlertInfo{FailCount: 1},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		drop     []bool
+		expected *statepb.Grid
+	}{
+		{
+			name:     "basically works",
+			drop:     []bool{false, false, false},
+			expected: grid,
+		},
+		{
+			name: "drop columns",
+			drop: []bool{false, true, false},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "3"}, {Build: "1"}},
+				Rows: []*statepb.Row{
+					{
+						Name:     "row",
+						Id:       "id",
+						Results:  []int32{int32(statuspb.TestStatus_PASS), 2},
+						CellIds:  []string{"c3", "c1"},
+						Messages: []string{"m3", "m1"},
+						Icons:    []string{"i3", "i1"},
+						Properties: []*statepb.Property{
+							{Property: map[string]string{"log": "p3"}},
+							{Property: map[string]string{"log": "p1"}},
+						},
+						BugId:  []string{"bug"},
+						Metric: []string{"elapsed"},
+						Metrics: []*statepb.Metric{
+							{
+								Name:    "elapsed",
+								Indices: []int32{0, 2},
+								Values:  []float64{3, 1},
+							},
+						},
+					},
+					{
+						Name: "sparse",
+						Results: []int32{
+							int32(statuspb.TestStatus_NO_RESULT), 1,
+							int32(statuspb.TestStatus_FLAKY), 1,
+						},
+						CellIds:      []string{"", "c1"},
+						UserProperty: []string{"", "u1"},
+						Messages:     []string{"m1"},
+						Properties: []*statepb.Property{
+							{Property: map[string]string{"log": "p1"}},
+						},
+						Metrics: []*statepb.Metric{
+							{Name: "elapsed"},
+							{
+								Name:    "flakes",
+								Indices: []int32{1, 1},
+								Values:  []float64{7},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := withoutColumns(grid, tc.drop)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("withoutColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
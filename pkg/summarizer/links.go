@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// describeTab copies the description and documentation link of the tab into its summary.
+func describeTab(sum *summarypb.DashboardTabSummary, tab *configpb.DashboardTab) {
+	sum.Description = tab.Description
+	sum.DocumentationUrl = tab.DocumentationUrl
+}
+
+// fileBugTemplate returns the tab's file_bug_template, or else the dashboard's.
+func fileBugTemplate(dash *configpb.Dashboard, tab *configpb.DashboardTab) *configpb.LinkTemplate {
+	if tab.GetFileBugTemplate().GetUrl() != "" {
+		return tab.FileBugTemplate
+	}
+	return dash.GetFileBugTemplate()
+}
+
+// fileBugLinks expands the file bug template of the tab for the tab and each of its failing tests.
+//
+// Test fields are empty in the link for the tab.
+func fileBugLinks(tmpl *configpb.LinkTemplate, group *configpb.TestGroup, sum *summarypb.DashboardTabSummary) {
+	if tmpl.GetUrl() == "" {
+		return
+	}
+	fields := func(f *summarypb.FailingTestSummary) map[string]string {
+		status := "Failed"
+		if f == nil {
+			status = ""
+		}
+		return map[string]string{
+			"environment":     sum.DashboardTabName,
+			"gcs_prefix":      group.GetGcsPrefix(),
+			"test-name":       f.GetTestName(),
+			"display-name":    f.GetDisplayName(),
+			"test-status":     status,
+			"failure-message": f.GetFailureMessage(),
+			"build-id":        f.GetFailBuildId(),
+		}
+	}
+	sum.BugUrl = config.ExpandLink(tmpl, fields(nil))
+	for _, f := range sum.FailingTestSummaries {
+		f.FileBugUrl = config.ExpandLink(tmpl, fields(f))
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestFileBugTemplate(t *testing.T) {
+	dashTemplate := &configpb.LinkTemplate{Url: "https://dash"}
+	tabTemplate := &configpb.LinkTemplate{Url: "https://tab"}
+	cases := []struct {
+		name     string
+		dash     *configpb.Dashboard
+		tab      *configpb.DashboardTab
+		expected *configpb.LinkTemplate
+	}{
+		{
+			name: "basically works",
+			dash: &configpb.Dashboard{},
+			tab:  &configpb.DashboardTab{},
+		},
+		{
+			name:     "default to dashboard template",
+			dash:     &configpb.Dashboard{FileBugTemplate: dashTemplate},
+			tab:      &configpb.DashboardTab{FileBugTemplate: &configpb.LinkTemplate{}},
+			expected: dashTemplate,
+		},
+		{
+			name:     "prefer tab template",
+			dash:     &configpb.Dashboard{FileBugTemplate: dashTemplate},
+			tab:      &configpb.DashboardTab{FileBugTemplate: tabTemplate},
+			expected: tabTemplate,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := fileBugTemplate(tc.dash, tc.tab)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("fileBugTemplate() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileBugLinks(t *testing.T) {
+	tmpl := &configpb.LinkTemplate{
+		Url: "https://bugs/<environment>/new",
+		Options: []*configpb.LinkOptionsTemplate{
+			{Key: "title", Value: "<display-name> failing since <build-id>"},
+			{Key: "body", Value: "<gcs_prefix>: <failure-message>"},
+		},
+	}
+	sum := &summarypb.DashboardTabSummary{
+		DashboardTabName: "tab",
+		FailingTestSummaries: []*summarypb.FailingTestSummary{
+			{
+				TestName:       "//foo:bar",
+				DisplayName:    "bar",
+				FailBuildId:    "42",
+				FailureMessage: "boom",
+			},
+		},
+	}
+	fileBugLinks(tmpl, &configpb.TestGroup{GcsPrefix: "bucket/job"}, sum)
+	expected := &summarypb.DashboardTabSummary{
+		DashboardTabName: "tab",
+		BugUrl:           "https://bugs/tab/new?body=bucket%2Fjob%3A+&title=+failing+since+",
+		FailingTestSummaries: []*summarypb.FailingTestSummary{
+			{
+				TestName:       "//foo:bar",
+				DisplayName:    "bar",
+				FailBuildId:    "42",
+				FailureMessage: "boom",
+				FileBugUrl:     "https://bugs/tab/new?body=bucket%2Fjob%3A+boom&title=bar+failing+since+42",
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, sum, protocmp.Transform()); diff != "" {
+		t.Errorf("fileBugLinks() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Actions of audit entries.
+const (
+	// AuditOpen means a tab started alerting.
+	AuditOpen = "open"
+	// AuditResolve means an alerting tab recovered.
+	AuditResolve = "resolve"
+	// AuditNotify means an event was sent.
+	AuditNotify = "notify"
+)
+
+// AuditEntry records an alert opening, resolving or sending a notification.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Dashboard string    `json:"dashboard"`
+	Tab       string    `json:"tab"`
+	Action    string    `json:"action"`
+	// Kind is the kind of event sent, for notify entries.
+	Kind string `json:"kind,omitempty"`
+	// Status is the overall status of the tab.
+	Status string `json:"status,omitempty"`
+	// Tests lists the tests which started failing, for notify entries.
+	Tests []string `json:"tests,omitempty"`
+	// Recipients lists where the config routes the event, such as slack:channel or email:address.
+	Recipients []string `json:"recipients,omitempty"`
+	// Error describes why sending the events failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditPath returns the name of the dashboard's audit log for the month of when, relative to the summaries.
+func AuditPath(dashboard string, when time.Time) string {
+	return fmt.Sprintf("audit-%s-%s.jsonl", config.Normalize(dashboard), when.UTC().Format("2006-01"))
+}
+
+// AuditEntries returns the alerts of the dashboard the transition from the before to the after state opened and resolved, followed by the events sent.
+//
+// Records the error of sending the events, if any, on each notify entry.
+func AuditEntries(cfg *configpb.Configuration, dashboard string, sum *summarypb.DashboardSummary, before, after *summarypb.DashboardAlertState, sent []Event, sendErr error, now time.Time) []AuditEntry {
+	status := map[string]string{}
+	for _, tab := range sum.GetTabSummaries() {
+		status[tab.DashboardTabName] = tab.OverallStatus.String()
+	}
+	alerting := map[string]bool{}
+	for _, ts := range before.GetTabs() {
+		alerting[ts.DashboardTabName] = ts.FirstFired != nil
+	}
+	var out []AuditEntry
+	for _, ts := range after.GetTabs() {
+		entry := AuditEntry{
+			Time:      now,
+			Dashboard: dashboard,
+			Tab:       ts.DashboardTabName,
+			Status:    status[ts.DashboardTabName],
+		}
+		switch was, is := alerting[ts.DashboardTabName], ts.FirstFired != nil; {
+		case !was && is:
+			entry.Action = AuditOpen
+		case was && !is:
+			entry.Action = AuditResolve
+		default:
+			continue
+		}
+		out = append(out, entry)
+	}
+	for _, e := range sent {
+		entry := AuditEntry{
+			Time:       now,
+			Dashboard:  e.Dashboard,
+			Tab:        e.Tab,
+			Action:     AuditNotify,
+			Kind:       e.Kind.String(),
+			Status:     e.Summary.GetOverallStatus().String(),
+			Recipients: Recipients(cfg, e),
+		}
+		for _, f := range e.Failures {
+			entry.Tests = append(entry.Tests, f.DisplayName)
+		}
+		if sendErr != nil {
+			entry.Error = sendErr.Error()
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// Recipients returns every destination the config routes the event to, sorted.
+//
+// Includes destinations of every notifier, whether or not it is enabled.
+func Recipients(cfg *configpb.Configuration, e Event) []string {
+	seen := map[string]bool{}
+	add := func(kind, name string) {
+		if name = strings.TrimSpace(name); name != "" {
+			seen[kind+":"+name] = true
+		}
+	}
+	mail := e.Kind != TabRecovered && e.Kind != TabAcknowledged
+	dash := config.FindDashboard(e.Dashboard, cfg)
+	opts := dash.GetNotificationOptions()
+	for _, ch := range opts.GetSlackChannels() {
+		add("slack", ch)
+	}
+	for _, wh := range opts.GetWebhooks() {
+		add("webhook", wh)
+	}
+	for _, name := range opts.GetChannels() {
+		ch := config.FindNotificationChannel(name, cfg)
+		switch {
+		case ch.GetSlack() != nil, ch.GetWebhook() != nil:
+			add("channel", name)
+		case ch.GetEmail() != nil && mail:
+			for _, to := range strings.Split(ch.GetEmail().GetToAddresses(), ",") {
+				add("email", to)
+			}
+		}
+	}
+	if mail {
+		for _, to := range strings.Split(findTab(cfg, e).GetAlertOptions().GetAlertMailToAddresses(), ",") {
+			add("email", to)
+		}
+	}
+	for _, group := range cfg.GetDashboardGroups() {
+		for _, name := range group.DashboardNames {
+			if name != e.Dashboard {
+				continue
+			}
+			for _, svc := range group.GetNotificationOptions().GetPagerdutyServices() {
+				add("pagerduty", svc)
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for r := range seen {
+		out = append(out, r)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// AppendAudit adds the entries to the end of the JSON lines audit log at path, creating it when missing.
+//
+// Fails when something else changes the log in the meantime.
+func AppendAudit(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, entries []AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var buf []byte
+	cond := storage.Conditions{DoesNotExist: true}
+	attrs, err := client.Stat(ctx, path)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+	case err != nil:
+		return fmt.Errorf("stat: %w", err)
+	default:
+		cond = storage.Conditions{GenerationMatch: attrs.Generation}
+		r, err := client.If(&cond, nil).Open(ctx, path)
+		if err != nil {
+			return fmt.Errorf("open: %w", err)
+		}
+		buf, err = ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+	}
+	out := bytes.NewBuffer(buf)
+	enc := json.NewEncoder(out)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("encode: %w", err)
+		}
+	}
+	return client.If(nil, &cond).Upload(ctx, path, out.Bytes(), gcs.DefaultAcl, "no-cache")
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestAuditPath(t *testing.T) {
+	when := time.Date(2021, time.March, 31, 23, 0, 0, 0, time.UTC)
+	if got, want := AuditPath("My Dashboard-1", when), "audit-mydashboard1-2021-03.jsonl"; got != want {
+		t.Errorf("AuditPath() got %q, want %q", got, want)
+	}
+}
+
+func TestRecipients(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					SlackChannels: []string{"team"},
+					Webhooks:      []string{"hook"},
+					Channels:      []string{"chat", "mail"},
+				},
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name: "tab",
+						AlertOptions: &configpb.DashboardTabAlertOptions{
+							AlertMailToAddresses: "a@example.com, b@example.com",
+						},
+					},
+				},
+			},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{
+				Name:           "group",
+				DashboardNames: []string{"dash"},
+				NotificationOptions: &configpb.DashboardGroupNotificationOptions{
+					PagerdutyServices: []string{"oncall"},
+				},
+			},
+		},
+		NotificationChannels: []*configpb.NotificationChannel{
+			{Name: "chat", Slack: &configpb.SlackChannel{}},
+			{Name: "mail", Email: &configpb.EmailChannel{ToAddresses: "b@example.com,c@example.com"}},
+		},
+	}
+	cases := []struct {
+		name  string
+		event Event
+		want  []string
+	}{
+		{
+			name:  "failing",
+			event: Event{Kind: TabFailing, Dashboard: "dash", Tab: "tab"},
+			want: []string{
+				"channel:chat",
+				"email:a@example.com",
+				"email:b@example.com",
+				"email:c@example.com",
+				"pagerduty:oncall",
+				"slack:team",
+				"webhook:hook",
+			},
+		},
+		{
+			name:  "recoveries are not mailed",
+			event: Event{Kind: TabRecovered, Dashboard: "dash", Tab: "tab"},
+			want: []string{
+				"channel:chat",
+				"pagerduty:oncall",
+				"slack:team",
+				"webhook:hook",
+			},
+		},
+		{
+			name:  "unknown dashboard",
+			event: Event{Kind: TabFailing, Dashboard: "other", Tab: "tab"},
+			want:  []string{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Recipients(cfg, tc.event)); diff != "" {
+				t.Errorf("Recipients() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAuditEntries(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					SlackChannels: []string{"team"},
+				},
+			},
+		},
+	}
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{DashboardTabName: "new", OverallStatus: summarypb.DashboardTabSummary_FAIL},
+			{DashboardTabName: "fixed", OverallStatus: summarypb.DashboardTabSummary_PASS},
+			{DashboardTabName: "still", OverallStatus: summarypb.DashboardTabSummary_FAIL},
+		},
+	}
+	fired := &timestamp.Timestamp{Seconds: 1}
+	before := &summarypb.DashboardAlertState{
+		Tabs: []*summarypb.AlertState{
+			{DashboardTabName: "fixed", FirstFired: fired},
+			{DashboardTabName: "still", FirstFired: fired},
+		},
+	}
+	after := &summarypb.DashboardAlertState{
+		Tabs: []*summarypb.AlertState{
+			{DashboardTabName: "fixed"},
+			{DashboardTabName: "still", FirstFired: fired},
+			{DashboardTabName: "new", FirstFired: &timestamp.Timestamp{Seconds: 1000}},
+		},
+	}
+	sent := []Event{
+		{
+			Kind:      TabRecovered,
+			Dashboard: "dash",
+			Tab:       "fixed",
+			Summary:   sum.TabSummaries[1],
+		},
+		{
+			Kind:      TestFailing,
+			Dashboard: "dash",
+			Tab:       "new",
+			Summary:   sum.TabSummaries[0],
+			Failures: []*summarypb.FailingTestSummary{
+				{DisplayName: "foo"},
+				{DisplayName: "bar"},
+			},
+		},
+	}
+	cases := []struct {
+		name    string
+		sendErr error
+		want    []AuditEntry
+	}{
+		{
+			name: "open, resolve and notify",
+			want: []AuditEntry{
+				{
+					Time:      now,
+					Dashboard: "dash",
+					Tab:       "fixed",
+					Action:    AuditResolve,
+					Status:    "PASS",
+				},
+				{
+					Time:      now,
+					Dashboard: "dash",
+					Tab:       "new",
+					Action:    AuditOpen,
+					Status:    "FAIL",
+				},
+				{
+					Time:       now,
+					Dashboard:  "dash",
+					Tab:        "fixed",
+					Action:     AuditNotify,
+					Kind:       "recovered",
+					Status:     "PASS",
+					Recipients: []string{"slack:team"},
+				},
+				{
+					Time:       now,
+					Dashboard:  "dash",
+					Tab:        "new",
+					Action:     AuditNotify,
+					Kind:       "new failures",
+					Status:     "FAIL",
+					Tests:      []string{"foo", "bar"},
+					Recipients: []string{"slack:team"},
+				},
+			},
+		},
+		{
+			name:    "record send errors",
+			sendErr: errors.New("injected send error"),
+			want: []AuditEntry{
+				{
+					Time:      now,
+					Dashboard: "dash",
+					Tab:       "fixed",
+					Action:    AuditResolve,
+					Status:    "PASS",
+				},
+				{
+					Time:      now,
+					Dashboard: "dash",
+					Tab:       "new",
+					Action:    AuditOpen,
+					Status:    "FAIL",
+				},
+				{
+					Time:       now,
+					Dashboard:  "dash",
+					Tab:        "fixed",
+					Action:     AuditNotify,
+					Kind:       "recovered",
+					Status:     "PASS",
+					Recipients: []string{"slack:team"},
+					Error:      "injected send error",
+				},
+				{
+					Time:       now,
+					Dashboard:  "dash",
+					Tab:        "new",
+					Action:     AuditNotify,
+					Kind:       "new failures",
+					Status:     "FAIL",
+					Tests:      []string{"foo", "bar"},
+					Recipients: []string{"slack:team"},
+					Error:      "injected send error",
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := AuditEntries(cfg, "dash", sum, before, after, sent, tc.sendErr, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("AuditEntries() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAppendAudit(t *testing.T) {
+	root, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	ctx := context.Background()
+	client := gcs.NewLocalClient(root)
+	path, err := gcs.NewPath("gs://bucket/summary/audit-dash-2021-03.jsonl")
+	if err != nil {
+		t.Fatalf("NewPath: %v", err)
+	}
+	when := time.Unix(1000, 0).UTC()
+	for _, tab := range []string{"first", "second"} {
+		entries := []AuditEntry{{Time: when, Dashboard: "dash", Tab: tab, Action: AuditOpen}}
+		if err := AppendAudit(ctx, client, *path, entries); err != nil {
+			t.Fatalf("AppendAudit(%s) got unexpected error: %v", tab, err)
+		}
+	}
+	if err := AppendAudit(ctx, client, *path, nil); err != nil {
+		t.Fatalf("AppendAudit(nil) got unexpected error: %v", err)
+	}
+	r, err := client.Open(ctx, *path)
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() got unexpected error: %v", err)
+	}
+	want := `{"time":"1970-01-01T00:16:40Z","dashboard":"dash","tab":"first","action":"open"}
+{"time":"1970-01-01T00:16:40Z","dashboard":"dash","tab":"second","action":"open"}
+`
+	if diff := cmp.Diff(want, string(buf)); diff != "" {
+		t.Errorf("AppendAudit() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
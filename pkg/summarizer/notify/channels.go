@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Channels sends events to the named notification_channels each dashboard references.
+//
+// Posts one message per slack channel and mails each recipient once, describing all their events.
+// Sends each webhook a payload per event.
+type Channels struct {
+	// Email mails the recipients of email channels, which fail unless Send is set.
+	Email  Email
+	Client *http.Client
+}
+
+// Notify sends the events to the channels of their dashboards.
+func (c Channels) Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error {
+	var mErr error
+	messages := map[string][]string{}
+	alerts := map[string][]EmailAlert{}
+	for _, e := range events {
+		dash := config.FindDashboard(e.Dashboard, cfg)
+		for _, name := range dash.GetNotificationOptions().GetChannels() {
+			ch := config.FindNotificationChannel(name, cfg)
+			switch {
+			case ch == nil:
+				mErr = multierror.Append(mErr, fmt.Errorf("%s: unknown notification channel %q", e.Dashboard, name))
+			case ch.Slack != nil:
+				messages[name] = append(messages[name], e.Text())
+			case ch.Email != nil:
+				if e.Kind == TabRecovered || e.Kind == TabAcknowledged {
+					continue
+				}
+				opts := findTab(cfg, e).GetAlertOptions()
+				if opts == nil {
+					opts = &configpb.DashboardTabAlertOptions{}
+				}
+				for _, to := range strings.Split(ch.Email.ToAddresses, ",") {
+					if to = strings.TrimSpace(to); to != "" {
+						alerts[to] = append(alerts[to], EmailAlert{Event: e, Options: opts})
+					}
+				}
+			case ch.Webhook != nil:
+				if err := c.sendWebhook(ctx, name, ch.Webhook, e); err != nil {
+					mErr = multierror.Append(mErr, err)
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(messages))
+	for name := range messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		url := config.FindNotificationChannel(name, cfg).GetSlack().GetWebhookUrl()
+		if err := postJSON(ctx, c.Client, url, map[string]string{"text": strings.Join(messages[name], "\n\n")}); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("post to %s: %w", name, err))
+		}
+	}
+
+	if len(alerts) > 0 {
+		if c.Email.Send == nil {
+			return multierror.Append(mErr, errors.New("cannot mail email channels without an smtp server"))
+		}
+		if err := c.Email.send(ctx, alerts, c.Email.now()); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	return mErr
+}
+
+// sendWebhook renders the event with the webhook's template and posts it.
+func (c Channels) sendWebhook(ctx context.Context, name string, wh *configpb.WebhookChannel, e Event) error {
+	tmpl, err := ParseWebhookTemplate(name, wh.Template)
+	if err != nil {
+		return fmt.Errorf("parse %s template: %w", name, err)
+	}
+	payload, err := render(tmpl, e)
+	if err != nil {
+		return fmt.Errorf("render %s: %w", name, err)
+	}
+	if err := post(ctx, c.Client, wh.Url, payload); err != nil {
+		return fmt.Errorf("post to %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestChannelsNotify(t *testing.T) {
+	event := func(kind Kind, dash string) Event {
+		return Event{
+			Kind:      kind,
+			Dashboard: dash,
+			Tab:       "tab",
+			Previous:  summarypb.DashboardTabSummary_PASS,
+			Summary: &summarypb.DashboardTabSummary{
+				DashboardName:    dash,
+				DashboardTabName: "tab",
+				OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+			},
+		}
+	}
+	type post struct {
+		Path string
+		Body string
+	}
+
+	cases := []struct {
+		name     string
+		events   []Event
+		noSMTP   bool
+		posts    []post
+		mailedTo []string
+		err      bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:   "send to each channel",
+			events: []Event{event(TabFailing, "dash"), event(TabFailing, "dash")},
+			posts: []post{
+				{Path: "/hook", Body: `{"tab": "tab"}`},
+				{Path: "/hook", Body: `{"tab": "tab"}`},
+				{Path: "/slack", Body: event(TabFailing, "dash").Text() + "\n\n" + event(TabFailing, "dash").Text()},
+			},
+			mailedTo: []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:   "do not mail recoveries",
+			events: []Event{event(TabRecovered, "dash")},
+			posts: []post{
+				{Path: "/hook", Body: `{"tab": "tab"}`},
+				{Path: "/slack", Body: event(TabRecovered, "dash").Text()},
+			},
+		},
+		{
+			name:   "ignore dashboards without channels",
+			events: []Event{event(TabFailing, "quiet")},
+		},
+		{
+			name:   "reject unknown channels",
+			events: []Event{event(TabFailing, "unknown")},
+			err:    true,
+		},
+		{
+			name:   "reject email channels without smtp",
+			events: []Event{event(TabFailing, "dash")},
+			noSMTP: true,
+			posts: []post{
+				{Path: "/hook", Body: `{"tab": "tab"}`},
+				{Path: "/slack", Body: event(TabFailing, "dash").Text()},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var posts []post
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				buf, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("read: %v", err)
+				}
+				body := string(buf)
+				if r.URL.Path == "/slack" {
+					var msg map[string]string
+					if err := json.Unmarshal(buf, &msg); err != nil {
+						t.Errorf("unmarshal: %v", err)
+					}
+					body = msg["text"]
+				}
+				posts = append(posts, post{Path: r.URL.Path, Body: body})
+			}))
+			defer server.Close()
+
+			cfg := &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							Channels: []string{"hook", "mail", "slack"},
+						},
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab"}},
+					},
+					{
+						Name: "quiet",
+					},
+					{
+						Name: "unknown",
+						NotificationOptions: &configpb.DashboardNotificationOptions{
+							Channels: []string{"missing"},
+						},
+					},
+				},
+				NotificationChannels: []*configpb.NotificationChannel{
+					{
+						Name:  "slack",
+						Slack: &configpb.SlackChannel{WebhookUrl: server.URL + "/slack"},
+					},
+					{
+						Name:  "mail",
+						Email: &configpb.EmailChannel{ToAddresses: "a@example.com, b@example.com"},
+					},
+					{
+						Name: "hook",
+						Webhook: &configpb.WebhookChannel{
+							Url:      server.URL + "/hook",
+							Template: `{"tab": {{json .Tab}}}`,
+						},
+					},
+				},
+			}
+
+			var mailedTo []string
+			c := Channels{Client: server.Client()}
+			if !tc.noSMTP {
+				c.Email = Email{
+					From: "testgrid@example.com",
+					Send: func(from string, to []string, msg []byte) error {
+						mailedTo = append(mailedTo, to...)
+						return nil
+					},
+				}
+			}
+			err := c.Notify(context.Background(), cfg, tc.events)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Notify() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Notify() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.posts, posts); diff != "" {
+				t.Errorf("Notify() posted unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.mailedTo, mailedTo); diff != "" {
+				t.Errorf("Notify() mailed unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
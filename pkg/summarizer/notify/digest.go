@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// DigestEvents returns a FlakyTests event for each tab in the digest, in order of their flakiest test.
+//
+// Routes each event like any other event of the tab, so teams receive the part of the digest they own.
+func DigestEvents(digest *summarypb.FlakyTestDigest) []Event {
+	var events []Event
+	index := map[string]int{}
+	for _, test := range digest.GetTests() {
+		key := test.DashboardName + "\x00" + test.DashboardTabName
+		i, ok := index[key]
+		if !ok {
+			i = len(events)
+			index[key] = i
+			events = append(events, Event{
+				Kind:      FlakyTests,
+				Dashboard: test.DashboardName,
+				Tab:       test.DashboardTabName,
+				Summary: &summarypb.DashboardTabSummary{
+					DashboardName:    test.DashboardName,
+					DashboardTabName: test.DashboardTabName,
+				},
+			})
+		}
+		events[i].Flakes = append(events[i].Flakes, test)
+	}
+	return events
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestDigestEvents(t *testing.T) {
+	flake := func(dash, tab, name string) *summarypb.FlakyTest {
+		return &summarypb.FlakyTest{
+			DashboardName:    dash,
+			DashboardTabName: tab,
+			FlakeRate:        &summarypb.TestFlakeRate{DisplayName: name},
+		}
+	}
+	event := func(dash, tab string, flakes ...*summarypb.FlakyTest) Event {
+		return Event{
+			Kind:      FlakyTests,
+			Dashboard: dash,
+			Tab:       tab,
+			Summary: &summarypb.DashboardTabSummary{
+				DashboardName:    dash,
+				DashboardTabName: tab,
+			},
+			Flakes: flakes,
+		}
+	}
+
+	cases := []struct {
+		name   string
+		digest *summarypb.FlakyTestDigest
+		want   []Event
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "group tests by tab",
+			digest: &summarypb.FlakyTestDigest{
+				Tests: []*summarypb.FlakyTest{
+					flake("dash", "tab", "foo"),
+					flake("other", "tab", "bar"),
+					flake("dash", "tab", "baz"),
+				},
+			},
+			want: []Event{
+				event("dash", "tab", flake("dash", "tab", "foo"), flake("dash", "tab", "baz")),
+				event("other", "tab", flake("other", "tab", "bar")),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DigestEvents(tc.digest)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DigestEvents() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
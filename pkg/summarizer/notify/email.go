@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// A Sender delivers a formatted message to the recipients.
+type Sender func(from string, to []string, msg []byte) error
+
+// SMTPSender sends mail through the SMTP server at addr, authenticating if username is set.
+//
+// Works with any SMTP relay, including the Amazon SES SMTP interface.
+func SMTPSender(addr, username, password string) (Sender, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("bad address %q: %w", addr, err)
+	}
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return func(from string, to []string, msg []byte) error {
+		return smtp.SendMail(addr, auth, from, to, msg)
+	}, nil
+}
+
+// Email sends one alert mail per recipient listed in each tab's alert_mail_to_addresses.
+//
+// Honors wait_minutes_between_emails, recording sent mail in each tab's alerting data.
+type Email struct {
+	From string
+	Send Sender
+	// Template renders the body from an EmailData, defaulting to DefaultEmailTemplate.
+	Template *template.Template
+	Now      func() time.Time
+}
+
+// EmailAlert is a single alert in an email.
+type EmailAlert struct {
+	Event
+	Options *configpb.DashboardTabAlertOptions
+}
+
+// EmailData is passed to the template to render an email.
+type EmailData struct {
+	To     string
+	Alerts []EmailAlert
+}
+
+// DefaultEmailTemplate renders each alert's text, custom message and debug link.
+var DefaultEmailTemplate = template.Must(template.New("email").Parse(`{{range .Alerts}}{{.Text}}
+{{with .Options.AlertMailFailureMessage}}
+{{.}}
+{{end}}{{if .Options.DebugUrl}}
+{{or .Options.DebugMessage "Debug"}}: {{.Options.DebugUrl}}
+{{end}}
+{{end}}`))
+
+// Notify emails alerts to the configured recipients of each tab that starts failing.
+func (e Email) Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error {
+	when := e.now()
+	alerts := map[string][]EmailAlert{}
+	for _, ev := range events {
+		if ev.Kind == TabRecovered || ev.Kind == TabAcknowledged {
+			continue
+		}
+		opts := findTab(cfg, ev).GetAlertOptions()
+		if opts.GetAlertMailToAddresses() == "" {
+			continue
+		}
+		if wait := opts.GetWaitMinutesBetweenEmails(); wait > 0 {
+			last, err := ptypes.Timestamp(ev.Summary.GetAlertingData().GetLastEmailTime())
+			if err == nil && when.Sub(last) < time.Duration(wait)*time.Minute {
+				continue
+			}
+		}
+		for _, to := range strings.Split(opts.AlertMailToAddresses, ",") {
+			to = strings.TrimSpace(to)
+			if to == "" {
+				continue
+			}
+			alerts[to] = append(alerts[to], EmailAlert{Event: ev, Options: opts})
+		}
+	}
+	return e.send(ctx, alerts, when)
+}
+
+func (e Email) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+// send mails each recipient their alerts, recording when each tab's mail was sent.
+func (e Email) send(ctx context.Context, alerts map[string][]EmailAlert, when time.Time) error {
+	tmpl := e.Template
+	if tmpl == nil {
+		tmpl = DefaultEmailTemplate
+	}
+	recipients := make([]string, 0, len(alerts))
+	for to := range alerts {
+		recipients = append(recipients, to)
+	}
+	sort.Strings(recipients)
+	var mErr error
+	for _, to := range recipients {
+		if err := ctx.Err(); err != nil {
+			return multierror.Append(mErr, err)
+		}
+		data := EmailData{To: to, Alerts: alerts[to]}
+		msg, err := e.message(tmpl, data)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("render %s: %w", to, err))
+			continue
+		}
+		if err := e.Send(e.From, []string{to}, msg); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("send %s: %w", to, err))
+			continue
+		}
+		for _, a := range data.Alerts {
+			markSent(a.Summary, when)
+		}
+	}
+	return mErr
+}
+
+// message renders the headers and body of the email.
+func (e Email) message(tmpl *template.Template, data EmailData) ([]byte, error) {
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return nil, err
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", data.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject(data.Alerts))
+	fmt.Fprint(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprint(&msg, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprint(&msg, "\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// subject uses the custom subject of a single alert, or else summarizes the alerting tabs.
+func subject(alerts []EmailAlert) string {
+	if len(alerts) == 1 && alerts[0].Options.GetSubject() != "" {
+		return alerts[0].Options.Subject
+	}
+	var tabs []string
+	seen := map[string]bool{}
+	for _, a := range alerts {
+		name := a.Dashboard + "/" + a.Tab
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tabs = append(tabs, name)
+	}
+	return "TestGrid alert: " + strings.Join(tabs, ", ")
+}
+
+// markSent records the time an email was sent for the tab.
+func markSent(tab *summarypb.DashboardTabSummary, when time.Time) {
+	stamp, err := ptypes.TimestampProto(when)
+	if err != nil {
+		return
+	}
+	if tab.AlertingData == nil {
+		tab.AlertingData = &summarypb.AlertingData{}
+	}
+	tab.AlertingData.LastEmailTime = stamp
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestEmailNotify(t *testing.T) {
+	now := time.Unix(10000, 0)
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name: "tab",
+						AlertOptions: &configpb.DashboardTabAlertOptions{
+							AlertMailToAddresses:    "a@example.com, b@example.com",
+							Subject:                 "tab is sad",
+							AlertMailFailureMessage: "please fix",
+							DebugUrl:                "http://debug",
+						},
+					},
+					{
+						Name: "patient",
+						AlertOptions: &configpb.DashboardTabAlertOptions{
+							AlertMailToAddresses:     "a@example.com",
+							WaitMinutesBetweenEmails: 60,
+						},
+					},
+					{
+						Name: "silent",
+					},
+				},
+			},
+		},
+	}
+	event := func(kind Kind, tab string, lastEmail int64) Event {
+		e := Event{
+			Kind:      kind,
+			Dashboard: "dash",
+			Tab:       tab,
+			Previous:  summarypb.DashboardTabSummary_PASS,
+			Summary: &summarypb.DashboardTabSummary{
+				DashboardName:    "dash",
+				DashboardTabName: tab,
+				OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+			},
+		}
+		if lastEmail > 0 {
+			e.Summary.AlertingData = &summarypb.AlertingData{
+				LastEmailTime: &timestamp.Timestamp{Seconds: lastEmail},
+			}
+		}
+		return e
+	}
+
+	cases := []struct {
+		name    string
+		events  []Event
+		sendErr error
+		want    map[string][]string // recipient: subject and body lines
+		sent    bool
+		err     bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:   "email each recipient",
+			events: []Event{event(TabFailing, "tab", 0)},
+			want: map[string][]string{
+				"a@example.com": {"Subject: tab is sad", "dash/tab: failing (PASS -> FAIL)", "please fix", "Debug: http://debug"},
+				"b@example.com": {"Subject: tab is sad", "dash/tab: failing (PASS -> FAIL)", "please fix", "Debug: http://debug"},
+			},
+			sent: true,
+		},
+		{
+			name:   "group alerts per recipient",
+			events: []Event{event(TabFailing, "tab", 0), event(TabFailing, "patient", 0)},
+			want: map[string][]string{
+				"a@example.com": {"Subject: TestGrid alert: dash/tab, dash/patient", "dash/tab: failing", "dash/patient: failing"},
+				"b@example.com": {"Subject: tab is sad", "dash/tab: failing"},
+			},
+			sent: true,
+		},
+		{
+			name:   "skip recovered and unconfigured tabs",
+			events: []Event{event(TabRecovered, "tab", 0), event(TabFailing, "silent", 0)},
+		},
+		{
+			name:   "rate limit",
+			events: []Event{event(TabFailing, "patient", now.Add(-time.Minute).Unix())},
+		},
+		{
+			name:   "send after waiting",
+			events: []Event{event(TabFailing, "patient", now.Add(-2*time.Hour).Unix())},
+			want: map[string][]string{
+				"a@example.com": {"Subject: TestGrid alert: dash/patient", "dash/patient: failing"},
+			},
+			sent: true,
+		},
+		{
+			name:    "send errors",
+			events:  []Event{event(TabFailing, "patient", 0)},
+			sendErr: errors.New("bad"),
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := map[string]string{}
+			email := Email{
+				From: "testgrid@example.com",
+				Send: func(from string, to []string, msg []byte) error {
+					if tc.sendErr != nil {
+						return tc.sendErr
+					}
+					if from != "testgrid@example.com" {
+						t.Errorf("Send() got from %q", from)
+					}
+					got[strings.Join(to, ",")] = string(msg)
+					return nil
+				},
+				Now: func() time.Time { return now },
+			}
+			err := email.Notify(context.Background(), cfg, tc.events)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Notify() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Notify() failed to return an error")
+			}
+			var recipients []string
+			for to := range got {
+				recipients = append(recipients, to)
+			}
+			var wantRecipients []string
+			for to := range tc.want {
+				wantRecipients = append(wantRecipients, to)
+			}
+			if diff := cmp.Diff(wantRecipients, recipients, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Notify() got unexpected recipients (-want +got):\n%s", diff)
+			}
+			for to, lines := range tc.want {
+				for _, line := range lines {
+					if !strings.Contains(got[to], line) {
+						t.Errorf("Notify() email to %s missing %q:\n%s", to, line, got[to])
+					}
+				}
+			}
+			for _, e := range tc.events {
+				sent := e.Summary.GetAlertingData().GetLastEmailTime().GetSeconds() == now.Unix()
+				if sent != (tc.sent && e.Kind != TabRecovered && e.Tab != "silent") {
+					t.Errorf("Notify() for %s got sent=%t, want %t", e.Tab, sent, tc.sent)
+				}
+			}
+		})
+	}
+}
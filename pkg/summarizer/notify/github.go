@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// GitHubAPI is the public GitHub API endpoint.
+const GitHubAPI = "https://api.github.com"
+
+const defaultConsecutiveFailures = 3
+
+// A Tracker files issues about the failures in a summary, recording them in the alert state.
+type Tracker interface {
+	Track(ctx context.Context, cfg *configpb.Configuration, sum *summarypb.DashboardSummary, state *summarypb.DashboardAlertState) error
+}
+
+// GitHub files issues about tests which fail consecutively, closing them once the test passes.
+type GitHub struct {
+	Token string
+	// URL defaults to GitHubAPI.
+	URL string
+	// Template renders the issue body from an IssueData, defaulting to DefaultIssueTemplate.
+	Template *template.Template
+	Client   *http.Client
+}
+
+// IssueData is passed to the template to render an issue.
+type IssueData struct {
+	Dashboard string
+	Tab       string
+	Failure   *summarypb.FailingTestSummary
+}
+
+// DefaultIssueTemplate describes the failure and the builds where it started.
+var DefaultIssueTemplate = template.Must(template.New("issue").Parse(`{{.Failure.DisplayName}} failed {{.Failure.FailCount}} consecutive runs of {{.Dashboard}}/{{.Tab}}.
+{{with .Failure.FailureMessage}}
+> {{.}}
+{{end}}{{with .Failure.FailBuildId}}
+First failed in build {{.}}{{with $.Failure.PassBuildId}}, last passed in build {{.}}{{end}}.
+{{end}}`))
+
+type gitHubIssue struct {
+	Number  int64  `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+}
+
+// Track files, comments on and closes issues about the failing tests of each tab, recording them in state.
+//
+// Links each failure to its issue.
+func (gh GitHub) Track(ctx context.Context, cfg *configpb.Configuration, sum *summarypb.DashboardSummary, state *summarypb.DashboardAlertState) error {
+	var mErr error
+	for _, tab := range sum.GetTabSummaries() {
+		opts := config.FindDashboard(tab.DashboardName, cfg).GetNotificationOptions().GetGithubIssues()
+		if opts.GetRepo() == "" {
+			continue
+		}
+		if tab.OverallStatus == summarypb.DashboardTabSummary_NOT_SET {
+			// Failed to summarize the tab, so its failures are unknown.
+			continue
+		}
+		if err := gh.trackTab(ctx, opts, tab, tabState(state, tab.DashboardTabName)); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s/%s: %w", tab.DashboardName, tab.DashboardTabName, err))
+		}
+	}
+	return mErr
+}
+
+func (gh GitHub) trackTab(ctx context.Context, opts *configpb.GitHubIssueOptions, tab *summarypb.DashboardTabSummary, ts *summarypb.AlertState) error {
+	threshold := opts.ConsecutiveFailures
+	if threshold <= 0 {
+		threshold = defaultConsecutiveFailures
+	}
+	issues := map[string]*summarypb.TestIssue{}
+	for _, issue := range ts.Issues {
+		issues[issue.DisplayName] = issue
+	}
+
+	var mErr error
+	failing := map[string]bool{}
+	for _, f := range tab.FailingTestSummaries {
+		failing[f.DisplayName] = true
+		issue, ok := issues[f.DisplayName]
+		if !ok && f.FailCount >= threshold {
+			var err error
+			if issue, err = gh.file(ctx, opts, tab, f); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("file %s: %w", f.DisplayName, err))
+				continue
+			}
+			issues[f.DisplayName] = issue
+			ts.Issues = append(ts.Issues, issue)
+		}
+		if issue != nil {
+			f.IssueUrl = issue.Url
+		}
+	}
+
+	var open []*summarypb.TestIssue
+	for _, issue := range ts.Issues {
+		if failing[issue.DisplayName] {
+			open = append(open, issue)
+			continue
+		}
+		if err := gh.close(ctx, issue); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("close %s: %w", issue.Url, err))
+			open = append(open, issue)
+		}
+	}
+	ts.Issues = open
+	return mErr
+}
+
+// file comments on the open issue about the failure, or opens one if none exists.
+func (gh GitHub) file(ctx context.Context, opts *configpb.GitHubIssueOptions, tab *summarypb.DashboardTabSummary, f *summarypb.FailingTestSummary) (*summarypb.TestIssue, error) {
+	tmpl := gh.Template
+	if tmpl == nil {
+		tmpl = DefaultIssueTemplate
+	}
+	var body bytes.Buffer
+	data := IssueData{Dashboard: tab.DashboardName, Tab: tab.DashboardTabName, Failure: f}
+	if err := tmpl.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	title := fmt.Sprintf("%s is failing in %s/%s", f.DisplayName, tab.DashboardName, tab.DashboardTabName)
+
+	var found struct {
+		Items []gitHubIssue `json:"items"`
+	}
+	query := fmt.Sprintf("repo:%s is:issue is:open in:title %q", opts.Repo, title)
+	if err := gh.do(ctx, http.MethodGet, "/search/issues?q="+url.QueryEscape(query), nil, &found); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	var issue *gitHubIssue
+	for i, item := range found.Items {
+		if item.Title == title {
+			issue = &found.Items[i]
+			break
+		}
+	}
+	if issue != nil {
+		path := fmt.Sprintf("/repos/%s/issues/%d/comments", opts.Repo, issue.Number)
+		if err := gh.do(ctx, http.MethodPost, path, map[string]string{"body": body.String()}, nil); err != nil {
+			return nil, fmt.Errorf("comment: %w", err)
+		}
+	} else {
+		issue = &gitHubIssue{}
+		req := map[string]interface{}{
+			"title":  title,
+			"body":   body.String(),
+			"labels": opts.Labels,
+		}
+		if err := gh.do(ctx, http.MethodPost, "/repos/"+opts.Repo+"/issues", req, issue); err != nil {
+			return nil, fmt.Errorf("create: %w", err)
+		}
+	}
+	return &summarypb.TestIssue{
+		DisplayName: f.DisplayName,
+		Repo:        opts.Repo,
+		Number:      issue.Number,
+		Url:         issue.HTMLURL,
+	}, nil
+}
+
+// close comments that the test recovered and closes the issue.
+func (gh GitHub) close(ctx context.Context, issue *summarypb.TestIssue) error {
+	path := fmt.Sprintf("/repos/%s/issues/%d", issue.Repo, issue.Number)
+	comment := map[string]string{"body": issue.DisplayName + " is passing again."}
+	if err := gh.do(ctx, http.MethodPost, path+"/comments", comment, nil); err != nil {
+		return fmt.Errorf("comment: %w", err)
+	}
+	return gh.do(ctx, http.MethodPatch, path, map[string]string{"state": "closed"}, nil)
+}
+
+// do sends the JSON encoding of body to the API path, decoding any response into out.
+func (gh GitHub) do(ctx context.Context, method, path string, body, out interface{}) error {
+	base := gh.URL
+	if base == "" {
+		base = GitHubAPI
+	}
+	var r io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+		r = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(base, "/")+path, r)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+	if gh.Token != "" {
+		req.Header.Set("Authorization", "token "+gh.Token)
+	}
+	client := gh.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestGitHubTrack(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					GithubIssues: &configpb.GitHubIssueOptions{Repo: "o/r"},
+				},
+			},
+			{
+				Name: "patient",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					GithubIssues: &configpb.GitHubIssueOptions{Repo: "o/r", ConsecutiveFailures: 5},
+				},
+			},
+			{
+				Name: "broken",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					GithubIssues: &configpb.GitHubIssueOptions{Repo: "o/broken"},
+				},
+			},
+		},
+	}
+	tab := func(dash string, failures ...*summarypb.FailingTestSummary) *summarypb.DashboardTabSummary {
+		return &summarypb.DashboardTabSummary{
+			DashboardName:        dash,
+			DashboardTabName:     "tab",
+			OverallStatus:        summarypb.DashboardTabSummary_FAIL,
+			FailingTestSummaries: failures,
+		}
+	}
+	failure := func(name string, count int32) *summarypb.FailingTestSummary {
+		return &summarypb.FailingTestSummary{DisplayName: name, FailCount: count}
+	}
+	linked := func(name string, count int32, link string) *summarypb.FailingTestSummary {
+		f := failure(name, count)
+		f.IssueUrl = link
+		return f
+	}
+	issue := func(name string, number int64) *summarypb.TestIssue {
+		return &summarypb.TestIssue{
+			DisplayName: name,
+			Repo:        "o/r",
+			Number:      number,
+			Url:         fmt.Sprintf("https://github.com/o/r/issues/%d", number),
+		}
+	}
+
+	cases := []struct {
+		name      string
+		tabs      []*summarypb.DashboardTabSummary
+		issues    []*summarypb.TestIssue
+		want      []*summarypb.DashboardTabSummary
+		wantState []*summarypb.TestIssue
+		requests  []string
+		err       bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:      "file issues for sustained failures",
+			tabs:      []*summarypb.DashboardTabSummary{tab("dash", failure("foo", 3), failure("bar", 2))},
+			want:      []*summarypb.DashboardTabSummary{tab("dash", linked("foo", 3, issue("foo", 7).Url), failure("bar", 2))},
+			wantState: []*summarypb.TestIssue{issue("foo", 7)},
+			requests:  []string{"GET /search/issues", "POST /repos/o/r/issues"},
+		},
+		{
+			name: "respect configured threshold",
+			tabs: []*summarypb.DashboardTabSummary{tab("patient", failure("foo", 4))},
+			want: []*summarypb.DashboardTabSummary{tab("patient", failure("foo", 4))},
+		},
+		{
+			name:      "comment on open issues",
+			tabs:      []*summarypb.DashboardTabSummary{tab("dash", failure("existing", 3))},
+			want:      []*summarypb.DashboardTabSummary{tab("dash", linked("existing", 3, issue("existing", 3).Url))},
+			wantState: []*summarypb.TestIssue{issue("existing", 3)},
+			requests:  []string{"GET /search/issues", "POST /repos/o/r/issues/3/comments"},
+		},
+		{
+			name:      "link known issues",
+			tabs:      []*summarypb.DashboardTabSummary{tab("dash", failure("foo", 10))},
+			issues:    []*summarypb.TestIssue{issue("foo", 4)},
+			want:      []*summarypb.DashboardTabSummary{tab("dash", linked("foo", 10, issue("foo", 4).Url))},
+			wantState: []*summarypb.TestIssue{issue("foo", 4)},
+		},
+		{
+			name:     "close issues once tests pass",
+			tabs:     []*summarypb.DashboardTabSummary{tab("dash")},
+			issues:   []*summarypb.TestIssue{issue("foo", 4)},
+			want:     []*summarypb.DashboardTabSummary{tab("dash")},
+			requests: []string{"POST /repos/o/r/issues/4/comments", "PATCH /repos/o/r/issues/4"},
+		},
+		{
+			name: "ignore tabs which failed to summarize",
+			tabs: []*summarypb.DashboardTabSummary{
+				{DashboardName: "dash", DashboardTabName: "tab"},
+			},
+			issues:    []*summarypb.TestIssue{issue("foo", 4)},
+			want:      []*summarypb.DashboardTabSummary{{DashboardName: "dash", DashboardTabName: "tab"}},
+			wantState: []*summarypb.TestIssue{issue("foo", 4)},
+		},
+		{
+			name:     "error when filing fails",
+			tabs:     []*summarypb.DashboardTabSummary{tab("broken", failure("foo", 3))},
+			want:     []*summarypb.DashboardTabSummary{tab("broken", failure("foo", 3))},
+			requests: []string{"GET /search/issues", "POST /repos/o/broken/issues"},
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "token secret" {
+					t.Errorf("Authorization got %q", got)
+				}
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch {
+				case r.URL.Path == "/search/issues":
+					var items []gitHubIssue
+					if strings.Contains(r.URL.Query().Get("q"), "existing") {
+						items = append(items, gitHubIssue{
+							Number:  3,
+							HTMLURL: issue("existing", 3).Url,
+							Title:   "existing is failing in dash/tab",
+						})
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+				case r.URL.Path == "/repos/o/broken/issues":
+					http.Error(w, "boom", http.StatusInternalServerError)
+				case r.Method == http.MethodPost && r.URL.Path == "/repos/o/r/issues":
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(gitHubIssue{Number: 7, HTMLURL: issue("foo", 7).Url})
+				}
+			}))
+			defer server.Close()
+
+			sum := &summarypb.DashboardSummary{TabSummaries: tc.tabs}
+			state := &summarypb.DashboardAlertState{}
+			if tc.issues != nil {
+				state.Tabs = []*summarypb.AlertState{{DashboardTabName: "tab", Issues: tc.issues}}
+			}
+			gh := GitHub{Token: "secret", URL: server.URL, Client: server.Client()}
+			err := gh.Track(context.Background(), cfg, sum, state)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Track() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Track() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, sum.TabSummaries, protocmp.Transform()); diff != "" {
+				t.Errorf("Track() got unexpected summary diff (-want +got):\n%s", diff)
+			}
+			var gotState []*summarypb.TestIssue
+			if len(state.Tabs) > 0 {
+				gotState = state.Tabs[0].Issues
+			}
+			if diff := cmp.Diff(tc.wantState, gotState, protocmp.Transform()); diff != "" {
+				t.Errorf("Track() got unexpected state diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.requests, requests); diff != "" {
+				t.Errorf("Track() sent unexpected requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
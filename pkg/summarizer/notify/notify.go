@@ -0,0 +1,272 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify sends notifications when dashboard tab summaries change state.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"sigs.k8s.io/yaml"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// Kind describes what changed about a tab.
+type Kind int
+
+const (
+	// TabFailing means the tab started failing.
+	TabFailing Kind = iota
+	// TabBroken means the tab became broken.
+	TabBroken
+	// TabRecovered means a failing or broken tab is healthy again.
+	TabRecovered
+	// TestFailing means tests started failing enough to alert.
+	TestFailing
+	// TabAcknowledged means issues were linked to an alerting tab.
+	TabAcknowledged
+	// FlakyTests lists the tab's tests in a periodic flaky test digest.
+	FlakyTests
+)
+
+func (k Kind) String() string {
+	switch k {
+	case TabFailing:
+		return "failing"
+	case TabBroken:
+		return "broken"
+	case TabRecovered:
+		return "recovered"
+	case TestFailing:
+		return "new failures"
+	case TabAcknowledged:
+		return "acknowledged"
+	case FlakyTests:
+		return "flaky tests"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Event describes a notable change to a dashboard tab.
+type Event struct {
+	Kind      Kind
+	Dashboard string
+	Tab       string
+	// Previous is the status of the tab before the change.
+	Previous summarypb.DashboardTabSummary_TabStatus
+	// Summary is the current summary of the tab.
+	Summary *summarypb.DashboardTabSummary
+	// Failures lists the tests which started alerting, for TestFailing events.
+	Failures []*summarypb.FailingTestSummary
+	// Flakes lists the tab's flakiest tests, for FlakyTests events.
+	Flakes []*summarypb.FlakyTest
+	// Ownership describes who is responsible for the dashboard, if anyone.
+	Ownership *summarypb.DashboardOwnership
+}
+
+// Text returns a short human-readable description of the event.
+func (e Event) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s/%s: %s", e.Dashboard, e.Tab, e.Kind)
+	if e.Kind != TestFailing && e.Kind != TabAcknowledged && e.Kind != FlakyTests {
+		fmt.Fprintf(&sb, " (%s -> %s)", e.Previous, e.Summary.OverallStatus)
+	}
+	if e.Summary.Status != "" {
+		fmt.Fprintf(&sb, "\n%s", e.Summary.Status)
+	}
+	if e.Kind == TabFailing || e.Kind == TabBroken {
+		for _, c := range e.Summary.FailureClusters {
+			fmt.Fprintf(&sb, "\n%d tests failing with the same error: %s", len(c.Tests), c.FailureMessage)
+		}
+	}
+	if e.Summary.DocumentationUrl != "" {
+		fmt.Fprintf(&sb, "\nDocumentation: %s", e.Summary.DocumentationUrl)
+	}
+	if e.Kind == TabAcknowledged {
+		fmt.Fprintf(&sb, "\nLinked issues: %s", strings.Join(e.Summary.LinkedIssues, ", "))
+	}
+	for _, f := range e.Failures {
+		fmt.Fprintf(&sb, "\n* %s failed %d times", f.DisplayName, f.FailCount)
+		if f.FailureMessage != "" {
+			fmt.Fprintf(&sb, ": %s", f.FailureMessage)
+		}
+		if f.IssueUrl != "" {
+			fmt.Fprintf(&sb, " (%s)", f.IssueUrl)
+		} else if f.FileBugUrl != "" {
+			fmt.Fprintf(&sb, " (file a bug: %s)", f.FileBugUrl)
+		}
+	}
+	for _, f := range e.Flakes {
+		rate := f.FlakeRate
+		fmt.Fprintf(&sb, "\n* %s flaked in %.1f%% of %d runs", rate.GetDisplayName(), rate.GetFlakeRate(), rate.GetRuns())
+	}
+	return sb.String()
+}
+
+// A Notifier delivers events to their destinations, using the config for routing.
+type Notifier interface {
+	Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error
+}
+
+// Multi sends events to every notifier.
+type Multi []Notifier
+
+// Notify sends the events to each notifier, returning any errors.
+func (m Multi) Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error {
+	var mErr error
+	for _, n := range m {
+		if err := n.Notify(ctx, cfg, events); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	return mErr
+}
+
+// Events returns any events caused by the transition from the previous to the current summary.
+//
+// A nil previous summary treats every tab as new.
+func Events(previous, current *summarypb.DashboardSummary) []Event {
+	old := map[string]*summarypb.DashboardTabSummary{}
+	for _, tab := range previous.GetTabSummaries() {
+		old[tab.DashboardTabName] = tab
+	}
+	var events []Event
+	for _, tab := range current.GetTabSummaries() {
+		events = append(events, tabEvents(old[tab.DashboardTabName], tab)...)
+	}
+	for i := range events {
+		events[i].Ownership = current.GetOwnership()
+	}
+	return events
+}
+
+func tabEvents(previous, current *summarypb.DashboardTabSummary) []Event {
+	var events []Event
+	prevStatus := previous.GetOverallStatus()
+	event := Event{
+		Dashboard: current.DashboardName,
+		Tab:       current.DashboardTabName,
+		Previous:  prevStatus,
+		Summary:   current,
+	}
+	switch cur := current.OverallStatus; {
+	case cur == prevStatus:
+	case cur == summarypb.DashboardTabSummary_BROKEN:
+		event.Kind = TabBroken
+		events = append(events, event)
+	case cur == summarypb.DashboardTabSummary_FAIL && prevStatus != summarypb.DashboardTabSummary_BROKEN:
+		event.Kind = TabFailing
+		events = append(events, event)
+	case alerting(prevStatus) && healthy(cur):
+		event.Kind = TabRecovered
+		events = append(events, event)
+	}
+	if alerting(prevStatus) && alerting(current.OverallStatus) && len(previous.GetLinkedIssues()) == 0 && len(current.LinkedIssues) > 0 {
+		event.Kind = TabAcknowledged
+		events = append(events, event)
+	}
+
+	known := map[string]bool{}
+	for _, f := range previous.GetFailingTestSummaries() {
+		known[f.TestName+"\x00"+f.DisplayName] = true
+	}
+	var failures []*summarypb.FailingTestSummary
+	for _, f := range current.FailingTestSummaries {
+		if known[f.TestName+"\x00"+f.DisplayName] {
+			continue
+		}
+		failures = append(failures, f)
+	}
+	if len(failures) > 0 {
+		event.Kind = TestFailing
+		event.Failures = failures
+		events = append(events, event)
+	}
+	return events
+}
+
+// LoadSecrets reads a YAML or JSON file mapping names in the config to secrets,
+// such as slack channels to webhook URLs.
+func LoadSecrets(path string) (map[string]string, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var secrets map[string]string
+	if err := yaml.Unmarshal(buf, &secrets); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return secrets, nil
+}
+
+// postJSON sends the JSON encoding of body to url, expecting a 2xx response.
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return post(ctx, client, url, buf)
+}
+
+// post sends the JSON payload to url, expecting a 2xx response.
+func post(ctx context.Context, client *http.Client, url string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// findTab returns the config of the event's tab, or nil if it is missing.
+func findTab(cfg *configpb.Configuration, e Event) *configpb.DashboardTab {
+	for _, tab := range config.FindDashboard(e.Dashboard, cfg).GetDashboardTab() {
+		if tab.Name == e.Tab {
+			return tab
+		}
+	}
+	return nil
+}
+
+func alerting(status summarypb.DashboardTabSummary_TabStatus) bool {
+	return status == summarypb.DashboardTabSummary_FAIL || status == summarypb.DashboardTabSummary_BROKEN
+}
+
+func healthy(status summarypb.DashboardTabSummary_TabStatus) bool {
+	return status == summarypb.DashboardTabSummary_PASS || status == summarypb.DashboardTabSummary_FLAKY
+}
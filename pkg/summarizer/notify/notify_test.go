@@ -0,0 +1,291 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func tabSummary(name string, status summarypb.DashboardTabSummary_TabStatus, failures ...string) *summarypb.DashboardTabSummary {
+	tab := summarypb.DashboardTabSummary{
+		DashboardName:    "dash",
+		DashboardTabName: name,
+		OverallStatus:    status,
+	}
+	for _, f := range failures {
+		tab.FailingTestSummaries = append(tab.FailingTestSummaries, &summarypb.FailingTestSummary{
+			DisplayName: f,
+			TestName:    f,
+		})
+	}
+	return &tab
+}
+
+func dashSummary(tabs ...*summarypb.DashboardTabSummary) *summarypb.DashboardSummary {
+	return &summarypb.DashboardSummary{TabSummaries: tabs}
+}
+
+func TestEvents(t *testing.T) {
+	acked := tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")
+	acked.LinkedIssues = []string{"123"}
+	cases := []struct {
+		name     string
+		previous *summarypb.DashboardSummary
+		current  *summarypb.DashboardSummary
+		want     []Event
+	}{
+		{
+			name:    "basically works",
+			current: dashSummary(),
+		},
+		{
+			name:     "unchanged",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")),
+		},
+		{
+			name:     "start failing",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_PASS)),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")),
+			want: []Event{
+				{
+					Kind:      TabFailing,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Previous:  summarypb.DashboardTabSummary_PASS,
+					Summary:   tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo"),
+				},
+				{
+					Kind:      TestFailing,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Previous:  summarypb.DashboardTabSummary_PASS,
+					Summary:   tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo"),
+					Failures:  tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo").FailingTestSummaries,
+				},
+			},
+		},
+		{
+			name:     "new tab is broken",
+			previous: dashSummary(),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_BROKEN)),
+			want: []Event{
+				{
+					Kind:      TabBroken,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Summary:   tabSummary("tab", summarypb.DashboardTabSummary_BROKEN),
+				},
+			},
+		},
+		{
+			name:     "broken to failing is not news",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_BROKEN)),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL)),
+		},
+		{
+			name:     "recover",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FLAKY)),
+			want: []Event{
+				{
+					Kind:      TabRecovered,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Previous:  summarypb.DashboardTabSummary_FAIL,
+					Summary:   tabSummary("tab", summarypb.DashboardTabSummary_FLAKY),
+				},
+			},
+		},
+		{
+			name:     "stale is not recovered",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL)),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_STALE)),
+		},
+		{
+			name:     "only new failures",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")),
+			current:  dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo", "bar")),
+			want: []Event{
+				{
+					Kind:      TestFailing,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Previous:  summarypb.DashboardTabSummary_FAIL,
+					Summary:   tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo", "bar"),
+					Failures:  tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "bar").FailingTestSummaries,
+				},
+			},
+		},
+		{
+			name:     "acknowledge when issues are linked",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL, "foo")),
+			current:  dashSummary(acked),
+			want: []Event{
+				{
+					Kind:      TabAcknowledged,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Previous:  summarypb.DashboardTabSummary_FAIL,
+					Summary:   acked,
+				},
+			},
+		},
+		{
+			name:     "already acknowledged",
+			previous: dashSummary(acked),
+			current:  dashSummary(acked),
+		},
+		{
+			name:     "include ownership",
+			previous: dashSummary(tabSummary("tab", summarypb.DashboardTabSummary_FAIL)),
+			current: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{tabSummary("tab", summarypb.DashboardTabSummary_PASS)},
+				Ownership:    &summarypb.DashboardOwnership{Team: "team"},
+			},
+			want: []Event{
+				{
+					Kind:      TabRecovered,
+					Dashboard: "dash",
+					Tab:       "tab",
+					Previous:  summarypb.DashboardTabSummary_FAIL,
+					Summary:   tabSummary("tab", summarypb.DashboardTabSummary_PASS),
+					Ownership: &summarypb.DashboardOwnership{Team: "team"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Events(tc.previous, tc.current)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Events() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventText(t *testing.T) {
+	cases := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{
+			name: "tab failing",
+			event: Event{
+				Kind:      TabFailing,
+				Dashboard: "dash",
+				Tab:       "tab",
+				Previous:  summarypb.DashboardTabSummary_PASS,
+				Summary: &summarypb.DashboardTabSummary{
+					OverallStatus: summarypb.DashboardTabSummary_FAIL,
+					Status:        "1 of 2 recent columns passed",
+				},
+			},
+			want: "dash/tab: failing (PASS -> FAIL)\n1 of 2 recent columns passed",
+		},
+		{
+			name: "tab broken with failure clusters",
+			event: Event{
+				Kind:      TabBroken,
+				Dashboard: "dash",
+				Tab:       "tab",
+				Previous:  summarypb.DashboardTabSummary_FAIL,
+				Summary: &summarypb.DashboardTabSummary{
+					OverallStatus: summarypb.DashboardTabSummary_BROKEN,
+					FailureClusters: []*summarypb.FailureCluster{
+						{FailureMessage: "connection refused", Tests: []string{"foo", "bar"}},
+					},
+				},
+			},
+			want: "dash/tab: broken (FAIL -> BROKEN)\n2 tests failing with the same error: connection refused",
+		},
+		{
+			name: "link documentation",
+			event: Event{
+				Kind:      TabFailing,
+				Dashboard: "dash",
+				Tab:       "tab",
+				Previous:  summarypb.DashboardTabSummary_PASS,
+				Summary: &summarypb.DashboardTabSummary{
+					OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+					Status:           "1 of 2 recent columns passed",
+					DocumentationUrl: "https://docs/tab",
+				},
+			},
+			want: "dash/tab: failing (PASS -> FAIL)\n1 of 2 recent columns passed\nDocumentation: https://docs/tab",
+		},
+		{
+			name: "new failures",
+			event: Event{
+				Kind:      TestFailing,
+				Dashboard: "dash",
+				Tab:       "tab",
+				Summary:   &summarypb.DashboardTabSummary{},
+				Failures: []*summarypb.FailingTestSummary{
+					{
+						DisplayName:    "foo",
+						FailCount:      3,
+						FailureMessage: "boom",
+					},
+					{
+						DisplayName: "bar",
+						FailCount:   2,
+						IssueUrl:    "https://github.com/o/r/issues/1",
+						FileBugUrl:  "https://bugs/new",
+					},
+					{
+						DisplayName: "baz",
+						FailCount:   2,
+						FileBugUrl:  "https://bugs/new",
+					},
+				},
+			},
+			want: "dash/tab: new failures\n* foo failed 3 times: boom\n* bar failed 2 times (https://github.com/o/r/issues/1)\n* baz failed 2 times (file a bug: https://bugs/new)",
+		},
+		{
+			name: "flaky tests",
+			event: Event{
+				Kind:      FlakyTests,
+				Dashboard: "dash",
+				Tab:       "tab",
+				Summary:   &summarypb.DashboardTabSummary{},
+				Flakes: []*summarypb.FlakyTest{
+					{
+						FlakeRate: &summarypb.TestFlakeRate{DisplayName: "foo", Runs: 8, FlakeRate: 25},
+					},
+				},
+			},
+			want: "dash/tab: flaky tests\n* foo flaked in 25.0% of 8 runs",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.event.Text(); got != tc.want {
+				t.Errorf("Text() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
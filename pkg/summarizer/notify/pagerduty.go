@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty manages an incident per tab on the services of each dashboard group containing the tab.
+//
+// Triggers incidents when tabs fail or break, acknowledges them when issues are linked
+// and resolves them when the tab recovers.
+type PagerDuty struct {
+	// RoutingKeys maps a service name to its integration routing key.
+	RoutingKeys map[string]string
+	// URL defaults to PagerDutyEventsURL.
+	URL    string
+	Client *http.Client
+}
+
+type pagerDutyPayload struct {
+	Summary  string            `json:"summary"`
+	Source   string            `json:"source"`
+	Severity string            `json:"severity"`
+	Details  map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+// Notify sends an incident update to each service routed to the event's dashboard.
+func (pd PagerDuty) Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error {
+	url := pd.URL
+	if url == "" {
+		url = PagerDutyEventsURL
+	}
+	services := map[string][]string{}
+	for _, group := range cfg.GetDashboardGroups() {
+		for _, dash := range group.DashboardNames {
+			services[dash] = append(services[dash], group.GetNotificationOptions().GetPagerdutyServices()...)
+		}
+	}
+
+	var mErr error
+	for _, e := range events {
+		body := pagerDutyEvent{DedupKey: e.Dashboard + "/" + e.Tab}
+		switch e.Kind {
+		case TabFailing, TabBroken:
+			severity := "error"
+			if e.Kind == TabBroken {
+				severity = "critical"
+			}
+			body.EventAction = "trigger"
+			body.Payload = &pagerDutyPayload{
+				Summary:  e.Text(),
+				Source:   "testgrid",
+				Severity: severity,
+				Details: map[string]string{
+					"dashboard": e.Dashboard,
+					"tab":       e.Tab,
+					"status":    e.Summary.OverallStatus.String(),
+				},
+			}
+		case TabAcknowledged:
+			body.EventAction = "acknowledge"
+		case TabRecovered:
+			body.EventAction = "resolve"
+		default:
+			continue
+		}
+		for _, service := range services[e.Dashboard] {
+			key, ok := pd.RoutingKeys[service]
+			if !ok {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s: no routing key for pagerduty service %q", e.Dashboard, service))
+				continue
+			}
+			body.RoutingKey = key
+			if err := postJSON(ctx, pd.Client, url, body); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s %s to %s: %w", body.EventAction, body.DedupKey, service, err))
+			}
+		}
+	}
+	return mErr
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestPagerDutyNotify(t *testing.T) {
+	cfg := &configpb.Configuration{
+		DashboardGroups: []*configpb.DashboardGroup{
+			{
+				Name:           "paged",
+				DashboardNames: []string{"dash"},
+				NotificationOptions: &configpb.DashboardGroupNotificationOptions{
+					PagerdutyServices: []string{"oncall"},
+				},
+			},
+			{
+				Name:           "misconfigured",
+				DashboardNames: []string{"other"},
+				NotificationOptions: &configpb.DashboardGroupNotificationOptions{
+					PagerdutyServices: []string{"unknown"},
+				},
+			},
+			{
+				Name:           "quiet",
+				DashboardNames: []string{"dash"},
+			},
+		},
+	}
+	event := func(kind Kind, dash string, status summarypb.DashboardTabSummary_TabStatus) Event {
+		return Event{
+			Kind:      kind,
+			Dashboard: dash,
+			Tab:       "tab",
+			Summary:   &summarypb.DashboardTabSummary{OverallStatus: status},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		events []Event
+		status int
+		want   []pagerDutyEvent
+		err    bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "incident lifecycle",
+			events: []Event{
+				event(TabBroken, "dash", summarypb.DashboardTabSummary_BROKEN),
+				event(TestFailing, "dash", summarypb.DashboardTabSummary_BROKEN),
+				event(TabAcknowledged, "dash", summarypb.DashboardTabSummary_BROKEN),
+				event(TabRecovered, "dash", summarypb.DashboardTabSummary_PASS),
+			},
+			want: []pagerDutyEvent{
+				{
+					RoutingKey:  "key",
+					EventAction: "trigger",
+					DedupKey:    "dash/tab",
+					Payload: &pagerDutyPayload{
+						Summary:  event(TabBroken, "dash", summarypb.DashboardTabSummary_BROKEN).Text(),
+						Source:   "testgrid",
+						Severity: "critical",
+						Details: map[string]string{
+							"dashboard": "dash",
+							"tab":       "tab",
+							"status":    "BROKEN",
+						},
+					},
+				},
+				{
+					RoutingKey:  "key",
+					EventAction: "acknowledge",
+					DedupKey:    "dash/tab",
+				},
+				{
+					RoutingKey:  "key",
+					EventAction: "resolve",
+					DedupKey:    "dash/tab",
+				},
+			},
+		},
+		{
+			name:   "ignore unrouted dashboards",
+			events: []Event{event(TabFailing, "unrouted", summarypb.DashboardTabSummary_FAIL)},
+		},
+		{
+			name:   "error on unknown service",
+			events: []Event{event(TabFailing, "other", summarypb.DashboardTabSummary_FAIL)},
+			err:    true,
+		},
+		{
+			name:   "error on bad response",
+			events: []Event{event(TabRecovered, "dash", summarypb.DashboardTabSummary_PASS)},
+			status: http.StatusBadRequest,
+			want: []pagerDutyEvent{
+				{
+					RoutingKey:  "key",
+					EventAction: "resolve",
+					DedupKey:    "dash/tab",
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []pagerDutyEvent
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var e pagerDutyEvent
+				if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+					t.Errorf("decode: %v", err)
+				}
+				got = append(got, e)
+				status := tc.status
+				if status == 0 {
+					status = http.StatusAccepted
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			pd := PagerDuty{
+				RoutingKeys: map[string]string{"oncall": "key"},
+				URL:         server.URL,
+				Client:      server.Client(),
+			}
+			err := pd.Notify(context.Background(), cfg, tc.events)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Notify() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Notify() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Notify() sent unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// Slack posts events to the incoming webhooks of the dashboard's slack channels.
+type Slack struct {
+	// Webhooks maps a channel name to its incoming webhook URL.
+	Webhooks map[string]string
+	Client   *http.Client
+}
+
+// Notify posts one message per channel, describing all the events routed to it.
+func (s Slack) Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error {
+	messages := map[string][]string{}
+	var mErr error
+	for _, e := range events {
+		dash := config.FindDashboard(e.Dashboard, cfg)
+		for _, channel := range dash.GetNotificationOptions().GetSlackChannels() {
+			if _, ok := s.Webhooks[channel]; !ok {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s: no webhook for slack channel %q", e.Dashboard, channel))
+				continue
+			}
+			messages[channel] = append(messages[channel], e.Text())
+		}
+	}
+
+	channels := make([]string, 0, len(messages))
+	for channel := range messages {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	for _, channel := range channels {
+		if err := s.post(ctx, s.Webhooks[channel], strings.Join(messages[channel], "\n\n")); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("post to %s: %w", channel, err))
+		}
+	}
+	return mErr
+}
+
+func (s Slack) post(ctx context.Context, webhook, text string) error {
+	return postJSON(ctx, s.Client, webhook, map[string]string{"text": text})
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestSlackNotify(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					SlackChannels: []string{"team", "oncall"},
+				},
+			},
+			{
+				Name: "missing",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					SlackChannels: []string{"unknown"},
+				},
+			},
+			{
+				Name: "quiet",
+			},
+		},
+	}
+	event := func(dash string) Event {
+		return Event{
+			Kind:      TabBroken,
+			Dashboard: dash,
+			Tab:       "tab",
+			Summary:   &summarypb.DashboardTabSummary{OverallStatus: summarypb.DashboardTabSummary_BROKEN},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		events []Event
+		status int
+		want   map[string][]string
+		err    bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:   "post to each channel",
+			events: []Event{event("dash"), event("quiet")},
+			want: map[string][]string{
+				"/team":   {event("dash").Text()},
+				"/oncall": {event("dash").Text()},
+			},
+		},
+		{
+			name:   "combine events",
+			events: []Event{event("dash"), event("dash")},
+			want: map[string][]string{
+				"/team":   {event("dash").Text() + "\n\n" + event("dash").Text()},
+				"/oncall": {event("dash").Text() + "\n\n" + event("dash").Text()},
+			},
+		},
+		{
+			name:   "error on unknown channel",
+			events: []Event{event("missing")},
+			err:    true,
+		},
+		{
+			name:   "error on bad response",
+			events: []Event{event("dash")},
+			status: http.StatusInternalServerError,
+			want: map[string][]string{
+				"/team":   {event("dash").Text()},
+				"/oncall": {event("dash").Text()},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var got map[string][]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var msg struct {
+					Text string `json:"text"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+					t.Errorf("decode: %v", err)
+				}
+				lock.Lock()
+				if got == nil {
+					got = map[string][]string{}
+				}
+				got[r.URL.Path] = append(got[r.URL.Path], msg.Text)
+				lock.Unlock()
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+			}))
+			defer server.Close()
+
+			slack := Slack{
+				Webhooks: map[string]string{
+					"team":   server.URL + "/team",
+					"oncall": server.URL + "/oncall",
+				},
+				Client: server.Client(),
+			}
+			err := slack.Notify(context.Background(), cfg, tc.events)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Notify() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Notify() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Notify() posted unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// StatePath returns the name of the dashboard's alert state object, relative to the summaries.
+func StatePath(dashboard string) string {
+	return "alerts-" + config.Normalize(dashboard)
+}
+
+// ReadState returns the alert state at path along with its generation.
+//
+// Returns an empty state and zero generation when the object does not exist.
+func ReadState(ctx context.Context, client gcs.ConditionalClient, path gcs.Path) (*summarypb.DashboardAlertState, int64, error) {
+	attrs, err := client.Stat(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return &summarypb.DashboardAlertState{}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("stat: %w", err)
+	}
+	r, err := client.If(&storage.Conditions{GenerationMatch: attrs.Generation}, nil).Open(ctx, path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read: %w", err)
+	}
+	var state summarypb.DashboardAlertState
+	if err := proto.Unmarshal(buf, &state); err != nil {
+		return nil, 0, fmt.Errorf("parse: %w", err)
+	}
+	return &state, attrs.Generation, nil
+}
+
+// WriteState uploads the alert state, provided the object is still at generation.
+//
+// A zero generation requires the object to not exist.
+func WriteState(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, state *summarypb.DashboardAlertState, generation int64) error {
+	buf, err := proto.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	cond := storage.Conditions{GenerationMatch: generation}
+	if generation == 0 {
+		cond = storage.Conditions{DoesNotExist: true}
+	}
+	return client.If(nil, &cond).Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
+}
+
+// tabState returns the state of the named tab, adding it when missing.
+func tabState(state *summarypb.DashboardAlertState, tab string) *summarypb.AlertState {
+	for _, ts := range state.Tabs {
+		if ts.DashboardTabName == tab {
+			return ts
+		}
+	}
+	ts := &summarypb.AlertState{DashboardTabName: tab}
+	state.Tabs = append(state.Tabs, ts)
+	return ts
+}
+
+// Snooze silences notifications about the tab until the specified time.
+//
+// A zero time removes any snooze.
+func Snooze(state *summarypb.DashboardAlertState, tab string, until time.Time) error {
+	ts := tabState(state, tab)
+	if until.IsZero() {
+		ts.SnoozedUntil = nil
+		return nil
+	}
+	stamp, err := ptypes.TimestampProto(until)
+	if err != nil {
+		return err
+	}
+	ts.SnoozedUntil = stamp
+	return nil
+}
+
+// Acknowledge silences alerts about the alerting tab until it recovers.
+//
+// An empty user removes any acknowledgement.
+func Acknowledge(state *summarypb.DashboardAlertState, tab, user string) error {
+	ts := tabState(state, tab)
+	if user != "" && ts.FirstFired == nil {
+		return fmt.Errorf("%s is not alerting", tab)
+	}
+	ts.AcknowledgedBy = user
+	return nil
+}
+
+// Filter drops events users should not hear about again, recording sent alerts in state.
+//
+// Drops every event about a tab snoozed past now.
+// Drops failing events for tabs that are already alerting, as well as any
+// failure alerts after a user acknowledges the alert.
+// A recovery ends the alert along with its acknowledgement.
+func Filter(state *summarypb.DashboardAlertState, events []Event, now time.Time) []Event {
+	stamp, _ := ptypes.TimestampProto(now)
+	var out []Event
+	for _, e := range events {
+		ts := tabState(state, e.Tab)
+		if snoozed(ts.SnoozedUntil, now) {
+			if e.Kind == TabRecovered {
+				*ts = summarypb.AlertState{DashboardTabName: ts.DashboardTabName, SnoozedUntil: ts.SnoozedUntil, Issues: ts.Issues}
+			}
+			continue
+		}
+		ts.SnoozedUntil = nil
+		switch e.Kind {
+		case TabRecovered:
+			*ts = summarypb.AlertState{DashboardTabName: ts.DashboardTabName, Issues: ts.Issues}
+			out = append(out, e)
+			continue
+		case TabFailing:
+			if ts.FirstFired != nil {
+				continue
+			}
+		case TabBroken, TestFailing:
+			if ts.AcknowledgedBy != "" {
+				continue
+			}
+		}
+		if ts.FirstFired == nil && e.Kind != TabAcknowledged {
+			ts.FirstFired = stamp
+		}
+		ts.LastFired = stamp
+		out = append(out, e)
+	}
+	return out
+}
+
+func snoozed(until *timestamp.Timestamp, now time.Time) bool {
+	if until == nil {
+		return false
+	}
+	when, err := ptypes.Timestamp(until)
+	return err == nil && when.After(now)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestStatePath(t *testing.T) {
+	if got, want := StatePath("My Dashboard-1"), "alerts-mydashboard1"; got != want {
+		t.Errorf("StatePath() got %q, want %q", got, want)
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	state := &summarypb.DashboardAlertState{}
+	if err := Snooze(state, "tab", time.Unix(100, 0)); err != nil {
+		t.Fatalf("Snooze() got unexpected error: %v", err)
+	}
+	want := &summarypb.DashboardAlertState{
+		Tabs: []*summarypb.AlertState{
+			{DashboardTabName: "tab", SnoozedUntil: &timestamp.Timestamp{Seconds: 100}},
+		},
+	}
+	if diff := cmp.Diff(want, state, protocmp.Transform()); diff != "" {
+		t.Errorf("Snooze() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if err := Snooze(state, "tab", time.Time{}); err != nil {
+		t.Fatalf("Snooze() got unexpected error: %v", err)
+	}
+	want.Tabs[0].SnoozedUntil = nil
+	if diff := cmp.Diff(want, state, protocmp.Transform()); diff != "" {
+		t.Errorf("Snooze() unsnooze got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestAcknowledge(t *testing.T) {
+	cases := []struct {
+		name  string
+		state *summarypb.DashboardAlertState
+		user  string
+		want  *summarypb.DashboardAlertState
+		err   bool
+	}{
+		{
+			name: "acknowledge alerting tab",
+			state: &summarypb.DashboardAlertState{
+				Tabs: []*summarypb.AlertState{
+					{DashboardTabName: "tab", FirstFired: &timestamp.Timestamp{Seconds: 1}},
+				},
+			},
+			user: "fejta",
+			want: &summarypb.DashboardAlertState{
+				Tabs: []*summarypb.AlertState{
+					{DashboardTabName: "tab", FirstFired: &timestamp.Timestamp{Seconds: 1}, AcknowledgedBy: "fejta"},
+				},
+			},
+		},
+		{
+			name: "remove acknowledgement",
+			state: &summarypb.DashboardAlertState{
+				Tabs: []*summarypb.AlertState{
+					{DashboardTabName: "tab", FirstFired: &timestamp.Timestamp{Seconds: 1}, AcknowledgedBy: "fejta"},
+				},
+			},
+			want: &summarypb.DashboardAlertState{
+				Tabs: []*summarypb.AlertState{
+					{DashboardTabName: "tab", FirstFired: &timestamp.Timestamp{Seconds: 1}},
+				},
+			},
+		},
+		{
+			name:  "reject tabs which are not alerting",
+			state: &summarypb.DashboardAlertState{},
+			user:  "fejta",
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Acknowledge(tc.state, "tab", tc.user)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Acknowledge() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("Acknowledge() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, tc.state, protocmp.Transform()); diff != "" {
+				t.Errorf("Acknowledge() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	event := func(kind Kind) Event {
+		return Event{Kind: kind, Dashboard: "dash", Tab: "tab"}
+	}
+	fired := func(first int64) *summarypb.AlertState {
+		return &summarypb.AlertState{
+			DashboardTabName: "tab",
+			FirstFired:       &timestamp.Timestamp{Seconds: first},
+			LastFired:        &timestamp.Timestamp{Seconds: 1000},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		state     []*summarypb.AlertState
+		events    []Event
+		want      []Event
+		wantState []*summarypb.AlertState
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:      "record new alerts",
+			events:    []Event{event(TabFailing), event(TestFailing)},
+			want:      []Event{event(TabFailing), event(TestFailing)},
+			wantState: []*summarypb.AlertState{fired(1000)},
+		},
+		{
+			name: "drop repeated alerts",
+			state: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					FirstFired:       &timestamp.Timestamp{Seconds: 10},
+					LastFired:        &timestamp.Timestamp{Seconds: 20},
+				},
+			},
+			events: []Event{event(TabFailing)},
+			wantState: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					FirstFired:       &timestamp.Timestamp{Seconds: 10},
+					LastFired:        &timestamp.Timestamp{Seconds: 20},
+				},
+			},
+		},
+		{
+			name:      "escalate to broken",
+			state:     []*summarypb.AlertState{fired(10)},
+			events:    []Event{event(TabBroken)},
+			want:      []Event{event(TabBroken)},
+			wantState: []*summarypb.AlertState{fired(10)},
+		},
+		{
+			name: "silence acknowledged alerts",
+			state: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					FirstFired:       &timestamp.Timestamp{Seconds: 10},
+					AcknowledgedBy:   "fejta",
+				},
+			},
+			events: []Event{event(TabBroken), event(TestFailing)},
+			wantState: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					FirstFired:       &timestamp.Timestamp{Seconds: 10},
+					AcknowledgedBy:   "fejta",
+				},
+			},
+		},
+		{
+			name: "recovery clears the alert",
+			state: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					FirstFired:       &timestamp.Timestamp{Seconds: 10},
+					AcknowledgedBy:   "fejta",
+				},
+			},
+			events:    []Event{event(TabRecovered)},
+			want:      []Event{event(TabRecovered)},
+			wantState: []*summarypb.AlertState{{DashboardTabName: "tab"}},
+		},
+		{
+			name: "drop events while snoozed",
+			state: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					FirstFired:       &timestamp.Timestamp{Seconds: 10},
+					SnoozedUntil:     &timestamp.Timestamp{Seconds: 2000},
+				},
+			},
+			events: []Event{event(TabBroken), event(TabRecovered)},
+			wantState: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					SnoozedUntil:     &timestamp.Timestamp{Seconds: 2000},
+				},
+			},
+		},
+		{
+			name: "clear expired snoozes",
+			state: []*summarypb.AlertState{
+				{
+					DashboardTabName: "tab",
+					SnoozedUntil:     &timestamp.Timestamp{Seconds: 500},
+				},
+			},
+			events:    []Event{event(TabFailing)},
+			want:      []Event{event(TabFailing)},
+			wantState: []*summarypb.AlertState{fired(1000)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := &summarypb.DashboardAlertState{Tabs: tc.state}
+			got := Filter(state, tc.events, now)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Filter() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantState, state.Tabs, protocmp.Transform()); diff != "" {
+				t.Errorf("Filter() got unexpected state diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
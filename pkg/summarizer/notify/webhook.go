@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"sigs.k8s.io/yaml"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// A WebhookTarget receives a payload rendered from an Event for each alert.
+type WebhookTarget struct {
+	URL      string
+	Template *template.Template
+}
+
+// Webhook sends a templated JSON payload per event to each named webhook of the dashboard.
+type Webhook struct {
+	Targets map[string]WebhookTarget
+	Client  *http.Client
+}
+
+// templateFuncs are available to webhook templates.
+var templateFuncs = template.FuncMap{
+	// json encodes the value, for example to quote and escape strings.
+	"json": func(v interface{}) (string, error) {
+		buf, err := json.Marshal(v)
+		return string(buf), err
+	},
+}
+
+// ParseWebhookTemplate parses a Go template rendering an Event into a JSON payload.
+func ParseWebhookTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// LoadWebhooks reads a YAML or JSON file mapping webhook names to their url and payload template.
+//
+// For example:
+//
+//	teams:
+//	  url: https://example.com/webhook
+//	  template: '{"text": {{json .Text}}}'
+func LoadWebhooks(path string) (map[string]WebhookTarget, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var raw map[string]struct {
+		URL      string `json:"url"`
+		Template string `json:"template"`
+	}
+	if err := yaml.Unmarshal(buf, &raw); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	targets := make(map[string]WebhookTarget, len(raw))
+	for name, r := range raw {
+		if r.URL == "" || r.Template == "" {
+			return nil, fmt.Errorf("%s: url and template required", name)
+		}
+		tmpl, err := ParseWebhookTemplate(name, r.Template)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		targets[name] = WebhookTarget{URL: r.URL, Template: tmpl}
+	}
+	return targets, nil
+}
+
+// Notify renders and sends each event to the webhooks of its dashboard.
+func (wh Webhook) Notify(ctx context.Context, cfg *configpb.Configuration, events []Event) error {
+	var mErr error
+	for _, e := range events {
+		dash := config.FindDashboard(e.Dashboard, cfg)
+		for _, name := range dash.GetNotificationOptions().GetWebhooks() {
+			target, ok := wh.Targets[name]
+			if !ok {
+				mErr = multierror.Append(mErr, fmt.Errorf("%s: unknown webhook %q", e.Dashboard, name))
+				continue
+			}
+			payload, err := render(target.Template, e)
+			if err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("render %s: %w", name, err))
+				continue
+			}
+			if err := post(ctx, wh.Client, target.URL, payload); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("post to %s: %w", name, err))
+			}
+		}
+	}
+	return mErr
+}
+
+// render executes the template, ensuring it produces valid JSON.
+func render(tmpl *template.Template, e Event) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, e); err != nil {
+		return nil, err
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("invalid json payload")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestLoadWebhooks(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    []string
+		err     bool
+	}{
+		{
+			name:    "basically works",
+			content: "{}",
+		},
+		{
+			name: "load targets",
+			content: `
+teams:
+  url: http://teams
+  template: '{"text": {{json .Text}}}'
+matrix:
+  url: http://matrix
+  template: '{"body": {{json .Tab}}}'
+`,
+			want: []string{"matrix", "teams"},
+		},
+		{
+			name: "reject missing template",
+			content: `
+teams:
+  url: http://teams
+`,
+			err: true,
+		},
+		{
+			name: "reject bad template",
+			content: `
+teams:
+  url: http://teams
+  template: '{{.Text'
+`,
+			err: true,
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "webhooks.yaml")
+			if err := ioutil.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			targets, err := LoadWebhooks(path)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("LoadWebhooks() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("LoadWebhooks() failed to return an error")
+			}
+			var got []string
+			for name, target := range targets {
+				if target.URL != "http://"+name {
+					t.Errorf("LoadWebhooks() %s got url %q", name, target.URL)
+				}
+				got = append(got, name)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("LoadWebhooks() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookNotify(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					Webhooks: []string{"good"},
+				},
+			},
+			{
+				Name: "invalid",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					Webhooks: []string{"bad-json"},
+				},
+			},
+			{
+				Name: "unknown",
+				NotificationOptions: &configpb.DashboardNotificationOptions{
+					Webhooks: []string{"missing"},
+				},
+			},
+		},
+	}
+	event := func(dash string) Event {
+		return Event{
+			Kind:      TabFailing,
+			Dashboard: dash,
+			Tab:       "tab",
+			Summary:   &summarypb.DashboardTabSummary{OverallStatus: summarypb.DashboardTabSummary_FAIL},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		events []Event
+		want   []string
+		err    bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:   "render each event",
+			events: []Event{event("dash"), event("dash")},
+			want: []string{
+				`{"tab": "tab", "kind": "failing", "status": "FAIL"}`,
+				`{"tab": "tab", "kind": "failing", "status": "FAIL"}`,
+			},
+		},
+		{
+			name:   "reject invalid json",
+			events: []Event{event("invalid")},
+			err:    true,
+		},
+		{
+			name:   "reject unknown webhooks",
+			events: []Event{event("unknown")},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				buf, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("read: %v", err)
+				}
+				got = append(got, string(buf))
+			}))
+			defer server.Close()
+
+			good, err := ParseWebhookTemplate("good", `{"tab": {{json .Tab}}, "kind": "{{.Kind}}", "status": "{{.Summary.OverallStatus}}"}`)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			bad, err := ParseWebhookTemplate("bad-json", `{"tab": {{.Tab}}}`)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			wh := Webhook{
+				Targets: map[string]WebhookTarget{
+					"good":     {URL: server.URL, Template: good},
+					"bad-json": {URL: server.URL, Template: bad},
+				},
+				Client: server.Client(),
+			}
+			err = wh.Notify(context.Background(), cfg, tc.events)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Notify() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Notify() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Notify() sent unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"strconv"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+)
+
+const (
+	defaultSLOShortHours = 24
+	defaultSLOLongHours  = 7 * 24
+	defaultBurnThreshold = 1
+)
+
+var (
+	tabBurnRate  = metrics.NewGauge("testgrid_tab_slo_burn_rate", "Rate the tab spends the failures allowed by its pass rate objective", "dashboard", "tab", "window")
+	tabViolating = metrics.NewGauge("testgrid_tab_slo_violating", "Whether both windows of the tab burn faster than its threshold allows", "dashboard", "tab")
+)
+
+// CalculateSLO computes the burn rates of the grid over a short and long window ending at now.
+//
+// Returns nil unless the options set a target pass rate.
+func CalculateSLO(grid *statepb.Grid, now time.Time, opts *configpb.SLOOptions) *summarypb.SLOStatus {
+	target := opts.GetTargetPassRate()
+	if target <= 0 || target >= 100 {
+		return nil
+	}
+	slo := summarypb.SLOStatus{
+		TargetPassRate:   target,
+		ShortWindowHours: opts.GetShortWindowHours(),
+		LongWindowHours:  opts.GetLongWindowHours(),
+	}
+	if slo.ShortWindowHours <= 0 {
+		slo.ShortWindowHours = defaultSLOShortHours
+	}
+	if slo.LongWindowHours <= 0 {
+		slo.LongWindowHours = defaultSLOLongHours
+	}
+	threshold := opts.GetBurnRateThreshold()
+	if threshold <= 0 {
+		threshold = defaultBurnThreshold
+	}
+
+	end := int(now.Unix())
+	short, shortOK := passRate(grid, goBackHours(int(slo.ShortWindowHours), now), end)
+	long, longOK := passRate(grid, goBackHours(int(slo.LongWindowHours), now), end)
+	if shortOK {
+		slo.ShortPassRate = short
+		slo.ShortBurnRate = burnRate(short, target)
+	}
+	if longOK {
+		slo.LongPassRate = long
+		slo.LongBurnRate = burnRate(long, target)
+	}
+	slo.Violating = shortOK && longOK && slo.ShortBurnRate >= threshold && slo.LongBurnRate >= threshold
+	return &slo
+}
+
+// burnRate returns the failure rate of the pass rate over the failure rate the target allows.
+func burnRate(passRate, target float32) float32 {
+	return (100 - passRate) / (100 - target)
+}
+
+func goBackHours(hours int, now time.Time) int {
+	return int(now.Add(-time.Duration(hours) * time.Hour).Unix())
+}
+
+// reportSLO sets the burn rate metrics of the tab, if it has an objective.
+func reportSLO(dashboard string, tab *summarypb.DashboardTabSummary) {
+	slo := tab.GetSlo()
+	if slo == nil {
+		return
+	}
+	tabBurnRate.Set(float64(slo.ShortBurnRate), dashboard, tab.DashboardTabName, strconv.Itoa(int(slo.ShortWindowHours))+"h")
+	tabBurnRate.Set(float64(slo.LongBurnRate), dashboard, tab.DashboardTabName, strconv.Itoa(int(slo.LongWindowHours))+"h")
+	var violating float64
+	if slo.Violating {
+		violating = 1
+	}
+	tabViolating.Set(violating, dashboard, tab.DashboardTabName)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestCalculateSLO(t *testing.T) {
+	const hour = 60 * 60
+	now := time.Unix(1000*hour, 0)
+	// Columns from 1 hour and 3 days ago.
+	grid := func(recent, old statuspb.TestStatus) *statepb.Grid {
+		return &statepb.Grid{
+			Columns: []*statepb.Column{
+				{Started: (999*hour + 1) * 1000},
+				{Started: (999*hour + 1) * 1000},
+				{Started: (1000 - 72) * hour * 1000},
+				{Started: (1000 - 72) * hour * 1000},
+			},
+			Rows: []*statepb.Row{
+				{
+					Name: "test",
+					Results: []int32{
+						int32(recent), 1,
+						int32(statuspb.TestStatus_PASS), 1,
+						int32(old), 2,
+					},
+					Messages: []string{"", "", "", ""},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		opts     *configpb.SLOOptions
+		expected *summarypb.SLOStatus
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+		},
+		{
+			name: "no results",
+			grid: &statepb.Grid{},
+			opts: &configpb.SLOOptions{TargetPassRate: 90},
+			expected: &summarypb.SLOStatus{
+				TargetPassRate:   90,
+				ShortWindowHours: 24,
+				LongWindowHours:  168,
+			},
+		},
+		{
+			name: "healthy",
+			grid: grid(statuspb.TestStatus_PASS, statuspb.TestStatus_PASS),
+			opts: &configpb.SLOOptions{TargetPassRate: 90},
+			expected: &summarypb.SLOStatus{
+				TargetPassRate:   90,
+				ShortWindowHours: 24,
+				LongWindowHours:  168,
+				ShortPassRate:    100,
+				LongPassRate:     100,
+			},
+		},
+		{
+			name: "violating",
+			grid: grid(statuspb.TestStatus_FAIL, statuspb.TestStatus_FAIL),
+			opts: &configpb.SLOOptions{TargetPassRate: 90},
+			expected: &summarypb.SLOStatus{
+				TargetPassRate:   90,
+				ShortWindowHours: 24,
+				LongWindowHours:  168,
+				ShortPassRate:    50,
+				LongPassRate:     25,
+				ShortBurnRate:    5,
+				LongBurnRate:     7.5,
+				Violating:        true,
+			},
+		},
+		{
+			name: "long window below threshold",
+			grid: grid(statuspb.TestStatus_FAIL, statuspb.TestStatus_PASS),
+			opts: &configpb.SLOOptions{
+				TargetPassRate:    90,
+				BurnRateThreshold: 3,
+			},
+			expected: &summarypb.SLOStatus{
+				TargetPassRate:   90,
+				ShortWindowHours: 24,
+				LongWindowHours:  168,
+				ShortPassRate:    50,
+				LongPassRate:     75,
+				ShortBurnRate:    5,
+				LongBurnRate:     2.5,
+			},
+		},
+		{
+			name: "custom windows",
+			grid: grid(statuspb.TestStatus_PASS, statuspb.TestStatus_FAIL),
+			opts: &configpb.SLOOptions{
+				TargetPassRate:   90,
+				ShortWindowHours: 48,
+				LongWindowHours:  96,
+			},
+			expected: &summarypb.SLOStatus{
+				TargetPassRate:   90,
+				ShortWindowHours: 48,
+				LongWindowHours:  96,
+				ShortPassRate:    100,
+				LongPassRate:     50,
+				LongBurnRate:     5,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := CalculateSLO(tc.grid, now, tc.opts)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("CalculateSLO() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
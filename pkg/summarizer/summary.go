@@ -18,15 +18,16 @@ limitations under the License.
 package summarizer
 
 import (
+	"bytes"
 	"compress/zlib"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"path"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,7 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/label"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
@@ -41,7 +43,13 @@ import (
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/alerts"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer/notify"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 )
 
 // gridReader returns the grid content and metadata (last updated time, generation id)
@@ -50,69 +58,109 @@ type gridReader func(ctx context.Context) (io.ReadCloser, time.Time, int64, erro
 // groupFinder returns the named group as well as reader for the grid state
 type groupFinder func(string) (*configpb.TestGroup, gridReader, error)
 
+// dashboardObjective tracks how reliably each dashboard is summarized.
+var dashboardObjective = metrics.NewObjective("summarizer")
+
 // Update summary protos by reading the state protos defined in the config.
 //
 // Will use concurrency go routines to update dashboards in parallel.
 // Setting dashboard will limit update to this dashboard.
-// Will write summary proto when confirm is set.
-func Update(ctx context.Context, client *storage.Client, configPath gcs.Path, concurrency int, dashboard, gridPathPrefix, summaryPathPrefix string, confirm bool) error {
+// Will embed signed links to the artifacts of failing results when signer is set.
+// Will write summary proto when confirm is set, notifying about any changes when notifier is set.
+// Notifications skip alerts already sent, acknowledged or snoozed according to the dashboard's alert state.
+// Will file issues about sustained failures when tracker is set.
+// Checks the config for changes at most once per reloadConfig, replanning the dashboards
+// left to summarize when it changes. Never reloads when reloadConfig is zero.
+// Summarizes dashboards with pending triggers under triggerPrefix first, checking for
+// new triggers throughout the pass, unless triggerPrefix is empty.
+func Update(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, reloadConfig time.Duration, concurrency int, dashboard, gridPathPrefix, summaryPathPrefix, triggerPrefix string, signer gcs.Signer, notifier notify.Notifier, tracker notify.Tracker, confirm bool) error {
 	if concurrency < 1 {
 		return fmt.Errorf("concurrency must be positive, got: %d", concurrency)
 	}
-	cfg, err := config.ReadGCS(ctx, gcs.NewClient(client), configPath)
+	defer dashboardObjective.Report()
+	watcher, err := config.NewWatcher(ctx, client, configPath, reloadConfig)
 	if err != nil {
 		return fmt.Errorf("Failed to read config: %w", err)
 	}
+	cfg := watcher.Config()
 	logrus.Infof("Found %d dashboards", len(cfg.Dashboards))
 
-	dashboards := make(chan *configpb.Dashboard)
+	dashboards := make(chan dashJob)
 	var wg sync.WaitGroup
 
-	groupFinder := func(name string) (*configpb.TestGroup, gridReader, error) {
-		group := config.FindTestGroup(name, cfg)
-		if group == nil {
-			return nil, nil, nil
-		}
-		groupPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(gridPathPrefix, name)})
-		if err != nil {
-			return group, nil, err
-		}
-		reader := func(ctx context.Context) (io.ReadCloser, time.Time, int64, error) {
-			return pathReader(ctx, client, *groupPath)
-		}
-		return group, reader, nil
-	}
+	groupFinder := gcsGroupFinder(client, cfg, configPath, gridPathPrefix)
 
 	errCh := make(chan error)
 
 	for i := 0; i < concurrency; i++ {
 		wg.Add(1)
 		go func() {
-			for dash := range dashboards {
+			for job := range dashboards {
+				dash, cfg, groupFinder := job.dash, job.cfg, job.finder
 				log := logrus.WithField("dashboard", dash.Name)
 				log.Info("Summarizing dashboard")
-				sum, err := updateDashboard(ctx, dash, groupFinder)
+				done := func(err error) {
+					dashboardObjective.Record(dash.Name, err)
+					if err != nil {
+						err = errors.New(dash.Name)
+					}
+					errCh <- err
+				}
+				summaryPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, SummaryPath(dash.Name))})
+				if err != nil {
+					log.WithError(err).Error("Cannot resolve summary path")
+					done(err)
+					continue
+				}
+				previous, prevErr := ReadSummary(ctx, client, *summaryPath)
+				if prevErr != nil {
+					log.WithError(prevErr).Warning("Cannot read previous summary, summarizing every tab")
+				}
+				sum, reports, err := updateDashboard(ctx, dash, groupFinder, previous)
 				if err != nil {
 					log.WithError(err).Error("Cannot summarize dashboard")
-					errCh <- errors.New(dash.Name)
+					done(err)
 					continue
 				}
+				sum.Ownership = dashboardOwnership(config.FindOwnership(dash.Name, cfg))
+				if signer != nil {
+					signArtifacts(log, dash, sum, cfg, signer)
+				}
 				log.WithField("summary", sum).Info("summarized")
 				if !confirm {
 					continue
 				}
-				summaryPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, summaryPath(dash.Name))})
-				if err != nil {
-					log.WithError(err).Error("Cannot resolve summary path")
-					errCh <- errors.New(dash.Name)
-					continue
+				if notifier != nil || tracker != nil {
+					// Notify before writing so the summary records any alerts sent.
+					if prevErr != nil {
+						log.Warning("Skipping notifications without the previous summary")
+					} else {
+						keepAlertingData(previous, sum)
+						alert(ctx, log, client, cfg, notifier, tracker, *summaryPath, dash.Name, previous, sum)
+					}
 				}
 				if err := writeSummary(ctx, client, *summaryPath, sum); err != nil {
 					log.WithError(err).Error("Cannot write summary")
-					errCh <- errors.New(dash.Name)
+					done(err)
 					continue
 				}
-				errCh <- nil
+				if jsonPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, exportPath(dash.Name))}); err != nil {
+					log.WithError(err).Error("Cannot resolve exported summary path")
+				} else if err := writeExportedSummary(ctx, client, *jsonPath, dash.Name, sum); err != nil {
+					log.WithError(err).Error("Cannot write exported summary")
+				}
+				for _, report := range reports {
+					log := log.WithField("tab", report.DashboardTabName)
+					reportPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, flakinessPath(dash.Name, report.DashboardTabName))})
+					if err != nil {
+						log.WithError(err).Error("Cannot resolve flakiness report path")
+						continue
+					}
+					if err := writeFlakinessReport(ctx, client, *reportPath, report); err != nil {
+						log.WithError(err).Error("Cannot write flakiness report")
+					}
+				}
+				done(nil)
 			}
 			wg.Done()
 		}()
@@ -134,12 +182,50 @@ func Update(ctx context.Context, client *storage.Client, configPath gcs.Path, co
 		close(resultCh)
 	}()
 
-	for _, d := range cfg.Dashboards {
+	pending := cfg.Dashboards
+	done := map[string]bool{}
+	triggered := map[string]bool{}
+	var checkedTriggers time.Time
+	for len(pending) > 0 {
+		if changed, err := watcher.Reload(ctx); err != nil {
+			logrus.WithError(err).Warning("Failed to reload config")
+		} else if changed {
+			cfg = watcher.Config()
+			groupFinder = gcsGroupFinder(client, cfg, configPath, gridPathPrefix)
+			pending = remainingDashboards(cfg.Dashboards, done)
+			logrus.WithField("dashboards", len(pending)).Info("Config changed, replanning remaining dashboards")
+			continue
+		}
+		if triggerPrefix != "" && time.Since(checkedTriggers) >= triggerInterval {
+			checkedTriggers = time.Now()
+			dashes, err := triggeredDashboards(ctx, client, configPath, summaryPathPrefix, triggerPrefix, cfg)
+			if err != nil {
+				logrus.WithError(err).Warning("Failed to check triggers")
+			}
+			var n int
+			for _, d := range dashes {
+				if triggered[d.Name] || (dashboard != "" && dashboard != d.Name) {
+					continue
+				}
+				logrus.WithField("dashboard", d.Name).Info("Summarizing triggered dashboard")
+				triggered[d.Name] = true
+				done[d.Name] = true
+				dashboards <- dashJob{dash: d, cfg: cfg, finder: groupFinder}
+				n++
+			}
+			if n > 0 {
+				pending = remainingDashboards(pending, done)
+				continue
+			}
+		}
+		d := pending[0]
+		pending = pending[1:]
+		done[d.Name] = true
 		if dashboard != "" && dashboard != d.Name {
 			logrus.WithField("dashboard", d.Name).Info("Skipping")
 			continue
 		}
-		dashboards <- d
+		dashboards <- dashJob{dash: d, cfg: cfg, finder: groupFinder}
 	}
 	close(dashboards)
 	wg.Wait()
@@ -147,55 +233,311 @@ func Update(ctx context.Context, client *storage.Client, configPath gcs.Path, co
 	return <-resultCh
 }
 
-var (
-	normalizer = regexp.MustCompile(`[^a-z0-9]+`)
-)
+// dashboardOwnership copies the config's ownership into the summary, returning nil when unset.
+func dashboardOwnership(o *configpb.Ownership) *summarypb.DashboardOwnership {
+	if o == nil {
+		return nil
+	}
+	return &summarypb.DashboardOwnership{
+		Owners:  o.Owners,
+		Team:    o.Team,
+		Contact: o.Contact,
+	}
+}
+
+// dashJob is a dashboard to summarize along with the config that defines it.
+type dashJob struct {
+	dash   *configpb.Dashboard
+	cfg    *configpb.Configuration
+	finder groupFinder
+}
 
-func summaryPath(name string) string {
+// remainingDashboards returns the dashboards not yet done.
+func remainingDashboards(dashboards []*configpb.Dashboard, done map[string]bool) []*configpb.Dashboard {
+	var out []*configpb.Dashboard
+	for _, d := range dashboards {
+		if !done[d.Name] {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// gcsGroupFinder finds test groups in the config and reads their grid state under gridPathPrefix.
+func gcsGroupFinder(client gcs.ConditionalClient, cfg *configpb.Configuration, configPath gcs.Path, gridPathPrefix string) groupFinder {
+	return func(name string) (*configpb.TestGroup, gridReader, error) {
+		group := config.FindTestGroup(name, cfg)
+		if group == nil {
+			return nil, nil, nil
+		}
+		groupPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(gridPathPrefix, name)})
+		if err != nil {
+			return group, nil, err
+		}
+		reader := func(ctx context.Context) (io.ReadCloser, time.Time, int64, error) {
+			return pathReader(ctx, client, *groupPath)
+		}
+		return group, reader, nil
+	}
+}
+
+// SummarizeGrid summarizes the dashboard's tabs of the group from the grid, without reading or writing storage.
+//
+// Tabs of other groups fail to summarize.
+func SummarizeGrid(ctx context.Context, dash *configpb.Dashboard, group *configpb.TestGroup, grid *statepb.Grid) (*summarypb.DashboardSummary, error) {
+	buf, err := proto.Marshal(grid)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	if _, err := zw.Write(buf); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+	now := time.Now()
+	finder := func(name string) (*configpb.TestGroup, gridReader, error) {
+		if name != group.Name {
+			return nil, nil, nil
+		}
+		reader := func(context.Context) (io.ReadCloser, time.Time, int64, error) {
+			return ioutil.NopCloser(bytes.NewReader(zbuf.Bytes())), now, 0, nil
+		}
+		return group, reader, nil
+	}
+	sum, _, err := updateDashboard(ctx, dash, finder, nil)
+	return sum, err
+}
+
+// alert tracks issues and sends the events users still want to hear about, updating the dashboard's alert state.
+//
+// Appends the alerts it opens and resolves and the events it sends to the dashboard's audit log for the month.
+func alert(ctx context.Context, log logrus.FieldLogger, client gcs.ConditionalClient, cfg *configpb.Configuration, notifier notify.Notifier, tracker notify.Tracker, summaryPath gcs.Path, dashboard string, previous, sum *summarypb.DashboardSummary) {
+	statePath, err := summaryPath.ResolveReference(&url.URL{Path: notify.StatePath(dashboard)})
+	if err != nil {
+		log.WithError(err).Warning("Cannot resolve alert state path, skipping notifications")
+		return
+	}
+	state, gen, err := notify.ReadState(ctx, client, *statePath)
+	if err != nil {
+		log.WithError(err).Warning("Cannot read alert state, skipping notifications")
+		return
+	}
+	if tracker != nil {
+		// Track first so notifications link to any issues.
+		if err := tracker.Track(ctx, cfg, sum, state); err != nil {
+			log.WithError(err).Warning("Failed to track issues")
+		}
+	}
+	before := proto.Clone(state).(*summarypb.DashboardAlertState)
+	now := time.Now()
+	var events []notify.Event
+	var sendErr error
+	if notifier != nil {
+		if events = notify.Filter(state, notify.Events(previous, sum), now); len(events) > 0 {
+			if sendErr = notifier.Notify(ctx, cfg, events); sendErr != nil {
+				log.WithError(sendErr).Warning("Failed to send notifications")
+			}
+		}
+	}
+	if err := notify.WriteState(ctx, client, *statePath, state, gen); err != nil {
+		log.WithError(err).Warning("Cannot write alert state")
+	}
+	auditPath, err := summaryPath.ResolveReference(&url.URL{Path: notify.AuditPath(dashboard, now)})
+	if err != nil {
+		log.WithError(err).Warning("Cannot resolve audit log path")
+		return
+	}
+	entries := notify.AuditEntries(cfg, dashboard, sum, before, state, events, sendErr, now)
+	if err := notify.AppendAudit(ctx, client, *auditPath, entries); err != nil {
+		log.WithError(err).WithField("entries", len(entries)).Warning("Cannot append to audit log")
+	}
+}
+
+// SummaryPath returns the name of the dashboard's summary object under the summary path prefix.
+func SummaryPath(name string) string {
 	// ''.join(c for c in n.lower() if c is alphanumeric
-	return "summary-" + normalizer.ReplaceAllString(strings.ToLower(name), "")
+	return "summary-" + config.Normalize(name)
+}
+
+func flakinessPath(dashboard, tab string) string {
+	return "flakiness-" + config.Normalize(dashboard) + "-" + config.Normalize(tab)
+}
+
+func writeFlakinessReport(ctx context.Context, client gcs.Uploader, path gcs.Path, report *summarypb.FlakinessReport) error {
+	buf, err := proto.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	return client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
 }
 
-func writeSummary(ctx context.Context, client *storage.Client, path gcs.Path, sum *summarypb.DashboardSummary) error {
+func writeSummary(ctx context.Context, client gcs.Uploader, path gcs.Path, sum *summarypb.DashboardSummary) error {
 	buf, err := proto.Marshal(sum)
 	if err != nil {
 		return fmt.Errorf("marshal: %v", err)
 	}
-	return gcs.Upload(ctx, client, path, buf, gcs.DefaultAcl, "no-cache") // TODO(fejta): configurable cache value
+	return client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache") // TODO(fejta): configurable cache value
+}
+
+// ReadSummary returns the existing summary at path, or nil if it does not exist.
+func ReadSummary(ctx context.Context, client gcs.Opener, path gcs.Path) (*summarypb.DashboardSummary, error) {
+	r, err := client.Open(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var sum summarypb.DashboardSummary
+	if err := proto.Unmarshal(buf, &sum); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return &sum, nil
+}
+
+// keepAlertingData copies the alerting data of each previous tab summary into the current one.
+func keepAlertingData(previous, current *summarypb.DashboardSummary) {
+	data := map[string]*summarypb.AlertingData{}
+	for _, tab := range previous.GetTabSummaries() {
+		data[tab.DashboardTabName] = tab.AlertingData
+	}
+	for _, tab := range current.TabSummaries {
+		if tab.AlertingData == nil {
+			tab.AlertingData = data[tab.DashboardTabName]
+		}
+	}
+}
+
+// linkProperty is the result property holding the link to the log of each result by default.
+const linkProperty = "log"
+
+// gcsLinks are the prefixes of links to GCS objects, such as those of artifact_links.
+var gcsLinks = []string{"gs://", "https://storage.cloud.google.com/", "https://storage.googleapis.com/"}
+
+// signArtifacts adds a signed link to the artifact of the latest failure of each failing test.
+//
+// Signs the link in the first property of the failure named by the artifact_links
+// of the tab's test group, or else its log property, when it points at a GCS object.
+func signArtifacts(log logrus.FieldLogger, dash *configpb.Dashboard, sum *summarypb.DashboardSummary, cfg *configpb.Configuration, signer gcs.Signer) {
+	groups := map[string]string{}
+	for _, tab := range dash.DashboardTab {
+		groups[tab.Name] = tab.TestGroupName
+	}
+	for _, tabSum := range sum.TabSummaries {
+		props := linkProperties(config.FindTestGroup(groups[tabSum.DashboardTabName], cfg))
+		for _, failure := range tabSum.FailingTestSummaries {
+			artifact := artifactPath(failure.Properties, props)
+			if artifact == nil {
+				continue
+			}
+			log := log.WithField("tab", tabSum.DashboardTabName).WithField("artifact", artifact)
+			link, err := signer.Sign(*artifact)
+			if err != nil {
+				logging.Repeated.Warning(log.WithError(err), "Failed to sign artifact url")
+				continue
+			}
+			failure.LatestFailArtifactUrl = link
+		}
+	}
+}
+
+// linkProperties returns the properties that may link to the artifacts of the group's results.
+func linkProperties(group *configpb.TestGroup) []string {
+	var props []string
+	for _, link := range group.GetArtifactLinks() {
+		if link.Property != "" {
+			props = append(props, link.Property)
+		}
+	}
+	return append(props, linkProperty)
+}
+
+// artifactPath returns the GCS object of the first property linking to one, or nil.
+func artifactPath(properties map[string]string, props []string) *gcs.Path {
+	for _, prop := range props {
+		link := properties[prop]
+		for _, prefix := range gcsLinks {
+			if !strings.HasPrefix(link, prefix) {
+				continue
+			}
+			artifact, err := gcs.NewPath("gs://" + strings.TrimPrefix(link, prefix))
+			if err == nil && artifact.Object() != "" {
+				return artifact
+			}
+		}
+	}
+	return nil
 }
 
 // pathReader returns a reader for the specified path and last modified, generation metadata.
-func pathReader(ctx context.Context, client *storage.Client, path gcs.Path) (io.ReadCloser, time.Time, int64, error) {
-	r, err := client.Bucket(path.Bucket()).Object(path.Object()).NewReader(ctx)
+func pathReader(ctx context.Context, client gcs.ConditionalClient, path gcs.Path) (io.ReadCloser, time.Time, int64, error) {
+	attrs, err := client.Stat(ctx, path)
+	if err != nil {
+		return nil, time.Time{}, 0, fmt.Errorf("stat %s: %w", path, err)
+	}
+	cond := storage.Conditions{GenerationMatch: attrs.Generation}
+	r, err := client.If(&cond, nil).Open(ctx, path)
 	if err != nil {
 		return nil, time.Time{}, 0, fmt.Errorf("read %s: %w", path, err)
 	}
-	return r, r.Attrs.LastModified, r.Attrs.Generation, nil
+	return r, attrs.Updated, attrs.Generation, nil
 }
 
 // updateDashboard will summarize all the tabs (through errors), returning an error if any fail to summarize.
-func updateDashboard(ctx context.Context, dash *configpb.Dashboard, finder groupFinder) (*summarypb.DashboardSummary, error) {
+//
+// Reuses the previous summary of tabs whose grid and configuration are unchanged.
+// Also returns the flakiness report of each tab configured with flake rate windows.
+func updateDashboard(ctx context.Context, dash *configpb.Dashboard, finder groupFinder, previous *summarypb.DashboardSummary) (*summarypb.DashboardSummary, []*summarypb.FlakinessReport, error) {
+	ctx, span := tracing.Start(ctx, "summarizer.summarize_dashboard", label.String("dashboard", dash.Name))
 	log := logrus.WithField("dashboard", dash.Name)
+	old := map[string]*summarypb.DashboardTabSummary{}
+	for _, tab := range previous.GetTabSummaries() {
+		old[tab.DashboardTabName] = tab
+	}
 	var badTabs []string
 	var sum summarypb.DashboardSummary
+	var reports []*summarypb.FlakinessReport
 	for _, tab := range dash.DashboardTab {
 		log := log.WithField("tab", tab.Name)
 		log.Info("Summarizing tab")
-		s, err := updateTab(ctx, tab, finder)
+		tctx, tspan := tracing.Start(ctx, "summarizer.summarize_tab", label.String("tab", tab.Name))
+		s, report, err := updateTab(tctx, tab, finder, old[tab.Name])
+		tracing.End(tspan, err)
 		if err != nil {
 			log.WithError(err).Error("Cannot summarize tab")
 			badTabs = append(badTabs, tab.Name)
-			sum.TabSummaries = append(sum.TabSummaries, problemTab(dash.Name, tab.Name))
+			problem := problemTab(dash.Name, tab.Name)
+			describeTab(problem, tab)
+			sum.TabSummaries = append(sum.TabSummaries, problem)
 			continue
 		}
 		s.DashboardName = dash.Name
+		describeTab(s, tab)
+		reportSLO(dash.Name, s)
+		if tmpl := fileBugTemplate(dash, tab); tmpl.GetUrl() != "" {
+			group, _, _ := finder(tab.TestGroupName)
+			fileBugLinks(tmpl, group, s)
+		}
 		sum.TabSummaries = append(sum.TabSummaries, s)
+		if report != nil {
+			report.DashboardName = dash.Name
+			reports = append(reports, report)
+		}
 	}
 	var err error
 	if d := len(badTabs); d > 0 {
 		err = fmt.Errorf("Failed %d tabs: %s", d, strings.Join(badTabs, ", "))
 	}
-	return &sum, err
+	tracing.End(span, err)
+	return &sum, reports, err
 }
 
 // problemTab summarizes a tab that cannot summarize
@@ -216,16 +558,30 @@ func staleHours(tab *configpb.DashboardTab) time.Duration {
 }
 
 // updateTab reads the latest grid state for the tab and summarizes it.
-func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupFinder) (*summarypb.DashboardTabSummary, error) {
+//
+// Returns a copy of the previous summary instead when it has the same grid generation and config fingerprint.
+// Returns a flakiness report when the tab configures flake rate windows.
+func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupFinder, previous *summarypb.DashboardTabSummary) (*summarypb.DashboardTabSummary, *summarypb.FlakinessReport, error) {
 	groupName := tab.TestGroupName
 	group, groupReader, err := findGroup(groupName)
 	if err != nil {
-		return nil, fmt.Errorf("find group: %v", err)
+		return nil, nil, fmt.Errorf("find group: %v", err)
 	}
 	if group == nil {
-		return nil, fmt.Errorf("not found: %q", groupName)
+		return nil, nil, fmt.Errorf("not found: %q", groupName)
 	}
-	grid, mod, _, err := readGrid(ctx, groupReader) // TODO(fejta): track gen
+	fingerprint, err := configFingerprint(tab, group)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fingerprint: %v", err)
+	}
+	r, mod, gen, err := groupReader(ctx)
+	if err == nil && fingerprint != "" && previous.GetConfigFingerprint() == fingerprint && previous.GetGridGeneration() == gen {
+		r.Close()
+		return proto.Clone(previous).(*summarypb.DashboardTabSummary), nil, nil
+	}
+	grid, mod, gen, err := readGrid(ctx, func(context.Context) (io.ReadCloser, time.Time, int64, error) {
+		return r, mod, gen, err
+	})
 	if err != nil && errors.Is(err, storage.ErrObjectNotExist) {
 		return &summarypb.DashboardTabSummary{
 			DashboardTabName: tab.Name,
@@ -233,32 +589,56 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 			OverallStatus:    overallStatus(nil, 0, noRuns, false, nil),
 			Status:           noRuns,
 			LatestGreen:      noGreens,
-		}, nil
+		}, nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("load %s: %v", groupName, err)
+		return nil, nil, fmt.Errorf("load %s: %v", groupName, err)
 	}
 
+	// Ignore infrastructure failures when analyzing flakiness and alerting.
+	infra := infraColumns(grid, tab.InfraFailureThreshold, tab.InfraFailureProperty)
+	usable := withoutColumns(grid, infra)
+
 	var healthiness *summarypb.HealthinessInfo
+	var trend *summarypb.HealthTrend
 	if shouldRunHealthiness(tab) {
 		// TODO (itsazhuhere@): Change to rely on YAML defaults rather than consts
 		interval := int(tab.HealthAnalysisOptions.DaysOfAnalysis)
 		if interval <= 0 {
 			interval = DefaultInterval
 		}
-		healthiness = getHealthinessForInterval(grid, tab.Name, time.Now(), interval)
+		healthiness = getHealthinessForInterval(usable, tab.Name, time.Now(), interval)
+		trend = CalculateHealthTrend(usable, time.Now(), tab.HealthAnalysisOptions)
+	}
+
+	var report *summarypb.FlakinessReport
+	var flakeRates map[string]float32
+	if windows := tab.GetHealthAnalysisOptions().GetFlakeRateWindows(); len(windows) > 0 {
+		report = &summarypb.FlakinessReport{
+			DashboardTabName: tab.Name,
+			Windows:          CalculateFlakeRates(usable, time.Now(), windows),
+		}
+		flakeRates = rowFlakeRates(report.Windows[0])
+	}
+
+	overrideAlerts(usable, tab, group, usable != grid)
+	for i, row := range usable.Rows {
+		grid.Rows[i].AlertInfo = row.AlertInfo
 	}
 
 	recent := recentColumns(tab, group)
 	grid.Rows, err = filterGrid(tab.BaseOptions, grid.Rows, recent)
 	if err != nil {
-		return nil, fmt.Errorf("filter: %v", err)
+		return nil, nil, fmt.Errorf("filter: %v", err)
 	}
 
 	latest, latestSeconds := latestRun(grid.Columns)
 	alert := staleAlert(mod, latest, staleHours(tab))
 	failures := failingTestSummaries(grid.Rows)
 	passingCols, completedCols, passingCells, filledCells, brokenState := gridMetrics(len(grid.Columns), grid.Rows, recent, tab.BrokenColumnThreshold)
+	ignored := ignoredCells(grid.Columns, recent)
+	passingCells += ignored
+	filledCells += ignored
 	return &summarypb.DashboardTabSummary{
 		DashboardTabName:     tab.Name,
 		LastUpdateTimestamp:  float64(mod.Unix()),
@@ -268,10 +648,40 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 		OverallStatus:        overallStatus(grid, recent, alert, brokenState, failures),
 		Status:               statusMessage(passingCols, completedCols, passingCells, filledCells),
 		LatestGreen:          latestGreen(grid, group.UseKubernetesClient),
-		// TODO(fejta): BugUrl
-		Healthiness:  healthiness,
-		LinkedIssues: allLinkedIssues(grid.Rows),
-	}, nil
+		Healthiness:          healthiness,
+		LinkedIssues:         allLinkedIssues(grid.Rows),
+		FlakeRates:           flakeRates,
+		FailureClusters:      failureClusters(grid.Rows, recent),
+		HealthTrend:          trend,
+		GridGeneration:       gen,
+		ConfigFingerprint:    fingerprint,
+		InfraFailureBuilds:   infraBuilds(grid.Columns, infra, recent),
+		CompletedColumns:     int32(completedCols),
+		PassingColumns:       int32(passingCols),
+		FilledCells:          int32(filledCells),
+		PassingCells:         int32(passingCells),
+		Slo:                  CalculateSLO(usable, time.Now(), tab.SloOptions),
+		RecentHealth:         recentHealth(usable, firstFilled(tab.RecentHealthColumns, defaultHealthColumns)),
+	}, report, nil
+}
+
+// configFingerprint returns a hash of the tab and group configuration when the summary depends on nothing else.
+//
+// Returns an empty fingerprint for tabs whose summary also depends on the current time,
+// such as stale alerts or healthiness analysis.
+func configFingerprint(tab *configpb.DashboardTab, group *configpb.TestGroup) (string, error) {
+	if staleHours(tab) > 0 || shouldRunHealthiness(tab) || len(tab.GetHealthAnalysisOptions().GetFlakeRateWindows()) > 0 || tab.GetSloOptions().GetTargetPassRate() > 0 {
+		return "", nil
+	}
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(tab); err != nil {
+		return "", fmt.Errorf("marshal tab: %w", err)
+	}
+	if err := buf.Marshal(group); err != nil {
+		return "", fmt.Errorf("marshal group: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(buf.Bytes())), nil
 }
 
 // readGrid downloads and deserializes the current test group state.
@@ -312,34 +722,16 @@ func firstFilled(values ...int32) int {
 	return 0
 }
 
-const (
-	includeFilter = "include-filter-by-regex"
-	excludeFilter = "exclude-filter-by-regex"
-	// TODO(fejta): others, which are not used by testgrid.k8s.io
-)
-
 // filterGrid truncates the grid to rows with recent results and matching the white/blacklist.
 func filterGrid(baseOptions string, rows []*statepb.Row, recent int) ([]*statepb.Row, error) {
 
-	vals, err := url.ParseQuery(baseOptions)
-	if err != nil {
-		return nil, fmt.Errorf("parse %q: %v", baseOptions, err)
-	}
-
 	rows = recentRows(rows, recent)
 
 	rows = filterMethods(rows)
 
-	for _, include := range vals[includeFilter] {
-		if rows, err = includeRows(rows, include); err != nil {
-			return nil, fmt.Errorf("bad %s=%s: %v", includeFilter, include, err)
-		}
-	}
-
-	for _, exclude := range vals[excludeFilter] {
-		if rows, err = excludeRows(rows, exclude); err != nil {
-			return nil, fmt.Errorf("bad %s=%s: %v", excludeFilter, exclude, err)
-		}
+	rows, err := tabulator.FilterRows(baseOptions, rows)
+	if err != nil {
+		return nil, err
 	}
 
 	// TODO(fejta): grouping, which is not used by testgrid.k8s.io
@@ -376,38 +768,6 @@ func filterMethods(rows []*statepb.Row) []*statepb.Row {
 	return filtered
 }
 
-// includeRows returns the subset of rows that match the regex
-func includeRows(in []*statepb.Row, include string) ([]*statepb.Row, error) {
-	re, err := regexp.Compile(include)
-	if err != nil {
-		return nil, err
-	}
-	var rows []*statepb.Row
-	for _, r := range in {
-		if !re.MatchString(r.Name) {
-			continue
-		}
-		rows = append(rows, r)
-	}
-	return rows, nil
-}
-
-// excludeRows returns the subset of rows that do not match the regex
-func excludeRows(in []*statepb.Row, exclude string) ([]*statepb.Row, error) {
-	re, err := regexp.Compile(exclude)
-	if err != nil {
-		return nil, err
-	}
-	var rows []*statepb.Row
-	for _, r := range in {
-		if re.MatchString(r.Name) {
-			continue
-		}
-		rows = append(rows, r)
-	}
-	return rows, nil
-}
-
 // latestRun returns the Time (and seconds-since-epoch) of the most recent run.
 func latestRun(columns []*statepb.Column) (time.Time, int64) {
 	if len(columns) > 0 {
@@ -479,6 +839,73 @@ func failingTestSummaries(rows []*statepb.Row) []*summarypb.FailingTestSummary {
 	return failures
 }
 
+// overrideAlerts recomputes the alert of each row when the tab overrides the thresholds of its test group,
+// ignores timed out or aborted results, or when forced, such as after dropping columns.
+//
+// Also clears the alerts of rows with fewer than the tab's minimum runs to alert.
+func overrideAlerts(grid *statepb.Grid, tab *configpb.DashboardTab, group *configpb.TestGroup, force bool) {
+	opts := tab.GetAlertOptions()
+	failuresOpen, passesClose := int(opts.GetNumFailuresToAlert()), int(opts.GetNumPassesToDisableAlert())
+	var ignored []statuspb.TestStatus
+	if opts.GetIgnoreTimedOut() {
+		ignored = append(ignored, statuspb.TestStatus_TIMED_OUT)
+	}
+	if opts.GetIgnoreAborted() {
+		ignored = append(ignored, statuspb.TestStatus_ABORTED)
+	}
+	if failuresOpen > 0 || passesClose > 0 || len(ignored) > 0 || force {
+		if failuresOpen == 0 {
+			failuresOpen = int(group.NumFailuresToAlert)
+		}
+		if passesClose == 0 {
+			passesClose = int(group.NumPassesToDisableAlert)
+		}
+		if failuresOpen > 0 && passesClose == 0 {
+			passesClose = 1
+		}
+		if failuresOpen == 1 && passesClose == 1 && len(ignored) == 0 && hasFailureStreaks(grid.Rows) {
+			// The updater already recorded these alerts.
+			for _, row := range grid.Rows {
+				row.AlertInfo = row.FailureStreak
+			}
+		} else {
+			alerts.AlertRows(grid.Columns, grid.Rows, failuresOpen, passesClose, ignored...)
+		}
+	}
+	minRuns := opts.GetMinRunsToAlert()
+	if minRuns <= 0 {
+		return
+	}
+	for _, row := range grid.Rows {
+		if row.AlertInfo != nil && rowRuns(row) < minRuns {
+			row.AlertInfo = nil
+		}
+	}
+}
+
+// hasFailureStreaks returns true when the updater recorded the failure streaks of the rows.
+//
+// Grids written before the updater recorded streaks have none.
+func hasFailureStreaks(rows []*statepb.Row) bool {
+	for _, row := range rows {
+		if row.FailureStreak != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// rowRuns returns the number of results in the run-length encoded row.
+func rowRuns(row *statepb.Row) int32 {
+	var runs int32
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		if statuspb.TestStatus(row.Results[i]) != statuspb.TestStatus_NO_RESULT {
+			runs += row.Results[i+1]
+		}
+	}
+	return runs
+}
+
 // buildFailLink creates a search link
 // TODO(#134): Build proper url for both internal and external jobs
 func buildFailLink(testID, target string) string {
@@ -591,13 +1018,44 @@ func gridMetrics(cols int, rows []*statepb.Row, recent int, brokenThreshold floa
 	return passingCols, completedCols, passingCells, filledCells, brokenState
 }
 
+// defaultHealthColumns is the number of recent columns of the health of tabs without recent_health_columns.
+const defaultHealthColumns = 50
+
+// recentHealth returns how many of the first window columns of the grid passed.
+//
+// Returns nil when none of these columns have results.
+func recentHealth(grid *statepb.Grid, window int) *summarypb.RecentHealth {
+	passing, completed, _, _, _ := gridMetrics(len(grid.Columns), grid.Rows, window, 0)
+	if completed == 0 {
+		return nil
+	}
+	return &summarypb.RecentHealth{
+		Window:           int32(window),
+		CompletedColumns: int32(completed),
+		PassingColumns:   int32(passing),
+		PassPercent:      100 * float32(passing) / float32(completed),
+	}
+}
+
+// ignoredCells returns the number of passing cells the updater omitted from the recent columns.
+func ignoredCells(cols []*statepb.Column, recent int) int {
+	var n int
+	for i, col := range cols {
+		if i >= recent {
+			break
+		}
+		n += int(col.IgnoredPasses + col.IgnoredSkips)
+	}
+	return n
+}
+
 func fmtStatus(passCols, cols, passCells, cells int) string {
 	colCent := 100 * float64(passCols) / float64(cols)
 	cellCent := 100 * float64(passCells) / float64(cells)
 	return fmt.Sprintf("%d of %d (%.1f%%) recent columns passed (%d of %d or %.1f%% cells)", passCols, cols, colCent, passCells, cells, cellCent)
 }
 
-//  2483 of 115784 tests (2.1%) and 163 of 164 runs (99.4%) failed in the past 7 days
+// 2483 of 115784 tests (2.1%) and 163 of 164 runs (99.4%) failed in the past 7 days
 func statusMessage(passingCols, completedCols, passingCells, filledCells int) string {
 	if filledCells == 0 {
 		return noRuns
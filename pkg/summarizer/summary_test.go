@@ -35,6 +35,7 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/testing/protocmp"
 
@@ -43,6 +44,8 @@ import (
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 )
 
 type fakeGroup struct {
@@ -67,8 +70,10 @@ func TestUpdateDashboard(t *testing.T) {
 				Name: "stale-dashboard",
 				DashboardTab: []*configpb.DashboardTab{
 					{
-						Name:          "stale-tab",
-						TestGroupName: "foo-group",
+						Name:             "stale-tab",
+						TestGroupName:    "foo-group",
+						Description:      "foo tests",
+						DocumentationUrl: "https://docs/foo",
 						AlertOptions: &configpb.DashboardTabAlertOptions{
 							AlertStaleResultsHours: 1,
 						},
@@ -85,6 +90,8 @@ func TestUpdateDashboard(t *testing.T) {
 					{
 						DashboardName:       "stale-dashboard",
 						DashboardTabName:    "stale-tab",
+						Description:         "foo tests",
+						DocumentationUrl:    "https://docs/foo",
 						LastUpdateTimestamp: 1000,
 						Alert:               noRuns,
 						OverallStatus:       summarypb.DashboardTabSummary_STALE,
@@ -180,7 +187,7 @@ func TestUpdateDashboard(t *testing.T) {
 				}
 				return &fake.group, reader, nil
 			}
-			actual, err := updateDashboard(context.Background(), tc.dash, finder)
+			actual, _, err := updateDashboard(context.Background(), tc.dash, finder, nil)
 			if err != nil && !tc.err {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -194,6 +201,44 @@ func TestUpdateDashboard(t *testing.T) {
 	}
 }
 
+func TestSummarizeGrid(t *testing.T) {
+	group := &configpb.TestGroup{Name: "group"}
+	dash := &configpb.Dashboard{
+		Name: "dash",
+		DashboardTab: []*configpb.DashboardTab{
+			{Name: "tab", TestGroupName: "group"},
+			{Name: "other", TestGroupName: "other-group"},
+		},
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "1", Started: float64(time.Now().Unix() * 1000)},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:    "good",
+				Id:      "good",
+				Results: []int32{int32(statuspb.TestStatus_PASS), 1},
+			},
+		},
+	}
+	sum, err := SummarizeGrid(context.Background(), dash, group, grid)
+	if err == nil {
+		t.Error("SummarizeGrid() failed to return an error for the tab of another group")
+	}
+	want := map[string]summarypb.DashboardTabSummary_TabStatus{
+		"tab":   summarypb.DashboardTabSummary_PASS,
+		"other": summarypb.DashboardTabSummary_NOT_SET,
+	}
+	got := map[string]summarypb.DashboardTabSummary_TabStatus{}
+	for _, tab := range sum.GetTabSummaries() {
+		got[tab.DashboardTabName] = tab.OverallStatus
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SummarizeGrid() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestStaleHours(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -300,6 +345,46 @@ func TestUpdateTab(t *testing.T) {
 				LatestGreen:         noGreens,
 				OverallStatus:       summarypb.DashboardTabSummary_STALE,
 				Status:              noRuns,
+				GridGeneration:      43,
+			},
+		},
+		{
+			name: "counts recent columns and cells",
+			tab: &configpb.DashboardTab{
+				Name:          "foo-tab",
+				TestGroupName: "foo-group",
+				AlertOptions: &configpb.DashboardTabAlertOptions{
+					AlertStaleResultsHours: 1,
+				},
+			},
+			group: &configpb.TestGroup{},
+			grid: statepb.Grid{
+				Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{int32(statuspb.TestStatus_PASS), 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+					{Name: "b", Results: []int32{int32(statuspb.TestStatus_PASS), 1, int32(statuspb.TestStatus_FAIL), 1}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+				},
+			},
+			mod: now,
+			gen: 44,
+			expected: &summarypb.DashboardTabSummary{
+				DashboardTabName:    "foo-tab",
+				LastUpdateTimestamp: float64(now.Unix()),
+				Alert:               noRuns,
+				LatestGreen:         "2",
+				OverallStatus:       summarypb.DashboardTabSummary_STALE,
+				Status:              fmtStatus(1, 2, 3, 4),
+				GridGeneration:      44,
+				CompletedColumns:    2,
+				PassingColumns:      1,
+				FilledCells:         4,
+				PassingCells:        3,
+				RecentHealth: &summarypb.RecentHealth{
+					Window:           50,
+					CompletedColumns: 2,
+					PassingColumns:   1,
+					PassPercent:      50,
+				},
 			},
 		},
 		{
@@ -340,7 +425,7 @@ func TestUpdateTab(t *testing.T) {
 			if tc.tab == nil {
 				tc.tab = &configpb.DashboardTab{}
 			}
-			actual, err := updateTab(context.Background(), tc.tab, finder)
+			actual, _, err := updateTab(context.Background(), tc.tab, finder, nil)
 			switch {
 			case err != nil:
 				if !tc.err {
@@ -355,6 +440,345 @@ func TestUpdateTab(t *testing.T) {
 	}
 }
 
+func TestUpdateTabInfraFailures(t *testing.T) {
+	cols := []*statepb.Column{
+		{Build: "3", Started: 3000},
+		{Build: "2", Started: 2000},
+		{Build: "1", Started: 1000},
+	}
+	cases := []struct {
+		name     string
+		tab      *configpb.DashboardTab
+		rows     []*statepb.Row
+		expected []*summarypb.FailingTestSummary
+		builds   []string
+	}{
+		{
+			name: "alerts on failures before the infra column keep their properties",
+			tab: &configpb.DashboardTab{
+				InfraFailureThreshold: 0.6,
+			},
+			rows: []*statepb.Row{
+				{
+					Name:     "a",
+					Id:       "a",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 2, int32(statuspb.TestStatus_PASS), 1},
+					CellIds:  []string{"a3", "a2", "a1"},
+					Messages: []string{"infra", "broke", ""},
+					Icons:    []string{"", "", ""},
+					Properties: []*statepb.Property{
+						{Property: map[string]string{"log": "gs://bucket/a/3"}},
+						{Property: map[string]string{"log": "gs://bucket/a/2"}},
+						{},
+					},
+				},
+				{
+					Name:     "b",
+					Id:       "b",
+					Results:  []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_PASS), 2},
+					CellIds:  []string{"b3", "b2", "b1"},
+					Messages: []string{"infra", "", ""},
+					Icons:    []string{"", "", ""},
+				},
+			},
+			expected: []*summarypb.FailingTestSummary{
+				{
+					DisplayName:        "a",
+					TestName:           "a",
+					FailBuildId:        "2",
+					FailCount:          1,
+					FailureMessage:     "broke",
+					PassBuildId:        "1",
+					FailTestLink:       "a2 a",
+					LatestFailTestLink: " a",
+					Properties:         map[string]string{"log": "gs://bucket/a/2"},
+					FailTimestamp:      2000,
+					PassTimestamp:      1000,
+				},
+			},
+			builds: []string{"3"},
+		},
+		{
+			name: "alerts ignoring columns with the infra property keep their properties and links",
+			tab: &configpb.DashboardTab{
+				InfraFailureProperty: "infra",
+			},
+			rows: []*statepb.Row{
+				{
+					Name: "a",
+					Id:   "a",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 1,
+						int32(statuspb.TestStatus_NO_RESULT), 1,
+						int32(statuspb.TestStatus_FAIL), 1,
+					},
+					CellIds:  []string{"a3", "", "a1"},
+					Messages: []string{"cluster down", "broke"},
+					Icons:    []string{"", ""},
+					Properties: []*statepb.Property{
+						{Property: map[string]string{"infra": "true", "log": "gs://bucket/a/3"}},
+						{Property: map[string]string{"log": "gs://bucket/a/1"}},
+					},
+				},
+				{
+					Name:     "b",
+					Id:       "b",
+					Results:  []int32{int32(statuspb.TestStatus_PASS), 3},
+					CellIds:  []string{"b3", "b2", "b1"},
+					Messages: []string{"", "", ""},
+					Icons:    []string{"", "", ""},
+				},
+			},
+			expected: []*summarypb.FailingTestSummary{
+				{
+					DisplayName:        "a",
+					TestName:           "a",
+					FailBuildId:        "1",
+					FailCount:          1,
+					FailureMessage:     "broke",
+					FailTestLink:       "a1 a",
+					LatestFailTestLink: " a",
+					Properties:         map[string]string{"log": "gs://bucket/a/1"},
+					FailTimestamp:      1000,
+				},
+			},
+			builds: []string{"3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.tab.Name = "tab"
+			tc.tab.TestGroupName = "group"
+			grid := &statepb.Grid{Columns: cols, Rows: tc.rows}
+			finder := func(string) (*configpb.TestGroup, gridReader, error) {
+				reader := func(context.Context) (io.ReadCloser, time.Time, int64, error) {
+					return ioutil.NopCloser(bytes.NewBuffer(compress(gridBuf(grid)))), time.Now(), 1, nil
+				}
+				return &configpb.TestGroup{NumFailuresToAlert: 1}, reader, nil
+			}
+			actual, _, err := updateTab(context.Background(), tc.tab, finder, nil)
+			if err != nil {
+				t.Fatalf("updateTab() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual.FailingTestSummaries, protocmp.Transform()); diff != "" {
+				t.Errorf("updateTab() got unexpected failing test diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.builds, actual.InfraFailureBuilds); diff != "" {
+				t.Errorf("updateTab() got unexpected infra builds diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecentHealth(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	cases := []struct {
+		name   string
+		rows   []*statepb.Row
+		window int
+		want   *summarypb.RecentHealth
+	}{
+		{
+			name:   "no results",
+			rows:   []*statepb.Row{{Name: "a", Results: []int32{0, 4}}},
+			window: 3,
+		},
+		{
+			name: "basically works",
+			rows: []*statepb.Row{
+				{Name: "a", Results: []int32{pass, 4}},
+				{Name: "b", Results: []int32{fail, 1, pass, 3}},
+			},
+			window: 10,
+			want: &summarypb.RecentHealth{
+				Window:           10,
+				CompletedColumns: 4,
+				PassingColumns:   3,
+				PassPercent:      75,
+			},
+		},
+		{
+			name: "only the window",
+			rows: []*statepb.Row{
+				{Name: "a", Results: []int32{pass, 2, fail, 2}},
+			},
+			window: 2,
+			want: &summarypb.RecentHealth{
+				Window:           2,
+				CompletedColumns: 2,
+				PassingColumns:   2,
+				PassPercent:      100,
+			},
+		},
+		{
+			name: "skip empty columns",
+			rows: []*statepb.Row{
+				{Name: "a", Results: []int32{0, 1, fail, 1, pass, 2}},
+			},
+			window: 4,
+			want: &summarypb.RecentHealth{
+				Window:           4,
+				CompletedColumns: 3,
+				PassingColumns:   2,
+				PassPercent:      200.0 / 3,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &statepb.Grid{
+				Columns: make([]*statepb.Column, 4),
+				Rows:    tc.rows,
+			}
+			got := recentHealth(grid, tc.window)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("recentHealth() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateTabReuse(t *testing.T) {
+	tab := &configpb.DashboardTab{Name: "tab", TestGroupName: "group"}
+	group := &configpb.TestGroup{Name: "group"}
+	fingerprint, err := configFingerprint(tab, group)
+	if err != nil {
+		t.Fatalf("configFingerprint() got unexpected error: %v", err)
+	}
+	const gen = 7
+
+	cases := []struct {
+		name     string
+		previous *summarypb.DashboardTabSummary
+		reused   bool
+	}{
+		{
+			name: "summarize new tabs",
+		},
+		{
+			name: "reuse unchanged tabs",
+			previous: &summarypb.DashboardTabSummary{
+				DashboardTabName:  "tab",
+				Status:            "cached",
+				GridGeneration:    gen,
+				ConfigFingerprint: fingerprint,
+			},
+			reused: true,
+		},
+		{
+			name: "summarize changed grids",
+			previous: &summarypb.DashboardTabSummary{
+				DashboardTabName:  "tab",
+				Status:            "cached",
+				GridGeneration:    gen - 1,
+				ConfigFingerprint: fingerprint,
+			},
+		},
+		{
+			name: "summarize changed configs",
+			previous: &summarypb.DashboardTabSummary{
+				DashboardTabName:  "tab",
+				Status:            "cached",
+				GridGeneration:    gen,
+				ConfigFingerprint: "old",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			finder := func(name string) (*configpb.TestGroup, gridReader, error) {
+				reader := func(_ context.Context) (io.ReadCloser, time.Time, int64, error) {
+					return ioutil.NopCloser(bytes.NewBuffer(compress(gridBuf(&statepb.Grid{})))), time.Unix(1000, 0), gen, nil
+				}
+				return group, reader, nil
+			}
+			actual, _, err := updateTab(context.Background(), tab, finder, tc.previous)
+			if err != nil {
+				t.Fatalf("updateTab() got unexpected error: %v", err)
+			}
+			if reused := actual.Status == "cached"; reused != tc.reused {
+				t.Errorf("updateTab() reused previous summary: %t, want %t", reused, tc.reused)
+			}
+			if actual.GridGeneration != gen || actual.ConfigFingerprint != fingerprint {
+				t.Errorf("updateTab() got generation %d and fingerprint %q, want %d and %q", actual.GridGeneration, actual.ConfigFingerprint, gen, fingerprint)
+			}
+		})
+	}
+}
+
+func TestConfigFingerprint(t *testing.T) {
+	group := &configpb.TestGroup{Name: "group"}
+	base, err := configFingerprint(&configpb.DashboardTab{Name: "tab"}, group)
+	if err != nil {
+		t.Fatalf("configFingerprint() got unexpected error: %v", err)
+	}
+	if base == "" {
+		t.Fatal("configFingerprint() got empty fingerprint")
+	}
+
+	cases := []struct {
+		name  string
+		tab   *configpb.DashboardTab
+		group *configpb.TestGroup
+		same  bool
+		empty bool
+	}{
+		{
+			name:  "stable",
+			tab:   &configpb.DashboardTab{Name: "tab"},
+			group: &configpb.TestGroup{Name: "group"},
+			same:  true,
+		},
+		{
+			name:  "tab changes",
+			tab:   &configpb.DashboardTab{Name: "tab", BaseOptions: "include-filter-by-regex=foo"},
+			group: group,
+		},
+		{
+			name:  "group changes",
+			tab:   &configpb.DashboardTab{Name: "tab"},
+			group: &configpb.TestGroup{Name: "group", DaysOfResults: 3},
+		},
+		{
+			name: "stale alerts depend on time",
+			tab: &configpb.DashboardTab{
+				Name:         "tab",
+				AlertOptions: &configpb.DashboardTabAlertOptions{AlertStaleResultsHours: 1},
+			},
+			group: group,
+			empty: true,
+		},
+		{
+			name: "healthiness depends on time",
+			tab: &configpb.DashboardTab{
+				Name:                  "tab",
+				HealthAnalysisOptions: &configpb.HealthAnalysisOptions{Enable: true},
+			},
+			group: group,
+			empty: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := configFingerprint(tc.tab, tc.group)
+			switch {
+			case err != nil:
+				t.Fatalf("configFingerprint() got unexpected error: %v", err)
+			case tc.empty:
+				if actual != "" {
+					t.Errorf("configFingerprint() got %q, want empty", actual)
+				}
+			case tc.same != (actual == base):
+				t.Errorf("configFingerprint() got %q, base %q, want same: %t", actual, base, tc.same)
+			}
+		})
+	}
+}
+
 func TestReadGrid(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -600,8 +1024,8 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "everything works",
 			baseOptions: url.Values{
-				includeFilter: []string{"foo"},
-				excludeFilter: []string{"bar"},
+				tabulator.IncludeFilter: []string{"foo"},
+				tabulator.ExcludeFilter: []string{"bar"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -631,7 +1055,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "must match all includes",
 			baseOptions: url.Values{
-				includeFilter: []string{"foo", "spam"},
+				tabulator.IncludeFilter: []string{"foo", "spam"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -659,7 +1083,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "exclude any exclusions",
 			baseOptions: url.Values{
-				excludeFilter: []string{"not", "nope"},
+				tabulator.ExcludeFilter: []string{"not", "nope"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -687,7 +1111,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "exclude all test methods",
 			baseOptions: url.Values{
-				includeFilter: []string{"test"},
+				tabulator.IncludeFilter: []string{"test"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -725,14 +1149,14 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "bad inclusion regexp errors",
 			baseOptions: url.Values{
-				includeFilter: []string{"this.("},
+				tabulator.IncludeFilter: []string{"this.("},
 			}.Encode(),
 			err: true,
 		},
 		{
 			name: "bad exclude regexp errors",
 			baseOptions: url.Values{
-				excludeFilter: []string{"this.("},
+				tabulator.ExcludeFilter: []string{"this.("},
 			}.Encode(),
 			err: true,
 		},
@@ -852,116 +1276,6 @@ func TestRecentRows(t *testing.T) {
 	}
 }
 
-func TestIncludeRows(t *testing.T) {
-	cases := []struct {
-		name     string
-		names    []string
-		include  string
-		expected []string
-		err      bool
-	}{
-		{
-			name: "basically works",
-		},
-		{
-			name:    "bad regex errors",
-			include: "^[a-z",
-			err:     true,
-		},
-		{
-			name:    "return nothing rows when nothing matches",
-			names:   []string{"hello", "world"},
-			include: "dog",
-		},
-		{
-			name:     "include only matching rows",
-			include:  "fun",
-			names:    []string{"apply", "function", "to", "funny", "bone"},
-			expected: []string{"function", "funny"},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			var rows []*statepb.Row
-			for _, n := range tc.names {
-				rows = append(rows, &statepb.Row{Name: n})
-			}
-			actualRows, err := includeRows(rows, tc.include)
-			var actual []string
-			for _, r := range actualRows {
-				actual = append(actual, r.Name)
-			}
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("failed to return expected error")
-			case !reflect.DeepEqual(actual, tc.expected):
-				t.Errorf("actual %s != expected %s", actual, tc.expected)
-			}
-		})
-	}
-}
-
-func TestExcludeRows(t *testing.T) {
-	cases := []struct {
-		name     string
-		names    []string
-		exclude  string
-		expected []string
-		err      bool
-	}{
-		{
-			name: "basically works",
-		},
-		{
-			name:    "bad regex errors",
-			exclude: "^[a-z",
-			err:     true,
-		},
-		{
-			name:     "return all rows when nothing matches",
-			names:    []string{"hello", "world"},
-			exclude:  "dog",
-			expected: []string{"hello", "world"},
-		},
-		{
-			name:     "drop matching rows",
-			exclude:  "fun",
-			names:    []string{"apply", "function", "to", "funny", "bone"},
-			expected: []string{"apply", "to", "bone"},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			var rows []*statepb.Row
-			for _, n := range tc.names {
-				rows = append(rows, &statepb.Row{Name: n})
-			}
-			actualRows, err := excludeRows(rows, tc.exclude)
-			var actual []string
-			for _, r := range actualRows {
-				actual = append(actual, r.Name)
-			}
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("failed to return expected error")
-			case !reflect.DeepEqual(actual, tc.expected):
-				t.Errorf("actual %s != expected %s", actual, tc.expected)
-			}
-		})
-	}
-
-}
-
 func TestLatestRun(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -1178,6 +1492,157 @@ func TestFailingTestSummaries(t *testing.T) {
 	}
 }
 
+type fakeSigner struct {
+	err error
+}
+
+func (fs fakeSigner) Sign(path gcs.Path) (string, error) {
+	if fs.err != nil {
+		return "", fs.err
+	}
+	return "https://signed/" + path.Bucket() + "/" + path.Object(), nil
+}
+
+func TestSignArtifacts(t *testing.T) {
+	cases := []struct {
+		name     string
+		signer   fakeSigner
+		cfg      *configpb.Configuration
+		dash     *configpb.Dashboard
+		sum      *summarypb.DashboardSummary
+		expected *summarypb.DashboardSummary
+	}{
+		{
+			name:     "basically works",
+			cfg:      &configpb.Configuration{},
+			dash:     &configpb.Dashboard{},
+			sum:      &summarypb.DashboardSummary{},
+			expected: &summarypb.DashboardSummary{},
+		},
+		{
+			name: "sign linked artifacts",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:      "some-group",
+						GcsPrefix: "bucket/logs/job,other/logs/job",
+						ArtifactLinks: []*configpb.TestGroup_ArtifactLink{
+							{Artifact: "artifacts/junit*.xml", Path: "stdout.txt", Property: "stdout"},
+						},
+					},
+				},
+			},
+			dash: &configpb.Dashboard{
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "some-tab",
+						TestGroupName: "some-group",
+					},
+				},
+			},
+			sum: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{
+						DashboardTabName: "some-tab",
+						FailingTestSummaries: []*summarypb.FailingTestSummary{
+							{
+								DisplayName: "configured",
+								Properties: map[string]string{
+									"stdout": "https://storage.cloud.google.com/other/logs/job/123/artifacts/stdout.txt",
+									"log":    "https://storage.cloud.google.com/other/logs/job/123/build-log.txt",
+								},
+							},
+							{
+								DisplayName: "log",
+								Properties:  map[string]string{"log": "gs://bucket/logs/job/456/build-log.txt"},
+							},
+							{
+								DisplayName: "not-gcs",
+								Properties:  map[string]string{"log": "https://ci.example.com/job/789/log"},
+							},
+							{
+								DisplayName:       "no-link",
+								LatestFailBuildId: "123",
+							},
+						},
+					},
+				},
+			},
+			expected: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{
+						DashboardTabName: "some-tab",
+						FailingTestSummaries: []*summarypb.FailingTestSummary{
+							{
+								DisplayName: "configured",
+								Properties: map[string]string{
+									"stdout": "https://storage.cloud.google.com/other/logs/job/123/artifacts/stdout.txt",
+									"log":    "https://storage.cloud.google.com/other/logs/job/123/build-log.txt",
+								},
+								LatestFailArtifactUrl: "https://signed/other/logs/job/123/artifacts/stdout.txt",
+							},
+							{
+								DisplayName:           "log",
+								Properties:            map[string]string{"log": "gs://bucket/logs/job/456/build-log.txt"},
+								LatestFailArtifactUrl: "https://signed/bucket/logs/job/456/build-log.txt",
+							},
+							{
+								DisplayName: "not-gcs",
+								Properties:  map[string]string{"log": "https://ci.example.com/job/789/log"},
+							},
+							{
+								DisplayName:       "no-link",
+								LatestFailBuildId: "123",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "signing errors leave links empty",
+			signer: fakeSigner{err: errors.New("bad")},
+			cfg:    &configpb.Configuration{},
+			dash:   &configpb.Dashboard{},
+			sum: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{
+						DashboardTabName: "some-tab",
+						FailingTestSummaries: []*summarypb.FailingTestSummary{
+							{
+								DisplayName: "foo",
+								Properties:  map[string]string{"log": "gs://bucket/logs/job/123/build-log.txt"},
+							},
+						},
+					},
+				},
+			},
+			expected: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{
+						DashboardTabName: "some-tab",
+						FailingTestSummaries: []*summarypb.FailingTestSummary{
+							{
+								DisplayName: "foo",
+								Properties:  map[string]string{"log": "gs://bucket/logs/job/123/build-log.txt"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signArtifacts(logrus.New(), tc.dash, tc.sum, tc.cfg, tc.signer)
+			if diff := cmp.Diff(tc.expected, tc.sum, protocmp.Transform()); diff != "" {
+				t.Errorf("signArtifacts() (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestOverallStatus(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -1759,6 +2224,37 @@ func TestStatusMessage(t *testing.T) {
 	}
 }
 
+func TestIgnoredCells(t *testing.T) {
+	cases := []struct {
+		name     string
+		cols     []*statepb.Column
+		recent   int
+		expected int
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "count recent columns",
+			cols: []*statepb.Column{
+				{IgnoredPasses: 3, IgnoredSkips: 1},
+				{IgnoredPasses: 2},
+				{IgnoredPasses: 100},
+			},
+			recent:   2,
+			expected: 6,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := ignoredCells(tc.cols, tc.recent); actual != tc.expected {
+				t.Errorf("ignoredCells() got %d, want %d", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestLatestGreen(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -2220,3 +2716,200 @@ func TestResultIter(t *testing.T) {
 		})
 	}
 }
+
+func TestKeepAlertingData(t *testing.T) {
+	sent := &summarypb.AlertingData{LastEmailTime: &timestamp.Timestamp{Seconds: 5}}
+	newer := &summarypb.AlertingData{LastEmailTime: &timestamp.Timestamp{Seconds: 7}}
+	cases := []struct {
+		name     string
+		previous *summarypb.DashboardSummary
+		current  *summarypb.DashboardSummary
+		expected *summarypb.DashboardSummary
+	}{
+		{
+			name:     "basically works",
+			current:  &summarypb.DashboardSummary{},
+			expected: &summarypb.DashboardSummary{},
+		},
+		{
+			name: "copy previous data",
+			previous: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{DashboardTabName: "foo", AlertingData: sent},
+					{DashboardTabName: "bar", AlertingData: sent},
+					{DashboardTabName: "gone", AlertingData: sent},
+				},
+			},
+			current: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{DashboardTabName: "foo"},
+					{DashboardTabName: "bar", AlertingData: newer},
+					{DashboardTabName: "new"},
+				},
+			},
+			expected: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{DashboardTabName: "foo", AlertingData: sent},
+					{DashboardTabName: "bar", AlertingData: newer},
+					{DashboardTabName: "new"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			keepAlertingData(tc.previous, tc.current)
+			if diff := cmp.Diff(tc.expected, tc.current, protocmp.Transform()); diff != "" {
+				t.Errorf("keepAlertingData() (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestOverrideAlerts(t *testing.T) {
+	columns := []*statepb.Column{
+		{Build: "4", Started: 4},
+		{Build: "3", Started: 3},
+		{Build: "2", Started: 2},
+		{Build: "1", Started: 1},
+	}
+	rows := func() []*statepb.Row {
+		return []*statepb.Row{
+			{
+				Name: "sustained",
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				Messages: []string{"", "", "", ""},
+				CellIds:  []string{"", "", "", ""},
+			},
+			{
+				Name: "new",
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_NO_RESULT), 2,
+				},
+				Messages: []string{"", ""},
+				CellIds:  []string{"", "", "", ""},
+				AlertInfo: &statepb.AlertInfo{
+					FailCount: 2,
+				},
+			},
+			{
+				Name: "timeout",
+				Results: []int32{
+					int32(statuspb.TestStatus_TIMED_OUT), 2,
+					int32(statuspb.TestStatus_PASS), 2,
+				},
+				Messages: []string{"", "", "", ""},
+				CellIds:  []string{"", "", "", ""},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		tab      *configpb.DashboardTab
+		group    *configpb.TestGroup
+		force    bool
+		streaks  map[string]int32
+		expected map[string]int32
+	}{
+		{
+			name:     "keep group alerts without overrides",
+			tab:      &configpb.DashboardTab{},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			expected: map[string]int32{"new": 2},
+		},
+		{
+			name: "override failures to alert",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3},
+			},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			expected: map[string]int32{"sustained": 3},
+		},
+		{
+			name: "override passes to disable alert",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{NumPassesToDisableAlert: 2},
+			},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			expected: map[string]int32{"sustained": 3, "new": 2, "timeout": 2},
+		},
+		{
+			name: "require minimum runs",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{MinRunsToAlert: 3},
+			},
+			group: &configpb.TestGroup{NumFailuresToAlert: 2},
+		},
+		{
+			name:     "force recomputing group alerts",
+			tab:      &configpb.DashboardTab{},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 3},
+			force:    true,
+			expected: map[string]int32{"sustained": 3},
+		},
+		{
+			name:     "timeouts count as failures",
+			tab:      &configpb.DashboardTab{},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			force:    true,
+			expected: map[string]int32{"sustained": 3, "new": 2, "timeout": 2},
+		},
+		{
+			name: "ignore timeouts",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{IgnoreTimedOut: true},
+			},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			expected: map[string]int32{"sustained": 3, "new": 2},
+		},
+		{
+			name: "use failure streaks for single failure alerts",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 1},
+			},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			streaks:  map[string]int32{"sustained": 5},
+			expected: map[string]int32{"sustained": 5},
+		},
+		{
+			name: "ignore failure streaks when ignoring timeouts",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 1, IgnoreTimedOut: true},
+			},
+			group:    &configpb.TestGroup{NumFailuresToAlert: 2},
+			streaks:  map[string]int32{"sustained": 5},
+			expected: map[string]int32{"sustained": 3, "new": 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &statepb.Grid{Columns: columns, Rows: rows()}
+			for _, row := range grid.Rows {
+				if n, ok := tc.streaks[row.Name]; ok {
+					row.FailureStreak = &statepb.AlertInfo{FailCount: n}
+				}
+			}
+			overrideAlerts(grid, tc.tab, tc.group, tc.force)
+			var actual map[string]int32
+			for _, row := range grid.Rows {
+				if row.AlertInfo == nil {
+					continue
+				}
+				if actual == nil {
+					actual = map[string]int32{}
+				}
+				actual[row.Name] = row.AlertInfo.FailCount
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("overrideAlerts() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
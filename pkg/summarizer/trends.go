@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+const (
+	defaultTrendShortDays     = 7
+	defaultTrendLongDays      = 30
+	defaultDegradingThreshold = 5
+)
+
+// CalculateHealthTrend compares the pass rate of the grid over a short and long window ending at now.
+func CalculateHealthTrend(grid *statepb.Grid, now time.Time, opts *configpb.HealthAnalysisOptions) *summarypb.HealthTrend {
+	trend := summarypb.HealthTrend{
+		ShortDays: opts.GetTrendShortDays(),
+		LongDays:  opts.GetTrendLongDays(),
+	}
+	if trend.ShortDays <= 0 {
+		trend.ShortDays = defaultTrendShortDays
+	}
+	if trend.LongDays <= 0 {
+		trend.LongDays = defaultTrendLongDays
+	}
+	threshold := opts.GetDegradingThreshold()
+	if threshold <= 0 {
+		threshold = defaultDegradingThreshold
+	}
+
+	end := goBackDays(0, now)
+	short, shortOK := passRate(grid, goBackDays(int(trend.ShortDays), now), end)
+	long, longOK := passRate(grid, goBackDays(int(trend.LongDays), now), end)
+	trend.ShortPassRate = short
+	trend.LongPassRate = long
+	if !shortOK || !longOK {
+		return &trend
+	}
+	trend.Delta = short - long
+	switch {
+	case trend.Delta <= -threshold:
+		trend.Direction = summarypb.HealthTrend_DEGRADING
+	case trend.Delta >= threshold:
+		trend.Direction = summarypb.HealthTrend_IMPROVING
+	default:
+		trend.Direction = summarypb.HealthTrend_STABLE
+	}
+	return &trend
+}
+
+// passRate returns the percentage of passing or flaky results between start and end, ignoring infra failures.
+//
+// Returns false when there are no such results.
+func passRate(grid *statepb.Grid, start, end int) (float32, bool) {
+	metrics, _ := parseGrid(grid, start, end)
+	var passed, total int
+	for _, m := range metrics {
+		passed += m.Passed + m.FlakyCount
+		total += m.Passed + m.FlakyCount + m.Failed
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * float32(passed) / float32(total), true
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestCalculateHealthTrend(t *testing.T) {
+	const day = 24 * 60 * 60
+	now := time.Unix(100*day, 0)
+	// Columns from 1 and 20 days ago.
+	grid := func(recent, old statuspb.TestStatus) *statepb.Grid {
+		return &statepb.Grid{
+			Columns: []*statepb.Column{
+				{Started: (99*day + 1) * 1000},
+				{Started: (99*day + 1) * 1000},
+				{Started: 80 * day * 1000},
+				{Started: 80 * day * 1000},
+			},
+			Rows: []*statepb.Row{
+				{
+					Name: "test",
+					Results: []int32{
+						int32(recent), 1,
+						int32(statuspb.TestStatus_PASS), 1,
+						int32(old), 2,
+					},
+					Messages: []string{"", "", "", ""},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		opts     *configpb.HealthAnalysisOptions
+		expected *summarypb.HealthTrend
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+			expected: &summarypb.HealthTrend{
+				ShortDays: 7,
+				LongDays:  30,
+			},
+		},
+		{
+			name: "degrading",
+			grid: grid(statuspb.TestStatus_FAIL, statuspb.TestStatus_PASS),
+			expected: &summarypb.HealthTrend{
+				ShortDays:     7,
+				LongDays:      30,
+				ShortPassRate: 50,
+				LongPassRate:  75,
+				Delta:         -25,
+				Direction:     summarypb.HealthTrend_DEGRADING,
+			},
+		},
+		{
+			name: "improving",
+			grid: grid(statuspb.TestStatus_PASS, statuspb.TestStatus_FAIL),
+			expected: &summarypb.HealthTrend{
+				ShortDays:     7,
+				LongDays:      30,
+				ShortPassRate: 100,
+				LongPassRate:  50,
+				Delta:         50,
+				Direction:     summarypb.HealthTrend_IMPROVING,
+			},
+		},
+		{
+			name: "stable within threshold",
+			grid: grid(statuspb.TestStatus_FAIL, statuspb.TestStatus_PASS),
+			opts: &configpb.HealthAnalysisOptions{DegradingThreshold: 30},
+			expected: &summarypb.HealthTrend{
+				ShortDays:     7,
+				LongDays:      30,
+				ShortPassRate: 50,
+				LongPassRate:  75,
+				Delta:         -25,
+				Direction:     summarypb.HealthTrend_STABLE,
+			},
+		},
+		{
+			name: "configure windows",
+			grid: grid(statuspb.TestStatus_FAIL, statuspb.TestStatus_PASS),
+			opts: &configpb.HealthAnalysisOptions{TrendShortDays: 2, TrendLongDays: 10},
+			expected: &summarypb.HealthTrend{
+				ShortDays:     2,
+				LongDays:      10,
+				ShortPassRate: 50,
+				LongPassRate:  50,
+				Direction:     summarypb.HealthTrend_STABLE,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := CalculateHealthTrend(tc.grid, now, tc.opts)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("CalculateHealthTrend() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
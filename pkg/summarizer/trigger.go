@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/trigger"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// triggerInterval is how often Update checks for triggered dashboards.
+const triggerInterval = 30 * time.Second
+
+// triggeredDashboards returns the dashboards of the config with pending triggers, oldest first.
+func triggeredDashboards(ctx context.Context, client trigger.Client, configPath gcs.Path, summaryPathPrefix, triggerPrefix string, cfg *configpb.Configuration) ([]*configpb.Dashboard, error) {
+	targets := make(map[string]gcs.Path, len(cfg.Dashboards))
+	dashboards := make(map[string]*configpb.Dashboard, len(cfg.Dashboards))
+	for _, dash := range cfg.Dashboards {
+		summaryPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(summaryPathPrefix, SummaryPath(dash.Name))})
+		if err != nil {
+			return nil, fmt.Errorf("%s bad summary path: %w", dash.Name, err)
+		}
+		targets[dash.Name] = *summaryPath
+		dashboards[dash.Name] = dash
+	}
+	pending, err := trigger.Check(ctx, client, configPath, triggerPrefix, trigger.Summary, targets)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*configpb.Dashboard, 0, len(pending))
+	for _, p := range pending {
+		out = append(out, dashboards[p.Name])
+	}
+	return out, nil
+}
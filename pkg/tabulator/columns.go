@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// GroupByHeader is the base_options key naming the column header to group columns by.
+//
+// Matches the label or configuration_value of a column_header of the test group.
+const GroupByHeader = "group-by-header"
+
+// headerIndex returns the index of the named header in the extra values of each column.
+func headerIndex(headers []*configpb.TestGroup_ColumnHeader, name string) (int, error) {
+	for i, h := range headers {
+		if h.Label == name || h.ConfigurationValue == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s=%s: no such column_header", GroupByHeader, name)
+}
+
+// cell is a single result of a row.
+type cell struct {
+	result   statuspb.TestStatus
+	id       string
+	message  string
+	icon     string
+	property string
+	metrics  map[string]float64
+
+	properties map[string]string
+}
+
+// groupColumns collapses adjacent columns sharing the value of the header into one column.
+//
+// The grouped column is the first of the group, with the worst result of
+// each row along with the id, message, icon and metrics of that result.
+// Columns without a value for the header are never grouped.
+func groupColumns(grid *statepb.Grid, header int) {
+	var starts []int
+	for i, col := range grid.Columns {
+		if i == 0 || !sameHeader(grid.Columns[i-1], col, header) {
+			starts = append(starts, i)
+		}
+	}
+	if len(starts) == len(grid.Columns) {
+		return
+	}
+	columns := make([]*statepb.Column, 0, len(starts))
+	for _, s := range starts {
+		columns = append(columns, grid.Columns[s])
+	}
+	for i, row := range grid.Rows {
+		cells := inflateRow(row, len(grid.Columns))
+		out := &statepb.Row{
+			Name:          row.Name,
+			Id:            row.Id,
+			BugId:         row.BugId,
+			AlertInfo:     row.AlertInfo,
+			FailureStreak: row.FailureStreak,
+			Metric:        row.Metric,
+		}
+		for _, m := range row.Metrics {
+			out.Metrics = append(out.Metrics, &statepb.Metric{Name: m.Name})
+		}
+		for g, s := range starts {
+			end := len(cells)
+			if g+1 < len(starts) {
+				end = starts[g+1]
+			}
+			worst := cells[s]
+			for _, c := range cells[s+1 : end] {
+				if result.Worse(c.result, worst.result) {
+					worst = c
+				}
+			}
+			appendCell(out, worst)
+		}
+		if len(row.CellIds) == 0 {
+			out.CellIds = nil
+		}
+		if len(row.UserProperty) == 0 {
+			out.UserProperty = nil
+		}
+		if len(row.Properties) == 0 {
+			out.Properties = nil
+		}
+		metrics := out.Metrics[:0]
+		for _, m := range out.Metrics {
+			if len(m.Values) > 0 {
+				metrics = append(metrics, m)
+			}
+		}
+		out.Metrics = metrics
+		grid.Rows[i] = out
+	}
+	grid.Columns = columns
+	grid.Cluster = nil
+}
+
+// sameHeader returns true when both columns have the same, non-empty value for the header.
+func sameHeader(a, b *statepb.Column, header int) bool {
+	if header >= len(a.Extra) || header >= len(b.Extra) {
+		return false
+	}
+	return a.Extra[header] != "" && a.Extra[header] == b.Extra[header]
+}
+
+// inflateRow decodes the row into a cell for each of the n columns.
+func inflateRow(row *statepb.Row, n int) []cell {
+	cells := make([]cell, n)
+	var col, filled int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		status, count := statuspb.TestStatus(row.Results[i]), int(row.Results[i+1])
+		for ; count > 0 && col < n; count-- {
+			c := &cells[col]
+			c.result = status
+			if col < len(row.CellIds) {
+				c.id = row.CellIds[col]
+			}
+			if col < len(row.UserProperty) {
+				c.property = row.UserProperty[col]
+			}
+			if status != statuspb.TestStatus_NO_RESULT {
+				// Messages and icons skip empty cells.
+				if filled < len(row.Messages) {
+					c.message = row.Messages[filled]
+				}
+				if filled < len(row.Icons) {
+					c.icon = row.Icons[filled]
+				}
+				if filled < len(row.Properties) {
+					c.properties = row.Properties[filled].GetProperty()
+				}
+				filled++
+			}
+			col++
+		}
+	}
+	for _, m := range row.Metrics {
+		var v int
+		for i := 0; i+1 < len(m.Indices); i += 2 {
+			first, count := int(m.Indices[i]), int(m.Indices[i+1])
+			for j := first; j < first+count && v < len(m.Values); j++ {
+				if j < n {
+					if cells[j].metrics == nil {
+						cells[j].metrics = map[string]float64{}
+					}
+					cells[j].metrics[m.Name] = m.Values[v]
+				}
+				v++
+			}
+		}
+	}
+	return cells
+}
+
+// appendCell adds the cell to the end of the row, which has every metric of the cells.
+func appendCell(row *statepb.Row, c cell) {
+	col := int32(len(row.CellIds))
+	if n := len(row.Results); n > 0 && row.Results[n-2] == int32(c.result) {
+		row.Results[n-1]++
+	} else {
+		row.Results = append(row.Results, int32(c.result), 1)
+	}
+	row.CellIds = append(row.CellIds, c.id)
+	row.UserProperty = append(row.UserProperty, c.property)
+	if c.result == statuspb.TestStatus_NO_RESULT {
+		return
+	}
+	row.Messages = append(row.Messages, c.message)
+	row.Icons = append(row.Icons, c.icon)
+	row.Properties = append(row.Properties, &statepb.Property{Property: c.properties})
+	for _, metric := range row.Metrics {
+		v, ok := c.metrics[metric.Name]
+		if !ok {
+			continue
+		}
+		if l := len(metric.Indices); l > 0 && metric.Indices[l-2]+metric.Indices[l-1] == col {
+			metric.Indices[l-1]++
+		} else {
+			metric.Indices = append(metric.Indices, col, 1)
+		}
+		metric.Values = append(metric.Values, v)
+	}
+}
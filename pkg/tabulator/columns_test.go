@@ -0,0 +1,295 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestHeaderIndex(t *testing.T) {
+	headers := []*configpb.TestGroup_ColumnHeader{
+		{ConfigurationValue: "Commit"},
+		{Label: "Branch", ConfigurationValue: "branch"},
+	}
+	cases := []struct {
+		name     string
+		header   string
+		expected int
+		err      bool
+	}{
+		{
+			name:   "configuration value",
+			header: "Commit",
+		},
+		{
+			name:     "label",
+			header:   "Branch",
+			expected: 1,
+		},
+		{
+			name:     "configuration value with a label",
+			header:   "branch",
+			expected: 1,
+		},
+		{
+			name:   "missing",
+			header: "env",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := headerIndex(headers, tc.header)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("headerIndex() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Fatal("headerIndex() failed to return an error")
+			case got != tc.expected:
+				t.Errorf("headerIndex() got %d, want %d", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGroupColumns(t *testing.T) {
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		header   int
+		expected *statepb.Grid
+	}{
+		{
+			name: "nothing to group",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Extra: []string{"a", "main"}},
+					{Build: "2", Extra: []string{"b", "dev"}},
+				},
+				Rows: []*statepb.Row{
+					{Name: "foo", Results: []int32{pass, 2}, CellIds: []string{"3", "2"}},
+				},
+			},
+			header: 1,
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Extra: []string{"a", "main"}},
+					{Build: "2", Extra: []string{"b", "dev"}},
+				},
+				Rows: []*statepb.Row{
+					{Name: "foo", Results: []int32{pass, 2}, CellIds: []string{"3", "2"}},
+				},
+			},
+		},
+		{
+			name: "keep the worst result",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4", Extra: []string{"a", "main"}},
+					{Build: "3", Extra: []string{"b", "main"}},
+					{Build: "2", Extra: []string{"c", "dev"}},
+					{Build: "1", Extra: []string{"d", "dev"}},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:     "foo",
+						Results:  []int32{pass, 1, fail, 1, empty, 1, pass, 1},
+						CellIds:  []string{"4", "3", "2", "1"},
+						Messages: []string{"", "boom", ""},
+						Icons:    []string{"", "F", ""},
+						AlertInfo: &statepb.AlertInfo{
+							FailCount: 1,
+						},
+						FailureStreak: &statepb.AlertInfo{
+							FailCount: 1,
+						},
+					},
+				},
+			},
+			header: 1,
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4", Extra: []string{"a", "main"}},
+					{Build: "2", Extra: []string{"c", "dev"}},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:     "foo",
+						Results:  []int32{fail, 1, pass, 1},
+						CellIds:  []string{"3", "1"},
+						Messages: []string{"boom", ""},
+						Icons:    []string{"F", ""},
+						AlertInfo: &statepb.AlertInfo{
+							FailCount: 1,
+						},
+						FailureStreak: &statepb.AlertInfo{
+							FailCount: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "keep the properties of the worst result",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4", Extra: []string{"main"}},
+					{Build: "3", Extra: []string{"main"}},
+					{Build: "2", Extra: []string{"dev"}},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:     "foo",
+						Results:  []int32{pass, 1, fail, 1, pass, 1},
+						CellIds:  []string{"4", "3", "2"},
+						Messages: []string{"", "boom", ""},
+						Icons:    []string{"", "F", ""},
+						Properties: []*statepb.Property{
+							{},
+							{Property: map[string]string{"log": "3"}},
+						},
+					},
+				},
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4", Extra: []string{"main"}},
+					{Build: "2", Extra: []string{"dev"}},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:     "foo",
+						Results:  []int32{fail, 1, pass, 1},
+						CellIds:  []string{"3", "2"},
+						Messages: []string{"boom", ""},
+						Icons:    []string{"F", ""},
+						Properties: []*statepb.Property{
+							{Property: map[string]string{"log": "3"}},
+							{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "only group adjacent columns",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Extra: []string{"main"}},
+					{Build: "2", Extra: []string{"dev"}},
+					{Build: "1", Extra: []string{"main"}},
+				},
+				Rows: []*statepb.Row{
+					{Name: "foo", Results: []int32{pass, 3}},
+				},
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Extra: []string{"main"}},
+					{Build: "2", Extra: []string{"dev"}},
+					{Build: "1", Extra: []string{"main"}},
+				},
+				Rows: []*statepb.Row{
+					{Name: "foo", Results: []int32{pass, 3}},
+				},
+			},
+		},
+		{
+			name: "never group columns without a value",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Extra: []string{""}},
+					{Build: "2", Extra: []string{""}},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					{Name: "foo", Results: []int32{pass, 3}},
+				},
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Extra: []string{""}},
+					{Build: "2", Extra: []string{""}},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					{Name: "foo", Results: []int32{pass, 3}},
+				},
+			},
+		},
+		{
+			name: "metrics and properties",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4", Extra: []string{"main"}},
+					{Build: "3", Extra: []string{"main"}},
+					{Build: "2", Extra: []string{"dev"}},
+					{Build: "1", Extra: []string{"dev"}},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:         "foo",
+						Results:      []int32{pass, 1, fail, 1, pass, 2},
+						UserProperty: []string{"p4", "p3", "p2", "p1"},
+						Metric:       []string{"elapsed", "memory"},
+						Metrics: []*statepb.Metric{
+							{Name: "elapsed", Indices: []int32{0, 4}, Values: []float64{4, 3, 2, 1}},
+							{Name: "memory", Indices: []int32{0, 1}, Values: []float64{64}},
+						},
+					},
+				},
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4", Extra: []string{"main"}},
+					{Build: "2", Extra: []string{"dev"}},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:         "foo",
+						Results:      []int32{fail, 1, pass, 1},
+						UserProperty: []string{"p3", "p2"},
+						Messages:     []string{"", ""},
+						Icons:        []string{"", ""},
+						Metric:       []string{"elapsed", "memory"},
+						Metrics: []*statepb.Metric{
+							{Name: "elapsed", Indices: []int32{0, 2}, Values: []float64{3, 2}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			groupColumns(tc.grid, tc.header)
+			if diff := cmp.Diff(tc.expected, tc.grid, protocmp.Transform()); diff != "" {
+				t.Errorf("groupColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// Filters the base_options of a tab may set, repeating each to require every regexp.
+const (
+	IncludeFilter = "include-filter-by-regex"
+	ExcludeFilter = "exclude-filter-by-regex"
+)
+
+// FilterRows returns the rows matching every include filter and no exclude filter of the base options.
+func FilterRows(baseOptions string, rows []*statepb.Row) ([]*statepb.Row, error) {
+	vals, err := url.ParseQuery(baseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %v", baseOptions, err)
+	}
+	return filterRows(vals, rows)
+}
+
+func filterRows(vals url.Values, rows []*statepb.Row) ([]*statepb.Row, error) {
+	var err error
+	for _, include := range vals[IncludeFilter] {
+		if rows, err = includeRows(rows, include); err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", IncludeFilter, include, err)
+		}
+	}
+
+	for _, exclude := range vals[ExcludeFilter] {
+		if rows, err = excludeRows(rows, exclude); err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", ExcludeFilter, exclude, err)
+		}
+	}
+	return rows, nil
+}
+
+// includeRows returns the subset of rows that match the regex
+func includeRows(in []*statepb.Row, include string) ([]*statepb.Row, error) {
+	re, err := regexp.Compile(include)
+	if err != nil {
+		return nil, err
+	}
+	var rows []*statepb.Row
+	for _, r := range in {
+		if !re.MatchString(r.Name) {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// excludeRows returns the subset of rows that do not match the regex
+func excludeRows(in []*statepb.Row, exclude string) ([]*statepb.Row, error) {
+	re, err := regexp.Compile(exclude)
+	if err != nil {
+		return nil, err
+	}
+	var rows []*statepb.Row
+	for _, r := range in {
+		if re.MatchString(r.Name) {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
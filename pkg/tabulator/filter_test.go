@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestFilterRows(t *testing.T) {
+	cases := []struct {
+		name        string
+		baseOptions string
+		names       []string
+		expected    []string
+		err         bool
+	}{
+		{
+			name:     "no filters",
+			names:    []string{"hello", "world"},
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:        "bad options",
+			baseOptions: "%z",
+			err:         true,
+		},
+		{
+			name: "include and exclude",
+			baseOptions: url.Values{
+				IncludeFilter: []string{"foo", "o$"},
+				ExcludeFilter: []string{"bar"},
+			}.Encode(),
+			names:    []string{"foo", "food", "barfoo", "foodfoo"},
+			expected: []string{"foo", "foodfoo"},
+		},
+		{
+			name:        "bad include",
+			baseOptions: url.Values{IncludeFilter: []string{"("}}.Encode(),
+			err:         true,
+		},
+		{
+			name:        "bad exclude",
+			baseOptions: url.Values{ExcludeFilter: []string{"("}}.Encode(),
+			err:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rows []*statepb.Row
+			for _, n := range tc.names {
+				rows = append(rows, &statepb.Row{Name: n})
+			}
+			actualRows, err := FilterRows(tc.baseOptions, rows)
+			var actual []string
+			for _, r := range actualRows {
+				actual = append(actual, r.Name)
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("failed to return expected error")
+			case !reflect.DeepEqual(actual, tc.expected):
+				t.Errorf("actual %s != expected %s", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIncludeRows(t *testing.T) {
+	cases := []struct {
+		name     string
+		names    []string
+		include  string
+		expected []string
+		err      bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:    "bad regex errors",
+			include: "^[a-z",
+			err:     true,
+		},
+		{
+			name:    "return nothing rows when nothing matches",
+			names:   []string{"hello", "world"},
+			include: "dog",
+		},
+		{
+			name:     "include only matching rows",
+			include:  "fun",
+			names:    []string{"apply", "function", "to", "funny", "bone"},
+			expected: []string{"function", "funny"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rows []*statepb.Row
+			for _, n := range tc.names {
+				rows = append(rows, &statepb.Row{Name: n})
+			}
+			actualRows, err := includeRows(rows, tc.include)
+			var actual []string
+			for _, r := range actualRows {
+				actual = append(actual, r.Name)
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("failed to return expected error")
+			case !reflect.DeepEqual(actual, tc.expected):
+				t.Errorf("actual %s != expected %s", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestExcludeRows(t *testing.T) {
+	cases := []struct {
+		name     string
+		names    []string
+		exclude  string
+		expected []string
+		err      bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:    "bad regex errors",
+			exclude: "^[a-z",
+			err:     true,
+		},
+		{
+			name:     "return all rows when nothing matches",
+			names:    []string{"hello", "world"},
+			exclude:  "dog",
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:     "drop matching rows",
+			exclude:  "fun",
+			names:    []string{"apply", "function", "to", "funny", "bone"},
+			expected: []string{"apply", "to", "bone"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rows []*statepb.Row
+			for _, n := range tc.names {
+				rows = append(rows, &statepb.Row{Name: n})
+			}
+			actualRows, err := excludeRows(rows, tc.exclude)
+			var actual []string
+			for _, r := range actualRows {
+				actual = append(actual, r.Name)
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("failed to return expected error")
+			case !reflect.DeepEqual(actual, tc.expected):
+				t.Errorf("actual %s != expected %s", actual, tc.expected)
+			}
+		})
+	}
+
+}
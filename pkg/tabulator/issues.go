@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// GitHubAPI is the public GitHub API endpoint.
+const GitHubAPI = "https://api.github.com"
+
+// maxIssueResults limits how many issues to associate with each test.
+const maxIssueResults = 10
+
+// An IssueSearcher finds the issues of the configured tracker which mention a test.
+type IssueSearcher interface {
+	// SearchIssues returns the IDs of the issues mentioning the test.
+	SearchIssues(ctx context.Context, opts *configpb.IssueTrackerOptions, test string) ([]string, error)
+}
+
+// IssueTrackers searches GitHub or Jira, whichever the options of the tab set.
+type IssueTrackers struct {
+	GitHub GitHubIssues
+	Jira   JiraIssues
+}
+
+// SearchIssues searches the tracker the options configure.
+func (it IssueTrackers) SearchIssues(ctx context.Context, opts *configpb.IssueTrackerOptions, test string) ([]string, error) {
+	switch {
+	case opts.GetGithubRepo() != "":
+		return it.GitHub.SearchIssues(ctx, opts, test)
+	case opts.GetJiraProject() != "":
+		return it.Jira.SearchIssues(ctx, opts, test)
+	}
+	return nil, nil
+}
+
+// GitHubIssues finds the issues of a repository whose title or body mention the test.
+//
+// Identifies issues as owner/repo#number.
+type GitHubIssues struct {
+	Token string
+	// URL defaults to GitHubAPI.
+	URL    string
+	Client *http.Client
+}
+
+// SearchIssues returns the open issues of the github_repo mentioning the test, or all issues with include_closed.
+func (gh GitHubIssues) SearchIssues(ctx context.Context, opts *configpb.IssueTrackerOptions, test string) ([]string, error) {
+	query := fmt.Sprintf("repo:%s is:issue in:title,body %q", opts.GithubRepo, test)
+	if !opts.IncludeClosed {
+		query += " is:open"
+	}
+	base := gh.URL
+	if base == "" {
+		base = GitHubAPI
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/search/issues?per_page=%d&q=%s", strings.TrimSuffix(base, "/"), maxIssueResults, url.QueryEscape(query)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if gh.Token != "" {
+		req.Header.Set("Authorization", "token "+gh.Token)
+	}
+	var found struct {
+		Items []struct {
+			Number int64 `json:"number"`
+		} `json:"items"`
+	}
+	if err := doJSON(ctx, gh.Client, req, &found); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, item := range found.Items {
+		ids = append(ids, fmt.Sprintf("%s#%d", opts.GithubRepo, item.Number))
+	}
+	return ids, nil
+}
+
+// JiraIssues finds the issues of a project whose text mentions the test.
+//
+// Identifies issues by their key, such as PROJ-123.
+type JiraIssues struct {
+	// User and Token authenticate requests if set.
+	User   string
+	Token  string
+	Client *http.Client
+}
+
+// SearchIssues returns the unresolved issues of the jira_project mentioning the test, or all issues with include_closed.
+func (j JiraIssues) SearchIssues(ctx context.Context, opts *configpb.IssueTrackerOptions, test string) ([]string, error) {
+	// Search for the name as a phrase.
+	phrase := strings.NewReplacer(`"`, " ", `\`, " ").Replace(test)
+	jql := fmt.Sprintf(`project = "%s" AND text ~ "\"%s\""`, opts.JiraProject, phrase)
+	if !opts.IncludeClosed {
+		jql += " AND statusCategory != Done"
+	}
+	vals := url.Values{
+		"jql":        []string{jql},
+		"fields":     []string{"key"},
+		"maxResults": []string{fmt.Sprint(maxIssueResults)},
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(opts.JiraUrl, "/")+"/rest/api/2/search?"+vals.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if j.User != "" || j.Token != "" {
+		req.SetBasicAuth(j.User, j.Token)
+	}
+	var found struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := doJSON(ctx, j.Client, req, &found); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, issue := range found.Issues {
+		ids = append(ids, issue.Key)
+	}
+	return ids, nil
+}
+
+// doJSON sends the request, decoding the response into out.
+func doJSON(ctx context.Context, client *http.Client, req *http.Request, out interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// associateIssues adds the issues mentioning each failing row to its bug_id.
+//
+// Searches for every row with a failing result, returning an error listing
+// the searches that failed along with associating the others.
+func associateIssues(ctx context.Context, searcher IssueSearcher, opts *configpb.IssueTrackerOptions, grid *statepb.Grid) error {
+	var mErr error
+	for _, row := range grid.Rows {
+		if failures, _ := counts(row); failures == 0 {
+			continue
+		}
+		ids, err := searcher.SearchIssues(ctx, opts, row.Name)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s: %w", row.Name, err))
+			continue
+		}
+		have := map[string]bool{}
+		for _, id := range row.BugId {
+			have[id] = true
+		}
+		for _, id := range ids {
+			if !have[id] {
+				have[id] = true
+				row.BugId = append(row.BugId, id)
+			}
+		}
+	}
+	return mErr
+}
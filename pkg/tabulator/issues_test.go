@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// fakeSearcher returns the issues of each test, failing for tests named "error".
+type fakeSearcher map[string][]string
+
+func (fs fakeSearcher) SearchIssues(_ context.Context, _ *configpb.IssueTrackerOptions, test string) ([]string, error) {
+	if test == "error" {
+		return nil, errors.New("injected error")
+	}
+	return fs[test], nil
+}
+
+func TestGitHubSearchIssues(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     *configpb.IssueTrackerOptions
+		status   int
+		query    string
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "open issues",
+			opts:     &configpb.IssueTrackerOptions{GithubRepo: "o/r"},
+			status:   http.StatusOK,
+			query:    `repo:o/r is:issue in:title,body "foo" is:open`,
+			expected: []string{"o/r#1", "o/r#2"},
+		},
+		{
+			name:     "include closed",
+			opts:     &configpb.IssueTrackerOptions{GithubRepo: "o/r", IncludeClosed: true},
+			status:   http.StatusOK,
+			query:    `repo:o/r is:issue in:title,body "foo"`,
+			expected: []string{"o/r#1", "o/r#2"},
+		},
+		{
+			name:   "error",
+			opts:   &configpb.IssueTrackerOptions{GithubRepo: "o/r"},
+			status: http.StatusForbidden,
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var query, auth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query, auth = r.URL.Query().Get("q"), r.Header.Get("Authorization")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"items": [{"number": 1}, {"number": 2}]}`))
+			}))
+			defer srv.Close()
+			gh := GitHubIssues{Token: "secret", URL: srv.URL}
+			got, err := gh.SearchIssues(context.Background(), tc.opts, "foo")
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("SearchIssues() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("SearchIssues() failed to return an error")
+			}
+			if query != tc.query {
+				t.Errorf("SearchIssues() searched %q, want %q", query, tc.query)
+			}
+			if auth != "token secret" {
+				t.Errorf("SearchIssues() sent authorization %q", auth)
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("SearchIssues() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJiraSearchIssues(t *testing.T) {
+	cases := []struct {
+		name     string
+		test     string
+		closed   bool
+		status   int
+		jql      string
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "unresolved issues",
+			test:     "foo",
+			status:   http.StatusOK,
+			jql:      `project = "PROJ" AND text ~ "\"foo\"" AND statusCategory != Done`,
+			expected: []string{"PROJ-1", "PROJ-2"},
+		},
+		{
+			name:     "include closed",
+			test:     "foo",
+			closed:   true,
+			status:   http.StatusOK,
+			jql:      `project = "PROJ" AND text ~ "\"foo\""`,
+			expected: []string{"PROJ-1", "PROJ-2"},
+		},
+		{
+			name:     "drop quotes",
+			test:     `foo "bar"`,
+			closed:   true,
+			status:   http.StatusOK,
+			jql:      `project = "PROJ" AND text ~ "\"foo  bar \""`,
+			expected: []string{"PROJ-1", "PROJ-2"},
+		},
+		{
+			name:   "error",
+			test:   "foo",
+			status: http.StatusInternalServerError,
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var jql, user, pass string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				jql = r.URL.Query().Get("jql")
+				user, pass, _ = r.BasicAuth()
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"issues": [{"key": "PROJ-1"}, {"key": "PROJ-2"}]}`))
+			}))
+			defer srv.Close()
+			opts := &configpb.IssueTrackerOptions{JiraProject: "PROJ", JiraUrl: srv.URL, IncludeClosed: tc.closed}
+			j := JiraIssues{User: "me", Token: "secret"}
+			got, err := j.SearchIssues(context.Background(), opts, tc.test)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("SearchIssues() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("SearchIssues() failed to return an error")
+			}
+			if jql != tc.jql {
+				t.Errorf("SearchIssues() searched %q, want %q", jql, tc.jql)
+			}
+			if user != "me" || pass != "secret" {
+				t.Errorf("SearchIssues() authenticated as %q:%q", user, pass)
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("SearchIssues() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAssociateIssues(t *testing.T) {
+	searcher := fakeSearcher{
+		"failing": {"o/r#1", "o/r#2"},
+		"passing": {"o/r#3"},
+	}
+	cases := []struct {
+		name     string
+		rows     []*statepb.Row
+		expected []*statepb.Row
+		err      bool
+	}{
+		{
+			name: "only failing rows",
+			rows: []*statepb.Row{
+				{Name: "failing", Results: []int32{pass, 1, fail, 1}},
+				{Name: "passing", Results: []int32{pass, 2}},
+				{Name: "unknown", Results: []int32{fail, 1}},
+			},
+			expected: []*statepb.Row{
+				{Name: "failing", Results: []int32{pass, 1, fail, 1}, BugId: []string{"o/r#1", "o/r#2"}},
+				{Name: "passing", Results: []int32{pass, 2}},
+				{Name: "unknown", Results: []int32{fail, 1}},
+			},
+		},
+		{
+			name: "keep existing bugs",
+			rows: []*statepb.Row{
+				{Name: "failing", Results: []int32{fail, 1}, BugId: []string{"123", "o/r#2"}},
+			},
+			expected: []*statepb.Row{
+				{Name: "failing", Results: []int32{fail, 1}, BugId: []string{"123", "o/r#2", "o/r#1"}},
+			},
+		},
+		{
+			name: "continue after errors",
+			rows: []*statepb.Row{
+				{Name: "error", Results: []int32{fail, 1}},
+				{Name: "failing", Results: []int32{fail, 1}},
+			},
+			expected: []*statepb.Row{
+				{Name: "error", Results: []int32{fail, 1}},
+				{Name: "failing", Results: []int32{fail, 1}, BugId: []string{"o/r#1", "o/r#2"}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &statepb.Grid{Rows: tc.rows}
+			err := associateIssues(context.Background(), searcher, &configpb.IssueTrackerOptions{GithubRepo: "o/r"}, grid)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("associateIssues() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("associateIssues() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, grid.Rows, protocmp.Transform()); diff != "" {
+				t.Errorf("associateIssues() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
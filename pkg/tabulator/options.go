@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// Options of the base_options of a tab, which are set without a value.
+const (
+	ExcludeNonFailed = "exclude-non-failed-tests"
+	ExcludeSkipped   = "exclude-skipped-tests"
+	SortByName       = "sort-by-name"
+	SortByFailures   = "sort-by-failures"
+	SortByFlakiness  = "sort-by-flakiness"
+)
+
+// Width is the base_options key setting the width of each cell in pixels.
+const Width = "width"
+
+// applyOptions filters and sorts the rows of the grid, groups its columns and sets its cell width, as the base options configure.
+func applyOptions(vals url.Values, headers []*configpb.TestGroup_ColumnHeader, grid *statepb.Grid) error {
+	rows, err := filterRows(vals, grid.Rows)
+	if err != nil {
+		return err
+	}
+	if name := vals.Get(GroupByHeader); name != "" {
+		idx, err := headerIndex(headers, name)
+		if err != nil {
+			return err
+		}
+		grid.Rows = rows
+		groupColumns(grid, idx)
+		rows = grid.Rows
+	}
+	if _, ok := vals[ExcludeNonFailed]; ok {
+		rows = failedRows(rows)
+	}
+	if has(vals, ExcludeSkipped) {
+		rows = unskippedRows(rows)
+	}
+	switch {
+	case has(vals, SortByName):
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].Name < rows[j].Name
+		})
+	case has(vals, SortByFailures):
+		sortRows(rows, func(r *statepb.Row) int {
+			failures, _ := counts(r)
+			return failures
+		})
+	case has(vals, SortByFlakiness):
+		sortRows(rows, func(r *statepb.Row) int {
+			_, flips := counts(r)
+			return flips
+		})
+	}
+	grid.Rows = rows
+	if w := vals.Get(Width); w != "" {
+		n, err := strconv.Atoi(w)
+		if err != nil || n < 0 {
+			return fmt.Errorf("bad %s=%s: must be a non-negative integer", Width, w)
+		}
+		grid.CellWidth = int32(n)
+	}
+	return nil
+}
+
+// has returns true when the options set the key, even without a value.
+func has(vals url.Values, key string) bool {
+	_, ok := vals[key]
+	return ok
+}
+
+// failedRows returns the rows with at least one failing result.
+func failedRows(in []*statepb.Row) []*statepb.Row {
+	var rows []*statepb.Row
+	for _, r := range in {
+		if failures, _ := counts(r); failures > 0 {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// unskippedRows returns the rows with at least one result other than a skip.
+func unskippedRows(in []*statepb.Row) []*statepb.Row {
+	var rows []*statepb.Row
+	for _, r := range in {
+		for i := 0; i < len(r.Results); i += 2 {
+			switch statuspb.TestStatus(r.Results[i]) {
+			case statuspb.TestStatus_NO_RESULT, statuspb.TestStatus_PASS_WITH_SKIPS:
+				continue
+			}
+			rows = append(rows, r)
+			break
+		}
+	}
+	return rows
+}
+
+// sortRows sorts the rows with the highest scores first, otherwise keeping their order.
+func sortRows(rows []*statepb.Row, score func(*statepb.Row) int) {
+	scores := make(map[*statepb.Row]int, len(rows))
+	for _, r := range rows {
+		scores[r] = score(r)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return scores[rows[i]] > scores[rows[j]]
+	})
+}
+
+// counts returns the number of failing results of the row, and how often it flips between passing and failing.
+//
+// Each flaky result counts as a flip.
+func counts(row *statepb.Row) (int, int) {
+	var failures, flips int
+	var last statuspb.TestStatus
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		status, n := statuspb.TestStatus(row.Results[i]), int(row.Results[i+1])
+		if result.IsFailingResult(status) {
+			failures += n
+		}
+		switch status = result.Coalesce(status, result.IgnoreRunning); status {
+		case statuspb.TestStatus_NO_RESULT:
+			continue
+		case statuspb.TestStatus_FLAKY:
+			flips += n
+		default:
+			if last != statuspb.TestStatus_NO_RESULT && last != statuspb.TestStatus_FLAKY && last != status {
+				flips++
+			}
+		}
+		last = status
+	}
+	return failures, flips
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+const (
+	pass  = int32(statuspb.TestStatus_PASS)
+	fail  = int32(statuspb.TestStatus_FAIL)
+	flaky = int32(statuspb.TestStatus_FLAKY)
+	skip  = int32(statuspb.TestStatus_PASS_WITH_SKIPS)
+	empty = int32(statuspb.TestStatus_NO_RESULT)
+)
+
+func TestApplyOptions(t *testing.T) {
+	rows := func() []*statepb.Row {
+		return []*statepb.Row{
+			{Name: "c-flaky", Results: []int32{pass, 1, fail, 1, pass, 1, fail, 1}},
+			{Name: "a-passing", Results: []int32{pass, 4}},
+			{Name: "b-failing", Results: []int32{fail, 3, pass, 1}},
+			{Name: "d-skipped", Results: []int32{skip, 2, empty, 1, skip, 1}},
+		}
+	}
+	cases := []struct {
+		name     string
+		options  url.Values
+		expected []string
+		width    int32
+		err      bool
+	}{
+		{
+			name:     "no options",
+			expected: []string{"c-flaky", "a-passing", "b-failing", "d-skipped"},
+		},
+		{
+			name:     "exclude non-failed",
+			options:  url.Values{ExcludeNonFailed: []string{""}},
+			expected: []string{"c-flaky", "b-failing"},
+		},
+		{
+			name:     "exclude skipped",
+			options:  url.Values{ExcludeSkipped: []string{""}},
+			expected: []string{"c-flaky", "a-passing", "b-failing"},
+		},
+		{
+			name:     "sort by name",
+			options:  url.Values{SortByName: []string{""}},
+			expected: []string{"a-passing", "b-failing", "c-flaky", "d-skipped"},
+		},
+		{
+			name:     "sort by failures",
+			options:  url.Values{SortByFailures: []string{""}},
+			expected: []string{"b-failing", "c-flaky", "a-passing", "d-skipped"},
+		},
+		{
+			name:     "sort by flakiness",
+			options:  url.Values{SortByFlakiness: []string{""}},
+			expected: []string{"c-flaky", "b-failing", "a-passing", "d-skipped"},
+		},
+		{
+			name:     "filter then sort",
+			options:  url.Values{SortByName: []string{""}, ExcludeFilter: []string{"^a"}},
+			expected: []string{"b-failing", "c-flaky", "d-skipped"},
+		},
+		{
+			name:     "width",
+			options:  url.Values{Width: []string{"10"}},
+			expected: []string{"c-flaky", "a-passing", "b-failing", "d-skipped"},
+			width:    10,
+		},
+		{
+			name:    "bad width",
+			options: url.Values{Width: []string{"wide"}},
+			err:     true,
+		},
+		{
+			name:    "negative width",
+			options: url.Values{Width: []string{"-1"}},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &statepb.Grid{Rows: rows()}
+			err := applyOptions(tc.options, nil, grid)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("applyOptions() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("applyOptions() failed to return an error")
+			}
+			var names []string
+			for _, r := range grid.Rows {
+				names = append(names, r.Name)
+			}
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("applyOptions() got unexpected row diff (-want +got):\n%s", diff)
+			}
+			if grid.CellWidth != tc.width {
+				t.Errorf("applyOptions() got width %d, want %d", grid.CellWidth, tc.width)
+			}
+		})
+	}
+}
+
+func TestCounts(t *testing.T) {
+	cases := []struct {
+		name     string
+		results  []int32
+		failures int
+		flips    int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "passing",
+			results: []int32{pass, 5},
+		},
+		{
+			name:     "failing",
+			results:  []int32{fail, 5},
+			failures: 5,
+		},
+		{
+			name:     "flip once",
+			results:  []int32{pass, 2, fail, 3},
+			failures: 3,
+			flips:    1,
+		},
+		{
+			name:     "ignore missing results",
+			results:  []int32{pass, 1, empty, 2, pass, 1, empty, 1, fail, 1},
+			failures: 1,
+			flips:    1,
+		},
+		{
+			name:    "flaky results",
+			results: []int32{pass, 1, flaky, 2, pass, 1},
+			flips:   2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failures, flips := counts(&statepb.Row{Results: tc.results})
+			if failures != tc.failures || flips != tc.flips {
+				t.Errorf("counts() got (%d, %d), want (%d, %d)", failures, flips, tc.failures, tc.flips)
+			}
+		})
+	}
+}
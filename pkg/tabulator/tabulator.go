@@ -0,0 +1,264 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tabulator computes the state of each dashboard tab from the state of its test group.
+package tabulator
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/label"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
+)
+
+// Client reads the state of test groups and writes the state of tabs.
+type Client interface {
+	gcs.Opener
+	gcs.Uploader
+}
+
+// TabStatePath returns the name of the tab's state object under the tab state path prefix.
+func TabStatePath(dashboard, tab string) string {
+	return path.Join(config.Normalize(dashboard), config.Normalize(tab))
+}
+
+// Tabulate returns the state of the tab from the grid of its test group.
+//
+// Applies the filters, column grouping, sorting and width of the tab's base_options to a copy of the grid.
+// Grouping columns requires the test group, to find its column headers.
+func Tabulate(grid *statepb.Grid, group *configpb.TestGroup, tab *configpb.DashboardTab) (*statepb.Grid, error) {
+	vals, err := url.ParseQuery(tab.BaseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("parse base_options %q: %w", tab.BaseOptions, err)
+	}
+	out := proto.Clone(grid).(*statepb.Grid)
+	if err := applyOptions(vals, group.GetColumnHeader(), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// groupJob is a test group to read along with the tabs to tabulate from its state.
+type groupJob struct {
+	name  string
+	group *configpb.TestGroup
+	tabs  []dashTab
+}
+
+type dashTab struct {
+	dashboard string
+	tab       *configpb.DashboardTab
+}
+
+// tabObjective tracks how reliably each dashboard tab is tabulated.
+var tabObjective = metrics.NewObjective("tabulator")
+
+// Update writes the state of each tab under tabsPathPrefix, from the state of its test group under gridPathPrefix.
+//
+// Both prefixes are relative to configPath. Reads concurrency groups at a time.
+// Setting dashboard limits the update to the tabs of this dashboard.
+// Associates the failing tests of tabs with an issue_tracker using issues if set.
+// Only writes when confirm is set.
+func Update(ctx context.Context, client Client, configPath gcs.Path, concurrency int, dashboard, gridPathPrefix, tabsPathPrefix string, issues IssueSearcher, confirm bool) error {
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive, got: %d", concurrency)
+	}
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("Failed to read config: %w", err)
+	}
+	defer tabObjective.Report()
+	jobs := groupJobs(cfg, dashboard)
+	logrus.WithField("groups", len(jobs)).Info("Tabulating test groups")
+
+	ch := make(chan groupJob)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var errs []string
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range ch {
+				failed := updateGroup(ctx, client, configPath, gridPathPrefix, tabsPathPrefix, job, issues, confirm)
+				if len(failed) == 0 {
+					continue
+				}
+				lock.Lock()
+				errs = append(errs, failed...)
+				lock.Unlock()
+			}
+		}()
+	}
+	for _, job := range jobs {
+		ch <- job
+	}
+	close(ch)
+	wg.Wait()
+	if n := len(errs); n > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("failed to update %d tabs: %v", n, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// groupJobs returns the test groups of the tabs of the dashboards, sorted by name.
+func groupJobs(cfg *configpb.Configuration, dashboard string) []groupJob {
+	tabs := map[string][]dashTab{}
+	for _, dash := range cfg.Dashboards {
+		if dashboard != "" && dashboard != dash.Name {
+			continue
+		}
+		for _, tab := range dash.DashboardTab {
+			tabs[tab.TestGroupName] = append(tabs[tab.TestGroupName], dashTab{dash.Name, tab})
+		}
+	}
+	jobs := make([]groupJob, 0, len(tabs))
+	for name, t := range tabs {
+		jobs = append(jobs, groupJob{name: name, group: config.FindTestGroup(name, cfg), tabs: t})
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].name < jobs[j].name
+	})
+	return jobs
+}
+
+// updateGroup tabulates each tab of the group, returning the dashboard/tab names of those it failed to update.
+func updateGroup(ctx context.Context, client Client, configPath gcs.Path, gridPathPrefix, tabsPathPrefix string, job groupJob, issues IssueSearcher, confirm bool) []string {
+	log := logrus.WithField("group", job.name)
+	var failed []string
+	fail := func(dt dashTab, err error) {
+		name := dt.dashboard + "/" + dt.tab.Name
+		failed = append(failed, name)
+		tabObjective.Record(name, err)
+	}
+	gridPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(gridPathPrefix, job.name)})
+	if err != nil {
+		log.WithError(err).Error("Cannot resolve grid path")
+		for _, dt := range job.tabs {
+			fail(dt, err)
+		}
+		return failed
+	}
+	grid, err := readGrid(ctx, client, *gridPath)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		log.Info("Skipping group without state")
+		return nil
+	}
+	if err != nil {
+		log.WithError(err).Error("Cannot read grid")
+		for _, dt := range job.tabs {
+			fail(dt, err)
+		}
+		return failed
+	}
+	for _, dt := range job.tabs {
+		log := log.WithField("dashboard", dt.dashboard).WithField("tab", dt.tab.Name)
+		ctx, span := tracing.Start(ctx, "tabulator.update_tab", label.String("dashboard", dt.dashboard), label.String("tab", dt.tab.Name))
+		err := updateTab(ctx, log, client, configPath, tabsPathPrefix, grid, job.group, dt, issues, confirm)
+		tracing.End(span, err)
+		if err != nil {
+			log.WithError(err).Error("Cannot update tab")
+			fail(dt, err)
+			continue
+		}
+		tabObjective.Record(dt.dashboard+"/"+dt.tab.Name, nil)
+	}
+	return failed
+}
+
+// updateTab tabulates the tab from the grid of its group, writing its state when confirm is set.
+func updateTab(ctx context.Context, log logrus.FieldLogger, client Client, configPath gcs.Path, tabsPathPrefix string, grid *statepb.Grid, group *configpb.TestGroup, dt dashTab, issues IssueSearcher, confirm bool) error {
+	tabGrid, err := Tabulate(grid, group, dt.tab)
+	if err != nil {
+		return fmt.Errorf("tabulate: %w", err)
+	}
+	if opts := dt.tab.IssueTracker; opts != nil && issues != nil {
+		if err := associateIssues(ctx, issues, opts, tabGrid); err != nil {
+			log.WithError(err).Warning("Cannot associate some issues")
+		}
+	}
+	log.WithField("rows", len(tabGrid.Rows)).Info("Tabulated")
+	if !confirm {
+		return nil
+	}
+	tabPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(tabsPathPrefix, TabStatePath(dt.dashboard, dt.tab.Name))})
+	if err != nil {
+		return fmt.Errorf("resolve tab state path: %w", err)
+	}
+	if err := writeGrid(ctx, client, *tabPath, tabGrid); err != nil {
+		return fmt.Errorf("write tab state: %w", err)
+	}
+	return nil
+}
+
+// readGrid downloads and decompresses the grid at path.
+func readGrid(ctx context.Context, opener gcs.Opener, path gcs.Path) (*statepb.Grid, error) {
+	r, err := opener.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open zlib: %w", err)
+	}
+	buf, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	var grid statepb.Grid
+	if err := proto.Unmarshal(buf, &grid); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return &grid, nil
+}
+
+// writeGrid compresses and uploads the grid to path.
+func writeGrid(ctx context.Context, client gcs.Uploader, path gcs.Path, grid *statepb.Grid) error {
+	buf, err := proto.Marshal(grid)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	if _, err := zw.Write(buf); err != nil {
+		return fmt.Errorf("compress: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	return client.Upload(ctx, path, zbuf.Bytes(), gcs.DefaultAcl, "no-cache")
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type fakeClient map[string][]byte
+
+func (fc fakeClient) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	buf, ok := fc[path.String()]
+	if !ok {
+		return nil, storage.ErrObjectNotExist
+	}
+	return ioutil.NopCloser(bytes.NewReader(buf)), nil
+}
+
+func (fc fakeClient) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, _ string) error {
+	fc[path.String()] = buf
+	return nil
+}
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("bad path %q: %v", s, err)
+	}
+	return *p
+}
+
+func TestTabStatePath(t *testing.T) {
+	cases := []struct {
+		dashboard string
+		tab       string
+		expected  string
+	}{
+		{
+			dashboard: "sig-testing",
+			tab:       "unit",
+			expected:  "sigtesting/unit",
+		},
+		{
+			dashboard: "Release 1.20 / Blocking",
+			tab:       "gce-cos-master-default",
+			expected:  "release120blocking/gcecosmasterdefault",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.dashboard+"/"+tc.tab, func(t *testing.T) {
+			if got := TabStatePath(tc.dashboard, tc.tab); got != tc.expected {
+				t.Errorf("TabStatePath(%q, %q) got %q, want %q", tc.dashboard, tc.tab, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{1, 1}},
+			{Name: "bar", Results: []int32{12, 1}},
+		},
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		tab      *configpb.DashboardTab
+		expected *statepb.Grid
+		err      bool
+	}{
+		{
+			name:     "no options",
+			tab:      &configpb.DashboardTab{},
+			expected: grid,
+		},
+		{
+			name: "filter",
+			tab:  &configpb.DashboardTab{BaseOptions: url.Values{ExcludeFilter: []string{"^b"}}.Encode()},
+			expected: &statepb.Grid{
+				Columns: grid.Columns,
+				Rows:    grid.Rows[:1],
+			},
+		},
+		{
+			name: "sort and width",
+			tab:  &configpb.DashboardTab{BaseOptions: "sort-by-name&width=20"},
+			expected: &statepb.Grid{
+				Columns:   grid.Columns,
+				Rows:      []*statepb.Row{grid.Rows[1], grid.Rows[0]},
+				CellWidth: 20,
+			},
+		},
+		{
+			name: "group columns",
+			group: &configpb.TestGroup{
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: "branch"}},
+			},
+			tab:      &configpb.DashboardTab{BaseOptions: "group-by-header=branch"},
+			expected: grid,
+		},
+		{
+			name: "group by missing header",
+			tab:  &configpb.DashboardTab{BaseOptions: "group-by-header=branch"},
+			err:  true,
+		},
+		{
+			name: "bad options",
+			tab:  &configpb.DashboardTab{BaseOptions: "width=%zz"},
+			err:  true,
+		},
+		{
+			name: "bad filter",
+			tab:  &configpb.DashboardTab{BaseOptions: url.Values{IncludeFilter: []string{"("}}.Encode()},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := proto.Clone(grid)
+			got, err := Tabulate(grid, tc.group, tc.tab)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("Tabulate() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Fatal("Tabulate() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Tabulate() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(orig, grid, protocmp.Transform()); diff != "" {
+				t.Errorf("Tabulate() modified the grid (-was +now):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{1, 1}},
+			{Name: "bar", Results: []int32{12, 1}},
+		},
+	}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}, {Name: "missing"}, {Name: "broken"}},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "all", TestGroupName: "group"},
+					{Name: "foo", TestGroupName: "group", BaseOptions: url.Values{IncludeFilter: []string{"foo"}}.Encode()},
+					{Name: "missing", TestGroupName: "missing"},
+					{Name: "issues", TestGroupName: "group", IssueTracker: &configpb.IssueTrackerOptions{GithubRepo: "o/r"}},
+				},
+			},
+			{
+				Name: "other",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "broken", TestGroupName: "broken"},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		name      string
+		dashboard string
+		issues    IssueSearcher
+		confirm   bool
+		expected  map[string]*statepb.Grid
+		err       bool
+	}{
+		{
+			name:    "dry run",
+			err:     true,
+			confirm: false,
+		},
+		{
+			name:    "write every tab",
+			confirm: true,
+			expected: map[string]*statepb.Grid{
+				"gs://bucket/tabs/dash/all":    grid,
+				"gs://bucket/tabs/dash/foo":    {Rows: grid.Rows[:1]},
+				"gs://bucket/tabs/dash/issues": grid,
+			},
+			err: true,
+		},
+		{
+			name:      "only one dashboard",
+			dashboard: "dash",
+			confirm:   true,
+			expected: map[string]*statepb.Grid{
+				"gs://bucket/tabs/dash/all":    grid,
+				"gs://bucket/tabs/dash/foo":    {Rows: grid.Rows[:1]},
+				"gs://bucket/tabs/dash/issues": grid,
+			},
+		},
+		{
+			name:      "associate issues",
+			dashboard: "dash",
+			issues:    fakeSearcher{"bar": {"o/r#1"}},
+			confirm:   true,
+			expected: map[string]*statepb.Grid{
+				"gs://bucket/tabs/dash/all": grid,
+				"gs://bucket/tabs/dash/foo": {Rows: grid.Rows[:1]},
+				"gs://bucket/tabs/dash/issues": {
+					Rows: []*statepb.Row{
+						grid.Rows[0],
+						{Name: "bar", Results: []int32{12, 1}, BugId: []string{"o/r#1"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeClient{}
+			buf, err := proto.Marshal(cfg)
+			if err != nil {
+				t.Fatalf("marshal config: %v", err)
+			}
+			client["gs://bucket/config"] = buf
+			if err := writeGrid(context.Background(), client, mustPath(t, "gs://bucket/grid/group"), grid); err != nil {
+				t.Fatalf("write grid: %v", err)
+			}
+			client["gs://bucket/grid/broken"] = []byte("garbage")
+			before := len(client)
+
+			err = Update(context.Background(), client, mustPath(t, "gs://bucket/config"), 2, tc.dashboard, "grid", "tabs", tc.issues, tc.confirm)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Update() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Update() failed to return an error")
+			}
+			if n := len(client) - before; n != len(tc.expected) {
+				t.Errorf("Update() wrote %d objects, want %d", n, len(tc.expected))
+			}
+			for path, want := range tc.expected {
+				got, err := readGrid(context.Background(), client, mustPath(t, path))
+				if err != nil {
+					t.Errorf("read %s: %v", path, err)
+					continue
+				}
+				if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("Update() got unexpected diff at %s (-want +got):\n%s", path, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trigger requests immediate updates and summaries through objects in GCS.
+//
+// Each trigger is an object named after its target, such as a test group, under
+// a directory for the kind of work it requests. A trigger remains pending until
+// its target changes after the trigger was written, so the updater and
+// summarizer need not delete triggers once they act on them.
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Kind is the kind of work a trigger requests.
+type Kind string
+
+const (
+	// Update requests the updater update a test group.
+	Update Kind = "update"
+	// Summary requests the summarizer summarize a dashboard.
+	Summary Kind = "summary"
+)
+
+// Trigger records who requested the work and when.
+type Trigger struct {
+	Requester string    `json:"requester,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Pending is a trigger written after its target last changed.
+type Pending struct {
+	Name      string
+	Requested time.Time
+	// Generation of the target when checked, or zero when it does not exist.
+	Generation int64
+}
+
+// Client lists triggers and stats their targets.
+type Client interface {
+	gcs.Lister
+	gcs.Stater
+}
+
+func dir(configPath gcs.Path, prefix string, kind Kind) (*gcs.Path, error) {
+	p, err := configPath.ResolveReference(&url.URL{Path: path.Join(prefix, string(kind)) + "/"})
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	return p, nil
+}
+
+// Path returns the path of the trigger of the kind for the target name, under prefix relative to the config.
+func Path(configPath gcs.Path, prefix string, kind Kind, name string) (*gcs.Path, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("bad trigger name %q", name)
+	}
+	d, err := dir(configPath, prefix, kind)
+	if err != nil {
+		return nil, err
+	}
+	p, err := d.ResolveReference(&url.URL{Path: name})
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	return p, nil
+}
+
+// Write writes a trigger of the kind for the target name on behalf of the requester.
+func Write(ctx context.Context, client gcs.Uploader, configPath gcs.Path, prefix string, kind Kind, name, requester string) error {
+	p, err := Path(configPath, prefix, kind, name)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(Trigger{Requester: requester, Time: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return client.Upload(ctx, *p, buf, gcs.DefaultAcl, "no-cache")
+}
+
+// Check returns the pending triggers of the kind, oldest first.
+//
+// Only considers triggers whose name has a target path, which are pending when
+// the target does not exist or last changed before the trigger.
+func Check(ctx context.Context, client Client, configPath gcs.Path, prefix string, kind Kind, targets map[string]gcs.Path) ([]Pending, error) {
+	d, err := dir(configPath, prefix, kind)
+	if err != nil {
+		return nil, err
+	}
+	var out []Pending
+	it := client.Objects(ctx, *d, "/", "")
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", d, err)
+		}
+		name := strings.TrimPrefix(attrs.Name, d.Object())
+		target, ok := targets[name]
+		if !ok || attrs.Name == "" {
+			continue
+		}
+		requested := attrs.Updated
+		targetAttrs, err := client.Stat(ctx, target)
+		switch {
+		case errors.Is(err, storage.ErrObjectNotExist):
+			out = append(out, Pending{Name: name, Requested: requested})
+		case err != nil:
+			return nil, fmt.Errorf("stat %s: %w", target, err)
+		case targetAttrs.Updated.Before(requested):
+			out = append(out, Pending{Name: name, Requested: requested, Generation: targetAttrs.Generation})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Requested.Before(out[j].Requested)
+	})
+	return out, nil
+}
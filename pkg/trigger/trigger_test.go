@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// fakeClient holds the attributes and contents of objects by path.
+type fakeClient struct {
+	attrs map[string]storage.ObjectAttrs
+	data  map[string][]byte
+}
+
+func (fc *fakeClient) Objects(_ context.Context, prefix gcs.Path, _, _ string) gcs.Iterator {
+	var it fakeIterator
+	for p, attrs := range fc.attrs {
+		path, err := gcs.NewPath(p)
+		if err != nil {
+			panic(err)
+		}
+		if path.Bucket() == prefix.Bucket() && strings.HasPrefix(path.Object(), prefix.Object()) {
+			attrs.Name = path.Object()
+			it = append(it, attrs)
+		}
+	}
+	return &it
+}
+
+type fakeIterator []storage.ObjectAttrs
+
+func (fi *fakeIterator) Next() (*storage.ObjectAttrs, error) {
+	if len(*fi) == 0 {
+		return nil, iterator.Done
+	}
+	attrs := (*fi)[0]
+	*fi = (*fi)[1:]
+	return &attrs, nil
+}
+
+func (fc *fakeClient) Stat(_ context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	attrs, ok := fc.attrs[path.String()]
+	if !ok {
+		return nil, storage.ErrObjectNotExist
+	}
+	return &attrs, nil
+}
+
+func (fc *fakeClient) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, _ string) error {
+	fc.data[path.String()] = buf
+	return nil
+}
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("bad path %q: %v", s, err)
+	}
+	return *p
+}
+
+func TestPath(t *testing.T) {
+	configPath := mustPath(t, "gs://bucket/config")
+	cases := []struct {
+		name     string
+		prefix   string
+		target   string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "basic",
+			prefix:   "triggers",
+			target:   "group",
+			expected: "gs://bucket/triggers/update/group",
+		},
+		{
+			name:     "escaped",
+			prefix:   "triggers",
+			target:   "my group?",
+			expected: "gs://bucket/triggers/update/my%20group%3F",
+		},
+		{
+			name:   "slash",
+			prefix: "triggers",
+			target: "a/b",
+			err:    true,
+		},
+		{
+			name:   "empty",
+			prefix: "triggers",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Path(configPath, tc.prefix, Update, tc.target)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Path() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("Path() failed to return an error, got %s", got)
+			case got.String() != tc.expected:
+				t.Errorf("Path() got %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	client := fakeClient{data: map[string][]byte{}}
+	configPath := mustPath(t, "gs://bucket/config")
+	if err := Write(context.Background(), &client, configPath, "triggers", Summary, "dash", "alice@example.com"); err != nil {
+		t.Fatalf("Write() got unexpected error: %v", err)
+	}
+	buf, ok := client.data["gs://bucket/triggers/summary/dash"]
+	if !ok {
+		t.Fatalf("Write() failed to write the trigger, got %v", client.data)
+	}
+	var got Trigger
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("Write() wrote an invalid trigger %q: %v", buf, err)
+	}
+	if got.Requester != "alice@example.com" || got.Time.IsZero() {
+		t.Errorf("Write() got %#v, want a trigger from alice@example.com", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	client := fakeClient{
+		attrs: map[string]storage.ObjectAttrs{
+			"gs://bucket/triggers/update/stale":   {Updated: now.Add(-time.Minute)},
+			"gs://bucket/triggers/update/fresh":   {Updated: now.Add(-time.Hour)},
+			"gs://bucket/triggers/update/new":     {Updated: now.Add(-2 * time.Minute)},
+			"gs://bucket/triggers/update/unknown": {Updated: now},
+			"gs://bucket/triggers/summary/stale":  {Updated: now},
+			"gs://bucket/grid/stale":              {Updated: now.Add(-time.Hour), Generation: 3},
+			"gs://bucket/grid/fresh":              {Updated: now, Generation: 4},
+		},
+	}
+	configPath := mustPath(t, "gs://bucket/config")
+	targets := map[string]gcs.Path{
+		"stale": mustPath(t, "gs://bucket/grid/stale"),
+		"fresh": mustPath(t, "gs://bucket/grid/fresh"),
+		"new":   mustPath(t, "gs://bucket/grid/new"),
+	}
+	got, err := Check(context.Background(), &client, configPath, "triggers", Update, targets)
+	if err != nil {
+		t.Fatalf("Check() got unexpected error: %v", err)
+	}
+	expected := []Pending{
+		{Name: "new", Requested: now.Add(-2 * time.Minute)},
+		{Name: "stale", Requested: now.Add(-time.Minute), Generation: 3},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Check() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// AzureDevOpsAPI is the Azure DevOps Services endpoint.
+const AzureDevOpsAPI = "https://dev.azure.com"
+
+// AzureTestRun is a run of tests in an Azure DevOps build.
+type AzureTestRun struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	State         string    `json:"state"`
+	StartedDate   time.Time `json:"startedDate"`
+	CompletedDate time.Time `json:"completedDate"`
+	Build         struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"build"`
+}
+
+// AzureTestResult is the result of a test in an Azure DevOps test run.
+type AzureTestResult struct {
+	TestCaseTitle     string  `json:"testCaseTitle"`
+	AutomatedTestName string  `json:"automatedTestName"`
+	Outcome           string  `json:"outcome"`
+	DurationInMs      float64 `json:"durationInMs"`
+	ErrorMessage      string  `json:"errorMessage"`
+}
+
+// A TestRunReader reads the test runs of an Azure DevOps project and the results of each run.
+type TestRunReader interface {
+	TestRuns(ctx context.Context, organization, project string, definitions []int32, since, until time.Time) ([]AzureTestRun, error)
+	TestResults(ctx context.Context, organization, project string, run int) ([]AzureTestResult, error)
+}
+
+// AzureDevOpsClient reads test runs with the Azure DevOps REST API.
+type AzureDevOpsClient struct {
+	// Token is a personal access token with the Test Management (read) scope.
+	Token string
+	// URL defaults to AzureDevOpsAPI.
+	URL    string
+	Client *http.Client
+}
+
+// The Azure DevOps API rejects queries of runs updated over longer spans.
+const azureRunWindow = 7 * 24 * time.Hour
+
+// TestRuns returns the runs of the build definitions, or all runs when empty, last updated between since and until.
+//
+// Queries a week at a time, following the continuation token of each query.
+func (c AzureDevOpsClient) TestRuns(ctx context.Context, organization, project string, definitions []int32, since, until time.Time) ([]AzureTestRun, error) {
+	path := fmt.Sprintf("/%s/%s/_apis/test/runs", url.PathEscape(organization), url.PathEscape(project))
+	var defs []string
+	for _, d := range definitions {
+		defs = append(defs, strconv.Itoa(int(d)))
+	}
+	var runs []AzureTestRun
+	for start := since; start.Before(until); start = start.Add(azureRunWindow) {
+		end := start.Add(azureRunWindow)
+		if end.After(until) {
+			end = until
+		}
+		query := url.Values{
+			"api-version":        {"7.0"},
+			"minLastUpdatedDate": {start.UTC().Format(time.RFC3339)},
+			"maxLastUpdatedDate": {end.UTC().Format(time.RFC3339)},
+		}
+		if len(defs) > 0 {
+			query.Set("buildDefIds", strings.Join(defs, ","))
+		}
+		for {
+			var page struct {
+				Value []AzureTestRun `json:"value"`
+			}
+			token, err := c.do(ctx, path, query, &page)
+			if err != nil {
+				return nil, fmt.Errorf("query runs: %w", err)
+			}
+			runs = append(runs, page.Value...)
+			if token == "" {
+				break
+			}
+			query.Set("continuationToken", token)
+		}
+	}
+	return runs, nil
+}
+
+// azureResultsPage is the most results the API returns at once.
+const azureResultsPage = 1000
+
+// TestResults returns every result of the run, reading a page at a time.
+func (c AzureDevOpsClient) TestResults(ctx context.Context, organization, project string, run int) ([]AzureTestResult, error) {
+	path := fmt.Sprintf("/%s/%s/_apis/test/Runs/%d/results", url.PathEscape(organization), url.PathEscape(project), run)
+	var results []AzureTestResult
+	for {
+		query := url.Values{
+			"api-version": {"7.0"},
+			"$top":        {strconv.Itoa(azureResultsPage)},
+			"$skip":       {strconv.Itoa(len(results))},
+		}
+		var page struct {
+			Value []AzureTestResult `json:"value"`
+		}
+		if _, err := c.do(ctx, path, query, &page); err != nil {
+			return nil, fmt.Errorf("list results: %w", err)
+		}
+		results = append(results, page.Value...)
+		if len(page.Value) < azureResultsPage {
+			return results, nil
+		}
+	}
+}
+
+// do gets the API path with the query, decoding the response into out.
+//
+// Returns the continuation token of the response, if any.
+func (c AzureDevOpsClient) do(ctx context.Context, path string, query url.Values, out interface{}) (string, error) {
+	base := c.URL
+	if base == "" {
+		base = AzureDevOpsAPI
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(base, "/")+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.SetBasicAuth("", c.Token)
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return "", fmt.Errorf("decode: %w", err)
+	}
+	return resp.Header.Get("x-ms-continuationtoken"), nil
+}
+
+// AzureDevOps returns a GroupUpdater that reads groups with an azure_devops_config result source with the reader.
+//
+// Updates every other group with next.
+func AzureDevOps(groupTimeout time.Duration, reader TestRunReader, write bool, cache *GridCache, next GroupUpdater) GroupUpdater {
+	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		if tg.GetResultSource().GetAzureDevopsConfig() == nil {
+			return next(parent, log, client, tg, gridPath)
+		}
+		ctx, cancel := context.WithTimeout(parent, updateTimeout(tg, groupTimeout))
+		defer cancel()
+		old, err := cache.download(ctx, client, gridPath)
+		if err != nil {
+			log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
+		}
+		const maxCols = 50
+		grid, err := azureGrid(ctx, log, reader, tg, old, maxCols)
+		if err != nil {
+			return err
+		}
+		return writeGrid(ctx, log, client, gridPath, grid, write, cache)
+	}
+}
+
+// azureGrid adds up to maxCols new builds with test runs in the project of the group to the recent columns of the old grid, if any.
+func azureGrid(ctx context.Context, log logrus.FieldLogger, reader TestRunReader, tg *configpb.TestGroup, old *statepb.Grid, maxCols int) (*statepb.Grid, error) {
+	ado := tg.GetResultSource().GetAzureDevopsConfig()
+	oldCols, since := recentColumns(tg, old)
+	runs, err := reader.TestRuns(ctx, ado.GetOrganization(), ado.GetProject(), ado.GetBuildDefinitionIds(), since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("runs", len(runs)).Debug("Found test runs")
+	builds := azureBuilds(runs, since)
+	if len(builds) > maxCols {
+		builds = builds[:maxCols]
+	}
+	newCols := make([]inflatedColumn, 0, len(builds))
+	for _, b := range builds {
+		results := make([][]AzureTestResult, len(b))
+		for i, run := range b {
+			if results[i], err = reader.TestResults(ctx, ado.GetOrganization(), ado.GetProject(), run.ID); err != nil {
+				return nil, fmt.Errorf("run %d: %w", run.ID, err)
+			}
+		}
+		col, err := azureColumn(ctx, log, b, results, tg.GetKeepSkipped())
+		if err != nil {
+			return nil, err
+		}
+		newCols = append(newCols, col)
+	}
+	cols := mergeColumns(newCols, oldCols)
+	cols = thinColumns(cols, tg.ColumnRetention, time.Now())
+	return constructGrid(log, tg, cols), nil
+}
+
+// azureBuildID returns the build number of the run, or the run ID for runs outside a build.
+func azureBuildID(run AzureTestRun) string {
+	if run.Build.Name != "" {
+		return run.Build.Name
+	}
+	if run.Build.ID != "" {
+		return run.Build.ID
+	}
+	return "run-" + strconv.Itoa(run.ID)
+}
+
+// azureBuilds groups the runs of each build started since the time, newest build first.
+//
+// Runs are listed by when they last updated, which includes builds that started
+// before the columns merged with the old grid.
+func azureBuilds(runs []AzureTestRun, since time.Time) [][]AzureTestRun {
+	idx := map[string]int{}
+	var out [][]AzureTestRun
+	for _, run := range runs {
+		id := azureBuildID(run)
+		i, ok := idx[id]
+		if !ok {
+			i = len(out)
+			idx[id] = i
+			out = append(out, nil)
+		}
+		out[i] = append(out[i], run)
+	}
+	started := func(b []AzureTestRun) time.Time {
+		when := b[0].StartedDate
+		for _, run := range b[1:] {
+			if run.StartedDate.Before(when) {
+				when = run.StartedDate
+			}
+		}
+		return when
+	}
+	builds := out[:0]
+	for _, b := range out {
+		if !started(b).Before(since) {
+			builds = append(builds, b)
+		}
+	}
+	out = builds
+	sort.SliceStable(out, func(i, j int) bool {
+		return started(out[i]).After(started(out[j]))
+	})
+	return out
+}
+
+// azureColumn returns a column for the runs of a build, with a row for each of their results.
+//
+// The overall cell is running until every run completes, and otherwise fails when any test fails.
+func azureColumn(ctx context.Context, log logrus.FieldLogger, runs []AzureTestRun, results [][]AzureTestResult, keepSkipped bool) (inflatedColumn, error) {
+	build := azureBuildID(runs[0])
+	start, end := runs[0].StartedDate, runs[0].CompletedDate
+	running, aborted := false, false
+	for _, run := range runs {
+		if run.StartedDate.Before(start) {
+			start = run.StartedDate
+		}
+		if run.CompletedDate.After(end) {
+			end = run.CompletedDate
+		}
+		switch run.State {
+		case "Completed", "NeedsInvestigation":
+		case "Aborted":
+			aborted = true
+		default:
+			running = true
+		}
+	}
+	col := inflatedColumn{
+		column: &statepb.Column{
+			Build:   build,
+			Started: float64(start.Unix()*1000 + int64(start.Nanosecond())/int64(time.Millisecond)),
+		},
+		cells: map[string]cell{},
+	}
+	var total, failures int
+	for _, rs := range results {
+		for _, r := range rs {
+			status := azureOutcome(r.Outcome)
+			if status == statuspb.TestStatus_NO_RESULT || (status == statuspb.TestStatus_PASS_WITH_SKIPS && !keepSkipped) {
+				continue
+			}
+			c := cell{
+				result:  status,
+				message: r.ErrorMessage,
+			}
+			if r.DurationInMs > 0 {
+				c.metrics = setElapsed(nil, r.DurationInMs/1000)
+			}
+			switch {
+			case status == statuspb.TestStatus_PASS_WITH_SKIPS:
+				c.icon = "S"
+			case c.message != "" && result.IsFailingResult(status):
+				c.icon = "F"
+			}
+			name := r.AutomatedTestName
+			if name == "" {
+				name = r.TestCaseTitle
+			}
+			if err := addCell(ctx, log, build, col.cells, name, &c); err != nil {
+				return inflatedColumn{}, err
+			}
+			total++
+			if result.IsFailingResult(status) {
+				failures++
+			}
+		}
+	}
+	overall := cell{result: statuspb.TestStatus_PASS}
+	switch {
+	case running:
+		overall = cell{result: statuspb.TestStatus_RUNNING, message: "Build still running...", icon: "R"}
+	case aborted:
+		overall = cell{result: statuspb.TestStatus_ABORTED, message: "Build aborted", icon: "A"}
+	case failures > 0:
+		overall.result = statuspb.TestStatus_FAIL
+		overall.message = fmt.Sprintf("%d of %d tests failed", failures, total)
+	}
+	if !running && end.After(start) {
+		overall.metrics = setElapsed(nil, end.Sub(start).Seconds())
+	}
+	col.cells["Overall"] = overall
+	return col, nil
+}
+
+var azureOutcomes = map[string]statuspb.TestStatus{
+	"passed":       statuspb.TestStatus_PASS,
+	"failed":       statuspb.TestStatus_FAIL,
+	"error":        statuspb.TestStatus_FAIL,
+	"timeout":      statuspb.TestStatus_TIMED_OUT,
+	"aborted":      statuspb.TestStatus_ABORTED,
+	"blocked":      statuspb.TestStatus_BLOCKED,
+	"inconclusive": statuspb.TestStatus_UNKNOWN,
+	"warning":      statuspb.TestStatus_PASS_WITH_ERRORS,
+	"notexecuted":  statuspb.TestStatus_PASS_WITH_SKIPS,
+	"inprogress":   statuspb.TestStatus_RUNNING,
+	"paused":       statuspb.TestStatus_RUNNING,
+}
+
+// azureOutcome returns the status of a test outcome, ignoring case, or NO_RESULT for outcomes such as NotApplicable.
+func azureOutcome(outcome string) statuspb.TestStatus {
+	return azureOutcomes[strings.ToLower(outcome)]
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestAzureDevOpsClient(t *testing.T) {
+	now := time.Date(2021, 3, 20, 0, 0, 0, 0, time.UTC)
+	var windows []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pass, ok := r.BasicAuth(); !ok || pass != "secret" {
+			http.Error(w, "bad auth", http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/my org/my-project/_apis/test/runs":
+			if got := q.Get("buildDefIds"); got != "1,2" {
+				http.Error(w, "bad definitions: "+got, http.StatusBadRequest)
+				return
+			}
+			var runs []AzureTestRun
+			switch tok := q.Get("continuationToken"); tok {
+			case "":
+				windows = append(windows, q.Get("minLastUpdatedDate")+"/"+q.Get("maxLastUpdatedDate"))
+				runs = []AzureTestRun{{ID: len(windows) * 10}}
+				w.Header().Set("x-ms-continuationtoken", "more")
+			case "more":
+				runs = []AzureTestRun{{ID: len(windows)*10 + 1}}
+			default:
+				http.Error(w, "bad token: "+tok, http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"count": len(runs), "value": runs})
+		case "/my org/my-project/_apis/test/Runs/7/results":
+			skip, _ := strconv.Atoi(q.Get("$skip"))
+			top, _ := strconv.Atoi(q.Get("$top"))
+			var results []AzureTestResult
+			for i := skip; i < skip+top && i < azureResultsPage+2; i++ {
+				results = append(results, AzureTestResult{AutomatedTestName: fmt.Sprintf("test-%d", i)})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "value": results})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	t.Run("runs", func(t *testing.T) {
+		client := AzureDevOpsClient{Token: "secret", URL: server.URL}
+		runs, err := client.TestRuns(ctx, "my org", "my-project", []int32{1, 2}, now.Add(-10*24*time.Hour), now)
+		if err != nil {
+			t.Fatalf("TestRuns() got unexpected error: %v", err)
+		}
+		var got []int
+		for _, run := range runs {
+			got = append(got, run.ID)
+		}
+		if diff := cmp.Diff([]int{10, 11, 20, 21}, got); diff != "" {
+			t.Errorf("TestRuns() got unexpected diff (-want +got):\n%s", diff)
+		}
+		wantWindows := []string{
+			"2021-03-10T00:00:00Z/2021-03-17T00:00:00Z",
+			"2021-03-17T00:00:00Z/2021-03-20T00:00:00Z",
+		}
+		if diff := cmp.Diff(wantWindows, windows); diff != "" {
+			t.Errorf("TestRuns() queried unexpected windows (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("results", func(t *testing.T) {
+		client := AzureDevOpsClient{Token: "secret", URL: server.URL}
+		results, err := client.TestResults(ctx, "my org", "my-project", 7)
+		if err != nil {
+			t.Fatalf("TestResults() got unexpected error: %v", err)
+		}
+		if n := len(results); n != azureResultsPage+2 {
+			t.Errorf("TestResults() got %d results, want %d", n, azureResultsPage+2)
+		}
+	})
+
+	t.Run("bad token", func(t *testing.T) {
+		client := AzureDevOpsClient{Token: "wrong", URL: server.URL}
+		if _, err := client.TestResults(ctx, "my org", "my-project", 7); err == nil {
+			t.Error("TestResults() failed to return an error")
+		}
+	})
+}
+
+func TestAzureOutcome(t *testing.T) {
+	cases := []struct {
+		outcome string
+		want    statuspb.TestStatus
+	}{
+		{
+			outcome: "Passed",
+			want:    statuspb.TestStatus_PASS,
+		},
+		{
+			outcome: "Failed",
+			want:    statuspb.TestStatus_FAIL,
+		},
+		{
+			outcome: "Timeout",
+			want:    statuspb.TestStatus_TIMED_OUT,
+		},
+		{
+			outcome: "NotExecuted",
+			want:    statuspb.TestStatus_PASS_WITH_SKIPS,
+		},
+		{
+			outcome: "NotApplicable",
+			want:    statuspb.TestStatus_NO_RESULT,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.outcome, func(t *testing.T) {
+			if got := azureOutcome(tc.outcome); got != tc.want {
+				t.Errorf("azureOutcome() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func azureRun(id int, build string, started time.Time, state string) AzureTestRun {
+	run := AzureTestRun{
+		ID:            id,
+		State:         state,
+		StartedDate:   started,
+		CompletedDate: started.Add(time.Minute),
+	}
+	run.Build.Name = build
+	return run
+}
+
+func TestAzureBuilds(t *testing.T) {
+	now := time.Now()
+	runs := []AzureTestRun{
+		azureRun(1, "100.1", now.Add(-time.Hour), "Completed"),
+		azureRun(2, "100.2", now.Add(-time.Minute), "Completed"),
+		azureRun(3, "100.1", now.Add(-2*time.Hour), "Completed"),
+		azureRun(4, "", now.Add(-3*time.Hour), "Completed"),
+		azureRun(5, "99.1", now.Add(-5*time.Hour), "Completed"),
+	}
+	var got [][]int
+	for _, b := range azureBuilds(runs, now.Add(-4*time.Hour)) {
+		var ids []int
+		for _, run := range b {
+			ids = append(ids, run.ID)
+		}
+		got = append(got, ids)
+	}
+	if diff := cmp.Diff([][]int{{2}, {1, 3}, {4}}, got); diff != "" {
+		t.Errorf("azureBuilds() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := azureBuildID(runs[3]); got != "run-4" {
+		t.Errorf("azureBuildID() got %q, want run-4", got)
+	}
+}
+
+func TestAzureColumn(t *testing.T) {
+	start := time.Unix(100, 0)
+	cases := []struct {
+		name        string
+		runs        []AzureTestRun
+		results     [][]AzureTestResult
+		keepSkipped bool
+		want        inflatedColumn
+	}{
+		{
+			name: "results of every run",
+			runs: []AzureTestRun{
+				azureRun(1, "100.1", start.Add(time.Minute), "Completed"),
+				azureRun(2, "100.1", start, "Completed"),
+			},
+			results: [][]AzureTestResult{
+				{
+					{AutomatedTestName: "pkg.Good", Outcome: "Passed", DurationInMs: 30000},
+					{TestCaseTitle: "Bad title", Outcome: "Failed", ErrorMessage: "boom"},
+				},
+				{
+					{AutomatedTestName: "pkg.Good", Outcome: "Passed"},
+					{AutomatedTestName: "pkg.Skipped", Outcome: "NotExecuted"},
+					{AutomatedTestName: "pkg.Ignored", Outcome: "NotApplicable"},
+				},
+			},
+			want: inflatedColumn{
+				column: &statepb.Column{Build: "100.1", Started: 100000},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "1 of 3 tests failed",
+						metrics: setElapsed(nil, 120),
+					},
+					"pkg.Good": {
+						result:  statuspb.TestStatus_PASS,
+						metrics: setElapsed(nil, 30),
+					},
+					"Bad title": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "boom",
+						icon:    "F",
+					},
+					"pkg.Good [1]": {result: statuspb.TestStatus_PASS},
+				},
+			},
+		},
+		{
+			name: "keep skipped",
+			runs: []AzureTestRun{
+				azureRun(1, "100.1", start, "Completed"),
+			},
+			results: [][]AzureTestResult{
+				{{AutomatedTestName: "pkg.Skipped", Outcome: "NotExecuted"}},
+			},
+			keepSkipped: true,
+			want: inflatedColumn{
+				column: &statepb.Column{Build: "100.1", Started: 100000},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_PASS,
+						metrics: setElapsed(nil, 60),
+					},
+					"pkg.Skipped": {result: statuspb.TestStatus_PASS_WITH_SKIPS, icon: "S"},
+				},
+			},
+		},
+		{
+			name: "running",
+			runs: []AzureTestRun{
+				azureRun(1, "100.1", start, "Completed"),
+				azureRun(2, "100.1", start, "InProgress"),
+			},
+			results: [][]AzureTestResult{nil, nil},
+			want: inflatedColumn{
+				column: &statepb.Column{Build: "100.1", Started: 100000},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_RUNNING,
+						message: "Build still running...",
+						icon:    "R",
+					},
+				},
+			},
+		},
+		{
+			name: "aborted",
+			runs: []AzureTestRun{
+				azureRun(1, "100.1", start, "Aborted"),
+			},
+			results: [][]AzureTestResult{nil},
+			want: inflatedColumn{
+				column: &statepb.Column{Build: "100.1", Started: 100000},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_ABORTED,
+						message: "Build aborted",
+						icon:    "A",
+						metrics: setElapsed(nil, 60),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := azureColumn(context.Background(), logrus.WithField("test", tc.name), tc.runs, tc.results, tc.keepSkipped)
+			if err != nil {
+				t.Fatalf("azureColumn() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(inflatedColumn{}, cell{}), protocmp.Transform()); diff != "" {
+				t.Errorf("azureColumn() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeRunReader struct {
+	runs        []AzureTestRun
+	results     map[int][]AzureTestResult
+	definitions []int32
+	since       time.Time
+}
+
+func (fr *fakeRunReader) TestRuns(_ context.Context, organization, project string, definitions []int32, since, _ time.Time) ([]AzureTestRun, error) {
+	if organization != "my-org" || project != "my-project" {
+		return nil, fmt.Errorf("unexpected project: %s/%s", organization, project)
+	}
+	fr.definitions, fr.since = definitions, since
+	return fr.runs, nil
+}
+
+func (fr *fakeRunReader) TestResults(_ context.Context, _, _ string, run int) ([]AzureTestResult, error) {
+	return fr.results[run], nil
+}
+
+func TestAzureGrid(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) time.Time {
+		return now.Add(-time.Duration(h) * time.Hour)
+	}
+	tg := &configpb.TestGroup{
+		Name:          "group",
+		DaysOfResults: 1,
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_AzureDevopsConfig{
+				AzureDevopsConfig: &configpb.AzureDevOpsConfig{
+					Organization:       "my-org",
+					Project:            "my-project",
+					BuildDefinitionIds: []int32{3},
+				},
+			},
+		},
+	}
+	results := map[int][]AzureTestResult{
+		2: {{AutomatedTestName: "good", Outcome: "Passed"}},
+		3: {{AutomatedTestName: "good", Outcome: "Passed"}},
+		4: {{AutomatedTestName: "good", Outcome: "Passed"}},
+	}
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: float64(hoursAgo(8).Unix() * 1000)},
+			{Build: "1", Started: float64(hoursAgo(10).Unix() * 1000)},
+		},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "good", Id: "good"}, cell{result: statuspb.TestStatus_PASS}, cell{result: statuspb.TestStatus_PASS}),
+		},
+	}
+	cases := []struct {
+		name      string
+		old       *statepb.Grid
+		runs      []AzureTestRun
+		maxCols   int
+		wantSince time.Time
+		want      []string
+	}{
+		{
+			name:      "new grid",
+			runs:      []AzureTestRun{azureRun(3, "3", hoursAgo(5), "Completed"), azureRun(4, "4", hoursAgo(1), "Completed")},
+			maxCols:   10,
+			wantSince: hoursAgo(24),
+			want:      []string{"4", "3"},
+		},
+		{
+			name:      "merge with old columns",
+			old:       old,
+			runs:      []AzureTestRun{azureRun(4, "4", hoursAgo(1), "Completed"), azureRun(3, "3", hoursAgo(5), "Completed"), azureRun(2, "2", hoursAgo(8), "Completed")},
+			maxCols:   10,
+			wantSince: hoursAgo(8),
+			want:      []string{"4", "3", "2", "1"},
+		},
+		{
+			name:      "ignore old builds that updated late",
+			old:       old,
+			runs:      []AzureTestRun{azureRun(4, "4", hoursAgo(1), "Completed"), azureRun(1, "1", hoursAgo(10), "Completed")},
+			maxCols:   10,
+			wantSince: hoursAgo(8),
+			want:      []string{"4", "2", "1"},
+		},
+		{
+			name:      "limit new columns",
+			runs:      []AzureTestRun{azureRun(3, "3", hoursAgo(5), "Completed"), azureRun(4, "4", hoursAgo(1), "Completed")},
+			maxCols:   1,
+			wantSince: hoursAgo(24),
+			want:      []string{"4"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := fakeRunReader{runs: tc.runs, results: results}
+			grid, err := azureGrid(context.Background(), logrus.WithField("test", tc.name), &reader, tg, tc.old, tc.maxCols)
+			if err != nil {
+				t.Fatalf("azureGrid() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("azureGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]int32{3}, reader.definitions); diff != "" {
+				t.Errorf("azureGrid() read unexpected definitions (-want +got):\n%s", diff)
+			}
+			if d := reader.since.Sub(tc.wantSince); d < -time.Minute || d > time.Minute {
+				t.Errorf("azureGrid() got since %v, want %v", reader.since, tc.wantSince)
+			}
+		})
+	}
+}
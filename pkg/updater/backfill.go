@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Backfill rebuilds the grid of the named group from every build started between start and end.
+//
+// Reads the config at configPath and the group's builds newest first, in batches
+// of batchSize, waiting pause between batches.
+// Saves the grid read so far under progressPrefix after each batch, resuming from it when it exists.
+// Only writes progress and the final grid under gridPrefix when write is set.
+func Backfill(ctx context.Context, client gcs.Client, configPath gcs.Path, gridPrefix, progressPrefix, group string, start, end time.Time, batchSize int, pause, buildTimeout time.Duration, concurrency int, write bool) error {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	tg := config.FindTestGroup(group, cfg)
+	if tg == nil {
+		return fmt.Errorf("test group %q not found", group)
+	}
+	gridPath, err := testGroupPath(configPath, gridPrefix, group)
+	if err != nil {
+		return fmt.Errorf("grid path: %w", err)
+	}
+	progressPath, err := testGroupPath(configPath, progressPrefix, group)
+	if err != nil {
+		return fmt.Errorf("progress path: %w", err)
+	}
+	if *gridPath == *progressPath {
+		return errors.New("progress and grid paths must differ")
+	}
+	log := logrus.WithField("group", group).WithField("progress", progressPath)
+	return backfillGroup(ctx, log, client, tg, *gridPath, *progressPath, start, end, batchSize, pause, buildTimeout, concurrency, write)
+}
+
+// backfillGroup rebuilds the grid of the group at gridPath, saving progress at progressPath.
+func backfillGroup(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath, progressPath gcs.Path, start, end time.Time, batchSize int, pause, buildTimeout time.Duration, concurrency int, write bool) error {
+	if batchSize < 1 {
+		return fmt.Errorf("batch size must be positive, got: %d", batchSize)
+	}
+	if !start.Before(end) {
+		return errors.New("start must be before end")
+	}
+	tgPaths, err := groupPaths(tg)
+	if err != nil {
+		return fmt.Errorf("group path: %w", err)
+	}
+
+	progress, err := downloadGrid(ctx, client, progressPath)
+	if err != nil {
+		return fmt.Errorf("download progress: %w", err)
+	}
+	var cols []inflatedColumn
+	for _, col := range inflateGrid(progress, time.Time{}, end) {
+		if inRange(col, start, end) {
+			cols = append(cols, col)
+		}
+	}
+
+	builds, err := listBuilds(ctx, client, "", tgPaths...)
+	if err != nil {
+		return fmt.Errorf("list builds: %w", err)
+	}
+	if len(cols) > 0 {
+		builds = olderBuilds(builds, cols[len(cols)-1].column.Build)
+		log.WithField("columns", len(cols)).Info("Resuming backfill")
+	}
+	log.WithField("total", len(builds)).Debug("Listed builds")
+
+	for len(builds) > 0 {
+		n := batchSize
+		if n > len(builds) {
+			n = len(builds)
+		}
+		newCols, err := readColumns(ctx, client, tg, builds[:n], start, n, buildTimeout, concurrency)
+		if err != nil {
+			return fmt.Errorf("read columns: %w", err)
+		}
+		builds = builds[n:]
+		for _, col := range newCols {
+			if inRange(col, start, end) {
+				cols = append(cols, col)
+			} else if int64(col.column.Started) < start.Unix()*1000 {
+				builds = nil // Reached builds before start
+			}
+		}
+		log.WithFields(logrus.Fields{
+			"cols":      len(cols),
+			"remaining": len(builds),
+		}).Info("Backfilled batch")
+		if write {
+			if err := uploadGrid(ctx, log, client, progressPath, tg, cols); err != nil {
+				return fmt.Errorf("save progress: %w", err)
+			}
+		}
+		if len(builds) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+
+	if !write {
+		log.WithField("cols", len(cols)).Info("Skipping write")
+		return nil
+	}
+	if err := uploadGrid(ctx, log, client, gridPath, tg, cols); err != nil {
+		return fmt.Errorf("write grid: %w", err)
+	}
+	log.WithField("url", gridPath).WithField("cols", len(cols)).Info("Wrote backfilled grid")
+	return nil
+}
+
+// inRange returns true when the column started at or after start and before end.
+func inRange(col inflatedColumn, start, end time.Time) bool {
+	started := int64(col.column.Started)
+	return started >= start.Unix()*1000 && started < end.Unix()*1000
+}
+
+// olderBuilds returns the builds after the one with the id, or else those whose id sorts before it.
+func olderBuilds(builds []gcs.Build, id string) []gcs.Build {
+	for i, b := range builds {
+		if b.Build() == id {
+			return builds[i+1:]
+		}
+	}
+	for i, b := range builds {
+		if sortorder.NaturalLess(b.Build(), id) {
+			return builds[i:]
+		}
+	}
+	return nil
+}
+
+// uploadGrid constructs the grid of the columns and uploads it to path.
+func uploadGrid(ctx context.Context, log logrus.FieldLogger, client gcs.Uploader, path gcs.Path, tg *configpb.TestGroup, cols []inflatedColumn) error {
+	buf, err := marshalGrid(constructGrid(log, tg, cols))
+	if err != nil {
+		return fmt.Errorf("marshal grid: %w", err)
+	}
+	return client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
+}
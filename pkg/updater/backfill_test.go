@@ -0,0 +1,251 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestBackfillGroup(t *testing.T) {
+	now := time.Now().Unix()
+	gridPath := newPathOrDie("gs://fake/grid/group")
+	progressPath := newPathOrDie("gs://fake/backfill/group")
+	group := configpb.TestGroup{
+		GcsPrefix: "bucket/path/to/build/",
+	}
+	builds := []fakeBuild{
+		{
+			id:       "40",
+			started:  jsonStarted(now + 40),
+			finished: jsonFinished(now+41, true, metadata.Metadata{}),
+			passed:   []string{"good"},
+		},
+		{
+			id:       "30",
+			started:  jsonStarted(now + 30),
+			finished: jsonFinished(now+31, true, metadata.Metadata{}),
+			passed:   []string{"good"},
+		},
+		{
+			id:       "20",
+			started:  jsonStarted(now + 20),
+			finished: jsonFinished(now+21, false, metadata.Metadata{}),
+			failed:   []string{"good"},
+		},
+		{
+			id:       "10",
+			started:  jsonStarted(now + 10),
+			finished: jsonFinished(now+11, true, metadata.Metadata{}),
+			passed:   []string{"good"},
+		},
+	}
+	cases := []struct {
+		name      string
+		start     int64
+		end       int64
+		batch     int
+		progress  *statepb.Grid
+		skipWrite bool
+		expected  []string
+		err       bool
+	}{
+		{
+			name:     "basically works",
+			start:    now,
+			end:      now + 100,
+			batch:    10,
+			expected: []string{"40", "30", "20", "10"},
+		},
+		{
+			name:     "only builds in range",
+			start:    now + 15,
+			end:      now + 35,
+			batch:    10,
+			expected: []string{"30", "20"},
+		},
+		{
+			name:     "batches",
+			start:    now + 15,
+			end:      now + 100,
+			batch:    1,
+			expected: []string{"40", "30", "20"},
+		},
+		{
+			name:  "resume",
+			start: now,
+			end:   now + 100,
+			batch: 1,
+			progress: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "40", Started: float64(now+40) * 1000},
+					{Build: "30", Started: float64(now+30) * 1000},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{Name: "Overall", Id: "Overall"},
+						cell{result: statuspb.TestStatus_PASS},
+						cell{result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+			expected: []string{"40", "30", "20", "10"},
+		},
+		{
+			name:      "do not write when requested",
+			start:     now,
+			end:       now + 100,
+			batch:     10,
+			skipWrite: true,
+		},
+		{
+			name:  "reject empty range",
+			start: now + 100,
+			end:   now,
+			batch: 10,
+			err:   true,
+		},
+		{
+			name:  "reject empty batch",
+			start: now,
+			end:   now + 100,
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client := fakeUploadClient{
+				fakeUploader: fakeUploader{},
+				fakeClient: fakeClient{
+					fakeLister: fakeLister{},
+					fakeOpener: fakeOpener{},
+				},
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			fi := client.fakeLister[buildsPath]
+			for _, build := range client.addBuilds(buildsPath, builds...) {
+				fi.objects = append(fi.objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.fakeLister[buildsPath] = fi
+			if tc.progress != nil {
+				client.fakeOpener[progressPath] = fakeObject{data: string(mustGrid(tc.progress))}
+			}
+
+			err := backfillGroup(
+				ctx,
+				logrus.WithField("test", tc.name),
+				client,
+				&group,
+				gridPath,
+				progressPath,
+				time.Unix(tc.start, 0),
+				time.Unix(tc.end, 0),
+				tc.batch,
+				0,
+				time.Minute,
+				2,
+				!tc.skipWrite,
+			)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("backfillGroup() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("backfillGroup() failed to return an error")
+			case tc.skipWrite:
+				if len(client.fakeUploader) > 0 {
+					t.Errorf("backfillGroup() wrote %v when asked not to", client.fakeUploader)
+				}
+			default:
+				for _, p := range []gcs.Path{gridPath, progressPath} {
+					up, ok := client.fakeUploader[p]
+					if !ok {
+						t.Fatalf("backfillGroup() failed to write %s", p)
+					}
+					grid, err := downloadGrid(ctx, fakeOpener{p: {data: string(up.buf)}}, p)
+					if err != nil {
+						t.Fatalf("downloadGrid(%s) got unexpected error: %v", p, err)
+					}
+					var actual []string
+					for _, col := range grid.Columns {
+						actual = append(actual, col.Build)
+					}
+					if diff := cmp.Diff(tc.expected, actual); diff != "" {
+						t.Errorf("backfillGroup() wrote %s with unexpected column diff (-want +got):\n%s", p, diff)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOlderBuilds(t *testing.T) {
+	path := newPathOrDie("gs://bucket/job/")
+	var builds []gcs.Build
+	for _, id := range []string{"30", "20", "10"} {
+		builds = append(builds, gcs.Build{Path: *resolveOrDie(&path, id+"/")})
+	}
+	cases := []struct {
+		name     string
+		id       string
+		expected []string
+	}{
+		{
+			name:     "after the build",
+			id:       "20",
+			expected: []string{"10"},
+		},
+		{
+			name:     "missing build",
+			id:       "25",
+			expected: []string{"20", "10"},
+		},
+		{
+			name: "oldest build",
+			id:   "10",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for _, b := range olderBuilds(builds, tc.id) {
+				actual = append(actual, b.Build())
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("olderBuilds(%q) got unexpected diff (-want +got):\n%s", tc.id, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// A Querier runs standard SQL queries, returning the value of each column of each row.
+type Querier interface {
+	Query(ctx context.Context, project, query string, params ...*bigquery.QueryParameter) ([]map[string]string, error)
+}
+
+// BigQueryClient runs queries with the BigQuery API.
+type BigQueryClient struct {
+	service *bigquery.Service
+}
+
+// NewBigQueryClient runs queries with the options, such as option.WithCredentialsFile.
+func NewBigQueryClient(ctx context.Context, opts ...option.ClientOption) (*BigQueryClient, error) {
+	service, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &BigQueryClient{service: service}, nil
+}
+
+// Query runs the query in the project, waiting for and reading every page of its results.
+func (c *BigQueryClient) Query(ctx context.Context, project, query string, params ...*bigquery.QueryParameter) ([]map[string]string, error) {
+	legacy := false
+	resp, err := c.service.Jobs.Query(project, &bigquery.QueryRequest{
+		Query:           query,
+		UseLegacySql:    &legacy,
+		ParameterMode:   "NAMED",
+		QueryParameters: params,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	rows := rowValues(resp.Schema, resp.Rows)
+	complete, token := resp.JobComplete, resp.PageToken
+	for !complete || token != "" {
+		if resp.JobReference == nil {
+			return nil, fmt.Errorf("incomplete query without a job")
+		}
+		call := c.service.Jobs.GetQueryResults(project, resp.JobReference.JobId).Context(ctx)
+		if loc := resp.JobReference.Location; loc != "" {
+			call = call.Location(loc)
+		}
+		if token != "" {
+			call = call.PageToken(token)
+		}
+		page, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("get results: %w", err)
+		}
+		if page.JobComplete {
+			rows = append(rows, rowValues(page.Schema, page.Rows)...)
+		}
+		complete, token = page.JobComplete, page.PageToken
+	}
+	return rows, nil
+}
+
+// rowValues maps the name of each field in the schema to its value in each row, skipping nulls and nested fields.
+func rowValues(schema *bigquery.TableSchema, rows []*bigquery.TableRow) []map[string]string {
+	if schema == nil {
+		schema = &bigquery.TableSchema{}
+	}
+	out := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		values := map[string]string{}
+		for i, f := range row.F {
+			if i >= len(schema.Fields) {
+				break
+			}
+			if s, ok := f.V.(string); ok {
+				values[schema.Fields[i].Name] = s
+			}
+		}
+		out = append(out, values)
+	}
+	return out
+}
+
+// BigQuery returns a GroupUpdater that reads groups with a bigquery_config result source with the querier.
+//
+// Updates every other group with next.
+func BigQuery(groupTimeout time.Duration, querier Querier, write bool, cache *GridCache, next GroupUpdater) GroupUpdater {
+	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		bq := tg.GetResultSource().GetBigqueryConfig()
+		if bq == nil {
+			return next(parent, log, client, tg, gridPath)
+		}
+		ctx, cancel := context.WithTimeout(parent, updateTimeout(tg, groupTimeout))
+		defer cancel()
+		old, err := cache.download(ctx, client, gridPath)
+		if err != nil {
+			log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
+		}
+		const maxCols = 50
+		grid, err := queryGrid(ctx, log, querier, tg, old, maxCols)
+		if err != nil {
+			return err
+		}
+		return writeGrid(ctx, log, client, gridPath, grid, write, cache)
+	}
+}
+
+// queryGrid adds up to maxCols new columns from the query of the group to the recent columns of the old grid, if any.
+func queryGrid(ctx context.Context, log logrus.FieldLogger, querier Querier, tg *configpb.TestGroup, old *statepb.Grid, maxCols int) (*statepb.Grid, error) {
+	bq := tg.GetResultSource().GetBigqueryConfig()
+	oldCols, since := recentColumns(tg, old)
+	rows, err := querier.Query(ctx, bq.GetProject(), bq.GetQuery(),
+		&bigquery.QueryParameter{
+			Name:           "since",
+			ParameterType:  &bigquery.QueryParameterType{Type: "TIMESTAMP"},
+			ParameterValue: &bigquery.QueryParameterValue{Value: since.UTC().Format("2006-01-02 15:04:05.999999 UTC")},
+		},
+		&bigquery.QueryParameter{
+			Name:           "group",
+			ParameterType:  &bigquery.QueryParameterType{Type: "STRING"},
+			ParameterValue: &bigquery.QueryParameterValue{Value: tg.GetName()},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	log.WithField("rows", len(rows)).Debug("Queried results")
+	newCols, err := queryColumns(ctx, log, rows)
+	if err != nil {
+		return nil, fmt.Errorf("convert rows: %w", err)
+	}
+	if len(newCols) > maxCols {
+		newCols = newCols[:maxCols]
+	}
+	cols := mergeColumns(newCols, oldCols)
+	cols = thinColumns(cols, tg.ColumnRetention, time.Now())
+	return constructGrid(log, tg, cols), nil
+}
+
+// recentColumns returns the finished columns of the old grid within the days_of_results of the group.
+//
+// Also returns when to read new columns since, which is when the newest of these columns started.
+func recentColumns(tg *configpb.TestGroup, old *statepb.Grid) ([]inflatedColumn, time.Time) {
+	dur := days(7)
+	if tg.DaysOfResults > 0 {
+		dur = days(float64(tg.DaysOfResults))
+	}
+	since := time.Now().Add(-dur)
+	var oldCols []inflatedColumn
+	if old != nil {
+		oldCols = truncateRunning(inflateGrid(old, since, time.Now().Add(-4*time.Hour)))
+	}
+	if len(oldCols) > 0 {
+		since = time.Unix(int64(oldCols[0].column.Started/1000), 0)
+	}
+	return oldCols, since
+}
+
+// queryColumns returns a column for each build in the rows, newest first.
+//
+// Each column starts with its earliest result, failing its overall cell when any of its tests fail.
+func queryColumns(ctx context.Context, log logrus.FieldLogger, rows []map[string]string) ([]inflatedColumn, error) {
+	cols := map[string]*inflatedColumn{}
+	for i, row := range rows {
+		build, name := row["build"], row["test_name"]
+		if build == "" || name == "" {
+			return nil, fmt.Errorf("row %d: missing build or test_name", i)
+		}
+		started, err := strconv.ParseFloat(row["started"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: bad started %q: %w", i, row["started"], err)
+		}
+		status, err := queryStatus(row["status"])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		c := cell{
+			result:  status,
+			message: row["message"],
+		}
+		if d := row["duration"]; d != "" {
+			seconds, err := strconv.ParseFloat(d, 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: bad duration %q: %w", i, d, err)
+			}
+			c.metrics = setElapsed(nil, seconds)
+		}
+		if c.message != "" && result.IsFailingResult(status) {
+			c.icon = "F"
+		}
+		col, ok := cols[build]
+		if !ok {
+			col = &inflatedColumn{
+				column: &statepb.Column{Build: build, Started: started * 1000},
+				cells:  map[string]cell{},
+			}
+			cols[build] = col
+		}
+		if started*1000 < col.column.Started {
+			col.column.Started = started * 1000
+		}
+		if err := addCell(ctx, log, build, col.cells, name, &c); err != nil {
+			return nil, err
+		}
+	}
+	out := make([]inflatedColumn, 0, len(cols))
+	for _, col := range cols {
+		overall := cell{result: statuspb.TestStatus_PASS}
+		var failures int
+		for _, c := range col.cells {
+			if result.IsFailingResult(c.result) {
+				failures++
+			}
+		}
+		if failures > 0 {
+			overall.result = statuspb.TestStatus_FAIL
+			overall.message = fmt.Sprintf("%d of %d tests failed", failures, len(col.cells))
+		}
+		col.cells["Overall"] = overall
+		out = append(out, *col)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].column.Started != out[j].column.Started {
+			return out[i].column.Started > out[j].column.Started
+		}
+		return out[i].column.Build > out[j].column.Build
+	})
+	return out, nil
+}
+
+// queryStatus returns the status of a TestStatus name or a common alias, ignoring case.
+func queryStatus(s string) (statuspb.TestStatus, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	switch s {
+	case "PASSED", "SUCCESS":
+		return statuspb.TestStatus_PASS, nil
+	case "FAILED", "FAILURE", "ERROR":
+		return statuspb.TestStatus_FAIL, nil
+	case "SKIPPED":
+		return statuspb.TestStatus_PASS_WITH_SKIPS, nil
+	case "TIMEOUT":
+		return statuspb.TestStatus_TIMED_OUT, nil
+	}
+	if v, ok := statuspb.TestStatus_value[s]; ok {
+		return statuspb.TestStatus(v), nil
+	}
+	return statuspb.TestStatus_NO_RESULT, fmt.Errorf("unknown status %q", s)
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestBigQueryClient(t *testing.T) {
+	var queries []bigquery.QueryRequest
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		schema := map[string]interface{}{
+			"fields": []map[string]string{{"name": "build"}, {"name": "test_name"}},
+		}
+		var resp map[string]interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/projects/my-project/queries"):
+			var req bigquery.QueryRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			queries = append(queries, req)
+			resp = map[string]interface{}{
+				"jobComplete":  false,
+				"jobReference": map[string]string{"jobId": "job", "location": "US"},
+			}
+		case strings.HasSuffix(r.URL.Path, "/projects/my-project/queries/job"):
+			token := r.URL.Query().Get("pageToken")
+			pages = append(pages, token)
+			if loc := r.URL.Query().Get("location"); loc != "US" {
+				t.Errorf("GetQueryResults() got location %q, want US", loc)
+			}
+			resp = map[string]interface{}{
+				"jobComplete": true,
+				"schema":      schema,
+				"rows": []interface{}{
+					map[string]interface{}{"f": []interface{}{map[string]interface{}{"v": "1" + token}, map[string]interface{}{"v": "foo"}}},
+					map[string]interface{}{"f": []interface{}{map[string]interface{}{"v": "2" + token}, map[string]interface{}{"v": nil}}},
+				},
+			}
+			if token == "" {
+				resp["pageToken"] = "next"
+			}
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewBigQueryClient(ctx, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewBigQueryClient() got unexpected error: %v", err)
+	}
+	param := &bigquery.QueryParameter{
+		Name:           "group",
+		ParameterType:  &bigquery.QueryParameterType{Type: "STRING"},
+		ParameterValue: &bigquery.QueryParameterValue{Value: "hello"},
+	}
+	got, err := client.Query(ctx, "my-project", "SELECT build, test_name FROM results", param)
+	if err != nil {
+		t.Fatalf("Query() got unexpected error: %v", err)
+	}
+	want := []map[string]string{
+		{"build": "1", "test_name": "foo"},
+		{"build": "2"},
+		{"build": "1next", "test_name": "foo"},
+		{"build": "2next"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Query() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if len(queries) != 1 || queries[0].Query != "SELECT build, test_name FROM results" || queries[0].ParameterMode != "NAMED" || len(queries[0].QueryParameters) != 1 {
+		t.Errorf("Query() sent unexpected requests: %v", queries)
+	}
+	if diff := cmp.Diff([]string{"", "next"}, pages); diff != "" {
+		t.Errorf("Query() read unexpected pages (-want +got):\n%s", diff)
+	}
+}
+
+func TestQueryStatus(t *testing.T) {
+	cases := []struct {
+		status string
+		want   statuspb.TestStatus
+		err    bool
+	}{
+		{status: "PASS", want: statuspb.TestStatus_PASS},
+		{status: "passed", want: statuspb.TestStatus_PASS},
+		{status: "Failed", want: statuspb.TestStatus_FAIL},
+		{status: "error", want: statuspb.TestStatus_FAIL},
+		{status: "skipped", want: statuspb.TestStatus_PASS_WITH_SKIPS},
+		{status: "timeout", want: statuspb.TestStatus_TIMED_OUT},
+		{status: "flaky", want: statuspb.TestStatus_FLAKY},
+		{status: "ABORTED", want: statuspb.TestStatus_ABORTED},
+		{status: "meh", err: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.status, func(t *testing.T) {
+			got, err := queryStatus(tc.status)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("queryStatus() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("queryStatus() failed to return an error")
+			case got != tc.want:
+				t.Errorf("queryStatus() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryColumns(t *testing.T) {
+	cases := []struct {
+		name string
+		rows []map[string]string
+		want []inflatedColumn
+		err  bool
+	}{
+		{
+			name: "empty",
+			want: []inflatedColumn{},
+		},
+		{
+			name: "columns per build",
+			rows: []map[string]string{
+				{"build": "1", "started": "1.0E2", "test_name": "foo", "status": "PASSED", "duration": "60"},
+				{"build": "2", "started": "200", "test_name": "foo", "status": "FAILED", "message": "boom"},
+				{"build": "2", "started": "190", "test_name": "bar", "status": "PASSED"},
+				{"build": "2", "started": "210", "test_name": "bar", "status": "PASSED"},
+			},
+			want: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2", Started: 190000},
+					cells: map[string]cell{
+						"Overall": {result: statuspb.TestStatus_FAIL, message: "1 of 3 tests failed"},
+						"foo":     {result: statuspb.TestStatus_FAIL, message: "boom", icon: "F"},
+						"bar":     {result: statuspb.TestStatus_PASS},
+						"bar [1]": {result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1", Started: 100000},
+					cells: map[string]cell{
+						"Overall": {result: statuspb.TestStatus_PASS},
+						"foo": {
+							result:  statuspb.TestStatus_PASS,
+							metrics: map[string]float64{elapsedKey: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing build",
+			rows: []map[string]string{{"started": "100", "test_name": "foo", "status": "PASSED"}},
+			err:  true,
+		},
+		{
+			name: "bad started",
+			rows: []map[string]string{{"build": "1", "started": "yesterday", "test_name": "foo", "status": "PASSED"}},
+			err:  true,
+		},
+		{
+			name: "bad status",
+			rows: []map[string]string{{"build": "1", "started": "100", "test_name": "foo", "status": "meh"}},
+			err:  true,
+		},
+		{
+			name: "bad duration",
+			rows: []map[string]string{{"build": "1", "started": "100", "test_name": "foo", "status": "PASSED", "duration": "long"}},
+			err:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := queryColumns(context.Background(), logrus.WithField("test", tc.name), tc.rows)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("queryColumns() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatalf("queryColumns() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(inflatedColumn{}, cell{}), protocmp.Transform()); diff != "" {
+				t.Errorf("queryColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeQuerier struct {
+	rows    []map[string]string
+	project string
+	query   string
+	params  map[string]string
+}
+
+func (fq *fakeQuerier) Query(_ context.Context, project, query string, params ...*bigquery.QueryParameter) ([]map[string]string, error) {
+	fq.project, fq.query = project, query
+	fq.params = map[string]string{}
+	for _, p := range params {
+		fq.params[p.Name] = p.ParameterValue.Value
+	}
+	return fq.rows, nil
+}
+
+func TestQueryGrid(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) float64 {
+		return float64(now.Add(-time.Duration(h) * time.Hour).Unix())
+	}
+	tg := &configpb.TestGroup{
+		Name:          "group",
+		DaysOfResults: 1,
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_BigqueryConfig{
+				BigqueryConfig: &configpb.BigQueryConfig{
+					Project: "my-project",
+					Query:   "SELECT * FROM results WHERE started > @since",
+				},
+			},
+		},
+	}
+	row := func(build string, hours int) map[string]string {
+		return map[string]string{
+			"build":     build,
+			"started":   strconv.FormatFloat(hoursAgo(hours), 'E', -1, 64),
+			"test_name": "good",
+			"status":    "PASS",
+		}
+	}
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: hoursAgo(8) * 1000},
+			{Build: "1", Started: hoursAgo(10) * 1000},
+		},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "good", Id: "good"}, cell{result: statuspb.TestStatus_PASS}, cell{result: statuspb.TestStatus_PASS}),
+		},
+	}
+	cases := []struct {
+		name      string
+		old       *statepb.Grid
+		rows      []map[string]string
+		wantSince time.Time
+		want      []string
+	}{
+		{
+			name:      "new grid",
+			rows:      []map[string]string{row("4", 1), row("3", 5)},
+			wantSince: now.Add(-24 * time.Hour),
+			want:      []string{"4", "3"},
+		},
+		{
+			name:      "merge with old columns",
+			old:       old,
+			rows:      []map[string]string{row("4", 1), row("3", 5), row("2", 8)},
+			wantSince: time.Unix(int64(hoursAgo(8)), 0),
+			want:      []string{"4", "3", "2", "1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier := fakeQuerier{rows: tc.rows}
+			grid, err := queryGrid(context.Background(), logrus.WithField("test", tc.name), &querier, tg, tc.old, 10)
+			if err != nil {
+				t.Fatalf("queryGrid() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("queryGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if querier.project != "my-project" || querier.query != "SELECT * FROM results WHERE started > @since" || querier.params["group"] != "group" {
+				t.Errorf("queryGrid() sent unexpected query: %#v", querier)
+			}
+			since, err := time.Parse("2006-01-02 15:04:05.999999 MST", querier.params["since"])
+			if err != nil {
+				t.Fatalf("queryGrid() sent bad since %q: %v", querier.params["since"], err)
+			}
+			if d := since.Sub(tc.wantSince); d < -time.Minute || d > time.Minute {
+				t.Errorf("queryGrid() got since %v, want %v", since, tc.wantSince)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+)
+
+var gridCacheLookups = metrics.NewCounter("testgrid_updater_grid_cache_lookups_total", "Number of grids looked up in the grid cache", "result")
+
+// GridCache keeps the grids an updater recently wrote in memory between cycles.
+//
+// The next update of the group reuses the cached grid instead of downloading
+// and decompressing it, so long as the object still holds what was written.
+// Evicts the least recently used grids once their encoded size exceeds the limit.
+// A nil cache keeps nothing.
+type GridCache struct {
+	lock    sync.Mutex
+	max     int64
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type cachedGrid struct {
+	path string
+	crc  uint32
+	size int64
+	grid *statepb.Grid
+}
+
+// NewGridCache returns a cache holding up to maxBytes of encoded grids, or nil when maxBytes is not positive.
+func NewGridCache(maxBytes int64) *GridCache {
+	if maxBytes <= 0 {
+		return nil
+	}
+	return &GridCache{
+		max:     maxBytes,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// download returns the grid at the path, from the cache when its checksum still matches.
+func (gc *GridCache) download(ctx context.Context, client gcs.Client, path gcs.Path) (*statepb.Grid, error) {
+	if gc == nil {
+		return downloadGrid(ctx, client, path)
+	}
+	if attrs, err := client.Stat(ctx, path); err == nil {
+		if grid := gc.take(path, attrs.CRC32C); grid != nil {
+			gridCacheLookups.Add(1, "hit")
+			return grid, nil
+		}
+	}
+	gridCacheLookups.Add(1, "miss")
+	return downloadGrid(ctx, client, path)
+}
+
+// take removes and returns the grid cached for the path when its written bytes had the checksum.
+//
+// The caller owns the grid, which the cache forgets in case the caller modifies it.
+func (gc *GridCache) take(path gcs.Path, crc uint32) *statepb.Grid {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	elem, ok := gc.entries[path.String()]
+	if !ok {
+		return nil
+	}
+	gc.remove(elem)
+	entry := elem.Value.(*cachedGrid)
+	if entry.crc != crc {
+		return nil
+	}
+	return entry.grid
+}
+
+// put caches the grid after writing buf to the path.
+//
+// The caller must not modify the grid afterwards.
+func (gc *GridCache) put(path gcs.Path, buf []byte, grid *statepb.Grid) {
+	if gc == nil {
+		return
+	}
+	size := int64(proto.Size(grid))
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	if elem, ok := gc.entries[path.String()]; ok {
+		gc.remove(elem)
+	}
+	if size > gc.max {
+		return
+	}
+	gc.entries[path.String()] = gc.lru.PushFront(&cachedGrid{
+		path: path.String(),
+		crc:  checksum(buf),
+		size: size,
+		grid: grid,
+	})
+	gc.size += size
+	for gc.size > gc.max {
+		gc.remove(gc.lru.Back())
+	}
+}
+
+func (gc *GridCache) remove(elem *list.Element) {
+	entry := elem.Value.(*cachedGrid)
+	gc.lru.Remove(elem)
+	delete(gc.entries, entry.path)
+	gc.size -= entry.size
+}
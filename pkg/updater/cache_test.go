@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"hash/crc32"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestGridCache(t *testing.T) {
+	grid := func(name string) *statepb.Grid {
+		return &statepb.Grid{Rows: []*statepb.Row{{Name: name}}}
+	}
+	foo := newPathOrDie("gs://bucket/grid/foo")
+	bar := newPathOrDie("gs://bucket/grid/bar")
+	size := int64(proto.Size(grid(foo.String())))
+	crc := func(buf []byte) uint32 {
+		return crc32.Checksum(buf, crc32.MakeTable(crc32.Castagnoli))
+	}
+
+	cases := []struct {
+		name  string
+		max   int64
+		puts  []gcs.Path
+		take  gcs.Path
+		crc   uint32
+		want  *statepb.Grid
+		after int
+	}{
+		{
+			name: "disabled",
+			puts: []gcs.Path{foo},
+			take: foo,
+			crc:  crc([]byte(foo.String())),
+		},
+		{
+			name:  "basically works",
+			max:   2 * size,
+			puts:  []gcs.Path{foo, bar},
+			take:  foo,
+			crc:   crc([]byte(foo.String())),
+			want:  grid(foo.String()),
+			after: 1,
+		},
+		{
+			name:  "changed object",
+			max:   2 * size,
+			puts:  []gcs.Path{foo, bar},
+			take:  foo,
+			crc:   crc([]byte("something else")),
+			after: 1,
+		},
+		{
+			name:  "evict least recently used",
+			max:   size,
+			puts:  []gcs.Path{foo, bar},
+			take:  foo,
+			crc:   crc([]byte(foo.String())),
+			after: 1,
+		},
+		{
+			name:  "rewrite replaces",
+			max:   2 * size,
+			puts:  []gcs.Path{foo, bar, foo},
+			take:  foo,
+			crc:   crc([]byte(foo.String())),
+			want:  grid(foo.String()),
+			after: 1,
+		},
+		{
+			name:  "missing",
+			max:   2 * size,
+			puts:  []gcs.Path{bar},
+			take:  foo,
+			after: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewGridCache(tc.max)
+			for _, p := range tc.puts {
+				cache.put(p, []byte(p.String()), grid(p.String()))
+			}
+			if cache == nil {
+				if tc.want != nil {
+					t.Fatal("NewGridCache() returned nil")
+				}
+				return
+			}
+			got := cache.take(tc.take, tc.crc)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("take() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if n := len(cache.entries); n != tc.after {
+				t.Errorf("take() left %d entries, want %d", n, tc.after)
+			}
+			if want := int64(tc.after) * size; cache.size != want {
+				t.Errorf("take() left size %d, want %d", cache.size, want)
+			}
+		})
+	}
+}
+
+func TestGridCacheDownload(t *testing.T) {
+	ctx := context.Background()
+	path := newPathOrDie("gs://bucket/grid/foo")
+	cached := &statepb.Grid{Rows: []*statepb.Row{{Name: "cached"}}}
+	stored := &statepb.Grid{Rows: []*statepb.Row{{Name: "stored"}}}
+	buf, err := marshalGrid(stored)
+	if err != nil {
+		t.Fatalf("marshalGrid(): %v", err)
+	}
+	client := fakeUploadClient{
+		fakeClient: fakeClient{
+			fakeOpener: fakeOpener{path: {data: string(buf)}},
+		},
+		fakeStater: fakeStater{},
+	}
+	stat := func(crc uint32) {
+		client.fakeStater[path] = fakeStat{attrs: storage.ObjectAttrs{CRC32C: crc}}
+	}
+
+	cache := NewGridCache(1 << 20)
+	cache.put(path, buf, cached)
+	stat(crc32.Checksum(buf, crc32.MakeTable(crc32.Castagnoli)))
+	got, err := cache.download(ctx, client, path)
+	if err != nil {
+		t.Fatalf("download(): %v", err)
+	}
+	if diff := cmp.Diff(cached, got, protocmp.Transform()); diff != "" {
+		t.Errorf("download() got unexpected diff from the cached grid (-want +got):\n%s", diff)
+	}
+
+	cache.put(path, buf, cached)
+	stat(0)
+	got, err = cache.download(ctx, client, path)
+	if err != nil {
+		t.Fatalf("download(changed): %v", err)
+	}
+	if diff := cmp.Diff(stored, got, protocmp.Transform()); diff != "" {
+		t.Errorf("download(changed) got unexpected diff from the stored grid (-want +got):\n%s", diff)
+	}
+}
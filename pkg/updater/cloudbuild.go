@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	cloudbuild "google.golang.org/api/cloudbuild/v1"
+	"google.golang.org/api/option"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// A BuildLister lists the Cloud Build builds of a trigger created since a time, newest first.
+type BuildLister interface {
+	ListBuilds(ctx context.Context, project, trigger string, since time.Time) ([]*cloudbuild.Build, error)
+}
+
+// CloudBuildClient lists builds with the Cloud Build API.
+type CloudBuildClient struct {
+	service *cloudbuild.Service
+}
+
+// NewCloudBuildClient lists builds with the options, such as option.WithCredentialsFile.
+func NewCloudBuildClient(ctx context.Context, opts ...option.ClientOption) (*CloudBuildClient, error) {
+	service, err := cloudbuild.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudBuildClient{service: service}, nil
+}
+
+// ListBuilds reads every page of the builds of the trigger created since then.
+func (c *CloudBuildClient) ListBuilds(ctx context.Context, project, trigger string, since time.Time) ([]*cloudbuild.Build, error) {
+	filter := fmt.Sprintf("trigger_id=%q AND create_time>=%q", trigger, since.UTC().Format(time.RFC3339))
+	var builds []*cloudbuild.Build
+	err := c.service.Projects.Builds.List(project).Filter(filter).Pages(ctx, func(resp *cloudbuild.ListBuildsResponse) error {
+		builds = append(builds, resp.Builds...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return builds, nil
+}
+
+// CloudBuild returns a GroupUpdater that reads groups with a cloud_build_config result source with the lister.
+//
+// Reads the junit artifacts of up to concurrency builds at a time, spending up to buildTimeout on each.
+// Updates every other group with next.
+func CloudBuild(groupTimeout, buildTimeout time.Duration, concurrency int, lister BuildLister, write bool, cache *GridCache, next GroupUpdater) GroupUpdater {
+	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		if tg.GetResultSource().GetCloudBuildConfig() == nil {
+			return next(parent, log, client, tg, gridPath)
+		}
+		ctx, cancel := context.WithTimeout(parent, updateTimeout(tg, groupTimeout))
+		defer cancel()
+		old, err := cache.download(ctx, client, gridPath)
+		if err != nil {
+			log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
+		}
+		const maxCols = 50
+		grid, err := cloudBuildGrid(ctx, log, lister, client, tg, old, maxCols, buildTimeout, concurrency)
+		if err != nil {
+			return err
+		}
+		return writeGrid(ctx, log, client, gridPath, grid, write, cache)
+	}
+}
+
+// cloudBuildGrid adds up to maxCols new builds of the trigger of the group to the recent columns of the old grid, if any.
+func cloudBuildGrid(ctx context.Context, log logrus.FieldLogger, lister BuildLister, client gcs.Downloader, tg *configpb.TestGroup, old *statepb.Grid, maxCols int, buildTimeout time.Duration, concurrency int) (*statepb.Grid, error) {
+	cb := tg.GetResultSource().GetCloudBuildConfig()
+	oldCols, since := recentColumns(tg, old)
+	builds, err := lister.ListBuilds(ctx, cb.GetProject(), cb.GetTriggerId(), since)
+	if err != nil {
+		return nil, fmt.Errorf("list builds: %w", err)
+	}
+	log.WithField("builds", len(builds)).Debug("Listed builds")
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].CreateTime > builds[j].CreateTime
+	})
+	if len(builds) > maxCols {
+		builds = builds[:maxCols]
+	}
+	newCols, err := cloudBuildColumns(ctx, log, client, tg, builds, buildTimeout, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	cols := mergeColumns(newCols, oldCols)
+	cols = thinColumns(cols, tg.ColumnRetention, time.Now())
+	return constructGrid(log, tg, cols), nil
+}
+
+// cloudBuildColumns concurrently reads the results of each build into a column.
+func cloudBuildColumns(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, tg *configpb.TestGroup, builds []*cloudbuild.Build, buildTimeout time.Duration, concurrency int) ([]inflatedColumn, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var heads []string
+	for _, h := range tg.ColumnHeader {
+		heads = append(heads, h.ConfigurationValue)
+	}
+	cols := make([]inflatedColumn, len(builds))
+	errs := make([]error, len(builds))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		nameCfg := makeNameConfig(tg)
+		methodCfg := makeMethodConfig(tg)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				b := builds[idx]
+				buildCtx, cancel := context.WithTimeout(ctx, buildTimeout)
+				result, err := cloudBuildResult(buildCtx, client, b)
+				cancel()
+				if err != nil {
+					errs[idx] = fmt.Errorf("read %s: %w", b.Id, err)
+					continue
+				}
+				col, err := convertResult(ctx, log, nameCfg, b.Id, heads, tg.ShortTextMetric, tg.ArtifactLinks, tg.GetCustomEvaluatorRuleSet().GetRules(), methodCfg, tg.GetKeepSkipped(), *result)
+				if err != nil {
+					errs[idx] = fmt.Errorf("convert %s: %w", b.Id, err)
+					continue
+				}
+				cols[idx] = *col
+			}
+		}()
+	}
+	for i := range builds {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cols, nil
+}
+
+// cloudBuildResult returns the result of the build, reading junit files from its artifacts location, if any.
+//
+// The substitutions of the build, along with its comma-separated tags, become the finished metadata.
+func cloudBuildResult(ctx context.Context, client gcs.Downloader, b *cloudbuild.Build) (*gcsResult, error) {
+	started := b.StartTime
+	if started == "" {
+		started = b.CreateTime
+	}
+	when, err := time.Parse(time.RFC3339Nano, started)
+	if err != nil {
+		return nil, fmt.Errorf("bad start time %q: %w", started, err)
+	}
+	meta := metadata.Metadata{}
+	for k, v := range b.Substitutions {
+		meta[k] = v
+	}
+	if len(b.Tags) > 0 {
+		meta["tags"] = strings.Join(b.Tags, ",")
+	}
+	result := gcsResult{
+		job:   b.BuildTriggerId,
+		build: b.Id,
+	}
+	result.started.Timestamp = when.Unix()
+	result.started.RepoCommit = b.Substitutions["COMMIT_SHA"]
+	result.finished.Metadata = meta
+	if b.FinishTime != "" {
+		finished, err := time.Parse(time.RFC3339Nano, b.FinishTime)
+		if err != nil {
+			return nil, fmt.Errorf("bad finish time %q: %w", b.FinishTime, err)
+		}
+		ts := finished.Unix()
+		passed := b.Status == "SUCCESS"
+		result.finished.Timestamp = &ts
+		result.finished.Passed = &passed
+		switch b.Status {
+		case "CANCELLED", "EXPIRED":
+			result.finished.Result = metadata.ResultAborted
+		case "TIMEOUT":
+			result.finished.Result = metadata.ResultTimedOut
+		}
+	}
+	loc := artifactsLocation(b)
+	if loc == "" {
+		return &result, nil
+	}
+	path, err := gcs.NewPath(loc)
+	if err != nil {
+		return nil, fmt.Errorf("bad artifacts location %q: %w", loc, err)
+	}
+	result.path = *path
+	result.suites, err = readSuites(ctx, client, gcs.Build{Path: *path})
+	if err != nil {
+		return nil, fmt.Errorf("suites: %w", err)
+	}
+	return &result, nil
+}
+
+// artifactsLocation returns the gs:// location the build uploads its artifacts to, expanding its substitutions.
+func artifactsLocation(b *cloudbuild.Build) string {
+	if b.Artifacts == nil || b.Artifacts.Objects == nil || b.Artifacts.Objects.Location == "" {
+		return ""
+	}
+	loc := os.Expand(b.Artifacts.Objects.Location, func(name string) string {
+		switch name {
+		case "BUILD_ID":
+			return b.Id
+		case "PROJECT_ID":
+			return b.ProjectId
+		}
+		if v, ok := b.Substitutions[name]; ok {
+			return v
+		}
+		return "$" + name
+	})
+	return strings.TrimSuffix(loc, "/") + "/"
+}
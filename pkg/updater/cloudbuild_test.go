@@ -0,0 +1,347 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	cloudbuild "google.golang.org/api/cloudbuild/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestArtifactsLocation(t *testing.T) {
+	cases := []struct {
+		name  string
+		build cloudbuild.Build
+		want  string
+	}{
+		{
+			name: "no artifacts",
+		},
+		{
+			name: "no objects",
+			build: cloudbuild.Build{
+				Artifacts: &cloudbuild.Artifacts{Images: []string{"gcr.io/my-project/image"}},
+			},
+		},
+		{
+			name: "adds trailing slash",
+			build: cloudbuild.Build{
+				Artifacts: &cloudbuild.Artifacts{
+					Objects: &cloudbuild.ArtifactObjects{Location: "gs://bucket/artifacts"},
+				},
+			},
+			want: "gs://bucket/artifacts/",
+		},
+		{
+			name: "expands substitutions",
+			build: cloudbuild.Build{
+				Id:            "123",
+				ProjectId:     "my-project",
+				Substitutions: map[string]string{"_SUITE": "unit"},
+				Artifacts: &cloudbuild.Artifacts{
+					Objects: &cloudbuild.ArtifactObjects{Location: "gs://$PROJECT_ID/${_SUITE}/$BUILD_ID/$UNKNOWN/"},
+				},
+			},
+			want: "gs://my-project/unit/123/$UNKNOWN/",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := artifactsLocation(&tc.build); got != tc.want {
+				t.Errorf("artifactsLocation() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCloudBuildColumns(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	stamp := func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	}
+	loc := newPathOrDie("gs://bucket/artifacts/123/")
+	client := fakeClient{
+		fakeLister: fakeLister{
+			loc: fakeIterator{
+				objects: []storage.ObjectAttrs{
+					{Name: "artifacts/123/junit.xml"},
+					{Name: "artifacts/123/build.log"},
+				},
+			},
+		},
+		fakeOpener: fakeOpener{
+			newPathOrDie("gs://bucket/artifacts/123/junit.xml"): {
+				data: `<testsuite><testcase name="good" time="60"/><testcase name="bad"><failure>boom</failure></testcase></testsuite>`,
+			},
+		},
+	}
+	tg := &configpb.TestGroup{
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ConfigurationValue: "Commit"},
+			{ConfigurationValue: "_SUITE"},
+			{ConfigurationValue: "tags"},
+		},
+	}
+	cases := []struct {
+		name   string
+		builds []*cloudbuild.Build
+		want   []inflatedColumn
+		err    bool
+	}{
+		{
+			name: "empty",
+			want: []inflatedColumn{},
+		},
+		{
+			name: "junit artifacts and metadata",
+			builds: []*cloudbuild.Build{
+				{
+					Id:             "123",
+					BuildTriggerId: "my-trigger",
+					Status:         "FAILURE",
+					CreateTime:     stamp(now.Add(-time.Hour)),
+					StartTime:      stamp(now.Add(-50 * time.Minute)),
+					FinishTime:     stamp(now.Add(-40 * time.Minute)),
+					Substitutions:  map[string]string{"COMMIT_SHA": "abc", "_SUITE": "unit"},
+					Tags:           []string{"nightly", "linux"},
+					Artifacts: &cloudbuild.Artifacts{
+						Objects: &cloudbuild.ArtifactObjects{Location: "gs://bucket/artifacts/$BUILD_ID", Paths: []string{"junit.xml"}},
+					},
+				},
+			},
+			want: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build:   "123",
+						Started: float64(now.Add(-50*time.Minute).Unix() * 1000),
+						Extra:   []string{"abc", "unit", "nightly,linux"},
+					},
+					cells: map[string]cell{
+						"Overall": {
+							result:  statuspb.TestStatus_FAIL,
+							metrics: setElapsed(nil, 600),
+						},
+						"good": {
+							result:  statuspb.TestStatus_PASS,
+							metrics: setElapsed(nil, 60),
+						},
+						"bad": {
+							result:  statuspb.TestStatus_FAIL,
+							message: "boom",
+							icon:    "F",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "running build without artifacts",
+			builds: []*cloudbuild.Build{
+				{
+					Id:         "456",
+					Status:     "QUEUED",
+					CreateTime: stamp(now.Add(-time.Minute)),
+				},
+			},
+			want: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build:   "456",
+						Started: float64(now.Add(-time.Minute).Unix() * 1000),
+						Extra:   []string{"", "", ""},
+					},
+					cells: map[string]cell{
+						"Overall": {
+							result:  statuspb.TestStatus_RUNNING,
+							message: "Build still running...",
+							icon:    "R",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cancelled build",
+			builds: []*cloudbuild.Build{
+				{
+					Id:         "789",
+					Status:     "CANCELLED",
+					CreateTime: stamp(now.Add(-time.Hour)),
+					StartTime:  stamp(now.Add(-time.Hour)),
+					FinishTime: stamp(now.Add(-time.Hour)),
+				},
+			},
+			want: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build:   "789",
+						Started: float64(now.Add(-time.Hour).Unix() * 1000),
+						Extra:   []string{"missing", "missing", "missing"},
+					},
+					cells: map[string]cell{
+						"Overall": {
+							result:  statuspb.TestStatus_ABORTED,
+							message: "Build aborted",
+							icon:    "A",
+							metrics: setElapsed(nil, 0),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "bad start time",
+			builds: []*cloudbuild.Build{
+				{
+					Id:         "123",
+					CreateTime: "yesterday",
+				},
+			},
+			err: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cloudBuildColumns(context.Background(), logrus.WithField("test", tc.name), client, tg, tc.builds, time.Minute, 2)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("cloudBuildColumns() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatalf("cloudBuildColumns() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(inflatedColumn{}, cell{}), protocmp.Transform()); diff != "" {
+				t.Errorf("cloudBuildColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeBuildLister struct {
+	builds  []*cloudbuild.Build
+	project string
+	trigger string
+	since   time.Time
+}
+
+func (fl *fakeBuildLister) ListBuilds(_ context.Context, project, trigger string, since time.Time) ([]*cloudbuild.Build, error) {
+	fl.project, fl.trigger, fl.since = project, trigger, since
+	return fl.builds, nil
+}
+
+func TestCloudBuildGrid(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) time.Time {
+		return now.Add(-time.Duration(h) * time.Hour)
+	}
+	tg := &configpb.TestGroup{
+		Name:          "group",
+		DaysOfResults: 1,
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_CloudBuildConfig{
+				CloudBuildConfig: &configpb.CloudBuildConfig{
+					Project:   "my-project",
+					TriggerId: "my-trigger",
+				},
+			},
+		},
+	}
+	build := func(id string, hours int) *cloudbuild.Build {
+		when := hoursAgo(hours).UTC().Format(time.RFC3339)
+		return &cloudbuild.Build{
+			Id:         id,
+			Status:     "SUCCESS",
+			CreateTime: when,
+			StartTime:  when,
+			FinishTime: when,
+		}
+	}
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: float64(hoursAgo(8).Unix() * 1000)},
+			{Build: "1", Started: float64(hoursAgo(10).Unix() * 1000)},
+		},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "Overall", Id: "Overall"}, cell{result: statuspb.TestStatus_PASS}, cell{result: statuspb.TestStatus_PASS}),
+		},
+	}
+	cases := []struct {
+		name      string
+		old       *statepb.Grid
+		builds    []*cloudbuild.Build
+		maxCols   int
+		wantSince time.Time
+		want      []string
+	}{
+		{
+			name:      "new grid",
+			builds:    []*cloudbuild.Build{build("3", 5), build("4", 1)},
+			maxCols:   10,
+			wantSince: hoursAgo(24),
+			want:      []string{"4", "3"},
+		},
+		{
+			name:      "merge with old columns",
+			old:       old,
+			builds:    []*cloudbuild.Build{build("4", 1), build("3", 5), build("2", 8)},
+			maxCols:   10,
+			wantSince: hoursAgo(8),
+			want:      []string{"4", "3", "2", "1"},
+		},
+		{
+			name:      "limit new columns",
+			builds:    []*cloudbuild.Build{build("4", 1), build("3", 5)},
+			maxCols:   1,
+			wantSince: hoursAgo(24),
+			want:      []string{"4"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lister := fakeBuildLister{builds: tc.builds}
+			grid, err := cloudBuildGrid(context.Background(), logrus.WithField("test", tc.name), &lister, fakeClient{}, tg, tc.old, tc.maxCols, time.Minute, 2)
+			if err != nil {
+				t.Fatalf("cloudBuildGrid() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("cloudBuildGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if lister.project != "my-project" || lister.trigger != "my-trigger" {
+				t.Errorf("cloudBuildGrid() listed %s/%s, want my-project/my-trigger", lister.project, lister.trigger)
+			}
+			if d := lister.since.Sub(tc.wantSince); d < -time.Minute || d > time.Minute {
+				t.Errorf("cloudBuildGrid() got since %v, want %v", lister.since, tc.wantSince)
+			}
+		})
+	}
+}
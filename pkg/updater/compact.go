@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"compress/zlib"
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Compact rewrites the grid of the named group without the columns started before cutoff.
+//
+// Truncates longer messages to maxMessage bytes when positive and recompresses
+// the grid at the best zlib compression level.
+// Only writes the grid when write is set, and fails if another writer updated it since reading it.
+// Returns the compressed size of the grid before and after.
+func Compact(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, gridPrefix, group string, cutoff time.Time, maxMessage int, write bool) (int64, int64, error) {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read config: %w", err)
+	}
+	tg := config.FindTestGroup(group, cfg)
+	if tg == nil {
+		return 0, 0, fmt.Errorf("test group %q not found", group)
+	}
+	gridPath, err := testGroupPath(configPath, gridPrefix, group)
+	if err != nil {
+		return 0, 0, fmt.Errorf("grid path: %w", err)
+	}
+	attrs, err := client.Stat(ctx, *gridPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("stat: %w", err)
+	}
+	cond := storage.Conditions{GenerationMatch: attrs.Generation}
+	client = client.If(&cond, &cond)
+	grid, err := downloadGrid(ctx, client, *gridPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("download: %w", err)
+	}
+	log := logrus.WithField("group", group).WithField("url", gridPath)
+	buf, err := marshalGridLevel(compactGrid(log, tg, grid, cutoff, maxMessage), zlib.BestCompression)
+	if err != nil {
+		return 0, 0, fmt.Errorf("marshal grid: %w", err)
+	}
+	if !write {
+		log.Debug("Skipping write")
+	} else if err := client.Upload(ctx, *gridPath, buf, gcs.DefaultAcl, "no-cache"); err != nil {
+		return 0, 0, fmt.Errorf("upload: %w", err)
+	}
+	return attrs.Size, int64(len(buf)), nil
+}
+
+// compactGrid returns the grid without the columns started before cutoff, truncating messages longer than maxMessage when positive.
+func compactGrid(log logrus.FieldLogger, tg *configpb.TestGroup, grid *statepb.Grid, cutoff time.Time, maxMessage int) *statepb.Grid {
+	var cols []inflatedColumn
+	for _, col := range inflateGrid(grid, cutoff, time.Unix(math.MaxInt64, 0)) {
+		if int64(col.column.Started) < cutoff.Unix()*1000 {
+			continue
+		}
+		if maxMessage > 0 {
+			for name, c := range col.cells {
+				if len(c.message) > maxMessage {
+					c.message = truncateMessage(c.message, maxMessage)
+					col.cells[name] = c
+				}
+			}
+		}
+		cols = append(cols, col)
+	}
+	log.WithFields(logrus.Fields{
+		"from": len(grid.Columns),
+		"to":   len(cols),
+	}).Debug("Dropped old columns")
+	return constructGrid(log, tg, cols)
+}
+
+// truncateMessage returns the first max bytes of the message followed by an ellipsis, without splitting a character.
+func truncateMessage(msg string, max int) string {
+	if len(msg) <= max {
+		return msg
+	}
+	for max > 0 && !utf8.RuneStart(msg[max]) {
+		max--
+	}
+	return msg[:max] + "..."
+}
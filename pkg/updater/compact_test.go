@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestCompactGrid(t *testing.T) {
+	now := time.Now().Round(time.Second)
+	col := func(build string, ago time.Duration, message string) inflatedColumn {
+		return inflatedColumn{
+			column: &statepb.Column{
+				Build:   build,
+				Started: float64(now.Add(-ago).Unix() * 1000),
+			},
+			cells: map[string]cell{
+				"Overall": {result: statuspb.TestStatus_PASS},
+				"test": {
+					result:  statuspb.TestStatus_FAIL,
+					message: message,
+				},
+			},
+		}
+	}
+	cases := []struct {
+		name       string
+		cols       []inflatedColumn
+		cutoff     time.Duration
+		maxMessage int
+		expected   []inflatedColumn
+	}{
+		{
+			name: "basically works",
+			cols: []inflatedColumn{
+				col("3", time.Hour, "hello"),
+				col("2", 2*time.Hour, "hello"),
+			},
+			cutoff: 3 * time.Hour,
+			expected: []inflatedColumn{
+				col("3", time.Hour, "hello"),
+				col("2", 2*time.Hour, "hello"),
+			},
+		},
+		{
+			name: "drop old columns",
+			cols: []inflatedColumn{
+				col("3", time.Hour, "hello"),
+				col("2", 2*time.Hour, "hello"),
+				col("1", 3*time.Hour, "hello"),
+			},
+			cutoff: 90 * time.Minute,
+			expected: []inflatedColumn{
+				col("3", time.Hour, "hello"),
+			},
+		},
+		{
+			name: "truncate messages",
+			cols: []inflatedColumn{
+				col("3", time.Hour, "hello world"),
+				col("2", 2*time.Hour, "hi"),
+			},
+			cutoff:     3 * time.Hour,
+			maxMessage: 5,
+			expected: []inflatedColumn{
+				col("3", time.Hour, "hello..."),
+				col("2", 2*time.Hour, "hi"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			log := logrus.WithField("test", tc.name)
+			var tg configpb.TestGroup
+			grid := constructGrid(log, &tg, tc.cols)
+			actual := compactGrid(log, &tg, grid, now.Add(-tc.cutoff), tc.maxMessage)
+			expected := constructGrid(log, &tg, tc.expected)
+			if diff := cmp.Diff(expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("compactGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		msg      string
+		max      int
+		expected string
+	}{
+		{
+			name:     "short",
+			msg:      "hello",
+			max:      5,
+			expected: "hello",
+		},
+		{
+			name:     "long",
+			msg:      "hello world",
+			max:      5,
+			expected: "hello...",
+		},
+		{
+			name:     "do not split characters",
+			msg:      "héllo",
+			max:      2,
+			expected: "h...",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := truncateMessage(tc.msg, tc.max); actual != tc.expected {
+				t.Errorf("truncateMessage(%q, %d) got %q, want %q", tc.msg, tc.max, actual, tc.expected)
+			}
+		})
+	}
+}
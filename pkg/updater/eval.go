@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	evalpb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// customStatus returns the computed status of the first rule matching the result, if any.
+//
+// Rules without comparisons never match.
+func customStatus(rules []*evalpb.Rule, r *junit.Result, props map[string][]string, status statuspb.TestStatus) (statuspb.TestStatus, bool) {
+	for _, rule := range rules {
+		cmps := rule.GetTestResultComparisons()
+		if len(cmps) == 0 {
+			continue
+		}
+		matched := true
+		for _, cmp := range cmps {
+			if !matchComparison(cmp, r, props, status) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.GetComputedStatus(), true
+		}
+	}
+	return status, false
+}
+
+// matchComparison returns true when the result has the compared value and it satisfies the comparison.
+func matchComparison(cmp *evalpb.TestResultComparison, r *junit.Result, props map[string][]string, status statuspb.TestStatus) bool {
+	var val string
+	switch info := cmp.GetTestResultInfo().(type) {
+	case *evalpb.TestResultComparison_PropertyKey:
+		vals := props[info.PropertyKey]
+		if len(vals) == 0 {
+			return false
+		}
+		val = vals[0]
+	case *evalpb.TestResultComparison_TestResultField:
+		v, ok := resultField(info.TestResultField, r, status)
+		if !ok {
+			return false
+		}
+		val = v
+	case *evalpb.TestResultComparison_TestResultErrorField:
+		if r.Failure == nil || info.TestResultErrorField != "message" {
+			return false
+		}
+		val = *r.Failure
+	default:
+		return false
+	}
+	return compare(cmp.GetComparison(), val)
+}
+
+// resultField returns the value of the named field of the result.
+func resultField(name string, r *junit.Result, status statuspb.TestStatus) (string, bool) {
+	switch name {
+	case "name":
+		return r.Name, true
+	case "classname":
+		return r.ClassName, true
+	case "time":
+		return strconv.FormatFloat(r.Time, 'f', -1, 64), true
+	case "message":
+		return r.Message(0), true
+	case "status":
+		return status.String(), true
+	}
+	return "", false
+}
+
+// compare returns true when the value satisfies the comparison, such as being less than its numerical_value.
+func compare(c *evalpb.Comparison, val string) bool {
+	switch want := c.GetComparisonValue().(type) {
+	case *evalpb.Comparison_NumericalValue:
+		got, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return false
+		}
+		switch c.GetOp() {
+		case evalpb.Comparison_OP_UNKNOWN, evalpb.Comparison_OP_EQ:
+			return got == want.NumericalValue
+		case evalpb.Comparison_OP_NE:
+			return got != want.NumericalValue
+		// The comparison value is the left operand, such as want < got for OP_LT.
+		case evalpb.Comparison_OP_LT:
+			return want.NumericalValue < got
+		case evalpb.Comparison_OP_LE:
+			return want.NumericalValue <= got
+		case evalpb.Comparison_OP_GT:
+			return want.NumericalValue > got
+		case evalpb.Comparison_OP_GE:
+			return want.NumericalValue >= got
+		}
+	case *evalpb.Comparison_StringValue:
+		switch c.GetOp() {
+		case evalpb.Comparison_OP_UNKNOWN, evalpb.Comparison_OP_EQ:
+			return val == want.StringValue
+		case evalpb.Comparison_OP_NE:
+			return val != want.StringValue
+		case evalpb.Comparison_OP_REGEX:
+			re, err := compileRule(want.StringValue)
+			return err == nil && re.MatchString(val)
+		case evalpb.Comparison_OP_STARTS_WITH:
+			return strings.HasPrefix(val, want.StringValue)
+		case evalpb.Comparison_OP_CONTAINS:
+			return strings.Contains(want.StringValue, val)
+		}
+	}
+	return false
+}
+
+// ruleRegexps caches the compiled expressions of rules, which apply to every result.
+var ruleRegexps sync.Map
+
+func compileRule(expr string) (*regexp.Regexp, error) {
+	if re, ok := ruleRegexps.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	ruleRegexps.Store(expr, re)
+	return re, nil
+}
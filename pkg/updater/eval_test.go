@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	evalpb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestCustomStatus(t *testing.T) {
+	pstr := func(s string) *string {
+		return &s
+	}
+	property := func(key string, op evalpb.Comparison_Operator, val string) *evalpb.TestResultComparison {
+		return &evalpb.TestResultComparison{
+			TestResultInfo: &evalpb.TestResultComparison_PropertyKey{PropertyKey: key},
+			Comparison: &evalpb.Comparison{
+				Op:              op,
+				ComparisonValue: &evalpb.Comparison_StringValue{StringValue: val},
+			},
+		}
+	}
+	field := func(name string, op evalpb.Comparison_Operator, val string) *evalpb.TestResultComparison {
+		return &evalpb.TestResultComparison{
+			TestResultInfo: &evalpb.TestResultComparison_TestResultField{TestResultField: name},
+			Comparison: &evalpb.Comparison{
+				Op:              op,
+				ComparisonValue: &evalpb.Comparison_StringValue{StringValue: val},
+			},
+		}
+	}
+	number := func(name string, op evalpb.Comparison_Operator, val float64) *evalpb.TestResultComparison {
+		return &evalpb.TestResultComparison{
+			TestResultInfo: &evalpb.TestResultComparison_TestResultField{TestResultField: name},
+			Comparison: &evalpb.Comparison{
+				Op:              op,
+				ComparisonValue: &evalpb.Comparison_NumericalValue{NumericalValue: val},
+			},
+		}
+	}
+	errorField := func(name string, op evalpb.Comparison_Operator, val string) *evalpb.TestResultComparison {
+		return &evalpb.TestResultComparison{
+			TestResultInfo: &evalpb.TestResultComparison_TestResultErrorField{TestResultErrorField: name},
+			Comparison: &evalpb.Comparison{
+				Op:              op,
+				ComparisonValue: &evalpb.Comparison_StringValue{StringValue: val},
+			},
+		}
+	}
+	rule := func(status statuspb.TestStatus, cmps ...*evalpb.TestResultComparison) *evalpb.Rule {
+		return &evalpb.Rule{
+			TestResultComparisons: cmps,
+			ComputedStatus:        status,
+		}
+	}
+
+	failure := junit.Result{
+		Name:      "//pkg:test",
+		ClassName: "pkg",
+		Time:      12.5,
+		Failure:   pstr("connection refused by infra"),
+		Properties: &junit.Properties{
+			PropertyList: []junit.Property{
+				{Name: "infra", Value: "true"},
+				{Name: "attempts", Value: "3"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		rules    []*evalpb.Rule
+		result   junit.Result
+		status   statuspb.TestStatus
+		expected statuspb.TestStatus
+		matched  bool
+	}{
+		{
+			name:     "basically works",
+			status:   statuspb.TestStatus_PASS,
+			expected: statuspb.TestStatus_PASS,
+		},
+		{
+			name: "property marks infra failures",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT,
+					field("status", evalpb.Comparison_OP_EQ, "FAIL"),
+					property("infra", evalpb.Comparison_OP_EQ, "true"),
+				),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_CATEGORIZED_ABORT,
+			matched:  true,
+		},
+		{
+			name: "every comparison must match",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT,
+					field("status", evalpb.Comparison_OP_EQ, "PASS"),
+					property("infra", evalpb.Comparison_OP_EQ, "true"),
+				),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_FAIL,
+		},
+		{
+			name: "first matching rule wins",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_FLAKY, property("missing", evalpb.Comparison_OP_NE, "anything")),
+				rule(statuspb.TestStatus_TOOL_FAIL, errorField("message", evalpb.Comparison_OP_REGEX, "refused")),
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, field("name", evalpb.Comparison_OP_STARTS_WITH, "//pkg")),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_TOOL_FAIL,
+			matched:  true,
+		},
+		{
+			name: "numerical comparisons",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_TIMED_OUT, number("time", evalpb.Comparison_OP_LT, 12)),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_TIMED_OUT,
+			matched:  true,
+		},
+		{
+			name: "numerical comparisons put the comparison value first",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, number("time", evalpb.Comparison_OP_GT, 12)),
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, number("time", evalpb.Comparison_OP_GE, 12)),
+				rule(statuspb.TestStatus_TIMED_OUT, number("time", evalpb.Comparison_OP_LE, 12)),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_TIMED_OUT,
+			matched:  true,
+		},
+		{
+			name: "contained within the comparison value",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_FLAKY, field("classname", evalpb.Comparison_OP_CONTAINS, "pkg,other")),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_FLAKY,
+			matched:  true,
+		},
+		{
+			name: "numerical property",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_FLAKY, &evalpb.TestResultComparison{
+					TestResultInfo: &evalpb.TestResultComparison_PropertyKey{PropertyKey: "attempts"},
+					Comparison: &evalpb.Comparison{
+						Op:              evalpb.Comparison_OP_LE,
+						ComparisonValue: &evalpb.Comparison_NumericalValue{NumericalValue: 2},
+					},
+				}),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_PASS,
+			expected: statuspb.TestStatus_FLAKY,
+			matched:  true,
+		},
+		{
+			name: "regex",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, field("message", evalpb.Comparison_OP_REGEX, `refused by \w+$`)),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_CATEGORIZED_ABORT,
+			matched:  true,
+		},
+		{
+			name: "bad regex never matches",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, field("message", evalpb.Comparison_OP_REGEX, `(`)),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_FAIL,
+		},
+		{
+			name: "unknown fields never match",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, field("nope", evalpb.Comparison_OP_NE, "x")),
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, errorField("type", evalpb.Comparison_OP_NE, "x")),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_FAIL,
+		},
+		{
+			name: "errors fields need a failure",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT, errorField("message", evalpb.Comparison_OP_NE, "x")),
+			},
+			result:   junit.Result{Name: "pass"},
+			status:   statuspb.TestStatus_PASS,
+			expected: statuspb.TestStatus_PASS,
+		},
+		{
+			name: "rules without comparisons never match",
+			rules: []*evalpb.Rule{
+				rule(statuspb.TestStatus_CATEGORIZED_ABORT),
+			},
+			result:   failure,
+			status:   statuspb.TestStatus_FAIL,
+			expected: statuspb.TestStatus_FAIL,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, matched := customStatus(tc.rules, &tc.result, propertyMap(&tc.result), tc.status)
+			if actual != tc.expected || matched != tc.matched {
+				t.Errorf("customStatus() got %s, %t, want %s, %t", actual, matched, tc.expected, tc.matched)
+			}
+		})
+	}
+}
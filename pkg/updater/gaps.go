@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// maxGapBuilds is the most builds a gap between two consecutive columns may miss.
+//
+// Larger jumps in numbering are more likely a change of numbering than missed builds.
+const maxGapBuilds = 10
+
+// listingGaps returns the numeric builds missing between consecutive columns started after the cutoff, newest first.
+//
+// Also returns the build of the column preceding the oldest gap, from which to list the missing builds.
+func listingGaps(cols []inflatedColumn, cutoff time.Time) ([]string, string) {
+	var missing []string
+	var after string
+	for i := 0; i+1 < len(cols); i++ {
+		newer, older := cols[i].column, cols[i+1].column
+		if int64(older.Started) < cutoff.Unix()*1000 {
+			break
+		}
+		hi, err := strconv.ParseInt(newer.Build, 10, 64)
+		if err != nil {
+			continue
+		}
+		lo, err := strconv.ParseInt(older.Build, 10, 64)
+		if err != nil || hi-lo <= 1 || hi-lo-1 > maxGapBuilds {
+			continue
+		}
+		for n := hi - 1; n > lo; n-- {
+			missing = append(missing, strconv.FormatInt(n, 10))
+		}
+		after = older.Build
+	}
+	return missing, after
+}
+
+// fillListingGaps reads the builds missing from the numbering of the recent columns, such as those listed late.
+//
+// Considers columns started within the listing_gap_hours of the group, returning the columns unchanged when zero.
+// Lists the builds again from the oldest gap, so missing builds that never appear cost a listing each cycle until they age out.
+func fillListingGaps(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, tg *configpb.TestGroup, tgPaths []gcs.Path, cols []inflatedColumn, buildTimeout time.Duration, concurrency int) ([]inflatedColumn, error) {
+	hours := tg.GetListingGapHours()
+	if hours <= 0 {
+		return cols, nil
+	}
+	missing, after := listingGaps(cols, time.Now().Add(-time.Duration(hours)*time.Hour))
+	if len(missing) == 0 {
+		return cols, nil
+	}
+	if len(after) != len(cols[0].column.Build) {
+		after = "" // Listing offsets sort by name rather than number.
+	}
+	builds, err := listBuilds(ctx, client, after, tgPaths...)
+	if err != nil {
+		return nil, fmt.Errorf("list builds: %w", err)
+	}
+	want := make(map[string]bool, len(missing))
+	for _, id := range missing {
+		want[id] = true
+	}
+	var found []gcs.Build
+	for _, b := range builds {
+		if want[b.Build()] {
+			found = append(found, b)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"missing": len(missing),
+		"found":   len(found),
+	}).Debug("Checked listing gaps")
+	if len(found) == 0 {
+		return cols, nil
+	}
+	gapCols, err := readColumns(ctx, client, tg, found, time.Time{}, len(found), buildTimeout, concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("read columns: %w", err)
+	}
+	log.WithField("columns", len(gapCols)).Info("Filled listing gaps")
+	out := append(append([]inflatedColumn{}, cols...), gapCols...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].column.Started > out[j].column.Started
+	})
+	return out, nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestListingGaps(t *testing.T) {
+	now := time.Now()
+	col := func(build string, hours int) inflatedColumn {
+		return inflatedColumn{
+			column: &statepb.Column{
+				Build:   build,
+				Started: float64(now.Add(-time.Duration(hours)*time.Hour).Unix() * 1000),
+			},
+		}
+	}
+	cases := []struct {
+		name      string
+		cols      []inflatedColumn
+		wantMiss  []string
+		wantAfter string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "consecutive",
+			cols: []inflatedColumn{col("12", 1), col("11", 2), col("10", 3)},
+		},
+		{
+			name:      "gaps",
+			cols:      []inflatedColumn{col("15", 1), col("12", 2), col("11", 3), col("9", 4)},
+			wantMiss:  []string{"14", "13", "10"},
+			wantAfter: "9",
+		},
+		{
+			name:      "ignore old columns",
+			cols:      []inflatedColumn{col("15", 1), col("12", 2), col("10", 30)},
+			wantMiss:  []string{"14", "13"},
+			wantAfter: "12",
+		},
+		{
+			name: "ignore renumbering",
+			cols: []inflatedColumn{col("1000", 1), col("12", 2)},
+		},
+		{
+			name:      "ignore non-numeric builds",
+			cols:      []inflatedColumn{col("15", 1), col("hello", 2), col("13", 3), col("11", 4)},
+			wantMiss:  []string{"12"},
+			wantAfter: "11",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotMiss, gotAfter := listingGaps(tc.cols, now.Add(-24*time.Hour))
+			if diff := cmp.Diff(tc.wantMiss, gotMiss); diff != "" {
+				t.Errorf("listingGaps() got unexpected missing diff (-want +got):\n%s", diff)
+			}
+			if gotAfter != tc.wantAfter {
+				t.Errorf("listingGaps() got after %q, want %q", gotAfter, tc.wantAfter)
+			}
+		})
+	}
+}
+
+func TestFillListingGaps(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) int64 {
+		return now.Add(-time.Duration(h) * time.Hour).Unix()
+	}
+	hours := map[string]int{"15": 1, "14": 2, "13": 3, "12": 4}
+	col := func(build string) inflatedColumn {
+		return inflatedColumn{
+			column: &statepb.Column{Build: build, Started: float64(hoursAgo(hours[build]) * 1000)},
+		}
+	}
+	cases := []struct {
+		name   string
+		hours  int32
+		cols   []inflatedColumn
+		listed []string
+		want   []string
+	}{
+		{
+			name:   "disabled by default",
+			cols:   []inflatedColumn{col("15"), col("12")},
+			listed: []string{"15", "14", "13", "12"},
+			want:   []string{"15", "12"},
+		},
+		{
+			name:   "fill gaps",
+			hours:  24,
+			cols:   []inflatedColumn{col("15"), col("12")},
+			listed: []string{"15", "14", "13", "12"},
+			want:   []string{"15", "14", "13", "12"},
+		},
+		{
+			name:   "still missing",
+			hours:  24,
+			cols:   []inflatedColumn{col("15"), col("12")},
+			listed: []string{"15", "13", "12"},
+			want:   []string{"15", "13", "12"},
+		},
+		{
+			name:   "gaps too old",
+			hours:  2,
+			cols:   []inflatedColumn{col("15"), col("12")},
+			listed: []string{"15", "14", "13", "12"},
+			want:   []string{"15", "12"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{
+				GcsPrefix:       "bucket/logs/job/",
+				ListingGapHours: tc.hours,
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			client := fakeClient{
+				fakeLister: fakeLister{},
+				fakeOpener: fakeOpener{},
+			}
+			var fakes []fakeBuild
+			for _, id := range tc.listed {
+				fakes = append(fakes, fakeBuild{
+					id:       id,
+					started:  jsonStarted(hoursAgo(hours[id])),
+					finished: jsonFinished(hoursAgo(hours[id])+1, true, metadata.Metadata{}),
+					passed:   []string{"good"},
+				})
+			}
+			fi := client.fakeLister[buildsPath]
+			for _, build := range client.addBuilds(buildsPath, fakes...) {
+				fi.objects = append(fi.objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.fakeLister[buildsPath] = fi
+
+			cols, err := fillListingGaps(context.Background(), logrus.WithField("test", tc.name), client, &group, []gcs.Path{buildsPath}, tc.cols, time.Minute, 2)
+			if err != nil {
+				t.Fatalf("fillListingGaps() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range cols {
+				got = append(got, col.column.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("fillListingGaps() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,16 +19,22 @@ package updater
 import (
 	"context"
 	"fmt"
+	"path"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	evalpb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/logging"
 )
 
 // gcsResult holds all the downloaded information for a build of a job.
@@ -40,6 +46,8 @@ type gcsResult struct {
 	suites   []gcs.SuitesMeta
 	job      string
 	build    string
+	path     gcs.Path
+	prow     map[string]string
 }
 
 const maxDuplicates = 20
@@ -93,8 +101,49 @@ func first(properties map[string][]string) map[string]string {
 	return out
 }
 
+// Defaults of each artifact link.
+const (
+	defaultLinkProperty  = "log"
+	defaultLinkURLPrefix = "https://storage.cloud.google.com/"
+)
+
+// artifactLinks returns the properties linking to the files next to the artifact, when it matches a link.
+func artifactLinks(build gcs.Path, artifact string, links []*configpb.TestGroup_ArtifactLink) map[string]string {
+	if len(links) == 0 {
+		return nil
+	}
+	prefix := strings.TrimSuffix(build.String(), "/") + "/"
+	if !strings.HasPrefix(artifact, prefix) {
+		return nil
+	}
+	rel := strings.TrimPrefix(artifact, prefix)
+	var out map[string]string
+	for _, link := range links {
+		if ok, _ := path.Match(link.Artifact, rel); !ok {
+			continue
+		}
+		target := path.Join(path.Dir(rel), link.Path)
+		if target == ".." || strings.HasPrefix(target, "../") {
+			continue // outside the build
+		}
+		prop := link.Property
+		if prop == "" {
+			prop = defaultLinkProperty
+		}
+		urlPrefix := link.UrlPrefix
+		if urlPrefix == "" {
+			urlPrefix = defaultLinkURLPrefix
+		}
+		if out == nil {
+			out = map[string]string{}
+		}
+		out[prop] = urlPrefix + path.Join(build.Bucket(), build.Object(), target)
+	}
+	return out
+}
+
 // convertResult returns an inflatedColumn representation of the GCS result.
-func convertResult(ctx context.Context, log logrus.FieldLogger, nameCfg nameConfig, id string, headers []string, metricKey string, result gcsResult) (*inflatedColumn, error) {
+func convertResult(ctx context.Context, log logrus.FieldLogger, nameCfg nameConfig, id string, headers []string, metricKey string, links []*configpb.TestGroup_ArtifactLink, rules []*evalpb.Rule, methods methodConfig, keepSkipped bool, result gcsResult) (*inflatedColumn, error) {
 	overall := overallCell(result)
 	out := inflatedColumn{
 		column: &statepb.Column{
@@ -107,6 +156,11 @@ func convertResult(ctx context.Context, log logrus.FieldLogger, nameCfg nameConf
 	}
 
 	meta := result.finished.Metadata.Strings()
+	for k, v := range result.prow {
+		if _, ok := meta[k]; !ok {
+			meta[k] = v
+		}
+	}
 	version := metadata.Version(result.started.Started, result.finished.Finished)
 
 	for _, h := range headers {
@@ -121,84 +175,28 @@ func convertResult(ctx context.Context, log logrus.FieldLogger, nameCfg nameConf
 
 	// Append each result into the column
 	for _, suite := range result.suites {
+		linked := artifactLinks(result.path, suite.Path, links)
 		for _, r := range flattenResults(suite.Suites.Suites...) {
-			if r.Skipped != nil && *r.Skipped == "" {
+			if !keepSkipped && r.Skipped != nil && *r.Skipped == "" {
 				continue
 			}
-			c := &cell{}
-			if elapsed := r.Time; elapsed > 0 {
-				c.metrics = setElapsed(c.metrics, elapsed)
-			}
-
 			props := propertyMap(&r)
-			for metric, mean := range means(props) {
-				if c.metrics == nil {
-					c.metrics = map[string]float64{}
-				}
-				c.metrics[metric] = mean
-			}
-
-			const max = 140
-			if msg := r.Message(max); msg != "" {
-				c.message = msg
+			c := resultCell(&r, props, linked, metricKey, rules)
+			name := nameCfg.render(result.job, r.Name, first(props), suite.Metadata, meta)
+			if err := addCell(ctx, log, id, out.cells, name, c); err != nil {
+				return nil, err
 			}
-
-			switch {
-			case r.Failure != nil:
-				c.result = statuspb.TestStatus_FAIL
-				if c.message != "" {
-					c.icon = "F"
+			for _, m := range methods.methods(r) {
+				if !keepSkipped && m.Skipped != nil && *m.Skipped == "" {
+					continue
 				}
-			case r.Skipped != nil:
-				c.result = statuspb.TestStatus_PASS_WITH_SKIPS
-				c.icon = "S"
-			default:
-				c.result = statuspb.TestStatus_PASS
-			}
-
-			if f, ok := c.metrics[metricKey]; ok {
-				c.icon = strconv.FormatFloat(f, 'g', 4, 64)
-			}
-
-			name := nameCfg.render(result.job, r.Name, first(props), suite.Metadata, meta)
-
-			// Ensure each name is unique
-			// If we have multiple results with the same name foo
-			// then append " [n]" to the name so we wind up with:
-			//   foo
-			//   foo [1]
-			//   foo [2]
-			//   etc
-			if _, present := out.cells[name]; present {
-				var attempt string
-				for idx := 1; true; idx++ {
-					select {
-					case <-ctx.Done():
-						return nil, ctx.Err()
-					default:
-					}
-					if idx == maxDuplicates {
-						name = name + " [overflow]"
-						if _, present := out.cells[name]; present {
-							c = nil
-							break
-						}
-						c = &overflowCell
-						break
-					}
-
-					attempt = name + " [" + strconv.Itoa(idx) + "]"
-					if _, present := out.cells[attempt]; present {
-						continue
-					}
-					name = attempt
-					break
+				mprops := propertyMap(&m)
+				c := resultCell(&m, mprops, linked, metricKey, rules)
+				name := nameCfg.render(result.job, dotName(r.Name, methods.name(m)), first(mprops), first(props), suite.Metadata, meta)
+				if err := addCell(ctx, log, id, out.cells, name, c); err != nil {
+					return nil, err
 				}
 			}
-			if c == nil {
-				continue
-			}
-			out.cells[name] = *c
 		}
 	}
 
@@ -224,6 +222,177 @@ func convertResult(ctx context.Context, log logrus.FieldLogger, nameCfg nameConf
 	return &out, nil
 }
 
+// resultCell returns the cell for the junit result.
+func resultCell(r *junit.Result, props map[string][]string, linked map[string]string, metricKey string, rules []*evalpb.Rule) *cell {
+	c := &cell{}
+	if elapsed := r.Time; elapsed > 0 {
+		c.metrics = setElapsed(c.metrics, elapsed)
+	}
+
+	for metric, mean := range means(props) {
+		if c.metrics == nil {
+			c.metrics = map[string]float64{}
+		}
+		c.metrics[metric] = mean
+	}
+
+	const max = 140
+	if msg := r.Message(max); msg != "" {
+		c.message = msg
+	}
+
+	switch {
+	case r.Failure != nil:
+		c.result = statuspb.TestStatus_FAIL
+		if c.message != "" {
+			c.icon = "F"
+		}
+		c.properties = linked
+	case r.Skipped != nil:
+		c.result = statuspb.TestStatus_PASS_WITH_SKIPS
+		c.icon = "S"
+	default:
+		c.result = statuspb.TestStatus_PASS
+	}
+
+	switch strings.ToLower(r.Status) {
+	case "aborted":
+		c.result = statuspb.TestStatus_ABORTED
+		c.icon = "A"
+	case "timed_out", "timeout":
+		c.result = statuspb.TestStatus_TIMED_OUT
+		c.icon = "T"
+	}
+
+	if status, ok := customStatus(rules, r, props, c.result); ok && status != c.result {
+		c.result = status
+		c.icon = ""
+	}
+
+	if f, ok := c.metrics[metricKey]; ok {
+		c.icon = strconv.FormatFloat(f, 'g', 4, 64)
+	}
+	return c
+}
+
+// addCell adds the cell to cells under a unique name.
+//
+// If we have multiple results with the same name foo
+// then append " [n]" to the name so we wind up with:
+//
+//	foo
+//	foo [1]
+//	foo [2]
+//	etc
+func addCell(ctx context.Context, log logrus.FieldLogger, id string, cells map[string]cell, name string, c *cell) error {
+	if _, present := cells[name]; present {
+		var attempt string
+		for idx := 1; true; idx++ {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if idx == maxDuplicates {
+				logging.Repeated.Warning(log.WithField("name", name).WithField("build", id), "Too many results with the same name, overflowing")
+				name = name + " [overflow]"
+				if _, present := cells[name]; present {
+					c = nil
+					break
+				}
+				c = &overflowCell
+				break
+			}
+
+			attempt = name + " [" + strconv.Itoa(idx) + "]"
+			if _, present := cells[attempt]; present {
+				continue
+			}
+			name = attempt
+			break
+		}
+	}
+	if c != nil {
+		cells[name] = *c
+	}
+	return nil
+}
+
+// methodConfig selects the test methods of each result that get their own rows.
+type methodConfig struct {
+	enabled    bool
+	max        int
+	match      *regexp.Regexp
+	properties []*configpb.TestGroup_KeyValue
+	fullNames  bool
+}
+
+func makeMethodConfig(group *configpb.TestGroup) methodConfig {
+	mc := methodConfig{
+		enabled:    group.GetEnableTestMethods(),
+		max:        int(group.GetMaxTestMethodsPerTest()),
+		properties: group.GetTestMethodProperties(),
+		fullNames:  group.GetUseFullMethodNames(),
+	}
+	if pattern := group.GetTestMethodMatchRegex(); pattern != "" {
+		// Validation rejects bad patterns, so this only drops those of unvalidated configs.
+		mc.match, _ = regexp.Compile(pattern)
+	}
+	return mc
+}
+
+// methods returns the test methods of the result that get their own rows.
+//
+// Returns none when the result has more than the max number of these methods.
+func (mc methodConfig) methods(r junit.Result) []junit.Result {
+	if !mc.enabled {
+		return nil
+	}
+	var out []junit.Result
+	for _, m := range r.Methods {
+		if mc.match != nil && !mc.match.MatchString(m.Name) {
+			continue
+		}
+		if !hasProperties(&m, mc.properties) {
+			continue
+		}
+		out = append(out, m)
+	}
+	if mc.max > 0 && len(out) > mc.max {
+		return nil
+	}
+	return out
+}
+
+// name returns the name of the method, including its class when configured.
+func (mc methodConfig) name(m junit.Result) string {
+	if mc.fullNames {
+		return dotName(m.ClassName, m.Name)
+	}
+	return m.Name
+}
+
+// hasProperties returns true when the result has every one of the properties.
+func hasProperties(r *junit.Result, required []*configpb.TestGroup_KeyValue) bool {
+	if len(required) == 0 {
+		return true
+	}
+	props := propertyMap(r)
+	for _, kv := range required {
+		var found bool
+		for _, v := range props[kv.GetKey()] {
+			if v == kv.GetValue() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // overallCell generates the overall cell for this GCS result.
 func overallCell(result gcsResult) cell {
 	var c cell
@@ -233,17 +402,10 @@ func overallCell(result gcsResult) cell {
 	}
 	switch {
 	case finished > 0: // completed result
-		var passed bool
-		res := result.finished.Result
-		switch {
-		case result.finished.Passed == nil:
-			if res != "" {
-				passed = res == "SUCCESS"
-				c.icon = "E"
-				c.message = fmt.Sprintf(`finished.json missing "passed": %t`, passed)
-			}
-		case result.finished.Passed != nil:
-			passed = *result.finished.Passed
+		passed, _ := result.finished.Succeeded()
+		if result.finished.Passed == nil && result.finished.Result != "" {
+			c.icon = "E"
+			c.message = fmt.Sprintf(`finished.json missing "passed": %t`, passed)
 		}
 
 		if passed {
@@ -251,9 +413,19 @@ func overallCell(result gcsResult) cell {
 		} else {
 			c.result = statuspb.TestStatus_FAIL
 		}
+		switch result.finished.Stopped() {
+		case metadata.ResultAborted:
+			c.result = statuspb.TestStatus_ABORTED
+			c.icon = "A"
+			c.message = "Build aborted"
+		case metadata.ResultTimedOut:
+			c.result = statuspb.TestStatus_TIMED_OUT
+			c.icon = "T"
+			c.message = "Build timed out"
+		}
 		c.metrics = setElapsed(nil, float64(finished-result.started.Timestamp))
 	case time.Now().Add(-24*time.Hour).Unix() > result.started.Timestamp:
-		c.result = statuspb.TestStatus_FAIL
+		c.result = statuspb.TestStatus_TIMED_OUT
 		c.message = "Build did not complete within 24 hours"
 		c.icon = "T"
 	default:
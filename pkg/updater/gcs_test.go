@@ -29,6 +29,8 @@ import (
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	evalpb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
@@ -44,14 +46,18 @@ func TestConvertResult(t *testing.T) {
 	yes := true
 	now := time.Now().Unix()
 	cases := []struct {
-		name      string
-		ctx       context.Context
-		nameCfg   nameConfig
-		id        string
-		headers   []string
-		metricKey string
-		result    gcsResult
-		expected  *inflatedColumn
+		name        string
+		ctx         context.Context
+		nameCfg     nameConfig
+		id          string
+		headers     []string
+		metricKey   string
+		links       []*configpb.TestGroup_ArtifactLink
+		rules       []*evalpb.Rule
+		methods     methodConfig
+		keepSkipped bool
+		result      gcsResult
+		expected    *inflatedColumn
 	}{
 		{
 			name: "basically works",
@@ -59,7 +65,7 @@ func TestConvertResult(t *testing.T) {
 				column: &statepb.Column{},
 				cells: map[string]cell{
 					"Overall": {
-						result:  statuspb.TestStatus_FAIL,
+						result:  statuspb.TestStatus_TIMED_OUT,
 						icon:    "T",
 						message: "Build did not complete within 24 hours",
 					},
@@ -99,7 +105,7 @@ func TestConvertResult(t *testing.T) {
 				},
 				cells: map[string]cell{
 					"Overall": {
-						result:  statuspb.TestStatus_FAIL,
+						result:  statuspb.TestStatus_TIMED_OUT,
 						icon:    "T",
 						message: "Build did not complete within 24 hours",
 					},
@@ -146,6 +152,48 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prow metadata fills in column headers",
+			headers: []string{"repo", "pull", "pull-sha"},
+			id:      "hello",
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Metadata: metadata.Metadata{
+							"repo": "finished/wins",
+						},
+					},
+				},
+				prow: map[string]string{
+					metadata.ProwRepo:    "o/r",
+					metadata.ProwPull:    "5",
+					metadata.ProwPullSHA: "abc",
+				},
+			},
+			expected: &inflatedColumn{
+				column: &statepb.Column{
+					Build:   "hello",
+					Started: float64(now * 1000),
+					Extra: []string{
+						"finished/wins",
+						"5",
+						"abc",
+					},
+				},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_RUNNING,
+						icon:    "R",
+						message: "Build still running...",
+					},
+				},
+			},
+		},
 		{
 			name: "inclue job name upon request",
 			nameCfg: nameConfig{
@@ -305,6 +353,15 @@ func TestConvertResult(t *testing.T) {
 											Name:   "stdout message",
 											Output: pstr("bellybutton"),
 										},
+										{
+											Name:   "aborted",
+											Status: "aborted",
+										},
+										{
+											Name:    "timed out",
+											Status:  "TIMEOUT",
+											Failure: pstr("killed"),
+										},
 									},
 								},
 							},
@@ -352,6 +409,351 @@ func TestConvertResult(t *testing.T) {
 						message: "bellybutton",
 						result:  statuspb.TestStatus_PASS,
 					},
+					"aborted": {
+						result: statuspb.TestStatus_ABORTED,
+						icon:   "A",
+					},
+					"timed out": {
+						message: "killed",
+						result:  statuspb.TestStatus_TIMED_OUT,
+						icon:    "T",
+					},
+				},
+			},
+		},
+		{
+			name: "keep skips without a reason",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			keepSkipped: true,
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "silent skip",
+											Skipped: pstr(""),
+										},
+										{
+											Name:    "explained skip",
+											Skipped: pstr("needs a gpu"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &inflatedColumn{
+				column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_PASS,
+						metrics: setElapsed(nil, 1),
+					},
+					"silent skip": {
+						result: statuspb.TestStatus_PASS_WITH_SKIPS,
+						icon:   "S",
+					},
+					"explained skip": {
+						result:  statuspb.TestStatus_PASS_WITH_SKIPS,
+						message: "needs a gpu",
+						icon:    "S",
+					},
+				},
+			},
+		},
+		{
+			name: "failures link to artifacts",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			links: []*configpb.TestGroup_ArtifactLink{
+				{
+					Artifact: "artifacts/junit*.xml",
+					Path:     "build-log.txt",
+				},
+				{
+					Artifact:  "artifacts/*/junit.xml",
+					Path:      "../../build-log.txt",
+					Property:  "build-log",
+					UrlPrefix: "https://gcsweb.example.com/gcs/",
+				},
+				{
+					Artifact: "artifacts/*/junit.xml",
+					Path:     "../../../escape.txt",
+					Property: "escape",
+				},
+			},
+			result: gcsResult{
+				path: newPathOrDie("gs://bucket/logs/job/1/"),
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Path: "gs://bucket/logs/job/1/artifacts/junit_01.xml",
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "fail",
+											Failure: pstr("boom"),
+										},
+										{
+											Name: "pass",
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Path: "gs://bucket/logs/job/1/artifacts/e2e/junit.xml",
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "nested fail",
+											Failure: pstr("bang"),
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Path: "gs://bucket/logs/job/1/junit.xml",
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "unmatched fail",
+											Failure: pstr("pow"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &inflatedColumn{
+				column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_FAIL,
+						metrics: setElapsed(nil, 1),
+					},
+					"fail": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "boom",
+						icon:    "F",
+						properties: map[string]string{
+							"log": "https://storage.cloud.google.com/bucket/logs/job/1/artifacts/build-log.txt",
+						},
+					},
+					"pass": {
+						result: statuspb.TestStatus_PASS,
+					},
+					"nested fail": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "bang",
+						icon:    "F",
+						properties: map[string]string{
+							"build-log": "https://gcsweb.example.com/gcs/bucket/logs/job/1/build-log.txt",
+						},
+					},
+					"unmatched fail": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "pow",
+						icon:    "F",
+					},
+				},
+			},
+		},
+		{
+			name: "custom rules override result status",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			rules: []*evalpb.Rule{
+				{
+					TestResultComparisons: []*evalpb.TestResultComparison{
+						{
+							TestResultInfo: &evalpb.TestResultComparison_PropertyKey{PropertyKey: "infra"},
+							Comparison: &evalpb.Comparison{
+								Op:              evalpb.Comparison_OP_EQ,
+								ComparisonValue: &evalpb.Comparison_StringValue{StringValue: "true"},
+							},
+						},
+					},
+					ComputedStatus: statuspb.TestStatus_CATEGORIZED_ABORT,
+				},
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "infra fail",
+											Failure: pstr("no quota"),
+											Properties: &junit.Properties{
+												PropertyList: []junit.Property{
+													{Name: "infra", Value: "true"},
+												},
+											},
+										},
+										{
+											Name:    "real fail",
+											Failure: pstr("boom"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &inflatedColumn{
+				column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_FAIL,
+						metrics: setElapsed(nil, 1),
+					},
+					"infra fail": {
+						result:  statuspb.TestStatus_CATEGORIZED_ABORT,
+						message: "no quota",
+					},
+					"real fail": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "boom",
+						icon:    "F",
+					},
+				},
+			},
+		},
+		{
+			name: "test methods get their own rows",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			methods: methodConfig{enabled: true},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "case",
+											Failure: pstr("step failed"),
+											Methods: []junit.Result{
+												{
+													Name: "setup",
+													Time: 60,
+												},
+												{
+													Name:    "assert",
+													Failure: pstr("boom"),
+												},
+												{
+													Name:    "hidden",
+													Skipped: pstr(""),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &inflatedColumn{
+				column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_FAIL,
+						metrics: setElapsed(nil, 1),
+					},
+					"case": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "step failed",
+						icon:    "F",
+					},
+					"case.setup": {
+						result:  statuspb.TestStatus_PASS,
+						metrics: setElapsed(nil, 60),
+					},
+					"case.assert": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "boom",
+						icon:    "F",
+					},
 				},
 			},
 		},
@@ -849,7 +1251,7 @@ func TestConvertResult(t *testing.T) {
 			ctx, cancel := context.WithCancel(tc.ctx)
 			defer cancel()
 			log := logrus.WithField("test name", tc.name)
-			actual, err := convertResult(ctx, log, tc.nameCfg, tc.id, tc.headers, tc.metricKey, tc.result)
+			actual, err := convertResult(ctx, log, tc.nameCfg, tc.id, tc.headers, tc.metricKey, tc.links, tc.rules, tc.methods, tc.keepSkipped, tc.result)
 			switch {
 			case err != nil:
 				if tc.expected != nil {
@@ -887,7 +1289,7 @@ func TestOverallCell(t *testing.T) {
 				},
 			},
 			expected: cell{
-				result:  statuspb.TestStatus_FAIL,
+				result:  statuspb.TestStatus_TIMED_OUT,
 				message: "Build did not complete within 24 hours",
 				icon:    "T",
 			},
@@ -976,6 +1378,52 @@ func TestOverallCell(t *testing.T) {
 				metrics: setElapsed(nil, 150),
 			},
 		},
+		{
+			name: "aborted result",
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: 100,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(250),
+						Passed:    &no,
+						Result:    "ABORTED",
+					},
+				},
+			},
+			expected: cell{
+				result:  statuspb.TestStatus_ABORTED,
+				icon:    "A",
+				message: "Build aborted",
+				metrics: setElapsed(nil, 150),
+			},
+		},
+		{
+			name: "timed out result",
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: 100,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(250),
+						Passed:    &no,
+						Result:    "TIMEOUT",
+					},
+				},
+			},
+			expected: cell{
+				result:  statuspb.TestStatus_TIMED_OUT,
+				icon:    "T",
+				message: "Build timed out",
+				metrics: setElapsed(nil, 150),
+			},
+		},
 		{
 			name: "missing passed field is a failure",
 			result: gcsResult{
@@ -1167,6 +1615,96 @@ func TestFlattenResults(t *testing.T) {
 	}
 }
 
+func TestMethods(t *testing.T) {
+	props := func(kvs ...string) *junit.Properties {
+		var out junit.Properties
+		for i := 0; i < len(kvs); i += 2 {
+			out.PropertyList = append(out.PropertyList, junit.Property{Name: kvs[i], Value: kvs[i+1]})
+		}
+		return &out
+	}
+	result := junit.Result{
+		Name: "case",
+		Methods: []junit.Result{
+			{Name: "testFoo", ClassName: "FooTest", Properties: props("size", "small")},
+			{Name: "testBar", ClassName: "FooTest", Properties: props("size", "large")},
+			{Name: "helper", ClassName: "FooTest"},
+		},
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		expected []string
+	}{
+		{
+			name:  "disabled by default",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name: "basically works",
+			group: &configpb.TestGroup{
+				EnableTestMethods: true,
+			},
+			expected: []string{"testFoo", "testBar", "helper"},
+		},
+		{
+			name: "match regex",
+			group: &configpb.TestGroup{
+				EnableTestMethods:    true,
+				TestMethodMatchRegex: "^test",
+			},
+			expected: []string{"testFoo", "testBar"},
+		},
+		{
+			name: "required properties",
+			group: &configpb.TestGroup{
+				EnableTestMethods: true,
+				TestMethodProperties: []*configpb.TestGroup_KeyValue{
+					{Key: "size", Value: "small"},
+				},
+			},
+			expected: []string{"testFoo"},
+		},
+		{
+			name: "too many methods shows none",
+			group: &configpb.TestGroup{
+				EnableTestMethods:     true,
+				MaxTestMethodsPerTest: 2,
+			},
+		},
+		{
+			name: "limit applies to selected methods",
+			group: &configpb.TestGroup{
+				EnableTestMethods:     true,
+				MaxTestMethodsPerTest: 2,
+				TestMethodMatchRegex:  "^test",
+			},
+			expected: []string{"testFoo", "testBar"},
+		},
+		{
+			name: "full names",
+			group: &configpb.TestGroup{
+				EnableTestMethods:  true,
+				UseFullMethodNames: true,
+			},
+			expected: []string{"FooTest.testFoo", "FooTest.testBar", "FooTest.helper"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := makeMethodConfig(tc.group)
+			var actual []string
+			for _, m := range mc.methods(result) {
+				actual = append(actual, mc.name(m))
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("methods() got %v, want %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestDotName(t *testing.T) {
 	cases := []struct {
 		name     string
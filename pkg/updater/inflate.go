@@ -44,6 +44,8 @@ type cell struct {
 	message string
 
 	metrics map[string]float64
+
+	properties map[string]string
 }
 
 // inflateGrid inflates the grid's rows into an inflatedColumn channel.
@@ -123,6 +125,9 @@ func inflateRow(parent context.Context, row *statepb.Row) <-chan cell {
 			if result != statuspb.TestStatus_NO_RESULT {
 				c.icon = row.Icons[filledIdx]
 				c.message = row.Messages[filledIdx]
+				if filledIdx < len(row.Properties) {
+					c.properties = row.Properties[filledIdx].GetProperty()
+				}
 				filledIdx++
 			}
 			select {
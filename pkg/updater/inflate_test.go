@@ -477,6 +477,33 @@ func TestInflateRow(t *testing.T) {
 				{},
 			},
 		},
+		{
+			name: "properties skip empty columns",
+			row: statepb.Row{
+				CellIds:  blank(3),
+				Icons:    blank(2),
+				Messages: blank(2),
+				Properties: []*statepb.Property{
+					{Property: map[string]string{"log": "here"}},
+					{},
+				},
+				Results: []int32{
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+			},
+			expected: []cell{
+				{},
+				{
+					result:     statuspb.TestStatus_FAIL,
+					properties: map[string]string{"log": "here"},
+				},
+				{
+					result: statuspb.TestStatus_PASS,
+				},
+			},
+		},
 		{
 			name: "find metric name from row when missing",
 			row: statepb.Row{
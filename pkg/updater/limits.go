@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+)
+
+const (
+	// defaultMaxMessageBytes limits the cell messages of groups without max_message_bytes.
+	defaultMaxMessageBytes = 64 << 20
+	// defaultMaxMetricNames limits the metrics of groups without max_metric_names.
+	defaultMaxMetricNames = 1000
+	// truncatedMessage replaces the messages beyond the limit.
+	truncatedMessage = "[message truncated: grid exceeds max_message_bytes]"
+)
+
+var gridLimits = metrics.NewCounter("testgrid_updater_grid_limits_total", "Number of grid updates that hit a size limit", "group", "limit")
+
+// limitColumns enforces the group's limits on the total size of cell messages and number of metric names.
+//
+// Keeps the messages and metrics of newer columns first, replacing messages
+// beyond the limit with truncatedMessage and dropping other metrics.
+func limitColumns(log logrus.FieldLogger, group *configpb.TestGroup, cols []inflatedColumn) {
+	maxBytes := group.GetMaxMessageBytes()
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxMessageBytes
+	}
+	maxMetrics := int(group.GetMaxMetricNames())
+	if maxMetrics <= 0 {
+		maxMetrics = defaultMaxMetricNames
+	}
+	var truncated, dropped int
+	remaining := maxBytes
+	keep := map[string]bool{}
+	for _, col := range cols {
+		names := make([]string, 0, len(col.cells))
+		for name := range col.cells {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			c := col.cells[name]
+			if n := int64(len(c.message)); n > 0 && n > remaining {
+				c.message = truncatedMessage
+				col.cells[name] = c
+				truncated++
+				remaining = 0
+			} else {
+				remaining -= n
+			}
+			if len(c.metrics) == 0 {
+				continue
+			}
+			metricNames := make([]string, 0, len(c.metrics))
+			for m := range c.metrics {
+				metricNames = append(metricNames, m)
+			}
+			sort.Strings(metricNames)
+			for _, m := range metricNames {
+				if keep[m] {
+					continue
+				}
+				if len(keep) < maxMetrics {
+					keep[m] = true
+					continue
+				}
+				delete(c.metrics, m)
+				dropped++
+			}
+		}
+	}
+	if truncated > 0 {
+		gridLimits.Add(1, group.GetName(), "messages")
+		log.WithFields(logrus.Fields{
+			"max_message_bytes": maxBytes,
+			"truncated":         truncated,
+		}).Warning("Truncated messages beyond the grid's limit")
+	}
+	if dropped > 0 {
+		gridLimits.Add(1, group.GetName(), "metrics")
+		log.WithFields(logrus.Fields{
+			"max_metric_names": maxMetrics,
+			"dropped":          dropped,
+		}).Warning("Dropped metrics beyond the grid's limit")
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestLimitColumns(t *testing.T) {
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		cols     []inflatedColumn
+		expected []inflatedColumn
+	}{
+		{
+			name:  "basically works",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name:  "within limits",
+			group: &configpb.TestGroup{MaxMessageBytes: 10, MaxMetricNames: 2},
+			cols: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2"},
+					cells: map[string]cell{
+						"a": {message: "hello", metrics: map[string]float64{"x": 1}},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1"},
+					cells: map[string]cell{
+						"a": {message: "world", metrics: map[string]float64{"y": 2}},
+					},
+				},
+			},
+			expected: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2"},
+					cells: map[string]cell{
+						"a": {message: "hello", metrics: map[string]float64{"x": 1}},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1"},
+					cells: map[string]cell{
+						"a": {message: "world", metrics: map[string]float64{"y": 2}},
+					},
+				},
+			},
+		},
+		{
+			name:  "truncate older messages",
+			group: &configpb.TestGroup{MaxMessageBytes: 8},
+			cols: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2"},
+					cells: map[string]cell{
+						"a": {message: "hello"},
+						"b": {},
+						"c": {message: "world"},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1"},
+					cells: map[string]cell{
+						"a": {message: "hi"},
+					},
+				},
+			},
+			expected: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2"},
+					cells: map[string]cell{
+						"a": {message: "hello"},
+						"b": {},
+						"c": {message: truncatedMessage},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1"},
+					cells: map[string]cell{
+						"a": {message: truncatedMessage},
+					},
+				},
+			},
+		},
+		{
+			name:  "drop extra metrics",
+			group: &configpb.TestGroup{MaxMetricNames: 2},
+			cols: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2"},
+					cells: map[string]cell{
+						"a": {metrics: map[string]float64{"x": 1, "y": 2}},
+						"b": {metrics: map[string]float64{"x": 3, "z": 4}},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1"},
+					cells: map[string]cell{
+						"a": {metrics: map[string]float64{"w": 5, "y": 6}},
+					},
+				},
+			},
+			expected: []inflatedColumn{
+				{
+					column: &statepb.Column{Build: "2"},
+					cells: map[string]cell{
+						"a": {metrics: map[string]float64{"x": 1, "y": 2}},
+						"b": {metrics: map[string]float64{"x": 3}},
+					},
+				},
+				{
+					column: &statepb.Column{Build: "1"},
+					cells: map[string]cell{
+						"a": {metrics: map[string]float64{"y": 6}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			limitColumns(logrus.WithField("name", tc.name), tc.group, tc.cols)
+			if diff := cmp.Diff(tc.expected, tc.cols, cmp.AllowUnexported(inflatedColumn{}, cell{}), protocmp.Transform()); diff != "" {
+				t.Errorf("limitColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// updateTimeout returns the update_timeout_minutes of the group, or else the default.
+func updateTimeout(tg *configpb.TestGroup, def time.Duration) time.Duration {
+	if m := tg.GetUpdateTimeoutMinutes(); m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return def
+}
+
+// readContext returns a context that expires with a tenth of the time until the deadline of ctx left, if any.
+//
+// Leaves the rest of the time to write the columns read before then.
+func readContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline.Add(-time.Until(deadline)/10))
+}
+
+// readChunks reads the columns of up to max builds a few at a time, keeping the chunks read before the context expires.
+//
+// Reads the oldest chunks first when oldest is set, so every build newer than the
+// columns it returns remains unread and the next update can continue from them.
+// Otherwise reads the newest chunks first until reaching a column started before stop.
+func readChunks(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, tg *configpb.TestGroup, builds []gcs.Build, stop time.Time, max int, buildTimeout time.Duration, concurrency int, oldest bool) ([]inflatedColumn, error) {
+	if len(builds) > max {
+		builds = builds[:max]
+	}
+	size := concurrency
+	if size < 1 {
+		size = 1
+	}
+	var cols []inflatedColumn
+	for done := 0; done < len(builds); done += size {
+		start, end := done, done+size
+		if end > len(builds) {
+			end = len(builds)
+		}
+		if oldest {
+			start, end = len(builds)-end, len(builds)-start
+		}
+		chunk := builds[start:end]
+		got, err := readColumns(ctx, client, tg, chunk, stop, len(chunk), buildTimeout, concurrency)
+		if err != nil {
+			if ctx.Err() == nil || len(cols) == 0 {
+				return nil, err
+			}
+			log.WithFields(logrus.Fields{
+				"read":   len(cols),
+				"unread": len(builds) - len(cols),
+			}).Warning("Ran out of time, keeping the columns read so far")
+			return cols, nil
+		}
+		past := len(got) > 0 && int64(got[len(got)-1].column.Started) < stop.Unix()*1000
+		if !oldest {
+			cols = append(cols, got...)
+			if past {
+				break
+			}
+			continue
+		}
+		if past {
+			cols = nil // Older chunks only hold columns started before stop.
+		}
+		cols = append(got, cols...)
+	}
+	return cols, nil
+}
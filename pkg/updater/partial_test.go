@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestUpdateTimeout(t *testing.T) {
+	cases := []struct {
+		name string
+		tg   *configpb.TestGroup
+		want time.Duration
+	}{
+		{
+			name: "default",
+			tg:   &configpb.TestGroup{},
+			want: 10 * time.Minute,
+		},
+		{
+			name: "group timeout",
+			tg:   &configpb.TestGroup{UpdateTimeoutMinutes: 3},
+			want: 3 * time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := updateTimeout(tc.tg, 10*time.Minute); got != tc.want {
+				t.Errorf("updateTimeout() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadContext(t *testing.T) {
+	ctx, cancel := readContext(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("readContext() added a deadline to a context without one")
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	parent, cancelParent := context.WithDeadline(context.Background(), deadline)
+	defer cancelParent()
+	ctx, cancel = readContext(parent)
+	defer cancel()
+	got, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("readContext() got no deadline")
+	}
+	if left := deadline.Sub(got); left < 5*time.Minute || left > 7*time.Minute {
+		t.Errorf("readContext() left %v before the deadline, want about 6m", left)
+	}
+}
+
+// expiringClient cancels the context when opening the files of the expire build.
+type expiringClient struct {
+	fakeClient
+	expire string
+	cancel context.CancelFunc
+}
+
+func (ec expiringClient) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, error) {
+	if ec.expire != "" && strings.Contains(path.Object(), "/"+ec.expire+"/") {
+		ec.cancel()
+		return nil, context.Canceled
+	}
+	return ec.fakeClient.Open(ctx, path)
+}
+
+func TestReadChunks(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) int64 {
+		return now.Add(-time.Duration(h) * time.Hour).Unix()
+	}
+	ids := []string{"5", "4", "3", "2", "1"}
+	cases := []struct {
+		name    string
+		oldest  bool
+		stop    int
+		max     int
+		expire  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "newest first",
+			want: []string{"5", "4", "3", "2", "1"},
+		},
+		{
+			name:   "oldest first",
+			oldest: true,
+			want:   []string{"5", "4", "3", "2", "1"},
+		},
+		{
+			name: "max",
+			max:  3,
+			want: []string{"5", "4", "3"},
+		},
+		{
+			name: "stop at the newest old column",
+			stop: 3,
+			want: []string{"5", "4", "3"},
+		},
+		{
+			name:   "oldest first drops columns past stop",
+			oldest: true,
+			stop:   3,
+			want:   []string{"5", "4", "3"},
+		},
+		{
+			name:   "keep the newest chunks read in time",
+			expire: "2",
+			want:   []string{"5", "4"},
+		},
+		{
+			name:   "keep the oldest chunks read in time",
+			oldest: true,
+			expire: "4",
+			want:   []string{"2", "1"},
+		},
+		{
+			name:    "nothing read in time",
+			expire:  "5",
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			buildsPath := newPathOrDie("gs://bucket/logs/job/")
+			client := expiringClient{
+				fakeClient: fakeClient{
+					fakeLister: fakeLister{},
+					fakeOpener: fakeOpener{},
+				},
+				expire: tc.expire,
+				cancel: cancel,
+			}
+			var fakes []fakeBuild
+			for i, id := range ids {
+				fakes = append(fakes, fakeBuild{
+					id:       id,
+					started:  jsonStarted(hoursAgo(i + 1)),
+					finished: jsonFinished(hoursAgo(i+1)+1, true, metadata.Metadata{}),
+					passed:   []string{"good"},
+				})
+			}
+			builds := client.addBuilds(buildsPath, fakes...)
+			max := tc.max
+			if max == 0 {
+				max = len(builds)
+			}
+			var stop time.Time
+			if tc.stop > 0 {
+				stop = now.Add(-time.Duration(tc.stop)*time.Hour + time.Minute)
+			}
+
+			cols, err := readChunks(ctx, logrus.WithField("test", tc.name), client, &configpb.TestGroup{}, builds, stop, max, time.Minute, 2, tc.oldest)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Fatalf("readChunks() got unexpected error: %v", err)
+				}
+				return
+			case tc.wantErr:
+				t.Fatalf("readChunks() failed to return an error")
+			}
+			var got []string
+			for _, col := range cols {
+				got = append(got, col.column.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("readChunks() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
@@ -39,7 +40,7 @@ import (
 func downloadGrid(ctx context.Context, opener gcs.Opener, path gcs.Path) (*statepb.Grid, error) {
 	var g statepb.Grid
 	r, err := opener.Open(ctx, path)
-	if err != nil && err == storage.ErrObjectNotExist {
+	if errors.Is(err, storage.ErrObjectNotExist) {
 		return &g, nil
 	}
 	if err != nil {
@@ -114,6 +115,7 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 	wg.Add(concurrency)
 	for i := 0; i < concurrency; i++ {
 		nameCfg := makeNameConfig(group)
+		methodCfg := makeMethodConfig(group)
 		go func() {
 			defer wg.Done()
 			for {
@@ -148,7 +150,7 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 					return
 				}
 				id := path.Base(b.Path.Object())
-				col, err := convertResult(ctx, log, nameCfg, id, heads, group.ShortTextMetric, *result)
+				col, err := convertResult(ctx, log, nameCfg, id, heads, group.ShortTextMetric, group.ArtifactLinks, group.GetCustomEvaluatorRuleSet().GetRules(), methodCfg, group.GetKeepSkipped(), *result)
 				if err != nil {
 					innerCancel()
 					select {
@@ -296,6 +298,7 @@ func ensureJobName(nc *nameConfig) {
 // Specifically download the following files:
 // * started.json
 // * finished.json
+// * clone-records.json and podinfo.json, when written by Prow
 // * any junit.xml files under the artifacts directory.
 func readResult(parent context.Context, client gcs.Downloader, build gcs.Build) (*gcsResult, error) {
 	ctx, cancel := context.WithCancel(parent) // Allows aborting after first error
@@ -303,6 +306,7 @@ func readResult(parent context.Context, client gcs.Downloader, build gcs.Build)
 	result := gcsResult{
 		job:   build.Job(),
 		build: build.Build(),
+		path:  build.Path,
 	}
 	ec := make(chan error) // Receives errors from anyone
 
@@ -338,6 +342,18 @@ func readResult(parent context.Context, client gcs.Downloader, build gcs.Build)
 		}
 	}()
 
+	// Download the Prow description of the repos under test, ignoring any problems.
+	work++
+	go func() {
+		records, _ := build.CloneRecords(ctx, client)
+		pod, _ := build.PodInfo(ctx, client)
+		result.prow = metadata.ProwMetadata(records, pod)
+		select {
+		case <-ctx.Done():
+		case ec <- nil:
+		}
+	}()
+
 	// Download suites
 	work++
 	go func() {
@@ -1051,6 +1051,44 @@ func TestReadResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "prow artifacts describe the pull",
+			data: map[string]fakeObject{
+				"started.json":       {data: `{"node": "fun"}`},
+				"finished.json":      {data: `{"passed": true}`},
+				"clone-records.json": {data: `[{"refs": {"org": "o", "repo": "r", "pulls": [{"number": 5}]}}]`},
+				"podinfo.json":       {data: `{"pod": {"spec": {"containers": [{"env": [{"name": "PULL_PULL_SHA", "value": "abc"}]}]}}}`},
+			},
+			expected: &gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{Node: "fun"},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{Passed: &yes},
+				},
+				prow: map[string]string{
+					metadata.ProwRepo:    "o/r",
+					metadata.ProwPull:    "5",
+					metadata.ProwPullSHA: "abc",
+				},
+			},
+		},
+		{
+			name: "malformed prow artifacts are ignored",
+			data: map[string]fakeObject{
+				"started.json":       {data: `{"node": "fun"}`},
+				"finished.json":      {data: `{"passed": true}`},
+				"clone-records.json": {data: `{"refs": "nope"}`},
+			},
+			expected: &gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{Node: "fun"},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{Passed: &yes},
+				},
+			},
+		},
 		{
 			name: "missing started.json reports pending",
 			data: map[string]fakeObject{
@@ -1173,6 +1211,7 @@ func TestReadResult(t *testing.T) {
 			if tc.expected != nil {
 				tc.expected.job = "some"
 				tc.expected.build = "build"
+				tc.expected.path = path
 			}
 			ctx, cancel := context.WithCancel(tc.ctx)
 			defer cancel()
@@ -1206,7 +1245,7 @@ func TestReadResult(t *testing.T) {
 			case tc.expected == nil:
 				t.Error("readResult(): failed to receive expected error")
 			default:
-				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcsResult{})); diff != "" {
+				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcsResult{}), cmp.Comparer(func(x, y gcs.Path) bool { return x.String() == y.String() })); diff != "" {
 					t.Errorf("readResult() got unexpected diff (-have, +want):\n%s", diff)
 				}
 			}
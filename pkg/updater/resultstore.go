@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	resultstorepb "google.golang.org/genproto/googleapis/devtools/resultstore/v2"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/resultstore"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// An InvocationSearcher finds the invocations of a project and lists the targets of each invocation.
+type InvocationSearcher interface {
+	Search(ctx context.Context, project, query string) ([]*resultstore.Invocation, error)
+	Targets(ctx context.Context, invocation string) ([]resultstore.Target, error)
+}
+
+// ResultStoreClient searches invocations with a ResultStore client.
+type ResultStoreClient struct {
+	client *resultstore.Client
+}
+
+// NewResultStoreClient searches invocations with the client, such as one from resultstore.Connect.
+func NewResultStoreClient(client *resultstore.Client) *ResultStoreClient {
+	return &ResultStoreClient{client: client}
+}
+
+// Search returns the name, timing and status of every invocation matching the query.
+func (c *ResultStoreClient) Search(ctx context.Context, project, query string) ([]*resultstore.Invocation, error) {
+	return c.client.Invocations().Search(ctx, project, query,
+		"invocations.name",
+		"invocations.timing",
+		"invocations.status_attributes",
+		"next_page_token",
+	)
+}
+
+// Targets returns the name, timing and status of every target of the invocation.
+func (c *ResultStoreClient) Targets(ctx context.Context, invocation string) ([]resultstore.Target, error) {
+	client := *c.client
+	return client.WithContext(ctx).Targets(invocation).List(
+		"targets.name",
+		"targets.timing",
+		"targets.status_attributes",
+	)
+}
+
+// ResultStore returns a GroupUpdater that reads groups with a resultstore_config result source with the searcher.
+//
+// Updates every other group with next.
+func ResultStore(groupTimeout time.Duration, searcher InvocationSearcher, write bool, cache *GridCache, next GroupUpdater) GroupUpdater {
+	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		if tg.GetResultSource().GetResultstoreConfig() == nil {
+			return next(parent, log, client, tg, gridPath)
+		}
+		ctx, cancel := context.WithTimeout(parent, updateTimeout(tg, groupTimeout))
+		defer cancel()
+		old, err := cache.download(ctx, client, gridPath)
+		if err != nil {
+			log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
+		}
+		const maxCols = 50
+		grid, err := searchGrid(ctx, log, searcher, tg, old, maxCols)
+		if err != nil {
+			return err
+		}
+		return writeGrid(ctx, log, client, gridPath, grid, write, cache)
+	}
+}
+
+// searchGrid adds up to maxCols new invocations matching the query of the group to the recent columns of the old grid, if any.
+func searchGrid(ctx context.Context, log logrus.FieldLogger, searcher InvocationSearcher, tg *configpb.TestGroup, old *statepb.Grid, maxCols int) (*statepb.Grid, error) {
+	rs := tg.GetResultSource().GetResultstoreConfig()
+	oldCols, since := recentColumns(tg, old)
+	query := fmt.Sprintf("(%s) AND timing.start_time>=%q", rs.GetQuery(), since.UTC().Format(time.RFC3339))
+	invocations, err := searcher.Search(ctx, rs.GetProject(), query)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	log.WithField("invocations", len(invocations)).Debug("Found invocations")
+	sort.SliceStable(invocations, func(i, j int) bool {
+		return invocations[i].Start.After(invocations[j].Start)
+	})
+	if len(invocations) > maxCols {
+		invocations = invocations[:maxCols]
+	}
+	newCols := make([]inflatedColumn, 0, len(invocations))
+	for _, inv := range invocations {
+		targets, err := searcher.Targets(ctx, inv.Name)
+		if err != nil {
+			return nil, fmt.Errorf("list %s targets: %w", inv.Name, err)
+		}
+		col, err := invocationColumn(ctx, log, *inv, targets)
+		if err != nil {
+			return nil, fmt.Errorf("convert %s: %w", inv.Name, err)
+		}
+		newCols = append(newCols, col)
+	}
+	cols := mergeColumns(newCols, oldCols)
+	cols = thinColumns(cols, tg.ColumnRetention, time.Now())
+	return constructGrid(log, tg, cols), nil
+}
+
+// invocationColumn returns a column for the invocation with a row for each of its targets.
+//
+// The overall cell has the status of the invocation. Skips targets without a result.
+func invocationColumn(ctx context.Context, log logrus.FieldLogger, inv resultstore.Invocation, targets []resultstore.Target) (inflatedColumn, error) {
+	build := strings.TrimPrefix(inv.Name, "invocations/")
+	overall := cell{
+		result:  resultStoreStatus(inv.Status),
+		message: inv.Description,
+	}
+	if inv.Duration > 0 {
+		overall.metrics = setElapsed(nil, inv.Duration.Seconds())
+	}
+	col := inflatedColumn{
+		column: &statepb.Column{
+			Build:   build,
+			Started: float64(inv.Start.Unix()*1000 + int64(inv.Start.Nanosecond())/int64(time.Millisecond)),
+		},
+		cells: map[string]cell{"Overall": overall},
+	}
+	for _, target := range targets {
+		status := resultStoreStatus(target.Status)
+		if status == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		c := cell{
+			result:  status,
+			message: target.Description,
+		}
+		if target.Duration > 0 {
+			c.metrics = setElapsed(nil, target.Duration.Seconds())
+		}
+		if c.message != "" && result.IsFailingResult(status) {
+			c.icon = "F"
+		}
+		if err := addCell(ctx, log, build, col.cells, targetLabel(target.Name), &c); err != nil {
+			return inflatedColumn{}, err
+		}
+	}
+	return col, nil
+}
+
+// targetLabel returns the label of the target from its name, such as //foo:bar from invocations/inv/targets/%2F%2Ffoo:bar.
+func targetLabel(name string) string {
+	idx := strings.LastIndex(name, "/targets/")
+	if idx < 0 {
+		return name
+	}
+	label := name[idx+len("/targets/"):]
+	if unescaped, err := url.PathUnescape(label); err == nil {
+		return unescaped
+	}
+	return label
+}
+
+var resultStoreStatuses = map[resultstore.Status]statuspb.TestStatus{
+	resultstorepb.Status_BUILDING:        statuspb.TestStatus_RUNNING,
+	resultstorepb.Status_BUILT:           statuspb.TestStatus_BUILD_PASSED,
+	resultstorepb.Status_FAILED_TO_BUILD: statuspb.TestStatus_BUILD_FAIL,
+	resultstorepb.Status_TESTING:         statuspb.TestStatus_RUNNING,
+	resultstorepb.Status_PASSED:          statuspb.TestStatus_PASS,
+	resultstorepb.Status_FAILED:          statuspb.TestStatus_FAIL,
+	resultstorepb.Status_TIMED_OUT:       statuspb.TestStatus_TIMED_OUT,
+	resultstorepb.Status_CANCELLED:       statuspb.TestStatus_CANCEL,
+	resultstorepb.Status_TOOL_FAILED:     statuspb.TestStatus_TOOL_FAIL,
+	resultstorepb.Status_FLAKY:           statuspb.TestStatus_FLAKY,
+	resultstorepb.Status_SKIPPED:         statuspb.TestStatus_PASS_WITH_SKIPS,
+}
+
+// resultStoreStatus returns the TestStatus of a ResultStore status, or NO_RESULT when unknown or incomplete.
+func resultStoreStatus(s resultstore.Status) statuspb.TestStatus {
+	return resultStoreStatuses[s]
+}
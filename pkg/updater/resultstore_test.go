@@ -0,0 +1,301 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	resultstorepb "google.golang.org/genproto/googleapis/devtools/resultstore/v2"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/resultstore"
+)
+
+func TestResultStoreStatus(t *testing.T) {
+	cases := []struct {
+		status resultstore.Status
+		want   statuspb.TestStatus
+	}{
+		{
+			status: resultstorepb.Status_PASSED,
+			want:   statuspb.TestStatus_PASS,
+		},
+		{
+			status: resultstorepb.Status_FAILED_TO_BUILD,
+			want:   statuspb.TestStatus_BUILD_FAIL,
+		},
+		{
+			status: resultstorepb.Status_TESTING,
+			want:   statuspb.TestStatus_RUNNING,
+		},
+		{
+			status: resultstorepb.Status_FLAKY,
+			want:   statuspb.TestStatus_FLAKY,
+		},
+		{
+			status: resultstorepb.Status_INCOMPLETE,
+			want:   statuspb.TestStatus_NO_RESULT,
+		},
+		{
+			status: resultstorepb.Status_STATUS_UNSPECIFIED,
+			want:   statuspb.TestStatus_NO_RESULT,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.status.String(), func(t *testing.T) {
+			if got := resultStoreStatus(tc.status); got != tc.want {
+				t.Errorf("resultStoreStatus() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTargetLabel(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "invocations/inv/targets/%2F%2Ffoo:bar",
+			want: "//foo:bar",
+		},
+		{
+			name: "invocations/inv/targets/plain",
+			want: "plain",
+		},
+		{
+			name: "not-a-target",
+			want: "not-a-target",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := targetLabel(tc.name); got != tc.want {
+				t.Errorf("targetLabel() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInvocationColumn(t *testing.T) {
+	cases := []struct {
+		name    string
+		inv     resultstore.Invocation
+		targets []resultstore.Target
+		want    inflatedColumn
+	}{
+		{
+			name: "no targets",
+			inv: resultstore.Invocation{
+				Name:   "invocations/inv",
+				Start:  time.Unix(100, 5e8),
+				Status: resultstorepb.Status_TESTING,
+			},
+			want: inflatedColumn{
+				column: &statepb.Column{Build: "inv", Started: 100500},
+				cells: map[string]cell{
+					"Overall": {result: statuspb.TestStatus_RUNNING},
+				},
+			},
+		},
+		{
+			name: "row per target",
+			inv: resultstore.Invocation{
+				Name:        "invocations/inv",
+				Start:       time.Unix(100, 0),
+				Duration:    time.Minute,
+				Status:      resultstorepb.Status_FAILED,
+				Description: "1 target failed",
+			},
+			targets: []resultstore.Target{
+				{
+					Name:     "invocations/inv/targets/%2F%2Ffoo:good",
+					Status:   resultstorepb.Status_PASSED,
+					Duration: 30 * time.Second,
+				},
+				{
+					Name:        "invocations/inv/targets/%2F%2Ffoo:bad",
+					Status:      resultstorepb.Status_FAILED,
+					Description: "boom",
+				},
+				{
+					Name:   "invocations/inv/targets/%2F%2Ffoo:unknown",
+					Status: resultstorepb.Status_UNKNOWN,
+				},
+			},
+			want: inflatedColumn{
+				column: &statepb.Column{Build: "inv", Started: 100000},
+				cells: map[string]cell{
+					"Overall": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "1 target failed",
+						metrics: map[string]float64{elapsedKey: 1},
+					},
+					"//foo:good": {
+						result:  statuspb.TestStatus_PASS,
+						metrics: map[string]float64{elapsedKey: 0.5},
+					},
+					"//foo:bad": {
+						result:  statuspb.TestStatus_FAIL,
+						message: "boom",
+						icon:    "F",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := invocationColumn(context.Background(), logrus.WithField("test", tc.name), tc.inv, tc.targets)
+			if err != nil {
+				t.Fatalf("invocationColumn() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(inflatedColumn{}, cell{}), protocmp.Transform()); diff != "" {
+				t.Errorf("invocationColumn() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeSearcher struct {
+	invocations []*resultstore.Invocation
+	targets     map[string][]resultstore.Target
+	project     string
+	query       string
+}
+
+func (fs *fakeSearcher) Search(_ context.Context, project, query string) ([]*resultstore.Invocation, error) {
+	fs.project, fs.query = project, query
+	return fs.invocations, nil
+}
+
+func (fs *fakeSearcher) Targets(_ context.Context, invocation string) ([]resultstore.Target, error) {
+	targets, ok := fs.targets[invocation]
+	if !ok {
+		return nil, fmt.Errorf("unexpected invocation: %s", invocation)
+	}
+	return targets, nil
+}
+
+func TestSearchGrid(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) time.Time {
+		return now.Add(-time.Duration(h) * time.Hour)
+	}
+	tg := &configpb.TestGroup{
+		Name:          "group",
+		DaysOfResults: 1,
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultstoreConfig{
+				ResultstoreConfig: &configpb.ResultStoreConfig{
+					Project: "my-project",
+					Query:   `invocation_attributes.labels:"my-job"`,
+				},
+			},
+		},
+	}
+	inv := func(id string, hours int) *resultstore.Invocation {
+		return &resultstore.Invocation{
+			Name:   "invocations/" + id,
+			Start:  hoursAgo(hours),
+			Status: resultstorepb.Status_PASSED,
+		}
+	}
+	targets := map[string][]resultstore.Target{
+		"invocations/2": {{Name: "invocations/2/targets/good", Status: resultstorepb.Status_PASSED}},
+		"invocations/3": {{Name: "invocations/3/targets/good", Status: resultstorepb.Status_PASSED}},
+		"invocations/4": {{Name: "invocations/4/targets/good", Status: resultstorepb.Status_PASSED}},
+	}
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: float64(hoursAgo(8).Unix() * 1000)},
+			{Build: "1", Started: float64(hoursAgo(10).Unix() * 1000)},
+		},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "good", Id: "good"}, cell{result: statuspb.TestStatus_PASS}, cell{result: statuspb.TestStatus_PASS}),
+		},
+	}
+	cases := []struct {
+		name        string
+		old         *statepb.Grid
+		invocations []*resultstore.Invocation
+		maxCols     int
+		wantSince   time.Time
+		want        []string
+	}{
+		{
+			name:        "new grid",
+			invocations: []*resultstore.Invocation{inv("3", 5), inv("4", 1)},
+			maxCols:     10,
+			wantSince:   hoursAgo(24),
+			want:        []string{"4", "3"},
+		},
+		{
+			name:        "merge with old columns",
+			old:         old,
+			invocations: []*resultstore.Invocation{inv("4", 1), inv("3", 5), inv("2", 8)},
+			maxCols:     10,
+			wantSince:   hoursAgo(8),
+			want:        []string{"4", "3", "2", "1"},
+		},
+		{
+			name:        "limit new columns",
+			invocations: []*resultstore.Invocation{inv("3", 5), inv("4", 1)},
+			maxCols:     1,
+			wantSince:   hoursAgo(24),
+			want:        []string{"4"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			searcher := fakeSearcher{invocations: tc.invocations, targets: targets}
+			grid, err := searchGrid(context.Background(), logrus.WithField("test", tc.name), &searcher, tg, tc.old, tc.maxCols)
+			if err != nil {
+				t.Fatalf("searchGrid() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("searchGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if searcher.project != "my-project" {
+				t.Errorf("searchGrid() searched %q, want my-project", searcher.project)
+			}
+			var since string
+			if _, err := fmt.Sscanf(searcher.query, `(invocation_attributes.labels:"my-job") AND timing.start_time>=%q`, &since); err != nil {
+				t.Fatalf("searchGrid() sent unexpected query %q: %v", searcher.query, err)
+			}
+			when, err := time.Parse(time.RFC3339, since)
+			if err != nil {
+				t.Fatalf("searchGrid() sent bad since %q: %v", since, err)
+			}
+			if d := when.Sub(tc.wantSince); d < -time.Minute || d > time.Minute {
+				t.Errorf("searchGrid() got since %v, want %v", when, tc.wantSince)
+			}
+		})
+	}
+}
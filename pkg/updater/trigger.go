@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/trigger"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// triggerInterval is how often Update checks for triggered groups.
+const triggerInterval = 30 * time.Second
+
+// triggeredJobs returns jobs updating the groups of the config with pending triggers, oldest first.
+func triggeredJobs(ctx context.Context, client trigger.Client, configPath gcs.Path, gridPrefix, triggerPrefix string, cfg *configpb.Configuration) ([]groupJob, error) {
+	targets := make(map[string]gcs.Path, len(cfg.TestGroups))
+	groups := make(map[string]*configpb.TestGroup, len(cfg.TestGroups))
+	for _, tg := range cfg.TestGroups {
+		tgp, err := testGroupPath(configPath, gridPrefix, tg.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s bad group path: %w", tg.Name, err)
+		}
+		targets[tg.Name] = *tgp
+		groups[tg.Name] = tg
+	}
+	pending, err := trigger.Check(ctx, client, configPath, triggerPrefix, trigger.Update, targets)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]groupJob, 0, len(pending))
+	for _, p := range pending {
+		jobs = append(jobs, groupJob{
+			group:      *groups[p.Name],
+			generation: p.Generation,
+			lock:       true,
+			triggered:  true,
+			requested:  p.Requested,
+			dashboards: groupDashboards(cfg, p.Name),
+		})
+	}
+	return jobs, nil
+}
+
+// WaitForTriggers returns once a group of the config has a pending trigger under triggerPrefix requested after since.
+//
+// Checks every triggerInterval, returning the error of ctx once it is done.
+// Ignores older triggers, which the last update already tried.
+func WaitForTriggers(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, gridPrefix, triggerPrefix string, since time.Time) error {
+	return waitForTriggers(ctx, client, configPath, gridPrefix, triggerPrefix, since, triggerInterval)
+}
+
+func waitForTriggers(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, gridPrefix, triggerPrefix string, since time.Time, interval time.Duration) error {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		jobs, err := triggeredJobs(ctx, client, configPath, gridPrefix, triggerPrefix, cfg)
+		if err != nil && ctx.Err() == nil {
+			logrus.WithError(err).Warning("Failed to check triggers")
+		}
+		for _, job := range jobs {
+			if job.requested.After(since) {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// groupDashboards returns the names of the dashboards with a tab of the group.
+func groupDashboards(cfg *configpb.Configuration, group string) []string {
+	var out []string
+	for _, dash := range cfg.Dashboards {
+		for _, tab := range dash.DashboardTab {
+			if tab.TestGroupName == group {
+				out = append(out, dash.Name)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// triggerSummaries asks the summarizer to summarize the dashboards.
+func triggerSummaries(ctx context.Context, log logrus.FieldLogger, client gcs.Uploader, configPath gcs.Path, triggerPrefix string, dashboards []string) {
+	for _, dash := range dashboards {
+		if err := trigger.Write(ctx, client, configPath, triggerPrefix, trigger.Summary, dash, "updater"); err != nil {
+			log.WithError(err).WithField("dashboard", dash).Warning("Failed to trigger summary")
+		}
+	}
+}
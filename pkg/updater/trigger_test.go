@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/trigger"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestWaitForTriggers(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("bad config path: %v", err)
+	}
+	cases := []struct {
+		name   string
+		before []string // groups triggered before since
+		after  []string // groups triggered after since
+		later  []string // groups triggered while waiting
+		want   error
+	}{
+		{
+			name: "no triggers",
+			want: context.DeadlineExceeded,
+		},
+		{
+			name:  "triggered",
+			after: []string{"group"},
+		},
+		{
+			name:  "triggered while waiting",
+			later: []string{"group"},
+		},
+		{
+			name:   "ignore older triggers",
+			before: []string{"group"},
+			want:   context.DeadlineExceeded,
+		},
+		{
+			name:  "ignore unknown groups",
+			after: []string{"other"},
+			want:  context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "triggers")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(root)
+			client := gcs.NewLocalClient(root)
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			buf, err := proto.Marshal(&configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "group"}},
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal config: %v", err)
+			}
+			if err := client.Upload(ctx, *configPath, buf, false, ""); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			write := func(names []string) {
+				for _, name := range names {
+					if err := trigger.Write(ctx, client, *configPath, "triggers", trigger.Update, name, "test"); err != nil {
+						t.Errorf("Failed to write trigger: %v", err)
+					}
+				}
+			}
+			write(tc.before)
+			time.Sleep(10 * time.Millisecond)
+			since := time.Now()
+			time.Sleep(10 * time.Millisecond)
+			write(tc.after)
+			if len(tc.later) > 0 {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					write(tc.later)
+				}()
+			}
+
+			got := waitForTriggers(ctx, client, *configPath, "grid", "triggers", since, time.Millisecond)
+			if !errors.Is(got, tc.want) {
+				t.Errorf("waitForTriggers() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupDashboards(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "twice",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "a", TestGroupName: "group"},
+					{Name: "b", TestGroupName: "group"},
+				},
+			},
+			{
+				Name:         "other",
+				DashboardTab: []*configpb.DashboardTab{{Name: "a", TestGroupName: "other"}},
+			},
+			{
+				Name:         "once",
+				DashboardTab: []*configpb.DashboardTab{{Name: "c", TestGroupName: "group"}},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		group    string
+		expected []string
+	}{
+		{
+			name:     "basic",
+			group:    "group",
+			expected: []string{"twice", "once"},
+		},
+		{
+			name:  "unused",
+			group: "nope",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := groupDashboards(cfg, tc.group)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("groupDashboards() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
+	"hash/crc32"
 	"net/http"
 	"net/url"
 	"path"
@@ -36,8 +36,8 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/fvbommel/sortorder"
 	"github.com/golang/protobuf/proto"
-	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/label"
 	"google.golang.org/api/googleapi"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
@@ -45,7 +45,10 @@ import (
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/alerts"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 )
 
 // GroupUpdater will compile the grid state proto for the specified group and upload it.
@@ -57,14 +60,35 @@ type GroupUpdater func(parent context.Context, log logrus.FieldLogger, client gc
 
 // GCS returns a GCS-based GroupUpdater, which knows how to process result data stored in GCS.
 func GCS(groupTimeout, buildTimeout time.Duration, concurrency int, write bool) GroupUpdater {
+	return CachedGCS(groupTimeout, buildTimeout, concurrency, write, nil)
+}
+
+// CachedGCS returns a GCS-based GroupUpdater that reuses the grids it wrote to the cache.
+func CachedGCS(groupTimeout, buildTimeout time.Duration, concurrency int, write bool, cache *GridCache) GroupUpdater {
 	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
 		if !tg.UseKubernetesClient {
 			log.Debug("Skipping non-kubernetes client group")
 			return nil
 		}
-		ctx, cancel := context.WithTimeout(parent, groupTimeout)
+		if tg.GetResultSource().GetBigqueryConfig() != nil {
+			log.Debug("Skipping bigquery group")
+			return nil
+		}
+		if tg.GetResultSource().GetResultstoreConfig() != nil {
+			log.Debug("Skipping resultstore group")
+			return nil
+		}
+		if tg.GetResultSource().GetCloudBuildConfig() != nil {
+			log.Debug("Skipping cloud build group")
+			return nil
+		}
+		if tg.GetResultSource().GetAzureDevopsConfig() != nil {
+			log.Debug("Skipping azure devops group")
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(parent, updateTimeout(tg, groupTimeout))
 		defer cancel()
-		return updateGCSGroup(ctx, log, client, tg, gridPath, concurrency, write, buildTimeout)
+		return updateGCSGroup(ctx, log, client, tg, gridPath, concurrency, write, buildTimeout, cache)
 	}
 }
 
@@ -97,6 +121,7 @@ func sortGroups(ctx context.Context, log logrus.FieldLogger, client gcs.Stater,
 				generations[name] = -1 // Should always fail
 			default:
 				updated[name] = attrs.Updated
+				groupObjective.Fresh(name, attrs.Updated)
 				generations[name] = attrs.Generation
 				log.WithField("updated", attrs.Updated).Debug("Found updated time")
 			}
@@ -145,29 +170,51 @@ func lockGroup(ctx context.Context, client gcs.ConditionalClient, path gcs.Path,
 	return client.If(&cond, &cond).Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache")
 }
 
+// groupJob is a test group to update, locking it at generation when lock is set.
+//
+// Triggers summaries of the dashboards once it updates a triggered group.
+type groupJob struct {
+	group      configpb.TestGroup
+	generation int64
+	lock       bool
+	triggered  bool
+	requested  time.Time
+	dashboards []string
+}
+
+// groupObjective tracks how reliably and recently each test group is updated.
+var groupObjective = metrics.NewObjective("updater")
+
 // Update performs a single update pass of all all test groups specified by the config.
-func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs.Path, gridPrefix string, groupConcurrency int, group string, updateGroup GroupUpdater) error {
+//
+// Checks the config for changes at most once per reloadConfig, replanning the groups
+// left to update when it changes. Never reloads when reloadConfig is zero.
+// Updates groups with pending triggers under triggerPrefix first, checking for
+// new triggers throughout the pass, unless triggerPrefix is empty.
+// Only updates the named groups when set, failing when the config lacks any of them.
+func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs.Path, reloadConfig time.Duration, gridPrefix, triggerPrefix string, groupConcurrency int, groupNames []string, updateGroup GroupUpdater) error {
 	defer growMaxUpdateArea()
 	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 	log := logrus.WithField("config", configPath)
-	cfg, err := config.ReadGCS(ctx, client, configPath)
+	watcher, err := config.NewWatcher(ctx, client, configPath, reloadConfig)
 	if err != nil {
 		return fmt.Errorf("read config: %w", err)
 	}
+	cfg := watcher.Config()
 	log.WithField("groups", len(cfg.TestGroups)).Info("Updating test groups")
 
-	groups := make(chan configpb.TestGroup)
+	groups := make(chan groupJob)
+	defer groupObjective.Report()
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	defer close(groups)
 
-	var generations map[string]int64
-
 	for i := 0; i < groupConcurrency; i++ {
 		wg.Add(1)
 		go func() {
-			for tg := range groups {
+			for job := range groups {
+				tg := job.group
 				log := log.WithField("group", tg.Name)
 				log.Debug("Starting update")
 				tgp, err := testGroupPath(configPath, gridPrefix, tg.Name)
@@ -175,8 +222,8 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 					log.WithError(err).Error("Bad path")
 					continue
 				}
-				if generations != nil {
-					if err := lockGroup(ctx, client, *tgp, generations[tg.Name]); err != nil {
+				if job.lock {
+					if err := lockGroup(ctx, client, *tgp, job.generation); err != nil {
 						switch ee := err.(type) {
 						case *googleapi.Error:
 							if ee.Code == http.StatusPreconditionFailed {
@@ -190,8 +237,14 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 						log.Debug("Acquired update lock")
 					}
 				}
-				if err := updateGroup(ctx, log, client, &tg, *tgp); err != nil {
+				gctx, span := tracing.Start(ctx, "updater.update_group", label.String("group", tg.Name))
+				err = updateGroup(gctx, log, client, &tg, *tgp)
+				tracing.End(span, err)
+				groupObjective.Record(tg.Name, err)
+				if err != nil {
 					log.WithError(err).Error("Error updating group")
+				} else if job.triggered {
+					triggerSummaries(ctx, log, client, configPath, triggerPrefix, job.dashboards)
 				}
 				// run the garbage collector after each group to minimize
 				// extraneous memory usage.
@@ -201,33 +254,102 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 		}()
 	}
 
-	if group != "" { // Just a specific group
-		tg := config.FindTestGroup(group, cfg)
-		if tg == nil {
-			return errors.New("group not found")
+	if len(groupNames) > 0 { // Just specific groups
+		var named []*configpb.TestGroup
+		var missing []string
+		for _, name := range groupNames {
+			tg := config.FindTestGroup(name, cfg)
+			if tg == nil {
+				missing = append(missing, name)
+				continue
+			}
+			named = append(named, tg)
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("groups not found: %s", strings.Join(missing, ", "))
 		}
-		groups <- *tg
-	} else { // All groups
+		for _, tg := range named {
+			groups <- groupJob{group: *tg}
+		}
+		return nil
+	}
+	// All groups
+	plan := func(pending []*configpb.TestGroup) map[string]int64 {
 		log.Info("Sorting groups")
-		generations, err = sortGroups(ctx, log, client, configPath, gridPrefix, cfg.TestGroups)
+		generations, err := sortGroups(ctx, log, client, configPath, gridPrefix, pending)
 		if err != nil {
 			log.WithError(err).Warning("Failed to sort groups")
 		}
 		log.Info("Sorted")
-		idxChan := make(chan int)
-		defer close(idxChan)
-		go logUpdate(idxChan, len(cfg.TestGroups), "Update in progress")
-		for i, tg := range cfg.TestGroups {
-			select {
-			case idxChan <- i:
-			default:
+		return generations
+	}
+	pending := cfg.TestGroups
+	generations := plan(pending)
+	idxChan := make(chan int)
+	defer close(idxChan)
+	go logUpdate(idxChan, len(pending), "Update in progress")
+	done := map[string]bool{}
+	triggered := map[string]bool{}
+	var checkedTriggers time.Time
+	for len(pending) > 0 {
+		if changed, err := watcher.Reload(ctx); err != nil {
+			log.WithError(err).Warning("Failed to reload config")
+		} else if changed {
+			cfg = watcher.Config()
+			pending = remainingGroups(cfg.TestGroups, done)
+			log.WithField("groups", len(pending)).Info("Config changed, replanning remaining groups")
+			generations = plan(pending)
+			continue
+		}
+		if triggerPrefix != "" && time.Since(checkedTriggers) >= triggerInterval {
+			checkedTriggers = time.Now()
+			jobs, err := triggeredJobs(ctx, client, configPath, gridPrefix, triggerPrefix, cfg)
+			if err != nil {
+				log.WithError(err).Warning("Failed to check triggers")
 			}
-			groups <- *tg
+			var n int
+			for _, job := range jobs {
+				if triggered[job.group.Name] {
+					continue
+				}
+				log.WithField("group", job.group.Name).Info("Updating triggered group")
+				triggered[job.group.Name] = true
+				done[job.group.Name] = true
+				groups <- job
+				n++
+			}
+			if n > 0 {
+				pending = remainingGroups(pending, done)
+				continue
+			}
+		}
+		tg := pending[0]
+		pending = pending[1:]
+		select {
+		case idxChan <- len(done):
+		default:
+		}
+		done[tg.Name] = true
+		groups <- groupJob{
+			group:      *tg,
+			generation: generations[tg.Name],
+			lock:       generations != nil,
 		}
 	}
 	return nil
 }
 
+// remainingGroups returns the groups not yet done.
+func remainingGroups(groups []*configpb.TestGroup, done map[string]bool) []*configpb.TestGroup {
+	var out []*configpb.TestGroup
+	for _, tg := range groups {
+		if !done[tg.Name] {
+			out = append(out, tg)
+		}
+	}
+	return out
+}
+
 // testGroupPath() returns the path to a test_group proto given this proto
 func testGroupPath(g gcs.Path, gridPrefix, groupName string) (*gcs.Path, error) {
 	name := path.Join(gridPrefix, groupName)
@@ -287,6 +409,7 @@ func groupPaths(tg *configpb.TestGroup) ([]gcs.Path, error) {
 	if len(prefixes) > 1 && !AllowMultiplePaths[tg.Name] {
 		return nil, fmt.Errorf("Maximum of one GCS path allowed")
 	}
+	prefixes = append(prefixes, tg.AdditionalGcsPrefixes...)
 	for idx, prefix := range prefixes {
 		prefix := strings.TrimSpace(prefix)
 		if prefix == "" {
@@ -329,6 +452,46 @@ func truncateRunning(cols []inflatedColumn) []inflatedColumn {
 	return cols[stillRunning:]
 }
 
+// truncateFinished filters out all columns until the oldest running column that may have finished since.
+//
+// Stats the finished.json of each running column under the path, concurrency at a time,
+// ignoring those still missing it unless the column started before timeout.
+func truncateFinished(ctx context.Context, log logrus.FieldLogger, client gcs.Stater, tgPath gcs.Path, cols []inflatedColumn, timeout time.Time, concurrency int) []inflatedColumn {
+	var idx []int
+	var paths []gcs.Path
+	for i, c := range cols {
+		if c.cells["Overall"].result != statuspb.TestStatus_RUNNING || int64(c.column.Started) < timeout.Unix()*1000 {
+			continue
+		}
+		p, err := tgPath.ResolveReference(&url.URL{Path: c.column.Build + "/finished.json"})
+		if err != nil {
+			continue
+		}
+		idx = append(idx, i)
+		paths = append(paths, *p)
+	}
+	unfinished := map[int]bool{}
+	for i, res := range gcs.StatAll(ctx, client, concurrency, paths...) {
+		if errors.Is(res.Err, storage.ErrObjectNotExist) {
+			unfinished[idx[i]] = true
+		}
+	}
+	var stillRunning int
+	for i, c := range cols {
+		if c.cells["Overall"].result == statuspb.TestStatus_RUNNING && !unfinished[i] {
+			stillRunning = i + 1
+		}
+	}
+	if len(unfinished) > 0 {
+		log.WithFields(logrus.Fields{
+			"running":    len(paths),
+			"unfinished": len(unfinished),
+			"kept":       len(cols) - stillRunning,
+		}).Debug("Checked running columns")
+	}
+	return cols[stillRunning:]
+}
+
 var (
 	maxUpdateArea  int = 20000
 	updateAreaLock sync.RWMutex
@@ -414,10 +577,76 @@ func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...g
 	return out, nil
 }
 
-func updateGCSGroup(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, concurrency int, write bool, buildTimeout time.Duration) error {
+func updateGCSGroup(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, concurrency int, write bool, buildTimeout time.Duration, cache *GridCache) error {
+	old, err := cache.download(ctx, client, gridPath)
+	if err != nil {
+		log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
+	}
+
+	const maxCols = 50
+	grid, err := buildGrid(ctx, log, client, tg, old, maxCols, concurrency, buildTimeout)
+	if err != nil {
+		return err
+	}
+	return writeGrid(ctx, log, client, gridPath, grid, write, cache)
+}
+
+// writeGrid uploads the grid to the path when write is set and it changed, caching it.
+func writeGrid(ctx context.Context, log logrus.FieldLogger, client gcs.Client, gridPath gcs.Path, grid *statepb.Grid, write bool, cache *GridCache) error {
+	buf, err := marshalGrid(grid)
+	if err != nil {
+		return fmt.Errorf("marshal grid: %w", err)
+	}
+	log = log.WithFields(logrus.Fields{
+		"url":   gridPath,
+		"bytes": len(buf),
+		"cols":  len(grid.Columns),
+		"rows":  len(grid.Rows),
+	})
+	if !write {
+		log.Info("Skipping write")
+		return nil
+	}
+	if unchangedGrid(ctx, client, gridPath, buf) {
+		log.Info("Skipping unchanged write")
+	} else {
+		log.Debug("Writing")
+		// TODO(fejta): configurable cache value
+		if err := client.Upload(ctx, gridPath, buf, gcs.DefaultAcl, "no-cache"); err != nil {
+			return fmt.Errorf("upload: %w", err)
+		}
+		log.Info("Wrote grid")
+	}
+	cache.put(gridPath, buf, grid)
+	return nil
+}
+
+// unchangedGrid returns true when the object at the path already holds buf, according to its size and checksum.
+func unchangedGrid(ctx context.Context, client gcs.Stater, path gcs.Path, buf []byte) bool {
+	attrs, err := client.Stat(ctx, path)
+	if err != nil {
+		return false
+	}
+	return attrs.Size == int64(len(buf)) && attrs.CRC32C == checksum(buf)
+}
+
+// checksum returns the CRC32C of buf, which GCS stores alongside each object.
+func checksum(buf []byte) uint32 {
+	return crc32.Checksum(buf, crc32.MakeTable(crc32.Castagnoli))
+}
+
+// SimulateGroup returns the grid the updater would create for a group without any previous state.
+//
+// Reads at most maxCols new columns and writes nothing.
+func SimulateGroup(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, tg *configpb.TestGroup, maxCols, concurrency int, buildTimeout time.Duration) (*statepb.Grid, error) {
+	return buildGrid(ctx, log, client, tg, nil, maxCols, concurrency, buildTimeout)
+}
+
+// buildGrid adds up to maxCols new columns to the recent columns of the old grid, if any.
+func buildGrid(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, tg *configpb.TestGroup, old *statepb.Grid, maxCols, concurrency int, buildTimeout time.Duration) (*statepb.Grid, error) {
 	tgPaths, err := groupPaths(tg)
 	if err != nil {
-		return fmt.Errorf("group path: %w", err)
+		return nil, fmt.Errorf("group path: %w", err)
 	}
 
 	var dur time.Duration
@@ -426,23 +655,29 @@ func updateGCSGroup(ctx context.Context, log logrus.FieldLogger, client gcs.Clie
 	} else {
 		dur = days(7)
 	}
-	const maxCols = 50
 
 	stop := time.Now().Add(-dur)
 
-	var oldCols []inflatedColumn
+	// Build numbers are only ordered within a path, so read each path
+	// separately when there are additional ones, interleaving by start time.
+	interleave := len(tgPaths) > 1 && len(tg.AdditionalGcsPrefixes) > 0
 
-	old, err := downloadGrid(ctx, client, gridPath)
-	if err != nil {
-		log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
-	}
+	var oldCols []inflatedColumn
 	if old != nil {
-		oldCols = truncateRunning(inflateGrid(old, stop, time.Now().Add(-4*time.Hour)))
+		oldCols = inflateGrid(old, stop, time.Now().Add(-4*time.Hour))
+		if stater, ok := client.(gcs.Stater); ok && len(tgPaths) == 1 {
+			// Builds running for a day time out, so reread those.
+			oldCols = truncateFinished(ctx, log, stater, tgPaths[0], oldCols, time.Now().Add(-24*time.Hour), concurrency)
+		} else {
+			oldCols = truncateRunning(oldCols)
+		}
 	}
 
 	var since string
 	if len(oldCols) > 0 {
-		since = oldCols[0].column.Build
+		if !interleave {
+			since = oldCols[0].column.Build
+		}
 		newStop := time.Unix(int64(oldCols[0].column.Started/1000), 0)
 		if newStop.After(stop) {
 			log.WithFields(logrus.Fields{
@@ -454,41 +689,62 @@ func updateGCSGroup(ctx context.Context, log logrus.FieldLogger, client gcs.Clie
 		}
 	}
 
-	builds, err := listBuilds(ctx, client, since, tgPaths...)
-	if err != nil {
-		return fmt.Errorf("list builds: %w", err)
-	}
-	log.WithField("total", len(builds)).Debug("Listed builds")
+	var newCols []inflatedColumn
+	var partial bool
+	if interleave {
+		for idx, tgPath := range tgPaths {
+			builds, err := listBuilds(ctx, client, "", tgPath)
+			if err != nil {
+				return nil, fmt.Errorf("list builds: %w", err)
+			}
+			log.WithField("path", tgPath).WithField("total", len(builds)).Debug("Listed builds")
+			cols, err := readColumns(ctx, client, tg, builds, stop, maxCols, buildTimeout, concurrency)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %s: read columns: %w", idx, tgPath, err)
+			}
+			// Skip the older column each path reads past stop, which would
+			// otherwise replace the newer old columns of other paths.
+			for _, col := range cols {
+				if int64(col.column.Started) >= stop.Unix()*1000 {
+					newCols = append(newCols, col)
+				}
+			}
+		}
+		sort.SliceStable(newCols, func(i, j int) bool {
+			return newCols[i].column.Started > newCols[j].column.Started
+		})
+		if len(newCols) > maxCols {
+			newCols = newCols[:maxCols]
+		}
+	} else {
+		builds, err := listBuilds(ctx, client, since, tgPaths...)
+		if err != nil {
+			return nil, fmt.Errorf("list builds: %w", err)
+		}
+		log.WithField("total", len(builds)).Debug("Listed builds")
 
-	builds = truncateBuilds(log, builds, oldCols)
+		builds = truncateBuilds(log, builds, oldCols)
 
-	newCols, err := readColumns(ctx, client, tg, builds, stop, maxCols, buildTimeout, concurrency)
-	if err != nil {
-		return fmt.Errorf("read columns: %w", err)
+		readCtx, cancel := readContext(ctx)
+		defer cancel()
+		// Later updates only list builds newer than the newest column, so read the oldest first.
+		newCols, err = readChunks(readCtx, log, client, tg, builds, stop, maxCols, buildTimeout, concurrency, len(oldCols) > 0)
+		if err != nil {
+			return nil, fmt.Errorf("read columns: %w", err)
+		}
+		partial = readCtx.Err() != nil
 	}
 
 	cols := mergeColumns(newCols, oldCols)
-
-	grid := constructGrid(log, tg, cols)
-	buf, err := marshalGrid(grid)
-	if err != nil {
-		return fmt.Errorf("marshal grid: %w", err)
-	}
-	log = log.WithField("url", gridPath).WithField("bytes", len(buf))
-	if !write {
-		log.Debug("Skipping write")
-	} else {
-		log.Debug("Writing")
-		// TODO(fejta): configurable cache value
-		if err := client.Upload(ctx, gridPath, buf, gcs.DefaultAcl, "no-cache"); err != nil {
-			return fmt.Errorf("upload: %w", err)
+	if !interleave && !partial {
+		if cols, err = fillListingGaps(ctx, log, client, tg, tgPaths, cols, buildTimeout, concurrency); err != nil {
+			return nil, fmt.Errorf("fill listing gaps: %w", err)
 		}
 	}
-	log.WithFields(logrus.Fields{
-		"cols": len(grid.Columns),
-		"rows": len(grid.Rows),
-	}).Info("Wrote grid")
-	return nil
+	cols = mergeDuplicateBuilds(cols, tg.DuplicateBuilds)
+	cols = thinColumns(cols, tg.ColumnRetention, time.Now())
+
+	return constructGrid(log, tg, cols), nil
 }
 
 // mergeColumns combines newCols and oldCols.
@@ -512,6 +768,103 @@ func mergeColumns(newCols, oldCols []inflatedColumn) []inflatedColumn {
 	return out
 }
 
+// mergeDuplicateBuilds combines the columns that share a build ID according to the policy.
+//
+// Keeps the position of the column that started last.
+func mergeDuplicateBuilds(cols []inflatedColumn, policy configpb.TestGroup_DuplicateBuilds) []inflatedColumn {
+	if policy == configpb.TestGroup_DUPLICATE_BUILDS_SEPARATE {
+		return cols
+	}
+	latest := map[string]int{}
+	for i, col := range cols {
+		id := col.column.Build
+		if j, ok := latest[id]; !ok || col.column.Started > cols[j].column.Started {
+			latest[id] = i
+		}
+	}
+	if len(latest) == len(cols) {
+		return cols
+	}
+	out := make([]inflatedColumn, 0, len(latest))
+	for i, col := range cols {
+		id := col.column.Build
+		if latest[id] != i {
+			continue
+		}
+		if policy == configpb.TestGroup_DUPLICATE_BUILDS_UNION {
+			col = unionColumns(col, cols, i)
+		}
+		out = append(out, col)
+	}
+	return out
+}
+
+// unionColumns adds the cells of every other column with the same build ID to cols[idx].
+//
+// Prefers the cells of columns that started later.
+func unionColumns(col inflatedColumn, cols []inflatedColumn, idx int) inflatedColumn {
+	var dups []inflatedColumn
+	for i, other := range cols {
+		if i != idx && other.column.Build == col.column.Build {
+			dups = append(dups, other)
+		}
+	}
+	sort.SliceStable(dups, func(i, j int) bool {
+		return dups[i].column.Started > dups[j].column.Started
+	})
+	cells := make(map[string]cell, len(col.cells))
+	for name, c := range col.cells {
+		cells[name] = c
+	}
+	for _, dup := range dups {
+		for name, c := range dup.cells {
+			if _, ok := cells[name]; !ok {
+				cells[name] = c
+			}
+		}
+	}
+	return inflatedColumn{column: col.column, cells: cells}
+}
+
+// thinColumns drops old columns according to the retention policy.
+//
+// Exponential retention keeps every column started within a week of now,
+// the latest column of each UTC day within 90 days and the latest column of each week before that.
+// Expects the columns to start from the latest.
+func thinColumns(cols []inflatedColumn, policy configpb.TestGroup_ColumnRetention, now time.Time) []inflatedColumn {
+	if policy != configpb.TestGroup_COLUMN_RETENTION_EXPONENTIAL {
+		return cols
+	}
+	type period struct {
+		weekly bool
+		n      int64
+	}
+	week := now.Add(-days(7)).Unix()
+	quarter := now.Add(-days(90)).Unix()
+	const day = 24 * 60 * 60
+	kept := map[period]bool{}
+	out := make([]inflatedColumn, 0, len(cols))
+	for _, col := range cols {
+		when := int64(col.column.Started / 1000)
+		var p period
+		switch {
+		case when > week:
+			out = append(out, col)
+			continue
+		case when > quarter:
+			p = period{n: when / day}
+		default:
+			p = period{weekly: true, n: when / (7 * day)}
+		}
+		if kept[p] {
+			continue
+		}
+		kept[p] = true
+		out = append(out, col)
+	}
+	return out
+}
+
 // days converts days float into a time.Duration, assuming a 24 hour day.
 //
 // A day is not always 24 hours due to things like leap-seconds.
@@ -533,13 +886,16 @@ func constructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []inf
 		passesClose = 1
 	}
 
+	limitColumns(log, group, cols)
 	for _, col := range cols {
 		appendColumn(&grid, rows, col)
 	}
 
 	dropEmptyRows(log, &grid, rows)
+	dropIgnoredRows(log, &grid, rows, group.IgnorePass, group.IgnoreSkip)
 
-	alertRows(grid.Columns, grid.Rows, failsOpen, passesClose)
+	alerts.AlertRows(grid.Columns, grid.Rows, failsOpen, passesClose)
+	alerts.FailureStreaks(grid.Columns, grid.Rows)
 	sort.SliceStable(grid.Rows, func(i, j int) bool {
 		return sortorder.NaturalLess(grid.Rows[i].Name, grid.Rows[j].Name)
 	})
@@ -586,13 +942,78 @@ func dropEmptyRows(log logrus.FieldLogger, grid *statepb.Grid, rows map[string]*
 }
 
 // marhshalGrid serializes a state proto into zlib-compressed bytes.
+// dropIgnoredRows omits rows with only passing or only skipped results, as configured.
+//
+// Counts the cells of each omitted row in the ignored_passes or ignored_skips of its columns.
+// Always keeps the Overall row.
+func dropIgnoredRows(log logrus.FieldLogger, grid *statepb.Grid, rows map[string]*statepb.Row, ignorePass, ignoreSkip bool) {
+	if !ignorePass && !ignoreSkip {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kept := make([]*statepb.Row, 0, len(grid.Rows))
+	var dropped int
+	for _, r := range grid.Rows {
+		if r.Name == "Overall" {
+			kept = append(kept, r)
+			continue
+		}
+		only := statuspb.TestStatus_NO_RESULT
+		for res := range result.Iter(ctx, r.Results) {
+			if res == statuspb.TestStatus_NO_RESULT || res == only {
+				continue
+			}
+			if only != statuspb.TestStatus_NO_RESULT {
+				only = statuspb.TestStatus_NO_RESULT
+				break
+			}
+			only = res
+		}
+		var counter func(*statepb.Column)
+		switch {
+		case ignorePass && only == statuspb.TestStatus_PASS:
+			counter = func(col *statepb.Column) { col.IgnoredPasses++ }
+		case ignoreSkip && only == statuspb.TestStatus_PASS_WITH_SKIPS:
+			counter = func(col *statepb.Column) { col.IgnoredSkips++ }
+		default:
+			kept = append(kept, r)
+			continue
+		}
+		var idx int
+		for res := range result.Iter(ctx, r.Results) {
+			if res != statuspb.TestStatus_NO_RESULT && idx < len(grid.Columns) {
+				counter(grid.Columns[idx])
+			}
+			idx++
+		}
+		dropped++
+		delete(rows, r.Name)
+	}
+
+	if dropped == 0 {
+		return
+	}
+
+	grid.Rows = kept
+	log.WithField("dropped", dropped).Info("Dropped ignored rows")
+}
+
 func marshalGrid(grid *statepb.Grid) ([]byte, error) {
+	return marshalGridLevel(grid, zlib.DefaultCompression)
+}
+
+// marshalGridLevel serializes a state proto into bytes compressed at the zlib level.
+func marshalGridLevel(grid *statepb.Grid, level int) ([]byte, error) {
 	buf, err := proto.Marshal(grid)
 	if err != nil {
 		return nil, fmt.Errorf("marshal: %w", err)
 	}
 	var zbuf bytes.Buffer
-	zw := zlib.NewWriter(&zbuf)
+	zw, err := zlib.NewWriterLevel(&zbuf, level)
+	if err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
 	if _, err = zw.Write(buf); err != nil {
 		return nil, fmt.Errorf("compress: %w", err)
 	}
@@ -665,6 +1086,13 @@ func appendCell(row *statepb.Row, cell cell, count int) {
 		// Javascript client expects no result cells to skip icons/messages
 		row.Messages = append(row.Messages, cell.message)
 		row.Icons = append(row.Icons, cell.icon)
+		if len(cell.properties) > 0 || len(row.Properties) > 0 {
+			// Only rows with properties store them, padding earlier results.
+			for len(row.Properties) < len(row.Messages)-1 {
+				row.Properties = append(row.Properties, &statepb.Property{})
+			}
+			row.Properties = append(row.Properties, &statepb.Property{Property: cell.properties})
+		}
 	}
 }
 
@@ -705,114 +1133,3 @@ func appendColumn(grid *statepb.Grid, rows map[string]*statepb.Row, inflated inf
 		appendCell(row, emptyCell, 1)
 	}
 }
-
-// alertRows configures the alert for every row that has one.
-func alertRows(cols []*statepb.Column, rows []*statepb.Row, openFailures, closePasses int) {
-	for _, r := range rows {
-		r.AlertInfo = alertRow(cols, r, openFailures, closePasses)
-	}
-}
-
-// alertRow returns an AlertInfo proto if there have been failuresToOpen consecutive failures more recently than passesToClose.
-func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesToClose int) *statepb.AlertInfo {
-	if failuresToOpen == 0 {
-		return nil
-	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	var failures int
-	var totalFailures int32
-	var passes int
-	var compressedIdx int
-	ch := result.Iter(ctx, row.Results)
-	var lastFail *statepb.Column
-	var latestPass *statepb.Column
-	var failIdx int
-	// find the first number of consecutive passesToClose (no alert)
-	// or else failuresToOpen (alert).
-	for _, col := range cols {
-		// TODO(fejta): ignore old running
-		rawRes := <-ch
-		res := result.Coalesce(rawRes, result.IgnoreRunning)
-		if res == statuspb.TestStatus_NO_RESULT {
-			if rawRes == statuspb.TestStatus_RUNNING {
-				compressedIdx++
-			}
-			continue
-		}
-		if res == statuspb.TestStatus_PASS {
-			passes++
-			if failures >= failuresToOpen {
-				latestPass = col // most recent pass before outage
-				break
-			}
-			if passes >= passesToClose {
-				return nil // there is no outage
-			}
-			failures = 0
-		}
-		if res == statuspb.TestStatus_FAIL {
-			passes = 0
-			failures++
-			totalFailures++
-			if failures == 1 { // note most recent failure for this outage
-				failIdx = compressedIdx
-			}
-			lastFail = col
-		}
-		if res == statuspb.TestStatus_FLAKY {
-			passes = 0
-			if failures >= failuresToOpen {
-				break // cannot definitively say which commit is at fault
-			}
-			failures = 0
-		}
-		compressedIdx++
-	}
-	if failures < failuresToOpen {
-		return nil
-	}
-	msg := row.Messages[failIdx]
-	id := row.CellIds[failIdx]
-	return alertInfo(totalFailures, msg, id, lastFail, latestPass)
-}
-
-// alertInfo returns an alert proto with the configured fields
-func alertInfo(failures int32, msg, cellID string, fail, pass *statepb.Column) *statepb.AlertInfo {
-	return &statepb.AlertInfo{
-		FailCount:      failures,
-		FailBuildId:    buildID(fail),
-		FailTime:       stamp(fail),
-		FailTestId:     cellID,
-		FailureMessage: msg,
-		PassTime:       stamp(pass),
-		PassBuildId:    buildID(pass),
-	}
-}
-
-// buildID extracts the ID from the first extra row or else the Build field.
-func buildID(col *statepb.Column) string {
-	if col == nil {
-		return ""
-	}
-	if len(col.Extra) > 0 {
-		return col.Extra[0]
-	}
-	return col.Build
-}
-
-const billion = 1e9
-
-// stamp converts seconds into a timestamp proto
-func stamp(col *statepb.Column) *timestamp.Timestamp {
-	if col == nil {
-		return nil
-	}
-	seconds := col.Started
-	floor := math.Floor(seconds)
-	remain := seconds - floor
-	return &timestamp.Timestamp{
-		Seconds: int64(floor),
-		Nanos:   int32(remain * billion),
-	}
-}
@@ -28,7 +28,6 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/fvbommel/sortorder"
 	"github.com/golang/protobuf/proto"
-	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -39,6 +38,7 @@ import (
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/alerts"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 )
 
@@ -103,7 +103,7 @@ func TestUpdate(t *testing.T) {
 		skipConfirm      bool
 		groupTimeout     *time.Duration
 		buildTimeout     *time.Duration
-		group            string
+		groups           []string
 
 		expected fakeUploader
 		err      bool
@@ -156,6 +156,121 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update named groups",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                "hello",
+						GcsPrefix:           "kubernetes-jenkins/path/to/job",
+						DaysOfResults:       7,
+						UseKubernetesClient: true,
+						NumColumnsRecent:    6,
+					},
+					{
+						Name:                "world",
+						GcsPrefix:           "kubernetes-jenkins/path/to/job",
+						DaysOfResults:       7,
+						UseKubernetesClient: true,
+						NumColumnsRecent:    6,
+					},
+					{
+						Name:                "ignored",
+						GcsPrefix:           "kubernetes-jenkins/path/to/job",
+						DaysOfResults:       7,
+						UseKubernetesClient: true,
+						NumColumnsRecent:    6,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "hello-tab",
+								TestGroupName: "hello",
+							},
+							{
+								Name:          "world-tab",
+								TestGroupName: "world",
+							},
+							{
+								Name:          "ignored-tab",
+								TestGroupName: "ignored",
+							},
+						},
+					},
+				},
+			},
+			groups: []string{"hello", "world"},
+			expected: fakeUploader{
+				*resolveOrDie(&configPath, "hello"): {
+					buf:          mustGrid(&statepb.Grid{}),
+					cacheControl: "no-cache",
+					worldRead:    gcs.DefaultAcl,
+				},
+				*resolveOrDie(&configPath, "world"): {
+					buf:          mustGrid(&statepb.Grid{}),
+					cacheControl: "no-cache",
+					worldRead:    gcs.DefaultAcl,
+				},
+			},
+		},
+		{
+			name: "reject missing named groups",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                "hello",
+						GcsPrefix:           "kubernetes-jenkins/path/to/job",
+						DaysOfResults:       7,
+						UseKubernetesClient: true,
+						NumColumnsRecent:    6,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "hello-tab",
+								TestGroupName: "hello",
+							},
+						},
+					},
+				},
+			},
+			groups: []string{"hello", "missing"},
+			err:    true,
+		},
+		{
+			name: "skip writes without confirm",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:                "hello",
+						GcsPrefix:           "kubernetes-jenkins/path/to/job",
+						DaysOfResults:       7,
+						UseKubernetesClient: true,
+						NumColumnsRecent:    6,
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "hello-tab",
+								TestGroupName: "hello",
+							},
+						},
+					},
+				},
+			},
+			groups:      []string{"hello"},
+			skipConfirm: true,
+			expected:    fakeUploader{},
+		},
 		// TODO(fejta): more cases
 	}
 
@@ -220,9 +335,11 @@ func TestUpdate(t *testing.T) {
 				ctx,
 				client,
 				configPath,
+				0,
 				tc.gridPrefix,
+				"",
 				tc.groupConcurrency,
-				tc.group,
+				tc.groups,
 				groupUpdater,
 			)
 			switch {
@@ -501,13 +618,56 @@ func TestSortGroups(t *testing.T) {
 	}
 }
 
-func TestGroupPaths(t *testing.T) {
+func TestRemainingGroups(t *testing.T) {
 	cases := []struct {
 		name     string
-		prefix   string
-		allowed  bool
-		expected []gcs.Path
-		err      bool
+		groups   []*configpb.TestGroup
+		done     map[string]bool
+		expected []*configpb.TestGroup
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "keeps pending groups in order",
+			groups: []*configpb.TestGroup{
+				{Name: "hello"},
+				{Name: "world"},
+				{Name: "new"},
+			},
+			done: map[string]bool{"hello": true},
+			expected: []*configpb.TestGroup{
+				{Name: "world"},
+				{Name: "new"},
+			},
+		},
+		{
+			name: "ignores done groups no longer configured",
+			groups: []*configpb.TestGroup{
+				{Name: "world"},
+			},
+			done: map[string]bool{"hello": true, "world": true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := remainingGroups(tc.groups, tc.done)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("remainingGroups() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupPaths(t *testing.T) {
+	cases := []struct {
+		name       string
+		prefix     string
+		additional []string
+		allowed    bool
+		expected   []gcs.Path
+		err        bool
 	}{
 		{
 			name: "basically works",
@@ -533,11 +693,26 @@ func TestGroupPaths(t *testing.T) {
 				newPathOrDie("gs://another/one/"),
 			},
 		},
+		{
+			name:       "additional prefixes",
+			prefix:     "foo/bar",
+			additional: []string{"another/one"},
+			expected: []gcs.Path{
+				newPathOrDie("gs://foo/bar/"),
+				newPathOrDie("gs://another/one/"),
+			},
+		},
 		{
 			name:   "reject bad path",
 			prefix: "foo:6667/haha",
 			err:    true,
 		},
+		{
+			name:       "reject bad additional path",
+			prefix:     "foo/bar",
+			additional: []string{"foo:6667/haha"},
+			err:        true,
+		},
 	}
 
 	old := AllowMultiplePaths
@@ -548,6 +723,7 @@ func TestGroupPaths(t *testing.T) {
 			var group configpb.TestGroup
 			group.Name = tc.name
 			group.GcsPrefix = tc.prefix
+			group.AdditionalGcsPrefixes = tc.additional
 			if tc.allowed {
 				AllowMultiplePaths = map[string]bool{
 					group.Name: true,
@@ -655,6 +831,90 @@ func TestTruncateRunning(t *testing.T) {
 	}
 }
 
+func TestTruncateFinished(t *testing.T) {
+	now := time.Now()
+	timeout := now.Add(-24 * time.Hour)
+	tgPath := newPathOrDie("gs://bucket/logs/job/")
+	col := func(build string, hours int, result statuspb.TestStatus) inflatedColumn {
+		return inflatedColumn{
+			column: &statepb.Column{
+				Build:   build,
+				Started: float64(now.Add(-time.Duration(hours)*time.Hour).Unix() * 1000),
+			},
+			cells: map[string]cell{"Overall": {result: result}},
+		}
+	}
+	finished := func(builds ...string) fakeStater {
+		fs := fakeStater{}
+		for _, b := range builds {
+			fs[*resolveOrDie(&tgPath, b+"/finished.json")] = fakeStat{}
+		}
+		return fs
+	}
+	cases := []struct {
+		name   string
+		cols   []inflatedColumn
+		client fakeStater
+		want   int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "keep unfinished running columns",
+			cols: []inflatedColumn{
+				col("5", 5, statuspb.TestStatus_PASS),
+				col("4", 6, statuspb.TestStatus_RUNNING),
+				col("3", 7, statuspb.TestStatus_FAIL),
+			},
+			client: finished(),
+		},
+		{
+			name: "drop everything before the oldest finished column",
+			cols: []inflatedColumn{
+				col("5", 5, statuspb.TestStatus_RUNNING),
+				col("4", 6, statuspb.TestStatus_PASS),
+				col("3", 7, statuspb.TestStatus_RUNNING),
+				col("2", 8, statuspb.TestStatus_RUNNING),
+				col("1", 9, statuspb.TestStatus_PASS),
+			},
+			client: finished("3"),
+			want:   3,
+		},
+		{
+			name: "drop columns running past the timeout",
+			cols: []inflatedColumn{
+				col("5", 5, statuspb.TestStatus_RUNNING),
+				col("4", 30, statuspb.TestStatus_RUNNING),
+				col("3", 31, statuspb.TestStatus_PASS),
+			},
+			client: finished(),
+			want:   2,
+		},
+		{
+			name: "drop columns that fail to stat",
+			cols: []inflatedColumn{
+				col("5", 5, statuspb.TestStatus_PASS),
+				col("4", 6, statuspb.TestStatus_RUNNING),
+				col("3", 7, statuspb.TestStatus_FAIL),
+			},
+			client: fakeStater{
+				*resolveOrDie(&tgPath, "4/finished.json"): fakeStat{err: errors.New("injected stat error")},
+			},
+			want: 2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := truncateFinished(context.Background(), logrus.WithField("test", tc.name), tc.client, tgPath, tc.cols, timeout, 2)
+			want := tc.cols[tc.want:]
+			if diff := cmp.Diff(want, got, cmp.AllowUnexported(inflatedColumn{}, cell{}), protocmp.Transform()); diff != "" {
+				t.Errorf("truncateFinished() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestTruncateBuilds(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -1198,6 +1458,7 @@ func TestUpdateGCSGroup(t *testing.T) {
 				tc.concurrency,
 				!tc.skipWrite,
 				*tc.buildTimeout,
+				nil,
 			)
 			switch {
 			case err != nil:
@@ -1239,93 +1500,293 @@ func TestUpdateGCSGroup(t *testing.T) {
 	}
 }
 
-func TestMergeColumns(t *testing.T) {
+func TestUnchangedGrid(t *testing.T) {
+	path := newPathOrDie("gs://bucket/grid/foo")
+	buf := []byte("hello")
 	cases := []struct {
-		name     string
-		newCols  []inflatedColumn
-		oldCols  []inflatedColumn
-		expected []inflatedColumn
+		name      string
+		stat      *fakeStat
+		unchanged bool
 	}{
 		{
-			name: "basically works",
+			name: "missing",
 		},
 		{
-			name: "only new cols",
-			newCols: []inflatedColumn{
-				{
-					column: &statepb.Column{
-						Build: "hello",
-					},
-					cells: map[string]cell{
-						"this": {result: statuspb.TestStatus_PASS},
-					},
-				},
-				{
-					column: &statepb.Column{
-						Build: "world",
-					},
-					cells: map[string]cell{
-						"that": {result: statuspb.TestStatus_FAIL},
-					},
-				},
-			},
-			expected: []inflatedColumn{
-				{
-					column: &statepb.Column{
-						Build: "hello",
-					},
-					cells: map[string]cell{
-						"this": {result: statuspb.TestStatus_PASS},
-					},
-				},
-				{
-					column: &statepb.Column{
-						Build: "world",
-					},
-					cells: map[string]cell{
-						"that": {result: statuspb.TestStatus_FAIL},
-					},
-				},
-			},
+			name: "stat error",
+			stat: &fakeStat{err: errors.New("injected")},
 		},
 		{
-			name: "only old cols",
-			oldCols: []inflatedColumn{
-				{
-					column: &statepb.Column{
-						Build: "ancient",
-					},
-					cells: map[string]cell{
-						"this": {result: statuspb.TestStatus_PASS},
-					},
-				},
-				{
-					column: &statepb.Column{
-						Build: "graveyard",
-					},
-					cells: map[string]cell{
-						"that": {result: statuspb.TestStatus_FAIL},
-					},
-				},
-			},
-			expected: []inflatedColumn{
-				{
-					column: &statepb.Column{
-						Build: "ancient",
-					},
-					cells: map[string]cell{
-						"this": {result: statuspb.TestStatus_PASS},
-					},
-				},
-				{
-					column: &statepb.Column{
-						Build: "graveyard",
-					},
-					cells: map[string]cell{
-						"that": {result: statuspb.TestStatus_FAIL},
-					},
-				},
-			},
+			name:      "same content",
+			stat:      &fakeStat{attrs: storage.ObjectAttrs{Size: 5, CRC32C: checksum(buf)}},
+			unchanged: true,
+		},
+		{
+			name: "different checksum",
+			stat: &fakeStat{attrs: storage.ObjectAttrs{Size: 5, CRC32C: checksum([]byte("world"))}},
+		},
+		{
+			name: "different size",
+			stat: &fakeStat{attrs: storage.ObjectAttrs{Size: 6, CRC32C: checksum(buf)}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeStater{}
+			if tc.stat != nil {
+				client[path] = *tc.stat
+			}
+			if got := unchangedGrid(context.Background(), client, path, buf); got != tc.unchanged {
+				t.Errorf("unchangedGrid() got %t, want %t", got, tc.unchanged)
+			}
+		})
+	}
+}
+
+func TestSimulateGroup(t *testing.T) {
+	now := time.Now().Unix()
+	group := configpb.TestGroup{
+		GcsPrefix: "bucket/path/to/build/",
+	}
+	builds := []fakeBuild{
+		{
+			id:       "2",
+			started:  jsonStarted(now - 10),
+			finished: jsonFinished(now-9, false, metadata.Metadata{}),
+			failed:   []string{"flaky"},
+		},
+		{
+			id:       "1",
+			started:  jsonStarted(now - 20),
+			finished: jsonFinished(now-19, true, metadata.Metadata{}),
+			passed:   []string{"flaky"},
+		},
+	}
+	cases := []struct {
+		name    string
+		maxCols int
+		want    []string
+	}{
+		{
+			name:    "basically works",
+			maxCols: 10,
+			want:    []string{"2", "1"},
+		},
+		{
+			name:    "limit columns",
+			maxCols: 1,
+			want:    []string{"2"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeClient{
+				fakeLister: fakeLister{},
+				fakeOpener: fakeOpener{},
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			fi := client.fakeLister[buildsPath]
+			for _, build := range client.addBuilds(buildsPath, builds...) {
+				fi.objects = append(fi.objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.fakeLister[buildsPath] = fi
+
+			grid, err := SimulateGroup(context.Background(), logrus.WithField("test", tc.name), client, &group, tc.maxCols, 2, time.Minute)
+			if err != nil {
+				t.Fatalf("SimulateGroup() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SimulateGroup() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildGrid(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int64) int64 {
+		return now.Add(-time.Duration(h) * time.Hour).Unix()
+	}
+	// Build numbers restart in the new bucket.
+	hours := map[string]int64{"3": 5, "2": 10, "100": 20, "1": 30, "99": 40}
+	builds := func(ids ...string) []fakeBuild {
+		var out []fakeBuild
+		for _, id := range ids {
+			out = append(out, fakeBuild{
+				id:       id,
+				started:  jsonStarted(hoursAgo(hours[id])),
+				finished: jsonFinished(hoursAgo(hours[id])+1, true, metadata.Metadata{}),
+				passed:   []string{"good"},
+			})
+		}
+		return out
+	}
+	group := configpb.TestGroup{
+		Name:                  "group",
+		GcsPrefix:             "new-bucket/logs/job/",
+		AdditionalGcsPrefixes: []string{"old-bucket/logs/job/"},
+	}
+	sources := map[string][]fakeBuild{
+		"gs://new-bucket/logs/job/": builds("3", "2", "1"),
+		"gs://old-bucket/logs/job/": builds("100", "99"),
+	}
+	oldGrid := func(ids ...string) *statepb.Grid {
+		var grid statepb.Grid
+		var cells []cell
+		for _, id := range ids {
+			grid.Columns = append(grid.Columns, &statepb.Column{Build: id, Started: float64(hoursAgo(hours[id]) * 1000)})
+			cells = append(cells, cell{result: statuspb.TestStatus_PASS})
+		}
+		grid.Rows = []*statepb.Row{setupRow(&statepb.Row{Name: "good", Id: "good"}, cells...)}
+		return &grid
+	}
+	cases := []struct {
+		name    string
+		old     *statepb.Grid
+		maxCols int
+		want    []string
+	}{
+		{
+			name:    "interleave paths by start time",
+			maxCols: 10,
+			want:    []string{"3", "2", "100", "1", "99"},
+		},
+		{
+			name:    "keep the newest columns",
+			maxCols: 3,
+			want:    []string{"3", "2", "100"},
+		},
+		{
+			name:    "merge with old columns",
+			old:     oldGrid("100", "1", "99"),
+			maxCols: 10,
+			want:    []string{"3", "2", "100", "1", "99"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeClient{
+				fakeLister: fakeLister{},
+				fakeOpener: fakeOpener{},
+			}
+			for path, fakes := range sources {
+				buildsPath := newPathOrDie(path)
+				fi := client.fakeLister[buildsPath]
+				for _, build := range client.addBuilds(buildsPath, fakes...) {
+					fi.objects = append(fi.objects, storage.ObjectAttrs{
+						Prefix: build.Path.Object(),
+					})
+				}
+				client.fakeLister[buildsPath] = fi
+			}
+
+			grid, err := buildGrid(context.Background(), logrus.WithField("test", tc.name), client, &group, tc.old, tc.maxCols, 2, time.Minute)
+			if err != nil {
+				t.Fatalf("buildGrid() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("buildGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeColumns(t *testing.T) {
+	cases := []struct {
+		name     string
+		newCols  []inflatedColumn
+		oldCols  []inflatedColumn
+		expected []inflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "only new cols",
+			newCols: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build: "hello",
+					},
+					cells: map[string]cell{
+						"this": {result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					column: &statepb.Column{
+						Build: "world",
+					},
+					cells: map[string]cell{
+						"that": {result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+			expected: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build: "hello",
+					},
+					cells: map[string]cell{
+						"this": {result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					column: &statepb.Column{
+						Build: "world",
+					},
+					cells: map[string]cell{
+						"that": {result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+		},
+		{
+			name: "only old cols",
+			oldCols: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build: "ancient",
+					},
+					cells: map[string]cell{
+						"this": {result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					column: &statepb.Column{
+						Build: "graveyard",
+					},
+					cells: map[string]cell{
+						"that": {result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+			expected: []inflatedColumn{
+				{
+					column: &statepb.Column{
+						Build: "ancient",
+					},
+					cells: map[string]cell{
+						"this": {result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					column: &statepb.Column{
+						Build: "graveyard",
+					},
+					cells: map[string]cell{
+						"that": {result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
 		},
 		{
 			name: "accept all when old are all older than new",
@@ -1721,6 +2182,157 @@ func TestMergeColumns(t *testing.T) {
 	}
 }
 
+func TestMergeDuplicateBuilds(t *testing.T) {
+	pass := cell{result: statuspb.TestStatus_PASS}
+	fail := cell{result: statuspb.TestStatus_FAIL}
+	col := func(build string, started float64, cells map[string]cell) inflatedColumn {
+		return inflatedColumn{
+			column: &statepb.Column{Build: build, Started: started},
+			cells:  cells,
+		}
+	}
+	cols := []inflatedColumn{
+		col("2", 200, map[string]cell{"Overall": pass}),
+		col("1", 150, map[string]cell{"Overall": fail, "retried": pass}),
+		col("1", 100, map[string]cell{"Overall": fail, "retried": fail, "flaky": fail}),
+		col("0", 50, map[string]cell{"Overall": pass}),
+	}
+	cases := []struct {
+		name     string
+		cols     []inflatedColumn
+		policy   configpb.TestGroup_DuplicateBuilds
+		expected []inflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "separate by default",
+			cols:     cols,
+			expected: cols,
+		},
+		{
+			name:   "latest",
+			cols:   cols,
+			policy: configpb.TestGroup_DUPLICATE_BUILDS_LATEST,
+			expected: []inflatedColumn{
+				cols[0],
+				cols[1],
+				cols[3],
+			},
+		},
+		{
+			name:   "latest when out of order",
+			cols:   []inflatedColumn{cols[2], cols[1]},
+			policy: configpb.TestGroup_DUPLICATE_BUILDS_LATEST,
+			expected: []inflatedColumn{
+				cols[1],
+			},
+		},
+		{
+			name:   "union",
+			cols:   cols,
+			policy: configpb.TestGroup_DUPLICATE_BUILDS_UNION,
+			expected: []inflatedColumn{
+				cols[0],
+				col("1", 150, map[string]cell{"Overall": fail, "retried": pass, "flaky": fail}),
+				cols[3],
+			},
+		},
+		{
+			name:   "unique builds",
+			cols:   []inflatedColumn{cols[0], cols[1], cols[3]},
+			policy: configpb.TestGroup_DUPLICATE_BUILDS_UNION,
+			expected: []inflatedColumn{
+				cols[0],
+				cols[1],
+				cols[3],
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mergeDuplicateBuilds(tc.cols, tc.policy)
+			internals := cmp.AllowUnexported(inflatedColumn{}, cell{})
+			if diff := cmp.Diff(tc.expected, actual, internals, protocmp.Transform()); diff != "" {
+				t.Errorf("mergeDuplicateBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestThinColumns(t *testing.T) {
+	now := time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC)
+	col := func(name string, ago time.Duration) inflatedColumn {
+		return inflatedColumn{
+			column: &statepb.Column{
+				Build:   name,
+				Started: float64(now.Add(-ago).Unix() * 1000),
+			},
+		}
+	}
+	hour := time.Hour
+	day := 24 * hour
+	cols := []inflatedColumn{
+		col("recent", hour),
+		col("recent-same-day", 2*hour),
+		col("six-days", 6*day),
+		col("ten-days", 10*day),
+		col("ten-days-earlier", 10*day+hour),
+		col("eleven-days", 11*day),
+		col("hundred-days", 100*day),
+		col("hundred-and-one-days", 101*day),
+		col("year", 365*day),
+	}
+
+	cases := []struct {
+		name   string
+		policy configpb.TestGroup_ColumnRetention
+		want   []string
+	}{
+		{
+			name: "keep all by default",
+			want: []string{
+				"recent",
+				"recent-same-day",
+				"six-days",
+				"ten-days",
+				"ten-days-earlier",
+				"eleven-days",
+				"hundred-days",
+				"hundred-and-one-days",
+				"year",
+			},
+		},
+		{
+			name:   "exponential",
+			policy: configpb.TestGroup_COLUMN_RETENTION_EXPONENTIAL,
+			want: []string{
+				"recent",
+				"recent-same-day",
+				"six-days",
+				"ten-days",
+				"eleven-days",
+				"hundred-days",
+				"year",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, c := range thinColumns(cols, tc.policy, now) {
+				got = append(got, c.column.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("thinColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestConstructGrid(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -1978,7 +2590,8 @@ func TestConstructGrid(t *testing.T) {
 			if failuresOpen > 0 && passesClose == 0 {
 				passesClose = 1
 			}
-			alertRows(tc.expected.Columns, tc.expected.Rows, failuresOpen, passesClose)
+			alerts.AlertRows(tc.expected.Columns, tc.expected.Rows, failuresOpen, passesClose)
+			alerts.FailureStreaks(tc.expected.Columns, tc.expected.Rows)
 			for _, row := range tc.expected.Rows {
 				sort.SliceStable(row.Metric, func(i, j int) bool {
 					return sortorder.NaturalLess(row.Metric[i], row.Metric[j])
@@ -2176,6 +2789,67 @@ func TestAppendCell(t *testing.T) {
 				Icons:    []string{"", "", "", "keeps going", "keeps going"},
 			},
 		},
+		{
+			name: "properties pad earlier results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+				},
+				CellIds:  []string{"", ""},
+				Messages: []string{"", ""},
+				Icons:    []string{"", ""},
+			},
+			cell: cell{
+				result:     statuspb.TestStatus_FAIL,
+				properties: map[string]string{"log": "here"},
+			},
+			count: 1,
+			expected: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+					int32(statuspb.TestStatus_FAIL), 1,
+				},
+				CellIds:  []string{"", "", ""},
+				Messages: []string{"", "", ""},
+				Icons:    []string{"", "", ""},
+				Properties: []*statepb.Property{
+					{},
+					{},
+					{Property: map[string]string{"log": "here"}},
+				},
+			},
+		},
+		{
+			name: "append without properties to row with properties",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+				},
+				CellIds:  []string{""},
+				Messages: []string{""},
+				Icons:    []string{""},
+				Properties: []*statepb.Property{
+					{Property: map[string]string{"log": "here"}},
+				},
+			},
+			cell: cell{
+				result: statuspb.TestStatus_PASS,
+			},
+			count: 1,
+			expected: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				CellIds:  []string{"", ""},
+				Messages: []string{"", ""},
+				Icons:    []string{"", ""},
+				Properties: []*statepb.Property{
+					{Property: map[string]string{"log": "here"}},
+					{},
+				},
+			},
+		},
 		{
 			name: "append different result",
 			row: statepb.Row{
@@ -2484,254 +3158,6 @@ func TestAppendColumn(t *testing.T) {
 	}
 }
 
-func TestAlertRow(t *testing.T) {
-	var columns []*statepb.Column
-	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
-		columns = append(columns, &statepb.Column{
-			Build:   id,
-			Started: 100 - float64(i),
-		})
-	}
-	cases := []struct {
-		name      string
-		row       statepb.Row
-		failOpen  int
-		passClose int
-		expected  *statepb.AlertInfo
-	}{
-		{
-			name: "never alert by default",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 6,
-				},
-			},
-		},
-		{
-			name: "passes do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 6,
-				},
-			},
-			failOpen:  1,
-			passClose: 3,
-		},
-		{
-			name: "flakes do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 6,
-				},
-			},
-			failOpen: 1,
-		},
-		{
-			name: "intermittent failures do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 2,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 2,
-				},
-			},
-			failOpen: 3,
-		},
-		{
-			name: "new failures alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 3,
-					int32(statuspb.TestStatus_PASS), 3,
-				},
-				Messages: []string{"hello", "no", "no again", "very wrong"},
-				CellIds:  []string{"yes", "no", "no again", "very wrong"},
-			},
-			failOpen: 3,
-			expected: alertInfo(3, "hello", "yes", columns[2], columns[3]),
-		},
-		{
-			name: "too few passes do not close",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 2,
-					int32(statuspb.TestStatus_FAIL), 4,
-				},
-				Messages: []string{"nope", "no", "yay", "very wrong"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong"},
-			},
-			failOpen:  1,
-			passClose: 3,
-			expected:  alertInfo(4, "yay", "yep", columns[5], nil),
-		},
-		{
-			name: "flakes do not close",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 2,
-					int32(statuspb.TestStatus_FAIL), 4,
-				},
-				Messages: []string{"nope", "no", "yay", "very wrong"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong"},
-			},
-			failOpen: 1,
-			expected: alertInfo(4, "yay", "yep", columns[5], nil),
-		},
-		{
-			name: "count failures after flaky passes",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_FLAKY), 1,
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 2,
-				},
-				Messages: []string{"nope", "no", "buu", "wrong", "this one"},
-				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job"},
-			},
-			failOpen:  2,
-			passClose: 2,
-			expected:  alertInfo(4, "this one", "good job", columns[5], nil),
-		},
-		{
-			name: "close alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 5,
-				},
-			},
-			failOpen: 1,
-		},
-		{
-			name: "track through empty results",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_FAIL), 4,
-				},
-				Messages: []string{"yay", "no", "buu", "wrong", "nono"},
-				CellIds:  []string{"yay-cell", "no", "buzz", "wrong2", "nada"},
-			},
-			failOpen:  5,
-			passClose: 2,
-			expected:  alertInfo(5, "yay", "yay-cell", columns[5], nil),
-		},
-		{
-			name: "track passes through empty results",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 3,
-				},
-			},
-			failOpen:  1,
-			passClose: 2,
-		},
-		{
-			name: "running cells advance compressed index",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_RUNNING), 1,
-					int32(statuspb.TestStatus_FAIL), 5,
-				},
-				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
-				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
-			},
-			failOpen: 1,
-			expected: alertInfo(5, "fail1-expected", "yep", columns[5], nil),
-		},
-	}
-
-	for _, tc := range cases {
-		if actual := alertRow(columns, &tc.row, tc.failOpen, tc.passClose); !reflect.DeepEqual(actual, tc.expected) {
-			t.Errorf("%s alert %s != expected %s", tc.name, actual, tc.expected)
-		}
-	}
-}
-
-func TestBuildID(t *testing.T) {
-	cases := []struct {
-		name     string
-		build    string
-		extra    string
-		expected string
-	}{
-		{
-			name: "return empty by default",
-		},
-		{
-			name:     "favor extra if it exists",
-			build:    "wrong",
-			extra:    "right",
-			expected: "right",
-		},
-		{
-			name:     "build if no extra",
-			build:    "yes",
-			expected: "yes",
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			col := statepb.Column{
-				Build: tc.build,
-			}
-			if tc.extra != "" {
-				col.Extra = append(col.Extra, tc.extra)
-			}
-			if actual := buildID(&col); actual != tc.expected {
-				t.Errorf("%q != expected %q", actual, tc.expected)
-			}
-		})
-	}
-}
-
-func TestStamp(t *testing.T) {
-	cases := []struct {
-		name     string
-		col      *statepb.Column
-		expected *timestamp.Timestamp
-	}{
-		{
-			name: "0 returns nil",
-		},
-		{
-			name: "no nanos",
-			col: &statepb.Column{
-				Started: 2,
-			},
-			expected: &timestamp.Timestamp{
-				Seconds: 2,
-				Nanos:   0,
-			},
-		},
-		{
-			name: "has nanos",
-			col: &statepb.Column{
-				Started: 1.1,
-			},
-			expected: &timestamp.Timestamp{
-				Seconds: 1,
-				Nanos:   1e8,
-			},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			if actual := stamp(tc.col); !reflect.DeepEqual(actual, tc.expected) {
-				t.Errorf("stamp %s != expected stamp %s", actual, tc.expected)
-			}
-		})
-	}
-}
-
 func TestBumpMaxUpdateArea(t *testing.T) {
 	updateAreaLock.RLock()
 	orig := maxUpdateArea
@@ -2880,3 +3306,96 @@ func TestDropEmptyRows(t *testing.T) {
 		})
 	}
 }
+
+func TestDropIgnoredRows(t *testing.T) {
+	const (
+		pass  = int32(statuspb.TestStatus_PASS)
+		skip  = int32(statuspb.TestStatus_PASS_WITH_SKIPS)
+		fail  = int32(statuspb.TestStatus_FAIL)
+		blank = int32(statuspb.TestStatus_NO_RESULT)
+	)
+	results := map[string][]int32{
+		"Overall": {pass, 3},
+		"pass":    {pass, 1, blank, 1, pass, 1},
+		"skip":    {skip, 2, blank, 1},
+		"mixed":   {pass, 1, skip, 1, fail, 1},
+		"fail":    {fail, 3},
+	}
+	cases := []struct {
+		name       string
+		ignorePass bool
+		ignoreSkip bool
+		expected   []string
+		passes     []int32
+		skips      []int32
+	}{
+		{
+			name:     "basically works",
+			expected: []string{"Overall", "fail", "mixed", "pass", "skip"},
+			passes:   []int32{0, 0, 0},
+			skips:    []int32{0, 0, 0},
+		},
+		{
+			name:       "ignore pass",
+			ignorePass: true,
+			expected:   []string{"Overall", "fail", "mixed", "skip"},
+			passes:     []int32{1, 0, 1},
+			skips:      []int32{0, 0, 0},
+		},
+		{
+			name:       "ignore skip",
+			ignoreSkip: true,
+			expected:   []string{"Overall", "fail", "mixed", "pass"},
+			passes:     []int32{0, 0, 0},
+			skips:      []int32{1, 1, 0},
+		},
+		{
+			name:       "ignore both",
+			ignorePass: true,
+			ignoreSkip: true,
+			expected:   []string{"Overall", "fail", "mixed"},
+			passes:     []int32{1, 0, 1},
+			skips:      []int32{1, 1, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := statepb.Grid{
+				Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+			}
+			rows := make(map[string]*statepb.Row, len(results))
+			for name, res := range results {
+				r := &statepb.Row{Name: name, Results: res}
+				grid.Rows = append(grid.Rows, r)
+				rows[name] = r
+			}
+			dropIgnoredRows(logrus.WithField("name", tc.name), &grid, rows, tc.ignorePass, tc.ignoreSkip)
+			var actual []string
+			for _, r := range grid.Rows {
+				actual = append(actual, r.Name)
+				if rows[r.Name] != r {
+					t.Errorf("dropIgnoredRows() did not keep %q in the row map", r.Name)
+				}
+			}
+			sort.Strings(actual)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("dropIgnoredRows() got unexpected rows (-want +got):\n%s", diff)
+			}
+			if len(rows) != len(grid.Rows) {
+				t.Errorf("dropIgnoredRows() kept %d rows in the map, want %d", len(rows), len(grid.Rows))
+			}
+			var passes, skips []int32
+			for _, col := range grid.Columns {
+				passes = append(passes, col.IgnoredPasses)
+				skips = append(skips, col.IgnoredSkips)
+			}
+			if diff := cmp.Diff(tc.passes, passes); diff != "" {
+				t.Errorf("dropIgnoredRows() got unexpected ignored passes (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.skips, skips); diff != "" {
+				t.Errorf("dropIgnoredRows() got unexpected ignored skips (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
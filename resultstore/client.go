@@ -206,19 +206,25 @@ func (t Targets) Create(id string, target Target) (string, error) {
 	return tgt.Name, nil
 }
 
-// List requested fields in targets, does not currently handle paging.
+// List requested fields in targets, reading every page.
 func (t Targets) List(fields ...string) ([]Target, error) {
-	resp, err := t.down.ListTargets(listMask(t.ctx, fields...), &resultstore.ListTargetsRequest{
+	req := resultstore.ListTargetsRequest{
 		Parent: t.inv,
-	})
-	if err != nil {
-		return nil, err
 	}
 	var targets []Target
-	for _, r := range resp.Targets {
-		targets = append(targets, fromTarget(r))
+	for {
+		resp, err := t.down.ListTargets(listMask(t.ctx, fields...), &req)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Targets {
+			targets = append(targets, fromTarget(r))
+		}
+		if resp.NextPageToken == "" {
+			return targets, nil
+		}
+		req.PageStart = &resultstore.ListTargetsRequest_PageToken{PageToken: resp.NextPageToken}
 	}
-	return targets, nil
 }
 
 // Configuration methods
@@ -360,13 +366,23 @@ func convertToInvocations(results *resultstore.SearchInvocationsResponse) []*Inv
 }
 
 // Search finds all the invocations that satisfies the query condition within a project.
+//
+// Reads every page when the fields include next_page_token.
 func (i Invocations) Search(ctx context.Context, projectID string, query string, fields ...string) ([]*Invocation, error) {
-	results, err := i.down.SearchInvocations(fieldMask(ctx, fields...), &resultstore.SearchInvocationsRequest{
+	req := resultstore.SearchInvocationsRequest{
 		ProjectId: projectID,
 		Query:     query,
-	})
-	if err != nil {
-		return nil, err
 	}
-	return convertToInvocations(results), nil
+	invocations := []*Invocation{}
+	for {
+		results, err := i.down.SearchInvocations(fieldMask(ctx, fields...), &req)
+		if err != nil {
+			return nil, err
+		}
+		invocations = append(invocations, convertToInvocations(results)...)
+		if results.NextPageToken == "" {
+			return invocations, nil
+		}
+		req.PageStart = &resultstore.SearchInvocationsRequest_PageToken{PageToken: results.NextPageToken}
+	}
 }
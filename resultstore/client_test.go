@@ -17,10 +17,12 @@ limitations under the License.
 package resultstore
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	resultstore "google.golang.org/genproto/googleapis/devtools/resultstore/v2"
+	"google.golang.org/grpc"
 )
 
 func TestConvertToInvocations(t *testing.T) {
@@ -77,3 +79,64 @@ func TestConvertToInvocations(t *testing.T) {
 		})
 	}
 }
+
+type fakeDownloader struct {
+	resultstore.ResultStoreDownloadClient
+	pages map[string]*resultstore.ListTargetsResponse
+}
+
+func (f fakeDownloader) ListTargets(ctx context.Context, req *resultstore.ListTargetsRequest, opts ...grpc.CallOption) (*resultstore.ListTargetsResponse, error) {
+	return f.pages[req.GetPageToken()], nil
+}
+
+func TestTargetsList(t *testing.T) {
+	cases := []struct {
+		name     string
+		pages    map[string]*resultstore.ListTargetsResponse
+		expected []Target
+	}{
+		{
+			name: "single page",
+			pages: map[string]*resultstore.ListTargetsResponse{
+				"": {
+					Targets: []*resultstore.Target{{Name: "invocations/inv/targets/a"}},
+				},
+			},
+			expected: []Target{{Name: "invocations/inv/targets/a"}},
+		},
+		{
+			name: "reads every page",
+			pages: map[string]*resultstore.ListTargetsResponse{
+				"": {
+					Targets:       []*resultstore.Target{{Name: "invocations/inv/targets/a"}},
+					NextPageToken: "second",
+				},
+				"second": {
+					Targets:       []*resultstore.Target{{Name: "invocations/inv/targets/b"}},
+					NextPageToken: "third",
+				},
+				"third": {
+					Targets: []*resultstore.Target{{Name: "invocations/inv/targets/c"}},
+				},
+			},
+			expected: []Target{
+				{Name: "invocations/inv/targets/a"},
+				{Name: "invocations/inv/targets/b"},
+				{Name: "invocations/inv/targets/c"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Client{down: fakeDownloader{pages: tc.pages}, ctx: context.Background()}
+			got, err := c.Targets("invocations/inv").List("targets.name")
+			if err != nil {
+				t.Fatalf("List() got unexpected error: %v", err)
+			}
+			if !deepEqual(got, tc.expected) {
+				t.Errorf(diff(got, tc.expected))
+			}
+		})
+	}
+}
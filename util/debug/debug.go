@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debug serves the pprof profiles and expvar runtime variables of a binary.
+package debug
+
+import (
+	"errors"
+	"expvar"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Paths of the debug endpoints.
+const (
+	PprofPath = "/debug/pprof/"
+	VarsPath  = "/debug/vars"
+)
+
+// Handler serves pprof profiles under PprofPath and expvar variables, such as memstats, at VarsPath.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.Handle(VarsPath, expvar.Handler())
+	return mux
+}
+
+// Serve listens on the address, such as :6060, serving the debug endpoints in the background.
+//
+// Does nothing when addr is empty. Callers should not expose the address
+// publicly, as profiles reveal the internals of the binary.
+func Serve(addr string) error {
+	if addr == "" {
+		return nil
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(lis, Handler()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.WithError(err).Error("Stopped serving debug endpoints")
+		}
+	}()
+	logrus.WithField("address", lis.Addr().String()).Info("Serving debug endpoints")
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		code     int
+		contains string
+	}{
+		{
+			name:     "profiles",
+			path:     PprofPath,
+			code:     http.StatusOK,
+			contains: "heap",
+		},
+		{
+			name:     "heap",
+			path:     PprofPath + "heap?debug=1",
+			code:     http.StatusOK,
+			contains: "heap profile",
+		},
+		{
+			name:     "vars",
+			path:     VarsPath,
+			code:     http.StatusOK,
+			contains: `"memstats"`,
+		},
+		{
+			name: "not found",
+			path: "/metrics",
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Errorf("ServeHTTP(%q) got code %d, want %d", tc.path, rec.Code, tc.code)
+			}
+			if body := rec.Body.String(); !strings.Contains(body, tc.contains) {
+				t.Errorf("ServeHTTP(%q) got body %q, want it to contain %q", tc.path, body, tc.contains)
+			}
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	cases := []struct {
+		name string
+		addr string
+		err  bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "serve",
+			addr: "127.0.0.1:0",
+		},
+		{
+			name: "bad address",
+			addr: "not an address",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Serve(tc.addr)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Serve() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Serve() failed to return an error")
+			}
+		})
+	}
+}
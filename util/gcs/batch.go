@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/storage"
+)
+
+// StatResult holds the attributes of an object, or the error statting it.
+type StatResult struct {
+	Attrs *storage.ObjectAttrs
+	Err   error
+}
+
+// StatAll stats every path, running at most concurrency stats at once.
+//
+// Returns the result of each path in the same order, such as a storage.ErrObjectNotExist error for missing objects.
+// Paths left unstatted when the context expires return its error.
+func StatAll(ctx context.Context, client Stater, concurrency int, paths ...Path) []StatResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	out := make([]StatResult, len(paths))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				if err := ctx.Err(); err != nil {
+					out[idx].Err = err
+					continue
+				}
+				out[idx].Attrs, out[idx].Err = client.Stat(ctx, paths[idx])
+			}
+		}()
+	}
+	for i := range paths {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeStater struct {
+	lock    sync.Mutex
+	objects map[string]int64
+	active  int
+	max     int
+}
+
+func (fs *fakeStater) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	fs.lock.Lock()
+	fs.active++
+	if fs.active > fs.max {
+		fs.max = fs.active
+	}
+	fs.lock.Unlock()
+	defer func() {
+		fs.lock.Lock()
+		fs.active--
+		fs.lock.Unlock()
+	}()
+	size, ok := fs.objects[path.String()]
+	if !ok {
+		return nil, storage.ErrObjectNotExist
+	}
+	return &storage.ObjectAttrs{Name: path.Object(), Size: size}, nil
+}
+
+func TestStatAll(t *testing.T) {
+	mustPath := func(s string) Path {
+		p, err := NewPath(s)
+		if err != nil {
+			t.Fatalf("NewPath(%q): %v", s, err)
+		}
+		return *p
+	}
+	cases := []struct {
+		name        string
+		concurrency int
+		canceled    bool
+	}{
+		{
+			name:        "basic",
+			concurrency: 3,
+		},
+		{
+			name: "zero concurrency",
+		},
+		{
+			name:        "canceled",
+			concurrency: 3,
+			canceled:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeStater{objects: map[string]int64{}}
+			var paths []Path
+			for i := 0; i < 20; i++ {
+				p := mustPath(fmt.Sprintf("gs://bucket/build/%d/finished.json", i))
+				paths = append(paths, p)
+				if i%2 == 0 {
+					client.objects[p.String()] = int64(i)
+				}
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.canceled {
+				cancel()
+			}
+			got := StatAll(ctx, &client, tc.concurrency, paths...)
+			if len(got) != len(paths) {
+				t.Fatalf("StatAll() got %d results, want %d", len(got), len(paths))
+			}
+			max := tc.concurrency
+			if max < 1 {
+				max = 1
+			}
+			if client.max > max {
+				t.Errorf("StatAll() ran %d stats at once, want at most %d", client.max, max)
+			}
+			for i, res := range got {
+				switch {
+				case tc.canceled:
+					if !errors.Is(res.Err, context.Canceled) {
+						t.Errorf("StatAll()[%d] got error %v, want %v", i, res.Err, context.Canceled)
+					}
+				case i%2 == 0:
+					if res.Err != nil {
+						t.Errorf("StatAll()[%d] got unexpected error: %v", i, res.Err)
+					} else if diff := cmp.Diff(&storage.ObjectAttrs{Name: paths[i].Object(), Size: int64(i)}, res.Attrs); diff != "" {
+						t.Errorf("StatAll()[%d] got unexpected diff (-want +got):\n%s", i, diff)
+					}
+				case !errors.Is(res.Err, storage.ErrObjectNotExist):
+					t.Errorf("StatAll()[%d] got error %v, want %v", i, res.Err, storage.ErrObjectNotExist)
+				}
+			}
+		})
+	}
+}
@@ -18,10 +18,16 @@ package gcs
 
 import (
 	"context"
+	"errors"
 	"io"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"go.opentelemetry.io/otel/label"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
+	"github.com/GoogleCloudPlatform/testgrid/util/tracing"
 )
 
 // Uploader adds upload capabilities to a GCS client.
@@ -78,13 +84,21 @@ type ConditionalClient interface {
 
 // NewClient returns a GCSUploadClient for the storage.Client.
 func NewClient(client *storage.Client) ConditionalClient {
-	return realGCSClient{client, nil, nil}
+	return realGCSClient{client: client}
+}
+
+// NewClientWithKeys returns a client that encrypts objects it writes with the bucket's KMS key.
+//
+// Objects written to buckets without a key use the bucket's default encryption.
+func NewClientWithKeys(client *storage.Client, keys KMSKeys) ConditionalClient {
+	return realGCSClient{client: client, keys: keys}
 }
 
 type realGCSClient struct {
 	client    *storage.Client
 	readCond  *storage.Conditions
 	writeCond *storage.Conditions
+	keys      KMSKeys
 }
 
 func (rgc realGCSClient) If(read, write *storage.Conditions) ConditionalClient {
@@ -92,6 +106,7 @@ func (rgc realGCSClient) If(read, write *storage.Conditions) ConditionalClient {
 		client:    rgc.client,
 		readCond:  read,
 		writeCond: write,
+		keys:      rgc.keys,
 	}
 }
 
@@ -103,14 +118,40 @@ func (rgc realGCSClient) handle(path Path, cond *storage.Conditions) *storage.Ob
 	return oh.If(*cond)
 }
 
+var (
+	opDurations = metrics.NewDuration("testgrid_gcs_operation_duration_seconds", "Time to complete each GCS operation", "operation")
+	opErrors    = metrics.NewCounter("testgrid_gcs_operation_errors_total", "Number of GCS operations which failed, other than for missing objects", "operation")
+)
+
+// observe starts a span for the operation on the path, returning a function to record its latency and whether it failed.
+func observe(ctx context.Context, op string, path Path) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "gcs."+op, label.String("path", path.String()))
+	return ctx, func(err error) {
+		opDurations.Since(start, op)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			opErrors.Add(1, op)
+			tracing.End(span, err)
+			return
+		}
+		span.End()
+	}
+}
+
 func (rgc realGCSClient) Copy(ctx context.Context, from, to Path) error {
+	ctx, done := observe(ctx, "copy", to)
 	fromH := rgc.handle(from, rgc.readCond)
-	_, err := rgc.handle(to, rgc.writeCond).CopierFrom(fromH).Run(ctx)
+	copier := rgc.handle(to, rgc.writeCond).CopierFrom(fromH)
+	copier.DestinationKMSKeyName = rgc.keys[to.Bucket()]
+	_, err := copier.Run(ctx)
+	done(err)
 	return err
 }
 
 func (rgc realGCSClient) Open(ctx context.Context, path Path) (io.ReadCloser, error) {
+	ctx, done := observe(ctx, "open", path)
 	r, err := rgc.handle(path, rgc.readCond).NewReader(ctx)
+	done(err)
 	return r, err
 }
 
@@ -127,9 +168,15 @@ func (rgc realGCSClient) Objects(ctx context.Context, path Path, delimiter, star
 }
 
 func (rgc realGCSClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string) error {
-	return UploadHandle(ctx, rgc.handle(path, rgc.writeCond), buf, worldReadable, cacheControl)
+	ctx, done := observe(ctx, "upload", path)
+	err := uploadHandle(ctx, rgc.handle(path, rgc.writeCond), buf, worldReadable, cacheControl, rgc.keys[path.Bucket()])
+	done(err)
+	return err
 }
 
 func (rgc realGCSClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
-	return rgc.handle(path, rgc.readCond).Attrs(ctx)
+	ctx, done := observe(ctx, "stat", path)
+	attrs, err := rgc.handle(path, rgc.readCond).Attrs(ctx)
+	done(err)
+	return attrs, err
 }
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// NewDryRunClient reads from the client, logging and discarding every write.
+//
+// Uploads and copies always succeed without changing anything.
+func NewDryRunClient(client ConditionalClient) ConditionalClient {
+	return dryRunClient{client}
+}
+
+type dryRunClient struct {
+	ConditionalClient
+}
+
+func (dc dryRunClient) If(read, write *storage.Conditions) ConditionalClient {
+	return dryRunClient{dc.ConditionalClient.If(read, write)}
+}
+
+func (dc dryRunClient) Upload(_ context.Context, path Path, buf []byte, _ bool, _ string) error {
+	logrus.WithField("path", path).WithField("bytes", len(buf)).Debug("Dry run: skipping upload")
+	return nil
+}
+
+func (dc dryRunClient) Copy(_ context.Context, from, to Path) error {
+	logrus.WithField("from", from).WithField("to", to).Debug("Dry run: skipping copy")
+	return nil
+}
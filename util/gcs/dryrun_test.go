@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeMirrorPrimary{
+		fakeOpener: fakeOpener{
+			mustPath(t, "gs://bucket/config"): fakeObject{data: "cfg"},
+		},
+	}
+	client := NewDryRunClient(primary).If(nil, nil)
+
+	if err := client.Upload(ctx, mustPath(t, "gs://bucket/grid/foo"), []byte("hello"), DefaultAcl, ""); err != nil {
+		t.Errorf("Upload() got unexpected error: %v", err)
+	}
+	if err := client.Copy(ctx, mustPath(t, "gs://bucket/config"), mustPath(t, "gs://bucket/config-copy")); err != nil {
+		t.Errorf("Copy() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string(nil), primary.files); diff != "" {
+		t.Errorf("Upload() wrote unexpected files (-want +got):\n%s", diff)
+	}
+	if _, ok := primary.fakeOpener[mustPath(t, "gs://bucket/config-copy")]; ok {
+		t.Error("Copy() unexpectedly copied the object")
+	}
+
+	r, err := client.Open(ctx, mustPath(t, "gs://bucket/config"))
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("cfg", string(buf)); diff != "" {
+		t.Errorf("Open() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"hash/crc32"
 	"log"
 	"net/url"
+	"sort"
 	"strings"
 
 	"cloud.google.com/go/storage"
@@ -42,11 +43,57 @@ func ClientWithCreds(ctx context.Context, creds ...string) (*storage.Client, err
 	return storage.NewClient(ctx, options...)
 }
 
-// Path parses gs://bucket/obj urls
+// KMSKeys maps a bucket name to the Cloud KMS key encrypting objects written to it.
+//
+// Implements flag.Value, accepting repeated bucket=projects/p/locations/l/keyRings/r/cryptoKeys/k values.
+type KMSKeys map[string]string
+
+// String returns the comma-separated bucket=key pairs.
+func (k KMSKeys) String() string {
+	parts := make([]string, 0, len(k))
+	for bucket, key := range k {
+		parts = append(parts, bucket+"="+key)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// Set adds a bucket=key pair.
+func (k *KMSKeys) Set(v string) error {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("want bucket=key, got %q", v)
+	}
+	bucket, key := parts[0], parts[1]
+	if !strings.HasPrefix(key, "projects/") || !strings.Contains(key, "/cryptoKeys/") {
+		return fmt.Errorf("%s: want projects/P/locations/L/keyRings/R/cryptoKeys/K, got %q", bucket, key)
+	}
+	if *k == nil {
+		*k = KMSKeys{}
+	}
+	if old, ok := (*k)[bucket]; ok && old != key {
+		return fmt.Errorf("%s: conflicting keys %q and %q", bucket, old, key)
+	}
+	(*k)[bucket] = key
+	return nil
+}
+
+// Path parses scheme://bucket/obj urls, such as gs://bucket/obj.
+//
+// Also accepts s3://bucket/obj, https://host[:port]/obj and file:///path/to/obj urls,
+// where file urls have no bucket.
 type Path struct {
 	url url.URL
 }
 
+// schemes lists the url schemes a Path may use.
+var schemes = map[string]bool{
+	"gs":    true,
+	"s3":    true,
+	"file":  true,
+	"https": true,
+}
+
 func NewPath(path string) (*Path, error) {
 	var p Path
 	err := p.Set(path)
@@ -75,9 +122,11 @@ func (g *Path) SetURL(u *url.URL) error {
 	switch {
 	case u == nil:
 		return errors.New("nil url")
-	case u.Scheme != "gs":
-		return fmt.Errorf("must use a gs:// url: %s", u)
-	case strings.Contains(u.Host, ":"):
+	case !schemes[u.Scheme]:
+		return fmt.Errorf("must use a gs://, s3://, file:// or https:// url: %s", u)
+	case u.Scheme == "file" && u.Host != "":
+		return fmt.Errorf("file:// url may not contain a host: %s", u)
+	case u.Scheme != "https" && strings.Contains(u.Host, ":"):
 		return fmt.Errorf("gs://bucket may not contain a port: %s", u)
 	case u.Opaque != "":
 		return fmt.Errorf("url must start with gs://: %s", u)
@@ -92,6 +141,11 @@ func (g *Path) SetURL(u *url.URL) error {
 	return nil
 }
 
+// Scheme returns gs in gs://bucket/obj
+func (g Path) Scheme() string {
+	return g.url.Scheme
+}
+
 // ResolveReference returns the path relative to the current path
 func (g Path) ResolveReference(ref *url.URL) (*Path, error) {
 	var newP Path
@@ -132,6 +186,11 @@ func Upload(ctx context.Context, client *storage.Client, path Path, buf []byte,
 
 // UploadHandle writes bytes to the specified ObjectHandle
 func UploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte, worldReadable bool, cacheControl string) error {
+	return uploadHandle(ctx, handle, buf, worldReadable, cacheControl, "")
+}
+
+// uploadHandle writes bytes to the handle, encrypting with kmsKey if set.
+func uploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte, worldReadable bool, cacheControl, kmsKey string) error {
 	crc := calcCRC(buf)
 	w := handle.NewWriter(ctx)
 	defer w.Close()
@@ -141,6 +200,9 @@ func UploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte,
 	if cacheControl != "" {
 		w.ObjectAttrs.CacheControl = cacheControl
 	}
+	if kmsKey != "" {
+		w.ObjectAttrs.KMSKeyName = kmsKey
+	}
 	w.SendCRC32C = true
 	// Send our CRC32 to ensure google received the same data we sent.
 	// See checksum example at:
@@ -19,6 +19,8 @@ package gcs
 import (
 	"net/url"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func Test_SetURL(t *testing.T) {
@@ -50,6 +52,28 @@ func Test_SetURL(t *testing.T) {
 			url:  "http://example.com/object",
 			err:  true,
 		},
+		{
+			name:   "accept https",
+			url:    "https://example.com/path/to/object",
+			bucket: "example.com",
+			object: "path/to/object",
+		},
+		{
+			name:   "accept s3",
+			url:    "s3://bucket/key",
+			bucket: "bucket",
+			object: "key",
+		},
+		{
+			name:   "accept local files",
+			url:    "file:///path/to/file",
+			object: "path/to/file",
+		},
+		{
+			name: "reject file hosts",
+			url:  "file://host/path/to/file",
+			err:  true,
+		},
 		{
 			name: "reject ports",
 			url:  "gs://first:123/second",
@@ -129,3 +153,77 @@ func Test_calcCRC(t *testing.T) {
 	}
 
 }
+
+func TestKMSKeys(t *testing.T) {
+	const key = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+	cases := []struct {
+		name   string
+		values []string
+		want   KMSKeys
+		str    string
+		err    bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:   "multiple buckets",
+			values: []string{"foo=" + key, "bar=" + key + "2"},
+			want: KMSKeys{
+				"foo": key,
+				"bar": key + "2",
+			},
+			str: "bar=" + key + "2,foo=" + key,
+		},
+		{
+			name:   "repeated bucket with the same key",
+			values: []string{"foo=" + key, "foo=" + key},
+			want: KMSKeys{
+				"foo": key,
+			},
+			str: "foo=" + key,
+		},
+		{
+			name:   "reject conflicting keys",
+			values: []string{"foo=" + key, "foo=" + key + "2"},
+			err:    true,
+		},
+		{
+			name:   "reject missing key",
+			values: []string{"foo"},
+			err:    true,
+		},
+		{
+			name:   "reject non-kms key",
+			values: []string{"foo=my-key"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got KMSKeys
+			var err error
+			for _, v := range tc.values {
+				if err = got.Set(v); err != nil {
+					break
+				}
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Set() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Set() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("Set() got unexpected diff (-want +got):\n%s", diff)
+				}
+				if s := got.String(); s != tc.str {
+					t.Errorf("String() got %q, want %q", s, tc.str)
+				}
+			}
+		})
+	}
+}
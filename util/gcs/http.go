@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// DefaultS3Endpoint formats the https url of an S3 bucket from its name.
+const DefaultS3Endpoint = "https://%s.s3.amazonaws.com"
+
+var errReadOnly = errors.New("read-only client")
+
+// NewHTTPClient returns a read-only client that fetches https://host/obj paths.
+//
+// Missing objects return storage.ErrObjectNotExist and conditions are ignored.
+// Lists, uploads and copies fail.
+func NewHTTPClient(client *http.Client) ConditionalClient {
+	return httpClient{client: client}
+}
+
+// NewS3Client returns a read-only client that anonymously fetches s3://bucket/obj paths.
+//
+// The endpoint formats the url of each bucket, such as DefaultS3Endpoint.
+// Lists use the ListObjectsV2 API, requiring the bucket to allow public listing.
+// Missing objects return storage.ErrObjectNotExist and conditions are ignored.
+// Uploads and copies fail.
+func NewS3Client(client *http.Client, endpoint string) ConditionalClient {
+	return httpClient{client: client, s3: endpoint}
+}
+
+type httpClient struct {
+	client *http.Client
+	// s3 formats the url of the bucket when set.
+	s3 string
+}
+
+func (hc httpClient) If(_, _ *storage.Conditions) ConditionalClient {
+	return hc
+}
+
+// url returns the https url of the object.
+func (hc httpClient) url(path Path) string {
+	if hc.s3 == "" {
+		return path.String()
+	}
+	obj := url.URL{Path: "/" + path.Object()}
+	return fmt.Sprintf(hc.s3, path.Bucket()) + obj.EscapedPath()
+}
+
+// get sends the request, returning the response when it succeeds.
+func (hc httpClient) get(ctx context.Context, method, u string, path Path) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hc.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", storage.ErrObjectNotExist, path)
+	}
+	resp.Body.Close()
+	return nil, fmt.Errorf("%s %s: %s", method, u, resp.Status)
+}
+
+func (hc httpClient) Copy(ctx context.Context, from, to Path) error {
+	return fmt.Errorf("copy to %s: %w", to, errReadOnly)
+}
+
+func (hc httpClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	return fmt.Errorf("upload %s: %w", path, errReadOnly)
+}
+
+func (hc httpClient) Open(ctx context.Context, path Path) (io.ReadCloser, error) {
+	resp, err := hc.get(ctx, http.MethodGet, hc.url(path), path)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (hc httpClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	resp, err := hc.get(ctx, http.MethodHead, hc.url(path), path)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	attrs := storage.ObjectAttrs{
+		Bucket:      path.Bucket(),
+		Name:        path.Object(),
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		Etag:        resp.Header.Get("ETag"),
+	}
+	if when, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		attrs.Updated = when
+	}
+	return &attrs, nil
+}
+
+func (hc httpClient) Objects(ctx context.Context, path Path, delimiter, startOffset string) Iterator {
+	if hc.s3 == "" {
+		return &localIterator{err: fmt.Errorf("list %s: unsupported", path)}
+	}
+	prefix := path.Object()
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &s3Iterator{
+		ctx:       ctx,
+		client:    hc,
+		path:      path,
+		prefix:    prefix,
+		delimiter: delimiter,
+		start:     startOffset,
+	}
+}
+
+// s3ListResult is the response of the ListObjectsV2 API.
+type s3ListResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+		ETag         string
+	}
+	CommonPrefixes []struct {
+		Prefix string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// s3Iterator lists a page of objects at a time.
+type s3Iterator struct {
+	ctx       context.Context
+	client    httpClient
+	path      Path
+	prefix    string
+	delimiter string
+	start     string
+	token     string
+	done      bool
+	page      localIterator
+}
+
+func (si *s3Iterator) Next() (*storage.ObjectAttrs, error) {
+	for len(si.page.attrs) == 0 && si.page.err == nil && !si.done {
+		si.page = si.list()
+	}
+	if len(si.page.attrs) == 0 && si.page.err == nil {
+		return nil, iterator.Done
+	}
+	return si.page.Next()
+}
+
+// list fetches the next page of objects.
+func (si *s3Iterator) list() localIterator {
+	q := url.Values{}
+	q.Set("list-type", "2")
+	if si.prefix != "" {
+		q.Set("prefix", si.prefix)
+	}
+	if si.delimiter != "" {
+		q.Set("delimiter", si.delimiter)
+	}
+	if si.start != "" {
+		q.Set("start-after", si.start)
+	}
+	if si.token != "" {
+		q.Set("continuation-token", si.token)
+	}
+	u := fmt.Sprintf(si.client.s3, si.path.Bucket()) + "/?" + q.Encode()
+	resp, err := si.client.get(si.ctx, http.MethodGet, u, si.path)
+	if err != nil {
+		return localIterator{err: fmt.Errorf("list: %w", err)}
+	}
+	defer resp.Body.Close()
+	var result s3ListResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return localIterator{err: fmt.Errorf("parse list: %w", err)}
+	}
+	si.token = result.NextContinuationToken
+	si.done = !result.IsTruncated || si.token == ""
+	var attrs []*storage.ObjectAttrs
+	for _, p := range result.CommonPrefixes {
+		attrs = append(attrs, &storage.ObjectAttrs{Prefix: p.Prefix})
+	}
+	for _, c := range result.Contents {
+		attrs = append(attrs, &storage.ObjectAttrs{
+			Bucket:  si.path.Bucket(),
+			Name:    c.Key,
+			Size:    c.Size,
+			Updated: c.LastModified,
+			Etag:    c.ETag,
+		})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name+attrs[i].Prefix < attrs[j].Name+attrs[j].Prefix
+	})
+	return localIterator{attrs: attrs}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+)
+
+func TestHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/bucket/" && r.URL.Query().Get("continuation-token") == "":
+			if got, want := r.URL.Query().Get("prefix"), "logs/job/"; got != want {
+				t.Errorf("prefix got %q, want %q", got, want)
+			}
+			fmt.Fprint(w, `<ListBucketResult>
+  <Contents><Key>logs/job/latest-build.txt</Key><Size>1</Size></Contents>
+  <CommonPrefixes><Prefix>logs/job/2/</Prefix></CommonPrefixes>
+  <CommonPrefixes><Prefix>logs/job/1/</Prefix></CommonPrefixes>
+  <IsTruncated>true</IsTruncated>
+  <NextContinuationToken>page2</NextContinuationToken>
+</ListBucketResult>`)
+		case r.URL.Path == "/bucket/":
+			fmt.Fprint(w, `<ListBucketResult>
+  <Contents><Key>logs/job/more.txt</Key><Size>2</Size></Contents>
+  <IsTruncated>false</IsTruncated>
+</ListBucketResult>`)
+		case r.URL.Path == "/bucket/logs/job/latest-build.txt":
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			fmt.Fprint(w, "2")
+		case r.URL.Path == "/broken":
+			http.Error(w, "oops", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	s3 := NewS3Client(server.Client(), server.URL+"/%s")
+
+	read := func(client Opener, path string) (string, error) {
+		r, err := client.Open(ctx, mustPath(t, path))
+		if err != nil {
+			return "", err
+		}
+		defer r.Close()
+		buf, err := ioutil.ReadAll(r)
+		return string(buf), err
+	}
+
+	if got, err := read(s3, "s3://bucket/logs/job/latest-build.txt"); err != nil || got != "2" {
+		t.Errorf("Open() got %q, %v, want \"2\"", got, err)
+	}
+	if _, err := read(s3, "s3://bucket/logs/job/missing.txt"); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Open(missing) got %v, want ErrObjectNotExist", err)
+	}
+	https := NewHTTPClient(server.Client())
+	if got, err := read(https, server.URL+"/bucket/logs/job/latest-build.txt"); err != nil || got != "2" {
+		t.Errorf("Open(https) got %q, %v, want \"2\"", got, err)
+	}
+	if _, err := read(https, server.URL+"/broken"); err == nil || errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Open(broken) got %v, want a server error", err)
+	}
+
+	attrs, err := s3.Stat(ctx, mustPath(t, "s3://bucket/logs/job/latest-build.txt"))
+	if err != nil {
+		t.Fatalf("Stat(): %v", err)
+	}
+	if attrs.Size != 1 || attrs.Updated.IsZero() {
+		t.Errorf("Stat() got size %d updated %v, want 1 and the last modification", attrs.Size, attrs.Updated)
+	}
+
+	var names []string
+	it := s3.Objects(ctx, mustPath(t, "s3://bucket/logs/job"), "/", "")
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next(): %v", err)
+		}
+		names = append(names, attrs.Name+attrs.Prefix)
+	}
+	want := []string{
+		"logs/job/1/",
+		"logs/job/2/",
+		"logs/job/latest-build.txt",
+		"logs/job/more.txt",
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("Objects() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := https.Objects(ctx, mustPath(t, server.URL+"/bucket"), "/", "").Next(); err == nil || err == iterator.Done {
+		t.Errorf("Objects(https) got %v, want an error", err)
+	}
+	if err := s3.Upload(ctx, mustPath(t, "s3://bucket/new"), []byte("hi"), false, ""); err == nil {
+		t.Error("Upload() failed to return an error")
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// NewLocalClient returns a client that stores objects as files under root.
+//
+// In other words gs://bucket/foo/bar is the file at root/bucket/foo/bar.
+// Missing files return storage.ErrObjectNotExist and conditions are ignored.
+// Lists support either no delimiter or a / delimiter.
+func NewLocalClient(root string) ConditionalClient {
+	return localClient{root: root}
+}
+
+type localClient struct {
+	root string
+}
+
+func (lc localClient) If(_, _ *storage.Conditions) ConditionalClient {
+	return lc
+}
+
+// file returns the local path of the object.
+func (lc localClient) file(path Path) string {
+	return filepath.Join(lc.root, path.Bucket(), filepath.FromSlash(path.Object()))
+}
+
+func notExist(err error) error {
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %v", storage.ErrObjectNotExist, err)
+	}
+	return err
+}
+
+func (lc localClient) Copy(ctx context.Context, from, to Path) error {
+	buf, err := ioutil.ReadFile(lc.file(from))
+	if err != nil {
+		return notExist(err)
+	}
+	return lc.Upload(ctx, to, buf, false, "")
+}
+
+func (lc localClient) Open(ctx context.Context, path Path) (io.ReadCloser, error) {
+	f, err := os.Open(lc.file(path))
+	if err != nil {
+		return nil, notExist(err)
+	}
+	return f, nil
+}
+
+func (lc localClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	name := lc.file(path)
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, buf, 0644)
+}
+
+func (lc localClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	info, err := os.Stat(lc.file(path))
+	if err != nil {
+		return nil, notExist(err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is a directory", storage.ErrObjectNotExist, path)
+	}
+	return &storage.ObjectAttrs{
+		Bucket:     path.Bucket(),
+		Name:       path.Object(),
+		Size:       info.Size(),
+		Updated:    info.ModTime(),
+		Generation: info.ModTime().UnixNano(),
+	}, nil
+}
+
+func (lc localClient) Objects(ctx context.Context, path Path, delimiter, startOffset string) Iterator {
+	prefix := path.Object()
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	if prefix == "/" {
+		prefix = ""
+	}
+	dir := filepath.Join(lc.root, path.Bucket(), filepath.FromSlash(prefix))
+	var attrs []*storage.ObjectAttrs
+	add := func(name string, info os.FileInfo) {
+		if info.IsDir() {
+			name += "/"
+		}
+		if name < startOffset {
+			return
+		}
+		if info.IsDir() {
+			attrs = append(attrs, &storage.ObjectAttrs{Prefix: name})
+			return
+		}
+		attrs = append(attrs, &storage.ObjectAttrs{
+			Bucket:  path.Bucket(),
+			Name:    name,
+			Size:    info.Size(),
+			Updated: info.ModTime(),
+		})
+	}
+	var err error
+	if delimiter != "" {
+		var infos []os.FileInfo
+		infos, err = ioutil.ReadDir(dir)
+		for _, info := range infos {
+			add(prefix+info.Name(), info)
+		}
+	} else {
+		err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			add(prefix+filepath.ToSlash(rel), info)
+			return nil
+		})
+	}
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name+attrs[i].Prefix < attrs[j].Name+attrs[j].Prefix
+	})
+	return &localIterator{attrs: attrs, err: err}
+}
+
+type localIterator struct {
+	attrs []*storage.ObjectAttrs
+	err   error
+}
+
+func (li *localIterator) Next() (*storage.ObjectAttrs, error) {
+	if li.err != nil {
+		return nil, li.err
+	}
+	if len(li.attrs) == 0 {
+		return nil, iterator.Done
+	}
+	a := li.attrs[0]
+	li.attrs = li.attrs[1:]
+	return a, nil
+}
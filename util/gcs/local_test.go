@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+)
+
+func TestLocalClient(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-client")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	ctx := context.Background()
+	client := NewLocalClient(root)
+
+	for _, name := range []string{
+		"gs://bucket/logs/job/2/finished.json",
+		"gs://bucket/logs/job/1/started.json",
+		"gs://bucket/logs/job/1/artifacts/junit.xml",
+		"gs://bucket/logs/job/latest-build.txt",
+	} {
+		if err := client.Upload(ctx, mustPath(t, name), []byte(name), false, ""); err != nil {
+			t.Fatalf("Upload(%s): %v", name, err)
+		}
+	}
+	if err := client.Copy(ctx, mustPath(t, "gs://bucket/logs/job/1/started.json"), mustPath(t, "gs://other/copy.json")); err != nil {
+		t.Fatalf("Copy(): %v", err)
+	}
+
+	list := func(path, delim, start string) []string {
+		var names []string
+		it := client.Objects(ctx, mustPath(t, path), delim, start)
+		for {
+			attrs, err := it.Next()
+			if err == iterator.Done {
+				return names
+			}
+			if err != nil {
+				t.Fatalf("Objects(%s).Next(): %v", path, err)
+			}
+			names = append(names, attrs.Name+attrs.Prefix)
+		}
+	}
+
+	cases := []struct {
+		name  string
+		path  string
+		delim string
+		start string
+		want  []string
+	}{
+		{
+			name:  "delimiter lists builds",
+			path:  "gs://bucket/logs/job",
+			delim: "/",
+			want: []string{
+				"logs/job/1/",
+				"logs/job/2/",
+				"logs/job/latest-build.txt",
+			},
+		},
+		{
+			name: "no delimiter lists everything",
+			path: "gs://bucket/logs/job/1/",
+			want: []string{
+				"logs/job/1/artifacts/junit.xml",
+				"logs/job/1/started.json",
+			},
+		},
+		{
+			name:  "start offset",
+			path:  "gs://bucket/logs/job",
+			delim: "/",
+			start: "logs/job/2/",
+			want: []string{
+				"logs/job/2/",
+				"logs/job/latest-build.txt",
+			},
+		},
+		{
+			name: "bucket root",
+			path: "gs://other",
+			want: []string{"copy.json"},
+		},
+		{
+			name:  "missing",
+			path:  "gs://bucket/nope",
+			delim: "/",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, list(tc.path, tc.delim, tc.start)); diff != "" {
+				t.Errorf("Objects() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	r, err := client.Open(ctx, mustPath(t, "gs://other/copy.json"))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll(): %v", err)
+	}
+	if got, want := string(buf), "gs://bucket/logs/job/1/started.json"; got != want {
+		t.Errorf("Open() got %q, want %q", got, want)
+	}
+
+	attrs, err := client.Stat(ctx, mustPath(t, "gs://bucket/logs/job/2/finished.json"))
+	if err != nil {
+		t.Fatalf("Stat(): %v", err)
+	}
+	if attrs.Size != int64(len("gs://bucket/logs/job/2/finished.json")) {
+		t.Errorf("Stat() got size %d", attrs.Size)
+	}
+
+	if _, err := client.Open(ctx, mustPath(t, "gs://bucket/missing")); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Open(missing) got %v, want ErrObjectNotExist", err)
+	}
+	if _, err := client.Stat(ctx, mustPath(t, "gs://bucket/logs")); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Stat(dir) got %v, want ErrObjectNotExist", err)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// MirrorStats counts the outcome of mirrored writes.
+type MirrorStats struct {
+	Succeeded int64
+	Failed    int64
+}
+
+// A MirrorClient asynchronously mirrors every write to a secondary location.
+//
+// Reads, lists and stats only go to the primary client.
+// Call Wait() to block until all pending mirror writes complete.
+type MirrorClient interface {
+	ConditionalClient
+	// Wait blocks until all pending mirror writes complete.
+	Wait()
+	// Stats returns the number of successful and failed mirror writes.
+	Stats() MirrorStats
+}
+
+// MirrorPath returns a function that rewrites paths to the same object under the mirror location.
+//
+// In other words gs://primary/foo/bar becomes gs://mirror/prefix/foo/bar
+func MirrorPath(mirror Path) func(Path) (*Path, error) {
+	return func(p Path) (*Path, error) {
+		u := url.URL{
+			Scheme: "gs",
+			Host:   mirror.Bucket(),
+			Path:   "/" + path.Join(mirror.Object(), p.Object()),
+		}
+		var out Path
+		if err := out.SetURL(&u); err != nil {
+			return nil, err
+		}
+		return &out, nil
+	}
+}
+
+// NewMirrorClient mirrors every write to the primary client to the secondary uploader.
+//
+// The mirror function determines where to write the secondary copy of each object.
+// At most concurrency mirror writes happen at once, after which writes to the
+// primary block until a slot frees up.
+func NewMirrorClient(primary ConditionalClient, secondary Uploader, mirror func(Path) (*Path, error), concurrency int, timeout time.Duration) MirrorClient {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &mirrorClient{
+		ConditionalClient: primary,
+		mirrorer: &mirrorer{
+			secondary: secondary,
+			mirror:    mirror,
+			timeout:   timeout,
+			slots:     make(chan struct{}, concurrency),
+		},
+	}
+}
+
+type mirrorer struct {
+	secondary Uploader
+	mirror    func(Path) (*Path, error)
+	timeout   time.Duration
+	slots     chan struct{}
+	wg        sync.WaitGroup
+	succeeded int64
+	failed    int64
+}
+
+type mirrorClient struct {
+	ConditionalClient
+	*mirrorer
+}
+
+func (mc *mirrorClient) If(read, write *storage.Conditions) ConditionalClient {
+	return &mirrorClient{
+		ConditionalClient: mc.ConditionalClient.If(read, write),
+		mirrorer:          mc.mirrorer,
+	}
+}
+
+func (mc *mirrorClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	if err := mc.ConditionalClient.Upload(ctx, path, buf, worldReadable, cacheControl); err != nil {
+		return err
+	}
+	mc.send(path, buf, worldReadable, cacheControl)
+	return nil
+}
+
+func (mc *mirrorClient) Copy(ctx context.Context, from, to Path) error {
+	if err := mc.ConditionalClient.Copy(ctx, from, to); err != nil {
+		return err
+	}
+	if from == to {
+		return nil // Touching an object in place does not change its content.
+	}
+	r, err := mc.ConditionalClient.Open(ctx, to)
+	if err != nil {
+		mc.fail(to, fmt.Errorf("open: %w", err))
+		return nil
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		mc.fail(to, fmt.Errorf("read: %w", err))
+		return nil
+	}
+	mc.send(to, buf, DefaultAcl, "")
+	return nil
+}
+
+// send asynchronously uploads buf to the mirror of path.
+func (m *mirrorer) send(path Path, buf []byte, worldReadable bool, cacheControl string) {
+	m.slots <- struct{}{}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer func() { <-m.slots }()
+		if err := m.write(path, buf, worldReadable, cacheControl); err != nil {
+			m.fail(path, err)
+			return
+		}
+		atomic.AddInt64(&m.succeeded, 1)
+	}()
+}
+
+func (m *mirrorer) write(path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	to, err := m.mirror(path)
+	if err != nil {
+		return fmt.Errorf("mirror path: %w", err)
+	}
+	// Mirrored writes outlive the context of the primary write.
+	ctx := context.Background()
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+	if err := m.secondary.Upload(ctx, *to, buf, worldReadable, cacheControl); err != nil {
+		return fmt.Errorf("upload %s: %w", to, err)
+	}
+	return nil
+}
+
+func (m *mirrorer) fail(path Path, err error) {
+	atomic.AddInt64(&m.failed, 1)
+	logrus.WithError(err).WithField("path", path).Warning("Failed to mirror write")
+}
+
+func (m *mirrorer) Wait() {
+	m.wg.Wait()
+}
+
+func (m *mirrorer) Stats() MirrorStats {
+	return MirrorStats{
+		Succeeded: atomic.LoadInt64(&m.succeeded),
+		Failed:    atomic.LoadInt64(&m.failed),
+	}
+}
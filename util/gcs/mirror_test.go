@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustPath(t *testing.T, s string) Path {
+	t.Helper()
+	p, err := NewPath(s)
+	if err != nil {
+		t.Fatalf("NewPath(%q): %v", s, err)
+	}
+	return *p
+}
+
+func TestMirrorPath(t *testing.T) {
+	cases := []struct {
+		name   string
+		mirror string
+		path   string
+		want   string
+	}{
+		{
+			name:   "bucket only",
+			mirror: "gs://backup",
+			path:   "gs://primary/grid/foo",
+			want:   "gs://backup/grid/foo",
+		},
+		{
+			name:   "bucket and prefix",
+			mirror: "gs://backup/some/prefix",
+			path:   "gs://primary/grid/foo",
+			want:   "gs://backup/some/prefix/grid/foo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MirrorPath(mustPath(t, tc.mirror))(mustPath(t, tc.path))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.String()); diff != "" {
+				t.Errorf("MirrorPath() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeMirrorUploader struct {
+	lock  sync.Mutex
+	err   error
+	files map[string]string
+}
+
+func (fu *fakeMirrorUploader) Upload(_ context.Context, path Path, buf []byte, _ bool, _ string) error {
+	if fu.err != nil {
+		return fu.err
+	}
+	fu.lock.Lock()
+	defer fu.lock.Unlock()
+	if fu.files == nil {
+		fu.files = map[string]string{}
+	}
+	fu.files[path.String()] = string(buf)
+	return nil
+}
+
+type fakeMirrorPrimary struct {
+	ConditionalClient
+	fakeMirrorUploader
+	fakeOpener
+}
+
+func (fp *fakeMirrorPrimary) If(_, _ *storage.Conditions) ConditionalClient {
+	return fp
+}
+
+func (fp *fakeMirrorPrimary) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	return fp.fakeMirrorUploader.Upload(ctx, path, buf, worldReadable, cacheControl)
+}
+
+func (fp *fakeMirrorPrimary) Open(ctx context.Context, path Path) (io.ReadCloser, error) {
+	return fp.fakeOpener.Open(ctx, path)
+}
+
+func (fp *fakeMirrorPrimary) Copy(_ context.Context, from, to Path) error {
+	if fp.fakeOpener[from].openErr != nil {
+		return fp.fakeOpener[from].openErr
+	}
+	fp.fakeOpener[to] = fp.fakeOpener[from]
+	return nil
+}
+
+func TestMirrorClient(t *testing.T) {
+	cases := []struct {
+		name       string
+		uploads    map[string]string
+		copies     [][2]string
+		objects    map[string]string
+		primaryErr error
+		mirrorErr  error
+		err        bool
+		want       map[string]string
+		stats      MirrorStats
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "mirror uploads",
+			uploads: map[string]string{
+				"gs://primary/grid/foo": "hello",
+				"gs://primary/grid/bar": "world",
+			},
+			want: map[string]string{
+				"gs://backup/prefix/grid/foo": "hello",
+				"gs://backup/prefix/grid/bar": "world",
+			},
+			stats: MirrorStats{Succeeded: 2},
+		},
+		{
+			name: "primary failure is not mirrored",
+			uploads: map[string]string{
+				"gs://primary/grid/foo": "hello",
+			},
+			primaryErr: errors.New("bad"),
+			err:        true,
+		},
+		{
+			name: "mirror failures are counted",
+			uploads: map[string]string{
+				"gs://primary/grid/foo": "hello",
+			},
+			mirrorErr: errors.New("bad"),
+			stats:     MirrorStats{Failed: 1},
+		},
+		{
+			name: "mirror copies",
+			objects: map[string]string{
+				"gs://primary/config": "cfg",
+			},
+			copies: [][2]string{
+				{"gs://primary/config", "gs://primary/config-copy"},
+			},
+			want: map[string]string{
+				"gs://backup/prefix/config-copy": "cfg",
+			},
+			stats: MirrorStats{Succeeded: 1},
+		},
+		{
+			name: "skip copies to self",
+			objects: map[string]string{
+				"gs://primary/grid/foo": "lock",
+			},
+			copies: [][2]string{
+				{"gs://primary/grid/foo", "gs://primary/grid/foo"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			primary := &fakeMirrorPrimary{
+				fakeMirrorUploader: fakeMirrorUploader{err: tc.primaryErr},
+				fakeOpener:         fakeOpener{},
+			}
+			for p, data := range tc.objects {
+				primary.fakeOpener[mustPath(t, p)] = fakeObject{data: data}
+			}
+			secondary := &fakeMirrorUploader{err: tc.mirrorErr}
+			client := NewMirrorClient(primary, secondary, MirrorPath(mustPath(t, "gs://backup/prefix")), 2, 0)
+
+			var err error
+			for p, data := range tc.uploads {
+				if err = client.If(nil, nil).Upload(ctx, mustPath(t, p), []byte(data), DefaultAcl, ""); err != nil {
+					break
+				}
+			}
+			for _, c := range tc.copies {
+				if err = client.Copy(ctx, mustPath(t, c[0]), mustPath(t, c[1])); err != nil {
+					break
+				}
+			}
+			client.Wait()
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("failed to receive an error")
+			}
+			if diff := cmp.Diff(tc.want, secondary.files); diff != "" {
+				t.Errorf("mirrored files got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.stats, client.Stats()); diff != "" {
+				t.Errorf("Stats() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -273,6 +273,44 @@ func (build Build) Finished(ctx context.Context, opener Opener) (*Finished, erro
 	return &finished, nil
 }
 
+// CloneRecords parses the records of the repos Prow checked out for the build.
+//
+// Returns nil when the build has no clone-records.json, such as when it is not a Prow job.
+func (build Build) CloneRecords(ctx context.Context, opener Opener) ([]metadata.CloneRecord, error) {
+	path, err := build.Path.ResolveReference(&url.URL{Path: "clone-records.json"})
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	var records []metadata.CloneRecord
+	err = readJSON(ctx, opener, *path, &records)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	return records, nil
+}
+
+// PodInfo parses the description of the pod Prow ran the build in.
+//
+// Returns nil when the build has no podinfo.json, such as when it is not a Prow job.
+func (build Build) PodInfo(ctx context.Context, opener Opener) (*metadata.PodInfo, error) {
+	path, err := build.Path.ResolveReference(&url.URL{Path: "podinfo.json"})
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	var pod metadata.PodInfo
+	err = readJSON(ctx, opener, *path, &pod)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	return &pod, nil
+}
+
 // Artifacts writes the object name of all paths under the build's artifact dir to the output channel.
 func (build Build) Artifacts(ctx context.Context, lister Lister, artifacts chan<- string) error {
 	objs := lister.Objects(ctx, build.Path, "", "") // no delim or offset so we get all objects.
@@ -798,6 +798,141 @@ func TestFinished(t *testing.T) {
 	}
 }
 
+func TestCloneRecords(t *testing.T) {
+	path := newPathOrDie("gs://bucket/path/")
+	records := resolveOrDie(path, "clone-records.json")
+	cases := []struct {
+		name     string
+		object   *fakeObject
+		expected []metadata.CloneRecord
+		err      bool
+	}{
+		{
+			name: "missing object means not prow",
+		},
+		{
+			name: "parse records",
+			object: &fakeObject{
+				data: `[{
+                    "refs": {
+                        "org": "kubernetes",
+                        "repo": "test-infra",
+                        "base_ref": "master",
+                        "base_sha": "abc",
+                        "pulls": [{"number": 123, "author": "someone", "sha": "def"}]
+                    },
+                    "final_sha": "fed"
+                }]`,
+			},
+			expected: []metadata.CloneRecord{
+				{
+					Refs: metadata.Refs{
+						Org:     "kubernetes",
+						Repo:    "test-infra",
+						BaseRef: "master",
+						BaseSHA: "abc",
+						Pulls: []metadata.Pull{
+							{Number: 123, Author: "someone", SHA: "def"},
+						},
+					},
+					FinalSHA: "fed",
+				},
+			},
+		},
+		{
+			name:   "malformed records return an error",
+			object: &fakeObject{data: `{"refs": "nope"}`},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fo := fakeOpener{}
+			if tc.object != nil {
+				fo[records] = *tc.object
+			}
+			b := Build{Path: path}
+			actual, err := b.CloneRecords(context.Background(), fo)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("CloneRecords(): unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("CloneRecords(): failed to return an error")
+			default:
+				if !reflect.DeepEqual(actual, tc.expected) {
+					t.Errorf("CloneRecords(): got %v, want %v", actual, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestPodInfo(t *testing.T) {
+	path := newPathOrDie("gs://bucket/path/")
+	podinfo := resolveOrDie(path, "podinfo.json")
+	cases := []struct {
+		name     string
+		object   *fakeObject
+		expected map[string]string
+		err      bool
+	}{
+		{
+			name: "missing object means not prow",
+		},
+		{
+			name: "parse labels and env",
+			object: &fakeObject{
+				data: `{
+                    "pod": {
+                        "metadata": {"labels": {"prow.k8s.io/refs.pull": "123"}},
+                        "spec": {"containers": [{"env": [{"name": "PULL_PULL_SHA", "value": "def"}]}]}
+                    },
+                    "events": []
+                }`,
+			},
+			expected: map[string]string{
+				metadata.ProwPull:    "123",
+				metadata.ProwPullSHA: "def",
+			},
+		},
+		{
+			name:   "read error returns an error",
+			object: &fakeObject{readErr: errors.New("injected read error")},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fo := fakeOpener{}
+			if tc.object != nil {
+				fo[podinfo] = *tc.object
+			}
+			b := Build{Path: path}
+			actual, err := b.PodInfo(context.Background(), fo)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("PodInfo(): unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("PodInfo(): failed to return an error")
+			case tc.expected == nil:
+				if actual != nil {
+					t.Errorf("PodInfo(): got %v, want nil", actual)
+				}
+			default:
+				if got := metadata.ProwMetadata(nil, actual); !reflect.DeepEqual(got, tc.expected) {
+					t.Errorf("PodInfo(): got metadata %v, want %v", got, tc.expected)
+				}
+			}
+		})
+	}
+}
+
 func resolveOrDie(p Path, s string) Path {
 	out, err := p.ResolveReference(&url.URL{Path: s})
 	if err != nil {
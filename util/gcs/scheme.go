@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+)
+
+// NewPathClient returns a client that selects the backend of each path from its scheme.
+//
+// Sends gs:// paths to the gcs client, file:// paths to the local filesystem,
+// and reads s3:// and https:// paths anonymously over http.
+func NewPathClient(gcs ConditionalClient) ConditionalClient {
+	return NewSchemeClient(map[string]ConditionalClient{
+		"gs":    gcs,
+		"file":  NewLocalClient("/"),
+		"s3":    NewS3Client(http.DefaultClient, DefaultS3Endpoint),
+		"https": NewHTTPClient(http.DefaultClient),
+	})
+}
+
+// NewSchemeClient returns a client that sends each operation to the client for the scheme of its path.
+//
+// Operations on paths without a client fail.
+// Copies between schemes read the source and upload it to the destination.
+func NewSchemeClient(clients map[string]ConditionalClient) ConditionalClient {
+	return schemeClient(clients)
+}
+
+type schemeClient map[string]ConditionalClient
+
+func (sc schemeClient) If(read, write *storage.Conditions) ConditionalClient {
+	out := make(schemeClient, len(sc))
+	for scheme, client := range sc {
+		out[scheme] = client.If(read, write)
+	}
+	return out
+}
+
+// client returns the client of the path's scheme.
+func (sc schemeClient) client(path Path) (ConditionalClient, error) {
+	client, ok := sc[path.Scheme()]
+	if !ok {
+		return nil, fmt.Errorf("no client for %s:// urls: %s", path.Scheme(), path)
+	}
+	return client, nil
+}
+
+func (sc schemeClient) Copy(ctx context.Context, from, to Path) error {
+	if from.Scheme() == to.Scheme() {
+		client, err := sc.client(to)
+		if err != nil {
+			return err
+		}
+		return client.Copy(ctx, from, to)
+	}
+	r, err := sc.Open(ctx, from)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	return sc.Upload(ctx, to, buf, DefaultAcl, "")
+}
+
+func (sc schemeClient) Open(ctx context.Context, path Path) (io.ReadCloser, error) {
+	client, err := sc.client(path)
+	if err != nil {
+		return nil, err
+	}
+	return client.Open(ctx, path)
+}
+
+func (sc schemeClient) Objects(ctx context.Context, path Path, delimiter, startOffset string) Iterator {
+	client, err := sc.client(path)
+	if err != nil {
+		return &localIterator{err: err}
+	}
+	return client.Objects(ctx, path, delimiter, startOffset)
+}
+
+func (sc schemeClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	client, err := sc.client(path)
+	if err != nil {
+		return err
+	}
+	return client.Upload(ctx, path, buf, worldReadable, cacheControl)
+}
+
+func (sc schemeClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	client, err := sc.client(path)
+	if err != nil {
+		return nil, err
+	}
+	return client.Stat(ctx, path)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchemeClient(t *testing.T) {
+	root, err := ioutil.TempDir("", "scheme-client")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	ctx := context.Background()
+	gcs := NewLocalClient(filepath.Join(root, "gcs"))
+	client := NewSchemeClient(map[string]ConditionalClient{
+		"gs":   gcs,
+		"file": NewLocalClient("/"),
+	})
+
+	file := mustPath(t, "file://"+filepath.ToSlash(filepath.Join(root, "local", "config.pb")))
+	if err := client.Upload(ctx, file, []byte("hello"), false, ""); err != nil {
+		t.Fatalf("Upload(%s): %v", file, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "local", "config.pb")); err != nil {
+		t.Errorf("Upload(%s) did not write the file: %v", file, err)
+	}
+
+	remote := mustPath(t, "gs://bucket/config.pb")
+	if err := client.Copy(ctx, file, remote); err != nil {
+		t.Fatalf("Copy(%s, %s): %v", file, remote, err)
+	}
+	r, err := gcs.Open(ctx, remote)
+	if err != nil {
+		t.Fatalf("Open(%s): %v", remote, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll(): %v", err)
+	}
+	if got, want := string(buf), "hello"; got != want {
+		t.Errorf("Copy() got %q, want %q", got, want)
+	}
+
+	if _, err := client.If(nil, nil).Stat(ctx, remote); err != nil {
+		t.Errorf("If().Stat(%s): %v", remote, err)
+	}
+	if _, err := client.Stat(ctx, mustPath(t, "s3://bucket/config.pb")); err == nil {
+		t.Error("Stat(s3) failed to return an error without an s3 client")
+	}
+}
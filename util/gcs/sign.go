@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// A Signer mints time-limited URLs that grant read access to an object.
+type Signer interface {
+	Sign(path Path) (string, error)
+}
+
+type serviceAccountSigner struct {
+	accessID   string
+	privateKey []byte
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// NewSigner returns a Signer that signs URLs valid for ttl using the service account key.
+func NewSigner(accessID string, privateKey []byte, ttl time.Duration) Signer {
+	return serviceAccountSigner{
+		accessID:   accessID,
+		privateKey: privateKey,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// SignerFromCreds returns a Signer using a service account key from a JSON credentials file.
+func SignerFromCreds(credsPath string, ttl time.Duration) (Signer, error) {
+	buf, err := ioutil.ReadFile(credsPath)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var creds struct {
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	if err := json.Unmarshal(buf, &creds); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if creds.ClientEmail == "" || creds.PrivateKey == "" {
+		return nil, errors.New("missing client_email or private_key")
+	}
+	return NewSigner(creds.ClientEmail, []byte(creds.PrivateKey), ttl), nil
+}
+
+func (s serviceAccountSigner) Sign(path Path) (string, error) {
+	return storage.SignedURL(path.Bucket(), path.Object(), &storage.SignedURLOptions{
+		GoogleAccessID: s.accessID,
+		PrivateKey:     s.privateKey,
+		Method:         http.MethodGet,
+		Expires:        s.now().Add(s.ttl),
+		Scheme:         storage.SigningSchemeV4,
+	})
+}
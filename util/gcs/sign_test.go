@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSignerFromCreds(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	good, err := json.Marshal(map[string]string{
+		"client_email": "robot@example.com",
+		"private_key":  string(pemKey),
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		creds string
+		err   bool
+	}{
+		{
+			name:  "basically works",
+			creds: string(good),
+		},
+		{
+			name:  "reject missing key",
+			creds: `{"client_email": "robot@example.com"}`,
+			err:   true,
+		},
+		{
+			name:  "reject malformed json",
+			creds: `{`,
+			err:   true,
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creds := filepath.Join(dir, "creds.json")
+			if err := ioutil.WriteFile(creds, []byte(tc.creds), 0600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			signer, err := SignerFromCreds(creds, time.Hour)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("failed to receive an error")
+			}
+			link, err := signer.Sign(mustPath(t, "gs://bucket/some/object"))
+			if err != nil {
+				t.Fatalf("Sign() got unexpected error: %v", err)
+			}
+			u, err := url.Parse(link)
+			if err != nil {
+				t.Fatalf("Sign() returned bad url %q: %v", link, err)
+			}
+			if want := "/bucket/some/object"; u.Path != want {
+				t.Errorf("Sign() got path %q, want %q", u.Path, want)
+			}
+			if u.Query().Get("X-Goog-Expires") == "" {
+				t.Errorf("Sign() got %q, want an expiring url", link)
+			}
+		})
+	}
+}
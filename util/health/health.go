@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health reports whether the cycles of a periodic binary complete often enough,
+// so that Kubernetes can restart it when stuck.
+package health
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Paths of the liveness and readiness endpoints.
+const (
+	LivePath  = "/healthz"
+	ReadyPath = "/readyz"
+)
+
+// A Checker tracks the successful cycles of a binary.
+type Checker struct {
+	timeout time.Duration
+	now     func() time.Time
+
+	lock    sync.Mutex
+	started time.Time
+	success time.Time
+}
+
+// NewChecker returns a checker that is unhealthy when no cycle succeeds within timeout.
+//
+// Such as some multiple of the --wait between cycles. Never times out unless positive.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		now:     time.Now,
+		started: time.Now(),
+	}
+}
+
+// Cycle records the completion of a cycle, which succeeded unless err is set.
+func (c *Checker) Cycle(err error) {
+	if err != nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.success = c.now()
+}
+
+// Live returns an error when no cycle has succeeded within the timeout, since the last success or else since starting.
+func (c *Checker) Live() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.timeout <= 0 {
+		return nil
+	}
+	since := c.success
+	if since.IsZero() {
+		since = c.started
+	}
+	if elapsed := c.now().Sub(since); elapsed > c.timeout {
+		if c.success.IsZero() {
+			return fmt.Errorf("no successful cycle within %s of starting", c.timeout)
+		}
+		return fmt.Errorf("last successful cycle %s ago, over %s", elapsed.Round(time.Second), c.timeout)
+	}
+	return nil
+}
+
+// Ready returns an error until a cycle succeeds, or while not live.
+func (c *Checker) Ready() error {
+	if err := c.Live(); err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.success.IsZero() {
+		return errors.New("no successful cycle yet")
+	}
+	return nil
+}
+
+// Handler serves the liveness of the checker at LivePath and its readiness at ReadyPath.
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivePath, handle(c.Live))
+	mux.HandleFunc(ReadyPath, handle(c.Ready))
+	return mux
+}
+
+func handle(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := check(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+// Serve listens on the address, such as :8081, serving the endpoints of the checker in the background.
+//
+// Does nothing when addr is empty.
+func Serve(addr string, c *Checker) error {
+	if addr == "" {
+		return nil
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := http.Serve(lis, c.Handler()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.WithError(err).Error("Stopped serving health checks")
+		}
+	}()
+	logrus.WithField("address", lis.Addr().String()).Info("Serving health checks")
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker(t *testing.T) {
+	start := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name    string
+		timeout time.Duration
+		cycles  []error
+		elapsed time.Duration
+		live    bool
+		ready   bool
+	}{
+		{
+			name:    "starting",
+			timeout: time.Hour,
+			live:    true,
+		},
+		{
+			name:    "stuck starting",
+			timeout: time.Hour,
+			elapsed: 2 * time.Hour,
+		},
+		{
+			name:    "failing cycles",
+			timeout: time.Hour,
+			cycles:  []error{errors.New("injected"), errors.New("again")},
+			live:    true,
+		},
+		{
+			name:    "successful cycle",
+			timeout: time.Hour,
+			cycles:  []error{nil, errors.New("injected")},
+			live:    true,
+			ready:   true,
+		},
+		{
+			name:    "stale success",
+			timeout: time.Hour,
+			cycles:  []error{nil},
+			elapsed: 90 * time.Minute,
+		},
+		{
+			name:    "no timeout",
+			cycles:  []error{nil},
+			elapsed: 1000 * time.Hour,
+			live:    true,
+			ready:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := start
+			c := NewChecker(tc.timeout)
+			c.now = func() time.Time { return now }
+			c.started = start
+			for _, err := range tc.cycles {
+				now = now.Add(time.Minute)
+				c.Cycle(err)
+			}
+			now = now.Add(tc.elapsed)
+			if err := c.Live(); (err == nil) != tc.live {
+				t.Errorf("Live() got %v, want live %t", err, tc.live)
+			}
+			if err := c.Ready(); (err == nil) != tc.ready {
+				t.Errorf("Ready() got %v, want ready %t", err, tc.ready)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := NewChecker(time.Hour)
+	cases := []struct {
+		name  string
+		cycle bool
+		path  string
+		code  int
+	}{
+		{
+			name: "live before a cycle",
+			path: LivePath,
+			code: http.StatusOK,
+		},
+		{
+			name: "not ready before a cycle",
+			path: ReadyPath,
+			code: http.StatusServiceUnavailable,
+		},
+		{
+			name:  "ready after a cycle",
+			cycle: true,
+			path:  ReadyPath,
+			code:  http.StatusOK,
+		},
+		{
+			name: "not found",
+			path: "/metrics",
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.cycle {
+				c.Cycle(nil)
+			}
+			rec := httptest.NewRecorder()
+			c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Errorf("ServeHTTP(%q) got code %d, want %d: %s", tc.path, rec.Code, tc.code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	cases := []struct {
+		name string
+		addr string
+		err  bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "serve",
+			addr: "127.0.0.1:0",
+		},
+		{
+			name: "bad address",
+			addr: "not an address",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Serve(tc.addr, NewChecker(0))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Serve() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Serve() failed to return an error")
+			}
+		})
+	}
+}
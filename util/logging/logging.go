@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging configures the format, level and sampling of the logs of each binary.
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Formats of the --log-format flag.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options configure the standard logrus logger.
+type Options struct {
+	Format string
+	Level  string
+	Sample int
+}
+
+// AddFlags registers --log-format, --log-level and --log-sample on the flag set.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Format, "log-format", FormatText, "Log lines as json or text")
+	fs.StringVar(&o.Level, "log-level", logrus.InfoLevel.String(), "Log lines at this level or above: trace, debug, info, warning, error or fatal")
+	fs.IntVar(&o.Sample, "log-sample", 0, "Log the first N of each repeated per-row warning, then one of every N, if set")
+}
+
+// Configure applies the options to the standard logger and the Repeated sampler.
+func (o Options) Configure() error {
+	switch o.Format {
+	case FormatJSON:
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	case FormatText, "":
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	default:
+		return fmt.Errorf("unknown --log-format %q, want json or text", o.Format)
+	}
+	if o.Level != "" {
+		level, err := logrus.ParseLevel(o.Level)
+		if err != nil {
+			return fmt.Errorf("bad --log-level: %w", err)
+		}
+		logrus.SetLevel(level)
+	}
+	if o.Sample < 0 {
+		return fmt.Errorf("negative --log-sample: %d", o.Sample)
+	}
+	Repeated.Reset(o.Sample, o.Sample)
+	return nil
+}
+
+// Repeated samples high-volume warnings, such as those about individual rows.
+//
+// Logs every warning until configured.
+var Repeated = &Sampler{}
+
+// Sampler limits how often each repeated message is logged.
+type Sampler struct {
+	first  int
+	every  int
+	lock   sync.Mutex
+	counts map[string]int
+}
+
+// Reset logs the first occurrences of each message, then one of every so many.
+//
+// Logs every occurrence when first is zero, and stops after the first ones when every is zero.
+func (s *Sampler) Reset(first, every int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.first = first
+	s.every = every
+	s.counts = nil
+}
+
+// Allow counts an occurrence of the message, returning the count and whether to log it.
+func (s *Sampler) Allow(msg string) (int, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.counts == nil {
+		s.counts = map[string]int{}
+	}
+	s.counts[msg]++
+	n := s.counts[msg]
+	switch {
+	case s.first == 0, n <= s.first:
+		return n, true
+	case s.every == 0:
+		return n, false
+	}
+	return n, (n-s.first)%s.every == 0
+}
+
+// Warning logs the message when allowed, along with how many times it occurred.
+func (s *Sampler) Warning(log logrus.FieldLogger, msg string) {
+	n, ok := s.Allow(msg)
+	if !ok {
+		return
+	}
+	if n > 1 {
+		log = log.WithField("occurrences", n)
+	}
+	log.Warning(msg)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAddFlags(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want Options
+	}{
+		{
+			name: "defaults",
+			want: Options{Format: FormatText, Level: "info"},
+		},
+		{
+			name: "set",
+			args: []string{"--log-format=json", "--log-level=debug", "--log-sample=5"},
+			want: Options{Format: FormatJSON, Level: "debug", Sample: 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Options
+			fs := flag.NewFlagSet(tc.name, flag.ContinueOnError)
+			got.AddFlags(fs)
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("AddFlags() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	defer logrus.SetLevel(logrus.GetLevel())
+	defer logrus.SetFormatter(logrus.StandardLogger().Formatter)
+	defer Repeated.Reset(0, 0)
+	cases := []struct {
+		name   string
+		opts   Options
+		level  logrus.Level
+		json   bool
+		sample int
+		err    bool
+	}{
+		{
+			name:  "text",
+			opts:  Options{Format: FormatText, Level: "warning"},
+			level: logrus.WarnLevel,
+		},
+		{
+			name:   "json",
+			opts:   Options{Format: FormatJSON, Level: "trace", Sample: 3},
+			level:  logrus.TraceLevel,
+			json:   true,
+			sample: 3,
+		},
+		{
+			name: "bad format",
+			opts: Options{Format: "xml"},
+			err:  true,
+		},
+		{
+			name: "bad level",
+			opts: Options{Level: "loud"},
+			err:  true,
+		},
+		{
+			name: "negative sample",
+			opts: Options{Sample: -1},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			switch err := tc.opts.Configure(); {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Configure() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Configure() failed to return an error")
+			default:
+				if got := logrus.GetLevel(); got != tc.level {
+					t.Errorf("Configure() got level %v, want %v", got, tc.level)
+				}
+				_, isJSON := logrus.StandardLogger().Formatter.(*logrus.JSONFormatter)
+				if isJSON != tc.json {
+					t.Errorf("Configure() got json formatter %t, want %t", isJSON, tc.json)
+				}
+				if got := Repeated.first; got != tc.sample {
+					t.Errorf("Configure() got sample %d, want %d", got, tc.sample)
+				}
+			}
+		})
+	}
+}
+
+func TestAllow(t *testing.T) {
+	cases := []struct {
+		name  string
+		first int
+		every int
+		want  []bool
+	}{
+		{
+			name: "log everything",
+			want: []bool{true, true, true, true},
+		},
+		{
+			name:  "first only",
+			first: 2,
+			want:  []bool{true, true, false, false, false},
+		},
+		{
+			name:  "first then every",
+			first: 2,
+			every: 3,
+			want:  []bool{true, true, false, false, true, false, false, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s Sampler
+			s.Reset(tc.first, tc.every)
+			var got []bool
+			for i := range tc.want {
+				n, ok := s.Allow("hello")
+				if n != i+1 {
+					t.Errorf("Allow() got count %d, want %d", n, i+1)
+				}
+				got = append(got, ok)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Allow() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if _, ok := s.Allow("other"); !ok {
+				t.Error("Allow() sampled the first occurrence of another message")
+			}
+		})
+	}
+}
+
+func TestWarning(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.Out = &buf
+	log.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	var s Sampler
+	s.Reset(1, 2)
+	for i := 0; i < 4; i++ {
+		s.Warning(log, "repeated")
+	}
+	const want = "level=warning msg=repeated\nlevel=warning msg=repeated occurrences=3\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Warning() got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics reports metrics about each binary, such as the counts and durations of its cycles,
+// to Prometheus, Stackdriver or statsd.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Path serves the metrics.
+const Path = "/metrics"
+
+// A Reporter sends the values of metrics to a monitoring system.
+type Reporter interface {
+	// Count adds n to the counter for the field values.
+	Count(m *Metric, n float64, values ...string)
+	// Observe adds the duration to the distribution for the field values.
+	Observe(m *Metric, dur time.Duration, values ...string)
+	// Set replaces the value of the gauge for the field values.
+	Set(m *Metric, value float64, values ...string)
+}
+
+// A Metric names a counter or duration along with the fields of its values.
+type Metric struct {
+	Name   string
+	Help   string
+	Fields []string
+}
+
+// Buckets of every duration, in seconds: 10ms, then each four times the previous, up to 43m.
+const (
+	bucketScale  = 0.01
+	bucketGrowth = 4
+	bucketCount  = 10
+)
+
+var (
+	reporterLock sync.RWMutex
+	reporter     Reporter = NewPrometheus(prometheus.DefaultRegisterer)
+)
+
+// SetReporter sends every metric to the reporter, instead of the default Prometheus registry.
+func SetReporter(r Reporter) {
+	reporterLock.Lock()
+	defer reporterLock.Unlock()
+	reporter = r
+}
+
+func current() Reporter {
+	reporterLock.RLock()
+	defer reporterLock.RUnlock()
+	return reporter
+}
+
+// A Counter counts events, such as failed requests, with a value for each of its fields.
+type Counter struct {
+	metric Metric
+}
+
+// NewCounter returns a counter with the named fields.
+func NewCounter(name, help string, fields ...string) *Counter {
+	return &Counter{Metric{Name: name, Help: help, Fields: fields}}
+}
+
+// Add increases the count of the field values by n.
+func (c *Counter) Add(n float64, values ...string) {
+	current().Count(&c.metric, n, values...)
+}
+
+// A Duration observes how long things take, such as requests, with a value for each of its fields.
+type Duration struct {
+	metric Metric
+}
+
+// NewDuration returns a duration in seconds with the named fields.
+func NewDuration(name, help string, fields ...string) *Duration {
+	return &Duration{Metric{Name: name, Help: help, Fields: fields}}
+}
+
+// Observe records the duration for the field values.
+func (d *Duration) Observe(dur time.Duration, values ...string) {
+	current().Observe(&d.metric, dur, values...)
+}
+
+// Since records the time since start for the field values.
+func (d *Duration) Since(start time.Time, values ...string) {
+	d.Observe(time.Since(start), values...)
+}
+
+// A Gauge is a value that can go up and down, such as a ratio, with a value for each of its fields.
+type Gauge struct {
+	metric Metric
+}
+
+// NewGauge returns a gauge with the named fields.
+func NewGauge(name, help string, fields ...string) *Gauge {
+	return &Gauge{Metric{Name: name, Help: help, Fields: fields}}
+}
+
+// Set replaces the value for the field values.
+func (g *Gauge) Set(value float64, values ...string) {
+	current().Set(&g.metric, value, values...)
+}
+
+var (
+	cycles         = NewCounter("testgrid_cycles_total", "Number of completed cycles", "component")
+	cycleErrors    = NewCounter("testgrid_cycle_errors_total", "Number of cycles which failed", "component")
+	cycleDurations = NewDuration("testgrid_cycle_duration_seconds", "Time to complete each cycle", "component")
+)
+
+// Cycle records a cycle of the component which began at start, counting it as an error if err is set.
+func Cycle(component string, start time.Time, err error) {
+	cycles.Add(1, component)
+	cycleDurations.Since(start, component)
+	if err != nil {
+		cycleErrors.Add(1, component)
+	}
+}
+
+// Reporters of the --metrics-reporter flag.
+const (
+	ReporterPrometheus  = "prometheus"
+	ReporterStackdriver = "stackdriver"
+	ReporterStatsd      = "statsd"
+)
+
+// Options select and configure the reporter of a binary.
+type Options struct {
+	Reporter           string
+	Address            string
+	StatsdAddress      string
+	StackdriverProject string
+	SLOTarget          float64
+	SLOFreshness       time.Duration
+}
+
+// AddFlags registers --metrics-reporter, --metrics-address, --statsd-address, --stackdriver-project,
+// --slo-target and --slo-freshness on the flag set.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Reporter, "metrics-reporter", ReporterPrometheus, "Report metrics to prometheus, stackdriver or statsd")
+	fs.StringVar(&o.Address, "metrics-address", "", "Serve Prometheus metrics at /metrics on this address, such as :2112, if set")
+	fs.StringVar(&o.StatsdAddress, "statsd-address", "", "Send statsd metrics to this host:port when --metrics-reporter=statsd")
+	fs.StringVar(&o.StackdriverProject, "stackdriver-project", "", "Write Stackdriver metrics to this GCP project when --metrics-reporter=stackdriver")
+	fs.Float64Var(&o.SLOTarget, "slo-target", 0.99, "Fraction of test groups or dashboards expected to process successfully, such as 0.99")
+	fs.DurationVar(&o.SLOFreshness, "slo-freshness", 30*time.Minute, "Count test groups or dashboards as stale when they have not processed successfully within this duration")
+}
+
+// Setup starts reporting metrics as configured by the options.
+//
+// Returns a function which sends any remaining metrics, which callers should run before exiting.
+func (o Options) Setup(ctx context.Context) (func(), error) {
+	if o.SLOTarget != 0 || o.SLOFreshness != 0 {
+		if o.SLOTarget <= 0 || o.SLOTarget >= 1 {
+			return nil, fmt.Errorf("--slo-target must be between 0 and 1, got %g", o.SLOTarget)
+		}
+		if o.SLOFreshness <= 0 {
+			return nil, fmt.Errorf("--slo-freshness must be positive, got %s", o.SLOFreshness)
+		}
+		SetObjectives(o.SLOTarget, o.SLOFreshness)
+	}
+	switch o.Reporter {
+	case ReporterPrometheus, "":
+		return func() {}, Serve(o.Address)
+	case ReporterStatsd:
+		if o.StatsdAddress == "" {
+			return nil, errors.New("--metrics-reporter=statsd requires --statsd-address")
+		}
+		s, err := NewStatsd(o.StatsdAddress, "testgrid")
+		if err != nil {
+			return nil, fmt.Errorf("statsd: %w", err)
+		}
+		SetReporter(s)
+		return func() { s.Close() }, nil
+	case ReporterStackdriver:
+		if o.StackdriverProject == "" {
+			return nil, errors.New("--metrics-reporter=stackdriver requires --stackdriver-project")
+		}
+		sd, err := NewStackdriver(ctx, o.StackdriverProject)
+		if err != nil {
+			return nil, fmt.Errorf("stackdriver: %w", err)
+		}
+		SetReporter(sd)
+		runCtx, cancel := context.WithCancel(ctx)
+		go sd.Run(runCtx, time.Minute)
+		return func() {
+			cancel()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := sd.Flush(ctx); err != nil {
+				logrus.WithError(err).Warning("Failed to write final metrics")
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown --metrics-reporter %q, want prometheus, stackdriver or statsd", o.Reporter)
+}
+
+// Serve exports the metrics of the default Prometheus registry at Path on the address.
+//
+// Returns once listening, serving in the background. Does nothing when addr is empty.
+func Serve(addr string) error {
+	if addr == "" {
+		return nil
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.Handler())
+	go func() {
+		if err := http.Serve(lis, mux); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.WithError(err).Error("Stopped serving metrics")
+		}
+	}()
+	logrus.WithField("address", lis.Addr().String()).Info("Serving metrics")
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeReporter records the total of each metric and field values.
+type fakeReporter struct {
+	lock      sync.Mutex
+	counts    map[string]float64
+	durations map[string]time.Duration
+	gauges    map[string]float64
+}
+
+func newFakeReporter() *fakeReporter {
+	return &fakeReporter{
+		counts:    map[string]float64{},
+		durations: map[string]time.Duration{},
+		gauges:    map[string]float64{},
+	}
+}
+
+func (f *fakeReporter) Count(m *Metric, n float64, values ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.counts[m.Name+"/"+strings.Join(values, ",")] += n
+}
+
+func (f *fakeReporter) Observe(m *Metric, dur time.Duration, values ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.durations[m.Name+"/"+strings.Join(values, ",")] += dur
+}
+
+func (f *fakeReporter) Set(m *Metric, value float64, values ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.gauges[m.Name+"/"+strings.Join(values, ",")] = value
+}
+
+func TestCounter(t *testing.T) {
+	fake := newFakeReporter()
+	defer SetReporter(current())
+	SetReporter(fake)
+	c := NewCounter("testgrid_test_counter_total", "Counter for tests", "field")
+	c.Add(1, "a")
+	c.Add(2, "a")
+	c.Add(5, "b")
+	want := map[string]float64{
+		"testgrid_test_counter_total/a": 3,
+		"testgrid_test_counter_total/b": 5,
+	}
+	if diff := cmp.Diff(want, fake.counts); diff != "" {
+		t.Errorf("Add() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	fake := newFakeReporter()
+	defer SetReporter(current())
+	SetReporter(fake)
+	d := NewDuration("testgrid_test_duration_seconds", "Duration for tests", "field")
+	d.Observe(time.Second, "a")
+	d.Observe(time.Minute, "a")
+	d.Since(time.Now().Add(-time.Hour), "b")
+	if got, want := fake.durations["testgrid_test_duration_seconds/a"], time.Minute+time.Second; got != want {
+		t.Errorf("Observe() got %s, want %s", got, want)
+	}
+	if got := fake.durations["testgrid_test_duration_seconds/b"]; got < time.Hour {
+		t.Errorf("Since() got %s, want at least an hour", got)
+	}
+}
+
+func TestGauge(t *testing.T) {
+	fake := newFakeReporter()
+	defer SetReporter(current())
+	SetReporter(fake)
+	g := NewGauge("testgrid_test_gauge", "Gauge for tests", "field")
+	g.Set(1, "a")
+	g.Set(0.5, "a")
+	g.Set(2, "b")
+	want := map[string]float64{
+		"testgrid_test_gauge/a": 0.5,
+		"testgrid_test_gauge/b": 2,
+	}
+	if diff := cmp.Diff(want, fake.gauges); diff != "" {
+		t.Errorf("Set() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	fake := newFakeReporter()
+	defer SetReporter(current())
+	SetReporter(fake)
+	start := time.Now()
+	Cycle("test", start, nil)
+	Cycle("test", start, errors.New("injected"))
+	Cycle("test", start, nil)
+	want := map[string]float64{
+		"testgrid_cycles_total/test":       3,
+		"testgrid_cycle_errors_total/test": 1,
+	}
+	if diff := cmp.Diff(want, fake.counts); diff != "" {
+		t.Errorf("Cycle() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if _, ok := fake.durations["testgrid_cycle_duration_seconds/test"]; !ok {
+		t.Error("Cycle() failed to observe the duration")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	defer SetReporter(current())
+	defer SetObjectives(objectives())
+	cases := []struct {
+		name string
+		opts Options
+		err  bool
+	}{
+		{
+			name: "prometheus",
+			opts: Options{Reporter: ReporterPrometheus},
+		},
+		{
+			name: "prometheus address",
+			opts: Options{Reporter: ReporterPrometheus, Address: "127.0.0.1:0"},
+		},
+		{
+			name: "statsd",
+			opts: Options{Reporter: ReporterStatsd, StatsdAddress: "127.0.0.1:8125"},
+		},
+		{
+			name: "statsd requires an address",
+			opts: Options{Reporter: ReporterStatsd},
+			err:  true,
+		},
+		{
+			name: "stackdriver requires a project",
+			opts: Options{Reporter: ReporterStackdriver},
+			err:  true,
+		},
+		{
+			name: "objectives",
+			opts: Options{Reporter: ReporterPrometheus, SLOTarget: 0.9, SLOFreshness: time.Hour},
+		},
+		{
+			name: "target must be a fraction",
+			opts: Options{Reporter: ReporterPrometheus, SLOTarget: 1, SLOFreshness: time.Hour},
+			err:  true,
+		},
+		{
+			name: "freshness must be positive",
+			opts: Options{Reporter: ReporterPrometheus, SLOTarget: 0.9},
+			err:  true,
+		},
+		{
+			name: "unknown reporter",
+			opts: Options{Reporter: "graphite"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			shutdown, err := tc.opts.Setup(context.Background())
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Setup() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Setup() failed to return an error")
+			default:
+				shutdown()
+			}
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	cases := []struct {
+		name string
+		addr string
+		err  bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "serve",
+			addr: "127.0.0.1:0",
+		},
+		{
+			name: "bad address",
+			addr: "not an address",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Serve(tc.addr)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Serve() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Serve() failed to return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus reports metrics to a Prometheus registry, registering each metric when first used.
+type Prometheus struct {
+	registry  prometheus.Registerer
+	lock      sync.Mutex
+	counters  map[string]*prometheus.CounterVec
+	durations map[string]*prometheus.HistogramVec
+	gauges    map[string]*prometheus.GaugeVec
+}
+
+// NewPrometheus reports metrics to the registry, such as prometheus.DefaultRegisterer.
+func NewPrometheus(registry prometheus.Registerer) *Prometheus {
+	return &Prometheus{
+		registry:  registry,
+		counters:  map[string]*prometheus.CounterVec{},
+		durations: map[string]*prometheus.HistogramVec{},
+		gauges:    map[string]*prometheus.GaugeVec{},
+	}
+}
+
+// Count adds n to the counter for the field values.
+func (p *Prometheus) Count(m *Metric, n float64, values ...string) {
+	p.counter(m).WithLabelValues(values...).Add(n)
+}
+
+// Observe adds the duration in seconds to the histogram for the field values.
+func (p *Prometheus) Observe(m *Metric, dur time.Duration, values ...string) {
+	p.duration(m).WithLabelValues(values...).Observe(dur.Seconds())
+}
+
+// Set replaces the value of the gauge for the field values.
+func (p *Prometheus) Set(m *Metric, value float64, values ...string) {
+	p.gauge(m).WithLabelValues(values...).Set(value)
+}
+
+func (p *Prometheus) counter(m *Metric) *prometheus.CounterVec {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	vec, ok := p.counters[m.Name]
+	if !ok {
+		vec = prometheus.NewCounterVec(prometheus.CounterOpts{Name: m.Name, Help: m.Help}, m.Fields)
+		p.registry.MustRegister(vec)
+		p.counters[m.Name] = vec
+	}
+	return vec
+}
+
+func (p *Prometheus) duration(m *Metric) *prometheus.HistogramVec {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	vec, ok := p.durations[m.Name]
+	if !ok {
+		vec = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    m.Name,
+			Help:    m.Help,
+			Buckets: prometheus.ExponentialBuckets(bucketScale, bucketGrowth, bucketCount),
+		}, m.Fields)
+		p.registry.MustRegister(vec)
+		p.durations[m.Name] = vec
+	}
+	return vec
+}
+
+func (p *Prometheus) gauge(m *Metric) *prometheus.GaugeVec {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	vec, ok := p.gauges[m.Name]
+	if !ok {
+		vec = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: m.Name, Help: m.Help}, m.Fields)
+		p.registry.MustRegister(vec)
+		p.gauges[m.Name] = vec
+	}
+	return vec
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheus(t *testing.T) {
+	p := NewPrometheus(prometheus.NewRegistry())
+	counter := Metric{Name: "testgrid_test_counter_total", Help: "Counter for tests", Fields: []string{"field"}}
+	duration := Metric{Name: "testgrid_test_duration_seconds", Help: "Duration for tests", Fields: []string{"field"}}
+	p.Count(&counter, 1, "a")
+	p.Count(&counter, 2, "a")
+	p.Count(&counter, 5, "b")
+	p.Observe(&duration, time.Second, "a")
+	p.Observe(&duration, time.Minute, "b")
+
+	cases := map[string]float64{"a": 3, "b": 5, "c": 0}
+	for value, want := range cases {
+		if got := testutil.ToFloat64(p.counters[counter.Name].WithLabelValues(value)); got != want {
+			t.Errorf("Count() got %s=%f, want %f", value, got, want)
+		}
+	}
+	if n := testutil.CollectAndCount(p.durations[duration.Name]); n != 2 {
+		t.Errorf("Observe() got %d series, want 2", n)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Windows over which objectives report their success ratio and burn rate.
+var Windows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+var (
+	objectiveLock sync.RWMutex
+	sloTarget     = 0.99
+	sloFreshness  = 30 * time.Minute
+)
+
+// SetObjectives sets the fraction of items every objective expects to process
+// successfully, which must be less than one, and how recently each item must
+// have succeeded to count as fresh.
+func SetObjectives(target float64, freshness time.Duration) {
+	objectiveLock.Lock()
+	defer objectiveLock.Unlock()
+	sloTarget = target
+	sloFreshness = freshness
+}
+
+func objectives() (float64, time.Duration) {
+	objectiveLock.RLock()
+	defer objectiveLock.RUnlock()
+	return sloTarget, sloFreshness
+}
+
+var (
+	sloSuccess = NewGauge("testgrid_slo_success_ratio", "Fraction of attempts to process an item which succeeded over the window", "component", "window")
+	sloBurn    = NewGauge("testgrid_slo_burn_rate", "Rate the window consumes the error budget, where 1 spends exactly the budget", "component", "window")
+	sloFresh   = NewGauge("testgrid_slo_fresh_ratio", "Fraction of items which succeeded within the freshness objective", "component")
+	sloStale   = NewGauge("testgrid_slo_stale_items", "Number of items which have not succeeded within the freshness objective", "component")
+)
+
+// An Objective tracks how reliably a component processes its items, such as test groups or dashboards.
+type Objective struct {
+	component string
+	now       func() time.Time
+
+	lock    sync.Mutex
+	items   map[string]*objectiveItem
+	minutes []objectiveMinute
+}
+
+type objectiveItem struct {
+	seen    time.Time
+	success time.Time
+}
+
+// objectiveMinute counts the attempts within a minute.
+type objectiveMinute struct {
+	start time.Time
+	good  int
+	bad   int
+}
+
+// NewObjective tracks the items of the component.
+func NewObjective(component string) *Objective {
+	return &Objective{
+		component: component,
+		now:       time.Now,
+		items:     map[string]*objectiveItem{},
+	}
+}
+
+// Record an attempt to process the item, which succeeded unless err is set.
+func (o *Objective) Record(item string, err error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	now := o.now()
+	it := o.item(item, now)
+	minute := now.Truncate(time.Minute)
+	if n := len(o.minutes); n == 0 || o.minutes[n-1].start.Before(minute) {
+		o.minutes = append(o.minutes, objectiveMinute{start: minute})
+	}
+	m := &o.minutes[len(o.minutes)-1]
+	if err != nil {
+		m.bad++
+		return
+	}
+	m.good++
+	it.success = now
+}
+
+// Fresh notes that the item last succeeded at when, such as when processed by another replica.
+func (o *Objective) Fresh(item string, when time.Time) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	it := o.item(item, o.now())
+	if when.After(it.success) {
+		it.success = when
+	}
+}
+
+func (o *Objective) item(name string, now time.Time) *objectiveItem {
+	it, ok := o.items[name]
+	if !ok {
+		it = &objectiveItem{}
+		o.items[name] = it
+	}
+	it.seen = now
+	return it
+}
+
+// objectiveStatus summarizes an objective at some moment.
+type objectiveStatus struct {
+	success []float64 // for each of the Windows
+	fresh   float64
+	stale   int
+}
+
+// status summarizes the objective at now, forgetting attempts and items older than the longest window.
+func (o *Objective) status(now time.Time, freshness time.Duration) objectiveStatus {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	oldest := now.Add(-Windows[len(Windows)-1])
+	for len(o.minutes) > 0 && o.minutes[0].start.Before(oldest) {
+		o.minutes = o.minutes[1:]
+	}
+	var out objectiveStatus
+	for _, w := range Windows {
+		var good, bad int
+		for _, m := range o.minutes {
+			if m.start.Before(now.Add(-w)) {
+				continue
+			}
+			good += m.good
+			bad += m.bad
+		}
+		ratio := 1.0
+		if total := good + bad; total > 0 {
+			ratio = float64(good) / float64(total)
+		}
+		out.success = append(out.success, ratio)
+	}
+	for name, it := range o.items {
+		if it.seen.Before(oldest) {
+			delete(o.items, name) // Such as when removed from the config.
+			continue
+		}
+		since := it.success
+		if since.IsZero() {
+			since = it.seen
+		}
+		if now.Sub(since) > freshness {
+			out.stale++
+		}
+	}
+	out.fresh = 1
+	if n := len(o.items); n > 0 {
+		out.fresh = float64(n-out.stale) / float64(n)
+	}
+	return out
+}
+
+// Report the success ratio and burn rate over each of the Windows, along with how many items are fresh.
+func (o *Objective) Report() {
+	target, freshness := objectives()
+	st := o.status(o.now(), freshness)
+	for i, w := range Windows {
+		window := windowName(w)
+		sloSuccess.Set(st.success[i], o.component, window)
+		sloBurn.Set(burnRate(st.success[i], target), o.component, window)
+	}
+	sloFresh.Set(st.fresh, o.component)
+	sloStale.Set(float64(st.stale), o.component)
+}
+
+// burnRate returns how quickly the success ratio spends the error budget of the target, which must be less than one.
+func burnRate(success, target float64) float64 {
+	return (1 - success) / (1 - target)
+}
+
+// windowName returns the window in hours when possible, such as 6h rather than 6h0m0s.
+func windowName(w time.Duration) string {
+	if w%time.Hour == 0 {
+		return fmt.Sprintf("%dh", w/time.Hour)
+	}
+	return w.String()
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestObjectiveStatus(t *testing.T) {
+	start := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	type attempt struct {
+		item string
+		ago  time.Duration
+		err  bool
+	}
+	cases := []struct {
+		name     string
+		attempts []attempt
+		fresh    map[string]time.Duration
+		want     objectiveStatus
+	}{
+		{
+			name: "empty",
+			want: objectiveStatus{
+				success: []float64{1, 1, 1},
+				fresh:   1,
+			},
+		},
+		{
+			name: "all good",
+			attempts: []attempt{
+				{item: "a", ago: 10 * time.Minute},
+				{item: "b", ago: 5 * time.Minute},
+			},
+			want: objectiveStatus{
+				success: []float64{1, 1, 1},
+				fresh:   1,
+			},
+		},
+		{
+			name: "failures over windows",
+			attempts: []attempt{
+				{item: "a", ago: 12 * time.Hour, err: true},
+				{item: "a", ago: 3 * time.Hour, err: true},
+				{item: "a", ago: 2 * time.Hour},
+				{item: "b", ago: 40 * time.Minute},
+				{item: "b", ago: 10 * time.Minute, err: true},
+			},
+			want: objectiveStatus{
+				success: []float64{0.5, 0.5, 0.4},
+				fresh:   0,
+				stale:   2,
+			},
+		},
+		{
+			name: "fresh from elsewhere",
+			attempts: []attempt{
+				{item: "a", ago: 2 * time.Hour},
+				{item: "b", ago: time.Hour, err: true},
+			},
+			fresh: map[string]time.Duration{
+				"a": 5 * time.Minute,
+				"c": 2 * time.Hour,
+			},
+			want: objectiveStatus{
+				success: []float64{0, 0.5, 0.5},
+				fresh:   1.0 / 3,
+				stale:   2,
+			},
+		},
+		{
+			name: "forget old attempts and items",
+			attempts: []attempt{
+				{item: "gone", ago: 30 * time.Hour, err: true},
+				{item: "a", ago: 5 * time.Minute},
+			},
+			want: objectiveStatus{
+				success: []float64{1, 1, 1},
+				fresh:   1,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := NewObjective("test")
+			for _, a := range tc.attempts {
+				when := start.Add(-a.ago)
+				o.now = func() time.Time { return when }
+				var err error
+				if a.err {
+					err = errors.New("injected")
+				}
+				o.Record(a.item, err)
+			}
+			o.now = func() time.Time { return start }
+			for item, ago := range tc.fresh {
+				o.Fresh(item, start.Add(-ago))
+			}
+			got := o.status(start, 30*time.Minute)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(objectiveStatus{})); diff != "" {
+				t.Errorf("status() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectiveReport(t *testing.T) {
+	fake := newFakeReporter()
+	defer SetReporter(current())
+	SetReporter(fake)
+	defer SetObjectives(objectives())
+	SetObjectives(0.9, time.Hour)
+
+	o := NewObjective("test")
+	o.Record("a", nil)
+	o.Record("b", errors.New("injected"))
+	o.Report()
+	want := map[string]float64{
+		"testgrid_slo_success_ratio/test,1h":  0.5,
+		"testgrid_slo_success_ratio/test,6h":  0.5,
+		"testgrid_slo_success_ratio/test,24h": 0.5,
+		"testgrid_slo_burn_rate/test,1h":      5,
+		"testgrid_slo_burn_rate/test,6h":      5,
+		"testgrid_slo_burn_rate/test,24h":     5,
+		"testgrid_slo_fresh_ratio/test":       1,
+		"testgrid_slo_stale_items/test":       0,
+	}
+	if diff := cmp.Diff(want, fake.gauges, cmp.Comparer(func(a, b float64) bool { return a-b < 1e-9 && b-a < 1e-9 })); diff != "" {
+		t.Errorf("Report() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+)
+
+// StackdriverPrefix begins the type of every metric written to Stackdriver.
+const StackdriverPrefix = "custom.googleapis.com/testgrid/"
+
+// maxSeries is the most time series Stackdriver accepts in each request.
+const maxSeries = 200
+
+// Stackdriver accumulates metrics in memory, periodically writing them to Cloud Monitoring as cumulative time series.
+type Stackdriver struct {
+	project string
+	service *monitoring.Service
+	start   time.Time
+
+	lock   sync.Mutex
+	series map[string]*series
+}
+
+// series accumulates the values of a metric for one set of field values.
+type series struct {
+	metric *Metric
+	values []string
+	total  float64
+	gauge  bool
+	dist   *distribution
+}
+
+// distribution accumulates durations in seconds into exponential buckets.
+type distribution struct {
+	buckets []int64
+	count   int64
+	mean    float64
+	squares float64
+}
+
+func (d *distribution) add(v float64) {
+	i := 0
+	if v >= bucketScale {
+		i = 1 + int(math.Floor(math.Log(v/bucketScale)/math.Log(bucketGrowth)))
+	}
+	if i > len(d.buckets)-1 {
+		i = len(d.buckets) - 1
+	}
+	d.buckets[i]++
+	d.count++
+	delta := v - d.mean
+	d.mean += delta / float64(d.count)
+	d.squares += delta * (v - d.mean)
+}
+
+// NewStackdriver writes metrics to the project, such as with option.WithCredentialsFile.
+func NewStackdriver(ctx context.Context, project string, opts ...option.ClientOption) (*Stackdriver, error) {
+	service, err := monitoring.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Stackdriver{
+		project: project,
+		service: service,
+		start:   time.Now(),
+		series:  map[string]*series{},
+	}, nil
+}
+
+// Count adds n to the counter for the field values.
+func (s *Stackdriver) Count(m *Metric, n float64, values ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.get(m, values).total += n
+}
+
+// Observe adds the duration in seconds to the distribution for the field values.
+func (s *Stackdriver) Observe(m *Metric, dur time.Duration, values ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ser := s.get(m, values)
+	if ser.dist == nil {
+		// An underflow and overflow bucket around the finite ones.
+		ser.dist = &distribution{buckets: make([]int64, bucketCount+1)}
+	}
+	ser.dist.add(dur.Seconds())
+}
+
+// Set replaces the value of the gauge for the field values.
+func (s *Stackdriver) Set(m *Metric, value float64, values ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ser := s.get(m, values)
+	ser.gauge = true
+	ser.total = value
+}
+
+func (s *Stackdriver) get(m *Metric, values []string) *series {
+	key := m.Name + "\x00" + strings.Join(values, "\x00")
+	ser, ok := s.series[key]
+	if !ok {
+		ser = &series{metric: m, values: append([]string(nil), values...)}
+		s.series[key] = ser
+	}
+	return ser
+}
+
+// Run writes the metrics every so often until the context is done.
+func (s *Stackdriver) Run(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				logrus.WithError(err).Warning("Failed to write metrics")
+			}
+		}
+	}
+}
+
+// Flush writes the current value of every time series.
+func (s *Stackdriver) Flush(ctx context.Context) error {
+	all := s.timeSeries(time.Now())
+	for len(all) > 0 {
+		n := len(all)
+		if n > maxSeries {
+			n = maxSeries
+		}
+		req := monitoring.CreateTimeSeriesRequest{TimeSeries: all[:n]}
+		if _, err := s.service.Projects.TimeSeries.Create("projects/"+s.project, &req).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("create time series: %w", err)
+		}
+		all = all[n:]
+	}
+	return nil
+}
+
+// timeSeries returns a point at now for each series, sorted by metric and field values.
+func (s *Stackdriver) timeSeries(now time.Time) []*monitoring.TimeSeries {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := make([]string, 0, len(s.series))
+	for key := range s.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	interval := monitoring.TimeInterval{
+		StartTime: s.start.UTC().Format(time.RFC3339Nano),
+		EndTime:   now.UTC().Format(time.RFC3339Nano),
+	}
+	out := make([]*monitoring.TimeSeries, 0, len(keys))
+	for _, key := range keys {
+		ser := s.series[key]
+		labels := map[string]string{}
+		for i, f := range ser.metric.Fields {
+			if i < len(ser.values) {
+				labels[f] = ser.values[i]
+			}
+		}
+		ts := monitoring.TimeSeries{
+			Metric:     &monitoring.Metric{Type: StackdriverPrefix + ser.metric.Name, Labels: labels},
+			Resource:   &monitoring.MonitoredResource{Type: "global", Labels: map[string]string{"project_id": s.project}},
+			MetricKind: "CUMULATIVE",
+		}
+		interval := interval
+		point := monitoring.Point{Interval: &interval, Value: &monitoring.TypedValue{}}
+		switch d := ser.dist; {
+		case ser.gauge:
+			// Gauges are a value at the end time, without a start.
+			value := ser.total
+			ts.MetricKind = "GAUGE"
+			ts.ValueType = "DOUBLE"
+			interval.StartTime = ""
+			point.Value.DoubleValue = &value
+		case d != nil:
+			ts.ValueType = "DISTRIBUTION"
+			ts.Unit = "s"
+			point.Value.DistributionValue = &monitoring.Distribution{
+				BucketCounts: append([]int64(nil), d.buckets...),
+				BucketOptions: &monitoring.BucketOptions{
+					ExponentialBuckets: &monitoring.Exponential{
+						GrowthFactor:     bucketGrowth,
+						NumFiniteBuckets: bucketCount - 1,
+						Scale:            bucketScale,
+					},
+				},
+				Count:                 d.count,
+				Mean:                  d.mean,
+				SumOfSquaredDeviation: d.squares,
+			}
+		default:
+			total := ser.total
+			ts.ValueType = "DOUBLE"
+			point.Value.DoubleValue = &total
+		}
+		ts.Points = []*monitoring.Point{&point}
+		out = append(out, &ts)
+	}
+	return out
+}
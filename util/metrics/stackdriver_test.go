@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDistribution(t *testing.T) {
+	d := distribution{buckets: make([]int64, bucketCount+1)}
+	for _, v := range []float64{0.001, 0.01, 0.02, 0.05, 1, 1e6} {
+		d.add(v)
+	}
+	want := []int64{1, 2, 1, 0, 1, 0, 0, 0, 0, 0, 1}
+	if diff := cmp.Diff(want, d.buckets); diff != "" {
+		t.Errorf("add() got unexpected bucket diff (-want +got):\n%s", diff)
+	}
+	if d.count != 6 {
+		t.Errorf("add() got count %d, want 6", d.count)
+	}
+}
+
+func TestStackdriver(t *testing.T) {
+	var got []*monitoring.TimeSeries
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var req monitoring.CreateTimeSeriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		got = append(got, req.TimeSeries...)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	sd, err := NewStackdriver(ctx, "my-project", option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewStackdriver() got unexpected error: %v", err)
+	}
+	counter := Metric{Name: "testgrid_cycles_total", Fields: []string{"component"}}
+	duration := Metric{Name: "testgrid_cycle_duration_seconds", Fields: []string{"component"}}
+	sd.Count(&counter, 1, "updater")
+	sd.Count(&counter, 2, "updater")
+	sd.Observe(&duration, time.Second, "updater")
+	gauge := Metric{Name: "testgrid_ratio", Fields: []string{"component"}}
+	sd.Set(&gauge, 0.25, "updater")
+	sd.Set(&gauge, 0.5, "updater")
+	if err := sd.Flush(ctx); err != nil {
+		t.Fatalf("Flush() got unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"/v3/projects/my-project/timeSeries"}, paths); diff != "" {
+		t.Errorf("Flush() got unexpected path diff (-want +got):\n%s", diff)
+	}
+	for _, ts := range got {
+		ts.Points[0].Interval = nil
+	}
+	three := 3.0
+	half := 0.5
+	resource := &monitoring.MonitoredResource{Type: "global", Labels: map[string]string{"project_id": "my-project"}}
+	want := []*monitoring.TimeSeries{
+		{
+			Metric:     &monitoring.Metric{Type: StackdriverPrefix + "testgrid_cycle_duration_seconds", Labels: map[string]string{"component": "updater"}},
+			Resource:   resource,
+			MetricKind: "CUMULATIVE",
+			ValueType:  "DISTRIBUTION",
+			Unit:       "s",
+			Points: []*monitoring.Point{
+				{
+					Value: &monitoring.TypedValue{
+						DistributionValue: &monitoring.Distribution{
+							BucketCounts: []int64{0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+							BucketOptions: &monitoring.BucketOptions{
+								ExponentialBuckets: &monitoring.Exponential{
+									GrowthFactor:     bucketGrowth,
+									NumFiniteBuckets: bucketCount - 1,
+									Scale:            bucketScale,
+								},
+							},
+							Count: 1,
+							Mean:  1,
+						},
+					},
+				},
+			},
+		},
+		{
+			Metric:     &monitoring.Metric{Type: StackdriverPrefix + "testgrid_cycles_total", Labels: map[string]string{"component": "updater"}},
+			Resource:   resource,
+			MetricKind: "CUMULATIVE",
+			ValueType:  "DOUBLE",
+			Points: []*monitoring.Point{
+				{
+					Value: &monitoring.TypedValue{DoubleValue: &three},
+				},
+			},
+		},
+		{
+			Metric:     &monitoring.Metric{Type: StackdriverPrefix + "testgrid_ratio", Labels: map[string]string{"component": "updater"}},
+			Resource:   resource,
+			MetricKind: "GAUGE",
+			ValueType:  "DOUBLE",
+			Points: []*monitoring.Point{
+				{
+					Value: &monitoring.TypedValue{DoubleValue: &half},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Flush() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
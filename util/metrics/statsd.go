@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Statsd sends metrics to a statsd server over UDP.
+//
+// Statsd has no labels, so appends the field values to the name of each metric,
+// such as testgrid.testgrid_cycles_total.updater for the updater component.
+type Statsd struct {
+	conn   net.Conn
+	prefix string
+}
+
+// NewStatsd sends metrics to the host:port address, prefixing their names with prefix if set.
+func NewStatsd(addr, prefix string) (*Statsd, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Statsd{conn: conn, prefix: prefix}, nil
+}
+
+// Close stops sending metrics.
+func (s *Statsd) Close() error {
+	return s.conn.Close()
+}
+
+// Count sends n for the counter of the field values.
+func (s *Statsd) Count(m *Metric, n float64, values ...string) {
+	s.send(fmt.Sprintf("%s:%g|c", s.name(m, values), n))
+}
+
+// Observe sends the duration in milliseconds for the timer of the field values.
+func (s *Statsd) Observe(m *Metric, dur time.Duration, values ...string) {
+	s.send(fmt.Sprintf("%s:%g|ms", s.name(m, values), float64(dur)/float64(time.Millisecond)))
+}
+
+// Set sends the value of the gauge of the field values.
+func (s *Statsd) Set(m *Metric, value float64, values ...string) {
+	s.send(fmt.Sprintf("%s:%g|g", s.name(m, values), value))
+}
+
+// statsdUnsafe matches the characters statsd does not allow in names.
+var statsdUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+func (s *Statsd) name(m *Metric, values []string) string {
+	parts := make([]string, 0, len(values)+2)
+	if s.prefix != "" {
+		parts = append(parts, s.prefix)
+	}
+	parts = append(parts, m.Name)
+	for _, v := range values {
+		parts = append(parts, statsdUnsafe.ReplaceAllString(v, "_"))
+	}
+	return strings.Join(parts, ".")
+}
+
+func (s *Statsd) send(line string) {
+	if _, err := s.conn.Write([]byte(line)); err != nil {
+		logrus.WithError(err).WithField("metric", line).Debug("Failed to send statsd metric")
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestStatsd(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	cases := []struct {
+		name   string
+		prefix string
+		send   func(*Statsd)
+		want   string
+	}{
+		{
+			name:   "count",
+			prefix: "testgrid",
+			send: func(s *Statsd) {
+				s.Count(&Metric{Name: "cycles_total"}, 2, "updater")
+			},
+			want: "testgrid.cycles_total.updater:2|c",
+		},
+		{
+			name: "duration",
+			send: func(s *Statsd) {
+				s.Observe(&Metric{Name: "op_seconds"}, 1500*time.Microsecond, "read")
+			},
+			want: "op_seconds.read:1.5|ms",
+		},
+		{
+			name: "gauge",
+			send: func(s *Statsd) {
+				s.Set(&Metric{Name: "ratio"}, 0.5, "updater")
+			},
+			want: "ratio.updater:0.5|g",
+		},
+		{
+			name: "unsafe values",
+			send: func(s *Statsd) {
+				s.Count(&Metric{Name: "total"}, 1, "gs://bucket/path", "a.b")
+			},
+			want: "total.gs___bucket_path.a_b:1|c",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewStatsd(conn.LocalAddr().String(), tc.prefix)
+			if err != nil {
+				t.Fatalf("NewStatsd() got unexpected error: %v", err)
+			}
+			defer s.Close()
+			tc.send(s)
+			buf := make([]byte, 1024)
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				t.Fatalf("ReadFrom() got unexpected error: %v", err)
+			}
+			if got := string(buf[:n]); got != tc.want {
+				t.Errorf("sent %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
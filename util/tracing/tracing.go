@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing records OpenTelemetry spans about the work of each binary, exporting them to an OTLP collector.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// instrumentation names the tracer of every span.
+const instrumentation = "github.com/GoogleCloudPlatform/testgrid"
+
+// Setup exports the spans of the service to the OTLP gRPC collector at the host:port endpoint.
+//
+// Returns a function which flushes any remaining spans, which callers should
+// run before exiting. Records nothing when endpoint is empty.
+func Setup(ctx context.Context, service, endpoint string) (func(), error) {
+	if endpoint == "" {
+		return func() {}, nil
+	}
+	exp, err := otlp.NewExporter(otlp.WithInsecure(), otlp.WithAddress(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	bsp := sdktrace.NewBatchSpanProcessor(exp)
+	global.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(bsp),
+		sdktrace.WithResource(resource.New(semconv.ServiceNameKey.String(service))),
+	))
+	return func() {
+		bsp.Shutdown()
+		if err := exp.Shutdown(ctx); err != nil {
+			global.Handle(err)
+		}
+	}, nil
+}
+
+// Start begins a span, named after the operation, as a child of any span in the context.
+func Start(ctx context.Context, name string, labels ...label.KeyValue) (context.Context, trace.Span) {
+	return global.Tracer(instrumentation).Start(ctx, name, trace.WithAttributes(labels...))
+}
+
+// End ends the span, marking it as an error when err is set.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(context.Background(), err, trace.WithErrorStatus(codes.Error))
+	}
+	span.End()
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type fakeExporter struct {
+	spans []*export.SpanData
+}
+
+func (e *fakeExporter) ExportSpans(_ context.Context, spans []*export.SpanData) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *fakeExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "test", "")
+	if err != nil {
+		t.Fatalf("Setup() got unexpected error: %v", err)
+	}
+	shutdown()
+}
+
+func TestEnd(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{
+			name: "ok",
+			want: codes.Unset,
+		},
+		{
+			name: "error",
+			err:  errors.New("injected"),
+			want: codes.Error,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var exp fakeExporter
+			global.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(&exp)))
+			ctx, parent := Start(context.Background(), "parent")
+			_, span := Start(ctx, "child", label.String("key", "value"))
+			End(span, tc.err)
+			End(parent, nil)
+			if len(exp.spans) != 2 {
+				t.Fatalf("End() exported %d spans, want 2", len(exp.spans))
+			}
+			child := exp.spans[0]
+			if got := child.StatusCode; got != tc.want {
+				t.Errorf("End() got status %v, want %v", got, tc.want)
+			}
+			if got, want := child.ParentSpanID, exp.spans[1].SpanContext.SpanID; got != want {
+				t.Errorf("Start() got parent %v, want %v", got, want)
+			}
+			if n := len(child.Attributes); n != 1 {
+				t.Errorf("Start() got %d attributes, want 1", n)
+			}
+		})
+	}
+}